@@ -0,0 +1,132 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compiler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// cacheFile is the name of the manifest incremental compilation keeps in the
+// output directory.
+const cacheFile = ".frugal-cache.json"
+
+// buildCache is the manifest of the source hash each Frugal file was last
+// generated from, per language, since languages may share an output
+// directory. A file's hash covers its contents, the hashes of its includes,
+// and the compiler settings, so a file is regenerated if it, any file it
+// includes, or the settings change. The manifest is discarded when the
+// compiler version changes.
+type buildCache struct {
+	FrugalVersion string                       `json:"frugal_version"`
+	Languages     map[string]map[string]string `json:"languages"`
+
+	path     string
+	lang     string
+	settings string
+	hashes   map[*parser.Frugal]string
+}
+
+// loadBuildCache reads the manifest in the output directory, starting an
+// empty one if there is none or it was written by another compiler version.
+// The settings describe the compiler options which affect the language's
+// generated code.
+func loadBuildCache(outputDir, lang, settings string) (*buildCache, error) {
+	cache := &buildCache{
+		FrugalVersion: globals.Version,
+		Languages:     make(map[string]map[string]string),
+		path:          filepath.Join(outputDir, cacheFile),
+		lang:          lang,
+		settings:      settings,
+		hashes:        make(map[*parser.Frugal]string),
+	}
+	contents, err := ioutil.ReadFile(cache.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		var previous buildCache
+		if err := json.Unmarshal(contents, &previous); err != nil {
+			return nil, err
+		}
+		if previous.FrugalVersion == globals.Version && previous.Languages != nil {
+			cache.Languages = previous.Languages
+		}
+	}
+	if cache.Languages[lang] == nil {
+		cache.Languages[lang] = make(map[string]string)
+	}
+	return cache, nil
+}
+
+// unchanged returns true if the file was last generated from the same
+// source hash.
+func (c *buildCache) unchanged(f *parser.Frugal) (bool, error) {
+	hash, err := c.hash(f)
+	if err != nil {
+		return false, err
+	}
+	return c.Languages[c.lang][f.File] == hash, nil
+}
+
+// record stores the source hash the file was generated from.
+func (c *buildCache) record(f *parser.Frugal) error {
+	hash, err := c.hash(f)
+	if err != nil {
+		return err
+	}
+	c.Languages[c.lang][f.File] = hash
+	return nil
+}
+
+// save writes the manifest to the output directory.
+func (c *buildCache) save() error {
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path, append(contents, '\n'), 0644)
+}
+
+// hash returns the source hash of the file.
+func (c *buildCache) hash(f *parser.Frugal) (string, error) {
+	if hash, ok := c.hashes[f]; ok {
+		return hash, nil
+	}
+	contents, err := ioutil.ReadFile(f.File)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(c.settings + "\n"))
+	h.Write(contents)
+	for _, include := range f.OrderedIncludes() {
+		includeHash, err := c.hash(f.ParsedIncludes[include.Name])
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte("\n" + include.Name + " " + includeHash))
+	}
+	hash := hex.EncodeToString(h.Sum(nil))
+	c.hashes[f] = hash
+	return hash, nil
+}
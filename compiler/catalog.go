@@ -0,0 +1,321 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package compiler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// eventCatalog is a machine-readable description of the scopes of a Frugal
+// file for developer portals. Schemas describes every type the payloads use,
+// keyed by name, with types from other files qualified by the file name.
+// Services are listed with their ownership so consumers know who to contact.
+type eventCatalog struct {
+	IDLFile       string                    `json:"idl_file"`
+	FrugalVersion string                    `json:"frugal_version"`
+	Scopes        []*catalogScope           `json:"scopes"`
+	Services      []*catalogService         `json:"services,omitempty"`
+	Schemas       map[string]*catalogSchema `json:"schemas"`
+}
+
+type catalogScope struct {
+	Name        string              `json:"name"`
+	Version     int                 `json:"version,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Owners      []string            `json:"owners,omitempty"`
+	Slack       string              `json:"slack,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Prefix      string              `json:"prefix"`
+	Variables   []string            `json:"variables"`
+	Operations  []*catalogOperation `json:"operations"`
+}
+
+type catalogOperation struct {
+	Name              string   `json:"name"`
+	Description       string   `json:"description,omitempty"`
+	Topic             string   `json:"topic"`
+	Payload           string   `json:"payload"`
+	Reply             string   `json:"reply,omitempty"`
+	Owners            []string `json:"owners,omitempty"`
+	Slack             string   `json:"slack,omitempty"`
+	PublishRoles      []string `json:"publish_roles,omitempty"`
+	SubscribeRoles    []string `json:"subscribe_roles,omitempty"`
+	Replayable        bool     `json:"replayable,omitempty"`
+	Deprecated        bool     `json:"deprecated,omitempty"`
+	DeprecationReason string   `json:"deprecation_reason,omitempty"`
+}
+
+type catalogService struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Owners      []string `json:"owners,omitempty"`
+	Slack       string   `json:"slack,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Methods     []string `json:"methods"`
+}
+
+// catalogSchema describes a struct, union, exception, enum, or typedef.
+type catalogSchema struct {
+	Kind        string              `json:"kind"`
+	Description string              `json:"description,omitempty"`
+	Type        string              `json:"type,omitempty"`
+	Fields      []*catalogField     `json:"fields,omitempty"`
+	Values      []*catalogEnumValue `json:"values,omitempty"`
+}
+
+type catalogField struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	Type              string `json:"type"`
+	Requiredness      string `json:"requiredness"`
+	Description       string `json:"description,omitempty"`
+	PII               bool   `json:"pii,omitempty"`
+	Deprecated        bool   `json:"deprecated,omitempty"`
+	DeprecationReason string `json:"deprecation_reason,omitempty"`
+}
+
+type catalogEnumValue struct {
+	Name        string `json:"name"`
+	Value       int    `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// writeEventCatalog writes the event catalog of the file's scopes and
+// services to <name>.events.json in the output directory. Nothing is written
+// for files without scopes or services.
+func writeEventCatalog(f *parser.Frugal, outputDir string) error {
+	if len(f.Scopes) == 0 && len(f.Services) == 0 {
+		return nil
+	}
+	catalog, err := newEventCatalog(f)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(catalog); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(outputDir, f.Name+".events.json"), buf.Bytes(), 0644)
+}
+
+func newEventCatalog(f *parser.Frugal) (*eventCatalog, error) {
+	catalog := &eventCatalog{
+		IDLFile:       filepath.Base(f.File),
+		FrugalVersion: globals.Version,
+		Scopes:        []*catalogScope{},
+		Schemas:       make(map[string]*catalogSchema),
+	}
+	for _, scope := range f.Scopes {
+		owners, _ := scope.Annotations.Owners()
+		slack, _ := scope.Annotations.Slack()
+		s := &catalogScope{
+			Name:        scope.Name,
+			Version:     scope.Version,
+			Description: strings.Join(scope.Comment, "\n"),
+			Owners:      owners,
+			Slack:       slack,
+			Tags:        scope.Annotations.Tags(),
+			Prefix:      scope.Prefix.String,
+			Variables:   scope.Prefix.Variables,
+			Operations:  []*catalogOperation{},
+		}
+		if s.Variables == nil {
+			s.Variables = []string{}
+		}
+		for _, op := range scope.Operations {
+			reason, deprecated := op.Annotations.Deprecated()
+			o := &catalogOperation{
+				Name:              op.Name,
+				Description:       strings.Join(op.Comment, "\n"),
+				Topic:             catalogTopic(scope, op),
+				Payload:           qualifiedType(f, f, op.Type),
+				Owners:            op.Owners(),
+				Slack:             op.Slack(),
+				PublishRoles:      op.PublishRoles(),
+				SubscribeRoles:    op.SubscribeRoles(),
+				Replayable:        op.Annotations.IsReplayable(),
+				Deprecated:        deprecated,
+				DeprecationReason: reason,
+			}
+			if err := addCatalogSchemas(catalog, f, f, op.Type); err != nil {
+				return nil, err
+			}
+			if reply := op.ReplyType(); reply != nil {
+				o.Reply = qualifiedType(f, f, reply)
+				if err := addCatalogSchemas(catalog, f, f, reply); err != nil {
+					return nil, err
+				}
+			}
+			s.Operations = append(s.Operations, o)
+		}
+		catalog.Scopes = append(catalog.Scopes, s)
+	}
+	for _, service := range f.Services {
+		owners, _ := service.Annotations.Owners()
+		slack, _ := service.Annotations.Slack()
+		s := &catalogService{
+			Name:        service.Name,
+			Description: strings.Join(service.Comment, "\n"),
+			Owners:      owners,
+			Slack:       slack,
+			Tags:        service.Annotations.Tags(),
+			Methods:     []string{},
+		}
+		for _, method := range service.Methods {
+			s.Methods = append(s.Methods, method.Name)
+		}
+		catalog.Services = append(catalog.Services, s)
+	}
+	return catalog, nil
+}
+
+// catalogTopic returns the topic of the operation with prefix variables left
+// as {variable}.
+func catalogTopic(scope *parser.Scope, op *parser.Operation) string {
+	tokens := []string{}
+	if scope.Prefix.String != "" {
+		tokens = append(tokens, strings.Split(scope.Prefix.String, ".")...)
+	}
+	tokens = append(tokens, scope.TopicName(scope.Name, globals.TopicDelimiter), op.Name)
+	return strings.Join(tokens, globals.TopicDelimiter)
+}
+
+// qualifiedType returns the IDL spelling of the type, which is referenced
+// from the given file, with custom types qualified as in the catalog schemas.
+func qualifiedType(root, f *parser.Frugal, t *parser.Type) string {
+	switch {
+	case t.Name == "map":
+		return fmt.Sprintf("map<%s,%s>", qualifiedType(root, f, t.KeyType), qualifiedType(root, f, t.ValueType))
+	case t.IsContainer():
+		return fmt.Sprintf("%s<%s>", t.Name, qualifiedType(root, f, t.ValueType))
+	case t.IsPrimitive():
+		return t.Name
+	}
+	declaring, name := declaringFile(f, t)
+	return qualifiedName(root, declaring, name)
+}
+
+// declaringFile returns the file declaring the custom type, which is
+// referenced from the given file, and the type's name in that file.
+func declaringFile(f *parser.Frugal, t *parser.Type) (*parser.Frugal, string) {
+	if include := t.IncludeName(); include != "" {
+		return f.ParsedIncludes[include], t.ParamName()
+	}
+	return f, t.Name
+}
+
+// qualifiedName returns the name of a definition in the given file, which is
+// qualified by the file's name unless it is the root file.
+func qualifiedName(root, f *parser.Frugal, name string) string {
+	if f == root {
+		return name
+	}
+	return f.Name + "." + name
+}
+
+// addCatalogSchemas adds the schemas of the custom types used by the type,
+// which is referenced from the given file.
+func addCatalogSchemas(catalog *eventCatalog, root, f *parser.Frugal, t *parser.Type) error {
+	if t.IsContainer() {
+		if t.KeyType != nil {
+			if err := addCatalogSchemas(catalog, root, f, t.KeyType); err != nil {
+				return err
+			}
+		}
+		return addCatalogSchemas(catalog, root, f, t.ValueType)
+	}
+	if t.IsPrimitive() {
+		return nil
+	}
+
+	declaring, name := declaringFile(f, t)
+	if declaring == nil {
+		return fmt.Errorf("Unknown include for type %s", t.Name)
+	}
+	qualified := qualifiedName(root, declaring, name)
+	if _, ok := catalog.Schemas[qualified]; ok {
+		return nil
+	}
+
+	for _, typedef := range declaring.Typedefs {
+		if typedef.Name == name {
+			catalog.Schemas[qualified] = &catalogSchema{
+				Kind:        "typedef",
+				Description: strings.Join(typedef.Comment, "\n"),
+				Type:        qualifiedType(root, declaring, typedef.Type),
+			}
+			return addCatalogSchemas(catalog, root, declaring, typedef.Type)
+		}
+	}
+	for _, enum := range declaring.Enums {
+		if enum.Name == name {
+			schema := &catalogSchema{Kind: "enum", Description: strings.Join(enum.Comment, "\n")}
+			for _, value := range enum.Values {
+				schema.Values = append(schema.Values, &catalogEnumValue{
+					Name:        value.Name,
+					Value:       value.Value,
+					Description: strings.Join(value.Comment, "\n"),
+				})
+			}
+			catalog.Schemas[qualified] = schema
+			return nil
+		}
+	}
+	s := findCatalogStruct(declaring, name)
+	if s == nil {
+		return fmt.Errorf("Unknown type %s", t.Name)
+	}
+	schema := &catalogSchema{Kind: s.Type.String(), Description: strings.Join(s.Comment, "\n")}
+	catalog.Schemas[qualified] = schema
+	for _, field := range s.Fields {
+		reason, deprecated := field.Annotations.Deprecated()
+		schema.Fields = append(schema.Fields, &catalogField{
+			ID:                field.ID,
+			Name:              field.Name,
+			Type:              qualifiedType(root, declaring, field.Type),
+			Requiredness:      strings.ToLower(field.Modifier.String()),
+			Description:       strings.Join(field.Comment, "\n"),
+			PII:               field.Annotations.IsPII(),
+			Deprecated:        deprecated,
+			DeprecationReason: reason,
+		})
+		if err := addCatalogSchemas(catalog, root, declaring, field.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findCatalogStruct returns the struct, union, or exception with the given
+// name declared in the file, or nil if there is none.
+func findCatalogStruct(f *parser.Frugal, name string) *parser.Struct {
+	for _, structs := range [][]*parser.Struct{f.Structs, f.Unions, f.Exceptions} {
+		for _, s := range structs {
+			if s.Name == name {
+				return s
+			}
+		}
+	}
+	return nil
+}
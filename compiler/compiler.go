@@ -20,11 +20,6 @@ import (
 	"strings"
 
 	"github.com/Workiva/frugal/compiler/generator"
-	"github.com/Workiva/frugal/compiler/generator/dartlang"
-	"github.com/Workiva/frugal/compiler/generator/golang"
-	"github.com/Workiva/frugal/compiler/generator/html"
-	"github.com/Workiva/frugal/compiler/generator/java"
-	"github.com/Workiva/frugal/compiler/generator/python"
 	"github.com/Workiva/frugal/compiler/globals"
 	"github.com/Workiva/frugal/compiler/parser"
 )
@@ -38,6 +33,54 @@ type Options struct {
 	DryRun  bool   // Do not generate code
 	Recurse bool   // Generate includes
 	Verbose bool   // Verbose mode
+
+	// EventCatalog writes a JSON catalog of the scopes of each generated
+	// file to the output directory alongside the generated code.
+	EventCatalog bool
+
+	// TestVectors writes the encoding of a deterministic instance of each
+	// struct with each protocol to the output directory and generates tests
+	// decoding them, where the language supports it.
+	TestVectors bool
+
+	// Tracing generates publishers and subscribers starting distributed
+	// tracing spans. It's only supported for Go.
+	Tracing bool
+
+	// Incremental skips regenerating files which, along with their
+	// includes and the options affecting generated code, are unchanged since
+	// they were last generated to the output location, as recorded in a
+	// .frugal-cache.json manifest there.
+	Incremental bool
+
+	// Strict fails compilation if generation reports any warnings, e.g.
+	// about features a language doesn't support.
+	Strict bool
+
+	// NamespacePolicy is what happens when a file being generated or one
+	// of its includes has no namespace for the language: NamespaceFallback,
+	// the default, NamespaceWarn, or NamespaceError.
+	NamespacePolicy string
+
+	// IncludeMap remaps the namespaces of includes at generation time with
+	// entries of the form [<lang>:]<include>:<namespace>, so generated
+	// dependencies can be relocated without editing shared IDL.
+	IncludeMap []string
+
+	// Namespaces overrides the namespaces declared by the file being
+	// generated with entries of the form <lang>=<namespace>, so the same IDL
+	// can be generated into differently named packages without editing it.
+	Namespaces []string
+
+	// Only and Exclude select the scopes and services to generate from the
+	// given file by name or, when prefixed with "tag:", by a tag in their
+	// "tags" annotation. Types are always generated.
+	Only    []string // Generate only matching scopes and services
+	Exclude []string // Don't generate matching scopes and services
+
+	// sandbox is the directory code must be generated within, if set, when
+	// generating to a temporary directory.
+	sandbox string
 }
 
 // Compile parses the Frugal IDL and generates code for it, returning an error
@@ -51,6 +94,11 @@ func Compile(options Options) error {
 	globals.DryRun = options.DryRun
 	globals.Recurse = options.Recurse
 	globals.Verbose = options.Verbose
+	globals.EventCatalog = options.EventCatalog
+	globals.TestVectors = options.TestVectors
+	globals.Tracing = options.Tracing
+	globals.Strict = options.Strict
+	globals.Sandbox = options.sandbox
 	globals.FileDir = filepath.Dir(options.File)
 
 	absFile, err := filepath.Abs(options.File)
@@ -63,20 +111,138 @@ func Compile(options Options) error {
 		return err
 	}
 
-	return generateFrugal(frugal)
+	if err := filterDefinitions(frugal, options.Only, options.Exclude); err != nil {
+		return err
+	}
+
+	err = generateFrugal(frugal, options)
+	if warnErr := printWarnings(); err == nil {
+		err = warnErr
+	}
+	return err
+}
+
+// printWarnings prints the distinct warnings reported during generation,
+// returning an error if there are any in strict mode.
+func printWarnings() error {
+	seen := make(map[string]bool)
+	warnings := []string{}
+	for _, warning := range globals.Warnings {
+		if !seen[warning] {
+			seen[warning] = true
+			warnings = append(warnings, warning)
+			globals.PrintWarning(warning)
+		}
+	}
+	if globals.Strict && len(warnings) > 0 {
+		return fmt.Errorf("Generation reported %d warning(s) in strict mode:\n\t%s",
+			len(warnings), strings.Join(warnings, "\n\t"))
+	}
+	return nil
+}
+
+// filterDefinitions removes the scopes and services which don't match the only
+// filters, if any, or which match the exclude filters. Services extended by a
+// selected service in the same file are always kept.
+func filterDefinitions(f *parser.Frugal, only, exclude []string) error {
+	if len(only) == 0 && len(exclude) == 0 {
+		return nil
+	}
+
+	matched := make(map[string]bool)
+	selected := func(name string, annotations parser.Annotations) bool {
+		keep := len(only) == 0
+		for _, filter := range only {
+			if matchesFilter(filter, name, annotations) {
+				matched[filter] = true
+				keep = true
+			}
+		}
+		for _, filter := range exclude {
+			if matchesFilter(filter, name, annotations) {
+				keep = false
+			}
+		}
+		return keep
+	}
+
+	scopes := []*parser.Scope{}
+	for _, scope := range f.Scopes {
+		if selected(scope.Name, scope.Annotations) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	keep := make(map[string]bool)
+	for _, service := range f.Services {
+		if !selected(service.Name, service.Annotations) {
+			continue
+		}
+		for s := service; s != nil && !keep[s.Name]; s = localParent(f, s) {
+			keep[s.Name] = true
+		}
+	}
+	services := []*parser.Service{}
+	for _, service := range f.Services {
+		if keep[service.Name] {
+			services = append(services, service)
+		}
+	}
+
+	for _, filter := range only {
+		if !matched[filter] {
+			return fmt.Errorf("No scope or service matches %s", filter)
+		}
+	}
+
+	f.Scopes = scopes
+	f.Services = services
+	return nil
+}
+
+// matchesFilter returns true if the scope or service with the given name and
+// annotations matches the filter, which is either a name or "tag:" followed by
+// one of its tags.
+func matchesFilter(filter, name string, annotations parser.Annotations) bool {
+	if !strings.HasPrefix(filter, "tag:") {
+		return filter == name
+	}
+	tag := strings.TrimPrefix(filter, "tag:")
+	for _, t := range annotations.Tags() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// localParent returns the service in the same file which the given service
+// extends, or nil if there is none.
+func localParent(f *parser.Frugal, service *parser.Service) *parser.Service {
+	if service.Extends == "" || service.ExtendsInclude() != "" {
+		return nil
+	}
+	for _, s := range f.Services {
+		if s.Name == service.Extends {
+			return s
+		}
+	}
+	return nil
 }
 
-// parseFrugal parses a frugal file.
+// parseFrugal parses a frugal file, evaluating its conditional blocks for the
+// language being generated.
 func parseFrugal(file string) (*parser.Frugal, error) {
 	if !exists(file) {
 		return nil, fmt.Errorf("Frugal file not found: %s\n", file)
 	}
 	logv(fmt.Sprintf("Parsing %s", file))
-	return parser.ParseFrugal(file)
+	lang := strings.SplitN(globals.Gen, ":", 2)[0]
+	return parser.ParseFrugalForLang(file, lang)
 }
 
 // generateFrugal generates code for a frugal struct.
-func generateFrugal(f *parser.Frugal) error {
+func generateFrugal(f *parser.Frugal, compilerOptions Options) error {
 	var gen = globals.Gen
 
 	lang, options, err := cleanGenParam(gen)
@@ -84,23 +250,62 @@ func generateFrugal(f *parser.Frugal) error {
 		return err
 	}
 
+	if compilerOptions.Tracing && lang != "go" {
+		return fmt.Errorf("tracing is not supported for %s", lang)
+	}
+
+	if err := overrideNamespace(f, lang, compilerOptions.Namespaces); err != nil {
+		return err
+	}
+
+	if err := remapIncludes(f, lang, compilerOptions.IncludeMap); err != nil {
+		return err
+	}
+
+	if err := checkNamespaces(f, lang, compilerOptions.NamespacePolicy); err != nil {
+		return err
+	}
+
 	// Resolve Frugal generator.
 	g, err := getProgramGenerator(lang, options)
 	if err != nil {
 		return err
 	}
 
+	var cache *buildCache
+	if compilerOptions.Incremental && !globals.DryRun {
+		out := globals.Out
+		if out == "" {
+			out = g.DefaultOutputDir()
+		}
+		if cache, err = loadBuildCache(out, lang, cacheSettings(compilerOptions)); err != nil {
+			return err
+		}
+	}
+
 	// The parsed frugal contains everything needed to generate
-	if err := generateFrugalRec(f, g, true, lang); err != nil {
+	if err := generateFrugalRec(f, g, true, lang, cache); err != nil {
 		return err
 	}
 
+	if cache != nil {
+		return cache.save()
+	}
 	return nil
 }
 
+// cacheSettings describes the options which affect generated code, so
+// changing them invalidates the incremental compilation manifest.
+func cacheSettings(options Options) string {
+	return fmt.Sprintf("gen=%s delim=%s recurse=%t only=%s exclude=%s include_map=%s namespaces=%s event_catalog=%t test_vectors=%t tracing=%t",
+		options.Gen, options.Delim, options.Recurse, strings.Join(options.Only, ","),
+		strings.Join(options.Exclude, ","), strings.Join(options.IncludeMap, ","),
+		strings.Join(options.Namespaces, ","), options.EventCatalog, options.TestVectors, options.Tracing)
+}
+
 // generateFrugalRec generates code for a frugal struct, recursively generating
 // code for includes
-func generateFrugalRec(f *parser.Frugal, g generator.ProgramGenerator, generate bool, lang string) error {
+func generateFrugalRec(f *parser.Frugal, g generator.ProgramGenerator, generate bool, lang string, cache *buildCache) error {
 	if _, ok := globals.CompiledFiles[f.File]; ok {
 		// Already generated this file
 		return nil
@@ -112,17 +317,35 @@ func generateFrugalRec(f *parser.Frugal, g generator.ProgramGenerator, generate
 		out = g.DefaultOutputDir()
 	}
 	fullOut := g.GetOutputDir(out, f)
-	if err := os.MkdirAll(out, 0777); err != nil {
+	if err := globals.CheckOutputDir(fullOut); err != nil {
 		return err
 	}
+	if !globals.DryRun {
+		if err := os.MkdirAll(out, 0777); err != nil {
+			return err
+		}
+	}
 
 	logv(fmt.Sprintf("Generating \"%s\" Frugal code for %s", lang, f.File))
 	if globals.DryRun || !generate {
 		return nil
 	}
 
-	if err := g.Generate(f, fullOut); err != nil {
+	if cache == nil {
+		if err := generateFile(f, g, out, fullOut); err != nil {
+			return err
+		}
+	} else if unchanged, err := cache.unchanged(f); err != nil {
 		return err
+	} else if unchanged {
+		logv(fmt.Sprintf("Skipping unchanged %s", f.File))
+	} else {
+		if err := generateFile(f, g, out, fullOut); err != nil {
+			return err
+		}
+		if err := cache.record(f); err != nil {
+			return err
+		}
 	}
 
 	// Iterate through includes in order to ensure determinism in
@@ -133,7 +356,29 @@ func generateFrugalRec(f *parser.Frugal, g generator.ProgramGenerator, generate
 			continue
 		}
 		inclFrugal := f.ParsedIncludes[include.Name]
-		if err := generateFrugalRec(inclFrugal, g, globals.Recurse, lang); err != nil {
+		if err := generateFrugalRec(inclFrugal, g, globals.Recurse, lang, cache); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateFile generates code for the frugal struct to fullOut along with the
+// requested artifacts written to the output location.
+func generateFile(f *parser.Frugal, g generator.ProgramGenerator, out, fullOut string) error {
+	if err := g.Generate(f, fullOut); err != nil {
+		return err
+	}
+
+	if globals.EventCatalog {
+		if err := writeEventCatalog(f, out); err != nil {
+			return err
+		}
+	}
+
+	if globals.TestVectors {
+		if err := writeTestVectors(f, out); err != nil {
 			return err
 		}
 	}
@@ -144,26 +389,8 @@ func generateFrugalRec(f *parser.Frugal, g generator.ProgramGenerator, generate
 // getProgramGenerator resolves the ProgramGenerator for the given language. It
 // returns an error if the language is not supported.
 func getProgramGenerator(lang string, options map[string]string) (generator.ProgramGenerator, error) {
-	var g generator.ProgramGenerator
-	switch lang {
-	case "dart":
-		g = generator.NewProgramGenerator(dartlang.NewGenerator(options), false)
-	case "go":
-		// Make sure the package prefix ends with a "/"
-		if package_prefix, ok := options["package_prefix"]; ok {
-			if package_prefix != "" && !strings.HasSuffix(package_prefix, "/") {
-				options["package_prefix"] = package_prefix + "/"
-			}
-		}
-
-		g = generator.NewProgramGenerator(golang.NewGenerator(options), false)
-	case "java":
-		g = generator.NewProgramGenerator(java.NewGenerator(options), true)
-	case "py":
-		g = generator.NewProgramGenerator(python.NewGenerator(options), true)
-	case "html":
-		g = html.NewGenerator(options)
-	default:
+	g, ok := generator.New(lang, options)
+	if !ok {
 		return nil, fmt.Errorf("Invalid gen value %s", lang)
 	}
 	return g, nil
@@ -177,11 +404,12 @@ func exists(path string) bool {
 
 // cleanGenParam processes a string that includes an optional trailing
 // options set.  Format: <language>:<name>=<value>,<name>=<value>,...
+// Values may contain ":" and "=", e.g. URLs, but not ",".
 func cleanGenParam(gen string) (lang string, options map[string]string, err error) {
 	lang = gen
 	options = make(map[string]string)
 	if strings.Contains(gen, ":") {
-		s := strings.Split(gen, ":")
+		s := strings.SplitN(gen, ":", 2)
 		lang = s[0]
 		dirty := s[1]
 		var optionArray []string
@@ -191,7 +419,7 @@ func cleanGenParam(gen string) (lang string, options map[string]string, err erro
 			optionArray = append(optionArray, dirty)
 		}
 		for _, option := range optionArray {
-			s := strings.Split(option, "=")
+			s := strings.SplitN(option, "=", 2)
 			if !generator.ValidateOption(lang, s[0]) {
 				err = fmt.Errorf("Unknown option '%s' for %s", s[0], lang)
 			}
@@ -208,6 +436,6 @@ func cleanGenParam(gen string) (lang string, options map[string]string, err erro
 // logv prints the message if in verbose mode.
 func logv(msg string) {
 	if globals.Verbose {
-		fmt.Println(msg)
+		fmt.Fprintln(os.Stderr, msg)
 	}
 }
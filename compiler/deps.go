@@ -0,0 +1,258 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compiler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// DependencyGraph is the include graph of a Frugal file along with the
+// packages generated for each file it contains, so build tooling can know
+// the output of a compilation ahead of time. Files are given relative to the
+// directory of the root file.
+type DependencyGraph struct {
+	File   string            `json:"file"`
+	Files  []*DependencyFile `json:"files"`
+	Cycles [][]string        `json:"cycles,omitempty"`
+}
+
+// DependencyFile is a file in a DependencyGraph. Packages maps each language
+// to the directory the file's code is generated to, which for includes
+// requires generating recursively. Languages using vendored includes don't
+// generate files only reachable through them.
+type DependencyFile struct {
+	File     string            `json:"file"`
+	Includes []string          `json:"includes"`
+	Packages map[string]string `json:"packages,omitempty"`
+}
+
+// Dependencies returns the include graph of the Frugal file with the
+// packages generated for each file by the given -gen targets to the given
+// output location, or each language's default if it's empty. Unlike
+// compilation, circular includes are reported in the graph's cycles rather
+// than as an error.
+func Dependencies(file string, targets []string, out string) (*DependencyGraph, error) {
+	generators := make(map[string]generator.ProgramGenerator, len(targets))
+	for _, target := range targets {
+		lang, options, err := cleanGenParam(target)
+		if err != nil {
+			return nil, err
+		}
+		g, err := getProgramGenerator(lang, options)
+		if err != nil {
+			return nil, err
+		}
+		generators[lang] = g
+	}
+
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+	w := &dependencyWalker{
+		root:     filepath.Dir(absFile),
+		out:      out,
+		files:    make(map[string]*parser.Frugal),
+		vendored: make(map[[2]string]bool),
+		graph:    &DependencyGraph{Files: []*DependencyFile{}},
+	}
+	w.graph.File = w.relative(absFile)
+	if err := w.walk(absFile, nil); err != nil {
+		return nil, err
+	}
+	for lang, g := range generators {
+		w.addPackages(w.graph.File, lang, g, make(map[string]bool))
+	}
+	return w.graph, nil
+}
+
+// WriteJSON writes the graph as indented JSON.
+func (d *DependencyGraph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(d)
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language, labeling each file
+// with its packages and highlighting the includes which form cycles.
+func (d *DependencyGraph) WriteDOT(w io.Writer) error {
+	cyclic := make(map[[2]string]bool)
+	for _, cycle := range d.Cycles {
+		for i := 1; i < len(cycle); i++ {
+			cyclic[[2]string{cycle[i-1], cycle[i]}] = true
+		}
+	}
+
+	lines := []string{fmt.Sprintf("digraph %q {", d.File)}
+	for _, file := range d.Files {
+		label := file.File
+		for _, lang := range sortedKeys(file.Packages) {
+			label += fmt.Sprintf("\n%s: %s", lang, file.Packages[lang])
+		}
+		lines = append(lines, fmt.Sprintf("\t%q [label=%q];", file.File, label))
+	}
+	for _, file := range d.Files {
+		for _, include := range file.Includes {
+			edge := fmt.Sprintf("\t%q -> %q", file.File, include)
+			if cyclic[[2]string{file.File, include}] {
+				edge += " [color=red]"
+			}
+			lines = append(lines, edge+";")
+		}
+	}
+	lines = append(lines, "}")
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// dependencyWalker builds a DependencyGraph by walking includes depth first.
+type dependencyWalker struct {
+	root     string
+	out      string
+	files    map[string]*parser.Frugal // Parsed files by graph name
+	vendored map[[2]string]bool        // Includes annotated vendor
+	graph    *DependencyGraph
+}
+
+// walk adds the file and everything it includes to the graph, given the
+// files including it on the current path, recording a cycle if it's one of
+// them.
+func (w *dependencyWalker) walk(file string, path []string) error {
+	name := w.relative(file)
+	for i, including := range path {
+		if including == name {
+			w.graph.Cycles = append(w.graph.Cycles, append(append([]string{}, path[i:]...), name))
+			return nil
+		}
+	}
+	if _, ok := w.files[name]; ok {
+		return nil
+	}
+
+	f, err := parseDependencyFile(file)
+	if err != nil {
+		return err
+	}
+	w.files[name] = f
+	node := &DependencyFile{File: name, Includes: []string{}}
+	w.graph.Files = append(w.graph.Files, node)
+
+	path = append(path, name)
+	for _, include := range f.OrderedIncludes() {
+		if !strings.HasSuffix(include.Value, ".thrift") && !strings.HasSuffix(include.Value, ".frugal") {
+			return fmt.Errorf("%s: Bad include name: %s", name, include.Value)
+		}
+		included := filepath.Join(f.Dir, include.Value)
+		node.Includes = append(node.Includes, w.relative(included))
+		if _, ok := include.Annotations.Vendor(); ok {
+			w.vendored[[2]string{name, w.relative(included)}] = true
+		}
+		if err := w.walk(included, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addPackages records the package the language generates for the file and
+// the includes it generates along with it, which skips vendored includes if
+// the generator uses them.
+func (w *dependencyWalker) addPackages(name, lang string, g generator.ProgramGenerator, seen map[string]bool) {
+	if seen[name] {
+		return
+	}
+	seen[name] = true
+
+	out := w.out
+	if out == "" {
+		out = g.DefaultOutputDir()
+	}
+	node := w.node(name)
+	if node.Packages == nil {
+		node.Packages = make(map[string]string)
+	}
+	node.Packages[lang] = filepath.ToSlash(g.GetOutputDir(out, w.files[name]))
+
+	for _, include := range node.Includes {
+		if !w.vendored[[2]string{name, include}] || !g.UseVendor() {
+			w.addPackages(include, lang, g, seen)
+		}
+	}
+}
+
+// node returns the file in the graph with the given name.
+func (w *dependencyWalker) node(name string) *DependencyFile {
+	for _, node := range w.graph.Files {
+		if node.File == name {
+			return node
+		}
+	}
+	return nil
+}
+
+// relative returns the path of the file relative to the root file's
+// directory, or the absolute path if it has none.
+func (w *dependencyWalker) relative(file string) string {
+	rel, err := filepath.Rel(w.root, file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// parseDependencyFile parses the file without its includes, which would fail
+// on circular includes. Conditional blocks aren't evaluated, since the
+// grammar treats directives as comments, so the includes of every branch are
+// dependencies. Template definitions are stripped, since their bodies are
+// only valid once expanded.
+func parseDependencyFile(file string) (*parser.Frugal, error) {
+	if !exists(file) {
+		return nil, fmt.Errorf("Frugal file not found: %s", file)
+	}
+	src, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parser.ParseReader(file, bytes.NewReader(parser.StripTemplates(src)))
+	if err != nil {
+		return nil, err
+	}
+	f := parsed.(*parser.Frugal)
+	f.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	f.File = file
+	f.Dir = filepath.Dir(file)
+	f.Path = file
+	return f, nil
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -16,10 +16,16 @@ package generator
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 
+	"github.com/Workiva/frugal/compiler/globals"
 	"github.com/Workiva/frugal/compiler/parser"
 )
 
+var runtimeVersion = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+
 // BaseGenerator contains base generator logic which language generators can
 // extend.
 type BaseGenerator struct {
@@ -69,11 +75,188 @@ func (b *BaseGenerator) GenerateBlockComment(comment []string, indent string) st
 	return block
 }
 
+// ArtifactsSeparated returns true if files of the artifact type of the given
+// output directory option are generated apart from the types they use.
+func (b *BaseGenerator) ArtifactsSeparated(option string) bool {
+	root, ok := b.Options[option]
+	if !ok || root == "" {
+		return false
+	}
+	return filepath.Clean(root) != filepath.Clean(b.Options[ModelsOutOption])
+}
+
+// WarnSubscribeAllDelimiter warns if the topic delimiter isn't ".", since the
+// "*" wildcard topics generated SubscribeAll methods subscribe to only match
+// a single "."-separated token with transports such as NATS, so they don't
+// match the scope's operations with other delimiters.
+func (b *BaseGenerator) WarnSubscribeAllDelimiter(scope *parser.Scope) {
+	if globals.TopicDelimiter != "." {
+		globals.Warn(fmt.Sprintf("%s: SubscribeAll subscribes to a * wildcard topic, which only matches "+
+			"every operation with the . topic delimiter, not %q", scope.Name, globals.TopicDelimiter))
+	}
+}
+
+// RuntimeCheckVersion returns the minimum version of the runtime library the
+// generated code checks for when loaded, which is the value of the
+// "runtime_check" option or the compiler's version if it has none, and false
+// if the option isn't set. An error is returned if the version isn't of the
+// form major.minor.patch.
+func (b *BaseGenerator) RuntimeCheckVersion() (string, bool, error) {
+	version, ok := b.Options[RuntimeCheckOption]
+	if !ok {
+		return "", false, nil
+	}
+	if version == "" {
+		version = globals.Version
+	}
+	if !runtimeVersion.MatchString(version) {
+		return "", false, fmt.Errorf("%s option %q must be a version of the form major.minor.patch",
+			RuntimeCheckOption, version)
+	}
+	return version, true, nil
+}
+
+// UnknownEnums returns the strategy of the "unknown_enums" option for decoding
+// enum values not declared in the IDL, or an empty string if the option isn't
+// set. An error is returned if the strategy isn't supported, or if it's
+// "unknown" and an enum of the IDL or its includes doesn't declare an UNKNOWN
+// value.
+func (b *BaseGenerator) UnknownEnums() (string, error) {
+	strategy, ok := b.Options[UnknownEnumsOption]
+	if !ok {
+		return "", nil
+	}
+	switch strategy {
+	case UnknownEnumsError, UnknownEnumsPreserve:
+		return strategy, nil
+	case UnknownEnumsSentinel:
+		if err := checkUnknownEnumValues(b.Frugal, make(map[*parser.Frugal]bool)); err != nil {
+			return "", err
+		}
+		return strategy, nil
+	}
+	return "", fmt.Errorf("%s option %q must be one of %s, %s, or %s", UnknownEnumsOption, strategy,
+		UnknownEnumsError, UnknownEnumsSentinel, UnknownEnumsPreserve)
+}
+
+// checkUnknownEnumValues returns an error if an enum of the Frugal file or its
+// includes doesn't declare an UNKNOWN value.
+func checkUnknownEnumValues(frugal *parser.Frugal, checked map[*parser.Frugal]bool) error {
+	if checked[frugal] {
+		return nil
+	}
+	checked[frugal] = true
+	for _, enum := range frugal.Enums {
+		declared := false
+		for _, value := range enum.Values {
+			if value.Name == UnknownEnumValue {
+				declared = true
+				break
+			}
+		}
+		if !declared {
+			return fmt.Errorf("%s.%s: enum must declare an %s value for %s=%s", frugal.Name, enum.Name,
+				UnknownEnumValue, UnknownEnumsOption, UnknownEnumsSentinel)
+		}
+	}
+	for _, include := range frugal.ParsedIncludes {
+		if err := checkUnknownEnumValues(include, checked); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrefixValidation returns the behavior of the "prefix_validation" option for
+// checking topic prefix variables, or an empty string if the option isn't set.
+// An error is returned if the behavior isn't supported.
+func (b *BaseGenerator) PrefixValidation() (string, error) {
+	behavior, ok := b.Options[PrefixValidationOption]
+	if !ok {
+		return "", nil
+	}
+	if behavior != PrefixValidationError && behavior != PrefixValidationSanitize {
+		return "", fmt.Errorf("%s option %q must be %s or %s", PrefixValidationOption, behavior,
+			PrefixValidationError, PrefixValidationSanitize)
+	}
+	return behavior, nil
+}
+
+// ScopeProtocol returns the protocol the scope's messages are serialized with:
+// the value of its "protocol" annotation or, if not present, of the
+// "scope_protocol" option, defaulting to the provider's protocol.
+func (b *BaseGenerator) ScopeProtocol(scope *parser.Scope) string {
+	if protocol, ok := scope.Annotations.Protocol(); ok {
+		return protocol
+	}
+	if protocol, ok := b.Options[ScopeProtocolOption]; ok {
+		return protocol
+	}
+	return parser.ProtocolProvider
+}
+
+// CheckScopeProtocols returns an error if a scope is annotated with a
+// protocol other than its provider's, for generators which don't support
+// other protocols, since its messages couldn't be decoded by other languages.
+func (b *BaseGenerator) CheckScopeProtocols(lang string) error {
+	for _, scope := range b.Frugal.Scopes {
+		if protocol := b.ScopeProtocol(scope); protocol != parser.ProtocolProvider {
+			return fmt.Errorf("Scope %s: \"%s\" protocol is not supported for %s", scope.Name, protocol, lang)
+		}
+	}
+	return nil
+}
+
+// CheckScopeReplies returns an error if a scope has request/reply operations,
+// for generators which don't generate requesters and responders, since their
+// requests would never be replied to.
+func (b *BaseGenerator) CheckScopeReplies(lang string) error {
+	for _, scope := range b.Frugal.Scopes {
+		for _, op := range scope.Operations {
+			if op.ReplyType() != nil {
+				return fmt.Errorf("%s.%s: reply operations are not supported for %s", scope.Name, op.Name, lang)
+			}
+		}
+	}
+	return nil
+}
+
+// WarnUnsupportedChunks warns about each of the scope's chunked operations
+// for generators which don't generate chunked send and receive methods, since
+// those operations are generated as plain publish/subscribe operations.
+func (b *BaseGenerator) WarnUnsupportedChunks(lang string, scope *parser.Scope) {
+	for _, op := range scope.Operations {
+		if op.IsChunked() {
+			globals.Warn(fmt.Sprintf("%s.%s: chunked transfer is not supported for %s, generating publish/subscribe only",
+				scope.Name, op.Name, lang))
+		}
+	}
+}
+
+// WarnUnsupportedSampling warns about each of the scope's operations
+// annotated with a sample rate for generators which don't generate sampling
+// publish methods, since every message of those operations is published.
+func (b *BaseGenerator) WarnUnsupportedSampling(lang string, scope *parser.Scope) {
+	for _, op := range scope.Operations {
+		if _, ok := op.SampleRate(); ok {
+			globals.Warn(fmt.Sprintf("%s.%s: sampling is not supported for %s, publishing every message",
+				scope.Name, op.Name, lang))
+		}
+	}
+}
+
 // SetFrugal sets the Frugal parse tree for this generator.
 func (b *BaseGenerator) SetFrugal(f *parser.Frugal) {
 	b.Frugal = f
 }
 
+// Option returns the value of the given generator option and true if it's
+// set.
+func (b *BaseGenerator) Option(name string) (string, bool) {
+	value, ok := b.Options[name]
+	return value, ok
+}
+
 func (b *BaseGenerator) GetElem() string {
 	s := fmt.Sprintf("elem%d", b.elemNum)
 	b.elemNum++
@@ -121,3 +304,58 @@ func (b *BaseGenerator) GetServiceMethodTypes(service *parser.Service) []*parser
 	}
 	return structs
 }
+
+// ContractMetadata contains the values of the contract metadata generated for
+// a scope or service.
+type ContractMetadata struct {
+	IDLFile         string
+	Kind            string
+	Name            string
+	Hash            string
+	CompilerVersion string
+	Operations      []string
+
+	// Descriptions maps the names of documented operations to their doc
+	// comments. Only scope operations are described.
+	Descriptions map[string]string
+}
+
+// ScopeMetadata returns the contract metadata of the given scope.
+func (b *BaseGenerator) ScopeMetadata(scope *parser.Scope) *ContractMetadata {
+	operations := make([]string, len(scope.Operations))
+	var descriptions map[string]string
+	for i, op := range scope.Operations {
+		operations[i] = op.Name
+		if op.Comment != nil {
+			if descriptions == nil {
+				descriptions = make(map[string]string)
+			}
+			descriptions[op.Name] = strings.Join(op.Comment, "\n")
+		}
+	}
+	return &ContractMetadata{
+		IDLFile:         filepath.Base(b.Frugal.File),
+		Kind:            "scope",
+		Name:            scope.Name,
+		Hash:            b.Frugal.ScopeHash(scope),
+		CompilerVersion: globals.Version,
+		Operations:      operations,
+		Descriptions:    descriptions,
+	}
+}
+
+// ServiceMetadata returns the contract metadata of the given service.
+func (b *BaseGenerator) ServiceMetadata(service *parser.Service) *ContractMetadata {
+	operations := make([]string, len(service.Methods))
+	for i, method := range service.Methods {
+		operations[i] = method.Name
+	}
+	return &ContractMetadata{
+		IDLFile:         filepath.Base(b.Frugal.File),
+		Kind:            "service",
+		Name:            service.Name,
+		Hash:            b.Frugal.ServiceHash(service),
+		CompilerVersion: globals.Version,
+		Operations:      operations,
+	}
+}
@@ -0,0 +1,340 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const defaultOutputDir = "gen-broker"
+
+// Broker options, which select the manifests to generate. Every manifest is
+// generated if none are given.
+const (
+	natsOption      = "nats"
+	kafkaOption     = "kafka"
+	rabbitMQOption  = "rabbitmq"
+	terraformOption = "terraform"
+)
+
+// Annotations on scopes and operations configuring Kafka topics and RabbitMQ
+// exchanges. An operation's annotation takes precedence over its scope's.
+const (
+	kafkaPartitionsAnnotation        = "kafka_partitions"
+	kafkaReplicationFactorAnnotation = "kafka_replication_factor"
+	kafkaRetentionMsAnnotation       = "kafka_retention_ms"
+	rabbitMQExchangeAnnotation       = "rabbitmq_exchange"
+)
+
+// natsSubjectPrefix is prepended to topics by the NATS scope transports.
+const natsSubjectPrefix = "frugal."
+
+// topicWildcard replaces prefix variables in NATS subjects.
+const topicWildcard = "*"
+
+// Generator implements the ProgramGenerator interface for broker
+// provisioning manifests, which configure brokers to match the scopes of the
+// IDL.
+type Generator struct {
+	nats      bool
+	kafka     bool
+	rabbitMQ  bool
+	terraform bool
+}
+
+// NewGenerator creates a new broker ProgramGenerator.
+func NewGenerator(options map[string]string) generator.ProgramGenerator {
+	_, nats := options[natsOption]
+	_, kafka := options[kafkaOption]
+	_, rabbitMQ := options[rabbitMQOption]
+	_, terraform := options[terraformOption]
+	if !nats && !kafka && !rabbitMQ && !terraform {
+		nats, kafka, rabbitMQ, terraform = true, true, true, true
+	}
+	return &Generator{nats: nats, kafka: kafka, rabbitMQ: rabbitMQ, terraform: terraform}
+}
+
+// Generate writes the manifests for the scopes of the Frugal file to the
+// output directory. Nothing is written for files without scopes.
+func (g *Generator) Generate(frugal *parser.Frugal, outputDir string) error {
+	if len(frugal.Scopes) == 0 {
+		return nil
+	}
+	if g.nats {
+		if err := writeManifest(outputDir, frugal.Name+".nats.json", natsManifestFor(frugal)); err != nil {
+			return err
+		}
+	}
+	if g.kafka {
+		manifest, err := kafkaManifestFor(frugal)
+		if err != nil {
+			return err
+		}
+		if err := writeManifest(outputDir, frugal.Name+".kafka.json", manifest); err != nil {
+			return err
+		}
+	}
+	if g.rabbitMQ {
+		if err := writeManifest(outputDir, frugal.Name+".rabbitmq.json", rabbitMQManifestFor(frugal)); err != nil {
+			return err
+		}
+	}
+	if g.terraform {
+		config, err := terraformConfigFor(frugal)
+		if err != nil {
+			return err
+		}
+		if err := writeManifest(outputDir, frugal.Name+".tf.json", config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetOutputDir returns the output directory for generated files.
+func (g *Generator) GetOutputDir(dir string, frugal *parser.Frugal) string {
+	return dir
+}
+
+// DefaultOutputDir returns the default output directory for generated files.
+func (g *Generator) DefaultOutputDir() string {
+	return defaultOutputDir
+}
+
+// UseVendor returns false since manifests are generated for every include.
+func (g *Generator) UseVendor() bool {
+	return false
+}
+
+func writeManifest(outputDir, name string, manifest interface{}) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(outputDir, name), buf.Bytes(), 0644)
+}
+
+// natsManifest lists the NATS subjects of the scopes and the subjects each
+// role may publish and subscribe to.
+type natsManifest struct {
+	IDLFile     string                      `json:"idl_file"`
+	Subjects    []natsSubject               `json:"subjects"`
+	Permissions map[string]*natsPermissions `json:"permissions"`
+}
+
+type natsSubject struct {
+	Scope     string `json:"scope"`
+	Operation string `json:"operation"`
+	Subject   string `json:"subject"`
+}
+
+type natsPermissions struct {
+	Publish   []string `json:"publish"`
+	Subscribe []string `json:"subscribe"`
+}
+
+func natsManifestFor(frugal *parser.Frugal) *natsManifest {
+	manifest := &natsManifest{
+		IDLFile:     filepath.Base(frugal.File),
+		Subjects:    []natsSubject{},
+		Permissions: make(map[string]*natsPermissions),
+	}
+	permissions := func(role string) *natsPermissions {
+		if _, ok := manifest.Permissions[role]; !ok {
+			manifest.Permissions[role] = &natsPermissions{Publish: []string{}, Subscribe: []string{}}
+		}
+		return manifest.Permissions[role]
+	}
+	for _, scope := range frugal.Scopes {
+		for _, op := range scope.Operations {
+			subject := natsSubjectPrefix + topic(scope, op, topicWildcard)
+			manifest.Subjects = append(manifest.Subjects, natsSubject{
+				Scope:     scope.Name,
+				Operation: op.Name,
+				Subject:   subject,
+			})
+			for _, role := range op.PublishRoles() {
+				p := permissions(role)
+				p.Publish = append(p.Publish, subject)
+			}
+			for _, role := range op.SubscribeRoles() {
+				p := permissions(role)
+				p.Subscribe = append(p.Subscribe, subject)
+			}
+		}
+	}
+	return manifest
+}
+
+// kafkaManifest lists a Kafka topic definition for each scope operation.
+type kafkaManifest struct {
+	IDLFile string       `json:"idl_file"`
+	Topics  []kafkaTopic `json:"topics"`
+}
+
+// kafkaTopic is a Kafka topic definition. Topics of scopes with prefix
+// variables are templated, with the variables in braces in the name, since a
+// topic is created for each set of values.
+type kafkaTopic struct {
+	Name              string            `json:"name"`
+	Templated         bool              `json:"templated"`
+	Partitions        int               `json:"partitions"`
+	ReplicationFactor int               `json:"replication_factor"`
+	Config            map[string]string `json:"config"`
+}
+
+func kafkaManifestFor(frugal *parser.Frugal) (*kafkaManifest, error) {
+	manifest := &kafkaManifest{IDLFile: filepath.Base(frugal.File), Topics: []kafkaTopic{}}
+	for _, scope := range frugal.Scopes {
+		for _, op := range scope.Operations {
+			topic, err := kafkaTopicFor(scope, op)
+			if err != nil {
+				return nil, err
+			}
+			manifest.Topics = append(manifest.Topics, topic)
+		}
+	}
+	return manifest, nil
+}
+
+func kafkaTopicFor(scope *parser.Scope, op *parser.Operation) (kafkaTopic, error) {
+	partitions, err := intAnnotation(scope, op, kafkaPartitionsAnnotation, 1)
+	if err != nil {
+		return kafkaTopic{}, err
+	}
+	replicationFactor, err := intAnnotation(scope, op, kafkaReplicationFactorAnnotation, 1)
+	if err != nil {
+		return kafkaTopic{}, err
+	}
+	config := make(map[string]string)
+	if retention, ok := annotation(scope, op, kafkaRetentionMsAnnotation); ok {
+		if _, err := strconv.ParseInt(retention, 10, 64); err != nil {
+			return kafkaTopic{}, fmt.Errorf("%s annotation on %s.%s must be an integer: %s",
+				kafkaRetentionMsAnnotation, scope.Name, op.Name, retention)
+		}
+		config["retention.ms"] = retention
+	}
+	return kafkaTopic{
+		Name:              topic(scope, op, ""),
+		Templated:         len(scope.Prefix.Variables) > 0,
+		Partitions:        partitions,
+		ReplicationFactor: replicationFactor,
+		Config:            config,
+	}, nil
+}
+
+// rabbitMQManifest declares the RabbitMQ exchanges of the scopes in the
+// format of RabbitMQ definition files.
+type rabbitMQManifest struct {
+	Exchanges []rabbitMQExchange `json:"exchanges"`
+}
+
+type rabbitMQExchange struct {
+	Name       string            `json:"name"`
+	VHost      string            `json:"vhost"`
+	Type       string            `json:"type"`
+	Durable    bool              `json:"durable"`
+	AutoDelete bool              `json:"auto_delete"`
+	Internal   bool              `json:"internal"`
+	Arguments  map[string]string `json:"arguments"`
+}
+
+// rabbitMQManifestFor declares a topic exchange for each scope exchange.
+func rabbitMQManifestFor(frugal *parser.Frugal) *rabbitMQManifest {
+	manifest := &rabbitMQManifest{Exchanges: []rabbitMQExchange{}}
+	for _, name := range exchangeNames(frugal) {
+		manifest.Exchanges = append(manifest.Exchanges, rabbitMQExchange{
+			Name:      name,
+			VHost:     "/",
+			Type:      "topic",
+			Durable:   true,
+			Arguments: map[string]string{},
+		})
+	}
+	return manifest
+}
+
+// exchangeNames returns the sorted RabbitMQ exchanges of the scopes, named by
+// their "rabbitmq_exchange" annotation or the scope name. Scopes annotated
+// with the same exchange share it.
+func exchangeNames(frugal *parser.Frugal) []string {
+	names := make(map[string]bool)
+	for _, scope := range frugal.Scopes {
+		name := scope.Name
+		if exchange, ok := scope.Annotations.Get(rabbitMQExchangeAnnotation); ok {
+			name = exchange
+		}
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// topic returns the topic of the scope operation the same way generated
+// publishers build it. Prefix variables are replaced with the given string or
+// kept in braces if it's empty.
+func topic(scope *parser.Scope, op *parser.Operation, variable string) string {
+	prefix := scope.Prefix.String
+	if variable != "" {
+		prefix = scope.Prefix.Template(variable)
+	}
+	tokens := []string{}
+	if prefix != "" {
+		tokens = append(tokens, strings.Split(prefix, ".")...)
+	}
+	tokens = append(tokens, scope.TopicName(scope.Name, globals.TopicDelimiter), op.Name)
+	return strings.Join(tokens, globals.TopicDelimiter)
+}
+
+// annotation returns the value of the operation's annotation, falling back to
+// its scope's, and true if either is annotated.
+func annotation(scope *parser.Scope, op *parser.Operation, name string) (string, bool) {
+	if value, ok := op.Annotations.Get(name); ok {
+		return value, true
+	}
+	return scope.Annotations.Get(name)
+}
+
+// intAnnotation returns the positive integer value of the operation's or its
+// scope's annotation, or the default if neither is annotated.
+func intAnnotation(scope *parser.Scope, op *parser.Operation, name string, defaultValue int) (int, error) {
+	value, ok := annotation(scope, op, name)
+	if !ok {
+		return defaultValue, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil || i <= 0 {
+		return 0, fmt.Errorf("%s annotation on %s.%s must be a positive integer: %s",
+			name, scope.Name, op.Name, value)
+	}
+	return i, nil
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// terraformConfig is a Terraform configuration in JSON syntax provisioning
+// the Kafka topics and RabbitMQ exchanges of the scopes.
+type terraformConfig struct {
+	Terraform map[string]interface{}                       `json:"terraform"`
+	Variable  map[string]*terraformVariable                `json:"variable,omitempty"`
+	Resource  map[string]map[string]map[string]interface{} `json:"resource"`
+}
+
+// terraformVariable takes the values of a scope prefix variable, for which a
+// topic is created each.
+type terraformVariable struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+func terraformConfigFor(frugal *parser.Frugal) (*terraformConfig, error) {
+	config := &terraformConfig{
+		Terraform: map[string]interface{}{
+			"required_providers": map[string]interface{}{
+				"kafka":    map[string]string{"source": "Mongey/kafka"},
+				"rabbitmq": map[string]string{"source": "cyrilgdn/rabbitmq"},
+			},
+		},
+		Variable: make(map[string]*terraformVariable),
+		Resource: map[string]map[string]map[string]interface{}{
+			"kafka_topic":       make(map[string]map[string]interface{}),
+			"rabbitmq_exchange": make(map[string]map[string]interface{}),
+		},
+	}
+
+	for _, scope := range frugal.Scopes {
+		variables := make([]string, len(scope.Prefix.Variables))
+		for i, variable := range scope.Prefix.Variables {
+			variables[i] = "var." + resourceName(scope.Name, variable)
+			config.Variable[resourceName(scope.Name, variable)] = &terraformVariable{
+				Type:        "list(string)",
+				Description: fmt.Sprintf("Values of the {%s} prefix variable of the %s scope", variable, scope.Name),
+			}
+		}
+		for _, op := range scope.Operations {
+			topic, err := kafkaTopicFor(scope, op)
+			if err != nil {
+				return nil, err
+			}
+			resource := map[string]interface{}{
+				"name":               topic.Name,
+				"partitions":         topic.Partitions,
+				"replication_factor": topic.ReplicationFactor,
+			}
+			if len(topic.Config) > 0 {
+				resource["config"] = topic.Config
+			}
+			if topic.Templated {
+				resource["name"], resource["for_each"] = templatedTopic(scope, op, variables)
+			}
+			config.Resource["kafka_topic"][resourceName(scope.Name, op.Name)] = resource
+		}
+	}
+
+	for _, name := range exchangeNames(frugal) {
+		config.Resource["rabbitmq_exchange"][resourceName(name)] = map[string]interface{}{
+			"name":  name,
+			"vhost": "/",
+			"settings": map[string]interface{}{
+				"type":        "topic",
+				"durable":     true,
+				"auto_delete": false,
+			},
+		}
+	}
+	return config, nil
+}
+
+// templatedTopic returns the name and for_each expression of a Kafka topic
+// resource created for each combination of the scope's prefix variable
+// values.
+func templatedTopic(scope *parser.Scope, op *parser.Operation, variables []string) (string, string) {
+	name := topic(scope, op, "")
+	if len(variables) == 1 {
+		name = strings.Replace(name, "{"+scope.Prefix.Variables[0]+"}", "${each.value}", -1)
+		return name, fmt.Sprintf("${toset(%s)}", variables[0])
+	}
+	for i, variable := range scope.Prefix.Variables {
+		name = strings.Replace(name, "{"+variable+"}", fmt.Sprintf("${each.value[%d]}", i), -1)
+	}
+	forEach := fmt.Sprintf("${{for values in setproduct(%s) : join(\".\", values) => values}}",
+		strings.Join(variables, ", "))
+	return name, forEach
+}
+
+// resourceName returns a Terraform identifier from the given names, converted
+// to snake case and joined by underscores.
+func resourceName(names ...string) string {
+	tokens := make([]string, len(names))
+	for i, name := range names {
+		var buf []rune
+		runes := []rune(name)
+		for j, r := range runes {
+			switch {
+			case unicode.IsUpper(r):
+				if j > 0 && (unicode.IsLower(runes[j-1]) || unicode.IsDigit(runes[j-1])) {
+					buf = append(buf, '_')
+				}
+				buf = append(buf, unicode.ToLower(r))
+			case unicode.IsLetter(r) || unicode.IsDigit(r):
+				buf = append(buf, r)
+			default:
+				buf = append(buf, '_')
+			}
+		}
+		tokens[i] = string(buf)
+	}
+	name := strings.Join(tokens, "_")
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "_" + name
+	}
+	return name
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dartlang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// useBatchPublish indicates if publishers should have methods publishing a
+// batch of messages in a single frame, and subscribers should receive them.
+func (g *Generator) useBatchPublish() bool {
+	_, ok := g.Options[generator.BatchPublishOption]
+	return ok
+}
+
+// wrapBatchFrameCallback wraps the given FAsyncCallback so it splits batch
+// frames into their messages with the batch_publish option.
+func (g *Generator) wrapBatchFrameCallback(callback string) string {
+	if g.useBatchPublish() {
+		return fmt.Sprintf("frugal.batchFrameCallback(%s)", callback)
+	}
+	return callback
+}
+
+// generatePublishBatchMethod generates the method publishing a list of
+// messages to the operation in a single frame, and the internal method it
+// invokes through the middleware.
+func (g *Generator) generatePublishBatchMethod(scope *parser.Scope, op *parser.Operation, args, argsWithoutTypes string) string {
+	dartType := g.getDartTypeFromThriftType(op.Type)
+
+	publishers := g.generateDocComment([]string{
+		"Publishes the messages in a single frame, sent with one transport flush.",
+		"Subscribers must be generated with the batch_publish option to receive",
+		"them.",
+	}, tab)
+	publishers += fmt.Sprintf(tab+"Future publish%sBatch(frugal.FContext ctx, %sList<%s> reqs, {Duration timeout}) {\n", op.Name, args, dartType)
+	publishers += fmt.Sprintf(tabtab+"var publish = this._methods['%sBatch']([ctx, %sreqs]);\n", op.Name, argsWithoutTypes)
+	publishers += tabtab + "if (timeout == null) {\n"
+	publishers += tabtabtab + "return publish;\n"
+	publishers += tabtab + "}\n"
+	publishers += tabtab + "return publish.timeout(timeout, onTimeout: () =>\n"
+	publishers += fmt.Sprintf(tabtabtabtab+"throw new frugal.FTimeoutError('frugal: publish of %s batch timed out'));\n", op.Name)
+	publishers += tab + "}\n\n"
+
+	publishers += fmt.Sprintf(tab+"Future _publish%sBatch(frugal.FContext ctx, %sList<%s> reqs) async {\n", op.Name, args, dartType)
+	publishers += g.generatePrefixVariableChecks(scope)
+	for _, prefixVar := range scope.Prefix.Variables {
+		publishers += fmt.Sprintf(tabtab+"ctx.addRequestHeader('_topic_%s', %s);\n", prefixVar, prefixVar)
+	}
+	publishers += tabtab + fmt.Sprintf("var op = \"%s\";\n", op.Name)
+	publishers += tabtab + fmt.Sprintf("var prefix = \"%s\";\n", generatePrefixStringTemplate(scope))
+	publishers += tabtab + "var topic = \"${prefix}" + scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter) + "${delimiter}${op}\";\n"
+	publishers += g.generateHookCall("onBeforePublish", "op", tabtab)
+	publishers += tabtab + "try {\n"
+	publishers += tabtabtab + "var frames = <Uint8List>[];\n"
+	publishers += tabtabtab + "for (var req in reqs) {\n"
+	publishers += tabtabtabtab + "var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);\n"
+	publishers += tabtabtabtab + "var oprot = protocolFactory.getProtocol(memoryBuffer);\n"
+	publishers += tabtabtabtab + "var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);\n"
+	publishers += tabtabtabtab + "oprot.writeRequestHeader(ctx);\n"
+	publishers += tabtabtabtab + "oprot.writeMessageBegin(msg);\n"
+	publishers += g.generateWriteFieldRec(parser.FieldFromType(op.Type, "req"), false, tabtab)
+	publishers += tabtabtabtab + "oprot.writeMessageEnd();\n"
+	publishers += tabtabtabtab + "frames.add(memoryBuffer.writeBytes);\n"
+	publishers += tabtabtab + "}\n"
+	publishers += tabtabtab + "await frugal.publishBatchFrame(transport, topic, frames);\n"
+	publishers += tabtab + "} on thrift.TTransportError catch (e) {\n"
+	publishers += tabtabtab + "throw frugal.translateTransportError(e);\n"
+	publishers += tabtab + "}\n"
+	publishers += tab + "}\n"
+	return publishers
+}
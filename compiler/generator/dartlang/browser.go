@@ -0,0 +1,110 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dartlang
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Workiva/frugal/compiler/generator"
+)
+
+const (
+	browserOption = "browser"
+
+	// minimumBrowserDartVersion is the first Dart version supporting the
+	// conditional imports generated with the browser option.
+	minimumBrowserDartVersion = "1.19.0"
+
+	platformVMFile        = "platform_vm"
+	platformBrowserFile   = "platform_browser"
+	platformTransportFile = "platform_transport"
+)
+
+// useBrowser indicates if a WebSocket transport working both in the Dart VM
+// and in browsers should be generated for the library's services.
+func (g *Generator) useBrowser() bool {
+	_, ok := g.Options[browserOption]
+	return ok && len(g.Frugal.Services) > 0
+}
+
+// platformTransportName returns the name of the function creating the
+// library's WebSocket transport.
+func (g *Generator) platformTransportName() string {
+	return fmt.Sprintf("new%sWebSocketTransport", snakeToCamel(g.getLibraryName()))
+}
+
+// generatePlatformTransport writes the sources of the library's WebSocket
+// transport with the browser option. w_transport must be configured for the
+// platform it runs on, which is selected with a conditional import of the
+// source configuring it for the Dart VM or, where dart:html is available,
+// for browsers.
+func (g *Generator) generatePlatformTransport() error {
+	if !g.useBrowser() {
+		return nil
+	}
+	dir := g.artifactDir(servicesArtifact)
+
+	platforms := []struct{ name, library, configure string }{
+		{platformVMFile, "vm", "configureWTransportForVM"},
+		{platformBrowserFile, "browser", "configureWTransportForBrowser"},
+	}
+	for _, platform := range platforms {
+		contents := fmt.Sprintf("import 'package:w_transport/%s.dart' show %s;\n\n", platform.library, platform.configure)
+		contents += g.generateDocComment([]string{"Configures w_transport for the platform."}, "")
+		contents += fmt.Sprintf("void configurePlatform() => %s();\n", platform.configure)
+		if err := g.writePlatformFile(dir, platform.name, contents); err != nil {
+			return err
+		}
+	}
+
+	contents := "import 'package:frugal/frugal.dart' as frugal;\n\n"
+	contents += fmt.Sprintf("import '%s'\n", g.platformImportPath(dir, platformVMFile))
+	contents += fmt.Sprintf(tabtab+"if (dart.library.html) '%s' as platform;\n\n", g.platformImportPath(dir, platformBrowserFile))
+	contents += g.generateDocComment([]string{
+		"Returns an [frugal.FTransport] making requests to the Frugal server at",
+		"the given WebSocket [uri], after configuring w_transport for the platform",
+		"the code runs on: the Dart VM or a browser.",
+	}, "")
+	contents += fmt.Sprintf("frugal.FTransport %s(Uri uri, {int requestSizeLimit: 0}) {\n", g.platformTransportName())
+	contents += tab + "platform.configurePlatform();\n"
+	contents += tab + "return new frugal.FWebSocketTransport(uri, requestSizeLimit: requestSizeLimit);\n"
+	contents += "}\n"
+	return g.writePlatformFile(dir, platformTransportFile, contents)
+}
+
+// writePlatformFile writes a source of the library's WebSocket transport.
+func (g *Generator) writePlatformFile(dir, name, contents string) error {
+	file, err := g.createSourceFile(dir, name, servicesArtifact)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := g.GenerateDocStringComment(file); err != nil {
+		return err
+	}
+	_, err = file.WriteString("\n\n" + contents)
+	return err
+}
+
+// platformImportPath returns the path of the given source of the library's
+// WebSocket transport relative to the source creating the transport.
+func (g *Generator) platformImportPath(dir, name string) string {
+	from := filepath.Dir(g.sourcePath(dir, generator.FilePrefix+platformTransportFile, servicesArtifact))
+	path := g.sourcePath(dir, generator.FilePrefix+name, servicesArtifact)
+	if rel, err := filepath.Rel(from, path); err == nil {
+		path = rel
+	}
+	return filepath.ToSlash(path)
+}
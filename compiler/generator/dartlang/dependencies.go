@@ -0,0 +1,82 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dartlang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultPubURL is the pub server the Thrift and Frugal runtimes are hosted
+// on unless a dependency option selects another source.
+const defaultPubURL = "https://pub.workiva.org"
+
+// runtimeDep describes a runtime library generated code depends on, whose
+// source and version are selected by the "<name>_dep" and "<name>_version"
+// options.
+type runtimeDep struct {
+	name           string
+	defaultVersion string
+}
+
+// dependency returns the pubspec dependency on the runtime library. The
+// "<name>_dep" option selects the source:
+//
+//	hosted             hosted on pub.workiva.org (the default)
+//	hosted+<url>       hosted on the given pub server
+//	git+<url>[#<ref>]  a git repository, optionally at a branch, tag, or commit
+//	path+<dir>         a local directory
+//
+// The "<name>_version" option overrides the version constraint of hosted
+// dependencies.
+func (g *Generator) dependency(r runtimeDep) (dep, error) {
+	depOption := r.name + "_dep"
+	versionOption := r.name + "_version"
+	version := r.defaultVersion
+	if v, ok := g.Options[versionOption]; ok {
+		version = v
+	}
+
+	source, ok := g.Options[depOption]
+	if !ok {
+		source = "hosted"
+	}
+	kind, location := source, ""
+	if i := strings.Index(source, "+"); i >= 0 {
+		kind, location = source[:i], source[i+1:]
+	}
+
+	switch {
+	case kind == "hosted" && location == "":
+		return dep{Hosted: hostedDep{Name: r.name, URL: defaultPubURL}, Version: version}, nil
+	case kind == "hosted":
+		return dep{Hosted: hostedDep{Name: r.name, URL: location}, Version: version}, nil
+	case kind == "git" && location != "":
+		if _, ok := g.Options[versionOption]; ok {
+			return dep{}, fmt.Errorf("Dart %s option doesn't apply to git dependencies", versionOption)
+		}
+		git := gitDep{URL: location}
+		if i := strings.LastIndex(location, "#"); i >= 0 {
+			git = gitDep{URL: location[:i], Ref: location[i+1:]}
+		}
+		return dep{Git: git}, nil
+	case kind == "path" && location != "":
+		if _, ok := g.Options[versionOption]; ok {
+			return dep{}, fmt.Errorf("Dart %s option doesn't apply to path dependencies", versionOption)
+		}
+		return dep{Path: location}, nil
+	}
+	return dep{}, fmt.Errorf("Dart %s option %q must be hosted, hosted+<url>, git+<url>[#<ref>], or path+<dir>",
+		depOption, source)
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dartlang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+)
+
+const excludeExportsOption = "exclude_exports"
+
+// Categories of the artifacts the library's export file exports, which the
+// "exclude_exports" option selects by name.
+const (
+	constantsExport  = "constants"
+	structsExport    = "structs"
+	unionsExport     = "unions"
+	exceptionsExport = "exceptions"
+	enumsExport      = "enums"
+	servicesExport   = "services"
+	scopesExport     = "scopes"
+)
+
+var exportCategories = []string{
+	constantsExport, structsExport, unionsExport, exceptionsExport, enumsExport, servicesExport, scopesExport,
+}
+
+// export is an export directive of the library's export file.
+type export struct {
+	category string
+	path     string
+	names    []string
+}
+
+func (e *export) String() string {
+	return fmt.Sprintf("export '%s' show %s;\n", e.path, strings.Join(e.names, ", "))
+}
+
+// excludedExports returns the categories of the "exclude_exports" option,
+// which are separated by "+" since options are separated by commas. An error
+// is returned for unknown categories.
+func (g *Generator) excludedExports() (map[string]bool, error) {
+	excluded := make(map[string]bool)
+	value, ok := g.Options[excludeExportsOption]
+	if !ok {
+		return excluded, nil
+	}
+	for _, category := range strings.Split(value, "+") {
+		known := false
+		for _, c := range exportCategories {
+			known = known || c == category
+		}
+		if !known {
+			return nil, fmt.Errorf("Dart %s option has unknown category %q, expected one of %s",
+				excludeExportsOption, category, strings.Join(exportCategories, ", "))
+		}
+		excluded[category] = true
+	}
+	return excluded, nil
+}
+
+// exportRegistry returns the export of every artifact generated for the file,
+// in the order they appear in the export file.
+func (g *Generator) exportRegistry() []*export {
+	exports := []*export{}
+	if len(g.Frugal.Constants) > 0 {
+		constantsName := fmt.Sprintf("%sConstants", snakeToCamel(g.getLibraryName()))
+		exports = append(exports, g.typeExport(constantsExport, constantsName))
+	}
+	for _, s := range g.Frugal.Structs {
+		exports = append(exports, g.typeExport(structsExport, s.Name))
+	}
+	for _, union := range g.Frugal.Unions {
+		exports = append(exports, g.typeExport(unionsExport, union.Name))
+	}
+	for _, exception := range g.Frugal.Exceptions {
+		exports = append(exports, g.typeExport(exceptionsExport, exception.Name))
+	}
+	for _, enum := range g.Frugal.Enums {
+		e := g.typeExport(enumsExport, enum.Name)
+		if g.useEnums() {
+			e.names = append(e.names, "serialize"+enum.Name, "deserialize"+enum.Name)
+		}
+		exports = append(exports, e)
+	}
+	for _, service := range g.Frugal.Services {
+		path := g.exportPath(generator.FilePrefix+toFileName(service.Name)+serviceSuffix, servicesArtifact)
+		servTitle := strings.Title(service.Name)
+		exports = append(exports,
+			&export{category: servicesExport, path: path, names: []string{"F" + servTitle}},
+			&export{category: servicesExport, path: path, names: []string{"F" + servTitle + "Client"}})
+	}
+	if g.useBrowser() {
+		exports = append(exports, &export{
+			category: servicesExport,
+			path:     g.exportPath(generator.FilePrefix+platformTransportFile, servicesArtifact),
+			names:    []string{g.platformTransportName()},
+		})
+	}
+	for _, scope := range g.Frugal.Scopes {
+		path := g.exportPath(generator.FilePrefix+toFileName(scope.Name)+scopeSuffix, scopesArtifact)
+		scopeTitle := strings.Title(scope.Name)
+		names := []string{scopeTitle + "Publisher", scopeTitle + "Subscriber"}
+		if g.useResilientPublishers() {
+			names = append(names, "Resilient"+scopeTitle+"Publisher")
+		}
+		exports = append(exports, &export{category: scopesExport, path: path, names: names})
+	}
+	return exports
+}
+
+// typeExport returns the export of the type with the given name.
+func (g *Generator) typeExport(category, name string) *export {
+	return &export{
+		category: category,
+		path:     g.exportPath(generator.FilePrefix+toFileName(name), typesArtifact),
+		names:    []string{name},
+	}
+}
+
+// mergeExports returns the exports apart from the excluded categories with
+// those of the same file merged into the first, so each file is exported by
+// a single directive showing each of its names once.
+func mergeExports(exports []*export, excluded map[string]bool) []*export {
+	merged := []*export{}
+	byPath := make(map[string]*export)
+	shown := make(map[string]bool)
+	for _, e := range exports {
+		if excluded[e.category] {
+			continue
+		}
+		first, ok := byPath[e.path]
+		if !ok {
+			first = &export{category: e.category, path: e.path}
+			byPath[e.path] = first
+			merged = append(merged, first)
+		}
+		for _, name := range e.names {
+			if !shown[e.path+" "+name] {
+				shown[e.path+" "+name] = true
+				first.names = append(first.names, name)
+			}
+		}
+	}
+	return merged
+}
+
+// exportClasses writes the library's export file, exporting every artifact
+// in the registry apart from the categories the "exclude_exports" option
+// excludes.
+func (g *Generator) exportClasses(dir string) error {
+	excluded, err := g.excludedExports()
+	if err != nil {
+		return err
+	}
+
+	path := g.getExportFilePath(dir)
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := g.GenerateDocStringComment(file); err != nil {
+		return err
+	}
+
+	contents := fmt.Sprintf("\n\nlibrary %s;\n\n", g.getLibraryDeclarationName())
+	separated := false
+	for _, e := range mergeExports(g.exportRegistry(), excluded) {
+		// Services and scopes are separated from types by a blank line.
+		if !separated && (e.category == servicesExport || e.category == scopesExport) {
+			contents += "\n"
+			separated = true
+		}
+		contents += e.String()
+	}
+	if !separated {
+		contents += "\n"
+	}
+
+	_, err = file.WriteString(contents)
+	return err
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dartlang
+
+import (
+	"fmt"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const (
+	flutterComputeOption = "flutter_compute"
+	flutterComputeImport = "import 'package:flutter/foundation.dart' show compute;\n"
+)
+
+// useFlutterCompute indicates if subscribers should decode messages in a
+// background isolate with Flutter's compute.
+func (g *Generator) useFlutterCompute() bool {
+	_, ok := g.Options[flutterComputeOption]
+	return ok
+}
+
+func (g *Generator) generateFlutterComputeImport() string {
+	if g.useFlutterCompute() {
+		return flutterComputeImport
+	}
+	return ""
+}
+
+// generateIsolateRecv generates the rest of the method returning the
+// callback of the given scope operation with the flutter_compute option, and
+// the static method decoding its messages. The callback decodes each message
+// with compute, so the decoder runs in a background isolate, and calls the
+// handler with the decoded messages in the order they were received.
+func (g *Generator) generateIsolateRecv(op *parser.Operation) string {
+	dartType := g.getDartTypeFromThriftType(op.Type)
+	contents := fmt.Sprintf(tabtab+"return frugal.isolateDecodeCallback(op, protocolFactory, compute, _decode%s, (frugal.FContext ctx, Object message) {\n", op.Name)
+	contents += tabtabtab + fmt.Sprintf("%s req = message as %s;\n", dartType, dartType)
+	contents += g.generateHookCall("onAfterReceive", "op", tabtabtab)
+	contents += tabtabtab + "method([ctx, req]);\n"
+	contents += tabtab + "});\n"
+	contents += tab + "}\n\n"
+
+	contents += fmt.Sprintf(tab+"static frugal.FDecodedMessage _decode%s(frugal.FIsolateMessage message) {\n", op.Name)
+	contents += tabtab + "var op = message.op;\n"
+	contents += tabtab + "var iprot = message.getProtocol();\n"
+	contents += g.generateReadScopeMessage(op, tabtab)
+	contents += tabtab + "return new frugal.FDecodedMessage(ctx, req);\n"
+	contents += tab + "}\n"
+	return contents
+}
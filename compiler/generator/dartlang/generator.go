@@ -20,7 +20,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"syscall"
 	"unicode"
 
 	"gopkg.in/yaml.v2"
@@ -45,6 +44,10 @@ const (
 	tabtabtabtabtabtabtab = tab + tab + tab + tab + tab + tab + tab
 	libraryPrefixOption   = "library_prefix"
 	useVendorOption       = "use_vendor"
+	pathTemplateOption    = "path_template"
+	nestedNamespaceOption = "nested_namespaces"
+	builtCollectionOption = "built_collections"
+	builtCollectionImport = "import 'package:built_collection/built_collection.dart';\n"
 )
 
 // Generator implements the LanguageGenerator interface for Dart.
@@ -68,71 +71,159 @@ func (g *Generator) getLibraryName() string {
 	return parser.LowercaseFirstLetter(g.Frugal.Name)
 }
 
+// nestedNamespaces indicates if dotted namespaces are kept as nested
+// directories and dotted library names rather than flattened with
+// underscores.
+func (g *Generator) nestedNamespaces() bool {
+	_, ok := g.Options[nestedNamespaceOption]
+	return ok
+}
+
+// getLibraryDeclarationName returns the name in the library declaration of
+// the export file, which is the dotted namespace if namespaces are nested.
+func (g *Generator) getLibraryDeclarationName() string {
+	if ns := g.Frugal.Namespace(lang); ns != nil && g.nestedNamespaces() {
+		return parser.LowercaseFirstLetter(ns.Value)
+	}
+	return g.getLibraryName()
+}
+
+// getLibraryDirName returns the name of the export file, without extension,
+// and of the directory of the library's sources next to it when generated
+// within another library. If namespaces are nested, it's the last component
+// of the namespace, since the preceding components are parent directories.
+func (g *Generator) getLibraryDirName() string {
+	if ns := g.Frugal.Namespace(lang); ns != nil && g.nestedNamespaces() {
+		components := strings.Split(ns.Value, ".")
+		return parser.LowercaseFirstLetter(components[len(components)-1])
+	}
+	return g.getLibraryName()
+}
+
 // SetupGenerator performs any setup logic before generation.
 func (g *Generator) SetupGenerator(outputDir string) error {
 	g.outputDir = outputDir
 
-	if g.getLibraryPrefix() == "" {
-		libDir := filepath.Join(outputDir, "lib", "src")
-		if err := os.MkdirAll(libDir, 0777); err != nil {
-			return err
+	if template, ok := g.Options[pathTemplateOption]; ok && !strings.Contains(template, "{name}") {
+		return fmt.Errorf("Dart %s option must contain {name}: %s", pathTemplateOption, template)
+	}
+	if g.nestedNamespaces() {
+		if _, ok := g.Options[libraryPrefixOption]; !ok {
+			return fmt.Errorf("Dart %s option requires the %s option, since package names can't be nested",
+				nestedNamespaceOption, libraryPrefixOption)
+		}
+		if _, ok := g.Options[pathTemplateOption]; ok {
+			return fmt.Errorf("Dart %s option can't be combined with the %s option",
+				nestedNamespaceOption, pathTemplateOption)
 		}
 	}
 
-	libraryName := g.getLibraryName()
-	file, err := os.Create(g.getExportFilePath(outputDir))
+	strategy, err := g.UnknownEnums()
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-
-	if err := g.GenerateDocStringComment(file); err != nil {
+	if strategy == generator.UnknownEnumsPreserve && g.useEnums() {
+		return fmt.Errorf("Dart %s=%s option can't be combined with the use_enums option, since enums can't hold undeclared values",
+			generator.UnknownEnumsOption, strategy)
+	}
+	if _, err := g.PrefixValidation(); err != nil {
+		return err
+	}
+	if err := g.CheckScopeProtocols("dart"); err != nil {
 		return err
 	}
 
-	contents := ""
-	contents += fmt.Sprintf("\n\nlibrary %s;\n\n", libraryName)
-
-	if len(g.Frugal.Constants) > 0 {
-		constantsName := fmt.Sprintf("%sConstants", snakeToCamel(libraryName))
-		contents += g.createExport(constantsName, false)
+	if g.getLibraryPrefix() == "" {
+		libDir := filepath.Join(outputDir, "lib", "src")
+		if err := os.MkdirAll(libDir, 0777); err != nil {
+			return err
+		}
 	}
-	for _, s := range g.Frugal.Structs {
-		contents += g.createExport(s.Name, false)
+
+	return nil
+}
+
+// Artifact types of generated source files, used in path templates.
+const (
+	typesArtifact    = "types"
+	servicesArtifact = "services"
+	scopesArtifact   = "scopes"
+)
+
+// sourcePath returns the path of the generated source file with the given
+// name, which excludes the extension, and artifact type. The path is built
+// from the "path_template" option, relative to the output root, if given and
+// is in the library's lib/src directory otherwise.
+func (g *Generator) sourcePath(outputDir, name, artifact string) string {
+	template, ok := g.Options[pathTemplateOption]
+	if !ok {
+		if _, ok := g.Options[libraryPrefixOption]; !ok {
+			outputDir = filepath.Join(outputDir, "lib", "src")
+		}
+		return filepath.Join(outputDir, fmt.Sprintf("%s.%s", name, lang))
+	}
+	path := strings.NewReplacer(
+		"{namespace}", filepath.Base(outputDir),
+		"{type}", artifact,
+		"{name}", name,
+	).Replace(template)
+	return filepath.Join(filepath.Dir(outputDir), filepath.FromSlash(path))
+}
+
+// exportPath returns the path of the generated source file with the given
+// name and artifact type relative to the library's export file.
+func (g *Generator) exportPath(name, artifact string) string {
+	dir := g.artifactDir(artifact)
+	if _, ok := g.Options[pathTemplateOption]; !ok && dir == g.outputDir {
+		srcDir := "src"
+		if _, ok := g.Options[libraryPrefixOption]; ok {
+			srcDir = g.getLibraryDirName()
+		}
+		return fmt.Sprintf("%s/%s.%s", srcDir, name, lang)
 	}
-	for _, union := range g.Frugal.Unions {
-		contents += g.createExport(union.Name, false)
+	exportDir, err := filepath.Abs(filepath.Dir(g.getExportFilePath(g.outputDir)))
+	if err != nil {
+		exportDir = filepath.Dir(g.getExportFilePath(g.outputDir))
 	}
-	for _, exception := range g.Frugal.Exceptions {
-		contents += g.createExport(exception.Name, false)
+	path := g.sourcePath(dir, name, artifact)
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
 	}
-	for _, enum := range g.Frugal.Enums {
-		contents += g.createExport(enum.Name, true)
+	if rel, err := filepath.Rel(exportDir, path); err == nil {
+		path = rel
 	}
-
-	_, err = file.WriteString(contents)
-	return err
+	return filepath.ToSlash(path)
 }
 
-func (g *Generator) createExport(structName string, isEnum bool) string {
-	srcDir := "src"
-	if _, ok := g.Options[libraryPrefixOption]; ok {
-		srcDir = g.getLibraryName()
+// artifactDir returns the output directory of the given artifact type, which
+// differs from the library's when generated apart from its types.
+func (g *Generator) artifactDir(artifact string) string {
+	option := ""
+	switch artifact {
+	case servicesArtifact:
+		option = generator.ServicesOutOption
+	case scopesArtifact:
+		option = generator.ScopesOutOption
 	}
-	if !isEnum || !g.useEnums() {
-		return fmt.Sprintf("export '%s/f_%s.dart' show %s;\n",
-			srcDir, toFileName(structName), structName)
+	if option == "" || !g.ArtifactsSeparated(option) {
+		return g.outputDir
 	}
-	return fmt.Sprintf("export '%s/f_%s.dart' show %s, serialize%s, deserialize%s;\n",
-		srcDir, toFileName(structName), structName, structName, structName)
+	return g.GetOutputDir(g.Options[option])
 }
 
 // TeardownGenerator is run after generation.
-func (g *Generator) TeardownGenerator() error { return nil }
+func (g *Generator) TeardownGenerator() error {
+	if globals.TestVectors {
+		return g.generateTestVectorsTest()
+	}
+	return nil
+}
 
 // GetOutputDir returns the output directory for generated files.
 func (g *Generator) GetOutputDir(dir string) string {
-	if namespace := g.Frugal.Namespace(lang); namespace != nil {
+	if namespace := g.Frugal.Namespace(lang); namespace != nil && g.nestedNamespaces() {
+		dir = filepath.Join(dir, filepath.Join(strings.Split(namespace.Value, ".")...))
+	} else if namespace != nil {
 		dir = filepath.Join(dir, toLibraryName(namespace.Value))
 	} else {
 		dir = filepath.Join(dir, g.Frugal.Name)
@@ -158,15 +249,16 @@ func (g *Generator) GenerateDependencies(dir string) error {
 	if err := g.exportClasses(dir); err != nil {
 		return err
 	}
-	return nil
+	return g.generatePlatformTransport()
 }
 
 type pubspec struct {
-	Name         string                      `yaml:"name"`
-	Version      string                      `yaml:"version"`
-	Description  string                      `yaml:"description"`
-	Environment  env                         `yaml:"environment"`
-	Dependencies map[interface{}]interface{} `yaml:"dependencies"`
+	Name            string                      `yaml:"name"`
+	Version         string                      `yaml:"version"`
+	Description     string                      `yaml:"description"`
+	Environment     env                         `yaml:"environment"`
+	Dependencies    map[interface{}]interface{} `yaml:"dependencies"`
+	DevDependencies map[interface{}]interface{} `yaml:"dev_dependencies,omitempty"`
 }
 
 type env struct {
@@ -177,6 +269,7 @@ type dep struct {
 	Hosted  hostedDep `yaml:"hosted,omitempty"`
 	Git     gitDep    `yaml:"git,omitempty"`
 	Path    string    `yaml:"path,omitempty"`
+	SDK     string    `yaml:"sdk,omitempty"`
 	Version string    `yaml:"version,omitempty"`
 }
 
@@ -187,25 +280,38 @@ type hostedDep struct {
 
 type gitDep struct {
 	URL string `yaml:"url"`
-	Ref string `yaml:"ref"`
+	Ref string `yaml:"ref,omitempty"`
 }
 
 func (g *Generator) addToPubspec(dir string) error {
 	pubFilePath := filepath.Join(dir, "pubspec.yaml")
 
+	thrift, err := g.dependency(runtimeDep{name: "thrift", defaultVersion: "^0.0.7"})
+	if err != nil {
+		return err
+	}
 	deps := map[interface{}]interface{}{
 		"logging": "^0.11.2",
-		"thrift": dep{
-			Hosted:  hostedDep{Name: "thrift", URL: "https://pub.workiva.org"},
-			Version: "^0.0.7",
-		},
+		"thrift":  thrift,
+	}
+	if g.useBuiltCollections() {
+		deps["built_collection"] = "^1.0.0"
+	}
+	if g.useFlutterCompute() && len(g.Frugal.Scopes) > 0 {
+		deps["flutter"] = dep{SDK: "flutter"}
+	}
+	sdk := minimumDartVersion
+	if g.useBrowser() {
+		deps["w_transport"] = ">=2.9.4 <4.0.0"
+		sdk = minimumBrowserDartVersion
 	}
 
 	if g.Frugal.ContainsFrugalDefinitions() {
-		deps["frugal"] = dep{
-			Hosted:  hostedDep{Name: "frugal", URL: "https://pub.workiva.org"},
-			Version: fmt.Sprintf("^%s", globals.Version),
+		frugal, err := g.dependency(runtimeDep{name: "frugal", defaultVersion: fmt.Sprintf("^%s", globals.Version)})
+		if err != nil {
+			return err
 		}
+		deps["frugal"] = frugal
 	}
 
 	includesSet := make(map[string]bool) // include.Name ---> include.Annotations.Vendor()
@@ -242,7 +348,7 @@ func (g *Generator) addToPubspec(dir string) error {
 		if g.UseVendor() && includesSet[include] {
 			vendorPath, _ := namespace.Annotations.Vendor()
 			deps[toLibraryName(vendorPath)] = dep{
-				Hosted:  hostedDep{Name: toLibraryName(vendorPath), URL: "https://pub.workiva.org"},
+				Hosted:  hostedDep{Name: toLibraryName(vendorPath), URL: defaultPubURL},
 				Version: "any",
 			}
 		} else {
@@ -261,10 +367,13 @@ func (g *Generator) addToPubspec(dir string) error {
 		Version:     globals.Version,
 		Description: "Autogenerated by the frugal compiler",
 		Environment: env{
-			SDK: "^" + minimumDartVersion,
+			SDK: "^" + sdk,
 		},
 		Dependencies: deps,
 	}
+	if globals.TestVectors {
+		ps.DevDependencies = map[interface{}]interface{}{"test": testPackageVersion}
+	}
 
 	d, err := yaml.Marshal(&ps)
 	if err != nil {
@@ -283,7 +392,7 @@ func (g *Generator) addToPubspec(dir string) error {
 }
 
 func (g *Generator) getExportFilePath(dir string) string {
-	libName := g.getLibraryName()
+	libName := g.getLibraryDirName()
 	dartFile := fmt.Sprintf("%s.%s", libName, lang)
 
 	if _, ok := g.Options[libraryPrefixOption]; ok {
@@ -292,58 +401,15 @@ func (g *Generator) getExportFilePath(dir string) string {
 	return filepath.Join(dir, "lib", dartFile)
 }
 
-func (g *Generator) exportClasses(dir string) error {
-	filename := g.getLibraryName()
-	mainFilePath := g.getExportFilePath(dir)
-	mainFile, err := os.OpenFile(mainFilePath, syscall.O_RDWR, 0777)
-	defer mainFile.Close()
-	if err != nil {
-		return err
-	}
-
-	exports := "\n"
-	for _, service := range g.Frugal.Services {
-		servSrcDir := "src"
-		if _, ok := g.Options[libraryPrefixOption]; ok {
-			servSrcDir = filename
-		}
-
-		servTitle := strings.Title(service.Name)
-		exports += fmt.Sprintf("export '%s/%s%s%s.%s' show F%s;\n",
-			servSrcDir, generator.FilePrefix, toFileName(service.Name), serviceSuffix, lang, servTitle)
-		exports += fmt.Sprintf("export '%s/%s%s%s.%s' show F%sClient;\n",
-			servSrcDir, generator.FilePrefix, toFileName(service.Name), serviceSuffix, lang, servTitle)
-	}
-	for _, scope := range g.Frugal.Scopes {
-		scopeSrcDir := "src"
-		if _, ok := g.Options[libraryPrefixOption]; ok {
-			scopeSrcDir = filename
-		}
-		scopeTitle := strings.Title(scope.Name)
-		exports += fmt.Sprintf("export '%s/%s%s%s.%s' show %sPublisher, %sSubscriber;\n",
-			scopeSrcDir, generator.FilePrefix, toFileName(scope.Name), scopeSuffix, lang, scopeTitle, scopeTitle)
-	}
-	stat, err := mainFile.Stat()
-	if err != nil {
-		return err
-	}
-	_, err = mainFile.WriteAt([]byte(exports), stat.Size())
-	return err
-}
-
 // GenerateFile generates the given FileType.
 func (g *Generator) GenerateFile(name, outputDir string, fileType generator.FileType) (*os.File, error) {
-	if _, ok := g.Options[libraryPrefixOption]; !ok {
-		outputDir = filepath.Join(outputDir, "lib")
-		outputDir = filepath.Join(outputDir, "src")
-	}
 	switch fileType {
 	case generator.CombinedServiceFile:
-		return g.CreateFile(toFileName(name)+serviceSuffix, outputDir, lang, true)
+		return g.createSourceFile(outputDir, toFileName(name)+serviceSuffix, servicesArtifact)
 	case generator.CombinedScopeFile:
-		return g.CreateFile(toFileName(name)+scopeSuffix, outputDir, lang, true)
+		return g.createSourceFile(outputDir, toFileName(name)+scopeSuffix, scopesArtifact)
 	case generator.ObjectFile:
-		file, err := g.CreateFile(toFileName(name), outputDir, lang, true)
+		file, err := g.createSourceFile(outputDir, toFileName(name), typesArtifact)
 		if err != nil {
 			return file, err
 		}
@@ -360,6 +426,16 @@ func (g *Generator) GenerateFile(name, outputDir string, fileType generator.File
 	}
 }
 
+// createSourceFile creates the generated source file with the given name and
+// artifact type.
+func (g *Generator) createSourceFile(outputDir, name, artifact string) (*os.File, error) {
+	path := g.sourcePath(outputDir, generator.FilePrefix+name, artifact)
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return nil, err
+	}
+	return os.Create(path)
+}
+
 // GenerateDocStringComment generates the autogenerated notice.
 func (g *Generator) GenerateDocStringComment(file *os.File) error {
 	comment := fmt.Sprintf(
@@ -456,7 +532,9 @@ func (g *Generator) generateConstantValue(t *parser.Type, value interface{}, ind
 			return fmt.Sprintf("new Uint8List.fromList(UTF8.encode('%s'))", value)
 		case "list", "set":
 			contents := ""
-			if underlyingType.Name == "set" {
+			if g.useBuiltCollections() {
+				contents += fmt.Sprintf("new %s(", g.getDartTypeFromThriftType(underlyingType))
+			} else if underlyingType.Name == "set" {
 				contents += fmt.Sprintf("new Set<%s>.from(", underlyingType.ValueType)
 			}
 			contents += "[\n"
@@ -465,18 +543,25 @@ func (g *Generator) generateConstantValue(t *parser.Type, value interface{}, ind
 				contents += fmt.Sprintf(tabtab+ind+"%s,\n", val)
 			}
 			contents += tab + ind + "]"
-			if underlyingType.Name == "set" {
+			if g.useBuiltCollections() || underlyingType.Name == "set" {
 				contents += ")"
 			}
 			return contents
 		case "map":
-			contents := "{\n"
+			contents := ""
+			if g.useBuiltCollections() {
+				contents += fmt.Sprintf("new %s(", g.getDartTypeFromThriftType(underlyingType))
+			}
+			contents += "{\n"
 			for _, pair := range value.([]parser.KeyValue) {
 				key := g.generateConstantValue(underlyingType.KeyType, pair.Key, ind+tab)
 				val := g.generateConstantValue(underlyingType.ValueType, pair.Value, ind+tab)
 				contents += fmt.Sprintf(tabtab+ind+"%s: %s,\n", key, val)
 			}
 			contents += tab + ind + "}"
+			if g.useBuiltCollections() {
+				contents += ")"
+			}
 			return contents
 		}
 	} else if g.Frugal.IsEnum(underlyingType) {
@@ -584,7 +669,11 @@ func (g *Generator) generateEnumUsingEnums(enum *parser.Enum) string {
 		contents += fmt.Sprintf(tabtabtab+"return %s.%s;\n", enum.Name, field.Name)
 	}
 	contents += tabtab + "default:\n"
-	contents += fmt.Sprintf(tabtabtab+"throw new thrift.TProtocolError(thrift.TProtocolErrorType.UNKNOWN, \"Invalid value '$value' for enum '%s'\");", enum.Name)
+	if g.Options[generator.UnknownEnumsOption] == generator.UnknownEnumsSentinel {
+		contents += fmt.Sprintf(tabtabtab+"return %s.%s;\n", enum.Name, generator.UnknownEnumValue)
+	} else {
+		contents += fmt.Sprintf(tabtabtab+"throw new thrift.TProtocolError(thrift.TProtocolErrorType.UNKNOWN, \"Invalid value '$value' for enum '%s'\");", enum.Name)
+	}
 
 	contents += tab + "}\n"
 	contents += "}\n"
@@ -603,7 +692,7 @@ func (g *Generator) GenerateStruct(s *parser.Struct) error {
 		return err
 	}
 
-	contents := g.generateStruct(s)
+	contents := g.generateStruct(s, false)
 	_, err = file.WriteString(contents)
 	return err
 }
@@ -623,12 +712,12 @@ func (g *Generator) GenerateException(s *parser.Struct) error {
 func (g *Generator) generateServiceArgsResults(service *parser.Service) string {
 	contents := ""
 	for _, s := range g.GetServiceMethodTypes(service) {
-		contents += g.generateStruct(s)
+		contents += g.generateStruct(s, true)
 	}
 	return contents
 }
 
-func (g *Generator) generateStruct(s *parser.Struct) string {
+func (g *Generator) generateStruct(s *parser.Struct, isArgOrResult bool) string {
 	contents := ""
 
 	// Class declaration
@@ -699,6 +788,16 @@ func (g *Generator) generateStruct(s *parser.Struct) string {
 	// clone
 	contents += g.generateClone(s)
 
+	// deep copy and merge
+	if !isArgOrResult {
+		contents += g.generateCopyMethods(s)
+	}
+
+	// JSON
+	if !isArgOrResult {
+		contents += g.generateJSONMethods(s)
+	}
+
 	// validate
 	contents += g.generateValidate(s)
 
@@ -893,6 +992,7 @@ func (g *Generator) generateReadFieldRec(field *parser.Field, first bool, ind st
 				prefix, fName, g.includeQualifier(underlyingType), underlyingType.Name)
 		} else {
 			contents += fmt.Sprintf(ind+"%s%s = iprot.readI32();\n", prefix, fName)
+			contents += g.generateUnknownEnumCheck(fName, underlyingType, ind)
 		}
 
 		if first {
@@ -908,6 +1008,10 @@ func (g *Generator) generateReadFieldRec(field *parser.Field, first bool, ind st
 		valContents := g.generateReadFieldRec(valField, false, ind+tab)
 		counterElem := g.GetElem()
 		dartType := g.getDartTypeFromThriftType(underlyingType)
+		if g.useBuiltCollections() {
+			contents += g.generateReadBuiltCollection(underlyingType, prefix+fName, containerElem, valElem, valContents, counterElem, ind)
+			return contents
+		}
 
 		switch underlyingType.Name {
 		case "list":
@@ -947,6 +1051,44 @@ func (g *Generator) generateReadFieldRec(field *parser.Field, first bool, ind st
 	return contents
 }
 
+// generateReadBuiltCollection generates the code reading a list, set, or map
+// into a builder of the built_collection type and assigning the built
+// collection to the given variable.
+func (g *Generator) generateReadBuiltCollection(underlyingType *parser.Type, variable, containerElem, valElem, valContents, counterElem, ind string) string {
+	contents := ""
+	builderElem := g.GetElem()
+	valueType := g.getDartTypeFromThriftType(underlyingType.ValueType)
+	add := fmt.Sprintf(tab+ind+"%s.add(%s);\n", builderElem, valElem)
+
+	switch underlyingType.Name {
+	case "list":
+		contents += fmt.Sprintf(ind+"thrift.TList %s = iprot.readListBegin();\n", containerElem)
+		contents += fmt.Sprintf(ind+"var %s = new ListBuilder<%s>();\n", builderElem, valueType)
+	case "set":
+		contents += fmt.Sprintf(ind+"thrift.TSet %s = iprot.readSetBegin();\n", containerElem)
+		contents += fmt.Sprintf(ind+"var %s = new SetBuilder<%s>();\n", builderElem, valueType)
+	case "map":
+		contents += fmt.Sprintf(ind+"thrift.TMap %s = iprot.readMapBegin();\n", containerElem)
+		contents += fmt.Sprintf(ind+"var %s = new MapBuilder<%s, %s>();\n",
+			builderElem, g.getDartTypeFromThriftType(underlyingType.KeyType), valueType)
+	default:
+		panic("unrecognized thrift type: " + underlyingType.Name)
+	}
+	contents += fmt.Sprintf(ind+"for(int %s = 0; %s < %s.length; ++%s) {\n", counterElem, counterElem, containerElem, counterElem)
+	if underlyingType.Name == "map" {
+		keyElem := g.GetElem()
+		keyField := parser.FieldFromType(underlyingType.KeyType, keyElem)
+		contents += g.generateReadFieldRec(keyField, false, ind+tab)
+		add = fmt.Sprintf(tab+ind+"%s[%s] = %s;\n", builderElem, keyElem, valElem)
+	}
+	contents += valContents
+	contents += add
+	contents += ind + "}\n"
+	contents += fmt.Sprintf(ind+"iprot.read%sEnd();\n", strings.Title(underlyingType.Name))
+	contents += fmt.Sprintf(ind+"%s = %s.build();\n", variable, builderElem)
+	return contents
+}
+
 func (g *Generator) generateWrite(s *parser.Struct) string {
 	contents := tab + "write(thrift.TProtocol oprot) {\n"
 	contents += tabtab + "validate();\n\n"
@@ -1194,6 +1336,118 @@ func (g *Generator) generateClone(s *parser.Struct) string {
 	return contents
 }
 
+// generateCopyMethods generates deepCopy and, for structs and exceptions,
+// mergeFrom methods when the copy_merge option is set. mergeFrom sets each
+// field set in another instance to a deep copy of it.
+func (g *Generator) generateCopyMethods(s *parser.Struct) string {
+	if _, ok := g.Options[generator.CopyMergeOption]; !ok {
+		return ""
+	}
+
+	contents := fmt.Sprintf(tab+"/// Returns a copy of this %s sharing no mutable state with it.\n", s.Name)
+	contents += fmt.Sprintf(tab+"%s deepCopy() {\n", s.Name)
+	contents += fmt.Sprintf(tabtab+"return new %s()", s.Name)
+	for _, field := range s.Fields {
+		fName := toFieldName(field.Name)
+		contents += fmt.Sprintf("\n"+tabtabtab+".._%s = %s", fName, g.copyValue("this._"+fName, field.Type, 0))
+		if g.isDartPrimitive(field.Type) {
+			contents += fmt.Sprintf("\n"+tabtabtab+"..__isset_%s = this.__isset_%s", fName, fName)
+		}
+	}
+	contents += ";\n"
+	contents += tab + "}\n\n"
+
+	if s.Type == parser.StructTypeUnion {
+		return contents
+	}
+
+	contents += tab + "/// Sets each field set in [other] to a deep copy of it.\n"
+	contents += fmt.Sprintf(tab+"void mergeFrom(%s other) {\n", s.Name)
+	for _, field := range s.Fields {
+		fName := toFieldName(field.Name)
+		contents += fmt.Sprintf(tabtab+"if(other.isSet%s()) {\n", strings.Title(field.Name))
+		contents += fmt.Sprintf(tabtabtab+"this.%s = %s;\n", fName, g.copyValue("other."+fName, field.Type, 0))
+		contents += tabtab + "}\n"
+	}
+	contents += tab + "}\n\n"
+	return contents
+}
+
+// copyValue returns an expression deep copying the given value of the given
+// type. Built collections are immutable and only copied if their elements
+// need to be.
+func (g *Generator) copyValue(value string, t *parser.Type, depth int) string {
+	if !g.needsCopy(t) {
+		return value
+	}
+
+	underlyingType := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsStruct(underlyingType) {
+		return value + "?.deepCopy()"
+	}
+
+	copied := ""
+	switch underlyingType.Name {
+	case "binary":
+		copied = fmt.Sprintf("new Uint8List.fromList(%s)", value)
+	case "list", "set":
+		elemType := g.getDartTypeFromThriftType(underlyingType.ValueType)
+		container := strings.Title(underlyingType.Name)
+		elems := value
+		if g.needsCopy(underlyingType.ValueType) {
+			elem := fmt.Sprintf("e%d", depth)
+			elems = fmt.Sprintf("%s.map((%s) => %s)", value, elem, g.copyValue(elem, underlyingType.ValueType, depth+1))
+		}
+		if g.useBuiltCollections() {
+			copied = fmt.Sprintf("new Built%s<%s>(%s)", container, elemType, elems)
+		} else {
+			copied = fmt.Sprintf("new %s<%s>.from(%s)", container, elemType, elems)
+		}
+	case "map":
+		mapType := fmt.Sprintf("Map<%s, %s>", g.getDartTypeFromThriftType(underlyingType.KeyType),
+			g.getDartTypeFromThriftType(underlyingType.ValueType))
+		if g.needsCopy(underlyingType.KeyType) || g.needsCopy(underlyingType.ValueType) {
+			// Keys and values iterate in the same order
+			keys := value + ".keys"
+			if g.needsCopy(underlyingType.KeyType) {
+				key := fmt.Sprintf("k%d", depth)
+				keys = fmt.Sprintf("%s.map((%s) => %s)", keys, key, g.copyValue(key, underlyingType.KeyType, depth+1))
+			}
+			values := value + ".values"
+			if g.needsCopy(underlyingType.ValueType) {
+				val := fmt.Sprintf("v%d", depth)
+				values = fmt.Sprintf("%s.map((%s) => %s)", values, val, g.copyValue(val, underlyingType.ValueType, depth+1))
+			}
+			copied = fmt.Sprintf("new %s.fromIterables(%s, %s)", mapType, keys, values)
+		} else {
+			copied = fmt.Sprintf("new %s.from(%s)", mapType, value)
+		}
+		if g.useBuiltCollections() {
+			copied = fmt.Sprintf("new Built%s(%s)", mapType, copied)
+		}
+	}
+	return fmt.Sprintf("%s == null ? null : %s", value, copied)
+}
+
+// needsCopy returns true if values of the given type are mutable or contain
+// mutable values.
+func (g *Generator) needsCopy(t *parser.Type) bool {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsStruct(underlyingType) {
+		return true
+	}
+	switch underlyingType.Name {
+	case "binary":
+		return true
+	case "list", "set":
+		return !g.useBuiltCollections() || g.needsCopy(underlyingType.ValueType)
+	case "map":
+		return !g.useBuiltCollections() || g.needsCopy(underlyingType.KeyType) || g.needsCopy(underlyingType.ValueType)
+	default:
+		return false
+	}
+}
+
 func (g *Generator) generateValidate(s *parser.Struct) string {
 	contents := tab + "validate() {\n"
 
@@ -1222,7 +1476,14 @@ func (g *Generator) generateValidate(s *parser.Struct) string {
 		contents += tabtab + "}\n"
 	}
 
-	if !g.useEnums() {
+	if s.HasConstraints() {
+		contents += tabtab + "// check field constraints\n"
+		for _, field := range s.Fields {
+			contents += g.generateConstraintChecks(s, field)
+		}
+	}
+
+	if !g.useEnums() && g.Options[generator.UnknownEnumsOption] != generator.UnknownEnumsPreserve {
 		contents += tabtab + "// check that fields of type enum have valid values\n"
 		for _, field := range s.Fields {
 			if g.Frugal.IsEnum(field.Type) {
@@ -1257,7 +1518,12 @@ func (g *Generator) GenerateObjectPackage(file *os.File, name string) error {
 
 // GenerateThriftImports generates necessary imports for Thrift.
 func (g *Generator) GenerateThriftImports() (string, error) {
-	imports := "import 'dart:typed_data' show Uint8List;\n"
+	imports := ""
+	if g.generateJSONHelpers() {
+		imports += "import 'dart:convert' show BASE64;\n"
+	}
+	imports += "import 'dart:typed_data' show Uint8List;\n"
+	imports += g.generateBuiltCollectionImport()
 	imports += "import 'package:thrift/thrift.dart' as thrift;\n"
 	// Import the current package
 	imports += g.getImportDeclaration(g.getNamespaceOrName(), g.getPackagePrefix())
@@ -1293,6 +1559,7 @@ func (g *Generator) writeThriftImports(file *os.File) error {
 func (g *Generator) GenerateServiceImports(file *os.File, s *parser.Service) error {
 	imports := "import 'dart:async';\n\n"
 	imports += "import 'dart:typed_data' show Uint8List;\n"
+	imports += g.generateBuiltCollectionImport()
 	imports += "import 'package:logging/logging.dart' as logging;\n"
 	imports += "import 'package:thrift/thrift.dart' as thrift;\n"
 	imports += "import 'package:frugal/frugal.dart' as frugal;\n\n"
@@ -1320,6 +1587,8 @@ func (g *Generator) GenerateServiceImports(file *os.File, s *parser.Service) err
 func (g *Generator) GenerateScopeImports(file *os.File, s *parser.Scope) error {
 	imports := "import 'dart:async';\n"
 	imports += "import 'dart:typed_data' show Uint8List;\n\n"
+	imports += g.generateBuiltCollectionImport()
+	imports += g.generateFlutterComputeImport()
 	imports += "import 'package:thrift/thrift.dart' as thrift;\n"
 	imports += "import 'package:frugal/frugal.dart' as frugal;\n\n"
 	// import included packages
@@ -1351,14 +1620,19 @@ func (g *Generator) GenerateConstants(file *os.File, name string) error {
 
 // GeneratePublisher generates the publisher for the given scope.
 func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error {
+	g.WarnUnsupportedChunks("dart", scope)
+	g.WarnUnsupportedSampling("dart", scope)
+
 	publishers := ""
-	if scope.Comment != nil {
-		publishers += g.GenerateInlineComment(scope.Comment, "/")
+	if comment := scope.DocComment(); comment != nil {
+		publishers += g.GenerateInlineComment(comment, "/")
 	}
 	publishers += fmt.Sprintf("class %sPublisher {\n", strings.Title(scope.Name))
+	publishers += g.generateContractMetadata(g.ScopeMetadata(scope)) + "\n"
 	publishers += tab + "frugal.FPublisherTransport transport;\n"
 	publishers += tab + "frugal.FProtocolFactory protocolFactory;\n"
 	publishers += tab + "Map<String, frugal.FMethod> _methods;\n"
+	publishers += g.generateHookField("onBeforePublish", "before each publish")
 
 	publishers += fmt.Sprintf(tab+"%sPublisher(frugal.FScopeProvider provider, [List<frugal.Middleware> middleware]) {\n", strings.Title(scope.Name))
 	publishers += tabtab + "transport = provider.publisherTransportFactory.getTransport();\n"
@@ -1369,6 +1643,10 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 	for _, operation := range scope.Operations {
 		publishers += fmt.Sprintf(tabtab+"this._methods['%s'] = new frugal.FMethod(this._publish%s, '%s', 'publish%s', combined);\n",
 			operation.Name, operation.Name, strings.Title(scope.Name), operation.Name)
+		if g.useBatchPublish() {
+			publishers += fmt.Sprintf(tabtab+"this._methods['%sBatch'] = new frugal.FMethod(this._publish%sBatch, '%s', 'publish%sBatch', combined);\n",
+				operation.Name, operation.Name, strings.Title(scope.Name), operation.Name)
+		}
 	}
 	publishers += tab + "}\n\n"
 
@@ -1379,6 +1657,7 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 	publishers += tab + "Future close() {\n"
 	publishers += tabtab + "return transport.close();\n"
 	publishers += tab + "}\n\n"
+	publishers += g.generatePrefixVariableMethod(scope)
 
 	args := ""
 	argsWithoutTypes := ""
@@ -1392,17 +1671,24 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 	for _, op := range scope.Operations {
 		publishers += prefix
 		prefix = "\n\n"
-		if op.Comment != nil {
-			publishers += g.generateDocComment(op.Comment, tab)
+		if comment := op.DocComment(); comment != nil {
+			publishers += g.generateDocComment(comment, tab)
 		}
 
-		publishers += fmt.Sprintf(tab+"Future publish%s(frugal.FContext ctx, %s%s req) {\n", op.Name, args, g.getDartTypeFromThriftType(op.Type))
+		publishers += fmt.Sprintf(tab+"Future publish%s(frugal.FContext ctx, %s%s req, {Duration timeout}) {\n", op.Name, args, g.getDartTypeFromThriftType(op.Type))
 
-		publishers += fmt.Sprintf(tabtab+"return this._methods['%s']([ctx, %sreq]);\n", op.Name, argsWithoutTypes)
+		publishers += fmt.Sprintf(tabtab+"var publish = this._methods['%s']([ctx, %sreq]);\n", op.Name, argsWithoutTypes)
+		publishers += tabtab + "if (timeout == null) {\n"
+		publishers += tabtabtab + "return publish;\n"
+		publishers += tabtab + "}\n"
+		publishers += tabtab + "return publish.timeout(timeout, onTimeout: () =>\n"
+		publishers += fmt.Sprintf(tabtabtabtab+"throw new frugal.FTimeoutError('frugal: publish of %s timed out'));\n", op.Name)
 		publishers += tab + "}\n\n"
 
 		publishers += fmt.Sprintf(tab+"Future _publish%s(frugal.FContext ctx, %s%s req) async {\n", op.Name, args, g.getDartTypeFromThriftType(op.Type))
 
+		publishers += g.generatePrefixVariableChecks(scope)
+
 		// Inject the prefix variables into the FContext to send
 		for _, prefixVar := range scope.Prefix.Variables {
 			publishers += fmt.Sprintf(tabtab+"ctx.addRequestHeader('_topic_%s', %s);\n", prefixVar, prefixVar)
@@ -1410,19 +1696,36 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 
 		publishers += tabtab + fmt.Sprintf("var op = \"%s\";\n", op.Name)
 		publishers += tabtab + fmt.Sprintf("var prefix = \"%s\";\n", generatePrefixStringTemplate(scope))
-		publishers += tabtab + "var topic = \"${prefix}" + strings.Title(scope.Name) + "${delimiter}${op}\";\n"
-		publishers += tabtab + "var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);\n"
-		publishers += tabtab + "var oprot = protocolFactory.getProtocol(memoryBuffer);\n"
-		publishers += tabtab + "var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);\n"
-		publishers += tabtab + "oprot.writeRequestHeader(ctx);\n"
-		publishers += tabtab + "oprot.writeMessageBegin(msg);\n"
-		publishers += g.generateWriteFieldRec(parser.FieldFromType(op.Type, "req"), false, "")
-		publishers += tabtab + "oprot.writeMessageEnd();\n"
-		publishers += tabtab + "await transport.publish(topic, memoryBuffer.writeBytes);\n"
+		publishers += tabtab + "var topic = \"${prefix}" + scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter) + "${delimiter}${op}\";\n"
+		publishers += g.generateHookCall("onBeforePublish", "op", tabtab)
+		publishers += tabtab + "try {\n"
+		publishers += tabtabtab + "var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);\n"
+		publishers += tabtabtab + "var oprot = protocolFactory.getProtocol(memoryBuffer);\n"
+		publishers += tabtabtab + "var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);\n"
+		publishers += tabtabtab + "oprot.writeRequestHeader(ctx);\n"
+		publishers += tabtabtab + "oprot.writeMessageBegin(msg);\n"
+		publishers += g.generateWriteFieldRec(parser.FieldFromType(op.Type, "req"), false, tab)
+		publishers += tabtabtab + "oprot.writeMessageEnd();\n"
+		publishers += tabtabtab + "await transport.publish(topic, memoryBuffer.writeBytes);\n"
+		// Translate transport errors into typed frugal errors so callers can
+		// branch on the cause of a failed publish.
+		publishers += tabtab + "} on thrift.TTransportError catch (e) {\n"
+		publishers += tabtabtab + "throw frugal.translateTransportError(e);\n"
+		publishers += tabtab + "}\n"
 		publishers += tab + "}\n"
+		if g.useBatchPublish() {
+			publishers += "\n"
+			publishers += g.generatePublishBatchMethod(scope, op, args, argsWithoutTypes)
+		}
 	}
 
 	publishers += "}\n"
+	if g.useResilientPublishers() {
+		publishers += "\n\n" + g.generateResilientPublisher(scope)
+	}
+	if scope.HasReplyOperations() {
+		publishers += "\n\n" + g.generateRequester(scope, args, argsWithoutTypes)
+	}
 
 	_, err := file.WriteString(publishers)
 	return err
@@ -1449,71 +1752,396 @@ func generatePrefixStringTemplate(scope *parser.Scope) string {
 // GenerateSubscriber generates the subscriber for the given scope.
 func (g *Generator) GenerateSubscriber(file *os.File, scope *parser.Scope) error {
 	subscribers := ""
-	if scope.Comment != nil {
-		subscribers += g.GenerateInlineComment(scope.Comment, "/")
+	if comment := scope.DocComment(); comment != nil {
+		subscribers += g.GenerateInlineComment(comment, "/")
 	}
 	subscribers += fmt.Sprintf("class %sSubscriber {\n", strings.Title(scope.Name))
+	subscribers += g.generateContractMetadata(g.ScopeMetadata(scope)) + "\n"
 	subscribers += tab + "final frugal.FScopeProvider provider;\n"
-	subscribers += tab + "final List<frugal.Middleware> _middleware;\n\n"
+	subscribers += tab + "final List<frugal.Middleware> _middleware;\n"
+	subscribers += g.generateHookField("onAfterReceive", "after each message is received")
+	subscribers += "\n"
 
 	subscribers += tab + fmt.Sprintf("%sSubscriber(this.provider, [List<frugal.Middleware> middleware])\n", strings.Title(scope.Name))
 	subscribers += tabtabtab + ": this._middleware = middleware ?? [] {\n"
 	subscribers += tabtab + "this._middleware.addAll(provider.middleware);\n"
 	subscribers += "}\n\n"
+	subscribers += g.generatePrefixVariableMethod(scope)
 
 	args := ""
+	argNames := ""
 	if len(scope.Prefix.Variables) > 0 {
 		for _, variable := range scope.Prefix.Variables {
 			args = fmt.Sprintf("%sString %s, ", args, variable)
+			argNames = fmt.Sprintf("%s%s, ", argNames, variable)
 		}
 	}
 	prefix := ""
 	for _, op := range scope.Operations {
 		subscribers += prefix
 		prefix = "\n\n"
-		if op.Comment != nil {
-			subscribers += g.generateDocComment(op.Comment, tab)
+		if comment := op.DocComment(); comment != nil {
+			subscribers += g.generateDocComment(comment, tab)
 		}
-		subscribers += fmt.Sprintf(tab+"Future<frugal.FSubscription> subscribe%s(%sdynamic on%s(frugal.FContext ctx, %s req)) async {\n",
-			op.Name, args, op.Type.ParamName(), g.getDartTypeFromThriftType(op.Type))
+		subscribers += fmt.Sprintf(tab+"Future<frugal.FSubscription> subscribe%s(%sdynamic on%s(frugal.FContext ctx, %s req), %s) async {\n",
+			op.Name, args, op.Type.ParamName(), g.getDartTypeFromThriftType(op.Type), subscribePolicyParams)
+		subscribers += g.generatePrefixVariableChecks(scope)
 		subscribers += fmt.Sprintf(tabtab+"var op = \"%s\";\n", op.Name)
 		subscribers += fmt.Sprintf(tabtab+"var prefix = \"%s\";\n", generatePrefixStringTemplate(scope))
-		subscribers += tabtab + "var topic = \"${prefix}" + strings.Title(scope.Name) + "${delimiter}${op}\";\n"
+		subscribers += tabtab + "var topic = \"${prefix}" + scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter) + "${delimiter}${op}\";\n"
 		subscribers += tabtab + "var transport = provider.subscriberTransportFactory.getTransport();\n"
-		subscribers += fmt.Sprintf(tabtab+"await transport.subscribe(topic, _recv%s(op, provider.protocolFactory, on%s));\n",
-			op.Name, op.Type.ParamName())
-		subscribers += tabtab + "return new frugal.FSubscription(topic, transport);\n"
+		subscribers += generateSubscribeWithPolicy(
+			g.wrapBatchFrameCallback(fmt.Sprintf("_recv%s(op, provider.protocolFactory, on%s)", op.Name, op.Type.ParamName())))
 		subscribers += tab + "}\n\n"
 
+		subscribers += g.generateSubscribeStream(op, args, argNames)
+
 		subscribers += fmt.Sprintf(tab+"frugal.FAsyncCallback _recv%s(String op, frugal.FProtocolFactory protocolFactory, dynamic on%s(frugal.FContext ctx, %s req)) {\n",
 			op.Name, op.Type.ParamName(), g.getDartTypeFromThriftType(op.Type))
 		subscribers += fmt.Sprintf(tabtab+"frugal.FMethod method = new frugal.FMethod(on%s, '%s', 'subscribe%s', this._middleware);\n",
 			op.Type.ParamName(), strings.Title(scope.Name), op.Type.ParamName())
+		if g.useFlutterCompute() {
+			subscribers += g.generateIsolateRecv(op)
+			continue
+		}
 		subscribers += fmt.Sprintf(tabtab+"callback%s(thrift.TTransport transport) {\n", op.Name)
 
 		subscribers += tabtabtab + "var iprot = protocolFactory.getProtocol(transport);\n"
-		subscribers += tabtabtab + "var ctx = iprot.readRequestHeader();\n"
-		subscribers += tabtabtab + "var tMsg = iprot.readMessageBegin();\n"
-		subscribers += tabtabtab + "if (tMsg.name != op) {\n"
-		subscribers += tabtabtabtab + "thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);\n"
-		subscribers += tabtabtabtab + "iprot.readMessageEnd();\n"
-		subscribers += tabtabtabtab + "throw new thrift.TApplicationError(\n"
-		subscribers += tabtabtabtab + "frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);\n"
-		subscribers += tabtabtab + "}\n"
-		subscribers += g.generateReadFieldRec(parser.FieldFromType(op.Type, "req"), false, tabtabtab)
-		subscribers += tabtabtab + "iprot.readMessageEnd();\n"
+		subscribers += g.generateReadScopeMessage(op, tabtabtab)
+		subscribers += g.generateHookCall("onAfterReceive", "op", tabtabtab)
 		subscribers += tabtabtab + "method([ctx, req]);\n"
 		subscribers += tabtab + "}\n"
 		subscribers += fmt.Sprintf(tabtab+"return callback%s;\n", op.Name)
 		subscribers += tab + "}\n"
 	}
 
+	subscribers += "\n\n"
+	subscribers += g.generateSubscribeAll(scope, args)
+
 	subscribers += "}\n"
+	if scope.HasReplyOperations() {
+		subscribers += "\n\n" + g.generateResponder(scope, args)
+	}
 
 	_, err := file.WriteString(subscribers)
 	return err
 }
 
+// generateRequester generates the requester for the request/reply operations
+// of the given scope.
+func (g *Generator) generateRequester(scope *parser.Scope, args, argsWithoutTypes string) string {
+	scopeTitle := strings.Title(scope.Name)
+	requester := ""
+	if comment := scope.DocComment(); comment != nil {
+		requester += g.GenerateInlineComment(comment, "/")
+	}
+	requester += fmt.Sprintf("class %sRequester {\n", scopeTitle)
+	requester += tab + "final frugal.FScopeRequester _requester;\n"
+	requester += tab + "Map<String, frugal.FMethod> _methods;\n\n"
+
+	requester += fmt.Sprintf(tab+"%sRequester(frugal.FScopeProvider provider, [List<frugal.Middleware> middleware])\n", scopeTitle)
+	requester += tabtabtab + ": _requester = new frugal.FScopeRequester(provider) {\n"
+	requester += tabtab + "var combined = middleware ?? [];\n"
+	requester += tabtab + "combined.addAll(provider.middleware);\n"
+	requester += tabtab + "this._methods = {};\n"
+	for _, op := range scope.Operations {
+		if op.ReplyType() != nil {
+			requester += fmt.Sprintf(tabtab+"this._methods['%s'] = new frugal.FMethod(this._request%s, '%s', 'request%s', combined);\n",
+				op.Name, op.Name, scopeTitle, op.Name)
+		}
+	}
+	requester += tab + "}\n\n"
+
+	requester += tab + "Future open() {\n"
+	requester += tabtab + "return _requester.open();\n"
+	requester += tab + "}\n\n"
+
+	requester += tab + "Future close() {\n"
+	requester += tabtab + "return _requester.close();\n"
+	requester += tab + "}\n\n"
+	requester += g.generatePrefixVariableMethod(scope)
+
+	prefix := ""
+	for _, op := range scope.Operations {
+		reply := op.ReplyType()
+		if reply == nil {
+			continue
+		}
+		reqType := g.getDartTypeFromThriftType(op.Type)
+		replyType := g.getDartTypeFromThriftType(reply)
+		requester += prefix
+		prefix = "\n"
+		if comment := op.DocComment(); comment != nil {
+			requester += g.generateDocComment(comment, tab)
+		}
+		requester += fmt.Sprintf(tab+"Future<%s> request%s(frugal.FContext ctx, %s%s req) {\n", replyType, op.Name, args, reqType)
+		requester += fmt.Sprintf(tabtab+"return this._methods['%s']([ctx, %sreq]) as Future<%s>;\n", op.Name, argsWithoutTypes, replyType)
+		requester += tab + "}\n\n"
+
+		requester += fmt.Sprintf(tab+"Future<%s> _request%s(frugal.FContext ctx, %s%s req) async {\n", replyType, op.Name, args, reqType)
+		requester += g.generatePrefixVariableChecks(scope)
+		for _, prefixVar := range scope.Prefix.Variables {
+			requester += fmt.Sprintf(tabtab+"ctx.addRequestHeader('_topic_%s', %s);\n", prefixVar, prefixVar)
+		}
+		requester += tabtab + fmt.Sprintf("var op = \"%s\";\n", op.Name)
+		requester += tabtab + fmt.Sprintf("var prefix = \"%s\";\n", generatePrefixStringTemplate(scope))
+		requester += tabtab + "var topic = \"${prefix}" + scope.TopicName(scopeTitle, globals.TopicDelimiter) + "${delimiter}${op}\";\n"
+		requester += tabtab + "return await _requester.request(ctx, topic, op, (frugal.FProtocol oprot) {\n"
+		requester += g.generateWriteFieldRec(parser.FieldFromType(op.Type, "req"), false, tab)
+		requester += tabtab + "}, (frugal.FProtocol iprot) {\n"
+		requester += g.generateReadFieldRec(parser.FieldFromType(reply, "reply"), false, tabtabtab)
+		requester += tabtabtab + "return reply;\n"
+		requester += tabtab + "});\n"
+		requester += tab + "}\n"
+	}
+
+	requester += "}\n"
+	return requester
+}
+
+// generateResponder generates the responder for the request/reply
+// operations of the given scope.
+func (g *Generator) generateResponder(scope *parser.Scope, args string) string {
+	scopeTitle := strings.Title(scope.Name)
+	responder := ""
+	if comment := scope.DocComment(); comment != nil {
+		responder += g.GenerateInlineComment(comment, "/")
+	}
+	responder += fmt.Sprintf("class %sResponder {\n", scopeTitle)
+	responder += tab + "final frugal.FScopeProvider provider;\n"
+	responder += tab + "final frugal.FScopeResponder _responder;\n"
+	responder += tab + "final List<frugal.Middleware> _middleware;\n\n"
+
+	responder += tab + fmt.Sprintf("%sResponder(frugal.FScopeProvider provider, [List<frugal.Middleware> middleware])\n", scopeTitle)
+	responder += tabtabtab + ": this.provider = provider,\n"
+	responder += tabtabtabtab + "_responder = new frugal.FScopeResponder(provider),\n"
+	responder += tabtabtabtab + "_middleware = middleware ?? [] {\n"
+	responder += tabtab + "this._middleware.addAll(provider.middleware);\n"
+	responder += tab + "}\n\n"
+
+	responder += tab + "Future close() {\n"
+	responder += tabtab + "return _responder.close();\n"
+	responder += tab + "}\n\n"
+	responder += g.generatePrefixVariableMethod(scope)
+
+	prefix := ""
+	for _, op := range scope.Operations {
+		reply := op.ReplyType()
+		if reply == nil {
+			continue
+		}
+		reqType := g.getDartTypeFromThriftType(op.Type)
+		replyType := g.getDartTypeFromThriftType(reply)
+		handler := fmt.Sprintf("dynamic on%s(frugal.FContext ctx, %s req)", op.Type.ParamName(), reqType)
+		responder += prefix
+		prefix = "\n"
+		if comment := op.DocComment(); comment != nil {
+			responder += g.generateDocComment(comment, tab)
+		}
+		responder += fmt.Sprintf(tab+"Future<frugal.FSubscription> respond%s(%s%s, %s) async {\n",
+			op.Name, args, handler, subscribePolicyParams)
+		responder += g.generatePrefixVariableChecks(scope)
+		responder += fmt.Sprintf(tabtab+"var op = \"%s\";\n", op.Name)
+		responder += fmt.Sprintf(tabtab+"var prefix = \"%s\";\n", generatePrefixStringTemplate(scope))
+		responder += tabtab + "var topic = \"${prefix}" + scope.TopicName(scopeTitle, globals.TopicDelimiter) + "${delimiter}${op}\";\n"
+		responder += tabtab + "var transport = provider.subscriberTransportFactory.getTransport();\n"
+		responder += generateSubscribeWithPolicy(fmt.Sprintf("_respond%s(op, provider.protocolFactory, on%s)", op.Name, op.Type.ParamName()))
+		responder += tab + "}\n\n"
+
+		responder += fmt.Sprintf(tab+"frugal.FAsyncCallback _respond%s(String op, frugal.FProtocolFactory protocolFactory, %s) {\n", op.Name, handler)
+		responder += fmt.Sprintf(tabtab+"frugal.FMethod method = new frugal.FMethod(on%s, '%s', 'respond%s', this._middleware);\n",
+			op.Type.ParamName(), scopeTitle, op.Name)
+		responder += fmt.Sprintf(tabtab+"callback%s(thrift.TTransport transport) {\n", op.Name)
+		responder += tabtabtab + "var iprot = protocolFactory.getProtocol(transport);\n"
+		responder += g.generateReadScopeMessage(op, tabtabtab)
+		responder += tabtabtab + "_responder.reply(ctx, op, new Future.sync(() => method([ctx, req])),\n"
+		responder += fmt.Sprintf(tabtabtabtab+tab+"(frugal.FProtocol oprot, %s reply) {\n", replyType)
+		responder += g.generateWriteFieldRec(parser.FieldFromType(reply, "reply"), false, tabtab)
+		responder += tabtabtab + "});\n"
+		responder += tabtab + "}\n"
+		responder += fmt.Sprintf(tabtab+"return callback%s;\n", op.Name)
+		responder += tab + "}\n"
+	}
+
+	responder += "}\n"
+	return responder
+}
+
+// generateReadScopeMessage generates the statements reading the request
+// headers and the value of a message of the given scope operation with iprot
+// into ctx and req.
+func (g *Generator) generateReadScopeMessage(op *parser.Operation, indent string) string {
+	contents := indent + "var ctx = iprot.readRequestHeader();\n"
+	contents += indent + "var tMsg = iprot.readMessageBegin();\n"
+	contents += indent + "if (tMsg.name != op) {\n"
+	contents += indent + tab + "thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);\n"
+	contents += indent + tab + "iprot.readMessageEnd();\n"
+	contents += indent + tab + "throw new thrift.TApplicationError(\n"
+	contents += indent + tab + "frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);\n"
+	contents += indent + "}\n"
+	contents += g.generateReadFieldRec(parser.FieldFromType(op.Type, "req"), false, indent)
+	contents += indent + "iprot.readMessageEnd();\n"
+	return contents
+}
+
+// generatePrefixVariableMethod generates the method checking the values of the
+// scope's prefix variables for the prefix_validation option.
+func (g *Generator) generatePrefixVariableMethod(scope *parser.Scope) string {
+	behavior, ok := g.Options[generator.PrefixValidationOption]
+	if !ok || len(scope.Prefix.Variables) == 0 {
+		return ""
+	}
+	contents := tab + "String _prefixVariable(String name, String value) {\n"
+	contents += tabtab + "if (value == null || value.isEmpty) {\n"
+	contents += tabtabtab + "throw new ArgumentError.value(value, name, 'topic prefix variable is empty');\n"
+	contents += tabtab + "}\n"
+	if behavior == generator.PrefixValidationError {
+		contents += tabtab + "if (value.contains(delimiter)) {\n"
+		contents += tabtabtab + "throw new ArgumentError.value(value, name, 'topic prefix variable contains the topic delimiter');\n"
+		contents += tabtab + "}\n"
+		contents += tabtab + "return value;\n"
+	} else {
+		contents += tabtab + fmt.Sprintf("return value.replaceAll(delimiter, '%s');\n", generator.PrefixSanitizeReplacement)
+	}
+	contents += tab + "}\n\n"
+	return contents
+}
+
+// generatePrefixVariableChecks generates the checks of the scope's prefix
+// variables for the prefix_validation option.
+func (g *Generator) generatePrefixVariableChecks(scope *parser.Scope) string {
+	if _, ok := g.Options[generator.PrefixValidationOption]; !ok {
+		return ""
+	}
+	contents := ""
+	for _, variable := range scope.Prefix.Variables {
+		contents += tabtab + fmt.Sprintf("%s = _prefixVariable('%s', %s);\n", variable, variable, variable)
+	}
+	return contents
+}
+
+// generateSubscribeAll generates subscribeAll, which subscribes to a wildcard
+// topic matching every operation of the scope and passes each decoded message
+// to the handler along with its operation name. The "*" wildcard only matches
+// the operations with the "." topic delimiter, so other delimiters are warned
+// about.
+func (g *Generator) generateSubscribeAll(scope *parser.Scope, args string) string {
+	g.WarnSubscribeAllDelimiter(scope)
+	contents := g.generateDocComment([]string{
+		"Subscribes to every operation of the scope. onMessage is called with the",
+		"name of the operation of each message and its decoded payload.",
+	}, tab)
+	contents += fmt.Sprintf(tab+"Future<frugal.FSubscription> subscribeAll(%sdynamic onMessage(frugal.FContext ctx, String op, dynamic req), %s) async {\n",
+		args, subscribePolicyParams)
+	contents += g.generatePrefixVariableChecks(scope)
+	contents += fmt.Sprintf(tabtab+"var prefix = \"%s\";\n", generatePrefixStringTemplate(scope))
+	contents += tabtab + "var topic = \"${prefix}" + scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter) + "${delimiter}*\";\n"
+	contents += tabtab + "var transport = provider.subscriberTransportFactory.getTransport();\n"
+	contents += generateSubscribeWithPolicy(g.wrapBatchFrameCallback("_recvAll(provider.protocolFactory, onMessage)"))
+	contents += tab + "}\n\n"
+
+	contents += tab + "frugal.FAsyncCallback _recvAll(frugal.FProtocolFactory protocolFactory, dynamic onMessage(frugal.FContext ctx, String op, dynamic req)) {\n"
+	contents += fmt.Sprintf(tabtab+"frugal.FMethod method = new frugal.FMethod(onMessage, '%s', 'subscribeAll', this._middleware);\n", strings.Title(scope.Name))
+	contents += tabtab + "callbackAll(thrift.TTransport transport) {\n"
+	contents += tabtabtab + "var iprot = protocolFactory.getProtocol(transport);\n"
+	contents += tabtabtab + "var ctx = iprot.readRequestHeader();\n"
+	contents += tabtabtab + "var tMsg = iprot.readMessageBegin();\n"
+	contents += tabtabtab + "var req;\n"
+	contents += tabtabtab + "switch (tMsg.name) {\n"
+	for _, op := range scope.Operations {
+		contents += tabtabtabtab + fmt.Sprintf("case '%s':\n", op.Name)
+		contents += g.generateReadFieldRec(parser.FieldFromType(op.Type, "req"+op.Name), false, tabtabtabtabtab)
+		contents += tabtabtabtabtab + fmt.Sprintf("req = req%s;\n", op.Name)
+		contents += tabtabtabtabtab + "break;\n"
+	}
+	contents += tabtabtabtab + "default:\n"
+	contents += tabtabtabtabtab + "thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);\n"
+	contents += tabtabtabtabtab + "iprot.readMessageEnd();\n"
+	contents += tabtabtabtabtab + "throw new thrift.TApplicationError(\n"
+	contents += tabtabtabtabtab + "frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);\n"
+	contents += tabtabtab + "}\n"
+	contents += tabtabtab + "iprot.readMessageEnd();\n"
+	contents += g.generateHookCall("onAfterReceive", "tMsg.name", tabtabtab)
+	contents += tabtabtab + "method([ctx, tMsg.name, req]);\n"
+	contents += tabtab + "}\n"
+	contents += tabtab + "return callbackAll;\n"
+	contents += tab + "}\n"
+	return contents
+}
+
+// subscribePolicyParams are the optional parameters of generated subscribe
+// methods handling subscription errors.
+const subscribePolicyParams = "{frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}"
+
+// generateSubscribeWithPolicy generates the subscription of the transport to
+// the topic with the given callback, passing on the error callback and
+// resubscribe policy of the subscribe method.
+func generateSubscribeWithPolicy(callback string) string {
+	contents := fmt.Sprintf(tabtab+"return frugal.subscribeWithPolicy(topic, transport, %s,\n", callback)
+	contents += tabtabtab + "onError: onError, resubscribePolicy: resubscribePolicy);\n"
+	return contents
+}
+
+// generateHookField generates the named hook field of a publisher or
+// subscriber if the hooks option is set.
+func (g *Generator) generateHookField(hook, when string) string {
+	if _, ok := g.Options[generator.HooksOption]; !ok {
+		return ""
+	}
+	contents := tab + fmt.Sprintf("/// Called with the context and operation name %s.\n", when)
+	contents += tab + fmt.Sprintf("frugal.FScopeHook %s;\n", hook)
+	return contents
+}
+
+// generateHookCall generates the call of the named hook field, if it's set,
+// with the given operation name if the hooks option is set.
+func (g *Generator) generateHookCall(hook, op, indent string) string {
+	if _, ok := g.Options[generator.HooksOption]; !ok {
+		return ""
+	}
+	contents := indent + fmt.Sprintf("if (%s != null) {\n", hook)
+	contents += indent + tab + fmt.Sprintf("%s(ctx, %s);\n", hook, op)
+	contents += indent + "}\n"
+	return contents
+}
+
+// generateSubscribeStream generates a Stream accessor for the given scope
+// operation. The subscription is created when the Stream is listened to,
+// paused and resumed with the listener, and unsubscribed when the listener
+// cancels. Errors of the subscription are added to the Stream, which is
+// closed if subscribing fails.
+func (g *Generator) generateSubscribeStream(op *parser.Operation, args, argNames string) string {
+	dartType := g.getDartTypeFromThriftType(op.Type)
+	contents := ""
+	if comment := op.DocComment(); comment != nil {
+		contents += g.generateDocComment(comment, tab)
+	}
+	contents += fmt.Sprintf(tab+"Stream<%s> stream%s(%s{frugal.FResubscribePolicy resubscribePolicy}) {\n", dartType, op.Name, args)
+	contents += tabtab + "Future<frugal.FSubscription> subscription;\n"
+	contents += tabtab + fmt.Sprintf("StreamController<%s> controller;\n", dartType)
+	contents += tabtab + fmt.Sprintf("controller = new StreamController<%s>(\n", dartType)
+	contents += tabtabtabtab + "onListen: () {\n"
+	contents += tabtabtabtabtab + fmt.Sprintf("subscription = subscribe%s(%s(frugal.FContext ctx, %s req) {\n", op.Name, argNames, dartType)
+	contents += tabtabtabtabtabtab + "controller.add(req);\n"
+	contents += tabtabtabtabtab + "}, onError: controller.addError, resubscribePolicy: resubscribePolicy);\n"
+	contents += tabtabtabtabtab + "subscription.catchError((_) => controller.close());\n"
+	contents += tabtabtabtab + "},\n"
+	contents += tabtabtabtab + "onPause: () {\n"
+	contents += tabtabtabtabtab + "subscription.then((sub) => sub.pause(), onError: (_) => null);\n"
+	contents += tabtabtabtab + "},\n"
+	contents += tabtabtabtab + "onResume: () {\n"
+	contents += tabtabtabtabtab + "subscription.then((sub) => sub.resume(), onError: (_) => null);\n"
+	contents += tabtabtabtab + "},\n"
+	contents += tabtabtabtab + "onCancel: () async {\n"
+	contents += tabtabtabtabtab + "var sub = await subscription.catchError((_) => null);\n"
+	contents += tabtabtabtabtab + "await sub?.unsubscribe();\n"
+	contents += tabtabtabtabtab + "controller.close();\n"
+	contents += tabtabtabtab + "});\n"
+	contents += tabtab + "return controller.stream;\n"
+	contents += tab + "}\n\n"
+	return contents
+}
+
 // GenerateService generates the given service.
 func (g *Generator) GenerateService(file *os.File, s *parser.Service) error {
 	contents := ""
@@ -1525,6 +2153,25 @@ func (g *Generator) GenerateService(file *os.File, s *parser.Service) error {
 	return err
 }
 
+// generateUnknownEnumCheck generates the handling of the enum value read into
+// the given variable when it isn't declared in the IDL, for enums generated as
+// classes.
+func (g *Generator) generateUnknownEnumCheck(name string, enumType *parser.Type, ind string) string {
+	typeName := g.qualifiedTypeName(enumType)
+	contents := ""
+	switch g.Options[generator.UnknownEnumsOption] {
+	case generator.UnknownEnumsError:
+		contents += fmt.Sprintf(ind+"if (!%s.VALID_VALUES.contains(%s)) {\n", typeName, name)
+		contents += fmt.Sprintf(ind+tab+"throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, \"Invalid value '$%s' for enum '%s'\");\n", name, enumType.Name)
+		contents += ind + "}\n"
+	case generator.UnknownEnumsSentinel:
+		contents += fmt.Sprintf(ind+"if (!%s.VALID_VALUES.contains(%s)) {\n", typeName, name)
+		contents += fmt.Sprintf(ind+tab+"%s = %s.%s;\n", name, typeName, generator.UnknownEnumValue)
+		contents += ind + "}\n"
+	}
+	return contents
+}
+
 func (g *Generator) generateCommentWithDeprecatedImpl(comment []string, indent string, anns parser.Annotations, deprecatedAnn bool) string {
 	contents := ""
 	if comment != nil {
@@ -1547,10 +2194,37 @@ func (g *Generator) generateCommentWithDeprecated(comment []string, indent strin
 	return g.generateCommentWithDeprecatedImpl(comment, indent, anns, true)
 }
 
+// generateContractMetadata generates the static FContractMetadata constant of
+// a scope or service class.
+func (g *Generator) generateContractMetadata(metadata *generator.ContractMetadata) string {
+	operations := make([]string, len(metadata.Operations))
+	for i, operation := range metadata.Operations {
+		operations[i] = fmt.Sprintf("'%s'", operation)
+	}
+
+	contents := tab + fmt.Sprintf("/// Describes the %s %s contract.\n", metadata.Name, metadata.Kind)
+	contents += tab + "static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(\n"
+	contents += tabtabtab + fmt.Sprintf("'%s', '%s', '%s',\n", metadata.IDLFile, metadata.Kind, metadata.Name)
+	contents += tabtabtab + fmt.Sprintf("'%s', '%s',\n", metadata.Hash, metadata.CompilerVersion)
+	if len(metadata.Descriptions) == 0 {
+		contents += tabtabtab + fmt.Sprintf("const [%s]);\n", strings.Join(operations, ", "))
+		return contents
+	}
+	contents += tabtabtab + fmt.Sprintf("const [%s],\n", strings.Join(operations, ", "))
+	contents += tabtabtab + "const {\n"
+	for _, operation := range metadata.Operations {
+		if description, ok := metadata.Descriptions[operation]; ok {
+			contents += tabtabtabtab + fmt.Sprintf("'%s': %s,\n", operation, dartQuote(description))
+		}
+	}
+	contents += tabtabtab + "});\n"
+	return contents
+}
+
 func (g *Generator) generateInterface(service *parser.Service) string {
 	contents := ""
-	if service.Comment != nil {
-		contents += g.GenerateInlineComment(service.Comment, "/")
+	if comment := service.DocComment(); comment != nil {
+		contents += g.GenerateInlineComment(comment, "/")
 	}
 	if service.Extends != "" {
 		contents += fmt.Sprintf("abstract class F%s extends %s {\n",
@@ -1558,6 +2232,7 @@ func (g *Generator) generateInterface(service *parser.Service) string {
 	} else {
 		contents += fmt.Sprintf("abstract class F%s {\n", strings.Title(service.Name))
 	}
+	contents += g.generateContractMetadata(g.ServiceMetadata(service))
 	for _, method := range service.Methods {
 		contents += "\n"
 		contents += g.generateCommentWithDeprecated(method.Comment, tab, method.Annotations)
@@ -1586,8 +2261,8 @@ func (g *Generator) getServiceExtendsName(service *parser.Service) string {
 func (g *Generator) generateClient(service *parser.Service) string {
 	servTitle := strings.Title(service.Name)
 	contents := ""
-	if service.Comment != nil {
-		contents += g.GenerateInlineComment(service.Comment, "/")
+	if comment := service.DocComment(); comment != nil {
+		contents += g.GenerateInlineComment(comment, "/")
 	}
 	if service.Extends != "" {
 		contents += fmt.Sprintf("class F%sClient extends %sClient implements F%s {\n",
@@ -1817,13 +2492,13 @@ func (g *Generator) getDartTypeFromThriftType(t *parser.Type) string {
 	case "binary":
 		return "Uint8List"
 	case "list":
-		return fmt.Sprintf("List<%s>",
+		return fmt.Sprintf("%sList<%s>", g.builtPrefix(),
 			g.getDartTypeFromThriftType(underlyingType.ValueType))
 	case "set":
-		return fmt.Sprintf("Set<%s>",
+		return fmt.Sprintf("%sSet<%s>", g.builtPrefix(),
 			g.getDartTypeFromThriftType(underlyingType.ValueType))
 	case "map":
-		return fmt.Sprintf("Map<%s, %s>",
+		return fmt.Sprintf("%sMap<%s, %s>", g.builtPrefix(),
 			g.getDartTypeFromThriftType(underlyingType.KeyType),
 			g.getDartTypeFromThriftType(underlyingType.ValueType))
 	default:
@@ -1897,13 +2572,27 @@ func (g *Generator) generateIncludeImport(include *parser.Include) (string, erro
 		if vendorPath == "" {
 			return "", fmt.Errorf("Vendored include %s does not specify vendor path for dart namespace", include.Name)
 		}
-		prefix = vendorPath
+		return g.getFlatImportDeclaration(name, vendorPath), nil
 	}
 
 	return g.getImportDeclaration(name, prefix), nil
 }
 
+// getImportDeclaration returns the import of the library with the given
+// namespace generated with the package prefix, which is nested if namespaces
+// are nested.
 func (g *Generator) getImportDeclaration(namespace, prefix string) string {
+	if !g.nestedNamespaces() {
+		return g.getFlatImportDeclaration(namespace, prefix)
+	}
+	alias := toLibraryName(namespace)
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return fmt.Sprintf("import 'package:%s%s.dart' as t_%s;\n", prefix, strings.Replace(namespace, ".", "/", -1), alias)
+}
+
+func (g *Generator) getFlatImportDeclaration(namespace, prefix string) string {
 	namespace = toLibraryName(filepath.Base(namespace))
 	if prefix == "" {
 		prefix += namespace + "/"
@@ -1926,6 +2615,29 @@ func (g *Generator) useEnums() bool {
 	return useEnums
 }
 
+func (g *Generator) useBuiltCollections() bool {
+	_, ok := g.Options[builtCollectionOption]
+	return ok
+}
+
+// builtPrefix returns the prefix of the names of the built_collection types
+// if they're used for containers.
+func (g *Generator) builtPrefix() string {
+	if g.useBuiltCollections() {
+		return "Built"
+	}
+	return ""
+}
+
+// generateBuiltCollectionImport generates the import of the built_collection
+// library if its types are used for containers.
+func (g *Generator) generateBuiltCollectionImport() string {
+	if g.useBuiltCollections() {
+		return builtCollectionImport
+	}
+	return ""
+}
+
 func (g *Generator) UseVendor() bool {
 	_, ok := g.Options[useVendorOption]
 	return ok
@@ -1984,3 +2696,48 @@ func toFieldName(name string) string {
 	runes[0] = unicode.ToLower(runes[0])
 	return string(runes)
 }
+
+// generateConstraintChecks generates checks in the validate method that the
+// field, if set, satisfies its constraints.
+func (g *Generator) generateConstraintChecks(s *parser.Struct, field *parser.Field) string {
+	constraints := field.Constraints()
+	if constraints == nil {
+		return ""
+	}
+	fName := toFieldName(field.Name)
+	isSet := fmt.Sprintf("isSet%s()", strings.Title(field.Name))
+	bounded, what := fName, ""
+	if g.Frugal.ConstrainsLength(field.Type) {
+		what = "length "
+		bounded = fName + ".length"
+		if g.Frugal.UnderlyingType(field.Type).Name == "string" {
+			bounded = fName + ".runes.length"
+		}
+	}
+
+	contents := ""
+	check := func(condition, message string) {
+		contents += fmt.Sprintf(tabtab+"if(%s && %s) {\n", isSet, condition)
+		contents += fmt.Sprintf(tabtabtab+"throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, %s);\n", dartQuote(message))
+		contents += tabtab + "}\n"
+	}
+	if constraints.Min != "" {
+		check(fmt.Sprintf("%s < %s", bounded, constraints.Min),
+			fmt.Sprintf("%s.%s %smust be at least %s", s.Name, field.Name, what, constraints.Min))
+	}
+	if constraints.Max != "" {
+		check(fmt.Sprintf("%s > %s", bounded, constraints.Max),
+			fmt.Sprintf("%s.%s %smust be at most %s", s.Name, field.Name, what, constraints.Max))
+	}
+	if constraints.Pattern != "" {
+		check(fmt.Sprintf("!new RegExp(%s).hasMatch(%s)", dartQuote(constraints.Pattern), fName),
+			fmt.Sprintf("%s.%s must match %s", s.Name, field.Name, constraints.Pattern))
+	}
+	return contents
+}
+
+// dartQuote returns the string as a Dart string literal, escaping
+// interpolation.
+func dartQuote(s string) string {
+	return strings.Replace(strconv.Quote(s), "$", "\\$", -1)
+}
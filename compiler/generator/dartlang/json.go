@@ -0,0 +1,218 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dartlang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// generateJSONMethods generates toJson and fromJson methods when the json
+// option is set, encoding the struct the same way as the other languages.
+// toJson returns a map, so structs can be passed to JSON.encode directly.
+// Structs are encoded as objects keyed by field name, omitting unset fields.
+// Binary values are base64 encoded, enums are encoded by name, and sets are
+// encoded as arrays. Maps with string, integer, or enum keys are encoded as
+// objects and other maps as arrays of key-value pairs.
+func (g *Generator) generateJSONMethods(s *parser.Struct) string {
+	if !g.generateJSONHelpers() {
+		return ""
+	}
+
+	contents := fmt.Sprintf(tab+"/// Returns a map encoding this %s, keyed by field name, for JSON.encode.\n", s.Name)
+	contents += tab + "Map<String, dynamic> toJson() {\n"
+	contents += tabtab + "Map<String, dynamic> json = <String, dynamic>{};\n"
+	for _, field := range s.Fields {
+		fName := toFieldName(field.Name)
+		fieldIndent := tabtab
+		// Primitive struct fields always have a value, as in Go.
+		checked := s.Type == parser.StructTypeUnion || g.generateInitValue(field) == ""
+		if checked {
+			contents += fmt.Sprintf(tabtab+"if(isSet%s()) {\n", strings.Title(field.Name))
+			fieldIndent += tab
+		}
+		contents += fieldIndent + fmt.Sprintf("json['%s'] = %s;\n", field.Name, g.toJSONValue("this."+fName, field.Type, 1))
+		if checked {
+			contents += tabtab + "}\n"
+		}
+	}
+	contents += tabtab + "return json;\n"
+	contents += tab + "}\n\n"
+
+	contents += fmt.Sprintf(tab+"/// Sets the fields of this %s from a map produced by [toJson]. Fields\n", s.Name)
+	contents += tab + "/// missing from the map are left unchanged.\n"
+	contents += tab + "void fromJson(Map json) {\n"
+	for _, field := range s.Fields {
+		contents += fmt.Sprintf(tabtab+"if(json.containsKey('%s')) {\n", field.Name)
+		contents += tabtabtab + "try {\n"
+		contents += fmt.Sprintf(tabtabtabtab+"this.%s = %s;\n", toFieldName(field.Name), g.fromJSONValue(fmt.Sprintf("json['%s']", field.Name), field.Type, 1))
+		contents += tabtabtab + "} catch (e) {\n"
+		contents += fmt.Sprintf(tabtabtabtab+"throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, \"error decoding field %s: $e\");\n", field.Name)
+		contents += tabtabtab + "}\n"
+		contents += tabtab + "}\n"
+	}
+	contents += tab + "}\n\n"
+	return contents
+}
+
+// toJSONValue returns an expression converting the value to the JSON value
+// described by generateJSONMethods.
+func (g *Generator) toJSONValue(value string, t *parser.Type, depth int) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	elem := fmt.Sprintf("e%d", depth)
+	switch {
+	case g.Frugal.IsStruct(underlying):
+		return value + ".toJson()"
+	case g.Frugal.IsEnum(underlying):
+		return g.enumName(value, underlying)
+	case underlying.Name == "binary":
+		return fmt.Sprintf("BASE64.encode(%s)", value)
+	case underlying.Name == "list", underlying.Name == "set":
+		converted := g.toJSONValue(elem, underlying.ValueType, depth+1)
+		if converted == elem {
+			return value + ".toList()"
+		}
+		return fmt.Sprintf("%s.map((%s) => %s).toList()", value, elem, converted)
+	case underlying.Name == "map":
+		key := fmt.Sprintf("k%d", depth)
+		val := fmt.Sprintf("v%d", depth)
+		if g.isJSONObjectKey(underlying.KeyType) {
+			// Keys and values iterate in the same order
+			return fmt.Sprintf("new Map<String, dynamic>.fromIterables(%s.keys.map((%s) => %s), %s.values.map((%s) => %s))",
+				value, key, g.jsonObjectKey(key, underlying.KeyType),
+				value, val, g.toJSONValue(val, underlying.ValueType, depth+1))
+		}
+		return fmt.Sprintf("%s.keys.map((%s) => [%s, %s]).toList()", value, key,
+			g.toJSONValue(key, underlying.KeyType, depth+1),
+			g.toJSONValue(fmt.Sprintf("%s[%s]", value, key), underlying.ValueType, depth+1))
+	default:
+		return value
+	}
+}
+
+// fromJSONValue returns an expression converting the JSON value back to a
+// value of the given type.
+func (g *Generator) fromJSONValue(value string, t *parser.Type, depth int) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	dartType := g.getDartTypeFromThriftType(t)
+	elem := fmt.Sprintf("e%d", depth)
+	switch {
+	case g.Frugal.IsStruct(underlying):
+		return fmt.Sprintf("(new %s()..fromJson(%s as Map))", dartType, value)
+	case g.Frugal.IsEnum(underlying):
+		return g.enumValue(value, underlying, depth)
+	case underlying.Name == "binary":
+		return fmt.Sprintf("new Uint8List.fromList(BASE64.decode(%s as String))", value)
+	case underlying.Name == "double":
+		return fmt.Sprintf("(%s as num).toDouble()", value)
+	case underlying.Name == "list", underlying.Name == "set":
+		elems := fmt.Sprintf("(%s as List).map((%s) => %s)", value, elem, g.fromJSONValue(elem, underlying.ValueType, depth+1))
+		elemType := g.getDartTypeFromThriftType(underlying.ValueType)
+		container := strings.Title(underlying.Name)
+		if g.useBuiltCollections() {
+			return fmt.Sprintf("new Built%s<%s>(%s)", container, elemType, elems)
+		}
+		return fmt.Sprintf("new %s<%s>.from(%s)", container, elemType, elems)
+	case underlying.Name == "map":
+		mapType := fmt.Sprintf("Map<%s, %s>", g.getDartTypeFromThriftType(underlying.KeyType),
+			g.getDartTypeFromThriftType(underlying.ValueType))
+		var keys, values string
+		if g.isJSONObjectKey(underlying.KeyType) {
+			key := fmt.Sprintf("k%d", depth)
+			val := fmt.Sprintf("v%d", depth)
+			keys = fmt.Sprintf("(%s as Map).keys.map((%s) => %s)", value, key, g.parseJSONObjectKey(key, underlying.KeyType, depth))
+			values = fmt.Sprintf("(%s as Map).values.map((%s) => %s)", value, val, g.fromJSONValue(val, underlying.ValueType, depth+1))
+		} else {
+			pair := fmt.Sprintf("p%d", depth)
+			keys = fmt.Sprintf("(%s as List).map((%s) => %s)", value, pair, g.fromJSONValue(pair+"[0]", underlying.KeyType, depth+1))
+			values = fmt.Sprintf("(%s as List).map((%s) => %s)", value, pair, g.fromJSONValue(pair+"[1]", underlying.ValueType, depth+1))
+		}
+		decoded := fmt.Sprintf("new %s.fromIterables(%s, %s)", mapType, keys, values)
+		if g.useBuiltCollections() {
+			return fmt.Sprintf("new Built%s(%s)", mapType, decoded)
+		}
+		return decoded
+	default:
+		return fmt.Sprintf("%s as %s", value, dartType)
+	}
+}
+
+// enumName returns an expression for the name of the enum value.
+func (g *Generator) enumName(value string, t *parser.Type) string {
+	if g.useEnums() {
+		return fmt.Sprintf("%s.toString().split('.').last", value)
+	}
+	return fmt.Sprintf("%s.VALUES_TO_NAMES[%s]", g.qualifiedTypeName(t), value)
+}
+
+// enumValue returns an expression for the enum value named by the JSON value,
+// which throws an ArgumentError if the enum has no such value.
+func (g *Generator) enumValue(name string, t *parser.Type, depth int) string {
+	enumType := g.qualifiedTypeName(t)
+	v := fmt.Sprintf("n%d", depth)
+	invalid := fmt.Sprintf("throw new ArgumentError('Invalid name ${%s} for enum %s')", name, t.ParamName())
+	if g.useEnums() {
+		return fmt.Sprintf("%s.values.firstWhere((%s) => %s.toString() == '%s.${%s}', orElse: () => %s)",
+			enumType, v, v, t.ParamName(), name, invalid)
+	}
+	return fmt.Sprintf("%s.VALUES_TO_NAMES.keys.firstWhere((%s) => %s.VALUES_TO_NAMES[%s] == %s, orElse: () => %s)",
+		enumType, v, enumType, v, name, invalid)
+}
+
+// isJSONObjectKey returns true if maps with keys of the type are encoded as
+// JSON objects.
+func (g *Generator) isJSONObjectKey(t *parser.Type) bool {
+	underlying := g.Frugal.UnderlyingType(t)
+	switch underlying.Name {
+	case "string", "byte", "i8", "i16", "i32", "i64":
+		return true
+	}
+	return g.Frugal.IsEnum(underlying)
+}
+
+// jsonObjectKey returns an expression converting the map key to its JSON
+// object key.
+func (g *Generator) jsonObjectKey(key string, t *parser.Type) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsEnum(underlying):
+		return g.enumName(key, underlying)
+	case underlying.Name == "string":
+		return key
+	default:
+		return key + ".toString()"
+	}
+}
+
+// parseJSONObjectKey returns an expression converting the JSON object key
+// back to the map key.
+func (g *Generator) parseJSONObjectKey(key string, t *parser.Type, depth int) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsEnum(underlying):
+		return g.enumValue(key, underlying, depth)
+	case underlying.Name == "string":
+		return key + " as String"
+	default:
+		return fmt.Sprintf("int.parse(%s as String)", key)
+	}
+}
+
+func (g *Generator) generateJSONHelpers() bool {
+	_, ok := g.Options[generator.JSONOption]
+	return ok
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dartlang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const resilientPublishersOption = "resilient_publishers"
+
+// useResilientPublishers indicates if a publisher retrying transient
+// failures should be generated alongside each publisher.
+func (g *Generator) useResilientPublishers() bool {
+	_, ok := g.Options[resilientPublishersOption]
+	return ok
+}
+
+// generateResilientPublisher generates the subclass of the scope's publisher
+// retrying publishes which fail with transient transport errors according to
+// the retry policy it's constructed with.
+func (g *Generator) generateResilientPublisher(scope *parser.Scope) string {
+	scopeTitle := strings.Title(scope.Name)
+	args := ""
+	argNames := ""
+	for _, variable := range scope.Prefix.Variables {
+		args += fmt.Sprintf("String %s, ", variable)
+		argNames += fmt.Sprintf("%s, ", variable)
+	}
+
+	contents := g.generateDocComment([]string{
+		fmt.Sprintf("A [%sPublisher] retrying publishes which fail with transient transport", scopeTitle),
+		"errors according to its retry policy. Publishes failing permanently throw",
+		"an [frugal.FPublishFailedError].",
+	}, "")
+	contents += fmt.Sprintf("class Resilient%sPublisher extends %sPublisher {\n", scopeTitle, scopeTitle)
+	contents += tab + "final frugal.FRetryPolicy retryPolicy;\n\n"
+	contents += fmt.Sprintf(tab+"Resilient%sPublisher(frugal.FScopeProvider provider,\n", scopeTitle)
+	contents += tabtabtab + "{List<frugal.Middleware> middleware, this.retryPolicy: const frugal.FRetryPolicy()})\n"
+	contents += tabtab + ": super(provider, middleware);\n"
+	for _, op := range scope.Operations {
+		dartType := g.getDartTypeFromThriftType(op.Type)
+		contents += "\n"
+		contents += tab + "@override\n"
+		contents += fmt.Sprintf(tab+"Future publish%s(frugal.FContext ctx, %s%s req, {Duration timeout}) {\n", op.Name, args, dartType)
+		contents += fmt.Sprintf(tabtab+"return frugal.retryPublish(retryPolicy, () => super.publish%s(ctx, %sreq, timeout: timeout));\n", op.Name, argNames)
+		contents += tab + "}\n"
+		if g.useBatchPublish() {
+			contents += "\n"
+			contents += tab + "@override\n"
+			contents += fmt.Sprintf(tab+"Future publish%sBatch(frugal.FContext ctx, %sList<%s> reqs, {Duration timeout}) {\n", op.Name, args, dartType)
+			contents += fmt.Sprintf(tabtab+"return frugal.retryPublish(retryPolicy, () => super.publish%sBatch(ctx, %sreqs, timeout: timeout));\n", op.Name, argNames)
+			contents += tab + "}\n"
+		}
+	}
+	contents += "}\n"
+	return contents
+}
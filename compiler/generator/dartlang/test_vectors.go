@@ -0,0 +1,110 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dartlang
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Workiva/frugal/compiler/generator"
+)
+
+// testPackageVersion is the version constraint of the test package the test
+// vectors test is run with.
+const testPackageVersion = "^0.12.0"
+
+// generateTestVectorsTest generates a test in the package's test directory
+// decoding the test vector of each struct, union, and exception with each
+// protocol and checking that writing the decoded value with the binary
+// protocol reproduces the binary vector, which locks in the wire format other
+// languages encode. Protocols which can't encode a struct are skipped. Nothing
+// is generated for code generated into an existing library, which has no
+// package of its own to test.
+func (g *Generator) generateTestVectorsTest() error {
+	if _, ok := g.Options[libraryPrefixOption]; ok {
+		return nil
+	}
+	structs := g.Frugal.DataStructures()
+	if len(structs) == 0 {
+		return nil
+	}
+	dir := filepath.Join(g.outputDir, "test")
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	file, err := os.Create(filepath.Join(dir, "test_vectors_test.dart"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := g.GenerateDocStringComment(file); err != nil {
+		return err
+	}
+
+	contents := "\n\n"
+	contents += "import 'dart:convert' show BASE64;\n"
+	contents += "import 'dart:typed_data' show Uint8List;\n\n"
+	contents += "import 'package:test/test.dart';\n"
+	contents += "import 'package:thrift/thrift.dart' as thrift;\n"
+	contents += g.getImportDeclaration(g.getNamespaceOrName(), g.getPackagePrefix())
+	contents += "\n"
+
+	contents += "/// Decodes the vector into the value with the protocol and checks that\n"
+	contents += "/// writing the value with the binary protocol produces the binary vector.\n"
+	contents += "void checkTestVector(String binary, thrift.TProtocolFactory factory, String vector, thrift.TBase value) {\n"
+	contents += tab + "new thrift.TDeserializer(protocolFactory: factory).read(value, new Uint8List.fromList(BASE64.decode(vector)));\n"
+	contents += tab + "var written = new thrift.TSerializer(protocolFactory: new thrift.TBinaryProtocolFactory()).write(value);\n"
+	contents += tab + "expect(written, equals(BASE64.decode(binary)));\n"
+	contents += "}\n\n"
+
+	library := fmt.Sprintf("t_%s", toLibraryName(g.getNamespaceOrName()))
+	contents += "void main() {\n"
+	for _, s := range structs {
+		binary, err := generator.TestVector(g.Frugal, s, "binary")
+		if err != nil {
+			// Every protocol fails to build the vector if binary does.
+			continue
+		}
+		contents += tab + fmt.Sprintf("test('%s test vectors', () {\n", s.Name)
+		contents += tabtab + fmt.Sprintf("var binary = '%s';\n", base64.StdEncoding.EncodeToString(binary))
+		for _, protocol := range generator.TestVectorProtocols {
+			vector, err := generator.TestVector(g.Frugal, s, protocol)
+			if err != nil {
+				continue
+			}
+			literal := "binary"
+			if protocol != "binary" {
+				literal = fmt.Sprintf("'%s'", base64.StdEncoding.EncodeToString(vector))
+			}
+			contents += tabtab + fmt.Sprintf("checkTestVector(binary, %s, %s, new %s.%s());\n",
+				dartProtocolFactories[protocol], literal, library, s.Name)
+		}
+		contents += tab + "});\n"
+	}
+	contents += "}\n"
+
+	_, err = file.WriteString(contents)
+	return err
+}
+
+// dartProtocolFactories are the expressions creating the protocol factory of
+// each test vector protocol.
+var dartProtocolFactories = map[string]string{
+	"binary":  "new thrift.TBinaryProtocolFactory()",
+	"compact": "new thrift.TCompactProtocolFactory()",
+	"json":    "new thrift.TJsonProtocolFactory()",
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/Workiva/frugal/plugin"
+)
+
+// externalGenerator implements the ProgramGenerator interface by running an
+// executable which speaks the plugin protocol: a plugin.Request is written as
+// JSON to its standard input and a plugin.Response is read from its standard
+// output.
+type externalGenerator struct {
+	lang    string
+	path    string
+	options map[string]string
+}
+
+// NewExternalGenerator creates a ProgramGenerator for the language which runs
+// the executable at the given path.
+func NewExternalGenerator(lang, path string, options map[string]string) ProgramGenerator {
+	return &externalGenerator{lang: lang, path: path, options: options}
+}
+
+// Generate runs the executable for the Frugal and writes the files it
+// generates to the output directory.
+func (e *externalGenerator) Generate(frugal *parser.Frugal, outputDir string) error {
+	request, err := json.Marshal(&plugin.Request{
+		ProtocolVersion: plugin.ProtocolVersion,
+		File:            frugal.File,
+		Options:         e.options,
+	})
+	if err != nil {
+		return err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(e.path)
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s generator %s failed: %s\n%s", e.lang, e.path, err, stderr.String())
+	}
+
+	var response plugin.Response
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return fmt.Errorf("%s generator %s wrote an invalid response: %s", e.lang, e.path, err)
+	}
+	for _, warning := range response.Warnings {
+		globals.Warn(fmt.Sprintf("%s: %s", e.lang, warning))
+	}
+	if response.Error != "" {
+		return fmt.Errorf("%s generator: %s", e.lang, response.Error)
+	}
+
+	for _, file := range response.Files {
+		path := filepath.FromSlash(file.Path)
+		if filepath.IsAbs(path) || strings.HasPrefix(filepath.Clean(path), "..") {
+			return fmt.Errorf("%s generator wrote %s outside of the output directory", e.lang, file.Path)
+		}
+		path = filepath.Join(outputDir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, []byte(file.Contents), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetOutputDir returns the output directory, since external generators choose
+// the paths of the files they generate.
+func (e *externalGenerator) GetOutputDir(dir string, f *parser.Frugal) string {
+	return dir
+}
+
+// DefaultOutputDir returns the default output directory for generated files.
+func (e *externalGenerator) DefaultOutputDir() string {
+	return "gen-" + e.lang
+}
+
+// UseVendor returns false since external generators don't support vendored
+// includes.
+func (e *externalGenerator) UseVendor() bool {
+	return false
+}
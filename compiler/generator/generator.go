@@ -17,6 +17,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/Workiva/frugal/compiler/globals"
 	"github.com/Workiva/frugal/compiler/parser"
 )
 
@@ -38,6 +39,117 @@ const (
 	ObjectFile             FileType = "object"
 )
 
+// Options overriding the output directory of an artifact type, which every
+// language generator supports. Their values replace the -out directory for the
+// artifact, so the language's layout within the directory is preserved.
+const (
+	ModelsOutOption   = "models_out"
+	ScopesOutOption   = "scopes_out"
+	ServicesOutOption = "services_out"
+)
+
+// RuntimeCheckOption makes generated code check the version of the runtime
+// library when loaded, for languages whose library reports its version.
+const RuntimeCheckOption = "runtime_check"
+
+// HooksOption makes generated publishers and subscribers call settable
+// callbacks before publishing and after receiving each message.
+const HooksOption = "hooks"
+
+// BatchPublishOption generates publish methods framing a list of messages
+// into a single transport flush, and subscribers which receive them.
+const BatchPublishOption = "batch_publish"
+
+// ContentTypeOption generates publishers recording the content type of their
+// protocol in each message, and subscribers decoding each message with the
+// codec registered for its content type.
+const ContentTypeOption = "content_type"
+
+// CopyMergeOption generates methods deep copying structs and merging the
+// fields set in one struct into another.
+const CopyMergeOption = "copy_merge"
+
+// JSONOption generates methods encoding structs to and decoding them from JSON
+// objects keyed by field name.
+const JSONOption = "json"
+
+// UnknownEnumsOption sets how generated code decodes enum values which aren't
+// declared in the IDL, such as values added by a newer version of it.
+const UnknownEnumsOption = "unknown_enums"
+
+// Strategies of the UnknownEnumsOption.
+const (
+	// UnknownEnumsError fails decoding with a protocol error.
+	UnknownEnumsError = "error"
+	// UnknownEnumsSentinel decodes the value as the enum's UnknownEnumValue.
+	UnknownEnumsSentinel = "unknown"
+	// UnknownEnumsPreserve keeps the raw integer value.
+	UnknownEnumsPreserve = "preserve"
+)
+
+// UnknownEnumValue is the name of the enum value which undeclared values are
+// decoded as with the UnknownEnumsSentinel strategy.
+const UnknownEnumValue = "UNKNOWN"
+
+// PrefixValidationOption makes generated publishers and subscribers check the
+// values of topic prefix variables, which are rejected if empty. Values
+// containing the topic delimiter, which would change the topic's routing, are
+// rejected with PrefixValidationError or have it replaced with
+// PrefixSanitizeReplacement with PrefixValidationSanitize.
+const PrefixValidationOption = "prefix_validation"
+
+// Behaviors of the PrefixValidationOption.
+const (
+	PrefixValidationError    = "error"
+	PrefixValidationSanitize = "sanitize"
+)
+
+// PrefixSanitizeReplacement replaces the topic delimiter in prefix variable
+// values with the PrefixValidationSanitize behavior.
+const PrefixSanitizeReplacement = "_"
+
+// ScopeProtocolOption sets the protocol messages of scopes without a
+// "protocol" annotation are serialized with, parser.ProtocolJSON or
+// parser.ProtocolProvider.
+const ScopeProtocolOption = "scope_protocol"
+
+const (
+	modelsOutUsage   = "Output directory for types and constants, in place of -out"
+	scopesOutUsage   = "Output directory for publishers and subscribers, in place of -out"
+	servicesOutUsage = "Output directory for services, in place of -out"
+)
+
+const runtimeCheckUsage = "Fail at startup if the Frugal library is older than the given version " +
+	"(default: the compiler's version) or has another major version"
+
+const unknownEnumsUsage = "[error|unknown|preserve] How to decode enum values not declared in the IDL: " +
+	"error: fail with a protocol error, unknown: use the enum's UNKNOWN value, which it must declare, " +
+	"preserve: keep the integer value (default: the language's historical behavior)"
+
+const prefixValidationUsage = "[error|sanitize] Reject empty topic prefix variables in publishers and subscribers, " +
+	"and reject values containing the topic delimiter (error) or replace it with \"_\" (sanitize)"
+
+const scopeProtocolUsage = "[json|provider] Protocol to serialize messages of scopes without a \"protocol\" annotation with: " +
+	"json: Thrift's JSON protocol, provider: the scope provider's protocol (default)"
+
+const hooksUsage = "Generate publishers and subscribers with settable callbacks run before publishing " +
+	"and after receiving each message, which are given the context and operation name"
+
+const subscribeVersionsUsage = "Number of previous versions of versioned scopes generated subscribers also " +
+	"subscribe to, so messages from publishers which haven't migrated yet are received during migrations (default: 1)"
+
+const batchPublishUsage = "Generate publish methods sending a list of messages in a single frame of " +
+	"length-prefixed segments with one transport flush, and subscribers which receive them " +
+	"(subscribers must be generated with this option before publishers use the methods)"
+
+const contentTypeUsage = "Generate publishers recording the content type of their protocol in each message, " +
+	"and subscribers decoding each message with the codec registered for its content type, " +
+	"so publishers can migrate to another protocol before every subscriber does"
+
+const copyMergeUsage = "Generate methods deep copying structs and merging the fields set in one struct into another"
+
+const jsonUsage = "Generate methods encoding structs to and decoding them from JSON objects keyed by field name"
+
 // Options contains language generator options. The map key is the option name,
 // and the value is the option description.
 type Options map[string]string
@@ -49,12 +161,28 @@ type LanguageOptions map[string]Options
 // it supports.
 var Languages = LanguageOptions{
 	"go": Options{
-		"thrift_import":  "Override Thrift package import path (default: git.apache.org/thrift.git/lib/go/thrift)",
-		"frugal_import":  "Override Frugal package import path (default: github.com/Workiva/frugal/lib/go)",
-		"package_prefix": "Package prefix for generated files",
-		"async":          "Generate async client code using channels",
-		"use_vendor":     "Use specified import references for vendored includes and do not generate code for them",
-		"slim":           "Generate slim type definitions (WARNING: code generated by this may break code consumers, protocol logic should not change)",
+		"thrift_import":   "Override Thrift package import path (default: git.apache.org/thrift.git/lib/go/thrift)",
+		"frugal_import":   "Override Frugal package import path (default: github.com/Workiva/frugal/lib/go)",
+		"package_prefix":  "Package prefix for generated files",
+		"async":           "Generate async client code using channels",
+		"use_vendor":      "Use specified import references for vendored includes and do not generate code for them",
+		"slim":            "Generate slim type definitions (WARNING: code generated by this may break code consumers, protocol logic should not change)",
+		"bridge":          "Generate a command bridging the scopes between NATS and HTTP for services without a Frugal runtime",
+		"builders":        "Generate fluent builders for structs and exceptions",
+		"sorted_maps":     "Write the entries of maps and sets, which are maps in Go, in sorted order so serialized data is deterministic",
+		"unknown_enums":   unknownEnumsUsage,
+		ModelsOutOption:   modelsOutUsage,
+		ScopesOutOption:   scopesOutUsage,
+		ServicesOutOption: servicesOutUsage,
+
+		PrefixValidationOption: prefixValidationUsage,
+		CopyMergeOption:        copyMergeUsage,
+		JSONOption:             jsonUsage,
+		RuntimeCheckOption:     runtimeCheckUsage,
+		ScopeProtocolOption:    scopeProtocolUsage,
+		"subscribe_versions":   subscribeVersionsUsage,
+		BatchPublishOption:     batchPublishUsage,
+		ContentTypeOption:      contentTypeUsage,
 	},
 	"java": Options{
 		"generated_annotations": "[undated|suppress] " +
@@ -62,32 +190,104 @@ var Languages = LanguageOptions{
 			"suppress: suppress @Generated annotations entirely",
 		"async":            "Generate async client code using futures",
 		"boxed_primitives": "Generate primitives as the boxed equivalents",
+		"builders":         "Generate fluent builders for structs and exceptions",
+		"maven":            "Generate a pom.xml building the generated code in the output directory, with the given group ID (default: com.workiva.frugal.generated)",
 		"use_vendor":       "Use specified import references for vendored includes and do not generate code for them",
+		"unknown_enums":    unknownEnumsUsage,
+		ModelsOutOption:    modelsOutUsage,
+		ScopesOutOption:    scopesOutUsage,
+		ServicesOutOption:  servicesOutUsage,
+
+		PrefixValidationOption: prefixValidationUsage,
+		CopyMergeOption:        copyMergeUsage,
+		JSONOption:             jsonUsage,
+		BatchPublishOption:     batchPublishUsage,
 	},
 	"dart": Options{
 		"library_prefix": "Generate code that can be used within an existing library. " +
 			"Use a dot-separated string, e.g. \"my_parent_lib.src.gen\"",
 		"use_enums":  "Generate enums as enums rather than a class with numerical constants",
 		"use_vendor": "Use specified import references for vendored includes and do not generate code for them",
+		"path_template": "Template for generated source file paths relative to the output directory " +
+			"using {namespace}, {type} (types, services, or scopes), and {name}, " +
+			"e.g. \"{namespace}/lib/src/{type}/{name}.dart\" (default: {namespace}/lib/src/{name}.dart)",
+		"nested_namespaces": "Keep dotted namespaces as nested directories and dotted library names rather than " +
+			"flattening them with underscores (requires library_prefix)",
+		"built_collections": "Generate lists, sets, and maps as the immutable BuiltList, BuiltSet, and BuiltMap " +
+			"of the built_collection package, which compare by value",
+		"browser": "Generate a WebSocket transport for the services which works both in the Dart VM and in browsers, " +
+			"configuring w_transport for the platform with conditional imports",
+		"resilient_publishers": "Generate a Resilient<Scope>Publisher for each scope, retrying publishes which fail " +
+			"with transient transport errors with jittered backoff",
+		"flutter_compute": "Decode the messages of subscribers in a background isolate with Flutter's compute, " +
+			"keeping the UI isolate responsive (requires Flutter)",
+		"exclude_exports": "Categories of artifacts to leave out of the library's export file, separated by \"+\": " +
+			"constants, structs, unions, exceptions, enums, services, or scopes, e.g. \"services+scopes\"",
+		"thrift_dep": "Source of the thrift dependency in the generated pubspec.yaml: hosted (default: pub.workiva.org), " +
+			"hosted+<url>, git+<url>[#<ref>], or path+<dir>",
+		"thrift_version": "Version constraint of the hosted thrift dependency (default: ^0.0.7)",
+		"frugal_dep": "Source of the frugal dependency in the generated pubspec.yaml: hosted (default: pub.workiva.org), " +
+			"hosted+<url>, git+<url>[#<ref>], or path+<dir>",
+		"frugal_version":  "Version constraint of the hosted frugal dependency (default: ^<compiler version>)",
+		"unknown_enums":   unknownEnumsUsage,
+		ModelsOutOption:   modelsOutUsage,
+		ScopesOutOption:   scopesOutUsage,
+		ServicesOutOption: servicesOutUsage,
+
+		PrefixValidationOption: prefixValidationUsage,
+		CopyMergeOption:        copyMergeUsage,
+		HooksOption:            hooksUsage,
+		BatchPublishOption:     batchPublishUsage,
+		JSONOption:             jsonUsage,
 	},
 	"py": Options{
-		"tornado":        "Generate code for use with Tornado (compatible with Python 2.7)",
-		"asyncio":        "Generate code for use with asyncio (compatible with Python 3.5 or above)",
-		"package_prefix": "Package prefix for generated files",
+		"tornado":         "Generate code for use with Tornado (compatible with Python 2.7)",
+		"asyncio":         "Generate code for use with asyncio (compatible with Python 3.5 or above)",
+		"package_prefix":  "Package prefix for generated files",
+		"setup":           "Generate a setup.py packaging the generated modules, named by the option's value or the file name",
+		"unknown_enums":   unknownEnumsUsage,
+		ModelsOutOption:   modelsOutUsage,
+		ScopesOutOption:   scopesOutUsage,
+		ServicesOutOption: servicesOutUsage,
+
+		PrefixValidationOption: prefixValidationUsage,
+		CopyMergeOption:        copyMergeUsage,
+		HooksOption:            hooksUsage,
+		RuntimeCheckOption:     runtimeCheckUsage,
+		BatchPublishOption:     batchPublishUsage,
+		JSONOption:             jsonUsage,
 	},
 	"html": Options{
 		"standalone": "Self-contained mode, includes all CSS in the HTML files. Generates no style.css file, but HTML files will be larger",
 	},
+	"broker": Options{
+		"nats":      "Generate NATS subjects and role permissions (all manifests are generated if none are selected)",
+		"kafka":     "Generate Kafka topic definitions",
+		"rabbitmq":  "Generate RabbitMQ exchange declarations",
+		"terraform": "Generate a Terraform configuration provisioning the Kafka topics and RabbitMQ exchanges",
+	},
+	"grpc": Options{
+		"package_prefix":    "Package prefix of the Go packages generated for the Frugal files",
+		"go_package_prefix": "Package prefix of the Go packages protoc generates in the output directory",
+		"frugal_import":     "Override Frugal package import path (default: github.com/Workiva/frugal/lib/go)",
+	},
+	"graphql": Options{},
 }
 
 // ValidateOption indicates if the language option is supported for the given
-// language.
+// language. Every option is supported for languages whose generator validates
+// its own options.
 func ValidateOption(lang, option string) bool {
-	options, ok := Languages[lang]
-	if !ok {
-		return false
+	if _, ok := Languages[lang]; !ok {
+		if _, ok := lookup(lang); !ok {
+			return false
+		}
 	}
-	_, ok = options[option]
+	options := Languages[lang]
+	if options == nil {
+		return true
+	}
+	_, ok := options[option]
 	return ok
 }
 
@@ -122,6 +322,7 @@ type LanguageGenerator interface {
 	GetOutputDir(dir string) string
 	DefaultOutputDir() string
 	PostProcess(*os.File) error
+	Option(name string) (string, bool)
 
 	// Thrift stuff
 	GenerateConstantsContents([]*parser.Constant) error
@@ -166,6 +367,14 @@ func NewProgramGenerator(generator LanguageGenerator, splitPublisherSubscriber b
 // Generate the Frugal in the given directory.
 func (o *programGenerator) Generate(frugal *parser.Frugal, outputDir string) error {
 	o.SetFrugal(frugal)
+	servicesDir := o.artifactOutputDir(frugal, ServicesOutOption, outputDir)
+	scopesDir := o.artifactOutputDir(frugal, ScopesOutOption, outputDir)
+	outputDir = o.artifactOutputDir(frugal, ModelsOutOption, outputDir)
+	for _, dir := range []string{servicesDir, scopesDir, outputDir} {
+		if err := globals.CheckOutputDir(dir); err != nil {
+			return err
+		}
+	}
 	if err := o.SetupGenerator(outputDir); err != nil {
 		return err
 	}
@@ -210,21 +419,21 @@ func (o *programGenerator) Generate(frugal *parser.Frugal, outputDir string) err
 
 	// Generate services
 	for _, service := range frugal.Services {
-		if err := o.generateServiceFile(service, outputDir); err != nil {
+		if err := o.generateServiceFile(service, servicesDir); err != nil {
 			return err
 		}
 	}
 	// Generate scopes
 	for _, scope := range frugal.Scopes {
 		if o.splitPublisherSubscriber {
-			if err := o.generateScopeFile(scope, outputDir, PublishFile); err != nil {
+			if err := o.generateScopeFile(scope, scopesDir, PublishFile); err != nil {
 				return err
 			}
-			if err := o.generateScopeFile(scope, outputDir, SubscribeFile); err != nil {
+			if err := o.generateScopeFile(scope, scopesDir, SubscribeFile); err != nil {
 				return err
 			}
 		} else {
-			if err := o.generateScopeFile(scope, outputDir, CombinedScopeFile); err != nil {
+			if err := o.generateScopeFile(scope, scopesDir, CombinedScopeFile); err != nil {
 				return err
 			}
 		}
@@ -233,6 +442,15 @@ func (o *programGenerator) Generate(frugal *parser.Frugal, outputDir string) err
 	return o.TeardownGenerator()
 }
 
+// artifactOutputDir returns the full output directory for the artifact type
+// of the given option, which is outputDir unless the option is set.
+func (o *programGenerator) artifactOutputDir(frugal *parser.Frugal, option, outputDir string) string {
+	if root, ok := o.Option(option); ok && root != "" {
+		return o.GetOutputDir(root, frugal)
+	}
+	return outputDir
+}
+
 func (o *programGenerator) generateServiceFile(service *parser.Service, outputDir string) error {
 	file, err := o.GenerateFile(service.Name, outputDir, CombinedServiceFile)
 	if err != nil {
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// useBatchPublish indicates if publishers should have methods publishing a
+// batch of messages in a single frame, and subscribers should receive them.
+func (g *Generator) useBatchPublish() bool {
+	_, ok := g.Options[generator.BatchPublishOption]
+	return ok
+}
+
+// generateRecvCallback generates the FAsyncCallback subscribers of the
+// operation subscribe with.
+func (g *Generator) generateRecvCallback(op *parser.Operation) string {
+	return g.wrapBatchFrameCallback(fmt.Sprintf("l.recv%s(op, protocolFactory, handler)", op.Name))
+}
+
+// wrapBatchFrameCallback wraps the given FAsyncCallback so it splits batch
+// frames into their messages with the batch_publish option.
+func (g *Generator) wrapBatchFrameCallback(callback string) string {
+	if g.useBatchPublish() {
+		return fmt.Sprintf("frugal.NewFBatchFrameCallback(%s)", callback)
+	}
+	return callback
+}
+
+// generatePublishBatchMethod generates the method publishing a list of
+// messages to the operation in a single frame, and the internal method it
+// invokes through the middleware.
+func (g *Generator) generatePublishBatchMethod(scope *parser.Scope, op *parser.Operation, args string) string {
+	var (
+		scopeLower = parser.LowercaseFirstLetter(scope.Name)
+		scopeTopic = scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter)
+		goType     = g.getGoTypeFromThriftType(op.Type)
+		publisher  = ""
+	)
+
+	publisher += fmt.Sprintf("// Publish%sBatch publishes the messages in a single frame, sent with\n", op.Name)
+	publisher += "// one transport flush. Subscribers must be generated with the batch_publish\n"
+	publisher += "// option to receive them.\n"
+	publisher += fmt.Sprintf("func (p *%sPublisher) Publish%sBatch(ctx frugal.FContext, %sreqs []%s) error {\n",
+		scopeLower, op.Name, args, goType)
+	invokeArgs := "[]interface{}{ctx"
+	for _, v := range scope.Prefix.Variables {
+		invokeArgs += ", " + v
+	}
+	invokeArgs += ", reqs}"
+	publisher += g.generateSample(op, "\t")
+	publisher += g.generateStartSpan("Publish", scope, strconv.Quote(op.Name), "\t")
+	publisher += fmt.Sprintf("\tret := p.methods[\"publish%sBatch\"].Invoke(%s)\n", op.Name, invokeArgs)
+	publisher += g.generateFinishSpan("ret.Error()", "\t")
+	publisher += "\tif ret[0] != nil {\n"
+	publisher += "\t\treturn ret[0].(error)\n"
+	publisher += "\t}\n"
+	publisher += "\treturn nil\n"
+	publisher += "}\n\n"
+
+	publisher += fmt.Sprintf("func (p *%sPublisher) publish%sBatch(ctx frugal.FContext, %sreqs []%s) error {\n",
+		scopeLower, op.Name, args, goType)
+	publisher += g.generatePrefixVariableChecks(scope, "return err")
+	for _, prefixVar := range scope.Prefix.Variables {
+		publisher += fmt.Sprintf("\tctx.AddRequestHeader(\"_topic_%s\", %s)\n", prefixVar, prefixVar)
+	}
+	publisher += fmt.Sprintf("\top := \"%s\"\n", op.Name)
+	publisher += fmt.Sprintf("\tprefix := %s\n", generatePrefixStringTemplate(scope))
+	publisher += "\ttopic := fmt.Sprintf(\"%s" + scopeTopic + "%s%s\", prefix, delimiter, op)\n"
+	publisher += g.generateAuthorize(scope, "Publish", "p.provider", "return err")
+	publisher += g.generateSetContentType()
+	publisher += "\tframes := make([][]byte, 0, len(reqs))\n"
+	publisher += "\tfor _, req := range reqs {\n"
+	publisher += "\t\tbuffer := frugal.NewTMemoryOutputBuffer(p.transport.GetPublishSizeLimit())\n"
+	publisher += "\t\toprot := p.protocolFactory.GetProtocol(buffer)\n"
+	publisher += "\t\tif err := oprot.WriteRequestHeader(ctx); err != nil {\n"
+	publisher += "\t\t\treturn err\n"
+	publisher += "\t\t}\n"
+	publisher += "\t\tif err := oprot.WriteMessageBegin(op, thrift.CALL, 0); err != nil {\n"
+	publisher += "\t\t\treturn err\n"
+	publisher += "\t\t}\n"
+	publisher += g.generateWriteFieldRec(parser.FieldFromType(op.Type, ""), "req")
+	publisher += "\t\tif err := oprot.WriteMessageEnd(); err != nil {\n"
+	publisher += "\t\t\treturn err\n"
+	publisher += "\t\t}\n"
+	publisher += "\t\tif err := oprot.Flush(); err != nil {\n"
+	publisher += "\t\t\treturn err\n"
+	publisher += "\t\t}\n"
+	publisher += "\t\tframes = append(frames, buffer.Bytes())\n"
+	publisher += "\t}\n"
+	publisher += "\treturn frugal.PublishBatchFrame(ctx, p.transport, topic, frames)\n"
+	publisher += "}\n"
+	return publisher
+}
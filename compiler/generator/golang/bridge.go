@@ -0,0 +1,216 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const (
+	bridgeOption = "bridge"
+	bridgeSuffix = "_bridge"
+)
+
+// useBridge indicates if a bridge command should be generated for the scopes.
+func (g *Generator) useBridge() bool {
+	_, ok := g.Options[bridgeOption]
+	return ok
+}
+
+// validateBridge ensures the scopes are generated in the package the bridge
+// imports.
+func (g *Generator) validateBridge() error {
+	if g.useBridge() && g.ArtifactsSeparated(generator.ScopesOutOption) {
+		return fmt.Errorf("go option %s can't be used with %s", bridgeOption, generator.ScopesOutOption)
+	}
+	return nil
+}
+
+// generateBridge generates a command in the <name>_bridge directory of the
+// package which bridges the scopes between NATS and HTTP, so services without
+// a Frugal runtime can publish and subscribe to events. See
+// frugal.FScopeBridge for the HTTP interface.
+func (g *Generator) generateBridge(outputDir string) error {
+	if len(g.Frugal.Scopes) == 0 {
+		return nil
+	}
+	dir := filepath.Join(outputDir, g.Frugal.Name+bridgeSuffix)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	file, err := os.Create(filepath.Join(dir, "main.go"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := g.GenerateDocStringComment(file); err != nil {
+		return err
+	}
+	if err := g.GenerateNewline(file, 2); err != nil {
+		return err
+	}
+	imports, err := g.generateBridgeImports()
+	if err != nil {
+		return err
+	}
+
+	contents := fmt.Sprintf("// Command %s%s bridges the scopes of %s.frugal between NATS and HTTP.\n",
+		g.Frugal.Name, bridgeSuffix, g.Frugal.Name)
+	contents += "// Events are published by POSTing them as JSON to /<scope>/<operation> and\n"
+	contents += "// subscribed to as Server-Sent Events with a GET of the same path. Prefix\n"
+	contents += "// variables are given as query parameters.\n"
+	contents += "package main\n\n"
+	contents += imports + "\n\n"
+	contents += g.generateBridgeMain()
+	for _, scope := range g.Frugal.Scopes {
+		contents += "\n" + g.generateBridgeScope(scope)
+	}
+	g.modelsPackage = ""
+
+	if _, err := file.WriteString(contents); err != nil {
+		return err
+	}
+	return g.PostProcess(file)
+}
+
+// generateBridgeImports returns the imports of the bridge command. Local
+// types are referenced through the generated package until the next call to
+// generateModelsImport.
+func (g *Generator) generateBridgeImports() (string, error) {
+	imports := "import (\n"
+	imports += "\t\"flag\"\n"
+	imports += "\t\"log\"\n"
+	imports += "\t\"net/http\"\n\n"
+	if g.Options[thriftImportOption] != "" {
+		imports += "\t\"" + g.Options[thriftImportOption] + "\"\n"
+	} else {
+		imports += "\t\"git.apache.org/thrift.git/lib/go/thrift\"\n"
+	}
+	if g.Options[frugalImportOption] != "" {
+		imports += "\t\"" + g.Options[frugalImportOption] + "\"\n"
+	} else {
+		imports += "\t\"github.com/Workiva/frugal/lib/go\"\n"
+	}
+	imports += "\tnats \"github.com/nats-io/go-nats\"\n"
+
+	pkgPrefix := g.Options[packagePrefixOption]
+	scopeIncludes, err := g.Frugal.ReferencedScopeIncludes()
+	if err != nil {
+		return "", err
+	}
+	for _, include := range scopeIncludes {
+		imp, err := g.generateIncludeImport(include, pkgPrefix)
+		if err != nil {
+			return "", err
+		}
+		imports += imp
+	}
+
+	name := g.packageName()
+	g.modelsPackage = includeNameToReference(name)
+	imports += fmt.Sprintf("\t\"%s%s\"\n", pkgPrefix, includeNameToImport(name))
+	imports += ")"
+	return imports, nil
+}
+
+func (g *Generator) generateBridgeMain() string {
+	contents := "func main() {\n"
+	contents += "\tnatsURL := flag.String(\"nats\", nats.DefaultURL, \"NATS server URL\")\n"
+	contents += "\taddr := flag.String(\"addr\", \"localhost:8080\", \"HTTP listen address\")\n"
+	contents += "\tprotocol := flag.String(\"protocol\", \"binary\", \"Protocol of published events: binary, compact, or json\")\n"
+	contents += "\tflag.Parse()\n\n"
+
+	contents += "\tvar protocolFactory thrift.TProtocolFactory\n"
+	contents += "\tswitch *protocol {\n"
+	contents += "\tcase \"binary\":\n"
+	contents += "\t\tprotocolFactory = thrift.NewTBinaryProtocolFactoryDefault()\n"
+	contents += "\tcase \"compact\":\n"
+	contents += "\t\tprotocolFactory = thrift.NewTCompactProtocolFactory()\n"
+	contents += "\tcase \"json\":\n"
+	contents += "\t\tprotocolFactory = thrift.NewTJSONProtocolFactory()\n"
+	contents += "\tdefault:\n"
+	contents += "\t\tlog.Fatalf(\"Invalid protocol %s\", *protocol)\n"
+	contents += "\t}\n\n"
+
+	contents += "\tconn, err := nats.Connect(*natsURL)\n"
+	contents += "\tif err != nil {\n"
+	contents += "\t\tlog.Fatal(err)\n"
+	contents += "\t}\n"
+	contents += "\tdefer conn.Close()\n\n"
+
+	contents += "\tprovider := frugal.NewFScopeProvider(\n"
+	contents += "\t\tfrugal.NewFNatsPublisherTransportFactory(conn),\n"
+	contents += "\t\tfrugal.NewFNatsSubscriberTransportFactory(conn),\n"
+	contents += "\t\tfrugal.NewFProtocolFactory(protocolFactory),\n"
+	contents += "\t)\n"
+	contents += "\tbridge := frugal.NewFScopeBridge()\n"
+	for _, scope := range g.Frugal.Scopes {
+		contents += fmt.Sprintf("\tif err := bridge%s(bridge, provider); err != nil {\n", snakeToCamel(scope.Name))
+		contents += "\t\tlog.Fatal(err)\n"
+		contents += "\t}\n"
+	}
+	contents += "\tlog.Fatal(http.ListenAndServe(*addr, bridge))\n"
+	contents += "}\n"
+	return contents
+}
+
+// generateBridgeScope generates the function registering the operations of
+// the scope with the bridge.
+func (g *Generator) generateBridgeScope(scope *parser.Scope) string {
+	scopeCamel := snakeToCamel(scope.Name)
+	variables := "nil"
+	args := ""
+	if len(scope.Prefix.Variables) > 0 {
+		quoted := make([]string, len(scope.Prefix.Variables))
+		for i, variable := range scope.Prefix.Variables {
+			quoted[i] = fmt.Sprintf("%q", variable)
+			args += fmt.Sprintf("variables[%d], ", i)
+		}
+		variables = fmt.Sprintf("[]string{%s}", strings.Join(quoted, ", "))
+	}
+
+	contents := fmt.Sprintf("func bridge%s(bridge *frugal.FScopeBridge, provider *frugal.FScopeProvider) error {\n", scopeCamel)
+	contents += fmt.Sprintf("\tpublisher := %s.New%sPublisher(provider)\n", g.modelsPackage, scopeCamel)
+	contents += "\tif err := publisher.Open(); err != nil {\n"
+	contents += "\t\treturn err\n"
+	contents += "\t}\n"
+	contents += fmt.Sprintf("\tsubscriber := %s.New%sSubscriber(provider)\n", g.modelsPackage, scopeCamel)
+	for _, op := range scope.Operations {
+		goType := g.getGoTypeFromThriftType(op.Type)
+		contents += fmt.Sprintf("\tbridge.HandlePublish(%q, %q, %s, func(ctx frugal.FContext, variables []string, decode func(interface{}) error) error {\n",
+			scope.Name, op.Name, variables)
+		contents += fmt.Sprintf("\t\tvar req %s\n", goType)
+		contents += "\t\tif err := decode(&req); err != nil {\n"
+		contents += "\t\t\treturn err\n"
+		contents += "\t\t}\n"
+		contents += fmt.Sprintf("\t\treturn publisher.Publish%s(ctx, %sreq)\n", op.Name, args)
+		contents += "\t})\n"
+		contents += fmt.Sprintf("\tbridge.HandleSubscribe(%q, %q, %s, func(variables []string, handler func(frugal.FContext, interface{})) (*frugal.FSubscription, error) {\n",
+			scope.Name, op.Name, variables)
+		contents += fmt.Sprintf("\t\treturn subscriber.Subscribe%s(%sfunc(ctx frugal.FContext, req %s) {\n", op.Name, args, goType)
+		contents += "\t\t\thandler(ctx, req)\n"
+		contents += "\t\t})\n"
+		contents += "\t})\n"
+	}
+	contents += "\treturn nil\n"
+	contents += "}\n"
+	return contents
+}
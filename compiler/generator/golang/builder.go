@@ -0,0 +1,61 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package golang
+
+import (
+	"fmt"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// generateBuilder generates a fluent builder for the given struct or
+// exception when the builders option is set. Each field gets a With method
+// taking its value, and Build returns a copy of the built struct so the
+// builder can be reused.
+func (g *Generator) generateBuilder(s *parser.Struct, sName string) string {
+	if !g.generateBuilders() || s.Type == parser.StructTypeUnion {
+		return ""
+	}
+
+	bName := sName + "Builder"
+	contents := fmt.Sprintf("// %s builds %s values field by field.\n", bName, sName)
+	contents += fmt.Sprintf("type %s struct {\n", bName)
+	contents += fmt.Sprintf("\tp *%s\n", sName)
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("// New%s returns a builder starting from the defaults of New%s.\n", bName, sName)
+	contents += fmt.Sprintf("func New%s() *%s {\n", bName, bName)
+	contents += fmt.Sprintf("\treturn &%s{p: New%s()}\n", bName, sName)
+	contents += "}\n\n"
+
+	for _, field := range s.Fields {
+		fName := title(field.Name)
+		goType := g.getGoTypeFromThriftTypePtr(field.Type, false)
+		value := "value"
+		if g.isPointerField(field) && !g.Frugal.IsStruct(g.Frugal.UnderlyingType(field.Type)) {
+			value = "&value"
+		}
+		contents += g.generateCommentWithDeprecated(field.Comment, "", field.Annotations)
+		contents += fmt.Sprintf("func (b *%s) With%s(value %s) *%s {\n", bName, fName, goType, bName)
+		contents += fmt.Sprintf("\tb.p.%s = %s\n", fName, value)
+		contents += "\treturn b\n"
+		contents += "}\n\n"
+	}
+
+	contents += fmt.Sprintf("// Build returns a copy of the %s built so far.\n", sName)
+	contents += fmt.Sprintf("func (b *%s) Build() *%s {\n", bName, sName)
+	contents += "\tp := *b.p\n"
+	contents += "\treturn &p\n"
+	contents += "}\n\n"
+	return contents
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package golang
+
+import (
+	"fmt"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// generateValidate generates a Validate method checking the constraints on
+// the struct's fields, along with the compiled patterns it uses, for structs
+// with constraints. Write calls Validate before serializing the struct.
+func (g *Generator) generateValidate(s *parser.Struct, sName string) string {
+	if !s.HasConstraints() {
+		return ""
+	}
+
+	contents := ""
+	for _, field := range s.Fields {
+		if constraints := field.Constraints(); constraints != nil && constraints.Pattern != "" {
+			contents += fmt.Sprintf("var %s = regexp.MustCompile(%s)\n\n",
+				patternVar(sName, field), g.quote(constraints.Pattern))
+		}
+	}
+
+	contents += fmt.Sprintf("// Validate returns an error if a field of %s violates its constraints.\n", sName)
+	contents += fmt.Sprintf("func (p *%s) Validate() error {\n", sName)
+	for _, field := range s.Fields {
+		constraints := field.Constraints()
+		if constraints == nil {
+			continue
+		}
+		fName := title(field.Name)
+		value := "p." + fName
+		if g.isPointerField(field) {
+			value = "*" + value
+		}
+		if field.Type.Name != "string" && g.Frugal.UnderlyingType(field.Type).Name == "string" {
+			// Typedefs of strings are named types.
+			value = fmt.Sprintf("string(%s)", value)
+		}
+		isSet := ""
+		if field.Modifier == parser.Optional || g.isPointerField(field) {
+			isSet = fmt.Sprintf("p.IsSet%s() && ", fName)
+		}
+
+		bounded, what := value, ""
+		if g.Frugal.ConstrainsLength(field.Type) {
+			what = "length "
+			bounded = fmt.Sprintf("len(%s)", value)
+			if g.Frugal.UnderlyingType(field.Type).Name == "string" {
+				bounded = fmt.Sprintf("utf8.RuneCountInString(%s)", value)
+			}
+		}
+		if constraints.Min != "" {
+			contents += g.generateConstraintCheck(isSet+fmt.Sprintf("%s < %s", bounded, constraints.Min),
+				fmt.Sprintf("%s.%s %smust be at least %s", s.Name, field.Name, what, constraints.Min))
+		}
+		if constraints.Max != "" {
+			contents += g.generateConstraintCheck(isSet+fmt.Sprintf("%s > %s", bounded, constraints.Max),
+				fmt.Sprintf("%s.%s %smust be at most %s", s.Name, field.Name, what, constraints.Max))
+		}
+		if constraints.Pattern != "" {
+			contents += g.generateConstraintCheck(isSet+fmt.Sprintf("!%s.MatchString(%s)", patternVar(sName, field), value),
+				fmt.Sprintf("%s.%s must match %s", s.Name, field.Name, constraints.Pattern))
+		}
+	}
+	contents += "\treturn nil\n"
+	contents += "}\n\n"
+	return contents
+}
+
+func (g *Generator) generateConstraintCheck(condition, message string) string {
+	contents := fmt.Sprintf("\tif %s {\n", condition)
+	contents += fmt.Sprintf("\t\treturn thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, errors.New(%s))\n", g.quote(message))
+	contents += "\t}\n"
+	return contents
+}
+
+func patternVar(sName string, field *parser.Field) string {
+	return fmt.Sprintf("%s_%s_PATTERN", sName, title(field.Name))
+}
+
+// generateConstraintImports generates the imports needed by the Validate
+// methods of the file's structs.
+func (g *Generator) generateConstraintImports() string {
+	constrained, patterns, runes := false, false, false
+	for _, s := range g.Frugal.DataStructures() {
+		for _, field := range s.Fields {
+			constraints := field.Constraints()
+			if constraints == nil {
+				continue
+			}
+			constrained = true
+			patterns = patterns || constraints.Pattern != ""
+			if constraints.Min != "" || constraints.Max != "" {
+				runes = runes || g.Frugal.UnderlyingType(field.Type).Name == "string"
+			}
+		}
+	}
+	contents := ""
+	if constrained && len(g.Frugal.Enums) == 0 {
+		// Enums already import errors.
+		contents += "\t\"errors\"\n"
+	}
+	if patterns {
+		contents += "\t\"regexp\"\n"
+	}
+	if runes {
+		contents += "\t\"unicode/utf8\"\n"
+	}
+	return contents
+}
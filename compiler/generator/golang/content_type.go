@@ -0,0 +1,46 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import "github.com/Workiva/frugal/compiler/generator"
+
+// useContentType indicates if publishers should record the content type of
+// their protocol in messages, and subscribers should decode messages with the
+// codec of their content type.
+func (g *Generator) useContentType() bool {
+	_, ok := g.Options[generator.ContentTypeOption]
+	return ok
+}
+
+// generateSetContentType generates the recording of the publisher's content
+// type in the request headers with the content_type option.
+func (g *Generator) generateSetContentType() string {
+	if !g.useContentType() {
+		return ""
+	}
+	return "\tfrugal.SetContentType(ctx, p.protocolFactory)\n"
+}
+
+// generateNegotiateProtocol generates the replacement of a subscriber
+// callback's protocol with one decoding the message's content type with the
+// content_type option.
+func (g *Generator) generateNegotiateProtocol() string {
+	if !g.useContentType() {
+		return ""
+	}
+	contents := "\t\tif iprot, err = pf.NegotiateProtocol(ctx, iprot); err != nil {\n"
+	contents += "\t\t\treturn err\n"
+	contents += "\t\t}\n\n"
+	return contents
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package golang
+
+import (
+	"fmt"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// generateCopyMethods generates DeepCopy and, for structs and exceptions,
+// MergeFrom methods when the copy_merge option is set. DeepCopy copies every
+// pointer, slice, and map so the copy shares no memory with the original.
+// MergeFrom overwrites the fields set in another value with copies of them,
+// leaving the rest alone. Fields which aren't optional have no presence in
+// Go, so non-nillable ones are always overwritten.
+func (g *Generator) generateCopyMethods(s *parser.Struct, sName string) string {
+	if !g.generateCopyMerge() {
+		return ""
+	}
+
+	contents := fmt.Sprintf("// DeepCopy returns a copy of the %s sharing no memory with it.\n", sName)
+	contents += fmt.Sprintf("func (p *%s) DeepCopy() *%s {\n", sName, sName)
+	contents += "\tif p == nil {\n"
+	contents += "\t\treturn nil\n"
+	contents += "\t}\n"
+	contents += "\tc := *p\n"
+	for _, field := range s.Fields {
+		if g.isPointerField(field) || g.needsDeepCopy(field.Type) {
+			fName := title(field.Name)
+			contents += g.generateFieldCopy(field, "c."+fName, "p."+fName, "\t")
+		}
+	}
+	contents += "\treturn &c\n"
+	contents += "}\n\n"
+
+	if s.Type == parser.StructTypeUnion {
+		return contents
+	}
+
+	contents += "// MergeFrom sets the fields which are set in other to copies of them.\n"
+	contents += fmt.Sprintf("func (p *%s) MergeFrom(other *%s) {\n", sName, sName)
+	contents += "\tif other == nil {\n"
+	contents += "\t\treturn\n"
+	contents += "\t}\n"
+	for _, field := range s.Fields {
+		fName := title(field.Name)
+		underlyingType := g.Frugal.UnderlyingType(field.Type)
+		isPointer := g.isPointerField(field)
+		hasDefaultPresence := underlyingType.Name == "binary" && field.Default != nil
+		if g.Frugal.IsStruct(underlyingType) ||
+			(field.Modifier == parser.Optional && !isPointer && (hasDefaultPresence || !g.needsDeepCopy(field.Type))) {
+			// A nil struct or a default value isn't set
+			contents += fmt.Sprintf("\tif other.IsSet%s() {\n", fName)
+			contents += g.generateFieldCopy(field, "p."+fName, "other."+fName, "\t\t")
+			contents += "\t}\n"
+		} else {
+			// Nil pointers, slices, and maps aren't copied
+			contents += g.generateFieldCopy(field, "p."+fName, "other."+fName, "\t")
+		}
+	}
+	contents += "}\n\n"
+	return contents
+}
+
+// generateFieldCopy generates code assigning a copy of the src field to dst.
+func (g *Generator) generateFieldCopy(field *parser.Field, dst, src, indent string) string {
+	underlyingType := g.Frugal.UnderlyingType(field.Type)
+	switch {
+	case g.Frugal.IsStruct(underlyingType):
+		return fmt.Sprintf("%s%s = %s.DeepCopy()\n", indent, dst, src)
+	case g.isPointerField(field):
+		contents := fmt.Sprintf("%sif %s != nil {\n", indent, src)
+		contents += fmt.Sprintf("%s\tvalue := *%s\n", indent, src)
+		if g.needsDeepCopy(field.Type) {
+			contents += g.generateCopyValue("value", "*"+src, field.Type, indent+"\t", 0)
+		}
+		contents += fmt.Sprintf("%s\t%s = &value\n", indent, dst)
+		contents += indent + "}\n"
+		return contents
+	case g.needsDeepCopy(field.Type):
+		return g.generateCopyValue(dst, src, field.Type, indent, 0)
+	default:
+		return fmt.Sprintf("%s%s = %s\n", indent, dst, src)
+	}
+}
+
+// generateCopyValue generates code assigning a copy of src, a value of the
+// given type, to dst. Nil slices and maps are left alone.
+func (g *Generator) generateCopyValue(dst, src string, t *parser.Type, indent string, depth int) string {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsStruct(underlyingType) {
+		return fmt.Sprintf("%s%s = %s.DeepCopy()\n", indent, dst, src)
+	}
+	if !g.needsDeepCopy(t) {
+		return fmt.Sprintf("%s%s = %s\n", indent, dst, src)
+	}
+
+	goType := g.getGoTypeFromThriftTypePtr(t, false)
+	contents := fmt.Sprintf("%sif %s != nil {\n", indent, src)
+	contents += fmt.Sprintf("%s\t%s = make(%s, len(%s))\n", indent, dst, goType, src)
+	switch underlyingType.Name {
+	case "binary":
+		contents += fmt.Sprintf("%s\tcopy(%s, %s)\n", indent, dst, src)
+	case "list":
+		if !g.needsDeepCopy(underlyingType.ValueType) {
+			contents += fmt.Sprintf("%s\tcopy(%s, %s)\n", indent, dst, src)
+			break
+		}
+		i := fmt.Sprintf("i%d", depth)
+		elem := fmt.Sprintf("elem%d", depth)
+		contents += fmt.Sprintf("%s\tfor %s, %s := range %s {\n", indent, i, elem, src)
+		contents += g.generateCopyValue(fmt.Sprintf("%s[%s]", dst, i), elem, underlyingType.ValueType, indent+"\t\t", depth+1)
+		contents += indent + "\t}\n"
+	case "set":
+		elem := fmt.Sprintf("elem%d", depth)
+		contents += fmt.Sprintf("%s\tfor %s := range %s {\n", indent, elem, src)
+		contents += fmt.Sprintf("%s\t\t%s[%s] = true\n", indent, dst, g.copyKey(elem, underlyingType.ValueType))
+		contents += indent + "\t}\n"
+	case "map":
+		key := fmt.Sprintf("key%d", depth)
+		value := fmt.Sprintf("value%d", depth)
+		keyCopy := g.copyKey(key, underlyingType.KeyType)
+		contents += fmt.Sprintf("%s\tfor %s, %s := range %s {\n", indent, key, value, src)
+		switch {
+		case g.Frugal.IsStruct(g.Frugal.UnderlyingType(underlyingType.ValueType)):
+			contents += fmt.Sprintf("%s\t\t%s[%s] = %s.DeepCopy()\n", indent, dst, keyCopy, value)
+		case g.needsDeepCopy(underlyingType.ValueType):
+			// Copy through a variable so nil values keep their keys
+			valueCopy := fmt.Sprintf("valueCopy%d", depth)
+			contents += fmt.Sprintf("%s\t\tvar %s %s\n", indent, valueCopy, g.getGoTypeFromThriftTypePtr(underlyingType.ValueType, false))
+			contents += g.generateCopyValue(valueCopy, value, underlyingType.ValueType, indent+"\t\t", depth+1)
+			contents += fmt.Sprintf("%s\t\t%s[%s] = %s\n", indent, dst, keyCopy, valueCopy)
+		default:
+			contents += fmt.Sprintf("%s\t\t%s[%s] = %s\n", indent, dst, keyCopy, value)
+		}
+		contents += indent + "\t}\n"
+	}
+	contents += indent + "}\n"
+	return contents
+}
+
+// copyKey returns an expression copying the given map key or set element.
+// Only structs can be keys and need copying.
+func (g *Generator) copyKey(key string, t *parser.Type) string {
+	if g.Frugal.IsStruct(g.Frugal.UnderlyingType(t)) {
+		return key + ".DeepCopy()"
+	}
+	return key
+}
+
+// needsDeepCopy returns true if values of the given type share memory when
+// assigned.
+func (g *Generator) needsDeepCopy(t *parser.Type) bool {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsStruct(underlyingType) {
+		return true
+	}
+	switch underlyingType.Name {
+	case "binary", "list", "set", "map":
+		return true
+	default:
+		return false
+	}
+}
@@ -40,6 +40,8 @@ const (
 	asyncOption         = "async"
 	useVendorOption     = "use_vendor"
 	slimOption          = "slim"
+	buildersOption      = "builders"
+	sortedMapsOption    = "sorted_maps"
 )
 
 // Generator implements the LanguageGenerator interface for Go.
@@ -47,15 +49,40 @@ type Generator struct {
 	*generator.BaseGenerator
 	generateConstants bool
 	typesFile         *os.File
+
+	// modelsPackage is the reference to the package containing the types
+	// of the Frugal file when scopes or services are generated apart from
+	// them, otherwise empty.
+	modelsPackage string
 }
 
 // NewGenerator creates a new Go LanguageGenerator.
 func NewGenerator(options map[string]string) generator.LanguageGenerator {
-	return &Generator{&generator.BaseGenerator{Options: options}, true, nil}
+	return &Generator{&generator.BaseGenerator{Options: options}, true, nil, ""}
 }
 
 // SetupGenerator initializes globals the generator needs, like the types file.
 func (g *Generator) SetupGenerator(outputDir string) error {
+	if err := g.validateIncludeReferences(); err != nil {
+		return err
+	}
+	if err := g.validateBridge(); err != nil {
+		return err
+	}
+	if _, err := g.UnknownEnums(); err != nil {
+		return err
+	}
+	if _, err := g.PrefixValidation(); err != nil {
+		return err
+	}
+	if _, err := g.subscribeVersions(); err != nil {
+		return err
+	}
+	if protocol, ok := g.Options[generator.ScopeProtocolOption]; ok &&
+		protocol != parser.ProtocolJSON && protocol != parser.ProtocolProvider {
+		return fmt.Errorf("%s option %q must be %s or %s", generator.ScopeProtocolOption, protocol,
+			parser.ProtocolJSON, parser.ProtocolProvider)
+	}
 	g.generateConstants = true
 	t, err := g.GenerateFile("", outputDir, generator.TypeFile)
 	if err != nil {
@@ -87,7 +114,19 @@ func (g *Generator) SetupGenerator(outputDir string) error {
 // TeardownGenerator cleanups globals the generator needs, like the types file.
 func (g *Generator) TeardownGenerator() error {
 	defer g.typesFile.Close()
-	return g.PostProcess(g.typesFile)
+	if err := g.PostProcess(g.typesFile); err != nil {
+		return err
+	}
+	outputDir := filepath.Dir(g.typesFile.Name())
+	if globals.TestVectors {
+		if err := g.generateTestVectorsTest(outputDir); err != nil {
+			return err
+		}
+	}
+	if g.useBridge() {
+		return g.generateBridge(outputDir)
+	}
+	return nil
 }
 
 // GetOutputDir returns the output directory for generated files.
@@ -181,6 +220,15 @@ func (g *Generator) GenerateConstantsContents(constants []*parser.Constant) erro
 	contents := ""
 	initfunc := "func init() {\n"
 
+	version, check, err := g.RuntimeCheckVersion()
+	if err != nil {
+		return err
+	}
+	if check {
+		initfunc += "\t// Fail at startup if the Frugal library is incompatible with this code.\n"
+		initfunc += fmt.Sprintf("\tfrugal.RequireVersion(\"%s\")\n", version)
+	}
+
 	for _, constant := range constants {
 		if constant.Comment != nil {
 			contents += g.GenerateInlineComment(constant.Comment, "")
@@ -219,9 +267,9 @@ func (g *Generator) generateConstantValue(t *parser.Type, value interface{}) str
 		idCtx := g.Frugal.ContextFromIdentifier(identifier)
 		switch idCtx.Type {
 		case parser.LocalConstant:
-			return title(idCtx.Constant.Name)
+			return g.localReference(title(idCtx.Constant.Name))
 		case parser.LocalEnum:
-			return fmt.Sprintf("%s_%s", title(idCtx.Enum.Name), idCtx.EnumValue.Name)
+			return g.localReference(fmt.Sprintf("%s_%s", title(idCtx.Enum.Name), idCtx.EnumValue.Name))
 		case parser.IncludeConstant:
 			include := idCtx.Include.Name
 			if namespace := g.Frugal.NamespaceForInclude(include, lang); namespace != nil {
@@ -346,6 +394,19 @@ func (g *Generator) GenerateEnum(enum *parser.Enum) error {
 	contents += "\treturn \"<UNSET>\"\n"
 	contents += "}\n\n"
 
+	if g.checkUnknownEnums() {
+		contents += fmt.Sprintf("// IsValid returns true if p is a value of %s declared in the IDL.\n", eName)
+		contents += fmt.Sprintf("func (p %s) IsValid() bool {\n", eName)
+		contents += "\tswitch p {\n"
+		for _, field := range enum.Values {
+			contents += fmt.Sprintf("\tcase %s_%s:\n", eName, field.Name)
+			contents += "\t\treturn true\n"
+		}
+		contents += "\t}\n"
+		contents += "\treturn false\n"
+		contents += "}\n\n"
+	}
+
 	contents += fmt.Sprintf("func %sFromString(s string) (%s, error) {\n", eName, eName)
 	contents += "\tswitch s {\n"
 	for _, field := range enum.Values {
@@ -435,9 +496,17 @@ func (g *Generator) generateStruct(s *parser.Struct, serviceName string) string
 	contents += g.generateCountSetFields(s, sName)
 
 	contents += g.generateRead(s, sName)
-	contents += g.generateWrite(s, sName)
+	contents += g.generateWrite(s, sName, serviceName == "" && s.HasConstraints())
 	contents += g.generateToString(s, sName)
 
+	if serviceName == "" {
+		contents += g.generateValidate(s, sName)
+		contents += g.generatePII(s, sName)
+		contents += g.generateBuilder(s, sName)
+		contents += g.generateJSON(s, sName)
+		contents += g.generateCopyMethods(s, sName)
+	}
+
 	return contents
 }
 
@@ -545,6 +614,15 @@ func (g *Generator) generateGetters(s *parser.Struct, sName string) string {
 				contents += fmt.Sprintf("\treturn p.%s != %s_%s_DEFAULT\n", fName, sName, fName)
 			}
 			contents += "}\n\n"
+
+			// Clears the field, so it's no longer set
+			contents += fmt.Sprintf("func (p *%s) Unset%s() {\n", sName, fName)
+			if isPointer || underlyingType.IsContainer() || (underlyingType.Name == "binary" && field.Default == nil) {
+				contents += fmt.Sprintf("\tp.%s = nil\n", fName)
+			} else {
+				contents += fmt.Sprintf("\tp.%s = %s_%s_DEFAULT\n", fName, sName, fName)
+			}
+			contents += "}\n\n"
 		}
 		if isPointer {
 			// Need to dereference the field before returning if it's a pointer
@@ -667,7 +745,7 @@ func (g *Generator) generateRead(s *parser.Struct, sName string) string {
 	return contents
 }
 
-func (g *Generator) generateWrite(s *parser.Struct, sName string) string {
+func (g *Generator) generateWrite(s *parser.Struct, sName string, validate bool) string {
 	contents := fmt.Sprintf("func (p *%s) Write(oprot thrift.TProtocol) error {\n", sName)
 
 	// Only one field can be set for a union, make sure that's the case
@@ -677,6 +755,12 @@ func (g *Generator) generateWrite(s *parser.Struct, sName string) string {
 		contents += "\t}\n"
 	}
 
+	if validate {
+		contents += "\tif err := p.Validate(); err != nil {\n"
+		contents += "\t\treturn err\n"
+		contents += "\t}\n"
+	}
+
 	// Use actual struct name so it's consistent between languages
 	contents += fmt.Sprintf("\tif err := oprot.WriteStructBegin(\"%s\"); err != nil {\n", s.Name)
 	contents += "\t\treturn thrift.PrependError(fmt.Sprintf(\"%T write struct begin error: \", p), err)\n"
@@ -789,6 +873,9 @@ func (g *Generator) generateReadFieldRec(field *parser.Field, first bool) string
 		contents += fmt.Sprintf("\tif v, err := iprot.Read%s(); err != nil {\n", thriftType)
 		contents += fmt.Sprintf("\t\treturn thrift.PrependError(\"error reading field %d: \", err)\n", field.ID)
 		contents += "\t} else {\n"
+		if isEnum {
+			contents += g.generateUnknownEnumCheck(underlyingType, field.ID)
+		}
 		if cast == "" {
 			contents += fmt.Sprintf("\t\t%s%s = %sv\n", prefix, fName, maybeAddress)
 		} else {
@@ -981,6 +1068,31 @@ func (g *Generator) generateWriteField(structName string, field *parser.Field) s
 	return contents
 }
 
+// checkUnknownEnums returns true if enum values read are checked against the
+// values declared in the IDL.
+func (g *Generator) checkUnknownEnums() bool {
+	strategy := g.Options[generator.UnknownEnumsOption]
+	return strategy == generator.UnknownEnumsError || strategy == generator.UnknownEnumsSentinel
+}
+
+// generateUnknownEnumCheck generates the handling of an enum value v read for
+// the field with the given ID which isn't declared in the IDL.
+func (g *Generator) generateUnknownEnumCheck(enumType *parser.Type, id int) string {
+	if !g.checkUnknownEnums() {
+		return ""
+	}
+	goType := g.getGoTypeFromThriftType(enumType)
+	contents := fmt.Sprintf("\t\tif !%s(v).IsValid() {\n", goType)
+	if g.Options[generator.UnknownEnumsOption] == generator.UnknownEnumsSentinel {
+		contents += fmt.Sprintf("\t\t\tv = int32(%s_%s)\n", goType, generator.UnknownEnumValue)
+	} else {
+		contents += fmt.Sprintf("\t\t\treturn thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf(\"error reading field %d: invalid value %%d for enum %s\", v))\n",
+			id, enumType.Name)
+	}
+	contents += "\t\t}\n"
+	return contents
+}
+
 func (g *Generator) generateWriteFieldRec(field *parser.Field, prefix string) string {
 	underlyingType := g.Frugal.UnderlyingType(field.Type)
 	isPointerField := g.isPointerField(field)
@@ -1049,7 +1161,12 @@ func (g *Generator) generateWriteFieldRec(field *parser.Field, prefix string) st
 			contents += fmt.Sprintf("\tif err := oprot.WriteSetBegin(%s, len(%s)); err != nil {\n", valEnumType, prefix+fName)
 			contents += "\t\treturn thrift.PrependError(\"error writing set begin: \", err)\n"
 			contents += "\t}\n"
-			contents += fmt.Sprintf("\tfor v, _ := range %s {\n", prefix+fName)
+			if g.generateSortedMaps() && g.isOrdered(underlyingType.ValueType) {
+				contents += g.generateSortedKeys(underlyingType.ValueType, prefix+fName)
+				contents += "\tfor _, v := range keys {\n"
+			} else {
+				contents += fmt.Sprintf("\tfor v, _ := range %s {\n", prefix+fName)
+			}
 			contents += g.generateWriteFieldRec(valField, "v")
 			contents += "\t}\n"
 			contents += "\tif err := oprot.WriteSetEnd(); err != nil {\n"
@@ -1060,7 +1177,18 @@ func (g *Generator) generateWriteFieldRec(field *parser.Field, prefix string) st
 			contents += fmt.Sprintf("\tif err := oprot.WriteMapBegin(%s, %s, len(%s)); err != nil {\n", keyEnumType, valEnumType, prefix+fName)
 			contents += "\t\treturn thrift.PrependError(\"error writing map begin: \", err)\n"
 			contents += "\t}\n"
-			contents += fmt.Sprintf("\tfor k, v := range %s {\n", prefix+fName)
+			if g.generateSortedMaps() && g.isOrdered(underlyingType.KeyType) {
+				contents += g.generateSortedKeys(underlyingType.KeyType, prefix+fName)
+				contents += "\tfor _, k := range keys {\n"
+				m := prefix + fName
+				if isPointerField {
+					// Dereference the pointer before indexing the map.
+					m = "(" + m + ")"
+				}
+				contents += fmt.Sprintf("\t\tv := %s[k]\n", m)
+			} else {
+				contents += fmt.Sprintf("\tfor k, v := range %s {\n", prefix+fName)
+			}
 			keyField := parser.FieldFromType(underlyingType.KeyType, "")
 			contents += g.generateWriteFieldRec(keyField, "k")
 			contents += g.generateWriteFieldRec(valField, "v")
@@ -1076,16 +1204,52 @@ func (g *Generator) generateWriteFieldRec(field *parser.Field, prefix string) st
 	return contents
 }
 
+// isOrdered returns true if values of the type, as map keys or set values,
+// can be sorted.
+func (g *Generator) isOrdered(t *parser.Type) bool {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	return underlyingType.IsPrimitive() && underlyingType.Name != "binary" || g.Frugal.IsEnum(underlyingType)
+}
+
+// generateSortedKeys generates a sorted slice, named keys, of the keys of the
+// given map or the values of the given set with keys of the given type, so
+// its entries can be written in a deterministic order.
+func (g *Generator) generateSortedKeys(keyType *parser.Type, m string) string {
+	less := "keys[i] < keys[j]"
+	if g.Frugal.UnderlyingType(keyType).Name == "bool" {
+		less = "!keys[i] && keys[j]"
+	}
+	contents := fmt.Sprintf("\tkeys := make([]%s, 0, len(%s))\n", g.getGoTypeFromThriftType(keyType), m)
+	contents += fmt.Sprintf("\tfor k := range %s {\n", m)
+	contents += "\t\tkeys = append(keys, k)\n"
+	contents += "\t}\n"
+	contents += fmt.Sprintf("\tsort.Slice(keys, func(i, j int) bool { return %s })\n", less)
+	return contents
+}
+
+// generateSortImport generates the import of the sort package, used to write
+// the entries of maps and sets in order, if the sorted_maps option is set.
+// It's removed from files which don't use it when they're formatted.
+func (g *Generator) generateSortImport() string {
+	if !g.generateSortedMaps() {
+		return ""
+	}
+	return "\t\"sort\"\n"
+}
+
 // GenerateTypesImports generates the necessary Go types imports.
 func (g *Generator) GenerateTypesImports(file *os.File) error {
 	contents := "import (\n"
 	contents += "\t\"bytes\"\n"
 	contents += "\t\"fmt\"\n"
+	contents += g.generateSortImport()
 	// Enums need these for some reason
 	if len(g.Frugal.Enums) > 0 {
 		contents += "\t\"database/sql/driver\"\n"
 		contents += "\t\"errors\"\n"
 	}
+	contents += g.generateConstraintImports()
+	contents += g.generateJSONImports()
 	if g.Options[thriftImportOption] != "" {
 		contents += "\t\"" + g.Options[thriftImportOption] + "\"\n"
 	} else {
@@ -1126,6 +1290,7 @@ func (g *Generator) GenerateServiceResultArgsImports(file *os.File) error {
 	contents += "import (\n"
 	contents += "\t\"bytes\"\n"
 	contents += "\t\"fmt\"\n"
+	contents += g.generateSortImport()
 	if g.Options[thriftImportOption] != "" {
 		contents += "\t\"" + g.Options[thriftImportOption] + "\"\n"
 	} else {
@@ -1159,6 +1324,7 @@ func (g *Generator) GenerateServiceImports(file *os.File, s *parser.Service) err
 	imports := "import (\n"
 	imports += "\t\"bytes\"\n"
 	imports += "\t\"fmt\"\n"
+	imports += g.generateSortImport()
 	imports += "\t\"sync\"\n"
 	if len(s.TwowayMethods()) > 0 {
 		// Only non-oneway methods require the time package.
@@ -1188,6 +1354,8 @@ func (g *Generator) GenerateServiceImports(file *os.File, s *parser.Service) err
 			imports += imp
 		}
 	}
+	modelsImport := g.generateModelsImport(generator.ServicesOutOption)
+	imports += modelsImport
 
 	imports += ")\n\n"
 
@@ -1196,6 +1364,9 @@ func (g *Generator) GenerateServiceImports(file *os.File, s *parser.Service) err
 	imports += "var _ = fmt.Printf\n"
 	imports += "var _ = bytes.Equal\n"
 	imports += "var _ = logrus.DebugLevel"
+	if modelsImport != "" {
+		imports += fmt.Sprintf("\nvar _ = %s.GoUnusedProtection__", g.modelsPackage)
+	}
 
 	_, err = file.WriteString(imports)
 	return err
@@ -1205,7 +1376,9 @@ func (g *Generator) GenerateServiceImports(file *os.File, s *parser.Service) err
 func (g *Generator) GenerateScopeImports(file *os.File, s *parser.Scope) error {
 	imports := "import (\n"
 	imports += "\t\"fmt\"\n"
-	imports += "\t\"log\"\n\n"
+	imports += "\t\"log\"\n"
+	imports += g.generateSortImport()
+	imports += "\n"
 	if g.Options[thriftImportOption] != "" {
 		imports += "\t\"" + g.Options[thriftImportOption] + "\"\n"
 	} else {
@@ -1229,8 +1402,13 @@ func (g *Generator) GenerateScopeImports(file *os.File, s *parser.Scope) error {
 			imports += imp
 		}
 	}
+	modelsImport := g.generateModelsImport(generator.ScopesOutOption)
+	imports += modelsImport
 
 	imports += ")"
+	if modelsImport != "" {
+		imports += fmt.Sprintf("\n\nvar _ = %s.GoUnusedProtection__", g.modelsPackage)
+	}
 
 	_, err = file.WriteString(imports)
 	return err
@@ -1265,6 +1443,53 @@ func (g *Generator) generateIncludeImport(include *parser.Include, pkgPrefix str
 	return fmt.Sprintf("\t\"%s\"\n", importPath), nil
 }
 
+// generateModelsImport returns the import of the package containing the types
+// of the Frugal file if the artifacts of the given output directory option are
+// generated apart from them, otherwise an empty string. Local types are
+// referenced through the imported package until the next call.
+func (g *Generator) generateModelsImport(option string) string {
+	g.modelsPackage = ""
+	if !g.ArtifactsSeparated(option) {
+		return ""
+	}
+	name := g.packageName()
+	g.modelsPackage = includeNameToReference(name)
+	return fmt.Sprintf("\t\"%s%s\"\n", g.Options[packagePrefixOption], includeNameToImport(name))
+}
+
+// localReference qualifies the name of a type or constant defined in the
+// Frugal file with its package if it's generated in another package.
+func (g *Generator) localReference(name string) string {
+	if g.modelsPackage == "" {
+		return name
+	}
+	return fmt.Sprintf("%s.%s", g.modelsPackage, name)
+}
+
+// validateIncludeReferences ensures no two includes in different packages are
+// imported with the same package name, which would not compile.
+func (g *Generator) validateIncludeReferences() error {
+	type imported struct {
+		include string
+		pkg     string
+	}
+	references := make(map[string]imported)
+	for _, include := range g.Frugal.OrderedIncludes() {
+		includeName := filepath.Base(include.Name)
+		pkg := includeName
+		if namespace := g.Frugal.NamespaceForInclude(includeName, lang); namespace != nil {
+			pkg = namespace.Value
+		}
+		reference := includeNameToReference(pkg)
+		if other, ok := references[reference]; ok && other.pkg != pkg {
+			return fmt.Errorf("Includes %s and %s are both imported as package %s in go; "+
+				"add a \"namespace go\" override to one of them", other.include, include.Name, reference)
+		}
+		references[reference] = imported{include: include.Name, pkg: pkg}
+	}
+	return nil
+}
+
 func (g *Generator) generateImportProtection(include *parser.Include) string {
 	includeName := filepath.Base(include.Name)
 	namespace := g.Frugal.NamespaceForInclude(includeName, lang)
@@ -1297,8 +1522,18 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 		publisher  = ""
 	)
 
-	if scope.Comment != nil {
-		publisher += g.GenerateInlineComment(scope.Comment, "")
+	publisher += g.generateContractMetadata(g.ScopeMetadata(scope), "Publishers and subscribers")
+
+	if scope.HasPublishRoles() {
+		publisher += g.generateRoles(scope, "Publish")
+	}
+
+	if _, ok := g.Options[generator.PrefixValidationOption]; ok && len(scope.Prefix.Variables) > 0 {
+		publisher += g.generatePrefixVariableFunc(scope)
+	}
+
+	if comment := scope.DocComment(); comment != nil {
+		publisher += g.GenerateInlineComment(comment, "")
 	}
 	args := ""
 	if len(scope.Prefix.Variables) > 0 {
@@ -1315,10 +1550,17 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 	publisher += "\tClose() error\n"
 	for _, op := range scope.Operations {
 		publisher += fmt.Sprintf("\tPublish%s(ctx frugal.FContext, %sreq %s) error\n", op.Name, args, g.getGoTypeFromThriftType(op.Type))
+		if op.IsChunked() {
+			publisher += fmt.Sprintf("\tSend%s(ctx frugal.FContext, %sdata %s) error\n", op.Name, args, g.getGoTypeFromThriftType(op.Type))
+		}
+		if g.useBatchPublish() {
+			publisher += fmt.Sprintf("\tPublish%sBatch(ctx frugal.FContext, %sreqs []%s) error\n", op.Name, args, g.getGoTypeFromThriftType(op.Type))
+		}
 	}
 	publisher += "}\n\n"
 
 	publisher += fmt.Sprintf("type %sPublisher struct {\n", scopeLower)
+	publisher += "\tprovider *frugal.FScopeProvider\n"
 	publisher += "\ttransport frugal.FPublisherTransport\n"
 	publisher += "\tprotocolFactory *frugal.FProtocolFactory\n"
 	publisher += "\tmethods   map[string]*frugal.Method\n"
@@ -1327,8 +1569,10 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 	publisher += fmt.Sprintf("func New%sPublisher(provider *frugal.FScopeProvider, middleware ...frugal.ServiceMiddleware) %sPublisher {\n",
 		scopeCamel, scopeCamel)
 	publisher += "\ttransport, protocolFactory := provider.NewPublisher()\n"
+	publisher += g.generateScopeProtocolFactory(scope)
 	publisher += "\tmethods := make(map[string]*frugal.Method)\n"
 	publisher += fmt.Sprintf("\tpublisher := &%sPublisher{\n", scopeLower)
+	publisher += "\t\tprovider: provider,\n"
 	publisher += "\t\ttransport: transport,\n"
 	publisher += "\t\tprotocolFactory:  protocolFactory,\n"
 	publisher += "\t\tmethods:   methods,\n"
@@ -1337,10 +1581,22 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 	for _, op := range scope.Operations {
 		publisher += fmt.Sprintf("\tmethods[\"publish%s\"] = frugal.NewMethod(publisher, publisher.publish%s, \"publish%s\", middleware)\n",
 			op.Name, op.Name, op.Name)
+		if g.useBatchPublish() {
+			publisher += fmt.Sprintf("\tmethods[\"publish%sBatch\"] = frugal.NewMethod(publisher, publisher.publish%sBatch, \"publish%sBatch\", middleware)\n",
+				op.Name, op.Name, op.Name)
+		}
 	}
 	publisher += "\treturn publisher\n"
 	publisher += "}\n\n"
 
+	publisher += fmt.Sprintf("// New%sBatchPublisher returns an implementation of %sPublisher\n", scopeCamel, scopeCamel)
+	publisher += "// which stages messages in the given batch, publishing them along with the\n"
+	publisher += "// rest of the batch when it's flushed.\n"
+	publisher += fmt.Sprintf("func New%sBatchPublisher(provider *frugal.FScopeProvider, batch *frugal.FPublishBatch, middleware ...frugal.ServiceMiddleware) %sPublisher {\n",
+		scopeCamel, scopeCamel)
+	publisher += fmt.Sprintf("\treturn New%sPublisher(provider.WithPublishBatch(batch), middleware...)\n", scopeCamel)
+	publisher += "}\n\n"
+
 	publisher += fmt.Sprintf("func (p *%sPublisher) Open() error {\n", scopeLower)
 
 	publisher += "\treturn p.transport.Open()\n"
@@ -1355,25 +1611,276 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 		publisher += prefix
 		prefix = "\n\n"
 		publisher += g.generatePublishMethod(scope, op, args)
+		if op.IsChunked() {
+			publisher += "\n\n"
+			publisher += fmt.Sprintf("// Send%s publishes data to %s in chunks, which Receive%s\n", op.Name, op.Name, op.Name)
+			publisher += "// reassembles.\n"
+			publisher += g.generateSendMethod(scope, op, scopeLower+"Publisher", args)
+		}
+		if g.useBatchPublish() {
+			publisher += "\n\n"
+			publisher += g.generatePublishBatchMethod(scope, op, args)
+		}
+	}
+
+	publisher += "\n"
+	publisher += g.generateNoopPublisher(scope, args)
+	publisher += g.generateFanOutPublisher(scope, args)
+
+	if scope.HasReplyOperations() {
+		publisher += "\n"
+		publisher += g.generateRequester(scope, args)
 	}
 
 	_, err := file.WriteString(publisher)
 	return err
 }
 
+// generateContractMetadata generates the contract hash constant and the
+// FContractMetadata variable of a scope or service.
+func (g *Generator) generateContractMetadata(metadata *generator.ContractMetadata, sides string) string {
+	name := snakeToCamel(metadata.Name)
+	contents := fmt.Sprintf("// %sContractHash is a hash of the %s %s contract.\n", name, metadata.Name, metadata.Kind)
+	contents += fmt.Sprintf("// %s built from the same contract have the same hash.\n", sides)
+	contents += fmt.Sprintf("const %sContractHash = \"%s\"\n\n", name, metadata.Hash)
+
+	contents += fmt.Sprintf("// %sMetadata describes the %s %s contract.\n", name, metadata.Name, metadata.Kind)
+	contents += fmt.Sprintf("var %sMetadata = &frugal.FContractMetadata{\n", name)
+	contents += fmt.Sprintf("\tIDLFile:         %s,\n", strconv.Quote(metadata.IDLFile))
+	contents += fmt.Sprintf("\tKind:            %s,\n", strconv.Quote(metadata.Kind))
+	contents += fmt.Sprintf("\tName:            %s,\n", strconv.Quote(metadata.Name))
+	contents += fmt.Sprintf("\tHash:            %sContractHash,\n", name)
+	contents += fmt.Sprintf("\tCompilerVersion: %s,\n", strconv.Quote(metadata.CompilerVersion))
+	contents += "\tOperations: []string{\n"
+	for _, operation := range metadata.Operations {
+		contents += fmt.Sprintf("\t\t%s,\n", strconv.Quote(operation))
+	}
+	contents += "\t},\n"
+	if len(metadata.Descriptions) > 0 {
+		contents += "\tDescriptions: map[string]string{\n"
+		for _, operation := range metadata.Operations {
+			if description, ok := metadata.Descriptions[operation]; ok {
+				contents += fmt.Sprintf("\t\t%s: %s,\n", strconv.Quote(operation), strconv.Quote(description))
+			}
+		}
+		contents += "\t},\n"
+	}
+	contents += "}\n\n"
+	return contents
+}
+
+// generateRoles generates the map of the given scope's operations to the
+// roles permitted to perform the given action ("Publish" or "Subscribe").
+func (g *Generator) generateRoles(scope *parser.Scope, action string) string {
+	scopeCamel := snakeToCamel(scope.Name)
+	contents := fmt.Sprintf("// %s%sRoles maps the operations of the %s scope to the roles\n", scopeCamel, action, scope.Name)
+	contents += fmt.Sprintf("// permitted to %s them.\n", strings.ToLower(action))
+	contents += fmt.Sprintf("var %s%sRoles = map[string][]string{\n", scopeCamel, action)
+	for _, op := range scope.Operations {
+		roles := op.SubscribeRoles()
+		if action == "Publish" {
+			roles = op.PublishRoles()
+		}
+		if roles == nil {
+			continue
+		}
+		quoted := make([]string, len(roles))
+		for i, role := range roles {
+			quoted[i] = strconv.Quote(role)
+		}
+		contents += fmt.Sprintf("\t\"%s\": []string{%s},\n", op.Name, strings.Join(quoted, ", "))
+	}
+	contents += "}\n\n"
+	return contents
+}
+
+// generateAuthorize generates the code which authorizes the given action
+// ("Publish" or "Subscribe") on the current operation with the scope
+// provider, executing the given failure statement if it is denied.
+func (g *Generator) generateAuthorize(scope *parser.Scope, action, provider, failure string) string {
+	contents := fmt.Sprintf("\tif err := %s.Authorize(&frugal.FAuthorizationRequest{\n", provider)
+	contents += fmt.Sprintf("\t\tAction: frugal.FScopeAction%s,\n", action)
+	contents += "\t\tTopic: topic,\n"
+	contents += "\t\tOperation: op,\n"
+	if (action == "Publish" && scope.HasPublishRoles()) || (action == "Subscribe" && scope.HasSubscribeRoles()) {
+		contents += fmt.Sprintf("\t\tRoles: %s%sRoles[op],\n", snakeToCamel(scope.Name), action)
+	}
+	if action == "Publish" {
+		contents += "\t\tContext: ctx,\n"
+	}
+	contents += "\t}); err != nil {\n"
+	contents += fmt.Sprintf("\t\t%s\n", failure)
+	contents += "\t}\n"
+	return contents
+}
+
+// generateNoopPublisher generates a publisher implementation which discards
+// everything published to it.
+func (g *Generator) generateNoopPublisher(scope *parser.Scope, args string) string {
+	var (
+		scopeLower = parser.LowercaseFirstLetter(scope.Name)
+		scopeCamel = snakeToCamel(scope.Name)
+		publisher  = ""
+	)
+
+	publisher += fmt.Sprintf("type %sNoopPublisher struct{}\n\n", scopeLower)
+
+	publisher += fmt.Sprintf("// New%sNoopPublisher returns an implementation of %sPublisher\n", scopeCamel, scopeCamel)
+	publisher += "// which discards every message published to it. This is useful for tests\n"
+	publisher += "// or for disabling publishing behind a feature flag.\n"
+	publisher += fmt.Sprintf("func New%sNoopPublisher() %sPublisher {\n", scopeCamel, scopeCamel)
+	publisher += fmt.Sprintf("\treturn &%sNoopPublisher{}\n", scopeLower)
+	publisher += "}\n\n"
+
+	publisher += fmt.Sprintf("func (p *%sNoopPublisher) Open() error {\n", scopeLower)
+	publisher += "\treturn nil\n"
+	publisher += "}\n\n"
+
+	publisher += fmt.Sprintf("func (p *%sNoopPublisher) Close() error {\n", scopeLower)
+	publisher += "\treturn nil\n"
+	publisher += "}\n\n"
+
+	for _, op := range scope.Operations {
+		publisher += fmt.Sprintf("func (p *%sNoopPublisher) Publish%s(ctx frugal.FContext, %sreq %s) error {\n",
+			scopeLower, op.Name, args, g.getGoTypeFromThriftType(op.Type))
+		publisher += "\treturn nil\n"
+		publisher += "}\n\n"
+		if op.IsChunked() {
+			publisher += fmt.Sprintf("func (p *%sNoopPublisher) Send%s(ctx frugal.FContext, %sdata %s) error {\n",
+				scopeLower, op.Name, args, g.getGoTypeFromThriftType(op.Type))
+			publisher += "\treturn nil\n"
+			publisher += "}\n\n"
+		}
+		if g.useBatchPublish() {
+			publisher += fmt.Sprintf("func (p *%sNoopPublisher) Publish%sBatch(ctx frugal.FContext, %sreqs []%s) error {\n",
+				scopeLower, op.Name, args, g.getGoTypeFromThriftType(op.Type))
+			publisher += "\treturn nil\n"
+			publisher += "}\n\n"
+		}
+	}
+	return publisher
+}
+
+// generateFanOutPublisher generates a publisher implementation which
+// publishes every message to a set of publishers.
+func (g *Generator) generateFanOutPublisher(scope *parser.Scope, args string) string {
+	var (
+		scopeLower = parser.LowercaseFirstLetter(scope.Name)
+		scopeCamel = snakeToCamel(scope.Name)
+		publisher  = ""
+	)
+
+	argsWithoutTypes := ""
+	for _, variable := range scope.Prefix.Variables {
+		argsWithoutTypes += variable + ", "
+	}
+
+	publisher += fmt.Sprintf("type %sFanOutPublisher struct {\n", scopeLower)
+	publisher += fmt.Sprintf("\tpublishers []%sPublisher\n", scopeCamel)
+	publisher += "}\n\n"
+
+	publisher += fmt.Sprintf("// New%sFanOutPublisher returns an implementation of %sPublisher\n", scopeCamel, scopeCamel)
+	publisher += "// which publishes every message to each of the given publishers, e.g. to\n"
+	publisher += "// publish to both brokers during a migration. Every publisher is attempted\n"
+	publisher += "// and the first error encountered is returned.\n"
+	publisher += fmt.Sprintf("func New%sFanOutPublisher(publishers ...%sPublisher) %sPublisher {\n",
+		scopeCamel, scopeCamel, scopeCamel)
+	publisher += fmt.Sprintf("\treturn &%sFanOutPublisher{publishers: publishers}\n", scopeLower)
+	publisher += "}\n\n"
+
+	publisher += fmt.Sprintf("func (p *%sFanOutPublisher) Open() error {\n", scopeLower)
+	publisher += "\tfor _, publisher := range p.publishers {\n"
+	publisher += "\t\tif err := publisher.Open(); err != nil {\n"
+	publisher += "\t\t\treturn err\n"
+	publisher += "\t\t}\n"
+	publisher += "\t}\n"
+	publisher += "\treturn nil\n"
+	publisher += "}\n\n"
+
+	publisher += fmt.Sprintf("func (p *%sFanOutPublisher) Close() error {\n", scopeLower)
+	publisher += "\tvar err error\n"
+	publisher += "\tfor _, publisher := range p.publishers {\n"
+	publisher += "\t\tif closeErr := publisher.Close(); closeErr != nil && err == nil {\n"
+	publisher += "\t\t\terr = closeErr\n"
+	publisher += "\t\t}\n"
+	publisher += "\t}\n"
+	publisher += "\treturn err\n"
+	publisher += "}\n\n"
+
+	for _, op := range scope.Operations {
+		publisher += fmt.Sprintf("func (p *%sFanOutPublisher) Publish%s(ctx frugal.FContext, %sreq %s) error {\n",
+			scopeLower, op.Name, args, g.getGoTypeFromThriftType(op.Type))
+		publisher += "\tvar err error\n"
+		publisher += "\tfor _, publisher := range p.publishers {\n"
+		publisher += fmt.Sprintf("\t\tif publishErr := publisher.Publish%s(ctx, %sreq); publishErr != nil && err == nil {\n",
+			op.Name, argsWithoutTypes)
+		publisher += "\t\t\terr = publishErr\n"
+		publisher += "\t\t}\n"
+		publisher += "\t}\n"
+		publisher += "\treturn err\n"
+		publisher += "}\n\n"
+		if op.IsChunked() {
+			publisher += g.generateSendMethod(scope, op, scopeLower+"FanOutPublisher", args)
+		}
+		if g.useBatchPublish() {
+			publisher += fmt.Sprintf("func (p *%sFanOutPublisher) Publish%sBatch(ctx frugal.FContext, %sreqs []%s) error {\n",
+				scopeLower, op.Name, args, g.getGoTypeFromThriftType(op.Type))
+			publisher += "\tvar err error\n"
+			publisher += "\tfor _, publisher := range p.publishers {\n"
+			publisher += fmt.Sprintf("\t\tif publishErr := publisher.Publish%sBatch(ctx, %sreqs); publishErr != nil && err == nil {\n",
+				op.Name, argsWithoutTypes)
+			publisher += "\t\t\terr = publishErr\n"
+			publisher += "\t\t}\n"
+			publisher += "\t}\n"
+			publisher += "\treturn err\n"
+			publisher += "}\n\n"
+		}
+	}
+	return publisher
+}
+
+// generateSendMethod generates the method of the given publisher type which
+// publishes a blob to a chunked operation as a sequence of chunks.
+func (g *Generator) generateSendMethod(scope *parser.Scope, op *parser.Operation, publisherType, args string) string {
+	goType := g.getGoTypeFromThriftType(op.Type)
+	size := "frugal.DefaultChunkSize"
+	if op.ChunkSize() > 0 {
+		size = strconv.Itoa(op.ChunkSize())
+	}
+	data, chunk := "data", "chunk"
+	if goType != "[]byte" {
+		data, chunk = "[]byte(data)", fmt.Sprintf("%s(chunk)", goType)
+	}
+	argsWithoutTypes := ""
+	for _, variable := range scope.Prefix.Variables {
+		argsWithoutTypes += variable + ", "
+	}
+
+	publisher := fmt.Sprintf("func (p *%s) Send%s(ctx frugal.FContext, %sdata %s) error {\n", publisherType, op.Name, args, goType)
+	publisher += g.generateSample(op, "\t")
+	publisher += fmt.Sprintf("\treturn frugal.PublishChunks(ctx, %s, %s, func(ctx frugal.FContext, chunk []byte) error {\n", data, size)
+	publisher += fmt.Sprintf("\t\treturn p.Publish%s(ctx, %s%s)\n", op.Name, argsWithoutTypes, chunk)
+	publisher += "\t})\n"
+	publisher += "}\n\n"
+	return publisher
+}
+
 func (g *Generator) generatePublishMethod(scope *parser.Scope, op *parser.Operation, args string) string {
 	var (
 		scopeLower = parser.LowercaseFirstLetter(scope.Name)
 		publisher  = ""
 	)
 
-	if op.Comment != nil {
-		publisher += g.GenerateInlineComment(op.Comment, "")
+	if comment := op.DocComment(); comment != nil {
+		publisher += g.GenerateInlineComment(comment, "")
 	}
 
 	publisher += fmt.Sprintf("func (p *%sPublisher) Publish%s(ctx frugal.FContext, %sreq %s) error {\n",
 		scopeLower, op.Name, args, g.getGoTypeFromThriftType(op.Type))
+	publisher += g.generateSample(op, "\t")
+	publisher += g.generateStartSpan("Publish", scope, strconv.Quote(op.Name), "\t")
 	publisher += fmt.Sprintf("\tret := p.methods[\"publish%s\"].Invoke(%s)\n", op.Name, g.generateScopeArgs(scope))
+	publisher += g.generateFinishSpan("ret.Error()", "\t")
 	publisher += "\tif ret[0] != nil {\n"
 	publisher += "\t\treturn ret[0].(error)\n"
 	publisher += "\t}\n"
@@ -1388,13 +1895,15 @@ func (g *Generator) generatePublishMethod(scope *parser.Scope, op *parser.Operat
 func (g *Generator) generateInternalPublishMethod(scope *parser.Scope, op *parser.Operation, args string) string {
 	var (
 		scopeLower = parser.LowercaseFirstLetter(scope.Name)
-		scopeTitle = strings.Title(scope.Name)
+		scopeTopic = scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter)
 		publisher  = ""
 	)
 
 	publisher += fmt.Sprintf("func (p *%sPublisher) publish%s(ctx frugal.FContext, %sreq %s) error {\n",
 		scopeLower, op.Name, args, g.getGoTypeFromThriftType(op.Type))
 
+	publisher += g.generatePrefixVariableChecks(scope, "return err")
+
 	// Inject the prefix variables into the FContext to send
 	for _, prefixVar := range scope.Prefix.Variables {
 		publisher += fmt.Sprintf("\tctx.AddRequestHeader(\"_topic_%s\", %s)\n", prefixVar, prefixVar)
@@ -1402,7 +1911,9 @@ func (g *Generator) generateInternalPublishMethod(scope *parser.Scope, op *parse
 
 	publisher += fmt.Sprintf("\top := \"%s\"\n", op.Name)
 	publisher += fmt.Sprintf("\tprefix := %s\n", generatePrefixStringTemplate(scope))
-	publisher += "\ttopic := fmt.Sprintf(\"%s" + scopeTitle + "%s%s\", prefix, delimiter, op)\n"
+	publisher += "\ttopic := fmt.Sprintf(\"%s" + scopeTopic + "%s%s\", prefix, delimiter, op)\n"
+	publisher += g.generateAuthorize(scope, "Publish", "p.provider", "return err")
+	publisher += g.generateSetContentType()
 	publisher += "\tbuffer := frugal.NewTMemoryOutputBuffer(p.transport.GetPublishSizeLimit())\n"
 	publisher += "\toprot := p.protocolFactory.GetProtocol(buffer)\n"
 	publisher += "\tif err := oprot.WriteRequestHeader(ctx); err != nil {\n"
@@ -1418,11 +1929,56 @@ func (g *Generator) generateInternalPublishMethod(scope *parser.Scope, op *parse
 	publisher += "\tif err := oprot.Flush(); err != nil {\n"
 	publisher += "\t\treturn err\n"
 	publisher += "\t}\n"
-	publisher += "\treturn p.transport.Publish(topic, buffer.Bytes())\n"
+	publisher += "\treturn frugal.Publish(ctx, p.transport, topic, buffer.Bytes())\n"
 	publisher += "}\n"
 	return publisher
 }
 
+// generatePrefixVariableFunc generates the function checking the values of
+// the scope's prefix variables for the prefix_validation option.
+func (g *Generator) generatePrefixVariableFunc(scope *parser.Scope) string {
+	contents := fmt.Sprintf("// %sPrefixVariable returns the value to use for the topic prefix variable with\n", parser.LowercaseFirstLetter(scope.Name))
+	contents += "// the given name, or an error if the value is empty"
+	if g.Options[generator.PrefixValidationOption] == generator.PrefixValidationError {
+		contents += " or contains the topic\n// delimiter.\n"
+	} else {
+		contents += ". The topic delimiter is\n// replaced so the value can't change the topic's routing.\n"
+	}
+	contents += fmt.Sprintf("func %sPrefixVariable(name, value string) (string, error) {\n", parser.LowercaseFirstLetter(scope.Name))
+	contents += "\tif value == \"\" {\n"
+	contents += "\t\treturn \"\", fmt.Errorf(\"topic prefix variable %s is empty\", name)\n"
+	contents += "\t}\n"
+	if g.Options[generator.PrefixValidationOption] == generator.PrefixValidationError {
+		contents += "\tif strings.Contains(value, delimiter) {\n"
+		contents += "\t\treturn \"\", fmt.Errorf(\"topic prefix variable %s contains the topic delimiter: %q\", name, value)\n"
+		contents += "\t}\n"
+		contents += "\treturn value, nil\n"
+	} else {
+		contents += fmt.Sprintf("\treturn strings.Replace(value, delimiter, %q, -1), nil\n", generator.PrefixSanitizeReplacement)
+	}
+	contents += "}\n\n"
+	return contents
+}
+
+// generatePrefixVariableChecks generates the checks of the scope's prefix
+// variables for the prefix_validation option, running the failure statement
+// with err set if one is invalid.
+func (g *Generator) generatePrefixVariableChecks(scope *parser.Scope, failure string) string {
+	if _, ok := g.Options[generator.PrefixValidationOption]; !ok {
+		return ""
+	}
+	contents := ""
+	for _, variable := range scope.Prefix.Variables {
+		contents += fmt.Sprintf("\tif v, err := %sPrefixVariable(\"%s\", %s); err != nil {\n",
+			parser.LowercaseFirstLetter(scope.Name), variable, variable)
+		contents += fmt.Sprintf("\t\t%s\n", failure)
+		contents += "\t} else {\n"
+		contents += fmt.Sprintf("\t\t%s = v\n", variable)
+		contents += "\t}\n"
+	}
+	return contents
+}
+
 func generatePrefixStringTemplate(scope *parser.Scope) string {
 	if len(scope.Prefix.Variables) == 0 {
 		if scope.Prefix.String == "" {
@@ -1450,8 +2006,12 @@ func (g *Generator) GenerateSubscriber(file *os.File, scope *parser.Scope) error
 		subscriber = ""
 	)
 
-	if scope.Comment != nil {
-		subscriber += g.GenerateInlineComment(scope.Comment, "")
+	if scope.HasSubscribeRoles() {
+		subscriber += g.generateRoles(scope, "Subscribe")
+	}
+
+	if comment := scope.DocComment(); comment != nil {
+		subscriber += g.GenerateInlineComment(comment, "")
 	}
 
 	args := ""
@@ -1470,17 +2030,39 @@ func (g *Generator) GenerateSubscriber(file *os.File, scope *parser.Scope) error
 	for _, op := range scope.Operations {
 		subscriber += fmt.Sprintf("\tSubscribe%s(%shandler func(frugal.FContext, %s)) (*frugal.FSubscription, error)\n",
 			op.Name, args, g.getGoTypeFromThriftType(op.Type))
+		subscriber += fmt.Sprintf("\tSubscribe%sFiltered(%sfilter func(frugal.FContext, %s) bool, handler func(frugal.FContext, %s)) (*frugal.FSubscription, error)\n",
+			op.Name, args, g.getGoTypeFromThriftType(op.Type), g.getGoTypeFromThriftType(op.Type))
+		if op.Annotations.IsReplayable() {
+			subscriber += fmt.Sprintf("\tSubscribe%sFrom(%sfrom frugal.FReplayPosition, handler func(frugal.FContext, %s)) (*frugal.FSubscription, error)\n",
+				op.Name, args, g.getGoTypeFromThriftType(op.Type))
+		}
+		if op.IsChunked() {
+			subscriber += fmt.Sprintf("\tReceive%s(%shandler func(frugal.FContext, %s)) (*frugal.FSubscription, error)\n",
+				op.Name, args, g.getGoTypeFromThriftType(op.Type))
+		}
 	}
+	subscriber += fmt.Sprintf("\tSubscribeAll(%shandler func(frugal.FContext, string, interface{})) (*frugal.FSubscription, error)\n", args)
 	subscriber += "}\n\n"
 
-	if scope.Comment != nil {
-		subscriber += g.GenerateInlineComment(scope.Comment, "")
+	if comment := scope.DocComment(); comment != nil {
+		subscriber += g.GenerateInlineComment(comment, "")
 	}
 	subscriber += fmt.Sprintf("type %sErrorableSubscriber interface {\n", scopeCamel)
 	for _, op := range scope.Operations {
 		subscriber += fmt.Sprintf("\tSubscribe%sErrorable(%shandler func(frugal.FContext, %s) error) (*frugal.FSubscription, error)\n",
 			op.Name, args, g.getGoTypeFromThriftType(op.Type))
+		subscriber += fmt.Sprintf("\tSubscribe%sErrorableFiltered(%sfilter func(frugal.FContext, %s) bool, handler func(frugal.FContext, %s) error) (*frugal.FSubscription, error)\n",
+			op.Name, args, g.getGoTypeFromThriftType(op.Type), g.getGoTypeFromThriftType(op.Type))
+		if op.Annotations.IsReplayable() {
+			subscriber += fmt.Sprintf("\tSubscribe%sErrorableFrom(%sfrom frugal.FReplayPosition, handler func(frugal.FContext, %s) error) (*frugal.FSubscription, error)\n",
+				op.Name, args, g.getGoTypeFromThriftType(op.Type))
+		}
+		if op.IsChunked() {
+			subscriber += fmt.Sprintf("\tReceive%sErrorable(%shandler func(frugal.FContext, %s) error) (*frugal.FSubscription, error)\n",
+				op.Name, args, g.getGoTypeFromThriftType(op.Type))
+		}
 	}
+	subscriber += fmt.Sprintf("\tSubscribeAllErrorable(%shandler func(frugal.FContext, string, interface{}) error) (*frugal.FSubscription, error)\n", args)
 	subscriber += "}\n\n"
 
 	subscriber += fmt.Sprintf("type %sSubscriber struct {\n", scopeLower)
@@ -1506,6 +2088,13 @@ func (g *Generator) GenerateSubscriber(file *os.File, scope *parser.Scope) error
 		prefix = "\n\n"
 		subscriber += g.generateSubscribeMethod(scope, op, args, argsWithoutTypes)
 	}
+	subscriber += "\n\n"
+	subscriber += g.generateSubscribeAllMethod(scope, args, argsWithoutTypes)
+
+	if scope.HasReplyOperations() {
+		subscriber += "\n\n"
+		subscriber += g.generateResponder(scope, args)
+	}
 
 	_, err := file.WriteString(subscriber)
 	return err
@@ -1514,11 +2103,11 @@ func (g *Generator) GenerateSubscriber(file *os.File, scope *parser.Scope) error
 func (g *Generator) generateSubscribeMethod(scope *parser.Scope, op *parser.Operation, args, argsWithoutTypes string) string {
 	var (
 		scopeLower = parser.LowercaseFirstLetter(scope.Name)
-		scopeTitle = strings.Title(scope.Name)
+		scopeTopic = scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter)
 		subscriber = ""
 	)
-	if op.Comment != nil {
-		subscriber += g.GenerateInlineComment(op.Comment, "")
+	if comment := op.DocComment(); comment != nil {
+		subscriber += g.GenerateInlineComment(comment, "")
 	}
 
 	subscriber += fmt.Sprintf("func (l *%sSubscriber) Subscribe%s(%shandler func(frugal.FContext, %s)) (*frugal.FSubscription, error) {\n",
@@ -1530,17 +2119,386 @@ func (g *Generator) generateSubscribeMethod(scope *parser.Scope, op *parser.Oper
 	subscriber += "\t})\n"
 	subscriber += "}\n\n"
 
-	if op.Comment != nil {
-		subscriber += g.GenerateInlineComment(op.Comment, "")
+	if comment := op.DocComment(); comment != nil {
+		subscriber += g.GenerateInlineComment(comment, "")
 	}
 	subscriber += fmt.Sprintf("func (l *%sSubscriber) Subscribe%sErrorable(%shandler func(frugal.FContext, %s) error) (*frugal.FSubscription, error) {\n",
 		scopeLower, op.Name, args, g.getGoTypeFromThriftType(op.Type))
+	subscriber += g.generatePrefixVariableChecks(scope, "return nil, err")
+	subscriber += fmt.Sprintf("\top := \"%s\"\n", op.Name)
+	subscriber += fmt.Sprintf("\tprefix := %s\n", generatePrefixStringTemplate(scope))
+	subscriber += "\ttopic := fmt.Sprintf(\"%s" + scopeTopic + "%s%s\", prefix, delimiter, op)\n"
+	subscriber += g.generateAuthorize(scope, "Subscribe", "l.provider", "return nil, err")
+	if previous := g.previousScopeVersions(scope); len(previous) > 0 {
+		subscriber += g.generateVersionedSubscribe(scope, previous, "%s", ", op", g.generateRecvCallback(op))
+	} else {
+		subscriber += "\ttransport, protocolFactory := l.provider.NewSubscriber()\n"
+		subscriber += g.generateScopeProtocolFactory(scope)
+		subscriber += fmt.Sprintf("\tcb := %s\n", g.generateRecvCallback(op))
+		subscriber += "\tif err := transport.Subscribe(topic, cb); err != nil {\n"
+		subscriber += "\t\treturn nil, err\n"
+		subscriber += "\t}\n\n"
+
+		subscriber += "\tsub := frugal.NewFSubscription(topic, transport)\n"
+		subscriber += "\treturn sub, nil\n"
+	}
+	subscriber += "}\n\n"
+
+	subscriber += g.generateSubscribeFilteredMethod(scope, op, args, argsWithoutTypes)
+
+	if op.Annotations.IsReplayable() {
+		subscriber += g.generateSubscribeFromMethod(scope, op, args, argsWithoutTypes)
+	}
+
+	if op.IsChunked() {
+		subscriber += g.generateReceiveMethod(scope, op, args, argsWithoutTypes)
+	}
+
+	subscriber += fmt.Sprintf("func (l *%sSubscriber) recv%s(op string, pf *frugal.FProtocolFactory, handler func(frugal.FContext, %s) error) frugal.FAsyncCallback {\n",
+		scopeLower, op.Name, g.getGoTypeFromThriftType(op.Type))
+	subscriber += fmt.Sprintf("\tmethod := frugal.NewMethod(l, handler, \"Subscribe%s\", l.middleware)\n", op.Name)
+	subscriber += "\treturn func(transport thrift.TTransport) error {\n"
+	subscriber += g.generateReadScopeRequest(op)
+	subscriber += g.generateInvokeHandler(scope, "op", "ctx, req")
+	subscriber += "\t}\n"
+	subscriber += "}"
+
+	return subscriber
+}
+
+// generateReadScopeRequest generates the code which reads the context and
+// request of a scope operation from the protocol in a subscriber callback.
+func (g *Generator) generateReadScopeRequest(op *parser.Operation) string {
+	contents := "\t\tiprot := pf.GetProtocol(transport)\n"
+	contents += "\t\tctx, err := iprot.ReadRequestHeader()\n"
+	contents += "\t\tif err != nil {\n"
+	contents += "\t\t\treturn err\n"
+	contents += "\t\t}\n\n"
+	contents += g.generateNegotiateProtocol()
+	contents += "\t\tname, _, _, err := iprot.ReadMessageBegin()\n"
+	contents += "\t\tif err != nil {\n"
+	contents += "\t\t\treturn err\n"
+	contents += "\t\t}\n\n"
+	contents += "\t\tif name != op {\n"
+	contents += "\t\t\tiprot.Skip(thrift.STRUCT)\n"
+	contents += "\t\t\tiprot.ReadMessageEnd()\n"
+	contents += "\t\t\treturn thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_UNKNOWN_METHOD, \"Unknown function\"+name)\n"
+	contents += "\t\t}\n"
+	contents += g.generateReadFieldRec(parser.FieldFromType(op.Type, "req"), false)
+	contents += "\t\tiprot.ReadMessageEnd()\n\n"
+	return contents
+}
+
+// generateRequester generates the requester for the request/reply operations
+// of the given scope.
+func (g *Generator) generateRequester(scope *parser.Scope, args string) string {
+	var (
+		scopeLower = parser.LowercaseFirstLetter(scope.Name)
+		scopeCamel = snakeToCamel(scope.Name)
+		requester  = ""
+	)
+
+	if comment := scope.DocComment(); comment != nil {
+		requester += g.GenerateInlineComment(comment, "")
+	}
+	requester += fmt.Sprintf("type %sRequester interface {\n", scopeCamel)
+	requester += "\tOpen() error\n"
+	requester += "\tClose() error\n"
+	for _, op := range scope.Operations {
+		if reply := op.ReplyType(); reply != nil {
+			requester += fmt.Sprintf("\tRequest%s(ctx frugal.FContext, %sreq %s) (%s, error)\n",
+				op.Name, args, g.getGoTypeFromThriftType(op.Type), g.getGoTypeFromThriftType(reply))
+		}
+	}
+	requester += "}\n\n"
+
+	requester += fmt.Sprintf("type %sRequester struct {\n", scopeLower)
+	requester += "\tprovider  *frugal.FScopeProvider\n"
+	requester += "\trequester *frugal.FScopeRequester\n"
+	requester += "\tmethods   map[string]*frugal.Method\n"
+	requester += "}\n\n"
+
+	requester += fmt.Sprintf("func New%sRequester(provider *frugal.FScopeProvider, middleware ...frugal.ServiceMiddleware) %sRequester {\n",
+		scopeCamel, scopeCamel)
+	requester += "\tmethods := make(map[string]*frugal.Method)\n"
+	requester += fmt.Sprintf("\trequester := &%sRequester{\n", scopeLower)
+	requester += "\t\tprovider:  provider,\n"
+	requester += fmt.Sprintf("\t\trequester: frugal.NewFScopeRequester(provider)%s,\n", g.generateScopeProtocolOption(scope))
+	requester += "\t\tmethods:   methods,\n"
+	requester += "\t}\n"
+	requester += "\tmiddleware = append(middleware, provider.GetMiddleware()...)\n"
+	for _, op := range scope.Operations {
+		if op.ReplyType() != nil {
+			requester += fmt.Sprintf("\tmethods[\"request%s\"] = frugal.NewMethod(requester, requester.request%s, \"request%s\", middleware)\n",
+				op.Name, op.Name, op.Name)
+		}
+	}
+	requester += "\treturn requester\n"
+	requester += "}\n\n"
+
+	requester += fmt.Sprintf("func (p *%sRequester) Open() error {\n", scopeLower)
+	requester += "\treturn p.requester.Open()\n"
+	requester += "}\n\n"
+
+	requester += fmt.Sprintf("func (p *%sRequester) Close() error {\n", scopeLower)
+	requester += "\treturn p.requester.Close()\n"
+	requester += "}\n\n"
+
+	for _, op := range scope.Operations {
+		if op.ReplyType() != nil {
+			requester += g.generateRequestMethod(scope, op, args)
+		}
+	}
+	return requester
+}
+
+// scopeTProtocolFactory returns the expression creating the TProtocolFactory
+// the scope's messages are serialized with, or an empty string if they use
+// the provider's protocol.
+func (g *Generator) scopeTProtocolFactory(scope *parser.Scope) string {
+	if g.ScopeProtocol(scope) == parser.ProtocolJSON {
+		return "thrift.NewTJSONProtocolFactory()"
+	}
+	return ""
+}
+
+// generateScopeProtocolFactory generates the replacement of the provider's
+// protocolFactory if the scope uses another protocol.
+func (g *Generator) generateScopeProtocolFactory(scope *parser.Scope) string {
+	factory := g.scopeTProtocolFactory(scope)
+	if factory == "" {
+		return ""
+	}
+	return fmt.Sprintf("\tprotocolFactory = protocolFactory.WithTProtocolFactory(%s)\n", factory)
+}
+
+// generateScopeProtocolOption generates the configuration of a scope
+// requester or responder if the scope uses another protocol than the
+// provider's.
+func (g *Generator) generateScopeProtocolOption(scope *parser.Scope) string {
+	factory := g.scopeTProtocolFactory(scope)
+	if factory == "" {
+		return ""
+	}
+	return fmt.Sprintf(".WithTProtocolFactory(%s)", factory)
+}
+
+func (g *Generator) generateRequestMethod(scope *parser.Scope, op *parser.Operation, args string) string {
+	var (
+		scopeLower = parser.LowercaseFirstLetter(scope.Name)
+		scopeTopic = scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter)
+		reqType    = g.getGoTypeFromThriftType(op.Type)
+		replyType  = g.getGoTypeFromThriftType(op.ReplyType())
+		requester  = ""
+	)
+
+	if comment := op.DocComment(); comment != nil {
+		requester += g.GenerateInlineComment(comment, "")
+	}
+	requester += fmt.Sprintf("func (p *%sRequester) Request%s(ctx frugal.FContext, %sreq %s) (r %s, err error) {\n",
+		scopeLower, op.Name, args, reqType, replyType)
+	requester += fmt.Sprintf("\tret := p.methods[\"request%s\"].Invoke(%s)\n", op.Name, g.generateScopeArgs(scope))
+	requester += "\tif len(ret) != 2 {\n"
+	requester += "\t\tpanic(fmt.Sprintf(\"Middleware returned %d arguments, expected 2\", len(ret)))\n"
+	requester += "\t}\n"
+	requester += "\tif ret[0] != nil {\n"
+	requester += fmt.Sprintf("\t\tr = ret[0].(%s)\n", replyType)
+	requester += "\t}\n"
+	requester += "\tif ret[1] != nil {\n"
+	requester += "\t\terr = ret[1].(error)\n"
+	requester += "\t}\n"
+	requester += "\treturn r, err\n"
+	requester += "}\n\n"
+
+	requester += fmt.Sprintf("func (p *%sRequester) request%s(ctx frugal.FContext, %sreq %s) (r %s, err error) {\n",
+		scopeLower, op.Name, args, reqType, replyType)
+	requester += g.generatePrefixVariableChecks(scope, "return r, err")
+	for _, prefixVar := range scope.Prefix.Variables {
+		requester += fmt.Sprintf("\tctx.AddRequestHeader(\"_topic_%s\", %s)\n", prefixVar, prefixVar)
+	}
+	requester += fmt.Sprintf("\top := \"%s\"\n", op.Name)
+	requester += fmt.Sprintf("\tprefix := %s\n", generatePrefixStringTemplate(scope))
+	requester += "\ttopic := fmt.Sprintf(\"%s" + scopeTopic + "%s%s\", prefix, delimiter, op)\n"
+	requester += g.generateAuthorize(scope, "Publish", "p.provider", "return r, err")
+	requester += "\terr = p.requester.Request(ctx, topic, op, func(oprot *frugal.FProtocol) error {\n"
+	requester += g.generateWriteFieldRec(parser.FieldFromType(op.Type, ""), "req")
+	requester += "\t\treturn nil\n"
+	requester += "\t}, func(iprot *frugal.FProtocol) error {\n"
+	requester += g.generateReadFieldRec(parser.FieldFromType(op.ReplyType(), "reply"), false)
+	requester += "\t\tr = reply\n"
+	requester += "\t\treturn nil\n"
+	requester += "\t})\n"
+	requester += "\treturn r, err\n"
+	requester += "}\n\n"
+	return requester
+}
+
+// generateResponder generates the responder for the request/reply
+// operations of the given scope.
+func (g *Generator) generateResponder(scope *parser.Scope, args string) string {
+	var (
+		scopeLower = parser.LowercaseFirstLetter(scope.Name)
+		scopeCamel = snakeToCamel(scope.Name)
+		responder  = ""
+	)
+
+	if comment := scope.DocComment(); comment != nil {
+		responder += g.GenerateInlineComment(comment, "")
+	}
+	responder += fmt.Sprintf("type %sResponder interface {\n", scopeCamel)
+	responder += "\tClose() error\n"
+	for _, op := range scope.Operations {
+		if reply := op.ReplyType(); reply != nil {
+			responder += fmt.Sprintf("\tRespond%s(%shandler func(frugal.FContext, %s) (%s, error)) (*frugal.FSubscription, error)\n",
+				op.Name, args, g.getGoTypeFromThriftType(op.Type), g.getGoTypeFromThriftType(reply))
+		}
+	}
+	responder += "}\n\n"
+
+	responder += fmt.Sprintf("type %sResponder struct {\n", scopeLower)
+	responder += "\tprovider   *frugal.FScopeProvider\n"
+	responder += "\tresponder  *frugal.FScopeResponder\n"
+	responder += "\tmiddleware []frugal.ServiceMiddleware\n"
+	responder += "}\n\n"
+
+	responder += fmt.Sprintf("func New%sResponder(provider *frugal.FScopeProvider, middleware ...frugal.ServiceMiddleware) %sResponder {\n",
+		scopeCamel, scopeCamel)
+	responder += "\tmiddleware = append(middleware, provider.GetMiddleware()...)\n"
+	responder += fmt.Sprintf("\treturn &%sResponder{provider: provider, responder: frugal.NewFScopeResponder(provider)%s, middleware: middleware}\n",
+		scopeLower, g.generateScopeProtocolOption(scope))
+	responder += "}\n\n"
+
+	responder += fmt.Sprintf("func (l *%sResponder) Close() error {\n", scopeLower)
+	responder += "\treturn l.responder.Close()\n"
+	responder += "}\n\n"
+
+	for _, op := range scope.Operations {
+		if op.ReplyType() != nil {
+			responder += g.generateRespondMethod(scope, op, args)
+		}
+	}
+	return responder
+}
+
+func (g *Generator) generateRespondMethod(scope *parser.Scope, op *parser.Operation, args string) string {
+	var (
+		scopeLower = parser.LowercaseFirstLetter(scope.Name)
+		scopeTopic = scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter)
+		reqType    = g.getGoTypeFromThriftType(op.Type)
+		replyType  = g.getGoTypeFromThriftType(op.ReplyType())
+		responder  = ""
+	)
+
+	if comment := op.DocComment(); comment != nil {
+		responder += g.GenerateInlineComment(comment, "")
+	}
+	responder += fmt.Sprintf("func (l *%sResponder) Respond%s(%shandler func(frugal.FContext, %s) (%s, error)) (*frugal.FSubscription, error) {\n",
+		scopeLower, op.Name, args, reqType, replyType)
+	responder += g.generatePrefixVariableChecks(scope, "return nil, err")
+	responder += fmt.Sprintf("\top := \"%s\"\n", op.Name)
+	responder += fmt.Sprintf("\tprefix := %s\n", generatePrefixStringTemplate(scope))
+	responder += "\ttopic := fmt.Sprintf(\"%s" + scopeTopic + "%s%s\", prefix, delimiter, op)\n"
+	responder += g.generateAuthorize(scope, "Subscribe", "l.provider", "return nil, err")
+	responder += "\ttransport, protocolFactory := l.provider.NewSubscriber()\n"
+	responder += g.generateScopeProtocolFactory(scope)
+	responder += fmt.Sprintf("\tcb := %s\n", g.generateRecvCallback(op))
+	responder += "\tif err := transport.Subscribe(topic, cb); err != nil {\n"
+	responder += "\t\treturn nil, err\n"
+	responder += "\t}\n\n"
+	responder += "\tsub := frugal.NewFSubscription(topic, transport)\n"
+	responder += "\treturn sub, nil\n"
+	responder += "}\n\n"
+
+	responder += fmt.Sprintf("func (l *%sResponder) recv%s(op string, pf *frugal.FProtocolFactory, handler func(frugal.FContext, %s) (%s, error)) frugal.FAsyncCallback {\n",
+		scopeLower, op.Name, reqType, replyType)
+	responder += fmt.Sprintf("\tmethod := frugal.NewMethod(l, handler, \"Respond%s\", l.middleware)\n", op.Name)
+	responder += "\treturn func(transport thrift.TTransport) error {\n"
+	responder += g.generateReadScopeRequest(op)
+	responder += "\t\tret := method.Invoke([]interface{}{ctx, req})\n"
+	responder += "\t\treturn l.responder.Reply(ctx, op, ret, func(oprot *frugal.FProtocol) error {\n"
+	responder += fmt.Sprintf("\t\t\treply := ret[0].(%s)\n", replyType)
+	responder += g.generateWriteFieldRec(parser.FieldFromType(op.ReplyType(), ""), "reply")
+	responder += "\t\t\treturn nil\n"
+	responder += "\t\t})\n"
+	responder += "\t}\n"
+	responder += "}\n\n"
+	return responder
+}
+
+// generateSubscribeFilteredMethod generates the subscribe methods which
+// evaluate a filter predicate on each decoded message before invoking the
+// handler.
+func (g *Generator) generateSubscribeFilteredMethod(scope *parser.Scope, op *parser.Operation, args, argsWithoutTypes string) string {
+	var (
+		scopeLower = parser.LowercaseFirstLetter(scope.Name)
+		goType     = g.getGoTypeFromThriftType(op.Type)
+		subscriber = ""
+	)
+	if comment := op.DocComment(); comment != nil {
+		subscriber += g.GenerateInlineComment(comment, "")
+	}
+
+	subscriber += fmt.Sprintf("func (l *%sSubscriber) Subscribe%sFiltered(%sfilter func(frugal.FContext, %s) bool, handler func(frugal.FContext, %s)) (*frugal.FSubscription, error) {\n",
+		scopeLower, op.Name, args, goType, goType)
+	subscriber += fmt.Sprintf("\treturn l.Subscribe%sErrorableFiltered(%sfilter, func(fctx frugal.FContext, arg %s) error {\n",
+		op.Name, argsWithoutTypes, goType)
+	subscriber += "\t\thandler(fctx, arg)\n"
+	subscriber += "\t\treturn nil\n"
+	subscriber += "\t})\n"
+	subscriber += "}\n\n"
+
+	if comment := op.DocComment(); comment != nil {
+		subscriber += g.GenerateInlineComment(comment, "")
+	}
+	subscriber += fmt.Sprintf("func (l *%sSubscriber) Subscribe%sErrorableFiltered(%sfilter func(frugal.FContext, %s) bool, handler func(frugal.FContext, %s) error) (*frugal.FSubscription, error) {\n",
+		scopeLower, op.Name, args, goType, goType)
+	subscriber += fmt.Sprintf("\treturn l.Subscribe%sErrorable(%sfunc(fctx frugal.FContext, arg %s) error {\n",
+		op.Name, argsWithoutTypes, goType)
+	subscriber += "\t\tif !filter(fctx, arg) {\n"
+	subscriber += "\t\t\treturn nil\n"
+	subscriber += "\t\t}\n"
+	subscriber += "\t\treturn handler(fctx, arg)\n"
+	subscriber += "\t})\n"
+	subscriber += "}\n\n"
+
+	return subscriber
+}
+
+// generateSubscribeFromMethod generates the subscribe methods for a
+// replayable operation which begin consuming at a given replay position.
+func (g *Generator) generateSubscribeFromMethod(scope *parser.Scope, op *parser.Operation, args, argsWithoutTypes string) string {
+	var (
+		scopeLower = parser.LowercaseFirstLetter(scope.Name)
+		scopeTopic = scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter)
+		subscriber = ""
+	)
+	if comment := op.DocComment(); comment != nil {
+		subscriber += g.GenerateInlineComment(comment, "")
+	}
+
+	subscriber += fmt.Sprintf("func (l *%sSubscriber) Subscribe%sFrom(%sfrom frugal.FReplayPosition, handler func(frugal.FContext, %s)) (*frugal.FSubscription, error) {\n",
+		scopeLower, op.Name, args, g.getGoTypeFromThriftType(op.Type))
+	subscriber += fmt.Sprintf("\treturn l.Subscribe%sErrorableFrom(%sfrom, func(fctx frugal.FContext, arg %s) error {\n",
+		op.Name, argsWithoutTypes, g.getGoTypeFromThriftType(op.Type))
+	subscriber += "\t\thandler(fctx, arg)\n"
+	subscriber += "\t\treturn nil\n"
+	subscriber += "\t})\n"
+	subscriber += "}\n\n"
+
+	if comment := op.DocComment(); comment != nil {
+		subscriber += g.GenerateInlineComment(comment, "")
+	}
+	subscriber += fmt.Sprintf("func (l *%sSubscriber) Subscribe%sErrorableFrom(%sfrom frugal.FReplayPosition, handler func(frugal.FContext, %s) error) (*frugal.FSubscription, error) {\n",
+		scopeLower, op.Name, args, g.getGoTypeFromThriftType(op.Type))
+	subscriber += g.generatePrefixVariableChecks(scope, "return nil, err")
 	subscriber += fmt.Sprintf("\top := \"%s\"\n", op.Name)
 	subscriber += fmt.Sprintf("\tprefix := %s\n", generatePrefixStringTemplate(scope))
-	subscriber += "\ttopic := fmt.Sprintf(\"%s" + scopeTitle + "%s%s\", prefix, delimiter, op)\n"
+	subscriber += "\ttopic := fmt.Sprintf(\"%s" + scopeTopic + "%s%s\", prefix, delimiter, op)\n"
+	subscriber += g.generateAuthorize(scope, "Subscribe", "l.provider", "return nil, err")
 	subscriber += "\ttransport, protocolFactory := l.provider.NewSubscriber()\n"
-	subscriber += fmt.Sprintf("\tcb := l.recv%s(op, protocolFactory, handler)\n", op.Name)
-	subscriber += "\tif err := transport.Subscribe(topic, cb); err != nil {\n"
+	subscriber += g.generateScopeProtocolFactory(scope)
+	subscriber += fmt.Sprintf("\tcb := %s\n", g.generateRecvCallback(op))
+	subscriber += "\tif err := frugal.SubscribeFrom(transport, topic, from, cb); err != nil {\n"
 	subscriber += "\t\treturn nil, err\n"
 	subscriber += "\t}\n\n"
 
@@ -1548,36 +2506,137 @@ func (g *Generator) generateSubscribeMethod(scope *parser.Scope, op *parser.Oper
 	subscriber += "\treturn sub, nil\n"
 	subscriber += "}\n\n"
 
-	subscriber += fmt.Sprintf("func (l *%sSubscriber) recv%s(op string, pf *frugal.FProtocolFactory, handler func(frugal.FContext, %s) error) frugal.FAsyncCallback {\n",
-		scopeLower, op.Name, g.getGoTypeFromThriftType(op.Type))
-	subscriber += fmt.Sprintf("\tmethod := frugal.NewMethod(l, handler, \"Subscribe%s\", l.middleware)\n", op.Name)
+	return subscriber
+}
+
+// generateReceiveMethod generates the subscribe methods for a chunked
+// operation which pass each blob to the handler once all of its chunks are
+// received.
+func (g *Generator) generateReceiveMethod(scope *parser.Scope, op *parser.Operation, args, argsWithoutTypes string) string {
+	var (
+		scopeLower = parser.LowercaseFirstLetter(scope.Name)
+		goType     = g.getGoTypeFromThriftType(op.Type)
+		subscriber = ""
+	)
+	chunk, data := "chunk", "data"
+	if goType != "[]byte" {
+		chunk, data = "[]byte(chunk)", fmt.Sprintf("%s(data)", goType)
+	}
+
+	subscriber += fmt.Sprintf("// Receive%s subscribes to %s and passes each blob sent with\n", op.Name, op.Name)
+	subscriber += fmt.Sprintf("// Send%s to the handler once all of its chunks are received.\n", op.Name)
+	subscriber += fmt.Sprintf("func (l *%sSubscriber) Receive%s(%shandler func(frugal.FContext, %s)) (*frugal.FSubscription, error) {\n",
+		scopeLower, op.Name, args, goType)
+	subscriber += fmt.Sprintf("\treturn l.Receive%sErrorable(%sfunc(fctx frugal.FContext, arg %s) error {\n",
+		op.Name, argsWithoutTypes, goType)
+	subscriber += "\t\thandler(fctx, arg)\n"
+	subscriber += "\t\treturn nil\n"
+	subscriber += "\t})\n"
+	subscriber += "}\n\n"
+
+	subscriber += fmt.Sprintf("// Receive%sErrorable subscribes to %s and passes each blob sent with\n", op.Name, op.Name)
+	subscriber += fmt.Sprintf("// Send%s to the handler once all of its chunks are received.\n", op.Name)
+	subscriber += fmt.Sprintf("func (l *%sSubscriber) Receive%sErrorable(%shandler func(frugal.FContext, %s) error) (*frugal.FSubscription, error) {\n",
+		scopeLower, op.Name, args, goType)
+	subscriber += "\tassembler := frugal.NewFChunkAssembler()\n"
+	subscriber += fmt.Sprintf("\treturn l.Subscribe%sErrorable(%sfunc(fctx frugal.FContext, chunk %s) error {\n",
+		op.Name, argsWithoutTypes, goType)
+	subscriber += fmt.Sprintf("\t\tdata, ok, err := assembler.Add(fctx, %s)\n", chunk)
+	subscriber += "\t\tif err != nil || !ok {\n"
+	subscriber += "\t\t\treturn err\n"
+	subscriber += "\t\t}\n"
+	subscriber += fmt.Sprintf("\t\treturn handler(fctx, %s)\n", data)
+	subscriber += "\t})\n"
+	subscriber += "}\n\n"
+
+	return subscriber
+}
+
+// generateSubscribeAllMethod generates the subscribe methods which subscribe
+// to a wildcard topic matching every operation of the scope, and of its
+// previous versions with the subscribe_versions option, and pass each decoded
+// message to the handler along with its operation name. The "*" wildcard only
+// matches the operations with the "." topic delimiter, so other delimiters
+// are warned about.
+func (g *Generator) generateSubscribeAllMethod(scope *parser.Scope, args, argsWithoutTypes string) string {
+	g.WarnSubscribeAllDelimiter(scope)
+	var (
+		scopeLower = parser.LowercaseFirstLetter(scope.Name)
+		scopeTopic = scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter)
+		subscriber = ""
+	)
+
+	subscriber += fmt.Sprintf("func (l *%sSubscriber) SubscribeAll(%shandler func(frugal.FContext, string, interface{})) (*frugal.FSubscription, error) {\n",
+		scopeLower, args)
+	subscriber += fmt.Sprintf("\treturn l.SubscribeAllErrorable(%sfunc(fctx frugal.FContext, op string, arg interface{}) error {\n", argsWithoutTypes)
+	subscriber += "\t\thandler(fctx, op, arg)\n"
+	subscriber += "\t\treturn nil\n"
+	subscriber += "\t})\n"
+	subscriber += "}\n\n"
+
+	ops := []string{}
+	for _, op := range scope.Operations {
+		ops = append(ops, strconv.Quote(op.Name))
+	}
+	subscriber += fmt.Sprintf("func (l *%sSubscriber) SubscribeAllErrorable(%shandler func(frugal.FContext, string, interface{}) error) (*frugal.FSubscription, error) {\n",
+		scopeLower, args)
+	subscriber += g.generatePrefixVariableChecks(scope, "return nil, err")
+	subscriber += fmt.Sprintf("\tprefix := %s\n", generatePrefixStringTemplate(scope))
+	subscriber += "\ttopic := fmt.Sprintf(\"%s" + scopeTopic + "%s*\", prefix, delimiter)\n"
+	subscriber += fmt.Sprintf("\tfor _, op := range []string{%s} {\n", strings.Join(ops, ", "))
+	authorize := g.generateAuthorize(scope, "Subscribe", "l.provider", "return nil, err")
+	subscriber += "\t" + strings.Replace(authorize, "\n\t", "\n\t\t", -1)
+	subscriber += "\t}\n"
+	callback := g.wrapBatchFrameCallback("l.recvAll(protocolFactory, handler)")
+	if previous := g.previousScopeVersions(scope); len(previous) > 0 {
+		subscriber += g.generateVersionedSubscribe(scope, previous, "*", "", callback)
+	} else {
+		subscriber += "\ttransport, protocolFactory := l.provider.NewSubscriber()\n"
+		subscriber += g.generateScopeProtocolFactory(scope)
+		subscriber += fmt.Sprintf("\tif err := transport.Subscribe(topic, %s); err != nil {\n", callback)
+		subscriber += "\t\treturn nil, err\n"
+		subscriber += "\t}\n\n"
+		subscriber += "\tsub := frugal.NewFSubscription(topic, transport)\n"
+		subscriber += "\treturn sub, nil\n"
+	}
+	subscriber += "}\n\n"
+
+	subscriber += fmt.Sprintf("func (l *%sSubscriber) recvAll(pf *frugal.FProtocolFactory, handler func(frugal.FContext, string, interface{}) error) frugal.FAsyncCallback {\n", scopeLower)
+	subscriber += "\tmethod := frugal.NewMethod(l, handler, \"SubscribeAll\", l.middleware)\n"
 	subscriber += "\treturn func(transport thrift.TTransport) error {\n"
 	subscriber += "\t\tiprot := pf.GetProtocol(transport)\n"
 	subscriber += "\t\tctx, err := iprot.ReadRequestHeader()\n"
 	subscriber += "\t\tif err != nil {\n"
 	subscriber += "\t\t\treturn err\n"
 	subscriber += "\t\t}\n\n"
+	subscriber += g.generateNegotiateProtocol()
 	subscriber += "\t\tname, _, _, err := iprot.ReadMessageBegin()\n"
 	subscriber += "\t\tif err != nil {\n"
 	subscriber += "\t\t\treturn err\n"
 	subscriber += "\t\t}\n\n"
-	subscriber += "\t\tif name != op {\n"
+	subscriber += "\t\tvar arg interface{}\n"
+	subscriber += "\t\tswitch name {\n"
+	for _, op := range scope.Operations {
+		subscriber += fmt.Sprintf("\t\tcase %s:\n", strconv.Quote(op.Name))
+		subscriber += g.generateReadFieldRec(parser.FieldFromType(op.Type, "req"), false)
+		subscriber += "\t\t\targ = req\n"
+	}
+	subscriber += "\t\tdefault:\n"
 	subscriber += "\t\t\tiprot.Skip(thrift.STRUCT)\n"
 	subscriber += "\t\t\tiprot.ReadMessageEnd()\n"
 	subscriber += "\t\t\treturn thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_UNKNOWN_METHOD, \"Unknown function\"+name)\n"
 	subscriber += "\t\t}\n"
-	subscriber += g.generateReadFieldRec(parser.FieldFromType(op.Type, "req"), false)
 	subscriber += "\t\tiprot.ReadMessageEnd()\n\n"
-	subscriber += "\t\treturn method.Invoke([]interface{}{ctx, req}).Error()\n"
+	subscriber += g.generateInvokeHandler(scope, "name", "ctx, name, arg")
 	subscriber += "\t}\n"
 	subscriber += "}"
-
 	return subscriber
 }
 
 // GenerateService generates the given service.
 func (g *Generator) GenerateService(file *os.File, s *parser.Service) error {
 	contents := ""
+	contents += g.generateContractMetadata(g.ServiceMetadata(s), "Clients and servers")
 	contents += g.generateServiceInterface(s)
 	contents += g.generateClient(s)
 	contents += g.generateServer(s)
@@ -1589,8 +2648,8 @@ func (g *Generator) GenerateService(file *os.File, s *parser.Service) error {
 
 func (g *Generator) generateServiceInterface(service *parser.Service) string {
 	contents := ""
-	if service.Comment != nil {
-		contents += g.GenerateInlineComment(service.Comment, "")
+	if comment := service.DocComment(); comment != nil {
+		contents += g.GenerateInlineComment(comment, "")
 	}
 
 	contents += fmt.Sprintf("type F%s interface {\n", snakeToCamel(service.Name))
@@ -1651,8 +2710,8 @@ func (g *Generator) generateAsyncReturnArgs(method *parser.Method) string {
 func (g *Generator) generateClient(service *parser.Service) string {
 	servTitle := snakeToCamel(service.Name)
 	contents := ""
-	if service.Comment != nil {
-		contents += g.GenerateInlineComment(service.Comment, "")
+	if comment := service.DocComment(); comment != nil {
+		contents += g.GenerateInlineComment(comment, "")
 	}
 
 	contents += fmt.Sprintf("type F%sClient struct {\n", servTitle)
@@ -2335,6 +3394,8 @@ func (g *Generator) qualifiedTypeName(t *parser.Type) string {
 			name = namespace.Value
 		}
 		param = fmt.Sprintf("%s.%s", includeNameToReference(name), param)
+	} else {
+		param = g.localReference(param)
 	}
 
 	// The Thrift generator uses a convention of appending a suffix of '_'
@@ -2356,6 +3417,26 @@ func (g *Generator) generateSlim() bool {
 	return ok
 }
 
+func (g *Generator) generateBuilders() bool {
+	_, ok := g.Options[buildersOption]
+	return ok
+}
+
+func (g *Generator) generateJSONHelpers() bool {
+	_, ok := g.Options[generator.JSONOption]
+	return ok
+}
+
+func (g *Generator) generateSortedMaps() bool {
+	_, ok := g.Options[sortedMapsOption]
+	return ok
+}
+
+func (g *Generator) generateCopyMerge() bool {
+	_, ok := g.Options[generator.CopyMergeOption]
+	return ok
+}
+
 func (g *Generator) UseVendor() bool {
 	_, ok := g.Options[useVendorOption]
 	return ok
@@ -0,0 +1,303 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// generateJSON generates ToJSON, JSONValue, and FromJSON methods for the
+// given struct when the json option is set. Structs are encoded as objects
+// keyed by field name, omitting unset fields. Binary values are base64
+// encoded, enums are encoded by name, and sets are encoded as arrays. Maps
+// with string, integer, or enum keys are encoded as objects and other maps as
+// arrays of key-value pairs.
+func (g *Generator) generateJSON(s *parser.Struct, sName string) string {
+	if !g.generateJSONHelpers() {
+		return ""
+	}
+
+	contents := fmt.Sprintf("// ToJSON returns the JSON encoding of the %s.\n", sName)
+	contents += fmt.Sprintf("func (p *%s) ToJSON() ([]byte, error) {\n", sName)
+	contents += "\treturn json.Marshal(p.JSONValue())\n"
+	contents += "}\n\n"
+
+	contents += "// JSONValue returns the value encoded by ToJSON.\n"
+	contents += fmt.Sprintf("func (p *%s) JSONValue() map[string]interface{} {\n", sName)
+	contents += "\tif p == nil {\n"
+	contents += "\t\treturn nil\n"
+	contents += "\t}\n"
+	contents += fmt.Sprintf("\tvalue := make(map[string]interface{}, %d)\n", len(s.Fields))
+	for _, field := range s.Fields {
+		fName := title(field.Name)
+		value := "p." + fName
+		if g.isPointerField(field) && !g.Frugal.IsStruct(g.Frugal.UnderlyingType(field.Type)) {
+			value = "*" + value
+		}
+		indent := "\t"
+		switch {
+		case field.Modifier == parser.Optional:
+			contents += fmt.Sprintf("\tif p.IsSet%s() {\n", fName)
+			indent += "\t"
+		case g.isNillable(field.Type):
+			contents += fmt.Sprintf("\tif p.%s != nil {\n", fName)
+			indent += "\t"
+		}
+		code, expr := g.generateToJSONValue(value, field.Type, indent, 1)
+		contents += code
+		contents += fmt.Sprintf("%svalue[%q] = %s\n", indent, field.Name, expr)
+		if indent != "\t" {
+			contents += "\t}\n"
+		}
+	}
+	contents += "\treturn value\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("// FromJSON sets the fields of the %s from the JSON encoding produced by\n", sName)
+	contents += "// ToJSON. Fields missing from the JSON are left unchanged.\n"
+	contents += fmt.Sprintf("func (p *%s) FromJSON(data []byte) error {\n", sName)
+	contents += "\tvar fields map[string]json.RawMessage\n"
+	contents += "\tif err := json.Unmarshal(data, &fields); err != nil {\n"
+	contents += "\t\treturn err\n"
+	contents += "\t}\n"
+	for _, field := range s.Fields {
+		fName := title(field.Name)
+		failure := fmt.Sprintf("return thrift.PrependError(%q, err)", fmt.Sprintf("error decoding field %s: ", field.Name))
+		contents += fmt.Sprintf("\tif raw, ok := fields[%q]; ok {\n", field.Name)
+		contents += g.generateFromJSONValue("raw", "v", field.Type, failure, "\t\t", 1)
+		if g.isPointerField(field) && !g.Frugal.IsStruct(g.Frugal.UnderlyingType(field.Type)) {
+			contents += fmt.Sprintf("\t\tp.%s = &v\n", fName)
+		} else {
+			contents += fmt.Sprintf("\t\tp.%s = v\n", fName)
+		}
+		contents += "\t}\n"
+	}
+	contents += "\treturn nil\n"
+	contents += "}\n\n"
+	return contents
+}
+
+// generateToJSONValue generates the code converting the value to one which
+// encoding/json encodes as described by generateJSON. It returns the code
+// and an expression for the converted value.
+func (g *Generator) generateToJSONValue(value string, t *parser.Type, indent string, depth int) (string, string) {
+	underlying := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsStruct(underlying) {
+		return "", value + ".JSONValue()"
+	}
+	if !g.needsJSONConversion(underlying) {
+		return "", value
+	}
+
+	v := fmt.Sprintf("v%d", depth)
+	elem := fmt.Sprintf("elem%d", depth)
+	contents := ""
+	switch underlying.Name {
+	case "list", "set":
+		contents += fmt.Sprintf("%s%s := make([]interface{}, 0, len(%s))\n", indent, v, value)
+		if underlying.Name == "list" {
+			contents += fmt.Sprintf("%sfor _, %s := range %s {\n", indent, elem, value)
+		} else {
+			contents += fmt.Sprintf("%sfor %s := range %s {\n", indent, elem, value)
+		}
+		code, expr := g.generateToJSONValue(elem, underlying.ValueType, indent+"\t", depth+1)
+		contents += code
+		contents += fmt.Sprintf("%s\t%s = append(%s, %s)\n", indent, v, v, expr)
+	case "map":
+		// Keys and values are converted in the same scope, so their
+		// variables need different depths.
+		key := fmt.Sprintf("key%d", depth)
+		keyCode, keyExpr := g.generateToJSONValue(key, underlying.KeyType, indent+"\t", depth+1)
+		valueCode, valueExpr := g.generateToJSONValue(elem, underlying.ValueType, indent+"\t", depth+2)
+		if g.isJSONObjectKey(underlying.KeyType) {
+			contents += fmt.Sprintf("%s%s := make(map[string]interface{}, len(%s))\n", indent, v, value)
+			contents += fmt.Sprintf("%sfor %s, %s := range %s {\n", indent, key, elem, value)
+			contents += valueCode
+			contents += fmt.Sprintf("%s\t%s[%s] = %s\n", indent, v, g.jsonObjectKey(key, underlying.KeyType), valueExpr)
+		} else {
+			contents += fmt.Sprintf("%s%s := make([]interface{}, 0, len(%s))\n", indent, v, value)
+			contents += fmt.Sprintf("%sfor %s, %s := range %s {\n", indent, key, elem, value)
+			contents += keyCode
+			contents += valueCode
+			contents += fmt.Sprintf("%s\t%s = append(%s, []interface{}{%s, %s})\n", indent, v, v, keyExpr, valueExpr)
+		}
+	}
+	contents += indent + "}\n"
+	return contents, v
+}
+
+// generateFromJSONValue generates the code declaring the variable v with the
+// value decoded from the json.RawMessage raw, running failure on errors.
+func (g *Generator) generateFromJSONValue(raw, v string, t *parser.Type, failure, indent string, depth int) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	goType := g.getGoTypeFromThriftTypePtr(t, false)
+	contents := ""
+	if g.Frugal.IsStruct(underlying) {
+		// Need to extract the struct name from the package prefix
+		// ie *base.APIException -> base.NewAPIException()
+		goUnderlyingType := g.getGoTypeFromThriftTypePtr(underlying, false)
+		lastInd := strings.LastIndex(goUnderlyingType, ".")
+		if lastInd == -1 {
+			lastInd = 0
+		}
+		contents += fmt.Sprintf("%s%s := %sNew%s()\n", indent, v, goUnderlyingType[1:lastInd+1], goUnderlyingType[lastInd+1:])
+		contents += fmt.Sprintf("%sif err := %s.FromJSON(%s); err != nil {\n", indent, v, raw)
+		contents += fmt.Sprintf("%s\t%s\n", indent, failure)
+		contents += indent + "}\n"
+		return contents
+	}
+	if !g.needsJSONConversion(underlying) {
+		contents += fmt.Sprintf("%svar %s %s\n", indent, v, goType)
+		contents += fmt.Sprintf("%sif err := json.Unmarshal(%s, &%s); err != nil {\n", indent, raw, v)
+		contents += fmt.Sprintf("%s\t%s\n", indent, failure)
+		contents += indent + "}\n"
+		return contents
+	}
+
+	raws := fmt.Sprintf("raws%d", depth)
+	elemRaw := fmt.Sprintf("raw%d", depth)
+	elem := fmt.Sprintf("elem%d", depth)
+	switch underlying.Name {
+	case "list", "set":
+		contents += fmt.Sprintf("%svar %s []json.RawMessage\n", indent, raws)
+		contents += fmt.Sprintf("%sif err := json.Unmarshal(%s, &%s); err != nil {\n", indent, raw, raws)
+		contents += fmt.Sprintf("%s\t%s\n", indent, failure)
+		contents += indent + "}\n"
+		if underlying.Name == "list" {
+			contents += fmt.Sprintf("%s%s := make(%s, 0, len(%s))\n", indent, v, goType, raws)
+		} else {
+			contents += fmt.Sprintf("%s%s := make(%s, len(%s))\n", indent, v, goType, raws)
+		}
+		contents += fmt.Sprintf("%sfor _, %s := range %s {\n", indent, elemRaw, raws)
+		contents += g.generateFromJSONValue(elemRaw, elem, underlying.ValueType, failure, indent+"\t", depth+1)
+		if underlying.Name == "list" {
+			contents += fmt.Sprintf("%s\t%s = append(%s, %s)\n", indent, v, v, elem)
+		} else {
+			contents += fmt.Sprintf("%s\t%s[%s] = true\n", indent, v, elem)
+		}
+	case "map":
+		key := fmt.Sprintf("key%d", depth)
+		if g.isJSONObjectKey(underlying.KeyType) {
+			contents += fmt.Sprintf("%svar %s map[%s]json.RawMessage\n", indent, raws, g.getGoTypeFromThriftTypePtr(underlying.KeyType, false))
+			contents += fmt.Sprintf("%sif err := json.Unmarshal(%s, &%s); err != nil {\n", indent, raw, raws)
+			contents += fmt.Sprintf("%s\t%s\n", indent, failure)
+			contents += indent + "}\n"
+			contents += fmt.Sprintf("%s%s := make(%s, len(%s))\n", indent, v, goType, raws)
+			contents += fmt.Sprintf("%sfor %s, %s := range %s {\n", indent, key, elemRaw, raws)
+		} else {
+			contents += fmt.Sprintf("%svar %s [][2]json.RawMessage\n", indent, raws)
+			contents += fmt.Sprintf("%sif err := json.Unmarshal(%s, &%s); err != nil {\n", indent, raw, raws)
+			contents += fmt.Sprintf("%s\t%s\n", indent, failure)
+			contents += indent + "}\n"
+			contents += fmt.Sprintf("%s%s := make(%s, len(%s))\n", indent, v, goType, raws)
+			contents += fmt.Sprintf("%sfor _, pair := range %s {\n", indent, raws)
+			contents += g.generateFromJSONValue("pair[0]", key, underlying.KeyType, failure, indent+"\t", depth+1)
+			contents += fmt.Sprintf("%s\t%s := pair[1]\n", indent, elemRaw)
+		}
+		// Keys and values are decoded in the same scope, so their variables
+		// need different depths.
+		contents += g.generateFromJSONValue(elemRaw, elem, underlying.ValueType, failure, indent+"\t", depth+2)
+		contents += fmt.Sprintf("%s\t%s[%s] = %s\n", indent, v, key, elem)
+	}
+	contents += indent + "}\n"
+	return contents
+}
+
+// needsJSONConversion returns true if values of the type can't be encoded
+// directly with encoding/json, i.e. they contain structs, sets, or maps which
+// are encoded as arrays of key-value pairs.
+func (g *Generator) needsJSONConversion(t *parser.Type) bool {
+	underlying := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsStruct(underlying) {
+		return true
+	}
+	switch underlying.Name {
+	case "set":
+		return true
+	case "list":
+		return g.needsJSONConversion(underlying.ValueType)
+	case "map":
+		return !g.isJSONObjectKey(underlying.KeyType) || g.needsJSONConversion(underlying.ValueType)
+	}
+	return false
+}
+
+// isJSONObjectKey returns true if maps with keys of the type are encoded as
+// JSON objects.
+func (g *Generator) isJSONObjectKey(t *parser.Type) bool {
+	underlying := g.Frugal.UnderlyingType(t)
+	switch underlying.Name {
+	case "string", "byte", "i8", "i16", "i32", "i64":
+		return true
+	}
+	return g.Frugal.IsEnum(underlying)
+}
+
+// jsonObjectKey returns an expression converting the map key to the JSON
+// object key encoding/json uses for it.
+func (g *Generator) jsonObjectKey(key string, t *parser.Type) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsEnum(underlying):
+		return key + ".String()"
+	case underlying.Name == "string":
+		return fmt.Sprintf("string(%s)", key)
+	default:
+		return fmt.Sprintf("strconv.FormatInt(int64(%s), 10)", key)
+	}
+}
+
+// isNillable returns true if the Go type of the given type can be nil.
+func (g *Generator) isNillable(t *parser.Type) bool {
+	underlying := g.Frugal.UnderlyingType(t)
+	return g.Frugal.IsStruct(underlying) || underlying.IsContainer() || underlying.Name == "binary"
+}
+
+// generateJSONImports generates the imports needed by the JSON methods of the
+// file's structs.
+func (g *Generator) generateJSONImports() string {
+	if !g.generateJSONHelpers() || len(g.Frugal.DataStructures()) == 0 {
+		return ""
+	}
+	contents := "\t\"encoding/json\"\n"
+	for _, s := range g.Frugal.DataStructures() {
+		for _, field := range s.Fields {
+			if g.formatsJSONIntegerKeys(field.Type) {
+				return contents + "\t\"strconv\"\n"
+			}
+		}
+	}
+	return contents
+}
+
+// formatsJSONIntegerKeys returns true if converting values of the type for
+// encoding/json formats integer map keys.
+func (g *Generator) formatsJSONIntegerKeys(t *parser.Type) bool {
+	underlying := g.Frugal.UnderlyingType(t)
+	if !underlying.IsContainer() || !g.needsJSONConversion(underlying) {
+		return false
+	}
+	if underlying.Name == "map" {
+		key := g.Frugal.UnderlyingType(underlying.KeyType)
+		if g.isJSONObjectKey(key) && key.Name != "string" && !g.Frugal.IsEnum(key) {
+			return true
+		}
+		if g.formatsJSONIntegerKeys(underlying.KeyType) {
+			return true
+		}
+	}
+	return g.formatsJSONIntegerKeys(underlying.ValueType)
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"fmt"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// TypeNames names the Go declarations generated for a Frugal file so
+// generators of Go code in other packages can reference them.
+type TypeNames struct {
+	g *Generator
+}
+
+// NewTypeNames returns the TypeNames of the Frugal file generated with the
+// given Go options. Declarations of the file are qualified with its package.
+func NewTypeNames(frugal *parser.Frugal, options map[string]string) *TypeNames {
+	g := NewGenerator(options).(*Generator)
+	g.SetFrugal(frugal)
+	g.modelsPackage = includeNameToReference(g.packageName())
+	return &TypeNames{g: g}
+}
+
+// Package returns the reference to the package of the Frugal file.
+func (n *TypeNames) Package() string {
+	return n.g.modelsPackage
+}
+
+// ImportPath returns the import path of the package of the Frugal file.
+func (n *TypeNames) ImportPath() string {
+	return n.g.Options[packagePrefixOption] + includeNameToImport(n.g.packageName())
+}
+
+// Type returns the Go type of the Frugal type. Structs are pointers.
+func (n *TypeNames) Type(t *parser.Type) string {
+	return n.g.getGoTypeFromThriftType(t)
+}
+
+// FieldName returns the name of the struct field.
+func (n *TypeNames) FieldName(field *parser.Field) string {
+	return title(field.Name)
+}
+
+// IsPointerField indicates if the struct field is a pointer to its type.
+func (n *TypeNames) IsPointerField(field *parser.Field) bool {
+	return n.g.isPointerField(field)
+}
+
+// Service returns the qualified name of the interface of the service.
+func (n *TypeNames) Service(service *parser.Service) string {
+	return fmt.Sprintf("%s.F%s", n.g.modelsPackage, snakeToCamel(service.Name))
+}
+
+// Method returns the name of the service method.
+func (n *TypeNames) Method(method *parser.Method) string {
+	return snakeToCamel(method.Name)
+}
+
+// packageName returns the Go namespace of the Frugal file or its name.
+func (g *Generator) packageName() string {
+	if namespace := g.Frugal.Namespace(lang); namespace != nil {
+		return namespace.Value
+	}
+	return g.Frugal.Name
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// generatePII generates PIIFields and RedactPII methods for structs which
+// contain fields annotated as PII, directly or in nested structs. RedactPII
+// lets generated structs satisfy frugal.FPIIRedactor.
+func (g *Generator) generatePII(s *parser.Struct, sName string) string {
+	if !g.Frugal.ContainsPII(s) {
+		return ""
+	}
+
+	names := []string{}
+	for _, field := range s.PIIFields() {
+		names = append(names, fmt.Sprintf("%q", field.Name))
+	}
+	contents := fmt.Sprintf("// PIIFields returns the names of the fields of %s annotated as PII.\n", sName)
+	contents += fmt.Sprintf("func (p *%s) PIIFields() []string {\n", sName)
+	contents += fmt.Sprintf("\treturn []string{%s}\n", strings.Join(names, ", "))
+	contents += "}\n\n"
+
+	contents += "// RedactPII clears the fields annotated as PII, including those of nested\n"
+	contents += "// structs, in place.\n"
+	contents += fmt.Sprintf("func (p *%s) RedactPII() {\n", sName)
+	for _, field := range s.Fields {
+		fName := title(field.Name)
+		if field.Annotations.IsPII() {
+			contents += fmt.Sprintf("\tp.%s = %s\n", fName, g.piiZeroValue(field))
+			continue
+		}
+		contents += g.generateRedactNested("p."+fName, field.Type, "\t", 0)
+	}
+	contents += "}\n\n"
+	return contents
+}
+
+// generateRedactNested generates code redacting the structs contained in the
+// value, which is either a struct or a container of them.
+func (g *Generator) generateRedactNested(value string, t *parser.Type, indent string, depth int) string {
+	if !g.Frugal.TypeContainsPII(t) {
+		return ""
+	}
+	underlying := g.Frugal.UnderlyingType(t)
+	elem := "elem"
+	if depth > 0 {
+		elem = fmt.Sprintf("elem%d", depth)
+	}
+	contents := ""
+	switch underlying.Name {
+	case "list", "map":
+		contents += fmt.Sprintf("%sfor _, %s := range %s {\n", indent, elem, value)
+	case "set":
+		// Sets are maps keyed by their elements.
+		contents += fmt.Sprintf("%sfor %s := range %s {\n", indent, elem, value)
+	default:
+		contents += fmt.Sprintf("%sif %s != nil {\n", indent, value)
+		contents += fmt.Sprintf("%s\t%s.RedactPII()\n", indent, value)
+		contents += indent + "}\n"
+		return contents
+	}
+	contents += g.generateRedactNested(elem, underlying.ValueType, indent+"\t", depth+1)
+	contents += indent + "}\n"
+	return contents
+}
+
+// piiZeroValue returns the zero value the field is cleared to.
+func (g *Generator) piiZeroValue(field *parser.Field) string {
+	if g.isPointerField(field) {
+		return "nil"
+	}
+	underlying := g.Frugal.UnderlyingType(field.Type)
+	switch underlying.Name {
+	case "bool":
+		return "false"
+	case "string":
+		return `""`
+	case "binary", "list", "set", "map":
+		return "nil"
+	}
+	// Numbers and enums.
+	return "0"
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// generateSample generates the check dropping messages of the given
+// operation which aren't sampled if it's annotated with a sample rate.
+func (g *Generator) generateSample(op *parser.Operation, indent string) string {
+	rate, ok := op.SampleRate()
+	if !ok {
+		return ""
+	}
+	contents := fmt.Sprintf("%sif !frugal.Sample(ctx, %s) {\n", indent, strconv.FormatFloat(rate, 'g', -1, 64))
+	contents += indent + "\treturn nil\n"
+	contents += indent + "}\n"
+	return contents
+}
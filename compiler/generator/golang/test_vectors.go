@@ -0,0 +1,107 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Workiva/frugal/compiler/generator"
+)
+
+const testVectorsSuffix = "test_vectors_test"
+
+// generateTestVectorsTest generates a test decoding the test vector of each
+// struct, union, and exception with each protocol and checking that writing
+// the decoded value reproduces the vector, which locks in the wire format
+// other languages decode. Protocols which can't encode a struct are skipped.
+func (g *Generator) generateTestVectorsTest(outputDir string) error {
+	structs := g.Frugal.DataStructures()
+	if len(structs) == 0 {
+		return nil
+	}
+	file, err := g.CreateFile(testVectorsSuffix, outputDir, lang, true)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := g.GenerateDocStringComment(file); err != nil {
+		return err
+	}
+	if err := g.GenerateNewline(file, 2); err != nil {
+		return err
+	}
+	if err := g.generatePackage(file); err != nil {
+		return err
+	}
+	if err := g.GenerateNewline(file, 2); err != nil {
+		return err
+	}
+
+	contents := "import (\n"
+	contents += "\t\"bytes\"\n"
+	contents += "\t\"testing\"\n\n"
+	if g.Options[thriftImportOption] != "" {
+		contents += "\t\"" + g.Options[thriftImportOption] + "\"\n"
+	} else {
+		contents += "\t\"git.apache.org/thrift.git/lib/go/thrift\"\n"
+	}
+	contents += ")\n\n"
+
+	contents += "var testVectorProtocolFactories = map[string]thrift.TProtocolFactory{\n"
+	contents += "\t\"binary\":  thrift.NewTBinaryProtocolFactoryDefault(),\n"
+	contents += "\t\"compact\": thrift.NewTCompactProtocolFactory(),\n"
+	contents += "\t\"json\":    thrift.NewTJSONProtocolFactory(),\n"
+	contents += "}\n\n"
+
+	contents += "// checkTestVector decodes the vector into the value with the protocol and\n"
+	contents += "// checks that writing it reproduces the vector.\n"
+	contents += "func checkTestVector(t *testing.T, protocol string, vector []byte, value thrift.TStruct) {\n"
+	contents += "\tfactory := testVectorProtocolFactories[protocol]\n"
+	contents += "\tin := thrift.NewTMemoryBuffer()\n"
+	contents += "\tin.Write(vector)\n"
+	contents += "\tif err := value.Read(factory.GetProtocol(in)); err != nil {\n"
+	contents += "\t\tt.Fatalf(\"failed to decode %s test vector: %s\", protocol, err)\n"
+	contents += "\t}\n"
+	contents += "\tout := thrift.NewTMemoryBuffer()\n"
+	contents += "\toprot := factory.GetProtocol(out)\n"
+	contents += "\tif err := value.Write(oprot); err != nil {\n"
+	contents += "\t\tt.Fatalf(\"failed to encode %s test vector: %s\", protocol, err)\n"
+	contents += "\t}\n"
+	contents += "\toprot.Flush()\n"
+	contents += "\tif !bytes.Equal(out.Bytes(), vector) {\n"
+	contents += "\t\tt.Fatalf(\"%s test vector mismatch:\\nexpected %q\\nactual   %q\", protocol, vector, out.Bytes())\n"
+	contents += "\t}\n"
+	contents += "}\n"
+
+	for _, s := range structs {
+		sName := title(s.Name)
+		contents += fmt.Sprintf("\nfunc Test%sTestVectors(t *testing.T) {\n", sName)
+		for _, protocol := range generator.TestVectorProtocols {
+			vector, err := generator.TestVector(g.Frugal, s, protocol)
+			if err != nil {
+				continue
+			}
+			contents += fmt.Sprintf("\tcheckTestVector(t, %q, []byte(%s), New%s())\n",
+				protocol, strconv.Quote(string(vector)), sName)
+		}
+		contents += "}\n"
+	}
+
+	if _, err := file.WriteString(contents); err != nil {
+		return err
+	}
+	return g.PostProcess(file)
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// generateStartSpan generates the start of a publish or receive span of the
+// given scope operation with the -tracing flag. The operation is a Go
+// expression.
+func (g *Generator) generateStartSpan(kind string, scope *parser.Scope, op, indent string) string {
+	if !globals.Tracing {
+		return ""
+	}
+	return fmt.Sprintf("%sspan := frugal.Start%sSpan(ctx, %s, %s)\n", indent, kind, strconv.Quote(scope.Name), op)
+}
+
+// generateFinishSpan generates the end of the span started by
+// generateStartSpan with the given error with the -tracing flag.
+func (g *Generator) generateFinishSpan(err, indent string) string {
+	if !globals.Tracing {
+		return ""
+	}
+	return fmt.Sprintf("%sspan.Finish(%s)\n", indent, err)
+}
+
+// generateInvokeHandler generates the invocation of a subscriber's handler
+// method with the given arguments, in a receive span of the given operation
+// with the -tracing flag, returning its error from the subscriber callback.
+func (g *Generator) generateInvokeHandler(scope *parser.Scope, op, args string) string {
+	if !globals.Tracing {
+		return fmt.Sprintf("\t\treturn method.Invoke([]interface{}{%s}).Error()\n", args)
+	}
+	contents := g.generateStartSpan("Receive", scope, op, "\t\t")
+	contents += fmt.Sprintf("\t\terr = method.Invoke([]interface{}{%s}).Error()\n", args)
+	contents += g.generateFinishSpan("err", "\t\t")
+	contents += "\t\treturn err\n"
+	return contents
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const subscribeVersionsOption = "subscribe_versions"
+
+// subscribeVersions returns the number of previous versions of versioned
+// scopes subscribers also subscribe to, 1 if the option is given without a
+// value and 0 if it isn't given.
+func (g *Generator) subscribeVersions() (int, error) {
+	value, ok := g.Options[subscribeVersionsOption]
+	if !ok {
+		return 0, nil
+	}
+	if value == "" {
+		return 1, nil
+	}
+	versions, err := strconv.Atoi(value)
+	if err != nil || versions < 1 {
+		return 0, fmt.Errorf("go option %s %q must be a positive number", subscribeVersionsOption, value)
+	}
+	return versions, nil
+}
+
+// previousScopeVersions returns the previous versions of the scope its
+// subscribers also subscribe to, newest first. Version 0 is the scope before
+// it was versioned.
+func (g *Generator) previousScopeVersions(scope *parser.Scope) []int {
+	versions, _ := g.subscribeVersions()
+	previous := []int{}
+	for version := scope.Version - 1; version >= 0 && len(previous) < versions; version-- {
+		previous = append(previous, version)
+	}
+	return previous
+}
+
+// generateVersionedSubscribe generates the statements of a subscribe method
+// which subscribe to the topic at the scope's version and its previous
+// versions, each with its own transport and the given callback, returning an
+// FSubscription unsubscribing from all of them. The topic's operation is
+// given by opFormat, e.g. "%s" followed by opArgs ", op", or "*" matching
+// every operation.
+func (g *Generator) generateVersionedSubscribe(scope *parser.Scope, previous []int, opFormat, opArgs, callback string) string {
+	scopeTitle := strings.Title(scope.Name)
+	subscriber := "\ttopics := []string{\n"
+	subscriber += "\t\ttopic,\n"
+	for _, version := range previous {
+		subscriber += fmt.Sprintf("\t\tfmt.Sprintf(\"%%s%s%%s%s\", prefix, delimiter%s),\n",
+			scopeTitle+parser.ScopeVersionSuffix(version, globals.TopicDelimiter), opFormat, opArgs)
+	}
+	subscriber += "\t}\n"
+	subscriber += "\tsubs := make([]*frugal.FSubscription, 0, len(topics))\n"
+	subscriber += "\tfor _, topic := range topics {\n"
+	subscriber += "\t\ttransport, protocolFactory := l.provider.NewSubscriber()\n"
+	if factory := g.generateScopeProtocolFactory(scope); factory != "" {
+		subscriber += "\t" + factory
+	}
+	subscriber += fmt.Sprintf("\t\tcb := %s\n", callback)
+	subscriber += "\t\tif err := transport.Subscribe(topic, cb); err != nil {\n"
+	subscriber += "\t\t\tfor _, sub := range subs {\n"
+	subscriber += "\t\t\t\tsub.Unsubscribe()\n"
+	subscriber += "\t\t\t}\n"
+	subscriber += "\t\t\treturn nil, err\n"
+	subscriber += "\t\t}\n"
+	subscriber += "\t\tsubs = append(subs, frugal.NewFSubscription(topic, transport))\n"
+	subscriber += "\t}\n"
+	subscriber += "\treturn frugal.NewFSubscriptionGroup(subs...), nil\n"
+	return subscriber
+}
@@ -0,0 +1,584 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package graphql
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const defaultOutputDir = "gen-graphql"
+
+// graphQLAnnotation overrides how a service method is exported. Its value is
+// "query", "mutation", or "ignore".
+const graphQLAnnotation = "graphql"
+
+const (
+	queryOperation    = "query"
+	mutationOperation = "mutation"
+	ignoreOperation   = "ignore"
+)
+
+// queryPrefixes are the method name prefixes which mark a method as a read
+// and export it as a query when it has no graphql annotation.
+var queryPrefixes = []string{"get", "list", "find", "search", "query", "fetch", "lookup", "count"}
+
+// Custom scalars for the Frugal types GraphQL has no built-in scalar for.
+// GraphQL's Int is 32 bits, so i64 is exported as Long.
+const (
+	longScalar   = "Long"
+	binaryScalar = "Binary"
+)
+
+// Generator implements the ProgramGenerator interface for GraphQL schema
+// documents, which describe the types of the IDL and the query-like methods of
+// its services for GraphQL gateways fronting Frugal services.
+type Generator struct{}
+
+// NewGenerator creates a new GraphQL ProgramGenerator.
+func NewGenerator(options map[string]string) generator.ProgramGenerator {
+	return &Generator{}
+}
+
+// Generate writes the schema for the Frugal file to the output directory.
+// Nothing is written for files without types or exported methods.
+func (g *Generator) Generate(frugal *parser.Frugal, outputDir string) error {
+	s, err := newSchema(frugal)
+	if err != nil {
+		return err
+	}
+	if s.empty() {
+		return nil
+	}
+	return ioutil.WriteFile(filepath.Join(outputDir, frugal.Name+".graphql"), s.document(), 0644)
+}
+
+// GetOutputDir returns the output directory for generated files.
+func (g *Generator) GetOutputDir(dir string, frugal *parser.Frugal) string {
+	return dir
+}
+
+// DefaultOutputDir returns the default output directory for generated files.
+func (g *Generator) DefaultOutputDir() string {
+	return defaultOutputDir
+}
+
+// UseVendor returns false since a schema is generated for every include.
+func (g *Generator) UseVendor() bool {
+	return false
+}
+
+// operation is a service method exported as a field of the Query or Mutation
+// type.
+type operation struct {
+	service *parser.Service
+	method  *parser.Method
+}
+
+// definition is a struct, union, or exception exported as an object or input
+// type.
+type definition struct {
+	frugal *parser.Frugal
+	s      *parser.Struct
+	name   string
+	input  bool
+}
+
+type enumDefinition struct {
+	enum *parser.Enum
+	name string
+}
+
+// entryDefinition is the key-value object type a map is exported as a list
+// of, since GraphQL has no map type.
+type entryDefinition struct {
+	name  string
+	key   string
+	value string
+	input bool
+}
+
+// schema collects the definitions of a GraphQL schema document in the order
+// they are first referenced.
+type schema struct {
+	frugal    *parser.Frugal
+	queries   []*operation
+	mutations []*operation
+
+	scalars     []string
+	enums       []*enumDefinition
+	definitions []*definition
+	entries     []*entryDefinition
+	seen        map[string]bool
+}
+
+func newSchema(frugal *parser.Frugal) (*schema, error) {
+	s := &schema{frugal: frugal, seen: make(map[string]bool)}
+	fields := make(map[string]string)
+	for _, service := range frugal.Services {
+		for _, method := range service.Methods {
+			kind, err := operationKind(method)
+			if err != nil {
+				return nil, fmt.Errorf("graphql: %s.%s: %s", service.Name, method.Name, err)
+			}
+			if kind == ignoreOperation {
+				continue
+			}
+			key := kind + "." + method.Name
+			if other, ok := fields[key]; ok {
+				return nil, fmt.Errorf("graphql: %s.%s and %s.%s are both exported as the %s field %s",
+					other, method.Name, service.Name, method.Name, kind, method.Name)
+			}
+			fields[key] = service.Name
+			op := &operation{service: service, method: method}
+			if kind == queryOperation {
+				s.queries = append(s.queries, op)
+			} else {
+				s.mutations = append(s.mutations, op)
+			}
+		}
+	}
+
+	for _, op := range append(s.queries, s.mutations...) {
+		for _, arg := range op.method.Arguments {
+			if err := s.addType(frugal, arg.Type, true); err != nil {
+				return nil, err
+			}
+		}
+		if op.method.ReturnType != nil {
+			if err := s.addType(frugal, op.method.ReturnType, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, enum := range frugal.Enums {
+		s.addEnum(frugal, enum)
+	}
+	for _, structs := range [][]*parser.Struct{frugal.Structs, frugal.Unions, frugal.Exceptions} {
+		for _, st := range structs {
+			if err := s.addStruct(frugal, st, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s, nil
+}
+
+// operationKind returns whether the method is exported as a query or a
+// mutation, or is ignored.
+func operationKind(method *parser.Method) (string, error) {
+	kind, ok := method.Annotations.Get(graphQLAnnotation)
+	if !ok {
+		if !method.Oneway && method.ReturnType != nil && isQueryLike(method.Name) {
+			return queryOperation, nil
+		}
+		return ignoreOperation, nil
+	}
+	switch kind {
+	case queryOperation:
+		if method.Oneway || method.ReturnType == nil {
+			return "", fmt.Errorf("only methods returning a value can be queries")
+		}
+	case mutationOperation:
+		if method.Oneway {
+			return "", fmt.Errorf("oneway methods can't be mutations")
+		}
+	case ignoreOperation:
+	default:
+		return "", fmt.Errorf("invalid %s annotation %q, must be %s, %s, or %s",
+			graphQLAnnotation, kind, queryOperation, mutationOperation, ignoreOperation)
+	}
+	return kind, nil
+}
+
+// isQueryLike returns true if the method name starts with one of the query
+// prefixes followed by the end of a word, such as getProduct or list.
+func isQueryLike(name string) bool {
+	for _, prefix := range queryPrefixes {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		rest := name[len(prefix):]
+		if rest == "" || rest[0] == '_' || (rest[0] >= 'A' && rest[0] <= 'Z') {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *schema) empty() bool {
+	return len(s.queries) == 0 && len(s.mutations) == 0 && len(s.enums) == 0 && len(s.definitions) == 0
+}
+
+// resolve returns the file declaring the type and the type with its include
+// prefix and any typedefs removed.
+func resolve(frugal *parser.Frugal, t *parser.Type) (*parser.Frugal, *parser.Type) {
+	for {
+		if include := t.IncludeName(); include != "" {
+			frugal = frugal.ParsedIncludes[include]
+			t = &parser.Type{Name: t.ParamName(), KeyType: t.KeyType, ValueType: t.ValueType}
+		}
+		typedef := findTypedef(frugal, t.Name)
+		if typedef == nil {
+			return frugal, t
+		}
+		t = typedef.Type
+	}
+}
+
+func findTypedef(frugal *parser.Frugal, name string) *parser.TypeDef {
+	for _, typedef := range frugal.Typedefs {
+		if typedef.Name == name {
+			return typedef
+		}
+	}
+	return nil
+}
+
+func findEnum(frugal *parser.Frugal, name string) *parser.Enum {
+	for _, enum := range frugal.Enums {
+		if enum.Name == name {
+			return enum
+		}
+	}
+	return nil
+}
+
+func findStruct(frugal *parser.Frugal, name string) *parser.Struct {
+	for _, structs := range [][]*parser.Struct{frugal.Structs, frugal.Unions, frugal.Exceptions} {
+		for _, s := range structs {
+			if s.Name == name {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+// addType adds the definitions the type references, if they haven't been
+// added yet.
+func (s *schema) addType(frugal *parser.Frugal, t *parser.Type, input bool) error {
+	frugal, t = resolve(frugal, t)
+	switch t.Name {
+	case "i64":
+		s.addScalar(longScalar)
+	case "binary":
+		s.addScalar(binaryScalar)
+	case "list", "set":
+		return s.addType(frugal, t.ValueType, input)
+	case "map":
+		if err := s.addType(frugal, t.KeyType, input); err != nil {
+			return err
+		}
+		if err := s.addType(frugal, t.ValueType, input); err != nil {
+			return err
+		}
+		entry := &entryDefinition{
+			name:  s.entryName(frugal, t, input),
+			key:   s.typeName(frugal, t.KeyType, input),
+			value: s.typeName(frugal, t.ValueType, input),
+			input: input,
+		}
+		if !s.seen[entry.name] {
+			s.seen[entry.name] = true
+			s.entries = append(s.entries, entry)
+		}
+	default:
+		if t.IsPrimitive() {
+			return nil
+		}
+		if enum := findEnum(frugal, t.Name); enum != nil {
+			s.addEnum(frugal, enum)
+			return nil
+		}
+		if st := findStruct(frugal, t.Name); st != nil {
+			return s.addStruct(frugal, st, input)
+		}
+		return fmt.Errorf("graphql: unknown type %s", t.Name)
+	}
+	return nil
+}
+
+func (s *schema) addScalar(name string) {
+	if !s.seen[name] {
+		s.seen[name] = true
+		s.scalars = append(s.scalars, name)
+	}
+}
+
+func (s *schema) addEnum(frugal *parser.Frugal, enum *parser.Enum) {
+	name := s.definitionName(frugal, enum.Name)
+	if !s.seen[name] {
+		s.seen[name] = true
+		s.enums = append(s.enums, &enumDefinition{enum: enum, name: name})
+	}
+}
+
+func (s *schema) addStruct(frugal *parser.Frugal, st *parser.Struct, input bool) error {
+	name := s.definitionName(frugal, st.Name)
+	if input {
+		name += "Input"
+	}
+	if s.seen[name] {
+		return nil
+	}
+	s.seen[name] = true
+	s.definitions = append(s.definitions, &definition{frugal: frugal, s: st, name: name, input: input})
+	for _, field := range st.Fields {
+		if err := s.addType(frugal, field.Type, input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// definitionName returns the name of an enum or struct declared in the given
+// file. Definitions from includes are prefixed with the include name since a
+// schema has a single namespace.
+func (s *schema) definitionName(frugal *parser.Frugal, name string) string {
+	if frugal == s.frugal {
+		return name
+	}
+	return frugal.Name + "_" + name
+}
+
+// typeName returns the GraphQL type of the Frugal type, without the non-null
+// modifier of the field or argument using it. Elements of lists and entries of
+// maps are never null.
+func (s *schema) typeName(frugal *parser.Frugal, t *parser.Type, input bool) string {
+	frugal, t = resolve(frugal, t)
+	switch t.Name {
+	case "bool":
+		return "Boolean"
+	case "byte", "i8", "i16", "i32":
+		return "Int"
+	case "i64":
+		return longScalar
+	case "double":
+		return "Float"
+	case "string":
+		return "String"
+	case "binary":
+		return binaryScalar
+	case "list", "set":
+		return "[" + s.typeName(frugal, t.ValueType, input) + "!]"
+	case "map":
+		return "[" + s.entryName(frugal, t, input) + "!]"
+	}
+	name := s.definitionName(frugal, t.Name)
+	if input && findStruct(frugal, t.Name) != nil {
+		name += "Input"
+	}
+	return name
+}
+
+// entryName returns the name of the entry type of the map, such as
+// StringPriceEntry, or StringPriceEntryInput for arguments.
+func (s *schema) entryName(frugal *parser.Frugal, t *parser.Type, input bool) string {
+	key := s.typeName(frugal, t.KeyType, false)
+	value := s.typeName(frugal, t.ValueType, false)
+	name := listFreeName(key) + listFreeName(value) + "Entry"
+	if input {
+		name += "Input"
+	}
+	return name
+}
+
+// listFreeName turns list and map types into names, such as StringList for
+// [String!].
+func listFreeName(typeName string) string {
+	if strings.HasPrefix(typeName, "[") {
+		return listFreeName(strings.TrimSuffix(typeName[1:], "!]")) + "List"
+	}
+	return strings.ToUpper(typeName[:1]) + typeName[1:]
+}
+
+// nonNull returns true if the field is always set. Required fields are always
+// set, as are default requiredness fields of base types and enums, which
+// always have a value.
+func (s *schema) nonNull(frugal *parser.Frugal, field *parser.Field) bool {
+	switch field.Modifier {
+	case parser.Required:
+		return true
+	case parser.Optional:
+		return false
+	}
+	frugal, t := resolve(frugal, field.Type)
+	return t.IsPrimitive() || findEnum(frugal, t.Name) != nil
+}
+
+func (s *schema) fieldType(frugal *parser.Frugal, field *parser.Field, input, union bool) string {
+	typeName := s.typeName(frugal, field.Type, input)
+	if !union && s.nonNull(frugal, field) {
+		typeName += "!"
+	}
+	return typeName
+}
+
+// document returns the schema document. Query and Mutation come first,
+// followed by the scalars, enums, object types, and input types.
+func (s *schema) document() []byte {
+	var buf bytes.Buffer
+	sections := []func(*bytes.Buffer){}
+	if len(s.queries) > 0 {
+		sections = append(sections, func(buf *bytes.Buffer) { s.writeOperations(buf, "Query", s.queries) })
+	}
+	if len(s.mutations) > 0 {
+		sections = append(sections, func(buf *bytes.Buffer) { s.writeOperations(buf, "Mutation", s.mutations) })
+	}
+	for _, scalar := range s.scalars {
+		scalar := scalar
+		sections = append(sections, func(buf *bytes.Buffer) { writeScalar(buf, scalar) })
+	}
+	for _, enum := range s.enums {
+		enum := enum
+		sections = append(sections, func(buf *bytes.Buffer) { writeEnum(buf, enum) })
+	}
+	for _, input := range []bool{false, true} {
+		for _, def := range s.definitions {
+			if def.input == input {
+				def := def
+				sections = append(sections, func(buf *bytes.Buffer) { s.writeDefinition(buf, def) })
+			}
+		}
+		for _, entry := range s.entries {
+			if entry.input == input {
+				entry := entry
+				sections = append(sections, func(buf *bytes.Buffer) { writeEntry(buf, entry) })
+			}
+		}
+	}
+
+	buf.WriteString("# Autogenerated by Frugal Compiler (" + globals.Version + ")\n")
+	buf.WriteString("# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING\n")
+	for _, section := range sections {
+		buf.WriteString("\n")
+		section(&buf)
+	}
+	return buf.Bytes()
+}
+
+func (s *schema) writeOperations(buf *bytes.Buffer, typeName string, ops []*operation) {
+	fmt.Fprintf(buf, "type %s {\n", typeName)
+	for _, op := range ops {
+		writeDescription(buf, "  ", op.method.Comment)
+		fmt.Fprintf(buf, "  %s", op.method.Name)
+		if len(op.method.Arguments) > 0 {
+			args := make([]string, len(op.method.Arguments))
+			for j, arg := range op.method.Arguments {
+				args[j] = arg.Name + ": " + s.fieldType(s.frugal, arg, true, false)
+			}
+			fmt.Fprintf(buf, "(%s)", strings.Join(args, ", "))
+		}
+		// Mutations of void methods return true once they complete.
+		returnType := "Boolean"
+		if op.method.ReturnType != nil {
+			returnType = s.typeName(s.frugal, op.method.ReturnType, false)
+		}
+		fmt.Fprintf(buf, ": %s%s\n", returnType, deprecation(op.method.Annotations))
+	}
+	buf.WriteString("}\n")
+}
+
+func writeScalar(buf *bytes.Buffer, name string) {
+	switch name {
+	case longScalar:
+		writeDescription(buf, "", []string{"A signed 64-bit integer."})
+	case binaryScalar:
+		writeDescription(buf, "", []string{"Binary data encoded in base64."})
+	}
+	fmt.Fprintf(buf, "scalar %s\n", name)
+}
+
+func writeEnum(buf *bytes.Buffer, enum *enumDefinition) {
+	writeDescription(buf, "", enum.enum.Comment)
+	fmt.Fprintf(buf, "enum %s {\n", enum.name)
+	for _, value := range enum.enum.Values {
+		writeDescription(buf, "  ", value.Comment)
+		fmt.Fprintf(buf, "  %s%s\n", value.Name, deprecation(value.Annotations))
+	}
+	buf.WriteString("}\n")
+}
+
+func (s *schema) writeDefinition(buf *bytes.Buffer, def *definition) {
+	writeDescription(buf, "", def.s.Comment)
+	keyword := "type"
+	if def.input {
+		keyword = "input"
+	}
+	fmt.Fprintf(buf, "%s %s {\n", keyword, def.name)
+	// GraphQL types must have a field, so empty structs get a placeholder.
+	if len(def.s.Fields) == 0 {
+		buf.WriteString("  _: Boolean\n")
+	}
+	union := def.s.Type == parser.StructTypeUnion
+	for _, field := range def.s.Fields {
+		writeDescription(buf, "  ", field.Comment)
+		fmt.Fprintf(buf, "  %s: %s", field.Name, s.fieldType(def.frugal, field, def.input, union))
+		// Older gateways don't allow the deprecated directive on input
+		// fields.
+		if !def.input {
+			buf.WriteString(deprecation(field.Annotations))
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n")
+}
+
+func writeEntry(buf *bytes.Buffer, entry *entryDefinition) {
+	keyword := "type"
+	if entry.input {
+		keyword = "input"
+	}
+	fmt.Fprintf(buf, "%s %s {\n", keyword, entry.name)
+	fmt.Fprintf(buf, "  key: %s!\n", entry.key)
+	fmt.Fprintf(buf, "  value: %s!\n", entry.value)
+	buf.WriteString("}\n")
+}
+
+// writeDescription writes the comment as a block string description.
+func writeDescription(buf *bytes.Buffer, indent string, comment []string) {
+	if len(comment) == 0 {
+		return
+	}
+	buf.WriteString(indent + "\"\"\"\n")
+	for _, line := range comment {
+		line = strings.Replace(line, `"""`, `\"""`, -1)
+		if line == "" {
+			buf.WriteString("\n")
+			continue
+		}
+		buf.WriteString(indent + line + "\n")
+	}
+	buf.WriteString(indent + "\"\"\"\n")
+}
+
+// deprecation returns the deprecated directive for the annotations, if they
+// mark the definition deprecated.
+func deprecation(annotations parser.Annotations) string {
+	reason, ok := annotations.Deprecated()
+	if !ok {
+		return ""
+	}
+	if reason == "" {
+		return " @deprecated"
+	}
+	return fmt.Sprintf(" @deprecated(reason: %q)", reason)
+}
@@ -0,0 +1,348 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator/golang"
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// bridge generates the Go bridge between the gRPC services protoc generates
+// and the Frugal services. Its output is formatted afterwards.
+type bridge struct {
+	file  *protoFile
+	names *golang.TypeNames
+}
+
+func (b *bridge) generate(pkg, frugalImport string) string {
+	contents := fmt.Sprintf("// Autogenerated by Frugal Compiler (%s)\n", globals.Version)
+	contents += "// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING\n\n"
+	contents += fmt.Sprintf("package %s\n\n", pkg)
+	contents += "import (\n"
+	contents += "\t\"context\"\n"
+	contents += "\t\"time\"\n\n"
+	contents += fmt.Sprintf("\t\"%s\"\n", frugalImport)
+	contents += fmt.Sprintf("\t\"%s\"\n", b.names.ImportPath())
+	contents += ")\n\n"
+
+	contents += "// frugalContext returns an FContext with the deadline of the gRPC context.\n"
+	contents += "func frugalContext(ctx context.Context) frugal.FContext {\n"
+	contents += "fctx := frugal.NewFContext(\"\")\n"
+	contents += "if deadline, ok := ctx.Deadline(); ok {\n"
+	contents += "fctx.SetTimeout(deadline.Sub(time.Now()))\n"
+	contents += "}\n"
+	contents += "return fctx\n"
+	contents += "}\n\n"
+
+	contents += "// grpcContext returns a context with the timeout of the FContext.\n"
+	contents += "func grpcContext(fctx frugal.FContext) (context.Context, context.CancelFunc) {\n"
+	contents += "return context.WithTimeout(context.Background(), fctx.Timeout())\n"
+	contents += "}\n"
+
+	for _, service := range b.file.services {
+		contents += b.generateServer(service)
+		contents += b.generateHandler(service)
+	}
+	for _, s := range b.file.structs {
+		contents += b.generateStructConverters(s)
+	}
+	return contents
+}
+
+// generateServer generates the gRPC server invoking the Frugal service.
+func (b *bridge) generateServer(service *protoService) string {
+	name := goCamelCase(service.service.Name)
+	server := name + "GRPCServer"
+	frugalService := b.names.Service(service.service)
+
+	contents := fmt.Sprintf("\n// %s implements %sServer by invoking the %s Frugal\n", server, name, service.service.Name)
+	contents += fmt.Sprintf("// service, e.g. an F%sClient, so gRPC clients can reach it. Errors,\n", name)
+	contents += "// including exceptions, are returned to gRPC clients as is.\n"
+	contents += fmt.Sprintf("type %s struct {\n", server)
+	contents += fmt.Sprintf("Unimplemented%sServer\n", name)
+	contents += fmt.Sprintf("handler %s\n", frugalService)
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("// New%s returns a gRPC server invoking the given Frugal service.\n", server)
+	contents += fmt.Sprintf("func New%s(handler %s) *%s {\n", server, frugalService, server)
+	contents += fmt.Sprintf("return &%s{handler: handler}\n", server)
+	contents += "}\n"
+
+	for _, m := range service.methods {
+		method := m.method
+		contents += fmt.Sprintf("\nfunc (s *%s) %s(ctx context.Context, req *%s) (*%s, error) {\n",
+			server, goCamelCase(method.Name), m.request(), m.response())
+		args := ""
+		for _, arg := range method.Arguments {
+			argName := "arg" + goCamelCase(arg.Name)
+			if b.file.frugal.UnderlyingType(arg.Type).IsContainer() {
+				contents += fmt.Sprintf("var %s %s\n", argName, b.names.Type(arg.Type))
+				contents += b.toFrugal(arg.Type, argName, "req."+goCamelCase(arg.Name), false)
+			} else {
+				contents += fmt.Sprintf("%s := %s\n", argName, b.toFrugalExpr(arg.Type, "req."+goCamelCase(arg.Name)))
+			}
+			args += ", " + argName
+		}
+		call := fmt.Sprintf("s.handler.%s(frugalContext(ctx)%s)", b.names.Method(method), args)
+		if method.ReturnType == nil {
+			contents += fmt.Sprintf("if err := %s; err != nil {\n", call)
+			contents += "return nil, err\n"
+			contents += "}\n"
+			contents += fmt.Sprintf("return &%s{}, nil\n", m.response())
+		} else {
+			contents += fmt.Sprintf("r, err := %s\n", call)
+			contents += "if err != nil {\n"
+			contents += "return nil, err\n"
+			contents += "}\n"
+			contents += fmt.Sprintf("resp := &%s{}\n", m.response())
+			contents += b.toProto(method.ReturnType, "resp.Success", "r")
+			contents += "return resp, nil\n"
+		}
+		contents += "}\n"
+	}
+	return contents
+}
+
+// generateHandler generates the Frugal service implementation invoking the
+// gRPC client.
+func (b *bridge) generateHandler(service *protoService) string {
+	name := goCamelCase(service.service.Name)
+	handler := name + "FrugalHandler"
+
+	contents := fmt.Sprintf("\n// %s implements the %s Frugal service by invoking a\n", handler, service.service.Name)
+	contents += fmt.Sprintf("// gRPC %sClient, so Frugal clients can reach a gRPC service. gRPC\n", name)
+	contents += "// errors are returned to Frugal clients as is.\n"
+	contents += fmt.Sprintf("type %s struct {\n", handler)
+	contents += fmt.Sprintf("client %sClient\n", name)
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("// New%s returns an implementation of the %s Frugal\n", handler, service.service.Name)
+	contents += "// service invoking the given gRPC client.\n"
+	contents += fmt.Sprintf("func New%s(client %sClient) %s {\n", handler, name, b.names.Service(service.service))
+	contents += fmt.Sprintf("return &%s{client: client}\n", handler)
+	contents += "}\n"
+
+	for _, m := range service.methods {
+		method := m.method
+		params := ""
+		for _, arg := range method.Arguments {
+			params += fmt.Sprintf(", arg%s %s", goCamelCase(arg.Name), b.names.Type(arg.Type))
+		}
+		results := "(err error)"
+		if method.ReturnType != nil {
+			results = fmt.Sprintf("(r %s, err error)", b.names.Type(method.ReturnType))
+		}
+		contents += fmt.Sprintf("\nfunc (h *%s) %s(fctx frugal.FContext%s) %s {\n",
+			handler, b.names.Method(method), params, results)
+		contents += fmt.Sprintf("req := &%s{}\n", m.request())
+		for _, arg := range method.Arguments {
+			contents += b.toProto(arg.Type, "req."+goCamelCase(arg.Name), "arg"+goCamelCase(arg.Name))
+		}
+		contents += "ctx, cancel := grpcContext(fctx)\n"
+		contents += "defer cancel()\n"
+		if method.ReturnType == nil {
+			contents += fmt.Sprintf("_, err = h.client.%s(ctx, req)\n", goCamelCase(method.Name))
+			contents += "return err\n"
+		} else {
+			contents += fmt.Sprintf("resp, err := h.client.%s(ctx, req)\n", goCamelCase(method.Name))
+			contents += "if err != nil {\n"
+			contents += "return r, err\n"
+			contents += "}\n"
+			contents += b.toFrugal(method.ReturnType, "r", "resp.Success", false)
+			contents += "return r, nil\n"
+		}
+		contents += "}\n"
+	}
+	return contents
+}
+
+// generateStructConverters generates the functions converting the struct
+// between its Frugal and proto types.
+func (b *bridge) generateStructConverters(s *parser.Struct) string {
+	typ := &parser.Type{Name: s.Name}
+	frugalType := b.names.Type(typ)
+	protoType := goCamelCase(s.Name)
+	constructor := strings.TrimPrefix(frugalType, "*")
+	dot := strings.LastIndex(constructor, ".")
+	constructor = constructor[:dot+1] + "New" + constructor[dot+1:]
+
+	contents := fmt.Sprintf("\nfunc %s(p %s) *%s {\n", b.toProtoFunc(typ), frugalType, protoType)
+	contents += "if p == nil {\n"
+	contents += "return nil\n"
+	contents += "}\n"
+	contents += fmt.Sprintf("m := &%s{}\n", protoType)
+	for _, field := range s.Fields {
+		src := "p." + b.names.FieldName(field)
+		dst := "m." + goCamelCase(field.Name)
+		switch {
+		case b.file.hasPresence(field):
+			contents += fmt.Sprintf("if p.IsSet%s() {\n", b.names.FieldName(field))
+			contents += fmt.Sprintf("v := %s\n", b.toProtoExpr(field.Type, fmt.Sprintf("p.Get%s()", b.names.FieldName(field))))
+			contents += fmt.Sprintf("%s = &v\n", dst)
+			contents += "}\n"
+		case b.names.IsPointerField(field) && !b.file.isStruct(field.Type):
+			contents += fmt.Sprintf("if %s != nil {\n", src)
+			contents += b.toProto(field.Type, dst, "*"+src)
+			contents += "}\n"
+		default:
+			contents += b.toProto(field.Type, dst, src)
+		}
+	}
+	contents += "return m\n"
+	contents += "}\n"
+
+	contents += fmt.Sprintf("\nfunc %s(m *%s) %s {\n", b.toFrugalFunc(typ), protoType, frugalType)
+	contents += "if m == nil {\n"
+	contents += "return nil\n"
+	contents += "}\n"
+	contents += fmt.Sprintf("p := %s()\n", constructor)
+	for _, field := range s.Fields {
+		src := "m." + goCamelCase(field.Name)
+		dst := "p." + b.names.FieldName(field)
+		pointer := b.names.IsPointerField(field) && !b.file.isStruct(field.Type)
+		if b.file.hasPresence(field) {
+			contents += fmt.Sprintf("if %s != nil {\n", src)
+			contents += fmt.Sprintf("v := %s\n", b.toFrugalExpr(field.Type, "*"+src))
+			if pointer {
+				contents += fmt.Sprintf("%s = &v\n", dst)
+			} else {
+				contents += fmt.Sprintf("%s = v\n", dst)
+			}
+			contents += "}\n"
+			continue
+		}
+		contents += b.toFrugal(field.Type, dst, src, pointer)
+	}
+	contents += "return p\n"
+	contents += "}\n"
+	return contents
+}
+
+// toProto returns the statements assigning the Frugal value src to the
+// proto value dst.
+func (b *bridge) toProto(t *parser.Type, dst, src string) string {
+	underlying := b.file.frugal.UnderlyingType(t)
+	contents := ""
+	switch underlying.Name {
+	case "list":
+		contents += fmt.Sprintf("if %s != nil {\n", src)
+		contents += fmt.Sprintf("%s = make([]%s, len(%s))\n", dst, b.file.protoGoType(underlying.ValueType), src)
+		contents += fmt.Sprintf("for i, e := range %s {\n", src)
+		contents += fmt.Sprintf("%s[i] = %s\n", dst, b.toProtoExpr(underlying.ValueType, "e"))
+		contents += "}\n"
+		contents += "}\n"
+	case "set":
+		contents += fmt.Sprintf("if %s != nil {\n", src)
+		contents += fmt.Sprintf("%s = make([]%s, 0, len(%s))\n", dst, b.file.protoGoType(underlying.ValueType), src)
+		contents += fmt.Sprintf("for e := range %s {\n", src)
+		contents += fmt.Sprintf("%s = append(%s, %s)\n", dst, dst, b.toProtoExpr(underlying.ValueType, "e"))
+		contents += "}\n"
+		contents += "}\n"
+	case "map":
+		contents += fmt.Sprintf("if %s != nil {\n", src)
+		contents += fmt.Sprintf("%s = make(map[%s]%s, len(%s))\n", dst,
+			b.file.protoGoType(underlying.KeyType), b.file.protoGoType(underlying.ValueType), src)
+		contents += fmt.Sprintf("for k, e := range %s {\n", src)
+		contents += fmt.Sprintf("%s[%s] = %s\n", dst,
+			b.toProtoExpr(underlying.KeyType, "k"), b.toProtoExpr(underlying.ValueType, "e"))
+		contents += "}\n"
+		contents += "}\n"
+	default:
+		contents += fmt.Sprintf("%s = %s\n", dst, b.toProtoExpr(t, src))
+	}
+	return contents
+}
+
+// toFrugal returns the statements assigning the proto value src to the
+// Frugal value dst, or to the value dst points to if pointer is true.
+func (b *bridge) toFrugal(t *parser.Type, dst, src string, pointer bool) string {
+	underlying := b.file.frugal.UnderlyingType(t)
+	assign := dst + " = v\n"
+	if pointer {
+		assign = dst + " = &v\n"
+	}
+	contents := ""
+	switch underlying.Name {
+	case "list":
+		contents += fmt.Sprintf("if %s != nil {\n", src)
+		contents += fmt.Sprintf("v := make(%s, len(%s))\n", b.names.Type(t), src)
+		contents += fmt.Sprintf("for i, e := range %s {\n", src)
+		contents += fmt.Sprintf("v[i] = %s\n", b.toFrugalExpr(underlying.ValueType, "e"))
+		contents += "}\n"
+		contents += assign
+		contents += "}\n"
+	case "set":
+		contents += fmt.Sprintf("if %s != nil {\n", src)
+		contents += fmt.Sprintf("v := make(%s, len(%s))\n", b.names.Type(t), src)
+		contents += fmt.Sprintf("for _, e := range %s {\n", src)
+		contents += fmt.Sprintf("v[%s] = true\n", b.toFrugalExpr(underlying.ValueType, "e"))
+		contents += "}\n"
+		contents += assign
+		contents += "}\n"
+	case "map":
+		contents += fmt.Sprintf("if %s != nil {\n", src)
+		contents += fmt.Sprintf("v := make(%s, len(%s))\n", b.names.Type(t), src)
+		contents += fmt.Sprintf("for k, e := range %s {\n", src)
+		contents += fmt.Sprintf("v[%s] = %s\n", b.toFrugalExpr(underlying.KeyType, "k"), b.toFrugalExpr(underlying.ValueType, "e"))
+		contents += "}\n"
+		contents += assign
+		contents += "}\n"
+	default:
+		if pointer {
+			contents += fmt.Sprintf("v := %s\n", b.toFrugalExpr(t, src))
+			contents += assign
+		} else {
+			contents += fmt.Sprintf("%s = %s\n", dst, b.toFrugalExpr(t, src))
+		}
+	}
+	return contents
+}
+
+// toProtoExpr returns the expression converting the Frugal value of a type
+// which isn't a container to its proto type.
+func (b *bridge) toProtoExpr(t *parser.Type, expr string) string {
+	if b.file.isStruct(t) {
+		return fmt.Sprintf("%s(%s)", b.toProtoFunc(t), expr)
+	}
+	protoType := b.file.protoGoType(t)
+	if protoType == b.names.Type(t) {
+		return expr
+	}
+	return fmt.Sprintf("%s(%s)", protoType, expr)
+}
+
+// toFrugalExpr returns the expression converting the proto value of a type
+// which isn't a container to its Frugal type.
+func (b *bridge) toFrugalExpr(t *parser.Type, expr string) string {
+	if b.file.isStruct(t) {
+		return fmt.Sprintf("%s(%s)", b.toFrugalFunc(t), expr)
+	}
+	frugalType := b.names.Type(t)
+	if frugalType == b.file.protoGoType(t) {
+		return expr
+	}
+	return fmt.Sprintf("%s(%s)", frugalType, expr)
+}
+
+func (b *bridge) toProtoFunc(t *parser.Type) string {
+	return lowerFirst(goCamelCase(b.file.frugal.UnderlyingType(t).Name)) + "ToProto"
+}
+
+func (b *bridge) toFrugalFunc(t *parser.Type) string {
+	return lowerFirst(goCamelCase(b.file.frugal.UnderlyingType(t).Name)) + "ToFrugal"
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/generator/golang"
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const (
+	defaultOutputDir = "gen-grpc"
+	packageSuffix    = "pb"
+
+	goPackagePrefixOption = "go_package_prefix"
+	frugalImportOption    = "frugal_import"
+)
+
+// Generator implements the ProgramGenerator interface for gRPC bridges. For
+// each Frugal file with services, it generates a proto3 definition of the
+// services and a Go bridge between them and the Frugal services, which is
+// compiled in the package protoc generates for the definition.
+type Generator struct {
+	options map[string]string
+}
+
+// NewGenerator creates a new gRPC bridge ProgramGenerator.
+func NewGenerator(options map[string]string) generator.ProgramGenerator {
+	return &Generator{options: options}
+}
+
+// Generate writes the proto definition and Go bridge of the Frugal file's
+// services to the output directory. Nothing is written for files without
+// services.
+func (g *Generator) Generate(frugal *parser.Frugal, outputDir string) error {
+	if len(frugal.Services) == 0 {
+		return nil
+	}
+	file, err := newProtoFile(frugal)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputDir, 0777); err != nil {
+		return err
+	}
+
+	proto := filepath.Join(outputDir, frugal.Name+".proto")
+	if err := ioutil.WriteFile(proto, []byte(g.generateProto(file)), 0644); err != nil {
+		return err
+	}
+
+	bridge, err := g.generateBridge(file)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(outputDir, generator.FilePrefix+frugal.Name+"_grpc_bridge.go"), bridge, 0644)
+}
+
+// GetOutputDir returns the output directory for generated files, which is
+// the directory of the Frugal file's Go package suffixed with "pb".
+func (g *Generator) GetOutputDir(dir string, frugal *parser.Frugal) string {
+	return filepath.Join(dir, filepath.FromSlash(packagePath(frugal)))
+}
+
+// DefaultOutputDir returns the default output directory for generated files.
+func (g *Generator) DefaultOutputDir() string {
+	return defaultOutputDir
+}
+
+// UseVendor returns false since includes aren't supported.
+func (g *Generator) UseVendor() bool {
+	return false
+}
+
+// packagePath returns the path of the generated package relative to the
+// output directory.
+func packagePath(frugal *parser.Frugal) string {
+	name := frugal.Name
+	if namespace := frugal.Namespace("go"); namespace != nil {
+		name = namespace.Value
+	}
+	return strings.Replace(name, ".", "/", -1) + packageSuffix
+}
+
+// generateProto returns the proto3 definition of the services.
+func (g *Generator) generateProto(f *protoFile) string {
+	contents := fmt.Sprintf("// Autogenerated by Frugal Compiler (%s)\n", globals.Version)
+	contents += "// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING\n\n"
+	contents += "syntax = \"proto3\";\n\n"
+	contents += fmt.Sprintf("package %s;\n\n", f.frugal.Name)
+	contents += fmt.Sprintf("option go_package = \"%s%s\";\n", g.options[goPackagePrefixOption], packagePath(f.frugal))
+
+	for _, service := range f.services {
+		contents += "\n" + comment(service.service.DocComment(), "")
+		contents += fmt.Sprintf("service %s {\n", service.service.Name)
+		for _, m := range service.methods {
+			contents += comment(m.method.Comment, "  ")
+			contents += fmt.Sprintf("  rpc %s(%s) returns (%s);\n", m.method.Name, m.request(), m.response())
+		}
+		contents += "}\n"
+	}
+
+	for _, m := range f.methods {
+		contents += fmt.Sprintf("\nmessage %s {\n", m.request())
+		for _, arg := range m.method.Arguments {
+			contents += f.generateField(arg)
+		}
+		contents += "}\n"
+		contents += fmt.Sprintf("\nmessage %s {\n", m.response())
+		if m.method.ReturnType != nil {
+			contents += f.generateField(&parser.Field{ID: 1, Name: "success", Type: m.method.ReturnType})
+		}
+		contents += "}\n"
+	}
+
+	for _, enum := range f.enums {
+		contents += "\n" + comment(enum.Comment, "")
+		contents += fmt.Sprintf("enum %s {\n", enum.Name)
+		hasZero := false
+		for _, value := range enum.Values {
+			hasZero = hasZero || value.Value == 0
+		}
+		// proto3 enums must start with a zero value.
+		if !hasZero {
+			contents += fmt.Sprintf("  %s = 0;\n", enumValueName(enum, "UNSPECIFIED"))
+		}
+		for _, value := range enum.Values {
+			contents += comment(value.Comment, "  ")
+			contents += fmt.Sprintf("  %s = %d;\n", enumValueName(enum, value.Name), value.Value)
+		}
+		contents += "}\n"
+	}
+
+	for _, s := range f.structs {
+		contents += "\n" + comment(s.Comment, "")
+		contents += fmt.Sprintf("message %s {\n", s.Name)
+		for _, field := range s.Fields {
+			contents += comment(field.Comment, "  ")
+			contents += f.generateField(field)
+		}
+		contents += "}\n"
+	}
+	return contents
+}
+
+func (f *protoFile) generateField(field *parser.Field) string {
+	label := ""
+	switch {
+	case f.hasPresence(field):
+		label = "optional "
+	case f.frugal.UnderlyingType(field.Type).Name == "list", f.frugal.UnderlyingType(field.Type).Name == "set":
+		label = "repeated "
+	}
+	return fmt.Sprintf("  %s%s %s = %d;\n", label, f.protoType(field.Type), field.Name, field.ID)
+}
+
+func comment(lines []string, indent string) string {
+	contents := ""
+	for _, line := range lines {
+		contents += strings.TrimRight(indent+"// "+line, " ") + "\n"
+	}
+	return contents
+}
+
+// generateBridge returns the formatted Go bridge of the services.
+func (g *Generator) generateBridge(f *protoFile) ([]byte, error) {
+	names := golang.NewTypeNames(f.frugal, g.options)
+	b := &bridge{file: f, names: names}
+	frugalImport := g.options[frugalImportOption]
+	if frugalImport == "" {
+		frugalImport = "github.com/Workiva/frugal/lib/go"
+	}
+	pkg := packagePath(f.frugal)
+	contents := b.generate(pkg[strings.LastIndex(pkg, "/")+1:], frugalImport)
+	formatted, err := format.Source([]byte(contents))
+	if err != nil {
+		return nil, fmt.Errorf("grpc: error formatting bridge for %s: %s", f.frugal.Name, err)
+	}
+	return formatted, nil
+}
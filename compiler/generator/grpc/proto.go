@@ -0,0 +1,327 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// Proto field numbers must be positive, at most maxFieldNumber, and not in
+// the range reserved by the protobuf implementation.
+const (
+	maxFieldNumber      = 536870911
+	firstReservedNumber = 19000
+	lastReservedNumber  = 19999
+)
+
+// protoFile is the proto3 definition of the services of a Frugal file and
+// the types they use.
+type protoFile struct {
+	frugal   *parser.Frugal
+	services []*protoService
+	enums    []*parser.Enum
+	structs  []*parser.Struct
+	methods  []*protoMethod
+	seen     map[string]bool
+}
+
+// protoService is a service with its methods, including those it inherits.
+type protoService struct {
+	service *parser.Service
+	methods []*protoMethod
+}
+
+// protoMethod is a method of the service declaring it, which names its
+// request and response messages.
+type protoMethod struct {
+	service *parser.Service
+	method  *parser.Method
+}
+
+func (m *protoMethod) request() string {
+	return goCamelCase(m.service.Name) + goCamelCase(m.method.Name) + "Request"
+}
+
+func (m *protoMethod) response() string {
+	return goCamelCase(m.service.Name) + goCamelCase(m.method.Name) + "Response"
+}
+
+// newProtoFile returns the proto definition of the Frugal file's services,
+// or an error if they use anything proto3 can't express.
+func newProtoFile(frugal *parser.Frugal) (*protoFile, error) {
+	f := &protoFile{frugal: frugal, seen: make(map[string]bool)}
+	declared := make(map[*parser.Method]*protoMethod)
+	for _, service := range frugal.Services {
+		for _, method := range service.Methods {
+			m := &protoMethod{service: service, method: method}
+			declared[method] = m
+			f.methods = append(f.methods, m)
+			for _, arg := range method.Arguments {
+				if err := f.addField(fmt.Sprintf("%s.%s", service.Name, method.Name), arg); err != nil {
+					return nil, err
+				}
+			}
+			if method.ReturnType != nil {
+				if err := f.addType(method.ReturnType); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for _, service := range frugal.Services {
+		methods, err := f.serviceMethods(service)
+		if err != nil {
+			return nil, err
+		}
+		s := &protoService{service: service}
+		for _, method := range methods {
+			s.methods = append(s.methods, declared[method])
+		}
+		f.services = append(f.services, s)
+	}
+
+	for _, enum := range frugal.Enums {
+		if f.seen[enum.Name] {
+			f.enums = append(f.enums, enum)
+		}
+	}
+	for _, s := range frugal.DataStructures() {
+		if f.seen[s.Name] {
+			f.structs = append(f.structs, s)
+		}
+	}
+	return f, nil
+}
+
+// serviceMethods returns the methods of the service, starting with those it
+// inherits. Services can only extend services in the same file.
+func (f *protoFile) serviceMethods(service *parser.Service) ([]*parser.Method, error) {
+	if service.Extends == "" {
+		return service.Methods, nil
+	}
+	if service.ExtendsInclude() != "" {
+		return nil, fmt.Errorf("grpc: service %s extends %s from an include, which isn't supported",
+			service.Name, service.Extends)
+	}
+	for _, base := range f.frugal.Services {
+		if base.Name == service.ExtendsService() {
+			methods, err := f.serviceMethods(base)
+			if err != nil {
+				return nil, err
+			}
+			return append(append([]*parser.Method{}, methods...), service.Methods...), nil
+		}
+	}
+	return nil, fmt.Errorf("grpc: service %s extends unknown service %s", service.Name, service.Extends)
+}
+
+func (f *protoFile) addField(parent string, field *parser.Field) error {
+	if field.ID < 1 || field.ID > maxFieldNumber || (field.ID >= firstReservedNumber && field.ID <= lastReservedNumber) {
+		return fmt.Errorf("grpc: field %s.%s has ID %d, which isn't a valid proto field number",
+			parent, field.Name, field.ID)
+	}
+	return f.addType(field.Type)
+}
+
+// addType adds the enums and structs the type uses to the file.
+func (f *protoFile) addType(t *parser.Type) error {
+	if t.IncludeName() != "" {
+		return fmt.Errorf("grpc: type %s is from an include, which isn't supported", t.Name)
+	}
+	underlying := f.frugal.UnderlyingType(t)
+	if underlying.IncludeName() != "" {
+		return fmt.Errorf("grpc: type %s is from an include, which isn't supported", underlying.Name)
+	}
+
+	switch underlying.Name {
+	case "bool", "byte", "i8", "i16", "i32", "i64", "double", "string", "binary":
+		return nil
+	case "list", "set":
+		if f.frugal.UnderlyingType(underlying.ValueType).IsContainer() {
+			return fmt.Errorf("grpc: type %s contains a container, which isn't supported", t)
+		}
+		return f.addType(underlying.ValueType)
+	case "map":
+		switch f.frugal.UnderlyingType(underlying.KeyType).Name {
+		case "bool", "byte", "i8", "i16", "i32", "i64", "string":
+		default:
+			return fmt.Errorf("grpc: type %s has a key type which can't be a proto map key", t)
+		}
+		if f.frugal.UnderlyingType(underlying.ValueType).IsContainer() {
+			return fmt.Errorf("grpc: type %s contains a container, which isn't supported", t)
+		}
+		if err := f.addType(underlying.KeyType); err != nil {
+			return err
+		}
+		return f.addType(underlying.ValueType)
+	}
+
+	if f.frugal.IsEnum(underlying) {
+		f.seen[underlying.Name] = true
+		return nil
+	}
+	if underlying != t {
+		return fmt.Errorf("grpc: typedef %s of struct %s isn't supported", t.Name, underlying.Name)
+	}
+	if f.seen[underlying.Name] {
+		return nil
+	}
+	for _, s := range f.frugal.DataStructures() {
+		if s.Name == underlying.Name {
+			f.seen[s.Name] = true
+			for _, field := range s.Fields {
+				if err := f.addField(s.Name, field); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("grpc: unknown type %s", t)
+}
+
+// protoType returns the proto type of the Frugal type, without the repeated
+// label of lists and sets.
+func (f *protoFile) protoType(t *parser.Type) string {
+	underlying := f.frugal.UnderlyingType(t)
+	switch underlying.Name {
+	case "bool", "string", "double":
+		return underlying.Name
+	case "byte", "i8", "i16", "i32":
+		return "int32"
+	case "i64":
+		return "int64"
+	case "binary":
+		return "bytes"
+	case "list", "set":
+		return f.protoType(underlying.ValueType)
+	case "map":
+		return fmt.Sprintf("map<%s, %s>", f.protoType(underlying.KeyType), f.protoType(underlying.ValueType))
+	default:
+		return underlying.Name
+	}
+}
+
+// protoGoType returns the Go type protoc-gen-go generates for the Frugal
+// type if it isn't a container.
+func (f *protoFile) protoGoType(t *parser.Type) string {
+	underlying := f.frugal.UnderlyingType(t)
+	switch underlying.Name {
+	case "bool", "string":
+		return underlying.Name
+	case "byte", "i8", "i16", "i32":
+		return "int32"
+	case "i64":
+		return "int64"
+	case "double":
+		return "float64"
+	case "binary":
+		return "[]byte"
+	}
+	if f.frugal.IsEnum(underlying) {
+		return goCamelCase(underlying.Name)
+	}
+	return "*" + goCamelCase(underlying.Name)
+}
+
+// hasPresence indicates if the field is generated with the proto3 optional
+// label, which proto3 requires to tell an unset scalar field from its zero
+// value.
+func (f *protoFile) hasPresence(field *parser.Field) bool {
+	if field.Modifier != parser.Optional {
+		return false
+	}
+	underlying := f.frugal.UnderlyingType(field.Type)
+	if underlying.IsContainer() || underlying.Name == "binary" {
+		return false
+	}
+	return underlying.IsPrimitive() || f.frugal.IsEnum(underlying)
+}
+
+func (f *protoFile) isStruct(t *parser.Type) bool {
+	underlying := f.frugal.UnderlyingType(t)
+	return !underlying.IsPrimitive() && !underlying.IsContainer() && !f.frugal.IsEnum(underlying)
+}
+
+// enumValueName returns the name of the enum value, prefixed with the enum
+// name since enum values share the scope of their enum in proto.
+func enumValueName(enum *parser.Enum, value string) string {
+	return screamingSnakeCase(enum.Name) + "_" + screamingSnakeCase(value)
+}
+
+// goCamelCase returns the Go name protoc-gen-go generates for a proto name.
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// Skip the dot and capitalize the next letter.
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// Skip the underscore and capitalize the next letter.
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// screamingSnakeCase converts a camel or snake case name to upper snake case,
+// e.g. "HealthCondition" to "HEALTH_CONDITION".
+func screamingSnakeCase(s string) string {
+	runes := []rune(s)
+	var b []rune
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && runes[i-1] != '_' {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b = append(b, '_')
+			}
+		}
+		b = append(b, unicode.ToUpper(r))
+	}
+	return string(b)
+}
+
+// lowerFirst lowercases the first letter of a Go name for unexported
+// identifiers.
+func lowerFirst(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
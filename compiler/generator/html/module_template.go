@@ -197,9 +197,9 @@ const moduleTemplate = `
 				<em>extends</em> <code>{{ $service.Extends | displayService }}</code>
 			</div>
 			{{ end }}
-			{{ if $service.Comment }}
+			{{ if $service.DocComment }}
 			<blockquote>
-				{{ range $service.Comment }}
+				{{ range $service.DocComment }}
 				{{ . }}<br />
 				{{ end }}
 			</blockquote>
@@ -229,9 +229,9 @@ const moduleTemplate = `
 				<em>prefix</em> <code>{{ $scope.Prefix.String }}</code>
 			</div>
 			{{ end }}
-			{{ if $scope.Comment }}
+			{{ if $scope.DocComment }}
 			<blockquote>
-				{{ range $scope.Comment }}
+				{{ range $scope.DocComment }}
 				{{ . }}<br />
 				{{ end }}
 			</blockquote>
@@ -240,9 +240,9 @@ const moduleTemplate = `
 			<div class="definition">
 				<h4 id="fn_{{ $scope.Name }}_{{ .Name }}">Operation: {{ $scope.Name }}.{{ .Name }}</h4>
 				<pre><code>[publish|subscribe]{{ .Name }}: {{ .Type | displayType }}</code></pre>
-				{{ if .Comment }}
+				{{ if .DocComment }}
 				<blockquote>
-					{{ range .Comment }}
+					{{ range .DocComment }}
 					{{ . }}<br />
 					{{ end }}
 				</blockquote>
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// useBatchPublish indicates if publishers should have methods publishing a
+// batch of messages in a single frame, and subscribers should receive them.
+func (g *Generator) useBatchPublish() bool {
+	_, ok := g.Options[generator.BatchPublishOption]
+	return ok
+}
+
+// wrapBatchFrameCallback wraps the given FAsyncCallback so it splits batch
+// frames into their messages with the batch_publish option.
+func (g *Generator) wrapBatchFrameCallback(callback string) string {
+	if g.useBatchPublish() {
+		return fmt.Sprintf("FBatchFrame.callback(%s)", callback)
+	}
+	return callback
+}
+
+// generatePublishBatchSignature generates the signature of the method
+// publishing a list of messages to the operation.
+func (g *Generator) generatePublishBatchSignature(scope *parser.Scope, op *parser.Operation) string {
+	return fmt.Sprintf("public void publish%sBatch(FContext ctx, %sList<%s> reqs) throws TException",
+		op.Name, g.generateScopePrefixArgs(scope), containerType(g.getJavaTypeFromThriftType(op.Type)))
+}
+
+// generatePublishBatchDocComment generates the doc comment of the method
+// publishing a list of messages to the operation.
+func (g *Generator) generatePublishBatchDocComment(indent string) string {
+	return g.GenerateBlockComment([]string{
+		"Publishes the messages in a single frame, sent with one transport flush.",
+		"Subscribers must be generated with the batch_publish option to receive",
+		"them.",
+	}, indent)
+}
+
+// generatePublishBatchMethod generates the internal publisher's method
+// publishing a list of messages to the operation in a single frame.
+func (g *Generator) generatePublishBatchMethod(scope *parser.Scope, op *parser.Operation, indent string) string {
+	contents := indent + g.generatePublishBatchSignature(scope, op) + " {\n"
+	contents += g.generatePrefixVariableChecks(scope, indent+tab)
+	for _, prefixVar := range scope.Prefix.Variables {
+		contents += indent + tab + fmt.Sprintf("ctx.addRequestHeader(\"_topic_%s\", %s);\n", prefixVar, prefixVar)
+	}
+	contents += indent + tab + fmt.Sprintf("String op = \"%s\";\n", op.Name)
+	contents += indent + tab + fmt.Sprintf("String prefix = %s;\n", generatePrefixStringTemplate(scope))
+	contents += indent + tab + "String topic = String.format(\"%s" + scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter) + "%s%s\", prefix, DELIMITER, op);\n"
+	contents += indent + tab + "List<byte[]> frames = new ArrayList<>(reqs.size());\n"
+	contents += indent + tab + fmt.Sprintf("for (%s req : reqs) {\n", containerType(g.getJavaTypeFromThriftType(op.Type)))
+	contents += indent + tabtab + "TMemoryOutputBuffer memoryBuffer = new TMemoryOutputBuffer(transport.getPublishSizeLimit());\n"
+	contents += indent + tabtab + "FProtocol oprot = protocolFactory.getProtocol(memoryBuffer);\n"
+	contents += indent + tabtab + "oprot.writeRequestHeader(ctx);\n"
+	contents += indent + tabtab + "oprot.writeMessageBegin(new TMessage(op, TMessageType.CALL, 0));\n"
+	contents += g.generateWriteFieldRec(parser.FieldFromType(op.Type, "req"), false, false, indent+tabtab)
+	contents += indent + tabtab + "oprot.writeMessageEnd();\n"
+	contents += indent + tabtab + "frames.add(memoryBuffer.getWriteBytes());\n"
+	contents += indent + tab + "}\n"
+	contents += indent + tab + "FBatchFrame.publish(transport, topic, frames);\n"
+	contents += indent + "}\n"
+	return contents
+}
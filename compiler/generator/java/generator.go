@@ -45,8 +45,9 @@ const (
 
 type Generator struct {
 	*generator.BaseGenerator
-	time      time.Time
-	outputDir string
+	time       time.Time
+	outputDir  string
+	pomWritten bool
 }
 
 func NewGenerator(options map[string]string) generator.LanguageGenerator {
@@ -54,6 +55,7 @@ func NewGenerator(options map[string]string) generator.LanguageGenerator {
 		&generator.BaseGenerator{Options: options},
 		globals.Now,
 		"",
+		false,
 	}
 }
 
@@ -92,11 +94,34 @@ func (g *Generator) getIsSetType(s *parser.Struct) (IsSetType, string) {
 }
 
 func (g *Generator) SetupGenerator(outputDir string) error {
+	if err := g.validateMaven(); err != nil {
+		return err
+	}
+	strategy, err := g.UnknownEnums()
+	if err != nil {
+		return err
+	}
+	if strategy == generator.UnknownEnumsPreserve {
+		return fmt.Errorf("%s=%s is not supported for java, whose enums can't hold undeclared values",
+			generator.UnknownEnumsOption, strategy)
+	}
+	if _, err := g.PrefixValidation(); err != nil {
+		return err
+	}
+	if err := g.CheckScopeProtocols("java"); err != nil {
+		return err
+	}
+	if err := g.CheckScopeReplies("java"); err != nil {
+		return err
+	}
 	g.outputDir = outputDir
 	return nil
 }
 
 func (g *Generator) TeardownGenerator() error {
+	if globals.TestVectors {
+		return g.generateTestVectorsTest()
+	}
 	return nil
 }
 
@@ -523,6 +548,9 @@ func (g *Generator) generateUnion(union *parser.Struct, isArg, isResult bool) st
 	contents += g.generateUnionEquals(union, tab)
 	contents += g.generateUnionCompareTo(union, tab)
 	contents += g.generateUnionHashCode(union, tab)
+	if !isArg && !isResult {
+		contents += g.generateJSON(union, tab)
+	}
 	contents += g.generateWriteObject(union, tab)
 	contents += g.generateReadObject(union, tab)
 
@@ -877,6 +905,9 @@ func (g *Generator) generateStruct(s *parser.Struct, isArg, isResult bool, inden
 	contents += g.generateFullConstructor(s, nestedIndent)
 	contents += g.generateCopyConstructor(s, nestedIndent)
 	contents += g.generateDeepCopyMethod(s, nestedIndent)
+	if !isArg && !isResult {
+		contents += g.generateMergeFrom(s, nestedIndent)
+	}
 	contents += g.generateClear(s, nestedIndent)
 
 	for _, field := range s.Fields {
@@ -907,6 +938,10 @@ func (g *Generator) generateStruct(s *parser.Struct, isArg, isResult bool, inden
 
 	contents += g.generateToString(s, nestedIndent)
 	contents += g.generateValidate(s, nestedIndent)
+	if !isArg && !isResult {
+		contents += g.generateBuilder(s, nestedIndent)
+		contents += g.generateJSON(s, nestedIndent)
+	}
 
 	contents += g.generateWriteObject(s, nestedIndent)
 	contents += g.generateReadObject(s, nestedIndent)
@@ -918,6 +953,41 @@ func (g *Generator) generateStruct(s *parser.Struct, isArg, isResult bool, inden
 	return contents
 }
 
+// generateBuilder generates a static builder method and fluent Builder class
+// for the struct when the builders option is set. Build returns a deep copy so
+// the builder can be reused.
+func (g *Generator) generateBuilder(s *parser.Struct, indent string) string {
+	if !g.generateBuilders() {
+		return ""
+	}
+
+	contents := ""
+	contents += g.GenerateBlockComment([]string{fmt.Sprintf("Returns a new Builder for %s.", s.Name)}, indent)
+	contents += indent + "public static Builder builder() {\n"
+	contents += indent + tab + "return new Builder();\n"
+	contents += indent + "}\n\n"
+
+	contents += g.GenerateBlockComment([]string{fmt.Sprintf("Builds %s values field by field.", s.Name)}, indent)
+	contents += indent + "public static class Builder {\n"
+	contents += indent + tab + fmt.Sprintf("private final %s struct = new %s();\n\n", s.Name, s.Name)
+	for _, field := range s.Fields {
+		if field.Annotations.IsDeprecated() {
+			contents += indent + tab + "@Deprecated\n"
+		}
+		contents += indent + tab + fmt.Sprintf("public Builder with%s(%s %s) {\n",
+			strings.Title(field.Name), g.getJavaTypeFromThriftType(field.Type), field.Name)
+		contents += indent + tabtab + fmt.Sprintf("struct.set%s(%s);\n", strings.Title(field.Name), field.Name)
+		contents += indent + tabtab + "return this;\n"
+		contents += indent + tab + "}\n\n"
+	}
+	contents += g.GenerateBlockComment([]string{fmt.Sprintf("Returns a copy of the %s built so far.", s.Name)}, indent+tab)
+	contents += indent + tab + fmt.Sprintf("public %s build() {\n", s.Name)
+	contents += indent + tabtab + fmt.Sprintf("return new %s(struct);\n", s.Name)
+	contents += indent + tab + "}\n"
+	contents += indent + "}\n\n"
+	return contents
+}
+
 func (g *Generator) generateDescriptors(s *parser.Struct, indent string) string {
 	contents := ""
 	contents += indent + fmt.Sprintf("private static final org.apache.thrift.protocol.TStruct STRUCT_DESC = new org.apache.thrift.protocol.TStruct(\"%s\");\n\n",
@@ -1148,6 +1218,30 @@ func (g *Generator) generateDeepCopyMethod(s *parser.Struct, indent string) stri
 	return contents
 }
 
+// generateMergeFrom generates a mergeFrom method for structs and exceptions
+// when the copy_merge option is set, which sets each field set in another
+// instance to a deep copy of it.
+func (g *Generator) generateMergeFrom(s *parser.Struct, indent string) string {
+	if !g.generateCopyMerge() || s.Type == parser.StructTypeUnion {
+		return ""
+	}
+
+	contents := ""
+	contents += g.GenerateBlockComment([]string{"Sets each field set in <i>other</i> to a deep copy of it."}, indent)
+	contents += indent + fmt.Sprintf("public void mergeFrom(%s other) {\n", s.Name)
+	for _, field := range s.Fields {
+		fName := strings.Title(field.Name)
+		contents += indent + tab + fmt.Sprintf("if (other.isSet%s()) {\n", fName)
+		contents += g.generateCopyConstructorField(field, "other."+field.Name, true, indent+tabtab)
+		if g.isJavaPrimitive(field.Type) {
+			contents += indent + tabtab + fmt.Sprintf("set%sIsSet(true);\n", fName)
+		}
+		contents += indent + tab + "}\n"
+	}
+	contents += indent + "}\n\n"
+	return contents
+}
+
 func (g *Generator) generateClear(s *parser.Struct, indent string) string {
 	contents := ""
 	contents += indent + "@Override\n"
@@ -1628,6 +1722,12 @@ func (g *Generator) generateToString(s *parser.Struct, indent string) string {
 
 func (g *Generator) generateValidate(s *parser.Struct, indent string) string {
 	contents := ""
+	for _, field := range s.Fields {
+		if constraints := field.Constraints(); constraints != nil && constraints.Pattern != "" {
+			contents += indent + fmt.Sprintf("private static final java.util.regex.Pattern %s_PATTERN = java.util.regex.Pattern.compile(%s);\n\n",
+				toConstantName(field.Name), g.quote(constraints.Pattern))
+		}
+	}
 	contents += indent + "public void validate() throws org.apache.thrift.TException {\n"
 	contents += indent + tab + "// check for required fields\n"
 	for _, field := range s.Fields {
@@ -1639,6 +1739,13 @@ func (g *Generator) generateValidate(s *parser.Struct, indent string) string {
 		}
 	}
 
+	if s.HasConstraints() {
+		contents += indent + tab + "// check field constraints\n"
+		for _, field := range s.Fields {
+			contents += g.generateConstraintChecks(s, field, indent+tab)
+		}
+	}
+
 	contents += indent + tab + "// check for sub-struct validity\n"
 	for _, field := range s.Fields {
 		if g.Frugal.IsStruct(field.Type) && !g.Frugal.IsUnion(field.Type) {
@@ -1979,6 +2086,16 @@ func (g *Generator) generateReadFieldRec(field *parser.Field, first bool, succin
 		contents += indent + fmt.Sprintf("%s%s = iprot.read%s();\n", declPrefix, field.Name, thriftType)
 	} else if g.Frugal.IsEnum(underlyingType) {
 		contents += indent + fmt.Sprintf("%s%s = %s.findByValue(iprot.readI32());\n", declPrefix, field.Name, javaType)
+		switch g.Options[generator.UnknownEnumsOption] {
+		case generator.UnknownEnumsError:
+			contents += indent + fmt.Sprintf("if (%s%s == null) {\n", accessPrefix, field.Name)
+			contents += indent + tab + fmt.Sprintf("throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, \"Invalid value for enum %s\");\n", underlyingType.Name)
+			contents += indent + "}\n"
+		case generator.UnknownEnumsSentinel:
+			contents += indent + fmt.Sprintf("if (%s%s == null) {\n", accessPrefix, field.Name)
+			contents += indent + tab + fmt.Sprintf("%s%s = %s.%s;\n", accessPrefix, field.Name, javaType, generator.UnknownEnumValue)
+			contents += indent + "}\n"
+		}
 	} else if g.Frugal.IsStruct(underlyingType) {
 		contents += indent + fmt.Sprintf("%s%s = new %s();\n", declPrefix, field.Name, javaType)
 		contents += indent + fmt.Sprintf("%s%s.read(iprot);\n", accessPrefix, field.Name)
@@ -2180,8 +2297,9 @@ func (g *Generator) DefaultOutputDir() string {
 
 func (g *Generator) PostProcess(f *os.File) error { return nil }
 
+// GenerateDependencies generates a pom.xml if the maven option is set.
 func (g *Generator) GenerateDependencies(dir string) error {
-	return nil
+	return g.generatePom(dir)
 }
 
 func (g *Generator) GenerateFile(name, outputDir string, fileType generator.FileType) (*os.File, error) {
@@ -2285,6 +2403,7 @@ func (g *Generator) GenerateServiceImports(file *os.File, s *parser.Service) err
 	imports += g.generateStructImports()
 
 	imports += "import com.workiva.frugal.FContext;\n"
+	imports += "import com.workiva.frugal.FContractMetadata;\n"
 	imports += "import com.workiva.frugal.exception.TApplicationExceptionType;\n"
 	imports += "import com.workiva.frugal.exception.TTransportExceptionType;\n"
 	imports += "import com.workiva.frugal.middleware.InvocationHandler;\n"
@@ -2313,11 +2432,15 @@ func (g *Generator) GenerateServiceImports(file *os.File, s *parser.Service) err
 
 func (g *Generator) GenerateScopeImports(file *os.File, s *parser.Scope) error {
 	imports := "import com.workiva.frugal.FContext;\n"
+	imports += "import com.workiva.frugal.FContractMetadata;\n"
 	imports += "import com.workiva.frugal.exception.TApplicationExceptionType;\n"
 	imports += "import com.workiva.frugal.middleware.InvocationHandler;\n"
 	imports += "import com.workiva.frugal.middleware.ServiceMiddleware;\n"
 	imports += "import com.workiva.frugal.protocol.*;\n"
 	imports += "import com.workiva.frugal.provider.FScopeProvider;\n"
+	if g.useBatchPublish() {
+		imports += "import com.workiva.frugal.transport.FBatchFrame;\n"
+	}
 	imports += "import com.workiva.frugal.transport.FPublisherTransport;\n"
 	imports += "import com.workiva.frugal.transport.FSubscriberTransport;\n"
 	imports += "import com.workiva.frugal.transport.FSubscription;\n"
@@ -2353,6 +2476,9 @@ func (g *Generator) GenerateConstants(file *os.File, name string) error {
 }
 
 func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error {
+	g.WarnUnsupportedChunks("java", scope)
+	g.WarnUnsupportedSampling("java", scope)
+
 	scopeTitle := strings.Title(scope.Name)
 	contents := ""
 
@@ -2361,6 +2487,7 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 	}
 
 	contents += fmt.Sprintf("public class %sPublisher {\n\n", scopeTitle)
+	contents += g.generateContractMetadata(g.ScopeMetadata(scope), tab)
 
 	contents += g.generatePublisherIface(scope, tab)
 	contents += g.generatePublisherClient(scope, tab)
@@ -2371,11 +2498,41 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 	return err
 }
 
+// generateContractMetadata generates the FContractMetadata constant of a scope
+// or service.
+func (g *Generator) generateContractMetadata(metadata *generator.ContractMetadata, indent string) string {
+	operations := make([]string, len(metadata.Operations))
+	for i, operation := range metadata.Operations {
+		operations[i] = strconv.Quote(operation)
+	}
+
+	contents := indent + "/**\n"
+	contents += indent + fmt.Sprintf(" * Describes the %s %s contract.\n", metadata.Name, metadata.Kind)
+	contents += indent + " */\n"
+	contents += indent + "public static final FContractMetadata METADATA = new FContractMetadata(\n"
+	contents += indent + tabtab + fmt.Sprintf("%s, %s, %s,\n",
+		strconv.Quote(metadata.IDLFile), strconv.Quote(metadata.Kind), strconv.Quote(metadata.Name))
+	contents += indent + tabtab + fmt.Sprintf("%s, %s,\n", strconv.Quote(metadata.Hash), strconv.Quote(metadata.CompilerVersion))
+	if len(metadata.Descriptions) == 0 {
+		contents += indent + tabtab + fmt.Sprintf("Arrays.asList(%s));\n\n", strings.Join(operations, ", "))
+		return contents
+	}
+	contents += indent + tabtab + fmt.Sprintf("Arrays.asList(%s),\n", strings.Join(operations, ", "))
+	descriptions := []string{}
+	for _, operation := range metadata.Operations {
+		if description, ok := metadata.Descriptions[operation]; ok {
+			descriptions = append(descriptions, fmt.Sprintf("%s, %s", strconv.Quote(operation), g.quote(description)))
+		}
+	}
+	contents += indent + tabtab + fmt.Sprintf("FContractMetadata.descriptions(%s));\n\n", strings.Join(descriptions, ", "))
+	return contents
+}
+
 func (g *Generator) generatePublisherIface(scope *parser.Scope, indent string) string {
 	contents := ""
 
-	if scope.Comment != nil {
-		contents += g.GenerateBlockComment(scope.Comment, indent)
+	if comment := scope.DocComment(); comment != nil {
+		contents += g.GenerateBlockComment(comment, indent)
 	}
 	contents += indent + "public interface Iface {\n"
 
@@ -2385,10 +2542,14 @@ func (g *Generator) generatePublisherIface(scope *parser.Scope, indent string) s
 	args := g.generateScopePrefixArgs(scope)
 
 	for _, op := range scope.Operations {
-		if op.Comment != nil {
-			contents += g.GenerateBlockComment(op.Comment, indent+tab)
+		if comment := op.DocComment(); comment != nil {
+			contents += g.GenerateBlockComment(comment, indent+tab)
 		}
 		contents += indent + tab + fmt.Sprintf("public void publish%s(FContext ctx, %s%s req) throws TException;\n\n", op.Name, args, g.getJavaTypeFromThriftType(op.Type))
+		if g.useBatchPublish() {
+			contents += g.generatePublishBatchDocComment(indent + tab)
+			contents += indent + tab + g.generatePublishBatchSignature(scope, op) + ";\n\n"
+		}
 	}
 
 	contents += indent + "}\n\n"
@@ -2400,11 +2561,12 @@ func (g *Generator) generatePublisherClient(scope *parser.Scope, indent string)
 
 	scopeTitle := strings.Title(scope.Name)
 
-	if scope.Comment != nil {
-		contents += g.GenerateBlockComment(scope.Comment, indent)
+	if comment := scope.DocComment(); comment != nil {
+		contents += g.GenerateBlockComment(comment, indent)
 	}
 	contents += indent + "public static class Client implements Iface {\n"
 	contents += indent + tab + fmt.Sprintf("private static final String DELIMITER = \"%s\";\n\n", globals.TopicDelimiter)
+	contents += g.generatePrefixVariableMethod(scope, indent+tab)
 	contents += indent + tab + "private final Iface target;\n"
 	contents += indent + tab + "private final Iface proxy;\n\n"
 
@@ -2427,12 +2589,18 @@ func (g *Generator) generatePublisherClient(scope *parser.Scope, indent string)
 	args := g.generateScopePrefixArgs(scope)
 
 	for _, op := range scope.Operations {
-		if op.Comment != nil {
-			contents += g.GenerateBlockComment(op.Comment, indent+tab)
+		if comment := op.DocComment(); comment != nil {
+			contents += g.GenerateBlockComment(comment, indent+tab)
 		}
 		contents += indent + tab + fmt.Sprintf("public void publish%s(FContext ctx, %s%s req) throws TException {\n", op.Name, args, g.getJavaTypeFromThriftType(op.Type))
 		contents += indent + tabtab + fmt.Sprintf("proxy.publish%s(%s);\n", op.Name, g.generateScopeArgs(scope))
 		contents += indent + tab + "}\n\n"
+		if g.useBatchPublish() {
+			contents += g.generatePublishBatchDocComment(indent + tab)
+			contents += indent + tab + g.generatePublishBatchSignature(scope, op) + " {\n"
+			contents += indent + tabtab + fmt.Sprintf("proxy.publish%sBatch(%ss);\n", op.Name, g.generateScopeArgs(scope))
+			contents += indent + tab + "}\n\n"
+		}
 	}
 
 	contents += indent + tab + fmt.Sprintf("protected static class Internal%sPublisher implements Iface {\n\n", scopeTitle)
@@ -2464,12 +2632,14 @@ func (g *Generator) generatePublisherClient(scope *parser.Scope, indent string)
 	for _, op := range scope.Operations {
 		contents += prefix
 		prefix = "\n\n"
-		if op.Comment != nil {
-			contents += g.GenerateBlockComment(op.Comment, indent+tabtab)
+		if comment := op.DocComment(); comment != nil {
+			contents += g.GenerateBlockComment(comment, indent+tabtab)
 		}
 
 		contents += indent + tabtab + fmt.Sprintf("public void publish%s(FContext ctx, %s%s req) throws TException {\n", op.Name, args, g.getJavaTypeFromThriftType(op.Type))
 
+		contents += g.generatePrefixVariableChecks(scope, indent+tabtabtab)
+
 		// Inject the prefix variables into the FContext to send
 		for _, prefixVar := range scope.Prefix.Variables {
 			contents += indent + tabtabtab + fmt.Sprintf("ctx.addRequestHeader(\"_topic_%s\", %s);\n", prefixVar, prefixVar)
@@ -2477,7 +2647,7 @@ func (g *Generator) generatePublisherClient(scope *parser.Scope, indent string)
 
 		contents += indent + tabtabtab + fmt.Sprintf("String op = \"%s\";\n", op.Name)
 		contents += indent + tabtabtab + fmt.Sprintf("String prefix = %s;\n", generatePrefixStringTemplate(scope))
-		contents += indent + tabtabtab + "String topic = String.format(\"%s" + strings.Title(scope.Name) + "%s%s\", prefix, DELIMITER, op);\n"
+		contents += indent + tabtabtab + "String topic = String.format(\"%s" + scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter) + "%s%s\", prefix, DELIMITER, op);\n"
 		contents += indent + tabtabtab + "TMemoryOutputBuffer memoryBuffer = new TMemoryOutputBuffer(transport.getPublishSizeLimit());\n"
 		contents += indent + tabtabtab + "FProtocol oprot = protocolFactory.getProtocol(memoryBuffer);\n"
 		contents += indent + tabtabtab + "oprot.writeRequestHeader(ctx);\n"
@@ -2486,6 +2656,10 @@ func (g *Generator) generatePublisherClient(scope *parser.Scope, indent string)
 		contents += indent + tabtabtab + "oprot.writeMessageEnd();\n"
 		contents += indent + tabtabtab + "transport.publish(topic, memoryBuffer.getWriteBytes());\n"
 		contents += indent + tabtab + "}\n"
+		if g.useBatchPublish() {
+			contents += "\n"
+			contents += g.generatePublishBatchMethod(scope, op, indent+tabtab)
+		}
 	}
 
 	contents += indent + tab + "}\n"
@@ -2494,6 +2668,42 @@ func (g *Generator) generatePublisherClient(scope *parser.Scope, indent string)
 	return contents
 }
 
+// generatePrefixVariableMethod generates the method checking the values of the
+// scope's prefix variables for the prefix_validation option.
+func (g *Generator) generatePrefixVariableMethod(scope *parser.Scope, indent string) string {
+	behavior, ok := g.Options[generator.PrefixValidationOption]
+	if !ok || len(scope.Prefix.Variables) == 0 {
+		return ""
+	}
+	contents := indent + "private static String prefixVariable(String name, String value) throws TException {\n"
+	contents += indent + tab + "if (value == null || value.isEmpty()) {\n"
+	contents += indent + tabtab + "throw new TException(\"Topic prefix variable \" + name + \" is empty\");\n"
+	contents += indent + tab + "}\n"
+	if behavior == generator.PrefixValidationError {
+		contents += indent + tab + "if (value.contains(DELIMITER)) {\n"
+		contents += indent + tabtab + "throw new TException(\"Topic prefix variable \" + name + \" contains the topic delimiter: \" + value);\n"
+		contents += indent + tab + "}\n"
+		contents += indent + tab + "return value;\n"
+	} else {
+		contents += indent + tab + fmt.Sprintf("return value.replace(DELIMITER, \"%s\");\n", generator.PrefixSanitizeReplacement)
+	}
+	contents += indent + "}\n\n"
+	return contents
+}
+
+// generatePrefixVariableChecks generates the checks of the scope's prefix
+// variables for the prefix_validation option.
+func (g *Generator) generatePrefixVariableChecks(scope *parser.Scope, indent string) string {
+	if _, ok := g.Options[generator.PrefixValidationOption]; !ok {
+		return ""
+	}
+	contents := ""
+	for _, variable := range scope.Prefix.Variables {
+		contents += indent + fmt.Sprintf("%s = prefixVariable(\"%s\", %s);\n", variable, variable, variable)
+	}
+	return contents
+}
+
 func generatePrefixStringTemplate(scope *parser.Scope) string {
 	if len(scope.Prefix.Variables) == 0 {
 		if scope.Prefix.String == "" {
@@ -2521,6 +2731,7 @@ func (g *Generator) GenerateSubscriber(file *os.File, scope *parser.Scope) error
 	}
 
 	contents += fmt.Sprintf("public class %sSubscriber {\n\n", scopeName)
+	contents += g.generateContractMetadata(g.ScopeMetadata(scope), tab)
 
 	contents += g.generateSubscriberIface(scope, tab)
 	contents += g.generateHandlerIfaces(scope, tab)
@@ -2535,16 +2746,16 @@ func (g *Generator) GenerateSubscriber(file *os.File, scope *parser.Scope) error
 func (g *Generator) generateSubscriberIface(scope *parser.Scope, indent string) string {
 	contents := ""
 
-	if scope.Comment != nil {
-		contents += g.GenerateBlockComment(scope.Comment, indent)
+	if comment := scope.DocComment(); comment != nil {
+		contents += g.GenerateBlockComment(comment, indent)
 	}
 
 	// generate a non-throwable interface
 	contents += indent + "public interface Iface {\n"
 	args := g.generateScopePrefixArgs(scope)
 	for _, op := range scope.Operations {
-		if op.Comment != nil {
-			contents += g.GenerateBlockComment(op.Comment, indent+tab)
+		if comment := op.DocComment(); comment != nil {
+			contents += g.GenerateBlockComment(comment, indent+tab)
 		}
 		contents += indent + tab + fmt.Sprintf("public FSubscription subscribe%s(%sfinal %sHandler handler) throws TException;\n\n",
 			op.Name, args, op.Name)
@@ -2555,8 +2766,8 @@ func (g *Generator) generateSubscriberIface(scope *parser.Scope, indent string)
 	contents += indent + "public interface IfaceThrowable {\n"
 	throwableArgs := g.generateScopePrefixArgs(scope)
 	for _, op := range scope.Operations {
-		if op.Comment != nil {
-			contents += g.GenerateBlockComment(op.Comment, indent+tab)
+		if comment := op.DocComment(); comment != nil {
+			contents += g.GenerateBlockComment(comment, indent+tab)
 		}
 		contents += indent + tab + fmt.Sprintf("public FSubscription subscribe%sThrowable(%sfinal %sThrowableHandler handler) throws TException;\n\n",
 			op.Name, throwableArgs, op.Name)
@@ -2592,13 +2803,14 @@ func (g *Generator) generateSubscriberClient(scope *parser.Scope, indent string)
 	prefix := ""
 	args := g.generateScopePrefixArgs(scope)
 
-	if scope.Comment != nil {
-		contents += g.GenerateBlockComment(scope.Comment, indent)
+	if comment := scope.DocComment(); comment != nil {
+		contents += g.GenerateBlockComment(comment, indent)
 	}
 	contents += indent + "public static class Client implements Iface, IfaceThrowable {\n"
 
 	contents += indent + tab + fmt.Sprintf("private static final String DELIMITER = \"%s\";\n", globals.TopicDelimiter)
 	contents += indent + tab + "private static final Logger LOGGER = LoggerFactory.getLogger(Client.class);\n\n"
+	contents += g.generatePrefixVariableMethod(scope, indent+tab)
 
 	contents += indent + tab + "private final FScopeProvider provider;\n"
 	contents += indent + tab + "private final ServiceMiddleware[] middleware;\n\n"
@@ -2615,8 +2827,8 @@ func (g *Generator) generateSubscriberClient(scope *parser.Scope, indent string)
 		for _, op := range scope.Operations {
 			contents += prefix
 			prefix = "\n\n"
-			if op.Comment != nil {
-				contents += g.GenerateBlockComment(op.Comment, indent+tab)
+			if comment := op.DocComment(); comment != nil {
+				contents += g.GenerateBlockComment(comment, indent+tab)
 			}
 
 			if throwable {
@@ -2624,9 +2836,10 @@ func (g *Generator) generateSubscriberClient(scope *parser.Scope, indent string)
 			} else {
 				contents += indent + tab + fmt.Sprintf("public FSubscription subscribe%s(%sfinal %sHandler handler) throws TException {\n", op.Name, args, op.Name)
 			}
+			contents += g.generatePrefixVariableChecks(scope, indent+tabtab)
 			contents += indent + tabtab + fmt.Sprintf("final String op = \"%s\";\n", op.Name)
 			contents += indent + tabtab + fmt.Sprintf("String prefix = %s;\n", generatePrefixStringTemplate(scope))
-			contents += indent + tabtab + "final String topic = String.format(\"%s" + strings.Title(scope.Name) + "%s%s\", prefix, DELIMITER, op);\n"
+			contents += indent + tabtab + "final String topic = String.format(\"%s" + scope.TopicName(strings.Title(scope.Name), globals.TopicDelimiter) + "%s%s\", prefix, DELIMITER, op);\n"
 			contents += indent + tabtab + "final FScopeProvider.Subscriber subscriber = provider.buildSubscriber();\n"
 
 			contents += indent + tabtab + "final FSubscriberTransport transport = subscriber.getTransport();\n"
@@ -2641,7 +2854,8 @@ func (g *Generator) generateSubscriberClient(scope *parser.Scope, indent string)
 					op.Name, op.Name)
 			}
 
-			contents += indent + tabtab + fmt.Sprintf("transport.subscribe(topic, recv%s(op, subscriber.getProtocolFactory(), proxiedHandler));\n", op.Name)
+			contents += indent + tabtab + fmt.Sprintf("transport.subscribe(topic, %s);\n",
+				g.wrapBatchFrameCallback(fmt.Sprintf("recv%s(op, subscriber.getProtocolFactory(), proxiedHandler)", op.Name)))
 			contents += indent + tabtab + "return FSubscription.of(topic, transport);\n"
 			contents += indent + tab + "}\n\n"
 
@@ -2695,6 +2909,7 @@ func (g *Generator) GenerateService(file *os.File, s *parser.Service) error {
 	}
 	contents += fmt.Sprintf("public class F%s {\n\n", s.Name)
 	contents += tab + fmt.Sprintf("private static final Logger logger = LoggerFactory.getLogger(F%s.class);\n\n", s.Name)
+	contents += g.generateContractMetadata(g.ServiceMetadata(s), tab)
 	contents += g.generateServiceInterface(s, tab)
 	contents += g.generateClient(s, tab)
 	contents += g.generateServer(s, tab)
@@ -2728,8 +2943,8 @@ func (g *Generator) generateCommentWithDeprecated(comment []string, indent strin
 
 func (g *Generator) generateServiceInterface(service *parser.Service, indent string) string {
 	contents := ""
-	if service.Comment != nil {
-		contents += g.GenerateBlockComment(service.Comment, indent)
+	if comment := service.DocComment(); comment != nil {
+		contents += g.GenerateBlockComment(comment, indent)
 	}
 	if service.Extends != "" {
 		contents += indent + fmt.Sprintf("public interface Iface extends %s.Iface {\n\n",
@@ -3269,6 +3484,16 @@ func (g *Generator) getPrimitiveDefaultValue(t *parser.Type) string {
 	}
 }
 
+func (g *Generator) generateBuilders() bool {
+	_, ok := g.Options["builders"]
+	return ok
+}
+
+func (g *Generator) generateCopyMerge() bool {
+	_, ok := g.Options[generator.CopyMergeOption]
+	return ok
+}
+
 func (g *Generator) generateBoxedPrimitives() bool {
 	_, ok := g.Options["boxed_primitives"]
 	return ok
@@ -3353,3 +3578,46 @@ func (g *Generator) UseVendor() bool {
 	_, ok := g.Options[useVendorOption]
 	return ok
 }
+
+// generateConstraintChecks generates checks that the field, if set, satisfies
+// its constraints.
+func (g *Generator) generateConstraintChecks(s *parser.Struct, field *parser.Field, indent string) string {
+	constraints := field.Constraints()
+	if constraints == nil {
+		return ""
+	}
+	isSet := fmt.Sprintf("isSet%s()", strings.Title(field.Name))
+	underlying := g.Frugal.UnderlyingType(field.Type)
+
+	bounded, what, suffix := field.Name, "", ""
+	switch underlying.Name {
+	case "string":
+		bounded, what = fmt.Sprintf("%s.codePointCount(0, %s.length())", field.Name, field.Name), "length "
+	case "binary":
+		bounded, what = field.Name+".remaining()", "length "
+	case "list", "set", "map":
+		bounded, what = field.Name+".size()", "length "
+	case "i64":
+		suffix = "L"
+	}
+
+	contents := ""
+	check := func(condition, message string) {
+		contents += indent + fmt.Sprintf("if (%s && %s) {\n", isSet, condition)
+		contents += indent + tab + fmt.Sprintf("throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, %s);\n", g.quote(message))
+		contents += indent + "}\n"
+	}
+	if constraints.Min != "" {
+		check(fmt.Sprintf("%s < %s%s", bounded, constraints.Min, suffix),
+			fmt.Sprintf("%s.%s %smust be at least %s", s.Name, field.Name, what, constraints.Min))
+	}
+	if constraints.Max != "" {
+		check(fmt.Sprintf("%s > %s%s", bounded, constraints.Max, suffix),
+			fmt.Sprintf("%s.%s %smust be at most %s", s.Name, field.Name, what, constraints.Max))
+	}
+	if constraints.Pattern != "" {
+		check(fmt.Sprintf("!%s_PATTERN.matcher(%s).find()", toConstantName(field.Name), field.Name),
+			fmt.Sprintf("%s.%s must match %s", s.Name, field.Name, constraints.Pattern))
+	}
+	return contents
+}
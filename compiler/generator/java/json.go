@@ -0,0 +1,286 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// generateJSON generates toJson, toJsonValue, fromJson, and fromJsonValue
+// methods for the given struct when the json option is set, encoding it with
+// Gson the same way as the other languages. Structs are encoded as objects
+// keyed by field name, omitting unset fields. Binary values are base64
+// encoded, enums are encoded by name, and sets are encoded as arrays. Maps
+// with string, integer, or enum keys are encoded as objects and other maps as
+// arrays of key-value pairs.
+func (g *Generator) generateJSON(s *parser.Struct, indent string) string {
+	if !g.generateJSONHelpers() {
+		return ""
+	}
+
+	contents := g.GenerateBlockComment([]string{fmt.Sprintf("Returns the JSON encoding of the %s.", s.Name)}, indent)
+	contents += indent + "public String toJson() {\n"
+	contents += indent + tab + "return toJsonValue().toString();\n"
+	contents += indent + "}\n\n"
+
+	contents += g.GenerateBlockComment([]string{"Returns the value encoded by toJson."}, indent)
+	contents += indent + "public com.google.gson.JsonObject toJsonValue() {\n"
+	contents += indent + tab + "com.google.gson.JsonObject value = new com.google.gson.JsonObject();\n"
+	for _, field := range s.Fields {
+		fieldIndent := indent + tab
+		// Primitive struct fields always have a value, as in Go.
+		checked := s.Type == parser.StructTypeUnion || field.Modifier == parser.Optional || !g.isJavaPrimitive(field.Type)
+		if checked {
+			contents += fieldIndent + fmt.Sprintf("if (isSet%s()) {\n", strings.Title(field.Name))
+			fieldIndent += tab
+		}
+		// Union values are only accessible through their getters.
+		value := "this." + field.Name
+		if s.Type == parser.StructTypeUnion {
+			value = fmt.Sprintf("get%s()", strings.Title(field.Name))
+		}
+		code, expr := g.generateToJSONValue(value, field.Type, fieldIndent, 1)
+		contents += code
+		contents += fieldIndent + fmt.Sprintf("value.add(\"%s\", %s);\n", field.Name, expr)
+		if checked {
+			contents += indent + tab + "}\n"
+		}
+	}
+	contents += indent + tab + "return value;\n"
+	contents += indent + "}\n\n"
+
+	contents += g.GenerateBlockComment([]string{
+		fmt.Sprintf("Sets the fields of the %s from the JSON encoding produced by", s.Name),
+		"toJson. Fields missing from the JSON are left unchanged.",
+	}, indent)
+	contents += indent + "public void fromJson(String json) throws TException {\n"
+	contents += indent + tab + "com.google.gson.JsonElement value;\n"
+	contents += indent + tab + "try {\n"
+	contents += indent + tabtab + "value = new com.google.gson.JsonParser().parse(json);\n"
+	contents += indent + tab + "} catch (com.google.gson.JsonParseException e) {\n"
+	contents += indent + tabtab + "throw new TProtocolException(TProtocolException.INVALID_DATA, e.getMessage());\n"
+	contents += indent + tab + "}\n"
+	contents += indent + tab + "fromJsonValue(value);\n"
+	contents += indent + "}\n\n"
+
+	contents += g.GenerateBlockComment([]string{
+		fmt.Sprintf("Sets the fields of the %s from the value returned by toJsonValue.", s.Name),
+	}, indent)
+	contents += indent + "public void fromJsonValue(com.google.gson.JsonElement value) throws TException {\n"
+	contents += indent + tab + "if (!value.isJsonObject()) {\n"
+	contents += indent + tabtab + fmt.Sprintf("throw new TProtocolException(TProtocolException.INVALID_DATA, \"%s JSON must be an object\");\n", s.Name)
+	contents += indent + tab + "}\n"
+	contents += indent + tab + "com.google.gson.JsonObject fields = value.getAsJsonObject();\n"
+	for _, field := range s.Fields {
+		contents += indent + tab + fmt.Sprintf("if (fields.has(\"%s\")) {\n", field.Name)
+		contents += indent + tabtab + "try {\n"
+		contents += g.generateFromJSONValue(fmt.Sprintf("fields.get(\"%s\")", field.Name), "v", field.Type, indent+tabtabtab, 1)
+		contents += indent + tabtabtab + fmt.Sprintf("set%s(v);\n", strings.Title(field.Name))
+		contents += indent + tabtab + "} catch (TException | RuntimeException e) {\n"
+		contents += indent + tabtabtab + fmt.Sprintf("throw new TProtocolException(TProtocolException.INVALID_DATA, \"error decoding field %s: \" + e.getMessage());\n", field.Name)
+		contents += indent + tabtab + "}\n"
+		contents += indent + tab + "}\n"
+	}
+	contents += indent + "}\n\n"
+	return contents
+}
+
+// generateToJSONValue generates the code converting the value to a Gson
+// JsonElement as described by generateJSON. It returns the code and an
+// expression for the converted value.
+func (g *Generator) generateToJSONValue(value string, t *parser.Type, indent string, depth int) (string, string) {
+	underlying := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsStruct(underlying):
+		return "", value + ".toJsonValue()"
+	case g.Frugal.IsEnum(underlying):
+		return "", fmt.Sprintf("new com.google.gson.JsonPrimitive(%s.name())", value)
+	case underlying.Name == "binary":
+		return "", fmt.Sprintf("new com.google.gson.JsonPrimitive(java.util.Base64.getEncoder().encodeToString(org.apache.thrift.TBaseHelper.byteBufferToByteArray(%s)))", value)
+	case !underlying.IsContainer():
+		return "", fmt.Sprintf("new com.google.gson.JsonPrimitive(%s)", value)
+	}
+
+	v := fmt.Sprintf("v%d", depth)
+	elem := fmt.Sprintf("elem%d", depth)
+	contents := ""
+	switch underlying.Name {
+	case "list", "set":
+		contents += indent + fmt.Sprintf("com.google.gson.JsonArray %s = new com.google.gson.JsonArray();\n", v)
+		contents += indent + fmt.Sprintf("for (%s %s : %s) {\n", containerType(g.getJavaTypeFromThriftType(underlying.ValueType)), elem, value)
+		code, expr := g.generateToJSONValue(elem, underlying.ValueType, indent+tab, depth+1)
+		contents += code
+		contents += indent + tab + fmt.Sprintf("%s.add(%s);\n", v, expr)
+	case "map":
+		entry := fmt.Sprintf("entry%d", depth)
+		contents += indent + fmt.Sprintf("for (Map.Entry<%s, %s> %s : %s.entrySet()) {\n",
+			containerType(g.getJavaTypeFromThriftType(underlying.KeyType)),
+			containerType(g.getJavaTypeFromThriftType(underlying.ValueType)), entry, value)
+		// Keys and values are converted in the same scope, so their
+		// variables need different depths.
+		valueCode, valueExpr := g.generateToJSONValue(entry+".getValue()", underlying.ValueType, indent+tab, depth+2)
+		if g.isJSONObjectKey(underlying.KeyType) {
+			contents = indent + fmt.Sprintf("com.google.gson.JsonObject %s = new com.google.gson.JsonObject();\n", v) + contents
+			contents += valueCode
+			contents += indent + tab + fmt.Sprintf("%s.add(%s, %s);\n", v, g.jsonObjectKey(entry+".getKey()", underlying.KeyType), valueExpr)
+		} else {
+			keyCode, keyExpr := g.generateToJSONValue(entry+".getKey()", underlying.KeyType, indent+tab, depth+1)
+			pair := fmt.Sprintf("pair%d", depth)
+			contents = indent + fmt.Sprintf("com.google.gson.JsonArray %s = new com.google.gson.JsonArray();\n", v) + contents
+			contents += keyCode
+			contents += valueCode
+			contents += indent + tab + fmt.Sprintf("com.google.gson.JsonArray %s = new com.google.gson.JsonArray();\n", pair)
+			contents += indent + tab + fmt.Sprintf("%s.add(%s);\n", pair, keyExpr)
+			contents += indent + tab + fmt.Sprintf("%s.add(%s);\n", pair, valueExpr)
+			contents += indent + tab + fmt.Sprintf("%s.add(%s);\n", v, pair)
+		}
+	}
+	contents += indent + "}\n"
+	return contents, v
+}
+
+// generateFromJSONValue generates the code declaring the variable v with the
+// value decoded from the Gson JsonElement json.
+func (g *Generator) generateFromJSONValue(json, v string, t *parser.Type, indent string, depth int) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	javaType := g.getJavaTypeFromThriftType(t)
+	switch {
+	case g.Frugal.IsStruct(underlying):
+		contents := indent + fmt.Sprintf("%s %s = new %s();\n", javaType, v, javaType)
+		contents += indent + fmt.Sprintf("%s.fromJsonValue(%s);\n", v, json)
+		return contents
+	case g.Frugal.IsEnum(underlying):
+		return indent + fmt.Sprintf("%s %s = %s.valueOf(%s.getAsString());\n", javaType, v, javaType, json)
+	case underlying.Name == "binary":
+		return indent + fmt.Sprintf("%s %s = ByteBuffer.wrap(java.util.Base64.getDecoder().decode(%s.getAsString()));\n", javaType, v, json)
+	case !underlying.IsContainer():
+		return indent + fmt.Sprintf("%s %s = %s.%s();\n", javaType, v, json, jsonGetter(underlying))
+	}
+
+	elemJSON := fmt.Sprintf("json%d", depth)
+	elem := fmt.Sprintf("elem%d", depth)
+	contents := ""
+	switch underlying.Name {
+	case "list", "set":
+		impl := "ArrayList"
+		if underlying.Name == "set" {
+			impl = "HashSet"
+		}
+		contents += indent + fmt.Sprintf("%s %s = new %s<%s>();\n", javaType, v, impl, containerType(g.getJavaTypeFromThriftType(underlying.ValueType)))
+		contents += indent + fmt.Sprintf("for (com.google.gson.JsonElement %s : %s.getAsJsonArray()) {\n", elemJSON, json)
+		contents += g.generateFromJSONValue(elemJSON, elem, underlying.ValueType, indent+tab, depth+1)
+		contents += indent + tab + fmt.Sprintf("%s.add(%s);\n", v, elem)
+	case "map":
+		key := fmt.Sprintf("key%d", depth)
+		contents += indent + fmt.Sprintf("%s %s = new HashMap<%s, %s>();\n", javaType, v,
+			containerType(g.getJavaTypeFromThriftType(underlying.KeyType)),
+			containerType(g.getJavaTypeFromThriftType(underlying.ValueType)))
+		if g.isJSONObjectKey(underlying.KeyType) {
+			entry := fmt.Sprintf("entry%d", depth)
+			contents += indent + fmt.Sprintf("for (Map.Entry<String, com.google.gson.JsonElement> %s : %s.getAsJsonObject().entrySet()) {\n", entry, json)
+			contents += indent + tab + fmt.Sprintf("%s %s = %s;\n", g.getJavaTypeFromThriftType(underlying.KeyType), key, g.parseJSONObjectKey(entry+".getKey()", underlying.KeyType))
+			elemJSON = entry + ".getValue()"
+		} else {
+			pair := fmt.Sprintf("pair%d", depth)
+			contents += indent + fmt.Sprintf("for (com.google.gson.JsonElement %s : %s.getAsJsonArray()) {\n", elemJSON, json)
+			contents += indent + tab + fmt.Sprintf("com.google.gson.JsonArray %s = %s.getAsJsonArray();\n", pair, elemJSON)
+			contents += g.generateFromJSONValue(pair+".get(0)", key, underlying.KeyType, indent+tab, depth+1)
+			elemJSON = pair + ".get(1)"
+		}
+		// Keys and values are decoded in the same scope, so their variables
+		// need different depths.
+		elem = fmt.Sprintf("val%d", depth)
+		contents += g.generateFromJSONValue(elemJSON, elem, underlying.ValueType, indent+tab, depth+2)
+		contents += indent + tab + fmt.Sprintf("%s.put(%s, %s);\n", v, key, elem)
+	}
+	contents += indent + "}\n"
+	return contents
+}
+
+// jsonGetter returns the JsonElement method getting a value of the given
+// base type.
+func jsonGetter(t *parser.Type) string {
+	switch t.Name {
+	case "bool":
+		return "getAsBoolean"
+	case "byte", "i8":
+		return "getAsByte"
+	case "i16":
+		return "getAsShort"
+	case "i32":
+		return "getAsInt"
+	case "i64":
+		return "getAsLong"
+	case "double":
+		return "getAsDouble"
+	default:
+		return "getAsString"
+	}
+}
+
+// isJSONObjectKey returns true if maps with keys of the type are encoded as
+// JSON objects.
+func (g *Generator) isJSONObjectKey(t *parser.Type) bool {
+	underlying := g.Frugal.UnderlyingType(t)
+	switch underlying.Name {
+	case "string", "byte", "i8", "i16", "i32", "i64":
+		return true
+	}
+	return g.Frugal.IsEnum(underlying)
+}
+
+// jsonObjectKey returns an expression converting the map key to its JSON
+// object key.
+func (g *Generator) jsonObjectKey(key string, t *parser.Type) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsEnum(underlying):
+		return key + ".name()"
+	case underlying.Name == "string":
+		return key
+	default:
+		return fmt.Sprintf("String.valueOf(%s)", key)
+	}
+}
+
+// parseJSONObjectKey returns an expression converting the JSON object key
+// back to the map key.
+func (g *Generator) parseJSONObjectKey(key string, t *parser.Type) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsEnum(underlying):
+		return fmt.Sprintf("%s.valueOf(%s)", g.getJavaTypeFromThriftType(t), key)
+	case underlying.Name == "string":
+		return key
+	}
+	switch underlying.Name {
+	case "byte", "i8":
+		return fmt.Sprintf("Byte.parseByte(%s)", key)
+	case "i16":
+		return fmt.Sprintf("Short.parseShort(%s)", key)
+	case "i32":
+		return fmt.Sprintf("Integer.parseInt(%s)", key)
+	default:
+		return fmt.Sprintf("Long.parseLong(%s)", key)
+	}
+}
+
+func (g *Generator) generateJSONHelpers() bool {
+	_, ok := g.Options[generator.JSONOption]
+	return ok
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/globals"
+)
+
+const (
+	mavenOption         = "maven"
+	defaultMavenGroupID = "com.workiva.frugal.generated"
+	thriftVersion       = "0.9.3"
+	slf4jVersion        = "1.7.22"
+	junitVersion        = "4.12"
+)
+
+// useMaven indicates if a pom.xml should be generated.
+func (g *Generator) useMaven() bool {
+	_, ok := g.Options[mavenOption]
+	return ok
+}
+
+// validateMaven ensures all generated sources are under the directory the
+// pom.xml builds.
+func (g *Generator) validateMaven() error {
+	if !g.useMaven() {
+		return nil
+	}
+	for _, option := range []string{generator.ScopesOutOption, generator.ServicesOutOption} {
+		if g.ArtifactsSeparated(option) {
+			return fmt.Errorf("java option %s can't be used with %s", mavenOption, option)
+		}
+	}
+	return nil
+}
+
+// generatePom writes a pom.xml building the generated sources to the root of
+// the output directory, i.e. the directory the package directories are in.
+// Only the first file compiled writes it, since included files generated with
+// it share the root. The group ID is the option's value, if any, and the
+// artifact ID is the file's name.
+func (g *Generator) generatePom(dir string) error {
+	if !g.useMaven() || g.pomWritten {
+		return nil
+	}
+	g.pomWritten = true
+
+	root := dir
+	if namespace := g.Frugal.Namespace(lang); namespace != nil {
+		for range generator.GetPackageComponents(namespace.Value) {
+			root = filepath.Dir(root)
+		}
+	}
+
+	groupID := g.Options[mavenOption]
+	if groupID == "" {
+		groupID = defaultMavenGroupID
+	}
+	artifactID := strings.Replace(g.Frugal.Name, "_", "-", -1)
+
+	contents := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"
+	contents += fmt.Sprintf("<!-- Autogenerated by Frugal Compiler (%s) -->\n", globals.Version)
+	contents += "<project xmlns=\"http://maven.apache.org/POM/4.0.0\" xmlns:xsi=\"http://www.w3.org/2001/XMLSchema-instance\" xsi:schemaLocation=\"http://maven.apache.org/POM/4.0.0 http://maven.apache.org/xsd/maven-4.0.0.xsd\">\n"
+	contents += "    <modelVersion>4.0.0</modelVersion>\n\n"
+	contents += fmt.Sprintf("    <groupId>%s</groupId>\n", groupID)
+	contents += fmt.Sprintf("    <artifactId>%s</artifactId>\n", artifactID)
+	contents += fmt.Sprintf("    <version>%s</version>\n", globals.Version)
+	contents += "    <packaging>jar</packaging>\n\n"
+	contents += "    <properties>\n"
+	contents += "        <maven.compiler.source>1.8</maven.compiler.source>\n"
+	contents += "        <maven.compiler.target>1.8</maven.compiler.target>\n"
+	contents += "        <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>\n"
+	contents += "    </properties>\n\n"
+	contents += "    <dependencies>\n"
+	contents += generatePomDependency("com.workiva", "frugal", globals.Version)
+	contents += generatePomDependency("org.apache.thrift", "libthrift", thriftVersion)
+	contents += generatePomDependency("org.slf4j", "slf4j-api", slf4jVersion)
+	if globals.TestVectors {
+		contents += generatePomTestDependency("junit", "junit", junitVersion)
+	}
+	contents += "    </dependencies>\n\n"
+	contents += "    <build>\n"
+	contents += "        <sourceDirectory>.</sourceDirectory>\n"
+	if globals.TestVectors {
+		// The test is generated next to the sources it tests.
+		contents += "        <testSourceDirectory>.</testSourceDirectory>\n"
+		contents += "        <plugins>\n"
+		contents += "            <plugin>\n"
+		contents += "                <groupId>org.apache.maven.plugins</groupId>\n"
+		contents += "                <artifactId>maven-compiler-plugin</artifactId>\n"
+		contents += "                <configuration>\n"
+		contents += fmt.Sprintf("                    <excludes><exclude>**/%s.java</exclude></excludes>\n", testVectorsTestName)
+		contents += fmt.Sprintf("                    <testIncludes><testInclude>**/%s.java</testInclude></testIncludes>\n", testVectorsTestName)
+		contents += "                </configuration>\n"
+		contents += "            </plugin>\n"
+		contents += "        </plugins>\n"
+	}
+	contents += "    </build>\n"
+	contents += "</project>\n"
+
+	return ioutil.WriteFile(filepath.Join(root, "pom.xml"), []byte(contents), 0644)
+}
+
+func generatePomDependency(groupID, artifactID, version string) string {
+	contents := "        <dependency>\n"
+	contents += fmt.Sprintf("            <groupId>%s</groupId>\n", groupID)
+	contents += fmt.Sprintf("            <artifactId>%s</artifactId>\n", artifactID)
+	contents += fmt.Sprintf("            <version>%s</version>\n", version)
+	contents += "        </dependency>\n"
+	return contents
+}
+
+func generatePomTestDependency(groupID, artifactID, version string) string {
+	contents := "        <dependency>\n"
+	contents += fmt.Sprintf("            <groupId>%s</groupId>\n", groupID)
+	contents += fmt.Sprintf("            <artifactId>%s</artifactId>\n", artifactID)
+	contents += fmt.Sprintf("            <version>%s</version>\n", version)
+	contents += "            <scope>test</scope>\n"
+	contents += "        </dependency>\n"
+	return contents
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/Workiva/frugal/compiler/generator"
+)
+
+const testVectorsTestName = "TestVectorsTest"
+
+// generateTestVectorsTest generates a JUnit test decoding the test vector of
+// each struct, union, and exception with each protocol and checking that
+// writing the decoded value with the binary protocol reproduces the binary
+// vector, which locks in the wire format other languages encode. Protocols
+// which can't encode a struct are skipped.
+func (g *Generator) generateTestVectorsTest() error {
+	structs := g.Frugal.DataStructures()
+	if len(structs) == 0 {
+		return nil
+	}
+	file, err := g.CreateFile(testVectorsTestName, g.outputDir, lang, false)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := g.GenerateDocStringComment(file); err != nil {
+		return err
+	}
+	if _, err := file.WriteString("\n"); err != nil {
+		return err
+	}
+	if err := g.generatePackage(file); err != nil {
+		return err
+	}
+
+	contents := "\n\n"
+	contents += "import org.apache.thrift.TBase;\n"
+	contents += "import org.apache.thrift.TDeserializer;\n"
+	contents += "import org.apache.thrift.TException;\n"
+	contents += "import org.apache.thrift.TSerializer;\n"
+	contents += "import org.apache.thrift.protocol.TBinaryProtocol;\n"
+	contents += "import org.apache.thrift.protocol.TCompactProtocol;\n"
+	contents += "import org.apache.thrift.protocol.TJSONProtocol;\n"
+	contents += "import org.apache.thrift.protocol.TProtocolFactory;\n"
+	contents += "import org.junit.Test;\n\n"
+	contents += "import java.util.Base64;\n\n"
+	contents += "import static org.junit.Assert.assertArrayEquals;\n\n"
+
+	contents += fmt.Sprintf("public class %s {\n\n", testVectorsTestName)
+	contents += g.GenerateBlockComment([]string{
+		"Decodes the vector into the value with the protocol and checks that",
+		"writing the value with the binary protocol produces the binary vector.",
+	}, tab)
+	contents += tab + "private static void checkTestVector(String binary, TProtocolFactory factory, String vector, TBase value) throws TException {\n"
+	contents += tabtab + "new TDeserializer(factory).deserialize(value, Base64.getDecoder().decode(vector));\n"
+	contents += tabtab + "byte[] written = new TSerializer(new TBinaryProtocol.Factory()).serialize(value);\n"
+	contents += tabtab + "assertArrayEquals(Base64.getDecoder().decode(binary), written);\n"
+	contents += tab + "}\n"
+
+	for _, s := range structs {
+		binary, err := generator.TestVector(g.Frugal, s, "binary")
+		if err != nil {
+			// Every protocol fails to build the vector if binary does.
+			continue
+		}
+		contents += "\n"
+		contents += tab + "@Test\n"
+		contents += tab + fmt.Sprintf("public void test%sTestVectors() throws TException {\n", s.Name)
+		contents += tabtab + fmt.Sprintf("String binary = \"%s\";\n", base64.StdEncoding.EncodeToString(binary))
+		for _, protocol := range generator.TestVectorProtocols {
+			vector, err := generator.TestVector(g.Frugal, s, protocol)
+			if err != nil {
+				continue
+			}
+			literal := "binary"
+			if protocol != "binary" {
+				literal = fmt.Sprintf("\"%s\"", base64.StdEncoding.EncodeToString(vector))
+			}
+			contents += tabtab + fmt.Sprintf("checkTestVector(binary, %s, %s, new %s());\n",
+				javaProtocolFactories[protocol], literal, s.Name)
+		}
+		contents += tab + "}\n"
+	}
+	contents += "}\n"
+
+	_, err = file.WriteString(contents)
+	return err
+}
+
+// javaProtocolFactories are the expressions creating the protocol factory of
+// each test vector protocol.
+var javaProtocolFactories = map[string]string{
+	"binary":  "new TBinaryProtocol.Factory()",
+	"compact": "new TCompactProtocol.Factory()",
+	"json":    "new TJSONProtocol.Factory()",
+}
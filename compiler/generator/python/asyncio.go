@@ -18,6 +18,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/Workiva/frugal/compiler/generator"
 	"github.com/Workiva/frugal/compiler/globals"
 	"github.com/Workiva/frugal/compiler/parser"
 )
@@ -38,6 +39,7 @@ func (a *AsyncIOGenerator) GenerateServiceImports(file *os.File, s *parser.Servi
 	imports += "from frugal.aio.processor import FProcessorFunction\n"
 	imports += "from frugal.exceptions import TApplicationExceptionType\n"
 	imports += "from frugal.exceptions import TTransportExceptionType\n"
+	imports += "from frugal.metadata import FContractMetadata\n"
 	imports += "from frugal.middleware import Method\n"
 	imports += "from frugal.transport import TMemoryOutputBuffer\n"
 	imports += "from frugal.util.deprecate import deprecated\n"
@@ -66,11 +68,14 @@ func (a *AsyncIOGenerator) GenerateScopeImports(file *os.File, s *parser.Scope)
 	imports += "from thrift.Thrift import TMessageType\n"
 	imports += "from thrift.Thrift import TType\n"
 	imports += "from frugal.exceptions import TApplicationExceptionType\n"
+	imports += "from frugal.metadata import FContractMetadata\n"
 	imports += "from frugal.middleware import Method\n"
 	imports += "from frugal.subscription import FSubscription\n"
-	imports += "from frugal.transport import TMemoryOutputBuffer\n\n"
+	imports += "from frugal.transport import TMemoryOutputBuffer\n"
+	imports += a.generateBatchFrameImports()
+	imports += "\n"
 
-	imports += "from .ttypes import *\n"
+	imports += a.generateTypesImport(generator.ScopesOutOption)
 	_, err := file.WriteString(imports)
 	return err
 }
@@ -303,12 +308,14 @@ func (a *AsyncIOGenerator) generateProcessorFunction(method *parser.Method) stri
 func (a *AsyncIOGenerator) GenerateSubscriber(file *os.File, scope *parser.Scope) error {
 	subscriber := ""
 	subscriber += fmt.Sprintf("class %sSubscriber(object):\n", scope.Name)
-	if scope.Comment != nil {
-		subscriber += a.generateDocString(scope.Comment, tab)
+	if comment := scope.DocComment(); comment != nil {
+		subscriber += a.generateDocString(comment, tab)
 	}
 	subscriber += "\n"
 
 	subscriber += tab + fmt.Sprintf("_DELIMITER = '%s'\n\n", globals.TopicDelimiter)
+	subscriber += a.generateContractMetadata(a.ScopeMetadata(scope))
+	subscriber += a.generatePrefixVariableMethod(scope)
 
 	subscriber += tab + "def __init__(self, provider, middleware=None):\n"
 	subscriber += a.generateDocString([]string{
@@ -323,7 +330,9 @@ func (a *AsyncIOGenerator) GenerateSubscriber(file *os.File, scope *parser.Scope
 	subscriber += tabtabtab + "middleware = [middleware]\n"
 	subscriber += tabtab + "middleware += provider.get_middleware()\n"
 	subscriber += tabtab + "self._middleware = middleware\n"
-	subscriber += tabtab + "self._provider = provider\n\n"
+	subscriber += tabtab + "self._provider = provider\n"
+	subscriber += a.generateHookAttribute("on_after_receive", "after each message is received")
+	subscriber += "\n"
 
 	for _, op := range scope.Operations {
 		subscriber += a.generateSubscribeMethod(scope, op)
@@ -348,23 +357,23 @@ func (a *AsyncIOGenerator) generateSubscribeMethod(scope *parser.Scope, op *pars
 		args += ", "
 	}
 	docstr = append(docstr, tab+fmt.Sprintf("%s_handler: function which takes FContext and %s", op.Name, op.Type))
-	if op.Comment != nil {
+	if comment := op.DocComment(); comment != nil {
 		docstr[0] = "\n" + tabtab + docstr[0]
-		docstr = append(op.Comment, docstr...)
+		docstr = append(comment, docstr...)
 	}
 	method := ""
 	method += tab + fmt.Sprintf("async def subscribe_%s(self, %s%s_handler):\n", op.Name, args, op.Name)
 	method += a.generateDocString(docstr, tabtab)
 	method += "\n"
 
+	method += a.generatePrefixVariableChecks(scope)
 	method += tabtab + fmt.Sprintf("op = '%s'\n", op.Name)
 	method += tabtab + fmt.Sprintf("prefix = %s\n", generatePrefixStringTemplate(scope))
-	method += tabtab + fmt.Sprintf("topic = '{}%s{}{}'.format(prefix, self._DELIMITER, op)\n\n", scope.Name)
+	method += tabtab + fmt.Sprintf("topic = '{}%s{}{}'.format(prefix, self._DELIMITER, op)\n\n", scope.TopicName(scope.Name, globals.TopicDelimiter))
 
 	method += tabtab + "transport, protocol_factory = self._provider.new_subscriber()\n"
-	method += tabtab + fmt.Sprintf(
-		"await transport.subscribe(topic, self._recv_%s(protocol_factory, op, %s_handler))\n",
-		op.Name, op.Name)
+	method += tabtab + fmt.Sprintf("await transport.subscribe(topic, %s)\n",
+		a.wrapBatchFrameCallback(fmt.Sprintf("self._recv_%s(protocol_factory, op, %s_handler)", op.Name, op.Name)))
 	method += tabtab + "return FSubscription(topic, transport)\n\n"
 
 	method += tab + fmt.Sprintf("def _recv_%s(self, protocol_factory, op, handler):\n", op.Name)
@@ -380,6 +389,7 @@ func (a *AsyncIOGenerator) generateSubscribeMethod(scope *parser.Scope, op *pars
 	method += tabtabtabtab + "raise TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD)\n"
 	method += a.generateReadFieldRec(parser.FieldFromType(op.Type, "req"), false, tabtabtab)
 	method += tabtabtab + "iprot.readMessageEnd()\n"
+	method += a.generateHookCall("on_after_receive", tabtabtab)
 	method += tabtabtab + "try:\n"
 	method += tabtabtabtab + "ret = method([ctx, req])\n"
 	method += tabtabtabtab + "if inspect.iscoroutine(ret):\n"
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package python
+
+import (
+	"fmt"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// useBatchPublish indicates if publishers should have methods publishing a
+// batch of messages in a single frame, and subscribers should receive them.
+func (g *Generator) useBatchPublish() bool {
+	_, ok := g.Options[generator.BatchPublishOption]
+	return ok
+}
+
+// generateBatchFrameImports generates the imports of the batch frame helpers
+// used by the file being generated with the batch_publish option.
+func (g *Generator) generateBatchFrameImports() string {
+	if !g.useBatchPublish() {
+		return ""
+	}
+	imports := ""
+	if g.fileType != generator.SubscribeFile {
+		imports += "from frugal.transport import batch_frame\n"
+	}
+	if g.fileType != generator.PublishFile {
+		if getAsyncOpt(g.Options) == asyncio {
+			imports += "from frugal.aio.transport import batch_frame_callback\n"
+		} else {
+			imports += "from frugal.transport import batch_frame_callback\n"
+		}
+	}
+	return imports
+}
+
+// wrapBatchFrameCallback wraps the given subscribe callback so it splits batch
+// frames into their messages with the batch_publish option.
+func (g *Generator) wrapBatchFrameCallback(callback string) string {
+	if g.useBatchPublish() {
+		return fmt.Sprintf("batch_frame_callback(%s)", callback)
+	}
+	return callback
+}
+
+// generatePublishBatchMethod generates the method publishing a list of
+// messages to the operation in a single frame, and the internal method it
+// invokes through the middleware.
+func (g *Generator) generatePublishBatchMethod(scope *parser.Scope, op *parser.Operation) string {
+	args := ""
+	asyncOpt := getAsyncOpt(g.Options)
+	docstr := []string{
+		"Publishes the messages in a single frame, sent with one transport flush.",
+		"Subscribers must be generated with the batch_publish option to receive",
+		"them.",
+		"\n" + tabtab + "Args:",
+		tab + "ctx: FContext",
+	}
+	if len(scope.Prefix.Variables) > 0 {
+		prefix := ""
+		for _, variable := range scope.Prefix.Variables {
+			docstr = append(docstr, tab+fmt.Sprintf("%s: string", variable))
+			args += prefix + variable
+			prefix = ", "
+		}
+		args += ", "
+	}
+	docstr = append(docstr, tab+fmt.Sprintf("reqs: list of %s", op.Type.Name))
+
+	method := tab
+	switch asyncOpt {
+	case tornado:
+		method += "@gen.coroutine\n" + tab
+	case asyncio:
+		method += "async "
+	}
+	method += fmt.Sprintf("def publish_%s_batch(self, ctx, %sreqs):\n", op.Name, args)
+	method += g.generateDocString(docstr, tabtab)
+	method += tabtab
+	switch asyncOpt {
+	case tornado:
+		method += "yield "
+	case asyncio:
+		method += "await "
+	}
+	method += fmt.Sprintf("self._methods['publish_%s_batch']([ctx, %sreqs])\n\n", op.Name, args)
+
+	method += tab
+	switch asyncOpt {
+	case tornado:
+		method += "@gen.coroutine\n" + tab
+	case asyncio:
+		method += "async "
+	}
+	method += fmt.Sprintf("def _publish_%s_batch(self, ctx, %sreqs):\n", op.Name, args)
+	method += g.generatePrefixVariableChecks(scope)
+	method += tabtab + "if not reqs:\n"
+	method += tabtabtab + "return\n"
+	for _, prefixVar := range scope.Prefix.Variables {
+		method += fmt.Sprintf(tabtab+"ctx.set_request_header('_topic_%s', %s)\n", prefixVar, prefixVar)
+	}
+	method += tabtab + fmt.Sprintf("op = '%s'\n", op.Name)
+	method += tabtab + fmt.Sprintf("prefix = %s\n", generatePrefixStringTemplate(scope))
+	method += tabtab + fmt.Sprintf("topic = '{}%s{}{}'.format(prefix, self._DELIMITER, op)\n", scope.TopicName(scope.Name, globals.TopicDelimiter))
+	method += g.generateHookCall("on_before_publish", tabtab)
+	method += tabtab + "limit = self._transport.get_publish_size_limit()\n"
+	method += tabtab + "frames = []\n"
+	method += tabtab + "for req in reqs:\n"
+	method += tabtabtab + "buffer = TMemoryOutputBuffer(limit)\n"
+	method += tabtabtab + "oprot = self._protocol_factory.get_protocol(buffer)\n"
+	method += tabtabtab + "oprot.write_request_headers(ctx)\n"
+	method += tabtabtab + "oprot.writeMessageBegin(op, TMessageType.CALL, 0)\n"
+	method += g.generateWriteFieldRec(parser.FieldFromType(op.Type, "req"), false, tabtabtab)
+	method += tabtabtab + "oprot.writeMessageEnd()\n"
+	method += tabtabtab + "frames.append(buffer.getvalue())\n"
+
+	method += tabtab
+	switch asyncOpt {
+	case tornado:
+		method += "yield "
+	case asyncio:
+		method += "await "
+	}
+	method += "self._transport.publish(topic, batch_frame(topic, frames, limit))\n"
+	return method
+}
@@ -57,11 +57,21 @@ type Generator struct {
 	outputDir string
 	typesFile *os.File
 	history   map[string][]genInfo
+
+	// packageDirs are the directories scopes and services are generated in
+	// apart from outputDir.
+	packageDirs []string
+
+	// setupWritten is set once the setup.py has been written.
+	setupWritten bool
+
+	// fileType is the type of the file being generated.
+	fileType generator.FileType
 }
 
 // NewGenerator creates a new Python LanguageGenerator.
 func NewGenerator(options map[string]string) generator.LanguageGenerator {
-	gen := &Generator{&generator.BaseGenerator{Options: options}, "", nil, map[string][]genInfo{}}
+	gen := &Generator{&generator.BaseGenerator{Options: options}, "", nil, map[string][]genInfo{}, nil, false, generator.CombinedScopeFile}
 	switch getAsyncOpt(options) {
 	case tornado:
 		return &TornadoGenerator{gen}
@@ -74,15 +84,90 @@ func NewGenerator(options map[string]string) generator.LanguageGenerator {
 // SetupGenerator performs any setup logic before generation.
 func (g *Generator) SetupGenerator(outputDir string) error {
 	g.outputDir = outputDir
+	g.packageDirs = nil
+	if err := g.validateSetup(); err != nil {
+		return err
+	}
+	if _, err := g.UnknownEnums(); err != nil {
+		return err
+	}
+	if _, err := g.PrefixValidation(); err != nil {
+		return err
+	}
+	if err := g.CheckScopeProtocols("py"); err != nil {
+		return err
+	}
+	if err := g.CheckScopeReplies("py"); err != nil {
+		return err
+	}
 
-	// To prevent littering the filesystem with __init__ in every folder between outputDir and the present working
-	// directory, use the relative path between the root output directory and the target outputDir. This creates
-	// __init__ files only in the folders used for frugal generation.
 	outputRoot := globals.Out
+	if root, ok := g.Option(generator.ModelsOutOption); ok && root != "" {
+		outputRoot = root
+	}
 	if outputRoot == "" {
 		outputRoot = g.DefaultOutputDir()
 	}
+	if err := g.generatePackageInits(outputRoot, outputDir); err != nil {
+		return err
+	}
+	if err := g.generateSetup(outputRoot); err != nil {
+		return err
+	}
+	for _, option := range []string{generator.ScopesOutOption, generator.ServicesOutOption} {
+		if !g.ArtifactsSeparated(option) {
+			continue
+		}
+		root := g.Options[option]
+		dir := g.GetOutputDir(root)
+		if err := g.generatePackageInits(root, dir); err != nil {
+			return err
+		}
+		g.addPackageDir(dir)
+	}
+
+	// create types file
+	typesFile, err := g.GenerateFile("ttypes", outputDir, generator.ObjectFile)
+	if err != nil {
+		return err
+	}
+	if err = g.GenerateDocStringComment(typesFile); err != nil {
+		return err
+	}
+	if _, err = typesFile.WriteString("\n\n"); err != nil {
+		return err
+	}
+	if err = g.GenerateTypesImports(typesFile, false); err != nil {
+		return err
+	}
+	if _, err = typesFile.WriteString("\n\n"); err != nil {
+		return err
+	}
+	g.typesFile = typesFile
+
+	return nil
+}
 
+// addPackageDir tracks dir as a directory scopes or services are generated
+// in if it isn't outputDir or already tracked.
+func (g *Generator) addPackageDir(dir string) {
+	if dir == g.outputDir {
+		return
+	}
+	for _, packageDir := range g.packageDirs {
+		if packageDir == dir {
+			return
+		}
+	}
+	g.packageDirs = append(g.packageDirs, dir)
+}
+
+// generatePackageInits creates __init__ files in outputDir and each of its
+// parents up to outputRoot.
+func (g *Generator) generatePackageInits(outputRoot, outputDir string) error {
+	// To prevent littering the filesystem with __init__ in every folder between outputDir and the present working
+	// directory, use the relative path between the root output directory and the target outputDir. This creates
+	// __init__ files only in the folders used for frugal generation.
 	absoluteOutputRoot, err := filepath.Abs(outputRoot)
 	if err != nil {
 		return err
@@ -110,32 +195,20 @@ func (g *Generator) SetupGenerator(outputDir string) error {
 		dir = filepath.Dir(dir)
 	}
 
-	// create types file
-	typesFile, err := g.GenerateFile("ttypes", outputDir, generator.ObjectFile)
-	if err != nil {
-		return err
-	}
-	if err = g.GenerateDocStringComment(typesFile); err != nil {
-		return err
-	}
-	if _, err = typesFile.WriteString("\n\n"); err != nil {
-		return err
-	}
-	if err = g.GenerateTypesImports(typesFile, false); err != nil {
-		return err
-	}
-	if _, err = typesFile.WriteString("\n\n"); err != nil {
-		return err
-	}
-	g.typesFile = typesFile
-
 	return nil
 }
 
 // TeardownGenerator is run after generation.
 func (g *Generator) TeardownGenerator() error {
-	if err := g.generateInitFile(); err != nil {
-		return err
+	for _, dir := range append([]string{g.outputDir}, g.packageDirs...) {
+		if err := g.generateInitFile(dir); err != nil {
+			return err
+		}
+	}
+	if globals.TestVectors {
+		if err := g.generateTestVectorsTest(); err != nil {
+			return err
+		}
 	}
 
 	return g.typesFile.Close()
@@ -143,15 +216,15 @@ func (g *Generator) TeardownGenerator() error {
 
 // generateInit adds subpackage imports to __init__.py files
 // to simplify consumer import paths
-func (g *Generator) generateInitFile() error {
-	initFile, err := os.OpenFile(path.Join(g.outputDir, "__init__.py"), os.O_WRONLY, 0644)
+func (g *Generator) generateInitFile(dir string) error {
+	initFile, err := os.OpenFile(path.Join(dir, "__init__.py"), os.O_WRONLY, 0644)
 	if err != nil {
 		return err
 	}
 	defer initFile.Close()
 
 	imports := []string{}
-	if fileInfoSlice, ok := g.history[g.outputDir]; ok {
+	if fileInfoSlice, ok := g.history[dir]; ok {
 		for _, fileInfo := range fileInfoSlice {
 			switch fileInfo.fileType {
 			case generator.PublishFile:
@@ -355,7 +428,7 @@ func (g *Generator) GenerateEnum(enum *parser.Enum) error {
 
 // GenerateStruct generates the given struct.
 func (g *Generator) GenerateStruct(s *parser.Struct) error {
-	_, err := g.typesFile.WriteString(g.generateStruct(s))
+	_, err := g.typesFile.WriteString(g.generateStruct(s, false))
 	return err
 }
 
@@ -363,13 +436,13 @@ func (g *Generator) GenerateStruct(s *parser.Struct) error {
 func (g *Generator) GenerateUnion(union *parser.Struct) error {
 	// TODO 2.0 consider adding validation only one field is set,
 	// similar to other languages
-	_, err := g.typesFile.WriteString(g.generateStruct(union))
+	_, err := g.typesFile.WriteString(g.generateStruct(union, false))
 	return err
 }
 
 // GenerateException generates the given exception.
 func (g *Generator) GenerateException(exception *parser.Struct) error {
-	_, err := g.typesFile.WriteString(g.generateStruct(exception))
+	_, err := g.typesFile.WriteString(g.generateStruct(exception, false))
 	return err
 }
 
@@ -378,13 +451,13 @@ func (g *Generator) GenerateException(exception *parser.Struct) error {
 func (g *Generator) generateServiceArgsResults(service *parser.Service) string {
 	contents := ""
 	for _, s := range g.GetServiceMethodTypes(service) {
-		contents += g.generateStruct(s)
+		contents += g.generateStruct(s, true)
 	}
 	return contents
 }
 
 // generateStruct generates a python representation of a thrift struct
-func (g *Generator) generateStruct(s *parser.Struct) string {
+func (g *Generator) generateStruct(s *parser.Struct, isArgOrResult bool) string {
 	contents := ""
 
 	extends := "(object)"
@@ -396,6 +469,11 @@ func (g *Generator) generateStruct(s *parser.Struct) string {
 
 	contents += g.generateDefaultMarkers(s)
 	contents += g.generateInitMethod(s)
+	contents += g.generatePresenceMethods(s)
+	if !isArgOrResult {
+		contents += g.generateCopyMethods(s)
+		contents += g.generateJSONMethods(s)
+	}
 
 	contents += g.generateRead(s)
 	contents += g.generateWrite(s)
@@ -475,6 +553,55 @@ func (g *Generator) generateInitMethod(s *parser.Struct) string {
 	return contents
 }
 
+// generatePresenceMethods generates methods to check and clear the presence
+// of each optional field.
+func (g *Generator) generatePresenceMethods(s *parser.Struct) string {
+	contents := ""
+	for _, field := range s.Fields {
+		if field.Modifier != parser.Optional {
+			continue
+		}
+		contents += fmt.Sprintf(tab+"def is_set_%s(self):\n", field.Name)
+		contents += fmt.Sprintf(tabtab+"return self.%s is not None\n\n", field.Name)
+		contents += fmt.Sprintf(tab+"def unset_%s(self):\n", field.Name)
+		contents += fmt.Sprintf(tabtab+"self.%s = None\n\n", field.Name)
+	}
+	return contents
+}
+
+// generateCopyMethods generates deep_copy and, for structs and exceptions,
+// merge_from methods when the copy_merge option is set. merge_from sets each
+// field which isn't None in another instance to a copy of it.
+func (g *Generator) generateCopyMethods(s *parser.Struct) string {
+	if _, ok := g.Options[generator.CopyMergeOption]; !ok {
+		return ""
+	}
+
+	contents := tab + "def deep_copy(self):\n"
+	contents += tabtab + "return copy.deepcopy(self)\n\n"
+	if s.Type == parser.StructTypeUnion {
+		return contents
+	}
+
+	contents += tab + "def merge_from(self, other):\n"
+	if len(s.Fields) == 0 {
+		contents += tabtab + "return\n\n"
+		return contents
+	}
+	for _, field := range s.Fields {
+		underlyingType := g.Frugal.UnderlyingType(field.Type)
+		value := fmt.Sprintf("copy.deepcopy(other.%s)", field.Name)
+		if underlyingType.IsPrimitive() || g.Frugal.IsEnum(underlyingType) {
+			// Immutable values are shared
+			value = "other." + field.Name
+		}
+		contents += fmt.Sprintf(tabtab+"if other.%s is not None:\n", field.Name)
+		contents += fmt.Sprintf(tabtabtab+"self.%s = %s\n", field.Name, value)
+	}
+	contents += "\n"
+	return contents
+}
+
 // generateClassDocstring generates a docstring for class. This includes a
 // description of the class, if present, a list of attributes, and descriptions
 // of each attribute, if present.
@@ -587,6 +714,10 @@ func (g *Generator) generateValidate(s *parser.Struct) string {
 		contents += fmt.Sprintf(tabtabtab + "raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='The union did not have exactly one field set, {} were set'.format(set_fields))\n")
 	}
 
+	for _, field := range s.Fields {
+		contents += g.generateConstraintChecks(s, field)
+	}
+
 	contents += tabtab + "return\n\n"
 	return contents
 }
@@ -669,6 +800,7 @@ func (g *Generator) generateReadFieldRec(field *parser.Field, first bool, ind st
 		contents += fmt.Sprintf(ind+"%s%s = iprot.read%s()\n", prefix, field.Name, thriftType)
 	} else if isEnum {
 		contents += fmt.Sprintf(ind+"%s%s = %s(iprot.readI32())\n", prefix, field.Name, g.qualifiedTypeName(underlyingType))
+		contents += g.generateUnknownEnumCheck(prefix+field.Name, underlyingType, ind)
 	} else if g.Frugal.IsStruct(underlyingType) {
 		g.qualifiedTypeName(underlyingType)
 		contents += fmt.Sprintf(ind+"%s%s = %s()\n", prefix, field.Name, g.qualifiedTypeName(underlyingType))
@@ -822,9 +954,26 @@ func (g *Generator) GenerateFile(name, outputDir string, fileType generator.File
 		g.history[outputDir] = append(g.history[outputDir], genInfo{fileName, name, fileType})
 	}
 
+	g.fileType = fileType
 	return g.CreateFile(fileName, outputDir, lang, false)
 }
 
+// generateUnknownEnumCheck generates the handling of the enum value read into
+// the given variable when it isn't declared in the IDL.
+func (g *Generator) generateUnknownEnumCheck(name string, enumType *parser.Type, ind string) string {
+	typeName := g.qualifiedTypeName(enumType)
+	contents := ""
+	switch g.Options[generator.UnknownEnumsOption] {
+	case generator.UnknownEnumsError:
+		contents += fmt.Sprintf(ind+"if %s not in %s._VALUES_TO_NAMES:\n", name, typeName)
+		contents += fmt.Sprintf(ind+tab+"raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='Invalid value {} for enum %s'.format(%s))\n", enumType.Name, name)
+	case generator.UnknownEnumsSentinel:
+		contents += fmt.Sprintf(ind+"if %s not in %s._VALUES_TO_NAMES:\n", name, typeName)
+		contents += fmt.Sprintf(ind+tab+"%s = %s.%s\n", name, typeName, generator.UnknownEnumValue)
+	}
+	return contents
+}
+
 // GenerateDocStringComment generates the autogenerated notice.
 func (g *Generator) GenerateDocStringComment(file *os.File) error {
 	comment := fmt.Sprintf(
@@ -864,8 +1013,28 @@ func (g *Generator) GenerateTypesImports(file *os.File, isArgsOrResult bool) err
 	contents += "from frugal.util import make_hashable\n"
 	contents += "from thrift.transport import TTransport\n"
 	contents += "from thrift.protocol import TBinaryProtocol, TProtocol\n"
+	if g.usesPatterns() {
+		contents += "import re\n"
+	}
+	if _, ok := g.Options[generator.CopyMergeOption]; ok && !isArgsOrResult {
+		contents += "import copy\n"
+	}
+	if g.generateJSONHelpers() && !isArgsOrResult {
+		contents += "import base64\n"
+		contents += "import json\n"
+	}
 
-	_, err := file.WriteString(contents)
+	version, check, err := g.RuntimeCheckVersion()
+	if err != nil {
+		return err
+	}
+	if check && !isArgsOrResult {
+		contents += "from frugal.compatibility import require_version\n\n"
+		contents += "# Fail at startup if the Frugal library is incompatible with this code.\n"
+		contents += fmt.Sprintf("require_version('%s')\n", version)
+	}
+
+	_, err = file.WriteString(contents)
 	return err
 }
 
@@ -873,6 +1042,7 @@ func (g *Generator) GenerateTypesImports(file *os.File, isArgsOrResult bool) err
 func (g *Generator) GenerateServiceImports(file *os.File, s *parser.Service) error {
 	imports := "from threading import Lock\n\n"
 
+	imports += "from frugal.metadata import FContractMetadata\n"
 	imports += "from frugal.middleware import Method\n"
 	imports += "from frugal.exceptions import TApplicationExceptionType\n"
 	imports += "from frugal.exceptions import TTransportExceptionType\n"
@@ -927,7 +1097,7 @@ func (g *Generator) generateServiceIncludeImports(s *parser.Service) (string, er
 	}
 
 	// Import this service's modules.
-	imports += "from .ttypes import *\n"
+	imports += g.generateTypesImport(generator.ServicesOutOption)
 
 	return imports, nil
 }
@@ -935,8 +1105,13 @@ func (g *Generator) generateServiceIncludeImports(s *parser.Service) (string, er
 // GenerateScopeImports generates necessary imports for the given scope.
 func (g *Generator) GenerateScopeImports(file *os.File, s *parser.Scope) error {
 	imports := "from thrift.Thrift import TMessageType\n"
+	// Only publishers are generated, so subscriber files have no metadata.
+	if g.fileType != generator.SubscribeFile {
+		imports += "from frugal.metadata import FContractMetadata\n"
+	}
 	imports += "from frugal.middleware import Method\n"
 	imports += "from frugal.transport import TMemoryOutputBuffer\n"
+	imports += g.generateBatchFrameImports()
 	_, err := file.WriteString(imports)
 	return err
 }
@@ -948,14 +1123,19 @@ func (g *Generator) GenerateConstants(file *os.File, name string) error {
 
 // GeneratePublisher generates the publisher for the given scope.
 func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error {
+	g.WarnUnsupportedChunks("py", scope)
+	g.WarnUnsupportedSampling("py", scope)
+
 	publisher := ""
 	publisher += fmt.Sprintf("class %sPublisher(object):\n", scope.Name)
-	if scope.Comment != nil {
-		publisher += g.generateDocString(scope.Comment, tab)
+	if comment := scope.DocComment(); comment != nil {
+		publisher += g.generateDocString(comment, tab)
 	}
 	publisher += "\n"
 
 	publisher += tab + fmt.Sprintf("_DELIMITER = '%s'\n\n", globals.TopicDelimiter)
+	publisher += g.generateContractMetadata(g.ScopeMetadata(scope))
+	publisher += g.generatePrefixVariableMethod(scope)
 
 	publisher += tab + "def __init__(self, provider, middleware=None):\n"
 	publisher += g.generateDocString([]string{
@@ -974,8 +1154,13 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 	publisher += tabtab + "self._methods = {\n"
 	for _, op := range scope.Operations {
 		publisher += tabtabtab + fmt.Sprintf("'publish_%s': Method(self._publish_%s, middleware),\n", op.Name, op.Name)
+		if g.useBatchPublish() {
+			publisher += tabtabtab + fmt.Sprintf("'publish_%s_batch': Method(self._publish_%s_batch, middleware),\n", op.Name, op.Name)
+		}
 	}
-	publisher += tabtab + "}\n\n"
+	publisher += tabtab + "}\n"
+	publisher += g.generateHookAttribute("on_before_publish", "before each publish")
+	publisher += "\n"
 
 	asyncOpt := getAsyncOpt(g.Options)
 	publisher += tab
@@ -1018,6 +1203,9 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 	for _, op := range scope.Operations {
 		publisher += prefix + g.generatePublishMethod(scope, op)
 		prefix = "\n\n"
+		if g.useBatchPublish() {
+			publisher += prefix + g.generatePublishBatchMethod(scope, op)
+		}
 	}
 
 	_, err := file.WriteString(publisher)
@@ -1038,9 +1226,9 @@ func (g *Generator) generatePublishMethod(scope *parser.Scope, op *parser.Operat
 		args += ", "
 	}
 	docstr = append(docstr, tab+fmt.Sprintf("req: %s", op.Type.Name))
-	if op.Comment != nil {
+	if comment := op.DocComment(); comment != nil {
 		docstr[0] = "\n" + tabtab + docstr[0]
-		docstr = append(op.Comment, docstr...)
+		docstr = append(comment, docstr...)
 	}
 
 	method := tab
@@ -1069,13 +1257,15 @@ func (g *Generator) generatePublishMethod(scope *parser.Scope, op *parser.Operat
 		method += "async "
 	}
 	method += fmt.Sprintf("def _publish_%s(self, ctx, %sreq):\n", op.Name, args)
+	method += g.generatePrefixVariableChecks(scope)
 	// Inject the prefix variables into the FContext to send
 	for _, prefixVar := range scope.Prefix.Variables {
 		method += fmt.Sprintf(tabtab+"ctx.set_request_header('_topic_%s', %s)\n", prefixVar, prefixVar)
 	}
 	method += tabtab + fmt.Sprintf("op = '%s'\n", op.Name)
 	method += tabtab + fmt.Sprintf("prefix = %s\n", generatePrefixStringTemplate(scope))
-	method += tabtab + fmt.Sprintf("topic = '{}%s{}{}'.format(prefix, self._DELIMITER, op)\n", scope.Name)
+	method += tabtab + fmt.Sprintf("topic = '{}%s{}{}'.format(prefix, self._DELIMITER, op)\n", scope.TopicName(scope.Name, globals.TopicDelimiter))
+	method += g.generateHookCall("on_before_publish", tabtab)
 	method += tabtab + "buffer = TMemoryOutputBuffer(self._transport.get_publish_size_limit())\n"
 	method += tabtab + "oprot = self._protocol_factory.get_protocol(buffer)\n"
 	method += tabtab + "oprot.write_request_headers(ctx)\n"
@@ -1094,6 +1284,40 @@ func (g *Generator) generatePublishMethod(scope *parser.Scope, op *parser.Operat
 	return method
 }
 
+// generatePrefixVariableMethod generates the method checking the values of the
+// scope's prefix variables for the prefix_validation option.
+func (g *Generator) generatePrefixVariableMethod(scope *parser.Scope) string {
+	behavior, ok := g.Options[generator.PrefixValidationOption]
+	if !ok || len(scope.Prefix.Variables) == 0 {
+		return ""
+	}
+	contents := tab + "@classmethod\n"
+	contents += tab + "def _prefix_variable(cls, name, value):\n"
+	contents += tabtab + "if not value:\n"
+	contents += tabtabtab + "raise ValueError('topic prefix variable {} is empty'.format(name))\n"
+	if behavior == generator.PrefixValidationError {
+		contents += tabtab + "if cls._DELIMITER in value:\n"
+		contents += tabtabtab + "raise ValueError('topic prefix variable {} contains the topic delimiter: {}'.format(name, value))\n"
+		contents += tabtab + "return value\n\n"
+	} else {
+		contents += tabtab + fmt.Sprintf("return value.replace(cls._DELIMITER, '%s')\n\n", generator.PrefixSanitizeReplacement)
+	}
+	return contents
+}
+
+// generatePrefixVariableChecks generates the checks of the scope's prefix
+// variables for the prefix_validation option.
+func (g *Generator) generatePrefixVariableChecks(scope *parser.Scope) string {
+	if _, ok := g.Options[generator.PrefixValidationOption]; !ok {
+		return ""
+	}
+	contents := ""
+	for _, variable := range scope.Prefix.Variables {
+		contents += tabtab + fmt.Sprintf("%s = self._prefix_variable('%s', %s)\n", variable, variable, variable)
+	}
+	return contents
+}
+
 func generatePrefixStringTemplate(scope *parser.Scope) string {
 	if len(scope.Prefix.Variables) == 0 {
 		if scope.Prefix.String == "" {
@@ -1114,7 +1338,7 @@ func generatePrefixStringTemplate(scope *parser.Scope) string {
 // GenerateSubscriber generates the subscriber for the given scope.
 func (g *Generator) GenerateSubscriber(file *os.File, scope *parser.Scope) error {
 	// TODO
-	globals.PrintWarning(fmt.Sprintf("%s: scope subscriber generation is not implemented for vanilla Python 2.7. For 2.7, use the Tornado framework (where available) or provide a pull request", scope.Name))
+	globals.Warn(fmt.Sprintf("%s: scope subscriber generation is not implemented for vanilla Python 2.7. For 2.7, use the Tornado framework (where available) or provide a pull request", scope.Name))
 	return nil
 }
 
@@ -1275,6 +1499,54 @@ func (g *Generator) generateServer(service *parser.Service) string {
 	return contents
 }
 
+// generateContractMetadata generates the FContractMetadata class attribute of a
+// scope or service class.
+func (g *Generator) generateContractMetadata(metadata *generator.ContractMetadata) string {
+	operations := make([]string, len(metadata.Operations))
+	for i, operation := range metadata.Operations {
+		operations[i] = fmt.Sprintf("'%s'", operation)
+	}
+
+	contents := tab + "metadata = FContractMetadata(\n"
+	contents += tabtab + fmt.Sprintf("'%s', '%s', '%s',\n", metadata.IDLFile, metadata.Kind, metadata.Name)
+	contents += tabtab + fmt.Sprintf("'%s', '%s',\n", metadata.Hash, metadata.CompilerVersion)
+	if len(metadata.Descriptions) == 0 {
+		contents += tabtab + fmt.Sprintf("[%s])\n\n", strings.Join(operations, ", "))
+		return contents
+	}
+	contents += tabtab + fmt.Sprintf("[%s],\n", strings.Join(operations, ", "))
+	contents += tabtab + "{\n"
+	for _, operation := range metadata.Operations {
+		if description, ok := metadata.Descriptions[operation]; ok {
+			contents += tabtabtab + fmt.Sprintf("'%s': %s,\n", operation, g.quote(description))
+		}
+	}
+	contents += tabtab + "})\n\n"
+	return contents
+}
+
+// generateHookAttribute generates the initialization of the named hook
+// attribute of a publisher or subscriber if the hooks option is set.
+func (g *Generator) generateHookAttribute(hook, when string) string {
+	if _, ok := g.Options[generator.HooksOption]; !ok {
+		return ""
+	}
+	contents := tabtab + fmt.Sprintf("# Called with the FContext and operation name %s.\n", when)
+	contents += tabtab + fmt.Sprintf("self.%s = None\n", hook)
+	return contents
+}
+
+// generateHookCall generates the call of the named hook attribute, if it's
+// set, if the hooks option is set.
+func (g *Generator) generateHookCall(hook, indent string) string {
+	if _, ok := g.Options[generator.HooksOption]; !ok {
+		return ""
+	}
+	contents := indent + fmt.Sprintf("if self.%s is not None:\n", hook)
+	contents += indent + tab + fmt.Sprintf("self.%s(ctx, op)\n", hook)
+	return contents
+}
+
 func (g *Generator) generateServiceInterface(service *parser.Service) string {
 	contents := ""
 	if service.Extends != "" {
@@ -1282,10 +1554,11 @@ func (g *Generator) generateServiceInterface(service *parser.Service) string {
 	} else {
 		contents += "class Iface(object):\n"
 	}
-	if service.Comment != nil {
-		contents += g.generateDocString(service.Comment, tab)
+	if comment := service.DocComment(); comment != nil {
+		contents += g.generateDocString(comment, tab)
 	}
 	contents += "\n"
+	contents += g.generateContractMetadata(g.ServiceMetadata(service))
 
 	for _, method := range service.Methods {
 		contents += g.generateMethodSignature(method)
@@ -1548,6 +1821,20 @@ func (g *Generator) getTType(t *parser.Type) string {
 	return "TType." + ttype
 }
 
+// generateTypesImport returns the import of the types of the Frugal file for
+// the artifacts of the given output directory option, which is absolute if
+// they're generated apart from the types.
+func (g *Generator) generateTypesImport(option string) string {
+	if !g.ArtifactsSeparated(option) {
+		return "from .ttypes import *\n"
+	}
+	name := g.Frugal.Name
+	if namespace := g.Frugal.Namespace(lang); namespace != nil {
+		name = namespace.Value
+	}
+	return fmt.Sprintf("from %s%s.ttypes import *\n", g.Options["package_prefix"], name)
+}
+
 func (g *Generator) getPackageNamespace(include string) string {
 	name := include
 	if namespace := g.Frugal.NamespaceForInclude(include, lang); namespace != nil {
@@ -1568,3 +1855,56 @@ func getAsyncOpt(options map[string]string) concurrencyModel {
 func (g *Generator) UseVendor() bool {
 	return false
 }
+
+// generateConstraintChecks generates checks in the validate method that the
+// field, if set, satisfies its constraints.
+func (g *Generator) generateConstraintChecks(s *parser.Struct, field *parser.Field) string {
+	constraints := field.Constraints()
+	if constraints == nil {
+		return ""
+	}
+	value := "self." + field.Name
+	bounded, what := value, ""
+	if g.Frugal.ConstrainsLength(field.Type) {
+		bounded, what = fmt.Sprintf("len(%s)", value), "length "
+	}
+
+	contents := ""
+	check := func(condition, message string) {
+		contents += fmt.Sprintf(tabtab+"if %s is not None and %s:\n", value, condition)
+		contents += fmt.Sprintf(tabtabtab+"raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message=%s)\n", g.quote(message))
+	}
+	if constraints.Min != "" {
+		check(fmt.Sprintf("%s < %s", bounded, constraints.Min),
+			fmt.Sprintf("%s.%s %smust be at least %s", s.Name, field.Name, what, constraints.Min))
+	}
+	if constraints.Max != "" {
+		check(fmt.Sprintf("%s > %s", bounded, constraints.Max),
+			fmt.Sprintf("%s.%s %smust be at most %s", s.Name, field.Name, what, constraints.Max))
+	}
+	if constraints.Pattern != "" {
+		check(fmt.Sprintf("re.search(%s, %s) is None", g.quote(constraints.Pattern), value),
+			fmt.Sprintf("%s.%s must match %s", s.Name, field.Name, constraints.Pattern))
+	}
+	return contents
+}
+
+// usesPatterns returns true if a struct or service method argument in the
+// file has a pattern constraint.
+func (g *Generator) usesPatterns() bool {
+	fields := []*parser.Field{}
+	for _, s := range g.Frugal.DataStructures() {
+		fields = append(fields, s.Fields...)
+	}
+	for _, service := range g.Frugal.Services {
+		for _, method := range service.Methods {
+			fields = append(fields, method.Arguments...)
+		}
+	}
+	for _, field := range fields {
+		if constraints := field.Constraints(); constraints != nil && constraints.Pattern != "" {
+			return true
+		}
+	}
+	return false
+}
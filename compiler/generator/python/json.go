@@ -0,0 +1,187 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package python
+
+import (
+	"fmt"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// generateJSONMethods generates to_json, to_json_value, from_json, and
+// from_json_value methods when the json option is set, encoding the struct the
+// same way as the other languages. Structs are encoded as objects keyed by
+// field name, omitting fields which are None. Binary values are base64
+// encoded, enums are encoded by name, and sets are encoded as arrays. Maps
+// with string, integer, or enum keys are encoded as objects and other maps as
+// arrays of key-value pairs.
+func (g *Generator) generateJSONMethods(s *parser.Struct) string {
+	if !g.generateJSONHelpers() {
+		return ""
+	}
+
+	contents := tab + "def to_json(self):\n"
+	contents += g.generateDocString([]string{fmt.Sprintf("Returns the JSON encoding of the %s.", s.Name)}, tabtab)
+	contents += tabtab + "return json.dumps(self.to_json_value())\n\n"
+
+	contents += tab + "def to_json_value(self):\n"
+	contents += g.generateDocString([]string{"Returns the dict encoded by to_json."}, tabtab)
+	contents += tabtab + "value = {}\n"
+	for _, field := range s.Fields {
+		contents += fmt.Sprintf(tabtab+"if self.%s is not None:\n", field.Name)
+		contents += fmt.Sprintf(tabtabtab+"value['%s'] = %s\n", field.Name, g.toJSONValue("self."+field.Name, field.Type, 1))
+	}
+	contents += tabtab + "return value\n\n"
+
+	contents += tab + "def from_json(self, data):\n"
+	contents += g.generateDocString([]string{
+		fmt.Sprintf("Sets the fields of the %s from the JSON encoding produced by", s.Name),
+		"to_json and returns it. Fields missing from the JSON are left unchanged.",
+	}, tabtab)
+	contents += tabtab + "try:\n"
+	contents += tabtabtab + "value = json.loads(data)\n"
+	contents += tabtab + "except ValueError as e:\n"
+	contents += tabtabtab + "raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message=str(e))\n"
+	contents += tabtab + "return self.from_json_value(value)\n\n"
+
+	contents += tab + "def from_json_value(self, value):\n"
+	contents += g.generateDocString([]string{
+		fmt.Sprintf("Sets the fields of the %s from the dict returned by", s.Name),
+		"to_json_value and returns it.",
+	}, tabtab)
+	contents += tabtab + "if not isinstance(value, dict):\n"
+	contents += fmt.Sprintf(tabtabtab+"raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='%s JSON must be an object')\n", s.Name)
+	for _, field := range s.Fields {
+		contents += fmt.Sprintf(tabtab+"if '%s' in value:\n", field.Name)
+		contents += tabtabtab + "try:\n"
+		contents += fmt.Sprintf(tabtabtabtab+"self.%s = %s\n", field.Name, g.fromJSONValue(fmt.Sprintf("value['%s']", field.Name), field.Type, 1))
+		contents += tabtabtab + "except Exception as e:\n"
+		contents += fmt.Sprintf(tabtabtabtab+"raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field %s: {}'.format(e))\n", field.Name)
+	}
+	contents += tabtab + "return self\n\n"
+	return contents
+}
+
+// toJSONValue returns an expression converting the value to the JSON value
+// described by generateJSONMethods.
+func (g *Generator) toJSONValue(value string, t *parser.Type, depth int) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	elem := fmt.Sprintf("elem%d", depth)
+	switch {
+	case g.Frugal.IsStruct(underlying):
+		return value + ".to_json_value()"
+	case g.Frugal.IsEnum(underlying):
+		return fmt.Sprintf("%s._VALUES_TO_NAMES[%s]", g.qualifiedTypeName(underlying), value)
+	case underlying.Name == "binary":
+		return fmt.Sprintf("base64.b64encode(%s).decode('ascii')", value)
+	case underlying.Name == "list", underlying.Name == "set":
+		converted := g.toJSONValue(elem, underlying.ValueType, depth+1)
+		if converted == elem {
+			return fmt.Sprintf("list(%s)", value)
+		}
+		return fmt.Sprintf("[%s for %s in %s]", converted, elem, value)
+	case underlying.Name == "map":
+		key := fmt.Sprintf("key%d", depth)
+		if g.isJSONObjectKey(underlying.KeyType) {
+			return fmt.Sprintf("{%s: %s for %s, %s in %s.items()}", g.jsonObjectKey(key, underlying.KeyType),
+				g.toJSONValue(elem, underlying.ValueType, depth+1), key, elem, value)
+		}
+		return fmt.Sprintf("[[%s, %s] for %s, %s in %s.items()]", g.toJSONValue(key, underlying.KeyType, depth+1),
+			g.toJSONValue(elem, underlying.ValueType, depth+1), key, elem, value)
+	default:
+		return value
+	}
+}
+
+// fromJSONValue returns an expression converting the JSON value back to a
+// value of the given type.
+func (g *Generator) fromJSONValue(value string, t *parser.Type, depth int) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	elem := fmt.Sprintf("elem%d", depth)
+	switch {
+	case g.Frugal.IsStruct(underlying):
+		return fmt.Sprintf("%s().from_json_value(%s)", g.qualifiedTypeName(underlying), value)
+	case g.Frugal.IsEnum(underlying):
+		return fmt.Sprintf("%s._NAMES_TO_VALUES[%s]", g.qualifiedTypeName(underlying), value)
+	case underlying.Name == "binary":
+		return fmt.Sprintf("base64.b64decode(%s)", value)
+	case underlying.Name == "double":
+		return fmt.Sprintf("float(%s)", value)
+	case underlying.Name == "list", underlying.Name == "set":
+		converted := g.fromJSONValue(elem, underlying.ValueType, depth+1)
+		if converted == elem {
+			return fmt.Sprintf("%s(%s)", underlying.Name, value)
+		}
+		if underlying.Name == "set" {
+			return fmt.Sprintf("set(%s for %s in %s)", converted, elem, value)
+		}
+		return fmt.Sprintf("[%s for %s in %s]", converted, elem, value)
+	case underlying.Name == "map":
+		key := fmt.Sprintf("key%d", depth)
+		if g.isJSONObjectKey(underlying.KeyType) {
+			return fmt.Sprintf("{%s: %s for %s, %s in %s.items()}", g.parseJSONObjectKey(key, underlying.KeyType),
+				g.fromJSONValue(elem, underlying.ValueType, depth+1), key, elem, value)
+		}
+		pair := fmt.Sprintf("pair%d", depth)
+		return fmt.Sprintf("{%s: %s for %s in %s}", g.fromJSONValue(pair+"[0]", underlying.KeyType, depth+1),
+			g.fromJSONValue(pair+"[1]", underlying.ValueType, depth+1), pair, value)
+	default:
+		return value
+	}
+}
+
+// isJSONObjectKey returns true if maps with keys of the type are encoded as
+// JSON objects.
+func (g *Generator) isJSONObjectKey(t *parser.Type) bool {
+	underlying := g.Frugal.UnderlyingType(t)
+	switch underlying.Name {
+	case "string", "byte", "i8", "i16", "i32", "i64":
+		return true
+	}
+	return g.Frugal.IsEnum(underlying)
+}
+
+// jsonObjectKey returns an expression converting the map key to its JSON
+// object key.
+func (g *Generator) jsonObjectKey(key string, t *parser.Type) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsEnum(underlying):
+		return fmt.Sprintf("%s._VALUES_TO_NAMES[%s]", g.qualifiedTypeName(underlying), key)
+	case underlying.Name == "string":
+		return key
+	default:
+		return fmt.Sprintf("str(%s)", key)
+	}
+}
+
+// parseJSONObjectKey returns an expression converting the JSON object key
+// back to the map key.
+func (g *Generator) parseJSONObjectKey(key string, t *parser.Type) string {
+	underlying := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsEnum(underlying):
+		return fmt.Sprintf("%s._NAMES_TO_VALUES[%s]", g.qualifiedTypeName(underlying), key)
+	case underlying.Name == "string":
+		return key
+	default:
+		return fmt.Sprintf("int(%s)", key)
+	}
+}
+
+func (g *Generator) generateJSONHelpers() bool {
+	_, ok := g.Options[generator.JSONOption]
+	return ok
+}
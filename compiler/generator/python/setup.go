@@ -0,0 +1,84 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package python
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/globals"
+)
+
+const setupOption = "setup"
+
+// useSetup indicates if a setup.py should be generated.
+func (g *Generator) useSetup() bool {
+	_, ok := g.Options[setupOption]
+	return ok
+}
+
+// validateSetup ensures all generated packages are under the directory the
+// setup.py builds.
+func (g *Generator) validateSetup() error {
+	if !g.useSetup() {
+		return nil
+	}
+	for _, option := range []string{generator.ScopesOutOption, generator.ServicesOutOption} {
+		if g.ArtifactsSeparated(option) {
+			return fmt.Errorf("py option %s can't be used with %s", setupOption, option)
+		}
+	}
+	return nil
+}
+
+// generateSetup writes a setup.py packaging the generated modules to the
+// output root, i.e. the directory the top-level package is in. Only the first
+// file compiled writes it, since included files generated with it share the
+// root. The distribution name is the option's value, if any, or the file's
+// name.
+func (g *Generator) generateSetup(outputRoot string) error {
+	if !g.useSetup() || g.setupWritten {
+		return nil
+	}
+	g.setupWritten = true
+
+	name := g.Options[setupOption]
+	if name == "" {
+		name = strings.Replace(g.Frugal.Name, "_", "-", -1)
+	}
+	requirement := "frugal"
+	switch getAsyncOpt(g.Options) {
+	case tornado:
+		requirement += "[tornado]"
+	case asyncio:
+		requirement += "[asyncio]"
+	}
+
+	contents := fmt.Sprintf("# Autogenerated by Frugal Compiler (%s)\n", globals.Version)
+	contents += "# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING\n\n"
+	contents += "from setuptools import setup, find_packages\n\n"
+	contents += "setup(\n"
+	contents += fmt.Sprintf(tab+"name='%s',\n", name)
+	contents += fmt.Sprintf(tab+"version='%s',\n", globals.Version)
+	contents += tab + "packages=find_packages(),\n"
+	contents += tab + "install_requires=[\n"
+	contents += fmt.Sprintf(tabtab+"'%s==%s',\n", requirement, globals.Version)
+	contents += tab + "],\n"
+	contents += ")\n"
+
+	return ioutil.WriteFile(filepath.Join(outputRoot, "setup.py"), []byte(contents), 0644)
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package python
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/Workiva/frugal/compiler/generator"
+)
+
+// generateTestVectorsTest generates a unittest module decoding the test vector
+// of each struct, union, and exception with each protocol and checking that
+// writing the decoded value with the binary protocol reproduces the binary
+// vector, which locks in the wire format other languages encode. Protocols
+// which can't encode a struct are skipped.
+func (g *Generator) generateTestVectorsTest() error {
+	structs := g.Frugal.DataStructures()
+	if len(structs) == 0 {
+		return nil
+	}
+	file, err := g.CreateFile("test_vectors_test", g.outputDir, lang, false)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := g.GenerateDocStringComment(file); err != nil {
+		return err
+	}
+
+	contents := "\n\n"
+	contents += "import base64\n"
+	contents += "import unittest\n\n"
+	contents += "from thrift.protocol.TBinaryProtocol import TBinaryProtocolFactory\n"
+	contents += "from thrift.protocol.TCompactProtocol import TCompactProtocolFactory\n"
+	contents += "from thrift.protocol.TJSONProtocol import TJSONProtocolFactory\n"
+	contents += "from thrift.TSerialization import deserialize, serialize\n\n"
+	contents += "from .ttypes import *\n\n\n"
+
+	contents += "class TestVectorsTest(unittest.TestCase):\n"
+	contents += g.generateDocString([]string{
+		"Decodes the test vector of each struct, union, and exception with each",
+		"protocol and checks that writing the decoded value with the binary",
+		"protocol reproduces the binary vector.",
+	}, tab)
+	contents += "\n"
+	contents += tab + "def check_test_vector(self, binary, factory, vector, value):\n"
+	contents += tabtab + "deserialize(value, base64.b64decode(vector), factory)\n"
+	contents += tabtab + "self.assertEqual(base64.b64decode(binary), serialize(value, TBinaryProtocolFactory()))\n"
+
+	for _, s := range structs {
+		binary, err := generator.TestVector(g.Frugal, s, "binary")
+		if err != nil {
+			// Every protocol fails to build the vector if binary does.
+			continue
+		}
+		contents += "\n"
+		contents += tab + fmt.Sprintf("def test_%s(self):\n", s.Name)
+		contents += tabtab + fmt.Sprintf("binary = '%s'\n", base64.StdEncoding.EncodeToString(binary))
+		for _, protocol := range generator.TestVectorProtocols {
+			vector, err := generator.TestVector(g.Frugal, s, protocol)
+			if err != nil {
+				continue
+			}
+			literal := "binary"
+			if protocol != "binary" {
+				literal = fmt.Sprintf("'%s'", base64.StdEncoding.EncodeToString(vector))
+			}
+			contents += tabtab + fmt.Sprintf("self.check_test_vector(binary, %s(), %s, %s())\n",
+				pythonProtocolFactories[protocol], literal, s.Name)
+		}
+	}
+
+	_, err = file.WriteString(contents)
+	return err
+}
+
+// pythonProtocolFactories are the protocol factory classes of each test
+// vector protocol.
+var pythonProtocolFactories = map[string]string{
+	"binary":  "TBinaryProtocolFactory",
+	"compact": "TCompactProtocolFactory",
+	"json":    "TJSONProtocolFactory",
+}
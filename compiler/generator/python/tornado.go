@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Workiva/frugal/compiler/generator"
 	"github.com/Workiva/frugal/compiler/globals"
 	"github.com/Workiva/frugal/compiler/parser"
 )
@@ -34,6 +35,7 @@ func (t *TornadoGenerator) GenerateServiceImports(file *os.File, s *parser.Servi
 
 	imports += "from frugal.exceptions import TApplicationExceptionType\n"
 	imports += "from frugal.exceptions import TTransportExceptionType\n"
+	imports += "from frugal.metadata import FContractMetadata\n"
 	imports += "from frugal.middleware import Method\n"
 	imports += "from frugal.tornado.processor import FBaseProcessor\n"
 	imports += "from frugal.tornado.processor import FProcessorFunction\n"
@@ -67,11 +69,14 @@ func (t *TornadoGenerator) GenerateScopeImports(file *os.File, s *parser.Scope)
 	imports += "from thrift.Thrift import TType\n"
 	imports += "from tornado import gen\n"
 	imports += "from frugal.exceptions import TApplicationExceptionType\n"
+	imports += "from frugal.metadata import FContractMetadata\n"
 	imports += "from frugal.middleware import Method\n"
 	imports += "from frugal.subscription import FSubscription\n"
-	imports += "from frugal.transport import TMemoryOutputBuffer\n\n"
+	imports += "from frugal.transport import TMemoryOutputBuffer\n"
+	imports += t.generateBatchFrameImports()
+	imports += "\n"
 
-	imports += "from .ttypes import *\n"
+	imports += t.generateTypesImport(generator.ScopesOutOption)
 	_, err := file.WriteString(imports)
 	return err
 }
@@ -230,12 +235,14 @@ func (t *TornadoGenerator) generateProcessorFunction(method *parser.Method) stri
 func (t *TornadoGenerator) GenerateSubscriber(file *os.File, scope *parser.Scope) error {
 	subscriber := ""
 	subscriber += fmt.Sprintf("class %sSubscriber(object):\n", scope.Name)
-	if scope.Comment != nil {
-		subscriber += t.generateDocString(scope.Comment, tab)
+	if comment := scope.DocComment(); comment != nil {
+		subscriber += t.generateDocString(comment, tab)
 	}
 	subscriber += "\n"
 
 	subscriber += tab + fmt.Sprintf("_DELIMITER = '%s'\n\n", globals.TopicDelimiter)
+	subscriber += t.generateContractMetadata(t.ScopeMetadata(scope))
+	subscriber += t.generatePrefixVariableMethod(scope)
 
 	subscriber += tab + "def __init__(self, provider, middleware=None):\n"
 	subscriber += t.generateDocString([]string{
@@ -250,7 +257,9 @@ func (t *TornadoGenerator) GenerateSubscriber(file *os.File, scope *parser.Scope
 	subscriber += tabtabtab + "middleware = [middleware]\n"
 	subscriber += tabtab + "middleware += provider.get_middleware()\n"
 	subscriber += tabtab + "self._middleware = middleware\n"
-	subscriber += tabtab + "self._provider = provider\n\n"
+	subscriber += tabtab + "self._provider = provider\n"
+	subscriber += t.generateHookAttribute("on_after_receive", "after each message is received")
+	subscriber += "\n"
 
 	for _, op := range scope.Operations {
 		subscriber += t.generateSubscribeMethod(scope, op)
@@ -275,23 +284,23 @@ func (t *TornadoGenerator) generateSubscribeMethod(scope *parser.Scope, op *pars
 		args += ", "
 	}
 	docstr = append(docstr, tab+fmt.Sprintf("%s_handler: function which takes FContext and %s", op.Name, op.Type))
-	if op.Comment != nil {
+	if comment := op.DocComment(); comment != nil {
 		docstr[0] = "\n" + tabtab + docstr[0]
-		docstr = append(op.Comment, docstr...)
+		docstr = append(comment, docstr...)
 	}
 	method := tab + "@gen.coroutine\n"
 	method += tab + fmt.Sprintf("def subscribe_%s(self, %s%s_handler):\n", op.Name, args, op.Name)
 	method += t.generateDocString(docstr, tabtab)
 	method += "\n"
 
+	method += t.generatePrefixVariableChecks(scope)
 	method += tabtab + fmt.Sprintf("op = '%s'\n", op.Name)
 	method += tabtab + fmt.Sprintf("prefix = %s\n", generatePrefixStringTemplate(scope))
-	method += tabtab + fmt.Sprintf("topic = '{}%s{}{}'.format(prefix, self._DELIMITER, op)\n\n", scope.Name)
+	method += tabtab + fmt.Sprintf("topic = '{}%s{}{}'.format(prefix, self._DELIMITER, op)\n\n", scope.TopicName(scope.Name, globals.TopicDelimiter))
 
 	method += tabtab + "transport, protocol_factory = self._provider.new_subscriber()\n"
-	method += tabtab + fmt.Sprintf(
-		"yield transport.subscribe(topic, self._recv_%s(protocol_factory, op, %s_handler))\n",
-		op.Name, op.Name)
+	method += tabtab + fmt.Sprintf("yield transport.subscribe(topic, %s)\n",
+		t.wrapBatchFrameCallback(fmt.Sprintf("self._recv_%s(protocol_factory, op, %s_handler)", op.Name, op.Name)))
 	method += tabtab + "raise gen.Return(FSubscription(topic, transport))\n\n"
 
 	method += tab + fmt.Sprintf("def _recv_%s(self, protocol_factory, op, handler):\n", op.Name)
@@ -307,6 +316,7 @@ func (t *TornadoGenerator) generateSubscribeMethod(scope *parser.Scope, op *pars
 	method += tabtabtabtab + "raise TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD)\n"
 	method += t.generateReadFieldRec(parser.FieldFromType(op.Type, "req"), false, tabtabtab)
 	method += tabtabtab + "iprot.readMessageEnd()\n"
+	method += t.generateHookCall("on_after_receive", tabtabtab)
 	method += tabtabtab + "try:\n"
 	method += tabtabtabtab + "method([ctx, req])\n"
 	method += tabtabtab + "except:\n"
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generator
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// ExternalPrefix prefixes the names of executables which generate code for
+// languages which aren't registered, e.g. "frugal-gen-ts" for "ts".
+const ExternalPrefix = "frugal-gen-"
+
+// Factory creates a ProgramGenerator with the given generator options.
+type Factory func(options map[string]string) ProgramGenerator
+
+var factories = make(map[string]Factory)
+
+// Register makes a generator available for the language along with the
+// options it supports. Options which aren't listed are rejected, unless
+// options is nil, in which case the generator validates its own options.
+// Register panics if a generator is already registered for the language.
+func Register(lang string, options Options, factory Factory) {
+	if factory == nil {
+		panic(fmt.Sprintf("generator: Register factory for %s is nil", lang))
+	}
+	if _, ok := factories[lang]; ok {
+		panic(fmt.Sprintf("generator: Register called twice for %s", lang))
+	}
+	factories[lang] = factory
+	Languages[lang] = options
+}
+
+// New returns a ProgramGenerator for the language with the given options.
+// Languages which aren't registered are looked up as an executable named
+// with ExternalPrefix on the PATH, which is registered when found. It returns
+// false if there is no generator for the language.
+func New(lang string, options map[string]string) (ProgramGenerator, bool) {
+	factory, ok := lookup(lang)
+	if !ok {
+		return nil, false
+	}
+	return factory(options), true
+}
+
+// lookup returns the factory of the language, discovering an external
+// generator if none is registered.
+func lookup(lang string) (Factory, bool) {
+	if factory, ok := factories[lang]; ok {
+		return factory, true
+	}
+	if lang == "" {
+		return nil, false
+	}
+	path, err := exec.LookPath(ExternalPrefix + lang)
+	if err != nil {
+		return nil, false
+	}
+	Register(lang, nil, func(options map[string]string) ProgramGenerator {
+		return NewExternalGenerator(lang, path, options)
+	})
+	return factories[lang], true
+}
@@ -0,0 +1,691 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generator
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// TestVectorProtocols are the protocols test vectors are encoded with.
+var TestVectorProtocols = []string{"binary", "compact", "json"}
+
+// Thrift type IDs.
+const (
+	tStop   = 0
+	tBool   = 2
+	tByte   = 3
+	tDouble = 4
+	tI16    = 6
+	tI32    = 8
+	tI64    = 10
+	tString = 11
+	tStruct = 12
+	tMap    = 13
+	tSet    = 14
+	tList   = 15
+)
+
+// TestVector returns the encoding of the test vector instance of the given
+// struct, union, or exception with the given protocol. The instance is
+// deterministic: every field is set, except to break recursion, with integers
+// set to the field ID, doubles to the field ID plus a half, strings to the
+// field name, binaries to the bytes 1, 2, 3, enums to their first value, and
+// containers to one element. Unions set their first field. Fields are written
+// in declaration order, as the generated code writes them.
+func TestVector(f *parser.Frugal, s *parser.Struct, protocol string) ([]byte, error) {
+	var w vectorWriter
+	switch protocol {
+	case "binary":
+		w = &binaryVectorWriter{}
+	case "compact":
+		w = &compactVectorWriter{}
+	case "json":
+		w = &jsonVectorWriter{}
+	default:
+		return nil, fmt.Errorf("Unknown test vector protocol %s", protocol)
+	}
+	e := &vectorEncoder{w: w, building: make(map[*parser.Struct]bool)}
+	if err := e.encodeStruct(f, s); err != nil {
+		return nil, fmt.Errorf("Can't build a test vector for %s: %s", s.Name, err)
+	}
+	return w.bytes(), nil
+}
+
+// vectorWriter writes Thrift values with a protocol.
+type vectorWriter interface {
+	writeStructBegin()
+	writeFieldBegin(typeID byte, id int)
+	writeFieldEnd()
+	writeStructEnd()
+	writeListBegin(elemType byte, size int)
+	writeSetBegin(elemType byte, size int)
+	writeMapBegin(keyType, valueType byte, size int)
+	writeMapKeyBegin()
+	writeMapKeyEnd()
+	writeContainerEnd()
+	writeBool(v bool)
+	writeByte(v int8)
+	writeI16(v int16)
+	writeI32(v int32)
+	writeI64(v int64)
+	writeDouble(v float64)
+	writeString(v string)
+	writeBinary(v []byte)
+	bytes() []byte
+}
+
+// vectorEncoder writes the test vector instance of a struct.
+type vectorEncoder struct {
+	w vectorWriter
+
+	// building tracks the structs being written to break recursion.
+	building map[*parser.Struct]bool
+}
+
+func (e *vectorEncoder) encodeStruct(f *parser.Frugal, s *parser.Struct) error {
+	e.building[s] = true
+	defer delete(e.building, s)
+
+	e.w.writeStructBegin()
+	for _, field := range s.Fields {
+		if !e.isFinite(f, field.Type) {
+			if field.Modifier == parser.Required {
+				return fmt.Errorf("required field %s is recursive", field.Name)
+			}
+			continue
+		}
+		typeID, err := e.typeID(f, field.Type)
+		if err != nil {
+			return err
+		}
+		e.w.writeFieldBegin(typeID, field.ID)
+		if err := e.encodeValue(f, field.Type, field); err != nil {
+			return err
+		}
+		e.w.writeFieldEnd()
+		if s.Type == parser.StructTypeUnion {
+			break
+		}
+	}
+	e.w.writeStructEnd()
+	return nil
+}
+
+func (e *vectorEncoder) encodeValue(f *parser.Frugal, t *parser.Type, field *parser.Field) error {
+	underlying := f.UnderlyingType(t)
+	if f.IsEnum(underlying) {
+		enum := findEnum(f, underlying)
+		if enum == nil || len(enum.Values) == 0 {
+			return fmt.Errorf("enum %s has no values", underlying.Name)
+		}
+		e.w.writeI32(int32(enum.Values[0].Value))
+		return nil
+	}
+
+	switch underlying.Name {
+	case "bool":
+		e.w.writeBool(true)
+	case "byte", "i8":
+		e.w.writeByte(int8(field.ID % math.MaxInt8))
+	case "i16":
+		e.w.writeI16(int16(field.ID))
+	case "i32":
+		e.w.writeI32(int32(field.ID))
+	case "i64":
+		e.w.writeI64(int64(field.ID))
+	case "double":
+		e.w.writeDouble(float64(field.ID) + 0.5)
+	case "string":
+		e.w.writeString(field.Name)
+	case "binary":
+		e.w.writeBinary([]byte{1, 2, 3})
+	case "list", "set":
+		elemType, err := e.typeID(f, underlying.ValueType)
+		if err != nil {
+			return err
+		}
+		size := 0
+		if e.isFinite(f, underlying.ValueType) {
+			size = 1
+		}
+		if underlying.Name == "list" {
+			e.w.writeListBegin(elemType, size)
+		} else {
+			e.w.writeSetBegin(elemType, size)
+		}
+		if size > 0 {
+			if err := e.encodeValue(f, underlying.ValueType, field); err != nil {
+				return err
+			}
+		}
+		e.w.writeContainerEnd()
+	case "map":
+		keyType, err := e.typeID(f, underlying.KeyType)
+		if err != nil {
+			return err
+		}
+		valueType, err := e.typeID(f, underlying.ValueType)
+		if err != nil {
+			return err
+		}
+		size := 0
+		if e.isFinite(f, underlying.KeyType) && e.isFinite(f, underlying.ValueType) {
+			size = 1
+		}
+		e.w.writeMapBegin(keyType, valueType, size)
+		if size > 0 {
+			e.w.writeMapKeyBegin()
+			if err := e.encodeValue(f, underlying.KeyType, field); err != nil {
+				return err
+			}
+			e.w.writeMapKeyEnd()
+			if err := e.encodeValue(f, underlying.ValueType, field); err != nil {
+				return err
+			}
+		}
+		e.w.writeContainerEnd()
+	default:
+		s, included := findDataStructure(f, underlying)
+		if s == nil {
+			return fmt.Errorf("unknown type %s", underlying.Name)
+		}
+		return e.encodeStruct(included, s)
+	}
+	return nil
+}
+
+// isFinite returns false if the type is a struct already being written.
+// Containers of such structs are written empty instead.
+func (e *vectorEncoder) isFinite(f *parser.Frugal, t *parser.Type) bool {
+	underlying := f.UnderlyingType(t)
+	if underlying.IsContainer() || f.IsEnum(underlying) || underlying.IsPrimitive() {
+		return true
+	}
+	s, _ := findDataStructure(f, underlying)
+	return s == nil || !e.building[s]
+}
+
+func (e *vectorEncoder) typeID(f *parser.Frugal, t *parser.Type) (byte, error) {
+	underlying := f.UnderlyingType(t)
+	if f.IsEnum(underlying) {
+		return tI32, nil
+	}
+	switch underlying.Name {
+	case "bool":
+		return tBool, nil
+	case "byte", "i8":
+		return tByte, nil
+	case "i16":
+		return tI16, nil
+	case "i32":
+		return tI32, nil
+	case "i64":
+		return tI64, nil
+	case "double":
+		return tDouble, nil
+	case "string", "binary":
+		return tString, nil
+	case "list":
+		return tList, nil
+	case "set":
+		return tSet, nil
+	case "map":
+		if _, ok := e.w.(*jsonVectorWriter); ok && !isJSONKeyType(f, underlying.KeyType) {
+			return 0, fmt.Errorf("the JSON protocol can't encode map keys of type %s", underlying.KeyType)
+		}
+		return tMap, nil
+	}
+	return tStruct, nil
+}
+
+// isJSONKeyType returns true if the JSON protocol can encode map keys of the
+// type, which it writes as strings.
+func isJSONKeyType(f *parser.Frugal, t *parser.Type) bool {
+	underlying := f.UnderlyingType(t)
+	return underlying.IsPrimitive() || f.IsEnum(underlying)
+}
+
+// findDataStructure returns the struct, union, or exception of the type and
+// the Frugal it's defined in, or nil if there is none.
+func findDataStructure(f *parser.Frugal, t *parser.Type) (*parser.Struct, *parser.Frugal) {
+	if include := t.IncludeName(); include != "" {
+		included, ok := f.ParsedIncludes[include]
+		if !ok {
+			return nil, nil
+		}
+		f = included
+	}
+	for _, s := range f.DataStructures() {
+		if s.Name == t.ParamName() {
+			return s, f
+		}
+	}
+	return nil, nil
+}
+
+// findEnum returns the enum of the type, or nil if there is none.
+func findEnum(f *parser.Frugal, t *parser.Type) *parser.Enum {
+	if include := t.IncludeName(); include != "" {
+		included, ok := f.ParsedIncludes[include]
+		if !ok {
+			return nil
+		}
+		f = included
+	}
+	for _, enum := range f.Enums {
+		if enum.Name == t.ParamName() {
+			return enum
+		}
+	}
+	return nil
+}
+
+// binaryVectorWriter writes TBinaryProtocol.
+type binaryVectorWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *binaryVectorWriter) writeStructBegin() {}
+
+func (w *binaryVectorWriter) writeFieldBegin(typeID byte, id int) {
+	w.buf.WriteByte(typeID)
+	w.writeI16(int16(id))
+}
+
+func (w *binaryVectorWriter) writeFieldEnd() {}
+
+func (w *binaryVectorWriter) writeStructEnd() {
+	w.buf.WriteByte(tStop)
+}
+
+func (w *binaryVectorWriter) writeListBegin(elemType byte, size int) {
+	w.buf.WriteByte(elemType)
+	w.writeI32(int32(size))
+}
+
+func (w *binaryVectorWriter) writeSetBegin(elemType byte, size int) {
+	w.writeListBegin(elemType, size)
+}
+
+func (w *binaryVectorWriter) writeMapBegin(keyType, valueType byte, size int) {
+	w.buf.WriteByte(keyType)
+	w.buf.WriteByte(valueType)
+	w.writeI32(int32(size))
+}
+
+func (w *binaryVectorWriter) writeMapKeyBegin() {}
+
+func (w *binaryVectorWriter) writeMapKeyEnd() {}
+
+func (w *binaryVectorWriter) writeContainerEnd() {}
+
+func (w *binaryVectorWriter) writeBool(v bool) {
+	if v {
+		w.buf.WriteByte(1)
+	} else {
+		w.buf.WriteByte(0)
+	}
+}
+
+func (w *binaryVectorWriter) writeByte(v int8) {
+	w.buf.WriteByte(byte(v))
+}
+
+func (w *binaryVectorWriter) writeI16(v int16) {
+	binary.Write(&w.buf, binary.BigEndian, v)
+}
+
+func (w *binaryVectorWriter) writeI32(v int32) {
+	binary.Write(&w.buf, binary.BigEndian, v)
+}
+
+func (w *binaryVectorWriter) writeI64(v int64) {
+	binary.Write(&w.buf, binary.BigEndian, v)
+}
+
+func (w *binaryVectorWriter) writeDouble(v float64) {
+	binary.Write(&w.buf, binary.BigEndian, math.Float64bits(v))
+}
+
+func (w *binaryVectorWriter) writeString(v string) {
+	w.writeBinary([]byte(v))
+}
+
+func (w *binaryVectorWriter) writeBinary(v []byte) {
+	w.writeI32(int32(len(v)))
+	w.buf.Write(v)
+}
+
+func (w *binaryVectorWriter) bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// Compact protocol type IDs.
+var compactTypes = map[byte]byte{
+	tBool:   1,
+	tByte:   3,
+	tI16:    4,
+	tI32:    5,
+	tI64:    6,
+	tDouble: 7,
+	tString: 8,
+	tList:   9,
+	tSet:    10,
+	tMap:    11,
+	tStruct: 12,
+}
+
+// compactVectorWriter writes TCompactProtocol.
+type compactVectorWriter struct {
+	buf bytes.Buffer
+
+	// lastFieldIDs is the stack of the last field IDs written in each
+	// struct, which field headers are encoded relative to.
+	lastFieldIDs []int
+
+	// boolFieldID is the ID of the bool field being written, whose value is
+	// encoded in its header, or -1.
+	boolFieldID int
+}
+
+func (w *compactVectorWriter) writeStructBegin() {
+	w.lastFieldIDs = append(w.lastFieldIDs, 0)
+	w.boolFieldID = -1
+}
+
+func (w *compactVectorWriter) writeFieldBegin(typeID byte, id int) {
+	if typeID == tBool {
+		w.boolFieldID = id
+		return
+	}
+	w.writeFieldHeader(compactTypes[typeID], id)
+}
+
+func (w *compactVectorWriter) writeFieldHeader(typeID byte, id int) {
+	last := w.lastFieldIDs[len(w.lastFieldIDs)-1]
+	if delta := id - last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta<<4) | typeID)
+	} else {
+		w.buf.WriteByte(typeID)
+		w.writeI16(int16(id))
+	}
+	w.lastFieldIDs[len(w.lastFieldIDs)-1] = id
+}
+
+func (w *compactVectorWriter) writeFieldEnd() {}
+
+func (w *compactVectorWriter) writeStructEnd() {
+	w.buf.WriteByte(tStop)
+	w.lastFieldIDs = w.lastFieldIDs[:len(w.lastFieldIDs)-1]
+}
+
+func (w *compactVectorWriter) writeListBegin(elemType byte, size int) {
+	if size < 15 {
+		w.buf.WriteByte(byte(size<<4) | compactTypes[elemType])
+	} else {
+		w.buf.WriteByte(0xf0 | compactTypes[elemType])
+		w.writeVarint(uint64(size))
+	}
+}
+
+func (w *compactVectorWriter) writeSetBegin(elemType byte, size int) {
+	w.writeListBegin(elemType, size)
+}
+
+func (w *compactVectorWriter) writeMapBegin(keyType, valueType byte, size int) {
+	if size == 0 {
+		w.buf.WriteByte(0)
+		return
+	}
+	w.writeVarint(uint64(size))
+	w.buf.WriteByte(compactTypes[keyType]<<4 | compactTypes[valueType])
+}
+
+func (w *compactVectorWriter) writeMapKeyBegin() {}
+
+func (w *compactVectorWriter) writeMapKeyEnd() {}
+
+func (w *compactVectorWriter) writeContainerEnd() {}
+
+func (w *compactVectorWriter) writeBool(v bool) {
+	value := byte(2)
+	if v {
+		value = 1
+	}
+	if w.boolFieldID >= 0 {
+		w.writeFieldHeader(value, w.boolFieldID)
+		w.boolFieldID = -1
+		return
+	}
+	w.buf.WriteByte(value)
+}
+
+func (w *compactVectorWriter) writeByte(v int8) {
+	w.buf.WriteByte(byte(v))
+}
+
+func (w *compactVectorWriter) writeI16(v int16) {
+	w.writeVarint(zigzag(int64(v)))
+}
+
+func (w *compactVectorWriter) writeI32(v int32) {
+	w.writeVarint(zigzag(int64(v)))
+}
+
+func (w *compactVectorWriter) writeI64(v int64) {
+	w.writeVarint(zigzag(v))
+}
+
+func (w *compactVectorWriter) writeDouble(v float64) {
+	binary.Write(&w.buf, binary.LittleEndian, math.Float64bits(v))
+}
+
+func (w *compactVectorWriter) writeString(v string) {
+	w.writeBinary([]byte(v))
+}
+
+func (w *compactVectorWriter) writeBinary(v []byte) {
+	w.writeVarint(uint64(len(v)))
+	w.buf.Write(v)
+}
+
+func (w *compactVectorWriter) writeVarint(v uint64) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	w.buf.Write(buf[:binary.PutUvarint(buf, v)])
+}
+
+func (w *compactVectorWriter) bytes() []byte {
+	return w.buf.Bytes()
+}
+
+func zigzag(v int64) uint64 {
+	return uint64((v << 1) ^ (v >> 63))
+}
+
+// JSON protocol type names.
+var jsonTypes = map[byte]string{
+	tBool:   "tf",
+	tByte:   "i8",
+	tI16:    "i16",
+	tI32:    "i32",
+	tI64:    "i64",
+	tDouble: "dbl",
+	tString: "str",
+	tList:   "lst",
+	tSet:    "set",
+	tMap:    "map",
+	tStruct: "rec",
+}
+
+// jsonVectorWriter writes TJSONProtocol.
+type jsonVectorWriter struct {
+	buf bytes.Buffer
+
+	// contexts is the stack of open objects and arrays.
+	contexts []*jsonContext
+
+	// key is true while a map key, which is written as a string, is written.
+	key bool
+}
+
+// jsonContext is an open JSON object or array.
+type jsonContext struct {
+	// first is true until a value is written, since the first value isn't
+	// preceded by a comma.
+	first bool
+
+	// mapEntries is true for the object holding a map's entries, which is
+	// closed along with the map's array.
+	mapEntries bool
+}
+
+func (w *jsonVectorWriter) separate() {
+	if len(w.contexts) == 0 {
+		return
+	}
+	top := w.contexts[len(w.contexts)-1]
+	if !top.first {
+		w.buf.WriteByte(',')
+	}
+	top.first = false
+}
+
+func (w *jsonVectorWriter) begin(delim byte, mapEntries bool) {
+	w.buf.WriteByte(delim)
+	w.contexts = append(w.contexts, &jsonContext{first: true, mapEntries: mapEntries})
+}
+
+func (w *jsonVectorWriter) end(delim byte) {
+	w.buf.WriteByte(delim)
+	w.contexts = w.contexts[:len(w.contexts)-1]
+}
+
+func (w *jsonVectorWriter) writeStructBegin() {
+	w.separate()
+	w.begin('{', false)
+}
+
+func (w *jsonVectorWriter) writeFieldBegin(typeID byte, id int) {
+	w.separate()
+	fmt.Fprintf(&w.buf, "%q:{%q:", strconv.Itoa(id), jsonTypes[typeID])
+	w.contexts = append(w.contexts, &jsonContext{first: true})
+}
+
+func (w *jsonVectorWriter) writeFieldEnd() {
+	w.end('}')
+}
+
+func (w *jsonVectorWriter) writeStructEnd() {
+	w.end('}')
+}
+
+func (w *jsonVectorWriter) writeListBegin(elemType byte, size int) {
+	w.separate()
+	w.begin('[', false)
+	w.writeRaw(strconv.Quote(jsonTypes[elemType]))
+	w.writeRaw(strconv.Itoa(size))
+}
+
+func (w *jsonVectorWriter) writeSetBegin(elemType byte, size int) {
+	w.writeListBegin(elemType, size)
+}
+
+func (w *jsonVectorWriter) writeMapBegin(keyType, valueType byte, size int) {
+	w.separate()
+	w.begin('[', false)
+	w.writeRaw(strconv.Quote(jsonTypes[keyType]))
+	w.writeRaw(strconv.Quote(jsonTypes[valueType]))
+	w.writeRaw(strconv.Itoa(size))
+	w.separate()
+	w.begin('{', true)
+}
+
+func (w *jsonVectorWriter) writeMapKeyBegin() {
+	w.key = true
+}
+
+func (w *jsonVectorWriter) writeMapKeyEnd() {
+	w.key = false
+	w.buf.WriteByte(':')
+	w.contexts[len(w.contexts)-1].first = true
+}
+
+func (w *jsonVectorWriter) writeContainerEnd() {
+	if w.contexts[len(w.contexts)-1].mapEntries {
+		w.end('}')
+	}
+	w.end(']')
+}
+
+func (w *jsonVectorWriter) writeRaw(v string) {
+	w.separate()
+	w.buf.WriteString(v)
+}
+
+// writeNumber writes the number, quoted when it's a map key.
+func (w *jsonVectorWriter) writeNumber(v string) {
+	if w.key {
+		v = strconv.Quote(v)
+	}
+	w.writeRaw(v)
+}
+
+func (w *jsonVectorWriter) writeBool(v bool) {
+	if v {
+		w.writeNumber("1")
+	} else {
+		w.writeNumber("0")
+	}
+}
+
+func (w *jsonVectorWriter) writeByte(v int8) {
+	w.writeNumber(strconv.Itoa(int(v)))
+}
+
+func (w *jsonVectorWriter) writeI16(v int16) {
+	w.writeNumber(strconv.Itoa(int(v)))
+}
+
+func (w *jsonVectorWriter) writeI32(v int32) {
+	w.writeNumber(strconv.Itoa(int(v)))
+}
+
+func (w *jsonVectorWriter) writeI64(v int64) {
+	w.writeNumber(strconv.FormatInt(v, 10))
+}
+
+func (w *jsonVectorWriter) writeDouble(v float64) {
+	w.writeNumber(strconv.FormatFloat(v, 'g', -1, 64))
+}
+
+func (w *jsonVectorWriter) writeString(v string) {
+	quoted, _ := json.Marshal(v)
+	w.writeRaw(string(quoted))
+}
+
+func (w *jsonVectorWriter) writeBinary(v []byte) {
+	w.writeRaw(strconv.Quote(base64.StdEncoding.EncodeToString(v)))
+}
+
+func (w *jsonVectorWriter) bytes() []byte {
+	return w.buf.Bytes()
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compiler
+
+import (
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/generator/broker"
+	"github.com/Workiva/frugal/compiler/generator/dartlang"
+	"github.com/Workiva/frugal/compiler/generator/golang"
+	"github.com/Workiva/frugal/compiler/generator/graphql"
+	"github.com/Workiva/frugal/compiler/generator/grpc"
+	"github.com/Workiva/frugal/compiler/generator/html"
+	"github.com/Workiva/frugal/compiler/generator/java"
+	"github.com/Workiva/frugal/compiler/generator/python"
+)
+
+// Register the generators built into the compiler.
+func init() {
+	register := func(lang string, factory generator.Factory) {
+		generator.Register(lang, generator.Languages[lang], factory)
+	}
+	register("dart", func(options map[string]string) generator.ProgramGenerator {
+		return generator.NewProgramGenerator(dartlang.NewGenerator(options), false)
+	})
+	register("go", func(options map[string]string) generator.ProgramGenerator {
+		// Make sure the package prefix ends with a "/"
+		if package_prefix, ok := options["package_prefix"]; ok {
+			if package_prefix != "" && !strings.HasSuffix(package_prefix, "/") {
+				options["package_prefix"] = package_prefix + "/"
+			}
+		}
+
+		return generator.NewProgramGenerator(golang.NewGenerator(options), false)
+	})
+	register("java", func(options map[string]string) generator.ProgramGenerator {
+		return generator.NewProgramGenerator(java.NewGenerator(options), true)
+	})
+	register("py", func(options map[string]string) generator.ProgramGenerator {
+		return generator.NewProgramGenerator(python.NewGenerator(options), true)
+	})
+	register("html", func(options map[string]string) generator.ProgramGenerator {
+		return html.NewGenerator(options)
+	})
+	register("broker", func(options map[string]string) generator.ProgramGenerator {
+		return broker.NewGenerator(options)
+	})
+	register("grpc", func(options map[string]string) generator.ProgramGenerator {
+		return grpc.NewGenerator(options)
+	})
+	register("graphql", func(options map[string]string) generator.ProgramGenerator {
+		return graphql.NewGenerator(options)
+	})
+}
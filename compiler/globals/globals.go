@@ -15,6 +15,9 @@ package globals
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/Workiva/frugal/compiler/parser"
@@ -32,6 +35,12 @@ var (
 	DryRun         bool
 	Recurse        bool
 	Verbose        bool
+	EventCatalog   bool
+	TestVectors    bool
+	Tracing        bool
+	Strict         bool
+	Sandbox        string
+	Warnings       []string
 	Now            = time.Now()
 	CompiledFiles  = make(map[string]*parser.Frugal)
 )
@@ -45,11 +54,42 @@ func Reset() {
 	DryRun = false
 	Recurse = false
 	Verbose = false
+	EventCatalog = false
+	TestVectors = false
+	Tracing = false
+	Strict = false
+	Sandbox = ""
+	Warnings = nil
 	Now = time.Now()
 	CompiledFiles = make(map[string]*parser.Frugal)
 }
 
-// PrintWarning prints the given message to stdout in yellow font.
+// Warn records a non-fatal warning from generation, which the compiler prints
+// once generation is done and treats as an error in strict mode.
+func Warn(msg string) {
+	Warnings = append(Warnings, msg)
+}
+
+// CheckOutputDir returns an error if code would be generated to the given
+// directory outside of the Sandbox directory, which is set when generating to
+// a temporary directory, e.g. to compile to memory.
+func CheckOutputDir(dir string) error {
+	if Sandbox == "" {
+		return nil
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(Sandbox, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("code would be generated to %s, outside of %s", dir, Sandbox)
+	}
+	return nil
+}
+
+// PrintWarning prints the given message to stderr in yellow font, keeping
+// stdout for generated output.
 func PrintWarning(msg string) {
-	fmt.Println("\x1b[33m" + msg + "\x1b[0m")
+	fmt.Fprintln(os.Stderr, "\x1b[33m"+msg+"\x1b[0m")
 }
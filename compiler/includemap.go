@@ -0,0 +1,84 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// includeMapping remaps the namespace of an include at generation time.
+type includeMapping struct {
+	lang      string // Empty to remap the include for every language
+	include   string
+	namespace string
+}
+
+// parseIncludeMap parses include map entries of the form
+// [<lang>:]<include>:<namespace>, e.g. "java:shared:com.acme.shared".
+func parseIncludeMap(entries []string) ([]*includeMapping, error) {
+	mappings := make([]*includeMapping, 0, len(entries))
+	for _, entry := range entries {
+		parts := strings.Split(entry, ":")
+		mapping := &includeMapping{}
+		switch len(parts) {
+		case 2:
+			mapping.include, mapping.namespace = parts[0], parts[1]
+		case 3:
+			mapping.lang, mapping.include, mapping.namespace = parts[0], parts[1], parts[2]
+		default:
+			return nil, fmt.Errorf("Invalid include map %s, expected [<lang>:]<include>:<namespace>", entry)
+		}
+		if mapping.include == "" || mapping.namespace == "" {
+			return nil, fmt.Errorf("Invalid include map %s, expected [<lang>:]<include>:<namespace>", entry)
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings, nil
+}
+
+// remapIncludes sets the namespace for the language of every include of the
+// file and its includes which is remapped. Since each file parses its own
+// copy of the files it includes, every copy is remapped. An error is
+// returned if an include to remap isn't included anywhere.
+func remapIncludes(f *parser.Frugal, lang string, entries []string) error {
+	mappings, err := parseIncludeMap(entries)
+	if err != nil {
+		return err
+	}
+	for _, mapping := range mappings {
+		if mapping.lang != "" && mapping.lang != lang {
+			continue
+		}
+		if !remapInclude(f, lang, mapping) {
+			return fmt.Errorf("Include map: %s is not included by %s or its includes", mapping.include, f.File)
+		}
+	}
+	return nil
+}
+
+func remapInclude(f *parser.Frugal, lang string, mapping *includeMapping) bool {
+	found := false
+	for _, include := range f.OrderedIncludes() {
+		parsed := f.ParsedIncludes[include.Name]
+		if include.Name == mapping.include {
+			parsed.SetNamespace(lang, mapping.namespace)
+			found = true
+		}
+		found = remapInclude(parsed, lang, mapping) || found
+	}
+	return found
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compiler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/generator"
+)
+
+// outputDirOptions are the generator options overriding the output
+// directory of an artifact type.
+var outputDirOptions = []string{
+	generator.ModelsOutOption,
+	generator.ScopesOutOption,
+	generator.ServicesOutOption,
+}
+
+// CompileToMemory generates code for the Frugal IDL like Compile, but returns
+// the generated files by their slash-separated paths relative to the output
+// location instead of leaving them on disk, so other tools can invoke
+// generation and capture its output. Generators write files as they go, so
+// they're written to a temporary directory which is removed afterwards, and
+// generation fails if it would write anywhere else. The output location and
+// incremental compilation are ignored, and options overriding the output
+// directory of an artifact type, e.g. models_out, can't be used. Like
+// Compile, it must not be called concurrently.
+func CompileToMemory(options Options) (map[string][]byte, error) {
+	lang, genOptions, err := cleanGenParam(options.Gen)
+	if err != nil {
+		return nil, err
+	}
+	for _, option := range outputDirOptions {
+		if genOptions[option] != "" {
+			return nil, fmt.Errorf("%s option %s can't be used when compiling to memory", lang, option)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "frugal")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	options.Out = dir
+	options.Incremental = false
+	options.sandbox = dir
+	if err := Compile(options); err != nil {
+		return nil, err
+	}
+	return readFiles(dir)
+}
+
+// ListOutputs returns the sorted paths of the files generating code for the
+// options would write, without writing to the output location, so build
+// systems can declare them beforehand. Each path is the file's path within
+// the output location, or the directory of an option overriding the output
+// directory of its artifact type, joined to it. The output location defaults
+// to the language's default output directory.
+func ListOutputs(options Options) ([]string, error) {
+	lang, genOptions, err := cleanGenParam(options.Gen)
+	if err != nil {
+		return nil, err
+	}
+	if options.Out == "" {
+		g, err := getProgramGenerator(lang, genOptions)
+		if err != nil {
+			return nil, err
+		}
+		options.Out = g.DefaultOutputDir()
+	}
+
+	dir, err := ioutil.TempDir("", "frugal")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	// Generate to the temporary directory with every output directory at
+	// its absolute path within it, so the layout of the directories relative
+	// to each other is unchanged, and map the files back to the directories.
+	redirected := make(map[string]string)
+	redirect := func(root string) (string, error) {
+		abs, err := filepath.Abs(root)
+		if err != nil {
+			return "", err
+		}
+		tmp := filepath.Join(dir, strings.TrimPrefix(abs, filepath.VolumeName(abs)))
+		redirected[tmp] = root
+		return tmp, nil
+	}
+	if options.Out, err = redirect(options.Out); err != nil {
+		return nil, err
+	}
+	gen := []string{}
+	for name, value := range genOptions {
+		for _, option := range outputDirOptions {
+			if name == option && value != "" {
+				if value, err = redirect(value); err != nil {
+					return nil, err
+				}
+			}
+		}
+		gen = append(gen, name+"="+value)
+	}
+	if len(gen) > 0 {
+		sort.Strings(gen)
+		options.Gen = lang + ":" + strings.Join(gen, ",")
+	}
+	options.Incremental = false
+	options.sandbox = dir
+	if err := Compile(options); err != nil {
+		return nil, err
+	}
+
+	files, err := readFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(files))
+	for file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		paths = append(paths, originalPath(path, redirected))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// originalPath returns the path a file generated to a redirected output
+// directory would have been generated to, using the innermost directory
+// containing it.
+func originalPath(path string, redirected map[string]string) string {
+	innermost := ""
+	for tmp := range redirected {
+		rel, err := filepath.Rel(tmp, path)
+		if err == nil && !strings.HasPrefix(rel, "..") && len(tmp) > len(innermost) {
+			innermost = tmp
+		}
+	}
+	rel, _ := filepath.Rel(innermost, path)
+	return filepath.Join(redirected[innermost], rel)
+}
+
+// readFiles returns the contents of the files in the directory and its
+// subdirectories by their slash-separated paths relative to it.
+func readFiles(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = contents
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
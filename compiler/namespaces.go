@@ -0,0 +1,112 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// Namespace policies, which select what happens when a file being generated
+// or included has no namespace for the language.
+const (
+	// NamespaceFallback silently falls back to the language's default.
+	NamespaceFallback = "fallback"
+	// NamespaceWarn falls back to the language's default with a warning.
+	NamespaceWarn = "warn"
+	// NamespaceError fails generation.
+	NamespaceError = "error"
+)
+
+// namespaceFallbacks describes what the generators of languages with
+// namespaces fall back to without one.
+var namespaceFallbacks = map[string]string{
+	"go":   "the file name",
+	"java": "the default package",
+	"dart": "the file name",
+	"py":   "the file name",
+}
+
+// checkNamespaces applies the namespace policy to the file and everything it
+// includes, since generated code references includes by their namespaces.
+func checkNamespaces(f *parser.Frugal, lang, policy string) error {
+	switch policy {
+	case "", NamespaceFallback:
+		return nil
+	case NamespaceWarn, NamespaceError:
+	default:
+		return fmt.Errorf("Invalid namespace policy %s, expected %s, %s, or %s",
+			policy, NamespaceFallback, NamespaceWarn, NamespaceError)
+	}
+	fallback, ok := namespaceFallbacks[lang]
+	if !ok {
+		return nil
+	}
+	return checkNamespacesRec(f, lang, policy, fallback, make(map[string]bool))
+}
+
+func checkNamespacesRec(f *parser.Frugal, lang, policy, fallback string, seen map[string]bool) error {
+	if seen[f.File] {
+		return nil
+	}
+	seen[f.File] = true
+
+	if f.Namespace(lang) == nil {
+		if policy == NamespaceError {
+			return fmt.Errorf("%s has no %s namespace", f.File, lang)
+		}
+		globals.Warn(fmt.Sprintf("%s has no %s namespace, falling back to %s", f.File, lang, fallback))
+	}
+	for _, include := range f.OrderedIncludes() {
+		if err := checkNamespacesRec(f.ParsedIncludes[include.Name], lang, policy, fallback, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseNamespaceOverrides parses namespace overrides of the form
+// <lang>=<namespace>, e.g. "dart=acme_events", into a map from language to
+// namespace.
+func parseNamespaceOverrides(entries []string) (map[string]string, error) {
+	overrides := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("Invalid namespace override %s, expected <lang>=<namespace>", entry)
+		}
+		if _, ok := overrides[parts[0]]; ok {
+			return nil, fmt.Errorf("Duplicate namespace override for %s", parts[0])
+		}
+		overrides[parts[0]] = parts[1]
+	}
+	return overrides, nil
+}
+
+// overrideNamespace sets the namespace for the language of the file being
+// generated if it's overridden, replacing the one it declares. Includes are
+// remapped by the include map instead.
+func overrideNamespace(f *parser.Frugal, lang string, entries []string) error {
+	overrides, err := parseNamespaceOverrides(entries)
+	if err != nil {
+		return err
+	}
+	if namespace, ok := overrides[lang]; ok {
+		f.SetNamespace(lang, namespace)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compiler
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// CompileAll compiles each of the given options, one per file and language,
+// with up to workers compilations running at once. The compile function must
+// be safe to call concurrently, which Compile isn't since the compiler's
+// state is global, e.g. by compiling in a separate process. Compilations to
+// the same output location run one at a time, even of different languages,
+// since they may write the same files, such as include outputs, the
+// incremental manifest, the event catalog and test vectors, Dart library
+// exports and pubspecs, Python package __init__.py files, or a setup.py. The
+// errors of every failed compilation are returned together.
+func CompileAll(options []Options, workers int, compile func(Options) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	// Compilations which may write the same files share a lock.
+	locks := make(map[string]*sync.Mutex)
+	jobLocks := make([]*sync.Mutex, len(options))
+	for i, o := range options {
+		key := o.Out
+		if key == "" {
+			// Each language has its own default output location.
+			key = "\x00" + genLanguage(o.Gen)
+		}
+		if locks[key] == nil {
+			locks[key] = &sync.Mutex{}
+		}
+		jobLocks[i] = locks[key]
+	}
+
+	jobs := make(chan int)
+	errs := make([]error, len(options))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				jobLocks[i].Lock()
+				errs[i] = compile(options[i])
+				jobLocks[i].Unlock()
+			}
+		}()
+	}
+	for i := range options {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	failures := []string{}
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("Failed to generate %s (%s):\n\t%s",
+				options[i].File, genLanguage(options[i].Gen), err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// genLanguage returns the language of a gen value, which may be followed by
+// options.
+func genLanguage(gen string) string {
+	return strings.SplitN(gen, ":", 2)[0]
+}
@@ -106,11 +106,16 @@ func (a *Auditor) Audit(oldFile, newFile string) error {
 }
 
 // checkScopes requirements:
+// Warning:
+// - Any of the below errors for an experimental scope
 // Error:
 // - Scopes removed
 // - Scope prefix changed in any way other than renaming variables
 // - Operation removed
 // - Operation type changed
+// - Operation reply type changed
+// - Stability lowered
+// - Frozen scope contract changed
 func (a *Auditor) checkScopes(oldScopes, newScopes []*Scope) {
 	newMap := make(map[string]*Scope)
 	for _, scope := range newScopes {
@@ -118,16 +123,64 @@ func (a *Auditor) checkScopes(oldScopes, newScopes []*Scope) {
 	}
 
 	for _, oldScope := range oldScopes {
+		restore := a.relaxExperimental(oldScope.Annotations)
 		if newScope, ok := newMap[oldScope.Name]; ok {
 			context := fmt.Sprintf("scope %s:", oldScope.Name)
+			a.checkStability(oldScope.Annotations, newScope.Annotations, context)
+			if oldScope.Annotations.Stability() == StabilityFrozen &&
+				a.oldFrugal.ScopeHash(oldScope) != a.newFrugal.ScopeHash(newScope) {
+				a.logger.LogError(context, "frozen contract changed")
+			}
 			a.checkScopePrefix(oldScope.Prefix, newScope.Prefix, context)
 			a.checkOperations(oldScope.Operations, newScope.Operations, context)
 		} else {
 			a.logger.LogError("missing scope:", oldScope.Name)
 		}
+		restore()
+	}
+}
+
+// stabilityLevels orders the stability levels from least to most stable.
+var stabilityLevels = map[string]int{
+	StabilityExperimental: 0,
+	StabilityStable:       1,
+	StabilityFrozen:       2,
+}
+
+// checkStability logs an error if the stability of a scope or service was
+// lowered, which would allow breaking changes to a contract consumers rely
+// on.
+func (a *Auditor) checkStability(oldAnnotations, newAnnotations Annotations, context string) {
+	oldStability := oldAnnotations.Stability()
+	newStability := newAnnotations.Stability()
+	if stabilityLevels[newStability] < stabilityLevels[oldStability] {
+		a.logger.LogError(context, fmt.Sprintf("stability lowered: '%s' -> '%s'", oldStability, newStability))
+	}
+}
+
+// relaxExperimental logs errors as warnings until the returned function is
+// called if the annotations mark the contract being checked experimental.
+func (a *Auditor) relaxExperimental(annotations Annotations) func() {
+	if !annotations.IsExperimental() {
+		return func() {}
+	}
+	logger := a.logger
+	a.logger = &warningLogger{logger}
+	return func() {
+		a.logger = logger
 	}
 }
 
+// warningLogger is a validation logger which logs errors as warnings with
+// the wrapped logger.
+type warningLogger struct {
+	ValidationLogger
+}
+
+func (w *warningLogger) LogError(errorMessage ...string) {
+	w.ValidationLogger.LogWarning(errorMessage...)
+}
+
 func (a *Auditor) checkScopePrefix(oldPrefix, newPrefix *ScopePrefix, context string) {
 	// variable names in scope prefixes should be able to change,
 	// but nothing else should be able to. Changing all the variables
@@ -161,6 +214,7 @@ func (a *Auditor) checkOperations(oldOps, newOps []*Operation, context string) {
 		if newOp, ok := newMap[oldOp.Name]; ok {
 			opContext := fmt.Sprintf("%s operation %s:", context, oldOp.Name)
 			a.checkType(oldOp.Type, newOp.Type, false, opContext)
+			a.checkType(oldOp.ReplyType(), newOp.ReplyType(), false, opContext+" reply:")
 		} else {
 			a.logger.LogError(context, "operation removed:", oldOp.Name)
 		}
@@ -301,6 +355,9 @@ func (a *Auditor) checkStructLike(oldStructs, newStructs []*Struct) {
 // - Method exception type changed
 // - Adding an exception with a nil return value and no current exceptions
 // - Removing an exception with a nil return value and only one current exception
+// - Stability lowered
+// - Frozen service contract changed
+// The errors are warnings for experimental services.
 func (a *Auditor) checkServices(oldServices, newServices []*Service) {
 	newMap := make(map[string]*Service)
 	for _, service := range newServices {
@@ -308,17 +365,24 @@ func (a *Auditor) checkServices(oldServices, newServices []*Service) {
 	}
 
 	for _, oldService := range oldServices {
+		restore := a.relaxExperimental(oldService.Annotations)
 		if newService, ok := newMap[oldService.Name]; ok {
+			context := fmt.Sprintf("service %s:", oldService.Name)
+			a.checkStability(oldService.Annotations, newService.Annotations, context)
+			if oldService.Annotations.Stability() == StabilityFrozen &&
+				a.oldFrugal.ServiceHash(oldService) != a.newFrugal.ServiceHash(newService) {
+				a.logger.LogError(context, "frozen contract changed")
+			}
 			// It's fine to add inheritance, but not change it if it already exists
 			if oldService.Extends != "" && oldService.Extends != newService.Extends {
 				a.logger.LogError(fmt.Sprintf("service %s: extends changed: '%s' -> '%s'",
 					oldService.Name, oldService.Extends, newService.Extends))
 			}
-			context := fmt.Sprintf("service %s:", oldService.Name)
 			a.checkServiceMethods(oldService.Methods, newService.Methods, context)
 		} else {
 			a.logger.LogError("missing service:", oldService.Name)
 		}
+		restore()
 	}
 }
 
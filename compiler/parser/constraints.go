@@ -0,0 +1,136 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package parser
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FieldConstraints are the constraints on the value of a field, taken from
+// its "min", "max", and "pattern" annotations. Empty values are unset.
+type FieldConstraints struct {
+	Min     string
+	Max     string
+	Pattern string
+}
+
+// Constraints returns the constraints on the field's value, or nil if it has
+// none.
+func (f *Field) Constraints() *FieldConstraints {
+	min, hasMin := f.Annotations.Get(MinAnnotation)
+	max, hasMax := f.Annotations.Get(MaxAnnotation)
+	pattern, hasPattern := f.Annotations.Get(PatternAnnotation)
+	if !hasMin && !hasMax && !hasPattern {
+		return nil
+	}
+	return &FieldConstraints{
+		Min:     strings.TrimSpace(min),
+		Max:     strings.TrimSpace(max),
+		Pattern: pattern,
+	}
+}
+
+// HasConstraints returns true if any field of the struct has constraints.
+func (s *Struct) HasConstraints() bool {
+	for _, field := range s.Fields {
+		if field.Constraints() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ConstrainsLength returns true if the min and max constraints on fields of
+// the type bound their length rather than their value.
+func (f *Frugal) ConstrainsLength(t *Type) bool {
+	switch f.UnderlyingType(t).Name {
+	case "string", "binary", "list", "set", "map":
+		return true
+	}
+	return false
+}
+
+// integerRanges are the values the integer types can hold.
+var integerRanges = map[string][2]int64{
+	"byte": {math.MinInt8, math.MaxInt8},
+	"i8":   {math.MinInt8, math.MaxInt8},
+	"i16":  {math.MinInt16, math.MaxInt16},
+	"i32":  {math.MinInt32, math.MaxInt32},
+	"i64":  {math.MinInt64, math.MaxInt64},
+}
+
+// validateConstraints ensures the field's constraints are supported by its
+// type and well-formed.
+func (f *Frugal) validateConstraints(s *Struct, field *Field) error {
+	constraints := field.Constraints()
+	if constraints == nil {
+		return nil
+	}
+	underlying := f.UnderlyingType(field.Type)
+	for _, bound := range []struct{ name, value string }{{MinAnnotation, constraints.Min}, {MaxAnnotation, constraints.Max}} {
+		if _, ok := field.Annotations.Get(bound.name); !ok {
+			continue
+		}
+		if err := f.validateBound(underlying, bound.value); err != nil {
+			return fmt.Errorf("Invalid %s annotation on field %s of struct %s: %s", bound.name, field.Name, s.Name, err)
+		}
+	}
+	if constraints.Min != "" && constraints.Max != "" {
+		min, _ := strconv.ParseFloat(constraints.Min, 64)
+		max, _ := strconv.ParseFloat(constraints.Max, 64)
+		if min > max {
+			return fmt.Errorf("Field %s of struct %s has min %s greater than max %s",
+				field.Name, s.Name, constraints.Min, constraints.Max)
+		}
+	}
+	if _, ok := field.Annotations.Get(PatternAnnotation); ok {
+		if underlying.Name != "string" {
+			return fmt.Errorf("Invalid pattern annotation on field %s of struct %s: only strings can have a pattern", field.Name, s.Name)
+		}
+		if _, err := regexp.Compile(constraints.Pattern); err != nil {
+			return fmt.Errorf("Invalid pattern annotation on field %s of struct %s: %s", field.Name, s.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateBound ensures the min or max value is valid for the given type.
+func (f *Frugal) validateBound(t *Type, value string) error {
+	if f.ConstrainsLength(t) {
+		if length, err := strconv.ParseInt(value, 10, 32); err != nil || length < 0 {
+			return fmt.Errorf("%q isn't a valid length", value)
+		}
+		return nil
+	}
+	if t.Name == "double" {
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%q isn't a number", value)
+		}
+		return nil
+	}
+	if limits, ok := integerRanges[t.Name]; ok {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q isn't an integer", value)
+		}
+		if n < limits[0] || n > limits[1] {
+			return fmt.Errorf("%s is out of range for %s", value, t.Name)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s fields can't be bounded", t.Name)
+}
@@ -0,0 +1,487 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ChangeSeverity classifies how a Change affects consumers of a contract.
+type ChangeSeverity int
+
+const (
+	// Compatible changes, such as additions, don't affect existing
+	// consumers.
+	Compatible ChangeSeverity = iota
+
+	// Risky changes are compatible on the wire but may affect generated code
+	// or consumers' expectations, such as renames.
+	Risky
+
+	// Breaking changes are incompatible with existing consumers.
+	Breaking
+)
+
+// String returns a human-readable version of the ChangeSeverity.
+func (c ChangeSeverity) String() string {
+	switch c {
+	case Compatible:
+		return "compatible"
+	case Risky:
+		return "risky"
+	case Breaking:
+		return "breaking"
+	default:
+		panic(fmt.Sprintf("unknown change severity %d", c))
+	}
+}
+
+// Change is a semantic difference between two versions of a frugal file.
+type Change struct {
+	Severity ChangeSeverity
+
+	// Path locates the changed definition, e.g. "scope Events: operation
+	// EventCreated".
+	Path string
+
+	// Description describes the change, e.g. "removed" or "type changed:
+	// 'i32' -> 'i64'".
+	Description string
+}
+
+// String returns a human-readable version of the Change.
+func (c *Change) String() string {
+	return fmt.Sprintf("%s: %s: %s", c.Severity, c.Path, c.Description)
+}
+
+// Differ computes the semantic differences between two versions of a frugal
+// file, i.e. ignoring comments, formatting, and definition order.
+type Differ struct {
+	oldFrugal *Frugal
+	newFrugal *Frugal
+	changes   []*Change
+}
+
+// NewDiffer constructs a Differ.
+func NewDiffer() *Differ {
+	return &Differ{}
+}
+
+// Diff returns the changes from oldFile to newFile, in the order of the
+// definitions of oldFile followed by those added in newFile.
+func (d *Differ) Diff(oldFile, newFile string) ([]*Change, error) {
+	oldFrugal, err := ParseFrugal(oldFile)
+	if err != nil {
+		return nil, err
+	}
+	newFrugal, err := ParseFrugal(newFile)
+	if err != nil {
+		return nil, err
+	}
+
+	d.oldFrugal = oldFrugal
+	d.newFrugal = newFrugal
+	d.changes = []*Change{}
+
+	d.diffNamespaces(oldFrugal.Namespaces, newFrugal.Namespaces)
+	d.diffConstants(oldFrugal.Constants, newFrugal.Constants)
+	d.diffTypedefs(oldFrugal.Typedefs, newFrugal.Typedefs)
+	d.diffEnums(oldFrugal.Enums, newFrugal.Enums)
+	d.diffStructLike("struct", oldFrugal.Structs, newFrugal.Structs)
+	d.diffStructLike("union", oldFrugal.Unions, newFrugal.Unions)
+	d.diffStructLike("exception", oldFrugal.Exceptions, newFrugal.Exceptions)
+	d.diffServices(oldFrugal.Services, newFrugal.Services)
+	d.diffScopes(oldFrugal.Scopes, newFrugal.Scopes)
+	return d.changes, nil
+}
+
+func (d *Differ) add(severity ChangeSeverity, path, description string) {
+	d.changes = append(d.changes, &Change{Severity: severity, Path: path, Description: description})
+}
+
+// Namespaces aren't sent over the network, but changing them moves generated
+// code.
+func (d *Differ) diffNamespaces(oldNamespaces, newNamespaces []*Namespace) {
+	newMap := make(map[string]*Namespace)
+	for _, namespace := range newNamespaces {
+		newMap[namespace.Scope] = namespace
+	}
+	oldMap := make(map[string]*Namespace)
+	for _, oldNamespace := range oldNamespaces {
+		oldMap[oldNamespace.Scope] = oldNamespace
+		path := "namespace " + oldNamespace.Scope
+		newNamespace, ok := newMap[oldNamespace.Scope]
+		if !ok {
+			d.add(Risky, path, "removed")
+			continue
+		}
+		if oldNamespace.Value != newNamespace.Value {
+			d.add(Risky, path, fmt.Sprintf("changed: '%s' -> '%s'", oldNamespace.Value, newNamespace.Value))
+		}
+		d.diffAnnotations(oldNamespace.Annotations, newNamespace.Annotations, path)
+	}
+	for _, newNamespace := range newNamespaces {
+		if _, ok := oldMap[newNamespace.Scope]; !ok {
+			d.add(Compatible, "namespace "+newNamespace.Scope, "added")
+		}
+	}
+}
+
+func (d *Differ) diffConstants(oldConstants, newConstants []*Constant) {
+	newMap := make(map[string]*Constant)
+	for _, constant := range newConstants {
+		newMap[constant.Name] = constant
+	}
+	oldMap := make(map[string]*Constant)
+	for _, oldConstant := range oldConstants {
+		oldMap[oldConstant.Name] = oldConstant
+		path := "constant " + oldConstant.Name
+		newConstant, ok := newMap[oldConstant.Name]
+		if !ok {
+			d.add(Risky, path, "removed")
+			continue
+		}
+		d.diffType(oldConstant.Type, newConstant.Type, Risky, path)
+		if !reflect.DeepEqual(oldConstant.Value, newConstant.Value) {
+			d.add(Risky, path, "value changed")
+		}
+	}
+	for _, newConstant := range newConstants {
+		if _, ok := oldMap[newConstant.Name]; !ok {
+			d.add(Compatible, "constant "+newConstant.Name, "added")
+		}
+	}
+}
+
+// Typedefs are compared through the types using them, so changing one is
+// only risky by itself.
+func (d *Differ) diffTypedefs(oldTypedefs, newTypedefs []*TypeDef) {
+	newMap := make(map[string]*TypeDef)
+	for _, typedef := range newTypedefs {
+		newMap[typedef.Name] = typedef
+	}
+	oldMap := make(map[string]*TypeDef)
+	for _, oldTypedef := range oldTypedefs {
+		oldMap[oldTypedef.Name] = oldTypedef
+		path := "typedef " + oldTypedef.Name
+		newTypedef, ok := newMap[oldTypedef.Name]
+		if !ok {
+			d.add(Risky, path, "removed")
+			continue
+		}
+		d.diffType(oldTypedef.Type, newTypedef.Type, Risky, path)
+		d.diffAnnotations(oldTypedef.Annotations, newTypedef.Annotations, path)
+	}
+	for _, newTypedef := range newTypedefs {
+		if _, ok := oldMap[newTypedef.Name]; !ok {
+			d.add(Compatible, "typedef "+newTypedef.Name, "added")
+		}
+	}
+}
+
+// Only the numeric values of enums are sent over the network, so removing a
+// value is breaking but renaming one is only risky.
+func (d *Differ) diffEnums(oldEnums, newEnums []*Enum) {
+	newMap := make(map[string]*Enum)
+	for _, enum := range newEnums {
+		newMap[enum.Name] = enum
+	}
+	oldMap := make(map[string]*Enum)
+	for _, oldEnum := range oldEnums {
+		oldMap[oldEnum.Name] = oldEnum
+		path := "enum " + oldEnum.Name
+		newEnum, ok := newMap[oldEnum.Name]
+		if !ok {
+			d.add(Risky, path, "removed")
+			continue
+		}
+		d.diffAnnotations(oldEnum.Annotations, newEnum.Annotations, path)
+
+		newValues := make(map[int]*EnumValue)
+		for _, value := range newEnum.Values {
+			newValues[value.Value] = value
+		}
+		oldValues := make(map[int]*EnumValue)
+		for _, oldValue := range oldEnum.Values {
+			oldValues[oldValue.Value] = oldValue
+			valuePath := fmt.Sprintf("%s: value %s", path, oldValue.Name)
+			newValue, ok := newValues[oldValue.Value]
+			if !ok {
+				d.add(Breaking, valuePath, fmt.Sprintf("removed with value %d", oldValue.Value))
+				continue
+			}
+			if oldValue.Name != newValue.Name {
+				d.add(Risky, valuePath, fmt.Sprintf("renamed to %s", newValue.Name))
+			}
+			d.diffAnnotations(oldValue.Annotations, newValue.Annotations, valuePath)
+		}
+		for _, newValue := range newEnum.Values {
+			if _, ok := oldValues[newValue.Value]; !ok {
+				d.add(Compatible, fmt.Sprintf("%s: value %s", path, newValue.Name), "added")
+			}
+		}
+	}
+	for _, newEnum := range newEnums {
+		if _, ok := oldMap[newEnum.Name]; !ok {
+			d.add(Compatible, "enum "+newEnum.Name, "added")
+		}
+	}
+}
+
+func (d *Differ) diffStructLike(kind string, oldStructs, newStructs []*Struct) {
+	newMap := make(map[string]*Struct)
+	for _, s := range newStructs {
+		newMap[s.Name] = s
+	}
+	oldMap := make(map[string]*Struct)
+	for _, oldStruct := range oldStructs {
+		oldMap[oldStruct.Name] = oldStruct
+		path := kind + " " + oldStruct.Name
+		newStruct, ok := newMap[oldStruct.Name]
+		if !ok {
+			d.add(Breaking, path, "removed")
+			continue
+		}
+		d.diffAnnotations(oldStruct.Annotations, newStruct.Annotations, path)
+		d.diffFields(oldStruct.Fields, newStruct.Fields, path+": field")
+	}
+	for _, newStruct := range newStructs {
+		if _, ok := oldMap[newStruct.Name]; !ok {
+			d.add(Compatible, kind+" "+newStruct.Name, "added")
+		}
+	}
+}
+
+// diffFields compares fields, arguments, or exceptions by ID, since only the
+// IDs are sent over the network.
+func (d *Differ) diffFields(oldFields, newFields []*Field, context string) {
+	newMap := makeFieldsMap(newFields)
+	oldMap := makeFieldsMap(oldFields)
+	for _, oldField := range oldFields {
+		path := fmt.Sprintf("%s %s", context, oldField.Name)
+		newField, ok := newMap[oldField.ID]
+		if !ok {
+			if oldField.Modifier == Optional {
+				d.add(Risky, path, fmt.Sprintf("removed with ID=%d", oldField.ID))
+			} else {
+				d.add(Breaking, path, fmt.Sprintf("removed with ID=%d", oldField.ID))
+			}
+			continue
+		}
+		d.diffType(oldField.Type, newField.Type, Breaking, path)
+		if oldField.Modifier != newField.Modifier {
+			severity := Risky
+			if oldField.Modifier == Required || newField.Modifier == Required {
+				severity = Breaking
+			}
+			d.add(severity, path, fmt.Sprintf("presence modifier changed: '%s' -> '%s'",
+				oldField.Modifier.String(), newField.Modifier.String()))
+		}
+		if !reflect.DeepEqual(oldField.Default, newField.Default) {
+			d.add(Risky, path, "default value changed")
+		}
+		if oldField.Name != newField.Name {
+			d.add(Risky, path, fmt.Sprintf("renamed to %s", newField.Name))
+		}
+		d.diffAnnotations(oldField.Annotations, newField.Annotations, path)
+	}
+	for _, newField := range newFields {
+		if _, ok := oldMap[newField.ID]; ok {
+			continue
+		}
+		path := fmt.Sprintf("%s %s", context, newField.Name)
+		if newField.Modifier == Required {
+			d.add(Breaking, path, fmt.Sprintf("added as required with ID=%d", newField.ID))
+		} else {
+			d.add(Compatible, path, fmt.Sprintf("added with ID=%d", newField.ID))
+		}
+	}
+}
+
+func (d *Differ) diffServices(oldServices, newServices []*Service) {
+	newMap := make(map[string]*Service)
+	for _, service := range newServices {
+		newMap[service.Name] = service
+	}
+	oldMap := make(map[string]*Service)
+	for _, oldService := range oldServices {
+		oldMap[oldService.Name] = oldService
+		path := "service " + oldService.Name
+		newService, ok := newMap[oldService.Name]
+		if !ok {
+			d.add(Breaking, path, "removed")
+			continue
+		}
+		if oldService.Extends != newService.Extends {
+			// Adding inheritance only adds methods.
+			severity := Breaking
+			if oldService.Extends == "" {
+				severity = Compatible
+			}
+			d.add(severity, path, fmt.Sprintf("extends changed: '%s' -> '%s'", oldService.Extends, newService.Extends))
+		}
+		d.diffAnnotations(oldService.Annotations, newService.Annotations, path)
+		d.diffMethods(oldService.Methods, newService.Methods, path)
+	}
+	for _, newService := range newServices {
+		if _, ok := oldMap[newService.Name]; !ok {
+			d.add(Compatible, "service "+newService.Name, "added")
+		}
+	}
+}
+
+func (d *Differ) diffMethods(oldMethods, newMethods []*Method, context string) {
+	newMap := make(map[string]*Method)
+	for _, method := range newMethods {
+		newMap[method.Name] = method
+	}
+	oldMap := make(map[string]*Method)
+	for _, oldMethod := range oldMethods {
+		oldMap[oldMethod.Name] = oldMethod
+		path := fmt.Sprintf("%s: method %s", context, oldMethod.Name)
+		newMethod, ok := newMap[oldMethod.Name]
+		if !ok {
+			d.add(Breaking, path, "removed")
+			continue
+		}
+		if oldMethod.Oneway != newMethod.Oneway {
+			d.add(Breaking, path, "one way modifier changed")
+		}
+		d.diffType(oldMethod.ReturnType, newMethod.ReturnType, Breaking, path+": return type")
+		d.diffAnnotations(oldMethod.Annotations, newMethod.Annotations, path)
+		d.diffFields(oldMethod.Arguments, newMethod.Arguments, path+": argument")
+		d.diffFields(oldMethod.Exceptions, newMethod.Exceptions, path+": exception")
+	}
+	for _, newMethod := range newMethods {
+		if _, ok := oldMap[newMethod.Name]; !ok {
+			d.add(Compatible, fmt.Sprintf("%s: method %s", context, newMethod.Name), "added")
+		}
+	}
+}
+
+func (d *Differ) diffScopes(oldScopes, newScopes []*Scope) {
+	newMap := make(map[string]*Scope)
+	for _, scope := range newScopes {
+		newMap[scope.Name] = scope
+	}
+	oldMap := make(map[string]*Scope)
+	for _, oldScope := range oldScopes {
+		oldMap[oldScope.Name] = oldScope
+		path := "scope " + oldScope.Name
+		newScope, ok := newMap[oldScope.Name]
+		if !ok {
+			d.add(Breaking, path, "removed")
+			continue
+		}
+		if oldScope.Version != newScope.Version {
+			d.add(Breaking, path, fmt.Sprintf("version changed: %d -> %d", oldScope.Version, newScope.Version))
+		}
+		// Renaming prefix variables doesn't change the topics.
+		oldPrefix := normalizeScopePrefix(oldScope.Prefix.String)
+		newPrefix := normalizeScopePrefix(newScope.Prefix.String)
+		if oldPrefix != newPrefix {
+			d.add(Breaking, path, fmt.Sprintf("prefix changed: '%s' -> '%s'", oldPrefix, newPrefix))
+		} else if oldScope.Prefix.String != newScope.Prefix.String {
+			d.add(Risky, path, fmt.Sprintf("prefix variables renamed: '%s' -> '%s'",
+				oldScope.Prefix.String, newScope.Prefix.String))
+		}
+		d.diffAnnotations(oldScope.Annotations, newScope.Annotations, path)
+		d.diffOperations(oldScope.Operations, newScope.Operations, path)
+	}
+	for _, newScope := range newScopes {
+		if _, ok := oldMap[newScope.Name]; !ok {
+			d.add(Compatible, "scope "+newScope.Name, "added")
+		}
+	}
+}
+
+func (d *Differ) diffOperations(oldOps, newOps []*Operation, context string) {
+	newMap := make(map[string]*Operation)
+	for _, op := range newOps {
+		newMap[op.Name] = op
+	}
+	oldMap := make(map[string]*Operation)
+	for _, oldOp := range oldOps {
+		oldMap[oldOp.Name] = oldOp
+		path := fmt.Sprintf("%s: operation %s", context, oldOp.Name)
+		newOp, ok := newMap[oldOp.Name]
+		if !ok {
+			d.add(Breaking, path, "removed")
+			continue
+		}
+		d.diffType(oldOp.Type, newOp.Type, Breaking, path)
+		d.diffType(oldOp.ReplyType(), newOp.ReplyType(), Breaking, path+" reply")
+		d.diffAnnotations(oldOp.Annotations, newOp.Annotations, path)
+	}
+	for _, newOp := range newOps {
+		if _, ok := oldMap[newOp.Name]; !ok {
+			d.add(Compatible, fmt.Sprintf("%s: operation %s", context, newOp.Name), "added")
+		}
+	}
+}
+
+// diffType compares the underlying types, adding a change with the given
+// severity if they differ.
+func (d *Differ) diffType(oldType, newType *Type, severity ChangeSeverity, path string) {
+	if oldType == nil || newType == nil {
+		if oldType != newType {
+			d.add(severity, path, fmt.Sprintf("type changed: '%v' -> '%v'", oldType, newType))
+		}
+		return
+	}
+	oldUnderlying := underlyingTypeString(d.oldFrugal, oldType)
+	newUnderlying := underlyingTypeString(d.newFrugal, newType)
+	if oldUnderlying != newUnderlying {
+		d.add(severity, path, fmt.Sprintf("type changed: '%s' -> '%s'", oldUnderlying, newUnderlying))
+	}
+}
+
+// underlyingTypeString returns the type with typedefs, including those of
+// container elements, resolved.
+func underlyingTypeString(f *Frugal, t *Type) string {
+	underlying := f.UnderlyingType(t)
+	switch underlying.Name {
+	case "map":
+		return fmt.Sprintf("map<%s,%s>", underlyingTypeString(f, underlying.KeyType), underlyingTypeString(f, underlying.ValueType))
+	case "list", "set":
+		return fmt.Sprintf("%s<%s>", underlying.Name, underlyingTypeString(f, underlying.ValueType))
+	}
+	return underlying.Name
+}
+
+// Annotations affect generated code and tooling rather than the wire format,
+// so changes to them are risky, except adding deprecations, which only warn
+// consumers.
+func (d *Differ) diffAnnotations(oldAnnotations, newAnnotations Annotations, path string) {
+	for _, oldAnnotation := range oldAnnotations {
+		newValue, ok := newAnnotations.Get(oldAnnotation.Name)
+		if !ok {
+			d.add(Risky, path, fmt.Sprintf("annotation %s removed", oldAnnotation.Name))
+		} else if newValue != oldAnnotation.Value {
+			d.add(Risky, path, fmt.Sprintf("annotation %s changed: '%s' -> '%s'",
+				oldAnnotation.Name, oldAnnotation.Value, newValue))
+		}
+	}
+	for _, newAnnotation := range newAnnotations {
+		if _, ok := oldAnnotations.Get(newAnnotation.Name); ok {
+			continue
+		}
+		severity := Risky
+		if newAnnotation.Name == DeprecatedAnnotation {
+			severity = Compatible
+		}
+		d.add(severity, path, fmt.Sprintf("annotation %s added", newAnnotation.Name))
+	}
+}
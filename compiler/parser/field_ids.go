@@ -0,0 +1,199 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// FieldIDs maps field IDs to the names of the fields they are assigned to.
+type FieldIDs map[int]string
+
+// FieldIDBaseline records every field ID ever assigned in each struct,
+// exception, union, and method argument or exception list, keyed by
+// definition, e.g. "struct base.Thing" or "service base.BaseFoo: method
+// basePing arguments". Fields are never removed from a baseline so that their
+// IDs are never reused.
+type FieldIDBaseline map[string]FieldIDs
+
+// ReadFieldIDBaseline reads a baseline from the given JSON file. An empty
+// baseline is returned if the file does not exist.
+func ReadFieldIDBaseline(path string) (FieldIDBaseline, error) {
+	baseline := FieldIDBaseline{}
+	contents, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return baseline, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(contents, &baseline); err != nil {
+		return nil, fmt.Errorf("Invalid field ID baseline %s: %s", path, err)
+	}
+	return baseline, nil
+}
+
+// WriteFieldIDBaseline writes the baseline to the given JSON file.
+func WriteFieldIDBaseline(path string, baseline FieldIDBaseline) error {
+	contents, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(contents, '\n'), 0644)
+}
+
+// FieldIDRule checks the field IDs of a definition and logs any violations.
+// assigned contains the field IDs previously assigned in the definition
+// according to the baseline, which is empty if the definition is new.
+type FieldIDRule func(logger ValidationLogger, definition string, fields []*Field, assigned FieldIDs)
+
+// DefaultFieldIDRules are the rules used by a FieldIDChecker when none are
+// given. The grammar already requires field IDs to be explicit.
+var DefaultFieldIDRules = []FieldIDRule{PositiveFieldIDs, UniqueFieldIDs, NoReusedFieldIDs}
+
+// PositiveFieldIDs requires field IDs to be positive. Non-positive IDs are
+// reserved for implicitly assigned fields in thrift.
+func PositiveFieldIDs(logger ValidationLogger, definition string, fields []*Field, assigned FieldIDs) {
+	for _, field := range fields {
+		if field.ID <= 0 {
+			logger.LogError(definition+":", fmt.Sprintf("field %s has non-positive ID=%d", field.Name, field.ID))
+		}
+	}
+}
+
+// UniqueFieldIDs requires field IDs to be unique within a definition.
+func UniqueFieldIDs(logger ValidationLogger, definition string, fields []*Field, assigned FieldIDs) {
+	names := make(map[int]string)
+	for _, field := range fields {
+		if other, ok := names[field.ID]; ok {
+			logger.LogError(definition+":", fmt.Sprintf("fields %s and %s have the same ID=%d",
+				other, field.Name, field.ID))
+			continue
+		}
+		names[field.ID] = field.Name
+	}
+}
+
+// NoReusedFieldIDs requires that field IDs in the baseline are only used by
+// the field they were originally assigned to, including after that field has
+// been removed.
+func NoReusedFieldIDs(logger ValidationLogger, definition string, fields []*Field, assigned FieldIDs) {
+	for _, field := range fields {
+		if name, ok := assigned[field.ID]; ok && name != field.Name {
+			logger.LogError(definition+":", fmt.Sprintf("field %s reuses ID=%d previously assigned to field %s",
+				field.Name, field.ID, name))
+		}
+	}
+}
+
+// FieldIDChecker checks the field IDs in a frugal file with a set of rules,
+// preventing changes which would corrupt data on the wire.
+type FieldIDChecker struct {
+	logger ValidationLogger
+	rules  []FieldIDRule
+}
+
+// NewFieldIDChecker constructs a field ID checker that logs violations of the
+// given rules, or DefaultFieldIDRules if none are given, to standard output.
+func NewFieldIDChecker(rules ...FieldIDRule) *FieldIDChecker {
+	return NewFieldIDCheckerWithLogger(&stdOutLogger{}, rules...)
+}
+
+// NewFieldIDCheckerWithLogger constructs a field ID checker which uses the
+// given logger to log violations of the given rules, or DefaultFieldIDRules if
+// none are given.
+func NewFieldIDCheckerWithLogger(logger ValidationLogger, rules ...FieldIDRule) *FieldIDChecker {
+	if len(rules) == 0 {
+		rules = DefaultFieldIDRules
+	}
+	return &FieldIDChecker{
+		logger: logger,
+		rules:  rules,
+	}
+}
+
+// Check checks the field IDs in the given file against the baseline, which may
+// be empty. It returns a new baseline which additionally contains the field IDs
+// assigned in the file, along with an error if any rule was violated.
+func (c *FieldIDChecker) Check(file string, baseline FieldIDBaseline) (FieldIDBaseline, error) {
+	frugal, err := ParseFrugal(file)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := make(FieldIDBaseline, len(baseline))
+	for definition, assigned := range baseline {
+		copied := make(FieldIDs, len(assigned))
+		for id, name := range assigned {
+			copied[id] = name
+		}
+		updated[definition] = copied
+	}
+
+	for _, definition := range fieldDefinitions(frugal) {
+		fields := definition.fields
+		assigned := baseline[definition.name]
+		for _, rule := range c.rules {
+			rule(c.logger, definition.name, fields, assigned)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if _, ok := updated[definition.name]; !ok {
+			updated[definition.name] = FieldIDs{}
+		}
+		for _, field := range fields {
+			if _, ok := updated[definition.name][field.ID]; !ok {
+				updated[definition.name][field.ID] = field.Name
+			}
+		}
+	}
+
+	if c.logger.ErrorsLogged() {
+		return updated, fmt.Errorf("FAILED: field ID check of %s", file)
+	}
+	return updated, nil
+}
+
+// fieldDefinition is a named list of fields with IDs.
+type fieldDefinition struct {
+	name   string
+	fields []*Field
+}
+
+// fieldDefinitions returns the field lists defined in the given file.
+func fieldDefinitions(f *Frugal) []fieldDefinition {
+	definitions := []fieldDefinition{}
+	for _, s := range f.Structs {
+		definitions = append(definitions, fieldDefinition{fmt.Sprintf("struct %s.%s", f.Name, s.Name), s.Fields})
+	}
+	for _, s := range f.Exceptions {
+		definitions = append(definitions, fieldDefinition{fmt.Sprintf("exception %s.%s", f.Name, s.Name), s.Fields})
+	}
+	for _, s := range f.Unions {
+		definitions = append(definitions, fieldDefinition{fmt.Sprintf("union %s.%s", f.Name, s.Name), s.Fields})
+	}
+	for _, service := range f.Services {
+		for _, method := range service.Methods {
+			context := fmt.Sprintf("service %s.%s: method %s", f.Name, service.Name, method.Name)
+			definitions = append(definitions,
+				fieldDefinition{context + " arguments", method.Arguments},
+				fieldDefinition{context + " exceptions", method.Exceptions})
+		}
+	}
+	return definitions
+}
@@ -0,0 +1,879 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const formatIndent = "    "
+
+// scopeVersion matches the version of a scope, e.g. "v2".
+var scopeVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// formatOrder is the order definitions are grouped in by kind, given by the
+// keywords declaring them.
+var formatOrder = []string{
+	"namespace", "include", "typedef", "const", "enum", "struct", "union", "exception", "service", "scope",
+}
+
+// Format returns the canonical formatting of the given source of a frugal
+// file. Definitions are grouped by kind in the order namespaces, includes,
+// typedefs, constants, enums, structs, unions, exceptions, services, and
+// scopes, keeping their order within each kind. Bodies are indented with four
+// spaces, enum values are numbered explicitly, and types, values, and
+// annotations are written the same way everywhere.
+//
+// The parser only keeps doc comments, so other comments are placed by
+// scanning the source: a comment is kept with the definition or member it
+// precedes, or follows on the same line. Comments separated from the first
+// definition by a blank line are kept at the top of the file.
+func Format(file string, src []byte) ([]byte, error) {
+	// Definitions are regrouped by kind, which would separate them from the
+	// directives around them.
+	if HasDirectives(src) {
+		return nil, fmt.Errorf("parser: can't format %s: preprocessor directives aren't supported", file)
+	}
+
+	parsed, err := ParseReader(file, bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+
+	f := &formatter{frugal: parsed.(*Frugal), tokens: scanFrugal(string(src))}
+	if err := f.matchStatements(); err != nil {
+		return nil, fmt.Errorf("parser: can't format %s: %s", file, err)
+	}
+	if err := f.placeComments(); err != nil {
+		return nil, fmt.Errorf("parser: can't format %s: %s", file, err)
+	}
+	formatted := f.format()
+
+	// Formatting must not change what the file defines.
+	reparsed, err := ParseReader(file, bytes.NewReader(formatted))
+	if err != nil || !reflect.DeepEqual(parsed, reparsed) {
+		return nil, fmt.Errorf("parser: can't format %s: formatting changes its definitions", file)
+	}
+	return formatted, nil
+}
+
+type fmtTokenKind int
+
+const (
+	fmtWord fmtTokenKind = iota
+	fmtPunct
+	fmtString
+	fmtComment
+	fmtDoc
+)
+
+// fmtToken is a token of frugal source, scanned to place the comments the
+// parser discards.
+type fmtToken struct {
+	kind        fmtTokenKind
+	text        string
+	line        int  // Line the token starts on
+	endLine     int  // Line the token ends on
+	depth       int  // Number of brackets enclosing the token
+	blankBefore bool // Whether a blank line precedes the token
+}
+
+func (t *fmtToken) isComment() bool {
+	return t.kind == fmtComment || t.kind == fmtDoc
+}
+
+// scanFrugal splits frugal source into tokens. Only comments, brackets, and
+// the words declaring definitions and naming their members need to be right.
+func scanFrugal(src string) []*fmtToken {
+	tokens := []*fmtToken{}
+	line, depth, lastEnd := 1, 0, 0
+	for i := 0; i < len(src); {
+		c := src[i]
+		if c == '\n' {
+			line++
+			i++
+			continue
+		}
+		if strings.IndexByte(" \t\r\f", c) >= 0 {
+			i++
+			continue
+		}
+
+		start := i
+		kind := fmtPunct
+		switch {
+		case strings.HasPrefix(src[i:], "/*"):
+			kind = fmtComment
+			if strings.HasPrefix(src[i:], "/**@") {
+				kind = fmtDoc
+			}
+			if end := strings.Index(src[i+2:], "*/"); end >= 0 {
+				i += end + 4
+			} else {
+				i = len(src)
+			}
+		case strings.HasPrefix(src[i:], "//") || c == '#':
+			kind = fmtComment
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			kind = fmtString
+			for i++; i < len(src) && src[i] != c; i++ {
+				if src[i] == '\\' && i+1 < len(src) && src[i+1] == c {
+					i++
+				}
+			}
+			i++
+			if i > len(src) {
+				i = len(src)
+			}
+		case isFmtWordChar(c) && isScopePrefix(tokens):
+			kind = fmtWord
+			i = scanScopePrefix(src, i)
+		case isFmtWordChar(c):
+			kind = fmtWord
+			for i < len(src) && isFmtWordChar(src[i]) {
+				i++
+			}
+		default:
+			i++
+		}
+
+		text := strings.TrimRight(src[start:i], " \t\r")
+		token := &fmtToken{
+			kind:        kind,
+			text:        text,
+			line:        line,
+			endLine:     line + strings.Count(text, "\n"),
+			blankBefore: strings.Count(src[lastEnd:start], "\n") > 1,
+		}
+		switch text {
+		case "{", "(", "[":
+			token.depth = depth
+			depth++
+		case "}", ")", "]":
+			if depth > 0 {
+				depth--
+			}
+			token.depth = depth
+		default:
+			token.depth = depth
+		}
+		tokens = append(tokens, token)
+		line = token.endLine
+		lastEnd = i
+	}
+	return tokens
+}
+
+func isFmtWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("_.-+*", c) >= 0
+}
+
+// isScopePrefix returns true if the next token is the prefix of a scope,
+// following "scope <name> prefix" or "scope <name> <version> prefix".
+func isScopePrefix(tokens []*fmtToken) bool {
+	words := []string{}
+	for i := len(tokens) - 1; i >= 0 && len(words) < 4; i-- {
+		if tokens[i].isComment() {
+			continue
+		}
+		if tokens[i].kind != fmtWord || tokens[i].depth != 0 {
+			return false
+		}
+		words = append(words, tokens[i].text)
+		if len(words) == 3 && words[2] == "scope" {
+			return words[0] == "prefix"
+		}
+	}
+	return len(words) == 4 && words[0] == "prefix" && words[3] == "scope" && scopeVersion.MatchString(words[1])
+}
+
+// scanScopePrefix returns the end of the scope prefix starting at i.
+func scanScopePrefix(src string, i int) int {
+	for {
+		if i < len(src) && src[i] == '{' {
+			end := strings.IndexByte(src[i:], '}')
+			if end < 0 {
+				return len(src)
+			}
+			i += end + 1
+		} else {
+			for i < len(src) && strings.IndexByte("\r\n\t\f .{}", src[i]) < 0 {
+				i++
+			}
+		}
+		if i+1 >= len(src) || src[i] != '.' {
+			return i
+		}
+		i++
+	}
+}
+
+// fmtStatement is a definition along with the position of its source and the
+// comments placed around it.
+type fmtStatement struct {
+	keyword    string
+	definition interface{}
+
+	start   int   // Index of the declaring keyword
+	end     int   // Index of the last token which isn't a comment
+	open    int   // Index of the body's opening brace, or -1
+	close   int   // Index of the body's closing brace, or -1
+	members []int // Indexes of the names of the body's members
+
+	leading      []*fmtToken
+	openTrailing []*fmtToken
+	closing      []*fmtToken
+	trailing     []*fmtToken
+
+	memberLeading  [][]*fmtToken
+	memberTrailing [][]*fmtToken
+}
+
+type formatter struct {
+	frugal     *Frugal
+	tokens     []*fmtToken
+	statements []*fmtStatement
+	header     []*fmtToken
+	footer     []*fmtToken
+	buf        bytes.Buffer
+}
+
+// matchStatements finds the source of each definition.
+func (f *formatter) matchStatements() error {
+	counts := make(map[string]int)
+	var prev *fmtToken
+	for i, token := range f.tokens {
+		if token.isComment() {
+			continue
+		}
+		startsStatement := prev == nil || prev.endLine < token.line || strings.Contains(";})]", prev.text)
+		prev = token
+		if token.kind != fmtWord || token.depth != 0 || !startsStatement {
+			continue
+		}
+		definitions := f.definitions(token.text)
+		if definitions.Kind() != reflect.Slice {
+			continue
+		}
+		n := counts[token.text]
+		counts[token.text]++
+		if n >= definitions.Len() {
+			return fmt.Errorf("line %d: unexpected %s", token.line, token.text)
+		}
+		f.statements = append(f.statements, &fmtStatement{
+			keyword:    token.text,
+			definition: definitions.Index(n).Interface(),
+			start:      i,
+			open:       -1,
+			close:      -1,
+		})
+	}
+	for _, keyword := range formatOrder {
+		if counts[keyword] != f.definitions(keyword).Len() {
+			return fmt.Errorf("found %d of %d %s definitions", counts[keyword], f.definitions(keyword).Len(), keyword)
+		}
+	}
+
+	for i, statement := range f.statements {
+		next := len(f.tokens)
+		if i+1 < len(f.statements) {
+			next = f.statements[i+1].start
+		}
+		statement.end = statement.start
+		for j := statement.start; j < next; j++ {
+			token := f.tokens[j]
+			if token.isComment() {
+				continue
+			}
+			statement.end = j
+			if token.depth != 0 {
+				continue
+			}
+			if token.text == "{" && statement.open < 0 && statement.keyword != "const" {
+				statement.open = j
+			} else if token.text == "}" && statement.open >= 0 && statement.close < 0 {
+				statement.close = j
+			}
+		}
+		statement.members = f.findMembers(statement)
+		statement.memberLeading = make([][]*fmtToken, len(statement.members))
+		statement.memberTrailing = make([][]*fmtToken, len(statement.members))
+	}
+	return nil
+}
+
+// definitions returns the definitions declared with the given keyword.
+func (f *formatter) definitions(keyword string) reflect.Value {
+	switch keyword {
+	case "namespace":
+		return reflect.ValueOf(f.frugal.Namespaces)
+	case "include":
+		return reflect.ValueOf(f.frugal.Includes)
+	case "typedef":
+		return reflect.ValueOf(f.frugal.Typedefs)
+	case "const":
+		return reflect.ValueOf(f.frugal.Constants)
+	case "enum":
+		return reflect.ValueOf(f.frugal.Enums)
+	case "struct":
+		return reflect.ValueOf(f.frugal.Structs)
+	case "union":
+		return reflect.ValueOf(f.frugal.Unions)
+	case "exception":
+		return reflect.ValueOf(f.frugal.Exceptions)
+	case "service":
+		return reflect.ValueOf(f.frugal.Services)
+	case "scope":
+		return reflect.ValueOf(f.frugal.Scopes)
+	default:
+		return reflect.Value{}
+	}
+}
+
+// findMembers returns the indexes of the names of the members of the
+// statement's body in order, or nil if they can't all be found.
+func (f *formatter) findMembers(statement *fmtStatement) []int {
+	if statement.open < 0 || statement.close < 0 {
+		return nil
+	}
+
+	var names []string
+	var follows func(next *fmtToken, name *fmtToken) bool
+	switch d := statement.definition.(type) {
+	case *Enum:
+		for _, value := range d.Values {
+			names = append(names, value.Name)
+		}
+		follows = func(next, name *fmtToken) bool { return true }
+	case *Struct:
+		for _, field := range d.Fields {
+			names = append(names, field.Name)
+		}
+		// Types are followed by names on the same line
+		follows = func(next, name *fmtToken) bool {
+			return next == nil || next.kind != fmtWord || next.line > name.endLine
+		}
+	case *Service:
+		for _, method := range d.Methods {
+			names = append(names, method.Name)
+		}
+		follows = func(next, name *fmtToken) bool { return next != nil && next.text == "(" }
+	case *Scope:
+		for _, op := range d.Operations {
+			names = append(names, op.Name)
+		}
+		follows = func(next, name *fmtToken) bool { return next != nil && next.text == ":" }
+	default:
+		return nil
+	}
+
+	members := []int{}
+	i := statement.open + 1
+	for _, name := range names {
+		for ; i < statement.close; i++ {
+			token := f.tokens[i]
+			if token.kind == fmtWord && token.depth == 1 && token.text == name && follows(f.nextToken(i), token) {
+				break
+			}
+		}
+		if i >= statement.close {
+			return nil
+		}
+		members = append(members, i)
+		i++
+	}
+	return members
+}
+
+// nextToken returns the token after the given one which isn't a comment.
+func (f *formatter) nextToken(i int) *fmtToken {
+	for i++; i < len(f.tokens); i++ {
+		if !f.tokens[i].isComment() {
+			return f.tokens[i]
+		}
+	}
+	return nil
+}
+
+// placeComments places each comment the parser discards with a statement or
+// member. Doc comments are kept by the parser except on includes and
+// namespaces.
+func (f *formatter) placeComments() error {
+	s := -1
+	prev := -1
+	for i, token := range f.tokens {
+		if s+1 < len(f.statements) && f.statements[s+1].start == i {
+			s++
+		}
+		if !token.isComment() {
+			prev = i
+			continue
+		}
+		if token.kind == fmtDoc && !f.isDiscardedDoc(i) {
+			continue
+		}
+
+		if s < 0 {
+			if len(f.statements) == 0 {
+				f.footer = append(f.footer, token)
+			} else {
+				f.statements[0].leading = append(f.statements[0].leading, token)
+			}
+			continue
+		}
+		statement := f.statements[s]
+		sameLine := prev >= 0 && f.tokens[prev].endLine == token.line
+		if i > statement.end {
+			switch {
+			case sameLine && prev == statement.end:
+				statement.trailing = append(statement.trailing, token)
+			case s+1 < len(f.statements):
+				f.statements[s+1].leading = append(f.statements[s+1].leading, token)
+			default:
+				f.footer = append(f.footer, token)
+			}
+			continue
+		}
+		if err := f.placeInStatement(statement, i, prev, sameLine); err != nil {
+			return err
+		}
+	}
+
+	// Comments separated from the first statement by a blank line are the
+	// file's header.
+	if len(f.statements) > 0 {
+		first := f.statements[0]
+		next := f.tokens[first.start]
+		for i := first.start - 1; i >= 0 && f.tokens[i].isComment(); i-- {
+			if f.tokens[i].kind == fmtDoc && !f.isDiscardedDoc(i) {
+				next = f.tokens[i]
+			}
+		}
+		for i := len(first.leading) - 1; i >= 0; i-- {
+			after := next
+			if i+1 < len(first.leading) {
+				after = first.leading[i+1]
+			}
+			if after.blankBefore {
+				f.header = first.leading[:i+1]
+				first.leading = first.leading[i+1:]
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// placeInStatement places the comment at index i, which is within the
+// statement.
+func (f *formatter) placeInStatement(statement *fmtStatement, i, prev int, sameLine bool) error {
+	token := f.tokens[i]
+	switch {
+	case statement.open < 0 || i < statement.open:
+		if sameLine {
+			statement.openTrailing = append(statement.openTrailing, token)
+		} else {
+			statement.leading = append(statement.leading, token)
+		}
+	case statement.close >= 0 && i > statement.close:
+		statement.trailing = append(statement.trailing, token)
+	case sameLine && prev == statement.open:
+		statement.openTrailing = append(statement.openTrailing, token)
+	case statement.members == nil && len(f.memberNames(statement)) > 0:
+		return fmt.Errorf("line %d: can't tell which member the comment belongs to", token.line)
+	case sameLine:
+		// Comments on the same line follow the member before them
+		owner := -1
+		for k, member := range statement.members {
+			if member <= prev {
+				owner = k
+			}
+		}
+		if owner >= 0 {
+			statement.memberTrailing[owner] = append(statement.memberTrailing[owner], token)
+		} else if len(statement.members) > 0 {
+			statement.memberLeading[0] = append(statement.memberLeading[0], token)
+		} else {
+			statement.closing = append(statement.closing, token)
+		}
+	default:
+		// Comments on their own line precede the next member
+		for k, member := range statement.members {
+			if member > i {
+				statement.memberLeading[k] = append(statement.memberLeading[k], token)
+				return nil
+			}
+		}
+		statement.closing = append(statement.closing, token)
+	}
+	return nil
+}
+
+// isDiscardedDoc returns true if the doc comment at index i precedes an
+// include or namespace, whose doc comments the parser discards.
+func (f *formatter) isDiscardedDoc(i int) bool {
+	next := f.nextToken(i)
+	return next != nil && next.depth == 0 && (next.text == "include" || next.text == "namespace")
+}
+
+// memberNames returns the names of the members of the statement's body.
+func (f *formatter) memberNames(statement *fmtStatement) []string {
+	names := []string{}
+	switch d := statement.definition.(type) {
+	case *Enum:
+		for _, value := range d.Values {
+			names = append(names, value.Name)
+		}
+	case *Struct:
+		for _, field := range d.Fields {
+			names = append(names, field.Name)
+		}
+	case *Service:
+		for _, method := range d.Methods {
+			names = append(names, method.Name)
+		}
+	case *Scope:
+		for _, op := range d.Operations {
+			names = append(names, op.Name)
+		}
+	}
+	return names
+}
+
+// format writes the statements grouped by kind.
+func (f *formatter) format() []byte {
+	f.writeComments(f.header, "")
+	wrote := len(f.header) > 0
+	for _, keyword := range formatOrder {
+		group := 0
+		for _, statement := range f.statements {
+			if statement.keyword != keyword {
+				continue
+			}
+			// One-line definitions are only separated when commented
+			if wrote && (group == 0 || statement.open >= 0 || len(statement.leading) > 0 || len(docComment(statement.definition)) > 0) {
+				f.buf.WriteString("\n")
+			}
+			f.writeStatement(statement)
+			wrote = true
+			group++
+		}
+	}
+	if len(f.footer) > 0 {
+		if wrote {
+			f.buf.WriteString("\n")
+		}
+		f.writeComments(f.footer, "")
+	}
+	return f.buf.Bytes()
+}
+
+func (f *formatter) writeStatement(statement *fmtStatement) {
+	f.writeComments(statement.leading, "")
+	f.writeDoc(docComment(statement.definition), "")
+
+	switch d := statement.definition.(type) {
+	case *Namespace:
+		f.writeLine("", fmt.Sprintf("namespace %s %s%s", d.Scope, d.Value, formatAnnotations(d.Annotations)), statement.trailing)
+	case *Include:
+		f.writeLine("", fmt.Sprintf("include %s%s", strconv.Quote(d.Value), formatAnnotations(d.Annotations)), statement.trailing)
+	case *TypeDef:
+		f.writeLine("", fmt.Sprintf("typedef %s %s%s", formatType(d.Type), d.Name, formatAnnotations(d.Annotations)), statement.trailing)
+	case *Constant:
+		f.writeLine("", fmt.Sprintf("const %s %s = %s%s", formatType(d.Type), d.Name, formatValue(d.Value),
+			formatAnnotations(d.Annotations)), statement.trailing)
+	case *Enum:
+		lines := make([]string, len(d.Values))
+		for i, value := range d.Values {
+			lines[i] = fmt.Sprintf("%s = %d%s,", value.Name, value.Value, formatAnnotations(value.Annotations))
+		}
+		f.writeBody(statement, "enum "+d.Name, lines, func(i int) []string { return d.Values[i].Comment }, d.Annotations)
+	case *Struct:
+		lines := make([]string, len(d.Fields))
+		for i, field := range d.Fields {
+			lines[i] = formatField(field, d.Type != StructTypeUnion) + ","
+		}
+		f.writeBody(statement, fmt.Sprintf("%s %s", statement.keyword, d.Name), lines,
+			func(i int) []string { return d.Fields[i].Comment }, d.Annotations)
+	case *Service:
+		declaration := "service " + d.Name
+		if d.Extends != "" {
+			declaration += " extends " + d.Extends
+		}
+		lines := make([]string, len(d.Methods))
+		for i, method := range d.Methods {
+			lines[i] = formatMethod(method) + ","
+		}
+		f.writeBody(statement, declaration, lines, func(i int) []string { return d.Methods[i].Comment }, d.Annotations)
+	case *Scope:
+		declaration := "scope " + d.Name
+		if d.Version > 0 {
+			declaration += fmt.Sprintf(" v%d", d.Version)
+		}
+		if d.Prefix != nil && d.Prefix.String != "" {
+			declaration += " prefix " + d.Prefix.String
+		}
+		lines := make([]string, len(d.Operations))
+		for i, op := range d.Operations {
+			reply := ""
+			if op.Reply != nil {
+				reply = " -> " + formatType(op.Reply)
+			}
+			lines[i] = fmt.Sprintf("%s: %s%s%s", op.Name, formatType(op.Type), reply, formatAnnotations(op.Annotations))
+		}
+		f.writeBody(statement, declaration, lines, func(i int) []string { return d.Operations[i].Comment }, d.Annotations)
+	}
+}
+
+// writeBody writes a definition with a body of the given member lines.
+func (f *formatter) writeBody(statement *fmtStatement, declaration string, lines []string, comment func(int) []string, annotations Annotations) {
+	if len(lines) == 0 && len(statement.openTrailing) == 0 && len(statement.closing) == 0 {
+		f.writeLine("", declaration+" {}"+formatAnnotations(annotations), statement.trailing)
+		return
+	}
+
+	f.writeLine("", declaration+" {", statement.openTrailing)
+	for i, line := range lines {
+		var leading, trailing []*fmtToken
+		if i < len(statement.memberLeading) {
+			leading, trailing = statement.memberLeading[i], statement.memberTrailing[i]
+		}
+		doc := comment(i)
+		if i > 0 && (len(leading) > 0 || len(doc) > 0) {
+			f.buf.WriteString("\n")
+		}
+		f.writeComments(leading, formatIndent)
+		f.writeDoc(doc, formatIndent)
+		// Methods with commented arguments take several lines
+		for j, part := range strings.Split(line, "\n") {
+			if j < strings.Count(line, "\n") {
+				f.writeLine(formatIndent, part, nil)
+			} else {
+				f.writeLine(formatIndent, part, trailing)
+			}
+		}
+	}
+	f.writeComments(statement.closing, formatIndent)
+	f.writeLine("", "}"+formatAnnotations(annotations), statement.trailing)
+}
+
+func (f *formatter) writeLine(indent, line string, trailing []*fmtToken) {
+	f.buf.WriteString(indent + line)
+	for _, comment := range trailing {
+		f.buf.WriteString(" " + reindentComment(comment.text, indent))
+	}
+	f.buf.WriteString("\n")
+}
+
+func (f *formatter) writeComments(comments []*fmtToken, indent string) {
+	for i, comment := range comments {
+		if i > 0 && comment.blankBefore {
+			f.buf.WriteString("\n")
+		}
+		f.buf.WriteString(indent + reindentComment(comment.text, indent) + "\n")
+	}
+}
+
+func (f *formatter) writeDoc(comment []string, indent string) {
+	f.buf.WriteString(formatDoc(comment, indent))
+}
+
+// reindentComment indents the lines after the first of a block comment,
+// aligning lines starting with "*" under the opening "/*".
+func reindentComment(comment, indent string) string {
+	lines := strings.Split(comment, "\n")
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "*"):
+			line = indent + " " + line
+		default:
+			line = indent + line
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatDoc returns a doc comment with the given lines.
+func formatDoc(comment []string, indent string) string {
+	switch len(comment) {
+	case 0:
+		return ""
+	case 1:
+		if comment[0] == "" {
+			return indent + "/**@ */\n"
+		}
+		return fmt.Sprintf("%s/**@ %s */\n", indent, comment[0])
+	}
+	doc := indent + "/**@\n"
+	for _, line := range comment {
+		doc += strings.TrimRight(indent+" * "+line, " ") + "\n"
+	}
+	return doc + indent + " */\n"
+}
+
+// docComment returns the doc comment of the given definition.
+func docComment(definition interface{}) []string {
+	switch d := definition.(type) {
+	case *TypeDef:
+		return d.Comment
+	case *Constant:
+		return d.Comment
+	case *Enum:
+		return d.Comment
+	case *Struct:
+		return d.Comment
+	case *Service:
+		return d.Comment
+	case *Scope:
+		return d.Comment
+	default:
+		return nil
+	}
+}
+
+// formatMethod returns a service method, on several lines if any of its
+// arguments or exceptions have doc comments.
+func formatMethod(method *Method) string {
+	returnType := "void"
+	if method.ReturnType != nil {
+		returnType = formatType(method.ReturnType)
+	}
+	oneway := ""
+	if method.Oneway {
+		oneway = "oneway "
+	}
+	line := fmt.Sprintf("%s%s %s(%s)", oneway, returnType, method.Name, formatFields(method.Arguments, true))
+	if len(method.Exceptions) > 0 {
+		line += fmt.Sprintf(" throws (%s)", formatFields(method.Exceptions, false))
+	}
+	return line + formatAnnotations(method.Annotations)
+}
+
+// formatFields returns the arguments or exceptions of a method.
+func formatFields(fields []*Field, modifiers bool) string {
+	commented := false
+	for _, field := range fields {
+		commented = commented || len(field.Comment) > 0
+	}
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = formatField(field, modifiers)
+	}
+	if !commented {
+		return strings.Join(parts, ", ")
+	}
+
+	args := "\n"
+	for i, field := range fields {
+		args += formatDoc(field.Comment, formatIndent)
+		args += formatIndent + parts[i] + ",\n"
+	}
+	return args
+}
+
+// formatField returns a field, with its modifier unless the field is in a
+// union or throws clause, where fields are always optional.
+func formatField(field *Field, modifier bool) string {
+	line := fmt.Sprintf("%d: ", field.ID)
+	if modifier && field.Modifier == Required {
+		line += "required "
+	} else if modifier && field.Modifier == Optional {
+		line += "optional "
+	}
+	line += formatType(field.Type) + " " + field.Name
+	if field.Default != nil {
+		line += " = " + formatValue(field.Default)
+	}
+	return line + formatAnnotations(field.Annotations)
+}
+
+func formatType(t *Type) string {
+	name := t.Name
+	switch t.Name {
+	case "list", "set":
+		name = fmt.Sprintf("%s<%s>", t.Name, formatType(t.ValueType))
+	case "map":
+		name = fmt.Sprintf("map<%s, %s>", formatType(t.KeyType), formatType(t.ValueType))
+	}
+	return name + formatAnnotations(t.Annotations)
+}
+
+// formatAnnotations returns the given annotations preceded by a space, or
+// nothing if there are none.
+func formatAnnotations(annotations Annotations) string {
+	if len(annotations) == 0 {
+		return ""
+	}
+	parts := make([]string, len(annotations))
+	for i, annotation := range annotations {
+		parts[i] = annotation.Name
+		if annotation.Value != "" {
+			parts[i] += "=" + strconv.Quote(annotation.Value)
+		}
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// formatValue returns a constant or default value.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		// Empty maps are parsed as nil
+		return "{}"
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		// Doubles need a decimal point
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.Contains(s, ".") {
+			if e := strings.IndexAny(s, "e"); e >= 0 {
+				s = s[:e] + ".0" + s[e:]
+			} else {
+				s += ".0"
+			}
+		}
+		return s
+	case Identifier:
+		return string(v)
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, elem := range v {
+			parts[i] = formatValue(elem)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case []KeyValue:
+		parts := make([]string, len(v))
+		for i, kv := range v {
+			parts[i] = formatValue(kv.Key) + ": " + formatValue(kv.Value)
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	default:
+		return fmt.Sprint(v)
+	}
+}
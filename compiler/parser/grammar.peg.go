@@ -2196,6 +2196,21 @@ var g = &grammar{
 							pos:  position{line: 473, col: 60, offset: 14532},
 							name: "__",
 						},
+						&labeledExpr{
+							pos:   position{line: 473, col: 63, offset: 14535},
+							label: "version",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 473, col: 71, offset: 14543},
+								expr: &ruleRefExpr{
+									pos:  position{line: 473, col: 71, offset: 14543},
+									name: "ScopeVersion",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 473, col: 85, offset: 14557},
+							name: "__",
+						},
 						&labeledExpr{
 							pos:   position{line: 473, col: 63, offset: 14535},
 							label: "prefix",
@@ -2288,6 +2303,34 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "ScopeVersion",
+			pos:  position{line: 499, col: 1, offset: 15325},
+			expr: &actionExpr{
+				pos: position{line: 499, col: 17, offset: 15341},
+				run: (*parser).callonScopeVersion1,
+				expr: &seqExpr{
+					pos: position{line: 499, col: 17, offset: 15341},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 499, col: 17, offset: 15341},
+							val:        "v",
+							ignoreCase: false,
+						},
+						&oneOrMoreExpr{
+							pos: position{line: 499, col: 21, offset: 15345},
+							expr: &charClassMatcher{
+								pos:        position{line: 499, col: 21, offset: 15345},
+								val:        "[0-9]",
+								ranges:     []rune{'0', '9'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Prefix",
 			pos:  position{line: 499, col: 1, offset: 15325},
@@ -2439,6 +2482,17 @@ var g = &grammar{
 							pos:  position{line: 508, col: 76, offset: 15652},
 							name: "_",
 						},
+						&labeledExpr{
+							pos:   position{line: 508, col: 78, offset: 15654},
+							label: "reply",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 508, col: 84, offset: 15660},
+								expr: &ruleRefExpr{
+									pos:  position{line: 508, col: 84, offset: 15660},
+									name: "OperationReply",
+								},
+							},
+						},
 						&labeledExpr{
 							pos:   position{line: 508, col: 78, offset: 15654},
 							label: "annotations",
@@ -2461,6 +2515,40 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "OperationReply",
+			pos:  position{line: 525, col: 1, offset: 16243},
+			expr: &actionExpr{
+				pos: position{line: 525, col: 19, offset: 16261},
+				run: (*parser).callonOperationReply1,
+				expr: &seqExpr{
+					pos: position{line: 525, col: 19, offset: 16261},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 525, col: 19, offset: 16261},
+							val:        "->",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 525, col: 24, offset: 16266},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 525, col: 26, offset: 16268},
+							label: "typ",
+							expr: &ruleRefExpr{
+								pos:  position{line: 525, col: 30, offset: 16272},
+								name: "FieldType",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 525, col: 40, offset: 16282},
+							name: "_",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Literal",
 			pos:  position{line: 525, col: 1, offset: 16243},
@@ -3620,7 +3708,7 @@ func (p *parser) callonConstMap1() (interface{}, error) {
 	return p.cur.onConstMap1(stack["values"])
 }
 
-func (c *current) onScope1(docstr, name, prefix, operations, annotations interface{}) (interface{}, error) {
+func (c *current) onScope1(docstr, name, version, prefix, operations, annotations interface{}) (interface{}, error) {
 	ops := operations.([]interface{})
 	scope := &Scope{
 		Name:        string(name.(Identifier)),
@@ -3632,6 +3720,9 @@ func (c *current) onScope1(docstr, name, prefix, operations, annotations interfa
 		raw := docstr.([]interface{})[0].(string)
 		scope.Comment = rawCommentToDocStr(raw)
 	}
+	if version != nil {
+		scope.Version = version.(int)
+	}
 	if prefix != nil {
 		scope.Prefix = prefix.(*ScopePrefix)
 	}
@@ -3645,7 +3736,7 @@ func (c *current) onScope1(docstr, name, prefix, operations, annotations interfa
 func (p *parser) callonScope1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onScope1(stack["docstr"], stack["name"], stack["prefix"], stack["operations"], stack["annotations"])
+	return p.cur.onScope1(stack["docstr"], stack["name"], stack["version"], stack["prefix"], stack["operations"], stack["annotations"])
 }
 
 func (c *current) onEndOfScopeError1() (interface{}, error) {
@@ -3658,6 +3749,20 @@ func (p *parser) callonEndOfScopeError1() (interface{}, error) {
 	return p.cur.onEndOfScopeError1()
 }
 
+func (c *current) onScopeVersion1() (interface{}, error) {
+	version, err := strconv.Atoi(string(c.text[1:]))
+	if err != nil || version == 0 {
+		return nil, fmt.Errorf("parser: invalid scope version %s", c.text)
+	}
+	return version, nil
+}
+
+func (p *parser) callonScopeVersion1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onScopeVersion1()
+}
+
 func (c *current) onPrefix1() (interface{}, error) {
 	prefix := strings.TrimSpace(strings.TrimPrefix(string(c.text), "prefix"))
 	return newScopePrefix(prefix)
@@ -3669,7 +3774,7 @@ func (p *parser) callonPrefix1() (interface{}, error) {
 	return p.cur.onPrefix1()
 }
 
-func (c *current) onOperation1(docstr, name, typ, annotations interface{}) (interface{}, error) {
+func (c *current) onOperation1(docstr, name, typ, reply, annotations interface{}) (interface{}, error) {
 	o := &Operation{
 		Name:        string(name.(Identifier)),
 		Type:        typ.(*Type),
@@ -3679,13 +3784,26 @@ func (c *current) onOperation1(docstr, name, typ, annotations interface{}) (inte
 		raw := docstr.([]interface{})[0].(string)
 		o.Comment = rawCommentToDocStr(raw)
 	}
+	if reply != nil {
+		o.Reply = reply.(*Type)
+	}
 	return o, nil
 }
 
 func (p *parser) callonOperation1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onOperation1(stack["docstr"], stack["name"], stack["typ"], stack["annotations"])
+	return p.cur.onOperation1(stack["docstr"], stack["name"], stack["typ"], stack["reply"], stack["annotations"])
+}
+
+func (c *current) onOperationReply1(typ interface{}) (interface{}, error) {
+	return typ, nil
+}
+
+func (p *parser) callonOperationReply1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onOperationReply1(stack["typ"])
 }
 
 func (c *current) onLiteral1() (interface{}, error) {
@@ -0,0 +1,190 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ScopeHash returns a hash of the contract defined by the given scope: its
+// name, prefix, and operations along with the full definitions of the types
+// they use. The hash ignores comments, formatting, declaration order, and
+// anything else which doesn't affect the contract, so publishers and
+// subscribers built from the same contract have the same hash.
+func (f *Frugal) ScopeHash(scope *Scope) string {
+	if hash, ok := f.contractHashes[scope]; ok {
+		return hash
+	}
+	return hashCanonical(f.canonicalScope(scope))
+}
+
+// ServiceHash returns a hash of the contract defined by the given service: its
+// name and methods, including those of any services it extends, along with the
+// full definitions of the types they use. Like ScopeHash, it ignores anything
+// which doesn't affect the contract.
+func (f *Frugal) ServiceHash(service *Service) string {
+	if hash, ok := f.contractHashes[service]; ok {
+		return hash
+	}
+	return hashCanonical(f.canonicalService(service))
+}
+
+// cacheContractHashes computes the hashes of the scopes and services when the
+// file is parsed since generators may modify definitions, e.g. field modifiers,
+// while generating code.
+func (f *Frugal) cacheContractHashes() {
+	f.contractHashes = make(map[interface{}]string)
+	for _, scope := range f.Scopes {
+		f.contractHashes[scope] = hashCanonical(f.canonicalScope(scope))
+	}
+	for _, service := range f.Services {
+		f.contractHashes[service] = hashCanonical(f.canonicalService(service))
+	}
+}
+
+func (f *Frugal) canonicalScope(scope *Scope) string {
+	canonical := fmt.Sprintf("scope %s prefix %q\n", scope.Name, scope.Prefix.String)
+	if scope.Version > 0 {
+		canonical = fmt.Sprintf("scope %s v%d prefix %q\n", scope.Name, scope.Version, scope.Prefix.String)
+	}
+	operations := make([]string, 0, len(scope.Operations))
+	for _, op := range scope.Operations {
+		operation := fmt.Sprintf("operation %s %s", op.Name, f.canonicalType(op.Type, nil))
+		if reply := op.ReplyType(); reply != nil {
+			operation += " reply " + f.canonicalType(reply, nil)
+		}
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+	return canonical + strings.Join(operations, "\n")
+}
+
+func (f *Frugal) canonicalService(service *Service) string {
+	canonical := fmt.Sprintf("service %s\n", service.Name)
+	methods := make([]string, 0, len(service.Methods))
+	for _, method := range service.Methods {
+		m := fmt.Sprintf("method %s oneway=%t", method.Name, method.Oneway)
+		if method.ReturnType != nil {
+			m += " returns " + f.canonicalType(method.ReturnType, nil)
+		}
+		m += " arguments " + f.canonicalFields(method.Arguments, nil)
+		m += " throws " + f.canonicalFields(method.Exceptions, nil)
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	canonical += strings.Join(methods, "\n")
+
+	if service.Extends != "" {
+		containing := f
+		if include := service.ExtendsInclude(); include != "" {
+			containing = f.ParsedIncludes[include]
+		}
+		if containing != nil {
+			for _, parent := range containing.Services {
+				if parent.Name == service.ExtendsService() {
+					canonical += "\nextends " + containing.canonicalService(parent)
+				}
+			}
+		}
+	}
+	return canonical
+}
+
+// canonicalType returns a canonical representation of the given type,
+// expanding typedefs, enums, and data structures into their definitions.
+// Data structures already being expanded are referenced by name to handle
+// recursive types.
+func (f *Frugal) canonicalType(t *Type, expanding map[*Struct]bool) string {
+	t = f.UnderlyingType(t)
+	switch t.Name {
+	case "list", "set":
+		return fmt.Sprintf("%s<%s>", t.Name, f.canonicalType(t.ValueType, expanding))
+	case "map":
+		return fmt.Sprintf("map<%s,%s>", f.canonicalType(t.KeyType, expanding),
+			f.canonicalType(t.ValueType, expanding))
+	}
+	if t.IsPrimitive() {
+		return t.Name
+	}
+
+	if enum := findEnum(f, t); enum != nil {
+		values := make([]string, 0, len(enum.Values))
+		for _, value := range enum.Values {
+			values = append(values, fmt.Sprintf("%d:%s", value.Value, value.Name))
+		}
+		sort.Strings(values)
+		return fmt.Sprintf("enum %s{%s}", enum.Name, strings.Join(values, ","))
+	}
+
+	s, containing := findDataStructure(f, t)
+	if s == nil {
+		return t.ParamName()
+	}
+	if expanding[s] {
+		return fmt.Sprintf("%s %s", s.Type, s.Name)
+	}
+	if expanding == nil {
+		expanding = make(map[*Struct]bool)
+	}
+	expanding[s] = true
+	defer delete(expanding, s)
+	return fmt.Sprintf("%s %s%s", s.Type, s.Name, containing.canonicalFields(s.Fields, expanding))
+}
+
+// canonicalFields returns a canonical representation of the given fields,
+// ordered by ID.
+func (f *Frugal) canonicalFields(fields []*Field, expanding map[*Struct]bool) string {
+	sorted := make([]*Field, len(fields))
+	copy(sorted, fields)
+	sort.Sort(fieldsByID(sorted))
+	canonical := make([]string, len(sorted))
+	for i, field := range sorted {
+		canonical[i] = fmt.Sprintf("%d:%s %s %s", field.ID, field.Modifier.String(), field.Name,
+			f.canonicalType(field.Type, expanding))
+	}
+	return "{" + strings.Join(canonical, ",") + "}"
+}
+
+type fieldsByID []*Field
+
+func (f fieldsByID) Len() int           { return len(f) }
+func (f fieldsByID) Less(i, j int) bool { return f[i].ID < f[j].ID }
+func (f fieldsByID) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+
+// findEnum returns the enum with the given type, or nil if there is none.
+func findEnum(f *Frugal, t *Type) *Enum {
+	containing := f
+	if include := t.IncludeName(); include != "" {
+		parsed, ok := f.ParsedIncludes[include]
+		if !ok {
+			return nil
+		}
+		containing = parsed
+	}
+	for _, enum := range containing.Enums {
+		if enum.Name == t.ParamName() {
+			return enum
+		}
+	}
+	return nil
+}
+
+func hashCanonical(canonical string) string {
+	sum := sha256.Sum256([]byte(canonical))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,318 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Rules checked by the Linter.
+const (
+	// LintUnusedInclude reports includes which nothing in the file references.
+	LintUnusedInclude = "unused-include"
+	// LintEmptyScope reports scopes without operations.
+	LintEmptyScope = "empty-scope"
+	// LintOperationName reports scope operations whose names aren't
+	// UpperCamelCase.
+	LintOperationName = "operation-name"
+	// LintMissingNamespace reports files without a namespace for one of the
+	// languages being linted for.
+	LintMissingNamespace = "missing-namespace"
+	// LintReservedWord reports names which are reserved words in one of the
+	// languages being linted for and would break its generated code.
+	LintReservedWord = "reserved-word"
+)
+
+// operationName matches UpperCamelCase operation names.
+var operationName = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+
+// namespacedLanguages are the languages whose generated code is placed in a
+// namespace.
+var namespacedLanguages = map[string]bool{"go": true, "java": true, "py": true, "dart": true}
+
+// reservedWords are the words of each language which can't be used as
+// identifiers.
+var reservedWords = map[string]map[string]bool{
+	"go": wordSet("break case chan const continue default defer else fallthrough for func go goto if import " +
+		"interface map package range return select struct switch type var"),
+	"java": wordSet("abstract assert boolean break byte case catch char class const continue default do double " +
+		"else enum extends false final finally float for goto if implements import instanceof int interface " +
+		"long native new null package private protected public return short static strictfp super switch " +
+		"synchronized this throw throws transient true try void volatile while"),
+	"py": wordSet("False None True and as assert async await break class continue def del elif else except " +
+		"exec finally for from global if import in is lambda nonlocal not or pass print raise return try " +
+		"while with yield"),
+	"dart": wordSet("assert break case catch class const continue default do else enum extends false final " +
+		"finally for if in is new null rethrow return super switch this throw true try var void while with"),
+}
+
+// reservedWordLanguages are the languages checked for reserved words when
+// the Linter isn't given any, in a stable order.
+var reservedWordLanguages = []string{"go", "java", "py", "dart"}
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+// LintProblem is a style or compatibility problem found in a frugal file.
+type LintProblem struct {
+	File    string
+	Rule    string // One of the Lint rule constants
+	Message string
+}
+
+// String returns a human-readable version of the LintProblem.
+func (p *LintProblem) String() string {
+	return fmt.Sprintf("%s: %s (%s)", p.File, p.Message, p.Rule)
+}
+
+// Linter checks frugal files for problems which don't prevent generating
+// them but are likely mistakes or break generated code in some languages.
+type Linter struct {
+	logger    ValidationLogger
+	languages []string
+}
+
+// NewLinter constructs a linter that logs problems as warnings to standard
+// output. Namespaces are checked for the given languages, and reserved words
+// for the given languages or, if none are given, all of them.
+func NewLinter(languages ...string) *Linter {
+	return NewLinterWithLogger(&stdOutLogger{}, languages...)
+}
+
+// NewLinterWithLogger constructs a linter for the given languages which uses
+// the given logger to log problems as warnings.
+func NewLinterWithLogger(logger ValidationLogger, languages ...string) *Linter {
+	return &Linter{
+		logger:    logger,
+		languages: languages,
+	}
+}
+
+// Lint parses the given file and logs a warning for each problem in it.
+// Included files are parsed but not linted. The problems are returned grouped
+// by rule, in the order of the definitions.
+func (l *Linter) Lint(file string) ([]*LintProblem, error) {
+	frugal, err := ParseFrugal(file)
+	if err != nil {
+		return nil, err
+	}
+
+	lint := &lintRun{frugal: frugal}
+	lint.checkIncludes()
+	lint.checkScopes()
+	lint.checkNamespaces(l.languages)
+	if len(l.languages) == 0 {
+		lint.checkReservedWords(reservedWordLanguages)
+	} else {
+		lint.checkReservedWords(l.languages)
+	}
+
+	for _, problem := range lint.problems {
+		l.logger.LogWarning(problem.String())
+	}
+	return lint.problems, nil
+}
+
+// lintRun collects the problems found linting a file.
+type lintRun struct {
+	frugal   *Frugal
+	problems []*LintProblem
+}
+
+func (r *lintRun) report(rule, format string, args ...interface{}) {
+	r.problems = append(r.problems, &LintProblem{
+		File:    r.frugal.File,
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// checkIncludes reports includes which no type, value, or service extension
+// of the file references.
+func (r *lintRun) checkIncludes() {
+	used := make(map[string]bool)
+	var markType func(t *Type)
+	markType = func(t *Type) {
+		if t == nil {
+			return
+		}
+		used[t.IncludeName()] = true
+		markType(t.KeyType)
+		markType(t.ValueType)
+	}
+	var markValue func(value interface{})
+	markValue = func(value interface{}) {
+		switch v := value.(type) {
+		case Identifier:
+			if i := strings.Index(string(v), "."); i > 0 {
+				used[string(v)[:i]] = true
+			}
+		case []interface{}:
+			for _, elem := range v {
+				markValue(elem)
+			}
+		case []KeyValue:
+			for _, kv := range v {
+				markValue(kv.Key)
+				markValue(kv.Value)
+			}
+		}
+	}
+	markFields := func(fields []*Field) {
+		for _, field := range fields {
+			markType(field.Type)
+			markValue(field.Default)
+		}
+	}
+
+	f := r.frugal
+	for _, typedef := range f.Typedefs {
+		markType(typedef.Type)
+	}
+	for _, constant := range f.Constants {
+		markType(constant.Type)
+		markValue(constant.Value)
+	}
+	for _, s := range f.Structs {
+		markFields(s.Fields)
+	}
+	for _, s := range f.Unions {
+		markFields(s.Fields)
+	}
+	for _, s := range f.Exceptions {
+		markFields(s.Fields)
+	}
+	for _, service := range f.Services {
+		if i := strings.Index(service.Extends, "."); i > 0 {
+			used[service.Extends[:i]] = true
+		}
+		for _, method := range service.Methods {
+			markType(method.ReturnType)
+			markFields(method.Arguments)
+			markFields(method.Exceptions)
+		}
+	}
+	for _, scope := range f.Scopes {
+		for _, op := range scope.Operations {
+			markType(op.Type)
+			markType(op.ReplyType())
+		}
+	}
+
+	for _, include := range f.OrderedIncludes() {
+		if !used[include.Name] {
+			r.report(LintUnusedInclude, "include %s is never referenced", include.Value)
+		}
+	}
+}
+
+// checkScopes reports scopes without operations and operations whose names
+// aren't UpperCamelCase.
+func (r *lintRun) checkScopes() {
+	for _, scope := range r.frugal.Scopes {
+		if len(scope.Operations) == 0 {
+			r.report(LintEmptyScope, "scope %s has no operations", scope.Name)
+		}
+	}
+	for _, scope := range r.frugal.Scopes {
+		for _, op := range scope.Operations {
+			if !operationName.MatchString(op.Name) {
+				r.report(LintOperationName, "operation %s.%s should be UpperCamelCase", scope.Name, op.Name)
+			}
+		}
+	}
+}
+
+// checkNamespaces reports the languages with namespaces which the file has
+// no namespace for.
+func (r *lintRun) checkNamespaces(languages []string) {
+	for _, lang := range languages {
+		if namespacedLanguages[lang] && r.frugal.Namespace(lang) == nil {
+			r.report(LintMissingNamespace, "no %s namespace", lang)
+		}
+	}
+}
+
+// lintIdentifier is a name which generated code uses as an identifier.
+type lintIdentifier struct {
+	kind string
+	name string
+	// capitalized is true if the Go generator capitalizes the name, so it
+	// can't collide with a Go keyword.
+	capitalized bool
+}
+
+// checkReservedWords reports names which are reserved words in the given
+// languages.
+func (r *lintRun) checkReservedWords(languages []string) {
+	f := r.frugal
+	identifiers := []lintIdentifier{}
+	add := func(kind, name string, capitalized bool) {
+		identifiers = append(identifiers, lintIdentifier{kind: kind, name: name, capitalized: capitalized})
+	}
+	addStructs := func(kind string, structs []*Struct) {
+		for _, s := range structs {
+			add(kind, s.Name, false)
+			for _, field := range s.Fields {
+				add("field", s.Name+"."+field.Name, true)
+			}
+		}
+	}
+
+	for _, typedef := range f.Typedefs {
+		add("typedef", typedef.Name, false)
+	}
+	for _, constant := range f.Constants {
+		add("constant", constant.Name, true)
+	}
+	for _, enum := range f.Enums {
+		add("enum", enum.Name, false)
+		for _, value := range enum.Values {
+			add("enum value", enum.Name+"."+value.Name, true)
+		}
+	}
+	addStructs("struct", f.Structs)
+	addStructs("union", f.Unions)
+	addStructs("exception", f.Exceptions)
+	for _, service := range f.Services {
+		for _, method := range service.Methods {
+			add("method", service.Name+"."+method.Name, true)
+			for _, arg := range method.Arguments {
+				add("argument", service.Name+"."+method.Name+"."+arg.Name, false)
+			}
+		}
+	}
+	for _, scope := range f.Scopes {
+		for _, variable := range scope.Prefix.Variables {
+			add("prefix variable", scope.Name+"."+variable, false)
+		}
+	}
+
+	for _, identifier := range identifiers {
+		name := identifier.name[strings.LastIndex(identifier.name, ".")+1:]
+		for _, lang := range languages {
+			if !reservedWords[lang][name] || (lang == "go" && identifier.capitalized) {
+				continue
+			}
+			r.report(LintReservedWord, "%s %s is a reserved word in %s", identifier.kind, identifier.name, lang)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Ownership records who to contact about a scope, scope operation, or
+// service contract.
+type Ownership struct {
+	Kind   string // "scope", "operation", or "service"
+	Name   string // Operations are named <scope>.<operation>
+	Owners []string
+	Slack  string
+}
+
+// String returns a human-readable version of the Ownership.
+func (o *Ownership) String() string {
+	owners := "unowned"
+	if len(o.Owners) > 0 {
+		owners = strings.Join(o.Owners, ", ")
+	}
+	if o.Slack != "" {
+		owners += " (" + o.Slack + ")"
+	}
+	return fmt.Sprintf("%s %s: %s", o.Kind, o.Name, owners)
+}
+
+// Ownerships returns the ownership of each scope and service in the file.
+// Operations are only included if they are annotated with owners or a Slack
+// channel which differ from their scope's.
+func (f *Frugal) Ownerships() []*Ownership {
+	ownerships := []*Ownership{}
+	for _, scope := range f.Scopes {
+		owners, _ := scope.Annotations.Owners()
+		slack, _ := scope.Annotations.Slack()
+		ownerships = append(ownerships, &Ownership{
+			Kind:   "scope",
+			Name:   scope.Name,
+			Owners: owners,
+			Slack:  slack,
+		})
+		for _, op := range scope.Operations {
+			_, hasOwners := op.Annotations.Owners()
+			_, hasSlack := op.Annotations.Slack()
+			if !hasOwners && !hasSlack {
+				continue
+			}
+			ownerships = append(ownerships, &Ownership{
+				Kind:   "operation",
+				Name:   scope.Name + "." + op.Name,
+				Owners: op.Owners(),
+				Slack:  op.Slack(),
+			})
+		}
+	}
+	for _, service := range f.Services {
+		owners, _ := service.Annotations.Owners()
+		slack, _ := service.Annotations.Slack()
+		ownerships = append(ownerships, &Ownership{
+			Kind:   "service",
+			Name:   service.Name,
+			Owners: owners,
+			Slack:  slack,
+		})
+	}
+	return ownerships
+}
@@ -14,7 +14,9 @@
 package parser
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -39,14 +41,123 @@ const (
 
 	// DeprecatedAnnotation is the annotation to mark a service method as deprecated.
 	DeprecatedAnnotation = "deprecated"
+
+	// ReplayableAnnotation is the annotation to mark a scope operation as
+	// replayable. Generators which support it emit subscribe methods that
+	// take a replay position, allowing subscribers on persistent transports
+	// to consume previously published messages.
+	ReplayableAnnotation = "replayable"
+
+	// ReplyAnnotation is the annotation to mark a scope operation as
+	// request/reply. Its value is the type of the reply, which can also be
+	// declared after the operation's type with "->". Generators which
+	// support it emit typed request methods which publish the operation and
+	// wait for a reply, and responders which reply to them.
+	ReplyAnnotation = "reply"
+
+	// ChunkedAnnotation is the annotation to mark a binary scope operation as
+	// a chunked transfer. Its optional value is the size in bytes of the
+	// chunks. Generators which support it emit methods which send blobs as a
+	// sequence of chunks and methods which receive the reassembled blobs.
+	ChunkedAnnotation = "chunked"
+
+	// SampleRateAnnotation is the annotation on a scope operation giving
+	// the fraction of its messages to publish, greater than 0 and at most 1.
+	// Generators which support it emit publish methods which sample
+	// messages and record the decision in a request header.
+	SampleRateAnnotation = "sample_rate"
+
+	// PublishRolesAnnotation is the annotation on a scope or scope operation
+	// listing the comma-separated roles permitted to publish to it. An
+	// operation's roles take precedence over its scope's. Generators which
+	// support it expose the roles in generated metadata and pass them to the
+	// runtime authorization hook.
+	PublishRolesAnnotation = "publish_roles"
+
+	// SubscribeRolesAnnotation is the annotation on a scope or scope
+	// operation listing the comma-separated roles permitted to subscribe to
+	// it. An operation's roles take precedence over its scope's.
+	SubscribeRolesAnnotation = "subscribe_roles"
+
+	// TagsAnnotation is the annotation on a scope or service listing
+	// comma-separated tags, which can be used to select it for generation.
+	TagsAnnotation = "tags"
+
+	// OwnersAnnotation is the annotation on a scope, scope operation, or
+	// service listing the comma-separated teams or people who own it. An
+	// operation's owners take precedence over its scope's.
+	OwnersAnnotation = "owners"
+
+	// OwnerAnnotation is the singular form of OwnersAnnotation for contracts
+	// with a single owner.
+	OwnerAnnotation = "owner"
+
+	// SlackAnnotation is the annotation on a scope, scope operation, or
+	// service naming the Slack channel to contact its owners in. An
+	// operation's channel takes precedence over its scope's.
+	SlackAnnotation = "slack"
+
+	// StabilityAnnotation is the annotation on a scope or service giving its
+	// stability level, one of StabilityExperimental, StabilityStable, or
+	// StabilityFrozen. Unannotated contracts are stable. The auditor only
+	// warns about breaking changes to experimental contracts and rejects any
+	// change to frozen ones, and generators mark experimental contracts in
+	// generated docs.
+	StabilityAnnotation = "stability"
+
+	// PIIAnnotation is the annotation to mark a struct, union, or exception
+	// field as containing personally identifiable information. Generators
+	// which support it expose the tagged fields in generated metadata and
+	// generate methods clearing them, which runtime redaction uses.
+	PIIAnnotation = "pii"
+
+	// MinAnnotation and MaxAnnotation are the annotations on a struct,
+	// union, or exception field bounding its value, if it's a number, or its
+	// length, if it's a string, binary, list, set, or map. Generators which
+	// support them check the bounds when the struct is validated.
+	MinAnnotation = "min"
+	MaxAnnotation = "max"
+
+	// PatternAnnotation is the annotation on a string field giving a regular
+	// expression its value must contain a match of. Anchor the expression to
+	// match the whole value.
+	PatternAnnotation = "pattern"
+
+	// ProtocolAnnotation is the annotation on a scope giving the protocol
+	// its messages are serialized with, ProtocolJSON or ProtocolProvider.
+	// Unannotated scopes use the protocol of their provider, unless the
+	// generator is configured with another one. Generators which don't
+	// support it reject scopes annotated with ProtocolJSON.
+	ProtocolAnnotation = "protocol"
+)
+
+// Protocols of the "protocol" annotation.
+const (
+	ProtocolJSON     = "json"
+	ProtocolProvider = "provider"
+)
+
+// Stability levels of the "stability" annotation.
+const (
+	StabilityExperimental = "experimental"
+	StabilityStable       = "stable"
+	StabilityFrozen       = "frozen"
 )
 
 // ParseFrugal parses the given Frugal file into its semantic representation.
+// Conditional blocks are evaluated as when not generating code.
 func ParseFrugal(filePath string) (*Frugal, error) {
-	return parseFrugal(filePath, []string{})
+	return ParseFrugalForLang(filePath, "")
+}
+
+// ParseFrugalForLang parses the given Frugal file into its semantic
+// representation, evaluating its conditional blocks and those of its
+// includes for the given language.
+func ParseFrugalForLang(filePath, lang string) (*Frugal, error) {
+	return parseFrugal(filePath, lang, []string{})
 }
 
-func parseFrugal(filePath string, visitedIncludes []string) (*Frugal, error) {
+func parseFrugal(filePath, lang string, visitedIncludes []string) (*Frugal, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
@@ -63,7 +174,16 @@ func parseFrugal(filePath string, visitedIncludes []string) (*Frugal, error) {
 	}
 	visitedIncludes = append(visitedIncludes, name)
 
-	parsed, err := ParseReader(filePath, file)
+	src, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	src, err = Preprocess(filePath, src, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := ParseReader(filePath, bytes.NewReader(src))
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +199,7 @@ func parseFrugal(filePath string, visitedIncludes []string) (*Frugal, error) {
 			return nil, fmt.Errorf("Bad include name: %s", include)
 		}
 
-		parsedIncl, err := parseFrugal(filepath.Join(frugal.Dir, include), visitedIncludes)
+		parsedIncl, err := parseFrugal(filepath.Join(frugal.Dir, include), lang, visitedIncludes)
 		if err != nil {
 			return nil, fmt.Errorf("Include %s: %s", include, err)
 		}
@@ -99,6 +219,7 @@ func parseFrugal(filePath string, visitedIncludes []string) (*Frugal, error) {
 
 	frugal.sort() // For determinism in generated code
 	frugal.assignFrugal()
+	frugal.cacheContractHashes()
 
 	return frugal, nil
 }
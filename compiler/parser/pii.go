@@ -0,0 +1,62 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package parser
+
+// PIIFields returns the fields of the struct annotated as PII.
+func (s *Struct) PIIFields() []*Field {
+	fields := []*Field{}
+	for _, field := range s.Fields {
+		if field.Annotations.IsPII() {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// ContainsPII returns true if the struct, exception, or union has fields
+// annotated as PII or fields containing structs which do, directly or in
+// lists, sets, or map values.
+func (f *Frugal) ContainsPII(s *Struct) bool {
+	return structContainsPII(f, s, make(map[*Struct]bool))
+}
+
+// TypeContainsPII returns true if values of the type are, or contain in
+// lists, sets, or map values, structs which contain PII.
+func (f *Frugal) TypeContainsPII(t *Type) bool {
+	return typeContainsPII(f, t, make(map[*Struct]bool))
+}
+
+func structContainsPII(f *Frugal, s *Struct, visiting map[*Struct]bool) bool {
+	if visiting[s] {
+		return false
+	}
+	visiting[s] = true
+	defer delete(visiting, s)
+	for _, field := range s.Fields {
+		if field.Annotations.IsPII() || typeContainsPII(f, field.Type, visiting) {
+			return true
+		}
+	}
+	return false
+}
+
+func typeContainsPII(f *Frugal, t *Type, visiting map[*Struct]bool) bool {
+	t = f.UnderlyingType(t)
+	if t.IsContainer() {
+		return typeContainsPII(f, t.ValueType, visiting)
+	}
+	if s, containing := findDataStructure(f, t); s != nil {
+		return structContainsPII(containing, s, visiting)
+	}
+	return false
+}
@@ -0,0 +1,441 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// directive matches the keyword and arguments of a preprocessor directive on
+// its own line, e.g. `#if lang == "dart"`.
+var directive = regexp.MustCompile(`^\s*#(if|elif|else|endif|template|endtemplate|expand)\b\s*(.*?)\s*$`)
+
+// directiveArgs matches the arguments of a well-formed directive by keyword.
+// Lines matching directive whose arguments don't match, e.g.
+// "#if you remove this field", are comments. The details of the arguments are
+// checked when the directive is evaluated.
+var directiveArgs = map[string]*regexp.Regexp{
+	"if":          regexp.MustCompile(`^(lang\b.*)?$`),
+	"elif":        regexp.MustCompile(`^(lang\b.*)?$`),
+	"else":        regexp.MustCompile(`^$`),
+	"endif":       regexp.MustCompile(`^$`),
+	"template":    regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\s*\(.*)?$`),
+	"endtemplate": regexp.MustCompile(`^$`),
+	"expand":      regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\s*\(.*)?$`),
+}
+
+// langCondition matches a comparison of the language being generated in the
+// condition of an #if or #elif directive.
+var langCondition = regexp.MustCompile(`^\s*lang\s*(==|!=)\s*"([^"]*)"\s*$`)
+
+// templateCall matches the name and arguments of a #template or #expand
+// directive, e.g. `crud(Account, AccountId)`.
+var templateCall = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*\(([^()]*)\)$`)
+
+// templateParam matches a parameter reference in a template's body, e.g.
+// "{{Entity}}".
+var templateParam = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// directiveScanner finds the directives of the lines of a frugal file, which
+// are well-formed and outside of block comments.
+type directiveScanner struct {
+	inComment bool // Whether the next line starts in a block comment
+}
+
+// scan returns the keyword and arguments of the line if it's a directive.
+func (s *directiveScanner) scan(line string) (string, string, bool) {
+	if !s.inComment {
+		match := directive.FindStringSubmatch(line)
+		if match != nil && directiveArgs[match[1]].MatchString(match[2]) {
+			return match[1], match[2], true
+		}
+	}
+	s.inComment = endsInBlockComment(line, s.inComment)
+	return "", "", false
+}
+
+// conditional is the state of an #if block being preprocessed.
+type conditional struct {
+	line      int  // Line of the #if directive
+	enclosing bool // Whether the enclosing block is included
+	taken     bool // Whether a previous branch was included
+	included  bool // Whether the current branch is included
+	sawElse   bool // Whether the #else branch was reached
+}
+
+// template is a template defined by a #template directive.
+type template struct {
+	name   string
+	line   int      // Line of the #template directive
+	params []string // Names of the parameters
+	body   []string // Trimmed, non-empty lines of the body
+}
+
+// preprocessor is the state of a file being preprocessed.
+type preprocessor struct {
+	file      string
+	lang      string
+	stack     []*conditional
+	included  bool
+	templates map[string]*template
+	defining  *template // Template whose body is being read, if any
+}
+
+// HasDirectives returns true if the given source of a frugal file contains
+// preprocessor directives.
+func HasDirectives(src []byte) bool {
+	scanner := &directiveScanner{}
+	for _, line := range strings.Split(string(src), "\n") {
+		if _, _, ok := scanner.scan(line); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Preprocess evaluates the preprocessor directives of the given source of a
+// frugal file for the given language, which is empty when not generating
+// code. Directives are on their own lines outside of block comments. Lines
+// starting with "#" which aren't well-formed directives, e.g.
+// "#if you remove this field", are comments.
+//
+// Conditional blocks include lines for some languages:
+//
+//	#if lang == "dart"
+//	    Updated: Event (replayable)
+//	#elif lang != "go"
+//	    Updated: Event
+//	#endif
+//
+// Conditions compare lang with "==" or "!=" and combine comparisons with
+// "&&" and "||", where "&&" binds tighter. Blocks can be nested and have any
+// number of #elif branches and an optional #else branch.
+//
+// Templates define lines once to expand them with different arguments, which
+// replace references to the template's parameters in its body:
+//
+//	#template crud(Entity, Key)
+//	    {{Entity}}Created: {{Entity}}
+//	    {{Entity}}Deleted: {{Key}}
+//	#endtemplate
+//
+//	scope Accounts {
+//	#expand crud(Account, AccountId)
+//	#expand crud(User, UserId)
+//	}
+//
+// Templates must be defined before they're expanded, can't contain
+// directives or single-line comments, and can be defined in conditional
+// blocks. An expansion joins the lines of the body with spaces on the line of
+// the #expand directive. Other directives, template definitions, and the
+// lines of branches which aren't included are blanked, so positions in parse
+// errors are unchanged.
+func Preprocess(file string, src []byte, lang string) ([]byte, error) {
+	p := &preprocessor{
+		file:      file,
+		lang:      lang,
+		included:  true,
+		templates: make(map[string]*template),
+	}
+	scanner := &directiveScanner{}
+	lines := strings.Split(string(src), "\n")
+	for i, line := range lines {
+		keyword, args, ok := scanner.scan(line)
+		expanded, err := p.preprocessLine(line, keyword, args, ok, i+1)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s", file, i+1, err)
+		}
+		lines[i] = expanded
+	}
+	if p.defining != nil {
+		return nil, fmt.Errorf("%s:%d: #template without #endtemplate", file, p.defining.line)
+	}
+	if len(p.stack) > 0 {
+		return nil, fmt.Errorf("%s:%d: #if without #endif", file, p.stack[len(p.stack)-1].line)
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// StripTemplates blanks the template definitions of the given source of a
+// frugal file, so it can be parsed without being preprocessed, e.g. to find
+// the includes of every branch of its conditional blocks.
+func StripTemplates(src []byte) []byte {
+	scanner := &directiveScanner{}
+	lines := strings.Split(string(src), "\n")
+	defining := false
+	for i, line := range lines {
+		keyword, _, ok := scanner.scan(line)
+		switch {
+		case ok && keyword == "template":
+			defining = true
+		case ok && keyword == "endtemplate":
+			defining = false
+		case !defining:
+			continue
+		}
+		lines[i] = ""
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// preprocessLine returns what the line with the given number is replaced
+// with, given its keyword and arguments if it's a directive.
+func (p *preprocessor) preprocessLine(line, keyword, args string, isDirective bool, number int) (string, error) {
+	if p.defining != nil {
+		if !isDirective {
+			return "", p.defining.addLine(line)
+		}
+		if keyword != "endtemplate" {
+			return "", fmt.Errorf("#%s can't be used in a template", keyword)
+		}
+		if p.included {
+			p.templates[p.defining.name] = p.defining
+		}
+		p.defining = nil
+		return "", nil
+	}
+	if !isDirective {
+		if !p.included {
+			return "", nil
+		}
+		return line, nil
+	}
+
+	switch keyword {
+	case "if", "elif", "else", "endif":
+		return "", p.conditional(keyword, args, number)
+	case "template":
+		t, err := p.template(args, number)
+		p.defining = t
+		return "", err
+	case "expand":
+		if !p.included {
+			return "", nil
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		expansion, err := p.expand(args)
+		return indent + expansion, err
+	default:
+		return "", fmt.Errorf("#%s without #template", keyword)
+	}
+}
+
+// conditional evaluates a conditional block directive.
+func (p *preprocessor) conditional(keyword, condition string, number int) error {
+	if keyword == "if" {
+		result, err := evalCondition(condition, p.lang)
+		if err != nil {
+			return err
+		}
+		p.stack = append(p.stack, &conditional{
+			line:      number,
+			enclosing: p.included,
+			taken:     result,
+			included:  result,
+		})
+		p.included = p.included && result
+		return nil
+	}
+
+	if len(p.stack) == 0 {
+		return fmt.Errorf("#%s without #if", keyword)
+	}
+	top := p.stack[len(p.stack)-1]
+	switch keyword {
+	case "elif":
+		if top.sawElse {
+			return fmt.Errorf("#elif after #else")
+		}
+		result, err := evalCondition(condition, p.lang)
+		if err != nil {
+			return err
+		}
+		top.included = !top.taken && result
+		top.taken = top.taken || result
+	case "else":
+		if top.sawElse {
+			return fmt.Errorf("#else after #else")
+		}
+		top.sawElse = true
+		top.included = !top.taken
+		top.taken = true
+	case "endif":
+		p.stack = p.stack[:len(p.stack)-1]
+		p.included = top.enclosing
+		return nil
+	}
+	p.included = top.enclosing && top.included
+	return nil
+}
+
+// template starts the definition of the template declared by a #template
+// directive.
+func (p *preprocessor) template(declaration string, number int) (*template, error) {
+	name, params, err := parseTemplateCall(declaration)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.templates[name]; ok && p.included {
+		return nil, fmt.Errorf("duplicate template %s", name)
+	}
+	seen := make(map[string]bool, len(params))
+	for _, param := range params {
+		if !isTemplateParam(param) {
+			return nil, fmt.Errorf("invalid parameter %q of template %s", param, name)
+		}
+		if seen[param] {
+			return nil, fmt.Errorf("duplicate parameter %s of template %s", param, name)
+		}
+		seen[param] = true
+	}
+	return &template{name: name, line: number, params: params}, nil
+}
+
+// addLine adds a line to the template's body.
+func (t *template) addLine(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+	if hasSingleLineComment(line) {
+		return fmt.Errorf("single-line comments can't be used in template %s, use /* */", t.name)
+	}
+	for _, ref := range templateParam.FindAllStringSubmatch(line, -1) {
+		if !contains(t.params, ref[1]) {
+			return fmt.Errorf("undefined parameter %s of template %s", ref[1], t.name)
+		}
+	}
+	t.body = append(t.body, line)
+	return nil
+}
+
+// expand returns the expansion of the template call of an #expand directive.
+func (p *preprocessor) expand(call string) (string, error) {
+	name, args, err := parseTemplateCall(call)
+	if err != nil {
+		return "", err
+	}
+	t, ok := p.templates[name]
+	if !ok {
+		return "", fmt.Errorf("undefined template %s", name)
+	}
+	if len(args) != len(t.params) {
+		return "", fmt.Errorf("template %s takes %d argument(s), got %d", name, len(t.params), len(args))
+	}
+	values := make(map[string]string, len(args))
+	for i, param := range t.params {
+		values[param] = args[i]
+	}
+	expansion := strings.Join(t.body, " ")
+	return templateParam.ReplaceAllStringFunc(expansion, func(ref string) string {
+		return values[templateParam.FindStringSubmatch(ref)[1]]
+	}), nil
+}
+
+// parseTemplateCall parses the name and comma-separated arguments of a
+// #template or #expand directive.
+func parseTemplateCall(call string) (string, []string, error) {
+	match := templateCall.FindStringSubmatch(call)
+	if match == nil {
+		return "", nil, fmt.Errorf("invalid template %s, expected <name>(<args>)", call)
+	}
+	args := []string{}
+	if strings.TrimSpace(match[2]) != "" {
+		for _, arg := range strings.Split(match[2], ",") {
+			args = append(args, strings.TrimSpace(arg))
+		}
+	}
+	return match[1], args, nil
+}
+
+// isTemplateParam returns true if the name is a valid template parameter.
+func isTemplateParam(name string) bool {
+	return templateCall.MatchString(name + "()")
+}
+
+// hasSingleLineComment returns true if the line of frugal source has a "//"
+// or "#" comment outside of a string literal, which would hide the lines
+// joined after it in an expansion.
+func hasSingleLineComment(line string) bool {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#', c == '/' && i+1 < len(line) && line[i+1] == '/':
+			return true
+		}
+	}
+	return false
+}
+
+// endsInBlockComment returns true if the line of frugal source, which starts
+// in a block comment if inComment is true, ends in one.
+func endsInBlockComment(line string, inComment bool) bool {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case inComment:
+			if c == '*' && i+1 < len(line) && line[i+1] == '/' {
+				inComment = false
+				i++
+			}
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#', c == '/' && i+1 < len(line) && line[i+1] == '/':
+			return false
+		case c == '/' && i+1 < len(line) && line[i+1] == '*':
+			inComment = true
+			i++
+		}
+	}
+	return inComment
+}
+
+// evalCondition evaluates the condition of an #if or #elif directive for the
+// given language.
+func evalCondition(condition, lang string) (bool, error) {
+	if condition == "" {
+		return false, fmt.Errorf("missing condition")
+	}
+	result := false
+	for _, disjunct := range strings.Split(condition, "||") {
+		conjunction := true
+		for _, comparison := range strings.Split(disjunct, "&&") {
+			match := langCondition.FindStringSubmatch(comparison)
+			if match == nil {
+				return false, fmt.Errorf("invalid condition %s, expected comparisons of the form lang == \"<lang>\"", condition)
+			}
+			conjunction = conjunction && (match[2] == lang) == (match[1] == "==")
+		}
+		result = result || conjunction
+	}
+	return result, nil
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import "fmt"
+
+// Assumptions used to estimate the serialized size of variable-length values.
+const (
+	estimatedStringLength    = 16
+	estimatedContainerLength = 8
+)
+
+// PayloadStats contains statistics on the serialized payload of a struct,
+// exception, union, or scope operation.
+type PayloadStats struct {
+	// Name describes the definition, e.g. "struct Event" or
+	// "scope Events: operation EventCreated".
+	Name string
+
+	// Fields is the number of fields defined directly on the payload.
+	Fields int
+
+	// NestedFields is the number of fields on the payload including those of
+	// nested structs.
+	NestedFields int
+
+	// Depth is the maximum struct nesting depth of the payload.
+	Depth int
+
+	// EstimatedSize is the estimated size, in bytes, of the payload
+	// serialized with the binary protocol. Strings, binaries, and containers
+	// are assumed to be of a typical length.
+	EstimatedSize int
+}
+
+// String returns a human-readable version of the PayloadStats.
+func (p *PayloadStats) String() string {
+	return fmt.Sprintf("%s: fields=%d nested_fields=%d depth=%d estimated_size=%d",
+		p.Name, p.Fields, p.NestedFields, p.Depth, p.EstimatedSize)
+}
+
+// PayloadThresholds are the limits above which a payload is flagged. A zero
+// value disables the corresponding check.
+type PayloadThresholds struct {
+	MaxEstimatedSize int
+	MaxFields        int
+	MaxDepth         int
+}
+
+// PayloadAnalyzer reports statistics on the payloads defined in a frugal file
+// and flags those exceeding the configured thresholds.
+type PayloadAnalyzer struct {
+	logger     ValidationLogger
+	thresholds PayloadThresholds
+}
+
+// NewPayloadAnalyzer constructs a payload analyzer that logs payloads
+// exceeding the given thresholds to standard output.
+func NewPayloadAnalyzer(thresholds PayloadThresholds) *PayloadAnalyzer {
+	return &PayloadAnalyzer{
+		logger:     &stdOutLogger{},
+		thresholds: thresholds,
+	}
+}
+
+// NewPayloadAnalyzerWithLogger constructs a payload analyzer which uses the
+// given logger to log payloads exceeding the given thresholds.
+func NewPayloadAnalyzerWithLogger(logger ValidationLogger, thresholds PayloadThresholds) *PayloadAnalyzer {
+	return &PayloadAnalyzer{
+		logger:     logger,
+		thresholds: thresholds,
+	}
+}
+
+// Analyze returns statistics on the structs, exceptions, unions, and scope
+// operations defined in the given file. An error is returned if any of them
+// exceed the thresholds.
+func (p *PayloadAnalyzer) Analyze(file string) ([]*PayloadStats, error) {
+	frugal, err := ParseFrugal(file)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := []*PayloadStats{}
+	for _, s := range frugal.Structs {
+		stats = append(stats, structPayloadStats(frugal, "struct "+s.Name, s))
+	}
+	for _, s := range frugal.Exceptions {
+		stats = append(stats, structPayloadStats(frugal, "exception "+s.Name, s))
+	}
+	for _, s := range frugal.Unions {
+		stats = append(stats, structPayloadStats(frugal, "union "+s.Name, s))
+	}
+	for _, scope := range frugal.Scopes {
+		for _, op := range scope.Operations {
+			name := fmt.Sprintf("scope %s: operation %s", scope.Name, op.Name)
+			stats = append(stats, operationPayloadStats(frugal, name, op))
+		}
+	}
+
+	for _, s := range stats {
+		p.check(s)
+	}
+
+	if p.logger.ErrorsLogged() {
+		return stats, fmt.Errorf("FAILED: payload analysis of %s", file)
+	}
+	return stats, nil
+}
+
+func (p *PayloadAnalyzer) check(stats *PayloadStats) {
+	context := stats.Name + ":"
+	if p.thresholds.MaxEstimatedSize > 0 && stats.EstimatedSize > p.thresholds.MaxEstimatedSize {
+		p.logger.LogError(context, fmt.Sprintf("estimated size %d exceeds maximum of %d",
+			stats.EstimatedSize, p.thresholds.MaxEstimatedSize))
+	}
+	if p.thresholds.MaxFields > 0 && stats.NestedFields > p.thresholds.MaxFields {
+		p.logger.LogError(context, fmt.Sprintf("nested field count %d exceeds maximum of %d",
+			stats.NestedFields, p.thresholds.MaxFields))
+	}
+	if p.thresholds.MaxDepth > 0 && stats.Depth > p.thresholds.MaxDepth {
+		p.logger.LogError(context, fmt.Sprintf("depth %d exceeds maximum of %d",
+			stats.Depth, p.thresholds.MaxDepth))
+	}
+}
+
+func structPayloadStats(f *Frugal, name string, s *Struct) *PayloadStats {
+	estimate := estimateStruct(f, s, make(map[*Struct]bool))
+	return &PayloadStats{
+		Name:          name,
+		Fields:        len(s.Fields),
+		NestedFields:  estimate.fields,
+		Depth:         estimate.depth,
+		EstimatedSize: estimate.size,
+	}
+}
+
+func operationPayloadStats(f *Frugal, name string, op *Operation) *PayloadStats {
+	estimate := estimateType(f, op.Type, make(map[*Struct]bool))
+	stats := &PayloadStats{
+		Name:          name,
+		NestedFields:  estimate.fields,
+		Depth:         estimate.depth,
+		EstimatedSize: estimate.size,
+	}
+	if s, _ := findDataStructure(f, op.Type); s != nil {
+		stats.Fields = len(s.Fields)
+	}
+	return stats
+}
+
+// payloadEstimate is the estimated field count, nesting depth, and size of a
+// serialized value.
+type payloadEstimate struct {
+	fields int
+	depth  int
+	size   int
+}
+
+// estimateStruct estimates the payload of the given struct. Recursive
+// references are estimated as empty structs.
+func estimateStruct(f *Frugal, s *Struct, visiting map[*Struct]bool) payloadEstimate {
+	// Every struct ends with a field stop byte.
+	estimate := payloadEstimate{depth: 1, size: 1}
+	if visiting[s] {
+		return estimate
+	}
+	visiting[s] = true
+	defer delete(visiting, s)
+
+	largestField := 0
+	for _, field := range s.Fields {
+		value := estimateType(f, field.Type, visiting)
+		// A field header is a one byte type and two byte ID.
+		fieldSize := 3 + value.size
+		estimate.fields += 1 + value.fields
+		if value.depth+1 > estimate.depth {
+			estimate.depth = value.depth + 1
+		}
+		if s.Type == StructTypeUnion {
+			// Only one field of a union is set.
+			if fieldSize > largestField {
+				largestField = fieldSize
+			}
+		} else {
+			estimate.size += fieldSize
+		}
+	}
+	estimate.size += largestField
+	return estimate
+}
+
+// estimateType estimates the payload of a value of the given type.
+func estimateType(f *Frugal, t *Type, visiting map[*Struct]bool) payloadEstimate {
+	t = f.UnderlyingType(t)
+	switch t.Name {
+	case "bool", "byte", "i8":
+		return payloadEstimate{size: 1}
+	case "i16":
+		return payloadEstimate{size: 2}
+	case "i32":
+		return payloadEstimate{size: 4}
+	case "i64", "double":
+		return payloadEstimate{size: 8}
+	case "string", "binary":
+		return payloadEstimate{size: 4 + estimatedStringLength}
+	case "list", "set":
+		value := estimateType(f, t.ValueType, visiting)
+		// A list or set header is a one byte type and four byte length.
+		value.size = 5 + estimatedContainerLength*value.size
+		return value
+	case "map":
+		key := estimateType(f, t.KeyType, visiting)
+		value := estimateType(f, t.ValueType, visiting)
+		depth := key.depth
+		if value.depth > depth {
+			depth = value.depth
+		}
+		// A map header is a one byte key type, one byte value type, and four
+		// byte length.
+		return payloadEstimate{
+			fields: key.fields + value.fields,
+			depth:  depth,
+			size:   6 + estimatedContainerLength*(key.size+value.size),
+		}
+	}
+
+	if f.IsEnum(t) {
+		return payloadEstimate{size: 4}
+	}
+	if s, containing := findDataStructure(f, t); s != nil {
+		return estimateStruct(containing, s, visiting)
+	}
+	return payloadEstimate{}
+}
+
+// findDataStructure returns the struct, exception, or union with the given
+// type and the Frugal containing it, or nil if there is none.
+func findDataStructure(f *Frugal, t *Type) (*Struct, *Frugal) {
+	t = f.UnderlyingType(t)
+	containing := f
+	if include := t.IncludeName(); include != "" {
+		parsed, ok := f.ParsedIncludes[include]
+		if !ok {
+			return nil, nil
+		}
+		containing = parsed
+	}
+	for _, s := range containing.DataStructures() {
+		if s.Name == t.ParamName() {
+			return s, containing
+		}
+	}
+	return nil, nil
+}
@@ -18,8 +18,10 @@ package parser
 
 import (
 	"fmt"
+	"math"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 )
@@ -281,6 +283,12 @@ type Service struct {
 	Frugal      *Frugal // Pointer back to containing Frugal
 }
 
+// DocComment returns the Service's comment followed, if the Service is
+// experimental, by a note saying so for generated docs.
+func (s *Service) DocComment() []string {
+	return stabilityDocComment(s.Comment, s.Annotations)
+}
+
 // ExtendsInclude returns the name of the include this service extends from, if
 // applicable, or an empty string if not.
 func (s *Service) ExtendsInclude() string {
@@ -505,6 +513,83 @@ func (a Annotations) DeprecationValue() string {
 	return v
 }
 
+// IsReplayable returns true if the "replayable" annotation is present.
+func (a Annotations) IsReplayable() bool {
+	_, r := a.Get(ReplayableAnnotation)
+	return r
+}
+
+// Roles returns the comma-separated roles of the given annotation and true if
+// the annotation is present.
+func (a Annotations) Roles(name string) ([]string, bool) {
+	return a.List(name)
+}
+
+// Tags returns the comma-separated values of the "tags" annotation.
+func (a Annotations) Tags() []string {
+	tags, _ := a.List(TagsAnnotation)
+	return tags
+}
+
+// IsPII returns true if the "pii" annotation is present and its value, if
+// any, isn't "false".
+func (a Annotations) IsPII() bool {
+	value, ok := a.Get(PIIAnnotation)
+	return ok && value != "false"
+}
+
+// Owners returns the comma-separated values of the "owners" annotation or,
+// if not present, the "owner" annotation and true if either is present.
+func (a Annotations) Owners() ([]string, bool) {
+	if owners, ok := a.List(OwnersAnnotation); ok {
+		return owners, true
+	}
+	return a.List(OwnerAnnotation)
+}
+
+// Slack returns the value of the "slack" annotation and true if the
+// annotation is present.
+func (a Annotations) Slack() (string, bool) {
+	return a.Get(SlackAnnotation)
+}
+
+// Stability returns the value of the "stability" annotation or
+// StabilityStable if the annotation isn't present.
+func (a Annotations) Stability() string {
+	if stability, ok := a.Get(StabilityAnnotation); ok {
+		return stability
+	}
+	return StabilityStable
+}
+
+// IsExperimental returns true if the "stability" annotation is
+// StabilityExperimental.
+func (a Annotations) IsExperimental() bool {
+	return a.Stability() == StabilityExperimental
+}
+
+// Protocol returns the value of the "protocol" annotation and true if the
+// annotation is present.
+func (a Annotations) Protocol() (string, bool) {
+	return a.Get(ProtocolAnnotation)
+}
+
+// List returns the comma-separated values of the given annotation and true if
+// the annotation is present.
+func (a Annotations) List(name string) ([]string, bool) {
+	value, ok := a.Get(name)
+	if !ok {
+		return nil, false
+	}
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values, true
+}
+
 func getImports(t *Type) []string {
 	list := []string{}
 	switch t.Name {
@@ -553,10 +638,158 @@ type Operation struct {
 	Comment     []string
 	Name        string
 	Type        *Type
+	Reply       *Type // Type declared with "->", nil if none
 	Annotations Annotations
 	Scope       *Scope // Pointer back to containing Scope
 }
 
+// ReplyType returns the type of the reply to the Operation if it is
+// request/reply, declared with "->" or the reply annotation, or nil if it is
+// not.
+func (o *Operation) ReplyType() *Type {
+	if o.Reply != nil {
+		return o.Reply
+	}
+	if reply, ok := o.Annotations.Get(ReplyAnnotation); ok {
+		return &Type{Name: reply}
+	}
+	return nil
+}
+
+// IsChunked returns true if the Operation is annotated as a chunked transfer.
+func (o *Operation) IsChunked() bool {
+	_, ok := o.annotation(ChunkedAnnotation)
+	return ok
+}
+
+// ChunkSize returns the chunk size given by the Operation's "chunked"
+// annotation, or 0 if it doesn't give one.
+func (o *Operation) ChunkSize() int {
+	value, _ := o.annotation(ChunkedAnnotation)
+	size, _ := strconv.Atoi(value)
+	return size
+}
+
+// SampleRate returns the fraction of the Operation's messages to publish,
+// given by its "sample_rate" annotation, and whether it's annotated with a
+// valid rate.
+func (o *Operation) SampleRate() (float64, bool) {
+	value, ok := o.annotation(SampleRateAnnotation)
+	if !ok {
+		return 0, false
+	}
+	rate, err := parseSampleRate(value)
+	return rate, err == nil
+}
+
+// parseSampleRate parses the value of a "sample_rate" annotation, returning an
+// error if it isn't a rate greater than 0 and at most 1.
+func parseSampleRate(value string) (float64, error) {
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(rate) || rate <= 0 || rate > 1 {
+		return 0, fmt.Errorf("\"%s\" annotation requires a rate greater than 0 and at most 1", SampleRateAnnotation)
+	}
+	return rate, nil
+}
+
+// annotation returns the value of the given annotation on the Operation.
+// Annotations following a base type are parsed as the type's, so those are
+// checked as well.
+func (o *Operation) annotation(name string) (string, bool) {
+	if value, ok := o.Annotations.Get(name); ok {
+		return value, true
+	}
+	return o.Type.Annotations.Get(name)
+}
+
+// PublishRoles returns the roles permitted to publish the Operation, taken
+// from the Operation's "publish_roles" annotation or, if not present, its
+// Scope's. Nil is returned if neither is annotated.
+func (o *Operation) PublishRoles() []string {
+	return o.inheritedList(PublishRolesAnnotation)
+}
+
+// SubscribeRoles returns the roles permitted to subscribe to the Operation,
+// taken from the Operation's "subscribe_roles" annotation or, if not
+// present, its Scope's. Nil is returned if neither is annotated.
+func (o *Operation) SubscribeRoles() []string {
+	return o.inheritedList(SubscribeRolesAnnotation)
+}
+
+// Owners returns the owners of the Operation, taken from the Operation's
+// "owners" or "owner" annotation or, if not present, its Scope's. Nil is
+// returned if neither is annotated.
+func (o *Operation) Owners() []string {
+	if owners, ok := o.Annotations.Owners(); ok {
+		return owners
+	}
+	if o.Scope != nil {
+		if owners, ok := o.Scope.Annotations.Owners(); ok {
+			return owners
+		}
+	}
+	return nil
+}
+
+// Slack returns the Slack channel of the Operation's owners, taken from the
+// Operation's "slack" annotation or, if not present, its Scope's. An empty
+// string is returned if neither is annotated.
+func (o *Operation) Slack() string {
+	if slack, ok := o.Annotations.Slack(); ok {
+		return slack
+	}
+	if o.Scope != nil {
+		slack, _ := o.Scope.Annotations.Slack()
+		return slack
+	}
+	return ""
+}
+
+// Deprecated returns true if the Operation or its Scope is annotated as
+// deprecated and the associated value, taken from the Operation's annotation
+// if present.
+func (o *Operation) Deprecated() (string, bool) {
+	if value, ok := o.Annotations.Deprecated(); ok {
+		return value, true
+	}
+	if o.Scope != nil {
+		return o.Scope.Annotations.Deprecated()
+	}
+	return "", false
+}
+
+// DocComment returns the Operation's comment followed, if the Operation or
+// its Scope is deprecated, by a note saying so for generated docs.
+func (o *Operation) DocComment() []string {
+	value, ok := o.Deprecated()
+	if !ok {
+		return o.Comment
+	}
+	note := "Deprecated: this operation may be removed in a future version."
+	if value != "" {
+		note = "Deprecated: " + value
+	}
+	if len(o.Comment) == 0 {
+		return []string{note}
+	}
+	docComment := append([]string{}, o.Comment...)
+	return append(docComment, "", note)
+}
+
+// inheritedList returns the comma-separated values of the Operation's
+// annotation with the given name or, if not present, its Scope's.
+func (o *Operation) inheritedList(name string) []string {
+	if values, ok := o.Annotations.List(name); ok {
+		return values
+	}
+	if o.Scope != nil {
+		if values, ok := o.Scope.Annotations.List(name); ok {
+			return values
+		}
+	}
+	return nil
+}
+
 // ScopePrefix is the string prefix prepended to a pub/sub topic. The string
 // can contain variables of the form {foo}, e.g. "foo.{bar}.baz" where "bar"
 // is supplied at publish/subscribe time.
@@ -575,12 +808,50 @@ func (n *ScopePrefix) Template(s string) string {
 type Scope struct {
 	Comment     []string
 	Name        string
+	Version     int // Version incorporated into topics, 0 if unversioned
 	Prefix      *ScopePrefix
 	Operations  []*Operation
 	Annotations Annotations
 	Frugal      *Frugal // Pointer back to containing Frugal
 }
 
+// TopicName returns the given name of the Scope, as it's cased by a
+// language, followed by the topic token of the Scope's version, if it has
+// one, e.g. "Events.v2".
+func (s *Scope) TopicName(name, delimiter string) string {
+	return name + ScopeVersionSuffix(s.Version, delimiter)
+}
+
+// ScopeVersionSuffix returns what's appended to the name of a scope in its
+// topics at the given version: the delimiter followed by "v" and the
+// version, or nothing for version 0, an unversioned scope.
+func ScopeVersionSuffix(version int, delimiter string) string {
+	if version == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%sv%d", delimiter, version)
+}
+
+// DocComment returns the Scope's comment followed, if the Scope is
+// experimental, by a note saying so for generated docs.
+func (s *Scope) DocComment() []string {
+	return stabilityDocComment(s.Comment, s.Annotations)
+}
+
+// stabilityDocComment appends a note to the comment if the annotations mark
+// the contract experimental.
+func stabilityDocComment(comment []string, annotations Annotations) []string {
+	if !annotations.IsExperimental() {
+		return comment
+	}
+	note := "Experimental: this API may change in incompatible ways or be removed."
+	if len(comment) == 0 {
+		return []string{note}
+	}
+	docComment := append([]string{}, comment...)
+	return append(docComment, "", note)
+}
+
 // ReferencedIncludes returns a slice containing the referenced includes which
 // will need to be imported in generated code for this Scope.
 func (s *Scope) ReferencedIncludes() ([]*Include, error) {
@@ -592,10 +863,49 @@ func (s *Scope) ReferencedIncludes() ([]*Include, error) {
 		if err != nil {
 			return nil, err
 		}
+		if reply := op.ReplyType(); reply != nil {
+			includesSet, includes, err = addInclude(includesSet, includes, reply, s.Frugal)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 	return includes, nil
 }
 
+// HasReplyOperations returns true if any of the Scope's operations are
+// request/reply.
+func (s *Scope) HasReplyOperations() bool {
+	for _, op := range s.Operations {
+		if op.ReplyType() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPublishRoles returns true if the Scope or any of its operations are
+// annotated with publish roles.
+func (s *Scope) HasPublishRoles() bool {
+	for _, op := range s.Operations {
+		if op.PublishRoles() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSubscribeRoles returns true if the Scope or any of its operations are
+// annotated with subscribe roles.
+func (s *Scope) HasSubscribeRoles() bool {
+	for _, op := range s.Operations {
+		if op.SubscribeRoles() != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Scope) assignScope() {
 	for _, op := range s.Operations {
 		op.Scope = s
@@ -644,6 +954,7 @@ type Frugal struct {
 
 	typedefIndex   map[string]*TypeDef
 	namespaceIndex map[string]*Namespace
+	contractHashes map[interface{}]string
 }
 
 // Namespace returns namespace value for the given scope.
@@ -655,6 +966,18 @@ func (f *Frugal) Namespace(scope string) *Namespace {
 	return f.namespaceIndex["*"]
 }
 
+// SetNamespace sets the namespace value for the given scope, replacing the
+// value of the namespace the file defines for the scope, if any.
+func (f *Frugal) SetNamespace(scope, value string) {
+	if namespace := f.namespaceIndex[scope]; namespace != nil {
+		namespace.Value = value
+		return
+	}
+	namespace := &Namespace{Scope: scope, Value: value}
+	f.Namespaces = append(f.Namespaces, namespace)
+	f.namespaceIndex[scope] = namespace
+}
+
 func (f *Frugal) FindStruct(typ *Type) *Struct {
 	frugal := f
 	includeName := typ.IncludeName()
@@ -984,6 +1307,9 @@ func (f *Frugal) validate() error {
 			return getConflictError("Services", service.Name, providedService)
 		}
 		names[lowercaseService] = service.Name
+		if err := validateStability(service.Annotations); err != nil {
+			return fmt.Errorf("Service %s: %s", service.Name, err)
+		}
 
 		methodNames := make(map[string]string)
 		for _, method := range service.Methods {
@@ -1012,6 +1338,12 @@ func (f *Frugal) validate() error {
 			return getConflictError("Scopes", scope.Name, providedScope)
 		}
 		names[lowercaseScope] = scope.Name
+		if err := validateStability(scope.Annotations); err != nil {
+			return fmt.Errorf("Scope %s: %s", scope.Name, err)
+		}
+		if err := validateProtocol(scope.Annotations); err != nil {
+			return fmt.Errorf("Scope %s: %s", scope.Name, err)
+		}
 
 		opNames := make(map[string]string)
 		for _, op := range scope.Operations {
@@ -1025,6 +1357,32 @@ func (f *Frugal) validate() error {
 				return getConflictError("Operations", op.Name, providedOp)
 			}
 			opNames[lowercaseOp] = op.Name
+
+			if reply, ok := op.Annotations.Get(ReplyAnnotation); ok {
+				if reply == "" {
+					return fmt.Errorf("Operation %s: \"%s\" annotation requires a reply type", op.Name, ReplyAnnotation)
+				}
+				if op.Reply != nil {
+					return fmt.Errorf("Operation %s: reply type declared with both \"->\" and the \"%s\" annotation", op.Name, ReplyAnnotation)
+				}
+			}
+			if err := f.validateChunked(op); err != nil {
+				return fmt.Errorf("Operation %s: %s", op.Name, err)
+			}
+			if err := validateSampleRate(op); err != nil {
+				return fmt.Errorf("Operation %s: %s", op.Name, err)
+			}
+			for _, name := range []string{PublishRolesAnnotation, SubscribeRolesAnnotation} {
+				if roles, ok := op.Annotations.Roles(name); ok && len(roles) == 0 {
+					return fmt.Errorf("Operation %s: \"%s\" annotation requires at least one role", op.Name, name)
+				}
+			}
+		}
+
+		for _, name := range []string{PublishRolesAnnotation, SubscribeRolesAnnotation} {
+			if roles, ok := scope.Annotations.Roles(name); ok && len(roles) == 0 {
+				return fmt.Errorf("Scope %s: \"%s\" annotation requires at least one role", scope.Name, name)
+			}
 		}
 	}
 
@@ -1034,6 +1392,9 @@ func (f *Frugal) validate() error {
 	if err := f.validateIncludes(); err != nil {
 		return err
 	}
+	if err := f.validateIncludeNamespaces(); err != nil {
+		return err
+	}
 	if err := f.validateConstants(); err != nil {
 		return err
 	}
@@ -1052,6 +1413,9 @@ func (f *Frugal) validate() error {
 	if err := f.validateServices(f.ParsedIncludes); err != nil {
 		return err
 	}
+	if err := f.validateScopeTypes(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1076,6 +1440,82 @@ func (f *Frugal) validateIncludes() error {
 	return nil
 }
 
+// validateIncludeNamespaces ensures this file and its includes don't define
+// types with the same name in the same namespace of a language, which would
+// collide in generated code.
+func (f *Frugal) validateIncludeNamespaces() error {
+	files := []*Frugal{f}
+	names := []string{f.Name}
+	for _, include := range f.Includes {
+		if parsed, ok := f.ParsedIncludes[include.Name]; ok {
+			files = append(files, parsed)
+			names = append(names, include.Name)
+		}
+	}
+	if len(files) < 2 {
+		return nil
+	}
+
+	langs := map[string]bool{}
+	for _, file := range files {
+		for _, namespace := range file.Namespaces {
+			langs[namespace.Scope] = true
+		}
+	}
+	sortedLangs := make([]string, 0, len(langs))
+	for lang := range langs {
+		sortedLangs = append(sortedLangs, lang)
+	}
+	sort.Strings(sortedLangs)
+
+	for _, lang := range sortedLangs {
+		// Maps namespace to definition name to the file defining it.
+		definitions := make(map[string]map[string]string)
+		for i, file := range files {
+			namespace := file.Namespace(lang)
+			if namespace == nil {
+				continue
+			}
+			defined, ok := definitions[namespace.Value]
+			if !ok {
+				defined = make(map[string]string)
+				definitions[namespace.Value] = defined
+			}
+			for _, name := range file.definitionNames() {
+				if other, ok := defined[name]; ok && other != names[i] {
+					return fmt.Errorf("%s and %s both define %s in %s namespace %s; "+
+						"add a \"namespace %s\" override to one of them",
+						other, names[i], name, lang, namespace.Value, lang)
+				}
+				defined[name] = names[i]
+			}
+		}
+	}
+	return nil
+}
+
+// definitionNames returns the names of the types, services, and scopes
+// defined in this file.
+func (f *Frugal) definitionNames() []string {
+	names := []string{}
+	for _, typedef := range f.Typedefs {
+		names = append(names, typedef.Name)
+	}
+	for _, enum := range f.Enums {
+		names = append(names, enum.Name)
+	}
+	for _, s := range f.DataStructures() {
+		names = append(names, s.Name)
+	}
+	for _, service := range f.Services {
+		names = append(names, service.Name)
+	}
+	for _, scope := range f.Scopes {
+		names = append(names, scope.Name)
+	}
+	return names
+}
+
 func (f *Frugal) validateConstants() error {
 	for _, constant := range f.Constants {
 		if err := f.validateConstant(constant); err != nil {
@@ -1136,6 +1576,41 @@ func (f *Frugal) validateConstant(constant *Constant) error {
 	return fmt.Errorf("Invalid constant name %s", name)
 }
 
+// validateChunked ensures an operation annotated as a chunked transfer has a
+// binary type, a positive chunk size if it gives one, and no reply.
+func (f *Frugal) validateChunked(op *Operation) error {
+	size, ok := op.annotation(ChunkedAnnotation)
+	if !ok {
+		return nil
+	}
+	if f.UnderlyingType(op.Type).Name != "binary" {
+		return fmt.Errorf("\"%s\" annotation requires a binary type", ChunkedAnnotation)
+	}
+	if n, err := strconv.Atoi(size); size != "" && (err != nil || n <= 0) {
+		return fmt.Errorf("\"%s\" annotation requires a positive chunk size", ChunkedAnnotation)
+	}
+	if op.ReplyType() != nil {
+		return fmt.Errorf("\"%s\" annotation can't be used with \"%s\"", ChunkedAnnotation, ReplyAnnotation)
+	}
+	return nil
+}
+
+// validateSampleRate ensures an operation annotated with a sample rate gives
+// a rate greater than 0 and at most 1, and has no reply.
+func validateSampleRate(op *Operation) error {
+	value, ok := op.annotation(SampleRateAnnotation)
+	if !ok {
+		return nil
+	}
+	if _, err := parseSampleRate(value); err != nil {
+		return err
+	}
+	if op.ReplyType() != nil {
+		return fmt.Errorf("\"%s\" annotation can't be used with \"%s\"", SampleRateAnnotation, ReplyAnnotation)
+	}
+	return nil
+}
+
 func (f *Frugal) validateTypedefs() error {
 	for _, typedef := range f.Typedefs {
 		if !f.isValidType(typedef.Type) {
@@ -1182,6 +1657,9 @@ func (f *Frugal) validateStructLike(s *Struct) error {
 		if _, ok := ids[field.ID]; ok {
 			return fmt.Errorf("Duplicate field id %d in struct %s", field.ID, s.Name)
 		}
+		if err := f.validateConstraints(s, field); err != nil {
+			return err
+		}
 		ids[field.ID] = struct{}{}
 	}
 	return nil
@@ -1249,6 +1727,29 @@ func (f *Frugal) isValidType(typ *Type) bool {
 	return false
 }
 
+// validateStability ensures the "stability" annotation, if present, is a
+// known stability level.
+func validateStability(annotations Annotations) error {
+	switch stability := annotations.Stability(); stability {
+	case StabilityExperimental, StabilityStable, StabilityFrozen:
+		return nil
+	default:
+		return fmt.Errorf("\"%s\" annotation must be %s, %s, or %s, not \"%s\"", StabilityAnnotation,
+			StabilityExperimental, StabilityStable, StabilityFrozen, stability)
+	}
+}
+
+// validateProtocol ensures the "protocol" annotation, if present, is a known
+// protocol.
+func validateProtocol(annotations Annotations) error {
+	protocol, ok := annotations.Protocol()
+	if !ok || protocol == ProtocolJSON || protocol == ProtocolProvider {
+		return nil
+	}
+	return fmt.Errorf("\"%s\" annotation must be %s or %s, not \"%s\"", ProtocolAnnotation,
+		ProtocolJSON, ProtocolProvider, protocol)
+}
+
 func (f *Frugal) validateServices(includes map[string]*Frugal) error {
 	for _, service := range f.Services {
 		if err := f.validateServiceTypes(service, includes); err != nil {
@@ -1285,6 +1786,20 @@ func (f *Frugal) validateServiceTypes(service *Service, includes map[string]*Fru
 	return nil
 }
 
+// validateScopeTypes ensures the reply types of request/reply operations are
+// defined.
+func (f *Frugal) validateScopeTypes() error {
+	for _, scope := range f.Scopes {
+		for _, op := range scope.Operations {
+			if reply := op.ReplyType(); reply != nil && !f.isValidType(reply) {
+				return fmt.Errorf("Invalid reply type %s for %s.%s",
+					reply.Name, scope.Name, op.Name)
+			}
+		}
+	}
+	return nil
+}
+
 func getConflictError(type_, name1, name2 string) error {
 	return fmt.Errorf("%s %s and %s conflict. Some languages do not support"+
 		" exported lowercase classes/methods. Only one of %s or %s may be used.",
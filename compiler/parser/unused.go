@@ -0,0 +1,240 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// UnusedDefinition is a type definition which is not referenced, directly or
+// transitively, by any scope, service, or constant in a workspace.
+type UnusedDefinition struct {
+	File string // File containing the definition
+	Kind string // "struct", "exception", "union", "enum", or "typedef"
+	Name string
+}
+
+// String returns a human-readable version of the UnusedDefinition.
+func (u *UnusedDefinition) String() string {
+	return fmt.Sprintf("%s: %s %s is never referenced by a scope, service, or constant",
+		u.File, u.Kind, u.Name)
+}
+
+// UnusedAnalyzer finds definitions across a workspace of frugal files which
+// are never referenced by any scope, service, or constant, so dead IDL can be
+// pruned.
+type UnusedAnalyzer struct {
+	logger ValidationLogger
+}
+
+// NewUnusedAnalyzer constructs an unused definition analyzer that logs
+// warnings to standard output.
+func NewUnusedAnalyzer() *UnusedAnalyzer {
+	return &UnusedAnalyzer{
+		logger: &stdOutLogger{},
+	}
+}
+
+// NewUnusedAnalyzerWithLogger constructs an unused definition analyzer which
+// uses the given logger to log warnings.
+func NewUnusedAnalyzerWithLogger(logger ValidationLogger) *UnusedAnalyzer {
+	return &UnusedAnalyzer{
+		logger: logger,
+	}
+}
+
+// Analyze parses the given files, which along with their includes make up
+// the workspace, and logs a warning for each unused definition. The unused
+// definitions are returned sorted by file, kind, and name.
+func (u *UnusedAnalyzer) Analyze(files ...string) ([]*UnusedDefinition, error) {
+	workspace := newWorkspace()
+	for _, file := range files {
+		frugal, err := ParseFrugal(file)
+		if err != nil {
+			return nil, err
+		}
+		workspace.add(frugal)
+	}
+
+	for _, frugal := range workspace.files {
+		workspace.markRoots(frugal)
+	}
+
+	unused := workspace.unused()
+	for _, definition := range unused {
+		u.logger.LogWarning(definition.String())
+	}
+	return unused, nil
+}
+
+// definitionKey uniquely identifies a definition within a workspace.
+type definitionKey struct {
+	file string
+	name string
+}
+
+// workspace tracks the definitions of a set of frugal files and which of
+// them are referenced.
+type workspace struct {
+	files      map[string]*Frugal
+	referenced map[definitionKey]bool
+}
+
+func newWorkspace() *workspace {
+	return &workspace{
+		files:      make(map[string]*Frugal),
+		referenced: make(map[definitionKey]bool),
+	}
+}
+
+// add adds the given file and its includes to the workspace.
+func (w *workspace) add(f *Frugal) {
+	key := fileKey(f)
+	if _, ok := w.files[key]; ok {
+		return
+	}
+	w.files[key] = f
+	for _, include := range f.ParsedIncludes {
+		w.add(include)
+	}
+}
+
+// markRoots marks the definitions referenced by the scopes, services, and
+// constants of the given file.
+func (w *workspace) markRoots(f *Frugal) {
+	for _, scope := range f.Scopes {
+		for _, op := range scope.Operations {
+			w.markType(f, op.Type)
+			if reply := op.ReplyType(); reply != nil {
+				w.markType(f, reply)
+			}
+		}
+	}
+	for _, service := range f.Services {
+		for _, method := range service.Methods {
+			if method.ReturnType != nil {
+				w.markType(f, method.ReturnType)
+			}
+			for _, field := range method.Arguments {
+				w.markType(f, field.Type)
+			}
+			for _, field := range method.Exceptions {
+				w.markType(f, field.Type)
+			}
+		}
+	}
+	for _, constant := range f.Constants {
+		w.markType(f, constant.Type)
+	}
+}
+
+// markType marks the definition of the given type, and those it references,
+// as referenced.
+func (w *workspace) markType(f *Frugal, t *Type) {
+	if t.KeyType != nil {
+		w.markType(f, t.KeyType)
+	}
+	if t.ValueType != nil {
+		w.markType(f, t.ValueType)
+	}
+	if !t.IsCustom() {
+		return
+	}
+
+	containing := f
+	if include := t.IncludeName(); include != "" {
+		parsed, ok := f.ParsedIncludes[include]
+		if !ok {
+			return
+		}
+		containing = parsed
+	}
+	key := definitionKey{file: fileKey(containing), name: t.ParamName()}
+	if w.referenced[key] {
+		return
+	}
+	w.referenced[key] = true
+
+	for _, typedef := range containing.Typedefs {
+		if typedef.Name == key.name {
+			w.markType(containing, typedef.Type)
+		}
+	}
+	for _, s := range containing.DataStructures() {
+		if s.Name == key.name {
+			for _, field := range s.Fields {
+				w.markType(containing, field.Type)
+			}
+		}
+	}
+}
+
+// unused returns the definitions in the workspace which are not referenced.
+func (w *workspace) unused() []*UnusedDefinition {
+	unused := []*UnusedDefinition{}
+	add := func(f *Frugal, kind, name string) {
+		if !w.referenced[definitionKey{file: fileKey(f), name: name}] {
+			unused = append(unused, &UnusedDefinition{File: f.File, Kind: kind, Name: name})
+		}
+	}
+	for _, f := range w.files {
+		for _, s := range f.Structs {
+			add(f, "struct", s.Name)
+		}
+		for _, s := range f.Exceptions {
+			add(f, "exception", s.Name)
+		}
+		for _, s := range f.Unions {
+			add(f, "union", s.Name)
+		}
+		for _, enum := range f.Enums {
+			add(f, "enum", enum.Name)
+		}
+		for _, typedef := range f.Typedefs {
+			add(f, "typedef", typedef.Name)
+		}
+	}
+	sort.Sort(unusedDefinitions(unused))
+	return unused
+}
+
+// fileKey returns the key identifying the given file within a workspace.
+func fileKey(f *Frugal) string {
+	if abs, err := filepath.Abs(f.File); err == nil {
+		return abs
+	}
+	return filepath.Clean(f.File)
+}
+
+type unusedDefinitions []*UnusedDefinition
+
+func (u unusedDefinitions) Len() int {
+	return len(u)
+}
+
+func (u unusedDefinitions) Less(i, j int) bool {
+	if u[i].File != u[j].File {
+		return u[i].File < u[j].File
+	}
+	if u[i].Kind != u[j].Kind {
+		return u[i].Kind < u[j].Kind
+	}
+	return u[i].Name < u[j].Name
+}
+
+func (u unusedDefinitions) Swap(i, j int) {
+	u[i], u[j] = u[j], u[i]
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compiler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// testVectorsDir is the directory of the output directory test vectors are
+// written to.
+const testVectorsDir = "test_vectors"
+
+// writeTestVectors writes the test vectors of the file's structs, unions, and
+// exceptions to test_vectors/<name>/<struct>.<protocol> in the output
+// directory. Protocols which can't encode a struct are skipped with a warning.
+func writeTestVectors(f *parser.Frugal, outputDir string) error {
+	structs := f.DataStructures()
+	if len(structs) == 0 {
+		return nil
+	}
+	dir := filepath.Join(outputDir, testVectorsDir, f.Name)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	for _, s := range structs {
+		for _, protocol := range generator.TestVectorProtocols {
+			vector, err := generator.TestVector(f, s, protocol)
+			if err != nil {
+				globals.Warn(fmt.Sprintf("Skipping %s test vector: %s", protocol, err))
+				continue
+			}
+			file := filepath.Join(dir, s.Name+"."+protocol)
+			if err := ioutil.WriteFile(file, vector, 0644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compiler
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Watch compiles each of the given files with the options and recompiles a
+// file whenever it or one of its includes changes, until stop is closed.
+// Compilation is incremental, so only the outputs of changed files and the
+// files including them are regenerated. Files are polled for changes at the
+// given interval, which avoids depending on platform file notification APIs.
+// The compiled callback is called with the file and the result of each
+// compilation, and errors don't stop watching, since a file being edited is
+// often invalid.
+func Watch(files []string, options Options, interval time.Duration, stop <-chan struct{}, compiled func(string, error)) {
+	options.Incremental = true
+	watched := make([]*watchedFile, 0, len(files))
+	for _, file := range files {
+		w := &watchedFile{file: file}
+		w.compile(options, compiled)
+		watched = append(watched, w)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			for _, w := range watched {
+				if w.changed() {
+					w.compile(options, compiled)
+				}
+			}
+		}
+	}
+}
+
+// watchedFile tracks the modification state of a Frugal file and its
+// includes.
+type watchedFile struct {
+	file    string
+	sources map[string]fileState
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// compile snapshots the state of the file's sources and compiles it. The
+// snapshot is taken first, so changes made while compiling are noticed.
+func (w *watchedFile) compile(options Options, compiled func(string, error)) {
+	w.snapshot()
+	options.File = w.file
+	compiled(w.file, Compile(options))
+}
+
+// snapshot records the state of the file and its includes. If the file or an
+// include can't be parsed, the previously known includes are kept so fixing
+// them is still noticed.
+func (w *watchedFile) snapshot() {
+	files := []string{absPath(w.file)}
+	for file := range w.sources {
+		files = append(files, file)
+	}
+	if sources, err := sourceFiles(absPath(w.file), make(map[string]bool)); err == nil {
+		files = sources
+	}
+
+	w.sources = make(map[string]fileState, len(files))
+	for _, file := range files {
+		w.sources[file] = statFile(file)
+	}
+}
+
+// changed returns true if any of the file's sources changed since the last
+// snapshot.
+func (w *watchedFile) changed() bool {
+	for file, state := range w.sources {
+		if statFile(file) != state {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceFiles returns the paths of the file and everything it includes. Like
+// Dependencies, the includes of every branch of conditional blocks are
+// sources, since a change to any of them may change what's generated.
+func sourceFiles(file string, seen map[string]bool) ([]string, error) {
+	if seen[file] {
+		return nil, nil
+	}
+	seen[file] = true
+	f, err := parseDependencyFile(file)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{file}
+	for _, include := range f.OrderedIncludes() {
+		included, err := sourceFiles(absPath(filepath.Join(f.Dir, include.Value)), seen)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, included...)
+	}
+	return files, nil
+}
+
+// statFile returns the state of the file, which is zero if it doesn't exist.
+func statFile(file string) fileState {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size()}
+}
+
+// absPath returns the absolute path of the file, or the path as given if it
+// can't be made absolute.
+func absPath(file string) string {
+	if abs, err := filepath.Abs(file); err == nil {
+		return abs
+	}
+	return file
+}
@@ -21,19 +21,38 @@ export 'src/frugal.dart'
         FAdapterTransport,
         FAsyncTransport,
         FContext,
+        FContractMetadata,
+        FDecodeRunner,
+        FDecodedMessage,
+        FDecoder,
         FHttpTransport,
+        FIsolateMessage,
         FMethod,
+        FPausableSubscriberTransport,
         FProtocol,
         FProtocolFactory,
+        FPublishFailedError,
         FPublisherTransport,
         FPublisherTransportFactory,
+        FResubscribePolicy,
+        FRetryPolicy,
+        FScopeHook,
         FScopeProvider,
+        FScopeRequester,
+        FScopeResponder,
         FServiceProvider,
         FSubscriberTransport,
         FSubscriberTransportFactory,
         FSubscription,
+        FSubscriptionErrorCallback,
+        FTimeoutError,
+        FTooLargeError,
+        FTopicMatch,
+        FTopicTemplate,
         FTransport,
+        FTransportClosedError,
         FTransportMonitor,
+        FWebSocketTransport,
         FrugalTApplicationErrorType,
         FrugalTTransportErrorType,
         GetHeadersWithContext,
@@ -41,4 +60,10 @@ export 'src/frugal.dart'
         Middleware,
         TMemoryOutputBuffer,
         TMemoryTransport,
-        debugMiddleware;
+        batchFrameCallback,
+        debugMiddleware,
+        isolateDecodeCallback,
+        publishBatchFrame,
+        retryPublish,
+        subscribeWithPolicy,
+        translateTransportError;
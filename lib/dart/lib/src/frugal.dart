@@ -25,11 +25,17 @@ import 'package:uuid/uuid.dart';
 import 'package:w_common/disposable.dart';
 import 'package:w_transport/w_transport.dart' as wt;
 
+part 'frugal/f_batch_frame.dart';
 part 'frugal/f_context.dart';
+part 'frugal/f_contract_metadata.dart';
 part 'frugal/f_error.dart';
+part 'frugal/f_isolate_decode.dart';
 part 'frugal/f_middleware.dart';
 part 'frugal/f_provider.dart';
+part 'frugal/f_retry_policy.dart';
+part 'frugal/f_scope_request.dart';
 part 'frugal/f_subscription.dart';
+part 'frugal/f_topic_template.dart';
 part 'frugal/internal/f_byte_buffer.dart';
 part 'frugal/internal/f_obj_to_json.dart';
 part 'frugal/internal/headers.dart';
@@ -43,6 +49,7 @@ part 'frugal/transport/f_publisher_transport.dart';
 part 'frugal/transport/f_subscriber_transport.dart';
 part 'frugal/transport/f_transport.dart';
 part 'frugal/transport/f_transport_monitor.dart';
+part 'frugal/transport/f_web_socket_transport.dart';
 part 'frugal/transport/monitor_runner.dart';
 part 'frugal/transport/t_framed_transport.dart';
 part 'frugal/transport/t_memory_output_buffer.dart';
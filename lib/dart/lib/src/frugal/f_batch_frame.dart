@@ -0,0 +1,98 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+part of frugal.src.frugal;
+
+/// The first byte of a batch frame, distinguishing it from the frame of a
+/// single message, which begins with the version of its request header.
+const int _batchFrameMarker = 0x80;
+
+/// Publishes the given frames, each the framed bytes of a message, in a single
+/// frame on the topic, so they're sent with one transport flush. The batch
+/// frame is the marker byte followed by the frames, which are already prefixed
+/// with their length. Nothing is published if there are no frames. This is
+/// used by generated code and should not be called directly.
+Future publishBatchFrame(
+    FPublisherTransport transport, String topic, List<Uint8List> frames) async {
+  if (frames.isEmpty) {
+    return;
+  }
+  var size = 1;
+  for (var frame in frames) {
+    size += frame.length;
+  }
+  var limit = transport.publishSizeLimit;
+  if (limit != null && limit > 0 && 4 + size > limit) {
+    throw new TTransportError(
+        FrugalTTransportErrorType.REQUEST_TOO_LARGE,
+        'Batch of ${frames.length} messages to topic $topic exceeds '
+        '$limit bytes, was ${4 + size} bytes');
+  }
+  var data = new Uint8List(4 + size);
+  data.buffer.asByteData().setUint32(0, size);
+  data[4] = _batchFrameMarker;
+  var offset = 5;
+  for (var frame in frames) {
+    data.setAll(offset, frame);
+    offset += frame.length;
+  }
+  await transport.publish(topic, data);
+}
+
+/// Returns an [FAsyncCallback] which calls the given callback with each message
+/// of the batch frames published with [publishBatchFrame], and with other
+/// frames as they are. Every message of a batch is processed, and the first
+/// error is rethrown. This is used by generated code and should not be called
+/// directly.
+FAsyncCallback batchFrameCallback(FAsyncCallback callback) {
+  return (TTransport transport) {
+    var data = _readAll(transport);
+    if (data.isEmpty || data[0] != _batchFrameMarker) {
+      callback(new TMemoryTransport.fromUint8List(data));
+      return;
+    }
+
+    var view = data.buffer.asByteData();
+    var error;
+    var offset = 1;
+    while (offset < data.length) {
+      if (data.length - offset < 4 ||
+          data.length - offset - 4 < view.getUint32(offset)) {
+        throw new TProtocolError(
+            TProtocolErrorType.INVALID_DATA, 'frugal: invalid batch frame');
+      }
+      var size = view.getUint32(offset);
+      var frame = data.sublist(offset + 4, offset + 4 + size);
+      try {
+        callback(new TMemoryTransport.fromUint8List(frame));
+      } catch (e) {
+        error ??= e;
+      }
+      offset += 4 + size;
+    }
+    if (error != null) {
+      throw error;
+    }
+  };
+}
+
+Uint8List _readAll(TTransport transport) {
+  var data = <int>[];
+  var chunk = new Uint8List(1024);
+  var n = transport.read(chunk, 0, chunk.length);
+  while (n > 0) {
+    data.addAll(chunk.sublist(0, n));
+    n = transport.read(chunk, 0, chunk.length);
+  }
+  return new Uint8List.fromList(data);
+}
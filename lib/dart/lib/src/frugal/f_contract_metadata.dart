@@ -0,0 +1,62 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+part of frugal.src.frugal;
+
+/// Describes the IDL contract a scope or service was generated from. Generated
+/// code exposes an [FContractMetadata] for each scope and service, which can be
+/// used for version checks, routing tables, and observability labels.
+class FContractMetadata {
+  /// Name of the frugal file defining the contract.
+  final String idlFile;
+
+  /// Either "scope" or "service".
+  final String kind;
+
+  /// Name of the scope or service.
+  final String name;
+
+  /// Hash of the contract which ignores comments and formatting.
+  final String hash;
+
+  /// Version of the frugal compiler which generated the code.
+  final String compilerVersion;
+
+  /// Names of the scope's operations or the service's methods, in the order
+  /// they are defined.
+  final List<String> operations;
+
+  /// Doc comments of the operations or methods documented in the IDL, by
+  /// name.
+  final Map<String, String> descriptions;
+
+  /// Create a new [FContractMetadata].
+  const FContractMetadata(this.idlFile, this.kind, this.name, this.hash,
+      this.compilerVersion, this.operations,
+      [this.descriptions = const {}]);
+
+  /// Returns true if the contract has the given hash, i.e. the other side of
+  /// the scope or service was built from the same contract.
+  bool matches(String hash) => this.hash == hash;
+
+  /// Returns true if the contract defines an operation or method with the
+  /// given name.
+  bool hasOperation(String name) => operations.contains(name);
+
+  /// Returns the doc comment of the operation or method with the given name,
+  /// or an empty string if it isn't documented.
+  String description(String name) => descriptions[name] ?? '';
+
+  @override
+  String toString() => '$kind $name ($idlFile, $hash)';
+}
@@ -79,3 +79,48 @@ class FrugalTTransportErrorType extends TTransportErrorType {
   /// Indicates the response was too large for the transport.
   static const int RESPONSE_TOO_LARGE = 101;
 }
+
+/// Indicates an operation failed because the transport is not open.
+class FTransportClosedError extends TTransportError {
+  /// Creates an [FTransportClosedError] with the given message.
+  FTransportClosedError([String message = ""])
+      : super(FrugalTTransportErrorType.NOT_OPEN, message);
+}
+
+/// Indicates an operation failed because it did not complete in time.
+class FTimeoutError extends TTransportError {
+  /// Creates an [FTimeoutError] with the given message.
+  FTimeoutError([String message = ""])
+      : super(FrugalTTransportErrorType.TIMED_OUT, message);
+}
+
+/// Indicates an operation failed because the message exceeded the
+/// transport's size limit.
+class FTooLargeError extends TTransportError {
+  /// Creates an [FTooLargeError] with the given message.
+  FTooLargeError([String message = ""])
+      : super(FrugalTTransportErrorType.REQUEST_TOO_LARGE, message);
+}
+
+/// Translates the given error into the typed frugal error corresponding to
+/// its [TTransportError] type, so callers can branch on the cause of a
+/// failure. Errors without a corresponding typed error are returned as is.
+Object translateTransportError(Object error) {
+  if (error is! TTransportError ||
+      error is FTransportClosedError ||
+      error is FTimeoutError ||
+      error is FTooLargeError) {
+    return error;
+  }
+  TTransportError transportError = error;
+  switch (transportError.type) {
+    case FrugalTTransportErrorType.NOT_OPEN:
+      return new FTransportClosedError(transportError.message);
+    case FrugalTTransportErrorType.TIMED_OUT:
+      return new FTimeoutError(transportError.message);
+    case FrugalTTransportErrorType.REQUEST_TOO_LARGE:
+      return new FTooLargeError(transportError.message);
+    default:
+      return error;
+  }
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+part of frugal.src.frugal;
+
+/// Decodes a scope message in a background isolate. Decoders must be
+/// top-level or static functions so they can be sent to another isolate.
+typedef FDecodedMessage FDecoder(FIsolateMessage message);
+
+/// Runs an [FDecoder] on the given message in a background isolate and
+/// returns its result, e.g. Flutter's compute.
+typedef Future FDecodeRunner(FDecoder decode, FIsolateMessage message);
+
+/// A scope message sent to a background isolate to be decoded.
+class FIsolateMessage {
+  /// The name of the scope operation the message is expected to be of.
+  final String op;
+
+  /// The protocol factory the message is decoded with.
+  final FProtocolFactory protocolFactory;
+
+  /// The encoded message.
+  final Uint8List data;
+
+  /// Create an [FIsolateMessage] with the remaining bytes of the given
+  /// transport.
+  FIsolateMessage(this.op, this.protocolFactory, TTransport transport)
+      : data = _readAll(transport);
+
+  /// Returns an [FProtocol] reading the message.
+  FProtocol getProtocol() =>
+      protocolFactory.getProtocol(new TMemoryTransport.fromUint8List(data));
+}
+
+/// A scope message decoded in a background isolate.
+class FDecodedMessage {
+  /// The request headers of the message.
+  final FContext ctx;
+
+  /// The decoded value of the message.
+  final Object req;
+
+  /// Create an [FDecodedMessage].
+  FDecodedMessage(this.ctx, this.req);
+}
+
+/// Returns an [FAsyncCallback] which decodes each message with [decode] in a
+/// background isolate using [runner], keeping the calling isolate responsive,
+/// and calls [handle] with the decoded messages in the order they were
+/// received. The callback returns a future completing once the message is
+/// handled, or with the error decoding or handling it. This is used by
+/// generated code and should not be called directly.
+FAsyncCallback isolateDecodeCallback(
+    String op,
+    FProtocolFactory protocolFactory,
+    FDecodeRunner runner,
+    FDecoder decode,
+    void handle(FContext ctx, Object req)) {
+  Future previous = new Future.value();
+  return (TTransport transport) {
+    var decoded =
+        runner(decode, new FIsolateMessage(op, protocolFactory, transport));
+    // Errors decoding are reported once the message is handled.
+    decoded.catchError((_) => null);
+    // Messages are handled in order, even after a previous message failed.
+    previous = previous
+        .catchError((_) => null)
+        .then((_) => decoded)
+        .then((FDecodedMessage message) => handle(message.ctx, message.req));
+    return previous;
+  };
+}
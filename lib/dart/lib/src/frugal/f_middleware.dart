@@ -26,6 +26,11 @@ typedef Future InvocationHandler(
 /// proxies the given [InvocationHandler].
 typedef InvocationHandler Middleware(InvocationHandler handler);
 
+/// Called by publishers and subscribers generated with the hooks option with
+/// the [FContext] and operation name of each message before it's published or
+/// after it's received, e.g. for lightweight instrumentation.
+typedef void FScopeHook(FContext ctx, String op);
+
 /// Contains an [InvocationHandler] used to proxy the given service method
 /// This should only be used by generated code.
 class FMethod {
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+part of frugal.src.frugal;
+
+final Random _retryRandom = new Random();
+
+/// Controls how resilient publishers retry publishes failing with transient
+/// transport errors, backing off exponentially with random jitter between
+/// attempts.
+class FRetryPolicy {
+  /// The delay before the first retry.
+  final Duration initialBackoff;
+
+  /// The maximum delay between retries.
+  final Duration maxBackoff;
+
+  /// The maximum number of attempts, including the first.
+  final int maxAttempts;
+
+  /// The fraction by which each delay is randomly varied, so publishers
+  /// failing together don't retry together.
+  final double jitter;
+
+  /// Create a new [FRetryPolicy].
+  const FRetryPolicy(
+      {this.initialBackoff: const Duration(milliseconds: 100),
+      this.maxBackoff: const Duration(seconds: 10),
+      this.maxAttempts: 5,
+      this.jitter: 0.2});
+
+  /// Returns the delay before the given retry, counting from 1.
+  Duration backoff(int retry, [Random random]) {
+    var backoff = initialBackoff;
+    for (var i = 1; i < retry && backoff < maxBackoff; i++) {
+      backoff *= 2;
+    }
+    if (backoff > maxBackoff) {
+      backoff = maxBackoff;
+    }
+    var variation = (random ?? _retryRandom).nextDouble() * 2 - 1;
+    return backoff * (1 + jitter * variation);
+  }
+
+  /// Returns whether the given publish error is transient: the transport
+  /// being closed, a timeout, or another transport failure. Messages too
+  /// large for the transport and other errors are permanent.
+  bool isTransient(Object error) {
+    if (error is! TTransportError) {
+      return false;
+    }
+    TTransportError transportError = error;
+    return transportError.type != FrugalTTransportErrorType.REQUEST_TOO_LARGE &&
+        transportError.type != FrugalTTransportErrorType.RESPONSE_TOO_LARGE;
+  }
+}
+
+/// Indicates a publish failed permanently, either with an error which isn't
+/// transient or after failing with transient errors for every attempt of
+/// its [FRetryPolicy].
+class FPublishFailedError extends Error {
+  /// The error of the last attempt.
+  final Object cause;
+
+  /// The number of attempts made.
+  final int attempts;
+
+  /// Creates an [FPublishFailedError] with the error of the last of the
+  /// given number of attempts.
+  FPublishFailedError(this.cause, this.attempts);
+
+  @override
+  String toString() =>
+      'frugal: publish failed after $attempts attempt(s): $cause';
+}
+
+/// Calls [publish] until it completes, retrying transient failures according
+/// to the [FRetryPolicy]. Throws an [FPublishFailedError] once it fails
+/// permanently. This is used by generated code and should not be called
+/// directly.
+Future retryPublish(FRetryPolicy policy, Future publish()) async {
+  var attempt = 1;
+  while (true) {
+    try {
+      return await publish();
+    } catch (e) {
+      if (!policy.isTransient(e) || attempt >= policy.maxAttempts) {
+        throw new FPublishFailedError(e, attempt);
+      }
+    }
+    await new Future.delayed(policy.backoff(attempt));
+    attempt++;
+  }
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+part of frugal.src.frugal;
+
+/// Header containing the topic replies to a scope request are published to.
+const String _replyToHeader = "_reply_to";
+
+/// Prefix of the unique reply topic of each [FScopeRequester].
+const String _replyTopicPrefix = "_frugal_reply.";
+
+/// Implements request/reply semantics on top of pub/sub scope transports.
+/// Each request is published with a reply-to topic in its headers which
+/// responders publish replies to. Replies are correlated to requests by op
+/// id, the same way RPC responses are. This is to be used by generated code
+/// and should not be called directly.
+class FScopeRequester {
+  final Logger _log = new Logger('FScopeRequester');
+  final FPublisherTransport _publisher;
+  final FSubscriberTransport _subscriber;
+  final FProtocolFactory _protocolFactory;
+  final Map<int, _FScopeRequest> _pending = {};
+
+  /// The topic replies to this requester's requests are published to.
+  final String replyTopic;
+
+  /// Create an [FScopeRequester] which publishes requests and subscribes to
+  /// replies using transports from the given [FScopeProvider].
+  FScopeRequester(FScopeProvider provider)
+      : _publisher = provider.publisherTransportFactory.getTransport(),
+        _subscriber = provider.subscriberTransportFactory.getTransport(),
+        _protocolFactory = provider.protocolFactory,
+        replyTopic = _replyTopicPrefix + FContext._generateCorrelationId();
+
+  /// Open the publisher transport and subscribe to the reply topic.
+  Future open() async {
+    await _publisher.open();
+    try {
+      await _subscriber.subscribe(replyTopic, _handleReply);
+    } catch (e) {
+      await _publisher.close();
+      rethrow;
+    }
+  }
+
+  /// Unsubscribe from the reply topic and close the publisher transport.
+  Future close() async {
+    await _subscriber.unsubscribe();
+    await _publisher.close();
+  }
+
+  /// Publish a request to the topic and complete with its reply, or throw a
+  /// [TTransportError] if no reply is received before the [FContext]
+  /// timeout. The request message is written by [writeRequest] and the
+  /// reply message, if successful, is read by [readReply]. A reply
+  /// containing an exception is thrown as a [TApplicationError].
+  Future request(FContext ctx, String topic, String op,
+      void writeRequest(FProtocol oprot), readReply(FProtocol iprot)) async {
+    ctx.addRequestHeader(_replyToHeader, replyTopic);
+
+    var memoryBuffer = new TMemoryOutputBuffer(_publisher.publishSizeLimit);
+    var oprot = _protocolFactory.getProtocol(memoryBuffer);
+    oprot.writeRequestHeader(ctx);
+    oprot.writeMessageBegin(new TMessage(op, TMessageType.CALL, 0));
+    writeRequest(oprot);
+    oprot.writeMessageEnd();
+
+    var opId = ctx._opId;
+    if (_pending.containsKey(opId)) {
+      throw new StateError("frugal: context already registered");
+    }
+    var request = new _FScopeRequest(ctx, readReply);
+    _pending[opId] = request;
+    try {
+      await _publisher.publish(topic, memoryBuffer.writeBytes);
+      return await request.reply.future.timeout(ctx.timeout);
+    } on TimeoutException catch (_) {
+      throw new TTransportError(FrugalTTransportErrorType.TIMED_OUT,
+          "frugal: scope request timed out after ${ctx.timeout}");
+    } finally {
+      _pending.remove(opId);
+    }
+  }
+
+  /// Read a reply and complete the request waiting on its op id. The reply
+  /// is read before returning, since the transport is only valid until then.
+  void _handleReply(TTransport transport) {
+    var iprot = _protocolFactory.getProtocol(transport);
+    var headers = Headers.read(transport);
+    int opId;
+    try {
+      opId = int.parse(headers[_opidHeader]);
+    } catch (e) {
+      _log.warning("frugal: invalid scope reply: op id not a uint64", e);
+      return;
+    }
+
+    var request = _pending[opId];
+    if (request == null) {
+      _log.warning("frugal: discarding scope reply with unknown op id $opId");
+      return;
+    }
+    if (request.reply.isCompleted) {
+      _log.warning(
+          "frugal: discarding duplicate scope reply with op id $opId");
+      return;
+    }
+
+    try {
+      request.ctx.addResponseHeaders(headers);
+      var msg = iprot.readMessageBegin();
+      if (msg.type == TMessageType.EXCEPTION) {
+        var error = TApplicationError.read(iprot);
+        iprot.readMessageEnd();
+        request.reply.completeError(error);
+        return;
+      }
+      var reply = request.readReply(iprot);
+      iprot.readMessageEnd();
+      request.reply.complete(reply);
+    } catch (e) {
+      request.reply.completeError(e);
+    }
+  }
+}
+
+/// A request waiting for its reply.
+class _FScopeRequest {
+  final FContext ctx;
+  final Function readReply;
+  final Completer reply = new Completer();
+
+  _FScopeRequest(this.ctx, this.readReply);
+}
+
+/// Publishes replies to requests received by scope subscribers. This is to be
+/// used by generated code and should not be called directly.
+class FScopeResponder {
+  final FPublisherTransport _publisher;
+  final FProtocolFactory _protocolFactory;
+  Future _opened;
+
+  /// Create an [FScopeResponder] which publishes replies using a publisher
+  /// transport from the given [FScopeProvider]. The transport is opened when
+  /// the first reply is sent.
+  FScopeResponder(FScopeProvider provider)
+      : _publisher = provider.publisherTransportFactory.getTransport(),
+        _protocolFactory = provider.protocolFactory;
+
+  /// Publish the reply to a request received with the given [FContext] once
+  /// the handler's [result], which may be a [Future], completes. If it
+  /// completes with an error, the error is replied as a [TApplicationError],
+  /// otherwise the reply message is written by [writeReply]. A null reply is
+  /// replied as a MISSING_RESULT [TApplicationError] since it can't be
+  /// written. If the request did not ask for a reply, nothing is published
+  /// and the handler's error, if any, is thrown.
+  Future reply(FContext ctx, String op, result,
+      void writeReply(FProtocol oprot, reply)) async {
+    var value;
+    var error;
+    try {
+      value = await result;
+    } catch (e) {
+      error = e;
+    }
+    var topic = ctx.requestHeader(_replyToHeader);
+    if (topic == null) {
+      if (error != null) {
+        throw error;
+      }
+      return;
+    }
+    if (error == null && value == null) {
+      error = new TApplicationError(FrugalTApplicationErrorType.MISSING_RESULT,
+          "$op failed: unknown result");
+    }
+
+    ctx.addResponseHeader(_opidHeader, ctx.requestHeader(_opidHeader));
+    var memoryBuffer = new TMemoryOutputBuffer(_publisher.publishSizeLimit);
+    var oprot = _protocolFactory.getProtocol(memoryBuffer);
+    oprot.writeResponseHeader(ctx);
+    if (error != null) {
+      var ex = error is TApplicationError
+          ? error
+          : new TApplicationError(FrugalTApplicationErrorType.INTERNAL_ERROR,
+              "Internal error processing $op: $error");
+      oprot.writeMessageBegin(new TMessage(op, TMessageType.EXCEPTION, 0));
+      ex.write(oprot);
+    } else {
+      oprot.writeMessageBegin(new TMessage(op, TMessageType.REPLY, 0));
+      writeReply(oprot, value);
+    }
+    oprot.writeMessageEnd();
+
+    _opened ??= _publisher.open();
+    try {
+      await _opened;
+    } catch (e) {
+      // Open the transport again with the next reply.
+      _opened = null;
+      rethrow;
+    }
+    await _publisher.publish(topic, memoryBuffer.writeBytes);
+  }
+
+  /// Close the publisher transport if it has been opened.
+  Future close() async {
+    if (_opened == null) {
+      return;
+    }
+    _opened = null;
+    await _publisher.close();
+  }
+}
@@ -13,6 +13,44 @@
 
 part of frugal.src.frugal;
 
+/// Called with the errors of a subscription: errors thrown by the callback
+/// of its transport and failures to subscribe or resubscribe.
+typedef void FSubscriptionErrorCallback(Object error);
+
+/// Controls how a subscription resubscribes after its transport fails,
+/// backing off exponentially between attempts.
+class FResubscribePolicy {
+  /// The delay before the first attempt to resubscribe.
+  final Duration initialBackoff;
+
+  /// The maximum delay between attempts to resubscribe.
+  final Duration maxBackoff;
+
+  /// The maximum number of consecutive attempts to resubscribe, or 0 to
+  /// keep trying until unsubscribed.
+  final int maxAttempts;
+
+  /// Create a new [FResubscribePolicy].
+  const FResubscribePolicy(
+      {this.initialBackoff: const Duration(milliseconds: 100),
+      this.maxBackoff: const Duration(seconds: 30),
+      this.maxAttempts: 10});
+
+  /// Returns the delay before the given attempt to resubscribe, counting
+  /// from 1.
+  Duration backoff(int attempt) {
+    var backoff = initialBackoff;
+    for (var i = 1; i < attempt && backoff < maxBackoff; i++) {
+      backoff *= 2;
+    }
+    return backoff < maxBackoff ? backoff : maxBackoff;
+  }
+
+  /// Returns whether another attempt to resubscribe should be made after the
+  /// given number of attempts failed.
+  bool shouldRetry(int attempts) => maxAttempts <= 0 || attempts < maxAttempts;
+}
+
 /// A subscription to a pub/sub topic created by a scope. The topic subscription
 /// is actually handled by an [FSubscriberTransport], which the [FSubscription]
 /// wraps. Each [FSubscription] should have its own [FSubscriberTransport]. The
@@ -21,14 +59,139 @@ class FSubscription {
   /// Scope topic for the subscription.
   final String topic;
   FSubscriberTransport _transport;
+  FAsyncCallback _callback;
+  FSubscriptionErrorCallback _onError;
+  FResubscribePolicy _resubscribePolicy;
+  bool _resubscribing = false;
+  bool _stopped = false;
+  bool _paused = false;
 
   /// Create a new [FSubscription] with the given topic and transport.
   FSubscription(this.topic, this._transport);
 
+  FSubscription._withPolicy(this.topic, this._transport, this._callback,
+      this._onError, this._resubscribePolicy);
+
   /// Unsubscribe from the topic.
-  Future unsubscribe() => _transport.unsubscribe();
+  Future unsubscribe() {
+    _stopped = true;
+    return _transport.unsubscribe();
+  }
+
+  /// Stops the delivery of messages until [resume] is called, if the
+  /// transport is an [FPausableSubscriberTransport]. Otherwise messages are
+  /// still delivered.
+  void pause() {
+    _paused = true;
+    if (_transport is FPausableSubscriberTransport) {
+      (_transport as FPausableSubscriberTransport).pause();
+    }
+  }
+
+  /// Resumes the delivery of messages after [pause].
+  void resume() {
+    _paused = false;
+    if (_transport is FPausableSubscriberTransport) {
+      (_transport as FPausableSubscriberTransport).resume();
+    }
+  }
 
   /// Unsubscribes and removes durably stored information on the broker,
   /// if applicable.
-  Future remove() => _transport.remove();
+  Future remove() {
+    _stopped = true;
+    return _transport.remove();
+  }
+
+  /// Subscribes the transport, retrying failures according to the
+  /// resubscribe policy. Unless resubscribing, the first attempt is made
+  /// without backing off.
+  Future _subscribe(bool resubscribe) async {
+    var attempt = resubscribe ? 1 : 0;
+    while (true) {
+      if (attempt > 0) {
+        await new Future.delayed(_resubscribePolicy.backoff(attempt));
+        if (_stopped) return;
+      }
+      var error;
+      try {
+        await _transport.subscribe(topic, _receive);
+        // A resubscribed transport stays paused.
+        if (_paused) pause();
+        return;
+      } catch (e) {
+        error = e;
+      }
+      _reportError(error);
+      if (_resubscribePolicy == null ||
+          !_resubscribePolicy.shouldRetry(attempt)) {
+        throw error;
+      }
+      attempt++;
+    }
+  }
+
+  void _receive(TTransport transport) {
+    try {
+      // Callbacks decoding messages asynchronously return a future.
+      var result = (_callback as dynamic)(transport);
+      if (result is Future) {
+        result.catchError(_handleError);
+      }
+    } catch (e) {
+      _handleError(e);
+    }
+  }
+
+  void _handleError(Object error) {
+    _reportError(error);
+    if (_resubscribePolicy != null) {
+      _resubscribe();
+    }
+  }
+
+  /// Unsubscribes the transport and subscribes it again, backing off
+  /// according to the resubscribe policy.
+  Future _resubscribe() async {
+    if (_resubscribing || _stopped) return;
+    _resubscribing = true;
+    try {
+      await _transport.unsubscribe();
+    } catch (e) {
+      _reportError(e);
+    }
+    try {
+      await _subscribe(true);
+    } catch (_) {
+      // The failures have been reported to the error callback.
+    } finally {
+      _resubscribing = false;
+    }
+  }
+
+  void _reportError(Object error) {
+    if (_onError != null) {
+      _onError(error);
+    }
+  }
+}
+
+/// Subscribes the transport to the topic with the given callback and returns
+/// the [FSubscription]. Errors thrown by the callback and failures to
+/// subscribe are passed to [onError], if given. If a [resubscribePolicy] is
+/// given, failures to subscribe are retried, and the transport is
+/// resubscribed after the callback throws an error. Without either, errors
+/// thrown by the callback are left to the transport.
+Future<FSubscription> subscribeWithPolicy(
+    String topic, FSubscriberTransport transport, FAsyncCallback callback,
+    {FSubscriptionErrorCallback onError,
+    FResubscribePolicy resubscribePolicy}) async {
+  if (onError == null && resubscribePolicy == null) {
+    await transport.subscribe(topic, callback);
+    return new FSubscription(topic, transport);
+  }
+  var subscription = new FSubscription._withPolicy(
+      topic, transport, callback, onError, resubscribePolicy);
+  await subscription._subscribe(false);
+  return subscription;
 }
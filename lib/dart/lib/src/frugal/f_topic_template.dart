@@ -0,0 +1,151 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+part of frugal.src.frugal;
+
+const String _topicDelimiter = '.';
+final RegExp _invalidTopicToken = new RegExp(r'[.*>\s]');
+
+bool _isValidTopicToken(String token) =>
+    token != null && token.isNotEmpty && !_invalidTopicToken.hasMatch(token);
+
+bool _isTopicVariable(String token) => token.startsWith('{');
+
+/// Builds and validates the topics of a scope from its prefix template, e.g.
+/// "foo.{user}", the same way generated publishers and subscribers do. It lets
+/// code which isn't generated, such as ACL provisioning and broker setup tools,
+/// work with a scope's topics.
+///
+/// A topic is the prefix with its variables replaced, the scope name, and the
+/// operation name joined by ".", e.g. "foo.bob.Events.EventCreated" for the
+/// EventCreated operation of the Events scope with the prefix above.
+class FTopicTemplate {
+  final String _scope;
+  final List<String> _tokens = [];
+  final List<String> _variables = [];
+
+  /// Create a new [FTopicTemplate] for the scope with the given prefix
+  /// template, which may be empty, and name. Throws an [ArgumentError] if the
+  /// prefix isn't a valid template.
+  FTopicTemplate(String prefix, String scope) : _scope = scope {
+    if (!_isValidTopicToken(scope)) {
+      throw new ArgumentError.value(scope, 'scope', 'invalid scope name');
+    }
+    if (prefix.isEmpty) {
+      return;
+    }
+    for (String token in prefix.split(_topicDelimiter)) {
+      String name = token;
+      bool isVariable = token.startsWith('{') && token.endsWith('}');
+      if (isVariable) {
+        name = token.substring(1, token.length - 1);
+      }
+      if (!_isValidTopicToken(name) ||
+          name.contains('{') ||
+          name.contains('}')) {
+        throw new ArgumentError.value(
+            prefix, 'prefix', 'invalid token "$token" in topic prefix');
+      }
+      if (isVariable) {
+        _variables.add(name);
+      }
+      _tokens.add(token);
+    }
+  }
+
+  /// Names of the prefix variables in the order their values are given to
+  /// [topic].
+  List<String> get variables => new List.unmodifiable(_variables);
+
+  /// Returns the topic of the given operation with the prefix variables
+  /// replaced by the given values. Throws an [ArgumentError] if the number of
+  /// values doesn't match the number of variables or a value is empty or
+  /// contains a topic delimiter or wildcard.
+  String topic(String op, [List<String> values = const []]) {
+    if (values.length != _variables.length) {
+      throw new ArgumentError.value(values, 'values',
+          'topic prefix has ${_variables.length} variables, got ${values.length} values');
+    }
+    if (!_isValidTopicToken(op)) {
+      throw new ArgumentError.value(op, 'op', 'invalid operation');
+    }
+    List<String> topic = [];
+    int i = 0;
+    for (String token in _tokens) {
+      if (_isTopicVariable(token)) {
+        if (!_isValidTopicToken(values[i])) {
+          throw new ArgumentError.value(values[i], 'values',
+              'invalid value for topic prefix variable ${_variables[i]}');
+        }
+        token = values[i++];
+      }
+      topic.add(token);
+    }
+    topic..add(_scope)..add(op);
+    return topic.join(_topicDelimiter);
+  }
+
+  /// Validates that the topic was built from the template and returns its
+  /// operation and the values of its prefix variables. Throws an
+  /// [ArgumentError] if the topic wasn't built from the template.
+  FTopicMatch match(String topic) {
+    ArgumentError mismatch() =>
+        new ArgumentError.value(topic, 'topic', 'does not match scope $_scope');
+    List<String> parts = topic.split(_topicDelimiter);
+    if (parts.length != _tokens.length + 2) {
+      throw mismatch();
+    }
+    Map<String, String> values = {};
+    for (int i = 0; i < _tokens.length; i++) {
+      String token = _tokens[i];
+      if (_isTopicVariable(token)) {
+        if (!_isValidTopicToken(parts[i])) {
+          throw mismatch();
+        }
+        values[token.substring(1, token.length - 1)] = parts[i];
+      } else if (parts[i] != token) {
+        throw mismatch();
+      }
+    }
+    String op = parts.last;
+    if (parts[parts.length - 2] != _scope || !_isValidTopicToken(op)) {
+      throw mismatch();
+    }
+    return new FTopicMatch._(op, values);
+  }
+
+  /// Returns the topic with the prefix variables and operation replaced by the
+  /// given wildcard, e.g. "foo.*.Events.*" for the wildcard "*", which matches
+  /// every topic of the scope.
+  String pattern(String wildcard) {
+    List<String> pattern = _tokens
+        .map((token) => _isTopicVariable(token) ? wildcard : token)
+        .toList();
+    pattern..add(_scope)..add(wildcard);
+    return pattern.join(_topicDelimiter);
+  }
+}
+
+/// The operation and prefix variable values of a topic matched by
+/// [FTopicTemplate.match].
+class FTopicMatch {
+  /// Name of the operation.
+  final String operation;
+
+  /// Values of the prefix variables keyed by name.
+  final Map<String, String> values;
+
+  FTopicMatch._(this.operation, Map<String, String> values)
+      : values = new Map.unmodifiable(values);
+}
@@ -37,6 +37,17 @@ abstract class FSubscriberTransport {
   Future remove() => unsubscribe();
 }
 
+/// An [FSubscriberTransport] which can stop delivering messages while its
+/// subscriber can't keep up, e.g. by no longer requesting messages from the
+/// broker, so they're held there rather than buffered by the subscriber.
+abstract class FPausableSubscriberTransport extends FSubscriberTransport {
+  /// Stops delivering messages until [resume] is called.
+  void pause();
+
+  /// Delivers messages again after [pause].
+  void resume();
+}
+
 /// Produces [FSubscriberTransport] instances.
 abstract class FSubscriberTransportFactory {
   /// Return a new [FSubscriberTransport] instance.
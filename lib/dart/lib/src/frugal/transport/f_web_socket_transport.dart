@@ -0,0 +1,108 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+part of frugal.src.frugal;
+
+/// An [FAsyncTransport] that makes frugal requests over a w_transport
+/// [wt.WebSocket]. Each request frame is sent as a binary message and each
+/// message received is expected to be a response frame. Since w_transport
+/// abstracts the platform, it works both in the Dart VM and in browsers once
+/// w_transport is configured for the platform.
+class FWebSocketTransport extends FAsyncTransport {
+  final Logger _webSocketLog = new Logger('FWebSocketTransport');
+
+  /// URI of the frugal WebSocket server.
+  final Uri uri;
+
+  wt.WebSocket _socket;
+  StreamSubscription<Object> _onMessageSub;
+
+  /// Create an [FWebSocketTransport] connecting to the given uri with the
+  /// optional [requestSizeLimit].
+  FWebSocketTransport(this.uri, {int requestSizeLimit: 0})
+      : super(requestSizeLimit: requestSizeLimit);
+
+  @override
+  bool get isOpen => _socket != null;
+
+  @override
+  Future open() async {
+    if (isOpen) {
+      throw new TTransportError(FrugalTTransportErrorType.ALREADY_OPEN,
+          'WebSocket already connected to $uri');
+    }
+    try {
+      _socket = await wt.WebSocket.connect(uri);
+    } on wt.WebSocketException catch (ex) {
+      throw new TTransportError(
+          FrugalTTransportErrorType.NOT_OPEN, ex.toString());
+    }
+    _onMessageSub = _socket.listen(_handleMessage, onDone: () {
+      // The server closed the connection.
+      if (isOpen) close();
+    });
+    _monitor?.signalOpen();
+  }
+
+  void _handleMessage(Object message) {
+    if (message is! List<int>) {
+      _webSocketLog.warning(
+          "frugal: expected a binary WebSocket message, dropping message");
+      return;
+    }
+    var frame = new Uint8List.fromList(message as List<int>);
+    if (frame.length < 4) {
+      _webSocketLog.warning(
+          "frugal: invalid protocol frame: missing frame size, dropping message");
+      return;
+    }
+    try {
+      handleResponse(frame.sublist(4));
+    } catch (e) {
+      // Fatal error. Close the transport.
+      _webSocketLog.severe(
+          "FAsyncCallback had a fatal error ${e.toString()}." +
+              "Closing transport.");
+      close(e);
+    }
+  }
+
+  @override
+  Future close([Error error]) async {
+    if (!isOpen) {
+      return;
+    }
+    wt.WebSocket socket = _socket;
+    _socket = null;
+    await _onMessageSub?.cancel();
+    _onMessageSub = null;
+    await socket.close();
+    await super.close(error);
+  }
+
+  @override
+  Future<Null> flush(Uint8List payload) {
+    if (!isOpen) {
+      throw new TTransportError(
+          FrugalTTransportErrorType.NOT_OPEN, 'transport not open');
+    }
+    _socket.add(payload);
+    return new Future.value();
+  }
+
+  @override
+  Future<Null> onDispose() async {
+    await close();
+    await super.onDispose();
+  }
+}
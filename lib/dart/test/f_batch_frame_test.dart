@@ -0,0 +1,140 @@
+import 'dart:async';
+import 'dart:typed_data' show Uint8List;
+
+import 'package:frugal/frugal.dart';
+import 'package:test/test.dart';
+import 'package:thrift/thrift.dart';
+
+void main() {
+  group('publishBatchFrame', () {
+    test('publishes the frames in a single batch frame', () async {
+      var transport = new _RecordingPublisherTransport(0);
+      await publishBatchFrame(transport, 'foo', [
+        _frameOf([1, 2]),
+        _frameOf([3])
+      ]);
+      expect(transport.topics, equals(['foo']));
+      expect(transport.payloads.single,
+          equals([0, 0, 0, 12, 0x80, 0, 0, 0, 2, 1, 2, 0, 0, 0, 1, 3]));
+    });
+
+    test('publishes nothing without frames', () async {
+      var transport = new _RecordingPublisherTransport(0);
+      await publishBatchFrame(transport, 'foo', []);
+      expect(transport.payloads, isEmpty);
+    });
+
+    test('throws a too large error over the size limit', () async {
+      var transport = new _RecordingPublisherTransport(10);
+      expect(
+          publishBatchFrame(transport, 'foo', [
+            _frameOf([1, 2]),
+            _frameOf([3])
+          ]),
+          throwsA(new isInstanceOf<TTransportError>()));
+      expect(transport.payloads, isEmpty);
+    });
+  });
+
+  group('batchFrameCallback', () {
+    test('calls the callback with each message of a batch', () async {
+      var transport = new _RecordingPublisherTransport(0);
+      await publishBatchFrame(transport, 'foo', [
+        _frameOf([1, 2]),
+        _frameOf([3])
+      ]);
+      var received = [];
+      var callback = batchFrameCallback((TTransport t) {
+        received.add((t as TMemoryTransport).buffer);
+      });
+      callback(_deliver(transport.payloads.single));
+      expect(
+          received,
+          equals([
+            [1, 2],
+            [3]
+          ]));
+    });
+
+    test('calls the callback with a single message', () {
+      var received = [];
+      var callback = batchFrameCallback((TTransport t) {
+        received.add((t as TMemoryTransport).buffer);
+      });
+      callback(new TMemoryTransport.fromUint8List(
+          new Uint8List.fromList([0, 1, 2])));
+      expect(
+          received,
+          equals([
+            [0, 1, 2]
+          ]));
+    });
+
+    test('processes every message and rethrows the first error', () async {
+      var transport = new _RecordingPublisherTransport(0);
+      await publishBatchFrame(transport, 'foo', [
+        _frameOf([1]),
+        _frameOf([2]),
+        _frameOf([3])
+      ]);
+      var received = [];
+      var callback = batchFrameCallback((TTransport t) {
+        var message = (t as TMemoryTransport).buffer;
+        received.add(message);
+        if (message[0] != 3) {
+          throw new StateError('bad message ${message[0]}');
+        }
+      });
+      expect(
+          () => callback(_deliver(transport.payloads.single)),
+          throwsA(
+              predicate((e) => e is StateError && e.message.endsWith('1'))));
+      expect(received.length, equals(3));
+    });
+
+    test('throws a protocol error for a truncated batch', () {
+      var callback = batchFrameCallback((TTransport t) {});
+      expect(
+          () => callback(new TMemoryTransport.fromUint8List(
+              new Uint8List.fromList([0x80, 0, 0, 0, 5, 1]))),
+          throwsA(new isInstanceOf<TProtocolError>()));
+    });
+  });
+}
+
+Uint8List _frameOf(List<int> message) {
+  var buffer = new TMemoryOutputBuffer();
+  buffer.write(new Uint8List.fromList(message), 0, message.length);
+  return buffer.writeBytes;
+}
+
+/// Returns the transport a subscriber transport passes its callback for the
+/// given published payload, without its frame size.
+TTransport _deliver(Uint8List payload) =>
+    new TMemoryTransport.fromUint8List(payload.sublist(4));
+
+class _RecordingPublisherTransport extends FPublisherTransport {
+  final int _limit;
+  final List<String> topics = [];
+  final List<Uint8List> payloads = [];
+
+  _RecordingPublisherTransport(this._limit);
+
+  @override
+  bool get isOpen => true;
+
+  @override
+  Future open() => new Future.value();
+
+  @override
+  Future close() => new Future.value();
+
+  @override
+  int get publishSizeLimit => _limit;
+
+  @override
+  void publish(String topic, Uint8List payload) {
+    topics.add(topic);
+    payloads.add(payload);
+  }
+}
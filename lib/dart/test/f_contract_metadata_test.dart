@@ -0,0 +1,31 @@
+import "package:frugal/frugal.dart";
+import "package:test/test.dart";
+
+void main() {
+  const metadata = const FContractMetadata('variety.frugal', 'service', 'Foo',
+      'abc', '2.23.0', const ['ping', 'blah']);
+
+  test("matches compares the contract hash", () {
+    expect(metadata.matches('abc'), isTrue);
+    expect(metadata.matches('def'), isFalse);
+  });
+
+  test("hasOperation checks the contract's operations", () {
+    expect(metadata.hasOperation('blah'), isTrue);
+    expect(metadata.hasOperation('Blah'), isFalse);
+  });
+
+  test("description returns the doc comments of documented operations", () {
+    const documented = const FContractMetadata('variety.frugal', 'scope',
+        'Events', 'abc', '2.23.0', const ['EventCreated', 'EventDeleted'],
+        const {'EventCreated': 'An event was created.'});
+    expect(documented.description('EventCreated'),
+        equals('An event was created.'));
+    expect(documented.description('EventDeleted'), equals(''));
+    expect(metadata.description('ping'), equals(''));
+  });
+
+  test("toString describes the contract", () {
+    expect(metadata.toString(), equals('service Foo (variety.frugal, abc)'));
+  });
+}
@@ -0,0 +1,35 @@
+import "package:frugal/frugal.dart";
+import "package:test/test.dart";
+import "package:thrift/thrift.dart";
+
+void main() {
+  test("translateTransportError translates not open errors", () {
+    var error = translateTransportError(
+        new TTransportError(FrugalTTransportErrorType.NOT_OPEN, "closed"));
+    expect(error, new isInstanceOf<FTransportClosedError>());
+    expect((error as FTransportClosedError).message, equals("closed"));
+  });
+
+  test("translateTransportError translates timeout errors", () {
+    var error = translateTransportError(
+        new TTransportError(FrugalTTransportErrorType.TIMED_OUT, "timeout"));
+    expect(error, new isInstanceOf<FTimeoutError>());
+    expect((error as FTimeoutError).type,
+        equals(FrugalTTransportErrorType.TIMED_OUT));
+  });
+
+  test("translateTransportError translates too large errors", () {
+    var error = translateTransportError(
+        new TTransportError(FrugalTTransportErrorType.REQUEST_TOO_LARGE));
+    expect(error, new isInstanceOf<FTooLargeError>());
+  });
+
+  test("translateTransportError leaves other errors unchanged", () {
+    var transportError = new TTransportError(FrugalTTransportErrorType.UNKNOWN);
+    expect(translateTransportError(transportError), same(transportError));
+    var stateError = new StateError("bad state");
+    expect(translateTransportError(stateError), same(stateError));
+    var typedError = new FTimeoutError();
+    expect(translateTransportError(typedError), same(typedError));
+  });
+}
@@ -0,0 +1,63 @@
+import 'dart:async';
+
+import 'package:frugal/frugal.dart';
+import 'package:test/test.dart';
+import 'package:thrift/thrift.dart';
+
+FProtocolFactory _protocolFactory =
+    new FProtocolFactory(new TBinaryProtocolFactory());
+
+TTransport _message(String value) {
+  TMemoryOutputBuffer trans = new TMemoryOutputBuffer();
+  FProtocol prot = _protocolFactory.getProtocol(trans);
+  prot.writeRequestHeader(new FContext(correlationId: value));
+  prot.writeString(value);
+  return new TMemoryTransport.fromUint8List(trans.writeBytes.sublist(4));
+}
+
+FDecodedMessage _decode(FIsolateMessage message) {
+  var iprot = message.getProtocol();
+  var ctx = iprot.readRequestHeader();
+  var value = iprot.readString();
+  if (value == 'bad') {
+    throw new StateError('bad message');
+  }
+  return new FDecodedMessage(ctx, value);
+}
+
+void main() {
+  group('isolateDecodeCallback', () {
+    test('handles decoded messages in the order they were received', () async {
+      var delays = {'first': 20, 'second': 0};
+      Future runner(FDecoder decode, FIsolateMessage message) {
+        var decoded = decode(message);
+        return new Future.delayed(
+            new Duration(milliseconds: delays[decoded.req]), () => decoded);
+      }
+
+      var handled = [];
+      var callback = isolateDecodeCallback('foo', _protocolFactory, runner,
+          _decode, (FContext ctx, Object req) {
+        expect(ctx.correlationId, equals(req));
+        handled.add(req);
+      }) as dynamic;
+      var first = callback(_message('first'));
+      var second = callback(_message('second'));
+      await Future.wait([first, second]);
+      expect(handled, equals(['first', 'second']));
+    });
+
+    test('completes with errors decoding and keeps handling', () async {
+      Future runner(FDecoder decode, FIsolateMessage message) =>
+          new Future(() => decode(message));
+
+      var handled = [];
+      var callback = isolateDecodeCallback('foo', _protocolFactory, runner,
+          _decode, (FContext ctx, Object req) => handled.add(req)) as dynamic;
+      expect(callback(_message('bad')),
+          throwsA(new isInstanceOf<StateError>()));
+      await callback(_message('good'));
+      expect(handled, equals(['good']));
+    });
+  });
+}
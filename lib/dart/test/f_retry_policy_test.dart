@@ -0,0 +1,96 @@
+import 'dart:async';
+import 'dart:math';
+
+import 'package:frugal/frugal.dart';
+import 'package:test/test.dart';
+import 'package:thrift/thrift.dart';
+
+const _policy = const FRetryPolicy(
+    initialBackoff: const Duration(milliseconds: 1),
+    maxBackoff: const Duration(milliseconds: 4),
+    maxAttempts: 3,
+    jitter: 0.0);
+
+class _FixedRandom implements Random {
+  final double value;
+
+  _FixedRandom(this.value);
+
+  @override
+  double nextDouble() => value;
+
+  @override
+  int nextInt(int max) => 0;
+
+  @override
+  bool nextBool() => false;
+}
+
+void main() {
+  group('FRetryPolicy', () {
+    test('backs off exponentially up to the max backoff', () {
+      expect(_policy.backoff(1), equals(new Duration(milliseconds: 1)));
+      expect(_policy.backoff(2), equals(new Duration(milliseconds: 2)));
+      expect(_policy.backoff(3), equals(new Duration(milliseconds: 4)));
+      expect(_policy.backoff(10), equals(new Duration(milliseconds: 4)));
+    });
+
+    test('varies backoffs by the jitter', () {
+      var policy = const FRetryPolicy(
+          initialBackoff: const Duration(milliseconds: 100), jitter: 0.5);
+      expect(policy.backoff(1, new _FixedRandom(0.0)),
+          equals(new Duration(milliseconds: 50)));
+      expect(policy.backoff(1, new _FixedRandom(1.0)),
+          equals(new Duration(milliseconds: 150)));
+    });
+
+    test('treats transport failures other than size limits as transient', () {
+      expect(_policy.isTransient(new FTimeoutError()), isTrue);
+      expect(_policy.isTransient(new FTransportClosedError()), isTrue);
+      expect(_policy.isTransient(new FTooLargeError()), isFalse);
+      expect(_policy.isTransient(new StateError('bad')), isFalse);
+    });
+  });
+
+  group('retryPublish', () {
+    test('retries transient failures', () async {
+      var attempts = 0;
+      await retryPublish(_policy, () async {
+        attempts++;
+        if (attempts < 3) {
+          throw new FTransportClosedError();
+        }
+      });
+      expect(attempts, equals(3));
+    });
+
+    test('fails once the max attempts fail', () async {
+      var attempts = 0;
+      try {
+        await retryPublish(_policy, () async {
+          attempts++;
+          throw new FTimeoutError();
+        });
+        fail('expected an FPublishFailedError');
+      } on FPublishFailedError catch (e) {
+        expect(e.attempts, equals(3));
+        expect(e.cause, new isInstanceOf<FTimeoutError>());
+      }
+      expect(attempts, equals(3));
+    });
+
+    test('fails permanent errors without retrying', () async {
+      var attempts = 0;
+      try {
+        await retryPublish(_policy, () async {
+          attempts++;
+          throw new TTransportError(FrugalTTransportErrorType.REQUEST_TOO_LARGE);
+        });
+        fail('expected an FPublishFailedError');
+      } on FPublishFailedError catch (e) {
+        expect(e.attempts, equals(1));
+      }
+      expect(attempts, equals(1));
+    });
+  });
+}
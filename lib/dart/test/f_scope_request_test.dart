@@ -0,0 +1,196 @@
+import 'dart:async';
+import 'dart:typed_data' show Uint8List;
+
+import 'package:frugal/frugal.dart';
+import 'package:test/test.dart';
+import 'package:thrift/thrift.dart';
+
+void main() {
+  group('FScopeRequester', () {
+    _FakeBroker broker;
+    FScopeProvider provider;
+    FScopeRequester requester;
+    FScopeResponder responder;
+
+    setUp(() async {
+      broker = new _FakeBroker();
+      provider = new FScopeProvider(broker, broker,
+          new FProtocolFactory(new TBinaryProtocolFactory()));
+      requester = new FScopeRequester(provider);
+      responder = new FScopeResponder(provider);
+      await requester.open();
+    });
+
+    tearDown(() async {
+      await requester.close();
+      await responder.close();
+    });
+
+    // Subscribes a responder to foo replying with the result of the handler.
+    Future respond(handler(String req)) {
+      return broker.getTransport().subscribe('foo', (TTransport transport) {
+        var iprot = provider.protocolFactory.getProtocol(transport);
+        var ctx = iprot.readRequestHeader();
+        iprot.readMessageBegin();
+        var req = iprot.readString();
+        iprot.readMessageEnd();
+        responder.reply(ctx, 'op', new Future.sync(() => handler(req)),
+            (FProtocol oprot, reply) => oprot.writeString(reply));
+      });
+    }
+
+    Future request(FContext ctx) {
+      return requester.request(
+          ctx,
+          'foo',
+          'op',
+          (FProtocol oprot) => oprot.writeString('ping'),
+          (FProtocol iprot) => iprot.readString());
+    }
+
+    test('completes with the reply to the request', () async {
+      await respond((req) => '$req pong');
+      var ctx = new FContext();
+      expect(await request(ctx), equals('ping pong'));
+      expect(ctx.requestHeader('_reply_to'), equals(requester.replyTopic));
+    });
+
+    test('throws errors replied as TApplicationErrors', () async {
+      await respond((req) => throw new StateError('bad request'));
+      expect(request(new FContext()),
+          throwsA(new isInstanceOf<TApplicationError>()));
+    });
+
+    test('throws MISSING_RESULT TApplicationErrors for null replies',
+        () async {
+      await respond((req) => null);
+      try {
+        await request(new FContext());
+        fail('expected missing result');
+      } on TApplicationError catch (e) {
+        expect(e.type, equals(FrugalTApplicationErrorType.MISSING_RESULT));
+        expect(e.message, equals('op failed: unknown result'));
+      }
+    });
+
+    test('throws TTransportErrors when no reply is received', () async {
+      var ctx = new FContext()..timeout = new Duration(milliseconds: 10);
+      try {
+        await request(ctx);
+        fail('expected timeout');
+      } on TTransportError catch (e) {
+        expect(e.type, equals(FrugalTTransportErrorType.TIMED_OUT));
+      }
+    });
+  });
+
+  group('FScopeResponder', () {
+    test('does not reply to requests without a reply topic', () async {
+      var broker = new _FakeBroker();
+      var provider = new FScopeProvider(broker, broker,
+          new FProtocolFactory(new TBinaryProtocolFactory()));
+      var responder = new FScopeResponder(provider);
+      await responder.reply(new FContext(), 'op', 'pong',
+          (FProtocol oprot, reply) => oprot.writeString(reply));
+      expect(broker.published, equals(0));
+      expect(
+          responder.reply(new FContext(), 'op',
+              new Future.error(new StateError('bad request')), (_, __) {}),
+          throwsA(new isInstanceOf<StateError>()));
+    });
+
+    test('opens the publisher transport again after it fails to open',
+        () async {
+      var broker = new _FakeBroker()..openError = new StateError('no broker');
+      var provider = new FScopeProvider(broker, broker,
+          new FProtocolFactory(new TBinaryProtocolFactory()));
+      var responder = new FScopeResponder(provider);
+      var ctx = new FContext()..addRequestHeader('_reply_to', 'reply');
+      writeReply(FProtocol oprot, reply) => oprot.writeString(reply);
+
+      try {
+        await responder.reply(ctx, 'op', 'pong', writeReply);
+        fail('expected open error');
+      } on StateError catch (e) {
+        expect(e.message, equals('no broker'));
+      }
+      expect(broker.published, equals(0));
+
+      broker.openError = null;
+      await responder.reply(ctx, 'op', 'pong', writeReply);
+      expect(broker.published, equals(1));
+      await responder.close();
+    });
+  });
+}
+
+/// Delivers the messages published with its transports to the subscribers
+/// of their topic.
+class _FakeBroker
+    implements FPublisherTransportFactory, FSubscriberTransportFactory {
+  final Map<String, List<FAsyncCallback>> _subscribers = {};
+  int published = 0;
+  Object openError;
+
+  @override
+  _FakeTransport getTransport() => new _FakeTransport(this);
+
+  void publish(String topic, Uint8List payload) {
+    published++;
+    for (var callback in _subscribers[topic] ?? []) {
+      // Subscribers receive the frame without its size.
+      callback(new TMemoryTransport.fromUint8List(payload.sublist(4)));
+    }
+  }
+}
+
+class _FakeTransport implements FPublisherTransport, FSubscriberTransport {
+  final _FakeBroker _broker;
+  String _topic;
+  FAsyncCallback _callback;
+
+  @override
+  bool isOpen = false;
+
+  _FakeTransport(this._broker);
+
+  @override
+  Future open() async {
+    if (_broker.openError != null) {
+      throw _broker.openError;
+    }
+    isOpen = true;
+  }
+
+  @override
+  Future close() async {
+    isOpen = false;
+  }
+
+  @override
+  int get publishSizeLimit => 0;
+
+  @override
+  void publish(String topic, Uint8List payload) {
+    _broker.publish(topic, payload);
+  }
+
+  @override
+  bool get isSubscribed => _callback != null;
+
+  @override
+  Future<Null> subscribe(String topic, FAsyncCallback callback) async {
+    _topic = topic;
+    _callback = callback;
+    _broker._subscribers.putIfAbsent(topic, () => []).add(callback);
+  }
+
+  @override
+  Future<Null> unsubscribe() async {
+    _broker._subscribers[_topic]?.remove(_callback);
+    _callback = null;
+  }
+
+  @override
+  Future remove() => unsubscribe();
+}
@@ -0,0 +1,193 @@
+import 'dart:async';
+import 'dart:typed_data' show Uint8List;
+
+import 'package:frugal/frugal.dart';
+import 'package:test/test.dart';
+import 'package:thrift/thrift.dart';
+
+const _policy = const FResubscribePolicy(
+    initialBackoff: const Duration(milliseconds: 1),
+    maxBackoff: const Duration(milliseconds: 4),
+    maxAttempts: 3);
+
+void main() {
+  group('FResubscribePolicy', () {
+    test('backs off exponentially up to the max backoff', () {
+      expect(_policy.backoff(1), equals(new Duration(milliseconds: 1)));
+      expect(_policy.backoff(2), equals(new Duration(milliseconds: 2)));
+      expect(_policy.backoff(3), equals(new Duration(milliseconds: 4)));
+      expect(_policy.backoff(10), equals(new Duration(milliseconds: 4)));
+    });
+
+    test('retries up to the max attempts', () {
+      expect(_policy.shouldRetry(2), isTrue);
+      expect(_policy.shouldRetry(3), isFalse);
+      expect(const FResubscribePolicy(maxAttempts: 0).shouldRetry(100), isTrue);
+    });
+  });
+
+  group('subscribeWithPolicy', () {
+    test('subscribes the transport with the callback', () async {
+      var transport = new _FakeSubscriberTransport(0);
+      var received = 0;
+      var subscription = await subscribeWithPolicy(
+          'foo', transport, (TTransport t) => received++);
+      expect(subscription.topic, equals('foo'));
+      expect(transport.topic, equals('foo'));
+      transport.deliver();
+      expect(received, equals(1));
+
+      await subscription.unsubscribe();
+      expect(transport.isSubscribed, isFalse);
+    });
+
+    test('passes callback errors to onError', () async {
+      var transport = new _FakeSubscriberTransport(0);
+      var errors = [];
+      await subscribeWithPolicy('foo', transport, (TTransport t) {
+        throw new StateError('bad message');
+      }, onError: errors.add);
+      transport.deliver();
+      expect(errors.single, new isInstanceOf<StateError>());
+      expect(transport.subscribes, equals(1));
+    });
+
+    test('retries failures to subscribe', () async {
+      var transport = new _FakeSubscriberTransport(2);
+      var errors = [];
+      var subscription = await subscribeWithPolicy(
+          'foo', transport, (TTransport t) {},
+          onError: errors.add, resubscribePolicy: _policy);
+      expect(subscription, isNotNull);
+      expect(transport.subscribes, equals(3));
+      expect(errors.length, equals(2));
+    });
+
+    test('throws once the max attempts fail', () async {
+      var transport = new _FakeSubscriberTransport(10);
+      var errors = [];
+      expect(
+          subscribeWithPolicy('foo', transport, (TTransport t) {},
+              onError: errors.add, resubscribePolicy: _policy),
+          throwsA(new isInstanceOf<TTransportError>()));
+      await new Future.delayed(new Duration(milliseconds: 50));
+      expect(transport.subscribes, equals(4));
+      expect(errors.length, equals(4));
+    });
+
+    test('resubscribes after a callback error', () async {
+      var transport = new _FakeSubscriberTransport(0);
+      var errors = [];
+      await subscribeWithPolicy('foo', transport, (TTransport t) {
+        throw new StateError('bad message');
+      }, onError: errors.add, resubscribePolicy: _policy);
+      transport.deliver();
+      await new Future.delayed(new Duration(milliseconds: 50));
+      expect(errors.single, new isInstanceOf<StateError>());
+      expect(transport.unsubscribes, equals(1));
+      expect(transport.subscribes, equals(2));
+      expect(transport.isSubscribed, isTrue);
+    });
+
+    test('stops resubscribing once unsubscribed', () async {
+      var transport = new _FakeSubscriberTransport(0);
+      var subscription = await subscribeWithPolicy('foo', transport,
+          (TTransport t) {
+        throw new StateError('bad message');
+      }, resubscribePolicy: _policy);
+      transport.deliver();
+      await subscription.unsubscribe();
+      await new Future.delayed(new Duration(milliseconds: 50));
+      expect(transport.subscribes, equals(1));
+      expect(transport.isSubscribed, isFalse);
+    });
+  });
+
+  group('FSubscription', () {
+    test('pauses and resumes pausable transports', () async {
+      var transport = new _FakePausableSubscriberTransport();
+      var subscription =
+          await subscribeWithPolicy('foo', transport, (TTransport t) {});
+      subscription.pause();
+      expect(transport.paused, isTrue);
+      subscription.resume();
+      expect(transport.paused, isFalse);
+    });
+
+    test('keeps resubscribed transports paused', () async {
+      var transport = new _FakePausableSubscriberTransport();
+      var subscription = await subscribeWithPolicy('foo', transport,
+          (TTransport t) {
+        throw new StateError('bad message');
+      }, resubscribePolicy: _policy);
+      subscription.pause();
+      transport.deliver();
+      transport.paused = false;
+      await new Future.delayed(new Duration(milliseconds: 50));
+      expect(transport.subscribes, equals(2));
+      expect(transport.paused, isTrue);
+    });
+
+    test('ignores pauses of other transports', () async {
+      var transport = new _FakeSubscriberTransport(0);
+      var received = 0;
+      var subscription = await subscribeWithPolicy(
+          'foo', transport, (TTransport t) => received++);
+      subscription.pause();
+      transport.deliver();
+      expect(received, equals(1));
+    });
+  });
+}
+
+class _FakeSubscriberTransport extends FSubscriberTransport {
+  int _failures;
+  String topic;
+  FAsyncCallback _callback;
+  int subscribes = 0;
+  int unsubscribes = 0;
+
+  _FakeSubscriberTransport(this._failures);
+
+  @override
+  bool get isSubscribed => _callback != null;
+
+  @override
+  Future<Null> subscribe(String topic, FAsyncCallback callback) async {
+    subscribes++;
+    if (_failures > 0) {
+      _failures--;
+      throw new TTransportError(
+          FrugalTTransportErrorType.NOT_OPEN, 'subscribe failed');
+    }
+    this.topic = topic;
+    _callback = callback;
+  }
+
+  @override
+  Future<Null> unsubscribe() async {
+    unsubscribes++;
+    _callback = null;
+  }
+
+  void deliver() {
+    _callback(new TMemoryTransport.fromUint8List(new Uint8List(0)));
+  }
+}
+
+class _FakePausableSubscriberTransport extends _FakeSubscriberTransport
+    implements FPausableSubscriberTransport {
+  bool paused = false;
+
+  _FakePausableSubscriberTransport() : super(0);
+
+  @override
+  void pause() {
+    paused = true;
+  }
+
+  @override
+  void resume() {
+    paused = false;
+  }
+}
@@ -0,0 +1,70 @@
+import "package:frugal/frugal.dart";
+import "package:test/test.dart";
+
+void main() {
+  test("topic builds topics the same way generated publishers do", () {
+    var template = new FTopicTemplate('foo.{user}', 'Events');
+    expect(template.variables, equals(['user']));
+    expect(template.topic('EventCreated', ['bob']),
+        equals('foo.bob.Events.EventCreated'));
+  });
+
+  test("topic works for scopes without a prefix", () {
+    var template = new FTopicTemplate('', 'Events');
+    expect(template.variables, isEmpty);
+    expect(template.topic('EventCreated'), equals('Events.EventCreated'));
+  });
+
+  test("topic rejects missing and invalid values", () {
+    var template = new FTopicTemplate('foo.{user}.{id}', 'Events');
+    for (var values in [
+      ['bob'],
+      ['bob', ''],
+      ['bob', 'a.b'],
+      ['*', '1']
+    ]) {
+      expect(() => template.topic('EventCreated', values), throwsArgumentError);
+    }
+    expect(() => template.topic('', ['bob', '1']), throwsArgumentError);
+  });
+
+  test("invalid prefixes are rejected", () {
+    for (var prefix in [
+      'foo.',
+      '.foo',
+      'foo..bar',
+      'foo.{}',
+      'foo.{user',
+      'foo.b{a}r',
+      'foo bar'
+    ]) {
+      expect(() => new FTopicTemplate(prefix, 'Events'), throwsArgumentError);
+    }
+    expect(() => new FTopicTemplate('foo', ''), throwsArgumentError);
+  });
+
+  test("match returns the operation and variable values", () {
+    var template = new FTopicTemplate('foo.{user}.bar.{id}', 'Events');
+    var match = template.match('foo.bob.bar.42.Events.EventCreated');
+    expect(match.operation, equals('EventCreated'));
+    expect(match.values, equals({'user': 'bob', 'id': '42'}));
+  });
+
+  test("match rejects topics not built from the template", () {
+    var template = new FTopicTemplate('foo.{user}', 'Events');
+    for (var topic in [
+      'foo.bob.Events',
+      'bar.bob.Events.EventCreated',
+      'foo.bob.Other.EventCreated',
+      'foo..Events.EventCreated',
+      'foo.bob.extra.Events.EventCreated'
+    ]) {
+      expect(() => template.match(topic), throwsArgumentError);
+    }
+  });
+
+  test("pattern replaces variables and the operation with the wildcard", () {
+    var template = new FTopicTemplate('foo.{user}', 'Events');
+    expect(template.pattern('*'), equals('foo.*.Events.*'));
+  });
+}
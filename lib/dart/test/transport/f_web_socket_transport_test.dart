@@ -0,0 +1,65 @@
+import 'dart:async';
+
+import 'package:frugal/frugal.dart';
+import 'package:test/test.dart';
+import 'package:thrift/thrift.dart';
+import 'package:w_transport/w_transport_mock.dart';
+import 'f_adapter_transport_test.dart' show mockFrame;
+
+void main() {
+  configureWTransportForTest();
+
+  group('FWebSocketTransport', () {
+    Uri uri = Uri.parse('ws://localhost/frugal');
+    MockWSocket socket;
+    FWebSocketTransport transport;
+
+    setUp(() {
+      socket = new MockWSocket();
+      MockTransports.webSocket.expect(uri, connectTo: socket);
+      transport = new FWebSocketTransport(uri);
+    });
+
+    tearDown(() async {
+      await transport.close();
+      await MockTransports.reset();
+    });
+
+    test('Test transport sends requests and receives responses', () async {
+      await transport.open();
+      expect(transport.isOpen, isTrue);
+
+      FContext reqCtx = new FContext();
+      var frame = mockFrame(reqCtx, "request");
+      var respFrame = mockFrame(reqCtx, "response");
+      socket.onOutgoing((data) {
+        expect(data, frame);
+        socket.addIncoming(respFrame);
+      });
+
+      var response = await transport.request(reqCtx, frame) as TMemoryTransport;
+      expect(response.buffer, respFrame.sublist(4));
+    });
+
+    test('Test transport drops text messages', () async {
+      await transport.open();
+      socket.addIncoming('not a frame');
+      await new Future.delayed(Duration.ZERO);
+      expect(transport.isOpen, isTrue);
+    });
+
+    test('Test transport closes when the server closes', () async {
+      await transport.open();
+      Completer closed = new Completer();
+      transport.onClose.listen((_) => closed.complete());
+      socket.triggerServerClose();
+      await closed.future;
+      expect(transport.isOpen, isFalse);
+    });
+
+    test('Test open twice throws', () async {
+      await transport.open();
+      expect(transport.open(), throwsA(new isInstanceOf<TTransportError>()));
+    });
+  });
+}
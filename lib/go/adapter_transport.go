@@ -17,7 +17,6 @@ import (
 	"bytes"
 	"io"
 	"sync"
-	"time"
 
 	"git.apache.org/thrift.git/lib/go/thrift"
 )
@@ -191,7 +190,7 @@ func (f *fAdapterTransport) Oneway(ctx FContext, payload []byte) error {
 	select {
 	case err := <-errorC:
 		return err
-	case <-time.After(ctx.Timeout()):
+	case <-clock().After(ctx.Timeout()):
 		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_TIMED_OUT, "frugal: request timed out")
 	}
 }
@@ -213,7 +212,7 @@ func (f *fAdapterTransport) Request(ctx FContext, payload []byte) (thrift.TTrans
 		return &thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(result)}, nil
 	case err := <-errorC:
 		return nil, err
-	case <-time.After(ctx.Timeout()):
+	case <-clock().After(ctx.Timeout()):
 		return nil, thrift.NewTTransportException(TRANSPORT_EXCEPTION_TIMED_OUT, "frugal: request timed out")
 	}
 }
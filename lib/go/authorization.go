@@ -0,0 +1,106 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"fmt"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// FScopeAction is an action on a pub/sub topic which is subject to
+// authorization.
+type FScopeAction int
+
+const (
+	// FScopeActionPublish is the action of publishing to a topic.
+	FScopeActionPublish FScopeAction = iota
+
+	// FScopeActionSubscribe is the action of subscribing to a topic.
+	FScopeActionSubscribe
+)
+
+// String returns the name of the action.
+func (a FScopeAction) String() string {
+	switch a {
+	case FScopeActionPublish:
+		return "publish"
+	case FScopeActionSubscribe:
+		return "subscribe"
+	default:
+		return fmt.Sprintf("FScopeAction(%d)", int(a))
+	}
+}
+
+// FAuthorizationRequest describes a publish or subscribe which is to be
+// authorized by an FAuthorizer.
+type FAuthorizationRequest struct {
+	// Action is the action being performed.
+	Action FScopeAction
+
+	// Topic is the full topic being published or subscribed to.
+	Topic string
+
+	// Operation is the name of the scope operation.
+	Operation string
+
+	// Roles are the roles permitted to perform the action, as declared by
+	// the "publish_roles" and "subscribe_roles" IDL annotations. This is nil
+	// if the operation is not annotated.
+	Roles []string
+
+	// Context is the FContext of a publish. This is nil for subscribes.
+	Context FContext
+}
+
+// FAuthorizer authorizes publishes and subscribes made through an
+// FScopeProvider, allowing access control policy to be enforced in one
+// place rather than at every call site.
+type FAuthorizer interface {
+	// Authorize returns an error if the given request is not permitted, in
+	// which case the publish or subscribe fails with that error.
+	Authorize(*FAuthorizationRequest) error
+}
+
+// FAuthorizerFunc is an adapter to allow the use of ordinary functions as an
+// FAuthorizer.
+type FAuthorizerFunc func(*FAuthorizationRequest) error
+
+// Authorize calls f(request).
+func (f FAuthorizerFunc) Authorize(request *FAuthorizationRequest) error {
+	return f(request)
+}
+
+// NewFRoleAuthorizer returns an FAuthorizer which permits a request if it
+// has no declared roles or if any of the roles returned by the given
+// function, typically derived from the request context, is one of the
+// request's declared roles. Denied requests fail with a
+// TApplicationException of type APPLICATION_EXCEPTION_UNAUTHORIZED.
+func NewFRoleAuthorizer(roles func(*FAuthorizationRequest) []string) FAuthorizer {
+	return FAuthorizerFunc(func(request *FAuthorizationRequest) error {
+		if request.Roles == nil {
+			return nil
+		}
+		for _, role := range roles(request) {
+			for _, permitted := range request.Roles {
+				if role == permitted {
+					return nil
+				}
+			}
+		}
+		return thrift.NewTApplicationException(APPLICATION_EXCEPTION_UNAUTHORIZED,
+			fmt.Sprintf("frugal: %s not authorized for operation %s on topic %s",
+				request.Action, request.Operation, request.Topic))
+	})
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"errors"
+	"testing"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures the FScopeProvider permits everything when no FAuthorizer is set.
+func TestScopeProviderAuthorizeNoAuthorizer(t *testing.T) {
+	provider := NewFScopeProvider(nil, nil, nil)
+	assert.Nil(t, provider.Authorize(&FAuthorizationRequest{
+		Action: FScopeActionPublish,
+		Topic:  "foo.Events.EventCreated",
+		Roles:  []string{"billing"},
+	}))
+}
+
+// Ensures the FScopeProvider delegates to its FAuthorizer and returns its
+// error.
+func TestScopeProviderAuthorize(t *testing.T) {
+	expectedErr := errors.New("denied")
+	var actual *FAuthorizationRequest
+	provider := NewFScopeProvider(nil, nil, nil).WithAuthorizer(
+		FAuthorizerFunc(func(request *FAuthorizationRequest) error {
+			actual = request
+			return expectedErr
+		}))
+	request := &FAuthorizationRequest{
+		Action:    FScopeActionSubscribe,
+		Topic:     "foo.Events.EventCreated",
+		Operation: "EventCreated",
+	}
+
+	assert.Equal(t, expectedErr, provider.Authorize(request))
+	assert.Equal(t, request, actual)
+}
+
+// Ensures the role authorizer permits requests without declared roles and
+// requests whose caller has one of the declared roles.
+func TestRoleAuthorizerPermits(t *testing.T) {
+	authorizer := NewFRoleAuthorizer(func(request *FAuthorizationRequest) []string {
+		return []string{"admin", "billing"}
+	})
+
+	assert.Nil(t, authorizer.Authorize(&FAuthorizationRequest{Action: FScopeActionPublish}))
+	assert.Nil(t, authorizer.Authorize(&FAuthorizationRequest{
+		Action: FScopeActionPublish,
+		Roles:  []string{"billing", "ops"},
+	}))
+}
+
+// Ensures the role authorizer denies requests whose caller has none of the
+// declared roles with an unauthorized TApplicationException.
+func TestRoleAuthorizerDenies(t *testing.T) {
+	ctx := NewFContext("")
+	ctx.AddRequestHeader("role", "guest")
+	authorizer := NewFRoleAuthorizer(func(request *FAuthorizationRequest) []string {
+		role, _ := request.Context.RequestHeader("role")
+		return []string{role}
+	})
+
+	err := authorizer.Authorize(&FAuthorizationRequest{
+		Action:    FScopeActionPublish,
+		Topic:     "foo.Events.EventCreated",
+		Operation: "EventCreated",
+		Roles:     []string{"billing"},
+		Context:   ctx,
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(APPLICATION_EXCEPTION_UNAUTHORIZED), err.(thrift.TApplicationException).TypeId())
+	assert.Equal(t, "frugal: publish not authorized for operation EventCreated on topic foo.Events.EventCreated", err.Error())
+}
+
+// Ensures FScopeAction names are human-readable.
+func TestScopeActionString(t *testing.T) {
+	assert.Equal(t, "publish", FScopeActionPublish.String())
+	assert.Equal(t, "subscribe", FScopeActionSubscribe.String())
+	assert.Equal(t, "FScopeAction(5)", FScopeAction(5).String())
+}
@@ -0,0 +1,367 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"sync"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// defaultBrokerRetryInterval is how long a broker endpoint which failed is
+// passed over before it is tried again.
+const defaultBrokerRetryInterval = 30 * time.Second
+
+// fBrokerPool tracks the health of a set of broker endpoints and hands out
+// the order in which they should be tried. Endpoints are rotated round-robin
+// to distribute load, and endpoints which recently failed are tried last.
+type fBrokerPool struct {
+	mu            sync.Mutex
+	next          int
+	failedAt      []time.Time
+	retryInterval time.Duration
+}
+
+func newFBrokerPool(size int) *fBrokerPool {
+	return &fBrokerPool{
+		failedAt:      make([]time.Time, size),
+		retryInterval: defaultBrokerRetryInterval,
+	}
+}
+
+// order returns the endpoint indexes in the order they should be tried.
+func (p *fBrokerPool) order() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	size := len(p.failedAt)
+	healthy := make([]int, 0, size)
+	unhealthy := make([]int, 0, size)
+	now := clock().Now()
+	for i := 0; i < size; i++ {
+		idx := (p.next + i) % size
+		if now.Sub(p.failedAt[idx]) < p.retryInterval {
+			unhealthy = append(unhealthy, idx)
+		} else {
+			healthy = append(healthy, idx)
+		}
+	}
+	if size > 0 {
+		p.next = (p.next + 1) % size
+	}
+	return append(healthy, unhealthy...)
+}
+
+// markFailed marks the endpoint as unhealthy for the retry interval.
+func (p *fBrokerPool) markFailed(idx int) {
+	p.mu.Lock()
+	p.failedAt[idx] = clock().Now()
+	p.mu.Unlock()
+}
+
+// markHealthy marks the endpoint as healthy.
+func (p *fBrokerPool) markHealthy(idx int) {
+	p.mu.Lock()
+	p.failedAt[idx] = time.Time{}
+	p.mu.Unlock()
+}
+
+// FBalancedPublisherTransportFactory produces FPublisherTransports which
+// distribute publishes across multiple broker endpoints, each represented by
+// an FPublisherTransportFactory. Publishes are rotated round-robin across the
+// endpoints and fail over to the next endpoint when a publish fails. Failed
+// endpoints are passed over until the retry interval elapses.
+type FBalancedPublisherTransportFactory struct {
+	factories []FPublisherTransportFactory
+	pool      *fBrokerPool
+}
+
+// NewFBalancedPublisherTransportFactory creates a new
+// FBalancedPublisherTransportFactory which balances across the given broker
+// endpoints.
+func NewFBalancedPublisherTransportFactory(factories ...FPublisherTransportFactory) *FBalancedPublisherTransportFactory {
+	return &FBalancedPublisherTransportFactory{
+		factories: factories,
+		pool:      newFBrokerPool(len(factories)),
+	}
+}
+
+// WithRetryInterval sets how long a failed endpoint is passed over before it
+// is tried again. The default is 30 seconds.
+func (f *FBalancedPublisherTransportFactory) WithRetryInterval(interval time.Duration) *FBalancedPublisherTransportFactory {
+	f.pool.retryInterval = interval
+	return f
+}
+
+// GetTransport returns a new balanced FPublisherTransport.
+func (f *FBalancedPublisherTransportFactory) GetTransport() FPublisherTransport {
+	transports := make([]FPublisherTransport, len(f.factories))
+	for i, factory := range f.factories {
+		transports[i] = factory.GetTransport()
+	}
+	return &fBalancedPublisherTransport{transports: transports, pool: f.pool}
+}
+
+// fBalancedPublisherTransport is an FPublisherTransport which publishes to
+// one of several underlying transports.
+type fBalancedPublisherTransport struct {
+	transports []FPublisherTransport
+	pool       *fBrokerPool
+}
+
+// Open opens every endpoint. An error is returned only if no endpoint could
+// be opened.
+func (b *fBalancedPublisherTransport) Open() error {
+	var lastErr error
+	opened := false
+	for i, transport := range b.transports {
+		if transport.IsOpen() {
+			opened = true
+			continue
+		}
+		if err := transport.Open(); err != nil {
+			logger().Warnf("frugal: failed to open broker endpoint %d: %s", i, err)
+			b.pool.markFailed(i)
+			lastErr = err
+			continue
+		}
+		opened = true
+	}
+	if !opened {
+		if lastErr == nil {
+			lastErr = thrift.NewTTransportException(TRANSPORT_EXCEPTION_NOT_OPEN,
+				"frugal: no broker endpoints configured")
+		}
+		return lastErr
+	}
+	return nil
+}
+
+// Close closes every endpoint, returning the first error encountered.
+func (b *fBalancedPublisherTransport) Close() error {
+	var firstErr error
+	for _, transport := range b.transports {
+		if !transport.IsOpen() {
+			continue
+		}
+		if err := transport.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// IsOpen returns true if any endpoint is open.
+func (b *fBalancedPublisherTransport) IsOpen() bool {
+	for _, transport := range b.transports {
+		if transport.IsOpen() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPublishSizeLimit returns the smallest publish size limit of the
+// endpoints so that a payload can be published to any of them.
+func (b *fBalancedPublisherTransport) GetPublishSizeLimit() uint {
+	var limit uint
+	for _, transport := range b.transports {
+		if l := transport.GetPublishSizeLimit(); l > 0 && (limit == 0 || l < limit) {
+			limit = l
+		}
+	}
+	return limit
+}
+
+// Publish sends the payload to the next healthy endpoint, failing over to
+// the remaining endpoints if the publish fails.
+func (b *fBalancedPublisherTransport) Publish(topic string, data []byte) error {
+	return b.publish(func(transport FPublisherTransport) error {
+		return transport.Publish(topic, data)
+	})
+}
+
+// PublishConfirmed sends the payload to the next healthy endpoint like
+// Publish, waiting for its broker to acknowledge it. An error is returned if
+// any endpoint doesn't support confirmation.
+func (b *fBalancedPublisherTransport) PublishConfirmed(topic string, data []byte) (string, error) {
+	if !b.confirmsPublishes() {
+		return "", newConfirmationNotSupportedError()
+	}
+	var messageID string
+	err := b.publish(func(transport FPublisherTransport) error {
+		id, err := publishConfirmed(transport, topic, data)
+		messageID = id
+		return err
+	})
+	return messageID, err
+}
+
+func (b *fBalancedPublisherTransport) confirmsPublishes() bool {
+	for _, transport := range b.transports {
+		if !confirmsPublishes(transport) {
+			return false
+		}
+	}
+	return len(b.transports) > 0
+}
+
+// publish calls publish with the next healthy endpoint, failing over to the
+// remaining endpoints if it fails.
+func (b *fBalancedPublisherTransport) publish(publish func(FPublisherTransport) error) error {
+	var lastErr error = thrift.NewTTransportException(TRANSPORT_EXCEPTION_NOT_OPEN,
+		"frugal: no open broker endpoints")
+	for _, idx := range b.pool.order() {
+		transport := b.transports[idx]
+		if !transport.IsOpen() {
+			continue
+		}
+		if err := publish(transport); err != nil {
+			if IsErrTooLarge(err) {
+				return err
+			}
+			logger().Warnf("frugal: publish to broker endpoint %d failed, failing over: %s", idx, err)
+			b.pool.markFailed(idx)
+			lastErr = err
+			continue
+		}
+		b.pool.markHealthy(idx)
+		return nil
+	}
+	return lastErr
+}
+
+// FBalancedSubscriberTransportFactory produces FSubscriberTransports which
+// distribute subscriptions across multiple broker endpoints, each represented
+// by an FSubscriberTransportFactory. Each subscription is placed on the next
+// endpoint round-robin and fails over to the remaining endpoints if
+// subscribing fails. Failed endpoints are passed over until the retry
+// interval elapses. Failover only happens at subscribe time: a subscription
+// isn't moved if its endpoint fails afterwards. Once it's no longer
+// subscribed, Subscribe can be called again to fail over.
+type FBalancedSubscriberTransportFactory struct {
+	factories []FSubscriberTransportFactory
+	pool      *fBrokerPool
+}
+
+// NewFBalancedSubscriberTransportFactory creates a new
+// FBalancedSubscriberTransportFactory which balances across the given broker
+// endpoints.
+func NewFBalancedSubscriberTransportFactory(factories ...FSubscriberTransportFactory) *FBalancedSubscriberTransportFactory {
+	return &FBalancedSubscriberTransportFactory{
+		factories: factories,
+		pool:      newFBrokerPool(len(factories)),
+	}
+}
+
+// WithRetryInterval sets how long a failed endpoint is passed over before it
+// is tried again. The default is 30 seconds.
+func (f *FBalancedSubscriberTransportFactory) WithRetryInterval(interval time.Duration) *FBalancedSubscriberTransportFactory {
+	f.pool.retryInterval = interval
+	return f
+}
+
+// GetTransport returns a new balanced FSubscriberTransport.
+func (f *FBalancedSubscriberTransportFactory) GetTransport() FSubscriberTransport {
+	return &fBalancedSubscriberTransport{factories: f.factories, pool: f.pool}
+}
+
+// fBalancedSubscriberTransport is an FSubscriberTransport which subscribes
+// on one of several broker endpoints. It fails over only when subscribing,
+// so if the active endpoint's subscription drops, it stays unsubscribed
+// until Subscribe is called again, which passes over the dropped endpoint.
+type fBalancedSubscriberTransport struct {
+	mu        sync.Mutex
+	factories []FSubscriberTransportFactory
+	pool      *fBrokerPool
+	active    FSubscriberTransport
+	activeIdx int
+}
+
+// Subscribe subscribes to the topic on the next healthy endpoint, failing
+// over to the remaining endpoints if subscribing fails. If the previous
+// subscription's endpoint dropped it, that endpoint is marked as failed.
+func (b *fBalancedSubscriberTransport) Subscribe(topic string, callback FAsyncCallback) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.active != nil {
+		if b.active.IsSubscribed() {
+			return thrift.NewTTransportException(TRANSPORT_EXCEPTION_ALREADY_OPEN,
+				"frugal: balanced subscriber already subscribed")
+		}
+		logger().Warnf("frugal: subscription on broker endpoint %d dropped, failing over", b.activeIdx)
+		b.pool.markFailed(b.activeIdx)
+		b.active = nil
+	}
+
+	var lastErr error = thrift.NewTTransportException(TRANSPORT_EXCEPTION_NOT_OPEN,
+		"frugal: no broker endpoints configured")
+	for _, idx := range b.pool.order() {
+		transport := b.factories[idx].GetTransport()
+		if err := transport.Subscribe(topic, callback); err != nil {
+			logger().Warnf("frugal: subscribe on broker endpoint %d failed, failing over: %s", idx, err)
+			b.pool.markFailed(idx)
+			lastErr = err
+			continue
+		}
+		b.pool.markHealthy(idx)
+		b.active = transport
+		b.activeIdx = idx
+		return nil
+	}
+	return lastErr
+}
+
+// Unsubscribe unsubscribes from the endpoint the topic is subscribed on.
+func (b *fBalancedSubscriberTransport) Unsubscribe() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.active == nil {
+		return nil
+	}
+	if err := b.active.Unsubscribe(); err != nil {
+		return err
+	}
+	b.active = nil
+	return nil
+}
+
+// Remove unsubscribes and removes durably stored information on the broker
+// the topic is subscribed on, if applicable.
+func (b *fBalancedSubscriberTransport) Remove() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.active == nil {
+		return nil
+	}
+	var err error
+	if r, ok := b.active.(remover); ok {
+		err = r.Remove()
+	} else {
+		err = b.active.Unsubscribe()
+	}
+	if err != nil {
+		return err
+	}
+	b.active = nil
+	return nil
+}
+
+// IsSubscribed returns true if the transport is subscribed on an endpoint.
+func (b *fBalancedSubscriberTransport) IsSubscribed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.active != nil && b.active.IsSubscribed()
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePublisherTransport is an FPublisherTransport which records published
+// topics.
+type fakePublisherTransport struct {
+	open       bool
+	openErr    error
+	publishErr error
+	sizeLimit  uint
+	published  []string
+}
+
+func (f *fakePublisherTransport) Open() error {
+	if f.openErr != nil {
+		return f.openErr
+	}
+	f.open = true
+	return nil
+}
+
+func (f *fakePublisherTransport) Close() error {
+	f.open = false
+	return nil
+}
+
+func (f *fakePublisherTransport) IsOpen() bool {
+	return f.open
+}
+
+func (f *fakePublisherTransport) GetPublishSizeLimit() uint {
+	return f.sizeLimit
+}
+
+func (f *fakePublisherTransport) Publish(topic string, data []byte) error {
+	if f.publishErr != nil {
+		return f.publishErr
+	}
+	f.published = append(f.published, topic)
+	return nil
+}
+
+func newBalancedPublisherTestFactory(transports ...FPublisherTransport) *FBalancedPublisherTransportFactory {
+	factories := make([]FPublisherTransportFactory, len(transports))
+	for i, transport := range transports {
+		factory := new(mockFPublisherTransportFactory)
+		factory.On("GetTransport").Return(transport)
+		factories[i] = factory
+	}
+	return NewFBalancedPublisherTransportFactory(factories...)
+}
+
+// Ensures publishes are distributed round-robin across endpoints.
+func TestBalancedPublisherRoundRobin(t *testing.T) {
+	first := &fakePublisherTransport{sizeLimit: 100}
+	second := &fakePublisherTransport{sizeLimit: 50}
+	transport := newBalancedPublisherTestFactory(first, second).GetTransport()
+
+	assert.Nil(t, transport.Open())
+	assert.True(t, transport.IsOpen())
+	assert.Equal(t, uint(50), transport.GetPublishSizeLimit())
+	for i := 0; i < 4; i++ {
+		assert.Nil(t, transport.Publish("foo", []byte("bar")))
+	}
+	assert.Len(t, first.published, 2)
+	assert.Len(t, second.published, 2)
+
+	assert.Nil(t, transport.Close())
+	assert.False(t, transport.IsOpen())
+}
+
+// Ensures publishes fail over to healthy endpoints and failed endpoints are
+// retried once the retry interval elapses.
+func TestBalancedPublisherFailover(t *testing.T) {
+	first := &fakePublisherTransport{publishErr: errors.New("broker down")}
+	second := &fakePublisherTransport{}
+	transport := newBalancedPublisherTestFactory(first, second).
+		WithRetryInterval(20 * time.Millisecond).GetTransport()
+
+	assert.Nil(t, transport.Open())
+	for i := 0; i < 4; i++ {
+		assert.Nil(t, transport.Publish("foo", []byte("bar")))
+	}
+	assert.Len(t, second.published, 4)
+
+	first.publishErr = nil
+	time.Sleep(30 * time.Millisecond)
+	for i := 0; i < 4; i++ {
+		assert.Nil(t, transport.Publish("foo", []byte("bar")))
+	}
+	assert.Len(t, first.published, 2)
+}
+
+// Ensures Publish returns the last error if every endpoint fails.
+func TestBalancedPublisherAllFailed(t *testing.T) {
+	err := errors.New("broker down")
+	first := &fakePublisherTransport{publishErr: err}
+	second := &fakePublisherTransport{publishErr: err}
+	transport := newBalancedPublisherTestFactory(first, second).GetTransport()
+
+	assert.Nil(t, transport.Open())
+	assert.Equal(t, err, transport.Publish("foo", []byte("bar")))
+}
+
+// Ensures Open only returns an error if no endpoint could be opened.
+func TestBalancedPublisherOpen(t *testing.T) {
+	err := errors.New("broker down")
+	first := &fakePublisherTransport{openErr: err}
+	second := &fakePublisherTransport{}
+	transport := newBalancedPublisherTestFactory(first, second).GetTransport()
+	assert.Nil(t, transport.Open())
+	assert.Nil(t, transport.Publish("foo", []byte("bar")))
+	assert.Len(t, second.published, 1)
+
+	transport = newBalancedPublisherTestFactory(first).GetTransport()
+	assert.Equal(t, err, transport.Open())
+
+	transport = newBalancedPublisherTestFactory().GetTransport()
+	assert.Equal(t, "frugal: no broker endpoints configured", transport.Open().Error())
+}
+
+// Ensures subscriptions fail over to healthy endpoints.
+func TestBalancedSubscriberFailover(t *testing.T) {
+	first := &capturingSubscriberTransport{subscribeErr: errors.New("broker down")}
+	second := &capturingSubscriberTransport{}
+	firstFactory := new(mockFSubscriberTransportFactory)
+	firstFactory.On("GetTransport").Return(first)
+	secondFactory := new(mockFSubscriberTransportFactory)
+	secondFactory.On("GetTransport").Return(second)
+	factory := NewFBalancedSubscriberTransportFactory(firstFactory, secondFactory)
+
+	transport := factory.GetTransport()
+	callback := func(thrift.TTransport) error { return nil }
+	assert.Nil(t, transport.Subscribe("foo", callback))
+	assert.True(t, transport.IsSubscribed())
+	assert.True(t, second.IsSubscribed())
+	assert.Equal(t, "foo", second.topic)
+
+	err := transport.Subscribe("foo", callback)
+	assert.Equal(t, TRANSPORT_EXCEPTION_ALREADY_OPEN, err.(thrift.TTransportException).TypeId())
+
+	assert.Nil(t, transport.Unsubscribe())
+	assert.False(t, transport.IsSubscribed())
+}
+
+// Ensures a subscription isn't moved when its endpoint drops it, but
+// subscribing again fails over to another endpoint.
+func TestBalancedSubscriberResubscribeAfterDrop(t *testing.T) {
+	first := &capturingSubscriberTransport{}
+	second := &capturingSubscriberTransport{}
+	firstFactory := new(mockFSubscriberTransportFactory)
+	firstFactory.On("GetTransport").Return(first)
+	secondFactory := new(mockFSubscriberTransportFactory)
+	secondFactory.On("GetTransport").Return(second)
+	factory := NewFBalancedSubscriberTransportFactory(firstFactory, secondFactory)
+
+	transport := factory.GetTransport()
+	callback := func(thrift.TTransport) error { return nil }
+	assert.Nil(t, transport.Subscribe("foo", callback))
+	assert.True(t, first.IsSubscribed())
+
+	assert.Nil(t, first.Unsubscribe())
+	assert.False(t, transport.IsSubscribed())
+	assert.False(t, second.IsSubscribed())
+
+	// Rotate the pool back to the first endpoint, which is passed over since
+	// it dropped the subscription.
+	factory.pool.order()
+	assert.Nil(t, transport.Subscribe("foo", callback))
+	assert.True(t, second.IsSubscribed())
+	assert.False(t, first.IsSubscribed())
+}
+
+// Ensures NewFBalancedScopeProvider balances across the given endpoints.
+func TestBalancedScopeProvider(t *testing.T) {
+	pub := &fakePublisherTransport{}
+	pubFactory := new(mockFPublisherTransportFactory)
+	pubFactory.On("GetTransport").Return(pub)
+	subFactory := new(mockFSubscriberTransportFactory)
+	protoFactory := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	provider := NewFBalancedScopeProvider([]FPublisherTransportFactory{pubFactory},
+		[]FSubscriberTransportFactory{subFactory}, protoFactory)
+
+	transport, _ := provider.NewPublisher()
+	assert.Nil(t, transport.Open())
+	assert.Nil(t, transport.Publish("foo", []byte("bar")))
+	assert.Equal(t, []string{"foo"}, pub.published)
+}
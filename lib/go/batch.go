@@ -0,0 +1,159 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"fmt"
+	"sync"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// FBatchMessage is a scope message staged in an FPublishBatch.
+type FBatchMessage struct {
+	Topic string
+	Data  []byte
+}
+
+// FTransactionalPublisherTransport is an FPublisherTransport backed by a
+// broker which supports transactions, such as Kafka, so a batch of messages
+// can be published atomically.
+type FTransactionalPublisherTransport interface {
+	FPublisherTransport
+
+	// PublishBatch publishes the given messages in a single transaction, so
+	// either all of them are published or none are. Implementations should
+	// be threadsafe.
+	PublishBatch([]*FBatchMessage) error
+}
+
+// FPublishBatch stages the messages published with its transports, across
+// any number of operations and scopes, until Flush publishes them together
+// with the transport it was created with. It is safe for concurrent use.
+type FPublishBatch struct {
+	mu        sync.Mutex
+	transport FPublisherTransport
+	messages  []*FBatchMessage
+}
+
+// NewFPublishBatch creates an FPublishBatch which publishes its messages with
+// the given transport when it's flushed. Publishers stage messages in it when
+// created with a scope provider returned by FScopeProvider.WithPublishBatch.
+func NewFPublishBatch(transport FPublisherTransport) *FPublishBatch {
+	return &FPublishBatch{transport: transport}
+}
+
+// GetTransport returns an FPublisherTransport which stages the messages
+// published with it in the batch.
+func (b *FPublishBatch) GetTransport() FPublisherTransport {
+	return &fBatchPublisherTransport{batch: b}
+}
+
+// Len returns the number of messages staged in the batch.
+func (b *FPublishBatch) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.messages)
+}
+
+// Discard drops the messages staged in the batch without publishing them.
+func (b *FPublishBatch) Discard() {
+	b.mu.Lock()
+	b.messages = nil
+	b.mu.Unlock()
+}
+
+// Flush publishes the messages staged in the batch and clears it. If the
+// transport implements FTransactionalPublisherTransport, the messages are
+// published in a single transaction. Otherwise, every message is checked
+// against the transport's size limit before any is published, and they're
+// published in order, stopping at the first failure. The messages which
+// weren't published stay in the batch, so a failed flush can be retried.
+func (b *FPublishBatch) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.messages) == 0 {
+		return nil
+	}
+
+	if transactional, ok := b.transport.(FTransactionalPublisherTransport); ok {
+		if err := transactional.PublishBatch(b.messages); err != nil {
+			return err
+		}
+		b.messages = nil
+		return nil
+	}
+
+	if !b.transport.IsOpen() {
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_NOT_OPEN,
+			"frugal: publish batch transport not open")
+	}
+	limit := b.transport.GetPublishSizeLimit()
+	for _, message := range b.messages {
+		if limit > 0 && uint(len(message.Data)) > limit {
+			return thrift.NewTTransportException(TRANSPORT_EXCEPTION_REQUEST_TOO_LARGE,
+				fmt.Sprintf("Message to topic %s exceeds %d bytes, was %d bytes", message.Topic, limit, len(message.Data)))
+		}
+	}
+	for len(b.messages) > 0 {
+		message := b.messages[0]
+		if err := b.transport.Publish(message.Topic, message.Data); err != nil {
+			return err
+		}
+		b.messages = b.messages[1:]
+	}
+	b.messages = nil
+	return nil
+}
+
+// stage adds a copy of the message to the batch, since the payload's buffer
+// may be reused once the publish returns.
+func (b *FPublishBatch) stage(topic string, data []byte) {
+	b.mu.Lock()
+	b.messages = append(b.messages, &FBatchMessage{Topic: topic, Data: append([]byte(nil), data...)})
+	b.mu.Unlock()
+}
+
+// fBatchPublisherTransport implements FPublisherTransport by staging
+// messages in an FPublishBatch. It has no connection, so it's always open.
+type fBatchPublisherTransport struct {
+	batch *FPublishBatch
+}
+
+// Open initializes the transport.
+func (f *fBatchPublisherTransport) Open() error {
+	return nil
+}
+
+// Close closes the transport.
+func (f *fBatchPublisherTransport) Close() error {
+	return nil
+}
+
+// IsOpen returns true since the transport is always open.
+func (f *fBatchPublisherTransport) IsOpen() bool {
+	return true
+}
+
+// GetPublishSizeLimit returns the size limit of the batch's transport, so
+// messages which are too large fail when they're staged.
+func (f *fBatchPublisherTransport) GetPublishSizeLimit() uint {
+	return f.batch.transport.GetPublishSizeLimit()
+}
+
+// Publish stages the given payload in the batch.
+func (f *fBatchPublisherTransport) Publish(topic string, data []byte) error {
+	f.batch.stage(topic, data)
+	return nil
+}
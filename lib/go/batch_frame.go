@@ -0,0 +1,89 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// batchFrameMarker is the first byte of a batch frame, distinguishing it
+// from the frame of a single message, which begins with the version of its
+// request header.
+const batchFrameMarker byte = 0x80
+
+// PublishBatchFrame publishes the given frames, each the framed bytes of a
+// message, in a single frame on the topic, so they're sent with one
+// transport flush. The batch frame is the marker byte followed by the
+// frames, which are already prefixed with their length. Nothing is published
+// if there are no frames. This is to be used by generated code and should
+// not be called directly.
+func PublishBatchFrame(ctx FContext, transport FPublisherTransport, topic string, frames [][]byte) error {
+	if len(frames) == 0 {
+		return nil
+	}
+	size := 1
+	for _, frame := range frames {
+		size += len(frame)
+	}
+	data := make([]byte, 4, 4+size)
+	binary.BigEndian.PutUint32(data, uint32(size))
+	data = append(data, batchFrameMarker)
+	for _, frame := range frames {
+		data = append(data, frame...)
+	}
+	if limit := transport.GetPublishSizeLimit(); limit > 0 && uint(len(data)) > limit {
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_REQUEST_TOO_LARGE,
+			fmt.Sprintf("Batch of %d messages to topic %s exceeds %d bytes, was %d bytes",
+				len(frames), topic, limit, len(data)))
+	}
+	return Publish(ctx, transport, topic, data)
+}
+
+// NewFBatchFrameCallback returns an FAsyncCallback which calls the given
+// callback with each message of the batch frames published with
+// PublishBatchFrame, and with other frames as they are. Every message of a
+// batch is processed, and the first error is returned. This is to be used by
+// generated code and should not be called directly.
+func NewFBatchFrameCallback(callback FAsyncCallback) FAsyncCallback {
+	return func(transport thrift.TTransport) error {
+		data, err := ioutil.ReadAll(transport)
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 || data[0] != batchFrameMarker {
+			return callback(&thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(data)})
+		}
+
+		var callbackErr error
+		for data = data[1:]; len(data) > 0; {
+			if len(data) < 4 || uint64(len(data)-4) < uint64(binary.BigEndian.Uint32(data)) {
+				return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA,
+					errors.New("frugal: invalid batch frame"))
+			}
+			size := binary.BigEndian.Uint32(data)
+			frame := &thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(data[4 : 4+size])}
+			if err := callback(frame); err != nil && callbackErr == nil {
+				callbackErr = err
+			}
+			data = data[4+size:]
+		}
+		return callbackErr
+	}
+}
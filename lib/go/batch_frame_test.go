@@ -0,0 +1,116 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingPublisherTransport is a fakePublisherTransport which records the
+// data published with it.
+type recordingPublisherTransport struct {
+	fakePublisherTransport
+	data [][]byte
+}
+
+func (r *recordingPublisherTransport) Publish(topic string, data []byte) error {
+	r.data = append(r.data, data)
+	return r.fakePublisherTransport.Publish(topic, data)
+}
+
+// frameOf returns the given payload prefixed with its length.
+func frameOf(payload string) []byte {
+	buffer := NewTMemoryOutputBuffer(0)
+	buffer.Write([]byte(payload))
+	return buffer.Bytes()
+}
+
+// deliverFrame calls the callback with the published data without its frame
+// size, the way subscriber transports do, returning the payloads received.
+func deliverFrame(t *testing.T, data []byte, errs ...error) ([]string, error) {
+	payloads := []string{}
+	callback := NewFBatchFrameCallback(func(transport thrift.TTransport) error {
+		payload, err := ioutil.ReadAll(transport)
+		assert.Nil(t, err)
+		payloads = append(payloads, string(payload))
+		if len(errs) > 0 {
+			err, errs = errs[0], errs[1:]
+			return err
+		}
+		return nil
+	})
+	err := callback(&thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(data[4:])})
+	return payloads, err
+}
+
+// Ensures the messages of a batch frame are published together and passed
+// to the subscriber's callback one by one.
+func TestPublishBatchFrame(t *testing.T) {
+	transport := &recordingPublisherTransport{}
+	frames := [][]byte{frameOf("foo"), frameOf(""), frameOf("bar")}
+	assert.Nil(t, PublishBatchFrame(NewFContext(""), transport, "topic", frames))
+	assert.Equal(t, []string{"topic"}, transport.published)
+
+	payloads, err := deliverFrame(t, transport.data[0])
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"foo", "", "bar"}, payloads)
+}
+
+// Ensures every message of a batch frame is processed and the first error is
+// returned.
+func TestBatchFrameCallbackError(t *testing.T) {
+	transport := &recordingPublisherTransport{}
+	frames := [][]byte{frameOf("foo"), frameOf("bar"), frameOf("baz")}
+	assert.Nil(t, PublishBatchFrame(NewFContext(""), transport, "topic", frames))
+
+	err := errors.New("error")
+	payloads, callbackErr := deliverFrame(t, transport.data[0], nil, err, errors.New("other"))
+	assert.Equal(t, err, callbackErr)
+	assert.Equal(t, []string{"foo", "bar", "baz"}, payloads)
+}
+
+// Ensures frames which aren't batches are passed to the callback as they
+// are.
+func TestBatchFrameCallbackSingleMessage(t *testing.T) {
+	payloads, err := deliverFrame(t, frameOf("\x00foo"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"\x00foo"}, payloads)
+}
+
+// Ensures truncated batch frames are rejected.
+func TestBatchFrameCallbackInvalid(t *testing.T) {
+	transport := &recordingPublisherTransport{}
+	assert.Nil(t, PublishBatchFrame(NewFContext(""), transport, "topic", [][]byte{frameOf("foo")}))
+
+	_, err := deliverFrame(t, transport.data[0][:len(transport.data[0])-1])
+	assert.Equal(t, thrift.INVALID_DATA, err.(thrift.TProtocolException).TypeId())
+}
+
+// Ensures batch frames exceeding the transport's size limit aren't
+// published, and empty batches publish nothing.
+func TestPublishBatchFrameSizeLimit(t *testing.T) {
+	transport := &recordingPublisherTransport{}
+	transport.sizeLimit = 10
+	err := PublishBatchFrame(NewFContext(""), transport, "topic", [][]byte{frameOf("foo"), frameOf("bar")})
+	assert.Equal(t, TRANSPORT_EXCEPTION_REQUEST_TOO_LARGE, err.(thrift.TTransportException).TypeId())
+
+	assert.Nil(t, PublishBatchFrame(NewFContext(""), transport, "topic", nil))
+	assert.Empty(t, transport.published)
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"errors"
+	"testing"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// transactionalPublisherTransport is a fakePublisherTransport which records
+// the batches published with it.
+type transactionalPublisherTransport struct {
+	fakePublisherTransport
+	batches  [][]*FBatchMessage
+	batchErr error
+}
+
+func (t *transactionalPublisherTransport) PublishBatch(messages []*FBatchMessage) error {
+	if t.batchErr != nil {
+		return t.batchErr
+	}
+	t.batches = append(t.batches, messages)
+	return nil
+}
+
+// Ensures batch transports stage copies of messages until the batch is
+// flushed.
+func TestPublishBatchFlush(t *testing.T) {
+	transport := &fakePublisherTransport{open: true}
+	batch := NewFPublishBatch(transport)
+	staging := batch.GetTransport()
+	assert.True(t, staging.IsOpen())
+
+	data := []byte{1}
+	assert.Nil(t, staging.Publish("a", data))
+	data[0] = 2
+	assert.Nil(t, batch.GetTransport().Publish("b", data))
+	assert.Equal(t, 2, batch.Len())
+	assert.Empty(t, transport.published)
+	assert.Equal(t, []byte{1}, batch.messages[0].Data)
+
+	assert.Nil(t, batch.Flush())
+	assert.Equal(t, []string{"a", "b"}, transport.published)
+	assert.Equal(t, 0, batch.Len())
+}
+
+// Ensures Flush publishes the batch in one transaction if the transport
+// supports it, keeping the messages if the transaction fails.
+func TestPublishBatchFlushTransactional(t *testing.T) {
+	transport := &transactionalPublisherTransport{batchErr: errors.New("error")}
+	batch := NewFPublishBatch(transport)
+	assert.Nil(t, batch.GetTransport().Publish("a", []byte{1}))
+	assert.Nil(t, batch.GetTransport().Publish("b", []byte{2}))
+
+	assert.Equal(t, transport.batchErr, batch.Flush())
+	assert.Equal(t, 2, batch.Len())
+
+	transport.batchErr = nil
+	assert.Nil(t, batch.Flush())
+	assert.Equal(t, [][]*FBatchMessage{{{"a", []byte{1}}, {"b", []byte{2}}}}, transport.batches)
+	assert.Empty(t, transport.published)
+	assert.Equal(t, 0, batch.Len())
+}
+
+// Ensures Flush publishes nothing if any message exceeds the transport's size
+// limit.
+func TestPublishBatchFlushSizeLimit(t *testing.T) {
+	transport := &fakePublisherTransport{open: true}
+	batch := NewFPublishBatch(transport)
+	assert.Nil(t, batch.GetTransport().Publish("a", []byte{1}))
+	assert.Nil(t, batch.GetTransport().Publish("b", []byte{1, 2, 3}))
+	transport.sizeLimit = 2
+
+	err := batch.Flush()
+	assert.Equal(t, TRANSPORT_EXCEPTION_REQUEST_TOO_LARGE, err.(thrift.TTransportException).TypeId())
+	assert.Empty(t, transport.published)
+	assert.Equal(t, 2, batch.Len())
+	assert.Equal(t, uint(2), batch.GetTransport().GetPublishSizeLimit())
+}
+
+// Ensures Flush keeps the messages which weren't published so the flush can
+// be retried.
+func TestPublishBatchFlushError(t *testing.T) {
+	transport := &fakePublisherTransport{}
+	batch := NewFPublishBatch(transport)
+	assert.Nil(t, batch.GetTransport().Publish("a", []byte{1}))
+
+	err := batch.Flush()
+	assert.Equal(t, TRANSPORT_EXCEPTION_NOT_OPEN, err.(thrift.TTransportException).TypeId())
+	assert.Equal(t, 1, batch.Len())
+
+	transport.open = true
+	transport.publishErr = errors.New("error")
+	assert.Equal(t, transport.publishErr, batch.Flush())
+	assert.Equal(t, 1, batch.Len())
+
+	transport.publishErr = nil
+	assert.Nil(t, batch.Flush())
+	assert.Equal(t, []string{"a"}, transport.published)
+}
+
+// Ensures Discard drops the staged messages.
+func TestPublishBatchDiscard(t *testing.T) {
+	transport := &fakePublisherTransport{open: true}
+	batch := NewFPublishBatch(transport)
+	assert.Nil(t, batch.GetTransport().Publish("a", []byte{1}))
+
+	batch.Discard()
+	assert.Equal(t, 0, batch.Len())
+	assert.Nil(t, batch.Flush())
+	assert.Empty(t, transport.published)
+}
+
+// Ensures providers returned by WithPublishBatch stage publishes in the batch
+// and keep the provider's protocol factory and middleware.
+func TestFScopeProviderWithPublishBatch(t *testing.T) {
+	protocolFactory := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	middleware := func(next InvocationHandler) InvocationHandler { return next }
+	provider := NewFScopeProvider(nil, nil, protocolFactory, middleware)
+	batch := NewFPublishBatch(&fakePublisherTransport{})
+
+	batched := provider.WithPublishBatch(batch)
+	transport, batchedFactory := batched.NewPublisher()
+	assert.Nil(t, transport.Publish("a", []byte{1}))
+	assert.Equal(t, 1, batch.Len())
+	assert.Equal(t, protocolFactory, batchedFactory)
+	assert.Len(t, batched.GetMiddleware(), 1)
+	assert.Nil(t, provider.publisherTransportFactory)
+}
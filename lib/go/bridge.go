@@ -0,0 +1,185 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// bridgeEventBuffer is the number of events buffered for each bridge
+// subscriber before the subscription blocks on the HTTP client.
+const bridgeEventBuffer = 64
+
+// FBridgePublishFunc publishes an event of a scope operation received by an
+// FScopeBridge. Decode unmarshals the JSON request body into the given
+// pointer. Variables are the values of the scope's prefix variables.
+type FBridgePublishFunc func(ctx FContext, variables []string, decode func(interface{}) error) error
+
+// FBridgeSubscribeFunc subscribes to a scope operation for an FScopeBridge,
+// calling the handler with each event received. Variables are the values of
+// the scope's prefix variables.
+type FBridgeSubscribeFunc func(variables []string, handler func(FContext, interface{})) (*FSubscription, error)
+
+// FScopeBridge is an http.Handler exposing scope operations over HTTP so
+// services without a Frugal runtime can publish and subscribe to events. An
+// event is published by POSTing it as JSON to /<scope>/<operation>, and a GET
+// of the same path subscribes to the operation, streaming events as
+// Server-Sent Events until the client disconnects. Prefix variables are given
+// as query parameters.
+type FScopeBridge struct {
+	mu         sync.RWMutex
+	operations map[string]*fBridgeOperation
+}
+
+type fBridgeOperation struct {
+	name      string
+	variables []string
+	publish   FBridgePublishFunc
+	subscribe FBridgeSubscribeFunc
+}
+
+// NewFScopeBridge creates a new FScopeBridge without any operations.
+func NewFScopeBridge() *FScopeBridge {
+	return &FScopeBridge{operations: make(map[string]*fBridgeOperation)}
+}
+
+// HandlePublish registers the function publishing events POSTed for the
+// scope operation with the given prefix variables.
+func (b *FScopeBridge) HandlePublish(scope, op string, variables []string, publish FBridgePublishFunc) {
+	b.operation(scope, op, variables).publish = publish
+}
+
+// HandleSubscribe registers the function subscribing to the scope operation
+// with the given prefix variables.
+func (b *FScopeBridge) HandleSubscribe(scope, op string, variables []string, subscribe FBridgeSubscribeFunc) {
+	b.operation(scope, op, variables).subscribe = subscribe
+}
+
+func (b *FScopeBridge) operation(scope, op string, variables []string) *fBridgeOperation {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	path := "/" + scope + "/" + op
+	operation, ok := b.operations[path]
+	if !ok {
+		operation = &fBridgeOperation{name: op}
+		b.operations[path] = operation
+	}
+	operation.variables = variables
+	return operation
+}
+
+// ServeHTTP publishes or subscribes to the scope operation of the request
+// path.
+func (b *FScopeBridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.RLock()
+	operation, ok := b.operations[strings.TrimSuffix(r.URL.Path, "/")]
+	b.mu.RUnlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	variables := make([]string, len(operation.variables))
+	for i, variable := range operation.variables {
+		variables[i] = r.URL.Query().Get(variable)
+		if variables[i] == "" {
+			http.Error(w, fmt.Sprintf("Missing prefix variable %s", variable), http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodPost && operation.publish != nil:
+		b.servePublish(w, r, operation, variables)
+	case r.Method == http.MethodGet && operation.subscribe != nil:
+		b.serveSubscribe(w, r, operation, variables)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (b *FScopeBridge) servePublish(w http.ResponseWriter, r *http.Request, operation *fBridgeOperation, variables []string) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var decodeErr error
+	decode := func(v interface{}) error {
+		decodeErr = json.Unmarshal(body, v)
+		return decodeErr
+	}
+	if err := operation.publish(NewFContext(""), variables, decode); err != nil {
+		if decodeErr != nil {
+			http.Error(w, fmt.Sprintf("Invalid event: %s", decodeErr), http.StatusBadRequest)
+			return
+		}
+		logger().Errorf("frugal: error publishing bridged %s event: %s", operation.name, err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *FScopeBridge) serveSubscribe(w http.ResponseWriter, r *http.Request, operation *fBridgeOperation, variables []string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	type event struct {
+		id   string
+		data []byte
+	}
+	events := make(chan event, bridgeEventBuffer)
+	done := r.Context().Done()
+	sub, err := operation.subscribe(variables, func(ctx FContext, req interface{}) {
+		data, err := json.Marshal(req)
+		if err != nil {
+			logger().Errorf("frugal: error encoding bridged %s event: %s", operation.name, err)
+			return
+		}
+		select {
+		case events <- event{id: ctx.CorrelationID(), data: data}:
+		case <-done:
+		}
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer sub.Unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case e := <-events:
+			if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.id, operation.name, e.data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-done:
+			return
+		}
+	}
+}
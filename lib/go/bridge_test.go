@@ -0,0 +1,126 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bufio"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+type bridgeTestEvent struct {
+	Message string `json:"message"`
+}
+
+// Ensures the bridge publishes POSTed events with their prefix variables.
+func TestScopeBridgePublish(t *testing.T) {
+	bridge := NewFScopeBridge()
+	var published *bridgeTestEvent
+	var publishedVariables []string
+	bridge.HandlePublish("Events", "Created", []string{"user"},
+		func(ctx FContext, variables []string, decode func(interface{}) error) error {
+			var req *bridgeTestEvent
+			if err := decode(&req); err != nil {
+				return err
+			}
+			published, publishedVariables = req, variables
+			return nil
+		})
+
+	w := httptest.NewRecorder()
+	bridge.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/Events/Created?user=bob",
+		strings.NewReader(`{"message": "hello"}`)))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, &bridgeTestEvent{Message: "hello"}, published)
+	assert.Equal(t, []string{"bob"}, publishedVariables)
+}
+
+// Ensures the bridge rejects requests it can't publish.
+func TestScopeBridgePublishErrors(t *testing.T) {
+	bridge := NewFScopeBridge()
+	bridge.HandlePublish("Events", "Created", []string{"user"},
+		func(ctx FContext, variables []string, decode func(interface{}) error) error {
+			var req *bridgeTestEvent
+			if err := decode(&req); err != nil {
+				return err
+			}
+			return errors.New("publish failed")
+		})
+
+	cases := []struct {
+		method string
+		target string
+		body   string
+		code   int
+	}{
+		{http.MethodPost, "/Events/Other?user=bob", "{}", http.StatusNotFound},
+		{http.MethodPost, "/Events/Created", "{}", http.StatusBadRequest},
+		{http.MethodPost, "/Events/Created?user=bob", "not json", http.StatusBadRequest},
+		{http.MethodPost, "/Events/Created?user=bob", "{}", http.StatusBadGateway},
+		{http.MethodGet, "/Events/Created?user=bob", "", http.StatusMethodNotAllowed},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		bridge.ServeHTTP(w, httptest.NewRequest(c.method, c.target, strings.NewReader(c.body)))
+		assert.Equal(t, c.code, w.Code, c.method+" "+c.target)
+	}
+}
+
+// Ensures the bridge streams subscribed events as Server-Sent Events and
+// unsubscribes when the client disconnects.
+func TestScopeBridgeSubscribe(t *testing.T) {
+	bridge := NewFScopeBridge()
+	transport := &capturingSubscriberTransport{}
+	handlers := make(chan func(FContext, interface{}), 1)
+	bridge.HandleSubscribe("Events", "Created", []string{"user"},
+		func(variables []string, handler func(FContext, interface{})) (*FSubscription, error) {
+			assert.Equal(t, []string{"bob"}, variables)
+			if err := transport.Subscribe("Events.Created", func(thrift.TTransport) error { return nil }); err != nil {
+				return nil, err
+			}
+			handlers <- handler
+			return NewFSubscription("Events.Created", transport), nil
+		})
+	server := httptest.NewServer(bridge)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/Events/Created?user=bob")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	handler := <-handlers
+	handler(NewFContext("cid"), &bridgeTestEvent{Message: "hello"})
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for i := 0; i < 4; i++ {
+		line, err := reader.ReadString('\n')
+		assert.Nil(t, err)
+		lines = append(lines, line)
+	}
+	assert.Equal(t, []string{"id: cid\n", "event: Created\n", "data: {\"message\":\"hello\"}\n", "\n"}, lines)
+
+	resp.Body.Close()
+	for i := 0; i < 100 && transport.IsSubscribed(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.False(t, transport.IsSubscribed())
+}
@@ -0,0 +1,389 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"sync"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// FChaosConfig configures the faults injected by chaos transports. Each rate
+// is the probability, from 0 to 1, of the fault being injected into a
+// message.
+type FChaosConfig struct {
+	// DropRate is the probability a message is silently discarded.
+	DropRate float64
+
+	// DuplicateRate is the probability a message is delivered twice.
+	DuplicateRate float64
+
+	// DelayRate is the probability a message is delayed by a random duration
+	// up to MaxDelay.
+	DelayRate float64
+	MaxDelay  time.Duration
+
+	// ReorderRate is the probability a message is held back and delivered
+	// after the next message.
+	ReorderRate float64
+
+	// DisconnectRate is the probability the transport disconnects instead of
+	// delivering a message. Publisher transports are closed, failing the
+	// publish, and must be reopened. Subscriber transports are unsubscribed
+	// and resubscribe after ReconnectDelay, losing the messages published in
+	// the meantime.
+	DisconnectRate float64
+	ReconnectDelay time.Duration
+
+	// Seed seeds the random faults so a run can be reproduced. The current
+	// time is used if it's zero.
+	Seed int64
+}
+
+// fChaos decides which faults to inject into messages.
+type fChaos struct {
+	config FChaosConfig
+	mu     sync.Mutex
+	rand   *rand.Rand
+}
+
+func newFChaos(config FChaosConfig) *fChaos {
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &fChaos{config: config, rand: rand.New(rand.NewSource(seed))}
+}
+
+// inject returns true with the given probability.
+func (c *fChaos) inject(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rand.Float64() < rate
+}
+
+// delay sleeps for a random duration up to MaxDelay if a delay is injected.
+func (c *fChaos) delay() {
+	if c.config.MaxDelay <= 0 || !c.inject(c.config.DelayRate) {
+		return
+	}
+	c.mu.Lock()
+	delay := time.Duration(c.rand.Int63n(int64(c.config.MaxDelay))) + 1
+	c.mu.Unlock()
+	clock().Sleep(delay)
+}
+
+// FChaosPublisherTransportFactory produces FPublisherTransports which inject
+// faults into the messages published with them, for testing the resilience
+// of services built on scopes. It should not be used in production.
+type FChaosPublisherTransportFactory struct {
+	factory FPublisherTransportFactory
+	chaos   *fChaos
+}
+
+// NewFChaosPublisherTransportFactory creates an
+// FChaosPublisherTransportFactory wrapping the given factory which injects
+// the faults described by the given config.
+func NewFChaosPublisherTransportFactory(factory FPublisherTransportFactory,
+	config FChaosConfig) *FChaosPublisherTransportFactory {
+	return &FChaosPublisherTransportFactory{factory: factory, chaos: newFChaos(config)}
+}
+
+// GetTransport returns a new chaos FPublisherTransport.
+func (f *FChaosPublisherTransportFactory) GetTransport() FPublisherTransport {
+	return &fChaosPublisherTransport{FPublisherTransport: f.factory.GetTransport(), chaos: f.chaos}
+}
+
+// chaosMessage is a message held back by a chaos transport to reorder it.
+type chaosMessage struct {
+	topic string
+	data  []byte
+}
+
+// fChaosPublisherTransport implements FPublisherTransport by injecting faults
+// into the messages published with the wrapped transport.
+type fChaosPublisherTransport struct {
+	FPublisherTransport
+	chaos *fChaos
+	mu    sync.Mutex
+	held  *chaosMessage
+}
+
+// Publish publishes the message with the wrapped transport, unless it's
+// dropped, held back, or the transport disconnects.
+func (c *fChaosPublisherTransport) Publish(topic string, data []byte) error {
+	if c.chaos.inject(c.chaos.config.DisconnectRate) {
+		c.FPublisherTransport.Close()
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_NOT_OPEN,
+			"frugal: chaos transport disconnected")
+	}
+	if c.chaos.inject(c.chaos.config.DropRate) {
+		return nil
+	}
+	c.chaos.delay()
+
+	c.mu.Lock()
+	if c.held == nil && c.chaos.inject(c.chaos.config.ReorderRate) {
+		c.held = &chaosMessage{topic: topic, data: data}
+		c.mu.Unlock()
+		return nil
+	}
+	held := c.held
+	c.held = nil
+	c.mu.Unlock()
+
+	if err := c.publish(topic, data); err != nil {
+		return err
+	}
+	if held != nil {
+		return c.publish(held.topic, held.data)
+	}
+	return nil
+}
+
+// PublishConfirmed publishes the message with the wrapped transport, waiting
+// for the broker to acknowledge it, unless the transport disconnects. Since
+// the broker acknowledges confirmed messages, they are delayed and
+// duplicated but never dropped or held back. A held back message is
+// published after it. An error is returned if the wrapped transport doesn't
+// support confirmation.
+func (c *fChaosPublisherTransport) PublishConfirmed(topic string, data []byte) (string, error) {
+	if !c.confirmsPublishes() {
+		return "", newConfirmationNotSupportedError()
+	}
+	if c.chaos.inject(c.chaos.config.DisconnectRate) {
+		c.FPublisherTransport.Close()
+		return "", thrift.NewTTransportException(TRANSPORT_EXCEPTION_NOT_OPEN,
+			"frugal: chaos transport disconnected")
+	}
+	c.chaos.delay()
+
+	c.mu.Lock()
+	held := c.held
+	c.held = nil
+	c.mu.Unlock()
+
+	messageID, err := publishConfirmed(c.FPublisherTransport, topic, data)
+	if err != nil {
+		return "", err
+	}
+	if c.chaos.inject(c.chaos.config.DuplicateRate) {
+		if _, err := publishConfirmed(c.FPublisherTransport, topic, data); err != nil {
+			return "", err
+		}
+	}
+	if held != nil {
+		if err := c.publish(held.topic, held.data); err != nil {
+			return "", err
+		}
+	}
+	return messageID, nil
+}
+
+func (c *fChaosPublisherTransport) confirmsPublishes() bool {
+	return confirmsPublishes(c.FPublisherTransport)
+}
+
+// Close publishes any held back message and closes the wrapped transport.
+func (c *fChaosPublisherTransport) Close() error {
+	c.mu.Lock()
+	held := c.held
+	c.held = nil
+	c.mu.Unlock()
+	if held != nil {
+		if err := c.publish(held.topic, held.data); err != nil {
+			logger().Warnf("frugal: chaos transport failed to publish held message: %s", err)
+		}
+	}
+	return c.FPublisherTransport.Close()
+}
+
+func (c *fChaosPublisherTransport) publish(topic string, data []byte) error {
+	if err := c.FPublisherTransport.Publish(topic, data); err != nil {
+		return err
+	}
+	if c.chaos.inject(c.chaos.config.DuplicateRate) {
+		return c.FPublisherTransport.Publish(topic, data)
+	}
+	return nil
+}
+
+// FChaosSubscriberTransportFactory produces FSubscriberTransports which inject
+// faults into the messages received by them, for testing the resilience of
+// services built on scopes. It should not be used in production.
+type FChaosSubscriberTransportFactory struct {
+	factory FSubscriberTransportFactory
+	chaos   *fChaos
+}
+
+// NewFChaosSubscriberTransportFactory creates an
+// FChaosSubscriberTransportFactory wrapping the given factory which injects
+// the faults described by the given config.
+func NewFChaosSubscriberTransportFactory(factory FSubscriberTransportFactory,
+	config FChaosConfig) *FChaosSubscriberTransportFactory {
+	return &FChaosSubscriberTransportFactory{factory: factory, chaos: newFChaos(config)}
+}
+
+// GetTransport returns a new chaos FSubscriberTransport.
+func (f *FChaosSubscriberTransportFactory) GetTransport() FSubscriberTransport {
+	return &fChaosSubscriberTransport{FSubscriberTransport: f.factory.GetTransport(), chaos: f.chaos}
+}
+
+// fChaosSubscriberTransport implements FSubscriberTransport by injecting
+// faults into the messages received by the wrapped transport.
+type fChaosSubscriberTransport struct {
+	FSubscriberTransport
+	chaos *fChaos
+
+	// connMu guards the subscription of the wrapped transport, and mu the
+	// callback and held back message.
+	connMu       sync.Mutex
+	topic        string
+	disconnected bool
+	mu           sync.Mutex
+	callback     FAsyncCallback
+	held         []byte
+}
+
+// Subscribe subscribes the wrapped transport to the topic.
+func (c *fChaosSubscriberTransport) Subscribe(topic string, callback FAsyncCallback) error {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	if err := c.FSubscriberTransport.Subscribe(topic, c.receive); err != nil {
+		return err
+	}
+	c.topic = topic
+	c.mu.Lock()
+	c.callback = callback
+	c.mu.Unlock()
+	return nil
+}
+
+// Unsubscribe unsubscribes the wrapped transport, discarding any held back
+// message, and stops it from resubscribing.
+func (c *fChaosSubscriberTransport) Unsubscribe() error {
+	return c.stop(c.FSubscriberTransport.Unsubscribe)
+}
+
+// Remove unsubscribes and removes durably stored information on the broker,
+// if applicable.
+func (c *fChaosSubscriberTransport) Remove() error {
+	if r, ok := c.FSubscriberTransport.(remover); ok {
+		return c.stop(r.Remove)
+	}
+	return c.Unsubscribe()
+}
+
+func (c *fChaosSubscriberTransport) stop(unsubscribe func() error) error {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	c.mu.Lock()
+	c.callback = nil
+	c.held = nil
+	c.mu.Unlock()
+	if c.disconnected {
+		c.disconnected = false
+		return nil
+	}
+	return unsubscribe()
+}
+
+// receive is the FAsyncCallback subscribed on the wrapped transport. It calls
+// the subscribed callback with the received message, unless it's dropped,
+// held back, or the transport disconnects.
+func (c *fChaosSubscriberTransport) receive(transport thrift.TTransport) error {
+	frame, err := ioutil.ReadAll(transport)
+	if err != nil {
+		return thrift.NewTTransportExceptionFromError(err)
+	}
+	if c.chaos.inject(c.chaos.config.DisconnectRate) {
+		go c.disconnect()
+		return nil
+	}
+	if c.chaos.inject(c.chaos.config.DropRate) {
+		return nil
+	}
+	c.chaos.delay()
+
+	c.mu.Lock()
+	callback := c.callback
+	if callback == nil {
+		c.mu.Unlock()
+		return nil
+	}
+	if c.held == nil && c.chaos.inject(c.chaos.config.ReorderRate) {
+		c.held = frame
+		c.mu.Unlock()
+		return nil
+	}
+	held := c.held
+	c.held = nil
+	c.mu.Unlock()
+
+	err = c.deliver(callback, frame)
+	if held != nil {
+		if heldErr := c.deliver(callback, held); err == nil {
+			err = heldErr
+		}
+	}
+	return err
+}
+
+func (c *fChaosSubscriberTransport) deliver(callback FAsyncCallback, frame []byte) error {
+	err := callback(&thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(frame)})
+	if c.chaos.inject(c.chaos.config.DuplicateRate) {
+		if dupErr := callback(&thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(frame)}); err == nil {
+			err = dupErr
+		}
+	}
+	return err
+}
+
+// disconnect unsubscribes the wrapped transport and resubscribes it after the
+// reconnect delay.
+func (c *fChaosSubscriberTransport) disconnect() {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	if c.disconnected || !c.FSubscriberTransport.IsSubscribed() {
+		return
+	}
+	if err := c.FSubscriberTransport.Unsubscribe(); err != nil {
+		logger().Warnf("frugal: chaos transport failed to disconnect from %s: %s", c.topic, err)
+		return
+	}
+	c.disconnected = true
+	go func() {
+		clock().Sleep(c.chaos.config.ReconnectDelay)
+		c.reconnect()
+	}()
+}
+
+func (c *fChaosSubscriberTransport) reconnect() {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	if !c.disconnected {
+		return
+	}
+	c.disconnected = false
+	if err := c.FSubscriberTransport.Subscribe(c.topic, c.receive); err != nil {
+		logger().Errorf("frugal: chaos transport failed to resubscribe to %s: %s", c.topic, err)
+	}
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+func newChaosPublisherTestTransport(transport FPublisherTransport, config FChaosConfig) FPublisherTransport {
+	factory := new(mockFPublisherTransportFactory)
+	factory.On("GetTransport").Return(transport)
+	return NewFChaosPublisherTransportFactory(factory, config).GetTransport()
+}
+
+// chaosCallback returns an FAsyncCallback recording the payloads it's called
+// with, and a function returning them.
+func chaosCallback(t *testing.T) (FAsyncCallback, func() []string) {
+	var (
+		mu       sync.Mutex
+		payloads []string
+	)
+	callback := func(transport thrift.TTransport) error {
+		payload, err := ioutil.ReadAll(transport)
+		assert.Nil(t, err)
+		mu.Lock()
+		payloads = append(payloads, string(payload))
+		mu.Unlock()
+		return nil
+	}
+	return callback, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return payloads
+	}
+}
+
+// Ensures messages are published as they are without faults.
+func TestChaosPublisherNoFaults(t *testing.T) {
+	wrapped := &recordingPublisherTransport{}
+	transport := newChaosPublisherTestTransport(wrapped, FChaosConfig{MaxDelay: time.Hour})
+
+	assert.Nil(t, transport.Open())
+	assert.Nil(t, transport.Publish("foo", []byte("a")))
+	assert.Nil(t, transport.Publish("bar", []byte("b")))
+	assert.Equal(t, []string{"foo", "bar"}, wrapped.published)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, wrapped.data)
+}
+
+// Ensures dropped messages aren't published.
+func TestChaosPublisherDrop(t *testing.T) {
+	wrapped := &recordingPublisherTransport{}
+	transport := newChaosPublisherTestTransport(wrapped, FChaosConfig{DropRate: 1})
+
+	assert.Nil(t, transport.Publish("foo", []byte("a")))
+	assert.Empty(t, wrapped.published)
+}
+
+// Ensures duplicated messages are published twice.
+func TestChaosPublisherDuplicate(t *testing.T) {
+	wrapped := &recordingPublisherTransport{}
+	transport := newChaosPublisherTestTransport(wrapped, FChaosConfig{DuplicateRate: 1})
+
+	assert.Nil(t, transport.Publish("foo", []byte("a")))
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("a")}, wrapped.data)
+}
+
+// Ensures reordered messages are published after the next message, and a
+// held message is published when the transport is closed.
+func TestChaosPublisherReorder(t *testing.T) {
+	wrapped := &recordingPublisherTransport{}
+	transport := newChaosPublisherTestTransport(wrapped, FChaosConfig{ReorderRate: 1})
+
+	assert.Nil(t, transport.Open())
+	for _, data := range []string{"a", "b", "c"} {
+		assert.Nil(t, transport.Publish("foo", []byte(data)))
+	}
+	assert.Equal(t, [][]byte{[]byte("b"), []byte("a")}, wrapped.data)
+	assert.Nil(t, transport.Close())
+	assert.Equal(t, [][]byte{[]byte("b"), []byte("a"), []byte("c")}, wrapped.data)
+	assert.False(t, wrapped.IsOpen())
+}
+
+// Ensures a disconnect closes the wrapped transport and fails the publish.
+func TestChaosPublisherDisconnect(t *testing.T) {
+	wrapped := &recordingPublisherTransport{}
+	transport := newChaosPublisherTestTransport(wrapped, FChaosConfig{DisconnectRate: 1})
+
+	assert.Nil(t, transport.Open())
+	err := transport.Publish("foo", []byte("a"))
+	assert.Equal(t, TRANSPORT_EXCEPTION_NOT_OPEN, err.(thrift.TTransportException).TypeId())
+	assert.False(t, transport.IsOpen())
+	assert.Empty(t, wrapped.published)
+}
+
+// Ensures received messages are delayed, duplicated and reordered.
+func TestChaosSubscriberFaults(t *testing.T) {
+	wrapped := &capturingSubscriberTransport{}
+	config := FChaosConfig{DuplicateRate: 1, ReorderRate: 1, DelayRate: 1, MaxDelay: time.Millisecond}
+	transport := NewFChaosSubscriberTransportFactory(
+		&capturingSubscriberTransportFactory{transport: wrapped}, config).GetTransport()
+	callback, payloads := chaosCallback(t)
+
+	assert.Nil(t, transport.Subscribe("foo", callback))
+	assert.Equal(t, "foo", wrapped.topic)
+	assert.Nil(t, wrapped.deliver([]byte("a")))
+	assert.Empty(t, payloads())
+	assert.Nil(t, wrapped.deliver([]byte("b")))
+	assert.Equal(t, []string{"b", "b", "a", "a"}, payloads())
+
+	assert.Nil(t, wrapped.deliver([]byte("c")))
+	assert.Nil(t, transport.Unsubscribe())
+	assert.False(t, transport.IsSubscribed())
+	assert.Equal(t, []string{"b", "b", "a", "a"}, payloads())
+}
+
+// Ensures a disconnect drops the message and unsubscribes the wrapped
+// transport until the reconnect delay passes.
+func TestChaosSubscriberDisconnect(t *testing.T) {
+	wrapped := &capturingSubscriberTransport{}
+	config := FChaosConfig{DisconnectRate: 1, ReconnectDelay: 20 * time.Millisecond}
+	transport := NewFChaosSubscriberTransportFactory(
+		&capturingSubscriberTransportFactory{transport: wrapped}, config).GetTransport()
+	callback, payloads := chaosCallback(t)
+
+	assert.Nil(t, transport.Subscribe("foo", callback))
+	assert.Nil(t, wrapped.deliver([]byte("a")))
+	assert.Empty(t, payloads())
+	deadline := time.Now().Add(time.Second)
+	for transport.IsSubscribed() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	assert.False(t, transport.IsSubscribed())
+	for !transport.IsSubscribed() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	assert.True(t, transport.IsSubscribed())
+	assert.Equal(t, "foo", wrapped.topic)
+
+	assert.Nil(t, transport.Unsubscribe())
+	assert.False(t, transport.IsSubscribed())
+}
+
+// Ensures unsubscribing while disconnected stops the transport from
+// resubscribing.
+func TestChaosSubscriberUnsubscribeWhileDisconnected(t *testing.T) {
+	wrapped := &capturingSubscriberTransport{}
+	config := FChaosConfig{DisconnectRate: 1, ReconnectDelay: 10 * time.Millisecond}
+	transport := NewFChaosSubscriberTransportFactory(
+		&capturingSubscriberTransportFactory{transport: wrapped}, config).GetTransport()
+	callback, _ := chaosCallback(t)
+
+	assert.Nil(t, transport.Subscribe("foo", callback))
+	chaos := transport.(*fChaosSubscriberTransport)
+	chaos.disconnect()
+	assert.False(t, transport.IsSubscribed())
+	assert.Nil(t, transport.Unsubscribe())
+	time.Sleep(30 * time.Millisecond)
+	assert.False(t, transport.IsSubscribed())
+}
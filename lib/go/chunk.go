@@ -0,0 +1,181 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// DefaultChunkSize is the size in bytes of the chunks blobs are sent in by
+// chunked scope operations which don't specify one.
+const DefaultChunkSize = 64 * 1024
+
+const (
+	// Headers identifying the blob a chunk belongs to, the chunk's position
+	// in it, and whether it's the blob's last chunk
+	chunkIDHeader    = "_chunk_id"
+	chunkSeqHeader   = "_chunk_seq"
+	chunkFinalHeader = "_chunk_final"
+
+	// How long an incomplete blob is kept after its last chunk is received
+	defaultChunkTimeout = time.Minute
+)
+
+// PublishChunks splits the data into chunks of at most size bytes and
+// publishes each with a clone of the given FContext, whose headers identify
+// the blob and the chunk's sequence number and mark the last chunk. Empty
+// data is published as a single empty chunk. If the chunks are confirmed,
+// the message ID of the last one is recorded in the given FContext. This is
+// to be used by generated code and should not be called directly.
+func PublishChunks(ctx FContext, data []byte, size int, publish func(FContext, []byte) error) error {
+	if size <= 0 {
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_UNKNOWN,
+			fmt.Sprintf("frugal: invalid chunk size %d", size))
+	}
+
+	id := generateCorrelationID()
+	for seq := 0; ; seq++ {
+		end := size
+		if end > len(data) {
+			end = len(data)
+		}
+		chunkCtx := Clone(ctx)
+		chunkCtx.AddRequestHeader(chunkIDHeader, id)
+		chunkCtx.AddRequestHeader(chunkSeqHeader, strconv.Itoa(seq))
+		if end == len(data) {
+			chunkCtx.AddRequestHeader(chunkFinalHeader, "true")
+		}
+		if err := publish(chunkCtx, data[:end]); err != nil {
+			return err
+		}
+		data = data[end:]
+		if len(data) == 0 {
+			// A confirmed blob's message ID is its last chunk's
+			if messageID, ok := PublishedMessageID(chunkCtx); ok {
+				ctx.AddResponseHeader(messageIDHeader, messageID)
+			}
+			return nil
+		}
+	}
+}
+
+// FChunkAssembler reassembles blobs published with PublishChunks from their
+// chunks, which may be received in any order. Blobs which aren't completed
+// within a timeout of receiving their last chunk are discarded. It is safe
+// for concurrent use.
+type FChunkAssembler struct {
+	mu        sync.Mutex
+	timeout   time.Duration
+	transfers map[string]*chunkTransfer
+}
+
+// chunkTransfer holds the chunks of a blob received so far.
+type chunkTransfer struct {
+	chunks   map[int][]byte
+	final    int // Sequence number of the last chunk, or -1 until it's received
+	received time.Time
+}
+
+// NewFChunkAssembler creates a new FChunkAssembler which discards incomplete
+// blobs a minute after receiving their last chunk.
+func NewFChunkAssembler() *FChunkAssembler {
+	return &FChunkAssembler{
+		timeout:   defaultChunkTimeout,
+		transfers: make(map[string]*chunkTransfer),
+	}
+}
+
+// WithTimeout configures the FChunkAssembler to discard incomplete blobs the
+// given duration after receiving their last chunk.
+func (a *FChunkAssembler) WithTimeout(timeout time.Duration) *FChunkAssembler {
+	a.timeout = timeout
+	return a
+}
+
+// Add adds a chunk received with the given FContext. If it completes its
+// blob, the blob is returned along with true. An error is returned if the
+// FContext doesn't describe a chunk.
+func (a *FChunkAssembler) Add(ctx FContext, chunk []byte) ([]byte, bool, error) {
+	id, ok := ctx.RequestHeader(chunkIDHeader)
+	if !ok {
+		return nil, false, thrift.NewTTransportException(TRANSPORT_EXCEPTION_UNKNOWN,
+			"frugal: message is missing chunk headers")
+	}
+	seqHeader, _ := ctx.RequestHeader(chunkSeqHeader)
+	seq, err := strconv.Atoi(seqHeader)
+	if err != nil || seq < 0 {
+		return nil, false, thrift.NewTTransportException(TRANSPORT_EXCEPTION_UNKNOWN,
+			fmt.Sprintf("frugal: invalid chunk sequence number %q", seqHeader))
+	}
+	final, _ := ctx.RequestHeader(chunkFinalHeader)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := clock().Now()
+	a.expire(now)
+
+	transfer, ok := a.transfers[id]
+	if !ok {
+		transfer = &chunkTransfer{chunks: make(map[int][]byte), final: -1}
+		a.transfers[id] = transfer
+	}
+	transfer.chunks[seq] = chunk
+	transfer.received = now
+	if final == "true" {
+		transfer.final = seq
+	}
+	if transfer.final < 0 || len(transfer.chunks) < transfer.final+1 {
+		return nil, false, nil
+	}
+
+	var blob bytes.Buffer
+	for i := 0; i <= transfer.final; i++ {
+		next, ok := transfer.chunks[i]
+		if !ok {
+			// A chunk past the last one was received, so one is still missing
+			return nil, false, nil
+		}
+		blob.Write(next)
+	}
+	delete(a.transfers, id)
+	return blob.Bytes(), true, nil
+}
+
+// expire discards the incomplete blobs which timed out.
+func (a *FChunkAssembler) expire(now time.Time) {
+	for id, transfer := range a.transfers {
+		if now.Sub(transfer.received) > a.timeout {
+			logger().Warnf("frugal: discarding incomplete chunked message %s with %d chunks", id, len(transfer.chunks))
+			delete(a.transfers, id)
+		}
+	}
+}
+
+// chunkMessageID returns the correlation ID of the given FContext, qualified
+// by the blob and sequence number if it describes a chunk, since every chunk
+// of a blob is published with the same correlation ID.
+func chunkMessageID(ctx FContext) string {
+	id := ctx.CorrelationID()
+	if chunkID, ok := ctx.RequestHeader(chunkIDHeader); ok {
+		seq, _ := ctx.RequestHeader(chunkSeqHeader)
+		id += "/" + chunkID + "/" + seq
+	}
+	return id
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// chunkCapture records the contexts and chunks passed to the publish
+// function of PublishChunks.
+type chunkCapture struct {
+	ctxs   []FContext
+	chunks [][]byte
+}
+
+func (c *chunkCapture) publish(ctx FContext, chunk []byte) error {
+	c.ctxs = append(c.ctxs, ctx)
+	c.chunks = append(c.chunks, chunk)
+	return nil
+}
+
+// Ensures PublishChunks splits data into chunks with sequence numbers and
+// marks the last one as final, keeping the correlation id.
+func TestPublishChunks(t *testing.T) {
+	ctx := NewFContext("cid")
+	capture := &chunkCapture{}
+
+	assert.Nil(t, PublishChunks(ctx, []byte("abcdefgh"), 3, capture.publish))
+	assert.Equal(t, [][]byte{[]byte("abc"), []byte("def"), []byte("gh")}, capture.chunks)
+
+	id, _ := capture.ctxs[0].RequestHeader(chunkIDHeader)
+	assert.NotEmpty(t, id)
+	for i, chunkCtx := range capture.ctxs {
+		assert.Equal(t, "cid", chunkCtx.CorrelationID())
+		chunkID, _ := chunkCtx.RequestHeader(chunkIDHeader)
+		assert.Equal(t, id, chunkID)
+		seq, _ := chunkCtx.RequestHeader(chunkSeqHeader)
+		assert.Equal(t, strconv.Itoa(i), seq)
+		_, final := chunkCtx.RequestHeader(chunkFinalHeader)
+		assert.Equal(t, i == 2, final)
+	}
+	_, ok := ctx.RequestHeader(chunkIDHeader)
+	assert.False(t, ok)
+}
+
+// Ensures PublishChunks publishes empty data as a single final chunk.
+func TestPublishChunksEmpty(t *testing.T) {
+	capture := &chunkCapture{}
+
+	assert.Nil(t, PublishChunks(NewFContext(""), nil, 3, capture.publish))
+	assert.Equal(t, [][]byte{nil}, capture.chunks)
+	final, _ := capture.ctxs[0].RequestHeader(chunkFinalHeader)
+	assert.Equal(t, "true", final)
+}
+
+// Ensures PublishChunks stops at the first chunk which fails to publish.
+func TestPublishChunksError(t *testing.T) {
+	calls := 0
+	err := PublishChunks(NewFContext(""), []byte("abcdef"), 2, func(FContext, []byte) error {
+		calls++
+		return errors.New("error")
+	})
+	assert.Equal(t, "error", err.Error())
+	assert.Equal(t, 1, calls)
+}
+
+// Ensures PublishChunks rejects chunk sizes which aren't positive.
+func TestPublishChunksInvalidSize(t *testing.T) {
+	err := PublishChunks(NewFContext(""), []byte("abc"), 0, (&chunkCapture{}).publish)
+	assert.Equal(t, "frugal: invalid chunk size 0", err.Error())
+}
+
+// Ensures FChunkAssembler reassembles chunks received out of order and
+// interleaved with the chunks of another blob.
+func TestFChunkAssembler(t *testing.T) {
+	first := &chunkCapture{}
+	second := &chunkCapture{}
+	assert.Nil(t, PublishChunks(NewFContext(""), []byte("hello world"), 4, first.publish))
+	assert.Nil(t, PublishChunks(NewFContext(""), []byte("bye"), 4, second.publish))
+	assembler := NewFChunkAssembler()
+
+	for _, i := range []int{2, 0} {
+		blob, ok, err := assembler.Add(first.ctxs[i], first.chunks[i])
+		assert.Nil(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, blob)
+	}
+
+	blob, ok, err := assembler.Add(second.ctxs[0], second.chunks[0])
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("bye"), blob)
+
+	blob, ok, err = assembler.Add(first.ctxs[1], first.chunks[1])
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("hello world"), blob)
+	assert.Len(t, assembler.transfers, 0)
+}
+
+// Ensures FChunkAssembler discards incomplete blobs once they time out.
+func TestFChunkAssemblerTimeout(t *testing.T) {
+	clock := NewFSimulatedClock(time.Now())
+	SetClock(clock)
+	defer SetClock(nil)
+	capture := &chunkCapture{}
+	assert.Nil(t, PublishChunks(NewFContext(""), []byte("abcdef"), 3, capture.publish))
+	assembler := NewFChunkAssembler().WithTimeout(time.Millisecond)
+
+	_, ok, err := assembler.Add(capture.ctxs[0], capture.chunks[0])
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	clock.Advance(5 * time.Millisecond)
+
+	_, ok, err = assembler.Add(capture.ctxs[1], capture.chunks[1])
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	assert.Len(t, assembler.transfers, 1)
+}
+
+// Ensures FChunkAssembler returns an error for messages which aren't chunks.
+func TestFChunkAssemblerNotChunk(t *testing.T) {
+	assembler := NewFChunkAssembler()
+
+	_, _, err := assembler.Add(NewFContext(""), []byte("abc"))
+	assert.Equal(t, "frugal: message is missing chunk headers", err.Error())
+
+	ctx := NewFContext("").AddRequestHeader(chunkIDHeader, "id").AddRequestHeader(chunkSeqHeader, "x")
+	_, _, err = assembler.Add(ctx, []byte("abc"))
+	assert.Equal(t, `frugal: invalid chunk sequence number "x"`, err.Error())
+}
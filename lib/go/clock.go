@@ -0,0 +1,247 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"sync"
+	"time"
+)
+
+// FClock tells the time and waits for durations to pass. Frugal uses it for
+// timeouts, retries, expirations and polling, so tests can replace it with an
+// FSimulatedClock using SetClock and advance time deterministically instead
+// of sleeping. Implementations must be threadsafe.
+type FClock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Sleep blocks until the given duration has passed.
+	Sleep(time.Duration)
+
+	// After returns a channel which receives the current time once the
+	// given duration has passed.
+	After(time.Duration) <-chan time.Time
+
+	// NewTimer returns an FTimer which fires once the given duration has
+	// passed.
+	NewTimer(time.Duration) FTimer
+
+	// NewTicker returns an FTicker which fires every time the given duration
+	// passes. The duration must be positive.
+	NewTicker(time.Duration) FTicker
+}
+
+// FTimer is a single event created by an FClock, like a time.Timer.
+type FTimer interface {
+	// C returns the channel which receives the time when the timer fires.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing. It returns false if the timer
+	// already fired or was stopped.
+	Stop() bool
+}
+
+// FTicker is a repeating event created by an FClock, like a time.Ticker.
+type FTicker interface {
+	// C returns the channel which receives the time when the ticker fires.
+	C() <-chan time.Time
+
+	// Stop stops the ticker from firing.
+	Stop()
+}
+
+var (
+	packageClock FClock = systemClock{}
+	clockMu      sync.RWMutex
+)
+
+// SetClock sets the FClock used by Frugal. Setting nil restores the system
+// clock.
+func SetClock(clock FClock) {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	clockMu.Lock()
+	packageClock = clock
+	clockMu.Unlock()
+}
+
+// clock returns the global FClock.
+func clock() FClock {
+	clockMu.RLock()
+	clock := packageClock
+	clockMu.RUnlock()
+	return clock
+}
+
+// since returns the time elapsed since t according to the global FClock.
+func since(t time.Time) time.Duration {
+	return clock().Now().Sub(t)
+}
+
+// systemClock implements FClock with the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (systemClock) NewTimer(d time.Duration) FTimer        { return systemTimer{time.NewTimer(d)} }
+func (systemClock) NewTicker(d time.Duration) FTicker      { return systemTicker{time.NewTicker(d)} }
+
+type systemTimer struct{ *time.Timer }
+
+func (t systemTimer) C() <-chan time.Time { return t.Timer.C }
+
+type systemTicker struct{ *time.Ticker }
+
+func (t systemTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// FSimulatedClock is an FClock whose time only passes when it's advanced, so
+// tests can control timeouts, retries and polling deterministically.
+type FSimulatedClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*simulatedWaiter
+}
+
+// simulatedWaiter is a timer or ticker of an FSimulatedClock.
+type simulatedWaiter struct {
+	clock  *FSimulatedClock
+	at     time.Time
+	period time.Duration
+	c      chan time.Time
+}
+
+// NewFSimulatedClock creates an FSimulatedClock starting at the given time.
+func NewFSimulatedClock(now time.Time) *FSimulatedClock {
+	clock := &FSimulatedClock{now: now}
+	clock.cond = sync.NewCond(&clock.mu)
+	return clock
+}
+
+// Now returns the simulated time.
+func (s *FSimulatedClock) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.now
+}
+
+// Sleep blocks until the clock is advanced by the given duration.
+func (s *FSimulatedClock) Sleep(d time.Duration) {
+	<-s.After(d)
+}
+
+// After returns a channel which receives the simulated time once the clock is
+// advanced by the given duration.
+func (s *FSimulatedClock) After(d time.Duration) <-chan time.Time {
+	return s.NewTimer(d).C()
+}
+
+// NewTimer returns an FTimer which fires once the clock is advanced by the
+// given duration.
+func (s *FSimulatedClock) NewTimer(d time.Duration) FTimer {
+	return s.addWaiter(d, 0)
+}
+
+// NewTicker returns an FTicker which fires every time the clock is advanced
+// by the given duration. It panics if the duration isn't positive.
+func (s *FSimulatedClock) NewTicker(d time.Duration) FTicker {
+	if d <= 0 {
+		panic("frugal: non-positive interval for NewTicker")
+	}
+	return simulatedTicker{s.addWaiter(d, d)}
+}
+
+func (s *FSimulatedClock) addWaiter(d, period time.Duration) *simulatedWaiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	waiter := &simulatedWaiter{clock: s, at: s.now.Add(d), period: period, c: make(chan time.Time, 1)}
+	if d <= 0 && period == 0 {
+		waiter.c <- s.now
+		return waiter
+	}
+	s.waiters = append(s.waiters, waiter)
+	s.cond.Broadcast()
+	return waiter
+}
+
+// Advance moves the clock forward by the given duration, firing the timers
+// and tickers due in order.
+func (s *FSimulatedClock) Advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	end := s.now.Add(d)
+	for {
+		next := -1
+		for i, waiter := range s.waiters {
+			if !waiter.at.After(end) && (next < 0 || waiter.at.Before(s.waiters[next].at)) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+		waiter := s.waiters[next]
+		s.now = waiter.at
+		select {
+		case waiter.c <- s.now:
+		default:
+			// Like a time.Ticker, ticks are dropped for slow receivers.
+		}
+		if waiter.period > 0 {
+			waiter.at = waiter.at.Add(waiter.period)
+		} else {
+			s.waiters = append(s.waiters[:next], s.waiters[next+1:]...)
+		}
+	}
+	s.now = end
+}
+
+// WaitForWaiters blocks until at least the given number of timers, tickers
+// and sleeps are waiting for the clock to advance. This lets a test wait for
+// the code under test to start waiting before advancing the clock.
+func (s *FSimulatedClock) WaitForWaiters(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.waiters) < n {
+		s.cond.Wait()
+	}
+}
+
+func (w *simulatedWaiter) C() <-chan time.Time {
+	return w.c
+}
+
+// Stop removes the waiter from its clock, returning false if it already
+// fired or was stopped.
+func (w *simulatedWaiter) Stop() bool {
+	s := w.clock
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, waiter := range s.waiters {
+		if waiter == w {
+			s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// simulatedTicker is an FTicker of an FSimulatedClock.
+type simulatedTicker struct{ *simulatedWaiter }
+
+func (t simulatedTicker) Stop() {
+	t.simulatedWaiter.Stop()
+}
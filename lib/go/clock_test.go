@@ -0,0 +1,148 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+var simulatedClockStart = time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Ensures a simulated timer only fires once the clock is advanced past it.
+func TestSimulatedClockTimer(t *testing.T) {
+	clock := NewFSimulatedClock(simulatedClockStart)
+	timer := clock.NewTimer(time.Second)
+
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-timer.C():
+		t.Fatal("Timer fired early")
+	default:
+	}
+	clock.Advance(time.Millisecond)
+	assert.Equal(t, simulatedClockStart.Add(time.Second), <-timer.C())
+	assert.False(t, timer.Stop())
+	assert.Equal(t, simulatedClockStart.Add(time.Second), clock.Now())
+}
+
+// Ensures a stopped simulated timer doesn't fire.
+func TestSimulatedClockTimerStop(t *testing.T) {
+	clock := NewFSimulatedClock(simulatedClockStart)
+	timer := clock.NewTimer(time.Second)
+
+	assert.True(t, timer.Stop())
+	clock.Advance(time.Minute)
+	select {
+	case <-timer.C():
+		t.Fatal("Stopped timer fired")
+	default:
+	}
+}
+
+// Ensures a simulated ticker fires every interval the clock is advanced
+// through, at the time of each tick.
+func TestSimulatedClockTicker(t *testing.T) {
+	clock := NewFSimulatedClock(simulatedClockStart)
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for i := 1; i <= 3; i++ {
+		clock.Advance(time.Second)
+		assert.Equal(t, simulatedClockStart.Add(time.Duration(i)*time.Second), <-ticker.C())
+	}
+	clock.Advance(500 * time.Millisecond)
+	select {
+	case <-ticker.C():
+		t.Fatal("Ticker fired early")
+	default:
+	}
+}
+
+// Ensures a simulated sleep returns once the clock is advanced.
+func TestSimulatedClockSleep(t *testing.T) {
+	clock := NewFSimulatedClock(simulatedClockStart)
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Minute)
+		close(done)
+	}()
+
+	clock.WaitForWaiters(1)
+	clock.Advance(time.Minute)
+	<-done
+}
+
+// Ensures Publish times out according to the clock set with SetClock.
+func TestPublishTimeoutSimulatedClock(t *testing.T) {
+	clock := NewFSimulatedClock(simulatedClockStart)
+	SetClock(clock)
+	defer SetClock(nil)
+	transport := &blockingPublisherTransport{release: make(chan struct{})}
+	defer close(transport.release)
+	ctx := NewFContext("")
+	ctx.SetTimeout(time.Hour)
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- Publish(ctx, transport, "topic", []byte{1})
+	}()
+	clock.WaitForWaiters(1)
+	clock.Advance(time.Hour)
+
+	err := <-errC
+	assert.Equal(t, TRANSPORT_EXCEPTION_TIMED_OUT, err.(thrift.TTransportException).TypeId())
+}
+
+// Ensures Publish isn't bounded by the default timeout of an FContext without
+// an explicit timeout.
+func TestPublishNoTimeoutSimulatedClock(t *testing.T) {
+	clock := NewFSimulatedClock(simulatedClockStart)
+	SetClock(clock)
+	defer SetClock(nil)
+	transport := &advancingPublisherTransport{clock: clock, d: time.Hour}
+
+	assert.Nil(t, Publish(NewFContext(""), transport, "topic", []byte{1}))
+	assert.Equal(t, []string{"topic"}, transport.published)
+}
+
+// advancingPublisherTransport advances a simulated clock while publishing.
+type advancingPublisherTransport struct {
+	fakePublisherTransport
+	clock *FSimulatedClock
+	d     time.Duration
+}
+
+func (a *advancingPublisherTransport) Publish(topic string, data []byte) error {
+	a.clock.Advance(a.d)
+	return a.fakePublisherTransport.Publish(topic, data)
+}
+
+// Ensures message IDs expire from the memory deduplication store according to
+// the clock set with SetClock.
+func TestMemoryDeduplicationStoreSimulatedClock(t *testing.T) {
+	clock := NewFSimulatedClock(simulatedClockStart)
+	SetClock(clock)
+	defer SetClock(nil)
+	store := NewMemoryDeduplicationStore()
+
+	assert.True(t, store.Add("id", time.Minute))
+	clock.Advance(59 * time.Second)
+	assert.False(t, store.Add("id", time.Minute))
+	clock.Advance(time.Second)
+	assert.True(t, store.Add("id", time.Minute))
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// ContentTypeHeader is the request header recording the content type of the
+// codec, i.e. the Thrift protocol, the payload of a scope message is encoded
+// with.
+const ContentTypeHeader = "_content_type"
+
+// Content types of the codecs registered by default.
+const (
+	ContentTypeBinary  = "binary"
+	ContentTypeCompact = "compact"
+	ContentTypeJSON    = "json"
+)
+
+var (
+	codecs = map[string]thrift.TProtocolFactory{
+		ContentTypeBinary:  thrift.NewTBinaryProtocolFactoryDefault(),
+		ContentTypeCompact: thrift.NewTCompactProtocolFactory(),
+		ContentTypeJSON:    thrift.NewTJSONProtocolFactory(),
+	}
+	codecsMu sync.RWMutex
+)
+
+// RegisterCodec registers the TProtocolFactory decoding scope messages with
+// the given content type. Publishers generated with the content_type option
+// record the content type of their TProtocolFactory's codec in each message,
+// and subscribers generated with it decode each message with the codec
+// registered for its content type, so publishers can migrate to another
+// protocol before every subscriber does. The binary, compact, and JSON
+// protocols are registered by default.
+func RegisterCodec(contentType string, factory thrift.TProtocolFactory) {
+	codecsMu.Lock()
+	codecs[contentType] = factory
+	codecsMu.Unlock()
+}
+
+// codec returns the TProtocolFactory registered for the given content type.
+func codec(contentType string) (thrift.TProtocolFactory, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	factory, ok := codecs[contentType]
+	return factory, ok
+}
+
+// ContentType returns the content type of the codec the FProtocolFactory's
+// TProtocolFactory is registered as, or an empty string if it isn't. A
+// TProtocolFactory which isn't registered itself matches the codec registered
+// with a TProtocolFactory of the same type, picking the first content type in
+// lexical order if there are several.
+func (f *FProtocolFactory) ContentType() string {
+	factoryType := reflect.TypeOf(f.protoFactory)
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	match := ""
+	for contentType, factory := range codecs {
+		if reflect.TypeOf(factory) != factoryType {
+			continue
+		}
+		if factoryType.Comparable() && factory == f.protoFactory {
+			return contentType
+		}
+		if match == "" || contentType < match {
+			match = contentType
+		}
+	}
+	return match
+}
+
+// SetContentType records the content type of the FProtocolFactory's codec in
+// the request headers of the given FContext. The headers are left unchanged
+// if the codec isn't registered.
+func SetContentType(ctx FContext, protocolFactory *FProtocolFactory) FContext {
+	if contentType := protocolFactory.ContentType(); contentType != "" {
+		ctx.AddRequestHeader(ContentTypeHeader, contentType)
+	}
+	return ctx
+}
+
+// NegotiateProtocol returns the FProtocol decoding the rest of the message
+// read by the given FProtocol, whose request headers are the given FContext.
+// This is an FProtocol using the codec registered for the content type in the
+// headers if it differs from the FProtocolFactory's, or the given FProtocol
+// otherwise. An error is returned if no codec is registered for the content
+// type.
+func (f *FProtocolFactory) NegotiateProtocol(ctx FContext, proto *FProtocol) (*FProtocol, error) {
+	contentType, ok := ctx.RequestHeader(ContentTypeHeader)
+	if !ok || contentType == f.ContentType() {
+		return proto, nil
+	}
+	factory, ok := codec(contentType)
+	if !ok {
+		return nil, thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA,
+			fmt.Errorf("frugal: no codec registered for content type %q", contentType))
+	}
+	return f.WithTProtocolFactory(factory).GetProtocol(proto.Transport()), nil
+}
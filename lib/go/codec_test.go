@@ -0,0 +1,124 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"testing"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+type testProtocolFactory struct {
+	thrift.TProtocolFactory
+}
+
+// encodeMessage returns a message with the given context and operation name
+// encoded with the given FProtocolFactory.
+func encodeMessage(t *testing.T, ctx FContext, factory *FProtocolFactory, op string) *thrift.TMemoryBuffer {
+	buffer := thrift.NewTMemoryBuffer()
+	oprot := factory.GetProtocol(buffer)
+	assert.Nil(t, oprot.WriteRequestHeader(ctx))
+	assert.Nil(t, oprot.WriteMessageBegin(op, thrift.CALL, 0))
+	assert.Nil(t, oprot.WriteMessageEnd())
+	assert.Nil(t, oprot.Flush())
+	return buffer
+}
+
+// Ensures ContentType returns the content type registered for the
+// TProtocolFactory's type.
+func TestFProtocolFactoryContentType(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(ContentTypeBinary, NewFProtocolFactory(thrift.NewTBinaryProtocolFactory(true, true)).ContentType())
+	assert.Equal(ContentTypeCompact, NewFProtocolFactory(thrift.NewTCompactProtocolFactory()).ContentType())
+	assert.Equal(ContentTypeJSON, NewFProtocolFactory(thrift.NewTJSONProtocolFactory()).ContentType())
+	assert.Equal("", NewFProtocolFactory(thrift.NewTSimpleJSONProtocolFactory()).ContentType())
+}
+
+// Ensures ContentType prefers the content type the TProtocolFactory itself is
+// registered as.
+func TestFProtocolFactoryContentTypeRegistered(t *testing.T) {
+	factory := &testProtocolFactory{thrift.NewTBinaryProtocolFactoryDefault()}
+	assert.Equal(t, "", NewFProtocolFactory(factory).ContentType())
+	RegisterCodec("test-b", &testProtocolFactory{thrift.NewTBinaryProtocolFactoryDefault()})
+	RegisterCodec("test-c", factory)
+	defer func() {
+		codecsMu.Lock()
+		delete(codecs, "test-b")
+		delete(codecs, "test-c")
+		codecsMu.Unlock()
+	}()
+
+	assert.Equal(t, "test-c", NewFProtocolFactory(factory).ContentType())
+	assert.Equal(t, "test-b", NewFProtocolFactory(&testProtocolFactory{}).ContentType())
+}
+
+// Ensures SetContentType only sets the header for registered codecs.
+func TestSetContentType(t *testing.T) {
+	ctx := SetContentType(NewFContext(""), NewFProtocolFactory(thrift.NewTJSONProtocolFactory()))
+	contentType, _ := ctx.RequestHeader(ContentTypeHeader)
+	assert.Equal(t, ContentTypeJSON, contentType)
+
+	ctx = SetContentType(NewFContext(""), NewFProtocolFactory(thrift.NewTSimpleJSONProtocolFactory()))
+	_, ok := ctx.RequestHeader(ContentTypeHeader)
+	assert.False(t, ok)
+}
+
+// Ensures NegotiateProtocol decodes messages with the codec of their content
+// type.
+func TestNegotiateProtocol(t *testing.T) {
+	binary := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	json := NewFProtocolFactory(thrift.NewTJSONProtocolFactory())
+	for _, publisher := range []*FProtocolFactory{binary, json} {
+		buffer := encodeMessage(t, SetContentType(NewFContext(""), publisher), publisher, "foo")
+
+		iprot := binary.GetProtocol(buffer)
+		ctx, err := iprot.ReadRequestHeader()
+		assert.Nil(t, err)
+		iprot, err = binary.NegotiateProtocol(ctx, iprot)
+		assert.Nil(t, err)
+		name, _, _, err := iprot.ReadMessageBegin()
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", name)
+	}
+}
+
+// Ensures NegotiateProtocol keeps the given protocol for messages without a
+// content type.
+func TestNegotiateProtocolNoContentType(t *testing.T) {
+	binary := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	buffer := encodeMessage(t, NewFContext(""), binary, "foo")
+
+	iprot := binary.GetProtocol(buffer)
+	ctx, err := iprot.ReadRequestHeader()
+	assert.Nil(t, err)
+	negotiated, err := binary.NegotiateProtocol(ctx, iprot)
+	assert.Nil(t, err)
+	assert.True(t, negotiated == iprot)
+}
+
+// Ensures NegotiateProtocol returns an error for content types without a
+// registered codec.
+func TestNegotiateProtocolUnknownContentType(t *testing.T) {
+	binary := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	ctx := NewFContext("")
+	ctx.AddRequestHeader(ContentTypeHeader, "xml")
+	buffer := encodeMessage(t, ctx, binary, "foo")
+
+	iprot := binary.GetProtocol(buffer)
+	ctx, err := iprot.ReadRequestHeader()
+	assert.Nil(t, err)
+	_, err = binary.NegotiateProtocol(ctx, iprot)
+	assert.Equal(t, thrift.INVALID_DATA, err.(thrift.TProtocolException).TypeId())
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import "git.apache.org/thrift.git/lib/go/thrift"
+
+const (
+	// Response header requesting that publishes with the FContext wait for
+	// the broker to acknowledge the message. Response headers aren't
+	// published, so the request stays local to the publisher.
+	confirmPublishHeader = "_confirm_publish"
+
+	// Response header containing the ID the broker assigned the last message
+	// published with the FContext
+	messageIDHeader = "_message_id"
+)
+
+// FConfirmingPublisherTransport is an FPublisherTransport backed by a broker
+// which acknowledges published messages, such as Kafka or JetStream, rather
+// than only flushing them locally.
+type FConfirmingPublisherTransport interface {
+	FPublisherTransport
+
+	// PublishConfirmed sends the given payload and blocks until the broker
+	// acknowledges it, returning the ID the broker assigned the message.
+	// Implementations should be threadsafe.
+	PublishConfirmed(string, []byte) (string, error)
+}
+
+// WithPublishConfirmation requests that scope messages published with the
+// given FContext wait for the broker to acknowledge them, which requires the
+// publisher's transport to implement FConfirmingPublisherTransport. Once a
+// generated publish method returns, PublishedMessageID returns the ID of the
+// message. Returns the same FContext to allow for chaining calls.
+func WithPublishConfirmation(ctx FContext) FContext {
+	return ctx.AddResponseHeader(confirmPublishHeader, "true")
+}
+
+// PublishedMessageID returns the ID the broker assigned the last message
+// confirmed as published with the given FContext and true, or false if none
+// has been.
+func PublishedMessageID(ctx FContext) (string, bool) {
+	return ctx.ResponseHeader(messageIDHeader)
+}
+
+// isPublishConfirmationRequested returns true if WithPublishConfirmation was
+// called with the given FContext.
+func isPublishConfirmationRequested(ctx FContext) bool {
+	confirm, _ := ctx.ResponseHeader(confirmPublishHeader)
+	return confirm == "true"
+}
+
+// fConfirmingWrapper is implemented by FPublisherTransports which wrap other
+// transports and implement FConfirmingPublisherTransport by delegating, so
+// whether publishes can be confirmed depends on the wrapped transports.
+type fConfirmingWrapper interface {
+	confirmsPublishes() bool
+}
+
+// confirmsPublishes returns true if publishes with the transport can be
+// confirmed.
+func confirmsPublishes(transport FPublisherTransport) bool {
+	if wrapper, ok := transport.(fConfirmingWrapper); ok {
+		return wrapper.confirmsPublishes()
+	}
+	_, ok := transport.(FConfirmingPublisherTransport)
+	return ok
+}
+
+// publishConfirmed publishes the payload with the transport and returns the
+// ID the broker assigned it, or an error if the transport doesn't support
+// confirmation.
+func publishConfirmed(transport FPublisherTransport, topic string, data []byte) (string, error) {
+	confirming, ok := transport.(FConfirmingPublisherTransport)
+	if !ok || !confirmsPublishes(transport) {
+		return "", newConfirmationNotSupportedError()
+	}
+	return confirming.PublishConfirmed(topic, data)
+}
+
+func newConfirmationNotSupportedError() error {
+	return thrift.NewTTransportException(TRANSPORT_EXCEPTION_UNKNOWN,
+		"frugal: publisher transport does not support publish confirmation")
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// confirmingPublisherTransport is a fakePublisherTransport which assigns
+// each confirmed message a sequential ID, blocking until release is closed if
+// it's set.
+type confirmingPublisherTransport struct {
+	fakePublisherTransport
+	release   chan struct{}
+	confirmed int
+}
+
+func (c *confirmingPublisherTransport) PublishConfirmed(topic string, data []byte) (string, error) {
+	if c.release != nil {
+		<-c.release
+	}
+	if err := c.Publish(topic, data); err != nil {
+		return "", err
+	}
+	c.confirmed++
+	return strconv.Itoa(c.confirmed), nil
+}
+
+// Ensures Publish waits for confirmation when the FContext requests it and
+// records the message ID.
+func TestPublishConfirmed(t *testing.T) {
+	transport := &confirmingPublisherTransport{}
+	ctx := WithPublishConfirmation(NewFContext(""))
+
+	assert.Nil(t, Publish(ctx, transport, "topic", []byte{1}))
+	id, ok := PublishedMessageID(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "1", id)
+	assert.Equal(t, []string{"topic"}, transport.published)
+
+	assert.Nil(t, Publish(ctx, transport, "topic", []byte{1}))
+	id, _ = PublishedMessageID(ctx)
+	assert.Equal(t, "2", id)
+}
+
+// Ensures Publish doesn't wait for confirmation unless the FContext requests
+// it.
+func TestPublishNotConfirmed(t *testing.T) {
+	transport := &confirmingPublisherTransport{}
+	ctx := NewFContext("")
+
+	assert.Nil(t, Publish(ctx, transport, "topic", []byte{1}))
+	_, ok := PublishedMessageID(ctx)
+	assert.False(t, ok)
+	assert.Equal(t, 0, transport.confirmed)
+	assert.Equal(t, []string{"topic"}, transport.published)
+}
+
+// Ensures Publish returns the error of an unconfirmed publish without
+// recording a message ID.
+func TestPublishConfirmedError(t *testing.T) {
+	transport := &confirmingPublisherTransport{}
+	transport.publishErr = errors.New("error")
+	ctx := WithPublishConfirmation(NewFContext(""))
+
+	assert.Equal(t, transport.publishErr, Publish(ctx, transport, "topic", []byte{1}))
+	_, ok := PublishedMessageID(ctx)
+	assert.False(t, ok)
+}
+
+// Ensures Publish returns an error if confirmation is requested but the
+// transport doesn't support it.
+func TestPublishConfirmedNotSupported(t *testing.T) {
+	transport := &fakePublisherTransport{}
+	ctx := WithPublishConfirmation(NewFContext(""))
+
+	err := Publish(ctx, transport, "topic", []byte{1})
+	assert.Equal(t, "frugal: publisher transport does not support publish confirmation", err.Error())
+	assert.Empty(t, transport.published)
+}
+
+// Ensures Publish returns a timeout error if confirmation doesn't arrive
+// within the FContext's timeout.
+func TestPublishConfirmedTimeout(t *testing.T) {
+	transport := &confirmingPublisherTransport{release: make(chan struct{})}
+	defer close(transport.release)
+	ctx := WithPublishConfirmation(NewFContext(""))
+	ctx.SetTimeout(5 * time.Millisecond)
+
+	err := Publish(ctx, transport, "topic", []byte{1})
+	assert.Equal(t, TRANSPORT_EXCEPTION_TIMED_OUT, err.(thrift.TTransportException).TypeId())
+	_, ok := PublishedMessageID(ctx)
+	assert.False(t, ok)
+}
+
+// Ensures the message ID of a confirmed chunked blob is its last chunk's.
+func TestPublishChunksConfirmed(t *testing.T) {
+	transport := &confirmingPublisherTransport{}
+	ctx := WithPublishConfirmation(NewFContext(""))
+
+	err := PublishChunks(ctx, []byte("abcdef"), 2, func(chunkCtx FContext, chunk []byte) error {
+		return Publish(chunkCtx, transport, "topic", chunk)
+	})
+	assert.Nil(t, err)
+	id, _ := PublishedMessageID(ctx)
+	assert.Equal(t, "3", id)
+}
+
+// Ensures publishes with a wrapped provider are confirmed by the memory log,
+// whose message IDs are the offsets of the messages.
+func TestPublishConfirmedTenantMemoryLog(t *testing.T) {
+	log := NewFMemoryLog()
+	provider := NewFTenantScopeProvider(NewFScopeProvider(
+		NewFMemoryLogPublisherTransportFactory(log),
+		NewFMemoryLogSubscriberTransportFactory(log),
+		NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())), "acme")
+	transport, _ := provider.NewPublisher()
+	ctx := WithPublishConfirmation(NewFContext(""))
+
+	assert.Nil(t, Publish(ctx, transport, "topic", scopeFrame(t, ctx, "payload")))
+	id, ok := PublishedMessageID(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "0", id)
+
+	assert.Nil(t, Publish(ctx, transport, "topic", scopeFrame(t, ctx, "payload")))
+	id, _ = PublishedMessageID(ctx)
+	assert.Equal(t, "1", id)
+	assert.Equal(t, int64(2), log.end("acme.topic"))
+}
+
+// Ensures the balanced and chaos transports confirm publishes with the
+// endpoint they publish to.
+func TestPublishConfirmedBalancedChaos(t *testing.T) {
+	endpoints := []*confirmingPublisherTransport{{}, {}}
+	endpoints[1].confirmed = 10
+	balanced := newBalancedPublisherTestFactory(endpoints[0], endpoints[1]).GetTransport()
+	transport := newChaosPublisherTestTransport(balanced, FChaosConfig{})
+	assert.Nil(t, transport.Open())
+	ctx := WithPublishConfirmation(NewFContext(""))
+
+	assert.Nil(t, Publish(ctx, transport, "topic", []byte{1}))
+	id, _ := PublishedMessageID(ctx)
+	assert.Equal(t, "1", id)
+	assert.Nil(t, Publish(ctx, transport, "topic", []byte{1}))
+	id, _ = PublishedMessageID(ctx)
+	assert.Equal(t, "11", id)
+}
+
+// Ensures wrapped transports don't support confirmation unless the
+// transports they wrap do, and that the outbox relay falls back to Publish
+// with them.
+func TestPublishConfirmedWrappedNotSupported(t *testing.T) {
+	pub := &fakePublisherTransport{}
+	transport, _ := newTenantTestProvider(pub, nil, "acme").NewPublisher()
+	ctx := WithPublishConfirmation(NewFContext(""))
+
+	err := Publish(ctx, transport, "topic", scopeFrame(t, ctx, "payload"))
+	assert.Equal(t, "frugal: publisher transport does not support publish confirmation", err.Error())
+	assert.Empty(t, pub.published)
+
+	balanced := newBalancedPublisherTestFactory(&confirmingPublisherTransport{}, &fakePublisherTransport{}).GetTransport()
+	assert.False(t, confirmsPublishes(balanced))
+	assert.False(t, confirmsPublishes(newChaosPublisherTestTransport(pub, FChaosConfig{})))
+
+	store := NewMemoryOutboxStore()
+	assert.Nil(t, NewFOutboxPublisherTransportFactory(store, nil).GetTransport().Publish("topic", scopeFrame(t, NewFContext(""), "payload")))
+	published, err := NewFOutboxRelay(store, transport).Flush()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []string{"acme.topic"}, pub.published)
+}
@@ -103,6 +103,11 @@ func Clone(ctx FContext) FContext {
 		responseHeaders: ctx.ResponseHeaders(),
 	}
 	clone.requestHeaders[opIDHeader] = getNextOpID()
+	if impl, ok := ctx.(*FContextImpl); ok {
+		impl.mu.RLock()
+		clone.timeoutSet = impl.timeoutSet
+		impl.mu.RUnlock()
+	}
 	return clone
 }
 
@@ -116,6 +121,7 @@ func getNextOpID() string {
 type FContextImpl struct {
 	requestHeaders  map[string]string
 	responseHeaders map[string]string
+	timeoutSet      bool // Whether SetTimeout was called
 	mu              sync.RWMutex
 }
 
@@ -209,6 +215,7 @@ func (c *FContextImpl) ResponseHeaders() map[string]string {
 func (c *FContextImpl) SetTimeout(timeout time.Duration) FContext {
 	c.mu.Lock()
 	c.requestHeaders[timeoutHeader] = strconv.FormatInt(int64(timeout/time.Millisecond), 10)
+	c.timeoutSet = true
 	c.mu.Unlock()
 	return c
 }
@@ -225,6 +232,21 @@ func (c *FContextImpl) Timeout() time.Duration {
 	return time.Millisecond * time.Duration(timeoutMillis)
 }
 
+// explicitTimeout returns the timeout of the given FContext and true if it
+// was set explicitly rather than defaulted. The timeouts of FContexts which
+// aren't FContextImpls are assumed to be explicit.
+func explicitTimeout(ctx FContext) (time.Duration, bool) {
+	if impl, ok := ctx.(*FContextImpl); ok {
+		impl.mu.RLock()
+		set := impl.timeoutSet
+		impl.mu.RUnlock()
+		if !set {
+			return 0, false
+		}
+	}
+	return ctx.Timeout(), true
+}
+
 // setRequestOpID sets the request operation id for context.
 func setRequestOpID(ctx FContext, id uint64) {
 	opIDStr := strconv.FormatUint(id, 10)
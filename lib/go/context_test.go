@@ -140,3 +140,21 @@ func TestFContextClone(t *testing.T) {
 	_, ok := cloned.RequestHeader("baz")
 	assert.False(t, ok)
 }
+
+// Ensures only timeouts set with SetTimeout, including on the FContext a
+// clone was made from, are explicit.
+func TestFContextExplicitTimeout(t *testing.T) {
+	ctx := NewFContext("")
+	_, ok := explicitTimeout(ctx)
+	assert.False(t, ok)
+	_, ok = explicitTimeout(Clone(ctx))
+	assert.False(t, ok)
+
+	ctx.SetTimeout(time.Minute)
+	timeout, ok := explicitTimeout(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, timeout)
+	timeout, ok = explicitTimeout(Clone(ctx))
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, timeout)
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// FDeduplicationStore tracks the IDs of messages which have been received by
+// a subscriber. Implementations must be threadsafe.
+type FDeduplicationStore interface {
+	// Add records the given message ID for the duration of the window. It
+	// returns false if the ID was already recorded and has not yet expired.
+	Add(id string, window time.Duration) bool
+
+	// Remove forgets the given message ID so that the message will be
+	// processed again if it is redelivered.
+	Remove(id string)
+}
+
+// NewDeduplicationMiddleware returns ServiceMiddleware which drops scope
+// messages that have already been received within the given window. This
+// supports transports with at-least-once delivery semantics without requiring
+// every subscriber handler to be idempotent. Messages are identified by the
+// correlation ID of their FContext, so publishers should use a distinct
+// FContext for each message published. The chunks of a chunked message are
+// identified by their position in it as well.
+//
+// If the subscriber handler returns an error, the message ID is removed from
+// the store so that a redelivery of the message is processed. This
+// middleware should only be applied to scope subscribers.
+func NewDeduplicationMiddleware(store FDeduplicationStore, window time.Duration) ServiceMiddleware {
+	return func(next InvocationHandler) InvocationHandler {
+		return func(service reflect.Value, method reflect.Method, args Arguments) Results {
+			id := chunkMessageID(args.Context())
+			if !store.Add(id, window) {
+				logger().Debugf("frugal: dropping duplicate message with correlation id %s", id)
+				return Results{nil}
+			}
+			results := next(service, method, args)
+			if results.Error() != nil {
+				store.Remove(id)
+			}
+			return results
+		}
+	}
+}
+
+// fMemoryDeduplicationStore is an in-memory implementation of
+// FDeduplicationStore.
+type fMemoryDeduplicationStore struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+	pruned  time.Time
+}
+
+// NewMemoryDeduplicationStore returns an FDeduplicationStore which keeps
+// message IDs in memory. Expired IDs are pruned, at most once per window, as
+// new IDs are added.
+func NewMemoryDeduplicationStore() FDeduplicationStore {
+	return &fMemoryDeduplicationStore{expires: make(map[string]time.Time)}
+}
+
+// Add records the given message ID for the duration of the window. It returns
+// false if the ID was already recorded and has not yet expired.
+func (m *fMemoryDeduplicationStore) Add(id string, window time.Duration) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := clock().Now()
+	if now.Sub(m.pruned) >= window {
+		for seen, expiration := range m.expires {
+			if !now.Before(expiration) {
+				delete(m.expires, seen)
+			}
+		}
+		m.pruned = now
+	}
+	if expiration, ok := m.expires[id]; ok && now.Before(expiration) {
+		return false
+	}
+	m.expires[id] = now.Add(window)
+	return true
+}
+
+// Remove forgets the given message ID.
+func (m *fMemoryDeduplicationStore) Remove(id string) {
+	m.mu.Lock()
+	delete(m.expires, id)
+	m.mu.Unlock()
+}
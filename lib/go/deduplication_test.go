@@ -0,0 +1,99 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures the deduplication middleware only invokes the handler once for
+// messages with the same correlation id.
+func TestDeduplicationMiddlewareDropsDuplicates(t *testing.T) {
+	assert := assert.New(t)
+	calls := 0
+	handler := &testSubscriber{handle: func(ctx FContext, x int) error {
+		calls++
+		return nil
+	}}
+	middleware := NewDeduplicationMiddleware(NewMemoryDeduplicationStore(), time.Minute)
+	method := NewMethod(handler, handler.handle, "handle", []ServiceMiddleware{middleware})
+
+	ctx := NewFContext("cid")
+	assert.Nil(method.Invoke([]interface{}{ctx, 1}).Error())
+	assert.Nil(method.Invoke([]interface{}{ctx, 1}).Error())
+	assert.Nil(method.Invoke([]interface{}{NewFContext("other"), 1}).Error())
+	assert.Equal(2, calls)
+}
+
+// Ensures the chunks of a chunked message, which share a correlation id, are
+// not dropped as duplicates of each other.
+func TestDeduplicationMiddlewareChunks(t *testing.T) {
+	assert := assert.New(t)
+	calls := 0
+	handler := &testSubscriber{handle: func(ctx FContext, x int) error {
+		calls++
+		return nil
+	}}
+	middleware := NewDeduplicationMiddleware(NewMemoryDeduplicationStore(), time.Minute)
+	method := NewMethod(handler, handler.handle, "handle", []ServiceMiddleware{middleware})
+
+	capture := &chunkCapture{}
+	assert.Nil(PublishChunks(NewFContext("cid"), []byte("abcdef"), 3, capture.publish))
+	for _, ctx := range append(capture.ctxs, capture.ctxs[0]) {
+		assert.Nil(method.Invoke([]interface{}{ctx, 1}).Error())
+	}
+	assert.Equal(2, calls)
+}
+
+// Ensures a message is processed again if the handler returned an error.
+func TestDeduplicationMiddlewareHandlerError(t *testing.T) {
+	assert := assert.New(t)
+	calls := 0
+	handlerErr := errors.New("error")
+	handler := &testSubscriber{handle: func(ctx FContext, x int) error {
+		calls++
+		if calls == 1 {
+			return handlerErr
+		}
+		return nil
+	}}
+	middleware := NewDeduplicationMiddleware(NewMemoryDeduplicationStore(), time.Minute)
+	method := NewMethod(handler, handler.handle, "handle", []ServiceMiddleware{middleware})
+
+	ctx := NewFContext("cid")
+	assert.Equal(handlerErr, method.Invoke([]interface{}{ctx, 1}).Error())
+	assert.Nil(method.Invoke([]interface{}{ctx, 1}).Error())
+	assert.Nil(method.Invoke([]interface{}{ctx, 1}).Error())
+	assert.Equal(2, calls)
+}
+
+// Ensures message ids expire from the memory store after the window.
+func TestMemoryDeduplicationStoreExpiration(t *testing.T) {
+	assert := assert.New(t)
+	store := NewMemoryDeduplicationStore()
+	assert.True(store.Add("foo", 10*time.Millisecond))
+	assert.False(store.Add("foo", 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+	assert.True(store.Add("foo", 10*time.Millisecond))
+	store.Remove("foo")
+	assert.True(store.Add("foo", 10*time.Millisecond))
+}
+
+type testSubscriber struct {
+	handle func(FContext, int) error
+}
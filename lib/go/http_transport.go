@@ -43,10 +43,17 @@ var newEncoder = func(buf *bytes.Buffer) io.WriteCloser {
 }
 
 // NewFrugalHandlerFunc is a function that creates a ready to use Frugal handler
-// function.
+// function. It can be mounted on any path of an http.ServeMux alongside other
+// routes. Requests which aren't POSTs are rejected with a 405, and requests
+// which can't be decoded with a 400.
 func NewFrugalHandlerFunc(processor FProcessor, protocolFactory *FProtocolFactory) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, fmt.Sprintf("Method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
 		w.Header().Add(contentTypeHeader, frugalContentType)
 
 		// Check for size limitation
@@ -93,7 +100,7 @@ func NewFrugalHandlerFunc(processor FProcessor, protocolFactory *FProtocolFactor
 		if err := processor.Process(iprot, oprot); err != nil {
 			http.Error(w,
 				fmt.Sprintf("Error processing request: %s", err),
-				http.StatusInternalServerError,
+				processErrorStatus(err),
 			)
 			return
 		}
@@ -138,6 +145,21 @@ func NewFrugalHandlerFunc(processor FProcessor, protocolFactory *FProtocolFactor
 	}
 }
 
+// processErrorStatus returns the HTTP status of a request an FProcessor
+// failed to process with the given error. Requests which couldn't be decoded
+// are bad requests, and other errors are internal server errors.
+func processErrorStatus(err error) int {
+	switch e := err.(type) {
+	case thrift.TProtocolException:
+		return http.StatusBadRequest
+	case thrift.TTransportException:
+		if e.TypeId() == TRANSPORT_EXCEPTION_END_OF_FILE {
+			return http.StatusBadRequest
+		}
+	}
+	return http.StatusInternalServerError
+}
+
 type GetHeadersWithContext func(FContext) map[string]string
 
 // FHTTPTransportBuilder configures and builds HTTP FTransport instances.
@@ -185,6 +185,52 @@ func TestFrugalHandlerFuncProcessorError(t *testing.T) {
 	)
 }
 
+// Ensures that processor errors decoding the request are routed back in the
+// http response as a 400 error.
+func TestFrugalHandlerFuncProtocolError(t *testing.T) {
+	assert := assert.New(t)
+	w := httptest.NewRecorder()
+
+	expectedBody := []byte{4, 5, 6, 7, 8}
+	framedBody := append([]byte{0, 1, 2, 3}, expectedBody...)
+	encodedBody := base64.StdEncoding.EncodeToString(framedBody)
+	r, err := http.NewRequest("POST", "fooUrl", strings.NewReader(encodedBody))
+	assert.Nil(err)
+
+	processorErr := thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("bad data"))
+	mockProcessor := &mockFProcessorForHTTP{expectedPayload: expectedBody, err: processorErr}
+	protocolFactory := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	handler := NewFrugalHandlerFunc(mockProcessor, protocolFactory)
+
+	handler(w, r)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(
+		fmt.Sprintf("Error processing request: %s\n", processorErr),
+		string(w.Body.Bytes()),
+	)
+}
+
+// Ensures that requests which aren't POSTs are rejected with a 405 error
+// without being processed.
+func TestFrugalHandlerFuncMethodNotAllowed(t *testing.T) {
+	assert := assert.New(t)
+	w := httptest.NewRecorder()
+
+	r, err := http.NewRequest("GET", "fooUrl", nil)
+	assert.Nil(err)
+
+	mockProcessor := &mockFProcessorForHTTP{err: fmt.Errorf("processed")}
+	protocolFactory := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	handler := NewFrugalHandlerFunc(mockProcessor, protocolFactory)
+
+	handler(w, r)
+
+	assert.Equal(http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(http.MethodPost, w.Header().Get("Allow"))
+	assert.Equal("Method GET not allowed\n", string(w.Body.Bytes()))
+}
+
 // Ensures that if the response payload exceeds the request limit, a
 // RequestEntityTooLarge error is returned.
 func TestFrugalHandlerFuncTooLargeError(t *testing.T) {
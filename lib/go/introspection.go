@@ -0,0 +1,278 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// FSubscriptionStats is a snapshot of an active subscription created by an
+// FScopeProvider with introspection enabled.
+type FSubscriptionStats struct {
+	Topic        string    `json:"topic"`
+	Messages     uint64    `json:"messages"`
+	SubscribedAt time.Time `json:"subscribed_at"`
+
+	// LastActivity is the time the last message was received, or the zero
+	// time if no message has been received.
+	LastActivity time.Time `json:"last_activity"`
+
+	// Processed is the number of received messages whose processing has
+	// finished, of which Failed returned an error.
+	Processed uint64 `json:"processed"`
+	Failed    uint64 `json:"failed"`
+
+	// AverageLatency and MaxLatency describe how long processing messages
+	// took, or are zero if no message has been processed.
+	AverageLatency time.Duration `json:"average_latency_ns"`
+	MaxLatency     time.Duration `json:"max_latency_ns"`
+
+	// Lag is the number of messages the subscription is behind the topic,
+	// or -1 if the transport doesn't implement
+	// FLagReportingSubscriberTransport.
+	Lag int64 `json:"lag"`
+}
+
+// FLagReportingSubscriberTransport is an FSubscriberTransport backed by a
+// persistent broker, such as Kafka or JetStream, which knows how far behind
+// the topic its subscription is.
+type FLagReportingSubscriberTransport interface {
+	FSubscriberTransport
+
+	// Lag returns the number of messages published to the topic which the
+	// subscription hasn't received yet. It's called after every message is
+	// processed, so it should be cheap, e.g. computed from the broker
+	// metadata of the last message received.
+	Lag() int64
+}
+
+// FSubscriptionMetrics describes the processing of a message received by a
+// subscription, as given to the hook set with
+// FScopeProvider.WithMetricsHook.
+type FSubscriptionMetrics struct {
+	Topic   string
+	Latency time.Duration
+	Err     error
+
+	// Lag is the number of messages the subscription is behind the topic
+	// after processing the message, or -1 if the transport doesn't report
+	// it.
+	Lag int64
+}
+
+// fSubscriptionRegistry tracks the active subscriptions of an FScopeProvider.
+type fSubscriptionRegistry struct {
+	mu            sync.Mutex
+	subscriptions map[*fTrackedSubscriberTransport]struct{}
+	metricsHook   func(FSubscriptionMetrics)
+}
+
+func newFSubscriptionRegistry() *fSubscriptionRegistry {
+	return &fSubscriptionRegistry{subscriptions: make(map[*fTrackedSubscriberTransport]struct{})}
+}
+
+func (r *fSubscriptionRegistry) add(t *fTrackedSubscriberTransport) {
+	r.mu.Lock()
+	r.subscriptions[t] = struct{}{}
+	r.mu.Unlock()
+}
+
+func (r *fSubscriptionRegistry) remove(t *fTrackedSubscriberTransport) {
+	r.mu.Lock()
+	delete(r.subscriptions, t)
+	r.mu.Unlock()
+}
+
+// stats returns a snapshot of the active subscriptions sorted by topic.
+func (r *fSubscriptionRegistry) stats() []FSubscriptionStats {
+	r.mu.Lock()
+	stats := make([]FSubscriptionStats, 0, len(r.subscriptions))
+	for t := range r.subscriptions {
+		stats = append(stats, t.stats())
+	}
+	r.mu.Unlock()
+	sort.Sort(subscriptionStatsByTopic(stats))
+	return stats
+}
+
+// subscriptionStatsByTopic sorts FSubscriptionStats by topic, then by
+// subscription time.
+type subscriptionStatsByTopic []FSubscriptionStats
+
+func (s subscriptionStatsByTopic) Len() int      { return len(s) }
+func (s subscriptionStatsByTopic) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s subscriptionStatsByTopic) Less(i, j int) bool {
+	if s[i].Topic == s[j].Topic {
+		return s[i].SubscribedAt.Before(s[j].SubscribedAt)
+	}
+	return s[i].Topic < s[j].Topic
+}
+
+// fTrackedSubscriberTransport wraps an FSubscriberTransport and records its
+// subscription in an fSubscriptionRegistry.
+type fTrackedSubscriberTransport struct {
+	FSubscriberTransport
+	registry     *fSubscriptionRegistry
+	mu           sync.Mutex
+	topic        string
+	subscribedAt time.Time
+	messages     uint64
+	lastActivity int64
+	processed    uint64
+	failed       uint64
+	totalLatency time.Duration
+	maxLatency   time.Duration
+}
+
+func newFTrackedSubscriberTransport(transport FSubscriberTransport, registry *fSubscriptionRegistry) *fTrackedSubscriberTransport {
+	return &fTrackedSubscriberTransport{FSubscriberTransport: transport, registry: registry}
+}
+
+// Subscribe subscribes the wrapped transport and registers the subscription.
+func (t *fTrackedSubscriberTransport) Subscribe(topic string, callback FAsyncCallback) error {
+	if err := t.FSubscriberTransport.Subscribe(topic, t.track(callback)); err != nil {
+		return err
+	}
+	t.register(topic)
+	return nil
+}
+
+// SubscribeFrom subscribes the wrapped transport at the given replay
+// position and registers the subscription.
+func (t *fTrackedSubscriberTransport) SubscribeFrom(topic string, from FReplayPosition, callback FAsyncCallback) error {
+	if err := SubscribeFrom(t.FSubscriberTransport, topic, from, t.track(callback)); err != nil {
+		return err
+	}
+	t.register(topic)
+	return nil
+}
+
+// Unsubscribe unsubscribes the wrapped transport and deregisters the
+// subscription.
+func (t *fTrackedSubscriberTransport) Unsubscribe() error {
+	t.registry.remove(t)
+	return t.FSubscriberTransport.Unsubscribe()
+}
+
+// Remove removes the wrapped transport, if supported, and deregisters the
+// subscription.
+func (t *fTrackedSubscriberTransport) Remove() error {
+	t.registry.remove(t)
+	if r, ok := t.FSubscriberTransport.(remover); ok {
+		return r.Remove()
+	}
+	return t.FSubscriberTransport.Unsubscribe()
+}
+
+func (t *fTrackedSubscriberTransport) register(topic string) {
+	t.mu.Lock()
+	t.topic = topic
+	t.subscribedAt = clock().Now()
+	t.mu.Unlock()
+	t.registry.add(t)
+}
+
+// track returns an FAsyncCallback which records message activity around
+// invoking the given callback.
+func (t *fTrackedSubscriberTransport) track(callback FAsyncCallback) FAsyncCallback {
+	return func(transport thrift.TTransport) error {
+		atomic.AddUint64(&t.messages, 1)
+		start := clock().Now()
+		atomic.StoreInt64(&t.lastActivity, start.UnixNano())
+		err := callback(transport)
+		t.record(since(start), err)
+		return err
+	}
+}
+
+// record tracks the processing of a message which took the given
+// latency, reporting it to the registry's metrics hook if there is one.
+func (t *fTrackedSubscriberTransport) record(latency time.Duration, err error) {
+	t.mu.Lock()
+	t.processed++
+	if err != nil {
+		t.failed++
+	}
+	t.totalLatency += latency
+	if latency > t.maxLatency {
+		t.maxLatency = latency
+	}
+	topic := t.topic
+	t.mu.Unlock()
+
+	if hook := t.registry.metricsHook; hook != nil {
+		hook(FSubscriptionMetrics{Topic: topic, Latency: latency, Err: err, Lag: t.lag()})
+	}
+}
+
+// lag returns the number of messages the subscription is behind the topic,
+// or -1 if the wrapped transport doesn't report it.
+func (t *fTrackedSubscriberTransport) lag() int64 {
+	if reporter, ok := t.FSubscriberTransport.(FLagReportingSubscriberTransport); ok {
+		return reporter.Lag()
+	}
+	return -1
+}
+
+func (t *fTrackedSubscriberTransport) stats() FSubscriptionStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := FSubscriptionStats{
+		Topic:        t.topic,
+		Messages:     atomic.LoadUint64(&t.messages),
+		SubscribedAt: t.subscribedAt,
+		Processed:    t.processed,
+		Failed:       t.failed,
+		MaxLatency:   t.maxLatency,
+		Lag:          t.lag(),
+	}
+	if t.processed > 0 {
+		stats.AverageLatency = t.totalLatency / time.Duration(t.processed)
+	}
+	if last := atomic.LoadInt64(&t.lastActivity); last != 0 {
+		stats.LastActivity = time.Unix(0, last)
+	}
+	return stats
+}
+
+// NewFIntrospectionHandlerFunc returns an http.HandlerFunc which serves the
+// active subscriptions of the given providers, keyed by name, as JSON.
+// Providers must have introspection enabled with WithIntrospection.
+func NewFIntrospectionHandlerFunc(providers map[string]*FScopeProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		response := make(map[string][]FSubscriptionStats, len(providers))
+		for name, provider := range providers {
+			subscriptions := provider.Subscriptions()
+			if subscriptions == nil {
+				subscriptions = []FSubscriptionStats{}
+			}
+			response[name] = subscriptions
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger().Errorf("frugal: error encoding introspection response: %s", err)
+		}
+	}
+}
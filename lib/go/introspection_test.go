@@ -0,0 +1,174 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIntrospectionTestProvider(transports ...FSubscriberTransport) *FScopeProvider {
+	factory := new(mockFSubscriberTransportFactory)
+	for _, transport := range transports {
+		factory.On("GetTransport").Return(transport).Once()
+	}
+	protoFactory := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	return NewFScopeProvider(nil, factory, protoFactory).WithIntrospection()
+}
+
+// Ensures providers with introspection enabled track subscriptions, message
+// counts, and activity.
+func TestProviderSubscriptions(t *testing.T) {
+	foo := &capturingSubscriberTransport{}
+	bar := &capturingSubscriberTransport{}
+	provider := newIntrospectionTestProvider(foo, bar)
+	callback := func(thrift.TTransport) error { return nil }
+
+	fooTransport, _ := provider.NewSubscriber()
+	assert.Nil(t, fooTransport.Subscribe("foo", callback))
+	barTransport, _ := provider.NewSubscriber()
+	assert.Nil(t, barTransport.Subscribe("bar", callback))
+	assert.Nil(t, foo.deliver(scopeFrame(t, NewFContext(""), "hello")[4:]))
+	assert.Nil(t, foo.deliver(scopeFrame(t, NewFContext(""), "world")[4:]))
+
+	stats := provider.Subscriptions()
+	assert.Len(t, stats, 2)
+	assert.Equal(t, "bar", stats[0].Topic)
+	assert.Equal(t, uint64(0), stats[0].Messages)
+	assert.True(t, stats[0].LastActivity.IsZero())
+	assert.Equal(t, "foo", stats[1].Topic)
+	assert.Equal(t, uint64(2), stats[1].Messages)
+	assert.False(t, stats[1].LastActivity.IsZero())
+	assert.False(t, stats[1].SubscribedAt.After(stats[1].LastActivity))
+
+	sub := NewFSubscription("foo", fooTransport)
+	assert.Nil(t, sub.Unsubscribe())
+	stats = provider.Subscriptions()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, "bar", stats[0].Topic)
+}
+
+// laggingSubscriberTransport is a capturingSubscriberTransport which reports
+// a fixed lag.
+type laggingSubscriberTransport struct {
+	capturingSubscriberTransport
+	lag int64
+}
+
+func (l *laggingSubscriberTransport) Lag() int64 {
+	return l.lag
+}
+
+// Ensures providers with introspection track processing counts, latency,
+// and the lag of transports which report it.
+func TestProviderSubscriptionsProcessing(t *testing.T) {
+	foo := &laggingSubscriberTransport{lag: 7}
+	bar := &capturingSubscriberTransport{}
+	provider := newIntrospectionTestProvider(foo, bar)
+	calls := 0
+	callback := func(thrift.TTransport) error {
+		calls++
+		time.Sleep(time.Duration(calls) * time.Millisecond)
+		if calls == 2 {
+			return errors.New("error")
+		}
+		return nil
+	}
+
+	fooTransport, _ := provider.NewSubscriber()
+	assert.Nil(t, fooTransport.Subscribe("foo", callback))
+	barTransport, _ := provider.NewSubscriber()
+	assert.Nil(t, barTransport.Subscribe("bar", callback))
+	assert.Nil(t, foo.deliver(scopeFrame(t, NewFContext(""), "hello")[4:]))
+	assert.Error(t, foo.deliver(scopeFrame(t, NewFContext(""), "world")[4:]))
+
+	stats := provider.Subscriptions()
+	assert.Equal(t, uint64(0), stats[0].Processed)
+	assert.Equal(t, time.Duration(0), stats[0].AverageLatency)
+	assert.Equal(t, int64(-1), stats[0].Lag)
+	assert.Equal(t, uint64(2), stats[1].Processed)
+	assert.Equal(t, uint64(1), stats[1].Failed)
+	assert.True(t, stats[1].MaxLatency >= 2*time.Millisecond)
+	assert.True(t, stats[1].AverageLatency >= 1500*time.Microsecond)
+	assert.True(t, stats[1].AverageLatency <= stats[1].MaxLatency)
+	assert.Equal(t, int64(7), stats[1].Lag)
+}
+
+// Ensures the metrics hook is called after every message is processed.
+func TestProviderMetricsHook(t *testing.T) {
+	foo := &laggingSubscriberTransport{lag: 3}
+	factory := new(mockFSubscriberTransportFactory)
+	factory.On("GetTransport").Return(foo)
+	metrics := []FSubscriptionMetrics{}
+	provider := NewFScopeProvider(nil, factory, nil).WithMetricsHook(func(m FSubscriptionMetrics) {
+		metrics = append(metrics, m)
+	})
+	err := errors.New("error")
+
+	transport, _ := provider.NewSubscriber()
+	assert.Nil(t, transport.Subscribe("foo", func(thrift.TTransport) error { return err }))
+	assert.Equal(t, err, foo.deliver(scopeFrame(t, NewFContext(""), "hello")[4:]))
+
+	assert.Len(t, metrics, 1)
+	assert.Equal(t, "foo", metrics[0].Topic)
+	assert.Equal(t, err, metrics[0].Err)
+	assert.Equal(t, int64(3), metrics[0].Lag)
+	assert.True(t, metrics[0].Latency >= 0)
+	assert.Len(t, provider.Subscriptions(), 1)
+}
+
+// Ensures providers without introspection do not track subscriptions.
+func TestProviderSubscriptionsDisabled(t *testing.T) {
+	transport := &capturingSubscriberTransport{}
+	factory := new(mockFSubscriberTransportFactory)
+	factory.On("GetTransport").Return(transport)
+	provider := NewFScopeProvider(nil, factory, nil)
+
+	subscriber, _ := provider.NewSubscriber()
+	assert.Equal(t, transport, subscriber)
+	assert.Nil(t, provider.Subscriptions())
+}
+
+// Ensures the introspection handler serves provider subscriptions as JSON.
+func TestIntrospectionHandlerFunc(t *testing.T) {
+	provider := newIntrospectionTestProvider(&capturingSubscriberTransport{})
+	transport, _ := provider.NewSubscriber()
+	assert.Nil(t, transport.Subscribe("foo", func(thrift.TTransport) error { return nil }))
+	handler := NewFIntrospectionHandlerFunc(map[string]*FScopeProvider{
+		"events": provider,
+		"empty":  newIntrospectionTestProvider(),
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/frugal", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var response map[string][]FSubscriptionStats
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response["events"], 1)
+	assert.Equal(t, "foo", response["events"][0].Topic)
+	assert.Equal(t, int64(-1), response["events"][0].Lag)
+	assert.Equal(t, []FSubscriptionStats{}, response["empty"])
+
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodPost, "/frugal", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -0,0 +1,293 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// FMemoryLog is an in-process log of the messages published to each topic,
+// which subscribers can replay from an offset or a time. Offsets start at
+// zero for each topic. It retains every message, so it's meant for tests and
+// single-process use rather than as a broker. Topics are matched exactly,
+// without wildcards.
+type FMemoryLog struct {
+	mu          sync.Mutex
+	topics      map[string][]memoryLogEntry
+	subscribers map[string][]*fMemoryLogSubscriberTransport
+}
+
+// memoryLogEntry is a message published to an FMemoryLog.
+type memoryLogEntry struct {
+	data      []byte
+	published time.Time
+}
+
+// NewFMemoryLog creates an empty FMemoryLog.
+func NewFMemoryLog() *FMemoryLog {
+	return &FMemoryLog{
+		topics:      make(map[string][]memoryLogEntry),
+		subscribers: make(map[string][]*fMemoryLogSubscriberTransport),
+	}
+}
+
+// append adds a message to the topic's log and returns its offset and the
+// subscribers of the topic to deliver it to.
+func (l *FMemoryLog) append(topic string, data []byte) (int64, []*fMemoryLogSubscriberTransport) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	offset := int64(len(l.topics[topic]))
+	l.topics[topic] = append(l.topics[topic], memoryLogEntry{
+		data:      append([]byte(nil), data...),
+		published: clock().Now(),
+	})
+	return offset, append([]*fMemoryLogSubscriberTransport(nil), l.subscribers[topic]...)
+}
+
+// entries returns the topic's messages starting at the given offset.
+func (l *FMemoryLog) entries(topic string, offset int64) []memoryLogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := l.topics[topic]
+	if offset >= int64(len(entries)) {
+		return nil
+	}
+	return entries[offset:]
+}
+
+// subscribe adds the subscriber to the topic and returns the offset of the
+// first message at the given position.
+func (l *FMemoryLog) subscribe(topic string, from FReplayPosition, s *fMemoryLogSubscriberTransport) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.subscribers[topic] = append(l.subscribers[topic], s)
+	entries := l.topics[topic]
+	if !from.IsTimestamp() {
+		if from.Offset() < 0 {
+			return 0
+		}
+		return from.Offset()
+	}
+	for i, entry := range entries {
+		if !entry.published.Before(from.Timestamp()) {
+			return int64(i)
+		}
+	}
+	return int64(len(entries))
+}
+
+// unsubscribe removes the subscriber from the topic.
+func (l *FMemoryLog) unsubscribe(topic string, s *fMemoryLogSubscriberTransport) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	subscribers := l.subscribers[topic]
+	for i, subscriber := range subscribers {
+		if subscriber == s {
+			l.subscribers[topic] = append(subscribers[:i:i], subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// end returns the offset the topic's next message is published at.
+func (l *FMemoryLog) end(topic string) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int64(len(l.topics[topic]))
+}
+
+// FMemoryLogPublisherTransportFactory creates FPublisherTransports publishing
+// to an FMemoryLog.
+type FMemoryLogPublisherTransportFactory struct {
+	log *FMemoryLog
+}
+
+// NewFMemoryLogPublisherTransportFactory creates an
+// FMemoryLogPublisherTransportFactory publishing to the given log.
+func NewFMemoryLogPublisherTransportFactory(log *FMemoryLog) *FMemoryLogPublisherTransportFactory {
+	return &FMemoryLogPublisherTransportFactory{log: log}
+}
+
+// GetTransport creates a new FPublisherTransport publishing to the log.
+func (f *FMemoryLogPublisherTransportFactory) GetTransport() FPublisherTransport {
+	return &fMemoryLogPublisherTransport{log: f.log}
+}
+
+// fMemoryLogPublisherTransport implements FConfirmingPublisherTransport.
+type fMemoryLogPublisherTransport struct {
+	log *FMemoryLog
+}
+
+// Open initializes the transport.
+func (m *fMemoryLogPublisherTransport) Open() error {
+	return nil
+}
+
+// IsOpen returns true, since the log is always available.
+func (m *fMemoryLogPublisherTransport) IsOpen() bool {
+	return true
+}
+
+// Close closes the transport.
+func (m *fMemoryLogPublisherTransport) Close() error {
+	return nil
+}
+
+// GetPublishSizeLimit returns 0, since the log's messages are unbounded.
+func (m *fMemoryLogPublisherTransport) GetPublishSizeLimit() uint {
+	return 0
+}
+
+// Publish appends the payload to the topic's log and delivers it to the
+// topic's subscribers. It's delivered before returning unless a subscriber is
+// delivering other messages, e.g. when publishing from a callback.
+func (m *fMemoryLogPublisherTransport) Publish(topic string, data []byte) error {
+	_, err := m.PublishConfirmed(topic, data)
+	return err
+}
+
+// PublishConfirmed publishes the payload like Publish. Since the log appends
+// it before returning, the message ID is its offset in the topic's log, which
+// can be replayed from with ReplayFromOffset.
+func (m *fMemoryLogPublisherTransport) PublishConfirmed(topic string, data []byte) (string, error) {
+	offset, subscribers := m.log.append(topic, data)
+	for _, subscriber := range subscribers {
+		subscriber.deliver()
+	}
+	return strconv.FormatInt(offset, 10), nil
+}
+
+// FMemoryLogSubscriberTransportFactory creates
+// FReplayableSubscriberTransports subscribing to an FMemoryLog.
+type FMemoryLogSubscriberTransportFactory struct {
+	log *FMemoryLog
+}
+
+// NewFMemoryLogSubscriberTransportFactory creates an
+// FMemoryLogSubscriberTransportFactory subscribing to the given log.
+func NewFMemoryLogSubscriberTransportFactory(log *FMemoryLog) *FMemoryLogSubscriberTransportFactory {
+	return &FMemoryLogSubscriberTransportFactory{log: log}
+}
+
+// GetTransport creates a new FReplayableSubscriberTransport subscribing to the
+// log.
+func (f *FMemoryLogSubscriberTransportFactory) GetTransport() FSubscriberTransport {
+	return &fMemoryLogSubscriberTransport{log: f.log}
+}
+
+// fMemoryLogSubscriberTransport implements FReplayableSubscriberTransport.
+// Messages are delivered in order, each once, by the subscribing goroutine
+// while replaying or by publishing goroutines.
+type fMemoryLogSubscriberTransport struct {
+	log          *FMemoryLog
+	mu           sync.Mutex
+	topic        string
+	callback     FAsyncCallback
+	next         int64 // Offset of the next message to deliver
+	delivering   bool  // Whether a goroutine is delivering messages
+	isSubscribed bool
+}
+
+// Subscribe sets the subscribe topic and opens the transport, delivering
+// messages published from now on.
+func (m *fMemoryLogSubscriberTransport) Subscribe(topic string, callback FAsyncCallback) error {
+	return m.SubscribeFrom(topic, ReplayFromOffset(m.log.end(topic)), callback)
+}
+
+// SubscribeFrom sets the subscribe topic and opens the transport, delivering
+// the messages retained from the given position before those published from
+// now on.
+func (m *fMemoryLogSubscriberTransport) SubscribeFrom(topic string, from FReplayPosition, callback FAsyncCallback) error {
+	m.mu.Lock()
+	if m.isSubscribed {
+		m.mu.Unlock()
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_ALREADY_OPEN,
+			"frugal: memory log transport already open")
+	}
+	if topic == "" {
+		m.mu.Unlock()
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_UNKNOWN,
+			"cannot subscribe to empty subject")
+	}
+	m.topic = topic
+	m.callback = callback
+	m.isSubscribed = true
+	m.next = m.log.subscribe(topic, from, m)
+	m.mu.Unlock()
+
+	m.deliver()
+	return nil
+}
+
+// deliver calls the callback with the messages of the topic the subscriber
+// hasn't delivered yet, unless another goroutine is already delivering them,
+// e.g. when the callback publishes to the topic. The callback is called
+// without holding the lock, so it can publish or unsubscribe.
+func (m *fMemoryLogSubscriberTransport) deliver() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.delivering {
+		return
+	}
+	m.delivering = true
+	for m.isSubscribed {
+		entries := m.log.entries(m.topic, m.next)
+		if len(entries) == 0 {
+			break
+		}
+		m.next++
+		callback := m.callback
+		m.mu.Unlock()
+		deliverMemoryLogEntry(entries[0], callback)
+		m.mu.Lock()
+	}
+	m.delivering = false
+}
+
+// deliverMemoryLogEntry calls the callback with the frame of the message.
+func deliverMemoryLogEntry(entry memoryLogEntry, callback FAsyncCallback) {
+	if len(entry.data) < 4 {
+		logger().Warn("frugal: Discarding invalid scope message frame")
+		return
+	}
+	transport := &thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(entry.data[4:])}
+	if err := callback(transport); err != nil {
+		logger().Warn("frugal: error executing callback: ", err)
+	}
+}
+
+// IsSubscribed returns true if the transport is subscribed to a topic, false
+// otherwise.
+func (m *fMemoryLogSubscriberTransport) IsSubscribed() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.isSubscribed
+}
+
+// Unsubscribe stops delivering messages and closes the transport.
+func (m *fMemoryLogSubscriberTransport) Unsubscribe() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.isSubscribed {
+		return nil
+	}
+	m.log.unsubscribe(m.topic, m)
+	m.isSubscribed = false
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryLogFrame frames the payload like a published scope message.
+func memoryLogFrame(payload string) []byte {
+	return append([]byte{0, 0, 0, byte(len(payload))}, payload...)
+}
+
+// memoryLogReceiver records the payloads of the messages it receives.
+type memoryLogReceiver struct {
+	received []string
+}
+
+func (r *memoryLogReceiver) callback(transport thrift.TTransport) error {
+	payload, err := ioutil.ReadAll(transport)
+	r.received = append(r.received, string(payload))
+	return err
+}
+
+func publishMemoryLog(t *testing.T, log *FMemoryLog, topic string, payloads ...string) {
+	transport := NewFMemoryLogPublisherTransportFactory(log).GetTransport()
+	for _, payload := range payloads {
+		assert.Nil(t, transport.Publish(topic, memoryLogFrame(payload)))
+	}
+}
+
+// Ensures a subscriber replays the topic's messages from an offset before
+// receiving those published afterwards.
+func TestMemoryLogReplayFromOffset(t *testing.T) {
+	log := NewFMemoryLog()
+	publishMemoryLog(t, log, "foo", "a", "b", "c")
+	publishMemoryLog(t, log, "bar", "x")
+
+	receiver := &memoryLogReceiver{}
+	transport := NewFMemoryLogSubscriberTransportFactory(log).GetTransport()
+	assert.Nil(t, SubscribeFrom(transport, "foo", ReplayFromOffset(1), receiver.callback))
+	assert.Equal(t, []string{"b", "c"}, receiver.received)
+
+	publishMemoryLog(t, log, "foo", "d")
+	assert.Equal(t, []string{"b", "c", "d"}, receiver.received)
+}
+
+// Ensures a subscriber replays the messages published at or after a time.
+func TestMemoryLogReplayFromTimestamp(t *testing.T) {
+	clock := NewFSimulatedClock(simulatedClockStart)
+	SetClock(clock)
+	defer SetClock(nil)
+	log := NewFMemoryLog()
+	publishMemoryLog(t, log, "foo", "a")
+	clock.Advance(time.Minute)
+	publishMemoryLog(t, log, "foo", "b")
+
+	receiver := &memoryLogReceiver{}
+	transport := NewFMemoryLogSubscriberTransportFactory(log).GetTransport()
+	from := ReplayFromTimestamp(simulatedClockStart.Add(time.Second))
+	assert.Nil(t, SubscribeFrom(transport, "foo", from, receiver.callback))
+	assert.Equal(t, []string{"b"}, receiver.received)
+}
+
+// Ensures Subscribe only receives messages published afterwards, until
+// unsubscribed.
+func TestMemoryLogSubscribe(t *testing.T) {
+	log := NewFMemoryLog()
+	publishMemoryLog(t, log, "foo", "a")
+
+	receiver := &memoryLogReceiver{}
+	transport := NewFMemoryLogSubscriberTransportFactory(log).GetTransport()
+	assert.Nil(t, transport.Subscribe("foo", receiver.callback))
+	assert.True(t, transport.IsSubscribed())
+	err := transport.Subscribe("foo", receiver.callback)
+	assert.Equal(t, TRANSPORT_EXCEPTION_ALREADY_OPEN, err.(thrift.TTransportException).TypeId())
+
+	publishMemoryLog(t, log, "foo", "b")
+	assert.Nil(t, transport.Unsubscribe())
+	assert.False(t, transport.IsSubscribed())
+	publishMemoryLog(t, log, "foo", "c")
+	assert.Equal(t, []string{"b"}, receiver.received)
+}
+
+// Ensures messages published by a callback are delivered in order after the
+// message being handled.
+func TestMemoryLogPublishFromCallback(t *testing.T) {
+	log := NewFMemoryLog()
+	receiver := &memoryLogReceiver{}
+	transport := NewFMemoryLogSubscriberTransportFactory(log).GetTransport()
+	assert.Nil(t, transport.Subscribe("foo", func(frame thrift.TTransport) error {
+		if err := receiver.callback(frame); err != nil {
+			return err
+		}
+		if len(receiver.received) == 1 {
+			publishMemoryLog(t, log, "foo", "c")
+		}
+		return nil
+	}))
+
+	publishMemoryLog(t, log, "foo", "a", "b")
+	assert.Equal(t, []string{"a", "c", "b"}, receiver.received)
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+// FContractMetadata describes the IDL contract a scope or service was
+// generated from. Generated code exposes an FContractMetadata for each scope
+// and service, which can be used for version checks, routing tables, and
+// observability labels.
+type FContractMetadata struct {
+	// IDLFile is the name of the frugal file defining the contract.
+	IDLFile string `json:"idl_file"`
+
+	// Kind is either "scope" or "service".
+	Kind string `json:"kind"`
+
+	// Name is the name of the scope or service.
+	Name string `json:"name"`
+
+	// Hash is a hash of the contract which ignores comments and formatting.
+	Hash string `json:"hash"`
+
+	// CompilerVersion is the version of the frugal compiler which generated
+	// the code.
+	CompilerVersion string `json:"compiler_version"`
+
+	// Operations are the names of the scope's operations or the service's
+	// methods, in the order they are defined.
+	Operations []string `json:"operations"`
+
+	// Descriptions maps the names of operations documented in the IDL to
+	// their doc comments, e.g. for display in admin UIs and debugging tools.
+	Descriptions map[string]string `json:"descriptions,omitempty"`
+}
+
+// Matches returns true if the contract has the given hash, i.e. the other
+// side of the scope or service was built from the same contract.
+func (m *FContractMetadata) Matches(hash string) bool {
+	return m.Hash == hash
+}
+
+// HasOperation returns true if the contract defines an operation or method
+// with the given name.
+func (m *FContractMetadata) HasOperation(name string) bool {
+	for _, operation := range m.Operations {
+		if operation == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Description returns the doc comment of the operation or method with the
+// given name, or an empty string if it isn't documented.
+func (m *FContractMetadata) Description(name string) string {
+	return m.Descriptions[name]
+}
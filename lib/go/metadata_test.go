@@ -0,0 +1,46 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures Matches compares the contract hash.
+func TestContractMetadataMatches(t *testing.T) {
+	metadata := &FContractMetadata{Kind: "scope", Name: "Events", Hash: "abc"}
+	assert.True(t, metadata.Matches("abc"))
+	assert.False(t, metadata.Matches("def"))
+}
+
+// Ensures HasOperation checks the contract's operations.
+func TestContractMetadataHasOperation(t *testing.T) {
+	metadata := &FContractMetadata{Kind: "service", Name: "Foo", Operations: []string{"ping", "blah"}}
+	assert.True(t, metadata.HasOperation("blah"))
+	assert.False(t, metadata.HasOperation("Blah"))
+}
+
+// Ensures Description returns the doc comment of documented operations.
+func TestContractMetadataDescription(t *testing.T) {
+	metadata := &FContractMetadata{
+		Kind:         "scope",
+		Name:         "Events",
+		Operations:   []string{"EventCreated", "EventDeleted"},
+		Descriptions: map[string]string{"EventCreated": "An event was created."},
+	}
+	assert.Equal(t, "An event was created.", metadata.Description("EventCreated"))
+	assert.Equal(t, "", metadata.Description("EventDeleted"))
+}
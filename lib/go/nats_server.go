@@ -154,7 +154,7 @@ func (f *fNatsServer) handler(msg *nats.Msg) {
 		return
 	}
 	select {
-	case f.workC <- &frameWrapper{frameBytes: msg.Data, timestamp: time.Now(), reply: msg.Reply}:
+	case f.workC <- &frameWrapper{frameBytes: msg.Data, timestamp: clock().Now(), reply: msg.Reply}:
 	case <-f.quit:
 		return
 	}
@@ -168,7 +168,7 @@ func (f *fNatsServer) worker() {
 		case <-f.quit:
 			return
 		case frame := <-f.workC:
-			dur := time.Since(frame.timestamp)
+			dur := since(frame.timestamp)
 			if dur > f.highWatermark {
 				logger().Warnf("frugal: request spent %+v in the transport buffer, your consumer might be backed up", dur)
 			}
@@ -16,7 +16,6 @@ package frugal
 import (
 	"bytes"
 	"fmt"
-	"time"
 
 	"git.apache.org/thrift.git/lib/go/thrift"
 	"github.com/nats-io/go-nats"
@@ -166,7 +165,7 @@ func (f *fNatsTransport) Request(ctx FContext, data []byte) (thrift.TTransport,
 	select {
 	case result := <-resultC:
 		return &thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(result)}, nil
-	case <-time.After(ctx.Timeout()):
+	case <-clock().After(ctx.Timeout()):
 		return nil, thrift.NewTTransportException(TRANSPORT_EXCEPTION_TIMED_OUT, "frugal: nats request timed out")
 	}
 }
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// NewOpenTracingTracer returns an FTracer starting spans with the given
+// OpenTracing tracer. Span contexts are propagated through request headers in
+// the TextMap format. OpenTelemetry is supported through its OpenTracing
+// bridge.
+func NewOpenTracingTracer(tracer opentracing.Tracer) FTracer {
+	return &openTracingTracer{tracer: tracer}
+}
+
+type openTracingTracer struct {
+	tracer opentracing.Tracer
+}
+
+// StartSpan starts an OpenTracing span with the given operation name and
+// kind, which is a child of the span whose context is extracted from the
+// given headers, if any.
+func (o *openTracingTracer) StartSpan(operation string, kind FSpanKind, headers map[string]string) FSpan {
+	options := []opentracing.StartSpanOption{opentracing.Tag{Key: string(ext.SpanKind), Value: kind.String()}}
+	if parent, err := o.tracer.Extract(opentracing.TextMap, opentracing.TextMapCarrier(headers)); err == nil {
+		options = append(options, opentracing.ChildOf(parent))
+	}
+	return &openTracingSpan{tracer: o.tracer, span: o.tracer.StartSpan(operation, options...)}
+}
+
+type openTracingSpan struct {
+	tracer opentracing.Tracer
+	span   opentracing.Span
+}
+
+// SetTag sets a tag on the span.
+func (o *openTracingSpan) SetTag(key string, value interface{}) {
+	o.span.SetTag(key, value)
+}
+
+// Inject adds the span's context to the given headers in the TextMap format.
+func (o *openTracingSpan) Inject(headers map[string]string) {
+	if err := o.tracer.Inject(o.span.Context(), opentracing.TextMap, opentracing.TextMapCarrier(headers)); err != nil {
+		logger().Warnf("frugal: unable to inject span context: %s", err)
+	}
+}
+
+// Finish ends the span. A non-nil error sets the error tag and is logged to
+// the span.
+func (o *openTracingSpan) Finish(err error) {
+	if err != nil {
+		ext.Error.Set(o.span, true)
+		o.span.LogFields(log.Error(err))
+	}
+	o.span.Finish()
+}
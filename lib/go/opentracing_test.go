@@ -0,0 +1,53 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures the OpenTracing tracer propagates the publish span's context to the
+// receive span and records the span kinds, tags, and errors.
+func TestOpenTracingTracer(t *testing.T) {
+	assert := assert.New(t)
+	tracer := mocktracer.New()
+	SetTracer(NewOpenTracingTracer(tracer))
+	defer SetTracer(nil)
+
+	ctx := NewFContext("cid")
+	StartPublishSpan(ctx, "Events", "EventCreated").Finish(nil)
+	StartReceiveSpan(ctx, "Events", "EventCreated").Finish(errors.New("error"))
+
+	spans := tracer.FinishedSpans()
+	assert.Len(spans, 2)
+	publish, receive := spans[0], spans[1]
+	assert.Equal("Events.EventCreated", publish.OperationName)
+	assert.Equal(0, publish.ParentID)
+	assert.Equal(map[string]interface{}{
+		"span.kind":             "producer",
+		"frugal.scope":          "Events",
+		"frugal.operation":      "EventCreated",
+		"frugal.correlation_id": "cid",
+	}, publish.Tags())
+
+	assert.Equal(publish.SpanContext.SpanID, receive.ParentID)
+	assert.Equal(publish.SpanContext.TraceID, receive.SpanContext.TraceID)
+	assert.Equal("consumer", receive.Tag("span.kind"))
+	assert.Equal(true, receive.Tag("error"))
+	assert.Len(receive.Logs(), 1)
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"hash/fnv"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// topicHeaderPrefix is prepended to the name of a scope prefix variable to
+// form the request header generated publishers set its value in.
+const topicHeaderPrefix = "_topic_"
+
+// FOrderedSubscriberTransportFactory produces FSubscriberTransports which
+// process received messages concurrently on a fixed number of ordered worker
+// lanes. Messages are hashed onto a lane using the values of scope prefix
+// variables, e.g. a tenant ID, so messages sharing those values are always
+// processed in the order they were received while messages for other values
+// are processed in parallel.
+type FOrderedSubscriberTransportFactory struct {
+	factory   FSubscriberTransportFactory
+	lanes     uint
+	queueLen  uint
+	variables []string
+}
+
+// NewFOrderedSubscriberTransportFactory creates an
+// FOrderedSubscriberTransportFactory wrapping the given factory. Messages are
+// ordered by the values of the given scope prefix variables. If no variables
+// are given, all prefix variables present on a message are used.
+func NewFOrderedSubscriberTransportFactory(factory FSubscriberTransportFactory,
+	lanes uint, variables ...string) *FOrderedSubscriberTransportFactory {
+	if lanes == 0 {
+		lanes = 1
+	}
+	return &FOrderedSubscriberTransportFactory{
+		factory:   factory,
+		lanes:     lanes,
+		queueLen:  defaultWorkQueueLen,
+		variables: variables,
+	}
+}
+
+// WithQueueLength controls the number of messages buffered per lane.
+func (f *FOrderedSubscriberTransportFactory) WithQueueLength(queueLength uint) *FOrderedSubscriberTransportFactory {
+	f.queueLen = queueLength
+	return f
+}
+
+// GetTransport returns a new ordered FSubscriberTransport.
+func (f *FOrderedSubscriberTransportFactory) GetTransport() FSubscriberTransport {
+	return &fOrderedSubscriberTransport{
+		FSubscriberTransport: f.factory.GetTransport(),
+		lanes:                f.lanes,
+		queueLen:             f.queueLen,
+		variables:            f.variables,
+	}
+}
+
+// fOrderedSubscriberTransport implements FSubscriberTransport by dispatching
+// the messages received by the wrapped transport onto ordered worker lanes.
+type fOrderedSubscriberTransport struct {
+	FSubscriberTransport
+	lanes     uint
+	queueLen  uint
+	variables []string
+	mu        sync.Mutex
+	laneMu    sync.RWMutex
+	workCs    []chan []byte
+	wg        sync.WaitGroup
+}
+
+// Subscribe starts the worker lanes and subscribes the wrapped transport to
+// the topic.
+func (o *fOrderedSubscriberTransport) Subscribe(topic string, callback FAsyncCallback) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.laneMu.RLock()
+	subscribed := o.workCs != nil
+	o.laneMu.RUnlock()
+	if subscribed {
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_ALREADY_OPEN,
+			"frugal: ordered subscriber transport already subscribed")
+	}
+
+	workCs := make([]chan []byte, o.lanes)
+	for i := range workCs {
+		workCs[i] = make(chan []byte, o.queueLen)
+		o.wg.Add(1)
+		go o.worker(workCs[i], callback)
+	}
+	o.laneMu.Lock()
+	o.workCs = workCs
+	o.laneMu.Unlock()
+	if err := o.FSubscriberTransport.Subscribe(topic, o.dispatch); err != nil {
+		o.closeLanes()
+		return err
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes the wrapped transport and stops the worker lanes
+// once any buffered messages have been processed.
+func (o *fOrderedSubscriberTransport) Unsubscribe() error {
+	return o.stop(o.FSubscriberTransport.Unsubscribe)
+}
+
+// Remove unsubscribes and removes durably stored information on the broker,
+// if applicable, and stops the worker lanes.
+func (o *fOrderedSubscriberTransport) Remove() error {
+	if r, ok := o.FSubscriberTransport.(remover); ok {
+		return o.stop(r.Remove)
+	}
+	return o.Unsubscribe()
+}
+
+func (o *fOrderedSubscriberTransport) stop(unsubscribe func() error) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err := unsubscribe(); err != nil {
+		return err
+	}
+	o.closeLanes()
+	return nil
+}
+
+// closeLanes closes the worker lanes and waits for them to process any
+// buffered messages.
+func (o *fOrderedSubscriberTransport) closeLanes() {
+	o.laneMu.Lock()
+	for _, workC := range o.workCs {
+		close(workC)
+	}
+	o.workCs = nil
+	o.laneMu.Unlock()
+	o.wg.Wait()
+}
+
+// dispatch is the FAsyncCallback subscribed on the wrapped transport. It
+// places each received frame on the lane selected by its ordering key.
+func (o *fOrderedSubscriberTransport) dispatch(transport thrift.TTransport) error {
+	frame, err := ioutil.ReadAll(transport)
+	if err != nil {
+		return thrift.NewTTransportExceptionFromError(err)
+	}
+	headers, err := getHeadersFromFrame(frame)
+	if err != nil {
+		return err
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(o.orderingKey(headers)))
+
+	o.laneMu.RLock()
+	defer o.laneMu.RUnlock()
+	if len(o.workCs) == 0 {
+		logger().Warn("frugal: discarding message received after unsubscribing")
+		return nil
+	}
+	o.workCs[hash.Sum32()%uint32(len(o.workCs))] <- frame
+	return nil
+}
+
+// orderingKey returns the values of the ordering prefix variables in the
+// given request headers.
+func (o *fOrderedSubscriberTransport) orderingKey(headers map[string]string) string {
+	variables := o.variables
+	if len(variables) == 0 {
+		for name := range headers {
+			if strings.HasPrefix(name, topicHeaderPrefix) {
+				variables = append(variables, strings.TrimPrefix(name, topicHeaderPrefix))
+			}
+		}
+		sort.Strings(variables)
+	}
+	values := make([]string, len(variables))
+	for i, variable := range variables {
+		values[i] = headers[topicHeaderPrefix+variable]
+	}
+	return strings.Join(values, "\x00")
+}
+
+func (o *fOrderedSubscriberTransport) worker(workC <-chan []byte, callback FAsyncCallback) {
+	defer o.wg.Done()
+	for frame := range workC {
+		transport := &thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(frame)}
+		if err := callback(transport); err != nil {
+			logger().Warn("frugal: error executing callback: ", err)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// capturingSubscriberTransport is an FSubscriberTransport which captures the
+// subscribed callback so tests can deliver frames to it directly.
+type capturingSubscriberTransport struct {
+	mu           sync.Mutex
+	topic        string
+	callback     FAsyncCallback
+	subscribeErr error
+}
+
+func (c *capturingSubscriberTransport) Subscribe(topic string, callback FAsyncCallback) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.subscribeErr != nil {
+		return c.subscribeErr
+	}
+	c.topic = topic
+	c.callback = callback
+	return nil
+}
+
+func (c *capturingSubscriberTransport) Unsubscribe() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.callback = nil
+	return nil
+}
+
+func (c *capturingSubscriberTransport) IsSubscribed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.callback != nil
+}
+
+func (c *capturingSubscriberTransport) deliver(frame []byte) error {
+	c.mu.Lock()
+	callback := c.callback
+	c.mu.Unlock()
+	return callback(&thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(frame)})
+}
+
+type capturingSubscriberTransportFactory struct {
+	transport FSubscriberTransport
+}
+
+func (c *capturingSubscriberTransportFactory) GetTransport() FSubscriberTransport {
+	return c.transport
+}
+
+// scopeFrame returns a scope message frame, including the frame size, whose
+// request headers are those of the given FContext and whose payload is the
+// given string.
+func scopeFrame(t *testing.T, ctx FContext, payload string) []byte {
+	buffer := NewTMemoryOutputBuffer(0)
+	proto := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault()).GetProtocol(buffer)
+	if err := proto.WriteRequestHeader(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := proto.WriteString(payload); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+// Ensures messages with the same ordering key are processed in the order they
+// were received.
+func TestOrderedSubscriberTransportPreservesOrderPerKey(t *testing.T) {
+	assert := assert.New(t)
+	wrapped := &capturingSubscriberTransport{}
+	factory := NewFOrderedSubscriberTransportFactory(
+		&capturingSubscriberTransportFactory{wrapped}, 4, "tenant")
+	transport := factory.GetTransport()
+
+	var (
+		mu       sync.Mutex
+		received = make(map[string][]string)
+	)
+	pf := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	callback := func(tr thrift.TTransport) error {
+		proto := pf.GetProtocol(tr)
+		ctx, err := proto.ReadRequestHeader()
+		if err != nil {
+			return err
+		}
+		payload, err := proto.ReadString()
+		if err != nil {
+			return err
+		}
+		tenant, _ := ctx.RequestHeader(topicHeaderPrefix + "tenant")
+		mu.Lock()
+		received[tenant] = append(received[tenant], payload)
+		mu.Unlock()
+		return nil
+	}
+	assert.Nil(transport.Subscribe("foo", callback))
+	assert.Equal("foo", wrapped.topic)
+
+	expected := make(map[string][]string)
+	for i := 0; i < 50; i++ {
+		for _, tenant := range []string{"a", "b", "c"} {
+			payload := string(rune('0' + i%10))
+			expected[tenant] = append(expected[tenant], payload)
+			ctx := NewFContext("")
+			ctx.AddRequestHeader(topicHeaderPrefix+"tenant", tenant)
+			ctx.AddRequestHeader(topicHeaderPrefix+"other", payload)
+			assert.Nil(wrapped.deliver(scopeFrame(t, ctx, payload)[4:]))
+		}
+	}
+
+	assert.Nil(transport.Unsubscribe())
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(expected, received)
+}
+
+// Ensures the ordering key uses all prefix variables when none are specified.
+func TestOrderedSubscriberTransportDefaultOrderingKey(t *testing.T) {
+	transport := &fOrderedSubscriberTransport{}
+	key := transport.orderingKey(map[string]string{
+		topicHeaderPrefix + "b": "2",
+		topicHeaderPrefix + "a": "1",
+		cidHeader:               "cid",
+	})
+	assert.Equal(t, "1\x002", key)
+}
+
+// Ensures an error is returned and the lanes are stopped if the wrapped
+// transport fails to subscribe.
+func TestOrderedSubscriberTransportSubscribeError(t *testing.T) {
+	err := errors.New("error")
+	wrapped := &capturingSubscriberTransport{subscribeErr: err}
+	transport := NewFOrderedSubscriberTransportFactory(
+		&capturingSubscriberTransportFactory{wrapped}, 2).GetTransport()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- transport.Subscribe("foo", func(thrift.TTransport) error { return nil })
+	}()
+	select {
+	case subscribeErr := <-done:
+		assert.Equal(t, err, subscribeErr)
+	case <-time.After(time.Second):
+		t.Fatal("Expected Subscribe to return")
+	}
+	assert.False(t, transport.IsSubscribed())
+}
@@ -0,0 +1,311 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+const (
+	// How often an FOutboxRelay publishes pending messages by default
+	defaultOutboxInterval = time.Second
+
+	// How many pending messages an FOutboxRelay reads at a time by default
+	defaultOutboxBatchSize = 100
+)
+
+// FOutboxMessage is a scope message stored in an outbox until it's
+// published.
+type FOutboxMessage struct {
+	ID      string
+	Topic   string
+	Data    []byte
+	Created time.Time
+}
+
+// FOutboxStore persists the messages of an outbox, typically in a table of
+// the database a service writes its state to, so messages are stored
+// atomically with the state changes they describe. Implementations must be
+// threadsafe.
+type FOutboxStore interface {
+	// Add stores the message as part of the given transaction, which is
+	// whatever the implementation uses to write atomically with the caller's
+	// other changes, e.g. a *sql.Tx. The message must only become pending
+	// once the transaction commits.
+	Add(tx interface{}, message *FOutboxMessage) error
+
+	// Pending returns up to the given number of pending messages, oldest
+	// first.
+	Pending(limit int) ([]*FOutboxMessage, error)
+
+	// Remove removes the message with the given ID once it's published.
+	Remove(id string) error
+}
+
+// FOutboxPublisherTransportFactory produces FPublisherTransports which add
+// messages to an outbox within a transaction instead of publishing them. An
+// FOutboxRelay publishes them once the transaction commits.
+type FOutboxPublisherTransportFactory struct {
+	store     FOutboxStore
+	tx        interface{}
+	sizeLimit uint
+}
+
+// NewFOutboxPublisherTransportFactory creates an
+// FOutboxPublisherTransportFactory adding messages to the store within the
+// given transaction. A publisher is typically created for each transaction
+// from an FScopeProvider using it.
+func NewFOutboxPublisherTransportFactory(store FOutboxStore, tx interface{}) *FOutboxPublisherTransportFactory {
+	return &FOutboxPublisherTransportFactory{store: store, tx: tx}
+}
+
+// WithPublishSizeLimit limits the size of the messages which can be added to
+// the outbox, which should match the limit of the transport relaying them.
+func (f *FOutboxPublisherTransportFactory) WithPublishSizeLimit(limit uint) *FOutboxPublisherTransportFactory {
+	f.sizeLimit = limit
+	return f
+}
+
+// GetTransport creates a new outbox FPublisherTransport.
+func (f *FOutboxPublisherTransportFactory) GetTransport() FPublisherTransport {
+	return &fOutboxPublisherTransport{store: f.store, tx: f.tx, sizeLimit: f.sizeLimit}
+}
+
+// fOutboxPublisherTransport implements FPublisherTransport by adding messages
+// to an outbox. It has no connection, so it's always open.
+type fOutboxPublisherTransport struct {
+	store     FOutboxStore
+	tx        interface{}
+	sizeLimit uint
+}
+
+// Open initializes the transport.
+func (o *fOutboxPublisherTransport) Open() error {
+	return nil
+}
+
+// Close closes the transport.
+func (o *fOutboxPublisherTransport) Close() error {
+	return nil
+}
+
+// IsOpen returns true since the transport is always open.
+func (o *fOutboxPublisherTransport) IsOpen() bool {
+	return true
+}
+
+// GetPublishSizeLimit returns the maximum allowable size of a payload
+// to be published. A non-positive number is returned to indicate an
+// unbounded allowable size.
+func (o *fOutboxPublisherTransport) GetPublishSizeLimit() uint {
+	return o.sizeLimit
+}
+
+// Publish adds the given payload to the outbox within the transaction.
+func (o *fOutboxPublisherTransport) Publish(topic string, data []byte) error {
+	if o.sizeLimit > 0 && uint(len(data)) > o.sizeLimit {
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_REQUEST_TOO_LARGE,
+			fmt.Sprintf("Message exceeds %d bytes, was %d bytes", o.sizeLimit, len(data)))
+	}
+
+	// The payload's buffer may be reused once the publish returns.
+	message := &FOutboxMessage{
+		ID:      generateCorrelationID(),
+		Topic:   topic,
+		Data:    append([]byte(nil), data...),
+		Created: clock().Now(),
+	}
+	return o.store.Add(o.tx, message)
+}
+
+// FOutboxRelay publishes the pending messages of an outbox in the background,
+// removing each once it's published. Messages are published in order, and a
+// message which fails to publish is retried before any later ones. Since a
+// message is published again if it can't be removed, delivery is
+// at-least-once, and subscribers should use NewDeduplicationMiddleware.
+type FOutboxRelay struct {
+	store     FOutboxStore
+	transport FPublisherTransport
+	interval  time.Duration
+	batchSize int
+
+	mu      sync.Mutex // Serializes flushes
+	startMu sync.Mutex // Guards quit and done
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// NewFOutboxRelay creates an FOutboxRelay publishing the messages of the
+// store with the given transport every second. If the transport implements
+// FConfirmingPublisherTransport, messages are only removed once the broker
+// acknowledges them.
+func NewFOutboxRelay(store FOutboxStore, transport FPublisherTransport) *FOutboxRelay {
+	return &FOutboxRelay{
+		store:     store,
+		transport: transport,
+		interval:  defaultOutboxInterval,
+		batchSize: defaultOutboxBatchSize,
+	}
+}
+
+// WithInterval configures how often the FOutboxRelay publishes pending
+// messages.
+func (r *FOutboxRelay) WithInterval(interval time.Duration) *FOutboxRelay {
+	r.interval = interval
+	return r
+}
+
+// WithBatchSize configures how many pending messages the FOutboxRelay reads
+// from the store at a time.
+func (r *FOutboxRelay) WithBatchSize(batchSize int) *FOutboxRelay {
+	r.batchSize = batchSize
+	return r
+}
+
+// Start opens the transport if it isn't open and publishes pending messages
+// in the background until Stop is called.
+func (r *FOutboxRelay) Start() error {
+	r.startMu.Lock()
+	defer r.startMu.Unlock()
+	if r.quit != nil {
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_ALREADY_OPEN,
+			"frugal: outbox relay already started")
+	}
+	if !r.transport.IsOpen() {
+		if err := r.transport.Open(); err != nil {
+			return err
+		}
+	}
+
+	r.quit = make(chan struct{})
+	r.done = make(chan struct{})
+	go r.run(r.quit, r.done)
+	return nil
+}
+
+// Stop stops publishing pending messages, waiting for a publish in progress
+// to finish. The transport is left open.
+func (r *FOutboxRelay) Stop() error {
+	r.startMu.Lock()
+	defer r.startMu.Unlock()
+	if r.quit == nil {
+		return nil
+	}
+	close(r.quit)
+	<-r.done
+	r.quit = nil
+	r.done = nil
+	return nil
+}
+
+// run flushes the outbox every interval until quit is closed.
+func (r *FOutboxRelay) run(quit, done chan struct{}) {
+	defer close(done)
+	ticker := clock().NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-quit:
+			return
+		case <-ticker.C():
+			if _, err := r.Flush(); err != nil {
+				logger().Errorf("frugal: error relaying outbox messages: %s", err.Error())
+			}
+		}
+	}
+}
+
+// Flush publishes the pending messages until none remain or one fails,
+// returning how many were published.
+func (r *FOutboxRelay) Flush() (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	published := 0
+	for {
+		messages, err := r.store.Pending(r.batchSize)
+		if err != nil {
+			return published, err
+		}
+		for _, message := range messages {
+			if err := r.publish(message); err != nil {
+				return published, err
+			}
+			if err := r.store.Remove(message.ID); err != nil {
+				return published, err
+			}
+			published++
+		}
+		if len(messages) == 0 || len(messages) < r.batchSize {
+			return published, nil
+		}
+	}
+}
+
+// publish publishes the message, waiting for the broker to acknowledge it if
+// the transport supports confirmation.
+func (r *FOutboxRelay) publish(message *FOutboxMessage) error {
+	if confirmsPublishes(r.transport) {
+		_, err := publishConfirmed(r.transport, message.Topic, message.Data)
+		return err
+	}
+	return r.transport.Publish(message.Topic, message.Data)
+}
+
+// fMemoryOutboxStore is an in-memory implementation of FOutboxStore.
+type fMemoryOutboxStore struct {
+	mu       sync.Mutex
+	messages []*FOutboxMessage
+}
+
+// NewMemoryOutboxStore returns an FOutboxStore which keeps messages in
+// memory, ignoring transactions. Since its messages aren't durable, it's
+// meant for tests.
+func NewMemoryOutboxStore() FOutboxStore {
+	return &fMemoryOutboxStore{}
+}
+
+// Add stores the message, ignoring the transaction.
+func (m *fMemoryOutboxStore) Add(tx interface{}, message *FOutboxMessage) error {
+	m.mu.Lock()
+	m.messages = append(m.messages, message)
+	m.mu.Unlock()
+	return nil
+}
+
+// Pending returns up to the given number of messages, oldest first.
+func (m *fMemoryOutboxStore) Pending(limit int) ([]*FOutboxMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if limit > len(m.messages) {
+		limit = len(m.messages)
+	}
+	return append([]*FOutboxMessage(nil), m.messages[:limit]...), nil
+}
+
+// Remove removes the message with the given ID.
+func (m *fMemoryOutboxStore) Remove(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, message := range m.messages {
+		if message.ID == id {
+			m.messages = append(m.messages[:i], m.messages[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
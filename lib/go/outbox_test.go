@@ -0,0 +1,194 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// txOutboxStore is an FOutboxStore recording the transaction of each message
+// added to it.
+type txOutboxStore struct {
+	FOutboxStore
+	txs       []interface{}
+	removeErr error
+}
+
+func (s *txOutboxStore) Add(tx interface{}, message *FOutboxMessage) error {
+	s.txs = append(s.txs, tx)
+	return s.FOutboxStore.Add(tx, message)
+}
+
+func (s *txOutboxStore) Remove(id string) error {
+	if s.removeErr != nil {
+		return s.removeErr
+	}
+	return s.FOutboxStore.Remove(id)
+}
+
+// syncPublisherTransport is a fakePublisherTransport which is safe to publish
+// with from the relay's goroutine.
+type syncPublisherTransport struct {
+	fakePublisherTransport
+	mu sync.Mutex
+}
+
+func (s *syncPublisherTransport) Publish(topic string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fakePublisherTransport.Publish(topic, data)
+}
+
+func (s *syncPublisherTransport) topics() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.published...)
+}
+
+// Ensures outbox transports add copies of messages to the store within the
+// factory's transaction.
+func TestOutboxPublisherTransport(t *testing.T) {
+	store := &txOutboxStore{FOutboxStore: NewMemoryOutboxStore()}
+	transport := NewFOutboxPublisherTransportFactory(store, "tx").GetTransport()
+	assert.Nil(t, transport.Open())
+	assert.True(t, transport.IsOpen())
+
+	data := []byte{1, 2}
+	assert.Nil(t, transport.Publish("a", data))
+	data[0] = 3
+	assert.Nil(t, transport.Publish("b", data))
+
+	assert.Equal(t, []interface{}{"tx", "tx"}, store.txs)
+	messages, err := store.Pending(10)
+	assert.Nil(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, "a", messages[0].Topic)
+	assert.Equal(t, []byte{1, 2}, messages[0].Data)
+	assert.Equal(t, "b", messages[1].Topic)
+	assert.Equal(t, []byte{3, 2}, messages[1].Data)
+	assert.NotEqual(t, messages[0].ID, messages[1].ID)
+}
+
+// Ensures outbox transports reject messages over the size limit.
+func TestOutboxPublisherTransportSizeLimit(t *testing.T) {
+	store := NewMemoryOutboxStore()
+	transport := NewFOutboxPublisherTransportFactory(store, nil).WithPublishSizeLimit(2).GetTransport()
+	assert.Equal(t, uint(2), transport.GetPublishSizeLimit())
+
+	err := transport.Publish("a", []byte{1, 2, 3})
+	assert.Equal(t, TRANSPORT_EXCEPTION_REQUEST_TOO_LARGE, err.(thrift.TTransportException).TypeId())
+	messages, _ := store.Pending(10)
+	assert.Empty(t, messages)
+}
+
+// Ensures Flush publishes every pending message in order across batches and
+// removes them.
+func TestOutboxRelayFlush(t *testing.T) {
+	store := NewMemoryOutboxStore()
+	outbox := NewFOutboxPublisherTransportFactory(store, nil).GetTransport()
+	for _, topic := range []string{"a", "b", "c"} {
+		assert.Nil(t, outbox.Publish(topic, []byte{1}))
+	}
+	transport := &fakePublisherTransport{}
+	relay := NewFOutboxRelay(store, transport).WithBatchSize(2)
+
+	published, err := relay.Flush()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, published)
+	assert.Equal(t, []string{"a", "b", "c"}, transport.published)
+	messages, _ := store.Pending(10)
+	assert.Empty(t, messages)
+}
+
+// Ensures Flush stops at a message which fails to publish, keeping it and the
+// messages after it.
+func TestOutboxRelayFlushPublishError(t *testing.T) {
+	store := NewMemoryOutboxStore()
+	outbox := NewFOutboxPublisherTransportFactory(store, nil).GetTransport()
+	assert.Nil(t, outbox.Publish("a", []byte{1}))
+	assert.Nil(t, outbox.Publish("b", []byte{1}))
+	transport := &fakePublisherTransport{publishErr: errors.New("error")}
+
+	published, err := NewFOutboxRelay(store, transport).Flush()
+	assert.Equal(t, transport.publishErr, err)
+	assert.Equal(t, 0, published)
+	messages, _ := store.Pending(10)
+	assert.Len(t, messages, 2)
+}
+
+// Ensures Flush keeps a message it can't remove so it's published again.
+func TestOutboxRelayFlushRemoveError(t *testing.T) {
+	store := &txOutboxStore{FOutboxStore: NewMemoryOutboxStore(), removeErr: errors.New("error")}
+	outbox := NewFOutboxPublisherTransportFactory(store, nil).GetTransport()
+	assert.Nil(t, outbox.Publish("a", []byte{1}))
+	transport := &fakePublisherTransport{}
+	relay := NewFOutboxRelay(store, transport)
+
+	_, err := relay.Flush()
+	assert.Equal(t, store.removeErr, err)
+	store.removeErr = nil
+	published, err := relay.Flush()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []string{"a", "a"}, transport.published)
+}
+
+// Ensures the relay publishes with confirmation if the transport supports it.
+func TestOutboxRelayFlushConfirmed(t *testing.T) {
+	store := NewMemoryOutboxStore()
+	assert.Nil(t, NewFOutboxPublisherTransportFactory(store, nil).GetTransport().Publish("a", []byte{1}))
+	transport := &confirmingPublisherTransport{}
+
+	published, err := NewFOutboxRelay(store, transport).Flush()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, 1, transport.confirmed)
+}
+
+// Ensures a started relay opens its transport and publishes pending messages
+// in the background until it's stopped.
+func TestOutboxRelayStartStop(t *testing.T) {
+	store := NewMemoryOutboxStore()
+	outbox := NewFOutboxPublisherTransportFactory(store, nil).GetTransport()
+	transport := &syncPublisherTransport{}
+	relay := NewFOutboxRelay(store, transport).WithInterval(time.Millisecond)
+
+	assert.Nil(t, relay.Start())
+	assert.True(t, transport.IsOpen())
+	assert.Error(t, relay.Start())
+	assert.Nil(t, outbox.Publish("a", []byte{1}))
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, []string{"a"}, transport.topics())
+
+	assert.Nil(t, relay.Stop())
+	assert.Nil(t, relay.Stop())
+	assert.Nil(t, outbox.Publish("b", []byte{1}))
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, []string{"a"}, transport.topics())
+}
+
+// Ensures a relay isn't started if its transport fails to open.
+func TestOutboxRelayStartOpenError(t *testing.T) {
+	transport := &fakePublisherTransport{openErr: errors.New("error")}
+	relay := NewFOutboxRelay(NewMemoryOutboxStore(), transport)
+
+	assert.Equal(t, transport.openErr, relay.Start())
+	assert.Nil(t, relay.Stop())
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package frugal
+
+import (
+	"reflect"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// FPIIRedactor is implemented by generated structs containing fields
+// annotated as PII, directly or in nested structs.
+type FPIIRedactor interface {
+	thrift.TStruct
+
+	// PIIFields returns the names of the struct's fields annotated as PII.
+	PIIFields() []string
+
+	// RedactPII clears the fields annotated as PII, including those of
+	// nested structs, in place.
+	RedactPII()
+}
+
+// RedactPII returns a copy of the struct with the fields annotated as PII
+// cleared, leaving the given struct unchanged. This can be used before
+// logging a struct. Structs which don't implement FPIIRedactor are returned
+// as is.
+func RedactPII(s thrift.TStruct) (thrift.TStruct, error) {
+	if _, ok := s.(FPIIRedactor); !ok {
+		return s, nil
+	}
+	if v := reflect.ValueOf(s); v.Kind() != reflect.Ptr || v.IsNil() {
+		return s, nil
+	}
+	// Copy the struct by serializing it, which copies nested structs and
+	// containers as well.
+	buffer := thrift.NewTMemoryBuffer()
+	if err := s.Write(thrift.NewTBinaryProtocolTransport(buffer)); err != nil {
+		return nil, err
+	}
+	redacted := reflect.New(reflect.TypeOf(s).Elem()).Interface().(FPIIRedactor)
+	if err := redacted.Read(thrift.NewTBinaryProtocolTransport(buffer)); err != nil {
+		return nil, err
+	}
+	redacted.RedactPII()
+	return redacted, nil
+}
+
+// NewPIIRedactionMiddleware returns ServiceMiddleware which replaces the
+// arguments of invocations implementing FPIIRedactor with copies whose PII
+// fields are cleared. Apply it to publishers publishing to less-trusted
+// topics, or ahead of middleware which logs arguments. If an argument can't
+// be copied, the invocation fails rather than proceeding with the PII.
+func NewPIIRedactionMiddleware() ServiceMiddleware {
+	return func(next InvocationHandler) InvocationHandler {
+		return func(service reflect.Value, method reflect.Method, args Arguments) Results {
+			for i, arg := range args {
+				s, ok := arg.(FPIIRedactor)
+				if !ok {
+					continue
+				}
+				redacted, err := RedactPII(s)
+				if err != nil {
+					results := zeroResults(method)
+					results.SetError(err)
+					return results
+				}
+				args[i] = redacted
+			}
+			return next(service, method, args)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package frugal
+
+import (
+	"testing"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// piiContact mimics a generated struct whose email field is annotated as PII.
+type piiContact struct {
+	ID       string
+	Email    string
+	Referrer *piiContact
+}
+
+func (p *piiContact) PIIFields() []string {
+	return []string{"email"}
+}
+
+func (p *piiContact) RedactPII() {
+	p.Email = ""
+	if p.Referrer != nil {
+		p.Referrer.RedactPII()
+	}
+}
+
+func (p *piiContact) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Contact"); err != nil {
+		return err
+	}
+	for i, value := range []string{p.ID, p.Email} {
+		if err := oprot.WriteFieldBegin("", thrift.STRING, int16(i+1)); err != nil {
+			return err
+		}
+		if err := oprot.WriteString(value); err != nil {
+			return err
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if p.Referrer != nil {
+		if err := oprot.WriteFieldBegin("referrer", thrift.STRUCT, 3); err != nil {
+			return err
+		}
+		if err := p.Referrer.Write(oprot); err != nil {
+			return err
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return err
+	}
+	return oprot.WriteStructEnd()
+}
+
+func (p *piiContact) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return err
+	}
+	for {
+		_, typeID, id, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return err
+		}
+		if typeID == thrift.STOP {
+			break
+		}
+		switch id {
+		case 1:
+			p.ID, err = iprot.ReadString()
+		case 2:
+			p.Email, err = iprot.ReadString()
+		case 3:
+			p.Referrer = &piiContact{}
+			err = p.Referrer.Read(iprot)
+		default:
+			err = iprot.Skip(typeID)
+		}
+		if err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	return iprot.ReadStructEnd()
+}
+
+// plainStruct is a struct without PII.
+type plainStruct struct{}
+
+func (p *plainStruct) Write(oprot thrift.TProtocol) error { return nil }
+func (p *plainStruct) Read(iprot thrift.TProtocol) error  { return nil }
+
+type piiPublisher struct {
+	published *piiContact
+}
+
+func (p *piiPublisher) PublishContact(ctx FContext, req *piiContact) error {
+	p.published = req
+	return nil
+}
+
+// Ensures RedactPII returns a redacted copy, including nested structs, and
+// leaves the original unchanged.
+func TestRedactPII(t *testing.T) {
+	contact := &piiContact{ID: "1", Email: "a@example.com", Referrer: &piiContact{ID: "2", Email: "b@example.com"}}
+
+	redacted, err := RedactPII(contact)
+	assert.Nil(t, err)
+	assert.Equal(t, &piiContact{ID: "1", Referrer: &piiContact{ID: "2"}}, redacted)
+	assert.Equal(t, "a@example.com", contact.Email)
+	assert.Equal(t, "b@example.com", contact.Referrer.Email)
+}
+
+// Ensures RedactPII returns structs without PII and nil structs as is.
+func TestRedactPIINoPII(t *testing.T) {
+	plain := &plainStruct{}
+	redacted, err := RedactPII(plain)
+	assert.Nil(t, err)
+	assert.True(t, plain == redacted)
+
+	var contact *piiContact
+	redacted, err = RedactPII(contact)
+	assert.Nil(t, err)
+	assert.Equal(t, contact, redacted)
+}
+
+// Ensures NewPIIRedactionMiddleware passes redacted copies of arguments on.
+func TestPIIRedactionMiddleware(t *testing.T) {
+	publisher := &piiPublisher{}
+	method := NewMethod(publisher, publisher.PublishContact, "PublishContact",
+		[]ServiceMiddleware{NewPIIRedactionMiddleware()})
+
+	contact := &piiContact{ID: "1", Email: "a@example.com"}
+	ret := method.Invoke([]interface{}{NewFContext("cid"), contact})
+	assert.Nil(t, ret.Error())
+	assert.Equal(t, &piiContact{ID: "1"}, publisher.published)
+	assert.Equal(t, "a@example.com", contact.Email)
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// FProjection is the result of partially decoding a frugal message. It holds
+// the message context, operation name, and the values of the requested
+// top-level fields of the message payload.
+type FProjection struct {
+	Context   FContext
+	Operation string
+
+	// Fields contains the requested fields present in the payload, keyed by
+	// field id. Values are decoded generically: bool, int8, int16, int32,
+	// int64, float64, and string for base types (binary fields are returned
+	// as string), map[int16]interface{} for structs, []interface{} for lists
+	// and sets, and map[interface{}]interface{} for maps.
+	Fields map[int16]interface{}
+}
+
+// ProjectFrame decodes a frugal frame, as delivered to an FAsyncCallback,
+// extracting only the given top-level field ids from the payload. Every other
+// field is skipped without being decoded, which makes this useful for routers
+// and filters that inspect one or two fields of large payloads without
+// building the full struct.
+func ProjectFrame(protoFactory *FProtocolFactory, frame []byte, fieldIDs ...int16) (*FProjection, error) {
+	iprot := protoFactory.GetProtocol(&thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(frame)})
+	ctx, err := iprot.ReadRequestHeader()
+	if err != nil {
+		return nil, err
+	}
+	name, _, _, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return nil, err
+	}
+	fields, err := ProjectStruct(iprot, fieldIDs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := iprot.ReadMessageEnd(); err != nil {
+		return nil, err
+	}
+	return &FProjection{Context: ctx, Operation: name, Fields: fields}, nil
+}
+
+// ProjectStruct reads a struct from the protocol, returning the values of only
+// the given field ids, keyed by field id. Fields not present in the struct are
+// not included in the result. See FProjection for how values are decoded.
+func ProjectStruct(iprot thrift.TProtocol, fieldIDs ...int16) (map[int16]interface{}, error) {
+	wanted := make(map[int16]bool, len(fieldIDs))
+	for _, id := range fieldIDs {
+		wanted[id] = true
+	}
+	return readProjectedStruct(iprot, wanted, thrift.DEFAULT_RECURSION_DEPTH)
+}
+
+// readProjectedStruct reads a struct from the protocol, decoding the fields
+// in wanted and skipping the rest. A nil wanted map decodes every field.
+// Values nested deeper than maxDepth are rejected like thrift.Skip does, so a
+// malicious frame can't exhaust the stack.
+func readProjectedStruct(iprot thrift.TProtocol, wanted map[int16]bool, maxDepth int) (map[int16]interface{}, error) {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return nil, thrift.PrependError("frugal: projection read error: ", err)
+	}
+	fields := make(map[int16]interface{}, len(wanted))
+	for {
+		_, fieldTypeID, fieldID, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return nil, thrift.PrependError(fmt.Sprintf("frugal: projection field %d read error: ", fieldID), err)
+		}
+		if fieldTypeID == thrift.STOP {
+			break
+		}
+		if wanted == nil || wanted[fieldID] {
+			value, err := readProjectedValue(iprot, fieldTypeID, maxDepth-1)
+			if err != nil {
+				return nil, thrift.PrependError(fmt.Sprintf("frugal: projection field %d read error: ", fieldID), err)
+			}
+			fields[fieldID] = value
+		} else if err := iprot.Skip(fieldTypeID); err != nil {
+			return nil, err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return nil, err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return nil, thrift.PrependError("frugal: projection read struct end error: ", err)
+	}
+	return fields, nil
+}
+
+// readProjectedValue generically decodes a value of the given type, returning
+// a TProtocolException of type DEPTH_LIMIT if it's nested deeper than
+// maxDepth.
+func readProjectedValue(iprot thrift.TProtocol, typeID thrift.TType, maxDepth int) (interface{}, error) {
+	if maxDepth <= 0 {
+		return nil, thrift.NewTProtocolExceptionWithType(thrift.DEPTH_LIMIT,
+			errors.New("frugal: projection depth limit exceeded"))
+	}
+	switch typeID {
+	case thrift.BOOL:
+		return iprot.ReadBool()
+	case thrift.BYTE:
+		return iprot.ReadByte()
+	case thrift.I16:
+		return iprot.ReadI16()
+	case thrift.I32:
+		return iprot.ReadI32()
+	case thrift.I64:
+		return iprot.ReadI64()
+	case thrift.DOUBLE:
+		return iprot.ReadDouble()
+	case thrift.STRING:
+		return iprot.ReadString()
+	case thrift.STRUCT:
+		return readProjectedStruct(iprot, nil, maxDepth)
+	case thrift.LIST, thrift.SET:
+		var (
+			elemType thrift.TType
+			size     int
+			err      error
+		)
+		if typeID == thrift.LIST {
+			elemType, size, err = iprot.ReadListBegin()
+		} else {
+			elemType, size, err = iprot.ReadSetBegin()
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Sizes come from the frame, so containers grow as their elements
+		// are read rather than being allocated up front.
+		list := []interface{}{}
+		for i := 0; i < size; i++ {
+			elem, err := readProjectedValue(iprot, elemType, maxDepth-1)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, elem)
+		}
+		if typeID == thrift.LIST {
+			err = iprot.ReadListEnd()
+		} else {
+			err = iprot.ReadSetEnd()
+		}
+		return list, err
+	case thrift.MAP:
+		keyType, valueType, size, err := iprot.ReadMapBegin()
+		if err != nil {
+			return nil, err
+		}
+		switch keyType {
+		case thrift.STRUCT, thrift.MAP, thrift.SET, thrift.LIST:
+			return nil, thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA,
+				fmt.Errorf("frugal: cannot project map with key type %s", keyType))
+		}
+		m := make(map[interface{}]interface{})
+		for i := 0; i < size; i++ {
+			key, err := readProjectedValue(iprot, keyType, maxDepth-1)
+			if err != nil {
+				return nil, err
+			}
+			if m[key], err = readProjectedValue(iprot, valueType, maxDepth-1); err != nil {
+				return nil, err
+			}
+		}
+		return m, iprot.ReadMapEnd()
+	default:
+		return nil, thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA,
+			fmt.Errorf("frugal: cannot project unknown type %s", typeID))
+	}
+}
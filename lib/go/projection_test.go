@@ -0,0 +1,163 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"testing"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// projectionFrame returns a frame containing a message with a struct of
+// every kind of field projection supports.
+func projectionFrame(t *testing.T) []byte {
+	buffer := thrift.NewTMemoryBuffer()
+	proto := NewFProtocolFactory(tProtocolFactory).GetProtocol(buffer)
+	ctx := NewFContext("cid")
+	assert.Nil(t, proto.WriteRequestHeader(ctx))
+	assert.Nil(t, proto.WriteMessageBegin("EventCreated", thrift.CALL, 0))
+	assert.Nil(t, proto.WriteStructBegin("Event"))
+
+	assert.Nil(t, proto.WriteFieldBegin("id", thrift.I64, 1))
+	assert.Nil(t, proto.WriteI64(42))
+	assert.Nil(t, proto.WriteFieldEnd())
+
+	assert.Nil(t, proto.WriteFieldBegin("message", thrift.STRING, 2))
+	assert.Nil(t, proto.WriteString("hello"))
+	assert.Nil(t, proto.WriteFieldEnd())
+
+	assert.Nil(t, proto.WriteFieldBegin("nested", thrift.STRUCT, 3))
+	assert.Nil(t, proto.WriteStructBegin("Nested"))
+	assert.Nil(t, proto.WriteFieldBegin("flag", thrift.BOOL, 1))
+	assert.Nil(t, proto.WriteBool(true))
+	assert.Nil(t, proto.WriteFieldEnd())
+	assert.Nil(t, proto.WriteFieldStop())
+	assert.Nil(t, proto.WriteStructEnd())
+	assert.Nil(t, proto.WriteFieldEnd())
+
+	assert.Nil(t, proto.WriteFieldBegin("list", thrift.LIST, 4))
+	assert.Nil(t, proto.WriteListBegin(thrift.I32, 2))
+	assert.Nil(t, proto.WriteI32(1))
+	assert.Nil(t, proto.WriteI32(2))
+	assert.Nil(t, proto.WriteListEnd())
+	assert.Nil(t, proto.WriteFieldEnd())
+
+	assert.Nil(t, proto.WriteFieldBegin("map", thrift.MAP, 5))
+	assert.Nil(t, proto.WriteMapBegin(thrift.STRING, thrift.DOUBLE, 1))
+	assert.Nil(t, proto.WriteString("pi"))
+	assert.Nil(t, proto.WriteDouble(3.14))
+	assert.Nil(t, proto.WriteMapEnd())
+	assert.Nil(t, proto.WriteFieldEnd())
+
+	assert.Nil(t, proto.WriteFieldStop())
+	assert.Nil(t, proto.WriteStructEnd())
+	assert.Nil(t, proto.WriteMessageEnd())
+	return buffer.Bytes()
+}
+
+// Ensures ProjectFrame returns only the requested fields.
+func TestProjectFrame(t *testing.T) {
+	protoFactory := NewFProtocolFactory(tProtocolFactory)
+	projection, err := ProjectFrame(protoFactory, projectionFrame(t), 2, 3, 5, 9)
+	assert.Nil(t, err)
+	assert.Equal(t, "cid", projection.Context.CorrelationID())
+	assert.Equal(t, "EventCreated", projection.Operation)
+	assert.Equal(t, map[int16]interface{}{
+		2: "hello",
+		3: map[int16]interface{}{1: true},
+		5: map[interface{}]interface{}{"pi": 3.14},
+	}, projection.Fields)
+}
+
+// Ensures ProjectFrame decodes lists and skips every field when none are
+// requested.
+func TestProjectFrameList(t *testing.T) {
+	protoFactory := NewFProtocolFactory(tProtocolFactory)
+	projection, err := ProjectFrame(protoFactory, projectionFrame(t), 1, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, map[int16]interface{}{
+		1: int64(42),
+		4: []interface{}{int32(1), int32(2)},
+	}, projection.Fields)
+
+	projection, err = ProjectFrame(protoFactory, projectionFrame(t))
+	assert.Nil(t, err)
+	assert.Empty(t, projection.Fields)
+}
+
+// Ensures ProjectStruct returns an error for maps with unhashable keys.
+func TestProjectStructUnhashableMapKey(t *testing.T) {
+	buffer := thrift.NewTMemoryBuffer()
+	proto := tProtocolFactory.GetProtocol(buffer)
+	assert.Nil(t, proto.WriteStructBegin("Struct"))
+	assert.Nil(t, proto.WriteFieldBegin("map", thrift.MAP, 1))
+	assert.Nil(t, proto.WriteMapBegin(thrift.LIST, thrift.I32, 0))
+	assert.Nil(t, proto.WriteMapEnd())
+	assert.Nil(t, proto.WriteFieldEnd())
+	assert.Nil(t, proto.WriteFieldStop())
+	assert.Nil(t, proto.WriteStructEnd())
+
+	_, err := ProjectStruct(proto, 1)
+	assert.Contains(t, err.Error(), "frugal: cannot project map with key type LIST")
+}
+
+// Ensures ProjectStruct returns an error, rather than allocating the declared
+// size, for containers declaring more elements than the frame contains.
+func TestProjectStructOversizedContainers(t *testing.T) {
+	for _, typeID := range []thrift.TType{thrift.LIST, thrift.MAP} {
+		buffer := thrift.NewTMemoryBuffer()
+		proto := tProtocolFactory.GetProtocol(buffer)
+		assert.Nil(t, proto.WriteStructBegin("Struct"))
+		assert.Nil(t, proto.WriteFieldBegin("container", typeID, 1))
+		if typeID == thrift.LIST {
+			assert.Nil(t, proto.WriteListBegin(thrift.I64, 1<<30))
+		} else {
+			assert.Nil(t, proto.WriteMapBegin(thrift.I64, thrift.I64, 1<<30))
+		}
+		assert.Nil(t, proto.WriteI64(1))
+
+		_, err := ProjectStruct(proto, 1)
+		assert.NotNil(t, err)
+	}
+}
+
+// Ensures ProjectStruct returns a DEPTH_LIMIT error, rather than exhausting
+// the stack, for values nested deeper than thrift skips.
+func TestProjectStructDepthLimit(t *testing.T) {
+	depth := thrift.DEFAULT_RECURSION_DEPTH + 1
+	buffer := thrift.NewTMemoryBuffer()
+	proto := tProtocolFactory.GetProtocol(buffer)
+	for i := 0; i < depth; i++ {
+		assert.Nil(t, proto.WriteStructBegin("Struct"))
+		assert.Nil(t, proto.WriteFieldBegin("nested", thrift.STRUCT, 1))
+	}
+	assert.Nil(t, proto.WriteStructBegin("Struct"))
+	for i := 0; i <= depth; i++ {
+		assert.Nil(t, proto.WriteFieldStop())
+		assert.Nil(t, proto.WriteStructEnd())
+		if i < depth {
+			assert.Nil(t, proto.WriteFieldEnd())
+		}
+	}
+
+	_, err := ProjectStruct(proto, 1)
+	assert.Equal(t, thrift.DEPTH_LIMIT, err.(thrift.TProtocolException).TypeId())
+}
+
+// Ensures ProjectFrame returns an error for an invalid frame.
+func TestProjectFrameInvalid(t *testing.T) {
+	_, err := ProjectFrame(NewFProtocolFactory(tProtocolFactory), []byte{})
+	assert.NotNil(t, err)
+}
@@ -76,17 +76,39 @@ func getMarshaler(version byte) (protocolMarshaler, error) {
 // any existing Thrift transports and protocols in a composable manner.
 type FProtocolFactory struct {
 	protoFactory thrift.TProtocolFactory
+	reporter     FUnknownReporter
 }
 
 // NewFProtocolFactory creates a new FProtocolFactory with the given
 // TProtocolFactory.
 func NewFProtocolFactory(protoFactory thrift.TProtocolFactory) *FProtocolFactory {
-	return &FProtocolFactory{protoFactory}
+	return &FProtocolFactory{protoFactory: protoFactory}
+}
+
+// WithUnknownReporter configures the FProtocolFactory to notify the given
+// FUnknownReporter when protocols it creates skip unknown fields or
+// operations while decoding.
+func (f *FProtocolFactory) WithUnknownReporter(reporter FUnknownReporter) *FProtocolFactory {
+	f.reporter = reporter
+	return f
+}
+
+// WithTProtocolFactory returns a new FProtocolFactory with the same
+// FUnknownReporter which wraps TProtocols produced by the given
+// TProtocolFactory. Generated publishers and subscribers of scopes with a
+// protocol other than their provider's use it to serialize messages, e.g.
+// with thrift.NewTJSONProtocolFactory().
+func (f *FProtocolFactory) WithTProtocolFactory(protoFactory thrift.TProtocolFactory) *FProtocolFactory {
+	return &FProtocolFactory{protoFactory: protoFactory, reporter: f.reporter}
 }
 
 // GetProtocol returns a new FProtocol instance using the given TTransport.
 func (f *FProtocolFactory) GetProtocol(tr thrift.TTransport) *FProtocol {
-	return &FProtocol{f.protoFactory.GetProtocol(tr)}
+	proto := f.protoFactory.GetProtocol(tr)
+	if f.reporter != nil {
+		proto = newUnknownReportingProtocol(proto, f.reporter)
+	}
+	return &FProtocol{proto}
 }
 
 // FProtocol is Frugal's equivalent of Thrift's TProtocol. It defines the
@@ -332,6 +332,29 @@ func TestMarshalUnmarshalHeadersUTF8(t *testing.T) {
 	assert.Equal(headers, decodedHeaders)
 }
 
+// Ensures WithTProtocolFactory returns an FProtocolFactory serializing with
+// the given TProtocolFactory and the same FUnknownReporter, leaving the
+// original FProtocolFactory unchanged.
+func TestWithTProtocolFactory(t *testing.T) {
+	assert := assert.New(t)
+	counter := NewFUnknownCounter()
+	factory := NewFProtocolFactory(tProtocolFactory).WithUnknownReporter(counter)
+	jsonFactory := factory.WithTProtocolFactory(thrift.NewTJSONProtocolFactory())
+	assert.Equal(tProtocolFactory, factory.protoFactory)
+
+	buffer := thrift.NewTMemoryBuffer()
+	proto := jsonFactory.GetProtocol(buffer)
+	writeUnknownTestMessage(t, proto, "ping", 1, 2, 3)
+	assert.Nil(proto.Flush())
+	assert.True(bytes.HasPrefix(buffer.Bytes(), []byte(`[1,"ping",`)))
+
+	_, _, _, err := proto.ReadMessageBegin()
+	assert.Nil(err)
+	readUnknownTestStruct(t, proto)
+	assert.Nil(proto.ReadMessageEnd())
+	assert.Equal(uint64(2), counter.UnknownFields())
+}
+
 func BenchmarkAddHeadersToFrame(b *testing.B) {
 	headers := map[string]string{"bat": "man", "spider": "man", "super": "man"}
 	b.ResetTimer()
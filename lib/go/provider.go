@@ -22,6 +22,8 @@ type FScopeProvider struct {
 	subscriberTransportFactory FSubscriberTransportFactory
 	protocolFactory            *FProtocolFactory
 	middleware                 []ServiceMiddleware
+	registry                   *fSubscriptionRegistry
+	authorizer                 FAuthorizer
 }
 
 // NewFScopeProvider creates a new FScopeProvider using the given factories.
@@ -35,6 +37,20 @@ func NewFScopeProvider(pub FPublisherTransportFactory, sub FSubscriberTransportF
 	}
 }
 
+// NewFBalancedScopeProvider creates a new FScopeProvider which distributes
+// publishes and subscriptions across multiple broker endpoints, failing over
+// between them when an endpoint is unhealthy. Each endpoint is represented by
+// a publisher and subscriber transport factory. See
+// FBalancedPublisherTransportFactory and FBalancedSubscriberTransportFactory
+// for more control over balancing.
+func NewFBalancedScopeProvider(pubs []FPublisherTransportFactory, subs []FSubscriberTransportFactory,
+	prot *FProtocolFactory, middleware ...ServiceMiddleware) *FScopeProvider {
+	return NewFScopeProvider(
+		NewFBalancedPublisherTransportFactory(pubs...),
+		NewFBalancedSubscriberTransportFactory(subs...),
+		prot, middleware...)
+}
+
 // NewPublisher returns a new FPublisherTransport and FProtocol used by
 // scope publishers.
 func (p *FScopeProvider) NewPublisher() (FPublisherTransport, *FProtocolFactory) {
@@ -42,13 +58,77 @@ func (p *FScopeProvider) NewPublisher() (FPublisherTransport, *FProtocolFactory)
 	return transport, p.protocolFactory
 }
 
+// WithPublishBatch returns a copy of this FScopeProvider whose publishers
+// stage their messages in the given FPublishBatch instead of publishing them.
+func (p *FScopeProvider) WithPublishBatch(batch *FPublishBatch) *FScopeProvider {
+	return &FScopeProvider{
+		publisherTransportFactory:  batch,
+		subscriberTransportFactory: p.subscriberTransportFactory,
+		protocolFactory:            p.protocolFactory,
+		middleware:                 p.GetMiddleware(),
+		registry:                   p.registry,
+		authorizer:                 p.authorizer,
+	}
+}
+
 // NewSubscriber returns a new FSubscriberTransport and FProtocolFactory used by
 // scope subscribers.
 func (p *FScopeProvider) NewSubscriber() (FSubscriberTransport, *FProtocolFactory) {
 	transport := p.subscriberTransportFactory.GetTransport()
+	if p.registry != nil {
+		transport = newFTrackedSubscriberTransport(transport, p.registry)
+	}
 	return transport, p.protocolFactory
 }
 
+// WithIntrospection enables tracking of the subscriptions created by this
+// FScopeProvider so they can be listed with Subscriptions. This must be
+// called before any subscribers are created.
+func (p *FScopeProvider) WithIntrospection() *FScopeProvider {
+	if p.registry == nil {
+		p.registry = newFSubscriptionRegistry()
+	}
+	return p
+}
+
+// WithMetricsHook enables introspection and sets a hook called after every
+// message received by the subscriptions created by this FScopeProvider is
+// processed, with the topic, processing latency, error, and lag, so consumer
+// health can be reported to a metrics system. The hook may be called
+// concurrently. This must be called before any subscribers are created.
+func (p *FScopeProvider) WithMetricsHook(hook func(FSubscriptionMetrics)) *FScopeProvider {
+	p.WithIntrospection()
+	p.registry.metricsHook = hook
+	return p
+}
+
+// Subscriptions returns a snapshot of the active subscriptions created by
+// this FScopeProvider, sorted by topic. Nil is returned if introspection has
+// not been enabled with WithIntrospection.
+func (p *FScopeProvider) Subscriptions() []FSubscriptionStats {
+	if p.registry == nil {
+		return nil
+	}
+	return p.registry.stats()
+}
+
+// WithAuthorizer sets the FAuthorizer invoked by generated publishers and
+// subscribers before every publish and subscribe made with this
+// FScopeProvider.
+func (p *FScopeProvider) WithAuthorizer(authorizer FAuthorizer) *FScopeProvider {
+	p.authorizer = authorizer
+	return p
+}
+
+// Authorize authorizes the given publish or subscribe with the FAuthorizer
+// set by WithAuthorizer. All requests are permitted if no FAuthorizer is set.
+func (p *FScopeProvider) Authorize(request *FAuthorizationRequest) error {
+	if p.authorizer == nil {
+		return nil
+	}
+	return p.authorizer.Authorize(request)
+}
+
 // GetMiddleware returns the ServiceMiddleware stored on this FScopeProvider.
 func (p *FScopeProvider) GetMiddleware() []ServiceMiddleware {
 	middleware := make([]ServiceMiddleware, len(p.middleware))
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// FReplayPosition describes where a replayable subscription should begin
+// consuming a topic. A position is either a broker offset or a timestamp.
+type FReplayPosition struct {
+	offset    int64
+	timestamp time.Time
+	byTime    bool
+}
+
+// ReplayFromOffset returns an FReplayPosition which begins consuming at the
+// given broker offset.
+func ReplayFromOffset(offset int64) FReplayPosition {
+	return FReplayPosition{offset: offset}
+}
+
+// ReplayFromTimestamp returns an FReplayPosition which begins consuming with
+// the first message published at or after the given time.
+func ReplayFromTimestamp(timestamp time.Time) FReplayPosition {
+	return FReplayPosition{offset: -1, timestamp: timestamp, byTime: true}
+}
+
+// IsTimestamp returns true if the position is timestamp based, false if it
+// is offset based.
+func (p FReplayPosition) IsTimestamp() bool {
+	return p.byTime
+}
+
+// Offset returns the broker offset of an offset-based position.
+func (p FReplayPosition) Offset() int64 {
+	return p.offset
+}
+
+// Timestamp returns the time of a timestamp-based position.
+func (p FReplayPosition) Timestamp() time.Time {
+	return p.timestamp
+}
+
+// FReplayableSubscriberTransport is an FSubscriberTransport backed by a
+// persistent broker, such as Kafka or JetStream, which is able to replay
+// previously published messages. The subscriber transports of an FMemoryLog
+// implement it.
+type FReplayableSubscriberTransport interface {
+	FSubscriberTransport
+
+	// SubscribeFrom opens the transport and sets the subscribe topic,
+	// delivering messages starting at the given position.
+	SubscribeFrom(string, FReplayPosition, FAsyncCallback) error
+}
+
+// SubscribeFrom subscribes the given transport to the topic starting at the
+// given position. An error is returned if the transport does not implement
+// FReplayableSubscriberTransport. This is to be used by generated code and
+// should not be called directly.
+func SubscribeFrom(transport FSubscriberTransport, topic string, from FReplayPosition, callback FAsyncCallback) error {
+	replayable, ok := transport.(FReplayableSubscriberTransport)
+	if !ok {
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_UNKNOWN,
+			"frugal: subscriber transport does not support replay")
+	}
+	return replayable.SubscribeFrom(topic, from, callback)
+}
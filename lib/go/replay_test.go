@@ -0,0 +1,70 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// replayingSubscriberTransport is a capturingSubscriberTransport which also
+// records the position passed to SubscribeFrom.
+type replayingSubscriberTransport struct {
+	capturingSubscriberTransport
+	from FReplayPosition
+}
+
+func (r *replayingSubscriberTransport) SubscribeFrom(topic string, from FReplayPosition, callback FAsyncCallback) error {
+	r.from = from
+	return r.Subscribe(topic, callback)
+}
+
+// Ensures ReplayFromOffset and ReplayFromTimestamp build the expected
+// positions.
+func TestReplayPosition(t *testing.T) {
+	offset := ReplayFromOffset(0)
+	assert.False(t, offset.IsTimestamp())
+	assert.Equal(t, int64(0), offset.Offset())
+
+	now := time.Now()
+	timestamp := ReplayFromTimestamp(now)
+	assert.True(t, timestamp.IsTimestamp())
+	assert.Equal(t, now, timestamp.Timestamp())
+}
+
+// Ensures SubscribeFrom passes the position to a replayable transport.
+func TestSubscribeFrom(t *testing.T) {
+	transport := &replayingSubscriberTransport{}
+	from := ReplayFromOffset(42)
+	callback := func(thrift.TTransport) error { return nil }
+
+	assert.Nil(t, SubscribeFrom(transport, "foo", from, callback))
+	assert.True(t, transport.IsSubscribed())
+	assert.Equal(t, "foo", transport.topic)
+	assert.Equal(t, from, transport.from)
+}
+
+// Ensures SubscribeFrom returns an error if the transport does not support
+// replay.
+func TestSubscribeFromNotReplayable(t *testing.T) {
+	transport := &capturingSubscriberTransport{}
+	callback := func(thrift.TTransport) error { return nil }
+
+	err := SubscribeFrom(transport, "foo", ReplayFromOffset(42), callback)
+	assert.Equal(t, "frugal: subscriber transport does not support replay", err.Error())
+	assert.False(t, transport.IsSubscribed())
+}
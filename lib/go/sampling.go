@@ -0,0 +1,54 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"math/rand"
+	"strconv"
+)
+
+const (
+	// SampledHeader is the request header recording whether messages
+	// published with an FContext by operations annotated with sample_rate are
+	// sampled, "true" or "false".
+	SampledHeader = "_sampled"
+
+	// SampleRateHeader is the request header recording the rate a sampled
+	// message was sampled at, so subscribers can scale what they count by
+	// its inverse.
+	SampleRateHeader = "_sample_rate"
+)
+
+// sampleRandom returns a random number in [0, 1). It's replaced in tests.
+var sampleRandom = rand.Float64
+
+// Sample decides whether a message of an operation annotated with
+// sample_rate is published with the given FContext, sampling it at the given
+// rate, and records the decision in the FContext's request headers. A
+// decision already recorded in the FContext is kept, so the messages
+// published with an FContext, e.g. while handling one request, are sampled
+// together.
+func Sample(ctx FContext, rate float64) bool {
+	if sampled, ok := ctx.RequestHeader(SampledHeader); ok {
+		if decision, err := strconv.ParseBool(sampled); err == nil {
+			return decision
+		}
+	}
+	decision := sampleRandom() < rate
+	ctx.AddRequestHeader(SampledHeader, strconv.FormatBool(decision))
+	if decision {
+		ctx.AddRequestHeader(SampleRateHeader, strconv.FormatFloat(rate, 'g', -1, 64))
+	}
+	return decision
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures Sample samples at the given rate and records the decision and rate
+// in the request headers.
+func TestSample(t *testing.T) {
+	assert := assert.New(t)
+	defer func(random func() float64) { sampleRandom = random }(sampleRandom)
+
+	sampleRandom = func() float64 { return 0.05 }
+	ctx := NewFContext("")
+	assert.True(Sample(ctx, 0.1))
+	sampled, _ := ctx.RequestHeader(SampledHeader)
+	assert.Equal("true", sampled)
+	rate, _ := ctx.RequestHeader(SampleRateHeader)
+	assert.Equal("0.1", rate)
+
+	sampleRandom = func() float64 { return 0.5 }
+	ctx = NewFContext("")
+	assert.False(Sample(ctx, 0.1))
+	sampled, _ = ctx.RequestHeader(SampledHeader)
+	assert.Equal("false", sampled)
+	_, ok := ctx.RequestHeader(SampleRateHeader)
+	assert.False(ok)
+}
+
+// Ensures Sample keeps the decision recorded in the FContext.
+func TestSampleRecordedDecision(t *testing.T) {
+	defer func(random func() float64) { sampleRandom = random }(sampleRandom)
+	sampleRandom = func() float64 { return 0.5 }
+
+	ctx := NewFContext("")
+	ctx.AddRequestHeader(SampledHeader, "true")
+	assert.True(t, Sample(ctx, 0.1))
+
+	ctx = NewFContext("")
+	assert.False(t, Sample(ctx, 0.1))
+	sampleRandom = func() float64 { return 0 }
+	assert.False(t, Sample(ctx, 0.1))
+}
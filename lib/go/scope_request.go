@@ -0,0 +1,299 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"sync"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+const (
+	// Header containing the topic replies to a scope request are published to
+	replyToHeader = "_reply_to"
+
+	// Prefix of the unique reply topic of each FScopeRequester
+	replyTopicPrefix = "_frugal_reply."
+)
+
+// FScopeRequester implements request/reply semantics on top of pub/sub scope
+// transports. Each request is published with a reply-to topic in its headers
+// which responders publish replies to. Replies are correlated to requests by
+// operation id, the same way RPC responses are. This is to be used by
+// generated code and should not be called directly.
+type FScopeRequester struct {
+	publisher    FPublisherTransport
+	subscriber   FSubscriberTransport
+	protoFactory *FProtocolFactory
+	replyTopic   string
+	mu           sync.Mutex
+	pending      map[string]chan []byte
+}
+
+// NewFScopeRequester creates a new FScopeRequester which publishes requests
+// and subscribes to replies using transports from the given FScopeProvider.
+func NewFScopeRequester(provider *FScopeProvider) *FScopeRequester {
+	publisher, protoFactory := provider.NewPublisher()
+	subscriber, _ := provider.NewSubscriber()
+	return &FScopeRequester{
+		publisher:    publisher,
+		subscriber:   subscriber,
+		protoFactory: protoFactory,
+		replyTopic:   replyTopicPrefix + generateCorrelationID(),
+		pending:      make(map[string]chan []byte),
+	}
+}
+
+// WithTProtocolFactory configures the FScopeRequester to serialize requests
+// and replies with TProtocols produced by the given TProtocolFactory in place
+// of its provider's.
+func (r *FScopeRequester) WithTProtocolFactory(protoFactory thrift.TProtocolFactory) *FScopeRequester {
+	r.protoFactory = r.protoFactory.WithTProtocolFactory(protoFactory)
+	return r
+}
+
+// Open opens the publisher transport and subscribes to the reply topic.
+func (r *FScopeRequester) Open() error {
+	if err := r.publisher.Open(); err != nil {
+		return err
+	}
+	if err := r.subscriber.Subscribe(r.replyTopic, r.handleReply); err != nil {
+		r.publisher.Close()
+		return err
+	}
+	return nil
+}
+
+// Close unsubscribes from the reply topic and closes the publisher transport.
+func (r *FScopeRequester) Close() error {
+	if err := r.subscriber.Unsubscribe(); err != nil {
+		return err
+	}
+	return r.publisher.Close()
+}
+
+// Request publishes a request to the topic and blocks until a reply is
+// received or the FContext timeout elapses. The request message is written
+// by writeRequest and the reply message, if successful, is read by
+// readReply. A reply containing an exception is returned as a
+// TApplicationException.
+func (r *FScopeRequester) Request(ctx FContext, topic, op string,
+	writeRequest, readReply func(*FProtocol) error) error {
+	opID, ok := ctx.RequestHeader(opIDHeader)
+	if !ok {
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_UNKNOWN,
+			"frugal: request context missing op id")
+	}
+	ctx.AddRequestHeader(replyToHeader, r.replyTopic)
+
+	buffer := NewTMemoryOutputBuffer(r.publisher.GetPublishSizeLimit())
+	oprot := r.protoFactory.GetProtocol(buffer)
+	if err := oprot.WriteRequestHeader(ctx); err != nil {
+		return err
+	}
+	if err := oprot.WriteMessageBegin(op, thrift.CALL, 0); err != nil {
+		return err
+	}
+	if err := writeRequest(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteMessageEnd(); err != nil {
+		return err
+	}
+	if err := oprot.Flush(); err != nil {
+		return err
+	}
+
+	replyC := make(chan []byte, 1)
+	r.mu.Lock()
+	r.pending[opID] = replyC
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.pending, opID)
+		r.mu.Unlock()
+	}()
+
+	if err := r.publisher.Publish(topic, buffer.Bytes()); err != nil {
+		return err
+	}
+
+	var reply []byte
+	select {
+	case reply = <-replyC:
+	case <-clock().After(ctx.Timeout()):
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_TIMED_OUT,
+			"frugal: scope request timed out")
+	}
+
+	iprot := r.protoFactory.GetProtocol(&thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(reply)})
+	if err := iprot.ReadResponseHeader(ctx); err != nil {
+		return err
+	}
+	_, typeID, _, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return err
+	}
+	if typeID == thrift.EXCEPTION {
+		ex := thrift.NewTApplicationException(APPLICATION_EXCEPTION_UNKNOWN, "Unknown Exception")
+		if ex, err = ex.Read(iprot); err != nil {
+			return err
+		}
+		if err := iprot.ReadMessageEnd(); err != nil {
+			return err
+		}
+		return ex
+	}
+	if err := readReply(iprot); err != nil {
+		return err
+	}
+	return iprot.ReadMessageEnd()
+}
+
+// handleReply delivers a reply frame to the request waiting on its op id.
+func (r *FScopeRequester) handleReply(transport thrift.TTransport) error {
+	frame, err := ioutil.ReadAll(transport)
+	if err != nil {
+		return thrift.NewTTransportExceptionFromError(err)
+	}
+	headers, err := getHeadersFromFrame(frame)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	replyC, ok := r.pending[headers[opIDHeader]]
+	r.mu.Unlock()
+	if !ok {
+		logger().Warnf("frugal: discarding scope reply with unknown op id %s", headers[opIDHeader])
+		return nil
+	}
+	select {
+	case replyC <- frame:
+	default:
+		logger().Warnf("frugal: discarding duplicate scope reply with op id %s", headers[opIDHeader])
+	}
+	return nil
+}
+
+// FScopeResponder publishes replies to requests received by scope
+// subscribers. This is to be used by generated code and should not be called
+// directly.
+type FScopeResponder struct {
+	mu           sync.Mutex
+	publisher    FPublisherTransport
+	protoFactory *FProtocolFactory
+}
+
+// NewFScopeResponder creates a new FScopeResponder which publishes replies
+// using a publisher transport from the given FScopeProvider. The transport is
+// opened when the first reply is sent.
+func NewFScopeResponder(provider *FScopeProvider) *FScopeResponder {
+	publisher, protoFactory := provider.NewPublisher()
+	return &FScopeResponder{publisher: publisher, protoFactory: protoFactory}
+}
+
+// WithTProtocolFactory configures the FScopeResponder to serialize replies
+// with TProtocols produced by the given TProtocolFactory in place of its
+// provider's.
+func (r *FScopeResponder) WithTProtocolFactory(protoFactory thrift.TProtocolFactory) *FScopeResponder {
+	r.protoFactory = r.protoFactory.WithTProtocolFactory(protoFactory)
+	return r
+}
+
+// Reply publishes the reply to a request received with the given FContext.
+// If the handler results contain an error, it is replied as a
+// TApplicationException, otherwise the reply message is written by
+// writeReply. A nil or nil pointer reply is replied as a MISSING_RESULT
+// TApplicationException since it can't be written. If the request did not ask
+// for a reply, nothing is published and the handler error, if any, is
+// returned.
+func (r *FScopeResponder) Reply(ctx FContext, op string, results Results,
+	writeReply func(*FProtocol) error) error {
+	topic, ok := ctx.RequestHeader(replyToHeader)
+	if !ok {
+		return results.Error()
+	}
+
+	buffer := NewTMemoryOutputBuffer(r.publisher.GetPublishSizeLimit())
+	oprot := r.protoFactory.GetProtocol(buffer)
+	if err := oprot.WriteResponseHeader(ctx); err != nil {
+		return err
+	}
+	var ex thrift.TApplicationException
+	if handlerErr := results.Error(); handlerErr != nil {
+		var ok bool
+		if ex, ok = handlerErr.(thrift.TApplicationException); !ok {
+			ex = thrift.NewTApplicationException(APPLICATION_EXCEPTION_INTERNAL_ERROR,
+				"Internal error processing "+op+": "+handlerErr.Error())
+		}
+	} else if isNilResult(results[0]) {
+		ex = thrift.NewTApplicationException(APPLICATION_EXCEPTION_MISSING_RESULT,
+			op+" failed: unknown result")
+	}
+	if ex != nil {
+		if err := oprot.WriteMessageBegin(op, thrift.EXCEPTION, 0); err != nil {
+			return err
+		}
+		if err := ex.Write(oprot); err != nil {
+			return err
+		}
+	} else {
+		if err := oprot.WriteMessageBegin(op, thrift.REPLY, 0); err != nil {
+			return err
+		}
+		if err := writeReply(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteMessageEnd(); err != nil {
+		return err
+	}
+	if err := oprot.Flush(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	if !r.publisher.IsOpen() {
+		if err := r.publisher.Open(); err != nil {
+			r.mu.Unlock()
+			return err
+		}
+	}
+	r.mu.Unlock()
+	return r.publisher.Publish(topic, buffer.Bytes())
+}
+
+// Close closes the publisher transport if it has been opened.
+func (r *FScopeResponder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.publisher.IsOpen() {
+		return nil
+	}
+	return r.publisher.Close()
+}
+
+// isNilResult returns true if the handler result is nil or a nil pointer. Nil
+// maps and slices are valid empty container replies.
+func isNilResult(result interface{}) bool {
+	if result == nil {
+		return true
+	}
+	value := reflect.ValueOf(result)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
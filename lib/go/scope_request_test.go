@@ -0,0 +1,264 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// loopbackBroker is an in-memory broker which delivers published frames to
+// the subscribers of a topic.
+type loopbackBroker struct {
+	mu          sync.Mutex
+	subscribers map[string][]FAsyncCallback
+}
+
+func newLoopbackBroker() *loopbackBroker {
+	return &loopbackBroker{subscribers: make(map[string][]FAsyncCallback)}
+}
+
+func (b *loopbackBroker) GetTransport() FPublisherTransport {
+	return &loopbackPublisherTransport{broker: b}
+}
+
+func (b *loopbackBroker) provider() *FScopeProvider {
+	return NewFScopeProvider(b, &loopbackSubscriberTransportFactory{b},
+		NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault()))
+}
+
+type loopbackPublisherTransport struct {
+	broker  *loopbackBroker
+	open    bool
+	openErr error
+}
+
+func (l *loopbackPublisherTransport) Open() error {
+	if l.openErr != nil {
+		return l.openErr
+	}
+	l.open = true
+	return nil
+}
+
+func (l *loopbackPublisherTransport) Close() error              { l.open = false; return nil }
+func (l *loopbackPublisherTransport) IsOpen() bool              { return l.open }
+func (l *loopbackPublisherTransport) GetPublishSizeLimit() uint { return 0 }
+
+func (l *loopbackPublisherTransport) Publish(topic string, data []byte) error {
+	l.broker.mu.Lock()
+	callbacks := l.broker.subscribers[topic]
+	l.broker.mu.Unlock()
+	for _, callback := range callbacks {
+		go callback(&thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(data[4:])})
+	}
+	return nil
+}
+
+type loopbackSubscriberTransportFactory struct {
+	broker *loopbackBroker
+}
+
+func (l *loopbackSubscriberTransportFactory) GetTransport() FSubscriberTransport {
+	return &capturingSubscriberTransport{}
+}
+
+// responderCallback returns an FAsyncCallback which replies to string
+// requests with the request reversed, with an error for "fail", with a nil
+// result for "missing", or with a nil list for "empty".
+func responderCallback(responder *FScopeResponder, pf *FProtocolFactory) FAsyncCallback {
+	return func(transport thrift.TTransport) error {
+		iprot := pf.GetProtocol(transport)
+		ctx, err := iprot.ReadRequestHeader()
+		if err != nil {
+			return err
+		}
+		op, _, _, err := iprot.ReadMessageBegin()
+		if err != nil {
+			return err
+		}
+		req, err := iprot.ReadString()
+		if err != nil {
+			return err
+		}
+		iprot.ReadMessageEnd()
+
+		results := Results{"", nil}
+		switch req {
+		case "fail":
+			results.SetError(errors.New("failed"))
+		case "missing":
+			results[0] = (*string)(nil)
+		case "empty":
+			results[0] = []string(nil)
+		default:
+			reversed := []byte(req)
+			for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+				reversed[i], reversed[j] = reversed[j], reversed[i]
+			}
+			results[0] = string(reversed)
+		}
+		return responder.Reply(ctx, op, results, func(oprot *FProtocol) error {
+			if list, ok := results[0].([]string); ok {
+				if err := oprot.WriteListBegin(thrift.STRING, len(list)); err != nil {
+					return err
+				}
+				return oprot.WriteListEnd()
+			}
+			return oprot.WriteString(results[0].(string))
+		})
+	}
+}
+
+// subscribe registers the callback for the topic on the broker.
+func (b *loopbackBroker) subscribe(topic string, callback FAsyncCallback) {
+	b.mu.Lock()
+	b.subscribers[topic] = append(b.subscribers[topic], callback)
+	b.mu.Unlock()
+}
+
+func newScopeRequestTest(t *testing.T) (*loopbackBroker, *FScopeRequester) {
+	broker := newLoopbackBroker()
+	provider := broker.provider()
+	requester := NewFScopeRequester(provider)
+	assert.Nil(t, requester.Open())
+	reply := requester.subscriber.(*capturingSubscriberTransport)
+	broker.subscribe(reply.topic, reply.callback)
+
+	responder := NewFScopeResponder(provider)
+	_, pf := provider.NewSubscriber()
+	broker.subscribe("reverse", responderCallback(responder, pf))
+	return broker, requester
+}
+
+func doScopeRequest(requester *FScopeRequester, ctx FContext, topic, req string) (string, error) {
+	var reply string
+	err := requester.Request(ctx, topic, "reverse", func(oprot *FProtocol) error {
+		return oprot.WriteString(req)
+	}, func(iprot *FProtocol) error {
+		var err error
+		reply, err = iprot.ReadString()
+		return err
+	})
+	return reply, err
+}
+
+// Ensures requests receive the reply correlated to them.
+func TestScopeRequest(t *testing.T) {
+	_, requester := newScopeRequestTest(t)
+
+	var wg sync.WaitGroup
+	for _, req := range []string{"foo", "hello", "frugal"} {
+		wg.Add(1)
+		go func(req string) {
+			defer wg.Done()
+			ctx := NewFContext("")
+			reply, err := doScopeRequest(requester, ctx, "reverse", req)
+			assert.Nil(t, err)
+			assert.Len(t, reply, len(req))
+			assert.Equal(t, req[len(req)-1], reply[0])
+		}(req)
+	}
+	wg.Wait()
+	assert.Nil(t, requester.Close())
+}
+
+// Ensures handler errors are returned to the requester as
+// TApplicationExceptions.
+func TestScopeRequestError(t *testing.T) {
+	_, requester := newScopeRequestTest(t)
+
+	_, err := doScopeRequest(requester, NewFContext(""), "reverse", "fail")
+	ex, ok := err.(thrift.TApplicationException)
+	assert.True(t, ok)
+	assert.Equal(t, int32(APPLICATION_EXCEPTION_INTERNAL_ERROR), ex.TypeId())
+	assert.Equal(t, "Internal error processing reverse: failed", ex.Error())
+}
+
+// Ensures nil handler results are returned to the requester as
+// MISSING_RESULT TApplicationExceptions.
+func TestScopeRequestMissingResult(t *testing.T) {
+	_, requester := newScopeRequestTest(t)
+
+	_, err := doScopeRequest(requester, NewFContext(""), "reverse", "missing")
+	ex, ok := err.(thrift.TApplicationException)
+	assert.True(t, ok)
+	assert.Equal(t, int32(APPLICATION_EXCEPTION_MISSING_RESULT), ex.TypeId())
+	assert.Equal(t, "reverse failed: unknown result", ex.Error())
+}
+
+// Ensures nil list handler results are replied as empty lists.
+func TestScopeRequestEmptyList(t *testing.T) {
+	_, requester := newScopeRequestTest(t)
+
+	size := -1
+	err := requester.Request(NewFContext(""), "reverse", "reverse", func(oprot *FProtocol) error {
+		return oprot.WriteString("empty")
+	}, func(iprot *FProtocol) error {
+		_, n, err := iprot.ReadListBegin()
+		if err != nil {
+			return err
+		}
+		size = n
+		return iprot.ReadListEnd()
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, size)
+	assert.Nil(t, requester.Close())
+}
+
+// Ensures a failure to open the publisher transport is returned from Reply
+// and the transport is opened again by later replies.
+func TestScopeReplyOpenError(t *testing.T) {
+	responder := NewFScopeResponder(newLoopbackBroker().provider())
+	publisher := responder.publisher.(*loopbackPublisherTransport)
+	publisher.openErr = errors.New("open failed")
+	ctx := NewFContext("")
+	ctx.AddRequestHeader(replyToHeader, "reply")
+	writeReply := func(oprot *FProtocol) error { return oprot.WriteString("foo") }
+
+	assert.Equal(t, publisher.openErr, responder.Reply(ctx, "op", Results{"foo", nil}, writeReply))
+	assert.False(t, publisher.IsOpen())
+
+	publisher.openErr = nil
+	assert.Nil(t, responder.Reply(ctx, "op", Results{"foo", nil}, writeReply))
+	assert.True(t, publisher.IsOpen())
+	assert.Nil(t, responder.Close())
+}
+
+// Ensures requests time out if no reply is received.
+func TestScopeRequestTimeout(t *testing.T) {
+	_, requester := newScopeRequestTest(t)
+
+	ctx := NewFContext("")
+	ctx.SetTimeout(10 * time.Millisecond)
+	_, err := doScopeRequest(requester, ctx, "nobody", "foo")
+	assert.Equal(t, TRANSPORT_EXCEPTION_TIMED_OUT, err.(thrift.TTransportException).TypeId())
+	assert.Empty(t, requester.pending)
+}
+
+// Ensures Reply returns the handler error when no reply was requested.
+func TestScopeReplyNotRequested(t *testing.T) {
+	responder := NewFScopeResponder(newLoopbackBroker().provider())
+	err := errors.New("failed")
+	assert.Equal(t, err, responder.Reply(NewFContext(""), "op", Results{"", err}, nil))
+	assert.Nil(t, responder.Reply(NewFContext(""), "op", Results{"", nil}, nil))
+	assert.Nil(t, responder.Close())
+}
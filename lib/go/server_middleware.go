@@ -0,0 +1,106 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Sirupsen/logrus"
+)
+
+// NewDefaultServerMiddleware returns the ServiceMiddleware recommended for
+// processors. It logs each invocation and recovers panics in handlers. It is
+// installed by passing it to a generated processor constructor, e.g.
+// NewFFooProcessor(handler, frugal.NewDefaultServerMiddleware()), or by
+// calling AddMiddleware on an FProcessor.
+func NewDefaultServerMiddleware() ServiceMiddleware {
+	logging := NewLoggingMiddleware()
+	recovery := NewRecoveryMiddleware()
+	return func(next InvocationHandler) InvocationHandler {
+		return logging(recovery(next))
+	}
+}
+
+// NewLoggingMiddleware returns a ServiceMiddleware which logs each invocation
+// with its service, operation, duration, and outcome. Successful invocations
+// are logged at debug level and failed invocations at error level.
+func NewLoggingMiddleware() ServiceMiddleware {
+	return func(next InvocationHandler) InvocationHandler {
+		return func(service reflect.Value, method reflect.Method, args Arguments) Results {
+			start := clock().Now()
+			results := next(service, method, args)
+			entry := logger().WithFields(logrus.Fields{
+				"service":        serviceName(service),
+				"operation":      method.Name,
+				"duration":       since(start),
+				"correlation_id": args.Context().CorrelationID(),
+			})
+			if err := results.Error(); err != nil {
+				entry.WithField("error", err).Error("frugal: invocation failed")
+			} else {
+				entry.Debug("frugal: invocation succeeded")
+			}
+			return results
+		}
+	}
+}
+
+// NewRecoveryMiddleware returns a ServiceMiddleware which recovers panics in
+// handlers and returns them as a TApplicationException with type
+// APPLICATION_EXCEPTION_INTERNAL_ERROR, so a panicking handler results in an
+// error response instead of crashing the server.
+func NewRecoveryMiddleware() ServiceMiddleware {
+	return func(next InvocationHandler) InvocationHandler {
+		return func(service reflect.Value, method reflect.Method, args Arguments) (results Results) {
+			defer func() {
+				if r := recover(); r != nil {
+					logger().Errorf("frugal: recovered panic in %s.%s: %v\n%s",
+						serviceName(service), method.Name, r, debug.Stack())
+					results = zeroResults(method)
+					results.SetError(thrift.NewTApplicationException(APPLICATION_EXCEPTION_INTERNAL_ERROR,
+						fmt.Sprintf("Internal error processing %s: %v", method.Name, r)))
+				}
+			}()
+			return next(service, method, args)
+		}
+	}
+}
+
+// serviceName returns the type name of the proxied service.
+func serviceName(service reflect.Value) string {
+	if !service.IsValid() {
+		return ""
+	}
+	t := service.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// zeroResults returns Results containing the zero value of each of the
+// method's return values.
+func zeroResults(method reflect.Method) Results {
+	results := make(Results, method.Type.NumOut())
+	if len(results) == 0 {
+		return Results{nil}
+	}
+	for i := range results {
+		results[i] = reflect.Zero(method.Type.Out(i)).Interface()
+	}
+	return results
+}
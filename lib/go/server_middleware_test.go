@@ -0,0 +1,87 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type panickingHandler struct{}
+
+func (p *panickingHandler) Explode(ctx FContext, message string) (string, error) {
+	panic(message)
+}
+
+func (p *panickingHandler) Fail(ctx FContext, message string) (string, error) {
+	return "", errors.New(message)
+}
+
+// Ensures NewRecoveryMiddleware converts a handler panic into a
+// TApplicationException.
+func TestRecoveryMiddleware(t *testing.T) {
+	handler := &panickingHandler{}
+	method := NewMethod(handler, handler.Explode, "Explode", []ServiceMiddleware{NewRecoveryMiddleware()})
+
+	ret := method.Invoke([]interface{}{NewFContext("cid"), "boom"})
+	assert.Len(t, ret, 2)
+	assert.Equal(t, "", ret[0])
+	err, ok := ret.Error().(thrift.TApplicationException)
+	assert.True(t, ok)
+	assert.Equal(t, int32(APPLICATION_EXCEPTION_INTERNAL_ERROR), err.TypeId())
+	assert.Equal(t, "Internal error processing Explode: boom", err.Error())
+}
+
+// Ensures NewLoggingMiddleware logs the outcome of invocations.
+func TestLoggingMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	log := logrus.New()
+	log.Out = &buf
+	log.Level = logrus.DebugLevel
+	SetLogger(log)
+	defer SetLogger(logrus.StandardLogger())
+
+	handler := &panickingHandler{}
+	method := NewMethod(handler, handler.Fail, "Fail", []ServiceMiddleware{NewLoggingMiddleware()})
+	ret := method.Invoke([]interface{}{NewFContext("cid"), "nope"})
+	assert.Equal(t, "nope", ret.Error().Error())
+
+	output := buf.String()
+	assert.Contains(t, output, "frugal: invocation failed")
+	assert.Contains(t, output, "operation=Fail")
+	assert.Contains(t, output, "service=panickingHandler")
+	assert.Contains(t, output, "correlation_id=cid")
+	assert.Contains(t, output, "error=nope")
+}
+
+// Ensures NewDefaultServerMiddleware both recovers and logs panics.
+func TestDefaultServerMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	log := logrus.New()
+	log.Out = &buf
+	SetLogger(log)
+	defer SetLogger(logrus.StandardLogger())
+
+	handler := &panickingHandler{}
+	method := NewMethod(handler, handler.Explode, "Explode", []ServiceMiddleware{NewDefaultServerMiddleware()})
+	ret := method.Invoke([]interface{}{NewFContext("cid"), "boom"})
+	assert.NotNil(t, ret.Error())
+	assert.Contains(t, buf.String(), "frugal: recovered panic in panickingHandler.Explode: boom")
+	assert.Contains(t, buf.String(), "frugal: invocation failed")
+}
@@ -13,6 +13,8 @@
 
 package frugal
 
+import "git.apache.org/thrift.git/lib/go/thrift"
+
 // FSubscription is a subscription to a pub/sub topic created by a scope. The
 // topic subscription is actually handled by an FScopeTransport, which the
 // FSubscription wraps. Each FSubscription should have its own FScopeTransport.
@@ -61,3 +63,57 @@ func (s *FSubscription) Remove() error {
 func (s *FSubscription) Topic() string {
 	return s.topic
 }
+
+// NewFSubscriptionGroup creates an FSubscription to the topic of the first of
+// the given subscriptions which unsubscribes from, or removes, all of them.
+// Generated subscribers use it to subscribe to several versions of a scope's
+// topics during a migration. This is to be used by generated code and should
+// not be called directly.
+func NewFSubscriptionGroup(subscriptions ...*FSubscription) *FSubscription {
+	return NewFSubscription(subscriptions[0].Topic(), &fSubscriptionGroupTransport{subscriptions: subscriptions})
+}
+
+// fSubscriptionGroupTransport implements FSubscriberTransport for a group of
+// FSubscriptions which are already subscribed.
+type fSubscriptionGroupTransport struct {
+	subscriptions []*FSubscription
+}
+
+// Subscribe returns an error since the group is already subscribed.
+func (f *fSubscriptionGroupTransport) Subscribe(string, FAsyncCallback) error {
+	return thrift.NewTTransportException(TRANSPORT_EXCEPTION_ALREADY_OPEN,
+		"frugal: subscription group already subscribed")
+}
+
+// Unsubscribe unsubscribes from every subscription of the group, returning
+// the first error.
+func (f *fSubscriptionGroupTransport) Unsubscribe() error {
+	var err error
+	for _, subscription := range f.subscriptions {
+		if unsubErr := subscription.Unsubscribe(); unsubErr != nil && err == nil {
+			err = unsubErr
+		}
+	}
+	return err
+}
+
+// Remove removes every subscription of the group, returning the first error.
+func (f *fSubscriptionGroupTransport) Remove() error {
+	var err error
+	for _, subscription := range f.subscriptions {
+		if removeErr := subscription.Remove(); removeErr != nil && err == nil {
+			err = removeErr
+		}
+	}
+	return err
+}
+
+// IsSubscribed returns true if any subscription of the group is subscribed.
+func (f *fSubscriptionGroupTransport) IsSubscribed() bool {
+	for _, subscription := range f.subscriptions {
+		if subscription.transport.IsSubscribed() {
+			return true
+		}
+	}
+	return false
+}
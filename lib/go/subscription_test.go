@@ -17,6 +17,7 @@ import (
 	"errors"
 	"testing"
 
+	"git.apache.org/thrift.git/lib/go/thrift"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -75,3 +76,37 @@ func TestSubscriptionTopic(t *testing.T) {
 	sub := NewFSubscription("foo", nil)
 	assert.Equal(t, "foo", sub.Topic())
 }
+
+// Ensures subscription groups unsubscribe from every subscription, returning
+// the first error.
+func TestSubscriptionGroupUnsubscribe(t *testing.T) {
+	err := errors.New("error")
+	first := new(mockFScopeTransport)
+	first.On("Unsubscribe").Return(nil)
+	second := new(mockFScopeTransport)
+	second.On("Unsubscribe").Return(err)
+	third := new(mockFScopeTransport)
+	third.On("Unsubscribe").Return(errors.New("other"))
+	sub := NewFSubscriptionGroup(NewFSubscription("foo.v2", first),
+		NewFSubscription("foo.v1", second), NewFSubscription("foo", third))
+
+	assert.Equal(t, "foo.v2", sub.Topic())
+	assert.Equal(t, err, sub.Unsubscribe())
+	first.AssertExpectations(t)
+	second.AssertExpectations(t)
+	third.AssertExpectations(t)
+}
+
+// Ensures subscription groups are subscribed while any subscription is and
+// can't be subscribed again.
+func TestSubscriptionGroupIsSubscribed(t *testing.T) {
+	first := new(mockFScopeTransport)
+	first.On("IsSubscribed").Return(false)
+	second := new(mockFScopeTransport)
+	second.On("IsSubscribed").Return(true)
+	group := NewFSubscriptionGroup(NewFSubscription("foo.v2", first), NewFSubscription("foo.v1", second))
+
+	assert.True(t, group.transport.IsSubscribed())
+	err := group.transport.Subscribe("foo", nil)
+	assert.Equal(t, TRANSPORT_EXCEPTION_ALREADY_OPEN, err.(thrift.TTransportException).TypeId())
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"fmt"
+	"strings"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// TenantHeader is the request header carrying the tenant identifier of a
+// publish made with a tenant FScopeProvider.
+const TenantHeader = "_tenant"
+
+// WithTenant sets the tenant identifier of publishes made with the given
+// FContext.
+func WithTenant(ctx FContext, tenant string) FContext {
+	return ctx.AddRequestHeader(TenantHeader, tenant)
+}
+
+// FTenantResolver derives the tenant identifier of a publish from its
+// request headers.
+type FTenantResolver func(headers map[string]string) (string, error)
+
+// NewFHeaderTenantResolver returns an FTenantResolver which uses the value of
+// the given request header, falling back to the given default tenant if the
+// header is not set.
+func NewFHeaderTenantResolver(header, defaultTenant string) FTenantResolver {
+	return func(headers map[string]string) (string, error) {
+		if tenant, ok := headers[header]; ok {
+			return tenant, nil
+		}
+		return defaultTenant, nil
+	}
+}
+
+// NewFTenantScopeProvider returns an FScopeProvider wrapping the given
+// provider which prefixes every topic with a tenant identifier, isolating
+// tenants without relying on every call site to pass the right scope prefix
+// variable. Publishes use the tenant set with WithTenant, falling back to the
+// given tenant. Subscribes always use the given tenant.
+func NewFTenantScopeProvider(provider *FScopeProvider, tenant string) *FScopeProvider {
+	return &FScopeProvider{
+		publisherTransportFactory: NewFTenantPublisherTransportFactory(
+			provider.publisherTransportFactory, NewFHeaderTenantResolver(TenantHeader, tenant)),
+		subscriberTransportFactory: NewFTenantSubscriberTransportFactory(
+			provider.subscriberTransportFactory, tenant),
+		protocolFactory: provider.protocolFactory,
+		middleware:      provider.GetMiddleware(),
+		registry:        provider.registry,
+		authorizer:      provider.authorizer,
+	}
+}
+
+// FTenantPublisherTransportFactory produces FPublisherTransports which prefix
+// the topic of every publish with the tenant resolved from its request
+// headers.
+type FTenantPublisherTransportFactory struct {
+	factory  FPublisherTransportFactory
+	resolver FTenantResolver
+}
+
+// NewFTenantPublisherTransportFactory creates an
+// FTenantPublisherTransportFactory wrapping the given factory.
+func NewFTenantPublisherTransportFactory(factory FPublisherTransportFactory,
+	resolver FTenantResolver) *FTenantPublisherTransportFactory {
+	return &FTenantPublisherTransportFactory{factory: factory, resolver: resolver}
+}
+
+// GetTransport returns a new tenant FPublisherTransport.
+func (f *FTenantPublisherTransportFactory) GetTransport() FPublisherTransport {
+	return &fTenantPublisherTransport{
+		FPublisherTransport: f.factory.GetTransport(),
+		resolver:            f.resolver,
+	}
+}
+
+// fTenantPublisherTransport implements FPublisherTransport by prefixing
+// topics with the tenant before publishing on the wrapped transport.
+type fTenantPublisherTransport struct {
+	FPublisherTransport
+	resolver FTenantResolver
+}
+
+// Publish resolves the tenant from the frame's request headers and publishes
+// the frame to the tenant's topic.
+func (t *fTenantPublisherTransport) Publish(topic string, data []byte) error {
+	tenantTopic, err := t.resolveTopic(topic, data)
+	if err != nil {
+		return err
+	}
+	return t.FPublisherTransport.Publish(tenantTopic, data)
+}
+
+// PublishConfirmed resolves the tenant like Publish and publishes the frame
+// to the tenant's topic with the wrapped transport, waiting for the broker to
+// acknowledge it. An error is returned if the wrapped transport doesn't
+// support confirmation.
+func (t *fTenantPublisherTransport) PublishConfirmed(topic string, data []byte) (string, error) {
+	tenantTopic, err := t.resolveTopic(topic, data)
+	if err != nil {
+		return "", err
+	}
+	return publishConfirmed(t.FPublisherTransport, tenantTopic, data)
+}
+
+func (t *fTenantPublisherTransport) confirmsPublishes() bool {
+	return confirmsPublishes(t.FPublisherTransport)
+}
+
+// resolveTopic returns the topic of the tenant resolved from the frame's
+// request headers.
+func (t *fTenantPublisherTransport) resolveTopic(topic string, data []byte) (string, error) {
+	if len(data) < 4 {
+		return "", thrift.NewTTransportException(TRANSPORT_EXCEPTION_UNKNOWN,
+			fmt.Sprintf("frugal: invalid frame size %d", len(data)))
+	}
+	headers, err := getHeadersFromFrame(data[4:])
+	if err != nil {
+		return "", err
+	}
+	tenant, err := t.resolver(headers)
+	if err != nil {
+		return "", err
+	}
+	return tenantTopic(tenant, topic)
+}
+
+// FTenantSubscriberTransportFactory produces FSubscriberTransports which
+// prefix the topic of every subscription with a fixed tenant.
+type FTenantSubscriberTransportFactory struct {
+	factory FSubscriberTransportFactory
+	tenant  string
+}
+
+// NewFTenantSubscriberTransportFactory creates an
+// FTenantSubscriberTransportFactory wrapping the given factory.
+func NewFTenantSubscriberTransportFactory(factory FSubscriberTransportFactory,
+	tenant string) *FTenantSubscriberTransportFactory {
+	return &FTenantSubscriberTransportFactory{factory: factory, tenant: tenant}
+}
+
+// GetTransport returns a new tenant FSubscriberTransport.
+func (f *FTenantSubscriberTransportFactory) GetTransport() FSubscriberTransport {
+	return &fTenantSubscriberTransport{
+		FSubscriberTransport: f.factory.GetTransport(),
+		tenant:               f.tenant,
+	}
+}
+
+// fTenantSubscriberTransport implements FSubscriberTransport by prefixing
+// topics with the tenant before subscribing on the wrapped transport.
+type fTenantSubscriberTransport struct {
+	FSubscriberTransport
+	tenant string
+}
+
+// Subscribe subscribes the wrapped transport to the tenant's topic.
+func (t *fTenantSubscriberTransport) Subscribe(topic string, callback FAsyncCallback) error {
+	tenantTopic, err := tenantTopic(t.tenant, topic)
+	if err != nil {
+		return err
+	}
+	return t.FSubscriberTransport.Subscribe(tenantTopic, callback)
+}
+
+// SubscribeFrom subscribes the wrapped transport to the tenant's topic from
+// the given replay position.
+func (t *fTenantSubscriberTransport) SubscribeFrom(topic string, from FReplayPosition, callback FAsyncCallback) error {
+	tenantTopic, err := tenantTopic(t.tenant, topic)
+	if err != nil {
+		return err
+	}
+	return SubscribeFrom(t.FSubscriberTransport, tenantTopic, from, callback)
+}
+
+// Remove removes the wrapped transport's subscription if it supports it.
+func (t *fTenantSubscriberTransport) Remove() error {
+	if r, ok := t.FSubscriberTransport.(remover); ok {
+		return r.Remove()
+	}
+	return t.Unsubscribe()
+}
+
+// tenantTopic returns the topic prefixed with the tenant. Tenants which are
+// empty or contain a topic delimiter or wildcard are rejected since they
+// would break isolation between tenants.
+func tenantTopic(tenant, topic string) (string, error) {
+	if tenant == "" {
+		return "", thrift.NewTTransportException(TRANSPORT_EXCEPTION_UNKNOWN,
+			fmt.Sprintf("frugal: no tenant for topic %s", topic))
+	}
+	if strings.ContainsAny(tenant, ".*>") {
+		return "", thrift.NewTTransportException(TRANSPORT_EXCEPTION_UNKNOWN,
+			fmt.Sprintf("frugal: invalid tenant %q", tenant))
+	}
+	return tenant + "." + topic, nil
+}
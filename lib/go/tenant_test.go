@@ -0,0 +1,106 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"errors"
+	"testing"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTenantTestProvider(pub FPublisherTransport, sub FSubscriberTransport, tenant string) *FScopeProvider {
+	pubFactory := new(mockFPublisherTransportFactory)
+	pubFactory.On("GetTransport").Return(pub)
+	provider := NewFScopeProvider(pubFactory, &capturingSubscriberTransportFactory{sub},
+		NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault()))
+	return NewFTenantScopeProvider(provider, tenant)
+}
+
+// Ensures publishes are prefixed with the tenant set on the FContext.
+func TestTenantPublishFromContext(t *testing.T) {
+	pub := &fakePublisherTransport{}
+	transport, _ := newTenantTestProvider(pub, nil, "default").NewPublisher()
+	ctx := WithTenant(NewFContext(""), "acme")
+
+	assert.Nil(t, transport.Publish("foo.Events.EventCreated", scopeFrame(t, ctx, "payload")))
+	assert.Equal(t, []string{"acme.foo.Events.EventCreated"}, pub.published)
+}
+
+// Ensures publishes without a tenant on the FContext fall back to the
+// provider's tenant.
+func TestTenantPublishDefault(t *testing.T) {
+	pub := &fakePublisherTransport{}
+	transport, _ := newTenantTestProvider(pub, nil, "default").NewPublisher()
+
+	assert.Nil(t, transport.Publish("foo.Events.EventCreated", scopeFrame(t, NewFContext(""), "payload")))
+	assert.Equal(t, []string{"default.foo.Events.EventCreated"}, pub.published)
+}
+
+// Ensures publishes fail when no tenant can be determined or the tenant would
+// break isolation.
+func TestTenantPublishInvalidTenant(t *testing.T) {
+	pub := &fakePublisherTransport{}
+	transport, _ := newTenantTestProvider(pub, nil, "").NewPublisher()
+
+	err := transport.Publish("foo.Events.EventCreated", scopeFrame(t, NewFContext(""), "payload"))
+	assert.Equal(t, "frugal: no tenant for topic foo.Events.EventCreated", err.Error())
+
+	ctx := WithTenant(NewFContext(""), "acme.*")
+	err = transport.Publish("foo.Events.EventCreated", scopeFrame(t, ctx, "payload"))
+	assert.Equal(t, "frugal: invalid tenant \"acme.*\"", err.Error())
+	assert.Empty(t, pub.published)
+}
+
+// Ensures errors from a custom tenant resolver are returned from publish.
+func TestTenantPublishResolverError(t *testing.T) {
+	expectedErr := errors.New("no tenant")
+	pub := &fakePublisherTransport{}
+	factory := new(mockFPublisherTransportFactory)
+	factory.On("GetTransport").Return(pub)
+	transport := NewFTenantPublisherTransportFactory(factory,
+		func(map[string]string) (string, error) { return "", expectedErr }).GetTransport()
+
+	assert.Equal(t, expectedErr, transport.Publish("topic", scopeFrame(t, NewFContext(""), "payload")))
+	assert.Empty(t, pub.published)
+}
+
+// Ensures subscriptions are prefixed with the provider's tenant.
+func TestTenantSubscribe(t *testing.T) {
+	sub := &capturingSubscriberTransport{}
+	transport, _ := newTenantTestProvider(nil, sub, "acme").NewSubscriber()
+
+	assert.Nil(t, transport.Subscribe("foo.Events.EventCreated", func(thrift.TTransport) error { return nil }))
+	assert.Equal(t, "acme.foo.Events.EventCreated", sub.topic)
+	assert.True(t, transport.IsSubscribed())
+}
+
+// Ensures subscribing without a tenant fails.
+func TestTenantSubscribeNoTenant(t *testing.T) {
+	sub := &capturingSubscriberTransport{}
+	transport, _ := newTenantTestProvider(nil, sub, "").NewSubscriber()
+
+	err := transport.Subscribe("foo.Events.EventCreated", func(thrift.TTransport) error { return nil })
+	assert.Equal(t, "frugal: no tenant for topic foo.Events.EventCreated", err.Error())
+	assert.False(t, transport.IsSubscribed())
+}
+
+// Ensures the tenant provider retains the wrapped provider's middleware.
+func TestTenantScopeProviderMiddleware(t *testing.T) {
+	middleware := func(next InvocationHandler) InvocationHandler { return next }
+	provider := NewFScopeProvider(nil, nil, nil, middleware)
+
+	assert.Len(t, NewFTenantScopeProvider(provider, "acme").GetMiddleware(), 1)
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// topicDelimiter separates the tokens of a topic.
+const topicDelimiter = "."
+
+// FTopicTemplate builds and validates the topics of a scope from its prefix
+// template, e.g. "foo.{user}", the same way generated publishers and
+// subscribers do. It lets code which isn't generated, such as ACL
+// provisioning and broker setup tools, work with a scope's topics.
+//
+// A topic is the prefix with its variables replaced, the scope name, and the
+// operation name joined by ".", e.g. "foo.bob.Events.EventCreated" for the
+// EventCreated operation of the Events scope with the prefix above.
+type FTopicTemplate struct {
+	scope     string
+	tokens    []string
+	variables []string
+}
+
+// NewFTopicTemplate returns an FTopicTemplate for the scope with the given
+// prefix template, which may be empty, and name. An error is returned if the
+// prefix isn't a valid template.
+func NewFTopicTemplate(prefix, scope string) (*FTopicTemplate, error) {
+	if !validTopicToken(scope) {
+		return nil, fmt.Errorf("frugal: invalid scope name %q", scope)
+	}
+	template := &FTopicTemplate{scope: scope}
+	if prefix == "" {
+		return template, nil
+	}
+	for _, token := range strings.Split(prefix, topicDelimiter) {
+		name := token
+		isVariable := strings.HasPrefix(token, "{") && strings.HasSuffix(token, "}")
+		if isVariable {
+			name = token[1 : len(token)-1]
+		}
+		if !validTopicToken(name) || strings.ContainsAny(name, "{}") {
+			return nil, fmt.Errorf("frugal: invalid token %q in topic prefix %q", token, prefix)
+		}
+		if isVariable {
+			template.variables = append(template.variables, name)
+		}
+		template.tokens = append(template.tokens, token)
+	}
+	return template, nil
+}
+
+// Variables returns the names of the prefix variables in the order their
+// values are given to Topic.
+func (t *FTopicTemplate) Variables() []string {
+	return append([]string(nil), t.variables...)
+}
+
+// Topic returns the topic of the given operation with the prefix variables
+// replaced by the given values. An error is returned if the number of values
+// doesn't match the number of variables or a value is empty or contains a
+// topic delimiter or wildcard.
+func (t *FTopicTemplate) Topic(op string, values ...string) (string, error) {
+	if len(values) != len(t.variables) {
+		return "", fmt.Errorf("frugal: topic prefix has %d variables, got %d values",
+			len(t.variables), len(values))
+	}
+	if !validTopicToken(op) {
+		return "", fmt.Errorf("frugal: invalid operation %q", op)
+	}
+	tokens := make([]string, 0, len(t.tokens)+2)
+	i := 0
+	for _, token := range t.tokens {
+		if isTopicVariable(token) {
+			if !validTopicToken(values[i]) {
+				return "", fmt.Errorf("frugal: invalid value %q for topic prefix variable %s",
+					values[i], t.variables[i])
+			}
+			token = values[i]
+			i++
+		}
+		tokens = append(tokens, token)
+	}
+	tokens = append(tokens, t.scope, op)
+	return strings.Join(tokens, topicDelimiter), nil
+}
+
+// Match validates that the topic was built from the template and returns its
+// operation and the values of its prefix variables keyed by name.
+func (t *FTopicTemplate) Match(topic string) (string, map[string]string, error) {
+	mismatch := fmt.Errorf("frugal: topic %q does not match scope %s", topic, t.scope)
+	tokens := strings.Split(topic, topicDelimiter)
+	if len(tokens) != len(t.tokens)+2 {
+		return "", nil, mismatch
+	}
+	values := make(map[string]string, len(t.variables))
+	for i, token := range t.tokens {
+		if isTopicVariable(token) {
+			if !validTopicToken(tokens[i]) {
+				return "", nil, mismatch
+			}
+			values[token[1:len(token)-1]] = tokens[i]
+		} else if tokens[i] != token {
+			return "", nil, mismatch
+		}
+	}
+	op := tokens[len(tokens)-1]
+	if tokens[len(tokens)-2] != t.scope || !validTopicToken(op) {
+		return "", nil, mismatch
+	}
+	return op, values, nil
+}
+
+// Pattern returns the topic with the prefix variables and operation replaced
+// by the given wildcard, e.g. "foo.*.Events.*" for the wildcard "*", which
+// matches every topic of the scope.
+func (t *FTopicTemplate) Pattern(wildcard string) string {
+	tokens := make([]string, 0, len(t.tokens)+2)
+	for _, token := range t.tokens {
+		if isTopicVariable(token) {
+			token = wildcard
+		}
+		tokens = append(tokens, token)
+	}
+	tokens = append(tokens, t.scope, wildcard)
+	return strings.Join(tokens, topicDelimiter)
+}
+
+func isTopicVariable(token string) bool {
+	return strings.HasPrefix(token, "{")
+}
+
+// validTopicToken returns true if the token is not empty and doesn't contain
+// a topic delimiter, wildcard, or whitespace.
+func validTopicToken(token string) bool {
+	return token != "" && !strings.ContainsAny(token, ".*> \t\r\n\f")
+}
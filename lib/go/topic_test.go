@@ -0,0 +1,105 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures Topic builds topics the same way generated publishers do.
+func TestTopicTemplateTopic(t *testing.T) {
+	template, err := NewFTopicTemplate("foo.{user}", "Events")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"user"}, template.Variables())
+
+	topic, err := template.Topic("EventCreated", "bob")
+	assert.Nil(t, err)
+	assert.Equal(t, "foo.bob.Events.EventCreated", topic)
+}
+
+// Ensures Topic works for scopes without a prefix.
+func TestTopicTemplateTopicNoPrefix(t *testing.T) {
+	template, err := NewFTopicTemplate("", "Events")
+	assert.Nil(t, err)
+	assert.Empty(t, template.Variables())
+
+	topic, err := template.Topic("EventCreated")
+	assert.Nil(t, err)
+	assert.Equal(t, "Events.EventCreated", topic)
+}
+
+// Ensures Topic rejects missing and invalid values.
+func TestTopicTemplateTopicInvalidValues(t *testing.T) {
+	template, err := NewFTopicTemplate("foo.{user}.{id}", "Events")
+	assert.Nil(t, err)
+
+	_, err = template.Topic("EventCreated", "bob")
+	assert.Error(t, err)
+	_, err = template.Topic("EventCreated", "bob", "")
+	assert.Error(t, err)
+	_, err = template.Topic("EventCreated", "bob", "a.b")
+	assert.Error(t, err)
+	_, err = template.Topic("EventCreated", "*", "1")
+	assert.Error(t, err)
+	_, err = template.Topic("", "bob", "1")
+	assert.Error(t, err)
+}
+
+// Ensures NewFTopicTemplate rejects invalid prefixes.
+func TestNewTopicTemplateInvalidPrefix(t *testing.T) {
+	for _, prefix := range []string{"foo.", ".foo", "foo..bar", "foo.{}", "foo.{user", "foo.b{a}r", "foo bar"} {
+		_, err := NewFTopicTemplate(prefix, "Events")
+		assert.Error(t, err, prefix)
+	}
+	_, err := NewFTopicTemplate("foo", "")
+	assert.Error(t, err)
+}
+
+// Ensures Match returns the operation and variable values of a topic built
+// from the template.
+func TestTopicTemplateMatch(t *testing.T) {
+	template, err := NewFTopicTemplate("foo.{user}.bar.{id}", "Events")
+	assert.Nil(t, err)
+
+	op, values, err := template.Match("foo.bob.bar.42.Events.EventCreated")
+	assert.Nil(t, err)
+	assert.Equal(t, "EventCreated", op)
+	assert.Equal(t, map[string]string{"user": "bob", "id": "42"}, values)
+}
+
+// Ensures Match rejects topics not built from the template.
+func TestTopicTemplateMatchMismatch(t *testing.T) {
+	template, err := NewFTopicTemplate("foo.{user}", "Events")
+	assert.Nil(t, err)
+
+	for _, topic := range []string{
+		"foo.bob.Events",
+		"bar.bob.Events.EventCreated",
+		"foo.bob.Other.EventCreated",
+		"foo..Events.EventCreated",
+		"foo.bob.extra.Events.EventCreated",
+	} {
+		_, _, err := template.Match(topic)
+		assert.Error(t, err, topic)
+	}
+}
+
+// Ensures Pattern replaces variables and the operation with the wildcard.
+func TestTopicTemplatePattern(t *testing.T) {
+	template, err := NewFTopicTemplate("foo.{user}", "Events")
+	assert.Nil(t, err)
+	assert.Equal(t, "foo.*.Events.*", template.Pattern("*"))
+}
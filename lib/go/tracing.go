@@ -0,0 +1,127 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import "sync"
+
+// FSpanKind is the role of a span in the exchange of a scope message.
+type FSpanKind int
+
+const (
+	// FSpanKindProducer is the kind of spans publishing a message.
+	FSpanKindProducer FSpanKind = iota
+
+	// FSpanKindConsumer is the kind of spans handling a received message.
+	FSpanKindConsumer
+)
+
+// String returns the name of the span kind, as used by OpenTracing and
+// OpenTelemetry.
+func (k FSpanKind) String() string {
+	if k == FSpanKindConsumer {
+		return "consumer"
+	}
+	return "producer"
+}
+
+// FTracer starts the spans of publishers and subscribers generated with the
+// -tracing flag. It's implemented by adapting a distributed tracing library,
+// such as OpenTracing with NewOpenTracingTracer, which propagates span
+// contexts through the request headers of scope messages. Implementations
+// must be threadsafe.
+type FTracer interface {
+	// StartSpan starts a span with the given operation name and kind. If the
+	// given headers carry the context of a span, the new span is its child.
+	StartSpan(operation string, kind FSpanKind, headers map[string]string) FSpan
+}
+
+// FSpan is a span started by an FTracer.
+type FSpan interface {
+	// SetTag sets a tag on the span.
+	SetTag(key string, value interface{})
+
+	// Inject adds the headers carrying the span's context to the given
+	// headers.
+	Inject(headers map[string]string)
+
+	// Finish ends the span, recording the given error if it's not nil.
+	Finish(err error)
+}
+
+var (
+	packageTracer FTracer = noopTracer{}
+	tracerMu      sync.RWMutex
+)
+
+// SetTracer sets the FTracer used by traced publishers and subscribers.
+// Setting nil disables tracing.
+func SetTracer(tracer FTracer) {
+	if tracer == nil {
+		tracer = noopTracer{}
+	}
+	tracerMu.Lock()
+	packageTracer = tracer
+	tracerMu.Unlock()
+}
+
+// tracer returns the global FTracer.
+func tracer() FTracer {
+	tracerMu.RLock()
+	tracer := packageTracer
+	tracerMu.RUnlock()
+	return tracer
+}
+
+// StartPublishSpan starts the producer span of publishing the given scope
+// operation with the given FContext, which is a child of the span carried by
+// its request headers, if any. The span's context is added to the request
+// headers so subscribers continue the trace.
+func StartPublishSpan(ctx FContext, scope, op string) FSpan {
+	return startSpan(ctx, scope, op, FSpanKindProducer)
+}
+
+// StartReceiveSpan starts the consumer span of handling a received message of
+// the given scope operation, which is a child of the publisher's span carried
+// by the request headers of the given FContext. The span's context replaces
+// the publisher's in the request headers, so it's the parent of spans started
+// with the FContext by the handler.
+func StartReceiveSpan(ctx FContext, scope, op string) FSpan {
+	return startSpan(ctx, scope, op, FSpanKindConsumer)
+}
+
+func startSpan(ctx FContext, scope, op string, kind FSpanKind) FSpan {
+	span := tracer().StartSpan(scope+"."+op, kind, ctx.RequestHeaders())
+	span.SetTag("frugal.scope", scope)
+	span.SetTag("frugal.operation", op)
+	span.SetTag("frugal.correlation_id", ctx.CorrelationID())
+	headers := make(map[string]string)
+	span.Inject(headers)
+	for name, value := range headers {
+		ctx.AddRequestHeader(name, value)
+	}
+	return span
+}
+
+// noopTracer is the FTracer used when tracing isn't enabled.
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(string, FSpanKind, map[string]string) FSpan {
+	return noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetTag(string, interface{}) {}
+func (noopSpan) Inject(map[string]string)   {}
+func (noopSpan) Finish(error)               {}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingTracer is an FTracer propagating the IDs of its spans in the
+// "trace-span" header.
+type recordingTracer struct {
+	spans []*recordingSpan
+}
+
+type recordingSpan struct {
+	id        string
+	parent    string
+	operation string
+	kind      FSpanKind
+	tags      map[string]interface{}
+	finished  bool
+	err       error
+}
+
+func (r *recordingTracer) StartSpan(operation string, kind FSpanKind, headers map[string]string) FSpan {
+	span := &recordingSpan{
+		id:        strconv.Itoa(len(r.spans) + 1),
+		parent:    headers["trace-span"],
+		operation: operation,
+		kind:      kind,
+		tags:      make(map[string]interface{}),
+	}
+	r.spans = append(r.spans, span)
+	return span
+}
+
+func (s *recordingSpan) SetTag(key string, value interface{}) {
+	s.tags[key] = value
+}
+
+func (s *recordingSpan) Inject(headers map[string]string) {
+	headers["trace-span"] = s.id
+}
+
+func (s *recordingSpan) Finish(err error) {
+	s.finished = true
+	s.err = err
+}
+
+// Ensures a receive span continues the trace of the publish span through the
+// request headers.
+func TestPublishAndReceiveSpans(t *testing.T) {
+	assert := assert.New(t)
+	tracer := &recordingTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	ctx := NewFContext("cid")
+	publishSpan := StartPublishSpan(ctx, "Events", "EventCreated")
+	publishSpan.Finish(nil)
+	receiveSpan := StartReceiveSpan(ctx, "Events", "EventCreated")
+	expected := errors.New("error")
+	receiveSpan.Finish(expected)
+
+	assert.Len(tracer.spans, 2)
+	publish, receive := tracer.spans[0], tracer.spans[1]
+	assert.Equal("Events.EventCreated", publish.operation)
+	assert.Equal(FSpanKindProducer, publish.kind)
+	assert.Equal("", publish.parent)
+	assert.Equal(map[string]interface{}{
+		"frugal.scope":          "Events",
+		"frugal.operation":      "EventCreated",
+		"frugal.correlation_id": "cid",
+	}, publish.tags)
+	assert.True(publish.finished)
+	assert.Nil(publish.err)
+
+	assert.Equal(FSpanKindConsumer, receive.kind)
+	assert.Equal("1", receive.parent)
+	assert.Equal(expected, receive.err)
+	header, _ := ctx.RequestHeader("trace-span")
+	assert.Equal("2", header)
+}
+
+// Ensures spans do nothing without a tracer.
+func TestNoopTracer(t *testing.T) {
+	ctx := NewFContext("cid")
+	headers := ctx.RequestHeaders()
+	StartPublishSpan(ctx, "Events", "EventCreated").Finish(nil)
+	assert.Equal(t, headers, ctx.RequestHeaders())
+	assert.Equal(t, "consumer", FSpanKindConsumer.String())
+	assert.Equal(t, "producer", FSpanKindProducer.String())
+}
@@ -16,6 +16,7 @@ package frugal
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	//"errors"
 
 	"git.apache.org/thrift.git/lib/go/thrift"
@@ -49,6 +50,60 @@ type FPublisherTransport interface {
 	Publish(string, []byte) error
 }
 
+// Publish publishes the given payload to the topic with the transport. If a
+// timeout was set on the given FContext with SetTimeout, the publish is
+// bounded by it and a TTransportException of type
+// TRANSPORT_EXCEPTION_TIMED_OUT is returned if it does not complete in time;
+// otherwise the publish is not bounded. The publish is not cancelled on
+// timeout, so a timed-out message may still be delivered and retrying it may
+// publish it twice. If the FContext requests
+// publish confirmation, the publish waits for the broker to acknowledge the
+// message and records the message ID in the FContext.
+func Publish(ctx FContext, transport FPublisherTransport, topic string, data []byte) error {
+	if !isPublishConfirmationRequested(ctx) {
+		return publishWithTimeout(ctx, topic, func() error {
+			return transport.Publish(topic, data)
+		})
+	}
+
+	var messageID string
+	err := publishWithTimeout(ctx, topic, func() error {
+		id, err := publishConfirmed(transport, topic, data)
+		messageID = id
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	ctx.AddResponseHeader(messageIDHeader, messageID)
+	return nil
+}
+
+// publishWithTimeout calls publish, returning a timeout error if it doesn't
+// return within the timeout set on the given FContext. Without one, publish is
+// called directly.
+func publishWithTimeout(ctx FContext, topic string, publish func() error) error {
+	timeout, ok := explicitTimeout(ctx)
+	if !ok || timeout <= 0 {
+		return publish()
+	}
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- publish()
+	}()
+
+	timer := clock().NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-errC:
+		return err
+	case <-timer.C():
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_TIMED_OUT,
+			fmt.Sprintf("frugal: publish to topic %s timed out", topic))
+	}
+}
+
 // FSubscriberTransportFactory produces FSubscriberTransports and is typically
 // used by an FScopeProvider.
 type FSubscriberTransportFactory interface {
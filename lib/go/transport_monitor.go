@@ -144,7 +144,7 @@ func (r *monitorRunner) attemptReopen(InitialWait time.Duration) bool {
 
 	for reopen {
 		logger().Infof("frugal: FTransportMonitor attempting to reopen after %v", wait)
-		time.Sleep(wait)
+		clock().Sleep(wait)
 
 		if err := r.transport.Open(); err != nil {
 			logger().Errorf("frugal: FTransportMonitor failed to re-open transport due to: %v", err)
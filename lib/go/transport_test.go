@@ -16,6 +16,7 @@ package frugal
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"git.apache.org/thrift.git/lib/go/thrift"
 	"github.com/stretchr/testify/assert"
@@ -30,3 +31,39 @@ func TestIsErrTooLarge(t *testing.T) {
 	assert.False(t, IsErrTooLarge(thrift.NewTTransportException(TRANSPORT_EXCEPTION_NOT_OPEN, "error")))
 	assert.False(t, IsErrTooLarge(thrift.NewTApplicationException(0, "error")))
 }
+
+type blockingPublisherTransport struct {
+	fakePublisherTransport
+	release chan struct{}
+}
+
+func (b *blockingPublisherTransport) Publish(topic string, data []byte) error {
+	<-b.release
+	return nil
+}
+
+// Ensures Publish returns the result of publishing with the transport.
+func TestPublish(t *testing.T) {
+	transport := &fakePublisherTransport{}
+	assert.Nil(t, Publish(NewFContext(""), transport, "topic", []byte{1}))
+	assert.Equal(t, []string{"topic"}, transport.published)
+
+	expectedErr := errors.New("error")
+	transport.publishErr = expectedErr
+	assert.Equal(t, expectedErr, Publish(NewFContext(""), transport, "topic", []byte{1}))
+}
+
+// Ensures Publish returns a timeout error if the publish does not complete
+// within the FContext's timeout.
+func TestPublishTimeout(t *testing.T) {
+	transport := &blockingPublisherTransport{release: make(chan struct{})}
+	defer close(transport.release)
+	ctx := NewFContext("")
+	ctx.SetTimeout(5 * time.Millisecond)
+
+	err := Publish(ctx, transport, "topic", []byte{1})
+
+	assert.Error(t, err)
+	assert.Equal(t, TRANSPORT_EXCEPTION_TIMED_OUT, err.(thrift.TTransportException).TypeId())
+	assert.Equal(t, "frugal: publish to topic topic timed out", err.Error())
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"sync/atomic"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// FUnknownReporter is notified when a decoded message contains fields or
+// operations which are not defined by the local IDL. Skipping these is
+// standard Thrift behavior and is what allows IDL to evolve, but reporting
+// them lets operators detect version skew between producers and consumers in
+// production. Implementations must be safe for concurrent use.
+type FUnknownReporter interface {
+	// UnknownField is called when a struct field with the given id and type
+	// is skipped because it is not defined by the local IDL.
+	UnknownField(fieldID int16, fieldType thrift.TType)
+
+	// UnknownOperation is called when a message for the given operation is
+	// skipped because it is not defined by the local IDL.
+	UnknownOperation(name string)
+}
+
+// FUnknownCounter is an FUnknownReporter which counts the unknown fields and
+// operations it is notified of.
+type FUnknownCounter struct {
+	fields     uint64
+	operations uint64
+}
+
+// NewFUnknownCounter returns a new FUnknownCounter with zeroed counts.
+func NewFUnknownCounter() *FUnknownCounter {
+	return &FUnknownCounter{}
+}
+
+// UnknownField increments the unknown field count.
+func (c *FUnknownCounter) UnknownField(fieldID int16, fieldType thrift.TType) {
+	atomic.AddUint64(&c.fields, 1)
+}
+
+// UnknownOperation increments the unknown operation count.
+func (c *FUnknownCounter) UnknownOperation(name string) {
+	atomic.AddUint64(&c.operations, 1)
+}
+
+// UnknownFields returns the number of unknown fields encountered.
+func (c *FUnknownCounter) UnknownFields() uint64 {
+	return atomic.LoadUint64(&c.fields)
+}
+
+// UnknownOperations returns the number of unknown operations encountered.
+func (c *FUnknownCounter) UnknownOperations() uint64 {
+	return atomic.LoadUint64(&c.operations)
+}
+
+// unknownReportingProtocol wraps a TProtocol and notifies an FUnknownReporter
+// whenever generated code skips data it does not recognize. Generated code
+// only calls Skip for a field after ReadFieldBegin and for an operation
+// directly after ReadMessageBegin, which is how the two are distinguished.
+type unknownReportingProtocol struct {
+	thrift.TProtocol
+	reporter  FUnknownReporter
+	message   string
+	fieldIDs  []int16
+	inMessage bool
+}
+
+func newUnknownReportingProtocol(protocol thrift.TProtocol, reporter FUnknownReporter) *unknownReportingProtocol {
+	return &unknownReportingProtocol{TProtocol: protocol, reporter: reporter}
+}
+
+func (u *unknownReportingProtocol) ReadMessageBegin() (string, thrift.TMessageType, int32, error) {
+	name, typeID, seqID, err := u.TProtocol.ReadMessageBegin()
+	if err == nil {
+		u.message = name
+		u.inMessage = true
+		u.fieldIDs = u.fieldIDs[:0]
+	}
+	return name, typeID, seqID, err
+}
+
+func (u *unknownReportingProtocol) ReadMessageEnd() error {
+	u.inMessage = false
+	return u.TProtocol.ReadMessageEnd()
+}
+
+func (u *unknownReportingProtocol) ReadFieldBegin() (string, thrift.TType, int16, error) {
+	name, typeID, id, err := u.TProtocol.ReadFieldBegin()
+	if err == nil && typeID != thrift.STOP {
+		u.fieldIDs = append(u.fieldIDs, id)
+	}
+	return name, typeID, id, err
+}
+
+func (u *unknownReportingProtocol) ReadFieldEnd() error {
+	if len(u.fieldIDs) > 0 {
+		u.fieldIDs = u.fieldIDs[:len(u.fieldIDs)-1]
+	}
+	return u.TProtocol.ReadFieldEnd()
+}
+
+func (u *unknownReportingProtocol) Skip(fieldType thrift.TType) error {
+	if len(u.fieldIDs) > 0 {
+		u.reporter.UnknownField(u.fieldIDs[len(u.fieldIDs)-1], fieldType)
+	} else if u.inMessage {
+		u.reporter.UnknownOperation(u.message)
+	}
+	return u.TProtocol.Skip(fieldType)
+}
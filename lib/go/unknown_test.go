@@ -0,0 +1,101 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"testing"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeUnknownTestMessage writes a message whose struct contains the given
+// i32 fields.
+func writeUnknownTestMessage(t *testing.T, proto thrift.TProtocol, name string, fieldIDs ...int16) {
+	assert.Nil(t, proto.WriteMessageBegin(name, thrift.CALL, 0))
+	assert.Nil(t, proto.WriteStructBegin("args"))
+	for _, id := range fieldIDs {
+		assert.Nil(t, proto.WriteFieldBegin("", thrift.I32, id))
+		assert.Nil(t, proto.WriteI32(int32(id)))
+		assert.Nil(t, proto.WriteFieldEnd())
+	}
+	assert.Nil(t, proto.WriteFieldStop())
+	assert.Nil(t, proto.WriteStructEnd())
+	assert.Nil(t, proto.WriteMessageEnd())
+}
+
+// readUnknownTestStruct reads a struct the way generated code does, knowing
+// only field 1.
+func readUnknownTestStruct(t *testing.T, proto thrift.TProtocol) {
+	_, err := proto.ReadStructBegin()
+	assert.Nil(t, err)
+	for {
+		_, fieldTypeID, fieldID, err := proto.ReadFieldBegin()
+		assert.Nil(t, err)
+		if fieldTypeID == thrift.STOP {
+			break
+		}
+		switch fieldID {
+		case 1:
+			_, err = proto.ReadI32()
+		default:
+			err = proto.Skip(fieldTypeID)
+		}
+		assert.Nil(t, err)
+		assert.Nil(t, proto.ReadFieldEnd())
+	}
+	assert.Nil(t, proto.ReadStructEnd())
+}
+
+// Ensures protocols created with an FUnknownReporter report skipped fields.
+func TestUnknownReporterField(t *testing.T) {
+	counter := NewFUnknownCounter()
+	factory := NewFProtocolFactory(tProtocolFactory).WithUnknownReporter(counter)
+	proto := factory.GetProtocol(thrift.NewTMemoryBuffer())
+	writeUnknownTestMessage(t, proto, "ping", 1, 2, 3)
+
+	_, _, _, err := proto.ReadMessageBegin()
+	assert.Nil(t, err)
+	readUnknownTestStruct(t, proto)
+	assert.Nil(t, proto.ReadMessageEnd())
+
+	assert.Equal(t, uint64(2), counter.UnknownFields())
+	assert.Equal(t, uint64(0), counter.UnknownOperations())
+}
+
+// Ensures protocols created with an FUnknownReporter report skipped
+// operations.
+func TestUnknownReporterOperation(t *testing.T) {
+	counter := NewFUnknownCounter()
+	factory := NewFProtocolFactory(tProtocolFactory).WithUnknownReporter(counter)
+	proto := factory.GetProtocol(thrift.NewTMemoryBuffer())
+	writeUnknownTestMessage(t, proto, "pong", 1, 2)
+
+	name, _, _, err := proto.ReadMessageBegin()
+	assert.Nil(t, err)
+	assert.Equal(t, "pong", name)
+	assert.Nil(t, proto.Skip(thrift.STRUCT))
+	assert.Nil(t, proto.ReadMessageEnd())
+
+	assert.Equal(t, uint64(0), counter.UnknownFields())
+	assert.Equal(t, uint64(1), counter.UnknownOperations())
+}
+
+// Ensures protocols created without an FUnknownReporter are not wrapped.
+func TestUnknownReporterNotConfigured(t *testing.T) {
+	factory := NewFProtocolFactory(tProtocolFactory)
+	proto := factory.GetProtocol(thrift.NewTMemoryBuffer())
+	_, ok := proto.TProtocol.(*unknownReportingProtocol)
+	assert.False(t, ok)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of the Frugal Go library.
+const Version = "2.23.0"
+
+// CheckVersion returns an error if code generated requiring at least the
+// given version of the library can't run with this version, because it's
+// older or has a different major version.
+func CheckVersion(required string) error {
+	requiredVersion, err := parseVersion(required)
+	if err != nil {
+		return err
+	}
+	version, err := parseVersion(Version)
+	if err != nil {
+		return err
+	}
+	if version[0] != requiredVersion[0] {
+		return fmt.Errorf("frugal: generated code requires library version %s, incompatible with major version %s",
+			required, Version)
+	}
+	for i := range version {
+		if version[i] != requiredVersion[i] {
+			if version[i] < requiredVersion[i] {
+				return fmt.Errorf("frugal: generated code requires library version %s or newer, found %s",
+					required, Version)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// RequireVersion panics if code generated requiring at least the given
+// version of the library can't run with this version. Code generated with
+// the "runtime_check" option calls it when its package is initialized, so a
+// mismatch fails at startup rather than with obscure errors later.
+func RequireVersion(required string) {
+	if err := CheckVersion(required); err != nil {
+		panic(err)
+	}
+}
+
+// parseVersion parses the major, minor, and patch versions of a version of
+// the form major.minor.patch, ignoring any pre-release or build suffix.
+func parseVersion(version string) ([3]int, error) {
+	var parsed [3]int
+	release := strings.SplitN(strings.SplitN(version, "-", 2)[0], "+", 2)[0]
+	parts := strings.Split(release, ".")
+	if len(parts) != len(parsed) {
+		return parsed, fmt.Errorf("frugal: invalid version %q, expected major.minor.patch", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return parsed, fmt.Errorf("frugal: invalid version %q, expected major.minor.patch", version)
+		}
+		parsed[i] = n
+	}
+	return parsed, nil
+}
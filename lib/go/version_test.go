@@ -0,0 +1,43 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures CheckVersion accepts the library's version and older versions of
+// the same major version.
+func TestCheckVersionCompatible(t *testing.T) {
+	assert.Nil(t, CheckVersion(Version))
+	assert.Nil(t, CheckVersion("2.0.0"))
+	assert.Nil(t, CheckVersion("2.0.0-rc1"))
+}
+
+// Ensures CheckVersion rejects newer versions and other major versions.
+func TestCheckVersionIncompatible(t *testing.T) {
+	assert.EqualError(t, CheckVersion("2.99.0"),
+		"frugal: generated code requires library version 2.99.0 or newer, found "+Version)
+	assert.EqualError(t, CheckVersion("1.0.0"),
+		"frugal: generated code requires library version 1.0.0, incompatible with major version "+Version)
+	assert.EqualError(t, CheckVersion("2.x"), `frugal: invalid version "2.x", expected major.minor.patch`)
+}
+
+// Ensures RequireVersion panics for incompatible versions.
+func TestRequireVersion(t *testing.T) {
+	assert.NotPanics(t, func() { RequireVersion(Version) })
+	assert.Panics(t, func() { RequireVersion("3.0.0") })
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package com.workiva.frugal;
+
+import java.util.Collections;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+/**
+ * FContractMetadata describes the IDL contract a scope or service was generated
+ * from. Generated code exposes an FContractMetadata for each scope and service,
+ * which can be used for version checks, routing tables, and observability labels.
+ */
+public class FContractMetadata {
+
+    private final String idlFile;
+    private final String kind;
+    private final String name;
+    private final String hash;
+    private final String compilerVersion;
+    private final List<String> operations;
+    private final Map<String, String> descriptions;
+
+    /**
+     * Creates a new FContractMetadata.
+     *
+     * @param idlFile         name of the frugal file defining the contract
+     * @param kind            either "scope" or "service"
+     * @param name            name of the scope or service
+     * @param hash            hash of the contract which ignores comments and formatting
+     * @param compilerVersion version of the frugal compiler which generated the code
+     * @param operations      names of the scope's operations or the service's methods
+     */
+    public FContractMetadata(String idlFile, String kind, String name, String hash,
+                             String compilerVersion, List<String> operations) {
+        this(idlFile, kind, name, hash, compilerVersion, operations, Collections.<String, String>emptyMap());
+    }
+
+    /**
+     * Creates a new FContractMetadata with the doc comments of its documented
+     * operations or methods.
+     *
+     * @param idlFile         name of the frugal file defining the contract
+     * @param kind            either "scope" or "service"
+     * @param name            name of the scope or service
+     * @param hash            hash of the contract which ignores comments and formatting
+     * @param compilerVersion version of the frugal compiler which generated the code
+     * @param operations      names of the scope's operations or the service's methods
+     * @param descriptions    doc comments of the documented operations or methods by name
+     */
+    public FContractMetadata(String idlFile, String kind, String name, String hash,
+                             String compilerVersion, List<String> operations,
+                             Map<String, String> descriptions) {
+        this.idlFile = idlFile;
+        this.kind = kind;
+        this.name = name;
+        this.hash = hash;
+        this.compilerVersion = compilerVersion;
+        this.operations = Collections.unmodifiableList(operations);
+        this.descriptions = Collections.unmodifiableMap(descriptions);
+    }
+
+    /**
+     * Returns a map of operation or method names to doc comments from
+     * alternating names and doc comments, for use by generated code.
+     *
+     * @param namesAndDescriptions alternating names and doc comments
+     * @return the doc comments by name
+     */
+    public static Map<String, String> descriptions(String... namesAndDescriptions) {
+        if (namesAndDescriptions.length % 2 != 0) {
+            throw new IllegalArgumentException("names and descriptions must alternate");
+        }
+        Map<String, String> descriptions = new HashMap<>();
+        for (int i = 0; i < namesAndDescriptions.length; i += 2) {
+            descriptions.put(namesAndDescriptions[i], namesAndDescriptions[i + 1]);
+        }
+        return descriptions;
+    }
+
+    public String getIdlFile() {
+        return idlFile;
+    }
+
+    public String getKind() {
+        return kind;
+    }
+
+    public String getName() {
+        return name;
+    }
+
+    public String getHash() {
+        return hash;
+    }
+
+    public String getCompilerVersion() {
+        return compilerVersion;
+    }
+
+    public List<String> getOperations() {
+        return operations;
+    }
+
+    public Map<String, String> getDescriptions() {
+        return descriptions;
+    }
+
+    /**
+     * Returns the doc comment of the operation or method with the given name,
+     * or an empty string if it isn't documented.
+     *
+     * @param name operation or method name
+     * @return the doc comment
+     */
+    public String getDescription(String name) {
+        String description = descriptions.get(name);
+        return description == null ? "" : description;
+    }
+
+    /**
+     * Returns true if the contract has the given hash, i.e. the other side of
+     * the scope or service was built from the same contract.
+     *
+     * @param hash contract hash to compare
+     * @return whether the hashes match
+     */
+    public boolean matches(String hash) {
+        return this.hash.equals(hash);
+    }
+
+    /**
+     * Returns true if the contract defines an operation or method with the
+     * given name.
+     *
+     * @param name operation or method name
+     * @return whether the operation is defined
+     */
+    public boolean hasOperation(String name) {
+        return operations.contains(name);
+    }
+
+    @Override
+    public String toString() {
+        return String.format("%s %s (%s, %s)", kind, name, idlFile, hash);
+    }
+}
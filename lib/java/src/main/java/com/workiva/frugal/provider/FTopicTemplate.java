@@ -0,0 +1,203 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package com.workiva.frugal.provider;
+
+import java.util.ArrayList;
+import java.util.Collections;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.regex.Pattern;
+
+/**
+ * FTopicTemplate builds and validates the topics of a scope from its prefix
+ * template, e.g. "foo.{user}", the same way generated publishers and subscribers
+ * do. It lets code which isn't generated, such as ACL provisioning and broker
+ * setup tools, work with a scope's topics.
+ * <p>
+ * A topic is the prefix with its variables replaced, the scope name, and the
+ * operation name joined by ".", e.g. "foo.bob.Events.EventCreated" for the
+ * EventCreated operation of the Events scope with the prefix above.
+ */
+public class FTopicTemplate {
+
+    private static final String DELIMITER = ".";
+    private static final Pattern INVALID_TOKEN = Pattern.compile("[.*>\\s]");
+
+    private final String scope;
+    private final List<String> tokens = new ArrayList<>();
+    private final List<String> variables = new ArrayList<>();
+
+    /**
+     * Creates a new FTopicTemplate for the scope with the given prefix template,
+     * which may be empty, and name.
+     *
+     * @param prefix prefix template of the scope
+     * @param scope  name of the scope
+     * @throws IllegalArgumentException if the prefix isn't a valid template
+     */
+    public FTopicTemplate(String prefix, String scope) {
+        if (!isValidToken(scope)) {
+            throw new IllegalArgumentException(String.format("invalid scope name \"%s\"", scope));
+        }
+        this.scope = scope;
+        if (prefix.isEmpty()) {
+            return;
+        }
+        for (String token : prefix.split(Pattern.quote(DELIMITER), -1)) {
+            String name = token;
+            boolean isVariable = token.startsWith("{") && token.endsWith("}");
+            if (isVariable) {
+                name = token.substring(1, token.length() - 1);
+            }
+            if (!isValidToken(name) || name.contains("{") || name.contains("}")) {
+                throw new IllegalArgumentException(
+                        String.format("invalid token \"%s\" in topic prefix \"%s\"", token, prefix));
+            }
+            if (isVariable) {
+                variables.add(name);
+            }
+            tokens.add(token);
+        }
+    }
+
+    /**
+     * Returns the names of the prefix variables in the order their values are
+     * given to {@link #topic(String, String...)}.
+     *
+     * @return prefix variable names
+     */
+    public List<String> getVariables() {
+        return Collections.unmodifiableList(variables);
+    }
+
+    /**
+     * Returns the topic of the given operation with the prefix variables
+     * replaced by the given values.
+     *
+     * @param op     name of the operation
+     * @param values values of the prefix variables
+     * @return the topic
+     * @throws IllegalArgumentException if the number of values doesn't match the
+     *                                  number of variables or a value is empty or
+     *                                  contains a topic delimiter or wildcard
+     */
+    public String topic(String op, String... values) {
+        if (values.length != variables.size()) {
+            throw new IllegalArgumentException(String.format(
+                    "topic prefix has %d variables, got %d values", variables.size(), values.length));
+        }
+        if (!isValidToken(op)) {
+            throw new IllegalArgumentException(String.format("invalid operation \"%s\"", op));
+        }
+        List<String> topic = new ArrayList<>();
+        int i = 0;
+        for (String token : tokens) {
+            if (isVariable(token)) {
+                if (!isValidToken(values[i])) {
+                    throw new IllegalArgumentException(String.format(
+                            "invalid value \"%s\" for topic prefix variable %s", values[i], variables.get(i)));
+                }
+                token = values[i++];
+            }
+            topic.add(token);
+        }
+        topic.add(scope);
+        topic.add(op);
+        return String.join(DELIMITER, topic);
+    }
+
+    /**
+     * Validates that the topic was built from the template and returns its
+     * operation and the values of its prefix variables.
+     *
+     * @param topic the topic
+     * @return the operation and variable values of the topic
+     * @throws IllegalArgumentException if the topic wasn't built from the template
+     */
+    public Match match(String topic) {
+        IllegalArgumentException mismatch = new IllegalArgumentException(
+                String.format("topic \"%s\" does not match scope %s", topic, scope));
+        String[] parts = topic.split(Pattern.quote(DELIMITER), -1);
+        if (parts.length != tokens.size() + 2) {
+            throw mismatch;
+        }
+        Map<String, String> values = new LinkedHashMap<>();
+        for (int i = 0; i < tokens.size(); i++) {
+            String token = tokens.get(i);
+            if (isVariable(token)) {
+                if (!isValidToken(parts[i])) {
+                    throw mismatch;
+                }
+                values.put(token.substring(1, token.length() - 1), parts[i]);
+            } else if (!parts[i].equals(token)) {
+                throw mismatch;
+            }
+        }
+        String op = parts[parts.length - 1];
+        if (!parts[parts.length - 2].equals(scope) || !isValidToken(op)) {
+            throw mismatch;
+        }
+        return new Match(op, values);
+    }
+
+    /**
+     * Returns the topic with the prefix variables and operation replaced by the
+     * given wildcard, e.g. "foo.*.Events.*" for the wildcard "*", which matches
+     * every topic of the scope.
+     *
+     * @param wildcard the wildcard
+     * @return the topic pattern
+     */
+    public String pattern(String wildcard) {
+        List<String> pattern = new ArrayList<>();
+        for (String token : tokens) {
+            pattern.add(isVariable(token) ? wildcard : token);
+        }
+        pattern.add(scope);
+        pattern.add(wildcard);
+        return String.join(DELIMITER, pattern);
+    }
+
+    private static boolean isVariable(String token) {
+        return token.startsWith("{");
+    }
+
+    private static boolean isValidToken(String token) {
+        return token != null && !token.isEmpty() && !INVALID_TOKEN.matcher(token).find();
+    }
+
+    /**
+     * Match is the operation and prefix variable values of a topic matched by
+     * {@link #match(String)}.
+     */
+    public static class Match {
+
+        private final String operation;
+        private final Map<String, String> values;
+
+        Match(String operation, Map<String, String> values) {
+            this.operation = operation;
+            this.values = Collections.unmodifiableMap(values);
+        }
+
+        public String getOperation() {
+            return operation;
+        }
+
+        public Map<String, String> getValues() {
+            return values;
+        }
+    }
+}
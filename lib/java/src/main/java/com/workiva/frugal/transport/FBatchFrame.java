@@ -0,0 +1,131 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package com.workiva.frugal.transport;
+
+import com.workiva.frugal.exception.TTransportExceptionType;
+import com.workiva.frugal.protocol.FAsyncCallback;
+import com.workiva.frugal.util.ProtocolUtils;
+import org.apache.thrift.TException;
+import org.apache.thrift.protocol.TProtocolException;
+import org.apache.thrift.transport.TMemoryInputTransport;
+import org.apache.thrift.transport.TTransport;
+import org.apache.thrift.transport.TTransportException;
+
+import java.io.ByteArrayOutputStream;
+import java.util.Arrays;
+import java.util.List;
+
+/**
+ * FBatchFrame publishes multiple messages in a single frame and splits them
+ * again for subscribers. The batch frame is a marker byte, distinguishing it
+ * from the frame of a single message which begins with the version of its
+ * request header, followed by the frames of the messages, which are prefixed
+ * with their length. This is used by generated code and should not be called
+ * directly.
+ */
+public final class FBatchFrame {
+
+    private static final byte BATCH_FRAME_MARKER = (byte) 0x80;
+
+    private FBatchFrame() {
+    }
+
+    /**
+     * Publish the given frames, each the framed bytes of a message, in a
+     * single frame on the topic, so they're sent with one transport flush.
+     * Nothing is published if there are no frames.
+     *
+     * @param transport the transport to publish with.
+     * @param topic     the topic to publish to.
+     * @param frames    the framed bytes of the messages.
+     * @throws TException if the batch exceeds the transport's size limit or
+     *                    the publish fails.
+     */
+    public static void publish(FPublisherTransport transport, String topic, List<byte[]> frames) throws TException {
+        if (frames.isEmpty()) {
+            return;
+        }
+        int size = 1;
+        for (byte[] frame : frames) {
+            size += frame.length;
+        }
+        int limit = transport.getPublishSizeLimit();
+        if (limit > 0 && 4 + size > limit) {
+            throw new TTransportException(TTransportExceptionType.REQUEST_TOO_LARGE,
+                    String.format("Batch of %d messages to topic %s exceeds %d bytes, was %d bytes",
+                            frames.size(), topic, limit, 4 + size));
+        }
+        byte[] data = new byte[4 + size];
+        ProtocolUtils.writeInt(size, data, 0);
+        data[4] = BATCH_FRAME_MARKER;
+        int offset = 5;
+        for (byte[] frame : frames) {
+            System.arraycopy(frame, 0, data, offset, frame.length);
+            offset += frame.length;
+        }
+        transport.publish(topic, data);
+    }
+
+    /**
+     * Return an FAsyncCallback which calls the given callback with each
+     * message of the batch frames published with
+     * {@link #publish(FPublisherTransport, String, List)}, and with other
+     * frames as they are. Every message of a batch is processed, and the first
+     * exception is rethrown.
+     *
+     * @param callback the callback receiving each message.
+     * @return the FAsyncCallback to subscribe with.
+     */
+    public static FAsyncCallback callback(FAsyncCallback callback) {
+        return transport -> {
+            byte[] data = readAll(transport);
+            if (data.length == 0 || data[0] != BATCH_FRAME_MARKER) {
+                callback.onMessage(new TMemoryInputTransport(data));
+                return;
+            }
+
+            TException error = null;
+            int offset = 1;
+            while (offset < data.length) {
+                if (data.length - offset < 4
+                        || Integer.toUnsignedLong(ProtocolUtils.readInt(data, offset)) > data.length - offset - 4) {
+                    throw new TProtocolException(TProtocolException.INVALID_DATA, "frugal: invalid batch frame");
+                }
+                int size = ProtocolUtils.readInt(data, offset);
+                byte[] frame = Arrays.copyOfRange(data, offset + 4, offset + 4 + size);
+                try {
+                    callback.onMessage(new TMemoryInputTransport(frame));
+                } catch (TException e) {
+                    if (error == null) {
+                        error = e;
+                    }
+                }
+                offset += 4 + size;
+            }
+            if (error != null) {
+                throw error;
+            }
+        };
+    }
+
+    private static byte[] readAll(TTransport transport) throws TTransportException {
+        ByteArrayOutputStream data = new ByteArrayOutputStream();
+        byte[] chunk = new byte[1024];
+        int n;
+        while ((n = transport.read(chunk, 0, chunk.length)) > 0) {
+            data.write(chunk, 0, n);
+        }
+        return data.toByteArray();
+    }
+}
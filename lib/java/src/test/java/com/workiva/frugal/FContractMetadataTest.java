@@ -0,0 +1,72 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package com.workiva.frugal;
+
+import org.junit.Test;
+import org.junit.runner.RunWith;
+import org.junit.runners.JUnit4;
+
+import java.util.Arrays;
+
+import static org.junit.Assert.assertEquals;
+import static org.junit.Assert.assertFalse;
+import static org.junit.Assert.assertTrue;
+
+/**
+ * Tests for {@link FContractMetadata}.
+ */
+@RunWith(JUnit4.class)
+public class FContractMetadataTest {
+
+    private final FContractMetadata metadata = new FContractMetadata(
+            "variety.frugal", "service", "Foo", "abc", "2.23.0", Arrays.asList("ping", "blah"));
+
+    @Test
+    public void testMatches() {
+        assertTrue(metadata.matches("abc"));
+        assertFalse(metadata.matches("def"));
+    }
+
+    @Test
+    public void testHasOperation() {
+        assertTrue(metadata.hasOperation("blah"));
+        assertFalse(metadata.hasOperation("Blah"));
+    }
+
+    @Test(expected = UnsupportedOperationException.class)
+    public void testOperationsUnmodifiable() {
+        metadata.getOperations().add("other");
+    }
+
+    @Test
+    public void testGetDescription() {
+        FContractMetadata documented = new FContractMetadata(
+                "variety.frugal", "scope", "Events", "abc", "2.23.0",
+                Arrays.asList("EventCreated", "EventDeleted"),
+                FContractMetadata.descriptions("EventCreated", "An event was created."));
+        assertEquals("An event was created.", documented.getDescription("EventCreated"));
+        assertEquals("", documented.getDescription("EventDeleted"));
+        assertEquals("", metadata.getDescription("ping"));
+    }
+
+    @Test(expected = IllegalArgumentException.class)
+    public void testDescriptionsOddArguments() {
+        FContractMetadata.descriptions("EventCreated");
+    }
+
+    @Test
+    public void testToString() {
+        assertEquals("service Foo (variety.frugal, abc)", metadata.toString());
+    }
+}
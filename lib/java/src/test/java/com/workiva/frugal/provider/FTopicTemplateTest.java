@@ -0,0 +1,106 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package com.workiva.frugal.provider;
+
+import org.junit.Test;
+import org.junit.runner.RunWith;
+import org.junit.runners.JUnit4;
+
+import java.util.Arrays;
+import java.util.Collections;
+import java.util.HashMap;
+import java.util.Map;
+
+import static org.junit.Assert.assertEquals;
+import static org.junit.Assert.fail;
+
+/**
+ * Tests for {@link FTopicTemplate}.
+ */
+@RunWith(JUnit4.class)
+public class FTopicTemplateTest {
+
+    @Test
+    public void testTopic() {
+        FTopicTemplate template = new FTopicTemplate("foo.{user}", "Events");
+        assertEquals(Collections.singletonList("user"), template.getVariables());
+        assertEquals("foo.bob.Events.EventCreated", template.topic("EventCreated", "bob"));
+    }
+
+    @Test
+    public void testTopicNoPrefix() {
+        FTopicTemplate template = new FTopicTemplate("", "Events");
+        assertEquals(Collections.emptyList(), template.getVariables());
+        assertEquals("Events.EventCreated", template.topic("EventCreated"));
+    }
+
+    @Test
+    public void testTopicInvalidValues() {
+        FTopicTemplate template = new FTopicTemplate("foo.{user}.{id}", "Events");
+        String[][] cases = {{"bob"}, {"bob", ""}, {"bob", "a.b"}, {"*", "1"}};
+        for (String[] values : cases) {
+            try {
+                template.topic("EventCreated", values);
+                fail("expected IllegalArgumentException for " + Arrays.toString(values));
+            } catch (IllegalArgumentException ignored) {
+            }
+        }
+    }
+
+    @Test
+    public void testInvalidPrefix() {
+        for (String prefix : Arrays.asList("foo.", ".foo", "foo..bar", "foo.{}", "foo.{user", "foo.b{a}r", "foo bar")) {
+            try {
+                new FTopicTemplate(prefix, "Events");
+                fail("expected IllegalArgumentException for " + prefix);
+            } catch (IllegalArgumentException ignored) {
+            }
+        }
+    }
+
+    @Test
+    public void testMatch() {
+        FTopicTemplate template = new FTopicTemplate("foo.{user}.bar.{id}", "Events");
+        FTopicTemplate.Match match = template.match("foo.bob.bar.42.Events.EventCreated");
+
+        Map<String, String> expected = new HashMap<>();
+        expected.put("user", "bob");
+        expected.put("id", "42");
+        assertEquals("EventCreated", match.getOperation());
+        assertEquals(expected, match.getValues());
+    }
+
+    @Test
+    public void testMatchMismatch() {
+        FTopicTemplate template = new FTopicTemplate("foo.{user}", "Events");
+        for (String topic : Arrays.asList(
+                "foo.bob.Events",
+                "bar.bob.Events.EventCreated",
+                "foo.bob.Other.EventCreated",
+                "foo..Events.EventCreated",
+                "foo.bob.extra.Events.EventCreated")) {
+            try {
+                template.match(topic);
+                fail("expected IllegalArgumentException for " + topic);
+            } catch (IllegalArgumentException ignored) {
+            }
+        }
+    }
+
+    @Test
+    public void testPattern() {
+        FTopicTemplate template = new FTopicTemplate("foo.{user}", "Events");
+        assertEquals("foo.*.Events.*", template.pattern("*"));
+    }
+}
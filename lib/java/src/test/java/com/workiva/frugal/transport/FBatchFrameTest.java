@@ -0,0 +1,124 @@
+package com.workiva.frugal.transport;
+
+import com.workiva.frugal.exception.TTransportExceptionType;
+import com.workiva.frugal.protocol.FAsyncCallback;
+import org.apache.thrift.TException;
+import org.apache.thrift.protocol.TProtocolException;
+import org.apache.thrift.transport.TMemoryInputTransport;
+import org.apache.thrift.transport.TTransportException;
+import org.junit.Before;
+import org.junit.Test;
+import org.junit.runner.RunWith;
+import org.junit.runners.JUnit4;
+import org.mockito.ArgumentCaptor;
+
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.Collections;
+import java.util.List;
+
+import static org.junit.Assert.assertEquals;
+import static org.junit.Assert.assertSame;
+import static org.junit.Assert.fail;
+import static org.mockito.Mockito.any;
+import static org.mockito.Mockito.anyString;
+import static org.mockito.Mockito.mock;
+import static org.mockito.Mockito.never;
+import static org.mockito.Mockito.verify;
+import static org.mockito.Mockito.when;
+
+/**
+ * Tests for {@link FBatchFrame}.
+ */
+@RunWith(JUnit4.class)
+public class FBatchFrameTest {
+
+    private FPublisherTransport mockTransport;
+    private List<String> payloads;
+
+    @Before
+    public void setUp() throws Exception {
+        mockTransport = mock(FPublisherTransport.class);
+        payloads = new ArrayList<>();
+    }
+
+    private static byte[] frameOf(String payload) throws TTransportException {
+        TMemoryOutputBuffer buffer = new TMemoryOutputBuffer();
+        buffer.write(payload.getBytes());
+        return buffer.getWriteBytes();
+    }
+
+    private byte[] publish(byte[]... frames) throws TException {
+        FBatchFrame.publish(mockTransport, "topic", Arrays.asList(frames));
+        ArgumentCaptor<byte[]> data = ArgumentCaptor.forClass(byte[].class);
+        verify(mockTransport).publish(anyString(), data.capture());
+        return data.getValue();
+    }
+
+    // Calls the callback with the published data without its frame size, the
+    // way subscriber transports do, recording the payloads received.
+    private void deliver(byte[] data, TException... errors) throws TException {
+        List<TException> remaining = new ArrayList<>(Arrays.asList(errors));
+        FAsyncCallback callback = FBatchFrame.callback(transport -> {
+            byte[] payload = new byte[1024];
+            int n = transport.read(payload, 0, payload.length);
+            payloads.add(new String(payload, 0, n));
+            if (!remaining.isEmpty()) {
+                TException error = remaining.remove(0);
+                if (error != null) {
+                    throw error;
+                }
+            }
+        });
+        callback.onMessage(new TMemoryInputTransport(Arrays.copyOfRange(data, 4, data.length)));
+    }
+
+    @Test
+    public void testPublishBatchFrame() throws TException {
+        byte[] data = publish(frameOf("foo"), frameOf(""), frameOf("bar"));
+        verify(mockTransport).publish("topic", data);
+
+        deliver(data);
+        assertEquals(Arrays.asList("foo", "", "bar"), payloads);
+    }
+
+    @Test
+    public void testCallbackError() throws TException {
+        byte[] data = publish(frameOf("foo"), frameOf("bar"), frameOf("baz"));
+
+        TException error = new TException("error");
+        try {
+            deliver(data, null, error, new TException("other"));
+            fail("Expected TException");
+        } catch (TException e) {
+            assertSame(error, e);
+        }
+        assertEquals(Arrays.asList("foo", "bar", "baz"), payloads);
+    }
+
+    @Test
+    public void testCallbackSingleMessage() throws TException {
+        deliver(frameOf("\u0000foo"));
+        assertEquals(Collections.singletonList("\u0000foo"), payloads);
+    }
+
+    @Test(expected = TProtocolException.class)
+    public void testCallbackInvalid() throws TException {
+        byte[] data = publish(frameOf("foo"));
+        deliver(Arrays.copyOf(data, data.length - 1));
+    }
+
+    @Test
+    public void testPublishSizeLimit() throws TException {
+        when(mockTransport.getPublishSizeLimit()).thenReturn(10);
+        try {
+            FBatchFrame.publish(mockTransport, "topic", Arrays.asList(frameOf("foo"), frameOf("bar")));
+            fail("Expected TTransportException");
+        } catch (TTransportException e) {
+            assertEquals(TTransportExceptionType.REQUEST_TOO_LARGE, e.getType());
+        }
+
+        FBatchFrame.publish(mockTransport, "topic", Collections.emptyList());
+        verify(mockTransport, never()).publish(anyString(), any(byte[].class));
+    }
+}
@@ -17,6 +17,7 @@ from .nats_scope_transport import FNatsSubscriberTransportFactory
 from .nats_scope_transport import FNatsSubscriberTransport
 from .nats_transport import FNatsTransport
 from .http_transport import FHttpTransport
+from .batch_frame import batch_frame_callback
 
 
 __all__ = [
@@ -30,4 +31,5 @@ __all__ = [
     'FNatsPublisherTransport',
     'FNatsSubscriberTransportFactory',
     'FNatsSubscriberTransport',
+    'batch_frame_callback',
 ]
@@ -0,0 +1,41 @@
+# Copyright 2017 Workiva
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#     http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import inspect
+
+from thrift.transport.TTransport import TMemoryBuffer
+
+from frugal.transport.batch_frame import read_all
+from frugal.transport.batch_frame import split_batch_frame
+
+
+def batch_frame_callback(callback):
+    """
+    Return a subscribe callback which calls the given callback with each
+    message of the batch frames published with batch_frame, awaiting its
+    result if it's a coroutine, and with other frames as they are. Every
+    message of a batch is processed, and the first exception is reraised.
+    This is used by generated code and should not be called directly.
+    """
+    async def batch_callback(transport):
+        error = None
+        for frame in split_batch_frame(read_all(transport)):
+            try:
+                ret = callback(TMemoryBuffer(frame))
+                if inspect.iscoroutine(ret):
+                    await ret
+            except Exception as e:
+                if error is None:
+                    error = e
+        if error is not None:
+            raise error
+
+    return batch_callback
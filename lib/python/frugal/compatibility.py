@@ -0,0 +1,48 @@
+# Copyright 2017 Workiva
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#     http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+from frugal.version import __version__
+
+
+class FIncompatibleVersionError(Exception):
+    """Raised when generated code requires a version of the library it isn't
+    compatible with."""
+
+
+def require_version(required):
+    """Raise an FIncompatibleVersionError if code generated requiring at least
+    the given version of the library can't run with this version, because
+    it's older or has a different major version. Code generated with the
+    runtime_check option calls it when its module is imported, so a mismatch
+    fails at startup rather than with obscure errors later.
+
+    Args:
+        required: the minimum version of the library, e.g. "2.23.0".
+    """
+    required_version = _parse_version(required)
+    version = _parse_version(__version__)
+    if version[0] != required_version[0]:
+        raise FIncompatibleVersionError(
+            'generated code requires frugal version {0}, incompatible with '
+            'major version {1}'.format(required, __version__))
+    if version < required_version:
+        raise FIncompatibleVersionError(
+            'generated code requires frugal version {0} or newer, '
+            'found {1}'.format(required, __version__))
+
+
+def _parse_version(version):
+    release = version.split('-', 1)[0].split('+', 1)[0]
+    parts = release.split('.')
+    if len(parts) != 3 or not all(part.isdigit() for part in parts):
+        raise ValueError(
+            'invalid version {0}, expected major.minor.patch'.format(version))
+    return tuple(int(part) for part in parts)
@@ -0,0 +1,69 @@
+# Copyright 2017 Workiva
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#     http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+class FContractMetadata(object):
+    """
+    FContractMetadata describes the IDL contract a scope or service was
+    generated from. Generated code exposes an FContractMetadata for each scope
+    and service, which can be used for version checks, routing tables, and
+    observability labels.
+    """
+
+    def __init__(self, idl_file, kind, name, hash, compiler_version,
+                 operations, descriptions=None):
+        """
+        Initialize FContractMetadata.
+
+        Args:
+            idl_file: name of the frugal file defining the contract.
+            kind: either "scope" or "service".
+            name: name of the scope or service.
+            hash: hash of the contract which ignores comments and formatting.
+            compiler_version: version of the frugal compiler which generated
+                              the code.
+            operations: names of the scope's operations or the service's
+                        methods, in the order they are defined.
+            descriptions: dict of the names of operations documented in the
+                          IDL to their doc comments.
+        """
+        self.idl_file = idl_file
+        self.kind = kind
+        self.name = name
+        self.hash = hash
+        self.compiler_version = compiler_version
+        self.operations = tuple(operations)
+        self.descriptions = dict(descriptions or {})
+
+    def matches(self, hash):
+        """
+        Return True if the contract has the given hash, i.e. the other side of
+        the scope or service was built from the same contract.
+        """
+        return self.hash == hash
+
+    def has_operation(self, name):
+        """
+        Return True if the contract defines an operation or method with the
+        given name.
+        """
+        return name in self.operations
+
+    def description(self, name):
+        """
+        Return the doc comment of the operation or method with the given name,
+        or an empty string if it isn't documented.
+        """
+        return self.descriptions.get(name, '')
+
+    def __repr__(self):
+        return '{} {} ({}, {})'.format(
+            self.kind, self.name, self.idl_file, self.hash)
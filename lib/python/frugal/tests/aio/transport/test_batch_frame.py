@@ -0,0 +1,70 @@
+# Copyright 2017 Workiva
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#     http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+from thrift.transport.TTransport import TMemoryBuffer
+
+from frugal.aio.transport import batch_frame_callback
+from frugal.tests.aio import utils
+from frugal.transport import batch_frame
+from frugal.transport import TMemoryOutputBuffer
+
+
+def frame_of(payload):
+    buffer = TMemoryOutputBuffer(0)
+    buffer.write(payload)
+    return buffer.getvalue()
+
+
+class TestBatchFrameCallback(utils.AsyncIOTestCase):
+
+    def setUp(self):
+        super().setUp()
+
+        self.payloads = []
+        self.errors = []
+
+    async def callback(self, transport):
+        self.payloads.append(transport.getvalue())
+        if self.errors:
+            error = self.errors.pop(0)
+            if error is not None:
+                raise error
+
+    async def deliver(self, data):
+        # Subscriber transports deliver frames without their size.
+        await batch_frame_callback(self.callback)(TMemoryBuffer(data[4:]))
+
+    @utils.async_runner
+    async def test_callback(self):
+        data = batch_frame('topic',
+                           [frame_of(b'foo'), frame_of(b''), frame_of(b'bar')],
+                           0)
+        await self.deliver(data)
+        self.assertEqual([b'foo', b'', b'bar'], self.payloads)
+
+    @utils.async_runner
+    async def test_callback_error(self):
+        data = batch_frame('topic',
+                           [frame_of(b'foo'), frame_of(b'bar'),
+                            frame_of(b'baz')],
+                           0)
+        error = Exception('error')
+        self.errors = [None, error, Exception('other')]
+
+        with self.assertRaises(Exception) as cm:
+            await self.deliver(data)
+        self.assertIs(error, cm.exception)
+        self.assertEqual([b'foo', b'bar', b'baz'], self.payloads)
+
+    @utils.async_runner
+    async def test_callback_single_message(self):
+        await self.deliver(frame_of(b'\x00foo'))
+        self.assertEqual([b'\x00foo'], self.payloads)
@@ -0,0 +1,36 @@
+# Copyright 2017 Workiva
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#     http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import unittest
+
+from frugal.compatibility import FIncompatibleVersionError
+from frugal.compatibility import require_version
+from frugal.version import __version__
+
+
+class TestRequireVersion(unittest.TestCase):
+
+    def test_compatible(self):
+        require_version(__version__)
+        require_version('2.0.0')
+        require_version('2.0.0-rc1')
+
+    def test_newer(self):
+        with self.assertRaises(FIncompatibleVersionError):
+            require_version('2.99.0')
+
+    def test_other_major_version(self):
+        with self.assertRaises(FIncompatibleVersionError):
+            require_version('1.0.0')
+
+    def test_invalid_version(self):
+        with self.assertRaises(ValueError):
+            require_version('2.x')
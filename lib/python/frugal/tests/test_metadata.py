@@ -0,0 +1,44 @@
+# Copyright 2017 Workiva
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#     http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import unittest
+
+from frugal.metadata import FContractMetadata
+
+
+class TestFContractMetadata(unittest.TestCase):
+
+    def setUp(self):
+        self.metadata = FContractMetadata(
+            'variety.frugal', 'service', 'Foo', 'abc', '2.23.0',
+            ['ping', 'blah'])
+
+    def test_matches(self):
+        self.assertTrue(self.metadata.matches('abc'))
+        self.assertFalse(self.metadata.matches('def'))
+
+    def test_has_operation(self):
+        self.assertTrue(self.metadata.has_operation('blah'))
+        self.assertFalse(self.metadata.has_operation('Blah'))
+
+    def test_description(self):
+        metadata = FContractMetadata(
+            'variety.frugal', 'scope', 'Events', 'abc', '2.23.0',
+            ['EventCreated', 'EventDeleted'],
+            {'EventCreated': 'An event was created.'})
+        self.assertEqual('An event was created.',
+                         metadata.description('EventCreated'))
+        self.assertEqual('', metadata.description('EventDeleted'))
+        self.assertEqual('', self.metadata.description('ping'))
+
+    def test_repr(self):
+        self.assertEqual('service Foo (variety.frugal, abc)',
+                         repr(self.metadata))
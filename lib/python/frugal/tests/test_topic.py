@@ -0,0 +1,64 @@
+# Copyright 2017 Workiva
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#     http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import unittest
+
+from frugal.topic import FTopicTemplate
+
+
+class TestFTopicTemplate(unittest.TestCase):
+
+    def test_topic(self):
+        template = FTopicTemplate('foo.{user}', 'Events')
+        self.assertEqual(('user',), template.variables)
+        self.assertEqual('foo.bob.Events.EventCreated',
+                         template.topic('EventCreated', 'bob'))
+
+    def test_topic_no_prefix(self):
+        template = FTopicTemplate('', 'Events')
+        self.assertEqual((), template.variables)
+        self.assertEqual('Events.EventCreated', template.topic('EventCreated'))
+
+    def test_topic_invalid_values(self):
+        template = FTopicTemplate('foo.{user}.{id}', 'Events')
+        for values in [('bob',), ('bob', ''), ('bob', 'a.b'), ('*', '1')]:
+            with self.assertRaises(ValueError):
+                template.topic('EventCreated', *values)
+        with self.assertRaises(ValueError):
+            template.topic('', 'bob', '1')
+
+    def test_invalid_prefix(self):
+        for prefix in ['foo.', '.foo', 'foo..bar', 'foo.{}', 'foo.{user',
+                       'foo.b{a}r', 'foo bar']:
+            with self.assertRaises(ValueError):
+                FTopicTemplate(prefix, 'Events')
+        with self.assertRaises(ValueError):
+            FTopicTemplate('foo', '')
+
+    def test_match(self):
+        template = FTopicTemplate('foo.{user}.bar.{id}', 'Events')
+        op, values = template.match('foo.bob.bar.42.Events.EventCreated')
+        self.assertEqual('EventCreated', op)
+        self.assertEqual({'user': 'bob', 'id': '42'}, values)
+
+    def test_match_mismatch(self):
+        template = FTopicTemplate('foo.{user}', 'Events')
+        for topic in ['foo.bob.Events',
+                      'bar.bob.Events.EventCreated',
+                      'foo.bob.Other.EventCreated',
+                      'foo..Events.EventCreated',
+                      'foo.bob.extra.Events.EventCreated']:
+            with self.assertRaises(ValueError):
+                template.match(topic)
+
+    def test_pattern(self):
+        template = FTopicTemplate('foo.{user}', 'Events')
+        self.assertEqual('foo.*.Events.*', template.pattern('*'))
@@ -0,0 +1,85 @@
+# Copyright 2017 Workiva
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#     http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import unittest
+
+from thrift.protocol.TProtocol import TProtocolException
+from thrift.transport.TTransport import TMemoryBuffer
+from thrift.transport.TTransport import TTransportException
+
+from frugal.exceptions import TTransportExceptionType
+from frugal.transport import batch_frame
+from frugal.transport import batch_frame_callback
+from frugal.transport import TMemoryOutputBuffer
+
+
+def frame_of(payload):
+    buffer = TMemoryOutputBuffer(0)
+    buffer.write(payload)
+    return buffer.getvalue()
+
+
+class TestBatchFrame(unittest.TestCase):
+
+    def setUp(self):
+        super(TestBatchFrame, self).setUp()
+
+        self.payloads = []
+        self.errors = []
+
+    def callback(self, transport):
+        self.payloads.append(transport.getvalue())
+        if self.errors:
+            error = self.errors.pop(0)
+            if error is not None:
+                raise error
+
+    def deliver(self, data):
+        # Subscriber transports deliver frames without their size.
+        batch_frame_callback(self.callback)(TMemoryBuffer(data[4:]))
+
+    def test_batch_frame(self):
+        data = batch_frame('topic',
+                           [frame_of(b'foo'), frame_of(b''), frame_of(b'bar')],
+                           0)
+        self.assertEqual(b'\x00\x00\x00\x13\x80', data[:5])
+
+        self.deliver(data)
+        self.assertEqual([b'foo', b'', b'bar'], self.payloads)
+
+    def test_callback_error(self):
+        data = batch_frame('topic',
+                           [frame_of(b'foo'), frame_of(b'bar'),
+                            frame_of(b'baz')],
+                           0)
+        error = Exception('error')
+        self.errors = [None, error, Exception('other')]
+
+        with self.assertRaises(Exception) as cm:
+            self.deliver(data)
+        self.assertIs(error, cm.exception)
+        self.assertEqual([b'foo', b'bar', b'baz'], self.payloads)
+
+    def test_callback_single_message(self):
+        self.deliver(frame_of(b'\x00foo'))
+        self.assertEqual([b'\x00foo'], self.payloads)
+
+    def test_callback_invalid(self):
+        data = batch_frame('topic', [frame_of(b'foo')], 0)
+        with self.assertRaises(TProtocolException) as cm:
+            self.deliver(data[:-1])
+        self.assertEqual(TProtocolException.INVALID_DATA, cm.exception.type)
+
+    def test_batch_frame_size_limit(self):
+        with self.assertRaises(TTransportException) as cm:
+            batch_frame('topic', [frame_of(b'foo'), frame_of(b'bar')], 10)
+        self.assertEqual(TTransportExceptionType.REQUEST_TOO_LARGE,
+                         cm.exception.type)
@@ -0,0 +1,142 @@
+# Copyright 2017 Workiva
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#     http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import re
+
+_DELIMITER = '.'
+_INVALID_TOKEN = re.compile(r'[.*>\s]')
+
+
+def _is_valid_token(token):
+    return bool(token) and not _INVALID_TOKEN.search(token)
+
+
+def _is_variable(token):
+    return token.startswith('{')
+
+
+class FTopicTemplate(object):
+    """
+    FTopicTemplate builds and validates the topics of a scope from its prefix
+    template, e.g. "foo.{user}", the same way generated publishers and
+    subscribers do. It lets code which isn't generated, such as ACL
+    provisioning and broker setup tools, work with a scope's topics.
+
+    A topic is the prefix with its variables replaced, the scope name, and the
+    operation name joined by ".", e.g. "foo.bob.Events.EventCreated" for the
+    EventCreated operation of the Events scope with the prefix above.
+    """
+
+    def __init__(self, prefix, scope):
+        """
+        Initialize FTopicTemplate.
+
+        Args:
+            prefix: prefix template of the scope, which may be empty.
+            scope: name of the scope.
+
+        Raises:
+            ValueError: if the prefix isn't a valid template.
+        """
+        if not _is_valid_token(scope):
+            raise ValueError('invalid scope name "{}"'.format(scope))
+        self._scope = scope
+        self._tokens = []
+        self._variables = []
+        if not prefix:
+            return
+        for token in prefix.split(_DELIMITER):
+            name = token
+            is_variable = token.startswith('{') and token.endswith('}')
+            if is_variable:
+                name = token[1:-1]
+            if not _is_valid_token(name) or '{' in name or '}' in name:
+                raise ValueError('invalid token "{}" in topic prefix "{}"'
+                                 .format(token, prefix))
+            if is_variable:
+                self._variables.append(name)
+            self._tokens.append(token)
+
+    @property
+    def variables(self):
+        """
+        Names of the prefix variables in the order their values are given to
+        topic.
+        """
+        return tuple(self._variables)
+
+    def topic(self, op, *values):
+        """
+        Return the topic of the given operation with the prefix variables
+        replaced by the given values.
+
+        Raises:
+            ValueError: if the number of values doesn't match the number of
+                        variables or a value is empty or contains a topic
+                        delimiter or wildcard.
+        """
+        if len(values) != len(self._variables):
+            raise ValueError(
+                'topic prefix has {} variables, got {} values'.format(
+                    len(self._variables), len(values)))
+        if not _is_valid_token(op):
+            raise ValueError('invalid operation "{}"'.format(op))
+        topic = []
+        values = list(values)
+        for token in self._tokens:
+            if _is_variable(token):
+                value = values.pop(0)
+                if not _is_valid_token(value):
+                    raise ValueError(
+                        'invalid value "{}" for topic prefix variable {}'
+                        .format(value, token[1:-1]))
+                token = value
+            topic.append(token)
+        topic.extend([self._scope, op])
+        return _DELIMITER.join(topic)
+
+    def match(self, topic):
+        """
+        Validate that the topic was built from the template and return its
+        operation and a dict of the values of its prefix variables keyed by
+        name.
+
+        Raises:
+            ValueError: if the topic wasn't built from the template.
+        """
+        mismatch = ValueError('topic "{}" does not match scope {}'.format(
+            topic, self._scope))
+        parts = topic.split(_DELIMITER)
+        if len(parts) != len(self._tokens) + 2:
+            raise mismatch
+        values = {}
+        for token, part in zip(self._tokens, parts):
+            if _is_variable(token):
+                if not _is_valid_token(part):
+                    raise mismatch
+                values[token[1:-1]] = part
+            elif part != token:
+                raise mismatch
+        op = parts[-1]
+        if parts[-2] != self._scope or not _is_valid_token(op):
+            raise mismatch
+        return op, values
+
+    def pattern(self, wildcard):
+        """
+        Return the topic with the prefix variables and operation replaced by
+        the given wildcard, e.g. "foo.*.Events.*" for the wildcard "*", which
+        matches every topic of the scope.
+        """
+        pattern = [wildcard if _is_variable(token) else token
+                   for token in self._tokens]
+        pattern.extend([self._scope, wildcard])
+        return _DELIMITER.join(pattern)
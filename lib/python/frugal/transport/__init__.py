@@ -9,6 +9,8 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 
+from .batch_frame import batch_frame
+from .batch_frame import batch_frame_callback
 from .memory_output_buffer import TMemoryOutputBuffer
 from .scope_transport import FPublisherTransport
 from .scope_transport import FSubscriberTransport
@@ -22,6 +24,8 @@ __all__ = [
     'TSynchronousTransport',
     'FTransportFactory',
     'TMemoryOutputBuffer',
+    'batch_frame',
+    'batch_frame_callback',
     'FPublisherTransport',
     'FSubscriberTransport',
     'FPublisherTransportFactory',
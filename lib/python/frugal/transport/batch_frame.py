@@ -0,0 +1,107 @@
+# Copyright 2017 Workiva
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#     http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import struct
+
+from thrift.protocol.TProtocol import TProtocolException
+from thrift.transport.TTransport import TMemoryBuffer
+from thrift.transport.TTransport import TTransportException
+
+from frugal.exceptions import TTransportExceptionType
+
+# The batch frame is a marker byte, distinguishing it from the frame of a
+# single message which begins with the version of its request header, followed
+# by the frames of the messages, which are prefixed with their length.
+_BATCH_FRAME_MARKER = b'\x80'
+
+
+def batch_frame(topic, frames, limit):
+    """
+    Return the frame publishing the given frames, each the framed bytes of a
+    message, in a single message on the topic. This is used by generated
+    code and should not be called directly.
+
+    Args:
+        topic: string topic the batch is published to.
+        frames: list of the framed bytes of the messages.
+        limit: integer publish size limit of the transport.
+
+    Raises:
+        TTransportException: if the batch exceeds the size limit.
+    """
+    data = _BATCH_FRAME_MARKER + b''.join(frames)
+    if len(data) + 4 > limit > 0:
+        raise TTransportException(
+            type=TTransportExceptionType.REQUEST_TOO_LARGE,
+            message='Batch of {} messages to topic {} exceeds {} bytes, '
+                    'was {} bytes'.format(len(frames), topic, limit,
+                                          len(data) + 4))
+    return struct.pack('!I', len(data)) + data
+
+
+def split_batch_frame(data):
+    """
+    Return the frames of the messages in the given batch frame, without its
+    size, or a list of just the data if it isn't a batch frame.
+
+    Raises:
+        TProtocolException: if the batch frame is truncated.
+    """
+    if data[:1] != _BATCH_FRAME_MARKER:
+        return [data]
+
+    frames = []
+    offset = 1
+    while offset < len(data):
+        if len(data) - offset < 4:
+            raise TProtocolException(TProtocolException.INVALID_DATA,
+                                     'frugal: invalid batch frame')
+        size = struct.unpack_from('!I', data, offset)[0]
+        if size > len(data) - offset - 4:
+            raise TProtocolException(TProtocolException.INVALID_DATA,
+                                     'frugal: invalid batch frame')
+        frames.append(data[offset + 4:offset + 4 + size])
+        offset += 4 + size
+    return frames
+
+
+def batch_frame_callback(callback):
+    """
+    Return a subscribe callback which calls the given callback with each
+    message of the batch frames published with batch_frame, and with other
+    frames as they are. Every message of a batch is processed, and the first
+    exception is reraised. This is used by generated code and should not be
+    called directly.
+    """
+    def batch_callback(transport):
+        error = None
+        for frame in split_batch_frame(read_all(transport)):
+            try:
+                callback(TMemoryBuffer(frame))
+            except Exception as e:
+                if error is None:
+                    error = e
+        if error is not None:
+            raise error
+
+    return batch_callback
+
+
+def read_all(transport):
+    """
+    Return the remaining bytes of the given transport.
+    """
+    chunks = []
+    chunk = transport.read(1024)
+    while chunk:
+        chunks.append(chunk)
+        chunk = transport.read(1024)
+    return b''.join(chunks)
@@ -2,27 +2,65 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"os/exec"
+	"runtime"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/Workiva/frugal/compiler"
 	"github.com/Workiva/frugal/compiler/generator"
 	"github.com/Workiva/frugal/compiler/globals"
 	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/urfave/cli"
 )
 
-const defaultTopicDelim = "."
+const (
+	defaultTopicDelim = "."
+
+	// watchInterval is how often -watch checks files for changes.
+	watchInterval = 500 * time.Millisecond
+)
 
 var (
-	help    bool
-	gen     string
-	out     string
-	delim   string
-	audit   string
-	recurse bool
-	verbose bool
-	version bool
+	help        bool
+	gen         string
+	out         string
+	delim       string
+	audit       string
+	diff        string
+	recurse     bool
+	only        string
+	exclude     string
+	includeMap  string
+	namespaces  string
+	verbose     bool
+	version     bool
+	catalog     bool
+	testVectors bool
+	tracing     bool
+	incremental bool
+	watch       bool
+	stdout      bool
+	dryRun      bool
+	strict      bool
+	jobs        int
+
+	namespacePolicy string
+
+	stats            bool
+	hash             bool
+	owners           bool
+	unused           bool
+	lint             bool
+	maxPayloadSize   int
+	maxPayloadFields int
+	maxPayloadDepth  int
+	fieldIDs         string
+	updateFieldIDs   bool
 )
 
 func main() {
@@ -55,11 +93,83 @@ func main() {
 			Usage:       "set the delimiter for pub/sub topic tokens",
 			Destination: &delim,
 		},
+		cli.StringFlag{
+			Name:        "only",
+			Usage:       "generate only the comma-separated scopes and services, given by name or as tag:<tag> for those with the tag in their \"tags\" annotation",
+			Destination: &only,
+		},
+		cli.StringFlag{
+			Name:        "exclude",
+			Usage:       "don't generate the comma-separated scopes and services, given by name or as tag:<tag>",
+			Destination: &exclude,
+		},
+		cli.StringFlag{
+			Name:        "include-map",
+			Usage:       "remap the namespaces of includes with comma-separated [<lang>:]<include>:<namespace> entries, e.g. java:shared:com.acme.shared",
+			Destination: &includeMap,
+		},
+		cli.StringFlag{
+			Name:        "namespace",
+			Usage:       "override the namespaces of the generated file with comma-separated <lang>=<namespace> entries, e.g. dart=acme_events,java=com.acme.events",
+			Destination: &namespaces,
+		},
 		cli.BoolFlag{
 			Name:        "recurse, r",
 			Usage:       "generate included files",
 			Destination: &recurse,
 		},
+		cli.BoolFlag{
+			Name:        "event-catalog",
+			Usage:       "also write a JSON catalog of the scopes, their topics, payload schemas, and owners to <name>.events.json in the output location",
+			Destination: &catalog,
+		},
+		cli.BoolFlag{
+			Name:        "test-vectors",
+			Usage:       "also write the encoding of a deterministic instance of each struct with each protocol to test_vectors/<name> in the output location, and generate tests decoding them",
+			Destination: &testVectors,
+		},
+		cli.BoolFlag{
+			Name:        "tracing",
+			Usage:       "generate publishers starting a span per publish and subscribers starting a child span per received message with the tracer set by frugal.SetTracer (go only)",
+			Destination: &tracing,
+		},
+		cli.BoolFlag{
+			Name:        "incremental",
+			Usage:       "skip regenerating files which, along with their includes and the options, are unchanged since they were last generated to the output location, as recorded in its .frugal-cache.json",
+			Destination: &incremental,
+		},
+		cli.StringFlag{
+			Name:        "namespace-policy",
+			Value:       compiler.NamespaceFallback,
+			Usage:       "what to do when a file or include has no namespace for the language: fallback to the file name or default package, warn, or error",
+			Destination: &namespacePolicy,
+		},
+		cli.BoolFlag{
+			Name:        "strict",
+			Usage:       "fail if generation reports any warnings, e.g. about features a language doesn't support",
+			Destination: &strict,
+		},
+		cli.IntFlag{
+			Name:        "jobs, j",
+			Value:       runtime.NumCPU(),
+			Usage:       "the number of files and languages to generate in parallel when given several of either",
+			Destination: &jobs,
+		},
+		cli.BoolFlag{
+			Name:        "dry-run",
+			Usage:       "print the paths of the files generation would write, for each -gen target, without writing anything",
+			Destination: &dryRun,
+		},
+		cli.BoolFlag{
+			Name:        "stdout",
+			Usage:       "write the generated file to stdout instead of the output location, failing if generation produces more than one file",
+			Destination: &stdout,
+		},
+		cli.BoolFlag{
+			Name:        "watch",
+			Usage:       "keep running and incrementally regenerate a file's outputs whenever it or one of its includes changes",
+			Destination: &watch,
+		},
 		cli.BoolFlag{
 			Name:        "verbose, v",
 			Usage:       "verbose mode",
@@ -74,6 +184,130 @@ func main() {
 			Usage:       "frugal file to run audit against",
 			Destination: &audit,
 		},
+		cli.StringFlag{
+			Name:        "diff",
+			Usage:       "report the semantic changes from the given frugal file to each file, classified as compatible, risky, or breaking, instead of generating code",
+			Destination: &diff,
+		},
+		cli.BoolFlag{
+			Name:        "stats",
+			Usage:       "report payload statistics instead of generating code",
+			Destination: &stats,
+		},
+		cli.BoolFlag{
+			Name:        "hash",
+			Usage:       "print a hash of each scope and service contract, which ignores comments and formatting, instead of generating code",
+			Destination: &hash,
+		},
+		cli.BoolFlag{
+			Name:        "owners",
+			Usage:       "print the owners and Slack channel of each scope and service, from the owners and slack annotations, instead of generating code",
+			Destination: &owners,
+		},
+		cli.BoolFlag{
+			Name:        "unused",
+			Usage:       "report definitions in the given files and their includes which no scope, service, or constant references",
+			Destination: &unused,
+		},
+		cli.BoolFlag{
+			Name:        "lint",
+			Usage:       "report style and compatibility problems in the given files, checking namespaces and reserved words for the -gen languages (reserved words for all languages without -gen), and fail if there are any",
+			Destination: &lint,
+		},
+		cli.IntFlag{
+			Name:        "max-payload-size",
+			Usage:       "with -stats, fail if a payload's estimated size in bytes exceeds this (0 for no limit)",
+			Destination: &maxPayloadSize,
+		},
+		cli.IntFlag{
+			Name:        "max-payload-fields",
+			Usage:       "with -stats, fail if a payload's nested field count exceeds this (0 for no limit)",
+			Destination: &maxPayloadFields,
+		},
+		cli.IntFlag{
+			Name:        "max-payload-depth",
+			Usage:       "with -stats, fail if a payload's nesting depth exceeds this (0 for no limit)",
+			Destination: &maxPayloadDepth,
+		},
+		cli.StringFlag{
+			Name:        "field-ids",
+			Usage:       "check field IDs against the given baseline file, which records every field ID ever assigned, instead of generating code",
+			Destination: &fieldIDs,
+		},
+		cli.BoolFlag{
+			Name:        "update-field-ids",
+			Usage:       "with -field-ids, add the field IDs in the given files to the baseline if the check passes",
+			Destination: &updateFieldIDs,
+		},
+	}
+
+	app.Commands = []cli.Command{
+		{
+			Name:      "fmt",
+			Usage:     "format frugal files canonically, printing the results",
+			ArgsUsage: "file...",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "w",
+					Usage: "write the results to the files instead of printing them",
+				},
+				cli.BoolFlag{
+					Name:  "d",
+					Usage: "print diffs of the changes instead of the results",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if len(c.Args()) == 0 {
+					fmt.Printf("Usage: %s fmt [-w] [-d] file...\n", app.Name)
+					os.Exit(1)
+				}
+				// Every file is formatted before failing.
+				failed := false
+				for _, file := range c.Args() {
+					if err := formatFile(file, c.Bool("w"), c.Bool("d")); err != nil {
+						fmt.Printf("Failed to format %s:\n\t%s\n", file, err.Error())
+						failed = true
+					}
+				}
+				if failed {
+					os.Exit(1)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "deps",
+			Usage:     "print the include graph of a frugal file with the packages generated for each file, failing if includes are circular",
+			ArgsUsage: "file",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "format",
+					Value: "dot",
+					Usage: "print the graph as dot or json",
+				},
+				cli.StringFlag{
+					Name:  "gen",
+					Value: "go java py dart",
+					Usage: "report the packages generated for the space-separated targets",
+				},
+				cli.StringFlag{
+					Name:  "out",
+					Usage: "report packages for the given output location instead of each language's default",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if len(c.Args()) != 1 {
+					fmt.Printf("Usage: %s deps [-format dot|json] [-gen targets] [-out dir] file\n", app.Name)
+					os.Exit(1)
+				}
+				file := c.Args()[0]
+				if err := printDependencies(file, c.String("format"), strings.Fields(c.String("gen")), c.String("out")); err != nil {
+					fmt.Printf("Failed to resolve dependencies of %s:\n\t%s\n", file, err.Error())
+					os.Exit(1)
+				}
+				return nil
+			},
+		},
 	}
 
 	app.Action = func(c *cli.Context) error {
@@ -93,7 +327,7 @@ func main() {
 			os.Exit(1)
 		}
 
-		if gen == "" && audit == "" {
+		if gen == "" && audit == "" && diff == "" && !stats && !hash && !owners && !unused && !lint && fieldIDs == "" {
 			fmt.Println("No output language specified")
 			fmt.Printf("Usage: %s [options] file\n\n", app.Name)
 			fmt.Printf("Use %s -help for a list of options\n", app.Name)
@@ -106,6 +340,19 @@ func main() {
 			Delim:   delim,
 			Recurse: recurse,
 			Verbose: verbose,
+			Only:    splitList(only),
+			Exclude: splitList(exclude),
+
+			IncludeMap: splitList(includeMap),
+			Namespaces: splitList(namespaces),
+
+			EventCatalog: catalog,
+			TestVectors:  testVectors,
+			Tracing:      tracing,
+			Incremental:  incremental,
+			Strict:       strict,
+
+			NamespacePolicy: namespacePolicy,
 		}
 
 		// Handle panics for graceful error messages.
@@ -116,13 +363,148 @@ func main() {
 			}
 		}()
 
+		targets := strings.Fields(gen)
+		if dryRun {
+			if stdout || watch {
+				fmt.Fprintln(os.Stderr, "-dry-run can't be used with -stdout or -watch")
+				os.Exit(1)
+			}
+			for _, file := range c.Args() {
+				for _, target := range targets {
+					o := options
+					o.File = file
+					o.Gen = target
+					paths, err := compiler.ListOutputs(o)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Failed to generate %s:\n\t%s\n", file, err.Error())
+						os.Exit(1)
+					}
+					for _, path := range paths {
+						fmt.Println(path)
+					}
+				}
+			}
+			return nil
+		}
+
+		if stdout {
+			if len(targets) != 1 || len(c.Args()) != 1 || watch {
+				fmt.Fprintln(os.Stderr, "-stdout requires a single -gen target and file")
+				os.Exit(1)
+			}
+			options.File = c.Args()[0]
+			if err := compileToStdout(options); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to generate %s:\n\t%s\n", options.File, err.Error())
+				os.Exit(1)
+			}
+			return nil
+		}
+
+		if watch {
+			if len(targets) != 1 {
+				fmt.Println("-watch requires a single -gen target")
+				os.Exit(1)
+			}
+			compiler.Watch(c.Args(), options, watchInterval, nil, func(file string, err error) {
+				if err != nil {
+					fmt.Printf("Failed to generate %s:\n\t%s\n", file, err.Error())
+					return
+				}
+				fmt.Printf("Generated %s\n", file)
+			})
+			return nil
+		}
+
+		// The unused analysis treats all of the given files as one workspace.
+		if unused {
+			if _, err := parser.NewUnusedAnalyzer().Analyze(c.Args()...); err != nil {
+				fmt.Printf("Failed to analyze %s:\n\t%s\n", strings.Join(c.Args(), ", "), err.Error())
+				os.Exit(1)
+			}
+			return nil
+		}
+
+		// Problems are reported for every file before failing.
+		if lint {
+			languages := make([]string, len(targets))
+			for i, target := range targets {
+				languages[i] = strings.Split(target, ":")[0]
+			}
+			linter := parser.NewLinter(languages...)
+			found := false
+			for _, file := range c.Args() {
+				problems, err := linter.Lint(file)
+				if err != nil {
+					fmt.Printf("Failed to lint %s:\n\t%s\n", file, err.Error())
+					os.Exit(1)
+				}
+				found = found || len(problems) > 0
+			}
+			if found {
+				os.Exit(1)
+			}
+			return nil
+		}
+
+		// Generating several files or languages runs them in parallel.
+		generating := audit == "" && diff == "" && !stats && !hash && !owners && fieldIDs == ""
+		if generating && (len(targets) > 1 || len(c.Args()) > 1 && jobs > 1) {
+			all := []compiler.Options{}
+			for _, file := range c.Args() {
+				for _, target := range targets {
+					o := options
+					o.File = file
+					o.Gen = target
+					all = append(all, o)
+				}
+			}
+			if err := compiler.CompileAll(all, jobs, compileInSubprocess); err != nil {
+				fmt.Println(err.Error())
+				os.Exit(1)
+			}
+			return nil
+		}
+
 		var err error
+		var baseline parser.FieldIDBaseline
+		if fieldIDs != "" {
+			if baseline, err = parser.ReadFieldIDBaseline(fieldIDs); err != nil {
+				fmt.Printf("Failed to read %s:\n\t%s\n", fieldIDs, err.Error())
+				os.Exit(1)
+			}
+		}
+		checker := parser.NewFieldIDChecker()
 		auditor := parser.NewAuditor()
+		differ := parser.NewDiffer()
+		analyzer := parser.NewPayloadAnalyzer(parser.PayloadThresholds{
+			MaxEstimatedSize: maxPayloadSize,
+			MaxFields:        maxPayloadFields,
+			MaxDepth:         maxPayloadDepth,
+		})
 		for _, options.File = range c.Args() {
-			if audit == "" {
-				err = compiler.Compile(options)
-			} else {
+			switch {
+			case stats:
+				var payloads []*parser.PayloadStats
+				payloads, err = analyzer.Analyze(options.File)
+				for _, payload := range payloads {
+					fmt.Println(payload)
+				}
+			case hash:
+				err = printHashes(options.File)
+			case owners:
+				err = printOwners(options.File)
+			case fieldIDs != "":
+				baseline, err = checker.Check(options.File, baseline)
+			case audit != "":
 				err = auditor.Audit(audit, options.File)
+			case diff != "":
+				var changes []*parser.Change
+				changes, err = differ.Diff(diff, options.File)
+				for _, change := range changes {
+					fmt.Println(change)
+				}
+			default:
+				err = compiler.Compile(options)
 			}
 			if err != nil {
 				fmt.Printf("Failed to generate %s:\n\t%s\n", options.File, err.Error())
@@ -130,15 +512,205 @@ func main() {
 			}
 		}
 
+		if fieldIDs != "" && updateFieldIDs {
+			if err := parser.WriteFieldIDBaseline(fieldIDs, baseline); err != nil {
+				fmt.Printf("Failed to write %s:\n\t%s\n", fieldIDs, err.Error())
+				os.Exit(1)
+			}
+		}
+
 		return nil
 	}
 
 	app.Run(os.Args)
 }
 
+// compileToStdout compiles the options in memory and writes the generated
+// file to stdout, which fails if generation produces more than one file.
+func compileToStdout(options compiler.Options) error {
+	files, err := compiler.CompileToMemory(options)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("-stdout requires generation to produce a single file, but it produced none")
+	}
+	if len(files) > 1 {
+		paths := make([]string, 0, len(files))
+		for path := range files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		return fmt.Errorf("-stdout requires generation to produce a single file, but it produced %s",
+			strings.Join(paths, ", "))
+	}
+	for _, contents := range files {
+		_, err = os.Stdout.Write(contents)
+	}
+	return err
+}
+
+// compileInSubprocess compiles the options with a separate frugal process,
+// since the compiler's state is global and can't be shared by parallel
+// compilations.
+func compileInSubprocess(options compiler.Options) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	args := []string{"-gen", options.Gen, "-delim", options.Delim, "-namespace-policy", options.NamespacePolicy}
+	if options.Out != "" {
+		args = append(args, "-out", options.Out)
+	}
+	if len(options.Only) > 0 {
+		args = append(args, "-only", strings.Join(options.Only, ","))
+	}
+	if len(options.Exclude) > 0 {
+		args = append(args, "-exclude", strings.Join(options.Exclude, ","))
+	}
+	if len(options.IncludeMap) > 0 {
+		args = append(args, "-include-map", strings.Join(options.IncludeMap, ","))
+	}
+	if len(options.Namespaces) > 0 {
+		args = append(args, "-namespace", strings.Join(options.Namespaces, ","))
+	}
+	for _, flag := range []struct {
+		name string
+		set  bool
+	}{
+		{"-r", options.Recurse},
+		{"-verbose", options.Verbose},
+		{"-event-catalog", options.EventCatalog},
+		{"-test-vectors", options.TestVectors},
+		{"-tracing", options.Tracing},
+		{"-incremental", options.Incremental},
+		{"-strict", options.Strict},
+	} {
+		if flag.set {
+			args = append(args, flag.name)
+		}
+	}
+	args = append(args, options.File)
+
+	output, err := exec.Command(executable, args...).CombinedOutput()
+	if err != nil {
+		failure := strings.TrimSpace(string(output))
+		return fmt.Errorf("%s", strings.TrimPrefix(failure, fmt.Sprintf("Failed to generate %s:\n\t", options.File)))
+	}
+	os.Stdout.Write(output)
+	return nil
+}
+
+// splitList splits a comma-separated flag value, ignoring empty values.
+func splitList(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// printHashes prints the contract hash of each scope and service in the given
+// file.
+func printHashes(file string) error {
+	frugal, err := parser.ParseFrugal(file)
+	if err != nil {
+		return err
+	}
+	for _, scope := range frugal.Scopes {
+		fmt.Printf("scope %s: %s\n", scope.Name, frugal.ScopeHash(scope))
+	}
+	for _, service := range frugal.Services {
+		fmt.Printf("service %s: %s\n", service.Name, frugal.ServiceHash(service))
+	}
+	return nil
+}
+
+// printOwners prints the ownership of each scope and service in the given
+// file.
+func printOwners(file string) error {
+	frugal, err := parser.ParseFrugal(file)
+	if err != nil {
+		return err
+	}
+	for _, ownership := range frugal.Ownerships() {
+		fmt.Println(ownership)
+	}
+	return nil
+}
+
+// formatFile formats the given file, writing the result to it if write is set
+// and it changed, and printing a diff of the changes if diff is set. The
+// result is printed if neither is set.
+func formatFile(file string, write, diff bool) error {
+	src, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	formatted, err := parser.Format(file, src)
+	if err != nil {
+		return err
+	}
+
+	if diff && string(formatted) != string(src) {
+		changes, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(src)),
+			B:        difflib.SplitLines(string(formatted)),
+			FromFile: file + ".orig",
+			ToFile:   file,
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Print(changes)
+	}
+	if write && string(formatted) != string(src) {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(file, formatted, info.Mode())
+	}
+	if !write && !diff {
+		os.Stdout.Write(formatted)
+	}
+	return nil
+}
+
+// printDependencies prints the include graph of the given file in the given
+// format, returning an error if its includes are circular once it's printed.
+func printDependencies(file, format string, targets []string, out string) error {
+	if format != "dot" && format != "json" {
+		return fmt.Errorf("Invalid format %s, expected dot or json", format)
+	}
+	graph, err := compiler.Dependencies(file, targets, out)
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		err = graph.WriteJSON(os.Stdout)
+	} else {
+		err = graph.WriteDOT(os.Stdout)
+	}
+	if err != nil {
+		return err
+	}
+	if len(graph.Cycles) > 0 {
+		cycles := make([]string, len(graph.Cycles))
+		for i, cycle := range graph.Cycles {
+			cycles[i] = strings.Join(cycle, " -> ")
+		}
+		return fmt.Errorf("Circular include: %s", strings.Join(cycles, ", "))
+	}
+	return nil
+}
+
 func genUsage() string {
 	usage := "generate code with a registered generator and optional parameters " +
-		"(lang[:key1=val1[,key2[,key3=val3]]])\n"
+		"(lang[:key1=val1[,key2[,key3=val3]]]), or several separated by spaces\n"
 	langKeys := make([]string, 0, len(generator.Languages))
 	for lang := range generator.Languages {
 		langKeys = append(langKeys, lang)
@@ -0,0 +1,213 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// newFile converts the parse tree of a Frugal file to its model. Files
+// already converted, since more than one file can include the same file, are
+// tracked by converted.
+func newFile(frugal *parser.Frugal, converted map[*parser.Frugal]*File) *File {
+	if file, ok := converted[frugal]; ok {
+		return file
+	}
+	file := &File{
+		Name:       frugal.Name,
+		Path:       frugal.Path,
+		Namespaces: make(map[string]string),
+	}
+	converted[frugal] = file
+
+	for _, namespace := range frugal.Namespaces {
+		file.Namespaces[namespace.Scope] = namespace.Value
+	}
+	for _, include := range frugal.Includes {
+		name := filepath.Base(include.Name)
+		if parsed, ok := frugal.ParsedIncludes[name]; ok {
+			file.Includes = append(file.Includes, &Include{
+				Name: name,
+				Path: include.Value,
+				File: newFile(parsed, converted),
+			})
+		}
+	}
+	for _, constant := range frugal.Constants {
+		file.Constants = append(file.Constants, &Constant{
+			Comment:     constant.Comment,
+			Name:        constant.Name,
+			Type:        newType(constant.Type),
+			Value:       newValue(constant.Value),
+			Annotations: newAnnotations(constant.Annotations),
+		})
+	}
+	for _, typedef := range frugal.Typedefs {
+		file.Typedefs = append(file.Typedefs, &Typedef{
+			Comment:     typedef.Comment,
+			Name:        typedef.Name,
+			Type:        newType(typedef.Type),
+			Annotations: newAnnotations(typedef.Annotations),
+		})
+	}
+	for _, enum := range frugal.Enums {
+		file.Enums = append(file.Enums, newEnum(enum))
+	}
+	for _, s := range frugal.Structs {
+		file.Structs = append(file.Structs, newStruct(s, StructKindStruct))
+	}
+	for _, s := range frugal.Exceptions {
+		file.Exceptions = append(file.Exceptions, newStruct(s, StructKindException))
+	}
+	for _, s := range frugal.Unions {
+		file.Unions = append(file.Unions, newStruct(s, StructKindUnion))
+	}
+	for _, service := range frugal.Services {
+		file.Services = append(file.Services, newService(frugal, service))
+	}
+	for _, scope := range frugal.Scopes {
+		file.Scopes = append(file.Scopes, newScope(frugal, scope))
+	}
+	return file
+}
+
+func newAnnotations(annotations parser.Annotations) Annotations {
+	converted := make(Annotations, len(annotations))
+	for _, annotation := range annotations {
+		converted[annotation.Name] = annotation.Value
+	}
+	return converted
+}
+
+func newType(t *parser.Type) *Type {
+	if t == nil {
+		return nil
+	}
+	return &Type{
+		Name:        t.Name,
+		KeyType:     newType(t.KeyType),
+		ValueType:   newType(t.ValueType),
+		Annotations: newAnnotations(t.Annotations),
+	}
+}
+
+func newValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case parser.Identifier:
+		return Identifier(v)
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, elem := range v {
+			values[i] = newValue(elem)
+		}
+		return values
+	case []parser.KeyValue:
+		kvs := make([]KeyValue, len(v))
+		for i, kv := range v {
+			kvs[i] = KeyValue{Key: newValue(kv.Key), Value: newValue(kv.Value)}
+		}
+		return kvs
+	}
+	return value
+}
+
+func newEnum(enum *parser.Enum) *Enum {
+	converted := &Enum{
+		Comment:     enum.Comment,
+		Name:        enum.Name,
+		Annotations: newAnnotations(enum.Annotations),
+	}
+	for _, value := range enum.Values {
+		converted.Values = append(converted.Values, &EnumValue{
+			Comment:     value.Comment,
+			Name:        value.Name,
+			Value:       value.Value,
+			Annotations: newAnnotations(value.Annotations),
+		})
+	}
+	return converted
+}
+
+func newStruct(s *parser.Struct, kind StructKind) *Struct {
+	return &Struct{
+		Comment:     s.Comment,
+		Name:        s.Name,
+		Kind:        kind,
+		Fields:      newFields(s.Fields),
+		Annotations: newAnnotations(s.Annotations),
+	}
+}
+
+func newFields(fields []*parser.Field) []*Field {
+	converted := make([]*Field, 0, len(fields))
+	for _, field := range fields {
+		converted = append(converted, &Field{
+			Comment:     field.Comment,
+			ID:          field.ID,
+			Name:        field.Name,
+			Modifier:    Modifier(strings.ToLower(field.Modifier.String())),
+			Type:        newType(field.Type),
+			Default:     newValue(field.Default),
+			Annotations: newAnnotations(field.Annotations),
+		})
+	}
+	return converted
+}
+
+func newService(frugal *parser.Frugal, service *parser.Service) *Service {
+	converted := &Service{
+		Comment:     service.Comment,
+		Name:        service.Name,
+		Extends:     service.Extends,
+		Hash:        frugal.ServiceHash(service),
+		Annotations: newAnnotations(service.Annotations),
+	}
+	for _, method := range service.Methods {
+		converted.Methods = append(converted.Methods, &Method{
+			Comment:     method.Comment,
+			Name:        method.Name,
+			Oneway:      method.Oneway,
+			ReturnType:  newType(method.ReturnType),
+			Arguments:   newFields(method.Arguments),
+			Exceptions:  newFields(method.Exceptions),
+			Annotations: newAnnotations(method.Annotations),
+		})
+	}
+	return converted
+}
+
+func newScope(frugal *parser.Frugal, scope *parser.Scope) *Scope {
+	converted := &Scope{
+		Comment: scope.Comment,
+		Name:    scope.Name,
+		Prefix: &ScopePrefix{
+			Template:  scope.Prefix.String,
+			Variables: append([]string(nil), scope.Prefix.Variables...),
+		},
+		Hash:        frugal.ScopeHash(scope),
+		Annotations: newAnnotations(scope.Annotations),
+	}
+	for _, op := range scope.Operations {
+		converted.Operations = append(converted.Operations, &Operation{
+			Comment:     op.Comment,
+			Name:        op.Name,
+			Type:        newType(op.Type),
+			Annotations: newAnnotations(op.Annotations),
+		})
+	}
+	return converted
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Emitter builds generated code line by line, tracking indentation.
+type Emitter struct {
+	buf    bytes.Buffer
+	indent string
+	level  int
+}
+
+// NewEmitter returns an Emitter which indents each level with the given
+// string, e.g. "\t" or "  ".
+func NewEmitter(indent string) *Emitter {
+	return &Emitter{indent: indent}
+}
+
+// Line writes a line formatted with the given arguments at the current
+// indentation. Lines which are empty aren't indented.
+func (e *Emitter) Line(format string, args ...interface{}) {
+	if len(args) > 0 {
+		format = fmt.Sprintf(format, args...)
+	}
+	e.writeLine(format)
+}
+
+func (e *Emitter) writeLine(line string) {
+	if line != "" {
+		e.buf.WriteString(strings.Repeat(e.indent, e.level))
+		e.buf.WriteString(line)
+	}
+	e.buf.WriteByte('\n')
+}
+
+// Blank writes an empty line.
+func (e *Emitter) Blank() {
+	e.buf.WriteByte('\n')
+}
+
+// Indent increases the indentation of following lines by one level.
+func (e *Emitter) Indent() {
+	e.level++
+}
+
+// Dedent decreases the indentation of following lines by one level.
+func (e *Emitter) Dedent() {
+	if e.level > 0 {
+		e.level--
+	}
+}
+
+// Block writes the open line, the lines written by body indented one level,
+// and the close line, e.g. for a function body in braces.
+func (e *Emitter) Block(open, close string, body func()) {
+	e.writeLine(open)
+	e.Indent()
+	body()
+	e.Dedent()
+	e.writeLine(close)
+}
+
+// Comment writes each line of an IDL comment with the given prefix, e.g.
+// "// " or "# ". Nothing is written for an empty comment.
+func (e *Emitter) Comment(prefix string, comment []string) {
+	for _, line := range comment {
+		e.writeLine(strings.TrimRight(prefix+line, " "))
+	}
+}
+
+// String returns the code written so far.
+func (e *Emitter) String() string {
+	return e.buf.String()
+}
+
+// WriteTo writes the code written so far to the file at the given path of the
+// Output.
+func (e *Emitter) WriteTo(out Output, path string) error {
+	file, err := out.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(e.buf.Bytes()); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
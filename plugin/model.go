@@ -0,0 +1,359 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Annotations are the key-value annotations on an IDL definition.
+type Annotations map[string]string
+
+// Get returns the value of the annotation with the given name and true if
+// it's present.
+func (a Annotations) Get(name string) (string, bool) {
+	value, ok := a[name]
+	return value, ok
+}
+
+// Has returns true if the annotation with the given name is present.
+func (a Annotations) Has(name string) bool {
+	_, ok := a[name]
+	return ok
+}
+
+// File is a parsed Frugal file.
+type File struct {
+	// Name is the file name without its directory or extension.
+	Name string
+	// Path is the path the file was loaded from.
+	Path string
+	// Namespaces maps a language, or "*" for all languages, to the namespace
+	// declared for it.
+	Namespaces map[string]string
+
+	Includes   []*Include
+	Constants  []*Constant
+	Typedefs   []*Typedef
+	Enums      []*Enum
+	Structs    []*Struct
+	Exceptions []*Struct
+	Unions     []*Struct
+	Services   []*Service
+	Scopes     []*Scope
+}
+
+// Namespace returns the namespace declared for the given language, falling
+// back to the wildcard namespace, or an empty string if neither is declared.
+func (f *File) Namespace(lang string) string {
+	if namespace, ok := f.Namespaces[lang]; ok {
+		return namespace
+	}
+	return f.Namespaces["*"]
+}
+
+// Include returns the included file with the given name, or nil if it isn't
+// included.
+func (f *File) Include(name string) *File {
+	for _, include := range f.Includes {
+		if include.Name == name {
+			return include.File
+		}
+	}
+	return nil
+}
+
+// Typedef returns the typedef the given type refers to, or nil if it isn't a
+// typedef.
+func (f *File) Typedef(t *Type) *Typedef {
+	file, name := f.definingFile(t)
+	if file == nil {
+		return nil
+	}
+	for _, typedef := range file.Typedefs {
+		if typedef.Name == name {
+			return typedef
+		}
+	}
+	return nil
+}
+
+// Enum returns the enum the given type refers to, or nil if it isn't an enum.
+func (f *File) Enum(t *Type) *Enum {
+	file, name := f.definingFile(t)
+	if file == nil {
+		return nil
+	}
+	for _, enum := range file.Enums {
+		if enum.Name == name {
+			return enum
+		}
+	}
+	return nil
+}
+
+// Struct returns the struct, exception, or union the given type refers to, or
+// nil if it isn't one.
+func (f *File) Struct(t *Type) *Struct {
+	file, name := f.definingFile(t)
+	if file == nil {
+		return nil
+	}
+	for _, structs := range [][]*Struct{file.Structs, file.Exceptions, file.Unions} {
+		for _, s := range structs {
+			if s.Name == name {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+// UnderlyingType follows any typedefs to get the type the given type is
+// defined as. Types of the returned type are relative to the file which
+// defines it, which the second return value is.
+func (f *File) UnderlyingType(t *Type) (*Type, *File) {
+	file, _ := f.definingFile(t)
+	if typedef := f.Typedef(t); typedef != nil {
+		return file.UnderlyingType(typedef.Type)
+	}
+	return t, f
+}
+
+// definingFile returns the file defining the given custom type, which is this
+// file or one of its includes, along with the type's name in that file.
+func (f *File) definingFile(t *Type) (*File, string) {
+	if include := t.IncludeName(); include != "" {
+		return f.Include(include), t.ParamName()
+	}
+	return f, t.Name
+}
+
+// Include is a file included by another.
+type Include struct {
+	// Name is the name types from the include are qualified with.
+	Name string
+	// Path is the path of the include as written in the IDL.
+	Path string
+	// File is the included file.
+	File *File
+}
+
+// Type is an IDL type. Custom types from includes are qualified with the
+// include name, e.g. "base.Thing".
+type Type struct {
+	Name string
+	// KeyType is the type of a map's keys.
+	KeyType *Type
+	// ValueType is the type of a list's or set's elements or a map's values.
+	ValueType   *Type
+	Annotations Annotations
+}
+
+// IsPrimitive returns true if the type is a base type, e.g. i32 or string.
+func (t *Type) IsPrimitive() bool {
+	switch t.Name {
+	case "bool", "byte", "i8", "i16", "i32", "i64", "double", "string", "binary":
+		return true
+	}
+	return false
+}
+
+// IsContainer returns true if the type is a list, set, or map.
+func (t *Type) IsContainer() bool {
+	switch t.Name {
+	case "list", "set", "map":
+		return true
+	}
+	return false
+}
+
+// IsCustom returns true if the type is a typedef, enum, struct, exception, or
+// union.
+func (t *Type) IsCustom() bool {
+	return !t.IsPrimitive() && !t.IsContainer()
+}
+
+// IncludeName returns the include the type is defined in, or an empty string
+// if it's defined locally.
+func (t *Type) IncludeName() string {
+	if i := strings.Index(t.Name, "."); i >= 0 {
+		return t.Name[:i]
+	}
+	return ""
+}
+
+// ParamName returns the type's name without its include qualifier.
+func (t *Type) ParamName() string {
+	if i := strings.Index(t.Name, "."); i >= 0 {
+		return t.Name[i+1:]
+	}
+	return t.Name
+}
+
+// String returns the type as written in the IDL.
+func (t *Type) String() string {
+	switch t.Name {
+	case "list", "set":
+		return fmt.Sprintf("%s<%s>", t.Name, t.ValueType)
+	case "map":
+		return fmt.Sprintf("map<%s,%s>", t.KeyType, t.ValueType)
+	}
+	return t.Name
+}
+
+// Identifier is a reference to a constant or enum value in a constant or
+// default value, e.g. "Color.RED" or "base.DEFAULT_NAME".
+type Identifier string
+
+// KeyValue is an entry of a map constant or default value.
+type KeyValue struct {
+	Key, Value interface{}
+}
+
+// Constant is an IDL constant. Values are bools, int64s, float64s, strings,
+// Identifiers, []interface{} for lists and sets, and []KeyValue for maps and
+// structs.
+type Constant struct {
+	Comment     []string
+	Name        string
+	Type        *Type
+	Value       interface{}
+	Annotations Annotations
+}
+
+// Typedef is an IDL typedef.
+type Typedef struct {
+	Comment     []string
+	Name        string
+	Type        *Type
+	Annotations Annotations
+}
+
+// Enum is an IDL enum.
+type Enum struct {
+	Comment     []string
+	Name        string
+	Values      []*EnumValue
+	Annotations Annotations
+}
+
+// EnumValue is a value of an enum.
+type EnumValue struct {
+	Comment     []string
+	Name        string
+	Value       int
+	Annotations Annotations
+}
+
+// StructKind is the kind of a struct-like definition.
+type StructKind string
+
+// Valid StructKinds.
+const (
+	StructKindStruct    StructKind = "struct"
+	StructKindException StructKind = "exception"
+	StructKindUnion     StructKind = "union"
+)
+
+// Struct is an IDL struct, exception, or union.
+type Struct struct {
+	Comment     []string
+	Name        string
+	Kind        StructKind
+	Fields      []*Field
+	Annotations Annotations
+}
+
+// Modifier is a field's requiredness.
+type Modifier string
+
+// Valid Modifiers.
+const (
+	// Required fields are always written and must be read.
+	Required Modifier = "required"
+	// Optional fields are written if set and read if present.
+	Optional Modifier = "optional"
+	// Default fields are always written and read if present.
+	Default Modifier = "default"
+)
+
+// Field is a field of a struct-like definition or a method's argument or
+// exception.
+type Field struct {
+	Comment  []string
+	ID       int
+	Name     string
+	Modifier Modifier
+	Type     *Type
+	// Default is the field's default value, if any, which takes the same
+	// forms as a Constant's value.
+	Default     interface{}
+	Annotations Annotations
+}
+
+// Service is an IDL service.
+type Service struct {
+	Comment []string
+	Name    string
+	// Extends is the name of the service this one extends, qualified with
+	// the include name if it's from an include, or empty.
+	Extends string
+	Methods []*Method
+	// Hash is the contract hash of the service, shared by clients and
+	// servers built from the same contract.
+	Hash        string
+	Annotations Annotations
+}
+
+// Method is a method of a service.
+type Method struct {
+	Comment []string
+	Name    string
+	Oneway  bool
+	// ReturnType is the method's return type, or nil if it returns void.
+	ReturnType  *Type
+	Arguments   []*Field
+	Exceptions  []*Field
+	Annotations Annotations
+}
+
+// Scope is an IDL pub/sub scope.
+type Scope struct {
+	Comment    []string
+	Name       string
+	Prefix     *ScopePrefix
+	Operations []*Operation
+	// Hash is the contract hash of the scope, shared by publishers and
+	// subscribers built from the same contract.
+	Hash        string
+	Annotations Annotations
+}
+
+// ScopePrefix is the prefix of a scope's topics.
+type ScopePrefix struct {
+	// Template is the prefix as written in the IDL, e.g. "foo.{user}".
+	Template string
+	// Variables are the names of the prefix's variables, in order.
+	Variables []string
+}
+
+// Operation is a scope operation, the type of messages published to a topic.
+type Operation struct {
+	Comment     []string
+	Name        string
+	Type        *Type
+	Annotations Annotations
+}
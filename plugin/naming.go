@@ -0,0 +1,75 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Title returns the name with its first letter in upper case, e.g. "getUser"
+// becomes "GetUser".
+func Title(name string) string {
+	if name == "" {
+		return name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// LowerFirst returns the name with its first letter in lower case, e.g.
+// "GetUser" becomes "getUser".
+func LowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// SnakeToCamel converts a snake case name to upper camel case, e.g.
+// "user_id" becomes "UserId".
+func SnakeToCamel(name string) string {
+	words := strings.Split(name, "_")
+	for i, word := range words {
+		words[i] = Title(word)
+	}
+	return strings.Join(words, "")
+}
+
+// CamelToSnake converts a camel case name to lower snake case, keeping
+// acronyms together, e.g. "getHTTPRequest" becomes "get_http_request".
+func CamelToSnake(name string) string {
+	runes := []rune(name)
+	snake := make([]rune, 0, len(runes))
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := !unicode.IsUpper(runes[i-1]) && runes[i-1] != '_'
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				snake = append(snake, '_')
+			}
+		}
+		snake = append(snake, unicode.ToLower(r))
+	}
+	return string(snake)
+}
+
+// ScreamingSnake converts a camel or snake case name to upper snake case,
+// e.g. "maxRetries" becomes "MAX_RETRIES".
+func ScreamingSnake(name string) string {
+	return strings.ToUpper(CamelToSnake(name))
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package plugin is the SDK for Frugal code generators maintained outside of
+// the compiler. It exposes a stable model of parsed IDL files along with
+// helpers for naming, type mapping, and emitting code, so generators for new
+// targets don't depend on the compiler's internal packages, which change
+// between releases. The plugintest package provides a harness for testing
+// generators against golden files.
+//
+// The SDK follows semantic versioning independent of the compiler, given by
+// Version. Additions bump the minor version, and changes which could break
+// existing generators bump the major version.
+package plugin
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// Version is the version of the plugin SDK.
+const Version = "1.0.0"
+
+// Generator generates code for a target from a parsed Frugal file.
+type Generator interface {
+	// Generate writes the code generated for the given file to out.
+	Generate(file *File, out Output) error
+}
+
+// Output creates the files written by a Generator.
+type Output interface {
+	// Create returns a writer for the file at the given slash-separated
+	// path, relative to the output root.
+	Create(path string) (io.WriteCloser, error)
+}
+
+// DirOutput is an Output which writes files to a directory, creating any
+// subdirectories as needed.
+type DirOutput string
+
+// Create creates the file at the given path relative to the directory.
+func (d DirOutput) Create(path string) (io.WriteCloser, error) {
+	fullPath := filepath.Join(string(d), filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0777); err != nil {
+		return nil, err
+	}
+	return os.Create(fullPath)
+}
+
+// Load parses the Frugal file at the given path, along with its includes, and
+// returns its model.
+func Load(path string) (*File, error) {
+	frugal, err := parser.ParseFrugal(path)
+	if err != nil {
+		return nil, err
+	}
+	return newFile(frugal, make(map[*parser.Frugal]*File)), nil
+}
+
+// Run loads the Frugal file at the given path and generates code for it with
+// the Generator into the output directory.
+func Run(gen Generator, path, outputDir string) error {
+	file, err := Load(path)
+	if err != nil {
+		return err
+	}
+	return gen.Generate(file, DirOutput(outputDir))
+}
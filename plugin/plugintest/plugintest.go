@@ -0,0 +1,127 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package plugintest provides a harness for testing generators built with the
+// plugin SDK, which runs a generator in memory and compares the generated
+// files with golden files.
+package plugintest
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/Workiva/frugal/plugin"
+)
+
+// Update causes AssertGolden to write golden files from the generated files
+// rather than compare them, e.g. when set from a test flag.
+var Update bool
+
+// MemoryOutput is a plugin.Output which keeps the generated files in memory,
+// keyed by path.
+type MemoryOutput map[string]*bytes.Buffer
+
+// Create returns a writer for the file at the given path, replacing any file
+// previously created at the path.
+func (m MemoryOutput) Create(path string) (io.WriteCloser, error) {
+	buf := new(bytes.Buffer)
+	m[path] = buf
+	return nopCloser{buf}, nil
+}
+
+// Files returns the contents of the generated files keyed by path.
+func (m MemoryOutput) Files() map[string]string {
+	files := make(map[string]string, len(m))
+	for path, buf := range m {
+		files[path] = buf.String()
+	}
+	return files
+}
+
+type nopCloser struct {
+	*bytes.Buffer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// Generate loads the Frugal file at the given path and returns the files the
+// generator generates for it keyed by path. The test fails if either step
+// fails.
+func Generate(t testing.TB, gen plugin.Generator, path string) map[string]string {
+	file, err := plugin.Load(path)
+	if err != nil {
+		t.Fatalf("plugintest: loading %s: %s", path, err)
+	}
+	out := make(MemoryOutput)
+	if err := gen.Generate(file, out); err != nil {
+		t.Fatalf("plugintest: generating %s: %s", path, err)
+	}
+	return out.Files()
+}
+
+// GenerateSource is like Generate for IDL given as source, which is written to
+// a temporary file with the given name, e.g. "example.frugal". The source
+// can't include other files.
+func GenerateSource(t testing.TB, gen plugin.Generator, name, source string) map[string]string {
+	dir, err := ioutil.TempDir("", "plugintest")
+	if err != nil {
+		t.Fatalf("plugintest: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatalf("plugintest: %s", err)
+	}
+	return Generate(t, gen, path)
+}
+
+// AssertGolden compares the generated files with the files at the same paths
+// in goldenDir. The test fails for each generated file which differs from or
+// is missing its golden file. If Update is set, golden files are written
+// instead.
+func AssertGolden(t testing.TB, files map[string]string, goldenDir string) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		goldenPath := filepath.Join(goldenDir, filepath.FromSlash(path))
+		if Update {
+			if err := os.MkdirAll(filepath.Dir(goldenPath), 0777); err != nil {
+				t.Fatalf("plugintest: %s", err)
+			}
+			if err := ioutil.WriteFile(goldenPath, []byte(files[path]), 0644); err != nil {
+				t.Fatalf("plugintest: %s", err)
+			}
+			continue
+		}
+
+		expected, err := ioutil.ReadFile(goldenPath)
+		if err != nil {
+			t.Errorf("plugintest: missing golden file for %s: %s", path, err)
+			continue
+		}
+		if string(expected) != files[path] {
+			t.Errorf("plugintest: %s differs from %s\nexpected:\n%s\ngenerated:\n%s",
+				path, goldenPath, expected, files[path])
+		}
+	}
+}
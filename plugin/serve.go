@@ -0,0 +1,121 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+)
+
+// ProtocolVersion is the version of the protocol the compiler speaks with
+// external generators.
+const ProtocolVersion = 1
+
+// Request is what the compiler writes, as JSON, to the standard input of an
+// external generator, i.e. an executable named "frugal-gen-<lang>" on the
+// PATH which is run for "-gen <lang>".
+type Request struct {
+	ProtocolVersion int               `json:"protocol_version"`
+	File            string            `json:"file"`
+	Options         map[string]string `json:"options"`
+}
+
+// Response is what an external generator writes, as JSON, to its standard
+// output in response to a Request. The compiler writes the files to the
+// output directory, prints the warnings, and fails generation if Error is
+// set.
+type Response struct {
+	Files    []*ResponseFile `json:"files,omitempty"`
+	Warnings []string        `json:"warnings,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// ResponseFile is a file generated by an external generator.
+type ResponseFile struct {
+	Path     string `json:"path"` // Slash-separated, relative to the output directory
+	Contents string `json:"contents"`
+}
+
+// OptionsGenerator is a Generator which takes the generator options given to
+// the compiler, e.g. "-gen ts:strict,indent=4".
+type OptionsGenerator interface {
+	Generator
+
+	// SetOptions is called with the generator options before Generate.
+	// An error is returned for unsupported options.
+	SetOptions(options map[string]string) error
+}
+
+// Serve runs the Generator as an external generator, reading the Request
+// from standard input and writing the Response to standard output. An error
+// is only returned if the Request can't be read or the Response can't be
+// written; generation errors are reported in the Response.
+func Serve(gen Generator) error {
+	return serve(gen, os.Stdin, os.Stdout)
+}
+
+func serve(gen Generator, in io.Reader, out io.Writer) error {
+	var request Request
+	if err := json.NewDecoder(in).Decode(&request); err != nil {
+		return err
+	}
+	return json.NewEncoder(out).Encode(handle(gen, &request))
+}
+
+// handle generates the files for the Request.
+func handle(gen Generator, request *Request) *Response {
+	if optionsGen, ok := gen.(OptionsGenerator); ok {
+		if err := optionsGen.SetOptions(request.Options); err != nil {
+			return &Response{Error: err.Error()}
+		}
+	}
+	file, err := Load(request.File)
+	if err != nil {
+		return &Response{Error: err.Error()}
+	}
+	output := make(bufferOutput)
+	if err := gen.Generate(file, output); err != nil {
+		return &Response{Error: err.Error()}
+	}
+
+	paths := make([]string, 0, len(output))
+	for path := range output {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	response := &Response{Files: make([]*ResponseFile, 0, len(paths))}
+	for _, path := range paths {
+		response.Files = append(response.Files, &ResponseFile{Path: path, Contents: output[path].String()})
+	}
+	return response
+}
+
+// bufferOutput is an Output which keeps files in memory until they're written
+// to the Response.
+type bufferOutput map[string]*bytes.Buffer
+
+func (b bufferOutput) Create(path string) (io.WriteCloser, error) {
+	buf := new(bytes.Buffer)
+	b[path] = buf
+	return bufferCloser{buf}, nil
+}
+
+type bufferCloser struct {
+	*bytes.Buffer
+}
+
+func (bufferCloser) Close() error { return nil }
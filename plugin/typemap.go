@@ -0,0 +1,75 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plugin
+
+import "fmt"
+
+// TypeMap maps IDL types to the types of a target language.
+type TypeMap struct {
+	// Base maps base types, e.g. "i32" or "string", to target types.
+	Base map[string]string
+	// List, Set, and Map are format strings for container types given the
+	// target element type, or key and value types for maps, e.g. "List<%s>"
+	// or "Map<%s, %s>".
+	List, Set, Map string
+	// Custom returns the target type of a typedef, enum, or struct-like type
+	// used in the given file. If nil, the type's name without its include
+	// qualifier is used.
+	Custom func(t *Type, file *File) string
+	// ResolveTypedefs maps typedefs to the target type of the type they're
+	// defined as rather than passing them to Custom.
+	ResolveTypedefs bool
+}
+
+// Resolve returns the target type of the given type used in the given file. An
+// error is returned if the type, or a type it contains, can't be mapped.
+func (m *TypeMap) Resolve(t *Type, file *File) (string, error) {
+	if m.ResolveTypedefs && file.Typedef(t) != nil {
+		underlying, definingFile := file.UnderlyingType(t)
+		return m.Resolve(underlying, definingFile)
+	}
+
+	switch {
+	case t.IsPrimitive():
+		if target, ok := m.Base[t.Name]; ok {
+			return target, nil
+		}
+		return "", fmt.Errorf("plugin: no mapping for base type %s", t.Name)
+	case t.Name == "list":
+		return m.resolveContainer(t.Name, m.List, file, t.ValueType)
+	case t.Name == "set":
+		return m.resolveContainer(t.Name, m.Set, file, t.ValueType)
+	case t.Name == "map":
+		return m.resolveContainer(t.Name, m.Map, file, t.KeyType, t.ValueType)
+	}
+	if m.Custom != nil {
+		return m.Custom(t, file), nil
+	}
+	return t.ParamName(), nil
+}
+
+func (m *TypeMap) resolveContainer(name, format string, file *File, types ...*Type) (string, error) {
+	if format == "" {
+		return "", fmt.Errorf("plugin: no mapping for container type %s", name)
+	}
+	args := make([]interface{}, len(types))
+	for i, t := range types {
+		target, err := m.Resolve(t, file)
+		if err != nil {
+			return "", err
+		}
+		args[i] = target
+	}
+	return fmt.Sprintf(format, args...), nil
+}
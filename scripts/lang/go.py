@@ -1,4 +1,5 @@
 import os
+import re
 import yaml
 
 from lang.base import LanguageBase
@@ -11,9 +12,18 @@ class Go(LanguageBase):
 
     def update_frugal(self, version, root):
         """
-        Update the go version. Go versioning is controlled by git tags, so
-        there is nothing to do here.
+        Update the go version. Go versioning is controlled by git tags, but
+        the library reports its version for runtime compatibility checks.
         """
+        os.chdir('{0}/lib/go'.format(root))
+
+        s = ''
+        with open('version.go', 'r') as f:
+            s = re.sub('const Version = ".*"',
+                       'const Version = "{0}"'.format(version), f.read())
+        with open('version.go', 'w') as f:
+            f.write(s)
+
         os.chdir('{0}/examples/go'.format(root))
 
         with open('glide.yaml') as f:
@@ -138,3 +138,20 @@ func TestScopeBreakingChanges(t *testing.T) {
 		}
 	}
 }
+
+func TestStabilityAudit(t *testing.T) {
+	logger := &MockValidationLogger{}
+	auditor := parser.NewAuditorWithLogger(logger)
+	if err := auditor.Audit(stabilityFile, stabilityChangedFile); err == nil {
+		t.Fatal("Expected error")
+	}
+	assert.Equal(t, []string{
+		"scope Core: frozen contract changed",
+		"scope Orders: stability lowered: 'stable' -> 'experimental'",
+		"service CoreService: frozen contract changed",
+	}, logger.errors)
+	assert.Equal(t, []string{
+		"scope Beta: operation removed: Created",
+		"missing service: BetaService",
+	}, logger.warnings)
+}
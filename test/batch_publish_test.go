@@ -0,0 +1,103 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/globals"
+)
+
+func TestGoBatchPublish(t *testing.T) {
+	defer globals.Reset()
+	options := compiler.Options{
+		File:  batchPublishFile,
+		Gen:   "go:package_prefix=github.com/Workiva/frugal/test/out/,batch_publish",
+		Out:   filepath.Join(outputDir, "batch_publish", "go"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/batch_publish/f_ticks_scope.txt", filepath.Join(outputDir, "batch_publish", "go", "batch_publish", "f_ticks_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestDartBatchPublish(t *testing.T) {
+	defer globals.Reset()
+	options := compiler.Options{
+		File:  batchPublishFile,
+		Gen:   "dart:batch_publish",
+		Out:   filepath.Join(outputDir, "batch_publish", "dart"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/dart/batch_publish/f_ticks_scope.dart", filepath.Join(outputDir, "batch_publish", "dart", "batch_publish", "lib", "src", "f_ticks_scope.dart")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestJavaBatchPublish(t *testing.T) {
+	options := compiler.Options{
+		File:  batchPublishFile,
+		Gen:   "java:batch_publish",
+		Out:   filepath.Join(outputDir, "batch_publish", "java"),
+		Delim: delim,
+	}
+
+	files := []FileComparisonPair{
+		{"expected/java/batch_publish/TicksPublisher.java", filepath.Join(outputDir, "batch_publish", "java", "batch_publish", "TicksPublisher.java")},
+		{"expected/java/batch_publish/TicksSubscriber.java", filepath.Join(outputDir, "batch_publish", "java", "batch_publish", "TicksSubscriber.java")},
+	}
+	compileAtFixedDate(t, []compiler.Options{options}, files)
+}
+
+func TestPythonBatchPublish(t *testing.T) {
+	root := filepath.Join(outputDir, "batch_publish")
+	gens := map[string]string{
+		"python":         "py:batch_publish",
+		"python.tornado": "py:tornado,batch_publish",
+		"python.asyncio": "py:asyncio,batch_publish",
+	}
+	var options []compiler.Options
+	for dir, gen := range gens {
+		options = append(options, compiler.Options{
+			File:  batchPublishFile,
+			Gen:   gen,
+			Out:   filepath.Join(root, dir),
+			Delim: delim,
+		})
+	}
+
+	// Vanilla Python has no subscribers.
+	files := []FileComparisonPair{
+		{"expected/python/batch_publish/f_Ticks_publisher.py", filepath.Join(root, "python", "batch_publish", "f_Ticks_publisher.py")},
+		{"expected/python.tornado/batch_publish/f_Ticks_publisher.py", filepath.Join(root, "python.tornado", "batch_publish", "f_Ticks_publisher.py")},
+		{"expected/python.tornado/batch_publish/f_Ticks_subscriber.py", filepath.Join(root, "python.tornado", "batch_publish", "f_Ticks_subscriber.py")},
+		{"expected/python.asyncio/batch_publish/f_Ticks_publisher.py", filepath.Join(root, "python.asyncio", "batch_publish", "f_Ticks_publisher.py")},
+		{"expected/python.asyncio/batch_publish/f_Ticks_subscriber.py", filepath.Join(root, "python.asyncio", "batch_publish", "f_Ticks_subscriber.py")},
+	}
+	compileAtFixedDate(t, options, files)
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func TestBroker(t *testing.T) {
+	options := compiler.Options{
+		File:  brokerFile,
+		Gen:   "broker",
+		Out:   filepath.Join(outputDir, "broker"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/broker/broker.nats.json", filepath.Join(outputDir, "broker", "broker.nats.json")},
+		{"expected/broker/broker.kafka.json", filepath.Join(outputDir, "broker", "broker.kafka.json")},
+		{"expected/broker/broker.rabbitmq.json", filepath.Join(outputDir, "broker", "broker.rabbitmq.json")},
+		{"expected/broker/broker.tf.json", filepath.Join(outputDir, "broker", "broker.tf.json")},
+	}
+
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestBrokerSelectedManifests(t *testing.T) {
+	options := compiler.Options{
+		File:  brokerFile,
+		Gen:   "broker:kafka",
+		Out:   filepath.Join(outputDir, "broker_kafka"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	dir := filepath.Join(outputDir, "broker_kafka")
+	assertFilesExist(t, []string{filepath.Join(dir, "broker.kafka.json")})
+	assertFilesNotExist(t, []string{
+		filepath.Join(dir, "broker.nats.json"),
+		filepath.Join(dir, "broker.rabbitmq.json"),
+		filepath.Join(dir, "broker.tf.json"),
+	})
+}
+
+func TestBrokerInvalidAnnotation(t *testing.T) {
+	options := compiler.Options{
+		File:  brokerInvalidFile,
+		Gen:   "broker",
+		Out:   filepath.Join(outputDir, "broker_invalid"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err == nil {
+		t.Fatal("Expected error")
+	}
+}
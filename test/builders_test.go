@@ -0,0 +1,41 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func TestBuilders(t *testing.T) {
+	root := filepath.Join(outputDir, "builders")
+	var options []compiler.Options
+	for _, gen := range []string{"go", "java"} {
+		options = append(options, compiler.Options{
+			File:  eventsFile,
+			Gen:   gen + ":builders",
+			Out:   filepath.Join(root, gen),
+			Delim: delim,
+		})
+	}
+	// Java doesn't support the Orders scope's reply operation.
+	options[1].Exclude = []string{"Orders"}
+
+	files := []FileComparisonPair{
+		{"expected/builders/go/f_types.txt", filepath.Join(root, "go", "events", "f_types.go")},
+		{"expected/builders/java/Order.java", filepath.Join(root, "java", "Order.java")},
+	}
+	compileAtFixedDate(t, options, files)
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/globals"
+)
+
+func TestGoChunked(t *testing.T) {
+	defer globals.Reset()
+	options := compiler.Options{
+		File:  chunkedFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "chunked"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/chunked/f_files_scope.txt", filepath.Join(outputDir, "chunked", "chunked", "f_files_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures chunked operations are reported as unsupported, which strict mode
+// turns into an error, since only their publishers and subscribers are
+// generated for languages other than Go.
+func TestChunkedUnsupported(t *testing.T) {
+	for _, lang := range []string{"java", "py", "dart"} {
+		options := compiler.Options{
+			File:   chunkedFile,
+			Gen:    lang,
+			Out:    filepath.Join(outputDir, "chunked_unsupported", lang),
+			Delim:  delim,
+			Strict: true,
+		}
+		err := compiler.Compile(options)
+		if err == nil {
+			t.Fatalf("Expected error for %s", lang)
+		}
+		if !strings.Contains(err.Error(), "Files.Uploaded: chunked transfer is not supported for "+lang) {
+			t.Fatalf("Unexpected error for %s: %s", lang, err)
+		}
+	}
+}
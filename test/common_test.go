@@ -19,6 +19,10 @@ import (
 	"io"
 	"os"
 	"testing"
+	"time"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/globals"
 )
 
 const (
@@ -30,6 +34,47 @@ const (
 	duplicateScopes         = "idl/duplicate_scopes.frugal"
 	duplicateMethods        = "idl/duplicate_methods.frugal"
 	duplicateOperations     = "idl/duplicate_operations.frugal"
+	includeCollision        = "idl/collision/main.frugal"
+	includeAliasCollision   = "idl/collision/alias.frugal"
+	filterFile              = "idl/filter.frugal"
+	brokerFile              = "idl/broker.frugal"
+	brokerInvalidFile       = "idl/broker_invalid.frugal"
+	catalogFile             = "idl/catalog.frugal"
+	constraintsFile         = "idl/constraints.frugal"
+	constraintsInvalidFile  = "idl/constraints_invalid.frugal"
+	eventsFile              = "idl/events.frugal"
+	graphQLInvalidFile      = "idl/graphql_invalid.frugal"
+	jsonFile                = "idl/json.frugal"
+	testVectorsFile         = "idl/test_vectors.frugal"
+	stabilityFile           = "idl/stability/stability.frugal"
+	stabilityChangedFile    = "idl/stability/changed.frugal"
+	invalidStabilityFile    = "idl/stability/invalid.frugal"
+	invalidProtocolFile     = "idl/invalid_protocol.frugal"
+	invalidChunkedFile      = "idl/chunked_invalid.frugal"
+	samplingFile            = "idl/sampling.frugal"
+	invalidSamplingFile     = "idl/sampling_invalid.frugal"
+	nanSamplingFile         = "idl/sampling_nan.frugal"
+	invalidReplyFile        = "idl/reply_invalid.frugal"
+	unknownReplyFile        = "idl/reply_unknown.frugal"
+	conditionalFile         = "idl/conditional.frugal"
+	templatesFile           = "idl/templates.frugal"
+	sortedMapsFile          = "idl/sorted_maps.frugal"
+	replayableFile          = "idl/replayable.frugal"
+	namespacesFile          = "idl/namespaces/main.frugal"
+	runtimeCheckFile        = "idl/runtime_check.frugal"
+	descriptionsFile        = "idl/descriptions.frugal"
+	containersFile          = "idl/containers.frugal"
+	deprecatedScopeFile     = "idl/deprecated_scope.frugal"
+	unknownEnumsFile        = "idl/unknown_enums.frugal"
+	prefixValidationFile    = "idl/prefix_validation.frugal"
+	scopeProtocolFile       = "idl/scope_protocol.frugal"
+	scopeRequestFile        = "idl/scope_request.frugal"
+	scopeVersionsFile       = "idl/scope_versions.frugal"
+	scopeVersionsInvalid    = "idl/scope_versions_invalid.frugal"
+	batchPublishFile        = "idl/batch_publish.frugal"
+	accessControlFile       = "idl/access_control.frugal"
+	copyMergeFile           = "idl/copy_merge.frugal"
+	chunkedFile             = "idl/chunked.frugal"
 	duplicateMethodArgIds   = "idl/duplicate_arg_ids.frugal"
 	duplicateStructFieldIds = "idl/duplicate_field_ids.frugal"
 	frugalGenFile           = "idl/variety.frugal"
@@ -122,6 +167,26 @@ func copyFilePair(pair FileComparisonPair) error {
 	return err
 }
 
+// compileAtFixedDate compiles each of the options with the generated date
+// pinned and compares the generated files to the expected ones.
+func compileAtFixedDate(t *testing.T, options []compiler.Options, files []FileComparisonPair) {
+	defer globals.Reset()
+	nowBefore := globals.Now
+	defer func() {
+		globals.Now = nowBefore
+	}()
+
+	for _, o := range options {
+		// Compile resets the globals, so the date is pinned for each run.
+		globals.Now = time.Date(2015, 11, 24, 0, 0, 0, 0, time.UTC)
+		if err := compiler.Compile(o); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
 func assertFilesNotExist(t *testing.T, filePaths []string) {
 	for _, fileThatShouldNotExist := range filePaths {
 		if _, err := os.Stat(fileThatShouldNotExist); !os.IsNotExist(err) {
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures conditional blocks are evaluated for the language, and as when not
+// generating code without one.
+func TestConditional(t *testing.T) {
+	cases := []struct {
+		lang       string
+		timestamp  string
+		operations int
+	}{
+		{"go", "i64", 2},
+		{"java", "i64", 1},
+		{"dart", "string", 2},
+		{"py", "double", 1},
+		{"", "double", 2},
+	}
+	for _, c := range cases {
+		frugal, err := parser.ParseFrugalForLang(conditionalFile, c.lang)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		assert.Equal(t, c.timestamp, frugal.Structs[0].Fields[1].Type.Name, c.lang)
+		assert.Len(t, frugal.Scopes[0].Operations, c.operations, c.lang)
+		assert.Equal(t, c.lang == "dart", frugal.Namespace("dart") != nil, c.lang)
+	}
+}
+
+func TestGoConditional(t *testing.T) {
+	options := compiler.Options{
+		File:  conditionalFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "conditional"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	types, err := ioutil.ReadFile(filepath.Join(outputDir, "conditional", "conditional", "f_types.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(types), "Timestamp int64") {
+		t.Fatalf("Expected the go branch to be generated:\n%s", types)
+	}
+}
+
+// Ensures malformed conditional blocks are errors reporting their line.
+func TestConditionalInvalid(t *testing.T) {
+	cases := []struct {
+		src string
+		err string
+	}{
+		{"#if lang == \"go\"\nstruct A {}\n", "test.frugal:1: #if without #endif"},
+		{"struct A {}\n#endif\n", "test.frugal:2: #endif without #if"},
+		{"#if lang = \"go\"\n#endif\n", "test.frugal:1: invalid condition lang = \"go\""},
+		{"#if\n#endif\n", "test.frugal:1: missing condition"},
+		{"#if lang == \"go\"\n#else\n#elif lang == \"py\"\n#endif\n", "test.frugal:3: #elif after #else"},
+	}
+	for _, c := range cases {
+		_, err := parser.Preprocess("test.frugal", []byte(c.src), "go")
+		if assert.Error(t, err, c.src) {
+			assert.True(t, strings.HasPrefix(err.Error(), c.err), err.Error())
+		}
+	}
+}
+
+// Ensures excluded lines are blanked rather than removed, so positions in
+// parse errors are unchanged, and lines starting with "#" which aren't
+// directives are kept as comments.
+func TestPreprocessKeepsLines(t *testing.T) {
+	src := "# if unset, defaults\n#if lang == \"py\"\nstruct A {}\n#endif\nstruct B {}\n"
+	preprocessed, err := parser.Preprocess("test.frugal", []byte(src), "go")
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assert.Equal(t, "# if unset, defaults\n\n\n\nstruct B {}\n", string(preprocessed))
+}
+
+// Ensures lines starting with "#" which aren't well-formed directives, and
+// directives in block comments, are kept as comments.
+func TestPreprocessComments(t *testing.T) {
+	src := "#if you remove this field, update the docs\n" +
+		"#else branches are generated last\n" +
+		"/* Disabled:\n" +
+		"#if lang == \"py\"\n" +
+		"*/\n" +
+		"struct A {}\n"
+	preprocessed, err := parser.Preprocess("test.frugal", []byte(src), "go")
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assert.Equal(t, src, string(preprocessed))
+	assert.False(t, parser.HasDirectives([]byte(src)))
+}
+
+// Formatting would separate definitions from the directives around them.
+func TestFormatConditional(t *testing.T) {
+	src, err := ioutil.ReadFile(conditionalFile)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	_, err = parser.Format(conditionalFile, src)
+	assert.Error(t, err)
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func TestConstraints(t *testing.T) {
+	root := filepath.Join(outputDir, "constraints")
+	var options []compiler.Options
+	for _, gen := range []string{"go", "java", "py", "dart"} {
+		options = append(options, compiler.Options{
+			File:  constraintsFile,
+			Gen:   gen,
+			Out:   filepath.Join(root, gen),
+			Delim: delim,
+		})
+	}
+
+	files := []FileComparisonPair{
+		{"expected/constraints/go/f_types.txt", filepath.Join(root, "go", "constraints", "f_types.go")},
+		{"expected/constraints/java/Profile.java", filepath.Join(root, "java", "constraints", "java", "Profile.java")},
+		{"expected/constraints/python/ttypes.py", filepath.Join(root, "py", "constraints", "python", "ttypes.py")},
+		{"expected/constraints/dart/f_profile.dart", filepath.Join(root, "dart", "constraints", "lib", "src", "f_profile.dart")},
+		{"expected/constraints/dart/f_contact.dart", filepath.Join(root, "dart", "constraints", "lib", "src", "f_contact.dart")},
+	}
+	compileAtFixedDate(t, options, files)
+}
+
+func TestConstraintsInvalid(t *testing.T) {
+	options := compiler.Options{
+		File:  constraintsInvalidFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "constraints_invalid"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err == nil {
+		t.Fatal("Expected error")
+	}
+}
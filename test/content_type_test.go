@@ -0,0 +1,42 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/globals"
+)
+
+func TestGoContentType(t *testing.T) {
+	defer globals.Reset()
+	options := compiler.Options{
+		File:  scopeProtocolFile,
+		Gen:   "go:package_prefix=github.com/Workiva/frugal/test/out/,content_type",
+		Out:   filepath.Join(outputDir, "content_type", "go"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/content_type/f_readings_scope.txt", filepath.Join(outputDir, "content_type", "go", "scope_protocol", "f_readings_scope.go")},
+		{"expected/go/content_type/f_calibrations_scope.txt", filepath.Join(outputDir, "content_type", "go", "scope_protocol", "f_calibrations_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func TestCopyMerge(t *testing.T) {
+	root := filepath.Join(outputDir, "copy_merge")
+	gens := map[string]string{
+		"go":         "go:copy_merge",
+		"java":       "java:copy_merge",
+		"py":         "py:copy_merge",
+		"dart":       "dart:copy_merge",
+		"dart_built": "dart:copy_merge,built_collections",
+	}
+	var options []compiler.Options
+	for dir, gen := range gens {
+		options = append(options, compiler.Options{
+			File:  copyMergeFile,
+			Gen:   gen,
+			Out:   filepath.Join(root, dir),
+			Delim: delim,
+		})
+	}
+
+	files := []FileComparisonPair{
+		{"expected/copy_merge/go/f_types.txt", filepath.Join(root, "go", "copy_merge", "f_types.go")},
+		{"expected/copy_merge/java/Account.java", filepath.Join(root, "java", "copy_merge", "Account.java")},
+		{"expected/copy_merge/java/Reference.java", filepath.Join(root, "java", "copy_merge", "Reference.java")},
+		{"expected/copy_merge/python/ttypes.py", filepath.Join(root, "py", "copy_merge", "ttypes.py")},
+		{"expected/copy_merge/dart/f_account.dart", filepath.Join(root, "dart", "copy_merge", "lib", "src", "f_account.dart")},
+		{"expected/copy_merge/dart/f_reference.dart", filepath.Join(root, "dart", "copy_merge", "lib", "src", "f_reference.dart")},
+		{"expected/copy_merge/dart_built/f_account.dart", filepath.Join(root, "dart_built", "copy_merge", "lib", "src", "f_account.dart")},
+	}
+	compileAtFixedDate(t, options, files)
+}
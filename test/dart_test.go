@@ -15,6 +15,7 @@ package test
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Workiva/frugal/compiler"
@@ -158,3 +159,326 @@ func TestValidDartVendorNamespaceTargetGenerate(t *testing.T) {
 	copyAllFiles(t, files)
 	compareAllFiles(t, files)
 }
+
+func TestDartPathTemplate(t *testing.T) {
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "dart:path_template={namespace}/lib/src/{type}/{name}.dart",
+		Out:   filepath.Join(outputDir, "path_template"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	srcDir := filepath.Join(outputDir, "path_template", "filter", "lib", "src")
+	assertFilesExist(t, []string{
+		filepath.Join(srcDir, "types", "f_alert.dart"),
+		filepath.Join(srcDir, "types", "f_invoice.dart"),
+		filepath.Join(srcDir, "scopes", "f_alerts_scope.dart"),
+		filepath.Join(srcDir, "services", "f_invoices_service.dart"),
+	})
+	assertFilesNotExist(t, []string{
+		filepath.Join(srcDir, "f_alert.dart"),
+	})
+
+	files := []FileComparisonPair{
+		{"expected/dart/path_template/filter.dart", filepath.Join(outputDir, "path_template", "filter", "lib", "filter.dart")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestDartArtifactOutputDirs(t *testing.T) {
+	root := filepath.Join(outputDir, "artifact_out")
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "dart:scopes_out=" + filepath.Join(root, "scopes"),
+		Out:   filepath.Join(root, "models"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	assertFilesExist(t, []string{
+		filepath.Join(root, "models", "filter", "lib", "src", "f_alert.dart"),
+		filepath.Join(root, "models", "filter", "lib", "src", "f_invoices_service.dart"),
+		filepath.Join(root, "scopes", "filter", "lib", "src", "f_alerts_scope.dart"),
+	})
+
+	files := []FileComparisonPair{
+		{"expected/dart/artifact_out/filter.dart", filepath.Join(root, "models", "filter", "lib", "filter.dart")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestDartPathTemplateWithoutName(t *testing.T) {
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "dart:path_template={namespace}/lib/src/{type}.dart",
+		Out:   filepath.Join(outputDir, "path_template_without_name"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDartExcludeExports(t *testing.T) {
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "dart:exclude_exports=services+scopes",
+		Out:   filepath.Join(outputDir, "exclude_exports"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/dart/exclude_exports/filter.dart", filepath.Join(outputDir, "exclude_exports", "filter", "lib", "filter.dart")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestDartExcludeExportsUnknownCategory(t *testing.T) {
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "dart:exclude_exports=services+models",
+		Out:   filepath.Join(outputDir, "exclude_exports_unknown"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDartExportFileIdempotent(t *testing.T) {
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "dart",
+		Out:   filepath.Join(outputDir, "export_idempotent"),
+		Delim: delim,
+	}
+	exportFile := filepath.Join(outputDir, "export_idempotent", "filter", "lib", "filter.dart")
+	// Regenerating must rewrite the export file rather than append to it.
+	for i := 0; i < 2; i++ {
+		if err := compiler.Compile(options); err != nil {
+			t.Fatal("unexpected error", err)
+		}
+	}
+
+	files := []FileComparisonPair{
+		{"expected/dart/export_idempotent/filter.dart", exportFile},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestDartDependencySources(t *testing.T) {
+	options := compiler.Options{
+		File: filterFile,
+		Gen: "dart:thrift_dep=git+https://github.com/Workiva/thrift-dart.git#0.0.7," +
+			"frugal_dep=hosted+https://pub.dev,frugal_version=^1.0.0",
+		Out:   filepath.Join(outputDir, "dependency_sources"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/dart/dependency_sources/pubspec.yaml", filepath.Join(outputDir, "dependency_sources", "filter", "pubspec.yaml")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestDartDependencyPathVersion(t *testing.T) {
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "dart:frugal_dep=path+../frugal,frugal_version=^1.0.0",
+		Out:   filepath.Join(outputDir, "dependency_path_version"),
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != "Dart frugal_version option doesn't apply to path dependencies" {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestDartNestedNamespaces(t *testing.T) {
+	options := compiler.Options{
+		File:    "idl/nested/events.frugal",
+		Gen:     "dart:library_prefix=app.src.gen,nested_namespaces",
+		Out:     filepath.Join(outputDir, "nested_namespaces"),
+		Delim:   delim,
+		Recurse: true,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/dart/nested_namespaces/events.dart", filepath.Join(outputDir, "nested_namespaces", "acme", "events.dart")},
+		{"expected/dart/nested_namespaces/invoice.dart", filepath.Join(outputDir, "nested_namespaces", "acme", "events", "f_invoice.dart")},
+		{"expected/dart/nested_namespaces/invoice_events_scope.dart", filepath.Join(outputDir, "nested_namespaces", "acme", "events", "f_invoice_events_scope.dart")},
+		{"expected/dart/nested_namespaces/shared.dart", filepath.Join(outputDir, "nested_namespaces", "acme", "shared.dart")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestDartNestedNamespacesRequiresLibraryPrefix(t *testing.T) {
+	options := compiler.Options{
+		File:  "idl/nested/events.frugal",
+		Gen:   "dart:nested_namespaces",
+		Out:   filepath.Join(outputDir, "nested_namespaces_package"),
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "nested_namespaces option requires the library_prefix option") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+// Ensures the hooks option generates publisher and subscriber hooks which are
+// called with the context and operation name of each message.
+func TestDartHooks(t *testing.T) {
+	options := compiler.Options{
+		File:  frugalGenFile,
+		Gen:   "dart:hooks",
+		Out:   filepath.Join(outputDir, "dart_hooks"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/dart/hooks/f_events_scope.dart", filepath.Join(outputDir, "dart_hooks", "variety", "lib", "src", "f_events_scope.dart")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures the built_collections option generates containers as built_collection
+// types and depends on the package.
+func TestDartBuiltCollections(t *testing.T) {
+	options := compiler.Options{
+		File:  containersFile,
+		Gen:   "dart:built_collections",
+		Out:   filepath.Join(outputDir, "built_collections"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	root := filepath.Join(outputDir, "built_collections", "containers")
+	files := []FileComparisonPair{
+		{"expected/dart/built_collections/f_inventory.dart", filepath.Join(root, "lib", "src", "f_inventory.dart")},
+		{"expected/dart/built_collections/f_containers_constants.dart", filepath.Join(root, "lib", "src", "f_containers_constants.dart")},
+		{"expected/dart/built_collections/f_stock_scope.dart", filepath.Join(root, "lib", "src", "f_stock_scope.dart")},
+		{"expected/dart/built_collections/pubspec.yaml", filepath.Join(root, "pubspec.yaml")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures the browser option generates a WebSocket transport selecting the
+// platform with conditional imports, exports it, and depends on w_transport.
+func TestDartBrowser(t *testing.T) {
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "dart:browser",
+		Out:   filepath.Join(outputDir, "browser"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	root := filepath.Join(outputDir, "browser", "filter")
+	files := []FileComparisonPair{
+		{"expected/dart/browser/filter.dart", filepath.Join(root, "lib", "filter.dart")},
+		{"expected/dart/browser/f_platform_transport.dart", filepath.Join(root, "lib", "src", "f_platform_transport.dart")},
+		{"expected/dart/browser/f_platform_vm.dart", filepath.Join(root, "lib", "src", "f_platform_vm.dart")},
+		{"expected/dart/browser/f_platform_browser.dart", filepath.Join(root, "lib", "src", "f_platform_browser.dart")},
+		{"expected/dart/browser/pubspec.yaml", filepath.Join(root, "pubspec.yaml")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures the flutter_compute option generates subscribers decoding messages
+// with compute and depends on Flutter.
+func TestDartFlutterCompute(t *testing.T) {
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "dart:flutter_compute",
+		Out:   filepath.Join(outputDir, "flutter_compute"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	root := filepath.Join(outputDir, "flutter_compute", "filter")
+	files := []FileComparisonPair{
+		{"expected/dart/flutter_compute/f_alerts_scope.dart", filepath.Join(root, "lib", "src", "f_alerts_scope.dart")},
+		{"expected/dart/flutter_compute/pubspec.yaml", filepath.Join(root, "pubspec.yaml")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures the resilient_publishers option generates and exports a publisher
+// retrying each publish, including batch publishes.
+func TestDartResilientPublishers(t *testing.T) {
+	options := compiler.Options{
+		File:  batchPublishFile,
+		Gen:   "dart:batch_publish,resilient_publishers",
+		Out:   filepath.Join(outputDir, "resilient_publishers"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	root := filepath.Join(outputDir, "resilient_publishers", "batch_publish")
+	files := []FileComparisonPair{
+		{"expected/dart/resilient_publishers/batch_publish.dart", filepath.Join(root, "lib", "batch_publish.dart")},
+		{"expected/dart/resilient_publishers/f_ticks_scope.dart", filepath.Join(root, "lib", "src", "f_ticks_scope.dart")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures request/reply operations generate requesters and responders.
+func TestDartScopeRequest(t *testing.T) {
+	options := compiler.Options{
+		File:  scopeRequestFile,
+		Gen:   "dart",
+		Out:   filepath.Join(outputDir, "scope_request", "dart"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/dart/scope_request/f_events_scope.dart", filepath.Join(outputDir, "scope_request", "dart", "scope_request", "lib", "src", "f_events_scope.dart")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
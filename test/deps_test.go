@@ -0,0 +1,70 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependenciesDOT(t *testing.T) {
+	graph, err := compiler.Dependencies(circularFile, []string{"go", "java", "py", "dart"}, "")
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assert.Equal(t, [][]string{
+		{"circular_1.frugal", "circular_2.frugal", "circular_3.frugal", "circular_1.frugal"},
+	}, graph.Cycles)
+
+	var buf bytes.Buffer
+	if err := graph.WriteDOT(&buf); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assertDependencies(t, "expected/deps/circular.dot", buf.String())
+}
+
+// Vendored includes aren't generated by languages using them.
+func TestDependenciesJSON(t *testing.T) {
+	graph, err := compiler.Dependencies(includeVendor, []string{"go:use_vendor", "java"}, "out")
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assert.Empty(t, graph.Cycles)
+
+	var buf bytes.Buffer
+	if err := graph.WriteJSON(&buf); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assertDependencies(t, "expected/deps/include_vendor.json", buf.String())
+}
+
+func TestDependenciesInvalid(t *testing.T) {
+	_, err := compiler.Dependencies(circularFile, []string{"nope"}, "")
+	assert.Equal(t, "Invalid gen value nope", err.Error())
+
+	_, err = compiler.Dependencies("idl/missing.frugal", nil, "")
+	assert.Error(t, err)
+}
+
+func assertDependencies(t *testing.T, expectedFile, actual string) {
+	expected, err := ioutil.ReadFile(expectedFile)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assert.Equal(t, string(expected), actual)
+}
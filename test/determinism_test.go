@@ -0,0 +1,111 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+// Ensures generating the same IDL twice produces byte-identical output for
+// every generator, so generated code can be checked in without spurious
+// diffs.
+func TestDeterministicOutput(t *testing.T) {
+	targets := []struct {
+		name, gen, file string
+	}{
+		{"go", "go:package_prefix=github.com/Workiva/frugal/test/out/determinism/go", frugalGenFile},
+		{"java", "java:generated_annotations=undated", frugalGenFile},
+		{"dart", "dart", frugalGenFile},
+		{"tornado", "py:tornado", frugalGenFile},
+		{"asyncio", "py:asyncio", frugalGenFile},
+		{"html", "html", frugalGenFile},
+		{"broker", "broker", brokerFile},
+		{"grpc", "grpc", catalogFile},
+		{"graphql", "graphql", catalogFile},
+	}
+	for _, target := range targets {
+		outs := []string{}
+		for _, run := range []string{"first", "second"} {
+			out := filepath.Join(outputDir, "determinism", target.name, run)
+			options := compiler.Options{
+				File:    target.file,
+				Gen:     target.gen,
+				Out:     out,
+				Delim:   delim,
+				Recurse: true,
+			}
+			if err := compiler.Compile(options); err != nil {
+				t.Fatalf("Unexpected error generating %s: %s", target.gen, err)
+			}
+			outs = append(outs, out)
+		}
+		compareDirs(t, target.gen, outs[0], outs[1])
+	}
+}
+
+// compareDirs fails the test if the directories don't contain the same files
+// with the same contents.
+func compareDirs(t *testing.T, gen, expectedDir, generatedDir string) {
+	expected := readDir(t, expectedDir)
+	generated := readDir(t, generatedDir)
+	paths := make([]string, 0, len(expected))
+	for path := range expected {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		contents, ok := generated[path]
+		if !ok {
+			t.Fatalf("%s: %s was only generated once", gen, path)
+		}
+		if !bytes.Equal(expected[path], contents) {
+			t.Fatalf("%s: %s differs between runs", gen, path)
+		}
+	}
+	if len(generated) != len(expected) {
+		t.Fatalf("%s: generated %d files, then %d", gen, len(expected), len(generated))
+	}
+}
+
+// readDir returns the contents of every file under the directory keyed by
+// path relative to it.
+func readDir(t *testing.T, dir string) map[string][]byte {
+	files := make(map[string][]byte)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[rel] = contents
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"testing"
+
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	diffOldFile = "idl/diff/old.frugal"
+	diffNewFile = "idl/diff/new.frugal"
+)
+
+func TestDiffUnchanged(t *testing.T) {
+	changes, err := parser.NewDiffer().Diff(validFile, validFile)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	assert.Empty(t, changes)
+}
+
+func TestDiff(t *testing.T) {
+	changes, err := parser.NewDiffer().Diff(diffOldFile, diffNewFile)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	actual := make([]string, 0, len(changes))
+	for _, change := range changes {
+		actual = append(actual, change.String())
+	}
+	expected := []string{
+		"risky: namespace java: changed: 'diff.java' -> 'diff.java.v2'",
+		"compatible: namespace py: added",
+		"risky: constant max_items: value changed",
+		"risky: typedef Millis: type changed: 'i64' -> 'i32'",
+		"risky: enum Status: value INACTIVE: renamed to DISABLED",
+		"breaking: enum Status: value ARCHIVED: removed with value 3",
+		"compatible: enum Status: value DELETED: added",
+		"risky: struct Item: field title: presence modifier changed: 'OPTIONAL' -> 'DEFAULT'",
+		"breaking: struct Item: field created: type changed: 'i64' -> 'i32'",
+		"risky: struct Item: field notes: removed with ID=4",
+		"breaking: struct Item: field owner: presence modifier changed: 'DEFAULT' -> 'REQUIRED'",
+		"risky: struct Item: field owner: annotation pii removed",
+		"compatible: struct Item: field tags: added with ID=6",
+		"breaking: struct Legacy: removed",
+		"compatible: service Store: method get: argument cached: added with ID=2",
+		"breaking: service Store: method put: removed",
+		"breaking: service Store: method ping: one way modifier changed",
+		"compatible: service Store: method delete: added",
+		"breaking: scope Audits: removed",
+		"risky: scope Items: prefix variables renamed: 'foo.{user}' -> 'foo.{userID}'",
+		"risky: scope Items: annotation owners changed: 'team-a' -> 'team-b'",
+		"breaking: scope Items: operation Deleted: removed",
+		"compatible: scope Items: operation Updated: added",
+		"compatible: scope Metrics: added",
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func TestEventCatalog(t *testing.T) {
+	options := compiler.Options{
+		File:         eventsFile,
+		Gen:          "html",
+		Out:          filepath.Join(outputDir, "event_catalog"),
+		Delim:        delim,
+		EventCatalog: true,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/event_catalog/events.events.json", filepath.Join(outputDir, "event_catalog", "events.events.json")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestEventCatalogNotRequested(t *testing.T) {
+	options := compiler.Options{
+		File:  eventsFile,
+		Gen:   "html",
+		Out:   filepath.Join(outputDir, "event_catalog_off"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	assertFilesNotExist(t, []string{filepath.Join(outputDir, "event_catalog_off", "events.events.json")})
+}
@@ -0,0 +1,1399 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+
+
+import org.apache.thrift.scheme.IScheme;
+import org.apache.thrift.scheme.SchemeFactory;
+import org.apache.thrift.scheme.StandardScheme;
+
+import org.apache.thrift.scheme.TupleScheme;
+import org.apache.thrift.protocol.TTupleProtocol;
+import org.apache.thrift.protocol.TProtocolException;
+import org.apache.thrift.EncodingUtils;
+import org.apache.thrift.TException;
+import org.apache.thrift.async.AsyncMethodCallback;
+import org.apache.thrift.server.AbstractNonblockingServer.*;
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import javax.annotation.Generated;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+/**
+ * An order placed by a customer.
+ */
+@Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
+public class Order implements org.apache.thrift.TBase<Order, Order._Fields>, java.io.Serializable, Cloneable, Comparable<Order> {
+	private static final org.apache.thrift.protocol.TStruct STRUCT_DESC = new org.apache.thrift.protocol.TStruct("Order");
+
+	private static final org.apache.thrift.protocol.TField ID_FIELD_DESC = new org.apache.thrift.protocol.TField("id", org.apache.thrift.protocol.TType.STRING, (short)1);
+	private static final org.apache.thrift.protocol.TField ITEMS_FIELD_DESC = new org.apache.thrift.protocol.TField("items", org.apache.thrift.protocol.TType.LIST, (short)2);
+	private static final org.apache.thrift.protocol.TField THINGS_FIELD_DESC = new org.apache.thrift.protocol.TField("things", org.apache.thrift.protocol.TType.MAP, (short)3);
+	private static final org.apache.thrift.protocol.TField COUPON_FIELD_DESC = new org.apache.thrift.protocol.TField("coupon", org.apache.thrift.protocol.TType.STRING, (short)4);
+	private static final org.apache.thrift.protocol.TField CUSTOMER_FIELD_DESC = new org.apache.thrift.protocol.TField("customer", org.apache.thrift.protocol.TType.STRUCT, (short)5);
+	private static final org.apache.thrift.protocol.TField CONTACTS_FIELD_DESC = new org.apache.thrift.protocol.TField("contacts", org.apache.thrift.protocol.TType.LIST, (short)6);
+	private static final org.apache.thrift.protocol.TField EMAIL_FIELD_DESC = new org.apache.thrift.protocol.TField("email", org.apache.thrift.protocol.TType.STRING, (short)7);
+	private static final org.apache.thrift.protocol.TField NOTES_FIELD_DESC = new org.apache.thrift.protocol.TField("notes", org.apache.thrift.protocol.TType.SET, (short)8);
+
+	private static final Map<Class<? extends IScheme>, SchemeFactory> schemes = new HashMap<Class<? extends IScheme>, SchemeFactory>();
+	static {
+		schemes.put(StandardScheme.class, new OrderStandardSchemeFactory());
+		schemes.put(TupleScheme.class, new OrderTupleSchemeFactory());
+	}
+
+	/**
+	 * Unique identifier of the order.
+	 */
+	public String id; // required
+	public java.util.List<LineItem> items;
+	public java.util.Map<String, actual_base.java.thing> things;
+	/**
+	 * @deprecated coupons are applied at checkout
+	 */
+	@Deprecated
+	public String coupon; // optional
+	public Customer customer;
+	public java.util.List<java.util.Map<String, Customer>> contacts;
+	public String email;
+	public java.util.Set<String> notes;
+	/** The set of fields this struct contains, along with convenience methods for finding and manipulating them. */
+	public enum _Fields implements org.apache.thrift.TFieldIdEnum {
+		/**
+		 * Unique identifier of the order.
+		 */
+		ID((short)1, "id"),
+		ITEMS((short)2, "items"),
+		THINGS((short)3, "things"),
+		COUPON((short)4, "coupon"),
+		CUSTOMER((short)5, "customer"),
+		CONTACTS((short)6, "contacts"),
+		EMAIL((short)7, "email"),
+		NOTES((short)8, "notes")
+		;
+
+		private static final Map<String, _Fields> byName = new HashMap<String, _Fields>();
+
+		static {
+			for (_Fields field : EnumSet.allOf(_Fields.class)) {
+				byName.put(field.getFieldName(), field);
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, or null if its not found.
+		 */
+		public static _Fields findByThriftId(int fieldId) {
+			switch(fieldId) {
+				case 1: // ID
+					return ID;
+				case 2: // ITEMS
+					return ITEMS;
+				case 3: // THINGS
+					return THINGS;
+				case 4: // COUPON
+					return COUPON;
+				case 5: // CUSTOMER
+					return CUSTOMER;
+				case 6: // CONTACTS
+					return CONTACTS;
+				case 7: // EMAIL
+					return EMAIL;
+				case 8: // NOTES
+					return NOTES;
+				default:
+					return null;
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, throwing an exception
+		 * if it is not found.
+		 */
+		public static _Fields findByThriftIdOrThrow(int fieldId) {
+			_Fields fields = findByThriftId(fieldId);
+			if (fields == null) throw new IllegalArgumentException("Field " + fieldId + " doesn't exist!");
+			return fields;
+		}
+
+		/**
+		 * Find the _Fields constant that matches name, or null if its not found.
+		 */
+		public static _Fields findByName(String name) {
+			return byName.get(name);
+		}
+
+		private final short _thriftId;
+		private final String _fieldName;
+
+		_Fields(short thriftId, String fieldName) {
+			_thriftId = thriftId;
+			_fieldName = fieldName;
+		}
+
+		public short getThriftFieldId() {
+			return _thriftId;
+		}
+
+		public String getFieldName() {
+			return _fieldName;
+		}
+	}
+
+	// isset id assignments
+	public Order() {
+	}
+
+	public Order(
+		String id,
+		java.util.List<LineItem> items,
+		java.util.Map<String, actual_base.java.thing> things,
+		Customer customer,
+		java.util.List<java.util.Map<String, Customer>> contacts,
+		String email,
+		java.util.Set<String> notes) {
+		this();
+		this.id = id;
+		this.items = items;
+		this.things = things;
+		this.customer = customer;
+		this.contacts = contacts;
+		this.email = email;
+		this.notes = notes;
+	}
+
+	/**
+	 * Performs a deep copy on <i>other</i>.
+	 */
+	public Order(Order other) {
+		if (other.isSetId()) {
+			this.id = other.id;
+		}
+		if (other.isSetItems()) {
+			this.items = new ArrayList<LineItem>(other.items.size());
+			for (LineItem elem14 : other.items) {
+				LineItem elem15 = new LineItem(elem14);
+				this.items.add(elem15);
+			}
+		}
+		if (other.isSetThings()) {
+			this.things = new HashMap<String,actual_base.java.thing>(other.things.size());
+			for (Map.Entry<String, actual_base.java.thing> elem16 : other.things.entrySet()) {
+				String elem18 = elem16.getKey();
+				actual_base.java.thing elem17 = new actual_base.java.thing(elem16.getValue());
+				this.things.put(elem18, elem17);
+			}
+		}
+		if (other.isSetCoupon()) {
+			this.coupon = other.coupon;
+		}
+		if (other.isSetCustomer()) {
+			this.customer = new Customer(other.customer);
+		}
+		if (other.isSetContacts()) {
+			this.contacts = new ArrayList<java.util.Map<String, Customer>>(other.contacts.size());
+			for (java.util.Map<String, Customer> elem19 : other.contacts) {
+				java.util.Map<String, Customer> elem20 = new HashMap<String,Customer>(elem19.size());
+				for (Map.Entry<String, Customer> elem21 : elem19.entrySet()) {
+					String elem23 = elem21.getKey();
+					Customer elem22 = new Customer(elem21.getValue());
+					elem20.put(elem23, elem22);
+				}
+				this.contacts.add(elem20);
+			}
+		}
+		if (other.isSetEmail()) {
+			this.email = other.email;
+		}
+		if (other.isSetNotes()) {
+			this.notes = new HashSet<String>(other.notes.size());
+			for (String elem24 : other.notes) {
+				String elem25 = elem24;
+				this.notes.add(elem25);
+			}
+		}
+	}
+
+	public Order deepCopy() {
+		return new Order(this);
+	}
+
+	@Override
+	public void clear() {
+		this.id = null;
+
+		this.items = null;
+
+		this.things = null;
+
+		this.coupon = null;
+
+		this.customer = null;
+
+		this.contacts = null;
+
+		this.email = null;
+
+		this.notes = null;
+
+	}
+
+	/**
+	 * Unique identifier of the order.
+	 */
+	public String getId() {
+		return this.id;
+	}
+
+	/**
+	 * Unique identifier of the order.
+	 */
+	public Order setId(String id) {
+		this.id = id;
+		return this;
+	}
+
+	public void unsetId() {
+		this.id = null;
+	}
+
+	/** Returns true if field id is set (has been assigned a value) and false otherwise */
+	public boolean isSetId() {
+		return this.id != null;
+	}
+
+	public void setIdIsSet(boolean value) {
+		if (!value) {
+			this.id = null;
+		}
+	}
+
+	public int getItemsSize() {
+		return (this.items == null) ? 0 : this.items.size();
+	}
+
+	public java.util.Iterator<LineItem> getItemsIterator() {
+		return (this.items == null) ? null : this.items.iterator();
+	}
+
+	public void addToItems(LineItem elem) {
+		if (this.items == null) {
+			this.items = new ArrayList<LineItem>();
+		}
+		this.items.add(elem);
+	}
+
+	public java.util.List<LineItem> getItems() {
+		return this.items;
+	}
+
+	public Order setItems(java.util.List<LineItem> items) {
+		this.items = items;
+		return this;
+	}
+
+	public void unsetItems() {
+		this.items = null;
+	}
+
+	/** Returns true if field items is set (has been assigned a value) and false otherwise */
+	public boolean isSetItems() {
+		return this.items != null;
+	}
+
+	public void setItemsIsSet(boolean value) {
+		if (!value) {
+			this.items = null;
+		}
+	}
+
+	public int getThingsSize() {
+		return (this.things == null) ? 0 : this.things.size();
+	}
+
+	public void putToThings(String key, actual_base.java.thing val) {
+		if (this.things == null) {
+			this.things = new HashMap<String,actual_base.java.thing>();
+		}
+		this.things.put(key, val);
+	}
+
+	public java.util.Map<String, actual_base.java.thing> getThings() {
+		return this.things;
+	}
+
+	public Order setThings(java.util.Map<String, actual_base.java.thing> things) {
+		this.things = things;
+		return this;
+	}
+
+	public void unsetThings() {
+		this.things = null;
+	}
+
+	/** Returns true if field things is set (has been assigned a value) and false otherwise */
+	public boolean isSetThings() {
+		return this.things != null;
+	}
+
+	public void setThingsIsSet(boolean value) {
+		if (!value) {
+			this.things = null;
+		}
+	}
+
+	@Deprecated
+	public String getCoupon() {
+		return this.coupon;
+	}
+
+	@Deprecated
+	public Order setCoupon(String coupon) {
+		this.coupon = coupon;
+		return this;
+	}
+
+	@Deprecated
+	public void unsetCoupon() {
+		this.coupon = null;
+	}
+
+	/** Returns true if field coupon is set (has been assigned a value) and false otherwise */
+	@Deprecated
+	public boolean isSetCoupon() {
+		return this.coupon != null;
+	}
+
+	@Deprecated
+	public void setCouponIsSet(boolean value) {
+		if (!value) {
+			this.coupon = null;
+		}
+	}
+
+	public Customer getCustomer() {
+		return this.customer;
+	}
+
+	public Order setCustomer(Customer customer) {
+		this.customer = customer;
+		return this;
+	}
+
+	public void unsetCustomer() {
+		this.customer = null;
+	}
+
+	/** Returns true if field customer is set (has been assigned a value) and false otherwise */
+	public boolean isSetCustomer() {
+		return this.customer != null;
+	}
+
+	public void setCustomerIsSet(boolean value) {
+		if (!value) {
+			this.customer = null;
+		}
+	}
+
+	public int getContactsSize() {
+		return (this.contacts == null) ? 0 : this.contacts.size();
+	}
+
+	public java.util.Iterator<java.util.Map<String, Customer>> getContactsIterator() {
+		return (this.contacts == null) ? null : this.contacts.iterator();
+	}
+
+	public void addToContacts(java.util.Map<String, Customer> elem) {
+		if (this.contacts == null) {
+			this.contacts = new ArrayList<java.util.Map<String, Customer>>();
+		}
+		this.contacts.add(elem);
+	}
+
+	public java.util.List<java.util.Map<String, Customer>> getContacts() {
+		return this.contacts;
+	}
+
+	public Order setContacts(java.util.List<java.util.Map<String, Customer>> contacts) {
+		this.contacts = contacts;
+		return this;
+	}
+
+	public void unsetContacts() {
+		this.contacts = null;
+	}
+
+	/** Returns true if field contacts is set (has been assigned a value) and false otherwise */
+	public boolean isSetContacts() {
+		return this.contacts != null;
+	}
+
+	public void setContactsIsSet(boolean value) {
+		if (!value) {
+			this.contacts = null;
+		}
+	}
+
+	public String getEmail() {
+		return this.email;
+	}
+
+	public Order setEmail(String email) {
+		this.email = email;
+		return this;
+	}
+
+	public void unsetEmail() {
+		this.email = null;
+	}
+
+	/** Returns true if field email is set (has been assigned a value) and false otherwise */
+	public boolean isSetEmail() {
+		return this.email != null;
+	}
+
+	public void setEmailIsSet(boolean value) {
+		if (!value) {
+			this.email = null;
+		}
+	}
+
+	public int getNotesSize() {
+		return (this.notes == null) ? 0 : this.notes.size();
+	}
+
+	public java.util.Iterator<String> getNotesIterator() {
+		return (this.notes == null) ? null : this.notes.iterator();
+	}
+
+	public void addToNotes(String elem) {
+		if (this.notes == null) {
+			this.notes = new HashSet<String>();
+		}
+		this.notes.add(elem);
+	}
+
+	public java.util.Set<String> getNotes() {
+		return this.notes;
+	}
+
+	public Order setNotes(java.util.Set<String> notes) {
+		this.notes = notes;
+		return this;
+	}
+
+	public void unsetNotes() {
+		this.notes = null;
+	}
+
+	/** Returns true if field notes is set (has been assigned a value) and false otherwise */
+	public boolean isSetNotes() {
+		return this.notes != null;
+	}
+
+	public void setNotesIsSet(boolean value) {
+		if (!value) {
+			this.notes = null;
+		}
+	}
+
+	public void setFieldValue(_Fields field, Object value) {
+		switch (field) {
+		case ID:
+			if (value == null) {
+				unsetId();
+			} else {
+				setId((String)value);
+			}
+			break;
+
+		case ITEMS:
+			if (value == null) {
+				unsetItems();
+			} else {
+				setItems((java.util.List<LineItem>)value);
+			}
+			break;
+
+		case THINGS:
+			if (value == null) {
+				unsetThings();
+			} else {
+				setThings((java.util.Map<String, actual_base.java.thing>)value);
+			}
+			break;
+
+		case COUPON:
+			if (value == null) {
+				unsetCoupon();
+			} else {
+				setCoupon((String)value);
+			}
+			break;
+
+		case CUSTOMER:
+			if (value == null) {
+				unsetCustomer();
+			} else {
+				setCustomer((Customer)value);
+			}
+			break;
+
+		case CONTACTS:
+			if (value == null) {
+				unsetContacts();
+			} else {
+				setContacts((java.util.List<java.util.Map<String, Customer>>)value);
+			}
+			break;
+
+		case EMAIL:
+			if (value == null) {
+				unsetEmail();
+			} else {
+				setEmail((String)value);
+			}
+			break;
+
+		case NOTES:
+			if (value == null) {
+				unsetNotes();
+			} else {
+				setNotes((java.util.Set<String>)value);
+			}
+			break;
+
+		}
+	}
+
+	public Object getFieldValue(_Fields field) {
+		switch (field) {
+		case ID:
+			return getId();
+
+		case ITEMS:
+			return getItems();
+
+		case THINGS:
+			return getThings();
+
+		case COUPON:
+			return getCoupon();
+
+		case CUSTOMER:
+			return getCustomer();
+
+		case CONTACTS:
+			return getContacts();
+
+		case EMAIL:
+			return getEmail();
+
+		case NOTES:
+			return getNotes();
+
+		}
+		throw new IllegalStateException();
+	}
+
+	/** Returns true if field corresponding to fieldID is set (has been assigned a value) and false otherwise */
+	public boolean isSet(_Fields field) {
+		if (field == null) {
+			throw new IllegalArgumentException();
+		}
+
+		switch (field) {
+		case ID:
+			return isSetId();
+		case ITEMS:
+			return isSetItems();
+		case THINGS:
+			return isSetThings();
+		case COUPON:
+			return isSetCoupon();
+		case CUSTOMER:
+			return isSetCustomer();
+		case CONTACTS:
+			return isSetContacts();
+		case EMAIL:
+			return isSetEmail();
+		case NOTES:
+			return isSetNotes();
+		}
+		throw new IllegalStateException();
+	}
+
+	@Override
+	public boolean equals(Object that) {
+		if (that == null)
+			return false;
+		if (that instanceof Order)
+			return this.equals((Order)that);
+		return false;
+	}
+
+	public boolean equals(Order that) {
+		if (that == null)
+			return false;
+
+		boolean this_present_id = true && this.isSetId();
+		boolean that_present_id = true && that.isSetId();
+		if (this_present_id || that_present_id) {
+			if (!(this_present_id && that_present_id))
+				return false;
+			if (!this.id.equals(that.id))
+				return false;
+		}
+
+		boolean this_present_items = true && this.isSetItems();
+		boolean that_present_items = true && that.isSetItems();
+		if (this_present_items || that_present_items) {
+			if (!(this_present_items && that_present_items))
+				return false;
+			if (!this.items.equals(that.items))
+				return false;
+		}
+
+		boolean this_present_things = true && this.isSetThings();
+		boolean that_present_things = true && that.isSetThings();
+		if (this_present_things || that_present_things) {
+			if (!(this_present_things && that_present_things))
+				return false;
+			if (!this.things.equals(that.things))
+				return false;
+		}
+
+		boolean this_present_coupon = true && this.isSetCoupon();
+		boolean that_present_coupon = true && that.isSetCoupon();
+		if (this_present_coupon || that_present_coupon) {
+			if (!(this_present_coupon && that_present_coupon))
+				return false;
+			if (!this.coupon.equals(that.coupon))
+				return false;
+		}
+
+		boolean this_present_customer = true && this.isSetCustomer();
+		boolean that_present_customer = true && that.isSetCustomer();
+		if (this_present_customer || that_present_customer) {
+			if (!(this_present_customer && that_present_customer))
+				return false;
+			if (!this.customer.equals(that.customer))
+				return false;
+		}
+
+		boolean this_present_contacts = true && this.isSetContacts();
+		boolean that_present_contacts = true && that.isSetContacts();
+		if (this_present_contacts || that_present_contacts) {
+			if (!(this_present_contacts && that_present_contacts))
+				return false;
+			if (!this.contacts.equals(that.contacts))
+				return false;
+		}
+
+		boolean this_present_email = true && this.isSetEmail();
+		boolean that_present_email = true && that.isSetEmail();
+		if (this_present_email || that_present_email) {
+			if (!(this_present_email && that_present_email))
+				return false;
+			if (!this.email.equals(that.email))
+				return false;
+		}
+
+		boolean this_present_notes = true && this.isSetNotes();
+		boolean that_present_notes = true && that.isSetNotes();
+		if (this_present_notes || that_present_notes) {
+			if (!(this_present_notes && that_present_notes))
+				return false;
+			if (!this.notes.equals(that.notes))
+				return false;
+		}
+
+		return true;
+	}
+
+	@Override
+	public int hashCode() {
+		List<Object> list = new ArrayList<Object>();
+
+		boolean present_id = true && (isSetId());
+		list.add(present_id);
+		if (present_id)
+			list.add(id);
+
+		boolean present_items = true && (isSetItems());
+		list.add(present_items);
+		if (present_items)
+			list.add(items);
+
+		boolean present_things = true && (isSetThings());
+		list.add(present_things);
+		if (present_things)
+			list.add(things);
+
+		boolean present_coupon = true && (isSetCoupon());
+		list.add(present_coupon);
+		if (present_coupon)
+			list.add(coupon);
+
+		boolean present_customer = true && (isSetCustomer());
+		list.add(present_customer);
+		if (present_customer)
+			list.add(customer);
+
+		boolean present_contacts = true && (isSetContacts());
+		list.add(present_contacts);
+		if (present_contacts)
+			list.add(contacts);
+
+		boolean present_email = true && (isSetEmail());
+		list.add(present_email);
+		if (present_email)
+			list.add(email);
+
+		boolean present_notes = true && (isSetNotes());
+		list.add(present_notes);
+		if (present_notes)
+			list.add(notes);
+
+		return list.hashCode();
+	}
+
+	@Override
+	public int compareTo(Order other) {
+		if (!getClass().equals(other.getClass())) {
+			return getClass().getName().compareTo(other.getClass().getName());
+		}
+
+		int lastComparison = 0;
+
+		lastComparison = Boolean.valueOf(isSetId()).compareTo(other.isSetId());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetId()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.id, other.id);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetItems()).compareTo(other.isSetItems());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetItems()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.items, other.items);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetThings()).compareTo(other.isSetThings());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetThings()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.things, other.things);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetCoupon()).compareTo(other.isSetCoupon());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetCoupon()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.coupon, other.coupon);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetCustomer()).compareTo(other.isSetCustomer());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetCustomer()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.customer, other.customer);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetContacts()).compareTo(other.isSetContacts());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetContacts()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.contacts, other.contacts);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetEmail()).compareTo(other.isSetEmail());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetEmail()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.email, other.email);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetNotes()).compareTo(other.isSetNotes());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetNotes()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.notes, other.notes);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		return 0;
+	}
+
+	public _Fields fieldForId(int fieldId) {
+		return _Fields.findByThriftId(fieldId);
+	}
+
+	public void read(org.apache.thrift.protocol.TProtocol iprot) throws org.apache.thrift.TException {
+		schemes.get(iprot.getScheme()).getScheme().read(iprot, this);
+	}
+
+	public void write(org.apache.thrift.protocol.TProtocol oprot) throws org.apache.thrift.TException {
+		schemes.get(oprot.getScheme()).getScheme().write(oprot, this);
+	}
+
+	@Override
+	public String toString() {
+		StringBuilder sb = new StringBuilder("Order(");
+		boolean first = true;
+
+		sb.append("id:");
+		if (this.id == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.id);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("items:");
+		if (this.items == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.items);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("things:");
+		if (this.things == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.things);
+		}
+		first = false;
+		if (isSetCoupon()) {
+			if (!first) sb.append(", ");
+			sb.append("coupon:");
+			if (this.coupon == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.coupon);
+			}
+			first = false;
+		}
+		if (!first) sb.append(", ");
+		sb.append("customer:");
+		if (this.customer == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.customer);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("contacts:");
+		if (this.contacts == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.contacts);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("email:");
+		if (this.email == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.email);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("notes:");
+		if (this.notes == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.notes);
+		}
+		first = false;
+		sb.append(")");
+		return sb.toString();
+	}
+
+	public void validate() throws org.apache.thrift.TException {
+		// check for required fields
+		if (id == null) {
+			throw new org.apache.thrift.protocol.TProtocolException("Required field 'id' is not present in struct 'Order'");
+		}
+		// check for sub-struct validity
+		if (customer != null) {
+			customer.validate();
+		}
+	}
+
+	/**
+	 * Returns a new Builder for Order.
+	 */
+	public static Builder builder() {
+		return new Builder();
+	}
+
+	/**
+	 * Builds Order values field by field.
+	 */
+	public static class Builder {
+		private final Order struct = new Order();
+
+		public Builder withId(String id) {
+			struct.setId(id);
+			return this;
+		}
+
+		public Builder withItems(java.util.List<LineItem> items) {
+			struct.setItems(items);
+			return this;
+		}
+
+		public Builder withThings(java.util.Map<String, actual_base.java.thing> things) {
+			struct.setThings(things);
+			return this;
+		}
+
+		@Deprecated
+		public Builder withCoupon(String coupon) {
+			struct.setCoupon(coupon);
+			return this;
+		}
+
+		public Builder withCustomer(Customer customer) {
+			struct.setCustomer(customer);
+			return this;
+		}
+
+		public Builder withContacts(java.util.List<java.util.Map<String, Customer>> contacts) {
+			struct.setContacts(contacts);
+			return this;
+		}
+
+		public Builder withEmail(String email) {
+			struct.setEmail(email);
+			return this;
+		}
+
+		public Builder withNotes(java.util.Set<String> notes) {
+			struct.setNotes(notes);
+			return this;
+		}
+
+		/**
+		 * Returns a copy of the Order built so far.
+		 */
+		public Order build() {
+			return new Order(struct);
+		}
+	}
+
+	private void writeObject(java.io.ObjectOutputStream out) throws java.io.IOException {
+		try {
+			write(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(out)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private void readObject(java.io.ObjectInputStream in) throws java.io.IOException, ClassNotFoundException {
+		try {
+			// it doesn't seem like you should have to do this, but java serialization is wacky, and doesn't call the default constructor.
+			read(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(in)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private static class OrderStandardSchemeFactory implements SchemeFactory {
+		public OrderStandardScheme getScheme() {
+			return new OrderStandardScheme();
+		}
+	}
+
+	private static class OrderStandardScheme extends StandardScheme<Order> {
+
+		public void read(org.apache.thrift.protocol.TProtocol iprot, Order struct) throws org.apache.thrift.TException {
+			org.apache.thrift.protocol.TField schemeField;
+			iprot.readStructBegin();
+			while (true) {
+				schemeField = iprot.readFieldBegin();
+				if (schemeField.type == org.apache.thrift.protocol.TType.STOP) {
+					break;
+				}
+				switch (schemeField.id) {
+					case 1: // ID
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.id = iprot.readString();
+							struct.setIdIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 2: // ITEMS
+						if (schemeField.type == org.apache.thrift.protocol.TType.LIST) {
+							org.apache.thrift.protocol.TList elem26 = iprot.readListBegin();
+							struct.items = new ArrayList<LineItem>(elem26.size);
+							for (int elem27 = 0; elem27 < elem26.size; ++elem27) {
+								LineItem elem28 = new LineItem();
+								elem28.read(iprot);
+								struct.items.add(elem28);
+							}
+							iprot.readListEnd();
+							struct.setItemsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 3: // THINGS
+						if (schemeField.type == org.apache.thrift.protocol.TType.MAP) {
+							org.apache.thrift.protocol.TMap elem29 = iprot.readMapBegin();
+							struct.things = new HashMap<String,actual_base.java.thing>(2*elem29.size);
+							for (int elem30 = 0; elem30 < elem29.size; ++elem30) {
+								String elem32 = iprot.readString();
+								actual_base.java.thing elem31 = new actual_base.java.thing();
+								elem31.read(iprot);
+								struct.things.put(elem32, elem31);
+							}
+							iprot.readMapEnd();
+							struct.setThingsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 4: // COUPON
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.coupon = iprot.readString();
+							struct.setCouponIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 5: // CUSTOMER
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRUCT) {
+							struct.customer = new Customer();
+							struct.customer.read(iprot);
+							struct.setCustomerIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 6: // CONTACTS
+						if (schemeField.type == org.apache.thrift.protocol.TType.LIST) {
+							org.apache.thrift.protocol.TList elem33 = iprot.readListBegin();
+							struct.contacts = new ArrayList<java.util.Map<String, Customer>>(elem33.size);
+							for (int elem34 = 0; elem34 < elem33.size; ++elem34) {
+								org.apache.thrift.protocol.TMap elem36 = iprot.readMapBegin();
+								java.util.Map<String, Customer> elem35 = new HashMap<String,Customer>(2*elem36.size);
+								for (int elem37 = 0; elem37 < elem36.size; ++elem37) {
+									String elem39 = iprot.readString();
+									Customer elem38 = new Customer();
+									elem38.read(iprot);
+									elem35.put(elem39, elem38);
+								}
+								iprot.readMapEnd();
+								struct.contacts.add(elem35);
+							}
+							iprot.readListEnd();
+							struct.setContactsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 7: // EMAIL
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.email = iprot.readString();
+							struct.setEmailIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 8: // NOTES
+						if (schemeField.type == org.apache.thrift.protocol.TType.SET) {
+							org.apache.thrift.protocol.TSet elem40 = iprot.readSetBegin();
+							struct.notes = new HashSet<String>(2*elem40.size);
+							for (int elem41 = 0; elem41 < elem40.size; ++elem41) {
+								String elem42 = iprot.readString();
+								struct.notes.add(elem42);
+							}
+							iprot.readSetEnd();
+							struct.setNotesIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					default:
+						org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+				}
+				iprot.readFieldEnd();
+			}
+			iprot.readStructEnd();
+
+			// check for required fields of primitive type, which can't be checked in the validate method
+			struct.validate();
+		}
+
+		public void write(org.apache.thrift.protocol.TProtocol oprot, Order struct) throws org.apache.thrift.TException {
+			struct.validate();
+
+			oprot.writeStructBegin(STRUCT_DESC);
+			if (struct.id != null) {
+				oprot.writeFieldBegin(ID_FIELD_DESC);
+				String elem43 = struct.id;
+				oprot.writeString(elem43);
+				oprot.writeFieldEnd();
+			}
+			if (struct.items != null) {
+				oprot.writeFieldBegin(ITEMS_FIELD_DESC);
+				oprot.writeListBegin(new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRUCT, struct.items.size()));
+				for (LineItem elem44 : struct.items) {
+					elem44.write(oprot);
+				}
+				oprot.writeListEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.things != null) {
+				oprot.writeFieldBegin(THINGS_FIELD_DESC);
+				oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRING, org.apache.thrift.protocol.TType.STRUCT, struct.things.size()));
+				for (Map.Entry<String, actual_base.java.thing> elem45 : struct.things.entrySet()) {
+					String elem46 = elem45.getKey();
+					oprot.writeString(elem46);
+					elem45.getValue().write(oprot);
+				}
+				oprot.writeMapEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.coupon != null) {
+				if (struct.isSetCoupon()) {
+					oprot.writeFieldBegin(COUPON_FIELD_DESC);
+					String elem47 = struct.coupon;
+					oprot.writeString(elem47);
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.customer != null) {
+				oprot.writeFieldBegin(CUSTOMER_FIELD_DESC);
+				struct.customer.write(oprot);
+				oprot.writeFieldEnd();
+			}
+			if (struct.contacts != null) {
+				oprot.writeFieldBegin(CONTACTS_FIELD_DESC);
+				oprot.writeListBegin(new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.MAP, struct.contacts.size()));
+				for (java.util.Map<String, Customer> elem48 : struct.contacts) {
+					oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRING, org.apache.thrift.protocol.TType.STRUCT, elem48.size()));
+					for (Map.Entry<String, Customer> elem49 : elem48.entrySet()) {
+						String elem50 = elem49.getKey();
+						oprot.writeString(elem50);
+						elem49.getValue().write(oprot);
+					}
+					oprot.writeMapEnd();
+				}
+				oprot.writeListEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.email != null) {
+				oprot.writeFieldBegin(EMAIL_FIELD_DESC);
+				String elem51 = struct.email;
+				oprot.writeString(elem51);
+				oprot.writeFieldEnd();
+			}
+			if (struct.notes != null) {
+				oprot.writeFieldBegin(NOTES_FIELD_DESC);
+				oprot.writeSetBegin(new org.apache.thrift.protocol.TSet(org.apache.thrift.protocol.TType.STRING, struct.notes.size()));
+				for (String elem52 : struct.notes) {
+					String elem53 = elem52;
+					oprot.writeString(elem53);
+				}
+				oprot.writeSetEnd();
+				oprot.writeFieldEnd();
+			}
+			oprot.writeFieldStop();
+			oprot.writeStructEnd();
+		}
+
+	}
+
+	private static class OrderTupleSchemeFactory implements SchemeFactory {
+		public OrderTupleScheme getScheme() {
+			return new OrderTupleScheme();
+		}
+	}
+
+	private static class OrderTupleScheme extends TupleScheme<Order> {
+
+		@Override
+		public void write(org.apache.thrift.protocol.TProtocol prot, Order struct) throws org.apache.thrift.TException {
+			TTupleProtocol oprot = (TTupleProtocol) prot;
+			String elem54 = struct.id;
+			oprot.writeString(elem54);
+			BitSet optionals = new BitSet();
+			if (struct.isSetItems()) {
+				optionals.set(0);
+			}
+			if (struct.isSetThings()) {
+				optionals.set(1);
+			}
+			if (struct.isSetCoupon()) {
+				optionals.set(2);
+			}
+			if (struct.isSetCustomer()) {
+				optionals.set(3);
+			}
+			if (struct.isSetContacts()) {
+				optionals.set(4);
+			}
+			if (struct.isSetEmail()) {
+				optionals.set(5);
+			}
+			if (struct.isSetNotes()) {
+				optionals.set(6);
+			}
+			oprot.writeBitSet(optionals, 7);
+			if (struct.isSetItems()) {
+				oprot.writeI32(struct.items.size());
+				for (LineItem elem55 : struct.items) {
+					elem55.write(oprot);
+				}
+			}
+			if (struct.isSetThings()) {
+				oprot.writeI32(struct.things.size());
+				for (Map.Entry<String, actual_base.java.thing> elem56 : struct.things.entrySet()) {
+					String elem57 = elem56.getKey();
+					oprot.writeString(elem57);
+					elem56.getValue().write(oprot);
+				}
+			}
+			if (struct.isSetCoupon()) {
+				String elem58 = struct.coupon;
+				oprot.writeString(elem58);
+			}
+			if (struct.isSetCustomer()) {
+				struct.customer.write(oprot);
+			}
+			if (struct.isSetContacts()) {
+				oprot.writeI32(struct.contacts.size());
+				for (java.util.Map<String, Customer> elem59 : struct.contacts) {
+					oprot.writeI32(elem59.size());
+					for (Map.Entry<String, Customer> elem60 : elem59.entrySet()) {
+						String elem61 = elem60.getKey();
+						oprot.writeString(elem61);
+						elem60.getValue().write(oprot);
+					}
+				}
+			}
+			if (struct.isSetEmail()) {
+				String elem62 = struct.email;
+				oprot.writeString(elem62);
+			}
+			if (struct.isSetNotes()) {
+				oprot.writeI32(struct.notes.size());
+				for (String elem63 : struct.notes) {
+					String elem64 = elem63;
+					oprot.writeString(elem64);
+				}
+			}
+		}
+
+		@Override
+		public void read(org.apache.thrift.protocol.TProtocol prot, Order struct) throws org.apache.thrift.TException {
+			TTupleProtocol iprot = (TTupleProtocol) prot;
+			struct.id = iprot.readString();
+			struct.setIdIsSet(true);
+			BitSet incoming = iprot.readBitSet(7);
+			if (incoming.get(0)) {
+				org.apache.thrift.protocol.TList elem65 = new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRUCT, iprot.readI32());
+				struct.items = new ArrayList<LineItem>(elem65.size);
+				for (int elem66 = 0; elem66 < elem65.size; ++elem66) {
+					LineItem elem67 = new LineItem();
+					elem67.read(iprot);
+					struct.items.add(elem67);
+				}
+				struct.setItemsIsSet(true);
+			}
+			if (incoming.get(1)) {
+				org.apache.thrift.protocol.TMap elem68 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRING, org.apache.thrift.protocol.TType.STRUCT, iprot.readI32());
+				struct.things = new HashMap<String,actual_base.java.thing>(2*elem68.size);
+				for (int elem69 = 0; elem69 < elem68.size; ++elem69) {
+					String elem71 = iprot.readString();
+					actual_base.java.thing elem70 = new actual_base.java.thing();
+					elem70.read(iprot);
+					struct.things.put(elem71, elem70);
+				}
+				struct.setThingsIsSet(true);
+			}
+			if (incoming.get(2)) {
+				struct.coupon = iprot.readString();
+				struct.setCouponIsSet(true);
+			}
+			if (incoming.get(3)) {
+				struct.customer = new Customer();
+				struct.customer.read(iprot);
+				struct.setCustomerIsSet(true);
+			}
+			if (incoming.get(4)) {
+				org.apache.thrift.protocol.TList elem72 = new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.MAP, iprot.readI32());
+				struct.contacts = new ArrayList<java.util.Map<String, Customer>>(elem72.size);
+				for (int elem73 = 0; elem73 < elem72.size; ++elem73) {
+					org.apache.thrift.protocol.TMap elem75 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRING, org.apache.thrift.protocol.TType.STRUCT, iprot.readI32());
+					java.util.Map<String, Customer> elem74 = new HashMap<String,Customer>(2*elem75.size);
+					for (int elem76 = 0; elem76 < elem75.size; ++elem76) {
+						String elem78 = iprot.readString();
+						Customer elem77 = new Customer();
+						elem77.read(iprot);
+						elem74.put(elem78, elem77);
+					}
+					struct.contacts.add(elem74);
+				}
+				struct.setContactsIsSet(true);
+			}
+			if (incoming.get(5)) {
+				struct.email = iprot.readString();
+				struct.setEmailIsSet(true);
+			}
+			if (incoming.get(6)) {
+				org.apache.thrift.protocol.TSet elem79 = new org.apache.thrift.protocol.TSet(org.apache.thrift.protocol.TType.STRING, iprot.readI32());
+				struct.notes = new HashSet<String>(2*elem79.size);
+				for (int elem80 = 0; elem80 < elem79.size; ++elem80) {
+					String elem81 = iprot.readString();
+					struct.notes.add(elem81);
+				}
+				struct.setNotesIsSet(true);
+			}
+		}
+
+	}
+
+}
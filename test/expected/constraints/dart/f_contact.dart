@@ -0,0 +1,218 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:typed_data' show Uint8List;
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:constraints/constraints.dart' as t_constraints;
+
+class Contact implements thrift.TBase {
+  static final thrift.TStruct _STRUCT_DESC = new thrift.TStruct("Contact");
+  static final thrift.TField _EMAIL_FIELD_DESC = new thrift.TField("email", thrift.TType.STRING, 1);
+  static final thrift.TField _PHONE_FIELD_DESC = new thrift.TField("phone", thrift.TType.STRING, 2);
+
+  String _email;
+  static const int EMAIL = 1;
+  String _phone;
+  static const int PHONE = 2;
+
+
+  Contact() {
+  }
+
+  String get email => this._email;
+
+  set email(String email) {
+    this._email = email;
+  }
+
+  bool isSetEmail() => this.email != null;
+
+  unsetEmail() {
+    this.email = null;
+  }
+
+  String get phone => this._phone;
+
+  set phone(String phone) {
+    this._phone = phone;
+  }
+
+  bool isSetPhone() => this.phone != null;
+
+  unsetPhone() {
+    this.phone = null;
+  }
+
+  getFieldValue(int fieldID) {
+    switch (fieldID) {
+      case EMAIL:
+        return this.email;
+      case PHONE:
+        return this.phone;
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  setFieldValue(int fieldID, Object value) {
+    switch(fieldID) {
+      case EMAIL:
+        if(value == null) {
+          unsetEmail();
+        } else {
+          this.email = value as String;
+        }
+        break;
+
+      case PHONE:
+        if(value == null) {
+          unsetPhone();
+        } else {
+          this.phone = value as String;
+        }
+        break;
+
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  // Returns true if the field corresponding to fieldID is set (has been assigned a value) and false otherwise
+  bool isSet(int fieldID) {
+    switch(fieldID) {
+      case EMAIL:
+        return isSetEmail();
+      case PHONE:
+        return isSetPhone();
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  read(thrift.TProtocol iprot) {
+    thrift.TField field;
+    iprot.readStructBegin();
+    while(true) {
+      field = iprot.readFieldBegin();
+      if(field.type == thrift.TType.STOP) {
+        break;
+      }
+      switch(field.id) {
+        case EMAIL:
+          if(field.type == thrift.TType.STRING) {
+            email = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case PHONE:
+          if(field.type == thrift.TType.STRING) {
+            phone = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, field.type);
+          break;
+      }
+      iprot.readFieldEnd();
+    }
+    iprot.readStructEnd();
+
+    // check for required fields of primitive type, which can't be checked in the validate method
+    validate();
+  }
+
+  write(thrift.TProtocol oprot) {
+    validate();
+
+    oprot.writeStructBegin(_STRUCT_DESC);
+    if(isSetEmail() && this.email != null) {
+      oprot.writeFieldBegin(_EMAIL_FIELD_DESC);
+      oprot.writeString(email);
+      oprot.writeFieldEnd();
+    }
+    if(isSetPhone() && this.phone != null) {
+      oprot.writeFieldBegin(_PHONE_FIELD_DESC);
+      oprot.writeString(phone);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldStop();
+    oprot.writeStructEnd();
+  }
+
+  String toString() {
+    StringBuffer ret = new StringBuffer("Contact(");
+
+    if(isSetEmail()) {
+      ret.write("email:");
+      if(this.email == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.email);
+      }
+    }
+
+    if(isSetPhone()) {
+      ret.write(", ");
+      ret.write("phone:");
+      if(this.phone == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.phone);
+      }
+    }
+
+    ret.write(")");
+
+    return ret.toString();
+  }
+
+  bool operator ==(Object o) {
+    if(o == null || !(o is Contact)) {
+      return false;
+    }
+    Contact other = o as Contact;
+    return this.email == other.email
+      && this.phone == other.phone;
+  }
+
+  int get hashCode {
+    var value = 17;
+    value = (value * 31) ^ email.hashCode;
+    value = (value * 31) ^ phone.hashCode;
+    return value;
+  }
+
+  Contact clone({
+    String email: null,
+    String phone: null,
+  }) {
+    return new Contact()
+      ..email = email ?? this.email
+      ..phone = phone ?? this.phone;
+  }
+
+  validate() {
+    // check exactly one field is set
+    int setFields = 0;
+    if(isSetEmail()) {
+      setFields++;
+    }
+    if(isSetPhone()) {
+      setFields++;
+    }
+    if(setFields != 1) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "The union did not have exactly one field set, $setFields were set");
+    }
+    // check field constraints
+    if(isSetEmail() && !new RegExp("@").hasMatch(email)) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Contact.email must match @");
+    }
+    if(isSetPhone() && !new RegExp("^\\+?[0-9]+\$").hasMatch(phone)) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Contact.phone must match ^\\+?[0-9]+\$");
+    }
+    // check that fields of type enum have valid values
+  }
+}
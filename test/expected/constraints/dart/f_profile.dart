@@ -0,0 +1,615 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:typed_data' show Uint8List;
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:constraints/constraints.dart' as t_constraints;
+
+class Profile implements thrift.TBase {
+  static final thrift.TStruct _STRUCT_DESC = new thrift.TStruct("Profile");
+  static final thrift.TField _USERNAME_FIELD_DESC = new thrift.TField("username", thrift.TType.STRING, 1);
+  static final thrift.TField _AGE_FIELD_DESC = new thrift.TField("age", thrift.TType.I32, 2);
+  static final thrift.TField _SCORE_FIELD_DESC = new thrift.TField("score", thrift.TType.I64, 3);
+  static final thrift.TField _RATING_FIELD_DESC = new thrift.TField("rating", thrift.TType.DOUBLE, 4);
+  static final thrift.TField _BIO_FIELD_DESC = new thrift.TField("bio", thrift.TType.STRING, 5);
+  static final thrift.TField _TAGS_FIELD_DESC = new thrift.TField("tags", thrift.TType.LIST, 6);
+  static final thrift.TField _AVATAR_FIELD_DESC = new thrift.TField("avatar", thrift.TType.STRING, 7);
+  static final thrift.TField _LINKS_FIELD_DESC = new thrift.TField("links", thrift.TType.MAP, 8);
+  static final thrift.TField _NICKNAME_FIELD_DESC = new thrift.TField("nickname", thrift.TType.STRING, 9);
+
+  String _username;
+  static const int USERNAME = 1;
+  int _age = 0;
+  static const int AGE = 2;
+  int _score;
+  static const int SCORE = 3;
+  double _rating = 0.0;
+  static const int RATING = 4;
+  String _bio;
+  static const int BIO = 5;
+  List<String> _tags;
+  static const int TAGS = 6;
+  Uint8List _avatar;
+  static const int AVATAR = 7;
+  Map<String, String> _links;
+  static const int LINKS = 8;
+  String _nickname;
+  static const int NICKNAME = 9;
+
+  bool __isset_age = false;
+  bool __isset_score = false;
+  bool __isset_rating = false;
+
+  Profile() {
+  }
+
+  String get username => this._username;
+
+  set username(String username) {
+    this._username = username;
+  }
+
+  bool isSetUsername() => this.username != null;
+
+  unsetUsername() {
+    this.username = null;
+  }
+
+  int get age => this._age;
+
+  set age(int age) {
+    this._age = age;
+    this.__isset_age = true;
+  }
+
+  bool isSetAge() => this.__isset_age;
+
+  unsetAge() {
+    this.__isset_age = false;
+  }
+
+  int get score => this._score;
+
+  set score(int score) {
+    this._score = score;
+    this.__isset_score = true;
+  }
+
+  bool isSetScore() => this.__isset_score;
+
+  unsetScore() {
+    this.__isset_score = false;
+  }
+
+  double get rating => this._rating;
+
+  set rating(double rating) {
+    this._rating = rating;
+    this.__isset_rating = true;
+  }
+
+  bool isSetRating() => this.__isset_rating;
+
+  unsetRating() {
+    this.__isset_rating = false;
+  }
+
+  String get bio => this._bio;
+
+  set bio(String bio) {
+    this._bio = bio;
+  }
+
+  bool isSetBio() => this.bio != null;
+
+  unsetBio() {
+    this.bio = null;
+  }
+
+  List<String> get tags => this._tags;
+
+  set tags(List<String> tags) {
+    this._tags = tags;
+  }
+
+  bool isSetTags() => this.tags != null;
+
+  unsetTags() {
+    this.tags = null;
+  }
+
+  Uint8List get avatar => this._avatar;
+
+  set avatar(Uint8List avatar) {
+    this._avatar = avatar;
+  }
+
+  bool isSetAvatar() => this.avatar != null;
+
+  unsetAvatar() {
+    this.avatar = null;
+  }
+
+  Map<String, String> get links => this._links;
+
+  set links(Map<String, String> links) {
+    this._links = links;
+  }
+
+  bool isSetLinks() => this.links != null;
+
+  unsetLinks() {
+    this.links = null;
+  }
+
+  String get nickname => this._nickname;
+
+  set nickname(String nickname) {
+    this._nickname = nickname;
+  }
+
+  bool isSetNickname() => this.nickname != null;
+
+  unsetNickname() {
+    this.nickname = null;
+  }
+
+  getFieldValue(int fieldID) {
+    switch (fieldID) {
+      case USERNAME:
+        return this.username;
+      case AGE:
+        return this.age;
+      case SCORE:
+        return this.score;
+      case RATING:
+        return this.rating;
+      case BIO:
+        return this.bio;
+      case TAGS:
+        return this.tags;
+      case AVATAR:
+        return this.avatar;
+      case LINKS:
+        return this.links;
+      case NICKNAME:
+        return this.nickname;
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  setFieldValue(int fieldID, Object value) {
+    switch(fieldID) {
+      case USERNAME:
+        if(value == null) {
+          unsetUsername();
+        } else {
+          this.username = value as String;
+        }
+        break;
+
+      case AGE:
+        if(value == null) {
+          unsetAge();
+        } else {
+          this.age = value as int;
+        }
+        break;
+
+      case SCORE:
+        if(value == null) {
+          unsetScore();
+        } else {
+          this.score = value as int;
+        }
+        break;
+
+      case RATING:
+        if(value == null) {
+          unsetRating();
+        } else {
+          this.rating = value as double;
+        }
+        break;
+
+      case BIO:
+        if(value == null) {
+          unsetBio();
+        } else {
+          this.bio = value as String;
+        }
+        break;
+
+      case TAGS:
+        if(value == null) {
+          unsetTags();
+        } else {
+          this.tags = value as List<String>;
+        }
+        break;
+
+      case AVATAR:
+        if(value == null) {
+          unsetAvatar();
+        } else {
+          this.avatar = value as Uint8List;
+        }
+        break;
+
+      case LINKS:
+        if(value == null) {
+          unsetLinks();
+        } else {
+          this.links = value as Map<String, String>;
+        }
+        break;
+
+      case NICKNAME:
+        if(value == null) {
+          unsetNickname();
+        } else {
+          this.nickname = value as String;
+        }
+        break;
+
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  // Returns true if the field corresponding to fieldID is set (has been assigned a value) and false otherwise
+  bool isSet(int fieldID) {
+    switch(fieldID) {
+      case USERNAME:
+        return isSetUsername();
+      case AGE:
+        return isSetAge();
+      case SCORE:
+        return isSetScore();
+      case RATING:
+        return isSetRating();
+      case BIO:
+        return isSetBio();
+      case TAGS:
+        return isSetTags();
+      case AVATAR:
+        return isSetAvatar();
+      case LINKS:
+        return isSetLinks();
+      case NICKNAME:
+        return isSetNickname();
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  read(thrift.TProtocol iprot) {
+    thrift.TField field;
+    iprot.readStructBegin();
+    while(true) {
+      field = iprot.readFieldBegin();
+      if(field.type == thrift.TType.STOP) {
+        break;
+      }
+      switch(field.id) {
+        case USERNAME:
+          if(field.type == thrift.TType.STRING) {
+            username = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case AGE:
+          if(field.type == thrift.TType.I32) {
+            age = iprot.readI32();
+            this.__isset_age = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case SCORE:
+          if(field.type == thrift.TType.I64) {
+            score = iprot.readI64();
+            this.__isset_score = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case RATING:
+          if(field.type == thrift.TType.DOUBLE) {
+            rating = iprot.readDouble();
+            this.__isset_rating = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case BIO:
+          if(field.type == thrift.TType.STRING) {
+            bio = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case TAGS:
+          if(field.type == thrift.TType.LIST) {
+            thrift.TList elem0 = iprot.readListBegin();
+            tags = new List<String>();
+            for(int elem2 = 0; elem2 < elem0.length; ++elem2) {
+              String elem1 = iprot.readString();
+              tags.add(elem1);
+            }
+            iprot.readListEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case AVATAR:
+          if(field.type == thrift.TType.STRING) {
+            avatar = iprot.readBinary();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case LINKS:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem3 = iprot.readMapBegin();
+            links = new Map<String, String>();
+            for(int elem5 = 0; elem5 < elem3.length; ++elem5) {
+              String elem6 = iprot.readString();
+              String elem4 = iprot.readString();
+              links[elem6] = elem4;
+            }
+            iprot.readMapEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case NICKNAME:
+          if(field.type == thrift.TType.STRING) {
+            nickname = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, field.type);
+          break;
+      }
+      iprot.readFieldEnd();
+    }
+    iprot.readStructEnd();
+
+    // check for required fields of primitive type, which can't be checked in the validate method
+    validate();
+  }
+
+  write(thrift.TProtocol oprot) {
+    validate();
+
+    oprot.writeStructBegin(_STRUCT_DESC);
+    if(this.username != null) {
+      oprot.writeFieldBegin(_USERNAME_FIELD_DESC);
+      oprot.writeString(username);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldBegin(_AGE_FIELD_DESC);
+    oprot.writeI32(age);
+    oprot.writeFieldEnd();
+    if(isSetScore()) {
+      oprot.writeFieldBegin(_SCORE_FIELD_DESC);
+      oprot.writeI64(score);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldBegin(_RATING_FIELD_DESC);
+    oprot.writeDouble(rating);
+    oprot.writeFieldEnd();
+    if(isSetBio() && this.bio != null) {
+      oprot.writeFieldBegin(_BIO_FIELD_DESC);
+      oprot.writeString(bio);
+      oprot.writeFieldEnd();
+    }
+    if(this.tags != null) {
+      oprot.writeFieldBegin(_TAGS_FIELD_DESC);
+      oprot.writeListBegin(new thrift.TList(thrift.TType.STRING, tags.length));
+      for(var elem7 in tags) {
+        oprot.writeString(elem7);
+      }
+      oprot.writeListEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.avatar != null) {
+      oprot.writeFieldBegin(_AVATAR_FIELD_DESC);
+      oprot.writeBinary(avatar);
+      oprot.writeFieldEnd();
+    }
+    if(isSetLinks() && this.links != null) {
+      oprot.writeFieldBegin(_LINKS_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.STRING, thrift.TType.STRING, links.length));
+      for(var elem8 in links.keys) {
+        oprot.writeString(elem8);
+        oprot.writeString(links[elem8]);
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.nickname != null) {
+      oprot.writeFieldBegin(_NICKNAME_FIELD_DESC);
+      oprot.writeString(nickname);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldStop();
+    oprot.writeStructEnd();
+  }
+
+  String toString() {
+    StringBuffer ret = new StringBuffer("Profile(");
+
+    ret.write("username:");
+    if(this.username == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.username);
+    }
+
+    ret.write(", ");
+    ret.write("age:");
+    ret.write(this.age);
+
+    if(isSetScore()) {
+      ret.write(", ");
+      ret.write("score:");
+      ret.write(this.score);
+    }
+
+    ret.write(", ");
+    ret.write("rating:");
+    ret.write(this.rating);
+
+    if(isSetBio()) {
+      ret.write(", ");
+      ret.write("bio:");
+      if(this.bio == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.bio);
+      }
+    }
+
+    ret.write(", ");
+    ret.write("tags:");
+    if(this.tags == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.tags);
+    }
+
+    ret.write(", ");
+    ret.write("avatar:");
+    if(this.avatar == null) {
+      ret.write("null");
+    } else {
+      ret.write("BINARY");
+    }
+
+    if(isSetLinks()) {
+      ret.write(", ");
+      ret.write("links:");
+      if(this.links == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.links);
+      }
+    }
+
+    ret.write(", ");
+    ret.write("nickname:");
+    if(this.nickname == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.nickname);
+    }
+
+    ret.write(")");
+
+    return ret.toString();
+  }
+
+  bool operator ==(Object o) {
+    if(o == null || !(o is Profile)) {
+      return false;
+    }
+    Profile other = o as Profile;
+    return this.username == other.username
+      && this.age == other.age
+      && this.score == other.score
+      && this.rating == other.rating
+      && this.bio == other.bio
+      && this.tags == other.tags
+      && this.avatar == other.avatar
+      && this.links == other.links
+      && this.nickname == other.nickname;
+  }
+
+  int get hashCode {
+    var value = 17;
+    value = (value * 31) ^ username.hashCode;
+    value = (value * 31) ^ age.hashCode;
+    value = (value * 31) ^ score.hashCode;
+    value = (value * 31) ^ rating.hashCode;
+    value = (value * 31) ^ bio.hashCode;
+    value = (value * 31) ^ tags.hashCode;
+    value = (value * 31) ^ avatar.hashCode;
+    value = (value * 31) ^ links.hashCode;
+    value = (value * 31) ^ nickname.hashCode;
+    return value;
+  }
+
+  Profile clone({
+    String username: null,
+    int age: null,
+    int score: null,
+    double rating: null,
+    String bio: null,
+    List<String> tags: null,
+    Uint8List avatar: null,
+    Map<String, String> links: null,
+    String nickname: null,
+  }) {
+    return new Profile()
+      ..username = username ?? this.username
+      ..age = age ?? this.age
+      ..score = score ?? this.score
+      ..rating = rating ?? this.rating
+      ..bio = bio ?? this.bio
+      ..tags = tags ?? this.tags
+      ..avatar = avatar ?? this.avatar
+      ..links = links ?? this.links
+      ..nickname = nickname ?? this.nickname;
+  }
+
+  validate() {
+    // check for required fields
+    // check field constraints
+    if(isSetUsername() && username.runes.length < 3) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.username length must be at least 3");
+    }
+    if(isSetUsername() && username.runes.length > 16) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.username length must be at most 16");
+    }
+    if(isSetUsername() && !new RegExp("^[a-z][a-z0-9_]*\$").hasMatch(username)) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.username must match ^[a-z][a-z0-9_]*\$");
+    }
+    if(isSetAge() && age < 0) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.age must be at least 0");
+    }
+    if(isSetAge() && age > 150) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.age must be at most 150");
+    }
+    if(isSetScore() && score < -1000) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.score must be at least -1000");
+    }
+    if(isSetScore() && score > 1000000) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.score must be at most 1000000");
+    }
+    if(isSetRating() && rating < 0) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.rating must be at least 0");
+    }
+    if(isSetRating() && rating > 5.5) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.rating must be at most 5.5");
+    }
+    if(isSetBio() && bio.runes.length > 280) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.bio length must be at most 280");
+    }
+    if(isSetTags() && tags.length > 10) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.tags length must be at most 10");
+    }
+    if(isSetAvatar() && avatar.length > 65536) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.avatar length must be at most 65536");
+    }
+    if(isSetLinks() && links.length < 1) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Profile.links length must be at least 1");
+    }
+    // check that fields of type enum have valid values
+  }
+}
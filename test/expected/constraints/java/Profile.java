@@ -0,0 +1,1352 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+package constraints.java;
+
+import org.apache.thrift.scheme.IScheme;
+import org.apache.thrift.scheme.SchemeFactory;
+import org.apache.thrift.scheme.StandardScheme;
+
+import org.apache.thrift.scheme.TupleScheme;
+import org.apache.thrift.protocol.TTupleProtocol;
+import org.apache.thrift.protocol.TProtocolException;
+import org.apache.thrift.EncodingUtils;
+import org.apache.thrift.TException;
+import org.apache.thrift.async.AsyncMethodCallback;
+import org.apache.thrift.server.AbstractNonblockingServer.*;
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import javax.annotation.Generated;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+@Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
+public class Profile implements org.apache.thrift.TBase<Profile, Profile._Fields>, java.io.Serializable, Cloneable, Comparable<Profile> {
+	private static final org.apache.thrift.protocol.TStruct STRUCT_DESC = new org.apache.thrift.protocol.TStruct("Profile");
+
+	private static final org.apache.thrift.protocol.TField USERNAME_FIELD_DESC = new org.apache.thrift.protocol.TField("username", org.apache.thrift.protocol.TType.STRING, (short)1);
+	private static final org.apache.thrift.protocol.TField AGE_FIELD_DESC = new org.apache.thrift.protocol.TField("age", org.apache.thrift.protocol.TType.I32, (short)2);
+	private static final org.apache.thrift.protocol.TField SCORE_FIELD_DESC = new org.apache.thrift.protocol.TField("score", org.apache.thrift.protocol.TType.I64, (short)3);
+	private static final org.apache.thrift.protocol.TField RATING_FIELD_DESC = new org.apache.thrift.protocol.TField("rating", org.apache.thrift.protocol.TType.DOUBLE, (short)4);
+	private static final org.apache.thrift.protocol.TField BIO_FIELD_DESC = new org.apache.thrift.protocol.TField("bio", org.apache.thrift.protocol.TType.STRING, (short)5);
+	private static final org.apache.thrift.protocol.TField TAGS_FIELD_DESC = new org.apache.thrift.protocol.TField("tags", org.apache.thrift.protocol.TType.LIST, (short)6);
+	private static final org.apache.thrift.protocol.TField AVATAR_FIELD_DESC = new org.apache.thrift.protocol.TField("avatar", org.apache.thrift.protocol.TType.STRING, (short)7);
+	private static final org.apache.thrift.protocol.TField LINKS_FIELD_DESC = new org.apache.thrift.protocol.TField("links", org.apache.thrift.protocol.TType.MAP, (short)8);
+	private static final org.apache.thrift.protocol.TField NICKNAME_FIELD_DESC = new org.apache.thrift.protocol.TField("nickname", org.apache.thrift.protocol.TType.STRING, (short)9);
+
+	private static final Map<Class<? extends IScheme>, SchemeFactory> schemes = new HashMap<Class<? extends IScheme>, SchemeFactory>();
+	static {
+		schemes.put(StandardScheme.class, new ProfileStandardSchemeFactory());
+		schemes.put(TupleScheme.class, new ProfileTupleSchemeFactory());
+	}
+
+	public String username;
+	public int age;
+	public long score; // optional
+	public double rating;
+	public String bio; // optional
+	public java.util.List<String> tags;
+	public java.nio.ByteBuffer avatar;
+	public java.util.Map<String, String> links; // optional
+	public String nickname;
+	/** The set of fields this struct contains, along with convenience methods for finding and manipulating them. */
+	public enum _Fields implements org.apache.thrift.TFieldIdEnum {
+		USERNAME((short)1, "username"),
+		AGE((short)2, "age"),
+		SCORE((short)3, "score"),
+		RATING((short)4, "rating"),
+		BIO((short)5, "bio"),
+		TAGS((short)6, "tags"),
+		AVATAR((short)7, "avatar"),
+		LINKS((short)8, "links"),
+		NICKNAME((short)9, "nickname")
+		;
+
+		private static final Map<String, _Fields> byName = new HashMap<String, _Fields>();
+
+		static {
+			for (_Fields field : EnumSet.allOf(_Fields.class)) {
+				byName.put(field.getFieldName(), field);
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, or null if its not found.
+		 */
+		public static _Fields findByThriftId(int fieldId) {
+			switch(fieldId) {
+				case 1: // USERNAME
+					return USERNAME;
+				case 2: // AGE
+					return AGE;
+				case 3: // SCORE
+					return SCORE;
+				case 4: // RATING
+					return RATING;
+				case 5: // BIO
+					return BIO;
+				case 6: // TAGS
+					return TAGS;
+				case 7: // AVATAR
+					return AVATAR;
+				case 8: // LINKS
+					return LINKS;
+				case 9: // NICKNAME
+					return NICKNAME;
+				default:
+					return null;
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, throwing an exception
+		 * if it is not found.
+		 */
+		public static _Fields findByThriftIdOrThrow(int fieldId) {
+			_Fields fields = findByThriftId(fieldId);
+			if (fields == null) throw new IllegalArgumentException("Field " + fieldId + " doesn't exist!");
+			return fields;
+		}
+
+		/**
+		 * Find the _Fields constant that matches name, or null if its not found.
+		 */
+		public static _Fields findByName(String name) {
+			return byName.get(name);
+		}
+
+		private final short _thriftId;
+		private final String _fieldName;
+
+		_Fields(short thriftId, String fieldName) {
+			_thriftId = thriftId;
+			_fieldName = fieldName;
+		}
+
+		public short getThriftFieldId() {
+			return _thriftId;
+		}
+
+		public String getFieldName() {
+			return _fieldName;
+		}
+	}
+
+	// isset id assignments
+	private static final int __AGE_ISSET_ID = 0;
+	private static final int __SCORE_ISSET_ID = 1;
+	private static final int __RATING_ISSET_ID = 2;
+	private byte __isset_bitfield = 0;
+	public Profile() {
+	}
+
+	public Profile(
+		String username,
+		int age,
+		double rating,
+		java.util.List<String> tags,
+		java.nio.ByteBuffer avatar,
+		String nickname) {
+		this();
+		this.username = username;
+		this.age = age;
+		setAgeIsSet(true);
+		this.rating = rating;
+		setRatingIsSet(true);
+		this.tags = tags;
+		this.avatar = org.apache.thrift.TBaseHelper.copyBinary(avatar);
+		this.nickname = nickname;
+	}
+
+	/**
+	 * Performs a deep copy on <i>other</i>.
+	 */
+	public Profile(Profile other) {
+		__isset_bitfield = other.__isset_bitfield;
+		if (other.isSetUsername()) {
+			this.username = other.username;
+		}
+		this.age = other.age;
+		this.score = other.score;
+		this.rating = other.rating;
+		if (other.isSetBio()) {
+			this.bio = other.bio;
+		}
+		if (other.isSetTags()) {
+			this.tags = new ArrayList<String>(other.tags.size());
+			for (String elem0 : other.tags) {
+				String elem1 = elem0;
+				this.tags.add(elem1);
+			}
+		}
+		if (other.isSetAvatar()) {
+			this.avatar = org.apache.thrift.TBaseHelper.copyBinary(other.avatar);
+		}
+		if (other.isSetLinks()) {
+			this.links = new HashMap<String,String>(other.links);
+		}
+		if (other.isSetNickname()) {
+			this.nickname = other.nickname;
+		}
+	}
+
+	public Profile deepCopy() {
+		return new Profile(this);
+	}
+
+	@Override
+	public void clear() {
+		this.username = null;
+
+		setAgeIsSet(false);
+		this.age = 0;
+
+		setScoreIsSet(false);
+		this.score = 0L;
+
+		setRatingIsSet(false);
+		this.rating = 0.0;
+
+		this.bio = null;
+
+		this.tags = null;
+
+		this.avatar = null;
+
+		this.links = null;
+
+		this.nickname = null;
+
+	}
+
+	public String getUsername() {
+		return this.username;
+	}
+
+	public Profile setUsername(String username) {
+		this.username = username;
+		return this;
+	}
+
+	public void unsetUsername() {
+		this.username = null;
+	}
+
+	/** Returns true if field username is set (has been assigned a value) and false otherwise */
+	public boolean isSetUsername() {
+		return this.username != null;
+	}
+
+	public void setUsernameIsSet(boolean value) {
+		if (!value) {
+			this.username = null;
+		}
+	}
+
+	public int getAge() {
+		return this.age;
+	}
+
+	public Profile setAge(int age) {
+		this.age = age;
+		setAgeIsSet(true);
+		return this;
+	}
+
+	public void unsetAge() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __AGE_ISSET_ID);
+	}
+
+	/** Returns true if field age is set (has been assigned a value) and false otherwise */
+	public boolean isSetAge() {
+		return EncodingUtils.testBit(__isset_bitfield, __AGE_ISSET_ID);
+	}
+
+	public void setAgeIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __AGE_ISSET_ID, value);
+	}
+
+	public long getScore() {
+		return this.score;
+	}
+
+	public Profile setScore(long score) {
+		this.score = score;
+		setScoreIsSet(true);
+		return this;
+	}
+
+	public void unsetScore() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __SCORE_ISSET_ID);
+	}
+
+	/** Returns true if field score is set (has been assigned a value) and false otherwise */
+	public boolean isSetScore() {
+		return EncodingUtils.testBit(__isset_bitfield, __SCORE_ISSET_ID);
+	}
+
+	public void setScoreIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __SCORE_ISSET_ID, value);
+	}
+
+	public double getRating() {
+		return this.rating;
+	}
+
+	public Profile setRating(double rating) {
+		this.rating = rating;
+		setRatingIsSet(true);
+		return this;
+	}
+
+	public void unsetRating() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __RATING_ISSET_ID);
+	}
+
+	/** Returns true if field rating is set (has been assigned a value) and false otherwise */
+	public boolean isSetRating() {
+		return EncodingUtils.testBit(__isset_bitfield, __RATING_ISSET_ID);
+	}
+
+	public void setRatingIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __RATING_ISSET_ID, value);
+	}
+
+	public String getBio() {
+		return this.bio;
+	}
+
+	public Profile setBio(String bio) {
+		this.bio = bio;
+		return this;
+	}
+
+	public void unsetBio() {
+		this.bio = null;
+	}
+
+	/** Returns true if field bio is set (has been assigned a value) and false otherwise */
+	public boolean isSetBio() {
+		return this.bio != null;
+	}
+
+	public void setBioIsSet(boolean value) {
+		if (!value) {
+			this.bio = null;
+		}
+	}
+
+	public int getTagsSize() {
+		return (this.tags == null) ? 0 : this.tags.size();
+	}
+
+	public java.util.Iterator<String> getTagsIterator() {
+		return (this.tags == null) ? null : this.tags.iterator();
+	}
+
+	public void addToTags(String elem) {
+		if (this.tags == null) {
+			this.tags = new ArrayList<String>();
+		}
+		this.tags.add(elem);
+	}
+
+	public java.util.List<String> getTags() {
+		return this.tags;
+	}
+
+	public Profile setTags(java.util.List<String> tags) {
+		this.tags = tags;
+		return this;
+	}
+
+	public void unsetTags() {
+		this.tags = null;
+	}
+
+	/** Returns true if field tags is set (has been assigned a value) and false otherwise */
+	public boolean isSetTags() {
+		return this.tags != null;
+	}
+
+	public void setTagsIsSet(boolean value) {
+		if (!value) {
+			this.tags = null;
+		}
+	}
+
+	public byte[] getAvatar() {
+		setAvatar(org.apache.thrift.TBaseHelper.rightSize(avatar));
+		return avatar == null ? null : avatar.array();
+	}
+
+	public java.nio.ByteBuffer bufferForAvatar() {
+		return org.apache.thrift.TBaseHelper.copyBinary(avatar);
+	}
+
+	public Profile setAvatar(byte[] avatar) {
+		this.avatar = avatar == null ? (java.nio.ByteBuffer)null : java.nio.ByteBuffer.wrap(Arrays.copyOf(avatar, avatar.length));
+		return this;
+	}
+
+	public Profile setAvatar(java.nio.ByteBuffer avatar) {
+		this.avatar = org.apache.thrift.TBaseHelper.copyBinary(avatar);
+		return this;
+	}
+
+	public void unsetAvatar() {
+		this.avatar = null;
+	}
+
+	/** Returns true if field avatar is set (has been assigned a value) and false otherwise */
+	public boolean isSetAvatar() {
+		return this.avatar != null;
+	}
+
+	public void setAvatarIsSet(boolean value) {
+		if (!value) {
+			this.avatar = null;
+		}
+	}
+
+	public int getLinksSize() {
+		return (this.links == null) ? 0 : this.links.size();
+	}
+
+	public void putToLinks(String key, String val) {
+		if (this.links == null) {
+			this.links = new HashMap<String,String>();
+		}
+		this.links.put(key, val);
+	}
+
+	public java.util.Map<String, String> getLinks() {
+		return this.links;
+	}
+
+	public Profile setLinks(java.util.Map<String, String> links) {
+		this.links = links;
+		return this;
+	}
+
+	public void unsetLinks() {
+		this.links = null;
+	}
+
+	/** Returns true if field links is set (has been assigned a value) and false otherwise */
+	public boolean isSetLinks() {
+		return this.links != null;
+	}
+
+	public void setLinksIsSet(boolean value) {
+		if (!value) {
+			this.links = null;
+		}
+	}
+
+	public String getNickname() {
+		return this.nickname;
+	}
+
+	public Profile setNickname(String nickname) {
+		this.nickname = nickname;
+		return this;
+	}
+
+	public void unsetNickname() {
+		this.nickname = null;
+	}
+
+	/** Returns true if field nickname is set (has been assigned a value) and false otherwise */
+	public boolean isSetNickname() {
+		return this.nickname != null;
+	}
+
+	public void setNicknameIsSet(boolean value) {
+		if (!value) {
+			this.nickname = null;
+		}
+	}
+
+	public void setFieldValue(_Fields field, Object value) {
+		switch (field) {
+		case USERNAME:
+			if (value == null) {
+				unsetUsername();
+			} else {
+				setUsername((String)value);
+			}
+			break;
+
+		case AGE:
+			if (value == null) {
+				unsetAge();
+			} else {
+				setAge((Integer)value);
+			}
+			break;
+
+		case SCORE:
+			if (value == null) {
+				unsetScore();
+			} else {
+				setScore((Long)value);
+			}
+			break;
+
+		case RATING:
+			if (value == null) {
+				unsetRating();
+			} else {
+				setRating((Double)value);
+			}
+			break;
+
+		case BIO:
+			if (value == null) {
+				unsetBio();
+			} else {
+				setBio((String)value);
+			}
+			break;
+
+		case TAGS:
+			if (value == null) {
+				unsetTags();
+			} else {
+				setTags((java.util.List<String>)value);
+			}
+			break;
+
+		case AVATAR:
+			if (value == null) {
+				unsetAvatar();
+			} else {
+				setAvatar((java.nio.ByteBuffer)value);
+			}
+			break;
+
+		case LINKS:
+			if (value == null) {
+				unsetLinks();
+			} else {
+				setLinks((java.util.Map<String, String>)value);
+			}
+			break;
+
+		case NICKNAME:
+			if (value == null) {
+				unsetNickname();
+			} else {
+				setNickname((String)value);
+			}
+			break;
+
+		}
+	}
+
+	public Object getFieldValue(_Fields field) {
+		switch (field) {
+		case USERNAME:
+			return getUsername();
+
+		case AGE:
+			return getAge();
+
+		case SCORE:
+			return getScore();
+
+		case RATING:
+			return getRating();
+
+		case BIO:
+			return getBio();
+
+		case TAGS:
+			return getTags();
+
+		case AVATAR:
+			return getAvatar();
+
+		case LINKS:
+			return getLinks();
+
+		case NICKNAME:
+			return getNickname();
+
+		}
+		throw new IllegalStateException();
+	}
+
+	/** Returns true if field corresponding to fieldID is set (has been assigned a value) and false otherwise */
+	public boolean isSet(_Fields field) {
+		if (field == null) {
+			throw new IllegalArgumentException();
+		}
+
+		switch (field) {
+		case USERNAME:
+			return isSetUsername();
+		case AGE:
+			return isSetAge();
+		case SCORE:
+			return isSetScore();
+		case RATING:
+			return isSetRating();
+		case BIO:
+			return isSetBio();
+		case TAGS:
+			return isSetTags();
+		case AVATAR:
+			return isSetAvatar();
+		case LINKS:
+			return isSetLinks();
+		case NICKNAME:
+			return isSetNickname();
+		}
+		throw new IllegalStateException();
+	}
+
+	@Override
+	public boolean equals(Object that) {
+		if (that == null)
+			return false;
+		if (that instanceof Profile)
+			return this.equals((Profile)that);
+		return false;
+	}
+
+	public boolean equals(Profile that) {
+		if (that == null)
+			return false;
+
+		boolean this_present_username = true && this.isSetUsername();
+		boolean that_present_username = true && that.isSetUsername();
+		if (this_present_username || that_present_username) {
+			if (!(this_present_username && that_present_username))
+				return false;
+			if (!this.username.equals(that.username))
+				return false;
+		}
+
+		boolean this_present_age = true;
+		boolean that_present_age = true;
+		if (this_present_age || that_present_age) {
+			if (!(this_present_age && that_present_age))
+				return false;
+			if (this.age != that.age)
+				return false;
+		}
+
+		boolean this_present_score = true && this.isSetScore();
+		boolean that_present_score = true && that.isSetScore();
+		if (this_present_score || that_present_score) {
+			if (!(this_present_score && that_present_score))
+				return false;
+			if (this.score != that.score)
+				return false;
+		}
+
+		boolean this_present_rating = true;
+		boolean that_present_rating = true;
+		if (this_present_rating || that_present_rating) {
+			if (!(this_present_rating && that_present_rating))
+				return false;
+			if (this.rating != that.rating)
+				return false;
+		}
+
+		boolean this_present_bio = true && this.isSetBio();
+		boolean that_present_bio = true && that.isSetBio();
+		if (this_present_bio || that_present_bio) {
+			if (!(this_present_bio && that_present_bio))
+				return false;
+			if (!this.bio.equals(that.bio))
+				return false;
+		}
+
+		boolean this_present_tags = true && this.isSetTags();
+		boolean that_present_tags = true && that.isSetTags();
+		if (this_present_tags || that_present_tags) {
+			if (!(this_present_tags && that_present_tags))
+				return false;
+			if (!this.tags.equals(that.tags))
+				return false;
+		}
+
+		boolean this_present_avatar = true && this.isSetAvatar();
+		boolean that_present_avatar = true && that.isSetAvatar();
+		if (this_present_avatar || that_present_avatar) {
+			if (!(this_present_avatar && that_present_avatar))
+				return false;
+			if (!this.avatar.equals(that.avatar))
+				return false;
+		}
+
+		boolean this_present_links = true && this.isSetLinks();
+		boolean that_present_links = true && that.isSetLinks();
+		if (this_present_links || that_present_links) {
+			if (!(this_present_links && that_present_links))
+				return false;
+			if (!this.links.equals(that.links))
+				return false;
+		}
+
+		boolean this_present_nickname = true && this.isSetNickname();
+		boolean that_present_nickname = true && that.isSetNickname();
+		if (this_present_nickname || that_present_nickname) {
+			if (!(this_present_nickname && that_present_nickname))
+				return false;
+			if (!this.nickname.equals(that.nickname))
+				return false;
+		}
+
+		return true;
+	}
+
+	@Override
+	public int hashCode() {
+		List<Object> list = new ArrayList<Object>();
+
+		boolean present_username = true && (isSetUsername());
+		list.add(present_username);
+		if (present_username)
+			list.add(username);
+
+		boolean present_age = true;
+		list.add(present_age);
+		if (present_age)
+			list.add(age);
+
+		boolean present_score = true && (isSetScore());
+		list.add(present_score);
+		if (present_score)
+			list.add(score);
+
+		boolean present_rating = true;
+		list.add(present_rating);
+		if (present_rating)
+			list.add(rating);
+
+		boolean present_bio = true && (isSetBio());
+		list.add(present_bio);
+		if (present_bio)
+			list.add(bio);
+
+		boolean present_tags = true && (isSetTags());
+		list.add(present_tags);
+		if (present_tags)
+			list.add(tags);
+
+		boolean present_avatar = true && (isSetAvatar());
+		list.add(present_avatar);
+		if (present_avatar)
+			list.add(avatar);
+
+		boolean present_links = true && (isSetLinks());
+		list.add(present_links);
+		if (present_links)
+			list.add(links);
+
+		boolean present_nickname = true && (isSetNickname());
+		list.add(present_nickname);
+		if (present_nickname)
+			list.add(nickname);
+
+		return list.hashCode();
+	}
+
+	@Override
+	public int compareTo(Profile other) {
+		if (!getClass().equals(other.getClass())) {
+			return getClass().getName().compareTo(other.getClass().getName());
+		}
+
+		int lastComparison = 0;
+
+		lastComparison = Boolean.valueOf(isSetUsername()).compareTo(other.isSetUsername());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetUsername()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.username, other.username);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetAge()).compareTo(other.isSetAge());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetAge()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.age, other.age);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetScore()).compareTo(other.isSetScore());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetScore()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.score, other.score);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetRating()).compareTo(other.isSetRating());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetRating()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.rating, other.rating);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetBio()).compareTo(other.isSetBio());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetBio()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.bio, other.bio);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetTags()).compareTo(other.isSetTags());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetTags()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.tags, other.tags);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetAvatar()).compareTo(other.isSetAvatar());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetAvatar()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.avatar, other.avatar);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetLinks()).compareTo(other.isSetLinks());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetLinks()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.links, other.links);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetNickname()).compareTo(other.isSetNickname());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetNickname()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.nickname, other.nickname);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		return 0;
+	}
+
+	public _Fields fieldForId(int fieldId) {
+		return _Fields.findByThriftId(fieldId);
+	}
+
+	public void read(org.apache.thrift.protocol.TProtocol iprot) throws org.apache.thrift.TException {
+		schemes.get(iprot.getScheme()).getScheme().read(iprot, this);
+	}
+
+	public void write(org.apache.thrift.protocol.TProtocol oprot) throws org.apache.thrift.TException {
+		schemes.get(oprot.getScheme()).getScheme().write(oprot, this);
+	}
+
+	@Override
+	public String toString() {
+		StringBuilder sb = new StringBuilder("Profile(");
+		boolean first = true;
+
+		sb.append("username:");
+		if (this.username == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.username);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("age:");
+		sb.append(this.age);
+		first = false;
+		if (isSetScore()) {
+			if (!first) sb.append(", ");
+			sb.append("score:");
+			sb.append(this.score);
+			first = false;
+		}
+		if (!first) sb.append(", ");
+		sb.append("rating:");
+		sb.append(this.rating);
+		first = false;
+		if (isSetBio()) {
+			if (!first) sb.append(", ");
+			sb.append("bio:");
+			if (this.bio == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.bio);
+			}
+			first = false;
+		}
+		if (!first) sb.append(", ");
+		sb.append("tags:");
+		if (this.tags == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.tags);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("avatar:");
+		if (this.avatar == null) {
+			sb.append("null");
+		} else {
+			org.apache.thrift.TBaseHelper.toString(this.avatar, sb);
+		}
+		first = false;
+		if (isSetLinks()) {
+			if (!first) sb.append(", ");
+			sb.append("links:");
+			if (this.links == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.links);
+			}
+			first = false;
+		}
+		if (!first) sb.append(", ");
+		sb.append("nickname:");
+		if (this.nickname == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.nickname);
+		}
+		first = false;
+		sb.append(")");
+		return sb.toString();
+	}
+
+	private static final java.util.regex.Pattern USERNAME_PATTERN = java.util.regex.Pattern.compile("^[a-z][a-z0-9_]*$");
+
+	public void validate() throws org.apache.thrift.TException {
+		// check for required fields
+		// check field constraints
+		if (isSetUsername() && username.codePointCount(0, username.length()) < 3) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.username length must be at least 3");
+		}
+		if (isSetUsername() && username.codePointCount(0, username.length()) > 16) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.username length must be at most 16");
+		}
+		if (isSetUsername() && !USERNAME_PATTERN.matcher(username).find()) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.username must match ^[a-z][a-z0-9_]*$");
+		}
+		if (isSetAge() && age < 0) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.age must be at least 0");
+		}
+		if (isSetAge() && age > 150) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.age must be at most 150");
+		}
+		if (isSetScore() && score < -1000L) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.score must be at least -1000");
+		}
+		if (isSetScore() && score > 1000000L) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.score must be at most 1000000");
+		}
+		if (isSetRating() && rating < 0) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.rating must be at least 0");
+		}
+		if (isSetRating() && rating > 5.5) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.rating must be at most 5.5");
+		}
+		if (isSetBio() && bio.codePointCount(0, bio.length()) > 280) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.bio length must be at most 280");
+		}
+		if (isSetTags() && tags.size() > 10) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.tags length must be at most 10");
+		}
+		if (isSetAvatar() && avatar.remaining() > 65536) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.avatar length must be at most 65536");
+		}
+		if (isSetLinks() && links.size() < 1) {
+			throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Profile.links length must be at least 1");
+		}
+		// check for sub-struct validity
+	}
+
+	private void writeObject(java.io.ObjectOutputStream out) throws java.io.IOException {
+		try {
+			write(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(out)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private void readObject(java.io.ObjectInputStream in) throws java.io.IOException, ClassNotFoundException {
+		try {
+			// it doesn't seem like you should have to do this, but java serialization is wacky, and doesn't call the default constructor.
+			__isset_bitfield = 0;
+			read(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(in)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private static class ProfileStandardSchemeFactory implements SchemeFactory {
+		public ProfileStandardScheme getScheme() {
+			return new ProfileStandardScheme();
+		}
+	}
+
+	private static class ProfileStandardScheme extends StandardScheme<Profile> {
+
+		public void read(org.apache.thrift.protocol.TProtocol iprot, Profile struct) throws org.apache.thrift.TException {
+			org.apache.thrift.protocol.TField schemeField;
+			iprot.readStructBegin();
+			while (true) {
+				schemeField = iprot.readFieldBegin();
+				if (schemeField.type == org.apache.thrift.protocol.TType.STOP) {
+					break;
+				}
+				switch (schemeField.id) {
+					case 1: // USERNAME
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.username = iprot.readString();
+							struct.setUsernameIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 2: // AGE
+						if (schemeField.type == org.apache.thrift.protocol.TType.I32) {
+							struct.age = iprot.readI32();
+							struct.setAgeIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 3: // SCORE
+						if (schemeField.type == org.apache.thrift.protocol.TType.I64) {
+							struct.score = iprot.readI64();
+							struct.setScoreIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 4: // RATING
+						if (schemeField.type == org.apache.thrift.protocol.TType.DOUBLE) {
+							struct.rating = iprot.readDouble();
+							struct.setRatingIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 5: // BIO
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.bio = iprot.readString();
+							struct.setBioIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 6: // TAGS
+						if (schemeField.type == org.apache.thrift.protocol.TType.LIST) {
+							org.apache.thrift.protocol.TList elem4 = iprot.readListBegin();
+							struct.tags = new ArrayList<String>(elem4.size);
+							for (int elem5 = 0; elem5 < elem4.size; ++elem5) {
+								String elem6 = iprot.readString();
+								struct.tags.add(elem6);
+							}
+							iprot.readListEnd();
+							struct.setTagsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 7: // AVATAR
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.avatar = iprot.readBinary();
+							struct.setAvatarIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 8: // LINKS
+						if (schemeField.type == org.apache.thrift.protocol.TType.MAP) {
+							org.apache.thrift.protocol.TMap elem7 = iprot.readMapBegin();
+							struct.links = new HashMap<String,String>(2*elem7.size);
+							for (int elem8 = 0; elem8 < elem7.size; ++elem8) {
+								String elem10 = iprot.readString();
+								String elem9 = iprot.readString();
+								struct.links.put(elem10, elem9);
+							}
+							iprot.readMapEnd();
+							struct.setLinksIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 9: // NICKNAME
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.nickname = iprot.readString();
+							struct.setNicknameIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					default:
+						org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+				}
+				iprot.readFieldEnd();
+			}
+			iprot.readStructEnd();
+
+			// check for required fields of primitive type, which can't be checked in the validate method
+			struct.validate();
+		}
+
+		public void write(org.apache.thrift.protocol.TProtocol oprot, Profile struct) throws org.apache.thrift.TException {
+			struct.validate();
+
+			oprot.writeStructBegin(STRUCT_DESC);
+			if (struct.username != null) {
+				oprot.writeFieldBegin(USERNAME_FIELD_DESC);
+				String elem11 = struct.username;
+				oprot.writeString(elem11);
+				oprot.writeFieldEnd();
+			}
+			oprot.writeFieldBegin(AGE_FIELD_DESC);
+			int elem12 = struct.age;
+			oprot.writeI32(elem12);
+			oprot.writeFieldEnd();
+			if (struct.isSetScore()) {
+				oprot.writeFieldBegin(SCORE_FIELD_DESC);
+				long elem13 = struct.score;
+				oprot.writeI64(elem13);
+				oprot.writeFieldEnd();
+			}
+			oprot.writeFieldBegin(RATING_FIELD_DESC);
+			double elem14 = struct.rating;
+			oprot.writeDouble(elem14);
+			oprot.writeFieldEnd();
+			if (struct.bio != null) {
+				if (struct.isSetBio()) {
+					oprot.writeFieldBegin(BIO_FIELD_DESC);
+					String elem15 = struct.bio;
+					oprot.writeString(elem15);
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.tags != null) {
+				oprot.writeFieldBegin(TAGS_FIELD_DESC);
+				oprot.writeListBegin(new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRING, struct.tags.size()));
+				for (String elem16 : struct.tags) {
+					String elem17 = elem16;
+					oprot.writeString(elem17);
+				}
+				oprot.writeListEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.avatar != null) {
+				oprot.writeFieldBegin(AVATAR_FIELD_DESC);
+				java.nio.ByteBuffer elem18 = struct.avatar;
+				oprot.writeBinary(elem18);
+				oprot.writeFieldEnd();
+			}
+			if (struct.links != null) {
+				if (struct.isSetLinks()) {
+					oprot.writeFieldBegin(LINKS_FIELD_DESC);
+					oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRING, org.apache.thrift.protocol.TType.STRING, struct.links.size()));
+					for (Map.Entry<String, String> elem19 : struct.links.entrySet()) {
+						String elem20 = elem19.getKey();
+						oprot.writeString(elem20);
+						String elem21 = elem19.getValue();
+						oprot.writeString(elem21);
+					}
+					oprot.writeMapEnd();
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.nickname != null) {
+				oprot.writeFieldBegin(NICKNAME_FIELD_DESC);
+				String elem22 = struct.nickname;
+				oprot.writeString(elem22);
+				oprot.writeFieldEnd();
+			}
+			oprot.writeFieldStop();
+			oprot.writeStructEnd();
+		}
+
+	}
+
+	private static class ProfileTupleSchemeFactory implements SchemeFactory {
+		public ProfileTupleScheme getScheme() {
+			return new ProfileTupleScheme();
+		}
+	}
+
+	private static class ProfileTupleScheme extends TupleScheme<Profile> {
+
+		@Override
+		public void write(org.apache.thrift.protocol.TProtocol prot, Profile struct) throws org.apache.thrift.TException {
+			TTupleProtocol oprot = (TTupleProtocol) prot;
+			BitSet optionals = new BitSet();
+			if (struct.isSetUsername()) {
+				optionals.set(0);
+			}
+			if (struct.isSetAge()) {
+				optionals.set(1);
+			}
+			if (struct.isSetScore()) {
+				optionals.set(2);
+			}
+			if (struct.isSetRating()) {
+				optionals.set(3);
+			}
+			if (struct.isSetBio()) {
+				optionals.set(4);
+			}
+			if (struct.isSetTags()) {
+				optionals.set(5);
+			}
+			if (struct.isSetAvatar()) {
+				optionals.set(6);
+			}
+			if (struct.isSetLinks()) {
+				optionals.set(7);
+			}
+			if (struct.isSetNickname()) {
+				optionals.set(8);
+			}
+			oprot.writeBitSet(optionals, 9);
+			if (struct.isSetUsername()) {
+				String elem23 = struct.username;
+				oprot.writeString(elem23);
+			}
+			if (struct.isSetAge()) {
+				int elem24 = struct.age;
+				oprot.writeI32(elem24);
+			}
+			if (struct.isSetScore()) {
+				long elem25 = struct.score;
+				oprot.writeI64(elem25);
+			}
+			if (struct.isSetRating()) {
+				double elem26 = struct.rating;
+				oprot.writeDouble(elem26);
+			}
+			if (struct.isSetBio()) {
+				String elem27 = struct.bio;
+				oprot.writeString(elem27);
+			}
+			if (struct.isSetTags()) {
+				oprot.writeI32(struct.tags.size());
+				for (String elem28 : struct.tags) {
+					String elem29 = elem28;
+					oprot.writeString(elem29);
+				}
+			}
+			if (struct.isSetAvatar()) {
+				java.nio.ByteBuffer elem30 = struct.avatar;
+				oprot.writeBinary(elem30);
+			}
+			if (struct.isSetLinks()) {
+				oprot.writeI32(struct.links.size());
+				for (Map.Entry<String, String> elem31 : struct.links.entrySet()) {
+					String elem32 = elem31.getKey();
+					oprot.writeString(elem32);
+					String elem33 = elem31.getValue();
+					oprot.writeString(elem33);
+				}
+			}
+			if (struct.isSetNickname()) {
+				String elem34 = struct.nickname;
+				oprot.writeString(elem34);
+			}
+		}
+
+		@Override
+		public void read(org.apache.thrift.protocol.TProtocol prot, Profile struct) throws org.apache.thrift.TException {
+			TTupleProtocol iprot = (TTupleProtocol) prot;
+			BitSet incoming = iprot.readBitSet(9);
+			if (incoming.get(0)) {
+				struct.username = iprot.readString();
+				struct.setUsernameIsSet(true);
+			}
+			if (incoming.get(1)) {
+				struct.age = iprot.readI32();
+				struct.setAgeIsSet(true);
+			}
+			if (incoming.get(2)) {
+				struct.score = iprot.readI64();
+				struct.setScoreIsSet(true);
+			}
+			if (incoming.get(3)) {
+				struct.rating = iprot.readDouble();
+				struct.setRatingIsSet(true);
+			}
+			if (incoming.get(4)) {
+				struct.bio = iprot.readString();
+				struct.setBioIsSet(true);
+			}
+			if (incoming.get(5)) {
+				org.apache.thrift.protocol.TList elem35 = new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRING, iprot.readI32());
+				struct.tags = new ArrayList<String>(elem35.size);
+				for (int elem36 = 0; elem36 < elem35.size; ++elem36) {
+					String elem37 = iprot.readString();
+					struct.tags.add(elem37);
+				}
+				struct.setTagsIsSet(true);
+			}
+			if (incoming.get(6)) {
+				struct.avatar = iprot.readBinary();
+				struct.setAvatarIsSet(true);
+			}
+			if (incoming.get(7)) {
+				org.apache.thrift.protocol.TMap elem38 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRING, org.apache.thrift.protocol.TType.STRING, iprot.readI32());
+				struct.links = new HashMap<String,String>(2*elem38.size);
+				for (int elem39 = 0; elem39 < elem38.size; ++elem39) {
+					String elem41 = iprot.readString();
+					String elem40 = iprot.readString();
+					struct.links.put(elem41, elem40);
+				}
+				struct.setLinksIsSet(true);
+			}
+			if (incoming.get(8)) {
+				struct.nickname = iprot.readString();
+				struct.setNicknameIsSet(true);
+			}
+		}
+
+	}
+
+}
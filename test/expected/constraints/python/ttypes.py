@@ -0,0 +1,374 @@
+#
+# Autogenerated by Frugal Compiler (2.23.0)
+#
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+#
+
+from thrift.Thrift import TType, TMessageType, TException, TApplicationException
+
+from frugal.util import make_hashable
+from thrift.transport import TTransport
+from thrift.protocol import TBinaryProtocol, TProtocol
+import re
+
+
+class Profile(object):
+    """
+    Attributes:
+     - username
+     - age
+     - score
+     - rating
+     - bio
+     - tags
+     - avatar
+     - links
+     - nickname
+    """
+    def __init__(self, username=None, age=None, score=None, rating=None, bio=None, tags=None, avatar=None, links=None, nickname=None):
+        self.username = username
+        self.age = age
+        self.score = score
+        self.rating = rating
+        self.bio = bio
+        self.tags = tags
+        self.avatar = avatar
+        self.links = links
+        self.nickname = nickname
+
+    def is_set_score(self):
+        return self.score is not None
+
+    def unset_score(self):
+        self.score = None
+
+    def is_set_bio(self):
+        return self.bio is not None
+
+    def unset_bio(self):
+        self.bio = None
+
+    def is_set_links(self):
+        return self.links is not None
+
+    def unset_links(self):
+        self.links = None
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.STRING:
+                    self.username = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 2:
+                if ftype == TType.I32:
+                    self.age = iprot.readI32()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 3:
+                if ftype == TType.I64:
+                    self.score = iprot.readI64()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 4:
+                if ftype == TType.DOUBLE:
+                    self.rating = iprot.readDouble()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 5:
+                if ftype == TType.STRING:
+                    self.bio = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 6:
+                if ftype == TType.LIST:
+                    self.tags = []
+                    (_, elem0) = iprot.readListBegin()
+                    for _ in range(elem0):
+                        elem1 = iprot.readString()
+                        self.tags.append(elem1)
+                    iprot.readListEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 7:
+                if ftype == TType.STRING:
+                    self.avatar = iprot.readBinary()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 8:
+                if ftype == TType.MAP:
+                    self.links = {}
+                    (_, _, elem2) = iprot.readMapBegin()
+                    for _ in range(elem2):
+                        elem4 = iprot.readString()
+                        elem3 = iprot.readString()
+                        self.links[elem4] = elem3
+                    iprot.readMapEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 9:
+                if ftype == TType.STRING:
+                    self.nickname = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('Profile')
+        if self.username is not None:
+            oprot.writeFieldBegin('username', TType.STRING, 1)
+            oprot.writeString(self.username)
+            oprot.writeFieldEnd()
+        if self.age is not None:
+            oprot.writeFieldBegin('age', TType.I32, 2)
+            oprot.writeI32(self.age)
+            oprot.writeFieldEnd()
+        if self.score is not None:
+            oprot.writeFieldBegin('score', TType.I64, 3)
+            oprot.writeI64(self.score)
+            oprot.writeFieldEnd()
+        if self.rating is not None:
+            oprot.writeFieldBegin('rating', TType.DOUBLE, 4)
+            oprot.writeDouble(self.rating)
+            oprot.writeFieldEnd()
+        if self.bio is not None:
+            oprot.writeFieldBegin('bio', TType.STRING, 5)
+            oprot.writeString(self.bio)
+            oprot.writeFieldEnd()
+        if self.tags is not None:
+            oprot.writeFieldBegin('tags', TType.LIST, 6)
+            oprot.writeListBegin(TType.STRING, len(self.tags))
+            for elem5 in self.tags:
+                oprot.writeString(elem5)
+            oprot.writeListEnd()
+            oprot.writeFieldEnd()
+        if self.avatar is not None:
+            oprot.writeFieldBegin('avatar', TType.STRING, 7)
+            oprot.writeBinary(self.avatar)
+            oprot.writeFieldEnd()
+        if self.links is not None:
+            oprot.writeFieldBegin('links', TType.MAP, 8)
+            oprot.writeMapBegin(TType.STRING, TType.STRING, len(self.links))
+            for elem7, elem6 in self.links.items():
+                oprot.writeString(elem7)
+                oprot.writeString(elem6)
+            oprot.writeMapEnd()
+            oprot.writeFieldEnd()
+        if self.nickname is not None:
+            oprot.writeFieldBegin('nickname', TType.STRING, 9)
+            oprot.writeString(self.nickname)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        if self.username is not None and len(self.username) < 3:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.username length must be at least 3")
+        if self.username is not None and len(self.username) > 16:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.username length must be at most 16")
+        if self.username is not None and re.search("^[a-z][a-z0-9_]*$", self.username) is None:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.username must match ^[a-z][a-z0-9_]*$")
+        if self.age is not None and self.age < 0:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.age must be at least 0")
+        if self.age is not None and self.age > 150:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.age must be at most 150")
+        if self.score is not None and self.score < -1000:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.score must be at least -1000")
+        if self.score is not None and self.score > 1000000:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.score must be at most 1000000")
+        if self.rating is not None and self.rating < 0:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.rating must be at least 0")
+        if self.rating is not None and self.rating > 5.5:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.rating must be at most 5.5")
+        if self.bio is not None and len(self.bio) > 280:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.bio length must be at most 280")
+        if self.tags is not None and len(self.tags) > 10:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.tags length must be at most 10")
+        if self.avatar is not None and len(self.avatar) > 65536:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.avatar length must be at most 65536")
+        if self.links is not None and len(self.links) < 1:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Profile.links length must be at least 1")
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.username))
+        value = (value * 31) ^ hash(make_hashable(self.age))
+        value = (value * 31) ^ hash(make_hashable(self.score))
+        value = (value * 31) ^ hash(make_hashable(self.rating))
+        value = (value * 31) ^ hash(make_hashable(self.bio))
+        value = (value * 31) ^ hash(make_hashable(self.tags))
+        value = (value * 31) ^ hash(make_hashable(self.avatar))
+        value = (value * 31) ^ hash(make_hashable(self.links))
+        value = (value * 31) ^ hash(make_hashable(self.nickname))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
+class Contact(object):
+    """
+    Attributes:
+     - email
+     - phone
+    """
+    def __init__(self, email=None, phone=None):
+        self.email = email
+        self.phone = phone
+
+    def is_set_email(self):
+        return self.email is not None
+
+    def unset_email(self):
+        self.email = None
+
+    def is_set_phone(self):
+        return self.phone is not None
+
+    def unset_phone(self):
+        self.phone = None
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.STRING:
+                    self.email = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 2:
+                if ftype == TType.STRING:
+                    self.phone = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('Contact')
+        if self.email is not None:
+            oprot.writeFieldBegin('email', TType.STRING, 1)
+            oprot.writeString(self.email)
+            oprot.writeFieldEnd()
+        if self.phone is not None:
+            oprot.writeFieldBegin('phone', TType.STRING, 2)
+            oprot.writeString(self.phone)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        set_fields = 0
+        if self.email is not None:
+            set_fields += 1
+        if self.phone is not None:
+            set_fields += 1
+        if set_fields != 1:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='The union did not have exactly one field set, {} were set'.format(set_fields))
+        if self.email is not None and re.search("@", self.email) is None:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Contact.email must match @")
+        if self.phone is not None and re.search("^\\+?[0-9]+$", self.phone) is None:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="Contact.phone must match ^\\+?[0-9]+$")
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.email))
+        value = (value * 31) ^ hash(make_hashable(self.phone))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
+class InvalidProfile(TException):
+    """
+    Attributes:
+     - message
+    """
+    def __init__(self, message=None):
+        self.message = message
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.STRING:
+                    self.message = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('InvalidProfile')
+        if self.message is not None:
+            oprot.writeFieldBegin('message', TType.STRING, 1)
+            oprot.writeString(self.message)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        if self.message is not None and len(self.message) < 1:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message="InvalidProfile.message length must be at least 1")
+        return
+
+    def __str__(self):
+        return repr(self)
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.message))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
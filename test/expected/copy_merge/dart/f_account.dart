@@ -0,0 +1,920 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:typed_data' show Uint8List;
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:copy_merge/copy_merge.dart' as t_copy_merge;
+
+class Account implements thrift.TBase {
+  static final thrift.TStruct _STRUCT_DESC = new thrift.TStruct("Account");
+  static final thrift.TField _ID_FIELD_DESC = new thrift.TField("id", thrift.TType.STRING, 1);
+  static final thrift.TField _BALANCE_FIELD_DESC = new thrift.TField("balance", thrift.TType.I64, 2);
+  static final thrift.TField _VERSION_FIELD_DESC = new thrift.TField("version", thrift.TType.I32, 3);
+  static final thrift.TField _OWNER_FIELD_DESC = new thrift.TField("owner", thrift.TType.STRING, 4);
+  static final thrift.TField _STATUS_FIELD_DESC = new thrift.TField("status", thrift.TType.I32, 5);
+  static final thrift.TField _ADDRESS_FIELD_DESC = new thrift.TField("address", thrift.TType.STRUCT, 6);
+  static final thrift.TField _TAGS_FIELD_DESC = new thrift.TField("tags", thrift.TType.LIST, 7);
+  static final thrift.TField _HISTORY_FIELD_DESC = new thrift.TField("history", thrift.TType.LIST, 8);
+  static final thrift.TField _AVATAR_FIELD_DESC = new thrift.TField("avatar", thrift.TType.STRING, 9);
+  static final thrift.TField _LEDGER_FIELD_DESC = new thrift.TField("ledger", thrift.TType.MAP, 10);
+  static final thrift.TField _ADDRESSES_FIELD_DESC = new thrift.TField("addresses", thrift.TType.SET, 11);
+  static final thrift.TField _LIMITS_FIELD_DESC = new thrift.TField("limits", thrift.TType.MAP, 12);
+  static final thrift.TField _PREVIOUS_FIELD_DESC = new thrift.TField("previous", thrift.TType.I32, 13);
+
+  String _id;
+  static const int ID = 1;
+  int _balance = 0;
+  static const int BALANCE = 2;
+  int _version;
+  static const int VERSION = 3;
+  String _owner;
+  static const int OWNER = 4;
+  int _status;
+  static const int STATUS = 5;
+  t_copy_merge.Address _address;
+  static const int ADDRESS = 6;
+  List<String> _tags;
+  static const int TAGS = 7;
+  List<t_copy_merge.Address> _history;
+  static const int HISTORY = 8;
+  Uint8List _avatar;
+  static const int AVATAR = 9;
+  Map<String, List<int>> _ledger;
+  static const int LEDGER = 10;
+  Set<t_copy_merge.Address> _addresses;
+  static const int ADDRESSES = 11;
+  Map<String, int> _limits;
+  static const int LIMITS = 12;
+  int _previous;
+  static const int PREVIOUS = 13;
+
+  bool __isset_balance = false;
+  bool __isset_version = false;
+  bool __isset_status = false;
+  bool __isset_previous = false;
+
+  Account() {
+    this.owner = "nobody";
+    this.limits = {
+      "daily": 100,
+    };
+  }
+
+  String get id => this._id;
+
+  set id(String id) {
+    this._id = id;
+  }
+
+  bool isSetId() => this.id != null;
+
+  unsetId() {
+    this.id = null;
+  }
+
+  int get balance => this._balance;
+
+  set balance(int balance) {
+    this._balance = balance;
+    this.__isset_balance = true;
+  }
+
+  bool isSetBalance() => this.__isset_balance;
+
+  unsetBalance() {
+    this.__isset_balance = false;
+  }
+
+  int get version => this._version;
+
+  set version(int version) {
+    this._version = version;
+    this.__isset_version = true;
+  }
+
+  bool isSetVersion() => this.__isset_version;
+
+  unsetVersion() {
+    this.__isset_version = false;
+  }
+
+  String get owner => this._owner;
+
+  set owner(String owner) {
+    this._owner = owner;
+  }
+
+  bool isSetOwner() => this.owner != null;
+
+  unsetOwner() {
+    this.owner = null;
+  }
+
+  int get status => this._status;
+
+  set status(int status) {
+    this._status = status;
+    this.__isset_status = true;
+  }
+
+  bool isSetStatus() => this.__isset_status;
+
+  unsetStatus() {
+    this.__isset_status = false;
+  }
+
+  t_copy_merge.Address get address => this._address;
+
+  set address(t_copy_merge.Address address) {
+    this._address = address;
+  }
+
+  bool isSetAddress() => this.address != null;
+
+  unsetAddress() {
+    this.address = null;
+  }
+
+  List<String> get tags => this._tags;
+
+  set tags(List<String> tags) {
+    this._tags = tags;
+  }
+
+  bool isSetTags() => this.tags != null;
+
+  unsetTags() {
+    this.tags = null;
+  }
+
+  List<t_copy_merge.Address> get history => this._history;
+
+  set history(List<t_copy_merge.Address> history) {
+    this._history = history;
+  }
+
+  bool isSetHistory() => this.history != null;
+
+  unsetHistory() {
+    this.history = null;
+  }
+
+  Uint8List get avatar => this._avatar;
+
+  set avatar(Uint8List avatar) {
+    this._avatar = avatar;
+  }
+
+  bool isSetAvatar() => this.avatar != null;
+
+  unsetAvatar() {
+    this.avatar = null;
+  }
+
+  Map<String, List<int>> get ledger => this._ledger;
+
+  set ledger(Map<String, List<int>> ledger) {
+    this._ledger = ledger;
+  }
+
+  bool isSetLedger() => this.ledger != null;
+
+  unsetLedger() {
+    this.ledger = null;
+  }
+
+  Set<t_copy_merge.Address> get addresses => this._addresses;
+
+  set addresses(Set<t_copy_merge.Address> addresses) {
+    this._addresses = addresses;
+  }
+
+  bool isSetAddresses() => this.addresses != null;
+
+  unsetAddresses() {
+    this.addresses = null;
+  }
+
+  Map<String, int> get limits => this._limits;
+
+  set limits(Map<String, int> limits) {
+    this._limits = limits;
+  }
+
+  bool isSetLimits() => this.limits != null;
+
+  unsetLimits() {
+    this.limits = null;
+  }
+
+  int get previous => this._previous;
+
+  set previous(int previous) {
+    this._previous = previous;
+    this.__isset_previous = true;
+  }
+
+  bool isSetPrevious() => this.__isset_previous;
+
+  unsetPrevious() {
+    this.__isset_previous = false;
+  }
+
+  getFieldValue(int fieldID) {
+    switch (fieldID) {
+      case ID:
+        return this.id;
+      case BALANCE:
+        return this.balance;
+      case VERSION:
+        return this.version;
+      case OWNER:
+        return this.owner;
+      case STATUS:
+        return this.status;
+      case ADDRESS:
+        return this.address;
+      case TAGS:
+        return this.tags;
+      case HISTORY:
+        return this.history;
+      case AVATAR:
+        return this.avatar;
+      case LEDGER:
+        return this.ledger;
+      case ADDRESSES:
+        return this.addresses;
+      case LIMITS:
+        return this.limits;
+      case PREVIOUS:
+        return this.previous;
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  setFieldValue(int fieldID, Object value) {
+    switch(fieldID) {
+      case ID:
+        if(value == null) {
+          unsetId();
+        } else {
+          this.id = value as String;
+        }
+        break;
+
+      case BALANCE:
+        if(value == null) {
+          unsetBalance();
+        } else {
+          this.balance = value as int;
+        }
+        break;
+
+      case VERSION:
+        if(value == null) {
+          unsetVersion();
+        } else {
+          this.version = value as int;
+        }
+        break;
+
+      case OWNER:
+        if(value == null) {
+          unsetOwner();
+        } else {
+          this.owner = value as String;
+        }
+        break;
+
+      case STATUS:
+        if(value == null) {
+          unsetStatus();
+        } else {
+          this.status = value as int;
+        }
+        break;
+
+      case ADDRESS:
+        if(value == null) {
+          unsetAddress();
+        } else {
+          this.address = value as t_copy_merge.Address;
+        }
+        break;
+
+      case TAGS:
+        if(value == null) {
+          unsetTags();
+        } else {
+          this.tags = value as List<String>;
+        }
+        break;
+
+      case HISTORY:
+        if(value == null) {
+          unsetHistory();
+        } else {
+          this.history = value as List<t_copy_merge.Address>;
+        }
+        break;
+
+      case AVATAR:
+        if(value == null) {
+          unsetAvatar();
+        } else {
+          this.avatar = value as Uint8List;
+        }
+        break;
+
+      case LEDGER:
+        if(value == null) {
+          unsetLedger();
+        } else {
+          this.ledger = value as Map<String, List<int>>;
+        }
+        break;
+
+      case ADDRESSES:
+        if(value == null) {
+          unsetAddresses();
+        } else {
+          this.addresses = value as Set<t_copy_merge.Address>;
+        }
+        break;
+
+      case LIMITS:
+        if(value == null) {
+          unsetLimits();
+        } else {
+          this.limits = value as Map<String, int>;
+        }
+        break;
+
+      case PREVIOUS:
+        if(value == null) {
+          unsetPrevious();
+        } else {
+          this.previous = value as int;
+        }
+        break;
+
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  // Returns true if the field corresponding to fieldID is set (has been assigned a value) and false otherwise
+  bool isSet(int fieldID) {
+    switch(fieldID) {
+      case ID:
+        return isSetId();
+      case BALANCE:
+        return isSetBalance();
+      case VERSION:
+        return isSetVersion();
+      case OWNER:
+        return isSetOwner();
+      case STATUS:
+        return isSetStatus();
+      case ADDRESS:
+        return isSetAddress();
+      case TAGS:
+        return isSetTags();
+      case HISTORY:
+        return isSetHistory();
+      case AVATAR:
+        return isSetAvatar();
+      case LEDGER:
+        return isSetLedger();
+      case ADDRESSES:
+        return isSetAddresses();
+      case LIMITS:
+        return isSetLimits();
+      case PREVIOUS:
+        return isSetPrevious();
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  read(thrift.TProtocol iprot) {
+    thrift.TField field;
+    iprot.readStructBegin();
+    while(true) {
+      field = iprot.readFieldBegin();
+      if(field.type == thrift.TType.STOP) {
+        break;
+      }
+      switch(field.id) {
+        case ID:
+          if(field.type == thrift.TType.STRING) {
+            id = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case BALANCE:
+          if(field.type == thrift.TType.I64) {
+            balance = iprot.readI64();
+            this.__isset_balance = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case VERSION:
+          if(field.type == thrift.TType.I32) {
+            version = iprot.readI32();
+            this.__isset_version = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case OWNER:
+          if(field.type == thrift.TType.STRING) {
+            owner = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case STATUS:
+          if(field.type == thrift.TType.I32) {
+            status = iprot.readI32();
+            this.__isset_status = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case ADDRESS:
+          if(field.type == thrift.TType.STRUCT) {
+            address = new t_copy_merge.Address();
+            address.read(iprot);
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case TAGS:
+          if(field.type == thrift.TType.LIST) {
+            thrift.TList elem0 = iprot.readListBegin();
+            tags = new List<String>();
+            for(int elem2 = 0; elem2 < elem0.length; ++elem2) {
+              String elem1 = iprot.readString();
+              tags.add(elem1);
+            }
+            iprot.readListEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case HISTORY:
+          if(field.type == thrift.TType.LIST) {
+            thrift.TList elem3 = iprot.readListBegin();
+            history = new List<t_copy_merge.Address>();
+            for(int elem5 = 0; elem5 < elem3.length; ++elem5) {
+              t_copy_merge.Address elem4 = new t_copy_merge.Address();
+              elem4.read(iprot);
+              history.add(elem4);
+            }
+            iprot.readListEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case AVATAR:
+          if(field.type == thrift.TType.STRING) {
+            avatar = iprot.readBinary();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case LEDGER:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem6 = iprot.readMapBegin();
+            ledger = new Map<String, List<int>>();
+            for(int elem11 = 0; elem11 < elem6.length; ++elem11) {
+              String elem12 = iprot.readString();
+              thrift.TList elem8 = iprot.readListBegin();
+              List<int> elem7 = new List<int>();
+              for(int elem10 = 0; elem10 < elem8.length; ++elem10) {
+                int elem9 = iprot.readI64();
+                elem7.add(elem9);
+              }
+              iprot.readListEnd();
+              ledger[elem12] = elem7;
+            }
+            iprot.readMapEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case ADDRESSES:
+          if(field.type == thrift.TType.SET) {
+            thrift.TSet elem13 = iprot.readSetBegin();
+            addresses = new Set<t_copy_merge.Address>();
+            for(int elem15 = 0; elem15 < elem13.length; ++elem15) {
+              t_copy_merge.Address elem14 = new t_copy_merge.Address();
+              elem14.read(iprot);
+              addresses.add(elem14);
+            }
+            iprot.readSetEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case LIMITS:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem16 = iprot.readMapBegin();
+            limits = new Map<String, int>();
+            for(int elem18 = 0; elem18 < elem16.length; ++elem18) {
+              String elem19 = iprot.readString();
+              int elem17 = iprot.readI32();
+              limits[elem19] = elem17;
+            }
+            iprot.readMapEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case PREVIOUS:
+          if(field.type == thrift.TType.I32) {
+            previous = iprot.readI32();
+            this.__isset_previous = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, field.type);
+          break;
+      }
+      iprot.readFieldEnd();
+    }
+    iprot.readStructEnd();
+
+    // check for required fields of primitive type, which can't be checked in the validate method
+    validate();
+  }
+
+  write(thrift.TProtocol oprot) {
+    validate();
+
+    oprot.writeStructBegin(_STRUCT_DESC);
+    if(this.id != null) {
+      oprot.writeFieldBegin(_ID_FIELD_DESC);
+      oprot.writeString(id);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldBegin(_BALANCE_FIELD_DESC);
+    oprot.writeI64(balance);
+    oprot.writeFieldEnd();
+    if(isSetVersion()) {
+      oprot.writeFieldBegin(_VERSION_FIELD_DESC);
+      oprot.writeI32(version);
+      oprot.writeFieldEnd();
+    }
+    if(isSetOwner() && this.owner != null) {
+      oprot.writeFieldBegin(_OWNER_FIELD_DESC);
+      oprot.writeString(owner);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldBegin(_STATUS_FIELD_DESC);
+    oprot.writeI32(status);
+    oprot.writeFieldEnd();
+    if(this.address != null) {
+      oprot.writeFieldBegin(_ADDRESS_FIELD_DESC);
+      address.write(oprot);
+      oprot.writeFieldEnd();
+    }
+    if(this.tags != null) {
+      oprot.writeFieldBegin(_TAGS_FIELD_DESC);
+      oprot.writeListBegin(new thrift.TList(thrift.TType.STRING, tags.length));
+      for(var elem20 in tags) {
+        oprot.writeString(elem20);
+      }
+      oprot.writeListEnd();
+      oprot.writeFieldEnd();
+    }
+    if(isSetHistory() && this.history != null) {
+      oprot.writeFieldBegin(_HISTORY_FIELD_DESC);
+      oprot.writeListBegin(new thrift.TList(thrift.TType.STRUCT, history.length));
+      for(var elem21 in history) {
+        elem21.write(oprot);
+      }
+      oprot.writeListEnd();
+      oprot.writeFieldEnd();
+    }
+    if(isSetAvatar() && this.avatar != null) {
+      oprot.writeFieldBegin(_AVATAR_FIELD_DESC);
+      oprot.writeBinary(avatar);
+      oprot.writeFieldEnd();
+    }
+    if(this.ledger != null) {
+      oprot.writeFieldBegin(_LEDGER_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.STRING, thrift.TType.LIST, ledger.length));
+      for(var elem22 in ledger.keys) {
+        oprot.writeString(elem22);
+        oprot.writeListBegin(new thrift.TList(thrift.TType.I64, ledger[elem22].length));
+        for(var elem23 in ledger[elem22]) {
+          oprot.writeI64(elem23);
+        }
+        oprot.writeListEnd();
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.addresses != null) {
+      oprot.writeFieldBegin(_ADDRESSES_FIELD_DESC);
+      oprot.writeSetBegin(new thrift.TSet(thrift.TType.STRUCT, addresses.length));
+      for(var elem24 in addresses) {
+        elem24.write(oprot);
+      }
+      oprot.writeSetEnd();
+      oprot.writeFieldEnd();
+    }
+    if(isSetLimits() && this.limits != null) {
+      oprot.writeFieldBegin(_LIMITS_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.STRING, thrift.TType.I32, limits.length));
+      for(var elem25 in limits.keys) {
+        oprot.writeString(elem25);
+        oprot.writeI32(limits[elem25]);
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(isSetPrevious()) {
+      oprot.writeFieldBegin(_PREVIOUS_FIELD_DESC);
+      oprot.writeI32(previous);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldStop();
+    oprot.writeStructEnd();
+  }
+
+  String toString() {
+    StringBuffer ret = new StringBuffer("Account(");
+
+    ret.write("id:");
+    if(this.id == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.id);
+    }
+
+    ret.write(", ");
+    ret.write("balance:");
+    ret.write(this.balance);
+
+    if(isSetVersion()) {
+      ret.write(", ");
+      ret.write("version:");
+      ret.write(this.version);
+    }
+
+    if(isSetOwner()) {
+      ret.write(", ");
+      ret.write("owner:");
+      if(this.owner == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.owner);
+      }
+    }
+
+    ret.write(", ");
+    ret.write("status:");
+    String status_name = t_copy_merge.Status.VALUES_TO_NAMES[this.status];
+    if(status_name != null) {
+      ret.write(status_name);
+      ret.write(" (");
+    }
+    ret.write(this.status);
+    if(status_name != null) {
+      ret.write(")");
+    }
+
+    ret.write(", ");
+    ret.write("address:");
+    if(this.address == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.address);
+    }
+
+    ret.write(", ");
+    ret.write("tags:");
+    if(this.tags == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.tags);
+    }
+
+    if(isSetHistory()) {
+      ret.write(", ");
+      ret.write("history:");
+      if(this.history == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.history);
+      }
+    }
+
+    if(isSetAvatar()) {
+      ret.write(", ");
+      ret.write("avatar:");
+      if(this.avatar == null) {
+        ret.write("null");
+      } else {
+        ret.write("BINARY");
+      }
+    }
+
+    ret.write(", ");
+    ret.write("ledger:");
+    if(this.ledger == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.ledger);
+    }
+
+    ret.write(", ");
+    ret.write("addresses:");
+    if(this.addresses == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.addresses);
+    }
+
+    if(isSetLimits()) {
+      ret.write(", ");
+      ret.write("limits:");
+      if(this.limits == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.limits);
+      }
+    }
+
+    if(isSetPrevious()) {
+      ret.write(", ");
+      ret.write("previous:");
+      String previous_name = t_copy_merge.Status.VALUES_TO_NAMES[this.previous];
+      if(previous_name != null) {
+        ret.write(previous_name);
+        ret.write(" (");
+      }
+      ret.write(this.previous);
+      if(previous_name != null) {
+        ret.write(")");
+      }
+    }
+
+    ret.write(")");
+
+    return ret.toString();
+  }
+
+  bool operator ==(Object o) {
+    if(o == null || !(o is Account)) {
+      return false;
+    }
+    Account other = o as Account;
+    return this.id == other.id
+      && this.balance == other.balance
+      && this.version == other.version
+      && this.owner == other.owner
+      && this.status == other.status
+      && this.address == other.address
+      && this.tags == other.tags
+      && this.history == other.history
+      && this.avatar == other.avatar
+      && this.ledger == other.ledger
+      && this.addresses == other.addresses
+      && this.limits == other.limits
+      && this.previous == other.previous;
+  }
+
+  int get hashCode {
+    var value = 17;
+    value = (value * 31) ^ id.hashCode;
+    value = (value * 31) ^ balance.hashCode;
+    value = (value * 31) ^ version.hashCode;
+    value = (value * 31) ^ owner.hashCode;
+    value = (value * 31) ^ status.hashCode;
+    value = (value * 31) ^ address.hashCode;
+    value = (value * 31) ^ tags.hashCode;
+    value = (value * 31) ^ history.hashCode;
+    value = (value * 31) ^ avatar.hashCode;
+    value = (value * 31) ^ ledger.hashCode;
+    value = (value * 31) ^ addresses.hashCode;
+    value = (value * 31) ^ limits.hashCode;
+    value = (value * 31) ^ previous.hashCode;
+    return value;
+  }
+
+  Account clone({
+    String id: null,
+    int balance: null,
+    int version: null,
+    String owner: null,
+    int status: null,
+    t_copy_merge.Address address: null,
+    List<String> tags: null,
+    List<t_copy_merge.Address> history: null,
+    Uint8List avatar: null,
+    Map<String, List<int>> ledger: null,
+    Set<t_copy_merge.Address> addresses: null,
+    Map<String, int> limits: null,
+    int previous: null,
+  }) {
+    return new Account()
+      ..id = id ?? this.id
+      ..balance = balance ?? this.balance
+      ..version = version ?? this.version
+      ..owner = owner ?? this.owner
+      ..status = status ?? this.status
+      ..address = address ?? this.address
+      ..tags = tags ?? this.tags
+      ..history = history ?? this.history
+      ..avatar = avatar ?? this.avatar
+      ..ledger = ledger ?? this.ledger
+      ..addresses = addresses ?? this.addresses
+      ..limits = limits ?? this.limits
+      ..previous = previous ?? this.previous;
+  }
+
+  /// Returns a copy of this Account sharing no mutable state with it.
+  Account deepCopy() {
+    return new Account()
+      .._id = this._id
+      .._balance = this._balance
+      ..__isset_balance = this.__isset_balance
+      .._version = this._version
+      ..__isset_version = this.__isset_version
+      .._owner = this._owner
+      .._status = this._status
+      ..__isset_status = this.__isset_status
+      .._address = this._address?.deepCopy()
+      .._tags = this._tags == null ? null : new List<String>.from(this._tags)
+      .._history = this._history == null ? null : new List<t_copy_merge.Address>.from(this._history.map((e0) => e0?.deepCopy()))
+      .._avatar = this._avatar == null ? null : new Uint8List.fromList(this._avatar)
+      .._ledger = this._ledger == null ? null : new Map<String, List<int>>.fromIterables(this._ledger.keys, this._ledger.values.map((v0) => v0 == null ? null : new List<int>.from(v0)))
+      .._addresses = this._addresses == null ? null : new Set<t_copy_merge.Address>.from(this._addresses.map((e0) => e0?.deepCopy()))
+      .._limits = this._limits == null ? null : new Map<String, int>.from(this._limits)
+      .._previous = this._previous
+      ..__isset_previous = this.__isset_previous;
+  }
+
+  /// Sets each field set in [other] to a deep copy of it.
+  void mergeFrom(Account other) {
+    if(other.isSetId()) {
+      this.id = other.id;
+    }
+    if(other.isSetBalance()) {
+      this.balance = other.balance;
+    }
+    if(other.isSetVersion()) {
+      this.version = other.version;
+    }
+    if(other.isSetOwner()) {
+      this.owner = other.owner;
+    }
+    if(other.isSetStatus()) {
+      this.status = other.status;
+    }
+    if(other.isSetAddress()) {
+      this.address = other.address?.deepCopy();
+    }
+    if(other.isSetTags()) {
+      this.tags = other.tags == null ? null : new List<String>.from(other.tags);
+    }
+    if(other.isSetHistory()) {
+      this.history = other.history == null ? null : new List<t_copy_merge.Address>.from(other.history.map((e0) => e0?.deepCopy()));
+    }
+    if(other.isSetAvatar()) {
+      this.avatar = other.avatar == null ? null : new Uint8List.fromList(other.avatar);
+    }
+    if(other.isSetLedger()) {
+      this.ledger = other.ledger == null ? null : new Map<String, List<int>>.fromIterables(other.ledger.keys, other.ledger.values.map((v0) => v0 == null ? null : new List<int>.from(v0)));
+    }
+    if(other.isSetAddresses()) {
+      this.addresses = other.addresses == null ? null : new Set<t_copy_merge.Address>.from(other.addresses.map((e0) => e0?.deepCopy()));
+    }
+    if(other.isSetLimits()) {
+      this.limits = other.limits == null ? null : new Map<String, int>.from(other.limits);
+    }
+    if(other.isSetPrevious()) {
+      this.previous = other.previous;
+    }
+  }
+
+  validate() {
+    // check for required fields
+    // check that fields of type enum have valid values
+    if(isSetStatus() && !t_copy_merge.Status.VALID_VALUES.contains(status)) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "The field 'status' has been assigned the invalid value $status");
+    }
+    if(isSetPrevious() && !t_copy_merge.Status.VALID_VALUES.contains(previous)) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "The field 'previous' has been assigned the invalid value $previous");
+    }
+  }
+}
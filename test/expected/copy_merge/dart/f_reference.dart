@@ -0,0 +1,219 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:typed_data' show Uint8List;
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:copy_merge/copy_merge.dart' as t_copy_merge;
+
+class Reference implements thrift.TBase {
+  static final thrift.TStruct _STRUCT_DESC = new thrift.TStruct("Reference");
+  static final thrift.TField _ID_FIELD_DESC = new thrift.TField("id", thrift.TType.STRING, 1);
+  static final thrift.TField _ADDRESS_FIELD_DESC = new thrift.TField("address", thrift.TType.STRUCT, 2);
+
+  String _id;
+  static const int ID = 1;
+  t_copy_merge.Address _address;
+  static const int ADDRESS = 2;
+
+
+  Reference() {
+  }
+
+  String get id => this._id;
+
+  set id(String id) {
+    this._id = id;
+  }
+
+  bool isSetId() => this.id != null;
+
+  unsetId() {
+    this.id = null;
+  }
+
+  t_copy_merge.Address get address => this._address;
+
+  set address(t_copy_merge.Address address) {
+    this._address = address;
+  }
+
+  bool isSetAddress() => this.address != null;
+
+  unsetAddress() {
+    this.address = null;
+  }
+
+  getFieldValue(int fieldID) {
+    switch (fieldID) {
+      case ID:
+        return this.id;
+      case ADDRESS:
+        return this.address;
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  setFieldValue(int fieldID, Object value) {
+    switch(fieldID) {
+      case ID:
+        if(value == null) {
+          unsetId();
+        } else {
+          this.id = value as String;
+        }
+        break;
+
+      case ADDRESS:
+        if(value == null) {
+          unsetAddress();
+        } else {
+          this.address = value as t_copy_merge.Address;
+        }
+        break;
+
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  // Returns true if the field corresponding to fieldID is set (has been assigned a value) and false otherwise
+  bool isSet(int fieldID) {
+    switch(fieldID) {
+      case ID:
+        return isSetId();
+      case ADDRESS:
+        return isSetAddress();
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  read(thrift.TProtocol iprot) {
+    thrift.TField field;
+    iprot.readStructBegin();
+    while(true) {
+      field = iprot.readFieldBegin();
+      if(field.type == thrift.TType.STOP) {
+        break;
+      }
+      switch(field.id) {
+        case ID:
+          if(field.type == thrift.TType.STRING) {
+            id = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case ADDRESS:
+          if(field.type == thrift.TType.STRUCT) {
+            address = new t_copy_merge.Address();
+            address.read(iprot);
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, field.type);
+          break;
+      }
+      iprot.readFieldEnd();
+    }
+    iprot.readStructEnd();
+
+    // check for required fields of primitive type, which can't be checked in the validate method
+    validate();
+  }
+
+  write(thrift.TProtocol oprot) {
+    validate();
+
+    oprot.writeStructBegin(_STRUCT_DESC);
+    if(isSetId() && this.id != null) {
+      oprot.writeFieldBegin(_ID_FIELD_DESC);
+      oprot.writeString(id);
+      oprot.writeFieldEnd();
+    }
+    if(isSetAddress() && this.address != null) {
+      oprot.writeFieldBegin(_ADDRESS_FIELD_DESC);
+      address.write(oprot);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldStop();
+    oprot.writeStructEnd();
+  }
+
+  String toString() {
+    StringBuffer ret = new StringBuffer("Reference(");
+
+    if(isSetId()) {
+      ret.write("id:");
+      if(this.id == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.id);
+      }
+    }
+
+    if(isSetAddress()) {
+      ret.write(", ");
+      ret.write("address:");
+      if(this.address == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.address);
+      }
+    }
+
+    ret.write(")");
+
+    return ret.toString();
+  }
+
+  bool operator ==(Object o) {
+    if(o == null || !(o is Reference)) {
+      return false;
+    }
+    Reference other = o as Reference;
+    return this.id == other.id
+      && this.address == other.address;
+  }
+
+  int get hashCode {
+    var value = 17;
+    value = (value * 31) ^ id.hashCode;
+    value = (value * 31) ^ address.hashCode;
+    return value;
+  }
+
+  Reference clone({
+    String id: null,
+    t_copy_merge.Address address: null,
+  }) {
+    return new Reference()
+      ..id = id ?? this.id
+      ..address = address ?? this.address;
+  }
+
+  /// Returns a copy of this Reference sharing no mutable state with it.
+  Reference deepCopy() {
+    return new Reference()
+      .._id = this._id
+      .._address = this._address?.deepCopy();
+  }
+
+  validate() {
+    // check exactly one field is set
+    int setFields = 0;
+    if(isSetId()) {
+      setFields++;
+    }
+    if(isSetAddress()) {
+      setFields++;
+    }
+    if(setFields != 1) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "The union did not have exactly one field set, $setFields were set");
+    }
+    // check that fields of type enum have valid values
+  }
+}
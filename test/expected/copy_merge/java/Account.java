@@ -0,0 +1,1959 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+package copy_merge;
+
+import org.apache.thrift.scheme.IScheme;
+import org.apache.thrift.scheme.SchemeFactory;
+import org.apache.thrift.scheme.StandardScheme;
+
+import org.apache.thrift.scheme.TupleScheme;
+import org.apache.thrift.protocol.TTupleProtocol;
+import org.apache.thrift.protocol.TProtocolException;
+import org.apache.thrift.EncodingUtils;
+import org.apache.thrift.TException;
+import org.apache.thrift.async.AsyncMethodCallback;
+import org.apache.thrift.server.AbstractNonblockingServer.*;
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import javax.annotation.Generated;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+@Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
+public class Account implements org.apache.thrift.TBase<Account, Account._Fields>, java.io.Serializable, Cloneable, Comparable<Account> {
+	private static final org.apache.thrift.protocol.TStruct STRUCT_DESC = new org.apache.thrift.protocol.TStruct("Account");
+
+	private static final org.apache.thrift.protocol.TField ID_FIELD_DESC = new org.apache.thrift.protocol.TField("id", org.apache.thrift.protocol.TType.STRING, (short)1);
+	private static final org.apache.thrift.protocol.TField BALANCE_FIELD_DESC = new org.apache.thrift.protocol.TField("balance", org.apache.thrift.protocol.TType.I64, (short)2);
+	private static final org.apache.thrift.protocol.TField VERSION_FIELD_DESC = new org.apache.thrift.protocol.TField("version", org.apache.thrift.protocol.TType.I32, (short)3);
+	private static final org.apache.thrift.protocol.TField OWNER_FIELD_DESC = new org.apache.thrift.protocol.TField("owner", org.apache.thrift.protocol.TType.STRING, (short)4);
+	private static final org.apache.thrift.protocol.TField STATUS_FIELD_DESC = new org.apache.thrift.protocol.TField("status", org.apache.thrift.protocol.TType.I32, (short)5);
+	private static final org.apache.thrift.protocol.TField ADDRESS_FIELD_DESC = new org.apache.thrift.protocol.TField("address", org.apache.thrift.protocol.TType.STRUCT, (short)6);
+	private static final org.apache.thrift.protocol.TField TAGS_FIELD_DESC = new org.apache.thrift.protocol.TField("tags", org.apache.thrift.protocol.TType.LIST, (short)7);
+	private static final org.apache.thrift.protocol.TField HISTORY_FIELD_DESC = new org.apache.thrift.protocol.TField("history", org.apache.thrift.protocol.TType.LIST, (short)8);
+	private static final org.apache.thrift.protocol.TField AVATAR_FIELD_DESC = new org.apache.thrift.protocol.TField("avatar", org.apache.thrift.protocol.TType.STRING, (short)9);
+	private static final org.apache.thrift.protocol.TField LEDGER_FIELD_DESC = new org.apache.thrift.protocol.TField("ledger", org.apache.thrift.protocol.TType.MAP, (short)10);
+	private static final org.apache.thrift.protocol.TField ADDRESSES_FIELD_DESC = new org.apache.thrift.protocol.TField("addresses", org.apache.thrift.protocol.TType.SET, (short)11);
+	private static final org.apache.thrift.protocol.TField LIMITS_FIELD_DESC = new org.apache.thrift.protocol.TField("limits", org.apache.thrift.protocol.TType.MAP, (short)12);
+	private static final org.apache.thrift.protocol.TField PREVIOUS_FIELD_DESC = new org.apache.thrift.protocol.TField("previous", org.apache.thrift.protocol.TType.I32, (short)13);
+
+	private static final Map<Class<? extends IScheme>, SchemeFactory> schemes = new HashMap<Class<? extends IScheme>, SchemeFactory>();
+	static {
+		schemes.put(StandardScheme.class, new AccountStandardSchemeFactory());
+		schemes.put(TupleScheme.class, new AccountTupleSchemeFactory());
+	}
+
+	public String id;
+	public long balance;
+	public int version; // optional
+	public String owner; // optional
+	public Status status;
+	public Address address;
+	public java.util.List<String> tags;
+	public java.util.List<Address> history; // optional
+	public java.nio.ByteBuffer avatar; // optional
+	public java.util.Map<String, java.util.List<Long>> ledger;
+	public java.util.Set<Address> addresses;
+	public java.util.Map<String, Integer> limits; // optional
+	public Status previous; // optional
+	/** The set of fields this struct contains, along with convenience methods for finding and manipulating them. */
+	public enum _Fields implements org.apache.thrift.TFieldIdEnum {
+		ID((short)1, "id"),
+		BALANCE((short)2, "balance"),
+		VERSION((short)3, "version"),
+		OWNER((short)4, "owner"),
+		STATUS((short)5, "status"),
+		ADDRESS((short)6, "address"),
+		TAGS((short)7, "tags"),
+		HISTORY((short)8, "history"),
+		AVATAR((short)9, "avatar"),
+		LEDGER((short)10, "ledger"),
+		ADDRESSES((short)11, "addresses"),
+		LIMITS((short)12, "limits"),
+		PREVIOUS((short)13, "previous")
+		;
+
+		private static final Map<String, _Fields> byName = new HashMap<String, _Fields>();
+
+		static {
+			for (_Fields field : EnumSet.allOf(_Fields.class)) {
+				byName.put(field.getFieldName(), field);
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, or null if its not found.
+		 */
+		public static _Fields findByThriftId(int fieldId) {
+			switch(fieldId) {
+				case 1: // ID
+					return ID;
+				case 2: // BALANCE
+					return BALANCE;
+				case 3: // VERSION
+					return VERSION;
+				case 4: // OWNER
+					return OWNER;
+				case 5: // STATUS
+					return STATUS;
+				case 6: // ADDRESS
+					return ADDRESS;
+				case 7: // TAGS
+					return TAGS;
+				case 8: // HISTORY
+					return HISTORY;
+				case 9: // AVATAR
+					return AVATAR;
+				case 10: // LEDGER
+					return LEDGER;
+				case 11: // ADDRESSES
+					return ADDRESSES;
+				case 12: // LIMITS
+					return LIMITS;
+				case 13: // PREVIOUS
+					return PREVIOUS;
+				default:
+					return null;
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, throwing an exception
+		 * if it is not found.
+		 */
+		public static _Fields findByThriftIdOrThrow(int fieldId) {
+			_Fields fields = findByThriftId(fieldId);
+			if (fields == null) throw new IllegalArgumentException("Field " + fieldId + " doesn't exist!");
+			return fields;
+		}
+
+		/**
+		 * Find the _Fields constant that matches name, or null if its not found.
+		 */
+		public static _Fields findByName(String name) {
+			return byName.get(name);
+		}
+
+		private final short _thriftId;
+		private final String _fieldName;
+
+		_Fields(short thriftId, String fieldName) {
+			_thriftId = thriftId;
+			_fieldName = fieldName;
+		}
+
+		public short getThriftFieldId() {
+			return _thriftId;
+		}
+
+		public String getFieldName() {
+			return _fieldName;
+		}
+	}
+
+	// isset id assignments
+	private static final int __BALANCE_ISSET_ID = 0;
+	private static final int __VERSION_ISSET_ID = 1;
+	private byte __isset_bitfield = 0;
+	public Account() {
+		this.owner = "nobody";
+
+		this.limits = new HashMap<String,Integer>();
+		this.limits.put("daily", 100);
+
+	}
+
+	public Account(
+		String id,
+		long balance,
+		Status status,
+		Address address,
+		java.util.List<String> tags,
+		java.util.Map<String, java.util.List<Long>> ledger,
+		java.util.Set<Address> addresses) {
+		this();
+		this.id = id;
+		this.balance = balance;
+		setBalanceIsSet(true);
+		this.status = status;
+		this.address = address;
+		this.tags = tags;
+		this.ledger = ledger;
+		this.addresses = addresses;
+	}
+
+	/**
+	 * Performs a deep copy on <i>other</i>.
+	 */
+	public Account(Account other) {
+		__isset_bitfield = other.__isset_bitfield;
+		if (other.isSetId()) {
+			this.id = other.id;
+		}
+		this.balance = other.balance;
+		this.version = other.version;
+		if (other.isSetOwner()) {
+			this.owner = other.owner;
+		}
+		if (other.isSetStatus()) {
+			this.status = other.status;
+		}
+		if (other.isSetAddress()) {
+			this.address = new Address(other.address);
+		}
+		if (other.isSetTags()) {
+			this.tags = new ArrayList<String>(other.tags.size());
+			for (String elem4 : other.tags) {
+				String elem5 = elem4;
+				this.tags.add(elem5);
+			}
+		}
+		if (other.isSetHistory()) {
+			this.history = new ArrayList<Address>(other.history.size());
+			for (Address elem6 : other.history) {
+				Address elem7 = new Address(elem6);
+				this.history.add(elem7);
+			}
+		}
+		if (other.isSetAvatar()) {
+			this.avatar = org.apache.thrift.TBaseHelper.copyBinary(other.avatar);
+		}
+		if (other.isSetLedger()) {
+			this.ledger = new HashMap<String,java.util.List<Long>>(other.ledger.size());
+			for (Map.Entry<String, java.util.List<Long>> elem8 : other.ledger.entrySet()) {
+				String elem10 = elem8.getKey();
+				java.util.List<Long> elem9 = new ArrayList<Long>(elem8.getValue().size());
+				for (long elem11 : elem8.getValue()) {
+					long elem12 = elem11;
+					elem9.add(elem12);
+				}
+				this.ledger.put(elem10, elem9);
+			}
+		}
+		if (other.isSetAddresses()) {
+			this.addresses = new HashSet<Address>(other.addresses.size());
+			for (Address elem13 : other.addresses) {
+				Address elem14 = new Address(elem13);
+				this.addresses.add(elem14);
+			}
+		}
+		if (other.isSetLimits()) {
+			this.limits = new HashMap<String,Integer>(other.limits);
+		}
+		if (other.isSetPrevious()) {
+			this.previous = other.previous;
+		}
+	}
+
+	public Account deepCopy() {
+		return new Account(this);
+	}
+
+	/**
+	 * Sets each field set in <i>other</i> to a deep copy of it.
+	 */
+	public void mergeFrom(Account other) {
+		if (other.isSetId()) {
+			this.id = other.id;
+		}
+		if (other.isSetBalance()) {
+			this.balance = other.balance;
+			setBalanceIsSet(true);
+		}
+		if (other.isSetVersion()) {
+			this.version = other.version;
+			setVersionIsSet(true);
+		}
+		if (other.isSetOwner()) {
+			this.owner = other.owner;
+		}
+		if (other.isSetStatus()) {
+			this.status = other.status;
+		}
+		if (other.isSetAddress()) {
+			this.address = new Address(other.address);
+		}
+		if (other.isSetTags()) {
+			this.tags = new ArrayList<String>(other.tags.size());
+			for (String elem17 : other.tags) {
+				String elem18 = elem17;
+				this.tags.add(elem18);
+			}
+		}
+		if (other.isSetHistory()) {
+			this.history = new ArrayList<Address>(other.history.size());
+			for (Address elem19 : other.history) {
+				Address elem20 = new Address(elem19);
+				this.history.add(elem20);
+			}
+		}
+		if (other.isSetAvatar()) {
+			this.avatar = org.apache.thrift.TBaseHelper.copyBinary(other.avatar);
+		}
+		if (other.isSetLedger()) {
+			this.ledger = new HashMap<String,java.util.List<Long>>(other.ledger.size());
+			for (Map.Entry<String, java.util.List<Long>> elem21 : other.ledger.entrySet()) {
+				String elem23 = elem21.getKey();
+				java.util.List<Long> elem22 = new ArrayList<Long>(elem21.getValue().size());
+				for (long elem24 : elem21.getValue()) {
+					long elem25 = elem24;
+					elem22.add(elem25);
+				}
+				this.ledger.put(elem23, elem22);
+			}
+		}
+		if (other.isSetAddresses()) {
+			this.addresses = new HashSet<Address>(other.addresses.size());
+			for (Address elem26 : other.addresses) {
+				Address elem27 = new Address(elem26);
+				this.addresses.add(elem27);
+			}
+		}
+		if (other.isSetLimits()) {
+			this.limits = new HashMap<String,Integer>(other.limits);
+		}
+		if (other.isSetPrevious()) {
+			this.previous = other.previous;
+		}
+	}
+
+	@Override
+	public void clear() {
+		this.id = null;
+
+		setBalanceIsSet(false);
+		this.balance = 0L;
+
+		setVersionIsSet(false);
+		this.version = 0;
+
+		this.owner = "nobody";
+
+		this.status = null;
+
+		this.address = null;
+
+		this.tags = null;
+
+		this.history = null;
+
+		this.avatar = null;
+
+		this.ledger = null;
+
+		this.addresses = null;
+
+		this.limits = new HashMap<String,Integer>();
+		this.limits.put("daily", 100);
+
+		this.previous = null;
+
+	}
+
+	public String getId() {
+		return this.id;
+	}
+
+	public Account setId(String id) {
+		this.id = id;
+		return this;
+	}
+
+	public void unsetId() {
+		this.id = null;
+	}
+
+	/** Returns true if field id is set (has been assigned a value) and false otherwise */
+	public boolean isSetId() {
+		return this.id != null;
+	}
+
+	public void setIdIsSet(boolean value) {
+		if (!value) {
+			this.id = null;
+		}
+	}
+
+	public long getBalance() {
+		return this.balance;
+	}
+
+	public Account setBalance(long balance) {
+		this.balance = balance;
+		setBalanceIsSet(true);
+		return this;
+	}
+
+	public void unsetBalance() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __BALANCE_ISSET_ID);
+	}
+
+	/** Returns true if field balance is set (has been assigned a value) and false otherwise */
+	public boolean isSetBalance() {
+		return EncodingUtils.testBit(__isset_bitfield, __BALANCE_ISSET_ID);
+	}
+
+	public void setBalanceIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __BALANCE_ISSET_ID, value);
+	}
+
+	public int getVersion() {
+		return this.version;
+	}
+
+	public Account setVersion(int version) {
+		this.version = version;
+		setVersionIsSet(true);
+		return this;
+	}
+
+	public void unsetVersion() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __VERSION_ISSET_ID);
+	}
+
+	/** Returns true if field version is set (has been assigned a value) and false otherwise */
+	public boolean isSetVersion() {
+		return EncodingUtils.testBit(__isset_bitfield, __VERSION_ISSET_ID);
+	}
+
+	public void setVersionIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __VERSION_ISSET_ID, value);
+	}
+
+	public String getOwner() {
+		return this.owner;
+	}
+
+	public Account setOwner(String owner) {
+		this.owner = owner;
+		return this;
+	}
+
+	public void unsetOwner() {
+		this.owner = null;
+	}
+
+	/** Returns true if field owner is set (has been assigned a value) and false otherwise */
+	public boolean isSetOwner() {
+		return this.owner != null;
+	}
+
+	public void setOwnerIsSet(boolean value) {
+		if (!value) {
+			this.owner = null;
+		}
+	}
+
+	public Status getStatus() {
+		return this.status;
+	}
+
+	public Account setStatus(Status status) {
+		this.status = status;
+		return this;
+	}
+
+	public void unsetStatus() {
+		this.status = null;
+	}
+
+	/** Returns true if field status is set (has been assigned a value) and false otherwise */
+	public boolean isSetStatus() {
+		return this.status != null;
+	}
+
+	public void setStatusIsSet(boolean value) {
+		if (!value) {
+			this.status = null;
+		}
+	}
+
+	public Address getAddress() {
+		return this.address;
+	}
+
+	public Account setAddress(Address address) {
+		this.address = address;
+		return this;
+	}
+
+	public void unsetAddress() {
+		this.address = null;
+	}
+
+	/** Returns true if field address is set (has been assigned a value) and false otherwise */
+	public boolean isSetAddress() {
+		return this.address != null;
+	}
+
+	public void setAddressIsSet(boolean value) {
+		if (!value) {
+			this.address = null;
+		}
+	}
+
+	public int getTagsSize() {
+		return (this.tags == null) ? 0 : this.tags.size();
+	}
+
+	public java.util.Iterator<String> getTagsIterator() {
+		return (this.tags == null) ? null : this.tags.iterator();
+	}
+
+	public void addToTags(String elem) {
+		if (this.tags == null) {
+			this.tags = new ArrayList<String>();
+		}
+		this.tags.add(elem);
+	}
+
+	public java.util.List<String> getTags() {
+		return this.tags;
+	}
+
+	public Account setTags(java.util.List<String> tags) {
+		this.tags = tags;
+		return this;
+	}
+
+	public void unsetTags() {
+		this.tags = null;
+	}
+
+	/** Returns true if field tags is set (has been assigned a value) and false otherwise */
+	public boolean isSetTags() {
+		return this.tags != null;
+	}
+
+	public void setTagsIsSet(boolean value) {
+		if (!value) {
+			this.tags = null;
+		}
+	}
+
+	public int getHistorySize() {
+		return (this.history == null) ? 0 : this.history.size();
+	}
+
+	public java.util.Iterator<Address> getHistoryIterator() {
+		return (this.history == null) ? null : this.history.iterator();
+	}
+
+	public void addToHistory(Address elem) {
+		if (this.history == null) {
+			this.history = new ArrayList<Address>();
+		}
+		this.history.add(elem);
+	}
+
+	public java.util.List<Address> getHistory() {
+		return this.history;
+	}
+
+	public Account setHistory(java.util.List<Address> history) {
+		this.history = history;
+		return this;
+	}
+
+	public void unsetHistory() {
+		this.history = null;
+	}
+
+	/** Returns true if field history is set (has been assigned a value) and false otherwise */
+	public boolean isSetHistory() {
+		return this.history != null;
+	}
+
+	public void setHistoryIsSet(boolean value) {
+		if (!value) {
+			this.history = null;
+		}
+	}
+
+	public byte[] getAvatar() {
+		setAvatar(org.apache.thrift.TBaseHelper.rightSize(avatar));
+		return avatar == null ? null : avatar.array();
+	}
+
+	public java.nio.ByteBuffer bufferForAvatar() {
+		return org.apache.thrift.TBaseHelper.copyBinary(avatar);
+	}
+
+	public Account setAvatar(byte[] avatar) {
+		this.avatar = avatar == null ? (java.nio.ByteBuffer)null : java.nio.ByteBuffer.wrap(Arrays.copyOf(avatar, avatar.length));
+		return this;
+	}
+
+	public Account setAvatar(java.nio.ByteBuffer avatar) {
+		this.avatar = org.apache.thrift.TBaseHelper.copyBinary(avatar);
+		return this;
+	}
+
+	public void unsetAvatar() {
+		this.avatar = null;
+	}
+
+	/** Returns true if field avatar is set (has been assigned a value) and false otherwise */
+	public boolean isSetAvatar() {
+		return this.avatar != null;
+	}
+
+	public void setAvatarIsSet(boolean value) {
+		if (!value) {
+			this.avatar = null;
+		}
+	}
+
+	public int getLedgerSize() {
+		return (this.ledger == null) ? 0 : this.ledger.size();
+	}
+
+	public void putToLedger(String key, java.util.List<Long> val) {
+		if (this.ledger == null) {
+			this.ledger = new HashMap<String,java.util.List<Long>>();
+		}
+		this.ledger.put(key, val);
+	}
+
+	public java.util.Map<String, java.util.List<Long>> getLedger() {
+		return this.ledger;
+	}
+
+	public Account setLedger(java.util.Map<String, java.util.List<Long>> ledger) {
+		this.ledger = ledger;
+		return this;
+	}
+
+	public void unsetLedger() {
+		this.ledger = null;
+	}
+
+	/** Returns true if field ledger is set (has been assigned a value) and false otherwise */
+	public boolean isSetLedger() {
+		return this.ledger != null;
+	}
+
+	public void setLedgerIsSet(boolean value) {
+		if (!value) {
+			this.ledger = null;
+		}
+	}
+
+	public int getAddressesSize() {
+		return (this.addresses == null) ? 0 : this.addresses.size();
+	}
+
+	public java.util.Iterator<Address> getAddressesIterator() {
+		return (this.addresses == null) ? null : this.addresses.iterator();
+	}
+
+	public void addToAddresses(Address elem) {
+		if (this.addresses == null) {
+			this.addresses = new HashSet<Address>();
+		}
+		this.addresses.add(elem);
+	}
+
+	public java.util.Set<Address> getAddresses() {
+		return this.addresses;
+	}
+
+	public Account setAddresses(java.util.Set<Address> addresses) {
+		this.addresses = addresses;
+		return this;
+	}
+
+	public void unsetAddresses() {
+		this.addresses = null;
+	}
+
+	/** Returns true if field addresses is set (has been assigned a value) and false otherwise */
+	public boolean isSetAddresses() {
+		return this.addresses != null;
+	}
+
+	public void setAddressesIsSet(boolean value) {
+		if (!value) {
+			this.addresses = null;
+		}
+	}
+
+	public int getLimitsSize() {
+		return (this.limits == null) ? 0 : this.limits.size();
+	}
+
+	public void putToLimits(String key, int val) {
+		if (this.limits == null) {
+			this.limits = new HashMap<String,Integer>();
+		}
+		this.limits.put(key, val);
+	}
+
+	public java.util.Map<String, Integer> getLimits() {
+		return this.limits;
+	}
+
+	public Account setLimits(java.util.Map<String, Integer> limits) {
+		this.limits = limits;
+		return this;
+	}
+
+	public void unsetLimits() {
+		this.limits = null;
+	}
+
+	/** Returns true if field limits is set (has been assigned a value) and false otherwise */
+	public boolean isSetLimits() {
+		return this.limits != null;
+	}
+
+	public void setLimitsIsSet(boolean value) {
+		if (!value) {
+			this.limits = null;
+		}
+	}
+
+	public Status getPrevious() {
+		return this.previous;
+	}
+
+	public Account setPrevious(Status previous) {
+		this.previous = previous;
+		return this;
+	}
+
+	public void unsetPrevious() {
+		this.previous = null;
+	}
+
+	/** Returns true if field previous is set (has been assigned a value) and false otherwise */
+	public boolean isSetPrevious() {
+		return this.previous != null;
+	}
+
+	public void setPreviousIsSet(boolean value) {
+		if (!value) {
+			this.previous = null;
+		}
+	}
+
+	public void setFieldValue(_Fields field, Object value) {
+		switch (field) {
+		case ID:
+			if (value == null) {
+				unsetId();
+			} else {
+				setId((String)value);
+			}
+			break;
+
+		case BALANCE:
+			if (value == null) {
+				unsetBalance();
+			} else {
+				setBalance((Long)value);
+			}
+			break;
+
+		case VERSION:
+			if (value == null) {
+				unsetVersion();
+			} else {
+				setVersion((Integer)value);
+			}
+			break;
+
+		case OWNER:
+			if (value == null) {
+				unsetOwner();
+			} else {
+				setOwner((String)value);
+			}
+			break;
+
+		case STATUS:
+			if (value == null) {
+				unsetStatus();
+			} else {
+				setStatus((Status)value);
+			}
+			break;
+
+		case ADDRESS:
+			if (value == null) {
+				unsetAddress();
+			} else {
+				setAddress((Address)value);
+			}
+			break;
+
+		case TAGS:
+			if (value == null) {
+				unsetTags();
+			} else {
+				setTags((java.util.List<String>)value);
+			}
+			break;
+
+		case HISTORY:
+			if (value == null) {
+				unsetHistory();
+			} else {
+				setHistory((java.util.List<Address>)value);
+			}
+			break;
+
+		case AVATAR:
+			if (value == null) {
+				unsetAvatar();
+			} else {
+				setAvatar((java.nio.ByteBuffer)value);
+			}
+			break;
+
+		case LEDGER:
+			if (value == null) {
+				unsetLedger();
+			} else {
+				setLedger((java.util.Map<String, java.util.List<Long>>)value);
+			}
+			break;
+
+		case ADDRESSES:
+			if (value == null) {
+				unsetAddresses();
+			} else {
+				setAddresses((java.util.Set<Address>)value);
+			}
+			break;
+
+		case LIMITS:
+			if (value == null) {
+				unsetLimits();
+			} else {
+				setLimits((java.util.Map<String, Integer>)value);
+			}
+			break;
+
+		case PREVIOUS:
+			if (value == null) {
+				unsetPrevious();
+			} else {
+				setPrevious((Status)value);
+			}
+			break;
+
+		}
+	}
+
+	public Object getFieldValue(_Fields field) {
+		switch (field) {
+		case ID:
+			return getId();
+
+		case BALANCE:
+			return getBalance();
+
+		case VERSION:
+			return getVersion();
+
+		case OWNER:
+			return getOwner();
+
+		case STATUS:
+			return getStatus();
+
+		case ADDRESS:
+			return getAddress();
+
+		case TAGS:
+			return getTags();
+
+		case HISTORY:
+			return getHistory();
+
+		case AVATAR:
+			return getAvatar();
+
+		case LEDGER:
+			return getLedger();
+
+		case ADDRESSES:
+			return getAddresses();
+
+		case LIMITS:
+			return getLimits();
+
+		case PREVIOUS:
+			return getPrevious();
+
+		}
+		throw new IllegalStateException();
+	}
+
+	/** Returns true if field corresponding to fieldID is set (has been assigned a value) and false otherwise */
+	public boolean isSet(_Fields field) {
+		if (field == null) {
+			throw new IllegalArgumentException();
+		}
+
+		switch (field) {
+		case ID:
+			return isSetId();
+		case BALANCE:
+			return isSetBalance();
+		case VERSION:
+			return isSetVersion();
+		case OWNER:
+			return isSetOwner();
+		case STATUS:
+			return isSetStatus();
+		case ADDRESS:
+			return isSetAddress();
+		case TAGS:
+			return isSetTags();
+		case HISTORY:
+			return isSetHistory();
+		case AVATAR:
+			return isSetAvatar();
+		case LEDGER:
+			return isSetLedger();
+		case ADDRESSES:
+			return isSetAddresses();
+		case LIMITS:
+			return isSetLimits();
+		case PREVIOUS:
+			return isSetPrevious();
+		}
+		throw new IllegalStateException();
+	}
+
+	@Override
+	public boolean equals(Object that) {
+		if (that == null)
+			return false;
+		if (that instanceof Account)
+			return this.equals((Account)that);
+		return false;
+	}
+
+	public boolean equals(Account that) {
+		if (that == null)
+			return false;
+
+		boolean this_present_id = true && this.isSetId();
+		boolean that_present_id = true && that.isSetId();
+		if (this_present_id || that_present_id) {
+			if (!(this_present_id && that_present_id))
+				return false;
+			if (!this.id.equals(that.id))
+				return false;
+		}
+
+		boolean this_present_balance = true;
+		boolean that_present_balance = true;
+		if (this_present_balance || that_present_balance) {
+			if (!(this_present_balance && that_present_balance))
+				return false;
+			if (this.balance != that.balance)
+				return false;
+		}
+
+		boolean this_present_version = true && this.isSetVersion();
+		boolean that_present_version = true && that.isSetVersion();
+		if (this_present_version || that_present_version) {
+			if (!(this_present_version && that_present_version))
+				return false;
+			if (this.version != that.version)
+				return false;
+		}
+
+		boolean this_present_owner = true && this.isSetOwner();
+		boolean that_present_owner = true && that.isSetOwner();
+		if (this_present_owner || that_present_owner) {
+			if (!(this_present_owner && that_present_owner))
+				return false;
+			if (!this.owner.equals(that.owner))
+				return false;
+		}
+
+		boolean this_present_status = true && this.isSetStatus();
+		boolean that_present_status = true && that.isSetStatus();
+		if (this_present_status || that_present_status) {
+			if (!(this_present_status && that_present_status))
+				return false;
+			if (!this.status.equals(that.status))
+				return false;
+		}
+
+		boolean this_present_address = true && this.isSetAddress();
+		boolean that_present_address = true && that.isSetAddress();
+		if (this_present_address || that_present_address) {
+			if (!(this_present_address && that_present_address))
+				return false;
+			if (!this.address.equals(that.address))
+				return false;
+		}
+
+		boolean this_present_tags = true && this.isSetTags();
+		boolean that_present_tags = true && that.isSetTags();
+		if (this_present_tags || that_present_tags) {
+			if (!(this_present_tags && that_present_tags))
+				return false;
+			if (!this.tags.equals(that.tags))
+				return false;
+		}
+
+		boolean this_present_history = true && this.isSetHistory();
+		boolean that_present_history = true && that.isSetHistory();
+		if (this_present_history || that_present_history) {
+			if (!(this_present_history && that_present_history))
+				return false;
+			if (!this.history.equals(that.history))
+				return false;
+		}
+
+		boolean this_present_avatar = true && this.isSetAvatar();
+		boolean that_present_avatar = true && that.isSetAvatar();
+		if (this_present_avatar || that_present_avatar) {
+			if (!(this_present_avatar && that_present_avatar))
+				return false;
+			if (!this.avatar.equals(that.avatar))
+				return false;
+		}
+
+		boolean this_present_ledger = true && this.isSetLedger();
+		boolean that_present_ledger = true && that.isSetLedger();
+		if (this_present_ledger || that_present_ledger) {
+			if (!(this_present_ledger && that_present_ledger))
+				return false;
+			if (!this.ledger.equals(that.ledger))
+				return false;
+		}
+
+		boolean this_present_addresses = true && this.isSetAddresses();
+		boolean that_present_addresses = true && that.isSetAddresses();
+		if (this_present_addresses || that_present_addresses) {
+			if (!(this_present_addresses && that_present_addresses))
+				return false;
+			if (!this.addresses.equals(that.addresses))
+				return false;
+		}
+
+		boolean this_present_limits = true && this.isSetLimits();
+		boolean that_present_limits = true && that.isSetLimits();
+		if (this_present_limits || that_present_limits) {
+			if (!(this_present_limits && that_present_limits))
+				return false;
+			if (!this.limits.equals(that.limits))
+				return false;
+		}
+
+		boolean this_present_previous = true && this.isSetPrevious();
+		boolean that_present_previous = true && that.isSetPrevious();
+		if (this_present_previous || that_present_previous) {
+			if (!(this_present_previous && that_present_previous))
+				return false;
+			if (!this.previous.equals(that.previous))
+				return false;
+		}
+
+		return true;
+	}
+
+	@Override
+	public int hashCode() {
+		List<Object> list = new ArrayList<Object>();
+
+		boolean present_id = true && (isSetId());
+		list.add(present_id);
+		if (present_id)
+			list.add(id);
+
+		boolean present_balance = true;
+		list.add(present_balance);
+		if (present_balance)
+			list.add(balance);
+
+		boolean present_version = true && (isSetVersion());
+		list.add(present_version);
+		if (present_version)
+			list.add(version);
+
+		boolean present_owner = true && (isSetOwner());
+		list.add(present_owner);
+		if (present_owner)
+			list.add(owner);
+
+		boolean present_status = true && (isSetStatus());
+		list.add(present_status);
+		if (present_status)
+			list.add(status.getValue());
+
+		boolean present_address = true && (isSetAddress());
+		list.add(present_address);
+		if (present_address)
+			list.add(address);
+
+		boolean present_tags = true && (isSetTags());
+		list.add(present_tags);
+		if (present_tags)
+			list.add(tags);
+
+		boolean present_history = true && (isSetHistory());
+		list.add(present_history);
+		if (present_history)
+			list.add(history);
+
+		boolean present_avatar = true && (isSetAvatar());
+		list.add(present_avatar);
+		if (present_avatar)
+			list.add(avatar);
+
+		boolean present_ledger = true && (isSetLedger());
+		list.add(present_ledger);
+		if (present_ledger)
+			list.add(ledger);
+
+		boolean present_addresses = true && (isSetAddresses());
+		list.add(present_addresses);
+		if (present_addresses)
+			list.add(addresses);
+
+		boolean present_limits = true && (isSetLimits());
+		list.add(present_limits);
+		if (present_limits)
+			list.add(limits);
+
+		boolean present_previous = true && (isSetPrevious());
+		list.add(present_previous);
+		if (present_previous)
+			list.add(previous.getValue());
+
+		return list.hashCode();
+	}
+
+	@Override
+	public int compareTo(Account other) {
+		if (!getClass().equals(other.getClass())) {
+			return getClass().getName().compareTo(other.getClass().getName());
+		}
+
+		int lastComparison = 0;
+
+		lastComparison = Boolean.valueOf(isSetId()).compareTo(other.isSetId());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetId()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.id, other.id);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetBalance()).compareTo(other.isSetBalance());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetBalance()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.balance, other.balance);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetVersion()).compareTo(other.isSetVersion());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetVersion()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.version, other.version);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetOwner()).compareTo(other.isSetOwner());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetOwner()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.owner, other.owner);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetStatus()).compareTo(other.isSetStatus());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetStatus()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.status, other.status);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetAddress()).compareTo(other.isSetAddress());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetAddress()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.address, other.address);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetTags()).compareTo(other.isSetTags());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetTags()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.tags, other.tags);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetHistory()).compareTo(other.isSetHistory());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetHistory()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.history, other.history);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetAvatar()).compareTo(other.isSetAvatar());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetAvatar()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.avatar, other.avatar);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetLedger()).compareTo(other.isSetLedger());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetLedger()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.ledger, other.ledger);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetAddresses()).compareTo(other.isSetAddresses());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetAddresses()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.addresses, other.addresses);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetLimits()).compareTo(other.isSetLimits());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetLimits()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.limits, other.limits);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetPrevious()).compareTo(other.isSetPrevious());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetPrevious()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.previous, other.previous);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		return 0;
+	}
+
+	public _Fields fieldForId(int fieldId) {
+		return _Fields.findByThriftId(fieldId);
+	}
+
+	public void read(org.apache.thrift.protocol.TProtocol iprot) throws org.apache.thrift.TException {
+		schemes.get(iprot.getScheme()).getScheme().read(iprot, this);
+	}
+
+	public void write(org.apache.thrift.protocol.TProtocol oprot) throws org.apache.thrift.TException {
+		schemes.get(oprot.getScheme()).getScheme().write(oprot, this);
+	}
+
+	@Override
+	public String toString() {
+		StringBuilder sb = new StringBuilder("Account(");
+		boolean first = true;
+
+		sb.append("id:");
+		if (this.id == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.id);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("balance:");
+		sb.append(this.balance);
+		first = false;
+		if (isSetVersion()) {
+			if (!first) sb.append(", ");
+			sb.append("version:");
+			sb.append(this.version);
+			first = false;
+		}
+		if (isSetOwner()) {
+			if (!first) sb.append(", ");
+			sb.append("owner:");
+			if (this.owner == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.owner);
+			}
+			first = false;
+		}
+		if (!first) sb.append(", ");
+		sb.append("status:");
+		if (this.status == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.status);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("address:");
+		if (this.address == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.address);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("tags:");
+		if (this.tags == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.tags);
+		}
+		first = false;
+		if (isSetHistory()) {
+			if (!first) sb.append(", ");
+			sb.append("history:");
+			if (this.history == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.history);
+			}
+			first = false;
+		}
+		if (isSetAvatar()) {
+			if (!first) sb.append(", ");
+			sb.append("avatar:");
+			if (this.avatar == null) {
+				sb.append("null");
+			} else {
+				org.apache.thrift.TBaseHelper.toString(this.avatar, sb);
+			}
+			first = false;
+		}
+		if (!first) sb.append(", ");
+		sb.append("ledger:");
+		if (this.ledger == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.ledger);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("addresses:");
+		if (this.addresses == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.addresses);
+		}
+		first = false;
+		if (isSetLimits()) {
+			if (!first) sb.append(", ");
+			sb.append("limits:");
+			if (this.limits == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.limits);
+			}
+			first = false;
+		}
+		if (isSetPrevious()) {
+			if (!first) sb.append(", ");
+			sb.append("previous:");
+			if (this.previous == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.previous);
+			}
+			first = false;
+		}
+		sb.append(")");
+		return sb.toString();
+	}
+
+	public void validate() throws org.apache.thrift.TException {
+		// check for required fields
+		// check for sub-struct validity
+		if (address != null) {
+			address.validate();
+		}
+	}
+
+	private void writeObject(java.io.ObjectOutputStream out) throws java.io.IOException {
+		try {
+			write(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(out)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private void readObject(java.io.ObjectInputStream in) throws java.io.IOException, ClassNotFoundException {
+		try {
+			// it doesn't seem like you should have to do this, but java serialization is wacky, and doesn't call the default constructor.
+			__isset_bitfield = 0;
+			read(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(in)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private static class AccountStandardSchemeFactory implements SchemeFactory {
+		public AccountStandardScheme getScheme() {
+			return new AccountStandardScheme();
+		}
+	}
+
+	private static class AccountStandardScheme extends StandardScheme<Account> {
+
+		public void read(org.apache.thrift.protocol.TProtocol iprot, Account struct) throws org.apache.thrift.TException {
+			org.apache.thrift.protocol.TField schemeField;
+			iprot.readStructBegin();
+			while (true) {
+				schemeField = iprot.readFieldBegin();
+				if (schemeField.type == org.apache.thrift.protocol.TType.STOP) {
+					break;
+				}
+				switch (schemeField.id) {
+					case 1: // ID
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.id = iprot.readString();
+							struct.setIdIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 2: // BALANCE
+						if (schemeField.type == org.apache.thrift.protocol.TType.I64) {
+							struct.balance = iprot.readI64();
+							struct.setBalanceIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 3: // VERSION
+						if (schemeField.type == org.apache.thrift.protocol.TType.I32) {
+							struct.version = iprot.readI32();
+							struct.setVersionIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 4: // OWNER
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.owner = iprot.readString();
+							struct.setOwnerIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 5: // STATUS
+						if (schemeField.type == org.apache.thrift.protocol.TType.I32) {
+							struct.status = Status.findByValue(iprot.readI32());
+							struct.setStatusIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 6: // ADDRESS
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRUCT) {
+							struct.address = new Address();
+							struct.address.read(iprot);
+							struct.setAddressIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 7: // TAGS
+						if (schemeField.type == org.apache.thrift.protocol.TType.LIST) {
+							org.apache.thrift.protocol.TList elem30 = iprot.readListBegin();
+							struct.tags = new ArrayList<String>(elem30.size);
+							for (int elem31 = 0; elem31 < elem30.size; ++elem31) {
+								String elem32 = iprot.readString();
+								struct.tags.add(elem32);
+							}
+							iprot.readListEnd();
+							struct.setTagsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 8: // HISTORY
+						if (schemeField.type == org.apache.thrift.protocol.TType.LIST) {
+							org.apache.thrift.protocol.TList elem33 = iprot.readListBegin();
+							struct.history = new ArrayList<Address>(elem33.size);
+							for (int elem34 = 0; elem34 < elem33.size; ++elem34) {
+								Address elem35 = new Address();
+								elem35.read(iprot);
+								struct.history.add(elem35);
+							}
+							iprot.readListEnd();
+							struct.setHistoryIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 9: // AVATAR
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.avatar = iprot.readBinary();
+							struct.setAvatarIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 10: // LEDGER
+						if (schemeField.type == org.apache.thrift.protocol.TType.MAP) {
+							org.apache.thrift.protocol.TMap elem36 = iprot.readMapBegin();
+							struct.ledger = new HashMap<String,java.util.List<Long>>(2*elem36.size);
+							for (int elem37 = 0; elem37 < elem36.size; ++elem37) {
+								String elem42 = iprot.readString();
+								org.apache.thrift.protocol.TList elem39 = iprot.readListBegin();
+								java.util.List<Long> elem38 = new ArrayList<Long>(elem39.size);
+								for (int elem40 = 0; elem40 < elem39.size; ++elem40) {
+									long elem41 = iprot.readI64();
+									elem38.add(elem41);
+								}
+								iprot.readListEnd();
+								struct.ledger.put(elem42, elem38);
+							}
+							iprot.readMapEnd();
+							struct.setLedgerIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 11: // ADDRESSES
+						if (schemeField.type == org.apache.thrift.protocol.TType.SET) {
+							org.apache.thrift.protocol.TSet elem43 = iprot.readSetBegin();
+							struct.addresses = new HashSet<Address>(2*elem43.size);
+							for (int elem44 = 0; elem44 < elem43.size; ++elem44) {
+								Address elem45 = new Address();
+								elem45.read(iprot);
+								struct.addresses.add(elem45);
+							}
+							iprot.readSetEnd();
+							struct.setAddressesIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 12: // LIMITS
+						if (schemeField.type == org.apache.thrift.protocol.TType.MAP) {
+							org.apache.thrift.protocol.TMap elem46 = iprot.readMapBegin();
+							struct.limits = new HashMap<String,Integer>(2*elem46.size);
+							for (int elem47 = 0; elem47 < elem46.size; ++elem47) {
+								String elem49 = iprot.readString();
+								int elem48 = iprot.readI32();
+								struct.limits.put(elem49, elem48);
+							}
+							iprot.readMapEnd();
+							struct.setLimitsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 13: // PREVIOUS
+						if (schemeField.type == org.apache.thrift.protocol.TType.I32) {
+							struct.previous = Status.findByValue(iprot.readI32());
+							struct.setPreviousIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					default:
+						org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+				}
+				iprot.readFieldEnd();
+			}
+			iprot.readStructEnd();
+
+			// check for required fields of primitive type, which can't be checked in the validate method
+			struct.validate();
+		}
+
+		public void write(org.apache.thrift.protocol.TProtocol oprot, Account struct) throws org.apache.thrift.TException {
+			struct.validate();
+
+			oprot.writeStructBegin(STRUCT_DESC);
+			if (struct.id != null) {
+				oprot.writeFieldBegin(ID_FIELD_DESC);
+				String elem50 = struct.id;
+				oprot.writeString(elem50);
+				oprot.writeFieldEnd();
+			}
+			oprot.writeFieldBegin(BALANCE_FIELD_DESC);
+			long elem51 = struct.balance;
+			oprot.writeI64(elem51);
+			oprot.writeFieldEnd();
+			if (struct.isSetVersion()) {
+				oprot.writeFieldBegin(VERSION_FIELD_DESC);
+				int elem52 = struct.version;
+				oprot.writeI32(elem52);
+				oprot.writeFieldEnd();
+			}
+			if (struct.owner != null) {
+				if (struct.isSetOwner()) {
+					oprot.writeFieldBegin(OWNER_FIELD_DESC);
+					String elem53 = struct.owner;
+					oprot.writeString(elem53);
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.status != null) {
+				oprot.writeFieldBegin(STATUS_FIELD_DESC);
+				Status elem54 = struct.status;
+				oprot.writeI32(elem54.getValue());
+				oprot.writeFieldEnd();
+			}
+			if (struct.address != null) {
+				oprot.writeFieldBegin(ADDRESS_FIELD_DESC);
+				struct.address.write(oprot);
+				oprot.writeFieldEnd();
+			}
+			if (struct.tags != null) {
+				oprot.writeFieldBegin(TAGS_FIELD_DESC);
+				oprot.writeListBegin(new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRING, struct.tags.size()));
+				for (String elem55 : struct.tags) {
+					String elem56 = elem55;
+					oprot.writeString(elem56);
+				}
+				oprot.writeListEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.history != null) {
+				if (struct.isSetHistory()) {
+					oprot.writeFieldBegin(HISTORY_FIELD_DESC);
+					oprot.writeListBegin(new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRUCT, struct.history.size()));
+					for (Address elem57 : struct.history) {
+						elem57.write(oprot);
+					}
+					oprot.writeListEnd();
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.avatar != null) {
+				if (struct.isSetAvatar()) {
+					oprot.writeFieldBegin(AVATAR_FIELD_DESC);
+					java.nio.ByteBuffer elem58 = struct.avatar;
+					oprot.writeBinary(elem58);
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.ledger != null) {
+				oprot.writeFieldBegin(LEDGER_FIELD_DESC);
+				oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRING, org.apache.thrift.protocol.TType.LIST, struct.ledger.size()));
+				for (Map.Entry<String, java.util.List<Long>> elem59 : struct.ledger.entrySet()) {
+					String elem60 = elem59.getKey();
+					oprot.writeString(elem60);
+					oprot.writeListBegin(new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.I64, elem59.getValue().size()));
+					for (long elem61 : elem59.getValue()) {
+						long elem62 = elem61;
+						oprot.writeI64(elem62);
+					}
+					oprot.writeListEnd();
+				}
+				oprot.writeMapEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.addresses != null) {
+				oprot.writeFieldBegin(ADDRESSES_FIELD_DESC);
+				oprot.writeSetBegin(new org.apache.thrift.protocol.TSet(org.apache.thrift.protocol.TType.STRUCT, struct.addresses.size()));
+				for (Address elem63 : struct.addresses) {
+					elem63.write(oprot);
+				}
+				oprot.writeSetEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.limits != null) {
+				if (struct.isSetLimits()) {
+					oprot.writeFieldBegin(LIMITS_FIELD_DESC);
+					oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRING, org.apache.thrift.protocol.TType.I32, struct.limits.size()));
+					for (Map.Entry<String, Integer> elem64 : struct.limits.entrySet()) {
+						String elem65 = elem64.getKey();
+						oprot.writeString(elem65);
+						int elem66 = elem64.getValue();
+						oprot.writeI32(elem66);
+					}
+					oprot.writeMapEnd();
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.previous != null) {
+				if (struct.isSetPrevious()) {
+					oprot.writeFieldBegin(PREVIOUS_FIELD_DESC);
+					Status elem67 = struct.previous;
+					oprot.writeI32(elem67.getValue());
+					oprot.writeFieldEnd();
+				}
+			}
+			oprot.writeFieldStop();
+			oprot.writeStructEnd();
+		}
+
+	}
+
+	private static class AccountTupleSchemeFactory implements SchemeFactory {
+		public AccountTupleScheme getScheme() {
+			return new AccountTupleScheme();
+		}
+	}
+
+	private static class AccountTupleScheme extends TupleScheme<Account> {
+
+		@Override
+		public void write(org.apache.thrift.protocol.TProtocol prot, Account struct) throws org.apache.thrift.TException {
+			TTupleProtocol oprot = (TTupleProtocol) prot;
+			BitSet optionals = new BitSet();
+			if (struct.isSetId()) {
+				optionals.set(0);
+			}
+			if (struct.isSetBalance()) {
+				optionals.set(1);
+			}
+			if (struct.isSetVersion()) {
+				optionals.set(2);
+			}
+			if (struct.isSetOwner()) {
+				optionals.set(3);
+			}
+			if (struct.isSetStatus()) {
+				optionals.set(4);
+			}
+			if (struct.isSetAddress()) {
+				optionals.set(5);
+			}
+			if (struct.isSetTags()) {
+				optionals.set(6);
+			}
+			if (struct.isSetHistory()) {
+				optionals.set(7);
+			}
+			if (struct.isSetAvatar()) {
+				optionals.set(8);
+			}
+			if (struct.isSetLedger()) {
+				optionals.set(9);
+			}
+			if (struct.isSetAddresses()) {
+				optionals.set(10);
+			}
+			if (struct.isSetLimits()) {
+				optionals.set(11);
+			}
+			if (struct.isSetPrevious()) {
+				optionals.set(12);
+			}
+			oprot.writeBitSet(optionals, 13);
+			if (struct.isSetId()) {
+				String elem68 = struct.id;
+				oprot.writeString(elem68);
+			}
+			if (struct.isSetBalance()) {
+				long elem69 = struct.balance;
+				oprot.writeI64(elem69);
+			}
+			if (struct.isSetVersion()) {
+				int elem70 = struct.version;
+				oprot.writeI32(elem70);
+			}
+			if (struct.isSetOwner()) {
+				String elem71 = struct.owner;
+				oprot.writeString(elem71);
+			}
+			if (struct.isSetStatus()) {
+				Status elem72 = struct.status;
+				oprot.writeI32(elem72.getValue());
+			}
+			if (struct.isSetAddress()) {
+				struct.address.write(oprot);
+			}
+			if (struct.isSetTags()) {
+				oprot.writeI32(struct.tags.size());
+				for (String elem73 : struct.tags) {
+					String elem74 = elem73;
+					oprot.writeString(elem74);
+				}
+			}
+			if (struct.isSetHistory()) {
+				oprot.writeI32(struct.history.size());
+				for (Address elem75 : struct.history) {
+					elem75.write(oprot);
+				}
+			}
+			if (struct.isSetAvatar()) {
+				java.nio.ByteBuffer elem76 = struct.avatar;
+				oprot.writeBinary(elem76);
+			}
+			if (struct.isSetLedger()) {
+				oprot.writeI32(struct.ledger.size());
+				for (Map.Entry<String, java.util.List<Long>> elem77 : struct.ledger.entrySet()) {
+					String elem78 = elem77.getKey();
+					oprot.writeString(elem78);
+					oprot.writeI32(elem77.getValue().size());
+					for (long elem79 : elem77.getValue()) {
+						long elem80 = elem79;
+						oprot.writeI64(elem80);
+					}
+				}
+			}
+			if (struct.isSetAddresses()) {
+				oprot.writeI32(struct.addresses.size());
+				for (Address elem81 : struct.addresses) {
+					elem81.write(oprot);
+				}
+			}
+			if (struct.isSetLimits()) {
+				oprot.writeI32(struct.limits.size());
+				for (Map.Entry<String, Integer> elem82 : struct.limits.entrySet()) {
+					String elem83 = elem82.getKey();
+					oprot.writeString(elem83);
+					int elem84 = elem82.getValue();
+					oprot.writeI32(elem84);
+				}
+			}
+			if (struct.isSetPrevious()) {
+				Status elem85 = struct.previous;
+				oprot.writeI32(elem85.getValue());
+			}
+		}
+
+		@Override
+		public void read(org.apache.thrift.protocol.TProtocol prot, Account struct) throws org.apache.thrift.TException {
+			TTupleProtocol iprot = (TTupleProtocol) prot;
+			BitSet incoming = iprot.readBitSet(13);
+			if (incoming.get(0)) {
+				struct.id = iprot.readString();
+				struct.setIdIsSet(true);
+			}
+			if (incoming.get(1)) {
+				struct.balance = iprot.readI64();
+				struct.setBalanceIsSet(true);
+			}
+			if (incoming.get(2)) {
+				struct.version = iprot.readI32();
+				struct.setVersionIsSet(true);
+			}
+			if (incoming.get(3)) {
+				struct.owner = iprot.readString();
+				struct.setOwnerIsSet(true);
+			}
+			if (incoming.get(4)) {
+				struct.status = Status.findByValue(iprot.readI32());
+				struct.setStatusIsSet(true);
+			}
+			if (incoming.get(5)) {
+				struct.address = new Address();
+				struct.address.read(iprot);
+				struct.setAddressIsSet(true);
+			}
+			if (incoming.get(6)) {
+				org.apache.thrift.protocol.TList elem86 = new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRING, iprot.readI32());
+				struct.tags = new ArrayList<String>(elem86.size);
+				for (int elem87 = 0; elem87 < elem86.size; ++elem87) {
+					String elem88 = iprot.readString();
+					struct.tags.add(elem88);
+				}
+				struct.setTagsIsSet(true);
+			}
+			if (incoming.get(7)) {
+				org.apache.thrift.protocol.TList elem89 = new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRUCT, iprot.readI32());
+				struct.history = new ArrayList<Address>(elem89.size);
+				for (int elem90 = 0; elem90 < elem89.size; ++elem90) {
+					Address elem91 = new Address();
+					elem91.read(iprot);
+					struct.history.add(elem91);
+				}
+				struct.setHistoryIsSet(true);
+			}
+			if (incoming.get(8)) {
+				struct.avatar = iprot.readBinary();
+				struct.setAvatarIsSet(true);
+			}
+			if (incoming.get(9)) {
+				org.apache.thrift.protocol.TMap elem92 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRING, org.apache.thrift.protocol.TType.LIST, iprot.readI32());
+				struct.ledger = new HashMap<String,java.util.List<Long>>(2*elem92.size);
+				for (int elem93 = 0; elem93 < elem92.size; ++elem93) {
+					String elem98 = iprot.readString();
+					org.apache.thrift.protocol.TList elem95 = new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.I64, iprot.readI32());
+					java.util.List<Long> elem94 = new ArrayList<Long>(elem95.size);
+					for (int elem96 = 0; elem96 < elem95.size; ++elem96) {
+						long elem97 = iprot.readI64();
+						elem94.add(elem97);
+					}
+					struct.ledger.put(elem98, elem94);
+				}
+				struct.setLedgerIsSet(true);
+			}
+			if (incoming.get(10)) {
+				org.apache.thrift.protocol.TSet elem99 = new org.apache.thrift.protocol.TSet(org.apache.thrift.protocol.TType.STRUCT, iprot.readI32());
+				struct.addresses = new HashSet<Address>(2*elem99.size);
+				for (int elem100 = 0; elem100 < elem99.size; ++elem100) {
+					Address elem101 = new Address();
+					elem101.read(iprot);
+					struct.addresses.add(elem101);
+				}
+				struct.setAddressesIsSet(true);
+			}
+			if (incoming.get(11)) {
+				org.apache.thrift.protocol.TMap elem102 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRING, org.apache.thrift.protocol.TType.I32, iprot.readI32());
+				struct.limits = new HashMap<String,Integer>(2*elem102.size);
+				for (int elem103 = 0; elem103 < elem102.size; ++elem103) {
+					String elem105 = iprot.readString();
+					int elem104 = iprot.readI32();
+					struct.limits.put(elem105, elem104);
+				}
+				struct.setLimitsIsSet(true);
+			}
+			if (incoming.get(12)) {
+				struct.previous = Status.findByValue(iprot.readI32());
+				struct.setPreviousIsSet(true);
+			}
+		}
+
+	}
+
+}
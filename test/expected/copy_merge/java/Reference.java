@@ -0,0 +1,353 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+package copy_merge;
+
+import org.apache.thrift.scheme.IScheme;
+import org.apache.thrift.scheme.SchemeFactory;
+import org.apache.thrift.scheme.StandardScheme;
+
+import org.apache.thrift.scheme.TupleScheme;
+import org.apache.thrift.protocol.TTupleProtocol;
+import org.apache.thrift.protocol.TProtocolException;
+import org.apache.thrift.EncodingUtils;
+import org.apache.thrift.TException;
+import org.apache.thrift.async.AsyncMethodCallback;
+import org.apache.thrift.server.AbstractNonblockingServer.*;
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import javax.annotation.Generated;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+@Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
+public class Reference extends org.apache.thrift.TUnion<Reference, Reference._Fields> {
+	private static final org.apache.thrift.protocol.TStruct STRUCT_DESC = new org.apache.thrift.protocol.TStruct("Reference");
+
+	private static final org.apache.thrift.protocol.TField ID_FIELD_DESC = new org.apache.thrift.protocol.TField("id", org.apache.thrift.protocol.TType.STRING, (short)1);
+	private static final org.apache.thrift.protocol.TField ADDRESS_FIELD_DESC = new org.apache.thrift.protocol.TField("address", org.apache.thrift.protocol.TType.STRUCT, (short)2);
+
+	/** The set of fields this struct contains, along with convenience methods for finding and manipulating them. */
+	public enum _Fields implements org.apache.thrift.TFieldIdEnum {
+		ID((short)1, "id"),
+		ADDRESS((short)2, "address")
+		;
+
+		private static final Map<String, _Fields> byName = new HashMap<String, _Fields>();
+
+		static {
+			for (_Fields field : EnumSet.allOf(_Fields.class)) {
+				byName.put(field.getFieldName(), field);
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, or null if its not found.
+		 */
+		public static _Fields findByThriftId(int fieldId) {
+			switch(fieldId) {
+				case 1: // ID
+					return ID;
+				case 2: // ADDRESS
+					return ADDRESS;
+				default:
+					return null;
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, throwing an exception
+		 * if it is not found.
+		 */
+		public static _Fields findByThriftIdOrThrow(int fieldId) {
+			_Fields fields = findByThriftId(fieldId);
+			if (fields == null) throw new IllegalArgumentException("Field " + fieldId + " doesn't exist!");
+			return fields;
+		}
+
+		/**
+		 * Find the _Fields constant that matches name, or null if its not found.
+		 */
+		public static _Fields findByName(String name) {
+			return byName.get(name);
+		}
+
+		private final short _thriftId;
+		private final String _fieldName;
+
+		_Fields(short thriftId, String fieldName) {
+			_thriftId = thriftId;
+			_fieldName = fieldName;
+		}
+
+		public short getThriftFieldId() {
+			return _thriftId;
+		}
+
+		public String getFieldName() {
+			return _fieldName;
+		}
+	}
+
+	public Reference() {
+		super();
+	}
+
+	public Reference(_Fields setField, Object value) {
+		super(setField, value);
+	}
+
+	public Reference(Reference other) {
+		super(other);
+	}
+	public Reference deepCopy() {
+		return new Reference(this);
+	}
+
+	public static Reference id(String value) {
+		Reference x = new Reference();
+		x.setId(value);
+		return x;
+	}
+
+	public static Reference address(Address value) {
+		Reference x = new Reference();
+		x.setAddress(value);
+		return x;
+	}
+
+	@Override
+	protected void checkType(_Fields setField, Object value) throws ClassCastException {
+		switch (setField) {
+			case ID:
+				if (value instanceof String) {
+					break;
+				}
+				throw new ClassCastException("Was expecting value of type String for field 'id', but got " + value.getClass().getSimpleName());
+			case ADDRESS:
+				if (value instanceof Address) {
+					break;
+				}
+				throw new ClassCastException("Was expecting value of type Address for field 'address', but got " + value.getClass().getSimpleName());
+			default:
+				throw new IllegalArgumentException("Unknown field id " + setField);
+		}
+	}
+
+	@Override
+	protected Object standardSchemeReadValue(org.apache.thrift.protocol.TProtocol iprot, org.apache.thrift.protocol.TField field) throws org.apache.thrift.TException {
+		_Fields setField = _Fields.findByThriftId(field.id);
+		if (setField != null) {
+			switch (setField) {
+				case ID:
+					if (field.type == ID_FIELD_DESC.type) {
+						String id = iprot.readString();
+						return id;
+					} else {
+						org.apache.thrift.protocol.TProtocolUtil.skip(iprot, field.type);
+						return null;
+					}
+				case ADDRESS:
+					if (field.type == ADDRESS_FIELD_DESC.type) {
+						Address address = new Address();
+						address.read(iprot);
+						return address;
+					} else {
+						org.apache.thrift.protocol.TProtocolUtil.skip(iprot, field.type);
+						return null;
+					}
+				default:
+					throw new IllegalStateException("setField wasn't null, but didn't match any of the case statements!");
+			}
+		} else {
+			org.apache.thrift.protocol.TProtocolUtil.skip(iprot, field.type);
+			return null;
+		}
+	}
+
+	@Override
+	protected void standardSchemeWriteValue(org.apache.thrift.protocol.TProtocol oprot) throws org.apache.thrift.TException {
+		switch (setField_) {
+			case ID:
+				String id = (String)value_;
+				String elem106 = id;
+				oprot.writeString(elem106);
+				return;
+			case ADDRESS:
+				Address address = (Address)value_;
+				address.write(oprot);
+				return;
+			default:
+				throw new IllegalStateException("Cannot write union with unknown field " + setField_);
+		}
+	}
+
+	@Override
+	protected Object tupleSchemeReadValue(org.apache.thrift.protocol.TProtocol iprot, short fieldID) throws org.apache.thrift.TException {
+		_Fields setField = _Fields.findByThriftId(fieldID);
+		if (setField != null) {
+			switch (setField) {
+				case ID:
+					String id = iprot.readString();
+					return id;
+				case ADDRESS:
+					Address address = new Address();
+					address.read(iprot);
+					return address;
+				default:
+					throw new IllegalStateException("setField wasn't null, but didn't match any of the case statements!");
+			}
+		} else {
+			throw new TProtocolException("Couldn't find a field with field id " + fieldID);
+		}
+	}
+
+	@Override
+	protected void tupleSchemeWriteValue(org.apache.thrift.protocol.TProtocol oprot) throws org.apache.thrift.TException {
+		switch (setField_) {
+			case ID:
+				String id = (String)value_;
+				String elem107 = id;
+				oprot.writeString(elem107);
+				return;
+			case ADDRESS:
+				Address address = (Address)value_;
+				address.write(oprot);
+				return;
+			default:
+				throw new IllegalStateException("Cannot write union with unknown field " + setField_);
+		}
+	}
+
+	@Override
+	protected org.apache.thrift.protocol.TField getFieldDesc(_Fields setField) {
+		switch (setField) {
+			case ID:
+				return ID_FIELD_DESC;
+			case ADDRESS:
+				return ADDRESS_FIELD_DESC;
+			default:
+				throw new IllegalArgumentException("Unknown field id " + setField);
+		}
+	}
+
+	@Override
+	protected org.apache.thrift.protocol.TStruct getStructDesc() {
+		return STRUCT_DESC;
+	}
+
+	@Override
+	protected _Fields enumForId(short id) {
+		return _Fields.findByThriftIdOrThrow(id);
+	}
+
+	public _Fields fieldForId(int fieldId) {
+		return _Fields.findByThriftId(fieldId);
+	}
+
+
+	public String getId() {
+		if (getSetField() == _Fields.ID) {
+			return (String)getFieldValue();
+		} else {
+			throw new RuntimeException("Cannot get field 'id' because union is currently set to " + getFieldDesc(getSetField()).name);
+		}
+	}
+
+	public void setId(String value) {
+		if (value == null) throw new NullPointerException();
+		setField_ = _Fields.ID;
+		value_ = value;
+	}
+
+	public Address getAddress() {
+		if (getSetField() == _Fields.ADDRESS) {
+			return (Address)getFieldValue();
+		} else {
+			throw new RuntimeException("Cannot get field 'address' because union is currently set to " + getFieldDesc(getSetField()).name);
+		}
+	}
+
+	public void setAddress(Address value) {
+		if (value == null) throw new NullPointerException();
+		setField_ = _Fields.ADDRESS;
+		value_ = value;
+	}
+
+	public boolean isSetId() {
+		return setField_ == _Fields.ID;
+	}
+
+	public boolean isSetAddress() {
+		return setField_ == _Fields.ADDRESS;
+	}
+
+
+	public boolean equals(Object other) {
+		if (other instanceof Reference) {
+			return equals((Reference)other);
+		} else {
+			return false;
+		}
+	}
+
+	public boolean equals(Reference other) {
+		return other != null && getSetField() == other.getSetField() && getFieldValue().equals(other.getFieldValue());
+	}
+
+	@Override
+	public int compareTo(Reference other) {
+		int lastComparison = org.apache.thrift.TBaseHelper.compareTo(getSetField(), other.getSetField());
+		if (lastComparison == 0) {
+			return org.apache.thrift.TBaseHelper.compareTo(getFieldValue(), other.getFieldValue());
+		}
+		return lastComparison;
+	}
+
+
+	@Override
+	public int hashCode() {
+		List<Object> list = new ArrayList<Object>();
+		list.add(this.getClass().getName());
+		org.apache.thrift.TFieldIdEnum setField = getSetField();
+		if (setField != null) {
+			list.add(setField.getThriftFieldId());
+			Object value = getFieldValue();
+			if (value instanceof org.apache.thrift.TEnum) {
+				list.add(((org.apache.thrift.TEnum)getFieldValue()).getValue());
+			} else {
+				list.add(value);
+			}
+		}
+		return list.hashCode();
+	}
+	private void writeObject(java.io.ObjectOutputStream out) throws java.io.IOException {
+		try {
+			write(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(out)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private void readObject(java.io.ObjectInputStream in) throws java.io.IOException, ClassNotFoundException {
+		try {
+			read(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(in)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+}
@@ -0,0 +1,588 @@
+#
+# Autogenerated by Frugal Compiler (2.23.0)
+#
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+#
+
+from thrift.Thrift import TType, TMessageType, TException, TApplicationException
+
+from frugal.util import make_hashable
+from thrift.transport import TTransport
+from thrift.protocol import TBinaryProtocol, TProtocol
+import copy
+
+
+class Status(int):
+    OPEN = 1
+    CLOSED = 2
+
+    _VALUES_TO_NAMES = {
+        1: "OPEN",
+        2: "CLOSED",
+    }
+
+    _NAMES_TO_VALUES = {
+        "OPEN": 1,
+        "CLOSED": 2,
+    }
+
+class Address(object):
+    """
+    Attributes:
+     - street
+     - city
+    """
+    def __init__(self, street=None, city=None):
+        self.street = street
+        self.city = city
+
+    def is_set_city(self):
+        return self.city is not None
+
+    def unset_city(self):
+        self.city = None
+
+    def deep_copy(self):
+        return copy.deepcopy(self)
+
+    def merge_from(self, other):
+        if other.street is not None:
+            self.street = other.street
+        if other.city is not None:
+            self.city = other.city
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.STRING:
+                    self.street = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 2:
+                if ftype == TType.STRING:
+                    self.city = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('Address')
+        if self.street is not None:
+            oprot.writeFieldBegin('street', TType.STRING, 1)
+            oprot.writeString(self.street)
+            oprot.writeFieldEnd()
+        if self.city is not None:
+            oprot.writeFieldBegin('city', TType.STRING, 2)
+            oprot.writeString(self.city)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.street))
+        value = (value * 31) ^ hash(make_hashable(self.city))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
+class Account(object):
+    """
+    Attributes:
+     - id
+     - balance
+     - version
+     - owner
+     - status
+     - address
+     - tags
+     - history
+     - avatar
+     - ledger
+     - addresses
+     - limits
+     - previous
+    """
+    _DEFAULT_owner_MARKER = "nobody"
+    _DEFAULT_limits_MARKER = object()
+    def __init__(self, id=None, balance=None, version=None, owner=_DEFAULT_owner_MARKER, status=None, address=None, tags=None, history=None, avatar=None, ledger=None, addresses=None, limits=_DEFAULT_limits_MARKER, previous=None):
+        self.id = id
+        self.balance = balance
+        self.version = version
+        self.owner = owner
+        self.status = status
+        self.address = address
+        self.tags = tags
+        self.history = history
+        self.avatar = avatar
+        self.ledger = ledger
+        self.addresses = addresses
+        if limits is self._DEFAULT_limits_MARKER:
+            limits = {
+                "daily": 100,
+            }
+        self.limits = limits
+        self.previous = previous
+
+    def is_set_version(self):
+        return self.version is not None
+
+    def unset_version(self):
+        self.version = None
+
+    def is_set_owner(self):
+        return self.owner is not None
+
+    def unset_owner(self):
+        self.owner = None
+
+    def is_set_history(self):
+        return self.history is not None
+
+    def unset_history(self):
+        self.history = None
+
+    def is_set_avatar(self):
+        return self.avatar is not None
+
+    def unset_avatar(self):
+        self.avatar = None
+
+    def is_set_limits(self):
+        return self.limits is not None
+
+    def unset_limits(self):
+        self.limits = None
+
+    def is_set_previous(self):
+        return self.previous is not None
+
+    def unset_previous(self):
+        self.previous = None
+
+    def deep_copy(self):
+        return copy.deepcopy(self)
+
+    def merge_from(self, other):
+        if other.id is not None:
+            self.id = other.id
+        if other.balance is not None:
+            self.balance = other.balance
+        if other.version is not None:
+            self.version = other.version
+        if other.owner is not None:
+            self.owner = other.owner
+        if other.status is not None:
+            self.status = other.status
+        if other.address is not None:
+            self.address = copy.deepcopy(other.address)
+        if other.tags is not None:
+            self.tags = copy.deepcopy(other.tags)
+        if other.history is not None:
+            self.history = copy.deepcopy(other.history)
+        if other.avatar is not None:
+            self.avatar = other.avatar
+        if other.ledger is not None:
+            self.ledger = copy.deepcopy(other.ledger)
+        if other.addresses is not None:
+            self.addresses = copy.deepcopy(other.addresses)
+        if other.limits is not None:
+            self.limits = copy.deepcopy(other.limits)
+        if other.previous is not None:
+            self.previous = other.previous
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.STRING:
+                    self.id = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 2:
+                if ftype == TType.I64:
+                    self.balance = iprot.readI64()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 3:
+                if ftype == TType.I32:
+                    self.version = iprot.readI32()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 4:
+                if ftype == TType.STRING:
+                    self.owner = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 5:
+                if ftype == TType.I32:
+                    self.status = Status(iprot.readI32())
+                else:
+                    iprot.skip(ftype)
+            elif fid == 6:
+                if ftype == TType.STRUCT:
+                    self.address = Address()
+                    self.address.read(iprot)
+                else:
+                    iprot.skip(ftype)
+            elif fid == 7:
+                if ftype == TType.LIST:
+                    self.tags = []
+                    (_, elem0) = iprot.readListBegin()
+                    for _ in range(elem0):
+                        elem1 = iprot.readString()
+                        self.tags.append(elem1)
+                    iprot.readListEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 8:
+                if ftype == TType.LIST:
+                    self.history = []
+                    (_, elem2) = iprot.readListBegin()
+                    for _ in range(elem2):
+                        elem3 = Address()
+                        elem3.read(iprot)
+                        self.history.append(elem3)
+                    iprot.readListEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 9:
+                if ftype == TType.STRING:
+                    self.avatar = iprot.readBinary()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 10:
+                if ftype == TType.MAP:
+                    self.ledger = {}
+                    (_, _, elem4) = iprot.readMapBegin()
+                    for _ in range(elem4):
+                        elem6 = iprot.readString()
+                        elem5 = []
+                        (_, elem7) = iprot.readListBegin()
+                        for _ in range(elem7):
+                            elem8 = iprot.readI64()
+                            elem5.append(elem8)
+                        iprot.readListEnd()
+                        self.ledger[elem6] = elem5
+                    iprot.readMapEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 11:
+                if ftype == TType.SET:
+                    self.addresses = set()
+                    (_, elem9) = iprot.readSetBegin()
+                    for _ in range(elem9):
+                        elem10 = Address()
+                        elem10.read(iprot)
+                        self.addresses.add(elem10)
+                    iprot.readSetEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 12:
+                if ftype == TType.MAP:
+                    self.limits = {}
+                    (_, _, elem11) = iprot.readMapBegin()
+                    for _ in range(elem11):
+                        elem13 = iprot.readString()
+                        elem12 = iprot.readI32()
+                        self.limits[elem13] = elem12
+                    iprot.readMapEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 13:
+                if ftype == TType.I32:
+                    self.previous = Status(iprot.readI32())
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('Account')
+        if self.id is not None:
+            oprot.writeFieldBegin('id', TType.STRING, 1)
+            oprot.writeString(self.id)
+            oprot.writeFieldEnd()
+        if self.balance is not None:
+            oprot.writeFieldBegin('balance', TType.I64, 2)
+            oprot.writeI64(self.balance)
+            oprot.writeFieldEnd()
+        if self.version is not None:
+            oprot.writeFieldBegin('version', TType.I32, 3)
+            oprot.writeI32(self.version)
+            oprot.writeFieldEnd()
+        if self.owner is not None:
+            oprot.writeFieldBegin('owner', TType.STRING, 4)
+            oprot.writeString(self.owner)
+            oprot.writeFieldEnd()
+        if self.status is not None:
+            oprot.writeFieldBegin('status', TType.I32, 5)
+            oprot.writeI32(self.status)
+            oprot.writeFieldEnd()
+        if self.address is not None:
+            oprot.writeFieldBegin('address', TType.STRUCT, 6)
+            self.address.write(oprot)
+            oprot.writeFieldEnd()
+        if self.tags is not None:
+            oprot.writeFieldBegin('tags', TType.LIST, 7)
+            oprot.writeListBegin(TType.STRING, len(self.tags))
+            for elem14 in self.tags:
+                oprot.writeString(elem14)
+            oprot.writeListEnd()
+            oprot.writeFieldEnd()
+        if self.history is not None:
+            oprot.writeFieldBegin('history', TType.LIST, 8)
+            oprot.writeListBegin(TType.STRUCT, len(self.history))
+            for elem15 in self.history:
+                elem15.write(oprot)
+            oprot.writeListEnd()
+            oprot.writeFieldEnd()
+        if self.avatar is not None:
+            oprot.writeFieldBegin('avatar', TType.STRING, 9)
+            oprot.writeBinary(self.avatar)
+            oprot.writeFieldEnd()
+        if self.ledger is not None:
+            oprot.writeFieldBegin('ledger', TType.MAP, 10)
+            oprot.writeMapBegin(TType.STRING, TType.LIST, len(self.ledger))
+            for elem17, elem16 in self.ledger.items():
+                oprot.writeString(elem17)
+                oprot.writeListBegin(TType.I64, len(elem16))
+                for elem18 in elem16:
+                    oprot.writeI64(elem18)
+                oprot.writeListEnd()
+            oprot.writeMapEnd()
+            oprot.writeFieldEnd()
+        if self.addresses is not None:
+            oprot.writeFieldBegin('addresses', TType.SET, 11)
+            oprot.writeSetBegin(TType.STRUCT, len(self.addresses))
+            for elem19 in self.addresses:
+                elem19.write(oprot)
+            oprot.writeSetEnd()
+            oprot.writeFieldEnd()
+        if self.limits is not None:
+            oprot.writeFieldBegin('limits', TType.MAP, 12)
+            oprot.writeMapBegin(TType.STRING, TType.I32, len(self.limits))
+            for elem21, elem20 in self.limits.items():
+                oprot.writeString(elem21)
+                oprot.writeI32(elem20)
+            oprot.writeMapEnd()
+            oprot.writeFieldEnd()
+        if self.previous is not None:
+            oprot.writeFieldBegin('previous', TType.I32, 13)
+            oprot.writeI32(self.previous)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.id))
+        value = (value * 31) ^ hash(make_hashable(self.balance))
+        value = (value * 31) ^ hash(make_hashable(self.version))
+        value = (value * 31) ^ hash(make_hashable(self.owner))
+        value = (value * 31) ^ hash(make_hashable(self.status))
+        value = (value * 31) ^ hash(make_hashable(self.address))
+        value = (value * 31) ^ hash(make_hashable(self.tags))
+        value = (value * 31) ^ hash(make_hashable(self.history))
+        value = (value * 31) ^ hash(make_hashable(self.avatar))
+        value = (value * 31) ^ hash(make_hashable(self.ledger))
+        value = (value * 31) ^ hash(make_hashable(self.addresses))
+        value = (value * 31) ^ hash(make_hashable(self.limits))
+        value = (value * 31) ^ hash(make_hashable(self.previous))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
+class Reference(object):
+    """
+    Attributes:
+     - id
+     - address
+    """
+    def __init__(self, id=None, address=None):
+        self.id = id
+        self.address = address
+
+    def is_set_id(self):
+        return self.id is not None
+
+    def unset_id(self):
+        self.id = None
+
+    def is_set_address(self):
+        return self.address is not None
+
+    def unset_address(self):
+        self.address = None
+
+    def deep_copy(self):
+        return copy.deepcopy(self)
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.STRING:
+                    self.id = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 2:
+                if ftype == TType.STRUCT:
+                    self.address = Address()
+                    self.address.read(iprot)
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('Reference')
+        if self.id is not None:
+            oprot.writeFieldBegin('id', TType.STRING, 1)
+            oprot.writeString(self.id)
+            oprot.writeFieldEnd()
+        if self.address is not None:
+            oprot.writeFieldBegin('address', TType.STRUCT, 2)
+            self.address.write(oprot)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        set_fields = 0
+        if self.id is not None:
+            set_fields += 1
+        if self.address is not None:
+            set_fields += 1
+        if set_fields != 1:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='The union did not have exactly one field set, {} were set'.format(set_fields))
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.id))
+        value = (value * 31) ^ hash(make_hashable(self.address))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
+class AccountError(TException):
+    """
+    Attributes:
+     - message
+    """
+    def __init__(self, message=None):
+        self.message = message
+
+    def deep_copy(self):
+        return copy.deepcopy(self)
+
+    def merge_from(self, other):
+        if other.message is not None:
+            self.message = other.message
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.STRING:
+                    self.message = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('AccountError')
+        if self.message is not None:
+            oprot.writeFieldBegin('message', TType.STRING, 1)
+            oprot.writeString(self.message)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        return
+
+    def __str__(self):
+        return repr(self)
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.message))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
@@ -14,6 +14,11 @@ import 'package:actual_base_dart/actual_base_dart.dart' as t_actual_base_dart;
 
 
 abstract class FBaseFoo {
+  /// Describes the BaseFoo service contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'base.frugal', 'service', 'BaseFoo',
+      '4516af8e48fd0b4a04bd7d4e0e10d58b72c50666e67f02ffab8a7f02dea3d408', '2.23.0',
+      const ['basePing']);
 
   Future basePing(frugal.FContext ctx);
 }
@@ -0,0 +1,7 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'package:w_transport/browser.dart' show configureWTransportForBrowser;
+
+/// Configures w_transport for the platform.
+void configurePlatform() => configureWTransportForBrowser();
@@ -0,0 +1,15 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'package:frugal/frugal.dart' as frugal;
+
+import 'f_platform_vm.dart'
+    if (dart.library.html) 'f_platform_browser.dart' as platform;
+
+/// Returns an [frugal.FTransport] making requests to the Frugal server at
+/// the given WebSocket [uri], after configuring w_transport for the platform
+/// the code runs on: the Dart VM or a browser.
+frugal.FTransport newFilterWebSocketTransport(Uri uri, {int requestSizeLimit: 0}) {
+  platform.configurePlatform();
+  return new frugal.FWebSocketTransport(uri, requestSizeLimit: requestSizeLimit);
+}
@@ -0,0 +1,23 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:typed_data' show Uint8List;
+import 'package:built_collection/built_collection.dart';
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:containers/containers.dart' as t_containers;
+
+import 'dart:convert' show UTF8;
+
+class ContainersConstants {
+  static final BuiltList<String> DEFAULT_NAMES = new BuiltList<String>([
+    "a",
+    "b",
+  ]);
+  static final BuiltSet<int> DEFAULT_IDS = new BuiltSet<int>([
+    1,
+    2,
+  ]);
+  static final BuiltMap<String, int> DEFAULT_COUNTS = new BuiltMap<String, int>({
+    "a": 1,
+  });
+}
@@ -0,0 +1,654 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:typed_data' show Uint8List;
+import 'package:built_collection/built_collection.dart';
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:containers/containers.dart' as t_containers;
+
+class Inventory implements thrift.TBase {
+  static final thrift.TStruct _STRUCT_DESC = new thrift.TStruct("Inventory");
+  static final thrift.TField _NAMES_FIELD_DESC = new thrift.TField("names", thrift.TType.LIST, 1);
+  static final thrift.TField _IDS_FIELD_DESC = new thrift.TField("ids", thrift.TType.SET, 2);
+  static final thrift.TField _COUNTS_FIELD_DESC = new thrift.TField("counts", thrift.TType.MAP, 3);
+  static final thrift.TField _TAGS_BY_COLOR_FIELD_DESC = new thrift.TField("tagsByColor", thrift.TType.MAP, 4);
+  static final thrift.TField _FLAGS_FIELD_DESC = new thrift.TField("flags", thrift.TType.MAP, 5);
+  static final thrift.TField _NOTES_FIELD_DESC = new thrift.TField("notes", thrift.TType.MAP, 6);
+  static final thrift.TField _NESTED_FIELD_DESC = new thrift.TField("nested", thrift.TType.MAP, 7);
+  static final thrift.TField _TAG_SETS_FIELD_DESC = new thrift.TField("tagSets", thrift.TType.LIST, 8);
+
+  BuiltList<String> _names;
+  static const int NAMES = 1;
+  BuiltSet<int> _ids;
+  static const int IDS = 2;
+  BuiltMap<String, int> _counts;
+  static const int COUNTS = 3;
+  BuiltMap<int, BuiltList<t_containers.Tag>> _tagsByColor;
+  static const int TAGSBYCOLOR = 4;
+  BuiltMap<bool, String> _flags;
+  static const int FLAGS = 5;
+  BuiltMap<t_containers.Tag, String> _notes;
+  static const int NOTES = 6;
+  BuiltMap<String, BuiltMap<int, BuiltSet<String>>> _nested;
+  static const int NESTED = 7;
+  BuiltList<BuiltSet<t_containers.Tag>> _tagSets;
+  static const int TAGSETS = 8;
+
+
+  Inventory() {
+    this.names = t_containers.ContainersConstants.DEFAULT_NAMES;
+  }
+
+  BuiltList<String> get names => this._names;
+
+  set names(BuiltList<String> names) {
+    this._names = names;
+  }
+
+  bool isSetNames() => this.names != null;
+
+  unsetNames() {
+    this.names = null;
+  }
+
+  BuiltSet<int> get ids => this._ids;
+
+  set ids(BuiltSet<int> ids) {
+    this._ids = ids;
+  }
+
+  bool isSetIds() => this.ids != null;
+
+  unsetIds() {
+    this.ids = null;
+  }
+
+  BuiltMap<String, int> get counts => this._counts;
+
+  set counts(BuiltMap<String, int> counts) {
+    this._counts = counts;
+  }
+
+  bool isSetCounts() => this.counts != null;
+
+  unsetCounts() {
+    this.counts = null;
+  }
+
+  BuiltMap<int, BuiltList<t_containers.Tag>> get tagsByColor => this._tagsByColor;
+
+  set tagsByColor(BuiltMap<int, BuiltList<t_containers.Tag>> tagsByColor) {
+    this._tagsByColor = tagsByColor;
+  }
+
+  bool isSetTagsByColor() => this.tagsByColor != null;
+
+  unsetTagsByColor() {
+    this.tagsByColor = null;
+  }
+
+  BuiltMap<bool, String> get flags => this._flags;
+
+  set flags(BuiltMap<bool, String> flags) {
+    this._flags = flags;
+  }
+
+  bool isSetFlags() => this.flags != null;
+
+  unsetFlags() {
+    this.flags = null;
+  }
+
+  BuiltMap<t_containers.Tag, String> get notes => this._notes;
+
+  set notes(BuiltMap<t_containers.Tag, String> notes) {
+    this._notes = notes;
+  }
+
+  bool isSetNotes() => this.notes != null;
+
+  unsetNotes() {
+    this.notes = null;
+  }
+
+  BuiltMap<String, BuiltMap<int, BuiltSet<String>>> get nested => this._nested;
+
+  set nested(BuiltMap<String, BuiltMap<int, BuiltSet<String>>> nested) {
+    this._nested = nested;
+  }
+
+  bool isSetNested() => this.nested != null;
+
+  unsetNested() {
+    this.nested = null;
+  }
+
+  BuiltList<BuiltSet<t_containers.Tag>> get tagSets => this._tagSets;
+
+  set tagSets(BuiltList<BuiltSet<t_containers.Tag>> tagSets) {
+    this._tagSets = tagSets;
+  }
+
+  bool isSetTagSets() => this.tagSets != null;
+
+  unsetTagSets() {
+    this.tagSets = null;
+  }
+
+  getFieldValue(int fieldID) {
+    switch (fieldID) {
+      case NAMES:
+        return this.names;
+      case IDS:
+        return this.ids;
+      case COUNTS:
+        return this.counts;
+      case TAGSBYCOLOR:
+        return this.tagsByColor;
+      case FLAGS:
+        return this.flags;
+      case NOTES:
+        return this.notes;
+      case NESTED:
+        return this.nested;
+      case TAGSETS:
+        return this.tagSets;
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  setFieldValue(int fieldID, Object value) {
+    switch(fieldID) {
+      case NAMES:
+        if(value == null) {
+          unsetNames();
+        } else {
+          this.names = value as BuiltList<String>;
+        }
+        break;
+
+      case IDS:
+        if(value == null) {
+          unsetIds();
+        } else {
+          this.ids = value as BuiltSet<int>;
+        }
+        break;
+
+      case COUNTS:
+        if(value == null) {
+          unsetCounts();
+        } else {
+          this.counts = value as BuiltMap<String, int>;
+        }
+        break;
+
+      case TAGSBYCOLOR:
+        if(value == null) {
+          unsetTagsByColor();
+        } else {
+          this.tagsByColor = value as BuiltMap<int, BuiltList<t_containers.Tag>>;
+        }
+        break;
+
+      case FLAGS:
+        if(value == null) {
+          unsetFlags();
+        } else {
+          this.flags = value as BuiltMap<bool, String>;
+        }
+        break;
+
+      case NOTES:
+        if(value == null) {
+          unsetNotes();
+        } else {
+          this.notes = value as BuiltMap<t_containers.Tag, String>;
+        }
+        break;
+
+      case NESTED:
+        if(value == null) {
+          unsetNested();
+        } else {
+          this.nested = value as BuiltMap<String, BuiltMap<int, BuiltSet<String>>>;
+        }
+        break;
+
+      case TAGSETS:
+        if(value == null) {
+          unsetTagSets();
+        } else {
+          this.tagSets = value as BuiltList<BuiltSet<t_containers.Tag>>;
+        }
+        break;
+
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  // Returns true if the field corresponding to fieldID is set (has been assigned a value) and false otherwise
+  bool isSet(int fieldID) {
+    switch(fieldID) {
+      case NAMES:
+        return isSetNames();
+      case IDS:
+        return isSetIds();
+      case COUNTS:
+        return isSetCounts();
+      case TAGSBYCOLOR:
+        return isSetTagsByColor();
+      case FLAGS:
+        return isSetFlags();
+      case NOTES:
+        return isSetNotes();
+      case NESTED:
+        return isSetNested();
+      case TAGSETS:
+        return isSetTagSets();
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  read(thrift.TProtocol iprot) {
+    thrift.TField field;
+    iprot.readStructBegin();
+    while(true) {
+      field = iprot.readFieldBegin();
+      if(field.type == thrift.TType.STOP) {
+        break;
+      }
+      switch(field.id) {
+        case NAMES:
+          if(field.type == thrift.TType.LIST) {
+            thrift.TList elem0 = iprot.readListBegin();
+            var elem3 = new ListBuilder<String>();
+            for(int elem2 = 0; elem2 < elem0.length; ++elem2) {
+              String elem1 = iprot.readString();
+              elem3.add(elem1);
+            }
+            iprot.readListEnd();
+            names = elem3.build();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case IDS:
+          if(field.type == thrift.TType.SET) {
+            thrift.TSet elem4 = iprot.readSetBegin();
+            var elem7 = new SetBuilder<int>();
+            for(int elem6 = 0; elem6 < elem4.length; ++elem6) {
+              int elem5 = iprot.readI32();
+              elem7.add(elem5);
+            }
+            iprot.readSetEnd();
+            ids = elem7.build();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case COUNTS:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem8 = iprot.readMapBegin();
+            var elem11 = new MapBuilder<String, int>();
+            for(int elem10 = 0; elem10 < elem8.length; ++elem10) {
+              String elem12 = iprot.readString();
+              int elem9 = iprot.readI64();
+              elem11[elem12] = elem9;
+            }
+            iprot.readMapEnd();
+            counts = elem11.build();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case TAGSBYCOLOR:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem13 = iprot.readMapBegin();
+            var elem20 = new MapBuilder<int, BuiltList<t_containers.Tag>>();
+            for(int elem19 = 0; elem19 < elem13.length; ++elem19) {
+              int elem21 = iprot.readI32();
+              thrift.TList elem15 = iprot.readListBegin();
+              var elem18 = new ListBuilder<t_containers.Tag>();
+              for(int elem17 = 0; elem17 < elem15.length; ++elem17) {
+                t_containers.Tag elem16 = new t_containers.Tag();
+                elem16.read(iprot);
+                elem18.add(elem16);
+              }
+              iprot.readListEnd();
+              BuiltList<t_containers.Tag> elem14 = elem18.build();
+              elem20[elem21] = elem14;
+            }
+            iprot.readMapEnd();
+            tagsByColor = elem20.build();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case FLAGS:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem22 = iprot.readMapBegin();
+            var elem25 = new MapBuilder<bool, String>();
+            for(int elem24 = 0; elem24 < elem22.length; ++elem24) {
+              bool elem26 = iprot.readBool();
+              String elem23 = iprot.readString();
+              elem25[elem26] = elem23;
+            }
+            iprot.readMapEnd();
+            flags = elem25.build();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case NOTES:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem27 = iprot.readMapBegin();
+            var elem30 = new MapBuilder<t_containers.Tag, String>();
+            for(int elem29 = 0; elem29 < elem27.length; ++elem29) {
+              t_containers.Tag elem31 = new t_containers.Tag();
+              elem31.read(iprot);
+              String elem28 = iprot.readString();
+              elem30[elem31] = elem28;
+            }
+            iprot.readMapEnd();
+            notes = elem30.build();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case NESTED:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem32 = iprot.readMapBegin();
+            var elem44 = new MapBuilder<String, BuiltMap<int, BuiltSet<String>>>();
+            for(int elem43 = 0; elem43 < elem32.length; ++elem43) {
+              String elem45 = iprot.readString();
+              thrift.TMap elem34 = iprot.readMapBegin();
+              var elem41 = new MapBuilder<int, BuiltSet<String>>();
+              for(int elem40 = 0; elem40 < elem34.length; ++elem40) {
+                int elem42 = iprot.readI32();
+                thrift.TSet elem36 = iprot.readSetBegin();
+                var elem39 = new SetBuilder<String>();
+                for(int elem38 = 0; elem38 < elem36.length; ++elem38) {
+                  String elem37 = iprot.readString();
+                  elem39.add(elem37);
+                }
+                iprot.readSetEnd();
+                BuiltSet<String> elem35 = elem39.build();
+                elem41[elem42] = elem35;
+              }
+              iprot.readMapEnd();
+              BuiltMap<int, BuiltSet<String>> elem33 = elem41.build();
+              elem44[elem45] = elem33;
+            }
+            iprot.readMapEnd();
+            nested = elem44.build();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case TAGSETS:
+          if(field.type == thrift.TType.LIST) {
+            thrift.TList elem46 = iprot.readListBegin();
+            var elem53 = new ListBuilder<BuiltSet<t_containers.Tag>>();
+            for(int elem52 = 0; elem52 < elem46.length; ++elem52) {
+              thrift.TSet elem48 = iprot.readSetBegin();
+              var elem51 = new SetBuilder<t_containers.Tag>();
+              for(int elem50 = 0; elem50 < elem48.length; ++elem50) {
+                t_containers.Tag elem49 = new t_containers.Tag();
+                elem49.read(iprot);
+                elem51.add(elem49);
+              }
+              iprot.readSetEnd();
+              BuiltSet<t_containers.Tag> elem47 = elem51.build();
+              elem53.add(elem47);
+            }
+            iprot.readListEnd();
+            tagSets = elem53.build();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, field.type);
+          break;
+      }
+      iprot.readFieldEnd();
+    }
+    iprot.readStructEnd();
+
+    // check for required fields of primitive type, which can't be checked in the validate method
+    validate();
+  }
+
+  write(thrift.TProtocol oprot) {
+    validate();
+
+    oprot.writeStructBegin(_STRUCT_DESC);
+    if(this.names != null) {
+      oprot.writeFieldBegin(_NAMES_FIELD_DESC);
+      oprot.writeListBegin(new thrift.TList(thrift.TType.STRING, names.length));
+      for(var elem54 in names) {
+        oprot.writeString(elem54);
+      }
+      oprot.writeListEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.ids != null) {
+      oprot.writeFieldBegin(_IDS_FIELD_DESC);
+      oprot.writeSetBegin(new thrift.TSet(thrift.TType.I32, ids.length));
+      for(var elem55 in ids) {
+        oprot.writeI32(elem55);
+      }
+      oprot.writeSetEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.counts != null) {
+      oprot.writeFieldBegin(_COUNTS_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.STRING, thrift.TType.I64, counts.length));
+      for(var elem56 in counts.keys) {
+        oprot.writeString(elem56);
+        oprot.writeI64(counts[elem56]);
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.tagsByColor != null) {
+      oprot.writeFieldBegin(_TAGS_BY_COLOR_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.I32, thrift.TType.LIST, tagsByColor.length));
+      for(var elem57 in tagsByColor.keys) {
+        oprot.writeI32(elem57);
+        oprot.writeListBegin(new thrift.TList(thrift.TType.STRUCT, tagsByColor[elem57].length));
+        for(var elem58 in tagsByColor[elem57]) {
+          elem58.write(oprot);
+        }
+        oprot.writeListEnd();
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.flags != null) {
+      oprot.writeFieldBegin(_FLAGS_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.BOOL, thrift.TType.STRING, flags.length));
+      for(var elem59 in flags.keys) {
+        oprot.writeBool(elem59);
+        oprot.writeString(flags[elem59]);
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.notes != null) {
+      oprot.writeFieldBegin(_NOTES_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.STRUCT, thrift.TType.STRING, notes.length));
+      for(var elem60 in notes.keys) {
+        elem60.write(oprot);
+        oprot.writeString(notes[elem60]);
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.nested != null) {
+      oprot.writeFieldBegin(_NESTED_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.STRING, thrift.TType.MAP, nested.length));
+      for(var elem61 in nested.keys) {
+        oprot.writeString(elem61);
+        oprot.writeMapBegin(new thrift.TMap(thrift.TType.I32, thrift.TType.SET, nested[elem61].length));
+        for(var elem62 in nested[elem61].keys) {
+          oprot.writeI32(elem62);
+          oprot.writeSetBegin(new thrift.TSet(thrift.TType.STRING, nested[elem61][elem62].length));
+          for(var elem63 in nested[elem61][elem62]) {
+            oprot.writeString(elem63);
+          }
+          oprot.writeSetEnd();
+        }
+        oprot.writeMapEnd();
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(isSetTagSets() && this.tagSets != null) {
+      oprot.writeFieldBegin(_TAG_SETS_FIELD_DESC);
+      oprot.writeListBegin(new thrift.TList(thrift.TType.SET, tagSets.length));
+      for(var elem64 in tagSets) {
+        oprot.writeSetBegin(new thrift.TSet(thrift.TType.STRUCT, elem64.length));
+        for(var elem65 in elem64) {
+          elem65.write(oprot);
+        }
+        oprot.writeSetEnd();
+      }
+      oprot.writeListEnd();
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldStop();
+    oprot.writeStructEnd();
+  }
+
+  String toString() {
+    StringBuffer ret = new StringBuffer("Inventory(");
+
+    ret.write("names:");
+    if(this.names == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.names);
+    }
+
+    ret.write(", ");
+    ret.write("ids:");
+    if(this.ids == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.ids);
+    }
+
+    ret.write(", ");
+    ret.write("counts:");
+    if(this.counts == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.counts);
+    }
+
+    ret.write(", ");
+    ret.write("tagsByColor:");
+    if(this.tagsByColor == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.tagsByColor);
+    }
+
+    ret.write(", ");
+    ret.write("flags:");
+    if(this.flags == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.flags);
+    }
+
+    ret.write(", ");
+    ret.write("notes:");
+    if(this.notes == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.notes);
+    }
+
+    ret.write(", ");
+    ret.write("nested:");
+    if(this.nested == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.nested);
+    }
+
+    if(isSetTagSets()) {
+      ret.write(", ");
+      ret.write("tagSets:");
+      if(this.tagSets == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.tagSets);
+      }
+    }
+
+    ret.write(")");
+
+    return ret.toString();
+  }
+
+  bool operator ==(Object o) {
+    if(o == null || !(o is Inventory)) {
+      return false;
+    }
+    Inventory other = o as Inventory;
+    return this.names == other.names
+      && this.ids == other.ids
+      && this.counts == other.counts
+      && this.tagsByColor == other.tagsByColor
+      && this.flags == other.flags
+      && this.notes == other.notes
+      && this.nested == other.nested
+      && this.tagSets == other.tagSets;
+  }
+
+  int get hashCode {
+    var value = 17;
+    value = (value * 31) ^ names.hashCode;
+    value = (value * 31) ^ ids.hashCode;
+    value = (value * 31) ^ counts.hashCode;
+    value = (value * 31) ^ tagsByColor.hashCode;
+    value = (value * 31) ^ flags.hashCode;
+    value = (value * 31) ^ notes.hashCode;
+    value = (value * 31) ^ nested.hashCode;
+    value = (value * 31) ^ tagSets.hashCode;
+    return value;
+  }
+
+  Inventory clone({
+    BuiltList<String> names: null,
+    BuiltSet<int> ids: null,
+    BuiltMap<String, int> counts: null,
+    BuiltMap<int, BuiltList<t_containers.Tag>> tagsByColor: null,
+    BuiltMap<bool, String> flags: null,
+    BuiltMap<t_containers.Tag, String> notes: null,
+    BuiltMap<String, BuiltMap<int, BuiltSet<String>>> nested: null,
+    BuiltList<BuiltSet<t_containers.Tag>> tagSets: null,
+  }) {
+    return new Inventory()
+      ..names = names ?? this.names
+      ..ids = ids ?? this.ids
+      ..counts = counts ?? this.counts
+      ..tagsByColor = tagsByColor ?? this.tagsByColor
+      ..flags = flags ?? this.flags
+      ..notes = notes ?? this.notes
+      ..nested = nested ?? this.nested
+      ..tagSets = tagSets ?? this.tagSets;
+  }
+
+  validate() {
+    // check for required fields
+    // check that fields of type enum have valid values
+  }
+}
@@ -0,0 +1,197 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+
+
+import 'dart:async';
+import 'dart:typed_data' show Uint8List;
+
+import 'package:built_collection/built_collection.dart';
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:frugal/frugal.dart' as frugal;
+
+import 'package:containers/containers.dart' as t_containers;
+
+
+const String delimiter = '.';
+
+class StockPublisher {
+  /// Describes the Stock scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'containers.frugal', 'scope', 'Stock',
+      '61bc7e363fde425e7bfda289c2d757e84eafc559ff1baa87bbd6b34cb153db9b', '2.23.0',
+      const ['Updated']);
+
+  frugal.FPublisherTransport transport;
+  frugal.FProtocolFactory protocolFactory;
+  Map<String, frugal.FMethod> _methods;
+  StockPublisher(frugal.FScopeProvider provider, [List<frugal.Middleware> middleware]) {
+    transport = provider.publisherTransportFactory.getTransport();
+    protocolFactory = provider.protocolFactory;
+    var combined = middleware ?? [];
+    combined.addAll(provider.middleware);
+    this._methods = {};
+    this._methods['Updated'] = new frugal.FMethod(this._publishUpdated, 'Stock', 'publishUpdated', combined);
+  }
+
+  Future open() {
+    return transport.open();
+  }
+
+  Future close() {
+    return transport.close();
+  }
+
+  Future publishUpdated(frugal.FContext ctx, BuiltMap<String, int> req, {Duration timeout}) {
+    var publish = this._methods['Updated']([ctx, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of Updated timed out'));
+  }
+
+  Future _publishUpdated(frugal.FContext ctx, BuiltMap<String, int> req) async {
+    var op = "Updated";
+    var prefix = "";
+    var topic = "${prefix}Stock${delimiter}${op}";
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.STRING, thrift.TType.I64, req.length));
+      for(var elem66 in req.keys) {
+        oprot.writeString(elem66);
+        oprot.writeI64(req[elem66]);
+      }
+      oprot.writeMapEnd();
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+}
+
+
+class StockSubscriber {
+  /// Describes the Stock scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'containers.frugal', 'scope', 'Stock',
+      '61bc7e363fde425e7bfda289c2d757e84eafc559ff1baa87bbd6b34cb153db9b', '2.23.0',
+      const ['Updated']);
+
+  final frugal.FScopeProvider provider;
+  final List<frugal.Middleware> _middleware;
+
+  StockSubscriber(this.provider, [List<frugal.Middleware> middleware])
+      : this._middleware = middleware ?? [] {
+    this._middleware.addAll(provider.middleware);
+}
+
+  Future<frugal.FSubscription> subscribeUpdated(dynamic onmap(frugal.FContext ctx, BuiltMap<String, int> req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var op = "Updated";
+    var prefix = "";
+    var topic = "${prefix}Stock${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvUpdated(op, provider.protocolFactory, onmap),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<BuiltMap<String, int>> streamUpdated({frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<BuiltMap<String, int>> controller;
+    controller = new StreamController<BuiltMap<String, int>>(
+        onListen: () {
+          subscription = subscribeUpdated((frugal.FContext ctx, BuiltMap<String, int> req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
+  }
+
+  frugal.FAsyncCallback _recvUpdated(String op, frugal.FProtocolFactory protocolFactory, dynamic onmap(frugal.FContext ctx, BuiltMap<String, int> req)) {
+    frugal.FMethod method = new frugal.FMethod(onmap, 'Stock', 'subscribemap', this._middleware);
+    callbackUpdated(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      if (tMsg.name != op) {
+        thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+        iprot.readMessageEnd();
+        throw new thrift.TApplicationError(
+        frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      thrift.TMap elem67 = iprot.readMapBegin();
+      var elem70 = new MapBuilder<String, int>();
+      for(int elem69 = 0; elem69 < elem67.length; ++elem69) {
+        String elem71 = iprot.readString();
+        int elem68 = iprot.readI64();
+        elem70[elem71] = elem68;
+      }
+      iprot.readMapEnd();
+      BuiltMap<String, int> req = elem70.build();
+      iprot.readMessageEnd();
+      method([ctx, req]);
+    }
+    return callbackUpdated;
+  }
+
+
+  /// Subscribes to every operation of the scope. onMessage is called with the
+  /// name of the operation of each message and its decoded payload.
+  Future<frugal.FSubscription> subscribeAll(dynamic onMessage(frugal.FContext ctx, String op, dynamic req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var prefix = "";
+    var topic = "${prefix}Stock${delimiter}*";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvAll(provider.protocolFactory, onMessage),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  frugal.FAsyncCallback _recvAll(frugal.FProtocolFactory protocolFactory, dynamic onMessage(frugal.FContext ctx, String op, dynamic req)) {
+    frugal.FMethod method = new frugal.FMethod(onMessage, 'Stock', 'subscribeAll', this._middleware);
+    callbackAll(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      var req;
+      switch (tMsg.name) {
+        case 'Updated':
+          thrift.TMap elem72 = iprot.readMapBegin();
+          var elem75 = new MapBuilder<String, int>();
+          for(int elem74 = 0; elem74 < elem72.length; ++elem74) {
+            String elem76 = iprot.readString();
+            int elem73 = iprot.readI64();
+            elem75[elem76] = elem73;
+          }
+          iprot.readMapEnd();
+          BuiltMap<String, int> reqUpdated = elem75.build();
+          req = reqUpdated;
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+          iprot.readMessageEnd();
+          throw new thrift.TApplicationError(
+          frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      iprot.readMessageEnd();
+      method([ctx, tMsg.name, req]);
+    }
+    return callbackAll;
+  }
+}
+
@@ -0,0 +1,8 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+library filter;
+
+export 'src/f_alert.dart' show Alert;
+export 'src/f_invoice.dart' show Invoice;
+
@@ -0,0 +1,13 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+library filter;
+
+export 'src/f_alert.dart' show Alert;
+export 'src/f_invoice.dart' show Invoice;
+
+export 'src/f_base_service.dart' show FBase, FBaseClient;
+export 'src/f_invoices_service.dart' show FInvoices, FInvoicesClient;
+export 'src/f_admin_service.dart' show FAdmin, FAdminClient;
+export 'src/f_alerts_scope.dart' show AlertsPublisher, AlertsSubscriber;
+export 'src/f_billing_scope.dart' show BillingPublisher, BillingSubscriber;
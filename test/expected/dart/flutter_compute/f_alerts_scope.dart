@@ -0,0 +1,183 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+
+
+import 'dart:async';
+import 'dart:typed_data' show Uint8List;
+
+import 'package:flutter/foundation.dart' show compute;
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:frugal/frugal.dart' as frugal;
+
+import 'package:filter/filter.dart' as t_filter;
+
+
+const String delimiter = '.';
+
+class AlertsPublisher {
+  /// Describes the Alerts scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'filter.frugal', 'scope', 'Alerts',
+      'b6d84b49700968a710de95d2c7bff219f3aa278598dfd240b6681cf6d1c3ca25', '2.23.0',
+      const ['AlertRaised']);
+
+  frugal.FPublisherTransport transport;
+  frugal.FProtocolFactory protocolFactory;
+  Map<String, frugal.FMethod> _methods;
+  AlertsPublisher(frugal.FScopeProvider provider, [List<frugal.Middleware> middleware]) {
+    transport = provider.publisherTransportFactory.getTransport();
+    protocolFactory = provider.protocolFactory;
+    var combined = middleware ?? [];
+    combined.addAll(provider.middleware);
+    this._methods = {};
+    this._methods['AlertRaised'] = new frugal.FMethod(this._publishAlertRaised, 'Alerts', 'publishAlertRaised', combined);
+  }
+
+  Future open() {
+    return transport.open();
+  }
+
+  Future close() {
+    return transport.close();
+  }
+
+  Future publishAlertRaised(frugal.FContext ctx, t_filter.Alert req, {Duration timeout}) {
+    var publish = this._methods['AlertRaised']([ctx, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of AlertRaised timed out'));
+  }
+
+  Future _publishAlertRaised(frugal.FContext ctx, t_filter.Alert req) async {
+    var op = "AlertRaised";
+    var prefix = "";
+    var topic = "${prefix}Alerts${delimiter}${op}";
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      req.write(oprot);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+}
+
+
+class AlertsSubscriber {
+  /// Describes the Alerts scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'filter.frugal', 'scope', 'Alerts',
+      'b6d84b49700968a710de95d2c7bff219f3aa278598dfd240b6681cf6d1c3ca25', '2.23.0',
+      const ['AlertRaised']);
+
+  final frugal.FScopeProvider provider;
+  final List<frugal.Middleware> _middleware;
+
+  AlertsSubscriber(this.provider, [List<frugal.Middleware> middleware])
+      : this._middleware = middleware ?? [] {
+    this._middleware.addAll(provider.middleware);
+}
+
+  Future<frugal.FSubscription> subscribeAlertRaised(dynamic onAlert(frugal.FContext ctx, t_filter.Alert req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var op = "AlertRaised";
+    var prefix = "";
+    var topic = "${prefix}Alerts${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvAlertRaised(op, provider.protocolFactory, onAlert),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<t_filter.Alert> streamAlertRaised({frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<t_filter.Alert> controller;
+    controller = new StreamController<t_filter.Alert>(
+        onListen: () {
+          subscription = subscribeAlertRaised((frugal.FContext ctx, t_filter.Alert req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
+  }
+
+  frugal.FAsyncCallback _recvAlertRaised(String op, frugal.FProtocolFactory protocolFactory, dynamic onAlert(frugal.FContext ctx, t_filter.Alert req)) {
+    frugal.FMethod method = new frugal.FMethod(onAlert, 'Alerts', 'subscribeAlert', this._middleware);
+    return frugal.isolateDecodeCallback(op, protocolFactory, compute, _decodeAlertRaised, (frugal.FContext ctx, Object message) {
+      t_filter.Alert req = message as t_filter.Alert;
+      method([ctx, req]);
+    });
+  }
+
+  static frugal.FDecodedMessage _decodeAlertRaised(frugal.FIsolateMessage message) {
+    var op = message.op;
+    var iprot = message.getProtocol();
+    var ctx = iprot.readRequestHeader();
+    var tMsg = iprot.readMessageBegin();
+    if (tMsg.name != op) {
+      thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+      iprot.readMessageEnd();
+      throw new thrift.TApplicationError(
+      frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+    }
+    t_filter.Alert req = new t_filter.Alert();
+    req.read(iprot);
+    iprot.readMessageEnd();
+    return new frugal.FDecodedMessage(ctx, req);
+  }
+
+
+  /// Subscribes to every operation of the scope. onMessage is called with the
+  /// name of the operation of each message and its decoded payload.
+  Future<frugal.FSubscription> subscribeAll(dynamic onMessage(frugal.FContext ctx, String op, dynamic req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var prefix = "";
+    var topic = "${prefix}Alerts${delimiter}*";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvAll(provider.protocolFactory, onMessage),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  frugal.FAsyncCallback _recvAll(frugal.FProtocolFactory protocolFactory, dynamic onMessage(frugal.FContext ctx, String op, dynamic req)) {
+    frugal.FMethod method = new frugal.FMethod(onMessage, 'Alerts', 'subscribeAll', this._middleware);
+    callbackAll(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      var req;
+      switch (tMsg.name) {
+        case 'AlertRaised':
+          t_filter.Alert reqAlertRaised = new t_filter.Alert();
+          reqAlertRaised.read(iprot);
+          req = reqAlertRaised;
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+          iprot.readMessageEnd();
+          throw new thrift.TApplicationError(
+          frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      iprot.readMessageEnd();
+      method([ctx, tMsg.name, req]);
+    }
+    return callbackAll;
+  }
+}
+
@@ -0,0 +1,522 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+
+
+import 'dart:async';
+import 'dart:typed_data' show Uint8List;
+
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:frugal/frugal.dart' as frugal;
+
+import 'package:variety/variety.dart' as t_variety;
+
+
+const String delimiter = '.';
+
+/// This docstring gets added to the generated code because it has
+/// the @ sign. Prefix specifies topic prefix tokens, which can be static or
+/// variable.
+class EventsPublisher {
+  /// Describes the Events scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'variety.frugal', 'scope', 'Events',
+      '660b9d3624d5677b532f8f7b53a07b9cbfd10edb64f73a24c4858e545e47098e', '2.23.0',
+      const ['EventCreated', 'SomeInt', 'SomeStr', 'SomeList'],
+      const {
+        'EventCreated': "This is a docstring.",
+      });
+
+  frugal.FPublisherTransport transport;
+  frugal.FProtocolFactory protocolFactory;
+  Map<String, frugal.FMethod> _methods;
+  /// Called with the context and operation name before each publish.
+  frugal.FScopeHook onBeforePublish;
+  EventsPublisher(frugal.FScopeProvider provider, [List<frugal.Middleware> middleware]) {
+    transport = provider.publisherTransportFactory.getTransport();
+    protocolFactory = provider.protocolFactory;
+    var combined = middleware ?? [];
+    combined.addAll(provider.middleware);
+    this._methods = {};
+    this._methods['EventCreated'] = new frugal.FMethod(this._publishEventCreated, 'Events', 'publishEventCreated', combined);
+    this._methods['SomeInt'] = new frugal.FMethod(this._publishSomeInt, 'Events', 'publishSomeInt', combined);
+    this._methods['SomeStr'] = new frugal.FMethod(this._publishSomeStr, 'Events', 'publishSomeStr', combined);
+    this._methods['SomeList'] = new frugal.FMethod(this._publishSomeList, 'Events', 'publishSomeList', combined);
+  }
+
+  Future open() {
+    return transport.open();
+  }
+
+  Future close() {
+    return transport.close();
+  }
+
+  /// This is a docstring.
+  Future publishEventCreated(frugal.FContext ctx, String user, t_variety.Event req, {Duration timeout}) {
+    var publish = this._methods['EventCreated']([ctx, user, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of EventCreated timed out'));
+  }
+
+  Future _publishEventCreated(frugal.FContext ctx, String user, t_variety.Event req) async {
+    ctx.addRequestHeader('_topic_user', user);
+    var op = "EventCreated";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    if (onBeforePublish != null) {
+      onBeforePublish(ctx, op);
+    }
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      req.write(oprot);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+
+
+  Future publishSomeInt(frugal.FContext ctx, String user, int req, {Duration timeout}) {
+    var publish = this._methods['SomeInt']([ctx, user, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of SomeInt timed out'));
+  }
+
+  Future _publishSomeInt(frugal.FContext ctx, String user, int req) async {
+    ctx.addRequestHeader('_topic_user', user);
+    var op = "SomeInt";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    if (onBeforePublish != null) {
+      onBeforePublish(ctx, op);
+    }
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      oprot.writeI64(req);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+
+
+  Future publishSomeStr(frugal.FContext ctx, String user, String req, {Duration timeout}) {
+    var publish = this._methods['SomeStr']([ctx, user, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of SomeStr timed out'));
+  }
+
+  Future _publishSomeStr(frugal.FContext ctx, String user, String req) async {
+    ctx.addRequestHeader('_topic_user', user);
+    var op = "SomeStr";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    if (onBeforePublish != null) {
+      onBeforePublish(ctx, op);
+    }
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      oprot.writeString(req);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+
+
+  Future publishSomeList(frugal.FContext ctx, String user, List<Map<int, t_variety.Event>> req, {Duration timeout}) {
+    var publish = this._methods['SomeList']([ctx, user, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of SomeList timed out'));
+  }
+
+  Future _publishSomeList(frugal.FContext ctx, String user, List<Map<int, t_variety.Event>> req) async {
+    ctx.addRequestHeader('_topic_user', user);
+    var op = "SomeList";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    if (onBeforePublish != null) {
+      onBeforePublish(ctx, op);
+    }
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      oprot.writeListBegin(new thrift.TList(thrift.TType.MAP, req.length));
+      for(var elem72 in req) {
+        oprot.writeMapBegin(new thrift.TMap(thrift.TType.I64, thrift.TType.STRUCT, elem72.length));
+        for(var elem73 in elem72.keys) {
+          oprot.writeI64(elem73);
+          elem72[elem73].write(oprot);
+        }
+        oprot.writeMapEnd();
+      }
+      oprot.writeListEnd();
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+}
+
+
+/// This docstring gets added to the generated code because it has
+/// the @ sign. Prefix specifies topic prefix tokens, which can be static or
+/// variable.
+class EventsSubscriber {
+  /// Describes the Events scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'variety.frugal', 'scope', 'Events',
+      '660b9d3624d5677b532f8f7b53a07b9cbfd10edb64f73a24c4858e545e47098e', '2.23.0',
+      const ['EventCreated', 'SomeInt', 'SomeStr', 'SomeList'],
+      const {
+        'EventCreated': "This is a docstring.",
+      });
+
+  final frugal.FScopeProvider provider;
+  final List<frugal.Middleware> _middleware;
+  /// Called with the context and operation name after each message is received.
+  frugal.FScopeHook onAfterReceive;
+
+  EventsSubscriber(this.provider, [List<frugal.Middleware> middleware])
+      : this._middleware = middleware ?? [] {
+    this._middleware.addAll(provider.middleware);
+}
+
+  /// This is a docstring.
+  Future<frugal.FSubscription> subscribeEventCreated(String user, dynamic onEvent(frugal.FContext ctx, t_variety.Event req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var op = "EventCreated";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvEventCreated(op, provider.protocolFactory, onEvent),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  /// This is a docstring.
+  Stream<t_variety.Event> streamEventCreated(String user, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<t_variety.Event> controller;
+    controller = new StreamController<t_variety.Event>(
+        onListen: () {
+          subscription = subscribeEventCreated(user, (frugal.FContext ctx, t_variety.Event req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
+  }
+
+  frugal.FAsyncCallback _recvEventCreated(String op, frugal.FProtocolFactory protocolFactory, dynamic onEvent(frugal.FContext ctx, t_variety.Event req)) {
+    frugal.FMethod method = new frugal.FMethod(onEvent, 'Events', 'subscribeEvent', this._middleware);
+    callbackEventCreated(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      if (tMsg.name != op) {
+        thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+        iprot.readMessageEnd();
+        throw new thrift.TApplicationError(
+        frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      t_variety.Event req = new t_variety.Event();
+      req.read(iprot);
+      iprot.readMessageEnd();
+      if (onAfterReceive != null) {
+        onAfterReceive(ctx, op);
+      }
+      method([ctx, req]);
+    }
+    return callbackEventCreated;
+  }
+
+
+  Future<frugal.FSubscription> subscribeSomeInt(String user, dynamic oni64(frugal.FContext ctx, int req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var op = "SomeInt";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvSomeInt(op, provider.protocolFactory, oni64),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<int> streamSomeInt(String user, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<int> controller;
+    controller = new StreamController<int>(
+        onListen: () {
+          subscription = subscribeSomeInt(user, (frugal.FContext ctx, int req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
+  }
+
+  frugal.FAsyncCallback _recvSomeInt(String op, frugal.FProtocolFactory protocolFactory, dynamic oni64(frugal.FContext ctx, int req)) {
+    frugal.FMethod method = new frugal.FMethod(oni64, 'Events', 'subscribei64', this._middleware);
+    callbackSomeInt(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      if (tMsg.name != op) {
+        thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+        iprot.readMessageEnd();
+        throw new thrift.TApplicationError(
+        frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      int req = iprot.readI64();
+      iprot.readMessageEnd();
+      if (onAfterReceive != null) {
+        onAfterReceive(ctx, op);
+      }
+      method([ctx, req]);
+    }
+    return callbackSomeInt;
+  }
+
+
+  Future<frugal.FSubscription> subscribeSomeStr(String user, dynamic onstring(frugal.FContext ctx, String req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var op = "SomeStr";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvSomeStr(op, provider.protocolFactory, onstring),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<String> streamSomeStr(String user, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<String> controller;
+    controller = new StreamController<String>(
+        onListen: () {
+          subscription = subscribeSomeStr(user, (frugal.FContext ctx, String req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
+  }
+
+  frugal.FAsyncCallback _recvSomeStr(String op, frugal.FProtocolFactory protocolFactory, dynamic onstring(frugal.FContext ctx, String req)) {
+    frugal.FMethod method = new frugal.FMethod(onstring, 'Events', 'subscribestring', this._middleware);
+    callbackSomeStr(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      if (tMsg.name != op) {
+        thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+        iprot.readMessageEnd();
+        throw new thrift.TApplicationError(
+        frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      String req = iprot.readString();
+      iprot.readMessageEnd();
+      if (onAfterReceive != null) {
+        onAfterReceive(ctx, op);
+      }
+      method([ctx, req]);
+    }
+    return callbackSomeStr;
+  }
+
+
+  Future<frugal.FSubscription> subscribeSomeList(String user, dynamic onlist(frugal.FContext ctx, List<Map<int, t_variety.Event>> req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var op = "SomeList";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvSomeList(op, provider.protocolFactory, onlist),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<List<Map<int, t_variety.Event>>> streamSomeList(String user, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<List<Map<int, t_variety.Event>>> controller;
+    controller = new StreamController<List<Map<int, t_variety.Event>>>(
+        onListen: () {
+          subscription = subscribeSomeList(user, (frugal.FContext ctx, List<Map<int, t_variety.Event>> req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
+  }
+
+  frugal.FAsyncCallback _recvSomeList(String op, frugal.FProtocolFactory protocolFactory, dynamic onlist(frugal.FContext ctx, List<Map<int, t_variety.Event>> req)) {
+    frugal.FMethod method = new frugal.FMethod(onlist, 'Events', 'subscribelist', this._middleware);
+    callbackSomeList(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      if (tMsg.name != op) {
+        thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+        iprot.readMessageEnd();
+        throw new thrift.TApplicationError(
+        frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      thrift.TList elem74 = iprot.readListBegin();
+      List<Map<int, t_variety.Event>> req = new List<Map<int, t_variety.Event>>();
+      for(int elem80 = 0; elem80 < elem74.length; ++elem80) {
+        thrift.TMap elem76 = iprot.readMapBegin();
+        Map<int, t_variety.Event> elem75 = new Map<int, t_variety.Event>();
+        for(int elem78 = 0; elem78 < elem76.length; ++elem78) {
+          int elem79 = iprot.readI64();
+          t_variety.Event elem77 = new t_variety.Event();
+          elem77.read(iprot);
+          elem75[elem79] = elem77;
+        }
+        iprot.readMapEnd();
+        req.add(elem75);
+      }
+      iprot.readListEnd();
+      iprot.readMessageEnd();
+      if (onAfterReceive != null) {
+        onAfterReceive(ctx, op);
+      }
+      method([ctx, req]);
+    }
+    return callbackSomeList;
+  }
+
+
+  /// Subscribes to every operation of the scope. onMessage is called with the
+  /// name of the operation of each message and its decoded payload.
+  Future<frugal.FSubscription> subscribeAll(String user, dynamic onMessage(frugal.FContext ctx, String op, dynamic req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}*";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvAll(provider.protocolFactory, onMessage),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  frugal.FAsyncCallback _recvAll(frugal.FProtocolFactory protocolFactory, dynamic onMessage(frugal.FContext ctx, String op, dynamic req)) {
+    frugal.FMethod method = new frugal.FMethod(onMessage, 'Events', 'subscribeAll', this._middleware);
+    callbackAll(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      var req;
+      switch (tMsg.name) {
+        case 'EventCreated':
+          t_variety.Event reqEventCreated = new t_variety.Event();
+          reqEventCreated.read(iprot);
+          req = reqEventCreated;
+          break;
+        case 'SomeInt':
+          int reqSomeInt = iprot.readI64();
+          req = reqSomeInt;
+          break;
+        case 'SomeStr':
+          String reqSomeStr = iprot.readString();
+          req = reqSomeStr;
+          break;
+        case 'SomeList':
+          thrift.TList elem81 = iprot.readListBegin();
+          List<Map<int, t_variety.Event>> reqSomeList = new List<Map<int, t_variety.Event>>();
+          for(int elem87 = 0; elem87 < elem81.length; ++elem87) {
+            thrift.TMap elem83 = iprot.readMapBegin();
+            Map<int, t_variety.Event> elem82 = new Map<int, t_variety.Event>();
+            for(int elem85 = 0; elem85 < elem83.length; ++elem85) {
+              int elem86 = iprot.readI64();
+              t_variety.Event elem84 = new t_variety.Event();
+              elem84.read(iprot);
+              elem82[elem86] = elem84;
+            }
+            iprot.readMapEnd();
+            reqSomeList.add(elem82);
+          }
+          iprot.readListEnd();
+          req = reqSomeList;
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+          iprot.readMessageEnd();
+          throw new thrift.TApplicationError(
+          frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      iprot.readMessageEnd();
+      if (onAfterReceive != null) {
+        onAfterReceive(ctx, tMsg.name);
+      }
+      method([ctx, tMsg.name, req]);
+    }
+    return callbackAll;
+  }
+}
+
@@ -16,6 +16,12 @@ import 'package:include_vendor/include_vendor.dart' as t_include_vendor;
 const String delimiter = '.';
 
 class MyScopePublisher {
+  /// Describes the MyScope scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'include_vendor.frugal', 'scope', 'MyScope',
+      'f8310e12fba5a05f3dd4aefd065927eec482a00b3967fb4b5b34ba3b2656acdb', '2.23.0',
+      const ['newItem']);
+
   frugal.FPublisherTransport transport;
   frugal.FProtocolFactory protocolFactory;
   Map<String, frugal.FMethod> _methods;
@@ -36,27 +42,42 @@ class MyScopePublisher {
     return transport.close();
   }
 
-  Future publishnewItem(frugal.FContext ctx, t_vendor_namespace.Item req) {
-    return this._methods['newItem']([ctx, req]);
+  Future publishnewItem(frugal.FContext ctx, t_vendor_namespace.Item req, {Duration timeout}) {
+    var publish = this._methods['newItem']([ctx, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of newItem timed out'));
   }
 
   Future _publishnewItem(frugal.FContext ctx, t_vendor_namespace.Item req) async {
     var op = "newItem";
     var prefix = "";
     var topic = "${prefix}MyScope${delimiter}${op}";
-    var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
-    var oprot = protocolFactory.getProtocol(memoryBuffer);
-    var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
-    oprot.writeRequestHeader(ctx);
-    oprot.writeMessageBegin(msg);
-    req.write(oprot);
-    oprot.writeMessageEnd();
-    await transport.publish(topic, memoryBuffer.writeBytes);
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      req.write(oprot);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
   }
 }
 
 
 class MyScopeSubscriber {
+  /// Describes the MyScope scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'include_vendor.frugal', 'scope', 'MyScope',
+      'f8310e12fba5a05f3dd4aefd065927eec482a00b3967fb4b5b34ba3b2656acdb', '2.23.0',
+      const ['newItem']);
+
   final frugal.FScopeProvider provider;
   final List<frugal.Middleware> _middleware;
 
@@ -65,13 +86,37 @@ class MyScopeSubscriber {
     this._middleware.addAll(provider.middleware);
 }
 
-  Future<frugal.FSubscription> subscribenewItem(dynamic onItem(frugal.FContext ctx, t_vendor_namespace.Item req)) async {
+  Future<frugal.FSubscription> subscribenewItem(dynamic onItem(frugal.FContext ctx, t_vendor_namespace.Item req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
     var op = "newItem";
     var prefix = "";
     var topic = "${prefix}MyScope${delimiter}${op}";
     var transport = provider.subscriberTransportFactory.getTransport();
-    await transport.subscribe(topic, _recvnewItem(op, provider.protocolFactory, onItem));
-    return new frugal.FSubscription(topic, transport);
+    return frugal.subscribeWithPolicy(topic, transport, _recvnewItem(op, provider.protocolFactory, onItem),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<t_vendor_namespace.Item> streamnewItem({frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<t_vendor_namespace.Item> controller;
+    controller = new StreamController<t_vendor_namespace.Item>(
+        onListen: () {
+          subscription = subscribenewItem((frugal.FContext ctx, t_vendor_namespace.Item req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
   }
 
   frugal.FAsyncCallback _recvnewItem(String op, frugal.FProtocolFactory protocolFactory, dynamic onItem(frugal.FContext ctx, t_vendor_namespace.Item req)) {
@@ -93,5 +138,41 @@ class MyScopeSubscriber {
     }
     return callbacknewItem;
   }
+
+
+  /// Subscribes to every operation of the scope. onMessage is called with the
+  /// name of the operation of each message and its decoded payload.
+  Future<frugal.FSubscription> subscribeAll(dynamic onMessage(frugal.FContext ctx, String op, dynamic req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var prefix = "";
+    var topic = "${prefix}MyScope${delimiter}*";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvAll(provider.protocolFactory, onMessage),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  frugal.FAsyncCallback _recvAll(frugal.FProtocolFactory protocolFactory, dynamic onMessage(frugal.FContext ctx, String op, dynamic req)) {
+    frugal.FMethod method = new frugal.FMethod(onMessage, 'MyScope', 'subscribeAll', this._middleware);
+    callbackAll(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      var req;
+      switch (tMsg.name) {
+        case 'newItem':
+          t_vendor_namespace.Item reqnewItem = new t_vendor_namespace.Item();
+          reqnewItem.read(iprot);
+          req = reqnewItem;
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+          iprot.readMessageEnd();
+          throw new thrift.TApplicationError(
+          frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      iprot.readMessageEnd();
+      method([ctx, tMsg.name, req]);
+    }
+    return callbackAll;
+  }
 }
 
@@ -16,6 +16,11 @@ import 'package:include_vendor/include_vendor.dart' as t_include_vendor;
 
 
 abstract class FMyService extends t_vendor_namespace.FVendoredBase {
+  /// Describes the MyService service contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'include_vendor.frugal', 'service', 'MyService',
+      '19ef102c1fca7eee88dc1c9957a1d3e59cfb31899724e0767852150c1ca55907', '2.23.0',
+      const ['getItem']);
 
   Future<t_vendor_namespace.Item> getItem(frugal.FContext ctx);
 }
@@ -5,6 +5,5 @@ library include_vendor;
 
 export 'src/f_vendored_references.dart' show VendoredReferences;
 
-export 'src/f_my_service_service.dart' show FMyService;
-export 'src/f_my_service_service.dart' show FMyServiceClient;
+export 'src/f_my_service_service.dart' show FMyService, FMyServiceClient;
 export 'src/f_my_scope_scope.dart' show MyScopePublisher, MyScopeSubscriber;
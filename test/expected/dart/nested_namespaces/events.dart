@@ -0,0 +1,10 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+library acme.events;
+
+export 'events/f_acme_events_constants.dart' show AcmeEventsConstants;
+export 'events/f_invoice.dart' show Invoice;
+
+export 'events/f_invoices_service.dart' show FInvoices, FInvoicesClient;
+export 'events/f_invoice_events_scope.dart' show InvoiceEventsPublisher, InvoiceEventsSubscriber;
@@ -0,0 +1,199 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:typed_data' show Uint8List;
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:app/src/gen/acme/events.dart' as t_acme_events;
+import 'package:app/src/gen/acme/shared.dart' as t_acme_shared;
+
+class Invoice implements thrift.TBase {
+  static final thrift.TStruct _STRUCT_DESC = new thrift.TStruct("Invoice");
+  static final thrift.TField _ID_FIELD_DESC = new thrift.TField("id", thrift.TType.STRING, 1);
+  static final thrift.TField _TOTAL_FIELD_DESC = new thrift.TField("total", thrift.TType.STRUCT, 2);
+
+  String _id;
+  static const int ID = 1;
+  t_acme_shared.Money _total;
+  static const int TOTAL = 2;
+
+
+  Invoice() {
+  }
+
+  String get id => this._id;
+
+  set id(String id) {
+    this._id = id;
+  }
+
+  bool isSetId() => this.id != null;
+
+  unsetId() {
+    this.id = null;
+  }
+
+  t_acme_shared.Money get total => this._total;
+
+  set total(t_acme_shared.Money total) {
+    this._total = total;
+  }
+
+  bool isSetTotal() => this.total != null;
+
+  unsetTotal() {
+    this.total = null;
+  }
+
+  getFieldValue(int fieldID) {
+    switch (fieldID) {
+      case ID:
+        return this.id;
+      case TOTAL:
+        return this.total;
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  setFieldValue(int fieldID, Object value) {
+    switch(fieldID) {
+      case ID:
+        if(value == null) {
+          unsetId();
+        } else {
+          this.id = value as String;
+        }
+        break;
+
+      case TOTAL:
+        if(value == null) {
+          unsetTotal();
+        } else {
+          this.total = value as t_acme_shared.Money;
+        }
+        break;
+
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  // Returns true if the field corresponding to fieldID is set (has been assigned a value) and false otherwise
+  bool isSet(int fieldID) {
+    switch(fieldID) {
+      case ID:
+        return isSetId();
+      case TOTAL:
+        return isSetTotal();
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  read(thrift.TProtocol iprot) {
+    thrift.TField field;
+    iprot.readStructBegin();
+    while(true) {
+      field = iprot.readFieldBegin();
+      if(field.type == thrift.TType.STOP) {
+        break;
+      }
+      switch(field.id) {
+        case ID:
+          if(field.type == thrift.TType.STRING) {
+            id = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case TOTAL:
+          if(field.type == thrift.TType.STRUCT) {
+            total = new t_acme_shared.Money();
+            total.read(iprot);
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, field.type);
+          break;
+      }
+      iprot.readFieldEnd();
+    }
+    iprot.readStructEnd();
+
+    // check for required fields of primitive type, which can't be checked in the validate method
+    validate();
+  }
+
+  write(thrift.TProtocol oprot) {
+    validate();
+
+    oprot.writeStructBegin(_STRUCT_DESC);
+    if(this.id != null) {
+      oprot.writeFieldBegin(_ID_FIELD_DESC);
+      oprot.writeString(id);
+      oprot.writeFieldEnd();
+    }
+    if(this.total != null) {
+      oprot.writeFieldBegin(_TOTAL_FIELD_DESC);
+      total.write(oprot);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldStop();
+    oprot.writeStructEnd();
+  }
+
+  String toString() {
+    StringBuffer ret = new StringBuffer("Invoice(");
+
+    ret.write("id:");
+    if(this.id == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.id);
+    }
+
+    ret.write(", ");
+    ret.write("total:");
+    if(this.total == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.total);
+    }
+
+    ret.write(")");
+
+    return ret.toString();
+  }
+
+  bool operator ==(Object o) {
+    if(o == null || !(o is Invoice)) {
+      return false;
+    }
+    Invoice other = o as Invoice;
+    return this.id == other.id
+      && this.total == other.total;
+  }
+
+  int get hashCode {
+    var value = 17;
+    value = (value * 31) ^ id.hashCode;
+    value = (value * 31) ^ total.hashCode;
+    return value;
+  }
+
+  Invoice clone({
+    String id: null,
+    t_acme_shared.Money total: null,
+  }) {
+    return new Invoice()
+      ..id = id ?? this.id
+      ..total = total ?? this.total;
+  }
+
+  validate() {
+    // check for required fields
+    // check that fields of type enum have valid values
+  }
+}
@@ -0,0 +1,177 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+
+
+import 'dart:async';
+import 'dart:typed_data' show Uint8List;
+
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:frugal/frugal.dart' as frugal;
+
+import 'package:app/src/gen/acme/events.dart' as t_acme_events;
+
+
+const String delimiter = '.';
+
+class InvoiceEventsPublisher {
+  /// Describes the InvoiceEvents scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'events.frugal', 'scope', 'InvoiceEvents',
+      '5fa0ed1dae58f431046fc6d4e45333bced82c3a32001d46e3cad460efe43a183', '2.23.0',
+      const ['Created']);
+
+  frugal.FPublisherTransport transport;
+  frugal.FProtocolFactory protocolFactory;
+  Map<String, frugal.FMethod> _methods;
+  InvoiceEventsPublisher(frugal.FScopeProvider provider, [List<frugal.Middleware> middleware]) {
+    transport = provider.publisherTransportFactory.getTransport();
+    protocolFactory = provider.protocolFactory;
+    var combined = middleware ?? [];
+    combined.addAll(provider.middleware);
+    this._methods = {};
+    this._methods['Created'] = new frugal.FMethod(this._publishCreated, 'InvoiceEvents', 'publishCreated', combined);
+  }
+
+  Future open() {
+    return transport.open();
+  }
+
+  Future close() {
+    return transport.close();
+  }
+
+  Future publishCreated(frugal.FContext ctx, t_acme_events.Invoice req, {Duration timeout}) {
+    var publish = this._methods['Created']([ctx, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of Created timed out'));
+  }
+
+  Future _publishCreated(frugal.FContext ctx, t_acme_events.Invoice req) async {
+    var op = "Created";
+    var prefix = "billing.";
+    var topic = "${prefix}InvoiceEvents${delimiter}${op}";
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      req.write(oprot);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+}
+
+
+class InvoiceEventsSubscriber {
+  /// Describes the InvoiceEvents scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'events.frugal', 'scope', 'InvoiceEvents',
+      '5fa0ed1dae58f431046fc6d4e45333bced82c3a32001d46e3cad460efe43a183', '2.23.0',
+      const ['Created']);
+
+  final frugal.FScopeProvider provider;
+  final List<frugal.Middleware> _middleware;
+
+  InvoiceEventsSubscriber(this.provider, [List<frugal.Middleware> middleware])
+      : this._middleware = middleware ?? [] {
+    this._middleware.addAll(provider.middleware);
+}
+
+  Future<frugal.FSubscription> subscribeCreated(dynamic onInvoice(frugal.FContext ctx, t_acme_events.Invoice req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var op = "Created";
+    var prefix = "billing.";
+    var topic = "${prefix}InvoiceEvents${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvCreated(op, provider.protocolFactory, onInvoice),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<t_acme_events.Invoice> streamCreated({frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<t_acme_events.Invoice> controller;
+    controller = new StreamController<t_acme_events.Invoice>(
+        onListen: () {
+          subscription = subscribeCreated((frugal.FContext ctx, t_acme_events.Invoice req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
+  }
+
+  frugal.FAsyncCallback _recvCreated(String op, frugal.FProtocolFactory protocolFactory, dynamic onInvoice(frugal.FContext ctx, t_acme_events.Invoice req)) {
+    frugal.FMethod method = new frugal.FMethod(onInvoice, 'InvoiceEvents', 'subscribeInvoice', this._middleware);
+    callbackCreated(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      if (tMsg.name != op) {
+        thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+        iprot.readMessageEnd();
+        throw new thrift.TApplicationError(
+        frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      t_acme_events.Invoice req = new t_acme_events.Invoice();
+      req.read(iprot);
+      iprot.readMessageEnd();
+      method([ctx, req]);
+    }
+    return callbackCreated;
+  }
+
+
+  /// Subscribes to every operation of the scope. onMessage is called with the
+  /// name of the operation of each message and its decoded payload.
+  Future<frugal.FSubscription> subscribeAll(dynamic onMessage(frugal.FContext ctx, String op, dynamic req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var prefix = "billing.";
+    var topic = "${prefix}InvoiceEvents${delimiter}*";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvAll(provider.protocolFactory, onMessage),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  frugal.FAsyncCallback _recvAll(frugal.FProtocolFactory protocolFactory, dynamic onMessage(frugal.FContext ctx, String op, dynamic req)) {
+    frugal.FMethod method = new frugal.FMethod(onMessage, 'InvoiceEvents', 'subscribeAll', this._middleware);
+    callbackAll(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      var req;
+      switch (tMsg.name) {
+        case 'Created':
+          t_acme_events.Invoice reqCreated = new t_acme_events.Invoice();
+          reqCreated.read(iprot);
+          req = reqCreated;
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+          iprot.readMessageEnd();
+          throw new thrift.TApplicationError(
+          frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      iprot.readMessageEnd();
+      method([ctx, tMsg.name, req]);
+    }
+    return callbackAll;
+  }
+}
+
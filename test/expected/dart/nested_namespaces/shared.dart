@@ -0,0 +1,7 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+library acme.shared;
+
+export 'shared/f_money.dart' show Money;
+
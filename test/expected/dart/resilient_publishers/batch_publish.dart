@@ -0,0 +1,8 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+library batch_publish;
+
+export 'src/f_tick.dart' show Tick;
+
+export 'src/f_ticks_scope.dart' show TicksPublisher, TicksSubscriber, ResilientTicksPublisher;
@@ -0,0 +1,371 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+
+
+import 'dart:async';
+import 'dart:typed_data' show Uint8List;
+
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:frugal/frugal.dart' as frugal;
+
+import 'package:batch_publish/batch_publish.dart' as t_batch_publish;
+
+
+const String delimiter = '.';
+
+/// Market data ticks, published in batches.
+class TicksPublisher {
+  /// Describes the Ticks scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'batch_publish.frugal', 'scope', 'Ticks',
+      '298b5c8a6925dea29dce346710555efe5fe6c46147b413707493b80c1ad6b0c9', '2.23.0',
+      const ['TickReceived', 'Heartbeat']);
+
+  frugal.FPublisherTransport transport;
+  frugal.FProtocolFactory protocolFactory;
+  Map<String, frugal.FMethod> _methods;
+  TicksPublisher(frugal.FScopeProvider provider, [List<frugal.Middleware> middleware]) {
+    transport = provider.publisherTransportFactory.getTransport();
+    protocolFactory = provider.protocolFactory;
+    var combined = middleware ?? [];
+    combined.addAll(provider.middleware);
+    this._methods = {};
+    this._methods['TickReceived'] = new frugal.FMethod(this._publishTickReceived, 'Ticks', 'publishTickReceived', combined);
+    this._methods['TickReceivedBatch'] = new frugal.FMethod(this._publishTickReceivedBatch, 'Ticks', 'publishTickReceivedBatch', combined);
+    this._methods['Heartbeat'] = new frugal.FMethod(this._publishHeartbeat, 'Ticks', 'publishHeartbeat', combined);
+    this._methods['HeartbeatBatch'] = new frugal.FMethod(this._publishHeartbeatBatch, 'Ticks', 'publishHeartbeatBatch', combined);
+  }
+
+  Future open() {
+    return transport.open();
+  }
+
+  Future close() {
+    return transport.close();
+  }
+
+  Future publishTickReceived(frugal.FContext ctx, String exchange, t_batch_publish.Tick req, {Duration timeout}) {
+    var publish = this._methods['TickReceived']([ctx, exchange, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of TickReceived timed out'));
+  }
+
+  Future _publishTickReceived(frugal.FContext ctx, String exchange, t_batch_publish.Tick req) async {
+    ctx.addRequestHeader('_topic_exchange', exchange);
+    var op = "TickReceived";
+    var prefix = "market.${exchange}.";
+    var topic = "${prefix}Ticks${delimiter}${op}";
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      req.write(oprot);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+
+  /// Publishes the messages in a single frame, sent with one transport flush.
+  /// Subscribers must be generated with the batch_publish option to receive
+  /// them.
+  Future publishTickReceivedBatch(frugal.FContext ctx, String exchange, List<t_batch_publish.Tick> reqs, {Duration timeout}) {
+    var publish = this._methods['TickReceivedBatch']([ctx, exchange, reqs]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of TickReceived batch timed out'));
+  }
+
+  Future _publishTickReceivedBatch(frugal.FContext ctx, String exchange, List<t_batch_publish.Tick> reqs) async {
+    ctx.addRequestHeader('_topic_exchange', exchange);
+    var op = "TickReceived";
+    var prefix = "market.${exchange}.";
+    var topic = "${prefix}Ticks${delimiter}${op}";
+    try {
+      var frames = <Uint8List>[];
+      for (var req in reqs) {
+        var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+        var oprot = protocolFactory.getProtocol(memoryBuffer);
+        var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+        oprot.writeRequestHeader(ctx);
+        oprot.writeMessageBegin(msg);
+        req.write(oprot);
+        oprot.writeMessageEnd();
+        frames.add(memoryBuffer.writeBytes);
+      }
+      await frugal.publishBatchFrame(transport, topic, frames);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+
+
+  Future publishHeartbeat(frugal.FContext ctx, String exchange, int req, {Duration timeout}) {
+    var publish = this._methods['Heartbeat']([ctx, exchange, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of Heartbeat timed out'));
+  }
+
+  Future _publishHeartbeat(frugal.FContext ctx, String exchange, int req) async {
+    ctx.addRequestHeader('_topic_exchange', exchange);
+    var op = "Heartbeat";
+    var prefix = "market.${exchange}.";
+    var topic = "${prefix}Ticks${delimiter}${op}";
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      oprot.writeI64(req);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+
+  /// Publishes the messages in a single frame, sent with one transport flush.
+  /// Subscribers must be generated with the batch_publish option to receive
+  /// them.
+  Future publishHeartbeatBatch(frugal.FContext ctx, String exchange, List<int> reqs, {Duration timeout}) {
+    var publish = this._methods['HeartbeatBatch']([ctx, exchange, reqs]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of Heartbeat batch timed out'));
+  }
+
+  Future _publishHeartbeatBatch(frugal.FContext ctx, String exchange, List<int> reqs) async {
+    ctx.addRequestHeader('_topic_exchange', exchange);
+    var op = "Heartbeat";
+    var prefix = "market.${exchange}.";
+    var topic = "${prefix}Ticks${delimiter}${op}";
+    try {
+      var frames = <Uint8List>[];
+      for (var req in reqs) {
+        var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+        var oprot = protocolFactory.getProtocol(memoryBuffer);
+        var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+        oprot.writeRequestHeader(ctx);
+        oprot.writeMessageBegin(msg);
+        oprot.writeI64(req);
+        oprot.writeMessageEnd();
+        frames.add(memoryBuffer.writeBytes);
+      }
+      await frugal.publishBatchFrame(transport, topic, frames);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+}
+
+
+/// A [TicksPublisher] retrying publishes which fail with transient transport
+/// errors according to its retry policy. Publishes failing permanently throw
+/// an [frugal.FPublishFailedError].
+class ResilientTicksPublisher extends TicksPublisher {
+  final frugal.FRetryPolicy retryPolicy;
+
+  ResilientTicksPublisher(frugal.FScopeProvider provider,
+      {List<frugal.Middleware> middleware, this.retryPolicy: const frugal.FRetryPolicy()})
+    : super(provider, middleware);
+
+  @override
+  Future publishTickReceived(frugal.FContext ctx, String exchange, t_batch_publish.Tick req, {Duration timeout}) {
+    return frugal.retryPublish(retryPolicy, () => super.publishTickReceived(ctx, exchange, req, timeout: timeout));
+  }
+
+  @override
+  Future publishTickReceivedBatch(frugal.FContext ctx, String exchange, List<t_batch_publish.Tick> reqs, {Duration timeout}) {
+    return frugal.retryPublish(retryPolicy, () => super.publishTickReceivedBatch(ctx, exchange, reqs, timeout: timeout));
+  }
+
+  @override
+  Future publishHeartbeat(frugal.FContext ctx, String exchange, int req, {Duration timeout}) {
+    return frugal.retryPublish(retryPolicy, () => super.publishHeartbeat(ctx, exchange, req, timeout: timeout));
+  }
+
+  @override
+  Future publishHeartbeatBatch(frugal.FContext ctx, String exchange, List<int> reqs, {Duration timeout}) {
+    return frugal.retryPublish(retryPolicy, () => super.publishHeartbeatBatch(ctx, exchange, reqs, timeout: timeout));
+  }
+}
+
+
+/// Market data ticks, published in batches.
+class TicksSubscriber {
+  /// Describes the Ticks scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'batch_publish.frugal', 'scope', 'Ticks',
+      '298b5c8a6925dea29dce346710555efe5fe6c46147b413707493b80c1ad6b0c9', '2.23.0',
+      const ['TickReceived', 'Heartbeat']);
+
+  final frugal.FScopeProvider provider;
+  final List<frugal.Middleware> _middleware;
+
+  TicksSubscriber(this.provider, [List<frugal.Middleware> middleware])
+      : this._middleware = middleware ?? [] {
+    this._middleware.addAll(provider.middleware);
+}
+
+  Future<frugal.FSubscription> subscribeTickReceived(String exchange, dynamic onTick(frugal.FContext ctx, t_batch_publish.Tick req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var op = "TickReceived";
+    var prefix = "market.${exchange}.";
+    var topic = "${prefix}Ticks${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, frugal.batchFrameCallback(_recvTickReceived(op, provider.protocolFactory, onTick)),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<t_batch_publish.Tick> streamTickReceived(String exchange, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<t_batch_publish.Tick> controller;
+    controller = new StreamController<t_batch_publish.Tick>(
+        onListen: () {
+          subscription = subscribeTickReceived(exchange, (frugal.FContext ctx, t_batch_publish.Tick req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
+  }
+
+  frugal.FAsyncCallback _recvTickReceived(String op, frugal.FProtocolFactory protocolFactory, dynamic onTick(frugal.FContext ctx, t_batch_publish.Tick req)) {
+    frugal.FMethod method = new frugal.FMethod(onTick, 'Ticks', 'subscribeTick', this._middleware);
+    callbackTickReceived(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      if (tMsg.name != op) {
+        thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+        iprot.readMessageEnd();
+        throw new thrift.TApplicationError(
+        frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      t_batch_publish.Tick req = new t_batch_publish.Tick();
+      req.read(iprot);
+      iprot.readMessageEnd();
+      method([ctx, req]);
+    }
+    return callbackTickReceived;
+  }
+
+
+  Future<frugal.FSubscription> subscribeHeartbeat(String exchange, dynamic oni64(frugal.FContext ctx, int req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var op = "Heartbeat";
+    var prefix = "market.${exchange}.";
+    var topic = "${prefix}Ticks${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, frugal.batchFrameCallback(_recvHeartbeat(op, provider.protocolFactory, oni64)),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<int> streamHeartbeat(String exchange, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<int> controller;
+    controller = new StreamController<int>(
+        onListen: () {
+          subscription = subscribeHeartbeat(exchange, (frugal.FContext ctx, int req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
+  }
+
+  frugal.FAsyncCallback _recvHeartbeat(String op, frugal.FProtocolFactory protocolFactory, dynamic oni64(frugal.FContext ctx, int req)) {
+    frugal.FMethod method = new frugal.FMethod(oni64, 'Ticks', 'subscribei64', this._middleware);
+    callbackHeartbeat(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      if (tMsg.name != op) {
+        thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+        iprot.readMessageEnd();
+        throw new thrift.TApplicationError(
+        frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      int req = iprot.readI64();
+      iprot.readMessageEnd();
+      method([ctx, req]);
+    }
+    return callbackHeartbeat;
+  }
+
+
+  /// Subscribes to every operation of the scope. onMessage is called with the
+  /// name of the operation of each message and its decoded payload.
+  Future<frugal.FSubscription> subscribeAll(String exchange, dynamic onMessage(frugal.FContext ctx, String op, dynamic req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var prefix = "market.${exchange}.";
+    var topic = "${prefix}Ticks${delimiter}*";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, frugal.batchFrameCallback(_recvAll(provider.protocolFactory, onMessage)),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  frugal.FAsyncCallback _recvAll(frugal.FProtocolFactory protocolFactory, dynamic onMessage(frugal.FContext ctx, String op, dynamic req)) {
+    frugal.FMethod method = new frugal.FMethod(onMessage, 'Ticks', 'subscribeAll', this._middleware);
+    callbackAll(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      var req;
+      switch (tMsg.name) {
+        case 'TickReceived':
+          t_batch_publish.Tick reqTickReceived = new t_batch_publish.Tick();
+          reqTickReceived.read(iprot);
+          req = reqTickReceived;
+          break;
+        case 'Heartbeat':
+          int reqHeartbeat = iprot.readI64();
+          req = reqHeartbeat;
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+          iprot.readMessageEnd();
+          throw new thrift.TApplicationError(
+          frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      iprot.readMessageEnd();
+      method([ctx, tMsg.name, req]);
+    }
+    return callbackAll;
+  }
+}
+
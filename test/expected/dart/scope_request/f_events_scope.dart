@@ -0,0 +1,374 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+
+
+import 'dart:async';
+import 'dart:typed_data' show Uint8List;
+
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:frugal/frugal.dart' as frugal;
+
+import 'package:scope_request/scope_request.dart' as t_scope_request;
+
+
+const String delimiter = '.';
+
+/// Events are requests replied to by responders, in addition to being
+/// published to subscribers.
+class EventsPublisher {
+  /// Describes the Events scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'scope_request.frugal', 'scope', 'Events',
+      '3c06b96b3efb5067de1e613730698a866799c08444a66f2641a4e318daced23e', '2.23.0',
+      const ['EventCreated', 'SomeInt'],
+      const {
+        'EventCreated': "This is a docstring.",
+      });
+
+  frugal.FPublisherTransport transport;
+  frugal.FProtocolFactory protocolFactory;
+  Map<String, frugal.FMethod> _methods;
+  EventsPublisher(frugal.FScopeProvider provider, [List<frugal.Middleware> middleware]) {
+    transport = provider.publisherTransportFactory.getTransport();
+    protocolFactory = provider.protocolFactory;
+    var combined = middleware ?? [];
+    combined.addAll(provider.middleware);
+    this._methods = {};
+    this._methods['EventCreated'] = new frugal.FMethod(this._publishEventCreated, 'Events', 'publishEventCreated', combined);
+    this._methods['SomeInt'] = new frugal.FMethod(this._publishSomeInt, 'Events', 'publishSomeInt', combined);
+  }
+
+  Future open() {
+    return transport.open();
+  }
+
+  Future close() {
+    return transport.close();
+  }
+
+  /// This is a docstring.
+  Future publishEventCreated(frugal.FContext ctx, String user, t_scope_request.Event req, {Duration timeout}) {
+    var publish = this._methods['EventCreated']([ctx, user, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of EventCreated timed out'));
+  }
+
+  Future _publishEventCreated(frugal.FContext ctx, String user, t_scope_request.Event req) async {
+    ctx.addRequestHeader('_topic_user', user);
+    var op = "EventCreated";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      req.write(oprot);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+
+
+  Future publishSomeInt(frugal.FContext ctx, String user, int req, {Duration timeout}) {
+    var publish = this._methods['SomeInt']([ctx, user, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of SomeInt timed out'));
+  }
+
+  Future _publishSomeInt(frugal.FContext ctx, String user, int req) async {
+    ctx.addRequestHeader('_topic_user', user);
+    var op = "SomeInt";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      oprot.writeI64(req);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+}
+
+
+/// Events are requests replied to by responders, in addition to being
+/// published to subscribers.
+class EventsRequester {
+  final frugal.FScopeRequester _requester;
+  Map<String, frugal.FMethod> _methods;
+
+  EventsRequester(frugal.FScopeProvider provider, [List<frugal.Middleware> middleware])
+      : _requester = new frugal.FScopeRequester(provider) {
+    var combined = middleware ?? [];
+    combined.addAll(provider.middleware);
+    this._methods = {};
+    this._methods['EventCreated'] = new frugal.FMethod(this._requestEventCreated, 'Events', 'requestEventCreated', combined);
+  }
+
+  Future open() {
+    return _requester.open();
+  }
+
+  Future close() {
+    return _requester.close();
+  }
+
+  /// This is a docstring.
+  Future<t_scope_request.EventWrapper> requestEventCreated(frugal.FContext ctx, String user, t_scope_request.Event req) {
+    return this._methods['EventCreated']([ctx, user, req]) as Future<t_scope_request.EventWrapper>;
+  }
+
+  Future<t_scope_request.EventWrapper> _requestEventCreated(frugal.FContext ctx, String user, t_scope_request.Event req) async {
+    ctx.addRequestHeader('_topic_user', user);
+    var op = "EventCreated";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    return await _requester.request(ctx, topic, op, (frugal.FProtocol oprot) {
+      req.write(oprot);
+    }, (frugal.FProtocol iprot) {
+      t_scope_request.EventWrapper reply = new t_scope_request.EventWrapper();
+      reply.read(iprot);
+      return reply;
+    });
+  }
+}
+
+
+/// Events are requests replied to by responders, in addition to being
+/// published to subscribers.
+class EventsSubscriber {
+  /// Describes the Events scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'scope_request.frugal', 'scope', 'Events',
+      '3c06b96b3efb5067de1e613730698a866799c08444a66f2641a4e318daced23e', '2.23.0',
+      const ['EventCreated', 'SomeInt'],
+      const {
+        'EventCreated': "This is a docstring.",
+      });
+
+  final frugal.FScopeProvider provider;
+  final List<frugal.Middleware> _middleware;
+
+  EventsSubscriber(this.provider, [List<frugal.Middleware> middleware])
+      : this._middleware = middleware ?? [] {
+    this._middleware.addAll(provider.middleware);
+}
+
+  /// This is a docstring.
+  Future<frugal.FSubscription> subscribeEventCreated(String user, dynamic onEvent(frugal.FContext ctx, t_scope_request.Event req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var op = "EventCreated";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvEventCreated(op, provider.protocolFactory, onEvent),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  /// This is a docstring.
+  Stream<t_scope_request.Event> streamEventCreated(String user, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<t_scope_request.Event> controller;
+    controller = new StreamController<t_scope_request.Event>(
+        onListen: () {
+          subscription = subscribeEventCreated(user, (frugal.FContext ctx, t_scope_request.Event req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
+  }
+
+  frugal.FAsyncCallback _recvEventCreated(String op, frugal.FProtocolFactory protocolFactory, dynamic onEvent(frugal.FContext ctx, t_scope_request.Event req)) {
+    frugal.FMethod method = new frugal.FMethod(onEvent, 'Events', 'subscribeEvent', this._middleware);
+    callbackEventCreated(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      if (tMsg.name != op) {
+        thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+        iprot.readMessageEnd();
+        throw new thrift.TApplicationError(
+        frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      t_scope_request.Event req = new t_scope_request.Event();
+      req.read(iprot);
+      iprot.readMessageEnd();
+      method([ctx, req]);
+    }
+    return callbackEventCreated;
+  }
+
+
+  Future<frugal.FSubscription> subscribeSomeInt(String user, dynamic oni64(frugal.FContext ctx, int req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var op = "SomeInt";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvSomeInt(op, provider.protocolFactory, oni64),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<int> streamSomeInt(String user, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<int> controller;
+    controller = new StreamController<int>(
+        onListen: () {
+          subscription = subscribeSomeInt(user, (frugal.FContext ctx, int req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
+  }
+
+  frugal.FAsyncCallback _recvSomeInt(String op, frugal.FProtocolFactory protocolFactory, dynamic oni64(frugal.FContext ctx, int req)) {
+    frugal.FMethod method = new frugal.FMethod(oni64, 'Events', 'subscribei64', this._middleware);
+    callbackSomeInt(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      if (tMsg.name != op) {
+        thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+        iprot.readMessageEnd();
+        throw new thrift.TApplicationError(
+        frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      int req = iprot.readI64();
+      iprot.readMessageEnd();
+      method([ctx, req]);
+    }
+    return callbackSomeInt;
+  }
+
+
+  /// Subscribes to every operation of the scope. onMessage is called with the
+  /// name of the operation of each message and its decoded payload.
+  Future<frugal.FSubscription> subscribeAll(String user, dynamic onMessage(frugal.FContext ctx, String op, dynamic req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}*";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvAll(provider.protocolFactory, onMessage),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  frugal.FAsyncCallback _recvAll(frugal.FProtocolFactory protocolFactory, dynamic onMessage(frugal.FContext ctx, String op, dynamic req)) {
+    frugal.FMethod method = new frugal.FMethod(onMessage, 'Events', 'subscribeAll', this._middleware);
+    callbackAll(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      var req;
+      switch (tMsg.name) {
+        case 'EventCreated':
+          t_scope_request.Event reqEventCreated = new t_scope_request.Event();
+          reqEventCreated.read(iprot);
+          req = reqEventCreated;
+          break;
+        case 'SomeInt':
+          int reqSomeInt = iprot.readI64();
+          req = reqSomeInt;
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+          iprot.readMessageEnd();
+          throw new thrift.TApplicationError(
+          frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      iprot.readMessageEnd();
+      method([ctx, tMsg.name, req]);
+    }
+    return callbackAll;
+  }
+}
+
+
+/// Events are requests replied to by responders, in addition to being
+/// published to subscribers.
+class EventsResponder {
+  final frugal.FScopeProvider provider;
+  final frugal.FScopeResponder _responder;
+  final List<frugal.Middleware> _middleware;
+
+  EventsResponder(frugal.FScopeProvider provider, [List<frugal.Middleware> middleware])
+      : this.provider = provider,
+        _responder = new frugal.FScopeResponder(provider),
+        _middleware = middleware ?? [] {
+    this._middleware.addAll(provider.middleware);
+  }
+
+  Future close() {
+    return _responder.close();
+  }
+
+  /// This is a docstring.
+  Future<frugal.FSubscription> respondEventCreated(String user, dynamic onEvent(frugal.FContext ctx, t_scope_request.Event req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var op = "EventCreated";
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _respondEventCreated(op, provider.protocolFactory, onEvent),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  frugal.FAsyncCallback _respondEventCreated(String op, frugal.FProtocolFactory protocolFactory, dynamic onEvent(frugal.FContext ctx, t_scope_request.Event req)) {
+    frugal.FMethod method = new frugal.FMethod(onEvent, 'Events', 'respondEventCreated', this._middleware);
+    callbackEventCreated(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      if (tMsg.name != op) {
+        thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+        iprot.readMessageEnd();
+        throw new thrift.TApplicationError(
+        frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      t_scope_request.Event req = new t_scope_request.Event();
+      req.read(iprot);
+      iprot.readMessageEnd();
+      _responder.reply(ctx, op, new Future.sync(() => method([ctx, req])),
+          (frugal.FProtocol oprot, t_scope_request.EventWrapper reply) {
+        reply.write(oprot);
+      });
+    }
+    return callbackEventCreated;
+  }
+}
+
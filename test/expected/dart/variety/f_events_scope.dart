@@ -18,6 +18,15 @@ const String delimiter = '.';
 /// the @ sign. Prefix specifies topic prefix tokens, which can be static or
 /// variable.
 class EventsPublisher {
+  /// Describes the Events scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'variety.frugal', 'scope', 'Events',
+      '660b9d3624d5677b532f8f7b53a07b9cbfd10edb64f73a24c4858e545e47098e', '2.23.0',
+      const ['EventCreated', 'SomeInt', 'SomeStr', 'SomeList'],
+      const {
+        'EventCreated': "This is a docstring.",
+      });
+
   frugal.FPublisherTransport transport;
   frugal.FProtocolFactory protocolFactory;
   Map<String, frugal.FMethod> _methods;
@@ -42,8 +51,13 @@ class EventsPublisher {
   }
 
   /// This is a docstring.
-  Future publishEventCreated(frugal.FContext ctx, String user, t_variety.Event req) {
-    return this._methods['EventCreated']([ctx, user, req]);
+  Future publishEventCreated(frugal.FContext ctx, String user, t_variety.Event req, {Duration timeout}) {
+    var publish = this._methods['EventCreated']([ctx, user, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of EventCreated timed out'));
   }
 
   Future _publishEventCreated(frugal.FContext ctx, String user, t_variety.Event req) async {
@@ -51,19 +65,28 @@ class EventsPublisher {
     var op = "EventCreated";
     var prefix = "foo.${user}.";
     var topic = "${prefix}Events${delimiter}${op}";
-    var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
-    var oprot = protocolFactory.getProtocol(memoryBuffer);
-    var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
-    oprot.writeRequestHeader(ctx);
-    oprot.writeMessageBegin(msg);
-    req.write(oprot);
-    oprot.writeMessageEnd();
-    await transport.publish(topic, memoryBuffer.writeBytes);
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      req.write(oprot);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
   }
 
 
-  Future publishSomeInt(frugal.FContext ctx, String user, int req) {
-    return this._methods['SomeInt']([ctx, user, req]);
+  Future publishSomeInt(frugal.FContext ctx, String user, int req, {Duration timeout}) {
+    var publish = this._methods['SomeInt']([ctx, user, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of SomeInt timed out'));
   }
 
   Future _publishSomeInt(frugal.FContext ctx, String user, int req) async {
@@ -71,19 +94,28 @@ class EventsPublisher {
     var op = "SomeInt";
     var prefix = "foo.${user}.";
     var topic = "${prefix}Events${delimiter}${op}";
-    var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
-    var oprot = protocolFactory.getProtocol(memoryBuffer);
-    var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
-    oprot.writeRequestHeader(ctx);
-    oprot.writeMessageBegin(msg);
-    oprot.writeI64(req);
-    oprot.writeMessageEnd();
-    await transport.publish(topic, memoryBuffer.writeBytes);
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      oprot.writeI64(req);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
   }
 
 
-  Future publishSomeStr(frugal.FContext ctx, String user, String req) {
-    return this._methods['SomeStr']([ctx, user, req]);
+  Future publishSomeStr(frugal.FContext ctx, String user, String req, {Duration timeout}) {
+    var publish = this._methods['SomeStr']([ctx, user, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of SomeStr timed out'));
   }
 
   Future _publishSomeStr(frugal.FContext ctx, String user, String req) async {
@@ -91,19 +123,28 @@ class EventsPublisher {
     var op = "SomeStr";
     var prefix = "foo.${user}.";
     var topic = "${prefix}Events${delimiter}${op}";
-    var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
-    var oprot = protocolFactory.getProtocol(memoryBuffer);
-    var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
-    oprot.writeRequestHeader(ctx);
-    oprot.writeMessageBegin(msg);
-    oprot.writeString(req);
-    oprot.writeMessageEnd();
-    await transport.publish(topic, memoryBuffer.writeBytes);
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      oprot.writeString(req);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
   }
 
 
-  Future publishSomeList(frugal.FContext ctx, String user, List<Map<int, t_variety.Event>> req) {
-    return this._methods['SomeList']([ctx, user, req]);
+  Future publishSomeList(frugal.FContext ctx, String user, List<Map<int, t_variety.Event>> req, {Duration timeout}) {
+    var publish = this._methods['SomeList']([ctx, user, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of SomeList timed out'));
   }
 
   Future _publishSomeList(frugal.FContext ctx, String user, List<Map<int, t_variety.Event>> req) async {
@@ -111,23 +152,27 @@ class EventsPublisher {
     var op = "SomeList";
     var prefix = "foo.${user}.";
     var topic = "${prefix}Events${delimiter}${op}";
-    var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
-    var oprot = protocolFactory.getProtocol(memoryBuffer);
-    var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
-    oprot.writeRequestHeader(ctx);
-    oprot.writeMessageBegin(msg);
-    oprot.writeListBegin(new thrift.TList(thrift.TType.MAP, req.length));
-    for(var elem72 in req) {
-      oprot.writeMapBegin(new thrift.TMap(thrift.TType.I64, thrift.TType.STRUCT, elem72.length));
-      for(var elem73 in elem72.keys) {
-        oprot.writeI64(elem73);
-        elem72[elem73].write(oprot);
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      oprot.writeListBegin(new thrift.TList(thrift.TType.MAP, req.length));
+      for(var elem72 in req) {
+        oprot.writeMapBegin(new thrift.TMap(thrift.TType.I64, thrift.TType.STRUCT, elem72.length));
+        for(var elem73 in elem72.keys) {
+          oprot.writeI64(elem73);
+          elem72[elem73].write(oprot);
+        }
+        oprot.writeMapEnd();
       }
-      oprot.writeMapEnd();
+      oprot.writeListEnd();
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
     }
-    oprot.writeListEnd();
-    oprot.writeMessageEnd();
-    await transport.publish(topic, memoryBuffer.writeBytes);
   }
 }
 
@@ -136,6 +181,15 @@ class EventsPublisher {
 /// the @ sign. Prefix specifies topic prefix tokens, which can be static or
 /// variable.
 class EventsSubscriber {
+  /// Describes the Events scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'variety.frugal', 'scope', 'Events',
+      '660b9d3624d5677b532f8f7b53a07b9cbfd10edb64f73a24c4858e545e47098e', '2.23.0',
+      const ['EventCreated', 'SomeInt', 'SomeStr', 'SomeList'],
+      const {
+        'EventCreated': "This is a docstring.",
+      });
+
   final frugal.FScopeProvider provider;
   final List<frugal.Middleware> _middleware;
 
@@ -145,13 +199,38 @@ class EventsSubscriber {
 }
 
   /// This is a docstring.
-  Future<frugal.FSubscription> subscribeEventCreated(String user, dynamic onEvent(frugal.FContext ctx, t_variety.Event req)) async {
+  Future<frugal.FSubscription> subscribeEventCreated(String user, dynamic onEvent(frugal.FContext ctx, t_variety.Event req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
     var op = "EventCreated";
     var prefix = "foo.${user}.";
     var topic = "${prefix}Events${delimiter}${op}";
     var transport = provider.subscriberTransportFactory.getTransport();
-    await transport.subscribe(topic, _recvEventCreated(op, provider.protocolFactory, onEvent));
-    return new frugal.FSubscription(topic, transport);
+    return frugal.subscribeWithPolicy(topic, transport, _recvEventCreated(op, provider.protocolFactory, onEvent),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  /// This is a docstring.
+  Stream<t_variety.Event> streamEventCreated(String user, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<t_variety.Event> controller;
+    controller = new StreamController<t_variety.Event>(
+        onListen: () {
+          subscription = subscribeEventCreated(user, (frugal.FContext ctx, t_variety.Event req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
   }
 
   frugal.FAsyncCallback _recvEventCreated(String op, frugal.FProtocolFactory protocolFactory, dynamic onEvent(frugal.FContext ctx, t_variety.Event req)) {
@@ -175,13 +254,37 @@ class EventsSubscriber {
   }
 
 
-  Future<frugal.FSubscription> subscribeSomeInt(String user, dynamic oni64(frugal.FContext ctx, int req)) async {
+  Future<frugal.FSubscription> subscribeSomeInt(String user, dynamic oni64(frugal.FContext ctx, int req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
     var op = "SomeInt";
     var prefix = "foo.${user}.";
     var topic = "${prefix}Events${delimiter}${op}";
     var transport = provider.subscriberTransportFactory.getTransport();
-    await transport.subscribe(topic, _recvSomeInt(op, provider.protocolFactory, oni64));
-    return new frugal.FSubscription(topic, transport);
+    return frugal.subscribeWithPolicy(topic, transport, _recvSomeInt(op, provider.protocolFactory, oni64),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<int> streamSomeInt(String user, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<int> controller;
+    controller = new StreamController<int>(
+        onListen: () {
+          subscription = subscribeSomeInt(user, (frugal.FContext ctx, int req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
   }
 
   frugal.FAsyncCallback _recvSomeInt(String op, frugal.FProtocolFactory protocolFactory, dynamic oni64(frugal.FContext ctx, int req)) {
@@ -204,13 +307,37 @@ class EventsSubscriber {
   }
 
 
-  Future<frugal.FSubscription> subscribeSomeStr(String user, dynamic onstring(frugal.FContext ctx, String req)) async {
+  Future<frugal.FSubscription> subscribeSomeStr(String user, dynamic onstring(frugal.FContext ctx, String req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
     var op = "SomeStr";
     var prefix = "foo.${user}.";
     var topic = "${prefix}Events${delimiter}${op}";
     var transport = provider.subscriberTransportFactory.getTransport();
-    await transport.subscribe(topic, _recvSomeStr(op, provider.protocolFactory, onstring));
-    return new frugal.FSubscription(topic, transport);
+    return frugal.subscribeWithPolicy(topic, transport, _recvSomeStr(op, provider.protocolFactory, onstring),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<String> streamSomeStr(String user, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<String> controller;
+    controller = new StreamController<String>(
+        onListen: () {
+          subscription = subscribeSomeStr(user, (frugal.FContext ctx, String req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
   }
 
   frugal.FAsyncCallback _recvSomeStr(String op, frugal.FProtocolFactory protocolFactory, dynamic onstring(frugal.FContext ctx, String req)) {
@@ -233,13 +360,37 @@ class EventsSubscriber {
   }
 
 
-  Future<frugal.FSubscription> subscribeSomeList(String user, dynamic onlist(frugal.FContext ctx, List<Map<int, t_variety.Event>> req)) async {
+  Future<frugal.FSubscription> subscribeSomeList(String user, dynamic onlist(frugal.FContext ctx, List<Map<int, t_variety.Event>> req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
     var op = "SomeList";
     var prefix = "foo.${user}.";
     var topic = "${prefix}Events${delimiter}${op}";
     var transport = provider.subscriberTransportFactory.getTransport();
-    await transport.subscribe(topic, _recvSomeList(op, provider.protocolFactory, onlist));
-    return new frugal.FSubscription(topic, transport);
+    return frugal.subscribeWithPolicy(topic, transport, _recvSomeList(op, provider.protocolFactory, onlist),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<List<Map<int, t_variety.Event>>> streamSomeList(String user, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<List<Map<int, t_variety.Event>>> controller;
+    controller = new StreamController<List<Map<int, t_variety.Event>>>(
+        onListen: () {
+          subscription = subscribeSomeList(user, (frugal.FContext ctx, List<Map<int, t_variety.Event>> req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
   }
 
   frugal.FAsyncCallback _recvSomeList(String op, frugal.FProtocolFactory protocolFactory, dynamic onlist(frugal.FContext ctx, List<Map<int, t_variety.Event>> req)) {
@@ -274,5 +425,67 @@ class EventsSubscriber {
     }
     return callbackSomeList;
   }
+
+
+  /// Subscribes to every operation of the scope. onMessage is called with the
+  /// name of the operation of each message and its decoded payload.
+  Future<frugal.FSubscription> subscribeAll(String user, dynamic onMessage(frugal.FContext ctx, String op, dynamic req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    var prefix = "foo.${user}.";
+    var topic = "${prefix}Events${delimiter}*";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvAll(provider.protocolFactory, onMessage),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  frugal.FAsyncCallback _recvAll(frugal.FProtocolFactory protocolFactory, dynamic onMessage(frugal.FContext ctx, String op, dynamic req)) {
+    frugal.FMethod method = new frugal.FMethod(onMessage, 'Events', 'subscribeAll', this._middleware);
+    callbackAll(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      var req;
+      switch (tMsg.name) {
+        case 'EventCreated':
+          t_variety.Event reqEventCreated = new t_variety.Event();
+          reqEventCreated.read(iprot);
+          req = reqEventCreated;
+          break;
+        case 'SomeInt':
+          int reqSomeInt = iprot.readI64();
+          req = reqSomeInt;
+          break;
+        case 'SomeStr':
+          String reqSomeStr = iprot.readString();
+          req = reqSomeStr;
+          break;
+        case 'SomeList':
+          thrift.TList elem81 = iprot.readListBegin();
+          List<Map<int, t_variety.Event>> reqSomeList = new List<Map<int, t_variety.Event>>();
+          for(int elem87 = 0; elem87 < elem81.length; ++elem87) {
+            thrift.TMap elem83 = iprot.readMapBegin();
+            Map<int, t_variety.Event> elem82 = new Map<int, t_variety.Event>();
+            for(int elem85 = 0; elem85 < elem83.length; ++elem85) {
+              int elem86 = iprot.readI64();
+              t_variety.Event elem84 = new t_variety.Event();
+              elem84.read(iprot);
+              elem82[elem86] = elem84;
+            }
+            iprot.readMapEnd();
+            reqSomeList.add(elem82);
+          }
+          iprot.readListEnd();
+          req = reqSomeList;
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+          iprot.readMessageEnd();
+          throw new thrift.TApplicationError(
+          frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      iprot.readMessageEnd();
+      method([ctx, tMsg.name, req]);
+    }
+    return callbackAll;
+  }
 }
 
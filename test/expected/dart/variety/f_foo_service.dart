@@ -20,6 +20,11 @@ import 'package:variety/variety.dart' as t_variety;
 /// This is a thrift service. Frugal will generate bindings that include
 /// a frugal Context for each service call.
 abstract class FFoo extends t_actual_base_dart.FBaseFoo {
+  /// Describes the Foo service contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'variety.frugal', 'service', 'Foo',
+      'a193743f25c5aede9ade7f9d0d5c99adc48638d0db1499acfcdd8f4fd9adb80b', '2.23.0',
+      const ['Ping', 'blah', 'oneWay', 'bin_method', 'param_modifiers', 'underlying_types_test', 'getThing', 'getMyInt', 'use_subdir_struct', 'sayHelloWith', 'whatDoYouSay', 'sayAgain']);
 
   /// Ping the server.
   /// Deprecated: don't use this; use "something else"
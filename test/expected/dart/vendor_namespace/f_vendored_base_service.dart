@@ -14,6 +14,11 @@ import 'package:vendor_namespace/vendor_namespace.dart' as t_vendor_namespace;
 
 
 abstract class FVendoredBase {
+  /// Describes the VendoredBase service contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'vendor_namespace.frugal', 'service', 'VendoredBase',
+      '37b9f40340a87618458c6083cbdb1e50a5948abb1983ce33b7a481a6163250e7', '2.23.0',
+      const []);
 }
 
 class FVendoredBaseClient implements FVendoredBase {
@@ -7,5 +7,4 @@ export 'src/f_vendor_namespace_constants.dart' show VendorNamespaceConstants;
 export 'src/f_item.dart' show Item;
 export 'src/f_my_enum.dart' show MyEnum;
 
-export 'src/f_vendored_base_service.dart' show FVendoredBase;
-export 'src/f_vendored_base_service.dart' show FVendoredBaseClient;
+export 'src/f_vendored_base_service.dart' show FVendoredBase, FVendoredBaseClient;
@@ -0,0 +1,337 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package filter
+
+import (
+	"fmt"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Workiva/frugal/lib/go"
+	"github.com/Workiva/frugal/test/out/artifact_out/models/filter"
+)
+
+var _ = filter.GoUnusedProtection__
+
+const delimiter = "."
+
+// AlertsContractHash is a hash of the Alerts scope contract.
+// Publishers and subscribers built from the same contract have the same hash.
+const AlertsContractHash = "b6d84b49700968a710de95d2c7bff219f3aa278598dfd240b6681cf6d1c3ca25"
+
+// AlertsMetadata describes the Alerts scope contract.
+var AlertsMetadata = &frugal.FContractMetadata{
+	IDLFile:         "filter.frugal",
+	Kind:            "scope",
+	Name:            "Alerts",
+	Hash:            AlertsContractHash,
+	CompilerVersion: "2.23.0",
+	Operations: []string{
+		"AlertRaised",
+	},
+}
+
+type AlertsPublisher interface {
+	Open() error
+	Close() error
+	PublishAlertRaised(ctx frugal.FContext, req *filter.Alert) error
+}
+
+type alertsPublisher struct {
+	provider        *frugal.FScopeProvider
+	transport       frugal.FPublisherTransport
+	protocolFactory *frugal.FProtocolFactory
+	methods         map[string]*frugal.Method
+}
+
+func NewAlertsPublisher(provider *frugal.FScopeProvider, middleware ...frugal.ServiceMiddleware) AlertsPublisher {
+	transport, protocolFactory := provider.NewPublisher()
+	methods := make(map[string]*frugal.Method)
+	publisher := &alertsPublisher{
+		provider:        provider,
+		transport:       transport,
+		protocolFactory: protocolFactory,
+		methods:         methods,
+	}
+	middleware = append(middleware, provider.GetMiddleware()...)
+	methods["publishAlertRaised"] = frugal.NewMethod(publisher, publisher.publishAlertRaised, "publishAlertRaised", middleware)
+	return publisher
+}
+
+// NewAlertsBatchPublisher returns an implementation of AlertsPublisher
+// which stages messages in the given batch, publishing them along with the
+// rest of the batch when it's flushed.
+func NewAlertsBatchPublisher(provider *frugal.FScopeProvider, batch *frugal.FPublishBatch, middleware ...frugal.ServiceMiddleware) AlertsPublisher {
+	return NewAlertsPublisher(provider.WithPublishBatch(batch), middleware...)
+}
+
+func (p *alertsPublisher) Open() error {
+	return p.transport.Open()
+}
+
+func (p *alertsPublisher) Close() error {
+	return p.transport.Close()
+}
+
+func (p *alertsPublisher) PublishAlertRaised(ctx frugal.FContext, req *filter.Alert) error {
+	ret := p.methods["publishAlertRaised"].Invoke([]interface{}{ctx, req})
+	if ret[0] != nil {
+		return ret[0].(error)
+	}
+	return nil
+}
+
+func (p *alertsPublisher) publishAlertRaised(ctx frugal.FContext, req *filter.Alert) error {
+	op := "AlertRaised"
+	prefix := ""
+	topic := fmt.Sprintf("%sAlerts%s%s", prefix, delimiter, op)
+	if err := p.provider.Authorize(&frugal.FAuthorizationRequest{
+		Action:    frugal.FScopeActionPublish,
+		Topic:     topic,
+		Operation: op,
+		Context:   ctx,
+	}); err != nil {
+		return err
+	}
+	buffer := frugal.NewTMemoryOutputBuffer(p.transport.GetPublishSizeLimit())
+	oprot := p.protocolFactory.GetProtocol(buffer)
+	if err := oprot.WriteRequestHeader(ctx); err != nil {
+		return err
+	}
+	if err := oprot.WriteMessageBegin(op, thrift.CALL, 0); err != nil {
+		return err
+	}
+	if err := req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", req), err)
+	}
+	if err := oprot.WriteMessageEnd(); err != nil {
+		return err
+	}
+	if err := oprot.Flush(); err != nil {
+		return err
+	}
+	return frugal.Publish(ctx, p.transport, topic, buffer.Bytes())
+}
+
+type alertsNoopPublisher struct{}
+
+// NewAlertsNoopPublisher returns an implementation of AlertsPublisher
+// which discards every message published to it. This is useful for tests
+// or for disabling publishing behind a feature flag.
+func NewAlertsNoopPublisher() AlertsPublisher {
+	return &alertsNoopPublisher{}
+}
+
+func (p *alertsNoopPublisher) Open() error {
+	return nil
+}
+
+func (p *alertsNoopPublisher) Close() error {
+	return nil
+}
+
+func (p *alertsNoopPublisher) PublishAlertRaised(ctx frugal.FContext, req *filter.Alert) error {
+	return nil
+}
+
+type alertsFanOutPublisher struct {
+	publishers []AlertsPublisher
+}
+
+// NewAlertsFanOutPublisher returns an implementation of AlertsPublisher
+// which publishes every message to each of the given publishers, e.g. to
+// publish to both brokers during a migration. Every publisher is attempted
+// and the first error encountered is returned.
+func NewAlertsFanOutPublisher(publishers ...AlertsPublisher) AlertsPublisher {
+	return &alertsFanOutPublisher{publishers: publishers}
+}
+
+func (p *alertsFanOutPublisher) Open() error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Open(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *alertsFanOutPublisher) Close() error {
+	var err error
+	for _, publisher := range p.publishers {
+		if closeErr := publisher.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (p *alertsFanOutPublisher) PublishAlertRaised(ctx frugal.FContext, req *filter.Alert) error {
+	var err error
+	for _, publisher := range p.publishers {
+		if publishErr := publisher.PublishAlertRaised(ctx, req); publishErr != nil && err == nil {
+			err = publishErr
+		}
+	}
+	return err
+}
+
+type AlertsSubscriber interface {
+	SubscribeAlertRaised(handler func(frugal.FContext, *filter.Alert)) (*frugal.FSubscription, error)
+	SubscribeAlertRaisedFiltered(filter func(frugal.FContext, *filter.Alert) bool, handler func(frugal.FContext, *filter.Alert)) (*frugal.FSubscription, error)
+	SubscribeAll(handler func(frugal.FContext, string, interface{})) (*frugal.FSubscription, error)
+}
+
+type AlertsErrorableSubscriber interface {
+	SubscribeAlertRaisedErrorable(handler func(frugal.FContext, *filter.Alert) error) (*frugal.FSubscription, error)
+	SubscribeAlertRaisedErrorableFiltered(filter func(frugal.FContext, *filter.Alert) bool, handler func(frugal.FContext, *filter.Alert) error) (*frugal.FSubscription, error)
+	SubscribeAllErrorable(handler func(frugal.FContext, string, interface{}) error) (*frugal.FSubscription, error)
+}
+
+type alertsSubscriber struct {
+	provider   *frugal.FScopeProvider
+	middleware []frugal.ServiceMiddleware
+}
+
+func NewAlertsSubscriber(provider *frugal.FScopeProvider, middleware ...frugal.ServiceMiddleware) AlertsSubscriber {
+	middleware = append(middleware, provider.GetMiddleware()...)
+	return &alertsSubscriber{provider: provider, middleware: middleware}
+}
+
+func NewAlertsErrorableSubscriber(provider *frugal.FScopeProvider, middleware ...frugal.ServiceMiddleware) AlertsErrorableSubscriber {
+	middleware = append(middleware, provider.GetMiddleware()...)
+	return &alertsSubscriber{provider: provider, middleware: middleware}
+}
+
+func (l *alertsSubscriber) SubscribeAlertRaised(handler func(frugal.FContext, *filter.Alert)) (*frugal.FSubscription, error) {
+	return l.SubscribeAlertRaisedErrorable(func(fctx frugal.FContext, arg *filter.Alert) error {
+		handler(fctx, arg)
+		return nil
+	})
+}
+
+func (l *alertsSubscriber) SubscribeAlertRaisedErrorable(handler func(frugal.FContext, *filter.Alert) error) (*frugal.FSubscription, error) {
+	op := "AlertRaised"
+	prefix := ""
+	topic := fmt.Sprintf("%sAlerts%s%s", prefix, delimiter, op)
+	if err := l.provider.Authorize(&frugal.FAuthorizationRequest{
+		Action:    frugal.FScopeActionSubscribe,
+		Topic:     topic,
+		Operation: op,
+	}); err != nil {
+		return nil, err
+	}
+	transport, protocolFactory := l.provider.NewSubscriber()
+	cb := l.recvAlertRaised(op, protocolFactory, handler)
+	if err := transport.Subscribe(topic, cb); err != nil {
+		return nil, err
+	}
+
+	sub := frugal.NewFSubscription(topic, transport)
+	return sub, nil
+}
+
+func (l *alertsSubscriber) SubscribeAlertRaisedFiltered(filter func(frugal.FContext, *filter.Alert) bool, handler func(frugal.FContext, *filter.Alert)) (*frugal.FSubscription, error) {
+	return l.SubscribeAlertRaisedErrorableFiltered(filter, func(fctx frugal.FContext, arg *filter.Alert) error {
+		handler(fctx, arg)
+		return nil
+	})
+}
+
+func (l *alertsSubscriber) SubscribeAlertRaisedErrorableFiltered(filter func(frugal.FContext, *filter.Alert) bool, handler func(frugal.FContext, *filter.Alert) error) (*frugal.FSubscription, error) {
+	return l.SubscribeAlertRaisedErrorable(func(fctx frugal.FContext, arg *filter.Alert) error {
+		if !filter(fctx, arg) {
+			return nil
+		}
+		return handler(fctx, arg)
+	})
+}
+
+func (l *alertsSubscriber) recvAlertRaised(op string, pf *frugal.FProtocolFactory, handler func(frugal.FContext, *filter.Alert) error) frugal.FAsyncCallback {
+	method := frugal.NewMethod(l, handler, "SubscribeAlertRaised", l.middleware)
+	return func(transport thrift.TTransport) error {
+		iprot := pf.GetProtocol(transport)
+		ctx, err := iprot.ReadRequestHeader()
+		if err != nil {
+			return err
+		}
+
+		name, _, _, err := iprot.ReadMessageBegin()
+		if err != nil {
+			return err
+		}
+
+		if name != op {
+			iprot.Skip(thrift.STRUCT)
+			iprot.ReadMessageEnd()
+			return thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_UNKNOWN_METHOD, "Unknown function"+name)
+		}
+		req := filter.NewAlert()
+		if err := req.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", req), err)
+		}
+		iprot.ReadMessageEnd()
+
+		return method.Invoke([]interface{}{ctx, req}).Error()
+	}
+}
+
+func (l *alertsSubscriber) SubscribeAll(handler func(frugal.FContext, string, interface{})) (*frugal.FSubscription, error) {
+	return l.SubscribeAllErrorable(func(fctx frugal.FContext, op string, arg interface{}) error {
+		handler(fctx, op, arg)
+		return nil
+	})
+}
+
+func (l *alertsSubscriber) SubscribeAllErrorable(handler func(frugal.FContext, string, interface{}) error) (*frugal.FSubscription, error) {
+	prefix := ""
+	topic := fmt.Sprintf("%sAlerts%s*", prefix, delimiter)
+	for _, op := range []string{"AlertRaised"} {
+		if err := l.provider.Authorize(&frugal.FAuthorizationRequest{
+			Action:    frugal.FScopeActionSubscribe,
+			Topic:     topic,
+			Operation: op,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	transport, protocolFactory := l.provider.NewSubscriber()
+	if err := transport.Subscribe(topic, l.recvAll(protocolFactory, handler)); err != nil {
+		return nil, err
+	}
+
+	sub := frugal.NewFSubscription(topic, transport)
+	return sub, nil
+}
+
+func (l *alertsSubscriber) recvAll(pf *frugal.FProtocolFactory, handler func(frugal.FContext, string, interface{}) error) frugal.FAsyncCallback {
+	method := frugal.NewMethod(l, handler, "SubscribeAll", l.middleware)
+	return func(transport thrift.TTransport) error {
+		iprot := pf.GetProtocol(transport)
+		ctx, err := iprot.ReadRequestHeader()
+		if err != nil {
+			return err
+		}
+
+		name, _, _, err := iprot.ReadMessageBegin()
+		if err != nil {
+			return err
+		}
+
+		var arg interface{}
+		switch name {
+		case "AlertRaised":
+			req := filter.NewAlert()
+			if err := req.Read(iprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", req), err)
+			}
+			arg = req
+		default:
+			iprot.Skip(thrift.STRUCT)
+			iprot.ReadMessageEnd()
+			return thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_UNKNOWN_METHOD, "Unknown function"+name)
+		}
+		iprot.ReadMessageEnd()
+
+		return method.Invoke([]interface{}{ctx, name, arg}).Error()
+	}
+}
@@ -12,6 +12,28 @@ import (
 
 const delimiter = "."
 
+// EventsContractHash is a hash of the Events scope contract.
+// Publishers and subscribers built from the same contract have the same hash.
+const EventsContractHash = "660b9d3624d5677b532f8f7b53a07b9cbfd10edb64f73a24c4858e545e47098e"
+
+// EventsMetadata describes the Events scope contract.
+var EventsMetadata = &frugal.FContractMetadata{
+	IDLFile:         "variety.frugal",
+	Kind:            "scope",
+	Name:            "Events",
+	Hash:            EventsContractHash,
+	CompilerVersion: "2.23.0",
+	Operations: []string{
+		"EventCreated",
+		"SomeInt",
+		"SomeStr",
+		"SomeList",
+	},
+	Descriptions: map[string]string{
+		"EventCreated": "This is a docstring.",
+	},
+}
+
 // This docstring gets added to the generated code because it has
 // the @ sign. Prefix specifies topic prefix tokens, which can be static or
 // variable.
@@ -25,6 +47,7 @@ type EventsPublisher interface {
 }
 
 type eventsPublisher struct {
+	provider        *frugal.FScopeProvider
 	transport       frugal.FPublisherTransport
 	protocolFactory *frugal.FProtocolFactory
 	methods         map[string]*frugal.Method
@@ -34,6 +57,7 @@ func NewEventsPublisher(provider *frugal.FScopeProvider, middleware ...frugal.Se
 	transport, protocolFactory := provider.NewPublisher()
 	methods := make(map[string]*frugal.Method)
 	publisher := &eventsPublisher{
+		provider:        provider,
 		transport:       transport,
 		protocolFactory: protocolFactory,
 		methods:         methods,
@@ -46,6 +70,13 @@ func NewEventsPublisher(provider *frugal.FScopeProvider, middleware ...frugal.Se
 	return publisher
 }
 
+// NewEventsBatchPublisher returns an implementation of EventsPublisher
+// which stages messages in the given batch, publishing them along with the
+// rest of the batch when it's flushed.
+func NewEventsBatchPublisher(provider *frugal.FScopeProvider, batch *frugal.FPublishBatch, middleware ...frugal.ServiceMiddleware) EventsPublisher {
+	return NewEventsPublisher(provider.WithPublishBatch(batch), middleware...)
+}
+
 func (p *eventsPublisher) Open() error {
 	return p.transport.Open()
 }
@@ -68,6 +99,14 @@ func (p *eventsPublisher) publishEventCreated(ctx frugal.FContext, user string,
 	op := "EventCreated"
 	prefix := fmt.Sprintf("foo.%s.", user)
 	topic := fmt.Sprintf("%sEvents%s%s", prefix, delimiter, op)
+	if err := p.provider.Authorize(&frugal.FAuthorizationRequest{
+		Action:    frugal.FScopeActionPublish,
+		Topic:     topic,
+		Operation: op,
+		Context:   ctx,
+	}); err != nil {
+		return err
+	}
 	buffer := frugal.NewTMemoryOutputBuffer(p.transport.GetPublishSizeLimit())
 	oprot := p.protocolFactory.GetProtocol(buffer)
 	if err := oprot.WriteRequestHeader(ctx); err != nil {
@@ -85,7 +124,7 @@ func (p *eventsPublisher) publishEventCreated(ctx frugal.FContext, user string,
 	if err := oprot.Flush(); err != nil {
 		return err
 	}
-	return p.transport.Publish(topic, buffer.Bytes())
+	return frugal.Publish(ctx, p.transport, topic, buffer.Bytes())
 }
 
 func (p *eventsPublisher) PublishSomeInt(ctx frugal.FContext, user string, req int64) error {
@@ -101,6 +140,14 @@ func (p *eventsPublisher) publishSomeInt(ctx frugal.FContext, user string, req i
 	op := "SomeInt"
 	prefix := fmt.Sprintf("foo.%s.", user)
 	topic := fmt.Sprintf("%sEvents%s%s", prefix, delimiter, op)
+	if err := p.provider.Authorize(&frugal.FAuthorizationRequest{
+		Action:    frugal.FScopeActionPublish,
+		Topic:     topic,
+		Operation: op,
+		Context:   ctx,
+	}); err != nil {
+		return err
+	}
 	buffer := frugal.NewTMemoryOutputBuffer(p.transport.GetPublishSizeLimit())
 	oprot := p.protocolFactory.GetProtocol(buffer)
 	if err := oprot.WriteRequestHeader(ctx); err != nil {
@@ -118,7 +165,7 @@ func (p *eventsPublisher) publishSomeInt(ctx frugal.FContext, user string, req i
 	if err := oprot.Flush(); err != nil {
 		return err
 	}
-	return p.transport.Publish(topic, buffer.Bytes())
+	return frugal.Publish(ctx, p.transport, topic, buffer.Bytes())
 }
 
 func (p *eventsPublisher) PublishSomeStr(ctx frugal.FContext, user string, req string) error {
@@ -134,6 +181,14 @@ func (p *eventsPublisher) publishSomeStr(ctx frugal.FContext, user string, req s
 	op := "SomeStr"
 	prefix := fmt.Sprintf("foo.%s.", user)
 	topic := fmt.Sprintf("%sEvents%s%s", prefix, delimiter, op)
+	if err := p.provider.Authorize(&frugal.FAuthorizationRequest{
+		Action:    frugal.FScopeActionPublish,
+		Topic:     topic,
+		Operation: op,
+		Context:   ctx,
+	}); err != nil {
+		return err
+	}
 	buffer := frugal.NewTMemoryOutputBuffer(p.transport.GetPublishSizeLimit())
 	oprot := p.protocolFactory.GetProtocol(buffer)
 	if err := oprot.WriteRequestHeader(ctx); err != nil {
@@ -151,7 +206,7 @@ func (p *eventsPublisher) publishSomeStr(ctx frugal.FContext, user string, req s
 	if err := oprot.Flush(); err != nil {
 		return err
 	}
-	return p.transport.Publish(topic, buffer.Bytes())
+	return frugal.Publish(ctx, p.transport, topic, buffer.Bytes())
 }
 
 func (p *eventsPublisher) PublishSomeList(ctx frugal.FContext, user string, req []map[ID]*Event) error {
@@ -167,6 +222,14 @@ func (p *eventsPublisher) publishSomeList(ctx frugal.FContext, user string, req
 	op := "SomeList"
 	prefix := fmt.Sprintf("foo.%s.", user)
 	topic := fmt.Sprintf("%sEvents%s%s", prefix, delimiter, op)
+	if err := p.provider.Authorize(&frugal.FAuthorizationRequest{
+		Action:    frugal.FScopeActionPublish,
+		Topic:     topic,
+		Operation: op,
+		Context:   ctx,
+	}); err != nil {
+		return err
+	}
 	buffer := frugal.NewTMemoryOutputBuffer(p.transport.GetPublishSizeLimit())
 	oprot := p.protocolFactory.GetProtocol(buffer)
 	if err := oprot.WriteRequestHeader(ctx); err != nil {
@@ -203,7 +266,111 @@ func (p *eventsPublisher) publishSomeList(ctx frugal.FContext, user string, req
 	if err := oprot.Flush(); err != nil {
 		return err
 	}
-	return p.transport.Publish(topic, buffer.Bytes())
+	return frugal.Publish(ctx, p.transport, topic, buffer.Bytes())
+}
+
+type eventsNoopPublisher struct{}
+
+// NewEventsNoopPublisher returns an implementation of EventsPublisher
+// which discards every message published to it. This is useful for tests
+// or for disabling publishing behind a feature flag.
+func NewEventsNoopPublisher() EventsPublisher {
+	return &eventsNoopPublisher{}
+}
+
+func (p *eventsNoopPublisher) Open() error {
+	return nil
+}
+
+func (p *eventsNoopPublisher) Close() error {
+	return nil
+}
+
+func (p *eventsNoopPublisher) PublishEventCreated(ctx frugal.FContext, user string, req *Event) error {
+	return nil
+}
+
+func (p *eventsNoopPublisher) PublishSomeInt(ctx frugal.FContext, user string, req int64) error {
+	return nil
+}
+
+func (p *eventsNoopPublisher) PublishSomeStr(ctx frugal.FContext, user string, req string) error {
+	return nil
+}
+
+func (p *eventsNoopPublisher) PublishSomeList(ctx frugal.FContext, user string, req []map[ID]*Event) error {
+	return nil
+}
+
+type eventsFanOutPublisher struct {
+	publishers []EventsPublisher
+}
+
+// NewEventsFanOutPublisher returns an implementation of EventsPublisher
+// which publishes every message to each of the given publishers, e.g. to
+// publish to both brokers during a migration. Every publisher is attempted
+// and the first error encountered is returned.
+func NewEventsFanOutPublisher(publishers ...EventsPublisher) EventsPublisher {
+	return &eventsFanOutPublisher{publishers: publishers}
+}
+
+func (p *eventsFanOutPublisher) Open() error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Open(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *eventsFanOutPublisher) Close() error {
+	var err error
+	for _, publisher := range p.publishers {
+		if closeErr := publisher.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (p *eventsFanOutPublisher) PublishEventCreated(ctx frugal.FContext, user string, req *Event) error {
+	var err error
+	for _, publisher := range p.publishers {
+		if publishErr := publisher.PublishEventCreated(ctx, user, req); publishErr != nil && err == nil {
+			err = publishErr
+		}
+	}
+	return err
+}
+
+func (p *eventsFanOutPublisher) PublishSomeInt(ctx frugal.FContext, user string, req int64) error {
+	var err error
+	for _, publisher := range p.publishers {
+		if publishErr := publisher.PublishSomeInt(ctx, user, req); publishErr != nil && err == nil {
+			err = publishErr
+		}
+	}
+	return err
+}
+
+func (p *eventsFanOutPublisher) PublishSomeStr(ctx frugal.FContext, user string, req string) error {
+	var err error
+	for _, publisher := range p.publishers {
+		if publishErr := publisher.PublishSomeStr(ctx, user, req); publishErr != nil && err == nil {
+			err = publishErr
+		}
+	}
+	return err
+}
+
+func (p *eventsFanOutPublisher) PublishSomeList(ctx frugal.FContext, user string, req []map[ID]*Event) error {
+	var err error
+	for _, publisher := range p.publishers {
+		if publishErr := publisher.PublishSomeList(ctx, user, req); publishErr != nil && err == nil {
+			err = publishErr
+		}
+	}
+	return err
 }
 
 // This docstring gets added to the generated code because it has
@@ -211,9 +378,14 @@ func (p *eventsPublisher) publishSomeList(ctx frugal.FContext, user string, req
 // variable.
 type EventsSubscriber interface {
 	SubscribeEventCreated(user string, handler func(frugal.FContext, *Event)) (*frugal.FSubscription, error)
+	SubscribeEventCreatedFiltered(user string, filter func(frugal.FContext, *Event) bool, handler func(frugal.FContext, *Event)) (*frugal.FSubscription, error)
 	SubscribeSomeInt(user string, handler func(frugal.FContext, int64)) (*frugal.FSubscription, error)
+	SubscribeSomeIntFiltered(user string, filter func(frugal.FContext, int64) bool, handler func(frugal.FContext, int64)) (*frugal.FSubscription, error)
 	SubscribeSomeStr(user string, handler func(frugal.FContext, string)) (*frugal.FSubscription, error)
+	SubscribeSomeStrFiltered(user string, filter func(frugal.FContext, string) bool, handler func(frugal.FContext, string)) (*frugal.FSubscription, error)
 	SubscribeSomeList(user string, handler func(frugal.FContext, []map[ID]*Event)) (*frugal.FSubscription, error)
+	SubscribeSomeListFiltered(user string, filter func(frugal.FContext, []map[ID]*Event) bool, handler func(frugal.FContext, []map[ID]*Event)) (*frugal.FSubscription, error)
+	SubscribeAll(user string, handler func(frugal.FContext, string, interface{})) (*frugal.FSubscription, error)
 }
 
 // This docstring gets added to the generated code because it has
@@ -221,9 +393,14 @@ type EventsSubscriber interface {
 // variable.
 type EventsErrorableSubscriber interface {
 	SubscribeEventCreatedErrorable(user string, handler func(frugal.FContext, *Event) error) (*frugal.FSubscription, error)
+	SubscribeEventCreatedErrorableFiltered(user string, filter func(frugal.FContext, *Event) bool, handler func(frugal.FContext, *Event) error) (*frugal.FSubscription, error)
 	SubscribeSomeIntErrorable(user string, handler func(frugal.FContext, int64) error) (*frugal.FSubscription, error)
+	SubscribeSomeIntErrorableFiltered(user string, filter func(frugal.FContext, int64) bool, handler func(frugal.FContext, int64) error) (*frugal.FSubscription, error)
 	SubscribeSomeStrErrorable(user string, handler func(frugal.FContext, string) error) (*frugal.FSubscription, error)
+	SubscribeSomeStrErrorableFiltered(user string, filter func(frugal.FContext, string) bool, handler func(frugal.FContext, string) error) (*frugal.FSubscription, error)
 	SubscribeSomeListErrorable(user string, handler func(frugal.FContext, []map[ID]*Event) error) (*frugal.FSubscription, error)
+	SubscribeSomeListErrorableFiltered(user string, filter func(frugal.FContext, []map[ID]*Event) bool, handler func(frugal.FContext, []map[ID]*Event) error) (*frugal.FSubscription, error)
+	SubscribeAllErrorable(user string, handler func(frugal.FContext, string, interface{}) error) (*frugal.FSubscription, error)
 }
 
 type eventsSubscriber struct {
@@ -254,6 +431,13 @@ func (l *eventsSubscriber) SubscribeEventCreatedErrorable(user string, handler f
 	op := "EventCreated"
 	prefix := fmt.Sprintf("foo.%s.", user)
 	topic := fmt.Sprintf("%sEvents%s%s", prefix, delimiter, op)
+	if err := l.provider.Authorize(&frugal.FAuthorizationRequest{
+		Action:    frugal.FScopeActionSubscribe,
+		Topic:     topic,
+		Operation: op,
+	}); err != nil {
+		return nil, err
+	}
 	transport, protocolFactory := l.provider.NewSubscriber()
 	cb := l.recvEventCreated(op, protocolFactory, handler)
 	if err := transport.Subscribe(topic, cb); err != nil {
@@ -264,6 +448,24 @@ func (l *eventsSubscriber) SubscribeEventCreatedErrorable(user string, handler f
 	return sub, nil
 }
 
+// This is a docstring.
+func (l *eventsSubscriber) SubscribeEventCreatedFiltered(user string, filter func(frugal.FContext, *Event) bool, handler func(frugal.FContext, *Event)) (*frugal.FSubscription, error) {
+	return l.SubscribeEventCreatedErrorableFiltered(user, filter, func(fctx frugal.FContext, arg *Event) error {
+		handler(fctx, arg)
+		return nil
+	})
+}
+
+// This is a docstring.
+func (l *eventsSubscriber) SubscribeEventCreatedErrorableFiltered(user string, filter func(frugal.FContext, *Event) bool, handler func(frugal.FContext, *Event) error) (*frugal.FSubscription, error) {
+	return l.SubscribeEventCreatedErrorable(user, func(fctx frugal.FContext, arg *Event) error {
+		if !filter(fctx, arg) {
+			return nil
+		}
+		return handler(fctx, arg)
+	})
+}
+
 func (l *eventsSubscriber) recvEventCreated(op string, pf *frugal.FProtocolFactory, handler func(frugal.FContext, *Event) error) frugal.FAsyncCallback {
 	method := frugal.NewMethod(l, handler, "SubscribeEventCreated", l.middleware)
 	return func(transport thrift.TTransport) error {
@@ -304,6 +506,13 @@ func (l *eventsSubscriber) SubscribeSomeIntErrorable(user string, handler func(f
 	op := "SomeInt"
 	prefix := fmt.Sprintf("foo.%s.", user)
 	topic := fmt.Sprintf("%sEvents%s%s", prefix, delimiter, op)
+	if err := l.provider.Authorize(&frugal.FAuthorizationRequest{
+		Action:    frugal.FScopeActionSubscribe,
+		Topic:     topic,
+		Operation: op,
+	}); err != nil {
+		return nil, err
+	}
 	transport, protocolFactory := l.provider.NewSubscriber()
 	cb := l.recvSomeInt(op, protocolFactory, handler)
 	if err := transport.Subscribe(topic, cb); err != nil {
@@ -314,6 +523,22 @@ func (l *eventsSubscriber) SubscribeSomeIntErrorable(user string, handler func(f
 	return sub, nil
 }
 
+func (l *eventsSubscriber) SubscribeSomeIntFiltered(user string, filter func(frugal.FContext, int64) bool, handler func(frugal.FContext, int64)) (*frugal.FSubscription, error) {
+	return l.SubscribeSomeIntErrorableFiltered(user, filter, func(fctx frugal.FContext, arg int64) error {
+		handler(fctx, arg)
+		return nil
+	})
+}
+
+func (l *eventsSubscriber) SubscribeSomeIntErrorableFiltered(user string, filter func(frugal.FContext, int64) bool, handler func(frugal.FContext, int64) error) (*frugal.FSubscription, error) {
+	return l.SubscribeSomeIntErrorable(user, func(fctx frugal.FContext, arg int64) error {
+		if !filter(fctx, arg) {
+			return nil
+		}
+		return handler(fctx, arg)
+	})
+}
+
 func (l *eventsSubscriber) recvSomeInt(op string, pf *frugal.FProtocolFactory, handler func(frugal.FContext, int64) error) frugal.FAsyncCallback {
 	method := frugal.NewMethod(l, handler, "SubscribeSomeInt", l.middleware)
 	return func(transport thrift.TTransport) error {
@@ -356,6 +581,13 @@ func (l *eventsSubscriber) SubscribeSomeStrErrorable(user string, handler func(f
 	op := "SomeStr"
 	prefix := fmt.Sprintf("foo.%s.", user)
 	topic := fmt.Sprintf("%sEvents%s%s", prefix, delimiter, op)
+	if err := l.provider.Authorize(&frugal.FAuthorizationRequest{
+		Action:    frugal.FScopeActionSubscribe,
+		Topic:     topic,
+		Operation: op,
+	}); err != nil {
+		return nil, err
+	}
 	transport, protocolFactory := l.provider.NewSubscriber()
 	cb := l.recvSomeStr(op, protocolFactory, handler)
 	if err := transport.Subscribe(topic, cb); err != nil {
@@ -366,6 +598,22 @@ func (l *eventsSubscriber) SubscribeSomeStrErrorable(user string, handler func(f
 	return sub, nil
 }
 
+func (l *eventsSubscriber) SubscribeSomeStrFiltered(user string, filter func(frugal.FContext, string) bool, handler func(frugal.FContext, string)) (*frugal.FSubscription, error) {
+	return l.SubscribeSomeStrErrorableFiltered(user, filter, func(fctx frugal.FContext, arg string) error {
+		handler(fctx, arg)
+		return nil
+	})
+}
+
+func (l *eventsSubscriber) SubscribeSomeStrErrorableFiltered(user string, filter func(frugal.FContext, string) bool, handler func(frugal.FContext, string) error) (*frugal.FSubscription, error) {
+	return l.SubscribeSomeStrErrorable(user, func(fctx frugal.FContext, arg string) error {
+		if !filter(fctx, arg) {
+			return nil
+		}
+		return handler(fctx, arg)
+	})
+}
+
 func (l *eventsSubscriber) recvSomeStr(op string, pf *frugal.FProtocolFactory, handler func(frugal.FContext, string) error) frugal.FAsyncCallback {
 	method := frugal.NewMethod(l, handler, "SubscribeSomeStr", l.middleware)
 	return func(transport thrift.TTransport) error {
@@ -408,6 +656,13 @@ func (l *eventsSubscriber) SubscribeSomeListErrorable(user string, handler func(
 	op := "SomeList"
 	prefix := fmt.Sprintf("foo.%s.", user)
 	topic := fmt.Sprintf("%sEvents%s%s", prefix, delimiter, op)
+	if err := l.provider.Authorize(&frugal.FAuthorizationRequest{
+		Action:    frugal.FScopeActionSubscribe,
+		Topic:     topic,
+		Operation: op,
+	}); err != nil {
+		return nil, err
+	}
 	transport, protocolFactory := l.provider.NewSubscriber()
 	cb := l.recvSomeList(op, protocolFactory, handler)
 	if err := transport.Subscribe(topic, cb); err != nil {
@@ -418,6 +673,22 @@ func (l *eventsSubscriber) SubscribeSomeListErrorable(user string, handler func(
 	return sub, nil
 }
 
+func (l *eventsSubscriber) SubscribeSomeListFiltered(user string, filter func(frugal.FContext, []map[ID]*Event) bool, handler func(frugal.FContext, []map[ID]*Event)) (*frugal.FSubscription, error) {
+	return l.SubscribeSomeListErrorableFiltered(user, filter, func(fctx frugal.FContext, arg []map[ID]*Event) error {
+		handler(fctx, arg)
+		return nil
+	})
+}
+
+func (l *eventsSubscriber) SubscribeSomeListErrorableFiltered(user string, filter func(frugal.FContext, []map[ID]*Event) bool, handler func(frugal.FContext, []map[ID]*Event) error) (*frugal.FSubscription, error) {
+	return l.SubscribeSomeListErrorable(user, func(fctx frugal.FContext, arg []map[ID]*Event) error {
+		if !filter(fctx, arg) {
+			return nil
+		}
+		return handler(fctx, arg)
+	})
+}
+
 func (l *eventsSubscriber) recvSomeList(op string, pf *frugal.FProtocolFactory, handler func(frugal.FContext, []map[ID]*Event) error) frugal.FAsyncCallback {
 	method := frugal.NewMethod(l, handler, "SubscribeSomeList", l.middleware)
 	return func(transport thrift.TTransport) error {
@@ -475,3 +746,115 @@ func (l *eventsSubscriber) recvSomeList(op string, pf *frugal.FProtocolFactory,
 		return method.Invoke([]interface{}{ctx, req}).Error()
 	}
 }
+
+func (l *eventsSubscriber) SubscribeAll(user string, handler func(frugal.FContext, string, interface{})) (*frugal.FSubscription, error) {
+	return l.SubscribeAllErrorable(user, func(fctx frugal.FContext, op string, arg interface{}) error {
+		handler(fctx, op, arg)
+		return nil
+	})
+}
+
+func (l *eventsSubscriber) SubscribeAllErrorable(user string, handler func(frugal.FContext, string, interface{}) error) (*frugal.FSubscription, error) {
+	prefix := fmt.Sprintf("foo.%s.", user)
+	topic := fmt.Sprintf("%sEvents%s*", prefix, delimiter)
+	for _, op := range []string{"EventCreated", "SomeInt", "SomeStr", "SomeList"} {
+		if err := l.provider.Authorize(&frugal.FAuthorizationRequest{
+			Action:    frugal.FScopeActionSubscribe,
+			Topic:     topic,
+			Operation: op,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	transport, protocolFactory := l.provider.NewSubscriber()
+	if err := transport.Subscribe(topic, l.recvAll(protocolFactory, handler)); err != nil {
+		return nil, err
+	}
+
+	sub := frugal.NewFSubscription(topic, transport)
+	return sub, nil
+}
+
+func (l *eventsSubscriber) recvAll(pf *frugal.FProtocolFactory, handler func(frugal.FContext, string, interface{}) error) frugal.FAsyncCallback {
+	method := frugal.NewMethod(l, handler, "SubscribeAll", l.middleware)
+	return func(transport thrift.TTransport) error {
+		iprot := pf.GetProtocol(transport)
+		ctx, err := iprot.ReadRequestHeader()
+		if err != nil {
+			return err
+		}
+
+		name, _, _, err := iprot.ReadMessageBegin()
+		if err != nil {
+			return err
+		}
+
+		var arg interface{}
+		switch name {
+		case "EventCreated":
+			req := NewEvent()
+			if err := req.Read(iprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", req), err)
+			}
+			arg = req
+		case "SomeInt":
+			var req int64
+			if v, err := iprot.ReadI64(); err != nil {
+				return thrift.PrependError("error reading field 0: ", err)
+			} else {
+				req = v
+			}
+			arg = req
+		case "SomeStr":
+			var req string
+			if v, err := iprot.ReadString(); err != nil {
+				return thrift.PrependError("error reading field 0: ", err)
+			} else {
+				req = v
+			}
+			arg = req
+		case "SomeList":
+			_, size, err := iprot.ReadListBegin()
+			if err != nil {
+				return thrift.PrependError("error reading list begin: ", err)
+			}
+			req := make([]map[ID]*Event, 0, size)
+			for i := 0; i < size; i++ {
+				_, _, size, err := iprot.ReadMapBegin()
+				if err != nil {
+					return thrift.PrependError("error reading map begin: ", err)
+				}
+				elem24 := make(map[ID]*Event, size)
+				for i := 0; i < size; i++ {
+					var elem25 ID
+					if v, err := iprot.ReadI64(); err != nil {
+						return thrift.PrependError("error reading field 0: ", err)
+					} else {
+						temp := ID(v)
+						elem25 = temp
+					}
+					elem26 := NewEvent()
+					if err := elem26.Read(iprot); err != nil {
+						return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", elem26), err)
+					}
+					(elem24)[elem25] = elem26
+				}
+				if err := iprot.ReadMapEnd(); err != nil {
+					return thrift.PrependError("error reading map end: ", err)
+				}
+				req = append(req, elem24)
+			}
+			if err := iprot.ReadListEnd(); err != nil {
+				return thrift.PrependError("error reading list end: ", err)
+			}
+			arg = req
+		default:
+			iprot.Skip(thrift.STRUCT)
+			iprot.ReadMessageEnd()
+			return thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_UNKNOWN_METHOD, "Unknown function"+name)
+		}
+		iprot.ReadMessageEnd()
+
+		return method.Invoke([]interface{}{ctx, name, arg}).Error()
+	}
+}
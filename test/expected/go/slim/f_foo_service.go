@@ -22,6 +22,33 @@ var _ = fmt.Printf
 var _ = bytes.Equal
 var _ = logrus.DebugLevel
 
+// FooContractHash is a hash of the Foo service contract.
+// Clients and servers built from the same contract have the same hash.
+const FooContractHash = "a193743f25c5aede9ade7f9d0d5c99adc48638d0db1499acfcdd8f4fd9adb80b"
+
+// FooMetadata describes the Foo service contract.
+var FooMetadata = &frugal.FContractMetadata{
+	IDLFile:         "variety.frugal",
+	Kind:            "service",
+	Name:            "Foo",
+	Hash:            FooContractHash,
+	CompilerVersion: "2.23.0",
+	Operations: []string{
+		"Ping",
+		"blah",
+		"oneWay",
+		"bin_method",
+		"param_modifiers",
+		"underlying_types_test",
+		"getThing",
+		"getMyInt",
+		"use_subdir_struct",
+		"sayHelloWith",
+		"whatDoYouSay",
+		"sayAgain",
+	},
+}
+
 // This is a thrift service. Frugal will generate bindings that include
 // a frugal Context for each service call.
 type FFoo interface {
@@ -2187,6 +2214,10 @@ func (p *FooBlahArgs) IsSetEvent() bool {
 	return p.Event != nil
 }
 
+func (p *FooBlahArgs) UnsetEvent() {
+	p.Event = nil
+}
+
 func (p *FooBlahArgs) GetEvent() *Event {
 	if !p.IsSetEvent() {
 		return FooBlahArgs_Event_DEFAULT
@@ -2285,6 +2316,10 @@ func (p *FooBlahResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
+func (p *FooBlahResult) UnsetSuccess() {
+	p.Success = nil
+}
+
 func (p *FooBlahResult) GetSuccess() int64 {
 	if !p.IsSetSuccess() {
 		return FooBlahResult_Success_DEFAULT
@@ -2298,6 +2333,10 @@ func (p *FooBlahResult) IsSetAwe() bool {
 	return p.Awe != nil
 }
 
+func (p *FooBlahResult) UnsetAwe() {
+	p.Awe = nil
+}
+
 func (p *FooBlahResult) GetAwe() *AwesomeException {
 	if !p.IsSetAwe() {
 		return FooBlahResult_Awe_DEFAULT
@@ -2311,6 +2350,10 @@ func (p *FooBlahResult) IsSetAPI() bool {
 	return p.API != nil
 }
 
+func (p *FooBlahResult) UnsetAPI() {
+	p.API = nil
+}
+
 func (p *FooBlahResult) GetAPI() *golang.APIException {
 	if !p.IsSetAPI() {
 		return FooBlahResult_API_DEFAULT
@@ -2625,6 +2668,10 @@ func (p *FooBinMethodResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
+func (p *FooBinMethodResult) UnsetSuccess() {
+	p.Success = nil
+}
+
 func (p *FooBinMethodResult) GetSuccess() []byte {
 	return p.Success
 }
@@ -2635,6 +2682,10 @@ func (p *FooBinMethodResult) IsSetAPI() bool {
 	return p.API != nil
 }
 
+func (p *FooBinMethodResult) UnsetAPI() {
+	p.API = nil
+}
+
 func (p *FooBinMethodResult) GetAPI() *golang.APIException {
 	if !p.IsSetAPI() {
 		return FooBinMethodResult_API_DEFAULT
@@ -2830,6 +2881,10 @@ func (p *FooParamModifiersResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
+func (p *FooParamModifiersResult) UnsetSuccess() {
+	p.Success = nil
+}
+
 func (p *FooParamModifiersResult) GetSuccess() int64 {
 	if !p.IsSetSuccess() {
 		return FooParamModifiersResult_Success_DEFAULT
@@ -3063,6 +3118,10 @@ func (p *FooUnderlyingTypesTestResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
+func (p *FooUnderlyingTypesTestResult) UnsetSuccess() {
+	p.Success = nil
+}
+
 func (p *FooUnderlyingTypesTestResult) GetSuccess() []ID {
 	return p.Success
 }
@@ -3228,6 +3287,10 @@ func (p *FooGetThingResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
+func (p *FooGetThingResult) UnsetSuccess() {
+	p.Success = nil
+}
+
 func (p *FooGetThingResult) GetSuccess() *validStructs.Thing {
 	if !p.IsSetSuccess() {
 		return FooGetThingResult_Success_DEFAULT
@@ -3361,6 +3424,10 @@ func (p *FooGetMyIntResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
+func (p *FooGetMyIntResult) UnsetSuccess() {
+	p.Success = nil
+}
+
 func (p *FooGetMyIntResult) GetSuccess() ValidTypes.MyInt {
 	if !p.IsSetSuccess() {
 		return FooGetMyIntResult_Success_DEFAULT
@@ -3443,6 +3510,10 @@ func (p *FooUseSubdirStructArgs) IsSetA() bool {
 	return p.A != nil
 }
 
+func (p *FooUseSubdirStructArgs) UnsetA() {
+	p.A = nil
+}
+
 func (p *FooUseSubdirStructArgs) GetA() *subdir_include.A {
 	if !p.IsSetA() {
 		return FooUseSubdirStructArgs_A_DEFAULT
@@ -3521,6 +3592,10 @@ func (p *FooUseSubdirStructResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
+func (p *FooUseSubdirStructResult) UnsetSuccess() {
+	p.Success = nil
+}
+
 func (p *FooUseSubdirStructResult) GetSuccess() *subdir_include.A {
 	if !p.IsSetSuccess() {
 		return FooUseSubdirStructResult_Success_DEFAULT
@@ -3671,6 +3746,10 @@ func (p *FooSayHelloWithResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
+func (p *FooSayHelloWithResult) UnsetSuccess() {
+	p.Success = nil
+}
+
 func (p *FooSayHelloWithResult) GetSuccess() string {
 	if !p.IsSetSuccess() {
 		return FooSayHelloWithResult_Success_DEFAULT
@@ -3822,6 +3901,10 @@ func (p *FooWhatDoYouSayResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
+func (p *FooWhatDoYouSayResult) UnsetSuccess() {
+	p.Success = nil
+}
+
 func (p *FooWhatDoYouSayResult) GetSuccess() string {
 	if !p.IsSetSuccess() {
 		return FooWhatDoYouSayResult_Success_DEFAULT
@@ -3973,6 +4056,10 @@ func (p *FooSayAgainResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
+func (p *FooSayAgainResult) UnsetSuccess() {
+	p.Success = nil
+}
+
 func (p *FooSayAgainResult) GetSuccess() string {
 	if !p.IsSetSuccess() {
 		return FooSayAgainResult_Success_DEFAULT
@@ -278,6 +278,10 @@ func (p *TestBase) IsSetBaseStruct() bool {
 	return p.BaseStruct != nil
 }
 
+func (p *TestBase) UnsetBaseStruct() {
+	p.BaseStruct = nil
+}
+
 func (p *TestBase) GetBaseStruct() *golang.Thing {
 	if !p.IsSetBaseStruct() {
 		return TestBase_BaseStruct_DEFAULT
@@ -556,6 +560,10 @@ func (p *TestingDefaults) IsSetID2() bool {
 	return p.ID2 != TestingDefaults_ID2_DEFAULT
 }
 
+func (p *TestingDefaults) UnsetID2() {
+	p.ID2 = TestingDefaults_ID2_DEFAULT
+}
+
 func (p *TestingDefaults) GetID2() ID {
 	return p.ID2
 }
@@ -569,6 +577,10 @@ func (p *TestingDefaults) IsSetEv1() bool {
 	return p.Ev1 != nil
 }
 
+func (p *TestingDefaults) UnsetEv1() {
+	p.Ev1 = nil
+}
+
 func (p *TestingDefaults) GetEv1() *Event {
 	if !p.IsSetEv1() {
 		return TestingDefaults_Ev1_DEFAULT
@@ -585,6 +597,10 @@ func (p *TestingDefaults) IsSetEv2() bool {
 	return p.Ev2 != nil
 }
 
+func (p *TestingDefaults) UnsetEv2() {
+	p.Ev2 = nil
+}
+
 func (p *TestingDefaults) GetEv2() *Event {
 	if !p.IsSetEv2() {
 		return TestingDefaults_Ev2_DEFAULT
@@ -606,6 +622,10 @@ func (p *TestingDefaults) IsSetThing2() bool {
 	return p.Thing2 != TestingDefaults_Thing2_DEFAULT
 }
 
+func (p *TestingDefaults) UnsetThing2() {
+	p.Thing2 = TestingDefaults_Thing2_DEFAULT
+}
+
 func (p *TestingDefaults) GetThing2() string {
 	return p.Thing2
 }
@@ -628,6 +648,10 @@ func (p *TestingDefaults) IsSetBinField2() bool {
 	return p.BinField2 != nil
 }
 
+func (p *TestingDefaults) UnsetBinField2() {
+	p.BinField2 = nil
+}
+
 func (p *TestingDefaults) GetBinField2() []byte {
 	return p.BinField2
 }
@@ -642,6 +666,10 @@ func (p *TestingDefaults) IsSetBinField4() bool {
 	return !bytes.Equal(p.BinField4, TestingDefaults_BinField4_DEFAULT)
 }
 
+func (p *TestingDefaults) UnsetBinField4() {
+	p.BinField4 = TestingDefaults_BinField4_DEFAULT
+}
+
 func (p *TestingDefaults) GetBinField4() []byte {
 	return p.BinField4
 }
@@ -658,6 +686,10 @@ func (p *TestingDefaults) IsSetList2() bool {
 	return p.List2 != nil
 }
 
+func (p *TestingDefaults) UnsetList2() {
+	p.List2 = nil
+}
+
 func (p *TestingDefaults) GetList2() []Int {
 	if !p.IsSetList2() {
 		return TestingDefaults_List2_DEFAULT
@@ -671,6 +703,10 @@ func (p *TestingDefaults) IsSetList3() bool {
 	return p.List3 != nil
 }
 
+func (p *TestingDefaults) UnsetList3() {
+	p.List3 = nil
+}
+
 func (p *TestingDefaults) GetList3() []Int {
 	return p.List3
 }
@@ -688,6 +724,10 @@ func (p *TestingDefaults) IsSetAMap() bool {
 	return p.AMap != nil
 }
 
+func (p *TestingDefaults) UnsetAMap() {
+	p.AMap = nil
+}
+
 func (p *TestingDefaults) GetAMap() map[string]string {
 	if !p.IsSetAMap() {
 		return TestingDefaults_AMap_DEFAULT
@@ -1153,6 +1193,10 @@ func (p *EventWrapper) IsSetID() bool {
 	return p.ID != nil
 }
 
+func (p *EventWrapper) UnsetID() {
+	p.ID = nil
+}
+
 func (p *EventWrapper) GetID() ID {
 	if !p.IsSetID() {
 		return EventWrapper_ID_DEFAULT
@@ -1166,6 +1210,10 @@ func (p *EventWrapper) IsSetEv() bool {
 	return p.Ev != nil
 }
 
+func (p *EventWrapper) UnsetEv() {
+	p.Ev = nil
+}
+
 func (p *EventWrapper) GetEv() *Event {
 	if !p.IsSetEv() {
 		return EventWrapper_Ev_DEFAULT
@@ -1203,6 +1251,10 @@ func (p *EventWrapper) IsSetAUnion() bool {
 	return p.AUnion != nil
 }
 
+func (p *EventWrapper) UnsetAUnion() {
+	p.AUnion = nil
+}
+
 func (p *EventWrapper) GetAUnion() *TestingUnions {
 	if !p.IsSetAUnion() {
 		return EventWrapper_AUnion_DEFAULT
@@ -1742,6 +1794,10 @@ func (p *TestingUnions) IsSetAnID() bool {
 	return p.AnID != nil
 }
 
+func (p *TestingUnions) UnsetAnID() {
+	p.AnID = nil
+}
+
 func (p *TestingUnions) GetAnID() ID {
 	if !p.IsSetAnID() {
 		return TestingUnions_AnID_DEFAULT
@@ -1755,6 +1811,10 @@ func (p *TestingUnions) IsSetAString() bool {
 	return p.AString != nil
 }
 
+func (p *TestingUnions) UnsetAString() {
+	p.AString = nil
+}
+
 func (p *TestingUnions) GetAString() string {
 	if !p.IsSetAString() {
 		return TestingUnions_AString_DEFAULT
@@ -1768,6 +1828,10 @@ func (p *TestingUnions) IsSetSomeotherthing() bool {
 	return p.Someotherthing != nil
 }
 
+func (p *TestingUnions) UnsetSomeotherthing() {
+	p.Someotherthing = nil
+}
+
 func (p *TestingUnions) GetSomeotherthing() Int {
 	if !p.IsSetSomeotherthing() {
 		return TestingUnions_Someotherthing_DEFAULT
@@ -1781,6 +1845,10 @@ func (p *TestingUnions) IsSetAnInt16() bool {
 	return p.AnInt16 != nil
 }
 
+func (p *TestingUnions) UnsetAnInt16() {
+	p.AnInt16 = nil
+}
+
 func (p *TestingUnions) GetAnInt16() int16 {
 	if !p.IsSetAnInt16() {
 		return TestingUnions_AnInt16_DEFAULT
@@ -1794,6 +1862,10 @@ func (p *TestingUnions) IsSetRequests() bool {
 	return p.Requests != nil
 }
 
+func (p *TestingUnions) UnsetRequests() {
+	p.Requests = nil
+}
+
 func (p *TestingUnions) GetRequests() Request {
 	return p.Requests
 }
@@ -1804,6 +1876,10 @@ func (p *TestingUnions) IsSetBinFieldInUnion() bool {
 	return p.BinFieldInUnion != nil
 }
 
+func (p *TestingUnions) UnsetBinFieldInUnion() {
+	p.BinFieldInUnion = nil
+}
+
 func (p *TestingUnions) GetBinFieldInUnion() []byte {
 	return p.BinFieldInUnion
 }
@@ -1814,6 +1890,10 @@ func (p *TestingUnions) IsSetDepr() bool {
 	return p.Depr != nil
 }
 
+func (p *TestingUnions) UnsetDepr() {
+	p.Depr = nil
+}
+
 func (p *TestingUnions) GetDepr() bool {
 	if !p.IsSetDepr() {
 		return TestingUnions_Depr_DEFAULT
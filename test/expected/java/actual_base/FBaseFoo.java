@@ -35,6 +35,7 @@ import org.slf4j.Logger;
 import org.slf4j.LoggerFactory;
 
 import com.workiva.frugal.FContext;
+import com.workiva.frugal.FContractMetadata;
 import com.workiva.frugal.exception.TApplicationExceptionType;
 import com.workiva.frugal.exception.TTransportExceptionType;
 import com.workiva.frugal.middleware.InvocationHandler;
@@ -62,6 +63,14 @@ public class FBaseFoo {
 
 	private static final Logger logger = LoggerFactory.getLogger(FBaseFoo.class);
 
+	/**
+	 * Describes the BaseFoo service contract.
+	 */
+	public static final FContractMetadata METADATA = new FContractMetadata(
+			"base.frugal", "service", "BaseFoo",
+			"4516af8e48fd0b4a04bd7d4e0e10d58b72c50666e67f02ffab8a7f02dea3d408", "2.23.0",
+			Arrays.asList("basePing"));
+
 	public interface Iface {
 
 		public void basePing(FContext ctx) throws TException;
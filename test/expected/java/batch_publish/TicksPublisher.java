@@ -0,0 +1,229 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+
+package batch_publish;
+
+import com.workiva.frugal.FContext;
+import com.workiva.frugal.FContractMetadata;
+import com.workiva.frugal.exception.TApplicationExceptionType;
+import com.workiva.frugal.middleware.InvocationHandler;
+import com.workiva.frugal.middleware.ServiceMiddleware;
+import com.workiva.frugal.protocol.*;
+import com.workiva.frugal.provider.FScopeProvider;
+import com.workiva.frugal.transport.FBatchFrame;
+import com.workiva.frugal.transport.FPublisherTransport;
+import com.workiva.frugal.transport.FSubscriberTransport;
+import com.workiva.frugal.transport.FSubscription;
+import com.workiva.frugal.transport.TMemoryOutputBuffer;
+import org.apache.thrift.TException;
+import org.apache.thrift.TApplicationException;
+import org.apache.thrift.transport.TTransport;
+import org.apache.thrift.transport.TTransportException;
+import org.apache.thrift.protocol.*;
+
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+import javax.annotation.Generated;
+
+
+
+
+@Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
+public class TicksPublisher {
+
+	/**
+	 * Describes the Ticks scope contract.
+	 */
+	public static final FContractMetadata METADATA = new FContractMetadata(
+			"batch_publish.frugal", "scope", "Ticks",
+			"298b5c8a6925dea29dce346710555efe5fe6c46147b413707493b80c1ad6b0c9", "2.23.0",
+			Arrays.asList("TickReceived", "Heartbeat"));
+
+	/**
+	 * Market data ticks, published in batches.
+	 */
+	public interface Iface {
+		public void open() throws TException;
+
+		public void close() throws TException;
+
+		public void publishTickReceived(FContext ctx, String exchange, Tick req) throws TException;
+
+		/**
+		 * Publishes the messages in a single frame, sent with one transport flush.
+		 * Subscribers must be generated with the batch_publish option to receive
+		 * them.
+		 */
+		public void publishTickReceivedBatch(FContext ctx, String exchange, List<Tick> reqs) throws TException;
+
+		public void publishHeartbeat(FContext ctx, String exchange, long req) throws TException;
+
+		/**
+		 * Publishes the messages in a single frame, sent with one transport flush.
+		 * Subscribers must be generated with the batch_publish option to receive
+		 * them.
+		 */
+		public void publishHeartbeatBatch(FContext ctx, String exchange, List<Long> reqs) throws TException;
+
+	}
+
+	/**
+	 * Market data ticks, published in batches.
+	 */
+	public static class Client implements Iface {
+		private static final String DELIMITER = ".";
+
+		private final Iface target;
+		private final Iface proxy;
+
+		public Client(FScopeProvider provider, ServiceMiddleware... middleware) {
+			target = new InternalTicksPublisher(provider);
+			List<ServiceMiddleware> combined = Arrays.asList(middleware);
+			combined.addAll(provider.getMiddleware());
+			middleware = combined.toArray(new ServiceMiddleware[0]);
+			proxy = InvocationHandler.composeMiddleware(target, Iface.class, middleware);
+		}
+
+		public void open() throws TException {
+			target.open();
+		}
+
+		public void close() throws TException {
+			target.close();
+		}
+
+		public void publishTickReceived(FContext ctx, String exchange, Tick req) throws TException {
+			proxy.publishTickReceived(ctx, exchange, req);
+		}
+
+		/**
+		 * Publishes the messages in a single frame, sent with one transport flush.
+		 * Subscribers must be generated with the batch_publish option to receive
+		 * them.
+		 */
+		public void publishTickReceivedBatch(FContext ctx, String exchange, List<Tick> reqs) throws TException {
+			proxy.publishTickReceivedBatch(ctx, exchange, reqs);
+		}
+
+		public void publishHeartbeat(FContext ctx, String exchange, long req) throws TException {
+			proxy.publishHeartbeat(ctx, exchange, req);
+		}
+
+		/**
+		 * Publishes the messages in a single frame, sent with one transport flush.
+		 * Subscribers must be generated with the batch_publish option to receive
+		 * them.
+		 */
+		public void publishHeartbeatBatch(FContext ctx, String exchange, List<Long> reqs) throws TException {
+			proxy.publishHeartbeatBatch(ctx, exchange, reqs);
+		}
+
+		protected static class InternalTicksPublisher implements Iface {
+
+			private FScopeProvider provider;
+			private FPublisherTransport transport;
+			private FProtocolFactory protocolFactory;
+
+			protected InternalTicksPublisher() {
+			}
+
+			public InternalTicksPublisher(FScopeProvider provider) {
+				this.provider = provider;
+			}
+
+			public void open() throws TException {
+				FScopeProvider.Publisher publisher = provider.buildPublisher();
+				transport = publisher.getTransport();
+				protocolFactory = publisher.getProtocolFactory();
+				transport.open();
+			}
+
+			public void close() throws TException {
+				transport.close();
+			}
+
+			public void publishTickReceived(FContext ctx, String exchange, Tick req) throws TException {
+				ctx.addRequestHeader("_topic_exchange", exchange);
+				String op = "TickReceived";
+				String prefix = String.format("market.%s.", exchange);
+				String topic = String.format("%sTicks%s%s", prefix, DELIMITER, op);
+				TMemoryOutputBuffer memoryBuffer = new TMemoryOutputBuffer(transport.getPublishSizeLimit());
+				FProtocol oprot = protocolFactory.getProtocol(memoryBuffer);
+				oprot.writeRequestHeader(ctx);
+				oprot.writeMessageBegin(new TMessage(op, TMessageType.CALL, 0));
+				req.write(oprot);
+				oprot.writeMessageEnd();
+				transport.publish(topic, memoryBuffer.getWriteBytes());
+			}
+
+			public void publishTickReceivedBatch(FContext ctx, String exchange, List<Tick> reqs) throws TException {
+				ctx.addRequestHeader("_topic_exchange", exchange);
+				String op = "TickReceived";
+				String prefix = String.format("market.%s.", exchange);
+				String topic = String.format("%sTicks%s%s", prefix, DELIMITER, op);
+				List<byte[]> frames = new ArrayList<>(reqs.size());
+				for (Tick req : reqs) {
+					TMemoryOutputBuffer memoryBuffer = new TMemoryOutputBuffer(transport.getPublishSizeLimit());
+					FProtocol oprot = protocolFactory.getProtocol(memoryBuffer);
+					oprot.writeRequestHeader(ctx);
+					oprot.writeMessageBegin(new TMessage(op, TMessageType.CALL, 0));
+					req.write(oprot);
+					oprot.writeMessageEnd();
+					frames.add(memoryBuffer.getWriteBytes());
+				}
+				FBatchFrame.publish(transport, topic, frames);
+			}
+
+
+			public void publishHeartbeat(FContext ctx, String exchange, long req) throws TException {
+				ctx.addRequestHeader("_topic_exchange", exchange);
+				String op = "Heartbeat";
+				String prefix = String.format("market.%s.", exchange);
+				String topic = String.format("%sTicks%s%s", prefix, DELIMITER, op);
+				TMemoryOutputBuffer memoryBuffer = new TMemoryOutputBuffer(transport.getPublishSizeLimit());
+				FProtocol oprot = protocolFactory.getProtocol(memoryBuffer);
+				oprot.writeRequestHeader(ctx);
+				oprot.writeMessageBegin(new TMessage(op, TMessageType.CALL, 0));
+				long elem4 = req;
+				oprot.writeI64(elem4);
+				oprot.writeMessageEnd();
+				transport.publish(topic, memoryBuffer.getWriteBytes());
+			}
+
+			public void publishHeartbeatBatch(FContext ctx, String exchange, List<Long> reqs) throws TException {
+				ctx.addRequestHeader("_topic_exchange", exchange);
+				String op = "Heartbeat";
+				String prefix = String.format("market.%s.", exchange);
+				String topic = String.format("%sTicks%s%s", prefix, DELIMITER, op);
+				List<byte[]> frames = new ArrayList<>(reqs.size());
+				for (Long req : reqs) {
+					TMemoryOutputBuffer memoryBuffer = new TMemoryOutputBuffer(transport.getPublishSizeLimit());
+					FProtocol oprot = protocolFactory.getProtocol(memoryBuffer);
+					oprot.writeRequestHeader(ctx);
+					oprot.writeMessageBegin(new TMessage(op, TMessageType.CALL, 0));
+					long elem5 = req;
+					oprot.writeI64(elem5);
+					oprot.writeMessageEnd();
+					frames.add(memoryBuffer.getWriteBytes());
+				}
+				FBatchFrame.publish(transport, topic, frames);
+			}
+		}
+	}
+}
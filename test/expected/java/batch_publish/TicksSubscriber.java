@@ -0,0 +1,227 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+
+package batch_publish;
+
+import com.workiva.frugal.FContext;
+import com.workiva.frugal.FContractMetadata;
+import com.workiva.frugal.exception.TApplicationExceptionType;
+import com.workiva.frugal.middleware.InvocationHandler;
+import com.workiva.frugal.middleware.ServiceMiddleware;
+import com.workiva.frugal.protocol.*;
+import com.workiva.frugal.provider.FScopeProvider;
+import com.workiva.frugal.transport.FBatchFrame;
+import com.workiva.frugal.transport.FPublisherTransport;
+import com.workiva.frugal.transport.FSubscriberTransport;
+import com.workiva.frugal.transport.FSubscription;
+import com.workiva.frugal.transport.TMemoryOutputBuffer;
+import org.apache.thrift.TException;
+import org.apache.thrift.TApplicationException;
+import org.apache.thrift.transport.TTransport;
+import org.apache.thrift.transport.TTransportException;
+import org.apache.thrift.protocol.*;
+
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+import javax.annotation.Generated;
+
+
+
+
+@Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
+public class TicksSubscriber {
+
+	/**
+	 * Describes the Ticks scope contract.
+	 */
+	public static final FContractMetadata METADATA = new FContractMetadata(
+			"batch_publish.frugal", "scope", "Ticks",
+			"298b5c8a6925dea29dce346710555efe5fe6c46147b413707493b80c1ad6b0c9", "2.23.0",
+			Arrays.asList("TickReceived", "Heartbeat"));
+
+	/**
+	 * Market data ticks, published in batches.
+	 */
+	public interface Iface {
+		public FSubscription subscribeTickReceived(String exchange, final TickReceivedHandler handler) throws TException;
+
+		public FSubscription subscribeHeartbeat(String exchange, final HeartbeatHandler handler) throws TException;
+
+	}
+
+	public interface IfaceThrowable {
+		public FSubscription subscribeTickReceivedThrowable(String exchange, final TickReceivedThrowableHandler handler) throws TException;
+
+		public FSubscription subscribeHeartbeatThrowable(String exchange, final HeartbeatThrowableHandler handler) throws TException;
+
+	}
+
+	public interface TickReceivedHandler {
+		void onTickReceived(FContext ctx, Tick req) throws TException;
+	}
+
+	public interface HeartbeatHandler {
+		void onHeartbeat(FContext ctx, long req) throws TException;
+	}
+
+	public interface TickReceivedThrowableHandler {
+		void onTickReceived(FContext ctx, Tick req) throws TException;
+	}
+
+	public interface HeartbeatThrowableHandler {
+		void onHeartbeat(FContext ctx, long req) throws TException;
+	}
+
+	/**
+	 * Market data ticks, published in batches.
+	 */
+	public static class Client implements Iface, IfaceThrowable {
+		private static final String DELIMITER = ".";
+		private static final Logger LOGGER = LoggerFactory.getLogger(Client.class);
+
+		private final FScopeProvider provider;
+		private final ServiceMiddleware[] middleware;
+
+		public Client(FScopeProvider provider, ServiceMiddleware... middleware) {
+			this.provider = provider;
+			List<ServiceMiddleware> combined = Arrays.asList(middleware);
+			combined.addAll(provider.getMiddleware());
+			this.middleware = combined.toArray(new ServiceMiddleware[0]);
+		}
+
+		public FSubscription subscribeTickReceived(String exchange, final TickReceivedHandler handler) throws TException {
+			final String op = "TickReceived";
+			String prefix = String.format("market.%s.", exchange);
+			final String topic = String.format("%sTicks%s%s", prefix, DELIMITER, op);
+			final FScopeProvider.Subscriber subscriber = provider.buildSubscriber();
+			final FSubscriberTransport transport = subscriber.getTransport();
+			final TickReceivedHandler proxiedHandler = InvocationHandler.composeMiddleware(handler, TickReceivedHandler.class, middleware);
+			transport.subscribe(topic, FBatchFrame.callback(recvTickReceived(op, subscriber.getProtocolFactory(), proxiedHandler)));
+			return FSubscription.of(topic, transport);
+		}
+
+		private FAsyncCallback recvTickReceived(String op, FProtocolFactory pf, TickReceivedHandler handler) {
+			return new FAsyncCallback() {
+				public void onMessage(TTransport tr) throws TException {
+					FProtocol iprot = pf.getProtocol(tr);
+					FContext ctx = iprot.readRequestHeader();
+					TMessage msg = iprot.readMessageBegin();
+					if (!msg.name.equals(op)) {
+						TProtocolUtil.skip(iprot, TType.STRUCT);
+						iprot.readMessageEnd();
+						throw new TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD);
+					}
+					Tick received = new Tick();
+					received.read(iprot);
+					iprot.readMessageEnd();
+					handler.onTickReceived(ctx, received);
+				}
+			};
+		}
+
+		public FSubscription subscribeHeartbeat(String exchange, final HeartbeatHandler handler) throws TException {
+			final String op = "Heartbeat";
+			String prefix = String.format("market.%s.", exchange);
+			final String topic = String.format("%sTicks%s%s", prefix, DELIMITER, op);
+			final FScopeProvider.Subscriber subscriber = provider.buildSubscriber();
+			final FSubscriberTransport transport = subscriber.getTransport();
+			final HeartbeatHandler proxiedHandler = InvocationHandler.composeMiddleware(handler, HeartbeatHandler.class, middleware);
+			transport.subscribe(topic, FBatchFrame.callback(recvHeartbeat(op, subscriber.getProtocolFactory(), proxiedHandler)));
+			return FSubscription.of(topic, transport);
+		}
+
+		private FAsyncCallback recvHeartbeat(String op, FProtocolFactory pf, HeartbeatHandler handler) {
+			return new FAsyncCallback() {
+				public void onMessage(TTransport tr) throws TException {
+					FProtocol iprot = pf.getProtocol(tr);
+					FContext ctx = iprot.readRequestHeader();
+					TMessage msg = iprot.readMessageBegin();
+					if (!msg.name.equals(op)) {
+						TProtocolUtil.skip(iprot, TType.STRUCT);
+						iprot.readMessageEnd();
+						throw new TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD);
+					}
+					long received = iprot.readI64();
+					iprot.readMessageEnd();
+					handler.onHeartbeat(ctx, received);
+				}
+			};
+		}
+
+		public FSubscription subscribeTickReceivedThrowable(String exchange, final TickReceivedThrowableHandler handler) throws TException {
+			final String op = "TickReceived";
+			String prefix = String.format("market.%s.", exchange);
+			final String topic = String.format("%sTicks%s%s", prefix, DELIMITER, op);
+			final FScopeProvider.Subscriber subscriber = provider.buildSubscriber();
+			final FSubscriberTransport transport = subscriber.getTransport();
+			final TickReceivedThrowableHandler proxiedHandler = InvocationHandler.composeMiddleware(handler, TickReceivedThrowableHandler.class, middleware);
+			transport.subscribe(topic, FBatchFrame.callback(recvTickReceived(op, subscriber.getProtocolFactory(), proxiedHandler)));
+			return FSubscription.of(topic, transport);
+		}
+
+		private FAsyncCallback recvTickReceived(String op, FProtocolFactory pf, TickReceivedThrowableHandler handler) {
+			return new FAsyncCallback() {
+				public void onMessage(TTransport tr) throws TException {
+					FProtocol iprot = pf.getProtocol(tr);
+					FContext ctx = iprot.readRequestHeader();
+					TMessage msg = iprot.readMessageBegin();
+					if (!msg.name.equals(op)) {
+						TProtocolUtil.skip(iprot, TType.STRUCT);
+						iprot.readMessageEnd();
+						throw new TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD);
+					}
+					Tick received = new Tick();
+					received.read(iprot);
+					iprot.readMessageEnd();
+					handler.onTickReceived(ctx, received);
+				}
+			};
+		}
+
+		public FSubscription subscribeHeartbeatThrowable(String exchange, final HeartbeatThrowableHandler handler) throws TException {
+			final String op = "Heartbeat";
+			String prefix = String.format("market.%s.", exchange);
+			final String topic = String.format("%sTicks%s%s", prefix, DELIMITER, op);
+			final FScopeProvider.Subscriber subscriber = provider.buildSubscriber();
+			final FSubscriberTransport transport = subscriber.getTransport();
+			final HeartbeatThrowableHandler proxiedHandler = InvocationHandler.composeMiddleware(handler, HeartbeatThrowableHandler.class, middleware);
+			transport.subscribe(topic, FBatchFrame.callback(recvHeartbeat(op, subscriber.getProtocolFactory(), proxiedHandler)));
+			return FSubscription.of(topic, transport);
+		}
+
+		private FAsyncCallback recvHeartbeat(String op, FProtocolFactory pf, HeartbeatThrowableHandler handler) {
+			return new FAsyncCallback() {
+				public void onMessage(TTransport tr) throws TException {
+					FProtocol iprot = pf.getProtocol(tr);
+					FContext ctx = iprot.readRequestHeader();
+					TMessage msg = iprot.readMessageBegin();
+					if (!msg.name.equals(op)) {
+						TProtocolUtil.skip(iprot, TType.STRUCT);
+						iprot.readMessageEnd();
+						throw new TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD);
+					}
+					long received = iprot.readI64();
+					iprot.readMessageEnd();
+					handler.onHeartbeat(ctx, received);
+				}
+			};
+		}
+	}
+
+}
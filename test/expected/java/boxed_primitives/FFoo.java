@@ -35,6 +35,7 @@ import org.slf4j.Logger;
 import org.slf4j.LoggerFactory;
 
 import com.workiva.frugal.FContext;
+import com.workiva.frugal.FContractMetadata;
 import com.workiva.frugal.exception.TApplicationExceptionType;
 import com.workiva.frugal.exception.TTransportExceptionType;
 import com.workiva.frugal.middleware.InvocationHandler;
@@ -62,6 +63,14 @@ public class FFoo {
 
 	private static final Logger logger = LoggerFactory.getLogger(FFoo.class);
 
+	/**
+	 * Describes the Foo service contract.
+	 */
+	public static final FContractMetadata METADATA = new FContractMetadata(
+			"variety.frugal", "service", "Foo",
+			"a193743f25c5aede9ade7f9d0d5c99adc48638d0db1499acfcdd8f4fd9adb80b", "2.23.0",
+			Arrays.asList("Ping", "blah", "oneWay", "bin_method", "param_modifiers", "underlying_types_test", "getThing", "getMyInt", "use_subdir_struct", "sayHelloWith", "whatDoYouSay", "sayAgain"));
+
 	/**
 	 * This is a thrift service. Frugal will generate bindings that include
 	 * a frugal Context for each service call.
@@ -0,0 +1,261 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+
+package deprecated_scope;
+
+import com.workiva.frugal.FContext;
+import com.workiva.frugal.FContractMetadata;
+import com.workiva.frugal.exception.TApplicationExceptionType;
+import com.workiva.frugal.middleware.InvocationHandler;
+import com.workiva.frugal.middleware.ServiceMiddleware;
+import com.workiva.frugal.protocol.*;
+import com.workiva.frugal.provider.FScopeProvider;
+import com.workiva.frugal.transport.FPublisherTransport;
+import com.workiva.frugal.transport.FSubscriberTransport;
+import com.workiva.frugal.transport.FSubscription;
+import com.workiva.frugal.transport.TMemoryOutputBuffer;
+import org.apache.thrift.TException;
+import org.apache.thrift.TApplicationException;
+import org.apache.thrift.transport.TTransport;
+import org.apache.thrift.transport.TTransportException;
+import org.apache.thrift.protocol.*;
+
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+import javax.annotation.Generated;
+
+
+
+
+@Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
+public class SensorsSubscriber {
+
+	/**
+	 * Describes the Sensors scope contract.
+	 */
+	public static final FContractMetadata METADATA = new FContractMetadata(
+			"deprecated_scope.frugal", "scope", "Sensors",
+			"0af5df04b2685fb8dd5f3c53244e48eccb811acb0d6ff1db70f3b3e2a4c43686", "2.23.0",
+			Arrays.asList("ReadingTaken", "Calibrated"),
+			FContractMetadata.descriptions("ReadingTaken", "Published when a sensor takes a reading."));
+
+	/**
+	 * Readings from legacy sensors.
+	 */
+	public interface Iface {
+		/**
+		 * Published when a sensor takes a reading.
+		 * 
+		 * Deprecated: use Telemetry.Sampled
+		 */
+		public FSubscription subscribeReadingTaken(final ReadingTakenHandler handler) throws TException;
+
+		/**
+		 * Deprecated: use the Telemetry scope
+		 */
+		public FSubscription subscribeCalibrated(final CalibratedHandler handler) throws TException;
+
+	}
+
+	public interface IfaceThrowable {
+		/**
+		 * Published when a sensor takes a reading.
+		 * 
+		 * Deprecated: use Telemetry.Sampled
+		 */
+		public FSubscription subscribeReadingTakenThrowable(final ReadingTakenThrowableHandler handler) throws TException;
+
+		/**
+		 * Deprecated: use the Telemetry scope
+		 */
+		public FSubscription subscribeCalibratedThrowable(final CalibratedThrowableHandler handler) throws TException;
+
+	}
+
+	public interface ReadingTakenHandler {
+		void onReadingTaken(FContext ctx, Reading req) throws TException;
+	}
+
+	public interface CalibratedHandler {
+		void onCalibrated(FContext ctx, Reading req) throws TException;
+	}
+
+	public interface ReadingTakenThrowableHandler {
+		void onReadingTaken(FContext ctx, Reading req) throws TException;
+	}
+
+	public interface CalibratedThrowableHandler {
+		void onCalibrated(FContext ctx, Reading req) throws TException;
+	}
+
+	/**
+	 * Readings from legacy sensors.
+	 */
+	public static class Client implements Iface, IfaceThrowable {
+		private static final String DELIMITER = ".";
+		private static final Logger LOGGER = LoggerFactory.getLogger(Client.class);
+
+		private final FScopeProvider provider;
+		private final ServiceMiddleware[] middleware;
+
+		public Client(FScopeProvider provider, ServiceMiddleware... middleware) {
+			this.provider = provider;
+			List<ServiceMiddleware> combined = Arrays.asList(middleware);
+			combined.addAll(provider.getMiddleware());
+			this.middleware = combined.toArray(new ServiceMiddleware[0]);
+		}
+
+		/**
+		 * Published when a sensor takes a reading.
+		 * 
+		 * Deprecated: use Telemetry.Sampled
+		 */
+		public FSubscription subscribeReadingTaken(final ReadingTakenHandler handler) throws TException {
+			final String op = "ReadingTaken";
+			String prefix = "";
+			final String topic = String.format("%sSensors%s%s", prefix, DELIMITER, op);
+			final FScopeProvider.Subscriber subscriber = provider.buildSubscriber();
+			final FSubscriberTransport transport = subscriber.getTransport();
+			final ReadingTakenHandler proxiedHandler = InvocationHandler.composeMiddleware(handler, ReadingTakenHandler.class, middleware);
+			transport.subscribe(topic, recvReadingTaken(op, subscriber.getProtocolFactory(), proxiedHandler));
+			return FSubscription.of(topic, transport);
+		}
+
+		private FAsyncCallback recvReadingTaken(String op, FProtocolFactory pf, ReadingTakenHandler handler) {
+			return new FAsyncCallback() {
+				public void onMessage(TTransport tr) throws TException {
+					FProtocol iprot = pf.getProtocol(tr);
+					FContext ctx = iprot.readRequestHeader();
+					TMessage msg = iprot.readMessageBegin();
+					if (!msg.name.equals(op)) {
+						TProtocolUtil.skip(iprot, TType.STRUCT);
+						iprot.readMessageEnd();
+						throw new TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD);
+					}
+					Reading received = new Reading();
+					received.read(iprot);
+					iprot.readMessageEnd();
+					handler.onReadingTaken(ctx, received);
+				}
+			};
+		}
+
+		/**
+		 * Deprecated: use the Telemetry scope
+		 */
+		public FSubscription subscribeCalibrated(final CalibratedHandler handler) throws TException {
+			final String op = "Calibrated";
+			String prefix = "";
+			final String topic = String.format("%sSensors%s%s", prefix, DELIMITER, op);
+			final FScopeProvider.Subscriber subscriber = provider.buildSubscriber();
+			final FSubscriberTransport transport = subscriber.getTransport();
+			final CalibratedHandler proxiedHandler = InvocationHandler.composeMiddleware(handler, CalibratedHandler.class, middleware);
+			transport.subscribe(topic, recvCalibrated(op, subscriber.getProtocolFactory(), proxiedHandler));
+			return FSubscription.of(topic, transport);
+		}
+
+		private FAsyncCallback recvCalibrated(String op, FProtocolFactory pf, CalibratedHandler handler) {
+			return new FAsyncCallback() {
+				public void onMessage(TTransport tr) throws TException {
+					FProtocol iprot = pf.getProtocol(tr);
+					FContext ctx = iprot.readRequestHeader();
+					TMessage msg = iprot.readMessageBegin();
+					if (!msg.name.equals(op)) {
+						TProtocolUtil.skip(iprot, TType.STRUCT);
+						iprot.readMessageEnd();
+						throw new TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD);
+					}
+					Reading received = new Reading();
+					received.read(iprot);
+					iprot.readMessageEnd();
+					handler.onCalibrated(ctx, received);
+				}
+			};
+		}
+
+		/**
+		 * Published when a sensor takes a reading.
+		 * 
+		 * Deprecated: use Telemetry.Sampled
+		 */
+		public FSubscription subscribeReadingTakenThrowable(final ReadingTakenThrowableHandler handler) throws TException {
+			final String op = "ReadingTaken";
+			String prefix = "";
+			final String topic = String.format("%sSensors%s%s", prefix, DELIMITER, op);
+			final FScopeProvider.Subscriber subscriber = provider.buildSubscriber();
+			final FSubscriberTransport transport = subscriber.getTransport();
+			final ReadingTakenThrowableHandler proxiedHandler = InvocationHandler.composeMiddleware(handler, ReadingTakenThrowableHandler.class, middleware);
+			transport.subscribe(topic, recvReadingTaken(op, subscriber.getProtocolFactory(), proxiedHandler));
+			return FSubscription.of(topic, transport);
+		}
+
+		private FAsyncCallback recvReadingTaken(String op, FProtocolFactory pf, ReadingTakenThrowableHandler handler) {
+			return new FAsyncCallback() {
+				public void onMessage(TTransport tr) throws TException {
+					FProtocol iprot = pf.getProtocol(tr);
+					FContext ctx = iprot.readRequestHeader();
+					TMessage msg = iprot.readMessageBegin();
+					if (!msg.name.equals(op)) {
+						TProtocolUtil.skip(iprot, TType.STRUCT);
+						iprot.readMessageEnd();
+						throw new TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD);
+					}
+					Reading received = new Reading();
+					received.read(iprot);
+					iprot.readMessageEnd();
+					handler.onReadingTaken(ctx, received);
+				}
+			};
+		}
+
+		/**
+		 * Deprecated: use the Telemetry scope
+		 */
+		public FSubscription subscribeCalibratedThrowable(final CalibratedThrowableHandler handler) throws TException {
+			final String op = "Calibrated";
+			String prefix = "";
+			final String topic = String.format("%sSensors%s%s", prefix, DELIMITER, op);
+			final FScopeProvider.Subscriber subscriber = provider.buildSubscriber();
+			final FSubscriberTransport transport = subscriber.getTransport();
+			final CalibratedThrowableHandler proxiedHandler = InvocationHandler.composeMiddleware(handler, CalibratedThrowableHandler.class, middleware);
+			transport.subscribe(topic, recvCalibrated(op, subscriber.getProtocolFactory(), proxiedHandler));
+			return FSubscription.of(topic, transport);
+		}
+
+		private FAsyncCallback recvCalibrated(String op, FProtocolFactory pf, CalibratedThrowableHandler handler) {
+			return new FAsyncCallback() {
+				public void onMessage(TTransport tr) throws TException {
+					FProtocol iprot = pf.getProtocol(tr);
+					FContext ctx = iprot.readRequestHeader();
+					TMessage msg = iprot.readMessageBegin();
+					if (!msg.name.equals(op)) {
+						TProtocolUtil.skip(iprot, TType.STRUCT);
+						iprot.readMessageEnd();
+						throw new TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD);
+					}
+					Reading received = new Reading();
+					received.read(iprot);
+					iprot.readMessageEnd();
+					handler.onCalibrated(ctx, received);
+				}
+			};
+		}
+	}
+
+}
@@ -0,0 +1,158 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+
+package scope_versions;
+
+import com.workiva.frugal.FContext;
+import com.workiva.frugal.FContractMetadata;
+import com.workiva.frugal.exception.TApplicationExceptionType;
+import com.workiva.frugal.middleware.InvocationHandler;
+import com.workiva.frugal.middleware.ServiceMiddleware;
+import com.workiva.frugal.protocol.*;
+import com.workiva.frugal.provider.FScopeProvider;
+import com.workiva.frugal.transport.FPublisherTransport;
+import com.workiva.frugal.transport.FSubscriberTransport;
+import com.workiva.frugal.transport.FSubscription;
+import com.workiva.frugal.transport.TMemoryOutputBuffer;
+import org.apache.thrift.TException;
+import org.apache.thrift.TApplicationException;
+import org.apache.thrift.transport.TTransport;
+import org.apache.thrift.transport.TTransportException;
+import org.apache.thrift.protocol.*;
+
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+import javax.annotation.Generated;
+
+
+
+
+@Generated(value = "Autogenerated by Frugal Compiler (2.23.0)")
+public class OrdersPublisher {
+
+	/**
+	 * Describes the Orders scope contract.
+	 */
+	public static final FContractMetadata METADATA = new FContractMetadata(
+			"scope_versions.frugal", "scope", "Orders",
+			"4a43fbf9eff078f793e315d82d20101ca670caa9c63238e7b6317436da06adbd", "2.23.0",
+			Arrays.asList("OrderPlaced", "OrderCancelled"));
+
+	/**
+	 * Orders of a shop. Version 2 made order IDs strings.
+	 */
+	public interface Iface {
+		public void open() throws TException;
+
+		public void close() throws TException;
+
+		public void publishOrderPlaced(FContext ctx, String shop, Order req) throws TException;
+
+		public void publishOrderCancelled(FContext ctx, String shop, Order req) throws TException;
+
+	}
+
+	/**
+	 * Orders of a shop. Version 2 made order IDs strings.
+	 */
+	public static class Client implements Iface {
+		private static final String DELIMITER = ".";
+
+		private final Iface target;
+		private final Iface proxy;
+
+		public Client(FScopeProvider provider, ServiceMiddleware... middleware) {
+			target = new InternalOrdersPublisher(provider);
+			List<ServiceMiddleware> combined = Arrays.asList(middleware);
+			combined.addAll(provider.getMiddleware());
+			middleware = combined.toArray(new ServiceMiddleware[0]);
+			proxy = InvocationHandler.composeMiddleware(target, Iface.class, middleware);
+		}
+
+		public void open() throws TException {
+			target.open();
+		}
+
+		public void close() throws TException {
+			target.close();
+		}
+
+		public void publishOrderPlaced(FContext ctx, String shop, Order req) throws TException {
+			proxy.publishOrderPlaced(ctx, shop, req);
+		}
+
+		public void publishOrderCancelled(FContext ctx, String shop, Order req) throws TException {
+			proxy.publishOrderCancelled(ctx, shop, req);
+		}
+
+		protected static class InternalOrdersPublisher implements Iface {
+
+			private FScopeProvider provider;
+			private FPublisherTransport transport;
+			private FProtocolFactory protocolFactory;
+
+			protected InternalOrdersPublisher() {
+			}
+
+			public InternalOrdersPublisher(FScopeProvider provider) {
+				this.provider = provider;
+			}
+
+			public void open() throws TException {
+				FScopeProvider.Publisher publisher = provider.buildPublisher();
+				transport = publisher.getTransport();
+				protocolFactory = publisher.getProtocolFactory();
+				transport.open();
+			}
+
+			public void close() throws TException {
+				transport.close();
+			}
+
+			public void publishOrderPlaced(FContext ctx, String shop, Order req) throws TException {
+				ctx.addRequestHeader("_topic_shop", shop);
+				String op = "OrderPlaced";
+				String prefix = String.format("shop.%s.", shop);
+				String topic = String.format("%sOrders.v2%s%s", prefix, DELIMITER, op);
+				TMemoryOutputBuffer memoryBuffer = new TMemoryOutputBuffer(transport.getPublishSizeLimit());
+				FProtocol oprot = protocolFactory.getProtocol(memoryBuffer);
+				oprot.writeRequestHeader(ctx);
+				oprot.writeMessageBegin(new TMessage(op, TMessageType.CALL, 0));
+				req.write(oprot);
+				oprot.writeMessageEnd();
+				transport.publish(topic, memoryBuffer.getWriteBytes());
+			}
+
+
+			public void publishOrderCancelled(FContext ctx, String shop, Order req) throws TException {
+				ctx.addRequestHeader("_topic_shop", shop);
+				String op = "OrderCancelled";
+				String prefix = String.format("shop.%s.", shop);
+				String topic = String.format("%sOrders.v2%s%s", prefix, DELIMITER, op);
+				TMemoryOutputBuffer memoryBuffer = new TMemoryOutputBuffer(transport.getPublishSizeLimit());
+				FProtocol oprot = protocolFactory.getProtocol(memoryBuffer);
+				oprot.writeRequestHeader(ctx);
+				oprot.writeMessageBegin(new TMessage(op, TMessageType.CALL, 0));
+				req.write(oprot);
+				oprot.writeMessageEnd();
+				transport.publish(topic, memoryBuffer.getWriteBytes());
+			}
+		}
+	}
+}
@@ -35,6 +35,7 @@ import org.slf4j.Logger;
 import org.slf4j.LoggerFactory;
 
 import com.workiva.frugal.FContext;
+import com.workiva.frugal.FContractMetadata;
 import com.workiva.frugal.exception.TApplicationExceptionType;
 import com.workiva.frugal.exception.TTransportExceptionType;
 import com.workiva.frugal.middleware.InvocationHandler;
@@ -62,6 +63,14 @@ public class FMyService {
 
 	private static final Logger logger = LoggerFactory.getLogger(FMyService.class);
 
+	/**
+	 * Describes the MyService service contract.
+	 */
+	public static final FContractMetadata METADATA = new FContractMetadata(
+			"include_vendor_no_path.frugal", "service", "MyService",
+			"19ef102c1fca7eee88dc1c9957a1d3e59cfb31899724e0767852150c1ca55907", "2.23.0",
+			Arrays.asList("getItem"));
+
 	public interface Iface extends vendor_namespace.java.FVendoredBase.Iface {
 
 		public vendor_namespace.java.Item getItem(FContext ctx) throws TException, InvalidData;
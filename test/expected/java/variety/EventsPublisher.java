@@ -8,6 +8,7 @@
 package variety.java;
 
 import com.workiva.frugal.FContext;
+import com.workiva.frugal.FContractMetadata;
 import com.workiva.frugal.exception.TApplicationExceptionType;
 import com.workiva.frugal.middleware.InvocationHandler;
 import com.workiva.frugal.middleware.ServiceMiddleware;
@@ -45,6 +46,15 @@ import javax.annotation.Generated;
 @Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
 public class EventsPublisher {
 
+	/**
+	 * Describes the Events scope contract.
+	 */
+	public static final FContractMetadata METADATA = new FContractMetadata(
+			"variety.frugal", "scope", "Events",
+			"660b9d3624d5677b532f8f7b53a07b9cbfd10edb64f73a24c4858e545e47098e", "2.23.0",
+			Arrays.asList("EventCreated", "SomeInt", "SomeStr", "SomeList"),
+			FContractMetadata.descriptions("EventCreated", "This is a docstring."));
+
 	/**
 	 * This docstring gets added to the generated code because it has
 	 * the @ sign. Prefix specifies topic prefix tokens, which can be static or
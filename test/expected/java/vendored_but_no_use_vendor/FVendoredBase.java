@@ -35,6 +35,7 @@ import org.slf4j.Logger;
 import org.slf4j.LoggerFactory;
 
 import com.workiva.frugal.FContext;
+import com.workiva.frugal.FContractMetadata;
 import com.workiva.frugal.exception.TApplicationExceptionType;
 import com.workiva.frugal.exception.TTransportExceptionType;
 import com.workiva.frugal.middleware.InvocationHandler;
@@ -62,6 +63,14 @@ public class FVendoredBase {
 
 	private static final Logger logger = LoggerFactory.getLogger(FVendoredBase.class);
 
+	/**
+	 * Describes the VendoredBase service contract.
+	 */
+	public static final FContractMetadata METADATA = new FContractMetadata(
+			"vendor_namespace.frugal", "service", "VendoredBase",
+			"37b9f40340a87618458c6083cbdb1e50a5948abb1983ce33b7a481a6163250e7", "2.23.0",
+			Arrays.asList());
+
 	public interface Iface {
 
 	}
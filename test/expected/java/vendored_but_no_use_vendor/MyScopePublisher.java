@@ -8,6 +8,7 @@
 package include_vendor.java;
 
 import com.workiva.frugal.FContext;
+import com.workiva.frugal.FContractMetadata;
 import com.workiva.frugal.exception.TApplicationExceptionType;
 import com.workiva.frugal.middleware.InvocationHandler;
 import com.workiva.frugal.middleware.ServiceMiddleware;
@@ -45,6 +46,14 @@ import javax.annotation.Generated;
 @Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
 public class MyScopePublisher {
 
+	/**
+	 * Describes the MyScope scope contract.
+	 */
+	public static final FContractMetadata METADATA = new FContractMetadata(
+			"include_vendor.frugal", "scope", "MyScope",
+			"f8310e12fba5a05f3dd4aefd065927eec482a00b3967fb4b5b34ba3b2656acdb", "2.23.0",
+			Arrays.asList("newItem"));
+
 	public interface Iface {
 		public void open() throws TException;
 
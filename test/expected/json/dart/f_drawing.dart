@@ -0,0 +1,1068 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:convert' show BASE64;
+import 'dart:typed_data' show Uint8List;
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:json/json.dart' as t_json;
+import 'package:actual_base_dart/actual_base_dart.dart' as t_actual_base_dart;
+
+class Drawing implements thrift.TBase {
+  static final thrift.TStruct _STRUCT_DESC = new thrift.TStruct("Drawing");
+  static final thrift.TField _NAME_FIELD_DESC = new thrift.TField("name", thrift.TType.STRING, 1);
+  static final thrift.TField _TITLE_FIELD_DESC = new thrift.TField("title", thrift.TType.STRING, 2);
+  static final thrift.TField _THUMBNAIL_FIELD_DESC = new thrift.TField("thumbnail", thrift.TType.STRING, 3);
+  static final thrift.TField _BACKGROUND_FIELD_DESC = new thrift.TField("background", thrift.TType.I32, 4);
+  static final thrift.TField _CREATED_FIELD_DESC = new thrift.TField("created", thrift.TType.I64, 5);
+  static final thrift.TField _POINTS_FIELD_DESC = new thrift.TField("points", thrift.TType.LIST, 6);
+  static final thrift.TField _TAGS_FIELD_DESC = new thrift.TField("tags", thrift.TType.SET, 7);
+  static final thrift.TField _LAYERS_FIELD_DESC = new thrift.TField("layers", thrift.TType.MAP, 8);
+  static final thrift.TField _PALETTES_FIELD_DESC = new thrift.TField("palettes", thrift.TType.MAP, 9);
+  static final thrift.TField _LABELS_FIELD_DESC = new thrift.TField("labels", thrift.TType.MAP, 10);
+  static final thrift.TField _OUTLINE_FIELD_DESC = new thrift.TField("outline", thrift.TType.STRUCT, 11);
+  static final thrift.TField _ORIGIN_FIELD_DESC = new thrift.TField("origin", thrift.TType.STRUCT, 12);
+  static final thrift.TField _HISTORY_FIELD_DESC = new thrift.TField("history", thrift.TType.LIST, 13);
+  static final thrift.TField _VERSION_FIELD_DESC = new thrift.TField("version", thrift.TType.I32, 14);
+
+  String _name;
+  static const int NAME = 1;
+  String _title;
+  static const int TITLE = 2;
+  Uint8List _thumbnail;
+  static const int THUMBNAIL = 3;
+  int _background;
+  static const int BACKGROUND = 4;
+  int _created = 0;
+  static const int CREATED = 5;
+  List<t_json.Point> _points;
+  static const int POINTS = 6;
+  Set<String> _tags;
+  static const int TAGS = 7;
+  Map<int, t_json.Point> _layers;
+  static const int LAYERS = 8;
+  Map<int, List<t_json.Point>> _palettes;
+  static const int PALETTES = 9;
+  Map<t_json.Point, String> _labels;
+  static const int LABELS = 10;
+  t_json.Shape _outline;
+  static const int OUTLINE = 11;
+  t_actual_base_dart.thing _origin;
+  static const int ORIGIN = 12;
+  List<Set<int>> _history;
+  static const int HISTORY = 13;
+  int _version;
+  static const int VERSION = 14;
+
+  bool __isset_background = false;
+  bool __isset_created = false;
+  bool __isset_version = false;
+
+  Drawing() {
+    this.version = 1;
+  }
+
+  String get name => this._name;
+
+  set name(String name) {
+    this._name = name;
+  }
+
+  bool isSetName() => this.name != null;
+
+  unsetName() {
+    this.name = null;
+  }
+
+  String get title => this._title;
+
+  set title(String title) {
+    this._title = title;
+  }
+
+  bool isSetTitle() => this.title != null;
+
+  unsetTitle() {
+    this.title = null;
+  }
+
+  Uint8List get thumbnail => this._thumbnail;
+
+  set thumbnail(Uint8List thumbnail) {
+    this._thumbnail = thumbnail;
+  }
+
+  bool isSetThumbnail() => this.thumbnail != null;
+
+  unsetThumbnail() {
+    this.thumbnail = null;
+  }
+
+  int get background => this._background;
+
+  set background(int background) {
+    this._background = background;
+    this.__isset_background = true;
+  }
+
+  bool isSetBackground() => this.__isset_background;
+
+  unsetBackground() {
+    this.__isset_background = false;
+  }
+
+  int get created => this._created;
+
+  set created(int created) {
+    this._created = created;
+    this.__isset_created = true;
+  }
+
+  bool isSetCreated() => this.__isset_created;
+
+  unsetCreated() {
+    this.__isset_created = false;
+  }
+
+  List<t_json.Point> get points => this._points;
+
+  set points(List<t_json.Point> points) {
+    this._points = points;
+  }
+
+  bool isSetPoints() => this.points != null;
+
+  unsetPoints() {
+    this.points = null;
+  }
+
+  Set<String> get tags => this._tags;
+
+  set tags(Set<String> tags) {
+    this._tags = tags;
+  }
+
+  bool isSetTags() => this.tags != null;
+
+  unsetTags() {
+    this.tags = null;
+  }
+
+  Map<int, t_json.Point> get layers => this._layers;
+
+  set layers(Map<int, t_json.Point> layers) {
+    this._layers = layers;
+  }
+
+  bool isSetLayers() => this.layers != null;
+
+  unsetLayers() {
+    this.layers = null;
+  }
+
+  Map<int, List<t_json.Point>> get palettes => this._palettes;
+
+  set palettes(Map<int, List<t_json.Point>> palettes) {
+    this._palettes = palettes;
+  }
+
+  bool isSetPalettes() => this.palettes != null;
+
+  unsetPalettes() {
+    this.palettes = null;
+  }
+
+  Map<t_json.Point, String> get labels => this._labels;
+
+  set labels(Map<t_json.Point, String> labels) {
+    this._labels = labels;
+  }
+
+  bool isSetLabels() => this.labels != null;
+
+  unsetLabels() {
+    this.labels = null;
+  }
+
+  t_json.Shape get outline => this._outline;
+
+  set outline(t_json.Shape outline) {
+    this._outline = outline;
+  }
+
+  bool isSetOutline() => this.outline != null;
+
+  unsetOutline() {
+    this.outline = null;
+  }
+
+  t_actual_base_dart.thing get origin => this._origin;
+
+  set origin(t_actual_base_dart.thing origin) {
+    this._origin = origin;
+  }
+
+  bool isSetOrigin() => this.origin != null;
+
+  unsetOrigin() {
+    this.origin = null;
+  }
+
+  List<Set<int>> get history => this._history;
+
+  set history(List<Set<int>> history) {
+    this._history = history;
+  }
+
+  bool isSetHistory() => this.history != null;
+
+  unsetHistory() {
+    this.history = null;
+  }
+
+  int get version => this._version;
+
+  set version(int version) {
+    this._version = version;
+    this.__isset_version = true;
+  }
+
+  bool isSetVersion() => this.__isset_version;
+
+  unsetVersion() {
+    this.__isset_version = false;
+  }
+
+  getFieldValue(int fieldID) {
+    switch (fieldID) {
+      case NAME:
+        return this.name;
+      case TITLE:
+        return this.title;
+      case THUMBNAIL:
+        return this.thumbnail;
+      case BACKGROUND:
+        return this.background;
+      case CREATED:
+        return this.created;
+      case POINTS:
+        return this.points;
+      case TAGS:
+        return this.tags;
+      case LAYERS:
+        return this.layers;
+      case PALETTES:
+        return this.palettes;
+      case LABELS:
+        return this.labels;
+      case OUTLINE:
+        return this.outline;
+      case ORIGIN:
+        return this.origin;
+      case HISTORY:
+        return this.history;
+      case VERSION:
+        return this.version;
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  setFieldValue(int fieldID, Object value) {
+    switch(fieldID) {
+      case NAME:
+        if(value == null) {
+          unsetName();
+        } else {
+          this.name = value as String;
+        }
+        break;
+
+      case TITLE:
+        if(value == null) {
+          unsetTitle();
+        } else {
+          this.title = value as String;
+        }
+        break;
+
+      case THUMBNAIL:
+        if(value == null) {
+          unsetThumbnail();
+        } else {
+          this.thumbnail = value as Uint8List;
+        }
+        break;
+
+      case BACKGROUND:
+        if(value == null) {
+          unsetBackground();
+        } else {
+          this.background = value as int;
+        }
+        break;
+
+      case CREATED:
+        if(value == null) {
+          unsetCreated();
+        } else {
+          this.created = value as int;
+        }
+        break;
+
+      case POINTS:
+        if(value == null) {
+          unsetPoints();
+        } else {
+          this.points = value as List<t_json.Point>;
+        }
+        break;
+
+      case TAGS:
+        if(value == null) {
+          unsetTags();
+        } else {
+          this.tags = value as Set<String>;
+        }
+        break;
+
+      case LAYERS:
+        if(value == null) {
+          unsetLayers();
+        } else {
+          this.layers = value as Map<int, t_json.Point>;
+        }
+        break;
+
+      case PALETTES:
+        if(value == null) {
+          unsetPalettes();
+        } else {
+          this.palettes = value as Map<int, List<t_json.Point>>;
+        }
+        break;
+
+      case LABELS:
+        if(value == null) {
+          unsetLabels();
+        } else {
+          this.labels = value as Map<t_json.Point, String>;
+        }
+        break;
+
+      case OUTLINE:
+        if(value == null) {
+          unsetOutline();
+        } else {
+          this.outline = value as t_json.Shape;
+        }
+        break;
+
+      case ORIGIN:
+        if(value == null) {
+          unsetOrigin();
+        } else {
+          this.origin = value as t_actual_base_dart.thing;
+        }
+        break;
+
+      case HISTORY:
+        if(value == null) {
+          unsetHistory();
+        } else {
+          this.history = value as List<Set<int>>;
+        }
+        break;
+
+      case VERSION:
+        if(value == null) {
+          unsetVersion();
+        } else {
+          this.version = value as int;
+        }
+        break;
+
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  // Returns true if the field corresponding to fieldID is set (has been assigned a value) and false otherwise
+  bool isSet(int fieldID) {
+    switch(fieldID) {
+      case NAME:
+        return isSetName();
+      case TITLE:
+        return isSetTitle();
+      case THUMBNAIL:
+        return isSetThumbnail();
+      case BACKGROUND:
+        return isSetBackground();
+      case CREATED:
+        return isSetCreated();
+      case POINTS:
+        return isSetPoints();
+      case TAGS:
+        return isSetTags();
+      case LAYERS:
+        return isSetLayers();
+      case PALETTES:
+        return isSetPalettes();
+      case LABELS:
+        return isSetLabels();
+      case OUTLINE:
+        return isSetOutline();
+      case ORIGIN:
+        return isSetOrigin();
+      case HISTORY:
+        return isSetHistory();
+      case VERSION:
+        return isSetVersion();
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  read(thrift.TProtocol iprot) {
+    thrift.TField field;
+    iprot.readStructBegin();
+    while(true) {
+      field = iprot.readFieldBegin();
+      if(field.type == thrift.TType.STOP) {
+        break;
+      }
+      switch(field.id) {
+        case NAME:
+          if(field.type == thrift.TType.STRING) {
+            name = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case TITLE:
+          if(field.type == thrift.TType.STRING) {
+            title = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case THUMBNAIL:
+          if(field.type == thrift.TType.STRING) {
+            thumbnail = iprot.readBinary();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case BACKGROUND:
+          if(field.type == thrift.TType.I32) {
+            background = iprot.readI32();
+            this.__isset_background = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case CREATED:
+          if(field.type == thrift.TType.I64) {
+            created = iprot.readI64();
+            this.__isset_created = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case POINTS:
+          if(field.type == thrift.TType.LIST) {
+            thrift.TList elem0 = iprot.readListBegin();
+            points = new List<t_json.Point>();
+            for(int elem2 = 0; elem2 < elem0.length; ++elem2) {
+              t_json.Point elem1 = new t_json.Point();
+              elem1.read(iprot);
+              points.add(elem1);
+            }
+            iprot.readListEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case TAGS:
+          if(field.type == thrift.TType.SET) {
+            thrift.TSet elem3 = iprot.readSetBegin();
+            tags = new Set<String>();
+            for(int elem5 = 0; elem5 < elem3.length; ++elem5) {
+              String elem4 = iprot.readString();
+              tags.add(elem4);
+            }
+            iprot.readSetEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case LAYERS:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem6 = iprot.readMapBegin();
+            layers = new Map<int, t_json.Point>();
+            for(int elem8 = 0; elem8 < elem6.length; ++elem8) {
+              int elem9 = iprot.readI32();
+              t_json.Point elem7 = new t_json.Point();
+              elem7.read(iprot);
+              layers[elem9] = elem7;
+            }
+            iprot.readMapEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case PALETTES:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem10 = iprot.readMapBegin();
+            palettes = new Map<int, List<t_json.Point>>();
+            for(int elem15 = 0; elem15 < elem10.length; ++elem15) {
+              int elem16 = iprot.readI32();
+              thrift.TList elem12 = iprot.readListBegin();
+              List<t_json.Point> elem11 = new List<t_json.Point>();
+              for(int elem14 = 0; elem14 < elem12.length; ++elem14) {
+                t_json.Point elem13 = new t_json.Point();
+                elem13.read(iprot);
+                elem11.add(elem13);
+              }
+              iprot.readListEnd();
+              palettes[elem16] = elem11;
+            }
+            iprot.readMapEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case LABELS:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem17 = iprot.readMapBegin();
+            labels = new Map<t_json.Point, String>();
+            for(int elem19 = 0; elem19 < elem17.length; ++elem19) {
+              t_json.Point elem20 = new t_json.Point();
+              elem20.read(iprot);
+              String elem18 = iprot.readString();
+              labels[elem20] = elem18;
+            }
+            iprot.readMapEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case OUTLINE:
+          if(field.type == thrift.TType.STRUCT) {
+            outline = new t_json.Shape();
+            outline.read(iprot);
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case ORIGIN:
+          if(field.type == thrift.TType.STRUCT) {
+            origin = new t_actual_base_dart.thing();
+            origin.read(iprot);
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case HISTORY:
+          if(field.type == thrift.TType.LIST) {
+            thrift.TList elem21 = iprot.readListBegin();
+            history = new List<Set<int>>();
+            for(int elem26 = 0; elem26 < elem21.length; ++elem26) {
+              thrift.TSet elem23 = iprot.readSetBegin();
+              Set<int> elem22 = new Set<int>();
+              for(int elem25 = 0; elem25 < elem23.length; ++elem25) {
+                int elem24 = iprot.readI32();
+                elem22.add(elem24);
+              }
+              iprot.readSetEnd();
+              history.add(elem22);
+            }
+            iprot.readListEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case VERSION:
+          if(field.type == thrift.TType.I32) {
+            version = iprot.readI32();
+            this.__isset_version = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, field.type);
+          break;
+      }
+      iprot.readFieldEnd();
+    }
+    iprot.readStructEnd();
+
+    // check for required fields of primitive type, which can't be checked in the validate method
+    validate();
+  }
+
+  write(thrift.TProtocol oprot) {
+    validate();
+
+    oprot.writeStructBegin(_STRUCT_DESC);
+    if(this.name != null) {
+      oprot.writeFieldBegin(_NAME_FIELD_DESC);
+      oprot.writeString(name);
+      oprot.writeFieldEnd();
+    }
+    if(isSetTitle() && this.title != null) {
+      oprot.writeFieldBegin(_TITLE_FIELD_DESC);
+      oprot.writeString(title);
+      oprot.writeFieldEnd();
+    }
+    if(this.thumbnail != null) {
+      oprot.writeFieldBegin(_THUMBNAIL_FIELD_DESC);
+      oprot.writeBinary(thumbnail);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldBegin(_BACKGROUND_FIELD_DESC);
+    oprot.writeI32(background);
+    oprot.writeFieldEnd();
+    oprot.writeFieldBegin(_CREATED_FIELD_DESC);
+    oprot.writeI64(created);
+    oprot.writeFieldEnd();
+    if(this.points != null) {
+      oprot.writeFieldBegin(_POINTS_FIELD_DESC);
+      oprot.writeListBegin(new thrift.TList(thrift.TType.STRUCT, points.length));
+      for(var elem27 in points) {
+        elem27.write(oprot);
+      }
+      oprot.writeListEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.tags != null) {
+      oprot.writeFieldBegin(_TAGS_FIELD_DESC);
+      oprot.writeSetBegin(new thrift.TSet(thrift.TType.STRING, tags.length));
+      for(var elem28 in tags) {
+        oprot.writeString(elem28);
+      }
+      oprot.writeSetEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.layers != null) {
+      oprot.writeFieldBegin(_LAYERS_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.I32, thrift.TType.STRUCT, layers.length));
+      for(var elem29 in layers.keys) {
+        oprot.writeI32(elem29);
+        layers[elem29].write(oprot);
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.palettes != null) {
+      oprot.writeFieldBegin(_PALETTES_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.I32, thrift.TType.LIST, palettes.length));
+      for(var elem30 in palettes.keys) {
+        oprot.writeI32(elem30);
+        oprot.writeListBegin(new thrift.TList(thrift.TType.STRUCT, palettes[elem30].length));
+        for(var elem31 in palettes[elem30]) {
+          elem31.write(oprot);
+        }
+        oprot.writeListEnd();
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.labels != null) {
+      oprot.writeFieldBegin(_LABELS_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.STRUCT, thrift.TType.STRING, labels.length));
+      for(var elem32 in labels.keys) {
+        elem32.write(oprot);
+        oprot.writeString(labels[elem32]);
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(isSetOutline() && this.outline != null) {
+      oprot.writeFieldBegin(_OUTLINE_FIELD_DESC);
+      outline.write(oprot);
+      oprot.writeFieldEnd();
+    }
+    if(this.origin != null) {
+      oprot.writeFieldBegin(_ORIGIN_FIELD_DESC);
+      origin.write(oprot);
+      oprot.writeFieldEnd();
+    }
+    if(isSetHistory() && this.history != null) {
+      oprot.writeFieldBegin(_HISTORY_FIELD_DESC);
+      oprot.writeListBegin(new thrift.TList(thrift.TType.SET, history.length));
+      for(var elem33 in history) {
+        oprot.writeSetBegin(new thrift.TSet(thrift.TType.I32, elem33.length));
+        for(var elem34 in elem33) {
+          oprot.writeI32(elem34);
+        }
+        oprot.writeSetEnd();
+      }
+      oprot.writeListEnd();
+      oprot.writeFieldEnd();
+    }
+    if(isSetVersion()) {
+      oprot.writeFieldBegin(_VERSION_FIELD_DESC);
+      oprot.writeI32(version);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldStop();
+    oprot.writeStructEnd();
+  }
+
+  String toString() {
+    StringBuffer ret = new StringBuffer("Drawing(");
+
+    ret.write("name:");
+    if(this.name == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.name);
+    }
+
+    if(isSetTitle()) {
+      ret.write(", ");
+      ret.write("title:");
+      if(this.title == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.title);
+      }
+    }
+
+    ret.write(", ");
+    ret.write("thumbnail:");
+    if(this.thumbnail == null) {
+      ret.write("null");
+    } else {
+      ret.write("BINARY");
+    }
+
+    ret.write(", ");
+    ret.write("background:");
+    String background_name = t_json.Color.VALUES_TO_NAMES[this.background];
+    if(background_name != null) {
+      ret.write(background_name);
+      ret.write(" (");
+    }
+    ret.write(this.background);
+    if(background_name != null) {
+      ret.write(")");
+    }
+
+    ret.write(", ");
+    ret.write("created:");
+    ret.write(this.created);
+
+    ret.write(", ");
+    ret.write("points:");
+    if(this.points == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.points);
+    }
+
+    ret.write(", ");
+    ret.write("tags:");
+    if(this.tags == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.tags);
+    }
+
+    ret.write(", ");
+    ret.write("layers:");
+    if(this.layers == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.layers);
+    }
+
+    ret.write(", ");
+    ret.write("palettes:");
+    if(this.palettes == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.palettes);
+    }
+
+    ret.write(", ");
+    ret.write("labels:");
+    if(this.labels == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.labels);
+    }
+
+    if(isSetOutline()) {
+      ret.write(", ");
+      ret.write("outline:");
+      if(this.outline == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.outline);
+      }
+    }
+
+    ret.write(", ");
+    ret.write("origin:");
+    if(this.origin == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.origin);
+    }
+
+    if(isSetHistory()) {
+      ret.write(", ");
+      ret.write("history:");
+      if(this.history == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.history);
+      }
+    }
+
+    if(isSetVersion()) {
+      ret.write(", ");
+      ret.write("version:");
+      ret.write(this.version);
+    }
+
+    ret.write(")");
+
+    return ret.toString();
+  }
+
+  bool operator ==(Object o) {
+    if(o == null || !(o is Drawing)) {
+      return false;
+    }
+    Drawing other = o as Drawing;
+    return this.name == other.name
+      && this.title == other.title
+      && this.thumbnail == other.thumbnail
+      && this.background == other.background
+      && this.created == other.created
+      && this.points == other.points
+      && this.tags == other.tags
+      && this.layers == other.layers
+      && this.palettes == other.palettes
+      && this.labels == other.labels
+      && this.outline == other.outline
+      && this.origin == other.origin
+      && this.history == other.history
+      && this.version == other.version;
+  }
+
+  int get hashCode {
+    var value = 17;
+    value = (value * 31) ^ name.hashCode;
+    value = (value * 31) ^ title.hashCode;
+    value = (value * 31) ^ thumbnail.hashCode;
+    value = (value * 31) ^ background.hashCode;
+    value = (value * 31) ^ created.hashCode;
+    value = (value * 31) ^ points.hashCode;
+    value = (value * 31) ^ tags.hashCode;
+    value = (value * 31) ^ layers.hashCode;
+    value = (value * 31) ^ palettes.hashCode;
+    value = (value * 31) ^ labels.hashCode;
+    value = (value * 31) ^ outline.hashCode;
+    value = (value * 31) ^ origin.hashCode;
+    value = (value * 31) ^ history.hashCode;
+    value = (value * 31) ^ version.hashCode;
+    return value;
+  }
+
+  Drawing clone({
+    String name: null,
+    String title: null,
+    Uint8List thumbnail: null,
+    int background: null,
+    int created: null,
+    List<t_json.Point> points: null,
+    Set<String> tags: null,
+    Map<int, t_json.Point> layers: null,
+    Map<int, List<t_json.Point>> palettes: null,
+    Map<t_json.Point, String> labels: null,
+    t_json.Shape outline: null,
+    t_actual_base_dart.thing origin: null,
+    List<Set<int>> history: null,
+    int version: null,
+  }) {
+    return new Drawing()
+      ..name = name ?? this.name
+      ..title = title ?? this.title
+      ..thumbnail = thumbnail ?? this.thumbnail
+      ..background = background ?? this.background
+      ..created = created ?? this.created
+      ..points = points ?? this.points
+      ..tags = tags ?? this.tags
+      ..layers = layers ?? this.layers
+      ..palettes = palettes ?? this.palettes
+      ..labels = labels ?? this.labels
+      ..outline = outline ?? this.outline
+      ..origin = origin ?? this.origin
+      ..history = history ?? this.history
+      ..version = version ?? this.version;
+  }
+
+  /// Returns a map encoding this Drawing, keyed by field name, for JSON.encode.
+  Map<String, dynamic> toJson() {
+    Map<String, dynamic> json = <String, dynamic>{};
+    if(isSetName()) {
+      json['name'] = this.name;
+    }
+    if(isSetTitle()) {
+      json['title'] = this.title;
+    }
+    if(isSetThumbnail()) {
+      json['thumbnail'] = BASE64.encode(this.thumbnail);
+    }
+    if(isSetBackground()) {
+      json['background'] = t_json.Color.VALUES_TO_NAMES[this.background];
+    }
+    json['created'] = this.created;
+    if(isSetPoints()) {
+      json['points'] = this.points.map((e1) => e1.toJson()).toList();
+    }
+    if(isSetTags()) {
+      json['tags'] = this.tags.toList();
+    }
+    if(isSetLayers()) {
+      json['layers'] = new Map<String, dynamic>.fromIterables(this.layers.keys.map((k1) => k1.toString()), this.layers.values.map((v1) => v1.toJson()));
+    }
+    if(isSetPalettes()) {
+      json['palettes'] = new Map<String, dynamic>.fromIterables(this.palettes.keys.map((k1) => t_json.Color.VALUES_TO_NAMES[k1]), this.palettes.values.map((v1) => v1.map((e2) => e2.toJson()).toList()));
+    }
+    if(isSetLabels()) {
+      json['labels'] = this.labels.keys.map((k1) => [k1.toJson(), this.labels[k1]]).toList();
+    }
+    if(isSetOutline()) {
+      json['outline'] = this.outline.toJson();
+    }
+    if(isSetOrigin()) {
+      json['origin'] = this.origin.toJson();
+    }
+    if(isSetHistory()) {
+      json['history'] = this.history.map((e1) => e1.map((e2) => t_json.Color.VALUES_TO_NAMES[e2]).toList()).toList();
+    }
+    if(isSetVersion()) {
+      json['version'] = this.version;
+    }
+    return json;
+  }
+
+  /// Sets the fields of this Drawing from a map produced by [toJson]. Fields
+  /// missing from the map are left unchanged.
+  void fromJson(Map json) {
+    if(json.containsKey('name')) {
+      try {
+        this.name = json['name'] as String;
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field name: $e");
+      }
+    }
+    if(json.containsKey('title')) {
+      try {
+        this.title = json['title'] as String;
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field title: $e");
+      }
+    }
+    if(json.containsKey('thumbnail')) {
+      try {
+        this.thumbnail = new Uint8List.fromList(BASE64.decode(json['thumbnail'] as String));
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field thumbnail: $e");
+      }
+    }
+    if(json.containsKey('background')) {
+      try {
+        this.background = t_json.Color.VALUES_TO_NAMES.keys.firstWhere((n1) => t_json.Color.VALUES_TO_NAMES[n1] == json['background'], orElse: () => throw new ArgumentError('Invalid name ${json['background']} for enum Color'));
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field background: $e");
+      }
+    }
+    if(json.containsKey('created')) {
+      try {
+        this.created = json['created'] as int;
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field created: $e");
+      }
+    }
+    if(json.containsKey('points')) {
+      try {
+        this.points = new List<t_json.Point>.from((json['points'] as List).map((e1) => (new t_json.Point()..fromJson(e1 as Map))));
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field points: $e");
+      }
+    }
+    if(json.containsKey('tags')) {
+      try {
+        this.tags = new Set<String>.from((json['tags'] as List).map((e1) => e1 as String));
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field tags: $e");
+      }
+    }
+    if(json.containsKey('layers')) {
+      try {
+        this.layers = new Map<int, t_json.Point>.fromIterables((json['layers'] as Map).keys.map((k1) => int.parse(k1 as String)), (json['layers'] as Map).values.map((v1) => (new t_json.Point()..fromJson(v1 as Map))));
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field layers: $e");
+      }
+    }
+    if(json.containsKey('palettes')) {
+      try {
+        this.palettes = new Map<int, List<t_json.Point>>.fromIterables((json['palettes'] as Map).keys.map((k1) => t_json.Color.VALUES_TO_NAMES.keys.firstWhere((n1) => t_json.Color.VALUES_TO_NAMES[n1] == k1, orElse: () => throw new ArgumentError('Invalid name ${k1} for enum Color'))), (json['palettes'] as Map).values.map((v1) => new List<t_json.Point>.from((v1 as List).map((e2) => (new t_json.Point()..fromJson(e2 as Map))))));
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field palettes: $e");
+      }
+    }
+    if(json.containsKey('labels')) {
+      try {
+        this.labels = new Map<t_json.Point, String>.fromIterables((json['labels'] as List).map((p1) => (new t_json.Point()..fromJson(p1[0] as Map))), (json['labels'] as List).map((p1) => p1[1] as String));
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field labels: $e");
+      }
+    }
+    if(json.containsKey('outline')) {
+      try {
+        this.outline = (new t_json.Shape()..fromJson(json['outline'] as Map));
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field outline: $e");
+      }
+    }
+    if(json.containsKey('origin')) {
+      try {
+        this.origin = (new t_actual_base_dart.thing()..fromJson(json['origin'] as Map));
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field origin: $e");
+      }
+    }
+    if(json.containsKey('history')) {
+      try {
+        this.history = new List<Set<int>>.from((json['history'] as List).map((e1) => new Set<int>.from((e1 as List).map((e2) => t_json.Color.VALUES_TO_NAMES.keys.firstWhere((n3) => t_json.Color.VALUES_TO_NAMES[n3] == e2, orElse: () => throw new ArgumentError('Invalid name ${e2} for enum Color'))))));
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field history: $e");
+      }
+    }
+    if(json.containsKey('version')) {
+      try {
+        this.version = json['version'] as int;
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field version: $e");
+      }
+    }
+  }
+
+  validate() {
+    // check for required fields
+    if(name == null) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Required field 'name' was not present in struct Drawing");
+    }
+    // check that fields of type enum have valid values
+    if(isSetBackground() && !t_json.Color.VALID_VALUES.contains(background)) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "The field 'background' has been assigned the invalid value $background");
+    }
+  }
+}
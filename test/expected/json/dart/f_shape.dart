@@ -0,0 +1,244 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:convert' show BASE64;
+import 'dart:typed_data' show Uint8List;
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:json/json.dart' as t_json;
+import 'package:actual_base_dart/actual_base_dart.dart' as t_actual_base_dart;
+
+class Shape implements thrift.TBase {
+  static final thrift.TStruct _STRUCT_DESC = new thrift.TStruct("Shape");
+  static final thrift.TField _POINT_FIELD_DESC = new thrift.TField("point", thrift.TType.STRUCT, 1);
+  static final thrift.TField _RADIUS_FIELD_DESC = new thrift.TField("radius", thrift.TType.DOUBLE, 2);
+
+  t_json.Point _point;
+  static const int POINT = 1;
+  double _radius;
+  static const int RADIUS = 2;
+
+  bool __isset_radius = false;
+
+  Shape() {
+  }
+
+  t_json.Point get point => this._point;
+
+  set point(t_json.Point point) {
+    this._point = point;
+  }
+
+  bool isSetPoint() => this.point != null;
+
+  unsetPoint() {
+    this.point = null;
+  }
+
+  double get radius => this._radius;
+
+  set radius(double radius) {
+    this._radius = radius;
+    this.__isset_radius = true;
+  }
+
+  bool isSetRadius() => this.__isset_radius;
+
+  unsetRadius() {
+    this.__isset_radius = false;
+  }
+
+  getFieldValue(int fieldID) {
+    switch (fieldID) {
+      case POINT:
+        return this.point;
+      case RADIUS:
+        return this.radius;
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  setFieldValue(int fieldID, Object value) {
+    switch(fieldID) {
+      case POINT:
+        if(value == null) {
+          unsetPoint();
+        } else {
+          this.point = value as t_json.Point;
+        }
+        break;
+
+      case RADIUS:
+        if(value == null) {
+          unsetRadius();
+        } else {
+          this.radius = value as double;
+        }
+        break;
+
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  // Returns true if the field corresponding to fieldID is set (has been assigned a value) and false otherwise
+  bool isSet(int fieldID) {
+    switch(fieldID) {
+      case POINT:
+        return isSetPoint();
+      case RADIUS:
+        return isSetRadius();
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  read(thrift.TProtocol iprot) {
+    thrift.TField field;
+    iprot.readStructBegin();
+    while(true) {
+      field = iprot.readFieldBegin();
+      if(field.type == thrift.TType.STOP) {
+        break;
+      }
+      switch(field.id) {
+        case POINT:
+          if(field.type == thrift.TType.STRUCT) {
+            point = new t_json.Point();
+            point.read(iprot);
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case RADIUS:
+          if(field.type == thrift.TType.DOUBLE) {
+            radius = iprot.readDouble();
+            this.__isset_radius = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, field.type);
+          break;
+      }
+      iprot.readFieldEnd();
+    }
+    iprot.readStructEnd();
+
+    // check for required fields of primitive type, which can't be checked in the validate method
+    validate();
+  }
+
+  write(thrift.TProtocol oprot) {
+    validate();
+
+    oprot.writeStructBegin(_STRUCT_DESC);
+    if(isSetPoint() && this.point != null) {
+      oprot.writeFieldBegin(_POINT_FIELD_DESC);
+      point.write(oprot);
+      oprot.writeFieldEnd();
+    }
+    if(isSetRadius()) {
+      oprot.writeFieldBegin(_RADIUS_FIELD_DESC);
+      oprot.writeDouble(radius);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldStop();
+    oprot.writeStructEnd();
+  }
+
+  String toString() {
+    StringBuffer ret = new StringBuffer("Shape(");
+
+    if(isSetPoint()) {
+      ret.write("point:");
+      if(this.point == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.point);
+      }
+    }
+
+    if(isSetRadius()) {
+      ret.write(", ");
+      ret.write("radius:");
+      ret.write(this.radius);
+    }
+
+    ret.write(")");
+
+    return ret.toString();
+  }
+
+  bool operator ==(Object o) {
+    if(o == null || !(o is Shape)) {
+      return false;
+    }
+    Shape other = o as Shape;
+    return this.point == other.point
+      && this.radius == other.radius;
+  }
+
+  int get hashCode {
+    var value = 17;
+    value = (value * 31) ^ point.hashCode;
+    value = (value * 31) ^ radius.hashCode;
+    return value;
+  }
+
+  Shape clone({
+    t_json.Point point: null,
+    double radius: null,
+  }) {
+    return new Shape()
+      ..point = point ?? this.point
+      ..radius = radius ?? this.radius;
+  }
+
+  /// Returns a map encoding this Shape, keyed by field name, for JSON.encode.
+  Map<String, dynamic> toJson() {
+    Map<String, dynamic> json = <String, dynamic>{};
+    if(isSetPoint()) {
+      json['point'] = this.point.toJson();
+    }
+    if(isSetRadius()) {
+      json['radius'] = this.radius;
+    }
+    return json;
+  }
+
+  /// Sets the fields of this Shape from a map produced by [toJson]. Fields
+  /// missing from the map are left unchanged.
+  void fromJson(Map json) {
+    if(json.containsKey('point')) {
+      try {
+        this.point = (new t_json.Point()..fromJson(json['point'] as Map));
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field point: $e");
+      }
+    }
+    if(json.containsKey('radius')) {
+      try {
+        this.radius = (json['radius'] as num).toDouble();
+      } catch (e) {
+        throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "error decoding field radius: $e");
+      }
+    }
+  }
+
+  validate() {
+    // check exactly one field is set
+    int setFields = 0;
+    if(isSetPoint()) {
+      setFields++;
+    }
+    if(isSetRadius()) {
+      setFields++;
+    }
+    if(setFields != 1) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "The union did not have exactly one field set, $setFields were set");
+    }
+    // check that fields of type enum have valid values
+  }
+}
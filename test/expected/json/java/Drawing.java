@@ -0,0 +1,2319 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+package json;
+
+import org.apache.thrift.scheme.IScheme;
+import org.apache.thrift.scheme.SchemeFactory;
+import org.apache.thrift.scheme.StandardScheme;
+
+import org.apache.thrift.scheme.TupleScheme;
+import org.apache.thrift.protocol.TTupleProtocol;
+import org.apache.thrift.protocol.TProtocolException;
+import org.apache.thrift.EncodingUtils;
+import org.apache.thrift.TException;
+import org.apache.thrift.async.AsyncMethodCallback;
+import org.apache.thrift.server.AbstractNonblockingServer.*;
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import javax.annotation.Generated;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+@Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
+public class Drawing implements org.apache.thrift.TBase<Drawing, Drawing._Fields>, java.io.Serializable, Cloneable, Comparable<Drawing> {
+	private static final org.apache.thrift.protocol.TStruct STRUCT_DESC = new org.apache.thrift.protocol.TStruct("Drawing");
+
+	private static final org.apache.thrift.protocol.TField NAME_FIELD_DESC = new org.apache.thrift.protocol.TField("name", org.apache.thrift.protocol.TType.STRING, (short)1);
+	private static final org.apache.thrift.protocol.TField TITLE_FIELD_DESC = new org.apache.thrift.protocol.TField("title", org.apache.thrift.protocol.TType.STRING, (short)2);
+	private static final org.apache.thrift.protocol.TField THUMBNAIL_FIELD_DESC = new org.apache.thrift.protocol.TField("thumbnail", org.apache.thrift.protocol.TType.STRING, (short)3);
+	private static final org.apache.thrift.protocol.TField BACKGROUND_FIELD_DESC = new org.apache.thrift.protocol.TField("background", org.apache.thrift.protocol.TType.I32, (short)4);
+	private static final org.apache.thrift.protocol.TField CREATED_FIELD_DESC = new org.apache.thrift.protocol.TField("created", org.apache.thrift.protocol.TType.I64, (short)5);
+	private static final org.apache.thrift.protocol.TField POINTS_FIELD_DESC = new org.apache.thrift.protocol.TField("points", org.apache.thrift.protocol.TType.LIST, (short)6);
+	private static final org.apache.thrift.protocol.TField TAGS_FIELD_DESC = new org.apache.thrift.protocol.TField("tags", org.apache.thrift.protocol.TType.SET, (short)7);
+	private static final org.apache.thrift.protocol.TField LAYERS_FIELD_DESC = new org.apache.thrift.protocol.TField("layers", org.apache.thrift.protocol.TType.MAP, (short)8);
+	private static final org.apache.thrift.protocol.TField PALETTES_FIELD_DESC = new org.apache.thrift.protocol.TField("palettes", org.apache.thrift.protocol.TType.MAP, (short)9);
+	private static final org.apache.thrift.protocol.TField LABELS_FIELD_DESC = new org.apache.thrift.protocol.TField("labels", org.apache.thrift.protocol.TType.MAP, (short)10);
+	private static final org.apache.thrift.protocol.TField OUTLINE_FIELD_DESC = new org.apache.thrift.protocol.TField("outline", org.apache.thrift.protocol.TType.STRUCT, (short)11);
+	private static final org.apache.thrift.protocol.TField ORIGIN_FIELD_DESC = new org.apache.thrift.protocol.TField("origin", org.apache.thrift.protocol.TType.STRUCT, (short)12);
+	private static final org.apache.thrift.protocol.TField HISTORY_FIELD_DESC = new org.apache.thrift.protocol.TField("history", org.apache.thrift.protocol.TType.LIST, (short)13);
+	private static final org.apache.thrift.protocol.TField VERSION_FIELD_DESC = new org.apache.thrift.protocol.TField("version", org.apache.thrift.protocol.TType.I32, (short)14);
+
+	private static final Map<Class<? extends IScheme>, SchemeFactory> schemes = new HashMap<Class<? extends IScheme>, SchemeFactory>();
+	static {
+		schemes.put(StandardScheme.class, new DrawingStandardSchemeFactory());
+		schemes.put(TupleScheme.class, new DrawingTupleSchemeFactory());
+	}
+
+	public String name; // required
+	public String title; // optional
+	public java.nio.ByteBuffer thumbnail;
+	public Color background;
+	public long created;
+	public java.util.List<Point> points;
+	public java.util.Set<String> tags;
+	public java.util.Map<Integer, Point> layers;
+	public java.util.Map<Color, java.util.List<Point>> palettes;
+	public java.util.Map<Point, String> labels;
+	public Shape outline; // optional
+	public actual_base.java.thing origin;
+	public java.util.List<java.util.Set<Color>> history; // optional
+	public int version; // optional
+	/** The set of fields this struct contains, along with convenience methods for finding and manipulating them. */
+	public enum _Fields implements org.apache.thrift.TFieldIdEnum {
+		NAME((short)1, "name"),
+		TITLE((short)2, "title"),
+		THUMBNAIL((short)3, "thumbnail"),
+		BACKGROUND((short)4, "background"),
+		CREATED((short)5, "created"),
+		POINTS((short)6, "points"),
+		TAGS((short)7, "tags"),
+		LAYERS((short)8, "layers"),
+		PALETTES((short)9, "palettes"),
+		LABELS((short)10, "labels"),
+		OUTLINE((short)11, "outline"),
+		ORIGIN((short)12, "origin"),
+		HISTORY((short)13, "history"),
+		VERSION((short)14, "version")
+		;
+
+		private static final Map<String, _Fields> byName = new HashMap<String, _Fields>();
+
+		static {
+			for (_Fields field : EnumSet.allOf(_Fields.class)) {
+				byName.put(field.getFieldName(), field);
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, or null if its not found.
+		 */
+		public static _Fields findByThriftId(int fieldId) {
+			switch(fieldId) {
+				case 1: // NAME
+					return NAME;
+				case 2: // TITLE
+					return TITLE;
+				case 3: // THUMBNAIL
+					return THUMBNAIL;
+				case 4: // BACKGROUND
+					return BACKGROUND;
+				case 5: // CREATED
+					return CREATED;
+				case 6: // POINTS
+					return POINTS;
+				case 7: // TAGS
+					return TAGS;
+				case 8: // LAYERS
+					return LAYERS;
+				case 9: // PALETTES
+					return PALETTES;
+				case 10: // LABELS
+					return LABELS;
+				case 11: // OUTLINE
+					return OUTLINE;
+				case 12: // ORIGIN
+					return ORIGIN;
+				case 13: // HISTORY
+					return HISTORY;
+				case 14: // VERSION
+					return VERSION;
+				default:
+					return null;
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, throwing an exception
+		 * if it is not found.
+		 */
+		public static _Fields findByThriftIdOrThrow(int fieldId) {
+			_Fields fields = findByThriftId(fieldId);
+			if (fields == null) throw new IllegalArgumentException("Field " + fieldId + " doesn't exist!");
+			return fields;
+		}
+
+		/**
+		 * Find the _Fields constant that matches name, or null if its not found.
+		 */
+		public static _Fields findByName(String name) {
+			return byName.get(name);
+		}
+
+		private final short _thriftId;
+		private final String _fieldName;
+
+		_Fields(short thriftId, String fieldName) {
+			_thriftId = thriftId;
+			_fieldName = fieldName;
+		}
+
+		public short getThriftFieldId() {
+			return _thriftId;
+		}
+
+		public String getFieldName() {
+			return _fieldName;
+		}
+	}
+
+	// isset id assignments
+	private static final int __CREATED_ISSET_ID = 0;
+	private static final int __VERSION_ISSET_ID = 1;
+	private byte __isset_bitfield = 0;
+	public Drawing() {
+		this.version = 1;
+
+	}
+
+	public Drawing(
+		String name,
+		java.nio.ByteBuffer thumbnail,
+		Color background,
+		long created,
+		java.util.List<Point> points,
+		java.util.Set<String> tags,
+		java.util.Map<Integer, Point> layers,
+		java.util.Map<Color, java.util.List<Point>> palettes,
+		java.util.Map<Point, String> labels,
+		actual_base.java.thing origin) {
+		this();
+		this.name = name;
+		this.thumbnail = org.apache.thrift.TBaseHelper.copyBinary(thumbnail);
+		this.background = background;
+		this.created = created;
+		setCreatedIsSet(true);
+		this.points = points;
+		this.tags = tags;
+		this.layers = layers;
+		this.palettes = palettes;
+		this.labels = labels;
+		this.origin = origin;
+	}
+
+	/**
+	 * Performs a deep copy on <i>other</i>.
+	 */
+	public Drawing(Drawing other) {
+		__isset_bitfield = other.__isset_bitfield;
+		if (other.isSetName()) {
+			this.name = other.name;
+		}
+		if (other.isSetTitle()) {
+			this.title = other.title;
+		}
+		if (other.isSetThumbnail()) {
+			this.thumbnail = org.apache.thrift.TBaseHelper.copyBinary(other.thumbnail);
+		}
+		if (other.isSetBackground()) {
+			this.background = other.background;
+		}
+		this.created = other.created;
+		if (other.isSetPoints()) {
+			this.points = new ArrayList<Point>(other.points.size());
+			for (Point elem4 : other.points) {
+				Point elem5 = new Point(elem4);
+				this.points.add(elem5);
+			}
+		}
+		if (other.isSetTags()) {
+			this.tags = new HashSet<String>(other.tags.size());
+			for (String elem6 : other.tags) {
+				String elem7 = elem6;
+				this.tags.add(elem7);
+			}
+		}
+		if (other.isSetLayers()) {
+			this.layers = new HashMap<Integer,Point>(other.layers.size());
+			for (Map.Entry<Integer, Point> elem8 : other.layers.entrySet()) {
+				int elem10 = elem8.getKey();
+				Point elem9 = new Point(elem8.getValue());
+				this.layers.put(elem10, elem9);
+			}
+		}
+		if (other.isSetPalettes()) {
+			this.palettes = new HashMap<Color,java.util.List<Point>>(other.palettes.size());
+			for (Map.Entry<Color, java.util.List<Point>> elem11 : other.palettes.entrySet()) {
+				Color elem13 = elem11.getKey();
+				java.util.List<Point> elem12 = new ArrayList<Point>(elem11.getValue().size());
+				for (Point elem14 : elem11.getValue()) {
+					Point elem15 = new Point(elem14);
+					elem12.add(elem15);
+				}
+				this.palettes.put(elem13, elem12);
+			}
+		}
+		if (other.isSetLabels()) {
+			this.labels = new HashMap<Point,String>(other.labels.size());
+			for (Map.Entry<Point, String> elem16 : other.labels.entrySet()) {
+				Point elem18 = new Point(elem16.getKey());
+				String elem17 = elem16.getValue();
+				this.labels.put(elem18, elem17);
+			}
+		}
+		if (other.isSetOutline()) {
+			this.outline = new Shape(other.outline);
+		}
+		if (other.isSetOrigin()) {
+			this.origin = new actual_base.java.thing(other.origin);
+		}
+		if (other.isSetHistory()) {
+			this.history = new ArrayList<java.util.Set<Color>>(other.history.size());
+			for (java.util.Set<Color> elem19 : other.history) {
+				java.util.Set<Color> elem20 = new HashSet<Color>(elem19.size());
+				for (Color elem21 : elem19) {
+					Color elem22 = elem21;
+					elem20.add(elem22);
+				}
+				this.history.add(elem20);
+			}
+		}
+		this.version = other.version;
+	}
+
+	public Drawing deepCopy() {
+		return new Drawing(this);
+	}
+
+	@Override
+	public void clear() {
+		this.name = null;
+
+		this.title = null;
+
+		this.thumbnail = null;
+
+		this.background = null;
+
+		setCreatedIsSet(false);
+		this.created = 0L;
+
+		this.points = null;
+
+		this.tags = null;
+
+		this.layers = null;
+
+		this.palettes = null;
+
+		this.labels = null;
+
+		this.outline = null;
+
+		this.origin = null;
+
+		this.history = null;
+
+		this.version = 1;
+
+	}
+
+	public String getName() {
+		return this.name;
+	}
+
+	public Drawing setName(String name) {
+		this.name = name;
+		return this;
+	}
+
+	public void unsetName() {
+		this.name = null;
+	}
+
+	/** Returns true if field name is set (has been assigned a value) and false otherwise */
+	public boolean isSetName() {
+		return this.name != null;
+	}
+
+	public void setNameIsSet(boolean value) {
+		if (!value) {
+			this.name = null;
+		}
+	}
+
+	public String getTitle() {
+		return this.title;
+	}
+
+	public Drawing setTitle(String title) {
+		this.title = title;
+		return this;
+	}
+
+	public void unsetTitle() {
+		this.title = null;
+	}
+
+	/** Returns true if field title is set (has been assigned a value) and false otherwise */
+	public boolean isSetTitle() {
+		return this.title != null;
+	}
+
+	public void setTitleIsSet(boolean value) {
+		if (!value) {
+			this.title = null;
+		}
+	}
+
+	public byte[] getThumbnail() {
+		setThumbnail(org.apache.thrift.TBaseHelper.rightSize(thumbnail));
+		return thumbnail == null ? null : thumbnail.array();
+	}
+
+	public java.nio.ByteBuffer bufferForThumbnail() {
+		return org.apache.thrift.TBaseHelper.copyBinary(thumbnail);
+	}
+
+	public Drawing setThumbnail(byte[] thumbnail) {
+		this.thumbnail = thumbnail == null ? (java.nio.ByteBuffer)null : java.nio.ByteBuffer.wrap(Arrays.copyOf(thumbnail, thumbnail.length));
+		return this;
+	}
+
+	public Drawing setThumbnail(java.nio.ByteBuffer thumbnail) {
+		this.thumbnail = org.apache.thrift.TBaseHelper.copyBinary(thumbnail);
+		return this;
+	}
+
+	public void unsetThumbnail() {
+		this.thumbnail = null;
+	}
+
+	/** Returns true if field thumbnail is set (has been assigned a value) and false otherwise */
+	public boolean isSetThumbnail() {
+		return this.thumbnail != null;
+	}
+
+	public void setThumbnailIsSet(boolean value) {
+		if (!value) {
+			this.thumbnail = null;
+		}
+	}
+
+	public Color getBackground() {
+		return this.background;
+	}
+
+	public Drawing setBackground(Color background) {
+		this.background = background;
+		return this;
+	}
+
+	public void unsetBackground() {
+		this.background = null;
+	}
+
+	/** Returns true if field background is set (has been assigned a value) and false otherwise */
+	public boolean isSetBackground() {
+		return this.background != null;
+	}
+
+	public void setBackgroundIsSet(boolean value) {
+		if (!value) {
+			this.background = null;
+		}
+	}
+
+	public long getCreated() {
+		return this.created;
+	}
+
+	public Drawing setCreated(long created) {
+		this.created = created;
+		setCreatedIsSet(true);
+		return this;
+	}
+
+	public void unsetCreated() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __CREATED_ISSET_ID);
+	}
+
+	/** Returns true if field created is set (has been assigned a value) and false otherwise */
+	public boolean isSetCreated() {
+		return EncodingUtils.testBit(__isset_bitfield, __CREATED_ISSET_ID);
+	}
+
+	public void setCreatedIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __CREATED_ISSET_ID, value);
+	}
+
+	public int getPointsSize() {
+		return (this.points == null) ? 0 : this.points.size();
+	}
+
+	public java.util.Iterator<Point> getPointsIterator() {
+		return (this.points == null) ? null : this.points.iterator();
+	}
+
+	public void addToPoints(Point elem) {
+		if (this.points == null) {
+			this.points = new ArrayList<Point>();
+		}
+		this.points.add(elem);
+	}
+
+	public java.util.List<Point> getPoints() {
+		return this.points;
+	}
+
+	public Drawing setPoints(java.util.List<Point> points) {
+		this.points = points;
+		return this;
+	}
+
+	public void unsetPoints() {
+		this.points = null;
+	}
+
+	/** Returns true if field points is set (has been assigned a value) and false otherwise */
+	public boolean isSetPoints() {
+		return this.points != null;
+	}
+
+	public void setPointsIsSet(boolean value) {
+		if (!value) {
+			this.points = null;
+		}
+	}
+
+	public int getTagsSize() {
+		return (this.tags == null) ? 0 : this.tags.size();
+	}
+
+	public java.util.Iterator<String> getTagsIterator() {
+		return (this.tags == null) ? null : this.tags.iterator();
+	}
+
+	public void addToTags(String elem) {
+		if (this.tags == null) {
+			this.tags = new HashSet<String>();
+		}
+		this.tags.add(elem);
+	}
+
+	public java.util.Set<String> getTags() {
+		return this.tags;
+	}
+
+	public Drawing setTags(java.util.Set<String> tags) {
+		this.tags = tags;
+		return this;
+	}
+
+	public void unsetTags() {
+		this.tags = null;
+	}
+
+	/** Returns true if field tags is set (has been assigned a value) and false otherwise */
+	public boolean isSetTags() {
+		return this.tags != null;
+	}
+
+	public void setTagsIsSet(boolean value) {
+		if (!value) {
+			this.tags = null;
+		}
+	}
+
+	public int getLayersSize() {
+		return (this.layers == null) ? 0 : this.layers.size();
+	}
+
+	public void putToLayers(int key, Point val) {
+		if (this.layers == null) {
+			this.layers = new HashMap<Integer,Point>();
+		}
+		this.layers.put(key, val);
+	}
+
+	public java.util.Map<Integer, Point> getLayers() {
+		return this.layers;
+	}
+
+	public Drawing setLayers(java.util.Map<Integer, Point> layers) {
+		this.layers = layers;
+		return this;
+	}
+
+	public void unsetLayers() {
+		this.layers = null;
+	}
+
+	/** Returns true if field layers is set (has been assigned a value) and false otherwise */
+	public boolean isSetLayers() {
+		return this.layers != null;
+	}
+
+	public void setLayersIsSet(boolean value) {
+		if (!value) {
+			this.layers = null;
+		}
+	}
+
+	public int getPalettesSize() {
+		return (this.palettes == null) ? 0 : this.palettes.size();
+	}
+
+	public void putToPalettes(Color key, java.util.List<Point> val) {
+		if (this.palettes == null) {
+			this.palettes = new HashMap<Color,java.util.List<Point>>();
+		}
+		this.palettes.put(key, val);
+	}
+
+	public java.util.Map<Color, java.util.List<Point>> getPalettes() {
+		return this.palettes;
+	}
+
+	public Drawing setPalettes(java.util.Map<Color, java.util.List<Point>> palettes) {
+		this.palettes = palettes;
+		return this;
+	}
+
+	public void unsetPalettes() {
+		this.palettes = null;
+	}
+
+	/** Returns true if field palettes is set (has been assigned a value) and false otherwise */
+	public boolean isSetPalettes() {
+		return this.palettes != null;
+	}
+
+	public void setPalettesIsSet(boolean value) {
+		if (!value) {
+			this.palettes = null;
+		}
+	}
+
+	public int getLabelsSize() {
+		return (this.labels == null) ? 0 : this.labels.size();
+	}
+
+	public void putToLabels(Point key, String val) {
+		if (this.labels == null) {
+			this.labels = new HashMap<Point,String>();
+		}
+		this.labels.put(key, val);
+	}
+
+	public java.util.Map<Point, String> getLabels() {
+		return this.labels;
+	}
+
+	public Drawing setLabels(java.util.Map<Point, String> labels) {
+		this.labels = labels;
+		return this;
+	}
+
+	public void unsetLabels() {
+		this.labels = null;
+	}
+
+	/** Returns true if field labels is set (has been assigned a value) and false otherwise */
+	public boolean isSetLabels() {
+		return this.labels != null;
+	}
+
+	public void setLabelsIsSet(boolean value) {
+		if (!value) {
+			this.labels = null;
+		}
+	}
+
+	public Shape getOutline() {
+		return this.outline;
+	}
+
+	public Drawing setOutline(Shape outline) {
+		this.outline = outline;
+		return this;
+	}
+
+	public void unsetOutline() {
+		this.outline = null;
+	}
+
+	/** Returns true if field outline is set (has been assigned a value) and false otherwise */
+	public boolean isSetOutline() {
+		return this.outline != null;
+	}
+
+	public void setOutlineIsSet(boolean value) {
+		if (!value) {
+			this.outline = null;
+		}
+	}
+
+	public actual_base.java.thing getOrigin() {
+		return this.origin;
+	}
+
+	public Drawing setOrigin(actual_base.java.thing origin) {
+		this.origin = origin;
+		return this;
+	}
+
+	public void unsetOrigin() {
+		this.origin = null;
+	}
+
+	/** Returns true if field origin is set (has been assigned a value) and false otherwise */
+	public boolean isSetOrigin() {
+		return this.origin != null;
+	}
+
+	public void setOriginIsSet(boolean value) {
+		if (!value) {
+			this.origin = null;
+		}
+	}
+
+	public int getHistorySize() {
+		return (this.history == null) ? 0 : this.history.size();
+	}
+
+	public java.util.Iterator<java.util.Set<Color>> getHistoryIterator() {
+		return (this.history == null) ? null : this.history.iterator();
+	}
+
+	public void addToHistory(java.util.Set<Color> elem) {
+		if (this.history == null) {
+			this.history = new ArrayList<java.util.Set<Color>>();
+		}
+		this.history.add(elem);
+	}
+
+	public java.util.List<java.util.Set<Color>> getHistory() {
+		return this.history;
+	}
+
+	public Drawing setHistory(java.util.List<java.util.Set<Color>> history) {
+		this.history = history;
+		return this;
+	}
+
+	public void unsetHistory() {
+		this.history = null;
+	}
+
+	/** Returns true if field history is set (has been assigned a value) and false otherwise */
+	public boolean isSetHistory() {
+		return this.history != null;
+	}
+
+	public void setHistoryIsSet(boolean value) {
+		if (!value) {
+			this.history = null;
+		}
+	}
+
+	public int getVersion() {
+		return this.version;
+	}
+
+	public Drawing setVersion(int version) {
+		this.version = version;
+		setVersionIsSet(true);
+		return this;
+	}
+
+	public void unsetVersion() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __VERSION_ISSET_ID);
+	}
+
+	/** Returns true if field version is set (has been assigned a value) and false otherwise */
+	public boolean isSetVersion() {
+		return EncodingUtils.testBit(__isset_bitfield, __VERSION_ISSET_ID);
+	}
+
+	public void setVersionIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __VERSION_ISSET_ID, value);
+	}
+
+	public void setFieldValue(_Fields field, Object value) {
+		switch (field) {
+		case NAME:
+			if (value == null) {
+				unsetName();
+			} else {
+				setName((String)value);
+			}
+			break;
+
+		case TITLE:
+			if (value == null) {
+				unsetTitle();
+			} else {
+				setTitle((String)value);
+			}
+			break;
+
+		case THUMBNAIL:
+			if (value == null) {
+				unsetThumbnail();
+			} else {
+				setThumbnail((java.nio.ByteBuffer)value);
+			}
+			break;
+
+		case BACKGROUND:
+			if (value == null) {
+				unsetBackground();
+			} else {
+				setBackground((Color)value);
+			}
+			break;
+
+		case CREATED:
+			if (value == null) {
+				unsetCreated();
+			} else {
+				setCreated((Long)value);
+			}
+			break;
+
+		case POINTS:
+			if (value == null) {
+				unsetPoints();
+			} else {
+				setPoints((java.util.List<Point>)value);
+			}
+			break;
+
+		case TAGS:
+			if (value == null) {
+				unsetTags();
+			} else {
+				setTags((java.util.Set<String>)value);
+			}
+			break;
+
+		case LAYERS:
+			if (value == null) {
+				unsetLayers();
+			} else {
+				setLayers((java.util.Map<Integer, Point>)value);
+			}
+			break;
+
+		case PALETTES:
+			if (value == null) {
+				unsetPalettes();
+			} else {
+				setPalettes((java.util.Map<Color, java.util.List<Point>>)value);
+			}
+			break;
+
+		case LABELS:
+			if (value == null) {
+				unsetLabels();
+			} else {
+				setLabels((java.util.Map<Point, String>)value);
+			}
+			break;
+
+		case OUTLINE:
+			if (value == null) {
+				unsetOutline();
+			} else {
+				setOutline((Shape)value);
+			}
+			break;
+
+		case ORIGIN:
+			if (value == null) {
+				unsetOrigin();
+			} else {
+				setOrigin((actual_base.java.thing)value);
+			}
+			break;
+
+		case HISTORY:
+			if (value == null) {
+				unsetHistory();
+			} else {
+				setHistory((java.util.List<java.util.Set<Color>>)value);
+			}
+			break;
+
+		case VERSION:
+			if (value == null) {
+				unsetVersion();
+			} else {
+				setVersion((Integer)value);
+			}
+			break;
+
+		}
+	}
+
+	public Object getFieldValue(_Fields field) {
+		switch (field) {
+		case NAME:
+			return getName();
+
+		case TITLE:
+			return getTitle();
+
+		case THUMBNAIL:
+			return getThumbnail();
+
+		case BACKGROUND:
+			return getBackground();
+
+		case CREATED:
+			return getCreated();
+
+		case POINTS:
+			return getPoints();
+
+		case TAGS:
+			return getTags();
+
+		case LAYERS:
+			return getLayers();
+
+		case PALETTES:
+			return getPalettes();
+
+		case LABELS:
+			return getLabels();
+
+		case OUTLINE:
+			return getOutline();
+
+		case ORIGIN:
+			return getOrigin();
+
+		case HISTORY:
+			return getHistory();
+
+		case VERSION:
+			return getVersion();
+
+		}
+		throw new IllegalStateException();
+	}
+
+	/** Returns true if field corresponding to fieldID is set (has been assigned a value) and false otherwise */
+	public boolean isSet(_Fields field) {
+		if (field == null) {
+			throw new IllegalArgumentException();
+		}
+
+		switch (field) {
+		case NAME:
+			return isSetName();
+		case TITLE:
+			return isSetTitle();
+		case THUMBNAIL:
+			return isSetThumbnail();
+		case BACKGROUND:
+			return isSetBackground();
+		case CREATED:
+			return isSetCreated();
+		case POINTS:
+			return isSetPoints();
+		case TAGS:
+			return isSetTags();
+		case LAYERS:
+			return isSetLayers();
+		case PALETTES:
+			return isSetPalettes();
+		case LABELS:
+			return isSetLabels();
+		case OUTLINE:
+			return isSetOutline();
+		case ORIGIN:
+			return isSetOrigin();
+		case HISTORY:
+			return isSetHistory();
+		case VERSION:
+			return isSetVersion();
+		}
+		throw new IllegalStateException();
+	}
+
+	@Override
+	public boolean equals(Object that) {
+		if (that == null)
+			return false;
+		if (that instanceof Drawing)
+			return this.equals((Drawing)that);
+		return false;
+	}
+
+	public boolean equals(Drawing that) {
+		if (that == null)
+			return false;
+
+		boolean this_present_name = true && this.isSetName();
+		boolean that_present_name = true && that.isSetName();
+		if (this_present_name || that_present_name) {
+			if (!(this_present_name && that_present_name))
+				return false;
+			if (!this.name.equals(that.name))
+				return false;
+		}
+
+		boolean this_present_title = true && this.isSetTitle();
+		boolean that_present_title = true && that.isSetTitle();
+		if (this_present_title || that_present_title) {
+			if (!(this_present_title && that_present_title))
+				return false;
+			if (!this.title.equals(that.title))
+				return false;
+		}
+
+		boolean this_present_thumbnail = true && this.isSetThumbnail();
+		boolean that_present_thumbnail = true && that.isSetThumbnail();
+		if (this_present_thumbnail || that_present_thumbnail) {
+			if (!(this_present_thumbnail && that_present_thumbnail))
+				return false;
+			if (!this.thumbnail.equals(that.thumbnail))
+				return false;
+		}
+
+		boolean this_present_background = true && this.isSetBackground();
+		boolean that_present_background = true && that.isSetBackground();
+		if (this_present_background || that_present_background) {
+			if (!(this_present_background && that_present_background))
+				return false;
+			if (!this.background.equals(that.background))
+				return false;
+		}
+
+		boolean this_present_created = true;
+		boolean that_present_created = true;
+		if (this_present_created || that_present_created) {
+			if (!(this_present_created && that_present_created))
+				return false;
+			if (this.created != that.created)
+				return false;
+		}
+
+		boolean this_present_points = true && this.isSetPoints();
+		boolean that_present_points = true && that.isSetPoints();
+		if (this_present_points || that_present_points) {
+			if (!(this_present_points && that_present_points))
+				return false;
+			if (!this.points.equals(that.points))
+				return false;
+		}
+
+		boolean this_present_tags = true && this.isSetTags();
+		boolean that_present_tags = true && that.isSetTags();
+		if (this_present_tags || that_present_tags) {
+			if (!(this_present_tags && that_present_tags))
+				return false;
+			if (!this.tags.equals(that.tags))
+				return false;
+		}
+
+		boolean this_present_layers = true && this.isSetLayers();
+		boolean that_present_layers = true && that.isSetLayers();
+		if (this_present_layers || that_present_layers) {
+			if (!(this_present_layers && that_present_layers))
+				return false;
+			if (!this.layers.equals(that.layers))
+				return false;
+		}
+
+		boolean this_present_palettes = true && this.isSetPalettes();
+		boolean that_present_palettes = true && that.isSetPalettes();
+		if (this_present_palettes || that_present_palettes) {
+			if (!(this_present_palettes && that_present_palettes))
+				return false;
+			if (!this.palettes.equals(that.palettes))
+				return false;
+		}
+
+		boolean this_present_labels = true && this.isSetLabels();
+		boolean that_present_labels = true && that.isSetLabels();
+		if (this_present_labels || that_present_labels) {
+			if (!(this_present_labels && that_present_labels))
+				return false;
+			if (!this.labels.equals(that.labels))
+				return false;
+		}
+
+		boolean this_present_outline = true && this.isSetOutline();
+		boolean that_present_outline = true && that.isSetOutline();
+		if (this_present_outline || that_present_outline) {
+			if (!(this_present_outline && that_present_outline))
+				return false;
+			if (!this.outline.equals(that.outline))
+				return false;
+		}
+
+		boolean this_present_origin = true && this.isSetOrigin();
+		boolean that_present_origin = true && that.isSetOrigin();
+		if (this_present_origin || that_present_origin) {
+			if (!(this_present_origin && that_present_origin))
+				return false;
+			if (!this.origin.equals(that.origin))
+				return false;
+		}
+
+		boolean this_present_history = true && this.isSetHistory();
+		boolean that_present_history = true && that.isSetHistory();
+		if (this_present_history || that_present_history) {
+			if (!(this_present_history && that_present_history))
+				return false;
+			if (!this.history.equals(that.history))
+				return false;
+		}
+
+		boolean this_present_version = true && this.isSetVersion();
+		boolean that_present_version = true && that.isSetVersion();
+		if (this_present_version || that_present_version) {
+			if (!(this_present_version && that_present_version))
+				return false;
+			if (this.version != that.version)
+				return false;
+		}
+
+		return true;
+	}
+
+	@Override
+	public int hashCode() {
+		List<Object> list = new ArrayList<Object>();
+
+		boolean present_name = true && (isSetName());
+		list.add(present_name);
+		if (present_name)
+			list.add(name);
+
+		boolean present_title = true && (isSetTitle());
+		list.add(present_title);
+		if (present_title)
+			list.add(title);
+
+		boolean present_thumbnail = true && (isSetThumbnail());
+		list.add(present_thumbnail);
+		if (present_thumbnail)
+			list.add(thumbnail);
+
+		boolean present_background = true && (isSetBackground());
+		list.add(present_background);
+		if (present_background)
+			list.add(background.getValue());
+
+		boolean present_created = true;
+		list.add(present_created);
+		if (present_created)
+			list.add(created);
+
+		boolean present_points = true && (isSetPoints());
+		list.add(present_points);
+		if (present_points)
+			list.add(points);
+
+		boolean present_tags = true && (isSetTags());
+		list.add(present_tags);
+		if (present_tags)
+			list.add(tags);
+
+		boolean present_layers = true && (isSetLayers());
+		list.add(present_layers);
+		if (present_layers)
+			list.add(layers);
+
+		boolean present_palettes = true && (isSetPalettes());
+		list.add(present_palettes);
+		if (present_palettes)
+			list.add(palettes);
+
+		boolean present_labels = true && (isSetLabels());
+		list.add(present_labels);
+		if (present_labels)
+			list.add(labels);
+
+		boolean present_outline = true && (isSetOutline());
+		list.add(present_outline);
+		if (present_outline)
+			list.add(outline);
+
+		boolean present_origin = true && (isSetOrigin());
+		list.add(present_origin);
+		if (present_origin)
+			list.add(origin);
+
+		boolean present_history = true && (isSetHistory());
+		list.add(present_history);
+		if (present_history)
+			list.add(history);
+
+		boolean present_version = true && (isSetVersion());
+		list.add(present_version);
+		if (present_version)
+			list.add(version);
+
+		return list.hashCode();
+	}
+
+	@Override
+	public int compareTo(Drawing other) {
+		if (!getClass().equals(other.getClass())) {
+			return getClass().getName().compareTo(other.getClass().getName());
+		}
+
+		int lastComparison = 0;
+
+		lastComparison = Boolean.valueOf(isSetName()).compareTo(other.isSetName());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetName()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.name, other.name);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetTitle()).compareTo(other.isSetTitle());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetTitle()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.title, other.title);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetThumbnail()).compareTo(other.isSetThumbnail());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetThumbnail()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.thumbnail, other.thumbnail);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetBackground()).compareTo(other.isSetBackground());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetBackground()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.background, other.background);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetCreated()).compareTo(other.isSetCreated());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetCreated()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.created, other.created);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetPoints()).compareTo(other.isSetPoints());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetPoints()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.points, other.points);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetTags()).compareTo(other.isSetTags());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetTags()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.tags, other.tags);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetLayers()).compareTo(other.isSetLayers());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetLayers()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.layers, other.layers);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetPalettes()).compareTo(other.isSetPalettes());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetPalettes()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.palettes, other.palettes);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetLabels()).compareTo(other.isSetLabels());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetLabels()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.labels, other.labels);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetOutline()).compareTo(other.isSetOutline());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetOutline()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.outline, other.outline);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetOrigin()).compareTo(other.isSetOrigin());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetOrigin()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.origin, other.origin);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetHistory()).compareTo(other.isSetHistory());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetHistory()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.history, other.history);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetVersion()).compareTo(other.isSetVersion());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetVersion()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.version, other.version);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		return 0;
+	}
+
+	public _Fields fieldForId(int fieldId) {
+		return _Fields.findByThriftId(fieldId);
+	}
+
+	public void read(org.apache.thrift.protocol.TProtocol iprot) throws org.apache.thrift.TException {
+		schemes.get(iprot.getScheme()).getScheme().read(iprot, this);
+	}
+
+	public void write(org.apache.thrift.protocol.TProtocol oprot) throws org.apache.thrift.TException {
+		schemes.get(oprot.getScheme()).getScheme().write(oprot, this);
+	}
+
+	@Override
+	public String toString() {
+		StringBuilder sb = new StringBuilder("Drawing(");
+		boolean first = true;
+
+		sb.append("name:");
+		if (this.name == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.name);
+		}
+		first = false;
+		if (isSetTitle()) {
+			if (!first) sb.append(", ");
+			sb.append("title:");
+			if (this.title == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.title);
+			}
+			first = false;
+		}
+		if (!first) sb.append(", ");
+		sb.append("thumbnail:");
+		if (this.thumbnail == null) {
+			sb.append("null");
+		} else {
+			org.apache.thrift.TBaseHelper.toString(this.thumbnail, sb);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("background:");
+		if (this.background == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.background);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("created:");
+		sb.append(this.created);
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("points:");
+		if (this.points == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.points);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("tags:");
+		if (this.tags == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.tags);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("layers:");
+		if (this.layers == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.layers);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("palettes:");
+		if (this.palettes == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.palettes);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("labels:");
+		if (this.labels == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.labels);
+		}
+		first = false;
+		if (isSetOutline()) {
+			if (!first) sb.append(", ");
+			sb.append("outline:");
+			if (this.outline == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.outline);
+			}
+			first = false;
+		}
+		if (!first) sb.append(", ");
+		sb.append("origin:");
+		if (this.origin == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.origin);
+		}
+		first = false;
+		if (isSetHistory()) {
+			if (!first) sb.append(", ");
+			sb.append("history:");
+			if (this.history == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.history);
+			}
+			first = false;
+		}
+		if (isSetVersion()) {
+			if (!first) sb.append(", ");
+			sb.append("version:");
+			sb.append(this.version);
+			first = false;
+		}
+		sb.append(")");
+		return sb.toString();
+	}
+
+	public void validate() throws org.apache.thrift.TException {
+		// check for required fields
+		if (name == null) {
+			throw new org.apache.thrift.protocol.TProtocolException("Required field 'name' is not present in struct 'Drawing'");
+		}
+		// check for sub-struct validity
+		if (origin != null) {
+			origin.validate();
+		}
+	}
+
+	/**
+	 * Returns the JSON encoding of the Drawing.
+	 */
+	public String toJson() {
+		return toJsonValue().toString();
+	}
+
+	/**
+	 * Returns the value encoded by toJson.
+	 */
+	public com.google.gson.JsonObject toJsonValue() {
+		com.google.gson.JsonObject value = new com.google.gson.JsonObject();
+		if (isSetName()) {
+			value.add("name", new com.google.gson.JsonPrimitive(this.name));
+		}
+		if (isSetTitle()) {
+			value.add("title", new com.google.gson.JsonPrimitive(this.title));
+		}
+		if (isSetThumbnail()) {
+			value.add("thumbnail", new com.google.gson.JsonPrimitive(java.util.Base64.getEncoder().encodeToString(org.apache.thrift.TBaseHelper.byteBufferToByteArray(this.thumbnail))));
+		}
+		if (isSetBackground()) {
+			value.add("background", new com.google.gson.JsonPrimitive(this.background.name()));
+		}
+		value.add("created", new com.google.gson.JsonPrimitive(this.created));
+		if (isSetPoints()) {
+			com.google.gson.JsonArray v1 = new com.google.gson.JsonArray();
+			for (Point elem1 : this.points) {
+				v1.add(elem1.toJsonValue());
+			}
+			value.add("points", v1);
+		}
+		if (isSetTags()) {
+			com.google.gson.JsonArray v1 = new com.google.gson.JsonArray();
+			for (String elem1 : this.tags) {
+				v1.add(new com.google.gson.JsonPrimitive(elem1));
+			}
+			value.add("tags", v1);
+		}
+		if (isSetLayers()) {
+			com.google.gson.JsonObject v1 = new com.google.gson.JsonObject();
+			for (Map.Entry<Integer, Point> entry1 : this.layers.entrySet()) {
+				v1.add(String.valueOf(entry1.getKey()), entry1.getValue().toJsonValue());
+			}
+			value.add("layers", v1);
+		}
+		if (isSetPalettes()) {
+			com.google.gson.JsonObject v1 = new com.google.gson.JsonObject();
+			for (Map.Entry<Color, java.util.List<Point>> entry1 : this.palettes.entrySet()) {
+				com.google.gson.JsonArray v3 = new com.google.gson.JsonArray();
+				for (Point elem3 : entry1.getValue()) {
+					v3.add(elem3.toJsonValue());
+				}
+				v1.add(entry1.getKey().name(), v3);
+			}
+			value.add("palettes", v1);
+		}
+		if (isSetLabels()) {
+			com.google.gson.JsonArray v1 = new com.google.gson.JsonArray();
+			for (Map.Entry<Point, String> entry1 : this.labels.entrySet()) {
+				com.google.gson.JsonArray pair1 = new com.google.gson.JsonArray();
+				pair1.add(entry1.getKey().toJsonValue());
+				pair1.add(new com.google.gson.JsonPrimitive(entry1.getValue()));
+				v1.add(pair1);
+			}
+			value.add("labels", v1);
+		}
+		if (isSetOutline()) {
+			value.add("outline", this.outline.toJsonValue());
+		}
+		if (isSetOrigin()) {
+			value.add("origin", this.origin.toJsonValue());
+		}
+		if (isSetHistory()) {
+			com.google.gson.JsonArray v1 = new com.google.gson.JsonArray();
+			for (java.util.Set<Color> elem1 : this.history) {
+				com.google.gson.JsonArray v2 = new com.google.gson.JsonArray();
+				for (Color elem2 : elem1) {
+					v2.add(new com.google.gson.JsonPrimitive(elem2.name()));
+				}
+				v1.add(v2);
+			}
+			value.add("history", v1);
+		}
+		if (isSetVersion()) {
+			value.add("version", new com.google.gson.JsonPrimitive(this.version));
+		}
+		return value;
+	}
+
+	/**
+	 * Sets the fields of the Drawing from the JSON encoding produced by
+	 * toJson. Fields missing from the JSON are left unchanged.
+	 */
+	public void fromJson(String json) throws TException {
+		com.google.gson.JsonElement value;
+		try {
+			value = new com.google.gson.JsonParser().parse(json);
+		} catch (com.google.gson.JsonParseException e) {
+			throw new TProtocolException(TProtocolException.INVALID_DATA, e.getMessage());
+		}
+		fromJsonValue(value);
+	}
+
+	/**
+	 * Sets the fields of the Drawing from the value returned by toJsonValue.
+	 */
+	public void fromJsonValue(com.google.gson.JsonElement value) throws TException {
+		if (!value.isJsonObject()) {
+			throw new TProtocolException(TProtocolException.INVALID_DATA, "Drawing JSON must be an object");
+		}
+		com.google.gson.JsonObject fields = value.getAsJsonObject();
+		if (fields.has("name")) {
+			try {
+				String v = fields.get("name").getAsString();
+				setName(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field name: " + e.getMessage());
+			}
+		}
+		if (fields.has("title")) {
+			try {
+				String v = fields.get("title").getAsString();
+				setTitle(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field title: " + e.getMessage());
+			}
+		}
+		if (fields.has("thumbnail")) {
+			try {
+				java.nio.ByteBuffer v = ByteBuffer.wrap(java.util.Base64.getDecoder().decode(fields.get("thumbnail").getAsString()));
+				setThumbnail(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field thumbnail: " + e.getMessage());
+			}
+		}
+		if (fields.has("background")) {
+			try {
+				Color v = Color.valueOf(fields.get("background").getAsString());
+				setBackground(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field background: " + e.getMessage());
+			}
+		}
+		if (fields.has("created")) {
+			try {
+				long v = fields.get("created").getAsLong();
+				setCreated(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field created: " + e.getMessage());
+			}
+		}
+		if (fields.has("points")) {
+			try {
+				java.util.List<Point> v = new ArrayList<Point>();
+				for (com.google.gson.JsonElement json1 : fields.get("points").getAsJsonArray()) {
+					Point elem1 = new Point();
+					elem1.fromJsonValue(json1);
+					v.add(elem1);
+				}
+				setPoints(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field points: " + e.getMessage());
+			}
+		}
+		if (fields.has("tags")) {
+			try {
+				java.util.Set<String> v = new HashSet<String>();
+				for (com.google.gson.JsonElement json1 : fields.get("tags").getAsJsonArray()) {
+					String elem1 = json1.getAsString();
+					v.add(elem1);
+				}
+				setTags(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field tags: " + e.getMessage());
+			}
+		}
+		if (fields.has("layers")) {
+			try {
+				java.util.Map<Integer, Point> v = new HashMap<Integer, Point>();
+				for (Map.Entry<String, com.google.gson.JsonElement> entry1 : fields.get("layers").getAsJsonObject().entrySet()) {
+					int key1 = Integer.parseInt(entry1.getKey());
+					Point val1 = new Point();
+					val1.fromJsonValue(entry1.getValue());
+					v.put(key1, val1);
+				}
+				setLayers(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field layers: " + e.getMessage());
+			}
+		}
+		if (fields.has("palettes")) {
+			try {
+				java.util.Map<Color, java.util.List<Point>> v = new HashMap<Color, java.util.List<Point>>();
+				for (Map.Entry<String, com.google.gson.JsonElement> entry1 : fields.get("palettes").getAsJsonObject().entrySet()) {
+					Color key1 = Color.valueOf(entry1.getKey());
+					java.util.List<Point> val1 = new ArrayList<Point>();
+					for (com.google.gson.JsonElement json3 : entry1.getValue().getAsJsonArray()) {
+						Point elem3 = new Point();
+						elem3.fromJsonValue(json3);
+						val1.add(elem3);
+					}
+					v.put(key1, val1);
+				}
+				setPalettes(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field palettes: " + e.getMessage());
+			}
+		}
+		if (fields.has("labels")) {
+			try {
+				java.util.Map<Point, String> v = new HashMap<Point, String>();
+				for (com.google.gson.JsonElement json1 : fields.get("labels").getAsJsonArray()) {
+					com.google.gson.JsonArray pair1 = json1.getAsJsonArray();
+					Point key1 = new Point();
+					key1.fromJsonValue(pair1.get(0));
+					String val1 = pair1.get(1).getAsString();
+					v.put(key1, val1);
+				}
+				setLabels(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field labels: " + e.getMessage());
+			}
+		}
+		if (fields.has("outline")) {
+			try {
+				Shape v = new Shape();
+				v.fromJsonValue(fields.get("outline"));
+				setOutline(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field outline: " + e.getMessage());
+			}
+		}
+		if (fields.has("origin")) {
+			try {
+				actual_base.java.thing v = new actual_base.java.thing();
+				v.fromJsonValue(fields.get("origin"));
+				setOrigin(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field origin: " + e.getMessage());
+			}
+		}
+		if (fields.has("history")) {
+			try {
+				java.util.List<java.util.Set<Color>> v = new ArrayList<java.util.Set<Color>>();
+				for (com.google.gson.JsonElement json1 : fields.get("history").getAsJsonArray()) {
+					java.util.Set<Color> elem1 = new HashSet<Color>();
+					for (com.google.gson.JsonElement json2 : json1.getAsJsonArray()) {
+						Color elem2 = Color.valueOf(json2.getAsString());
+						elem1.add(elem2);
+					}
+					v.add(elem1);
+				}
+				setHistory(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field history: " + e.getMessage());
+			}
+		}
+		if (fields.has("version")) {
+			try {
+				int v = fields.get("version").getAsInt();
+				setVersion(v);
+			} catch (TException | RuntimeException e) {
+				throw new TProtocolException(TProtocolException.INVALID_DATA, "error decoding field version: " + e.getMessage());
+			}
+		}
+	}
+
+	private void writeObject(java.io.ObjectOutputStream out) throws java.io.IOException {
+		try {
+			write(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(out)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private void readObject(java.io.ObjectInputStream in) throws java.io.IOException, ClassNotFoundException {
+		try {
+			// it doesn't seem like you should have to do this, but java serialization is wacky, and doesn't call the default constructor.
+			__isset_bitfield = 0;
+			read(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(in)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private static class DrawingStandardSchemeFactory implements SchemeFactory {
+		public DrawingStandardScheme getScheme() {
+			return new DrawingStandardScheme();
+		}
+	}
+
+	private static class DrawingStandardScheme extends StandardScheme<Drawing> {
+
+		public void read(org.apache.thrift.protocol.TProtocol iprot, Drawing struct) throws org.apache.thrift.TException {
+			org.apache.thrift.protocol.TField schemeField;
+			iprot.readStructBegin();
+			while (true) {
+				schemeField = iprot.readFieldBegin();
+				if (schemeField.type == org.apache.thrift.protocol.TType.STOP) {
+					break;
+				}
+				switch (schemeField.id) {
+					case 1: // NAME
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.name = iprot.readString();
+							struct.setNameIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 2: // TITLE
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.title = iprot.readString();
+							struct.setTitleIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 3: // THUMBNAIL
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.thumbnail = iprot.readBinary();
+							struct.setThumbnailIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 4: // BACKGROUND
+						if (schemeField.type == org.apache.thrift.protocol.TType.I32) {
+							struct.background = Color.findByValue(iprot.readI32());
+							struct.setBackgroundIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 5: // CREATED
+						if (schemeField.type == org.apache.thrift.protocol.TType.I64) {
+							struct.created = iprot.readI64();
+							struct.setCreatedIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 6: // POINTS
+						if (schemeField.type == org.apache.thrift.protocol.TType.LIST) {
+							org.apache.thrift.protocol.TList elem23 = iprot.readListBegin();
+							struct.points = new ArrayList<Point>(elem23.size);
+							for (int elem24 = 0; elem24 < elem23.size; ++elem24) {
+								Point elem25 = new Point();
+								elem25.read(iprot);
+								struct.points.add(elem25);
+							}
+							iprot.readListEnd();
+							struct.setPointsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 7: // TAGS
+						if (schemeField.type == org.apache.thrift.protocol.TType.SET) {
+							org.apache.thrift.protocol.TSet elem26 = iprot.readSetBegin();
+							struct.tags = new HashSet<String>(2*elem26.size);
+							for (int elem27 = 0; elem27 < elem26.size; ++elem27) {
+								String elem28 = iprot.readString();
+								struct.tags.add(elem28);
+							}
+							iprot.readSetEnd();
+							struct.setTagsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 8: // LAYERS
+						if (schemeField.type == org.apache.thrift.protocol.TType.MAP) {
+							org.apache.thrift.protocol.TMap elem29 = iprot.readMapBegin();
+							struct.layers = new HashMap<Integer,Point>(2*elem29.size);
+							for (int elem30 = 0; elem30 < elem29.size; ++elem30) {
+								int elem32 = iprot.readI32();
+								Point elem31 = new Point();
+								elem31.read(iprot);
+								struct.layers.put(elem32, elem31);
+							}
+							iprot.readMapEnd();
+							struct.setLayersIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 9: // PALETTES
+						if (schemeField.type == org.apache.thrift.protocol.TType.MAP) {
+							org.apache.thrift.protocol.TMap elem33 = iprot.readMapBegin();
+							struct.palettes = new HashMap<Color,java.util.List<Point>>(2*elem33.size);
+							for (int elem34 = 0; elem34 < elem33.size; ++elem34) {
+								Color elem39 = Color.findByValue(iprot.readI32());
+								org.apache.thrift.protocol.TList elem36 = iprot.readListBegin();
+								java.util.List<Point> elem35 = new ArrayList<Point>(elem36.size);
+								for (int elem37 = 0; elem37 < elem36.size; ++elem37) {
+									Point elem38 = new Point();
+									elem38.read(iprot);
+									elem35.add(elem38);
+								}
+								iprot.readListEnd();
+								struct.palettes.put(elem39, elem35);
+							}
+							iprot.readMapEnd();
+							struct.setPalettesIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 10: // LABELS
+						if (schemeField.type == org.apache.thrift.protocol.TType.MAP) {
+							org.apache.thrift.protocol.TMap elem40 = iprot.readMapBegin();
+							struct.labels = new HashMap<Point,String>(2*elem40.size);
+							for (int elem41 = 0; elem41 < elem40.size; ++elem41) {
+								Point elem43 = new Point();
+								elem43.read(iprot);
+								String elem42 = iprot.readString();
+								struct.labels.put(elem43, elem42);
+							}
+							iprot.readMapEnd();
+							struct.setLabelsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 11: // OUTLINE
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRUCT) {
+							struct.outline = new Shape();
+							struct.outline.read(iprot);
+							struct.setOutlineIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 12: // ORIGIN
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRUCT) {
+							struct.origin = new actual_base.java.thing();
+							struct.origin.read(iprot);
+							struct.setOriginIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 13: // HISTORY
+						if (schemeField.type == org.apache.thrift.protocol.TType.LIST) {
+							org.apache.thrift.protocol.TList elem44 = iprot.readListBegin();
+							struct.history = new ArrayList<java.util.Set<Color>>(elem44.size);
+							for (int elem45 = 0; elem45 < elem44.size; ++elem45) {
+								org.apache.thrift.protocol.TSet elem47 = iprot.readSetBegin();
+								java.util.Set<Color> elem46 = new HashSet<Color>(2*elem47.size);
+								for (int elem48 = 0; elem48 < elem47.size; ++elem48) {
+									Color elem49 = Color.findByValue(iprot.readI32());
+									elem46.add(elem49);
+								}
+								iprot.readSetEnd();
+								struct.history.add(elem46);
+							}
+							iprot.readListEnd();
+							struct.setHistoryIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 14: // VERSION
+						if (schemeField.type == org.apache.thrift.protocol.TType.I32) {
+							struct.version = iprot.readI32();
+							struct.setVersionIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					default:
+						org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+				}
+				iprot.readFieldEnd();
+			}
+			iprot.readStructEnd();
+
+			// check for required fields of primitive type, which can't be checked in the validate method
+			struct.validate();
+		}
+
+		public void write(org.apache.thrift.protocol.TProtocol oprot, Drawing struct) throws org.apache.thrift.TException {
+			struct.validate();
+
+			oprot.writeStructBegin(STRUCT_DESC);
+			if (struct.name != null) {
+				oprot.writeFieldBegin(NAME_FIELD_DESC);
+				String elem50 = struct.name;
+				oprot.writeString(elem50);
+				oprot.writeFieldEnd();
+			}
+			if (struct.title != null) {
+				if (struct.isSetTitle()) {
+					oprot.writeFieldBegin(TITLE_FIELD_DESC);
+					String elem51 = struct.title;
+					oprot.writeString(elem51);
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.thumbnail != null) {
+				oprot.writeFieldBegin(THUMBNAIL_FIELD_DESC);
+				java.nio.ByteBuffer elem52 = struct.thumbnail;
+				oprot.writeBinary(elem52);
+				oprot.writeFieldEnd();
+			}
+			if (struct.background != null) {
+				oprot.writeFieldBegin(BACKGROUND_FIELD_DESC);
+				Color elem53 = struct.background;
+				oprot.writeI32(elem53.getValue());
+				oprot.writeFieldEnd();
+			}
+			oprot.writeFieldBegin(CREATED_FIELD_DESC);
+			long elem54 = struct.created;
+			oprot.writeI64(elem54);
+			oprot.writeFieldEnd();
+			if (struct.points != null) {
+				oprot.writeFieldBegin(POINTS_FIELD_DESC);
+				oprot.writeListBegin(new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRUCT, struct.points.size()));
+				for (Point elem55 : struct.points) {
+					elem55.write(oprot);
+				}
+				oprot.writeListEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.tags != null) {
+				oprot.writeFieldBegin(TAGS_FIELD_DESC);
+				oprot.writeSetBegin(new org.apache.thrift.protocol.TSet(org.apache.thrift.protocol.TType.STRING, struct.tags.size()));
+				for (String elem56 : struct.tags) {
+					String elem57 = elem56;
+					oprot.writeString(elem57);
+				}
+				oprot.writeSetEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.layers != null) {
+				oprot.writeFieldBegin(LAYERS_FIELD_DESC);
+				oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.I32, org.apache.thrift.protocol.TType.STRUCT, struct.layers.size()));
+				for (Map.Entry<Integer, Point> elem58 : struct.layers.entrySet()) {
+					int elem59 = elem58.getKey();
+					oprot.writeI32(elem59);
+					elem58.getValue().write(oprot);
+				}
+				oprot.writeMapEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.palettes != null) {
+				oprot.writeFieldBegin(PALETTES_FIELD_DESC);
+				oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.I32, org.apache.thrift.protocol.TType.LIST, struct.palettes.size()));
+				for (Map.Entry<Color, java.util.List<Point>> elem60 : struct.palettes.entrySet()) {
+					Color elem61 = elem60.getKey();
+					oprot.writeI32(elem61.getValue());
+					oprot.writeListBegin(new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRUCT, elem60.getValue().size()));
+					for (Point elem62 : elem60.getValue()) {
+						elem62.write(oprot);
+					}
+					oprot.writeListEnd();
+				}
+				oprot.writeMapEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.labels != null) {
+				oprot.writeFieldBegin(LABELS_FIELD_DESC);
+				oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRUCT, org.apache.thrift.protocol.TType.STRING, struct.labels.size()));
+				for (Map.Entry<Point, String> elem63 : struct.labels.entrySet()) {
+					elem63.getKey().write(oprot);
+					String elem64 = elem63.getValue();
+					oprot.writeString(elem64);
+				}
+				oprot.writeMapEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.outline != null) {
+				if (struct.isSetOutline()) {
+					oprot.writeFieldBegin(OUTLINE_FIELD_DESC);
+					struct.outline.write(oprot);
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.origin != null) {
+				oprot.writeFieldBegin(ORIGIN_FIELD_DESC);
+				struct.origin.write(oprot);
+				oprot.writeFieldEnd();
+			}
+			if (struct.history != null) {
+				if (struct.isSetHistory()) {
+					oprot.writeFieldBegin(HISTORY_FIELD_DESC);
+					oprot.writeListBegin(new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.SET, struct.history.size()));
+					for (java.util.Set<Color> elem65 : struct.history) {
+						oprot.writeSetBegin(new org.apache.thrift.protocol.TSet(org.apache.thrift.protocol.TType.I32, elem65.size()));
+						for (Color elem66 : elem65) {
+							Color elem67 = elem66;
+							oprot.writeI32(elem67.getValue());
+						}
+						oprot.writeSetEnd();
+					}
+					oprot.writeListEnd();
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.isSetVersion()) {
+				oprot.writeFieldBegin(VERSION_FIELD_DESC);
+				int elem68 = struct.version;
+				oprot.writeI32(elem68);
+				oprot.writeFieldEnd();
+			}
+			oprot.writeFieldStop();
+			oprot.writeStructEnd();
+		}
+
+	}
+
+	private static class DrawingTupleSchemeFactory implements SchemeFactory {
+		public DrawingTupleScheme getScheme() {
+			return new DrawingTupleScheme();
+		}
+	}
+
+	private static class DrawingTupleScheme extends TupleScheme<Drawing> {
+
+		@Override
+		public void write(org.apache.thrift.protocol.TProtocol prot, Drawing struct) throws org.apache.thrift.TException {
+			TTupleProtocol oprot = (TTupleProtocol) prot;
+			String elem69 = struct.name;
+			oprot.writeString(elem69);
+			BitSet optionals = new BitSet();
+			if (struct.isSetTitle()) {
+				optionals.set(0);
+			}
+			if (struct.isSetThumbnail()) {
+				optionals.set(1);
+			}
+			if (struct.isSetBackground()) {
+				optionals.set(2);
+			}
+			if (struct.isSetCreated()) {
+				optionals.set(3);
+			}
+			if (struct.isSetPoints()) {
+				optionals.set(4);
+			}
+			if (struct.isSetTags()) {
+				optionals.set(5);
+			}
+			if (struct.isSetLayers()) {
+				optionals.set(6);
+			}
+			if (struct.isSetPalettes()) {
+				optionals.set(7);
+			}
+			if (struct.isSetLabels()) {
+				optionals.set(8);
+			}
+			if (struct.isSetOutline()) {
+				optionals.set(9);
+			}
+			if (struct.isSetOrigin()) {
+				optionals.set(10);
+			}
+			if (struct.isSetHistory()) {
+				optionals.set(11);
+			}
+			if (struct.isSetVersion()) {
+				optionals.set(12);
+			}
+			oprot.writeBitSet(optionals, 13);
+			if (struct.isSetTitle()) {
+				String elem70 = struct.title;
+				oprot.writeString(elem70);
+			}
+			if (struct.isSetThumbnail()) {
+				java.nio.ByteBuffer elem71 = struct.thumbnail;
+				oprot.writeBinary(elem71);
+			}
+			if (struct.isSetBackground()) {
+				Color elem72 = struct.background;
+				oprot.writeI32(elem72.getValue());
+			}
+			if (struct.isSetCreated()) {
+				long elem73 = struct.created;
+				oprot.writeI64(elem73);
+			}
+			if (struct.isSetPoints()) {
+				oprot.writeI32(struct.points.size());
+				for (Point elem74 : struct.points) {
+					elem74.write(oprot);
+				}
+			}
+			if (struct.isSetTags()) {
+				oprot.writeI32(struct.tags.size());
+				for (String elem75 : struct.tags) {
+					String elem76 = elem75;
+					oprot.writeString(elem76);
+				}
+			}
+			if (struct.isSetLayers()) {
+				oprot.writeI32(struct.layers.size());
+				for (Map.Entry<Integer, Point> elem77 : struct.layers.entrySet()) {
+					int elem78 = elem77.getKey();
+					oprot.writeI32(elem78);
+					elem77.getValue().write(oprot);
+				}
+			}
+			if (struct.isSetPalettes()) {
+				oprot.writeI32(struct.palettes.size());
+				for (Map.Entry<Color, java.util.List<Point>> elem79 : struct.palettes.entrySet()) {
+					Color elem80 = elem79.getKey();
+					oprot.writeI32(elem80.getValue());
+					oprot.writeI32(elem79.getValue().size());
+					for (Point elem81 : elem79.getValue()) {
+						elem81.write(oprot);
+					}
+				}
+			}
+			if (struct.isSetLabels()) {
+				oprot.writeI32(struct.labels.size());
+				for (Map.Entry<Point, String> elem82 : struct.labels.entrySet()) {
+					elem82.getKey().write(oprot);
+					String elem83 = elem82.getValue();
+					oprot.writeString(elem83);
+				}
+			}
+			if (struct.isSetOutline()) {
+				struct.outline.write(oprot);
+			}
+			if (struct.isSetOrigin()) {
+				struct.origin.write(oprot);
+			}
+			if (struct.isSetHistory()) {
+				oprot.writeI32(struct.history.size());
+				for (java.util.Set<Color> elem84 : struct.history) {
+					oprot.writeI32(elem84.size());
+					for (Color elem85 : elem84) {
+						Color elem86 = elem85;
+						oprot.writeI32(elem86.getValue());
+					}
+				}
+			}
+			if (struct.isSetVersion()) {
+				int elem87 = struct.version;
+				oprot.writeI32(elem87);
+			}
+		}
+
+		@Override
+		public void read(org.apache.thrift.protocol.TProtocol prot, Drawing struct) throws org.apache.thrift.TException {
+			TTupleProtocol iprot = (TTupleProtocol) prot;
+			struct.name = iprot.readString();
+			struct.setNameIsSet(true);
+			BitSet incoming = iprot.readBitSet(13);
+			if (incoming.get(0)) {
+				struct.title = iprot.readString();
+				struct.setTitleIsSet(true);
+			}
+			if (incoming.get(1)) {
+				struct.thumbnail = iprot.readBinary();
+				struct.setThumbnailIsSet(true);
+			}
+			if (incoming.get(2)) {
+				struct.background = Color.findByValue(iprot.readI32());
+				struct.setBackgroundIsSet(true);
+			}
+			if (incoming.get(3)) {
+				struct.created = iprot.readI64();
+				struct.setCreatedIsSet(true);
+			}
+			if (incoming.get(4)) {
+				org.apache.thrift.protocol.TList elem88 = new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRUCT, iprot.readI32());
+				struct.points = new ArrayList<Point>(elem88.size);
+				for (int elem89 = 0; elem89 < elem88.size; ++elem89) {
+					Point elem90 = new Point();
+					elem90.read(iprot);
+					struct.points.add(elem90);
+				}
+				struct.setPointsIsSet(true);
+			}
+			if (incoming.get(5)) {
+				org.apache.thrift.protocol.TSet elem91 = new org.apache.thrift.protocol.TSet(org.apache.thrift.protocol.TType.STRING, iprot.readI32());
+				struct.tags = new HashSet<String>(2*elem91.size);
+				for (int elem92 = 0; elem92 < elem91.size; ++elem92) {
+					String elem93 = iprot.readString();
+					struct.tags.add(elem93);
+				}
+				struct.setTagsIsSet(true);
+			}
+			if (incoming.get(6)) {
+				org.apache.thrift.protocol.TMap elem94 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.I32, org.apache.thrift.protocol.TType.STRUCT, iprot.readI32());
+				struct.layers = new HashMap<Integer,Point>(2*elem94.size);
+				for (int elem95 = 0; elem95 < elem94.size; ++elem95) {
+					int elem97 = iprot.readI32();
+					Point elem96 = new Point();
+					elem96.read(iprot);
+					struct.layers.put(elem97, elem96);
+				}
+				struct.setLayersIsSet(true);
+			}
+			if (incoming.get(7)) {
+				org.apache.thrift.protocol.TMap elem98 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.I32, org.apache.thrift.protocol.TType.LIST, iprot.readI32());
+				struct.palettes = new HashMap<Color,java.util.List<Point>>(2*elem98.size);
+				for (int elem99 = 0; elem99 < elem98.size; ++elem99) {
+					Color elem104 = Color.findByValue(iprot.readI32());
+					org.apache.thrift.protocol.TList elem101 = new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRUCT, iprot.readI32());
+					java.util.List<Point> elem100 = new ArrayList<Point>(elem101.size);
+					for (int elem102 = 0; elem102 < elem101.size; ++elem102) {
+						Point elem103 = new Point();
+						elem103.read(iprot);
+						elem100.add(elem103);
+					}
+					struct.palettes.put(elem104, elem100);
+				}
+				struct.setPalettesIsSet(true);
+			}
+			if (incoming.get(8)) {
+				org.apache.thrift.protocol.TMap elem105 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRUCT, org.apache.thrift.protocol.TType.STRING, iprot.readI32());
+				struct.labels = new HashMap<Point,String>(2*elem105.size);
+				for (int elem106 = 0; elem106 < elem105.size; ++elem106) {
+					Point elem108 = new Point();
+					elem108.read(iprot);
+					String elem107 = iprot.readString();
+					struct.labels.put(elem108, elem107);
+				}
+				struct.setLabelsIsSet(true);
+			}
+			if (incoming.get(9)) {
+				struct.outline = new Shape();
+				struct.outline.read(iprot);
+				struct.setOutlineIsSet(true);
+			}
+			if (incoming.get(10)) {
+				struct.origin = new actual_base.java.thing();
+				struct.origin.read(iprot);
+				struct.setOriginIsSet(true);
+			}
+			if (incoming.get(11)) {
+				org.apache.thrift.protocol.TList elem109 = new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.SET, iprot.readI32());
+				struct.history = new ArrayList<java.util.Set<Color>>(elem109.size);
+				for (int elem110 = 0; elem110 < elem109.size; ++elem110) {
+					org.apache.thrift.protocol.TSet elem112 = new org.apache.thrift.protocol.TSet(org.apache.thrift.protocol.TType.I32, iprot.readI32());
+					java.util.Set<Color> elem111 = new HashSet<Color>(2*elem112.size);
+					for (int elem113 = 0; elem113 < elem112.size; ++elem113) {
+						Color elem114 = Color.findByValue(iprot.readI32());
+						elem111.add(elem114);
+					}
+					struct.history.add(elem111);
+				}
+				struct.setHistoryIsSet(true);
+			}
+			if (incoming.get(12)) {
+				struct.version = iprot.readI32();
+				struct.setVersionIsSet(true);
+			}
+		}
+
+	}
+
+}
@@ -0,0 +1,721 @@
+#
+# Autogenerated by Frugal Compiler (2.23.0)
+#
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+#
+
+from thrift.Thrift import TType, TMessageType, TException, TApplicationException
+import actual_base.python.ttypes
+import actual_base.python.constants
+
+from frugal.util import make_hashable
+from thrift.transport import TTransport
+from thrift.protocol import TBinaryProtocol, TProtocol
+import base64
+import json
+
+
+class Color(int):
+    RED = 1
+    GREEN = 2
+
+    _VALUES_TO_NAMES = {
+        1: "RED",
+        2: "GREEN",
+    }
+
+    _NAMES_TO_VALUES = {
+        "RED": 1,
+        "GREEN": 2,
+    }
+
+class Point(object):
+    """
+    Attributes:
+     - x
+     - y
+    """
+    def __init__(self, x=None, y=None):
+        self.x = x
+        self.y = y
+
+    def to_json(self):
+        """
+        Returns the JSON encoding of the Point.
+        """
+        return json.dumps(self.to_json_value())
+
+    def to_json_value(self):
+        """
+        Returns the dict encoded by to_json.
+        """
+        value = {}
+        if self.x is not None:
+            value['x'] = self.x
+        if self.y is not None:
+            value['y'] = self.y
+        return value
+
+    def from_json(self, data):
+        """
+        Sets the fields of the Point from the JSON encoding produced by
+        to_json and returns it. Fields missing from the JSON are left unchanged.
+        """
+        try:
+            value = json.loads(data)
+        except ValueError as e:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message=str(e))
+        return self.from_json_value(value)
+
+    def from_json_value(self, value):
+        """
+        Sets the fields of the Point from the dict returned by
+        to_json_value and returns it.
+        """
+        if not isinstance(value, dict):
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='Point JSON must be an object')
+        if 'x' in value:
+            try:
+                self.x = value['x']
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field x: {}'.format(e))
+        if 'y' in value:
+            try:
+                self.y = value['y']
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field y: {}'.format(e))
+        return self
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.I32:
+                    self.x = iprot.readI32()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 2:
+                if ftype == TType.I32:
+                    self.y = iprot.readI32()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('Point')
+        if self.x is not None:
+            oprot.writeFieldBegin('x', TType.I32, 1)
+            oprot.writeI32(self.x)
+            oprot.writeFieldEnd()
+        if self.y is not None:
+            oprot.writeFieldBegin('y', TType.I32, 2)
+            oprot.writeI32(self.y)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.x))
+        value = (value * 31) ^ hash(make_hashable(self.y))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
+class Drawing(object):
+    """
+    Attributes:
+     - name
+     - title
+     - thumbnail
+     - background
+     - created
+     - points
+     - tags
+     - layers
+     - palettes
+     - labels
+     - outline
+     - origin
+     - history
+     - version
+    """
+    _DEFAULT_version_MARKER = 1
+    def __init__(self, name=None, title=None, thumbnail=None, background=None, created=None, points=None, tags=None, layers=None, palettes=None, labels=None, outline=None, origin=None, history=None, version=_DEFAULT_version_MARKER):
+        self.name = name
+        self.title = title
+        self.thumbnail = thumbnail
+        self.background = background
+        self.created = created
+        self.points = points
+        self.tags = tags
+        self.layers = layers
+        self.palettes = palettes
+        self.labels = labels
+        self.outline = outline
+        self.origin = origin
+        self.history = history
+        self.version = version
+
+    def is_set_title(self):
+        return self.title is not None
+
+    def unset_title(self):
+        self.title = None
+
+    def is_set_outline(self):
+        return self.outline is not None
+
+    def unset_outline(self):
+        self.outline = None
+
+    def is_set_history(self):
+        return self.history is not None
+
+    def unset_history(self):
+        self.history = None
+
+    def is_set_version(self):
+        return self.version is not None
+
+    def unset_version(self):
+        self.version = None
+
+    def to_json(self):
+        """
+        Returns the JSON encoding of the Drawing.
+        """
+        return json.dumps(self.to_json_value())
+
+    def to_json_value(self):
+        """
+        Returns the dict encoded by to_json.
+        """
+        value = {}
+        if self.name is not None:
+            value['name'] = self.name
+        if self.title is not None:
+            value['title'] = self.title
+        if self.thumbnail is not None:
+            value['thumbnail'] = base64.b64encode(self.thumbnail).decode('ascii')
+        if self.background is not None:
+            value['background'] = Color._VALUES_TO_NAMES[self.background]
+        if self.created is not None:
+            value['created'] = self.created
+        if self.points is not None:
+            value['points'] = [elem1.to_json_value() for elem1 in self.points]
+        if self.tags is not None:
+            value['tags'] = list(self.tags)
+        if self.layers is not None:
+            value['layers'] = {str(key1): elem1.to_json_value() for key1, elem1 in self.layers.items()}
+        if self.palettes is not None:
+            value['palettes'] = {Color._VALUES_TO_NAMES[key1]: [elem2.to_json_value() for elem2 in elem1] for key1, elem1 in self.palettes.items()}
+        if self.labels is not None:
+            value['labels'] = [[key1.to_json_value(), elem1] for key1, elem1 in self.labels.items()]
+        if self.outline is not None:
+            value['outline'] = self.outline.to_json_value()
+        if self.origin is not None:
+            value['origin'] = self.origin.to_json_value()
+        if self.history is not None:
+            value['history'] = [[Color._VALUES_TO_NAMES[elem2] for elem2 in elem1] for elem1 in self.history]
+        if self.version is not None:
+            value['version'] = self.version
+        return value
+
+    def from_json(self, data):
+        """
+        Sets the fields of the Drawing from the JSON encoding produced by
+        to_json and returns it. Fields missing from the JSON are left unchanged.
+        """
+        try:
+            value = json.loads(data)
+        except ValueError as e:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message=str(e))
+        return self.from_json_value(value)
+
+    def from_json_value(self, value):
+        """
+        Sets the fields of the Drawing from the dict returned by
+        to_json_value and returns it.
+        """
+        if not isinstance(value, dict):
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='Drawing JSON must be an object')
+        if 'name' in value:
+            try:
+                self.name = value['name']
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field name: {}'.format(e))
+        if 'title' in value:
+            try:
+                self.title = value['title']
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field title: {}'.format(e))
+        if 'thumbnail' in value:
+            try:
+                self.thumbnail = base64.b64decode(value['thumbnail'])
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field thumbnail: {}'.format(e))
+        if 'background' in value:
+            try:
+                self.background = Color._NAMES_TO_VALUES[value['background']]
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field background: {}'.format(e))
+        if 'created' in value:
+            try:
+                self.created = value['created']
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field created: {}'.format(e))
+        if 'points' in value:
+            try:
+                self.points = [Point().from_json_value(elem1) for elem1 in value['points']]
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field points: {}'.format(e))
+        if 'tags' in value:
+            try:
+                self.tags = set(value['tags'])
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field tags: {}'.format(e))
+        if 'layers' in value:
+            try:
+                self.layers = {int(key1): Point().from_json_value(elem1) for key1, elem1 in value['layers'].items()}
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field layers: {}'.format(e))
+        if 'palettes' in value:
+            try:
+                self.palettes = {Color._NAMES_TO_VALUES[key1]: [Point().from_json_value(elem2) for elem2 in elem1] for key1, elem1 in value['palettes'].items()}
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field palettes: {}'.format(e))
+        if 'labels' in value:
+            try:
+                self.labels = {Point().from_json_value(pair1[0]): pair1[1] for pair1 in value['labels']}
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field labels: {}'.format(e))
+        if 'outline' in value:
+            try:
+                self.outline = Shape().from_json_value(value['outline'])
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field outline: {}'.format(e))
+        if 'origin' in value:
+            try:
+                self.origin = actual_base.python.ttypes.thing().from_json_value(value['origin'])
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field origin: {}'.format(e))
+        if 'history' in value:
+            try:
+                self.history = [set(Color._NAMES_TO_VALUES[elem2] for elem2 in elem1) for elem1 in value['history']]
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field history: {}'.format(e))
+        if 'version' in value:
+            try:
+                self.version = value['version']
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field version: {}'.format(e))
+        return self
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.STRING:
+                    self.name = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 2:
+                if ftype == TType.STRING:
+                    self.title = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 3:
+                if ftype == TType.STRING:
+                    self.thumbnail = iprot.readBinary()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 4:
+                if ftype == TType.I32:
+                    self.background = Color(iprot.readI32())
+                else:
+                    iprot.skip(ftype)
+            elif fid == 5:
+                if ftype == TType.I64:
+                    self.created = iprot.readI64()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 6:
+                if ftype == TType.LIST:
+                    self.points = []
+                    (_, elem0) = iprot.readListBegin()
+                    for _ in range(elem0):
+                        elem1 = Point()
+                        elem1.read(iprot)
+                        self.points.append(elem1)
+                    iprot.readListEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 7:
+                if ftype == TType.SET:
+                    self.tags = set()
+                    (_, elem2) = iprot.readSetBegin()
+                    for _ in range(elem2):
+                        elem3 = iprot.readString()
+                        self.tags.add(elem3)
+                    iprot.readSetEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 8:
+                if ftype == TType.MAP:
+                    self.layers = {}
+                    (_, _, elem4) = iprot.readMapBegin()
+                    for _ in range(elem4):
+                        elem6 = iprot.readI32()
+                        elem5 = Point()
+                        elem5.read(iprot)
+                        self.layers[elem6] = elem5
+                    iprot.readMapEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 9:
+                if ftype == TType.MAP:
+                    self.palettes = {}
+                    (_, _, elem7) = iprot.readMapBegin()
+                    for _ in range(elem7):
+                        elem9 = Color(iprot.readI32())
+                        elem8 = []
+                        (_, elem10) = iprot.readListBegin()
+                        for _ in range(elem10):
+                            elem11 = Point()
+                            elem11.read(iprot)
+                            elem8.append(elem11)
+                        iprot.readListEnd()
+                        self.palettes[elem9] = elem8
+                    iprot.readMapEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 10:
+                if ftype == TType.MAP:
+                    self.labels = {}
+                    (_, _, elem12) = iprot.readMapBegin()
+                    for _ in range(elem12):
+                        elem14 = Point()
+                        elem14.read(iprot)
+                        elem13 = iprot.readString()
+                        self.labels[elem14] = elem13
+                    iprot.readMapEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 11:
+                if ftype == TType.STRUCT:
+                    self.outline = Shape()
+                    self.outline.read(iprot)
+                else:
+                    iprot.skip(ftype)
+            elif fid == 12:
+                if ftype == TType.STRUCT:
+                    self.origin = actual_base.python.ttypes.thing()
+                    self.origin.read(iprot)
+                else:
+                    iprot.skip(ftype)
+            elif fid == 13:
+                if ftype == TType.LIST:
+                    self.history = []
+                    (_, elem15) = iprot.readListBegin()
+                    for _ in range(elem15):
+                        elem16 = set()
+                        (_, elem17) = iprot.readSetBegin()
+                        for _ in range(elem17):
+                            elem18 = Color(iprot.readI32())
+                            elem16.add(elem18)
+                        iprot.readSetEnd()
+                        self.history.append(elem16)
+                    iprot.readListEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 14:
+                if ftype == TType.I32:
+                    self.version = iprot.readI32()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('Drawing')
+        if self.name is not None:
+            oprot.writeFieldBegin('name', TType.STRING, 1)
+            oprot.writeString(self.name)
+            oprot.writeFieldEnd()
+        if self.title is not None:
+            oprot.writeFieldBegin('title', TType.STRING, 2)
+            oprot.writeString(self.title)
+            oprot.writeFieldEnd()
+        if self.thumbnail is not None:
+            oprot.writeFieldBegin('thumbnail', TType.STRING, 3)
+            oprot.writeBinary(self.thumbnail)
+            oprot.writeFieldEnd()
+        if self.background is not None:
+            oprot.writeFieldBegin('background', TType.I32, 4)
+            oprot.writeI32(self.background)
+            oprot.writeFieldEnd()
+        if self.created is not None:
+            oprot.writeFieldBegin('created', TType.I64, 5)
+            oprot.writeI64(self.created)
+            oprot.writeFieldEnd()
+        if self.points is not None:
+            oprot.writeFieldBegin('points', TType.LIST, 6)
+            oprot.writeListBegin(TType.STRUCT, len(self.points))
+            for elem19 in self.points:
+                elem19.write(oprot)
+            oprot.writeListEnd()
+            oprot.writeFieldEnd()
+        if self.tags is not None:
+            oprot.writeFieldBegin('tags', TType.SET, 7)
+            oprot.writeSetBegin(TType.STRING, len(self.tags))
+            for elem20 in self.tags:
+                oprot.writeString(elem20)
+            oprot.writeSetEnd()
+            oprot.writeFieldEnd()
+        if self.layers is not None:
+            oprot.writeFieldBegin('layers', TType.MAP, 8)
+            oprot.writeMapBegin(TType.I32, TType.STRUCT, len(self.layers))
+            for elem22, elem21 in self.layers.items():
+                oprot.writeI32(elem22)
+                elem21.write(oprot)
+            oprot.writeMapEnd()
+            oprot.writeFieldEnd()
+        if self.palettes is not None:
+            oprot.writeFieldBegin('palettes', TType.MAP, 9)
+            oprot.writeMapBegin(TType.I32, TType.LIST, len(self.palettes))
+            for elem24, elem23 in self.palettes.items():
+                oprot.writeI32(elem24)
+                oprot.writeListBegin(TType.STRUCT, len(elem23))
+                for elem25 in elem23:
+                    elem25.write(oprot)
+                oprot.writeListEnd()
+            oprot.writeMapEnd()
+            oprot.writeFieldEnd()
+        if self.labels is not None:
+            oprot.writeFieldBegin('labels', TType.MAP, 10)
+            oprot.writeMapBegin(TType.STRUCT, TType.STRING, len(self.labels))
+            for elem27, elem26 in self.labels.items():
+                elem27.write(oprot)
+                oprot.writeString(elem26)
+            oprot.writeMapEnd()
+            oprot.writeFieldEnd()
+        if self.outline is not None:
+            oprot.writeFieldBegin('outline', TType.STRUCT, 11)
+            self.outline.write(oprot)
+            oprot.writeFieldEnd()
+        if self.origin is not None:
+            oprot.writeFieldBegin('origin', TType.STRUCT, 12)
+            self.origin.write(oprot)
+            oprot.writeFieldEnd()
+        if self.history is not None:
+            oprot.writeFieldBegin('history', TType.LIST, 13)
+            oprot.writeListBegin(TType.SET, len(self.history))
+            for elem28 in self.history:
+                oprot.writeSetBegin(TType.I32, len(elem28))
+                for elem29 in elem28:
+                    oprot.writeI32(elem29)
+                oprot.writeSetEnd()
+            oprot.writeListEnd()
+            oprot.writeFieldEnd()
+        if self.version is not None:
+            oprot.writeFieldBegin('version', TType.I32, 14)
+            oprot.writeI32(self.version)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        if self.name is None:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='Required field \'name\' is not present in struct \'Drawing\'')
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.name))
+        value = (value * 31) ^ hash(make_hashable(self.title))
+        value = (value * 31) ^ hash(make_hashable(self.thumbnail))
+        value = (value * 31) ^ hash(make_hashable(self.background))
+        value = (value * 31) ^ hash(make_hashable(self.created))
+        value = (value * 31) ^ hash(make_hashable(self.points))
+        value = (value * 31) ^ hash(make_hashable(self.tags))
+        value = (value * 31) ^ hash(make_hashable(self.layers))
+        value = (value * 31) ^ hash(make_hashable(self.palettes))
+        value = (value * 31) ^ hash(make_hashable(self.labels))
+        value = (value * 31) ^ hash(make_hashable(self.outline))
+        value = (value * 31) ^ hash(make_hashable(self.origin))
+        value = (value * 31) ^ hash(make_hashable(self.history))
+        value = (value * 31) ^ hash(make_hashable(self.version))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
+class Shape(object):
+    """
+    Attributes:
+     - point
+     - radius
+    """
+    def __init__(self, point=None, radius=None):
+        self.point = point
+        self.radius = radius
+
+    def is_set_point(self):
+        return self.point is not None
+
+    def unset_point(self):
+        self.point = None
+
+    def is_set_radius(self):
+        return self.radius is not None
+
+    def unset_radius(self):
+        self.radius = None
+
+    def to_json(self):
+        """
+        Returns the JSON encoding of the Shape.
+        """
+        return json.dumps(self.to_json_value())
+
+    def to_json_value(self):
+        """
+        Returns the dict encoded by to_json.
+        """
+        value = {}
+        if self.point is not None:
+            value['point'] = self.point.to_json_value()
+        if self.radius is not None:
+            value['radius'] = self.radius
+        return value
+
+    def from_json(self, data):
+        """
+        Sets the fields of the Shape from the JSON encoding produced by
+        to_json and returns it. Fields missing from the JSON are left unchanged.
+        """
+        try:
+            value = json.loads(data)
+        except ValueError as e:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message=str(e))
+        return self.from_json_value(value)
+
+    def from_json_value(self, value):
+        """
+        Sets the fields of the Shape from the dict returned by
+        to_json_value and returns it.
+        """
+        if not isinstance(value, dict):
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='Shape JSON must be an object')
+        if 'point' in value:
+            try:
+                self.point = Point().from_json_value(value['point'])
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field point: {}'.format(e))
+        if 'radius' in value:
+            try:
+                self.radius = float(value['radius'])
+            except Exception as e:
+                raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='error decoding field radius: {}'.format(e))
+        return self
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.STRUCT:
+                    self.point = Point()
+                    self.point.read(iprot)
+                else:
+                    iprot.skip(ftype)
+            elif fid == 2:
+                if ftype == TType.DOUBLE:
+                    self.radius = iprot.readDouble()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('Shape')
+        if self.point is not None:
+            oprot.writeFieldBegin('point', TType.STRUCT, 1)
+            self.point.write(oprot)
+            oprot.writeFieldEnd()
+        if self.radius is not None:
+            oprot.writeFieldBegin('radius', TType.DOUBLE, 2)
+            oprot.writeDouble(self.radius)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        set_fields = 0
+        if self.point is not None:
+            set_fields += 1
+        if self.radius is not None:
+            set_fields += 1
+        if set_fields != 1:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='The union did not have exactly one field set, {} were set'.format(set_fields))
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.point))
+        value = (value * 31) ^ hash(make_hashable(self.radius))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
@@ -0,0 +1,30 @@
+// filter (plugin SDK 1.0.0)
+
+export interface Alert { // struct
+  message?: string; // 1
+}
+
+export interface Invoice { // struct
+  id?: string; // 1
+}
+
+// hash 29e30747a70b1bc2060bc06d3a9892a7a27db67fa5ff475cde96e557be048057
+export interface FBase {
+  ping(): Promise<void>;
+}
+
+// hash f7dd4286e7ef8956e463fd9224912b33eed00f346611a345c7f1d961042c5cf7
+export interface FInvoices {
+  getInvoice(): Promise<Invoice>;
+}
+
+// hash 0dd358d87a277d8e193a10e50f8f8d63706255d48279d11adbeec047047bb068
+export interface FAdmin {
+  reset(): Promise<void>;
+}
+
+// scope Alerts prefix ""
+// AlertRaised: Alert
+
+// scope Billing prefix ""
+// InvoiceCreated: Invoice
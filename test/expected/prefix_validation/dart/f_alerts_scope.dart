@@ -0,0 +1,199 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+
+
+import 'dart:async';
+import 'dart:typed_data' show Uint8List;
+
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:frugal/frugal.dart' as frugal;
+
+import 'package:prefix_validation/prefix_validation.dart' as t_prefix_validation;
+
+
+const String delimiter = '.';
+
+class AlertsPublisher {
+  /// Describes the Alerts scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'prefix_validation.frugal', 'scope', 'Alerts',
+      'e06528dc778bda5a35c5a84ea3350a764cad76ece8c4a7c72459faf886841d5d', '2.23.0',
+      const ['AlertRaised']);
+
+  frugal.FPublisherTransport transport;
+  frugal.FProtocolFactory protocolFactory;
+  Map<String, frugal.FMethod> _methods;
+  AlertsPublisher(frugal.FScopeProvider provider, [List<frugal.Middleware> middleware]) {
+    transport = provider.publisherTransportFactory.getTransport();
+    protocolFactory = provider.protocolFactory;
+    var combined = middleware ?? [];
+    combined.addAll(provider.middleware);
+    this._methods = {};
+    this._methods['AlertRaised'] = new frugal.FMethod(this._publishAlertRaised, 'Alerts', 'publishAlertRaised', combined);
+  }
+
+  Future open() {
+    return transport.open();
+  }
+
+  Future close() {
+    return transport.close();
+  }
+
+  String _prefixVariable(String name, String value) {
+    if (value == null || value.isEmpty) {
+      throw new ArgumentError.value(value, name, 'topic prefix variable is empty');
+    }
+    return value.replaceAll(delimiter, '_');
+  }
+
+  Future publishAlertRaised(frugal.FContext ctx, String tenant, String region, t_prefix_validation.Alert req, {Duration timeout}) {
+    var publish = this._methods['AlertRaised']([ctx, tenant, region, req]);
+    if (timeout == null) {
+      return publish;
+    }
+    return publish.timeout(timeout, onTimeout: () =>
+        throw new frugal.FTimeoutError('frugal: publish of AlertRaised timed out'));
+  }
+
+  Future _publishAlertRaised(frugal.FContext ctx, String tenant, String region, t_prefix_validation.Alert req) async {
+    tenant = _prefixVariable('tenant', tenant);
+    region = _prefixVariable('region', region);
+    ctx.addRequestHeader('_topic_tenant', tenant);
+    ctx.addRequestHeader('_topic_region', region);
+    var op = "AlertRaised";
+    var prefix = "tenant.${tenant}.region.${region}.";
+    var topic = "${prefix}Alerts${delimiter}${op}";
+    try {
+      var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);
+      var oprot = protocolFactory.getProtocol(memoryBuffer);
+      var msg = new thrift.TMessage(op, thrift.TMessageType.CALL, 0);
+      oprot.writeRequestHeader(ctx);
+      oprot.writeMessageBegin(msg);
+      req.write(oprot);
+      oprot.writeMessageEnd();
+      await transport.publish(topic, memoryBuffer.writeBytes);
+    } on thrift.TTransportError catch (e) {
+      throw frugal.translateTransportError(e);
+    }
+  }
+}
+
+
+class AlertsSubscriber {
+  /// Describes the Alerts scope contract.
+  static const frugal.FContractMetadata metadata = const frugal.FContractMetadata(
+      'prefix_validation.frugal', 'scope', 'Alerts',
+      'e06528dc778bda5a35c5a84ea3350a764cad76ece8c4a7c72459faf886841d5d', '2.23.0',
+      const ['AlertRaised']);
+
+  final frugal.FScopeProvider provider;
+  final List<frugal.Middleware> _middleware;
+
+  AlertsSubscriber(this.provider, [List<frugal.Middleware> middleware])
+      : this._middleware = middleware ?? [] {
+    this._middleware.addAll(provider.middleware);
+}
+
+  String _prefixVariable(String name, String value) {
+    if (value == null || value.isEmpty) {
+      throw new ArgumentError.value(value, name, 'topic prefix variable is empty');
+    }
+    return value.replaceAll(delimiter, '_');
+  }
+
+  Future<frugal.FSubscription> subscribeAlertRaised(String tenant, String region, dynamic onAlert(frugal.FContext ctx, t_prefix_validation.Alert req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    tenant = _prefixVariable('tenant', tenant);
+    region = _prefixVariable('region', region);
+    var op = "AlertRaised";
+    var prefix = "tenant.${tenant}.region.${region}.";
+    var topic = "${prefix}Alerts${delimiter}${op}";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvAlertRaised(op, provider.protocolFactory, onAlert),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  Stream<t_prefix_validation.Alert> streamAlertRaised(String tenant, String region, {frugal.FResubscribePolicy resubscribePolicy}) {
+    Future<frugal.FSubscription> subscription;
+    StreamController<t_prefix_validation.Alert> controller;
+    controller = new StreamController<t_prefix_validation.Alert>(
+        onListen: () {
+          subscription = subscribeAlertRaised(tenant, region, (frugal.FContext ctx, t_prefix_validation.Alert req) {
+            controller.add(req);
+          }, onError: controller.addError, resubscribePolicy: resubscribePolicy);
+          subscription.catchError((_) => controller.close());
+        },
+        onPause: () {
+          subscription.then((sub) => sub.pause(), onError: (_) => null);
+        },
+        onResume: () {
+          subscription.then((sub) => sub.resume(), onError: (_) => null);
+        },
+        onCancel: () async {
+          var sub = await subscription.catchError((_) => null);
+          await sub?.unsubscribe();
+          controller.close();
+        });
+    return controller.stream;
+  }
+
+  frugal.FAsyncCallback _recvAlertRaised(String op, frugal.FProtocolFactory protocolFactory, dynamic onAlert(frugal.FContext ctx, t_prefix_validation.Alert req)) {
+    frugal.FMethod method = new frugal.FMethod(onAlert, 'Alerts', 'subscribeAlert', this._middleware);
+    callbackAlertRaised(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      if (tMsg.name != op) {
+        thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+        iprot.readMessageEnd();
+        throw new thrift.TApplicationError(
+        frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      t_prefix_validation.Alert req = new t_prefix_validation.Alert();
+      req.read(iprot);
+      iprot.readMessageEnd();
+      method([ctx, req]);
+    }
+    return callbackAlertRaised;
+  }
+
+
+  /// Subscribes to every operation of the scope. onMessage is called with the
+  /// name of the operation of each message and its decoded payload.
+  Future<frugal.FSubscription> subscribeAll(String tenant, String region, dynamic onMessage(frugal.FContext ctx, String op, dynamic req), {frugal.FSubscriptionErrorCallback onError, frugal.FResubscribePolicy resubscribePolicy}) async {
+    tenant = _prefixVariable('tenant', tenant);
+    region = _prefixVariable('region', region);
+    var prefix = "tenant.${tenant}.region.${region}.";
+    var topic = "${prefix}Alerts${delimiter}*";
+    var transport = provider.subscriberTransportFactory.getTransport();
+    return frugal.subscribeWithPolicy(topic, transport, _recvAll(provider.protocolFactory, onMessage),
+      onError: onError, resubscribePolicy: resubscribePolicy);
+  }
+
+  frugal.FAsyncCallback _recvAll(frugal.FProtocolFactory protocolFactory, dynamic onMessage(frugal.FContext ctx, String op, dynamic req)) {
+    frugal.FMethod method = new frugal.FMethod(onMessage, 'Alerts', 'subscribeAll', this._middleware);
+    callbackAll(thrift.TTransport transport) {
+      var iprot = protocolFactory.getProtocol(transport);
+      var ctx = iprot.readRequestHeader();
+      var tMsg = iprot.readMessageBegin();
+      var req;
+      switch (tMsg.name) {
+        case 'AlertRaised':
+          t_prefix_validation.Alert reqAlertRaised = new t_prefix_validation.Alert();
+          reqAlertRaised.read(iprot);
+          req = reqAlertRaised;
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, thrift.TType.STRUCT);
+          iprot.readMessageEnd();
+          throw new thrift.TApplicationError(
+          frugal.FrugalTApplicationErrorType.UNKNOWN_METHOD, tMsg.name);
+      }
+      iprot.readMessageEnd();
+      method([ctx, tMsg.name, req]);
+    }
+    return callbackAll;
+  }
+}
+
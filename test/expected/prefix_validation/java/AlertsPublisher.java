@@ -0,0 +1,141 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+
+package prefix_validation;
+
+import com.workiva.frugal.FContext;
+import com.workiva.frugal.FContractMetadata;
+import com.workiva.frugal.exception.TApplicationExceptionType;
+import com.workiva.frugal.middleware.InvocationHandler;
+import com.workiva.frugal.middleware.ServiceMiddleware;
+import com.workiva.frugal.protocol.*;
+import com.workiva.frugal.provider.FScopeProvider;
+import com.workiva.frugal.transport.FPublisherTransport;
+import com.workiva.frugal.transport.FSubscriberTransport;
+import com.workiva.frugal.transport.FSubscription;
+import com.workiva.frugal.transport.TMemoryOutputBuffer;
+import org.apache.thrift.TException;
+import org.apache.thrift.TApplicationException;
+import org.apache.thrift.transport.TTransport;
+import org.apache.thrift.transport.TTransportException;
+import org.apache.thrift.protocol.*;
+
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+import javax.annotation.Generated;
+
+
+
+
+@Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
+public class AlertsPublisher {
+
+	/**
+	 * Describes the Alerts scope contract.
+	 */
+	public static final FContractMetadata METADATA = new FContractMetadata(
+			"prefix_validation.frugal", "scope", "Alerts",
+			"e06528dc778bda5a35c5a84ea3350a764cad76ece8c4a7c72459faf886841d5d", "2.23.0",
+			Arrays.asList("AlertRaised"));
+
+	public interface Iface {
+		public void open() throws TException;
+
+		public void close() throws TException;
+
+		public void publishAlertRaised(FContext ctx, String tenant, String region, Alert req) throws TException;
+
+	}
+
+	public static class Client implements Iface {
+		private static final String DELIMITER = ".";
+
+		private static String prefixVariable(String name, String value) throws TException {
+			if (value == null || value.isEmpty()) {
+				throw new TException("Topic prefix variable " + name + " is empty");
+			}
+			return value.replace(DELIMITER, "_");
+		}
+
+		private final Iface target;
+		private final Iface proxy;
+
+		public Client(FScopeProvider provider, ServiceMiddleware... middleware) {
+			target = new InternalAlertsPublisher(provider);
+			List<ServiceMiddleware> combined = Arrays.asList(middleware);
+			combined.addAll(provider.getMiddleware());
+			middleware = combined.toArray(new ServiceMiddleware[0]);
+			proxy = InvocationHandler.composeMiddleware(target, Iface.class, middleware);
+		}
+
+		public void open() throws TException {
+			target.open();
+		}
+
+		public void close() throws TException {
+			target.close();
+		}
+
+		public void publishAlertRaised(FContext ctx, String tenant, String region, Alert req) throws TException {
+			proxy.publishAlertRaised(ctx, tenant, region, req);
+		}
+
+		protected static class InternalAlertsPublisher implements Iface {
+
+			private FScopeProvider provider;
+			private FPublisherTransport transport;
+			private FProtocolFactory protocolFactory;
+
+			protected InternalAlertsPublisher() {
+			}
+
+			public InternalAlertsPublisher(FScopeProvider provider) {
+				this.provider = provider;
+			}
+
+			public void open() throws TException {
+				FScopeProvider.Publisher publisher = provider.buildPublisher();
+				transport = publisher.getTransport();
+				protocolFactory = publisher.getProtocolFactory();
+				transport.open();
+			}
+
+			public void close() throws TException {
+				transport.close();
+			}
+
+			public void publishAlertRaised(FContext ctx, String tenant, String region, Alert req) throws TException {
+				tenant = prefixVariable("tenant", tenant);
+				region = prefixVariable("region", region);
+				ctx.addRequestHeader("_topic_tenant", tenant);
+				ctx.addRequestHeader("_topic_region", region);
+				String op = "AlertRaised";
+				String prefix = String.format("tenant.%s.region.%s.", tenant, region);
+				String topic = String.format("%sAlerts%s%s", prefix, DELIMITER, op);
+				TMemoryOutputBuffer memoryBuffer = new TMemoryOutputBuffer(transport.getPublishSizeLimit());
+				FProtocol oprot = protocolFactory.getProtocol(memoryBuffer);
+				oprot.writeRequestHeader(ctx);
+				oprot.writeMessageBegin(new TMessage(op, TMessageType.CALL, 0));
+				req.write(oprot);
+				oprot.writeMessageEnd();
+				transport.publish(topic, memoryBuffer.getWriteBytes());
+			}
+		}
+	}
+}
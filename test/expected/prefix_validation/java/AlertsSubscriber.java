@@ -0,0 +1,161 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+
+package prefix_validation;
+
+import com.workiva.frugal.FContext;
+import com.workiva.frugal.FContractMetadata;
+import com.workiva.frugal.exception.TApplicationExceptionType;
+import com.workiva.frugal.middleware.InvocationHandler;
+import com.workiva.frugal.middleware.ServiceMiddleware;
+import com.workiva.frugal.protocol.*;
+import com.workiva.frugal.provider.FScopeProvider;
+import com.workiva.frugal.transport.FPublisherTransport;
+import com.workiva.frugal.transport.FSubscriberTransport;
+import com.workiva.frugal.transport.FSubscription;
+import com.workiva.frugal.transport.TMemoryOutputBuffer;
+import org.apache.thrift.TException;
+import org.apache.thrift.TApplicationException;
+import org.apache.thrift.transport.TTransport;
+import org.apache.thrift.transport.TTransportException;
+import org.apache.thrift.protocol.*;
+
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+import javax.annotation.Generated;
+
+
+
+
+@Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
+public class AlertsSubscriber {
+
+	/**
+	 * Describes the Alerts scope contract.
+	 */
+	public static final FContractMetadata METADATA = new FContractMetadata(
+			"prefix_validation.frugal", "scope", "Alerts",
+			"e06528dc778bda5a35c5a84ea3350a764cad76ece8c4a7c72459faf886841d5d", "2.23.0",
+			Arrays.asList("AlertRaised"));
+
+	public interface Iface {
+		public FSubscription subscribeAlertRaised(String tenant, String region, final AlertRaisedHandler handler) throws TException;
+
+	}
+
+	public interface IfaceThrowable {
+		public FSubscription subscribeAlertRaisedThrowable(String tenant, String region, final AlertRaisedThrowableHandler handler) throws TException;
+
+	}
+
+	public interface AlertRaisedHandler {
+		void onAlertRaised(FContext ctx, Alert req) throws TException;
+	}
+
+	public interface AlertRaisedThrowableHandler {
+		void onAlertRaised(FContext ctx, Alert req) throws TException;
+	}
+
+	public static class Client implements Iface, IfaceThrowable {
+		private static final String DELIMITER = ".";
+		private static final Logger LOGGER = LoggerFactory.getLogger(Client.class);
+
+		private static String prefixVariable(String name, String value) throws TException {
+			if (value == null || value.isEmpty()) {
+				throw new TException("Topic prefix variable " + name + " is empty");
+			}
+			return value.replace(DELIMITER, "_");
+		}
+
+		private final FScopeProvider provider;
+		private final ServiceMiddleware[] middleware;
+
+		public Client(FScopeProvider provider, ServiceMiddleware... middleware) {
+			this.provider = provider;
+			List<ServiceMiddleware> combined = Arrays.asList(middleware);
+			combined.addAll(provider.getMiddleware());
+			this.middleware = combined.toArray(new ServiceMiddleware[0]);
+		}
+
+		public FSubscription subscribeAlertRaised(String tenant, String region, final AlertRaisedHandler handler) throws TException {
+			tenant = prefixVariable("tenant", tenant);
+			region = prefixVariable("region", region);
+			final String op = "AlertRaised";
+			String prefix = String.format("tenant.%s.region.%s.", tenant, region);
+			final String topic = String.format("%sAlerts%s%s", prefix, DELIMITER, op);
+			final FScopeProvider.Subscriber subscriber = provider.buildSubscriber();
+			final FSubscriberTransport transport = subscriber.getTransport();
+			final AlertRaisedHandler proxiedHandler = InvocationHandler.composeMiddleware(handler, AlertRaisedHandler.class, middleware);
+			transport.subscribe(topic, recvAlertRaised(op, subscriber.getProtocolFactory(), proxiedHandler));
+			return FSubscription.of(topic, transport);
+		}
+
+		private FAsyncCallback recvAlertRaised(String op, FProtocolFactory pf, AlertRaisedHandler handler) {
+			return new FAsyncCallback() {
+				public void onMessage(TTransport tr) throws TException {
+					FProtocol iprot = pf.getProtocol(tr);
+					FContext ctx = iprot.readRequestHeader();
+					TMessage msg = iprot.readMessageBegin();
+					if (!msg.name.equals(op)) {
+						TProtocolUtil.skip(iprot, TType.STRUCT);
+						iprot.readMessageEnd();
+						throw new TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD);
+					}
+					Alert received = new Alert();
+					received.read(iprot);
+					iprot.readMessageEnd();
+					handler.onAlertRaised(ctx, received);
+				}
+			};
+		}
+
+		public FSubscription subscribeAlertRaisedThrowable(String tenant, String region, final AlertRaisedThrowableHandler handler) throws TException {
+			tenant = prefixVariable("tenant", tenant);
+			region = prefixVariable("region", region);
+			final String op = "AlertRaised";
+			String prefix = String.format("tenant.%s.region.%s.", tenant, region);
+			final String topic = String.format("%sAlerts%s%s", prefix, DELIMITER, op);
+			final FScopeProvider.Subscriber subscriber = provider.buildSubscriber();
+			final FSubscriberTransport transport = subscriber.getTransport();
+			final AlertRaisedThrowableHandler proxiedHandler = InvocationHandler.composeMiddleware(handler, AlertRaisedThrowableHandler.class, middleware);
+			transport.subscribe(topic, recvAlertRaised(op, subscriber.getProtocolFactory(), proxiedHandler));
+			return FSubscription.of(topic, transport);
+		}
+
+		private FAsyncCallback recvAlertRaised(String op, FProtocolFactory pf, AlertRaisedThrowableHandler handler) {
+			return new FAsyncCallback() {
+				public void onMessage(TTransport tr) throws TException {
+					FProtocol iprot = pf.getProtocol(tr);
+					FContext ctx = iprot.readRequestHeader();
+					TMessage msg = iprot.readMessageBegin();
+					if (!msg.name.equals(op)) {
+						TProtocolUtil.skip(iprot, TType.STRUCT);
+						iprot.readMessageEnd();
+						throw new TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD);
+					}
+					Alert received = new Alert();
+					received.read(iprot);
+					iprot.readMessageEnd();
+					handler.onAlertRaised(ctx, received);
+				}
+			};
+		}
+	}
+
+}
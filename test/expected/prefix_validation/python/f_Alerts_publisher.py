@@ -0,0 +1,93 @@
+#
+# Autogenerated by Frugal Compiler (2.23.0)
+#
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+#
+
+
+
+import inspect
+import sys
+import traceback
+
+from thrift.Thrift import TApplicationException
+from thrift.Thrift import TMessageType
+from thrift.Thrift import TType
+from frugal.exceptions import TApplicationExceptionType
+from frugal.metadata import FContractMetadata
+from frugal.middleware import Method
+from frugal.subscription import FSubscription
+from frugal.transport import TMemoryOutputBuffer
+
+from .ttypes import *
+
+
+
+
+class AlertsPublisher(object):
+
+    _DELIMITER = '.'
+
+    metadata = FContractMetadata(
+        'prefix_validation.frugal', 'scope', 'Alerts',
+        'e06528dc778bda5a35c5a84ea3350a764cad76ece8c4a7c72459faf886841d5d', '2.23.0',
+        ['AlertRaised'])
+
+    @classmethod
+    def _prefix_variable(cls, name, value):
+        if not value:
+            raise ValueError('topic prefix variable {} is empty'.format(name))
+        if cls._DELIMITER in value:
+            raise ValueError('topic prefix variable {} contains the topic delimiter: {}'.format(name, value))
+        return value
+
+    def __init__(self, provider, middleware=None):
+        """
+        Create a new AlertsPublisher.
+
+        Args:
+            provider: FScopeProvider
+            middleware: ServiceMiddleware or list of ServiceMiddleware
+        """
+
+        middleware = middleware or []
+        if middleware and not isinstance(middleware, list):
+            middleware = [middleware]
+        middleware += provider.get_middleware()
+        self._transport, self._protocol_factory = provider.new_publisher()
+        self._methods = {
+            'publish_AlertRaised': Method(self._publish_AlertRaised, middleware),
+        }
+
+    async def open(self):
+        await self._transport.open()
+
+    async def close(self):
+        await self._transport.close()
+
+    async def publish_AlertRaised(self, ctx, tenant, region, req):
+        """
+        Args:
+            ctx: FContext
+            tenant: string
+            region: string
+            req: Alert
+        """
+        await self._methods['publish_AlertRaised']([ctx, tenant, region, req])
+
+    async def _publish_AlertRaised(self, ctx, tenant, region, req):
+        tenant = self._prefix_variable('tenant', tenant)
+        region = self._prefix_variable('region', region)
+        ctx.set_request_header('_topic_tenant', tenant)
+        ctx.set_request_header('_topic_region', region)
+        op = 'AlertRaised'
+        prefix = 'tenant.{}.region.{}.'.format(tenant, region)
+        topic = '{}Alerts{}{}'.format(prefix, self._DELIMITER, op)
+        buffer = TMemoryOutputBuffer(self._transport.get_publish_size_limit())
+        oprot = self._protocol_factory.get_protocol(buffer)
+        oprot.write_request_headers(ctx)
+        oprot.writeMessageBegin(op, TMessageType.CALL, 0)
+        req.write(oprot)
+        oprot.writeMessageEnd()
+        await self._transport.publish(topic, buffer.getvalue())
+
@@ -0,0 +1,104 @@
+#
+# Autogenerated by Frugal Compiler (2.23.0)
+#
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+#
+
+
+
+import inspect
+import sys
+import traceback
+
+from thrift.Thrift import TApplicationException
+from thrift.Thrift import TMessageType
+from thrift.Thrift import TType
+from frugal.exceptions import TApplicationExceptionType
+from frugal.metadata import FContractMetadata
+from frugal.middleware import Method
+from frugal.subscription import FSubscription
+from frugal.transport import TMemoryOutputBuffer
+
+from .ttypes import *
+
+
+
+
+class AlertsSubscriber(object):
+
+    _DELIMITER = '.'
+
+    metadata = FContractMetadata(
+        'prefix_validation.frugal', 'scope', 'Alerts',
+        'e06528dc778bda5a35c5a84ea3350a764cad76ece8c4a7c72459faf886841d5d', '2.23.0',
+        ['AlertRaised'])
+
+    @classmethod
+    def _prefix_variable(cls, name, value):
+        if not value:
+            raise ValueError('topic prefix variable {} is empty'.format(name))
+        if cls._DELIMITER in value:
+            raise ValueError('topic prefix variable {} contains the topic delimiter: {}'.format(name, value))
+        return value
+
+    def __init__(self, provider, middleware=None):
+        """
+        Create a new AlertsSubscriber.
+
+        Args:
+            provider: FScopeProvider
+            middleware: ServiceMiddleware or list of ServiceMiddleware
+        """
+
+        middleware = middleware or []
+        if middleware and not isinstance(middleware, list):
+            middleware = [middleware]
+        middleware += provider.get_middleware()
+        self._middleware = middleware
+        self._provider = provider
+
+    async def subscribe_AlertRaised(self, tenant, region, AlertRaised_handler):
+        """
+        Args:
+            tenant: string
+            region: string
+            AlertRaised_handler: function which takes FContext and Alert
+        """
+
+        tenant = self._prefix_variable('tenant', tenant)
+        region = self._prefix_variable('region', region)
+        op = 'AlertRaised'
+        prefix = 'tenant.{}.region.{}.'.format(tenant, region)
+        topic = '{}Alerts{}{}'.format(prefix, self._DELIMITER, op)
+
+        transport, protocol_factory = self._provider.new_subscriber()
+        await transport.subscribe(topic, self._recv_AlertRaised(protocol_factory, op, AlertRaised_handler))
+        return FSubscription(topic, transport)
+
+    def _recv_AlertRaised(self, protocol_factory, op, handler):
+        method = Method(handler, self._middleware)
+
+        async def callback(transport):
+            iprot = protocol_factory.get_protocol(transport)
+            ctx = iprot.read_request_headers()
+            mname, _, _ = iprot.readMessageBegin()
+            if mname != op:
+                iprot.skip(TType.STRUCT)
+                iprot.readMessageEnd()
+                raise TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD)
+            req = Alert()
+            req.read(iprot)
+            iprot.readMessageEnd()
+            try:
+                ret = method([ctx, req])
+                if inspect.iscoroutine(ret):
+                    await ret
+            except:
+                traceback.print_exc()
+                sys.exit(1)
+
+        return callback
+
+
+
+
@@ -0,0 +1,137 @@
+#
+# Autogenerated by Frugal Compiler (2.23.0)
+#
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+#
+
+
+
+import inspect
+import sys
+import traceback
+
+from thrift.Thrift import TApplicationException
+from thrift.Thrift import TMessageType
+from thrift.Thrift import TType
+from frugal.exceptions import TApplicationExceptionType
+from frugal.metadata import FContractMetadata
+from frugal.middleware import Method
+from frugal.subscription import FSubscription
+from frugal.transport import TMemoryOutputBuffer
+from frugal.aio.transport import batch_frame_callback
+
+from .ttypes import *
+
+
+
+
+class TicksSubscriber(object):
+    """
+    Market data ticks, published in batches.
+    """
+
+    _DELIMITER = '.'
+
+    metadata = FContractMetadata(
+        'batch_publish.frugal', 'scope', 'Ticks',
+        '298b5c8a6925dea29dce346710555efe5fe6c46147b413707493b80c1ad6b0c9', '2.23.0',
+        ['TickReceived', 'Heartbeat'])
+
+    def __init__(self, provider, middleware=None):
+        """
+        Create a new TicksSubscriber.
+
+        Args:
+            provider: FScopeProvider
+            middleware: ServiceMiddleware or list of ServiceMiddleware
+        """
+
+        middleware = middleware or []
+        if middleware and not isinstance(middleware, list):
+            middleware = [middleware]
+        middleware += provider.get_middleware()
+        self._middleware = middleware
+        self._provider = provider
+
+    async def subscribe_TickReceived(self, exchange, TickReceived_handler):
+        """
+        Args:
+            exchange: string
+            TickReceived_handler: function which takes FContext and Tick
+        """
+
+        op = 'TickReceived'
+        prefix = 'market.{}.'.format(exchange)
+        topic = '{}Ticks{}{}'.format(prefix, self._DELIMITER, op)
+
+        transport, protocol_factory = self._provider.new_subscriber()
+        await transport.subscribe(topic, batch_frame_callback(self._recv_TickReceived(protocol_factory, op, TickReceived_handler)))
+        return FSubscription(topic, transport)
+
+    def _recv_TickReceived(self, protocol_factory, op, handler):
+        method = Method(handler, self._middleware)
+
+        async def callback(transport):
+            iprot = protocol_factory.get_protocol(transport)
+            ctx = iprot.read_request_headers()
+            mname, _, _ = iprot.readMessageBegin()
+            if mname != op:
+                iprot.skip(TType.STRUCT)
+                iprot.readMessageEnd()
+                raise TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD)
+            req = Tick()
+            req.read(iprot)
+            iprot.readMessageEnd()
+            try:
+                ret = method([ctx, req])
+                if inspect.iscoroutine(ret):
+                    await ret
+            except:
+                traceback.print_exc()
+                sys.exit(1)
+
+        return callback
+
+
+
+    async def subscribe_Heartbeat(self, exchange, Heartbeat_handler):
+        """
+        Args:
+            exchange: string
+            Heartbeat_handler: function which takes FContext and i64
+        """
+
+        op = 'Heartbeat'
+        prefix = 'market.{}.'.format(exchange)
+        topic = '{}Ticks{}{}'.format(prefix, self._DELIMITER, op)
+
+        transport, protocol_factory = self._provider.new_subscriber()
+        await transport.subscribe(topic, batch_frame_callback(self._recv_Heartbeat(protocol_factory, op, Heartbeat_handler)))
+        return FSubscription(topic, transport)
+
+    def _recv_Heartbeat(self, protocol_factory, op, handler):
+        method = Method(handler, self._middleware)
+
+        async def callback(transport):
+            iprot = protocol_factory.get_protocol(transport)
+            ctx = iprot.read_request_headers()
+            mname, _, _ = iprot.readMessageBegin()
+            if mname != op:
+                iprot.skip(TType.STRUCT)
+                iprot.readMessageEnd()
+                raise TApplicationException(TApplicationExceptionType.UNKNOWN_METHOD)
+            req = iprot.readI64()
+            iprot.readMessageEnd()
+            try:
+                ret = method([ctx, req])
+                if inspect.iscoroutine(ret):
+                    await ret
+            except:
+                traceback.print_exc()
+                sys.exit(1)
+
+        return callback
+
+
+
+
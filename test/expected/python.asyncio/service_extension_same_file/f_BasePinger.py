@@ -14,6 +14,7 @@ from frugal.aio.processor import FBaseProcessor
 from frugal.aio.processor import FProcessorFunction
 from frugal.exceptions import TApplicationExceptionType
 from frugal.exceptions import TTransportExceptionType
+from frugal.metadata import FContractMetadata
 from frugal.middleware import Method
 from frugal.transport import TMemoryOutputBuffer
 from frugal.util.deprecate import deprecated
@@ -25,6 +26,11 @@ from .ttypes import *
 
 class Iface(object):
 
+    metadata = FContractMetadata(
+        'service_extension_same_file.frugal', 'service', 'BasePinger',
+        '1c203dc893a0f82649484a4f872a9ddae6d1909e42cc2fb3df4ff20951e2f1f5', '2.23.0',
+        ['basePing'])
+
     async def basePing(self, ctx):
         """
         Args:
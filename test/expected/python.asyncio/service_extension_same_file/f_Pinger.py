@@ -14,6 +14,7 @@ from frugal.aio.processor import FBaseProcessor
 from frugal.aio.processor import FProcessorFunction
 from frugal.exceptions import TApplicationExceptionType
 from frugal.exceptions import TTransportExceptionType
+from frugal.metadata import FContractMetadata
 from frugal.middleware import Method
 from frugal.transport import TMemoryOutputBuffer
 from frugal.util.deprecate import deprecated
@@ -26,6 +27,11 @@ from .ttypes import *
 
 class Iface(f_BasePinger.Iface):
 
+    metadata = FContractMetadata(
+        'service_extension_same_file.frugal', 'service', 'Pinger',
+        '739cefb920e53046893006d90161d18dace987bfbb9ce5a7b791a0e87dc99275', '2.23.0',
+        ['ping'])
+
     async def ping(self, ctx):
         """
         Args:
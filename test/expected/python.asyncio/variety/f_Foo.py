@@ -14,6 +14,7 @@ from frugal.aio.processor import FBaseProcessor
 from frugal.aio.processor import FProcessorFunction
 from frugal.exceptions import TApplicationExceptionType
 from frugal.exceptions import TTransportExceptionType
+from frugal.metadata import FContractMetadata
 from frugal.middleware import Method
 from frugal.transport import TMemoryOutputBuffer
 from frugal.util.deprecate import deprecated
@@ -38,6 +39,11 @@ class Iface(actual_base.python.f_BaseFoo.Iface):
     a frugal Context for each service call.
     """
 
+    metadata = FContractMetadata(
+        'variety.frugal', 'service', 'Foo',
+        'a193743f25c5aede9ade7f9d0d5c99adc48638d0db1499acfcdd8f4fd9adb80b', '2.23.0',
+        ['Ping', 'blah', 'oneWay', 'bin_method', 'param_modifiers', 'underlying_types_test', 'getThing', 'getMyInt', 'use_subdir_struct', 'sayHelloWith', 'whatDoYouSay', 'sayAgain'])
+
     @deprecated
     async def Ping(self, ctx):
         """
@@ -1264,6 +1270,24 @@ class blah_result(object):
         self.awe = awe
         self.api = api
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
+    def is_set_awe(self):
+        return self.awe is not None
+
+    def unset_awe(self):
+        self.awe = None
+
+    def is_set_api(self):
+        return self.api is not None
+
+    def unset_api(self):
+        self.api = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -1484,6 +1508,18 @@ class bin_method_result(object):
         self.success = success
         self.api = api
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
+    def is_set_api(self):
+        return self.api is not None
+
+    def unset_api(self):
+        self.api = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -1629,6 +1665,12 @@ class param_modifiers_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -1765,6 +1807,12 @@ class underlying_types_test_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -1864,6 +1912,12 @@ class getThing_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -1956,6 +2010,12 @@ class getMyInt_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -2065,6 +2125,12 @@ class use_subdir_struct_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -2174,6 +2240,12 @@ class sayHelloWith_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -2282,6 +2354,12 @@ class whatDoYouSay_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -2390,6 +2468,12 @@ class sayAgain_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
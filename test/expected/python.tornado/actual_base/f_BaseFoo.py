@@ -11,6 +11,7 @@ from threading import Lock
 
 from frugal.exceptions import TApplicationExceptionType
 from frugal.exceptions import TTransportExceptionType
+from frugal.metadata import FContractMetadata
 from frugal.middleware import Method
 from frugal.tornado.processor import FBaseProcessor
 from frugal.tornado.processor import FProcessorFunction
@@ -27,6 +28,11 @@ from .ttypes import *
 
 class Iface(object):
 
+    metadata = FContractMetadata(
+        'base.frugal', 'service', 'BaseFoo',
+        '4516af8e48fd0b4a04bd7d4e0e10d58b72c50666e67f02ffab8a7f02dea3d408', '2.23.0',
+        ['basePing'])
+
     def basePing(self, ctx):
         """
         Args:
@@ -0,0 +1,187 @@
+#
+# Autogenerated by Frugal Compiler (2.23.0)
+#
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+#
+
+
+
+import sys
+import traceback
+
+from thrift.Thrift import TApplicationException
+from thrift.Thrift import TMessageType
+from thrift.Thrift import TType
+from tornado import gen
+from frugal.exceptions import TApplicationExceptionType
+from frugal.metadata import FContractMetadata
+from frugal.middleware import Method
+from frugal.subscription import FSubscription
+from frugal.transport import TMemoryOutputBuffer
+from frugal.transport import batch_frame
+
+from .ttypes import *
+
+
+
+
+class TicksPublisher(object):
+    """
+    Market data ticks, published in batches.
+    """
+
+    _DELIMITER = '.'
+
+    metadata = FContractMetadata(
+        'batch_publish.frugal', 'scope', 'Ticks',
+        '298b5c8a6925dea29dce346710555efe5fe6c46147b413707493b80c1ad6b0c9', '2.23.0',
+        ['TickReceived', 'Heartbeat'])
+
+    def __init__(self, provider, middleware=None):
+        """
+        Create a new TicksPublisher.
+
+        Args:
+            provider: FScopeProvider
+            middleware: ServiceMiddleware or list of ServiceMiddleware
+        """
+
+        middleware = middleware or []
+        if middleware and not isinstance(middleware, list):
+            middleware = [middleware]
+        middleware += provider.get_middleware()
+        self._transport, self._protocol_factory = provider.new_publisher()
+        self._methods = {
+            'publish_TickReceived': Method(self._publish_TickReceived, middleware),
+            'publish_TickReceived_batch': Method(self._publish_TickReceived_batch, middleware),
+            'publish_Heartbeat': Method(self._publish_Heartbeat, middleware),
+            'publish_Heartbeat_batch': Method(self._publish_Heartbeat_batch, middleware),
+        }
+
+    @gen.coroutine
+    def open(self):
+        yield self._transport.open()
+
+    @gen.coroutine
+    def close(self):
+        yield self._transport.close()
+
+    @gen.coroutine
+    def publish_TickReceived(self, ctx, exchange, req):
+        """
+        Args:
+            ctx: FContext
+            exchange: string
+            req: Tick
+        """
+        yield self._methods['publish_TickReceived']([ctx, exchange, req])
+
+    @gen.coroutine
+    def _publish_TickReceived(self, ctx, exchange, req):
+        ctx.set_request_header('_topic_exchange', exchange)
+        op = 'TickReceived'
+        prefix = 'market.{}.'.format(exchange)
+        topic = '{}Ticks{}{}'.format(prefix, self._DELIMITER, op)
+        buffer = TMemoryOutputBuffer(self._transport.get_publish_size_limit())
+        oprot = self._protocol_factory.get_protocol(buffer)
+        oprot.write_request_headers(ctx)
+        oprot.writeMessageBegin(op, TMessageType.CALL, 0)
+        req.write(oprot)
+        oprot.writeMessageEnd()
+        yield self._transport.publish(topic, buffer.getvalue())
+
+
+    @gen.coroutine
+    def publish_TickReceived_batch(self, ctx, exchange, reqs):
+        """
+        Publishes the messages in a single frame, sent with one transport flush.
+        Subscribers must be generated with the batch_publish option to receive
+        them.
+        
+        Args:
+            ctx: FContext
+            exchange: string
+            reqs: list of Tick
+        """
+        yield self._methods['publish_TickReceived_batch']([ctx, exchange, reqs])
+
+    @gen.coroutine
+    def _publish_TickReceived_batch(self, ctx, exchange, reqs):
+        if not reqs:
+            return
+        ctx.set_request_header('_topic_exchange', exchange)
+        op = 'TickReceived'
+        prefix = 'market.{}.'.format(exchange)
+        topic = '{}Ticks{}{}'.format(prefix, self._DELIMITER, op)
+        limit = self._transport.get_publish_size_limit()
+        frames = []
+        for req in reqs:
+            buffer = TMemoryOutputBuffer(limit)
+            oprot = self._protocol_factory.get_protocol(buffer)
+            oprot.write_request_headers(ctx)
+            oprot.writeMessageBegin(op, TMessageType.CALL, 0)
+            req.write(oprot)
+            oprot.writeMessageEnd()
+            frames.append(buffer.getvalue())
+        yield self._transport.publish(topic, batch_frame(topic, frames, limit))
+
+
+    @gen.coroutine
+    def publish_Heartbeat(self, ctx, exchange, req):
+        """
+        Args:
+            ctx: FContext
+            exchange: string
+            req: i64
+        """
+        yield self._methods['publish_Heartbeat']([ctx, exchange, req])
+
+    @gen.coroutine
+    def _publish_Heartbeat(self, ctx, exchange, req):
+        ctx.set_request_header('_topic_exchange', exchange)
+        op = 'Heartbeat'
+        prefix = 'market.{}.'.format(exchange)
+        topic = '{}Ticks{}{}'.format(prefix, self._DELIMITER, op)
+        buffer = TMemoryOutputBuffer(self._transport.get_publish_size_limit())
+        oprot = self._protocol_factory.get_protocol(buffer)
+        oprot.write_request_headers(ctx)
+        oprot.writeMessageBegin(op, TMessageType.CALL, 0)
+        oprot.writeI64(req)
+        oprot.writeMessageEnd()
+        yield self._transport.publish(topic, buffer.getvalue())
+
+
+    @gen.coroutine
+    def publish_Heartbeat_batch(self, ctx, exchange, reqs):
+        """
+        Publishes the messages in a single frame, sent with one transport flush.
+        Subscribers must be generated with the batch_publish option to receive
+        them.
+        
+        Args:
+            ctx: FContext
+            exchange: string
+            reqs: list of i64
+        """
+        yield self._methods['publish_Heartbeat_batch']([ctx, exchange, reqs])
+
+    @gen.coroutine
+    def _publish_Heartbeat_batch(self, ctx, exchange, reqs):
+        if not reqs:
+            return
+        ctx.set_request_header('_topic_exchange', exchange)
+        op = 'Heartbeat'
+        prefix = 'market.{}.'.format(exchange)
+        topic = '{}Ticks{}{}'.format(prefix, self._DELIMITER, op)
+        limit = self._transport.get_publish_size_limit()
+        frames = []
+        for req in reqs:
+            buffer = TMemoryOutputBuffer(limit)
+            oprot = self._protocol_factory.get_protocol(buffer)
+            oprot.write_request_headers(ctx)
+            oprot.writeMessageBegin(op, TMessageType.CALL, 0)
+            oprot.writeI64(req)
+            oprot.writeMessageEnd()
+            frames.append(buffer.getvalue())
+        yield self._transport.publish(topic, batch_frame(topic, frames, limit))
+
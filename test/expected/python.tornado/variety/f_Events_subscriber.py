@@ -14,6 +14,7 @@ from thrift.Thrift import TMessageType
 from thrift.Thrift import TType
 from tornado import gen
 from frugal.exceptions import TApplicationExceptionType
+from frugal.metadata import FContractMetadata
 from frugal.middleware import Method
 from frugal.subscription import FSubscription
 from frugal.transport import TMemoryOutputBuffer
@@ -32,6 +33,14 @@ class EventsSubscriber(object):
 
     _DELIMITER = '.'
 
+    metadata = FContractMetadata(
+        'variety.frugal', 'scope', 'Events',
+        '660b9d3624d5677b532f8f7b53a07b9cbfd10edb64f73a24c4858e545e47098e', '2.23.0',
+        ['EventCreated', 'SomeInt', 'SomeStr', 'SomeList'],
+        {
+            'EventCreated': "This is a docstring.",
+        })
+
     def __init__(self, provider, middleware=None):
         """
         Create a new EventsSubscriber.
@@ -11,6 +11,7 @@ from threading import Lock
 
 from frugal.exceptions import TApplicationExceptionType
 from frugal.exceptions import TTransportExceptionType
+from frugal.metadata import FContractMetadata
 from frugal.middleware import Method
 from frugal.tornado.processor import FBaseProcessor
 from frugal.tornado.processor import FProcessorFunction
@@ -40,6 +41,11 @@ class Iface(actual_base.python.f_BaseFoo.Iface):
     a frugal Context for each service call.
     """
 
+    metadata = FContractMetadata(
+        'variety.frugal', 'service', 'Foo',
+        'a193743f25c5aede9ade7f9d0d5c99adc48638d0db1499acfcdd8f4fd9adb80b', '2.23.0',
+        ['Ping', 'blah', 'oneWay', 'bin_method', 'param_modifiers', 'underlying_types_test', 'getThing', 'getMyInt', 'use_subdir_struct', 'sayHelloWith', 'whatDoYouSay', 'sayAgain'])
+
     @deprecated
     def Ping(self, ctx):
         """
@@ -1246,6 +1252,24 @@ class blah_result(object):
         self.awe = awe
         self.api = api
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
+    def is_set_awe(self):
+        return self.awe is not None
+
+    def unset_awe(self):
+        self.awe = None
+
+    def is_set_api(self):
+        return self.api is not None
+
+    def unset_api(self):
+        self.api = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -1466,6 +1490,18 @@ class bin_method_result(object):
         self.success = success
         self.api = api
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
+    def is_set_api(self):
+        return self.api is not None
+
+    def unset_api(self):
+        self.api = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -1611,6 +1647,12 @@ class param_modifiers_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -1747,6 +1789,12 @@ class underlying_types_test_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -1846,6 +1894,12 @@ class getThing_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -1938,6 +1992,12 @@ class getMyInt_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -2047,6 +2107,12 @@ class use_subdir_struct_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -2156,6 +2222,12 @@ class sayHelloWith_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -2264,6 +2336,12 @@ class whatDoYouSay_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -2372,6 +2450,12 @@ class sayAgain_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
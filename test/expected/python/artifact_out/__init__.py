@@ -0,0 +1,6 @@
+from .f_Admin import Client as FAdminClient
+from .f_Admin import Iface as FAdminIface
+from .f_Base import Client as FBaseClient
+from .f_Base import Iface as FBaseIface
+from .f_Invoices import Client as FInvoicesClient
+from .f_Invoices import Iface as FInvoicesIface
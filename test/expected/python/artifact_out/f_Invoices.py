@@ -0,0 +1,281 @@
+#
+# Autogenerated by Frugal Compiler (2.23.0)
+#
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+#
+
+
+
+import asyncio
+from datetime import timedelta
+import inspect
+
+from frugal.aio.processor import FBaseProcessor
+from frugal.aio.processor import FProcessorFunction
+from frugal.exceptions import TApplicationExceptionType
+from frugal.exceptions import TTransportExceptionType
+from frugal.metadata import FContractMetadata
+from frugal.middleware import Method
+from frugal.transport import TMemoryOutputBuffer
+from frugal.util.deprecate import deprecated
+from thrift.Thrift import TApplicationException
+from thrift.Thrift import TMessageType
+from thrift.transport.TTransport import TTransportException
+from . import f_Base
+from filter.ttypes import *
+
+
+class Iface(f_Base.Iface):
+
+    metadata = FContractMetadata(
+        'filter.frugal', 'service', 'Invoices',
+        'f7dd4286e7ef8956e463fd9224912b33eed00f346611a345c7f1d961042c5cf7', '2.23.0',
+        ['getInvoice'])
+
+    async def getInvoice(self, ctx, id):
+        """
+        Args:
+            ctx: FContext
+            id: string
+        """
+        pass
+
+
+class Client(f_Base.Client, Iface):
+
+    def __init__(self, provider, middleware=None):
+        """
+        Create a new Client with an FServiceProvider containing a transport
+        and protocol factory.
+
+        Args:
+            provider: FServiceProvider
+            middleware: ServiceMiddleware or list of ServiceMiddleware
+        """
+        middleware = middleware or []
+        if middleware and not isinstance(middleware, list):
+            middleware = [middleware]
+        super(Client, self).__init__(provider, middleware=middleware)
+        middleware += provider.get_middleware()
+        self._methods.update({
+            'getInvoice': Method(self._getInvoice, middleware),
+        })
+
+    async def getInvoice(self, ctx, id):
+        """
+        Args:
+            ctx: FContext
+            id: string
+        """
+        return await self._methods['getInvoice']([ctx, id])
+
+    async def _getInvoice(self, ctx, id):
+        memory_buffer = TMemoryOutputBuffer(self._transport.get_request_size_limit())
+        oprot = self._protocol_factory.get_protocol(memory_buffer)
+        oprot.write_request_headers(ctx)
+        oprot.writeMessageBegin('getInvoice', TMessageType.CALL, 0)
+        args = getInvoice_args()
+        args.id = id
+        args.write(oprot)
+        oprot.writeMessageEnd()
+        response_transport = await self._transport.request(ctx, memory_buffer.getvalue())
+
+        iprot = self._protocol_factory.get_protocol(response_transport)
+        iprot.read_response_headers(ctx)
+        _, mtype, _ = iprot.readMessageBegin()
+        if mtype == TMessageType.EXCEPTION:
+            x = TApplicationException()
+            x.read(iprot)
+            iprot.readMessageEnd()
+            if x.type == TApplicationExceptionType.RESPONSE_TOO_LARGE:
+                raise TTransportException(type=TTransportExceptionType.RESPONSE_TOO_LARGE, message=x.message)
+            raise x
+        result = getInvoice_result()
+        result.read(iprot)
+        iprot.readMessageEnd()
+        if result.success is not None:
+            return result.success
+        raise TApplicationException(TApplicationExceptionType.MISSING_RESULT, "getInvoice failed: unknown result")
+
+
+class Processor(f_Base.Processor):
+
+    def __init__(self, handler, middleware=None):
+        """
+        Create a new Processor.
+
+        Args:
+            handler: Iface
+        """
+        if middleware and not isinstance(middleware, list):
+            middleware = [middleware]
+
+        super(Processor, self).__init__(handler, middleware=middleware)
+        self.add_to_processor_map('getInvoice', _getInvoice(Method(handler.getInvoice, middleware), self.get_write_lock()))
+
+
+class _getInvoice(FProcessorFunction):
+
+    def __init__(self, handler, lock):
+        super(_getInvoice, self).__init__(handler, lock)
+
+    async def process(self, ctx, iprot, oprot):
+        args = getInvoice_args()
+        args.read(iprot)
+        iprot.readMessageEnd()
+        result = getInvoice_result()
+        try:
+            ret = self._handler([ctx, args.id])
+            if inspect.iscoroutine(ret):
+                ret = await ret
+            result.success = ret
+        except TApplicationException as ex:
+            async with self._lock:
+                _write_application_exception(ctx, oprot, "getInvoice", exception=ex)
+                return
+        except Exception as e:
+            async with self._lock:
+                _write_application_exception(ctx, oprot, "getInvoice", ex_code=TApplicationExceptionType.INTERNAL_ERROR, message=str(e))
+            raise
+        async with self._lock:
+            try:
+                oprot.write_response_headers(ctx)
+                oprot.writeMessageBegin('getInvoice', TMessageType.REPLY, 0)
+                result.write(oprot)
+                oprot.writeMessageEnd()
+                oprot.get_transport().flush()
+            except TTransportException as e:
+                # catch a request too large error because the TMemoryOutputBuffer always throws that if too much data is written
+                if e.type == TTransportExceptionType.REQUEST_TOO_LARGE:
+                    raise _write_application_exception(ctx, oprot, "getInvoice", ex_code=TApplicationExceptionType.RESPONSE_TOO_LARGE, message=e.message)
+                else:
+                    raise e
+
+
+def _write_application_exception(ctx, oprot, method, ex_code=None, message=None, exception=None):
+    if exception is not None:
+        x = exception
+    else:
+        x = TApplicationException(type=ex_code, message=message)
+    oprot.write_response_headers(ctx)
+    oprot.writeMessageBegin(method, TMessageType.EXCEPTION, 0)
+    x.write(oprot)
+    oprot.writeMessageEnd()
+    oprot.get_transport().flush()
+    return x
+
+class getInvoice_args(object):
+    """
+    Attributes:
+     - id
+    """
+    def __init__(self, id=None):
+        self.id = id
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.STRING:
+                    self.id = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('getInvoice_args')
+        if self.id is not None:
+            oprot.writeFieldBegin('id', TType.STRING, 1)
+            oprot.writeString(self.id)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.id))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
+class getInvoice_result(object):
+    """
+    Attributes:
+     - success
+    """
+    def __init__(self, success=None):
+        self.success = success
+
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 0:
+                if ftype == TType.STRUCT:
+                    self.success = Invoice()
+                    self.success.read(iprot)
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('getInvoice_result')
+        if self.success is not None:
+            oprot.writeFieldBegin('success', TType.STRUCT, 0)
+            self.success.write(oprot)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.success))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
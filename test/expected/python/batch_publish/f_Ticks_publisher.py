@@ -0,0 +1,167 @@
+#
+# Autogenerated by Frugal Compiler (2.23.0)
+#
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+#
+
+
+
+from thrift.Thrift import TMessageType
+from frugal.metadata import FContractMetadata
+from frugal.middleware import Method
+from frugal.transport import TMemoryOutputBuffer
+from frugal.transport import batch_frame
+
+
+
+
+class TicksPublisher(object):
+    """
+    Market data ticks, published in batches.
+    """
+
+    _DELIMITER = '.'
+
+    metadata = FContractMetadata(
+        'batch_publish.frugal', 'scope', 'Ticks',
+        '298b5c8a6925dea29dce346710555efe5fe6c46147b413707493b80c1ad6b0c9', '2.23.0',
+        ['TickReceived', 'Heartbeat'])
+
+    def __init__(self, provider, middleware=None):
+        """
+        Create a new TicksPublisher.
+
+        Args:
+            provider: FScopeProvider
+            middleware: ServiceMiddleware or list of ServiceMiddleware
+        """
+
+        middleware = middleware or []
+        if middleware and not isinstance(middleware, list):
+            middleware = [middleware]
+        middleware += provider.get_middleware()
+        self._transport, self._protocol_factory = provider.new_publisher()
+        self._methods = {
+            'publish_TickReceived': Method(self._publish_TickReceived, middleware),
+            'publish_TickReceived_batch': Method(self._publish_TickReceived_batch, middleware),
+            'publish_Heartbeat': Method(self._publish_Heartbeat, middleware),
+            'publish_Heartbeat_batch': Method(self._publish_Heartbeat_batch, middleware),
+        }
+
+    def open(self):
+        self._transport.open()
+
+    def close(self):
+        self._transport.close()
+
+    def publish_TickReceived(self, ctx, exchange, req):
+        """
+        Args:
+            ctx: FContext
+            exchange: string
+            req: Tick
+        """
+        self._methods['publish_TickReceived']([ctx, exchange, req])
+
+    def _publish_TickReceived(self, ctx, exchange, req):
+        ctx.set_request_header('_topic_exchange', exchange)
+        op = 'TickReceived'
+        prefix = 'market.{}.'.format(exchange)
+        topic = '{}Ticks{}{}'.format(prefix, self._DELIMITER, op)
+        buffer = TMemoryOutputBuffer(self._transport.get_publish_size_limit())
+        oprot = self._protocol_factory.get_protocol(buffer)
+        oprot.write_request_headers(ctx)
+        oprot.writeMessageBegin(op, TMessageType.CALL, 0)
+        req.write(oprot)
+        oprot.writeMessageEnd()
+        self._transport.publish(topic, buffer.getvalue())
+
+
+    def publish_TickReceived_batch(self, ctx, exchange, reqs):
+        """
+        Publishes the messages in a single frame, sent with one transport flush.
+        Subscribers must be generated with the batch_publish option to receive
+        them.
+        
+        Args:
+            ctx: FContext
+            exchange: string
+            reqs: list of Tick
+        """
+        self._methods['publish_TickReceived_batch']([ctx, exchange, reqs])
+
+    def _publish_TickReceived_batch(self, ctx, exchange, reqs):
+        if not reqs:
+            return
+        ctx.set_request_header('_topic_exchange', exchange)
+        op = 'TickReceived'
+        prefix = 'market.{}.'.format(exchange)
+        topic = '{}Ticks{}{}'.format(prefix, self._DELIMITER, op)
+        limit = self._transport.get_publish_size_limit()
+        frames = []
+        for req in reqs:
+            buffer = TMemoryOutputBuffer(limit)
+            oprot = self._protocol_factory.get_protocol(buffer)
+            oprot.write_request_headers(ctx)
+            oprot.writeMessageBegin(op, TMessageType.CALL, 0)
+            req.write(oprot)
+            oprot.writeMessageEnd()
+            frames.append(buffer.getvalue())
+        self._transport.publish(topic, batch_frame(topic, frames, limit))
+
+
+    def publish_Heartbeat(self, ctx, exchange, req):
+        """
+        Args:
+            ctx: FContext
+            exchange: string
+            req: i64
+        """
+        self._methods['publish_Heartbeat']([ctx, exchange, req])
+
+    def _publish_Heartbeat(self, ctx, exchange, req):
+        ctx.set_request_header('_topic_exchange', exchange)
+        op = 'Heartbeat'
+        prefix = 'market.{}.'.format(exchange)
+        topic = '{}Ticks{}{}'.format(prefix, self._DELIMITER, op)
+        buffer = TMemoryOutputBuffer(self._transport.get_publish_size_limit())
+        oprot = self._protocol_factory.get_protocol(buffer)
+        oprot.write_request_headers(ctx)
+        oprot.writeMessageBegin(op, TMessageType.CALL, 0)
+        oprot.writeI64(req)
+        oprot.writeMessageEnd()
+        self._transport.publish(topic, buffer.getvalue())
+
+
+    def publish_Heartbeat_batch(self, ctx, exchange, reqs):
+        """
+        Publishes the messages in a single frame, sent with one transport flush.
+        Subscribers must be generated with the batch_publish option to receive
+        them.
+        
+        Args:
+            ctx: FContext
+            exchange: string
+            reqs: list of i64
+        """
+        self._methods['publish_Heartbeat_batch']([ctx, exchange, reqs])
+
+    def _publish_Heartbeat_batch(self, ctx, exchange, reqs):
+        if not reqs:
+            return
+        ctx.set_request_header('_topic_exchange', exchange)
+        op = 'Heartbeat'
+        prefix = 'market.{}.'.format(exchange)
+        topic = '{}Ticks{}{}'.format(prefix, self._DELIMITER, op)
+        limit = self._transport.get_publish_size_limit()
+        frames = []
+        for req in reqs:
+            buffer = TMemoryOutputBuffer(limit)
+            oprot = self._protocol_factory.get_protocol(buffer)
+            oprot.write_request_headers(ctx)
+            oprot.writeMessageBegin(op, TMessageType.CALL, 0)
+            oprot.writeI64(req)
+            oprot.writeMessageEnd()
+            frames.append(buffer.getvalue())
+        self._transport.publish(topic, batch_frame(topic, frames, limit))
+
@@ -8,6 +8,7 @@
 
 from threading import Lock
 
+from frugal.metadata import FContractMetadata
 from frugal.middleware import Method
 from frugal.exceptions import TApplicationExceptionType
 from frugal.exceptions import TTransportExceptionType
@@ -27,6 +28,11 @@ from .ttypes import *
 
 class Iface(generic_package_prefix.actual_base.python.f_BaseFoo.Iface):
 
+    metadata = FContractMetadata(
+        'service_inheritance.frugal', 'service', 'Foo',
+        '649f2c7309e680777c713a2a8af7fb1c5946bc45441d9aa1fb41f6be52aa3e5d', '2.23.0',
+        ['get_thing'])
+
     def get_thing(self, ctx, the_thing):
         """
         Args:
@@ -224,6 +230,12 @@ class get_thing_result(object):
     def __init__(self, success=None):
         self.success = success
 
+    def is_set_success(self):
+        return self.success is not None
+
+    def unset_success(self):
+        self.success = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
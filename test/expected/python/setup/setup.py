@@ -0,0 +1,13 @@
+# Autogenerated by Frugal Compiler (2.23.0)
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+from setuptools import setup, find_packages
+
+setup(
+    name='event-models',
+    version='2.23.0',
+    packages=find_packages(),
+    install_requires=[
+        'frugal[asyncio]==2.23.0',
+    ],
+)
@@ -352,6 +352,48 @@ class TestingDefaults(object):
         self.status = status
         self.base_status = base_status
 
+    def is_set_ID2(self):
+        return self.ID2 is not None
+
+    def unset_ID2(self):
+        self.ID2 = None
+
+    def is_set_thing2(self):
+        return self.thing2 is not None
+
+    def unset_thing2(self):
+        self.thing2 = None
+
+    def is_set_bin_field2(self):
+        return self.bin_field2 is not None
+
+    def unset_bin_field2(self):
+        self.bin_field2 = None
+
+    def is_set_bin_field4(self):
+        return self.bin_field4 is not None
+
+    def unset_bin_field4(self):
+        self.bin_field4 = None
+
+    def is_set_list2(self):
+        return self.list2 is not None
+
+    def unset_list2(self):
+        self.list2 = None
+
+    def is_set_list3(self):
+        return self.list3 is not None
+
+    def unset_list3(self):
+        self.list3 = None
+
+    def is_set_a_map(self):
+        return self.a_map is not None
+
+    def unset_a_map(self):
+        self.a_map = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -652,6 +694,12 @@ class EventWrapper(object):
         self.deprBinary = deprBinary
         self.deprList = deprList
 
+    def is_set_ID(self):
+        return self.ID is not None
+
+    def unset_ID(self):
+        self.ID = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
@@ -982,6 +1030,48 @@ class TestingUnions(object):
         self.bin_field_in_union = bin_field_in_union
         self.depr = depr
 
+    def is_set_AnID(self):
+        return self.AnID is not None
+
+    def unset_AnID(self):
+        self.AnID = None
+
+    def is_set_aString(self):
+        return self.aString is not None
+
+    def unset_aString(self):
+        self.aString = None
+
+    def is_set_someotherthing(self):
+        return self.someotherthing is not None
+
+    def unset_someotherthing(self):
+        self.someotherthing = None
+
+    def is_set_AnInt16(self):
+        return self.AnInt16 is not None
+
+    def unset_AnInt16(self):
+        self.AnInt16 = None
+
+    def is_set_Requests(self):
+        return self.Requests is not None
+
+    def unset_Requests(self):
+        self.Requests = None
+
+    def is_set_bin_field_in_union(self):
+        return self.bin_field_in_union is not None
+
+    def unset_bin_field_in_union(self):
+        self.bin_field_in_union = None
+
+    def is_set_depr(self):
+        return self.depr is not None
+
+    def unset_depr(self):
+        self.depr = None
+
     def read(self, iprot):
         iprot.readStructBegin()
         while True:
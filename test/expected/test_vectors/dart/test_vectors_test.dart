@@ -0,0 +1,49 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:convert' show BASE64;
+import 'dart:typed_data' show Uint8List;
+
+import 'package:test/test.dart';
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:test_vectors/test_vectors.dart' as t_test_vectors;
+
+/// Decodes the vector into the value with the protocol and checks that
+/// writing the value with the binary protocol produces the binary vector.
+void checkTestVector(String binary, thrift.TProtocolFactory factory, String vector, thrift.TBase value) {
+  new thrift.TDeserializer(protocolFactory: factory).read(value, new Uint8List.fromList(BASE64.decode(vector)));
+  var written = new thrift.TSerializer(protocolFactory: new thrift.TBinaryProtocolFactory()).write(value);
+  expect(written, equals(BASE64.decode(binary)));
+}
+
+void main() {
+  test('Node test vectors', () {
+    var binary = 'CwABAAAABG5hbWUPAAMMAAAAAAA=';
+    checkTestVector(binary, new thrift.TBinaryProtocolFactory(), binary, new t_test_vectors.Node());
+    checkTestVector(binary, new thrift.TCompactProtocolFactory(), 'GARuYW1lKQwA', new t_test_vectors.Node());
+    checkTestVector(binary, new thrift.TJsonProtocolFactory(), 'eyIxIjp7InN0ciI6Im5hbWUifSwiMyI6eyJsc3QiOlsicmVjIiwwXX19', new t_test_vectors.Node());
+  });
+  test('Sample test vectors', () {
+    var binary = 'AgABAQMAAgIGAAMAAwgABAAAAAQKAAUAAAAAAAAABQQABkAaAAAAAAAACwAHAAAABWxhYmVsCwAIAAAAAwECAwgACQAAAAEPAAoIAAAAAQAAAAoOAAsLAAAAAQAAAAR0YWdzDQAMCwwAAAABAAAABW5vZGVzCwABAAAABG5hbWUPAAMMAAAAAAAMABQIAAEAAAABCwACAAAACGFfc3RyaW5nAAgAFQAAAAECABYBAA==';
+    checkTestVector(binary, new thrift.TBinaryProtocolFactory(), binary, new t_test_vectors.Sample());
+    checkTestVector(binary, new thrift.TCompactProtocolFactory(), 'ERMCFAYVCBYKFwAAAAAAABpAGAVsYWJlbBgDAQIDFQIZFRQaGAR0YWdzGwGMBW5vZGVzGARuYW1lKQwAjBUCGAhhX3N0cmluZwAVAhEA', new t_test_vectors.Sample());
+    checkTestVector(binary, new thrift.TJsonProtocolFactory(), 'eyIxIjp7InRmIjoxfSwiMiI6eyJpOCI6Mn0sIjMiOnsiaTE2IjozfSwiNCI6eyJpMzIiOjR9LCI1Ijp7Imk2NCI6NX0sIjYiOnsiZGJsIjo2LjV9LCI3Ijp7InN0ciI6ImxhYmVsIn0sIjgiOnsic3RyIjoiQVFJRCJ9LCI5Ijp7ImkzMiI6MX0sIjEwIjp7ImxzdCI6WyJpMzIiLDEsMTBdfSwiMTEiOnsic2V0IjpbInN0ciIsMSwidGFncyJdfSwiMTIiOnsibWFwIjpbInN0ciIsInJlYyIsMSx7Im5vZGVzIjp7IjEiOnsic3RyIjoibmFtZSJ9LCIzIjp7ImxzdCI6WyJyZWMiLDBdfX19XX0sIjIwIjp7InJlYyI6eyIxIjp7ImkzMiI6MX0sIjIiOnsic3RyIjoiYV9zdHJpbmcifX19LCIyMSI6eyJpMzIiOjF9LCIyMiI6eyJ0ZiI6MX19', new t_test_vectors.Sample());
+  });
+  test('Keyed test vectors', () {
+    var binary = 'DQABDAIAAAABCwABAAAABG5hbWUPAAMMAAAAAAABAA==';
+    checkTestVector(binary, new thrift.TBinaryProtocolFactory(), binary, new t_test_vectors.Keyed());
+    checkTestVector(binary, new thrift.TCompactProtocolFactory(), 'GwHBGARuYW1lKQwAAQA=', new t_test_vectors.Keyed());
+  });
+  test('Failure test vectors', () {
+    var binary = 'CwABAAAAB21lc3NhZ2UA';
+    checkTestVector(binary, new thrift.TBinaryProtocolFactory(), binary, new t_test_vectors.Failure());
+    checkTestVector(binary, new thrift.TCompactProtocolFactory(), 'GAdtZXNzYWdlAA==', new t_test_vectors.Failure());
+    checkTestVector(binary, new thrift.TJsonProtocolFactory(), 'eyIxIjp7InN0ciI6Im1lc3NhZ2UifX0=', new t_test_vectors.Failure());
+  });
+  test('Choice test vectors', () {
+    var binary = 'DAABCwABAAAABG5hbWUPAAMMAAAAAAAA';
+    checkTestVector(binary, new thrift.TBinaryProtocolFactory(), binary, new t_test_vectors.Choice());
+    checkTestVector(binary, new thrift.TCompactProtocolFactory(), 'HBgEbmFtZSkMAAA=', new t_test_vectors.Choice());
+    checkTestVector(binary, new thrift.TJsonProtocolFactory(), 'eyIxIjp7InJlYyI6eyIxIjp7InN0ciI6Im5hbWUifSwiMyI6eyJsc3QiOlsicmVjIiwwXX19fX0=', new t_test_vectors.Choice());
+  });
+}
@@ -0,0 +1,73 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+
+
+import org.apache.thrift.TBase;
+import org.apache.thrift.TDeserializer;
+import org.apache.thrift.TException;
+import org.apache.thrift.TSerializer;
+import org.apache.thrift.protocol.TBinaryProtocol;
+import org.apache.thrift.protocol.TCompactProtocol;
+import org.apache.thrift.protocol.TJSONProtocol;
+import org.apache.thrift.protocol.TProtocolFactory;
+import org.junit.Test;
+
+import java.util.Base64;
+
+import static org.junit.Assert.assertArrayEquals;
+
+public class TestVectorsTest {
+
+	/**
+	 * Decodes the vector into the value with the protocol and checks that
+	 * writing the value with the binary protocol produces the binary vector.
+	 */
+	private static void checkTestVector(String binary, TProtocolFactory factory, String vector, TBase value) throws TException {
+		new TDeserializer(factory).deserialize(value, Base64.getDecoder().decode(vector));
+		byte[] written = new TSerializer(new TBinaryProtocol.Factory()).serialize(value);
+		assertArrayEquals(Base64.getDecoder().decode(binary), written);
+	}
+
+	@Test
+	public void testNodeTestVectors() throws TException {
+		String binary = "CwABAAAABG5hbWUPAAMMAAAAAAA=";
+		checkTestVector(binary, new TBinaryProtocol.Factory(), binary, new Node());
+		checkTestVector(binary, new TCompactProtocol.Factory(), "GARuYW1lKQwA", new Node());
+		checkTestVector(binary, new TJSONProtocol.Factory(), "eyIxIjp7InN0ciI6Im5hbWUifSwiMyI6eyJsc3QiOlsicmVjIiwwXX19", new Node());
+	}
+
+	@Test
+	public void testSampleTestVectors() throws TException {
+		String binary = "AgABAQMAAgIGAAMAAwgABAAAAAQKAAUAAAAAAAAABQQABkAaAAAAAAAACwAHAAAABWxhYmVsCwAIAAAAAwECAwgACQAAAAEPAAoIAAAAAQAAAAoOAAsLAAAAAQAAAAR0YWdzDQAMCwwAAAABAAAABW5vZGVzCwABAAAABG5hbWUPAAMMAAAAAAAMABQIAAEAAAABCwACAAAACGFfc3RyaW5nAAgAFQAAAAECABYBAA==";
+		checkTestVector(binary, new TBinaryProtocol.Factory(), binary, new Sample());
+		checkTestVector(binary, new TCompactProtocol.Factory(), "ERMCFAYVCBYKFwAAAAAAABpAGAVsYWJlbBgDAQIDFQIZFRQaGAR0YWdzGwGMBW5vZGVzGARuYW1lKQwAjBUCGAhhX3N0cmluZwAVAhEA", new Sample());
+		checkTestVector(binary, new TJSONProtocol.Factory(), "eyIxIjp7InRmIjoxfSwiMiI6eyJpOCI6Mn0sIjMiOnsiaTE2IjozfSwiNCI6eyJpMzIiOjR9LCI1Ijp7Imk2NCI6NX0sIjYiOnsiZGJsIjo2LjV9LCI3Ijp7InN0ciI6ImxhYmVsIn0sIjgiOnsic3RyIjoiQVFJRCJ9LCI5Ijp7ImkzMiI6MX0sIjEwIjp7ImxzdCI6WyJpMzIiLDEsMTBdfSwiMTEiOnsic2V0IjpbInN0ciIsMSwidGFncyJdfSwiMTIiOnsibWFwIjpbInN0ciIsInJlYyIsMSx7Im5vZGVzIjp7IjEiOnsic3RyIjoibmFtZSJ9LCIzIjp7ImxzdCI6WyJyZWMiLDBdfX19XX0sIjIwIjp7InJlYyI6eyIxIjp7ImkzMiI6MX0sIjIiOnsic3RyIjoiYV9zdHJpbmcifX19LCIyMSI6eyJpMzIiOjF9LCIyMiI6eyJ0ZiI6MX19", new Sample());
+	}
+
+	@Test
+	public void testKeyedTestVectors() throws TException {
+		String binary = "DQABDAIAAAABCwABAAAABG5hbWUPAAMMAAAAAAABAA==";
+		checkTestVector(binary, new TBinaryProtocol.Factory(), binary, new Keyed());
+		checkTestVector(binary, new TCompactProtocol.Factory(), "GwHBGARuYW1lKQwAAQA=", new Keyed());
+	}
+
+	@Test
+	public void testFailureTestVectors() throws TException {
+		String binary = "CwABAAAAB21lc3NhZ2UA";
+		checkTestVector(binary, new TBinaryProtocol.Factory(), binary, new Failure());
+		checkTestVector(binary, new TCompactProtocol.Factory(), "GAdtZXNzYWdlAA==", new Failure());
+		checkTestVector(binary, new TJSONProtocol.Factory(), "eyIxIjp7InN0ciI6Im1lc3NhZ2UifX0=", new Failure());
+	}
+
+	@Test
+	public void testChoiceTestVectors() throws TException {
+		String binary = "DAABCwABAAAABG5hbWUPAAMMAAAAAAAA";
+		checkTestVector(binary, new TBinaryProtocol.Factory(), binary, new Choice());
+		checkTestVector(binary, new TCompactProtocol.Factory(), "HBgEbmFtZSkMAAA=", new Choice());
+		checkTestVector(binary, new TJSONProtocol.Factory(), "eyIxIjp7InJlYyI6eyIxIjp7InN0ciI6Im5hbWUifSwiMyI6eyJsc3QiOlsicmVjIiwwXX19fX0=", new Choice());
+	}
+}
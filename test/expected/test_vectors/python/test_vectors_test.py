@@ -0,0 +1,56 @@
+#
+# Autogenerated by Frugal Compiler (2.23.0)
+#
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+#
+
+import base64
+import unittest
+
+from thrift.protocol.TBinaryProtocol import TBinaryProtocolFactory
+from thrift.protocol.TCompactProtocol import TCompactProtocolFactory
+from thrift.protocol.TJSONProtocol import TJSONProtocolFactory
+from thrift.TSerialization import deserialize, serialize
+
+from .ttypes import *
+
+
+class TestVectorsTest(unittest.TestCase):
+    """
+    Decodes the test vector of each struct, union, and exception with each
+    protocol and checks that writing the decoded value with the binary
+    protocol reproduces the binary vector.
+    """
+
+    def check_test_vector(self, binary, factory, vector, value):
+        deserialize(value, base64.b64decode(vector), factory)
+        self.assertEqual(base64.b64decode(binary), serialize(value, TBinaryProtocolFactory()))
+
+    def test_Node(self):
+        binary = 'CwABAAAABG5hbWUPAAMMAAAAAAA='
+        self.check_test_vector(binary, TBinaryProtocolFactory(), binary, Node())
+        self.check_test_vector(binary, TCompactProtocolFactory(), 'GARuYW1lKQwA', Node())
+        self.check_test_vector(binary, TJSONProtocolFactory(), 'eyIxIjp7InN0ciI6Im5hbWUifSwiMyI6eyJsc3QiOlsicmVjIiwwXX19', Node())
+
+    def test_Sample(self):
+        binary = 'AgABAQMAAgIGAAMAAwgABAAAAAQKAAUAAAAAAAAABQQABkAaAAAAAAAACwAHAAAABWxhYmVsCwAIAAAAAwECAwgACQAAAAEPAAoIAAAAAQAAAAoOAAsLAAAAAQAAAAR0YWdzDQAMCwwAAAABAAAABW5vZGVzCwABAAAABG5hbWUPAAMMAAAAAAAMABQIAAEAAAABCwACAAAACGFfc3RyaW5nAAgAFQAAAAECABYBAA=='
+        self.check_test_vector(binary, TBinaryProtocolFactory(), binary, Sample())
+        self.check_test_vector(binary, TCompactProtocolFactory(), 'ERMCFAYVCBYKFwAAAAAAABpAGAVsYWJlbBgDAQIDFQIZFRQaGAR0YWdzGwGMBW5vZGVzGARuYW1lKQwAjBUCGAhhX3N0cmluZwAVAhEA', Sample())
+        self.check_test_vector(binary, TJSONProtocolFactory(), 'eyIxIjp7InRmIjoxfSwiMiI6eyJpOCI6Mn0sIjMiOnsiaTE2IjozfSwiNCI6eyJpMzIiOjR9LCI1Ijp7Imk2NCI6NX0sIjYiOnsiZGJsIjo2LjV9LCI3Ijp7InN0ciI6ImxhYmVsIn0sIjgiOnsic3RyIjoiQVFJRCJ9LCI5Ijp7ImkzMiI6MX0sIjEwIjp7ImxzdCI6WyJpMzIiLDEsMTBdfSwiMTEiOnsic2V0IjpbInN0ciIsMSwidGFncyJdfSwiMTIiOnsibWFwIjpbInN0ciIsInJlYyIsMSx7Im5vZGVzIjp7IjEiOnsic3RyIjoibmFtZSJ9LCIzIjp7ImxzdCI6WyJyZWMiLDBdfX19XX0sIjIwIjp7InJlYyI6eyIxIjp7ImkzMiI6MX0sIjIiOnsic3RyIjoiYV9zdHJpbmcifX19LCIyMSI6eyJpMzIiOjF9LCIyMiI6eyJ0ZiI6MX19', Sample())
+
+    def test_Keyed(self):
+        binary = 'DQABDAIAAAABCwABAAAABG5hbWUPAAMMAAAAAAABAA=='
+        self.check_test_vector(binary, TBinaryProtocolFactory(), binary, Keyed())
+        self.check_test_vector(binary, TCompactProtocolFactory(), 'GwHBGARuYW1lKQwAAQA=', Keyed())
+
+    def test_Failure(self):
+        binary = 'CwABAAAAB21lc3NhZ2UA'
+        self.check_test_vector(binary, TBinaryProtocolFactory(), binary, Failure())
+        self.check_test_vector(binary, TCompactProtocolFactory(), 'GAdtZXNzYWdlAA==', Failure())
+        self.check_test_vector(binary, TJSONProtocolFactory(), 'eyIxIjp7InN0ciI6Im1lc3NhZ2UifX0=', Failure())
+
+    def test_Choice(self):
+        binary = 'DAABCwABAAAABG5hbWUPAAMMAAAAAAAA'
+        self.check_test_vector(binary, TBinaryProtocolFactory(), binary, Choice())
+        self.check_test_vector(binary, TCompactProtocolFactory(), 'HBgEbmFtZSkMAAA=', Choice())
+        self.check_test_vector(binary, TJSONProtocolFactory(), 'eyIxIjp7InJlYyI6eyIxIjp7InN0ciI6Im5hbWUifSwiMyI6eyJsc3QiOlsicmVjIiwwXX19fX0=', Choice())
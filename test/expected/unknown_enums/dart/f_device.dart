@@ -0,0 +1,353 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:typed_data' show Uint8List;
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:unknown_enums/unknown_enums.dart' as t_unknown_enums;
+
+class Device implements thrift.TBase {
+  static final thrift.TStruct _STRUCT_DESC = new thrift.TStruct("Device");
+  static final thrift.TField _STATUS_FIELD_DESC = new thrift.TField("status", thrift.TType.I32, 1);
+  static final thrift.TField _PREVIOUS_FIELD_DESC = new thrift.TField("previous", thrift.TType.I32, 2);
+  static final thrift.TField _HISTORY_FIELD_DESC = new thrift.TField("history", thrift.TType.LIST, 3);
+  static final thrift.TField _NOTES_FIELD_DESC = new thrift.TField("notes", thrift.TType.MAP, 4);
+
+  int _status;
+  static const int STATUS = 1;
+  int _previous;
+  static const int PREVIOUS = 2;
+  List<int> _history;
+  static const int HISTORY = 3;
+  Map<int, String> _notes;
+  static const int NOTES = 4;
+
+  bool __isset_status = false;
+  bool __isset_previous = false;
+
+  Device() {
+  }
+
+  int get status => this._status;
+
+  set status(int status) {
+    this._status = status;
+    this.__isset_status = true;
+  }
+
+  bool isSetStatus() => this.__isset_status;
+
+  unsetStatus() {
+    this.__isset_status = false;
+  }
+
+  int get previous => this._previous;
+
+  set previous(int previous) {
+    this._previous = previous;
+    this.__isset_previous = true;
+  }
+
+  bool isSetPrevious() => this.__isset_previous;
+
+  unsetPrevious() {
+    this.__isset_previous = false;
+  }
+
+  List<int> get history => this._history;
+
+  set history(List<int> history) {
+    this._history = history;
+  }
+
+  bool isSetHistory() => this.history != null;
+
+  unsetHistory() {
+    this.history = null;
+  }
+
+  Map<int, String> get notes => this._notes;
+
+  set notes(Map<int, String> notes) {
+    this._notes = notes;
+  }
+
+  bool isSetNotes() => this.notes != null;
+
+  unsetNotes() {
+    this.notes = null;
+  }
+
+  getFieldValue(int fieldID) {
+    switch (fieldID) {
+      case STATUS:
+        return this.status;
+      case PREVIOUS:
+        return this.previous;
+      case HISTORY:
+        return this.history;
+      case NOTES:
+        return this.notes;
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  setFieldValue(int fieldID, Object value) {
+    switch(fieldID) {
+      case STATUS:
+        if(value == null) {
+          unsetStatus();
+        } else {
+          this.status = value as int;
+        }
+        break;
+
+      case PREVIOUS:
+        if(value == null) {
+          unsetPrevious();
+        } else {
+          this.previous = value as int;
+        }
+        break;
+
+      case HISTORY:
+        if(value == null) {
+          unsetHistory();
+        } else {
+          this.history = value as List<int>;
+        }
+        break;
+
+      case NOTES:
+        if(value == null) {
+          unsetNotes();
+        } else {
+          this.notes = value as Map<int, String>;
+        }
+        break;
+
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  // Returns true if the field corresponding to fieldID is set (has been assigned a value) and false otherwise
+  bool isSet(int fieldID) {
+    switch(fieldID) {
+      case STATUS:
+        return isSetStatus();
+      case PREVIOUS:
+        return isSetPrevious();
+      case HISTORY:
+        return isSetHistory();
+      case NOTES:
+        return isSetNotes();
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  read(thrift.TProtocol iprot) {
+    thrift.TField field;
+    iprot.readStructBegin();
+    while(true) {
+      field = iprot.readFieldBegin();
+      if(field.type == thrift.TType.STOP) {
+        break;
+      }
+      switch(field.id) {
+        case STATUS:
+          if(field.type == thrift.TType.I32) {
+            status = iprot.readI32();
+            if (!t_unknown_enums.Status.VALID_VALUES.contains(status)) {
+              throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Invalid value '$status' for enum 'Status'");
+            }
+            this.__isset_status = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case PREVIOUS:
+          if(field.type == thrift.TType.I32) {
+            previous = iprot.readI32();
+            if (!t_unknown_enums.Status.VALID_VALUES.contains(previous)) {
+              throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Invalid value '$previous' for enum 'Status'");
+            }
+            this.__isset_previous = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case HISTORY:
+          if(field.type == thrift.TType.LIST) {
+            thrift.TList elem0 = iprot.readListBegin();
+            history = new List<int>();
+            for(int elem2 = 0; elem2 < elem0.length; ++elem2) {
+              int elem1 = iprot.readI32();
+              if (!t_unknown_enums.Status.VALID_VALUES.contains(elem1)) {
+                throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Invalid value '$elem1' for enum 'Status'");
+              }
+              history.add(elem1);
+            }
+            iprot.readListEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case NOTES:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem3 = iprot.readMapBegin();
+            notes = new Map<int, String>();
+            for(int elem5 = 0; elem5 < elem3.length; ++elem5) {
+              int elem6 = iprot.readI32();
+              if (!t_unknown_enums.Status.VALID_VALUES.contains(elem6)) {
+                throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Invalid value '$elem6' for enum 'Status'");
+              }
+              String elem4 = iprot.readString();
+              notes[elem6] = elem4;
+            }
+            iprot.readMapEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, field.type);
+          break;
+      }
+      iprot.readFieldEnd();
+    }
+    iprot.readStructEnd();
+
+    // check for required fields of primitive type, which can't be checked in the validate method
+    validate();
+  }
+
+  write(thrift.TProtocol oprot) {
+    validate();
+
+    oprot.writeStructBegin(_STRUCT_DESC);
+    oprot.writeFieldBegin(_STATUS_FIELD_DESC);
+    oprot.writeI32(status);
+    oprot.writeFieldEnd();
+    if(isSetPrevious()) {
+      oprot.writeFieldBegin(_PREVIOUS_FIELD_DESC);
+      oprot.writeI32(previous);
+      oprot.writeFieldEnd();
+    }
+    if(this.history != null) {
+      oprot.writeFieldBegin(_HISTORY_FIELD_DESC);
+      oprot.writeListBegin(new thrift.TList(thrift.TType.I32, history.length));
+      for(var elem7 in history) {
+        oprot.writeI32(elem7);
+      }
+      oprot.writeListEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.notes != null) {
+      oprot.writeFieldBegin(_NOTES_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.I32, thrift.TType.STRING, notes.length));
+      for(var elem8 in notes.keys) {
+        oprot.writeI32(elem8);
+        oprot.writeString(notes[elem8]);
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldStop();
+    oprot.writeStructEnd();
+  }
+
+  String toString() {
+    StringBuffer ret = new StringBuffer("Device(");
+
+    ret.write("status:");
+    String status_name = t_unknown_enums.Status.VALUES_TO_NAMES[this.status];
+    if(status_name != null) {
+      ret.write(status_name);
+      ret.write(" (");
+    }
+    ret.write(this.status);
+    if(status_name != null) {
+      ret.write(")");
+    }
+
+    if(isSetPrevious()) {
+      ret.write(", ");
+      ret.write("previous:");
+      String previous_name = t_unknown_enums.Status.VALUES_TO_NAMES[this.previous];
+      if(previous_name != null) {
+        ret.write(previous_name);
+        ret.write(" (");
+      }
+      ret.write(this.previous);
+      if(previous_name != null) {
+        ret.write(")");
+      }
+    }
+
+    ret.write(", ");
+    ret.write("history:");
+    if(this.history == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.history);
+    }
+
+    ret.write(", ");
+    ret.write("notes:");
+    if(this.notes == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.notes);
+    }
+
+    ret.write(")");
+
+    return ret.toString();
+  }
+
+  bool operator ==(Object o) {
+    if(o == null || !(o is Device)) {
+      return false;
+    }
+    Device other = o as Device;
+    return this.status == other.status
+      && this.previous == other.previous
+      && this.history == other.history
+      && this.notes == other.notes;
+  }
+
+  int get hashCode {
+    var value = 17;
+    value = (value * 31) ^ status.hashCode;
+    value = (value * 31) ^ previous.hashCode;
+    value = (value * 31) ^ history.hashCode;
+    value = (value * 31) ^ notes.hashCode;
+    return value;
+  }
+
+  Device clone({
+    int status: null,
+    int previous: null,
+    List<int> history: null,
+    Map<int, String> notes: null,
+  }) {
+    return new Device()
+      ..status = status ?? this.status
+      ..previous = previous ?? this.previous
+      ..history = history ?? this.history
+      ..notes = notes ?? this.notes;
+  }
+
+  validate() {
+    // check for required fields
+    // check that fields of type enum have valid values
+    if(isSetStatus() && !t_unknown_enums.Status.VALID_VALUES.contains(status)) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "The field 'status' has been assigned the invalid value $status");
+    }
+    if(isSetPrevious() && !t_unknown_enums.Status.VALID_VALUES.contains(previous)) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "The field 'previous' has been assigned the invalid value $previous");
+    }
+  }
+}
@@ -0,0 +1,811 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+package unknown_enums;
+
+import org.apache.thrift.scheme.IScheme;
+import org.apache.thrift.scheme.SchemeFactory;
+import org.apache.thrift.scheme.StandardScheme;
+
+import org.apache.thrift.scheme.TupleScheme;
+import org.apache.thrift.protocol.TTupleProtocol;
+import org.apache.thrift.protocol.TProtocolException;
+import org.apache.thrift.EncodingUtils;
+import org.apache.thrift.TException;
+import org.apache.thrift.async.AsyncMethodCallback;
+import org.apache.thrift.server.AbstractNonblockingServer.*;
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import javax.annotation.Generated;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+@Generated(value = "Autogenerated by Frugal Compiler (2.23.0)", date = "2015-11-24")
+public class Device implements org.apache.thrift.TBase<Device, Device._Fields>, java.io.Serializable, Cloneable, Comparable<Device> {
+	private static final org.apache.thrift.protocol.TStruct STRUCT_DESC = new org.apache.thrift.protocol.TStruct("Device");
+
+	private static final org.apache.thrift.protocol.TField STATUS_FIELD_DESC = new org.apache.thrift.protocol.TField("status", org.apache.thrift.protocol.TType.I32, (short)1);
+	private static final org.apache.thrift.protocol.TField PREVIOUS_FIELD_DESC = new org.apache.thrift.protocol.TField("previous", org.apache.thrift.protocol.TType.I32, (short)2);
+	private static final org.apache.thrift.protocol.TField HISTORY_FIELD_DESC = new org.apache.thrift.protocol.TField("history", org.apache.thrift.protocol.TType.LIST, (short)3);
+	private static final org.apache.thrift.protocol.TField NOTES_FIELD_DESC = new org.apache.thrift.protocol.TField("notes", org.apache.thrift.protocol.TType.MAP, (short)4);
+
+	private static final Map<Class<? extends IScheme>, SchemeFactory> schemes = new HashMap<Class<? extends IScheme>, SchemeFactory>();
+	static {
+		schemes.put(StandardScheme.class, new DeviceStandardSchemeFactory());
+		schemes.put(TupleScheme.class, new DeviceTupleSchemeFactory());
+	}
+
+	public Status status;
+	public Status previous; // optional
+	public java.util.List<Status> history;
+	public java.util.Map<Status, String> notes;
+	/** The set of fields this struct contains, along with convenience methods for finding and manipulating them. */
+	public enum _Fields implements org.apache.thrift.TFieldIdEnum {
+		STATUS((short)1, "status"),
+		PREVIOUS((short)2, "previous"),
+		HISTORY((short)3, "history"),
+		NOTES((short)4, "notes")
+		;
+
+		private static final Map<String, _Fields> byName = new HashMap<String, _Fields>();
+
+		static {
+			for (_Fields field : EnumSet.allOf(_Fields.class)) {
+				byName.put(field.getFieldName(), field);
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, or null if its not found.
+		 */
+		public static _Fields findByThriftId(int fieldId) {
+			switch(fieldId) {
+				case 1: // STATUS
+					return STATUS;
+				case 2: // PREVIOUS
+					return PREVIOUS;
+				case 3: // HISTORY
+					return HISTORY;
+				case 4: // NOTES
+					return NOTES;
+				default:
+					return null;
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, throwing an exception
+		 * if it is not found.
+		 */
+		public static _Fields findByThriftIdOrThrow(int fieldId) {
+			_Fields fields = findByThriftId(fieldId);
+			if (fields == null) throw new IllegalArgumentException("Field " + fieldId + " doesn't exist!");
+			return fields;
+		}
+
+		/**
+		 * Find the _Fields constant that matches name, or null if its not found.
+		 */
+		public static _Fields findByName(String name) {
+			return byName.get(name);
+		}
+
+		private final short _thriftId;
+		private final String _fieldName;
+
+		_Fields(short thriftId, String fieldName) {
+			_thriftId = thriftId;
+			_fieldName = fieldName;
+		}
+
+		public short getThriftFieldId() {
+			return _thriftId;
+		}
+
+		public String getFieldName() {
+			return _fieldName;
+		}
+	}
+
+	// isset id assignments
+	public Device() {
+	}
+
+	public Device(
+		Status status,
+		java.util.List<Status> history,
+		java.util.Map<Status, String> notes) {
+		this();
+		this.status = status;
+		this.history = history;
+		this.notes = notes;
+	}
+
+	/**
+	 * Performs a deep copy on <i>other</i>.
+	 */
+	public Device(Device other) {
+		if (other.isSetStatus()) {
+			this.status = other.status;
+		}
+		if (other.isSetPrevious()) {
+			this.previous = other.previous;
+		}
+		if (other.isSetHistory()) {
+			this.history = new ArrayList<Status>(other.history.size());
+			for (Status elem0 : other.history) {
+				Status elem1 = elem0;
+				this.history.add(elem1);
+			}
+		}
+		if (other.isSetNotes()) {
+			this.notes = new HashMap<Status,String>(other.notes.size());
+			for (Map.Entry<Status, String> elem2 : other.notes.entrySet()) {
+				Status elem4 = elem2.getKey();
+				String elem3 = elem2.getValue();
+				this.notes.put(elem4, elem3);
+			}
+		}
+	}
+
+	public Device deepCopy() {
+		return new Device(this);
+	}
+
+	@Override
+	public void clear() {
+		this.status = null;
+
+		this.previous = null;
+
+		this.history = null;
+
+		this.notes = null;
+
+	}
+
+	public Status getStatus() {
+		return this.status;
+	}
+
+	public Device setStatus(Status status) {
+		this.status = status;
+		return this;
+	}
+
+	public void unsetStatus() {
+		this.status = null;
+	}
+
+	/** Returns true if field status is set (has been assigned a value) and false otherwise */
+	public boolean isSetStatus() {
+		return this.status != null;
+	}
+
+	public void setStatusIsSet(boolean value) {
+		if (!value) {
+			this.status = null;
+		}
+	}
+
+	public Status getPrevious() {
+		return this.previous;
+	}
+
+	public Device setPrevious(Status previous) {
+		this.previous = previous;
+		return this;
+	}
+
+	public void unsetPrevious() {
+		this.previous = null;
+	}
+
+	/** Returns true if field previous is set (has been assigned a value) and false otherwise */
+	public boolean isSetPrevious() {
+		return this.previous != null;
+	}
+
+	public void setPreviousIsSet(boolean value) {
+		if (!value) {
+			this.previous = null;
+		}
+	}
+
+	public int getHistorySize() {
+		return (this.history == null) ? 0 : this.history.size();
+	}
+
+	public java.util.Iterator<Status> getHistoryIterator() {
+		return (this.history == null) ? null : this.history.iterator();
+	}
+
+	public void addToHistory(Status elem) {
+		if (this.history == null) {
+			this.history = new ArrayList<Status>();
+		}
+		this.history.add(elem);
+	}
+
+	public java.util.List<Status> getHistory() {
+		return this.history;
+	}
+
+	public Device setHistory(java.util.List<Status> history) {
+		this.history = history;
+		return this;
+	}
+
+	public void unsetHistory() {
+		this.history = null;
+	}
+
+	/** Returns true if field history is set (has been assigned a value) and false otherwise */
+	public boolean isSetHistory() {
+		return this.history != null;
+	}
+
+	public void setHistoryIsSet(boolean value) {
+		if (!value) {
+			this.history = null;
+		}
+	}
+
+	public int getNotesSize() {
+		return (this.notes == null) ? 0 : this.notes.size();
+	}
+
+	public void putToNotes(Status key, String val) {
+		if (this.notes == null) {
+			this.notes = new HashMap<Status,String>();
+		}
+		this.notes.put(key, val);
+	}
+
+	public java.util.Map<Status, String> getNotes() {
+		return this.notes;
+	}
+
+	public Device setNotes(java.util.Map<Status, String> notes) {
+		this.notes = notes;
+		return this;
+	}
+
+	public void unsetNotes() {
+		this.notes = null;
+	}
+
+	/** Returns true if field notes is set (has been assigned a value) and false otherwise */
+	public boolean isSetNotes() {
+		return this.notes != null;
+	}
+
+	public void setNotesIsSet(boolean value) {
+		if (!value) {
+			this.notes = null;
+		}
+	}
+
+	public void setFieldValue(_Fields field, Object value) {
+		switch (field) {
+		case STATUS:
+			if (value == null) {
+				unsetStatus();
+			} else {
+				setStatus((Status)value);
+			}
+			break;
+
+		case PREVIOUS:
+			if (value == null) {
+				unsetPrevious();
+			} else {
+				setPrevious((Status)value);
+			}
+			break;
+
+		case HISTORY:
+			if (value == null) {
+				unsetHistory();
+			} else {
+				setHistory((java.util.List<Status>)value);
+			}
+			break;
+
+		case NOTES:
+			if (value == null) {
+				unsetNotes();
+			} else {
+				setNotes((java.util.Map<Status, String>)value);
+			}
+			break;
+
+		}
+	}
+
+	public Object getFieldValue(_Fields field) {
+		switch (field) {
+		case STATUS:
+			return getStatus();
+
+		case PREVIOUS:
+			return getPrevious();
+
+		case HISTORY:
+			return getHistory();
+
+		case NOTES:
+			return getNotes();
+
+		}
+		throw new IllegalStateException();
+	}
+
+	/** Returns true if field corresponding to fieldID is set (has been assigned a value) and false otherwise */
+	public boolean isSet(_Fields field) {
+		if (field == null) {
+			throw new IllegalArgumentException();
+		}
+
+		switch (field) {
+		case STATUS:
+			return isSetStatus();
+		case PREVIOUS:
+			return isSetPrevious();
+		case HISTORY:
+			return isSetHistory();
+		case NOTES:
+			return isSetNotes();
+		}
+		throw new IllegalStateException();
+	}
+
+	@Override
+	public boolean equals(Object that) {
+		if (that == null)
+			return false;
+		if (that instanceof Device)
+			return this.equals((Device)that);
+		return false;
+	}
+
+	public boolean equals(Device that) {
+		if (that == null)
+			return false;
+
+		boolean this_present_status = true && this.isSetStatus();
+		boolean that_present_status = true && that.isSetStatus();
+		if (this_present_status || that_present_status) {
+			if (!(this_present_status && that_present_status))
+				return false;
+			if (!this.status.equals(that.status))
+				return false;
+		}
+
+		boolean this_present_previous = true && this.isSetPrevious();
+		boolean that_present_previous = true && that.isSetPrevious();
+		if (this_present_previous || that_present_previous) {
+			if (!(this_present_previous && that_present_previous))
+				return false;
+			if (!this.previous.equals(that.previous))
+				return false;
+		}
+
+		boolean this_present_history = true && this.isSetHistory();
+		boolean that_present_history = true && that.isSetHistory();
+		if (this_present_history || that_present_history) {
+			if (!(this_present_history && that_present_history))
+				return false;
+			if (!this.history.equals(that.history))
+				return false;
+		}
+
+		boolean this_present_notes = true && this.isSetNotes();
+		boolean that_present_notes = true && that.isSetNotes();
+		if (this_present_notes || that_present_notes) {
+			if (!(this_present_notes && that_present_notes))
+				return false;
+			if (!this.notes.equals(that.notes))
+				return false;
+		}
+
+		return true;
+	}
+
+	@Override
+	public int hashCode() {
+		List<Object> list = new ArrayList<Object>();
+
+		boolean present_status = true && (isSetStatus());
+		list.add(present_status);
+		if (present_status)
+			list.add(status.getValue());
+
+		boolean present_previous = true && (isSetPrevious());
+		list.add(present_previous);
+		if (present_previous)
+			list.add(previous.getValue());
+
+		boolean present_history = true && (isSetHistory());
+		list.add(present_history);
+		if (present_history)
+			list.add(history);
+
+		boolean present_notes = true && (isSetNotes());
+		list.add(present_notes);
+		if (present_notes)
+			list.add(notes);
+
+		return list.hashCode();
+	}
+
+	@Override
+	public int compareTo(Device other) {
+		if (!getClass().equals(other.getClass())) {
+			return getClass().getName().compareTo(other.getClass().getName());
+		}
+
+		int lastComparison = 0;
+
+		lastComparison = Boolean.valueOf(isSetStatus()).compareTo(other.isSetStatus());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetStatus()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.status, other.status);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetPrevious()).compareTo(other.isSetPrevious());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetPrevious()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.previous, other.previous);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetHistory()).compareTo(other.isSetHistory());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetHistory()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.history, other.history);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetNotes()).compareTo(other.isSetNotes());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetNotes()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.notes, other.notes);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		return 0;
+	}
+
+	public _Fields fieldForId(int fieldId) {
+		return _Fields.findByThriftId(fieldId);
+	}
+
+	public void read(org.apache.thrift.protocol.TProtocol iprot) throws org.apache.thrift.TException {
+		schemes.get(iprot.getScheme()).getScheme().read(iprot, this);
+	}
+
+	public void write(org.apache.thrift.protocol.TProtocol oprot) throws org.apache.thrift.TException {
+		schemes.get(oprot.getScheme()).getScheme().write(oprot, this);
+	}
+
+	@Override
+	public String toString() {
+		StringBuilder sb = new StringBuilder("Device(");
+		boolean first = true;
+
+		sb.append("status:");
+		if (this.status == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.status);
+		}
+		first = false;
+		if (isSetPrevious()) {
+			if (!first) sb.append(", ");
+			sb.append("previous:");
+			if (this.previous == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.previous);
+			}
+			first = false;
+		}
+		if (!first) sb.append(", ");
+		sb.append("history:");
+		if (this.history == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.history);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("notes:");
+		if (this.notes == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.notes);
+		}
+		first = false;
+		sb.append(")");
+		return sb.toString();
+	}
+
+	public void validate() throws org.apache.thrift.TException {
+		// check for required fields
+		// check for sub-struct validity
+	}
+
+	private void writeObject(java.io.ObjectOutputStream out) throws java.io.IOException {
+		try {
+			write(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(out)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private void readObject(java.io.ObjectInputStream in) throws java.io.IOException, ClassNotFoundException {
+		try {
+			// it doesn't seem like you should have to do this, but java serialization is wacky, and doesn't call the default constructor.
+			read(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(in)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private static class DeviceStandardSchemeFactory implements SchemeFactory {
+		public DeviceStandardScheme getScheme() {
+			return new DeviceStandardScheme();
+		}
+	}
+
+	private static class DeviceStandardScheme extends StandardScheme<Device> {
+
+		public void read(org.apache.thrift.protocol.TProtocol iprot, Device struct) throws org.apache.thrift.TException {
+			org.apache.thrift.protocol.TField schemeField;
+			iprot.readStructBegin();
+			while (true) {
+				schemeField = iprot.readFieldBegin();
+				if (schemeField.type == org.apache.thrift.protocol.TType.STOP) {
+					break;
+				}
+				switch (schemeField.id) {
+					case 1: // STATUS
+						if (schemeField.type == org.apache.thrift.protocol.TType.I32) {
+							struct.status = Status.findByValue(iprot.readI32());
+							if (struct.status == null) {
+								throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Invalid value for enum Status");
+							}
+							struct.setStatusIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 2: // PREVIOUS
+						if (schemeField.type == org.apache.thrift.protocol.TType.I32) {
+							struct.previous = Status.findByValue(iprot.readI32());
+							if (struct.previous == null) {
+								throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Invalid value for enum Status");
+							}
+							struct.setPreviousIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 3: // HISTORY
+						if (schemeField.type == org.apache.thrift.protocol.TType.LIST) {
+							org.apache.thrift.protocol.TList elem5 = iprot.readListBegin();
+							struct.history = new ArrayList<Status>(elem5.size);
+							for (int elem6 = 0; elem6 < elem5.size; ++elem6) {
+								Status elem7 = Status.findByValue(iprot.readI32());
+								if (elem7 == null) {
+									throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Invalid value for enum Status");
+								}
+								struct.history.add(elem7);
+							}
+							iprot.readListEnd();
+							struct.setHistoryIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 4: // NOTES
+						if (schemeField.type == org.apache.thrift.protocol.TType.MAP) {
+							org.apache.thrift.protocol.TMap elem8 = iprot.readMapBegin();
+							struct.notes = new HashMap<Status,String>(2*elem8.size);
+							for (int elem9 = 0; elem9 < elem8.size; ++elem9) {
+								Status elem11 = Status.findByValue(iprot.readI32());
+								if (elem11 == null) {
+									throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Invalid value for enum Status");
+								}
+								String elem10 = iprot.readString();
+								struct.notes.put(elem11, elem10);
+							}
+							iprot.readMapEnd();
+							struct.setNotesIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					default:
+						org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+				}
+				iprot.readFieldEnd();
+			}
+			iprot.readStructEnd();
+
+			// check for required fields of primitive type, which can't be checked in the validate method
+			struct.validate();
+		}
+
+		public void write(org.apache.thrift.protocol.TProtocol oprot, Device struct) throws org.apache.thrift.TException {
+			struct.validate();
+
+			oprot.writeStructBegin(STRUCT_DESC);
+			if (struct.status != null) {
+				oprot.writeFieldBegin(STATUS_FIELD_DESC);
+				Status elem12 = struct.status;
+				oprot.writeI32(elem12.getValue());
+				oprot.writeFieldEnd();
+			}
+			if (struct.previous != null) {
+				if (struct.isSetPrevious()) {
+					oprot.writeFieldBegin(PREVIOUS_FIELD_DESC);
+					Status elem13 = struct.previous;
+					oprot.writeI32(elem13.getValue());
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.history != null) {
+				oprot.writeFieldBegin(HISTORY_FIELD_DESC);
+				oprot.writeListBegin(new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.I32, struct.history.size()));
+				for (Status elem14 : struct.history) {
+					Status elem15 = elem14;
+					oprot.writeI32(elem15.getValue());
+				}
+				oprot.writeListEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.notes != null) {
+				oprot.writeFieldBegin(NOTES_FIELD_DESC);
+				oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.I32, org.apache.thrift.protocol.TType.STRING, struct.notes.size()));
+				for (Map.Entry<Status, String> elem16 : struct.notes.entrySet()) {
+					Status elem17 = elem16.getKey();
+					oprot.writeI32(elem17.getValue());
+					String elem18 = elem16.getValue();
+					oprot.writeString(elem18);
+				}
+				oprot.writeMapEnd();
+				oprot.writeFieldEnd();
+			}
+			oprot.writeFieldStop();
+			oprot.writeStructEnd();
+		}
+
+	}
+
+	private static class DeviceTupleSchemeFactory implements SchemeFactory {
+		public DeviceTupleScheme getScheme() {
+			return new DeviceTupleScheme();
+		}
+	}
+
+	private static class DeviceTupleScheme extends TupleScheme<Device> {
+
+		@Override
+		public void write(org.apache.thrift.protocol.TProtocol prot, Device struct) throws org.apache.thrift.TException {
+			TTupleProtocol oprot = (TTupleProtocol) prot;
+			BitSet optionals = new BitSet();
+			if (struct.isSetStatus()) {
+				optionals.set(0);
+			}
+			if (struct.isSetPrevious()) {
+				optionals.set(1);
+			}
+			if (struct.isSetHistory()) {
+				optionals.set(2);
+			}
+			if (struct.isSetNotes()) {
+				optionals.set(3);
+			}
+			oprot.writeBitSet(optionals, 4);
+			if (struct.isSetStatus()) {
+				Status elem19 = struct.status;
+				oprot.writeI32(elem19.getValue());
+			}
+			if (struct.isSetPrevious()) {
+				Status elem20 = struct.previous;
+				oprot.writeI32(elem20.getValue());
+			}
+			if (struct.isSetHistory()) {
+				oprot.writeI32(struct.history.size());
+				for (Status elem21 : struct.history) {
+					Status elem22 = elem21;
+					oprot.writeI32(elem22.getValue());
+				}
+			}
+			if (struct.isSetNotes()) {
+				oprot.writeI32(struct.notes.size());
+				for (Map.Entry<Status, String> elem23 : struct.notes.entrySet()) {
+					Status elem24 = elem23.getKey();
+					oprot.writeI32(elem24.getValue());
+					String elem25 = elem23.getValue();
+					oprot.writeString(elem25);
+				}
+			}
+		}
+
+		@Override
+		public void read(org.apache.thrift.protocol.TProtocol prot, Device struct) throws org.apache.thrift.TException {
+			TTupleProtocol iprot = (TTupleProtocol) prot;
+			BitSet incoming = iprot.readBitSet(4);
+			if (incoming.get(0)) {
+				struct.status = Status.findByValue(iprot.readI32());
+				if (struct.status == null) {
+					throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Invalid value for enum Status");
+				}
+				struct.setStatusIsSet(true);
+			}
+			if (incoming.get(1)) {
+				struct.previous = Status.findByValue(iprot.readI32());
+				if (struct.previous == null) {
+					throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Invalid value for enum Status");
+				}
+				struct.setPreviousIsSet(true);
+			}
+			if (incoming.get(2)) {
+				org.apache.thrift.protocol.TList elem26 = new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.I32, iprot.readI32());
+				struct.history = new ArrayList<Status>(elem26.size);
+				for (int elem27 = 0; elem27 < elem26.size; ++elem27) {
+					Status elem28 = Status.findByValue(iprot.readI32());
+					if (elem28 == null) {
+						throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Invalid value for enum Status");
+					}
+					struct.history.add(elem28);
+				}
+				struct.setHistoryIsSet(true);
+			}
+			if (incoming.get(3)) {
+				org.apache.thrift.protocol.TMap elem29 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.I32, org.apache.thrift.protocol.TType.STRING, iprot.readI32());
+				struct.notes = new HashMap<Status,String>(2*elem29.size);
+				for (int elem30 = 0; elem30 < elem29.size; ++elem30) {
+					Status elem32 = Status.findByValue(iprot.readI32());
+					if (elem32 == null) {
+						throw new org.apache.thrift.protocol.TProtocolException(org.apache.thrift.protocol.TProtocolException.INVALID_DATA, "Invalid value for enum Status");
+					}
+					String elem31 = iprot.readString();
+					struct.notes.put(elem32, elem31);
+				}
+				struct.setNotesIsSet(true);
+			}
+		}
+
+	}
+
+}
@@ -0,0 +1,152 @@
+#
+# Autogenerated by Frugal Compiler (2.23.0)
+#
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+#
+
+from thrift.Thrift import TType, TMessageType, TException, TApplicationException
+
+from frugal.util import make_hashable
+from thrift.transport import TTransport
+from thrift.protocol import TBinaryProtocol, TProtocol
+
+
+class Status(int):
+    UNKNOWN = 0
+    ACTIVE = 1
+    RETIRED = 2
+
+    _VALUES_TO_NAMES = {
+        0: "UNKNOWN",
+        1: "ACTIVE",
+        2: "RETIRED",
+    }
+
+    _NAMES_TO_VALUES = {
+        "UNKNOWN": 0,
+        "ACTIVE": 1,
+        "RETIRED": 2,
+    }
+
+class Device(object):
+    """
+    Attributes:
+     - status
+     - previous
+     - history
+     - notes
+    """
+    def __init__(self, status=None, previous=None, history=None, notes=None):
+        self.status = status
+        self.previous = previous
+        self.history = history
+        self.notes = notes
+
+    def is_set_previous(self):
+        return self.previous is not None
+
+    def unset_previous(self):
+        self.previous = None
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.I32:
+                    self.status = Status(iprot.readI32())
+                    if self.status not in Status._VALUES_TO_NAMES:
+                        self.status = Status.UNKNOWN
+                else:
+                    iprot.skip(ftype)
+            elif fid == 2:
+                if ftype == TType.I32:
+                    self.previous = Status(iprot.readI32())
+                    if self.previous not in Status._VALUES_TO_NAMES:
+                        self.previous = Status.UNKNOWN
+                else:
+                    iprot.skip(ftype)
+            elif fid == 3:
+                if ftype == TType.LIST:
+                    self.history = []
+                    (_, elem0) = iprot.readListBegin()
+                    for _ in range(elem0):
+                        elem1 = Status(iprot.readI32())
+                        if elem1 not in Status._VALUES_TO_NAMES:
+                            elem1 = Status.UNKNOWN
+                        self.history.append(elem1)
+                    iprot.readListEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 4:
+                if ftype == TType.MAP:
+                    self.notes = {}
+                    (_, _, elem2) = iprot.readMapBegin()
+                    for _ in range(elem2):
+                        elem4 = Status(iprot.readI32())
+                        if elem4 not in Status._VALUES_TO_NAMES:
+                            elem4 = Status.UNKNOWN
+                        elem3 = iprot.readString()
+                        self.notes[elem4] = elem3
+                    iprot.readMapEnd()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('Device')
+        if self.status is not None:
+            oprot.writeFieldBegin('status', TType.I32, 1)
+            oprot.writeI32(self.status)
+            oprot.writeFieldEnd()
+        if self.previous is not None:
+            oprot.writeFieldBegin('previous', TType.I32, 2)
+            oprot.writeI32(self.previous)
+            oprot.writeFieldEnd()
+        if self.history is not None:
+            oprot.writeFieldBegin('history', TType.LIST, 3)
+            oprot.writeListBegin(TType.I32, len(self.history))
+            for elem5 in self.history:
+                oprot.writeI32(elem5)
+            oprot.writeListEnd()
+            oprot.writeFieldEnd()
+        if self.notes is not None:
+            oprot.writeFieldBegin('notes', TType.MAP, 4)
+            oprot.writeMapBegin(TType.I32, TType.STRING, len(self.notes))
+            for elem7, elem6 in self.notes.items():
+                oprot.writeI32(elem7)
+                oprot.writeString(elem6)
+            oprot.writeMapEnd()
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.status))
+        value = (value * 31) ^ hash(make_hashable(self.previous))
+        value = (value * 31) ^ hash(make_hashable(self.history))
+        value = (value * 31) ^ hash(make_hashable(self.notes))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
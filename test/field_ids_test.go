@@ -0,0 +1,85 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"testing"
+
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fieldIDsFile     = "idl/field_ids/accounts.frugal"
+	fieldIDsBaseline = "idl/field_ids/baseline.json"
+)
+
+func TestFieldIDsWithoutBaseline(t *testing.T) {
+	logger := &MockValidationLogger{}
+	checker := parser.NewFieldIDCheckerWithLogger(logger)
+	baseline, err := checker.Check(fieldIDsFile, parser.FieldIDBaseline{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	assert.Equal(t, []string{"struct accounts.Account: field legacy has non-positive ID=-1"}, logger.errors)
+	assert.Equal(t, "FAILED: field ID check of "+fieldIDsFile, err.Error())
+	assert.Equal(t, parser.FieldIDBaseline{
+		"exception accounts.AccountNotFound":                      {1: "id"},
+		"service accounts.Accounts: method getAccount arguments":  {1: "id", 2: "region"},
+		"service accounts.Accounts: method getAccount exceptions": {1: "notFound"},
+		"struct accounts.Account":                                 {-1: "legacy", 1: "id", 2: "nickname", 3: "email"},
+	}, baseline)
+}
+
+func TestFieldIDsReused(t *testing.T) {
+	baseline, err := parser.ReadFieldIDBaseline(fieldIDsBaseline)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	logger := &MockValidationLogger{}
+	checker := parser.NewFieldIDCheckerWithLogger(logger, parser.NoReusedFieldIDs)
+	updated, err := checker.Check(fieldIDsFile, baseline)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	expected := []string{
+		"struct accounts.Account: field nickname reuses ID=2 previously assigned to field name",
+		"service accounts.Accounts: method getAccount arguments: field region reuses ID=2 previously assigned to field zone",
+	}
+	assert.Equal(t, expected, logger.errors)
+	// Removed fields remain in the baseline so their IDs are never reused.
+	assert.Equal(t, parser.FieldIDs{-1: "legacy", 1: "id", 2: "name", 3: "email", 4: "phone"},
+		updated["struct accounts.Account"])
+	// The given baseline is not modified.
+	assert.Len(t, baseline["struct accounts.Account"], 4)
+}
+
+func TestFieldIDsCustomRule(t *testing.T) {
+	logger := &MockValidationLogger{}
+	maxID := func(logger parser.ValidationLogger, definition string, fields []*parser.Field, assigned parser.FieldIDs) {
+		for _, field := range fields {
+			if field.ID > 2 {
+				logger.LogError(definition+":", field.Name)
+			}
+		}
+	}
+	checker := parser.NewFieldIDCheckerWithLogger(logger, maxID)
+	if _, err := checker.Check(fieldIDsFile, nil); err == nil {
+		t.Fatal("expected error")
+	}
+	assert.Equal(t, []string{"struct accounts.Account: email"}, logger.errors)
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func assertFilesExist(t *testing.T, filePaths []string) {
+	for _, file := range filePaths {
+		if _, err := os.Stat(file); err != nil {
+			t.Errorf("Expected %q to exist: %s", file, err)
+		}
+	}
+}
+
+func TestOnlyFilter(t *testing.T) {
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "only"),
+		Delim: delim,
+		Only:  []string{"tag:billing"},
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	dir := filepath.Join(outputDir, "only", "filter")
+	assertFilesExist(t, []string{
+		filepath.Join(dir, "f_types.go"),
+		filepath.Join(dir, "f_billing_scope.go"),
+		filepath.Join(dir, "f_invoices_service.go"),
+		// Invoices extends Base.
+		filepath.Join(dir, "f_base_service.go"),
+	})
+	assertFilesNotExist(t, []string{
+		filepath.Join(dir, "f_alerts_scope.go"),
+		filepath.Join(dir, "f_admin_service.go"),
+	})
+}
+
+func TestExcludeFilter(t *testing.T) {
+	options := compiler.Options{
+		File:    filterFile,
+		Gen:     "go",
+		Out:     filepath.Join(outputDir, "exclude"),
+		Delim:   delim,
+		Exclude: []string{"Admin", "tag:finance"},
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	dir := filepath.Join(outputDir, "exclude", "filter")
+	assertFilesExist(t, []string{
+		filepath.Join(dir, "f_alerts_scope.go"),
+		filepath.Join(dir, "f_base_service.go"),
+		filepath.Join(dir, "f_invoices_service.go"),
+	})
+	assertFilesNotExist(t, []string{
+		filepath.Join(dir, "f_billing_scope.go"),
+		filepath.Join(dir, "f_admin_service.go"),
+	})
+}
+
+func TestOnlyFilterUnmatched(t *testing.T) {
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "unmatched"),
+		Delim: delim,
+		Only:  []string{"Billing", "Missing"},
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if err.Error() != "No scope or service matches Missing" {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	unformattedFile = "idl/format/unformatted.frugal"
+	formattedFile   = "expected/format/formatted.frugal"
+)
+
+func TestFormat(t *testing.T) {
+	formatted := formatFile(t, unformattedFile)
+	expected, err := ioutil.ReadFile(formattedFile)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assert.Equal(t, string(expected), string(formatted))
+}
+
+// Formatting a formatted file leaves it unchanged.
+func TestFormatIdempotent(t *testing.T) {
+	for _, file := range []string{formattedFile, frugalGenFile, copyMergeFile} {
+		formatted := formatFile(t, file)
+		reformatted, err := parser.Format(file, formatted)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		assert.Equal(t, string(formatted), string(reformatted), file)
+	}
+}
+
+func TestFormatInvalid(t *testing.T) {
+	src, err := ioutil.ReadFile(invalidFile)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	_, err = parser.Format(invalidFile, src)
+	assert.Error(t, err)
+}
+
+func formatFile(t *testing.T, file string) []byte {
+	src, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	formatted, err := parser.Format(file, src)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	return formatted
+}
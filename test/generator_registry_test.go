@@ -0,0 +1,142 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/generator"
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/Workiva/frugal/plugin"
+)
+
+// externalGeneratorName is the name the test binary is linked as to run as
+// an external generator.
+const externalGeneratorName = generator.ExternalPrefix + "declarations"
+
+func init() {
+	if filepath.Base(os.Args[0]) == externalGeneratorName {
+		if err := plugin.Serve(declarationGenerator{}); err != nil {
+			os.Stderr.WriteString(err.Error())
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	generator.Register("names", generator.Options{
+		"upper": "Write the names in upper case",
+	}, func(options map[string]string) generator.ProgramGenerator {
+		_, upper := options["upper"]
+		return &namesGenerator{upper: upper}
+	})
+}
+
+// namesGenerator is an example registered generator which writes the names
+// of a file's structs.
+type namesGenerator struct {
+	upper bool
+}
+
+func (n *namesGenerator) Generate(frugal *parser.Frugal, outputDir string) error {
+	names := ""
+	for _, s := range frugal.Structs {
+		name := s.Name
+		if n.upper {
+			name = strings.ToUpper(name)
+		}
+		names += name + "\n"
+	}
+	if err := os.MkdirAll(outputDir, 0777); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(outputDir, frugal.Name+".txt"), []byte(names), 0644)
+}
+
+func (n *namesGenerator) GetOutputDir(dir string, f *parser.Frugal) string { return dir }
+
+func (n *namesGenerator) DefaultOutputDir() string { return "gen-names" }
+
+func (n *namesGenerator) UseVendor() bool { return false }
+
+func TestRegisteredGenerator(t *testing.T) {
+	options := compiler.Options{
+		File:  validFile,
+		Gen:   "names:upper",
+		Out:   filepath.Join(outputDir, "names"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	names, err := ioutil.ReadFile(filepath.Join(outputDir, "names", "valid.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(names), "THING\n") {
+		t.Fatalf("Unexpected names:\n%s", names)
+	}
+}
+
+func TestRegisteredGeneratorUnknownOption(t *testing.T) {
+	options := compiler.Options{
+		File:  validFile,
+		Gen:   "names:lower",
+		Out:   filepath.Join(outputDir, "names"),
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if err.Error() != "Unknown option 'lower' for names" {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+// Ensures generators for unregistered languages are discovered on the PATH
+// and run over the plugin protocol, with the test binary standing in for an
+// external generator built with the plugin SDK.
+func TestExternalGenerator(t *testing.T) {
+	binDir, err := ioutil.TempDir("", "frugal-gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(binDir)
+	testBinary, err := filepath.Abs(os.Args[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(testBinary, filepath.Join(binDir, externalGeneratorName)); err != nil {
+		t.Fatal(err)
+	}
+	path := os.Getenv("PATH")
+	defer os.Setenv("PATH", path)
+	os.Setenv("PATH", binDir+string(os.PathListSeparator)+path)
+
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "declarations:any_option",
+		Out:   filepath.Join(outputDir, "declarations"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	compareFiles(t, "expected/plugin/filter.d.ts", filepath.Join(outputDir, "declarations", "filter.d.ts"))
+}
@@ -165,3 +165,280 @@ func TestSlim(t *testing.T) {
 	copyAllFiles(t, files)
 	compareAllFiles(t, files)
 }
+
+func TestGoArtifactOutputDirs(t *testing.T) {
+	root := filepath.Join(outputDir, "artifact_out")
+	options := compiler.Options{
+		File: filterFile,
+		Gen: "go:package_prefix=github.com/Workiva/frugal/test/out/artifact_out/models/," +
+			"scopes_out=" + filepath.Join(root, "scopes") + ",services_out=" + filepath.Join(root, "services"),
+		Out:   filepath.Join(root, "models"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	assertFilesExist(t, []string{
+		filepath.Join(root, "models", "filter", "f_types.go"),
+		filepath.Join(root, "services", "filter", "f_base_service.go"),
+		filepath.Join(root, "services", "filter", "f_invoices_service.go"),
+	})
+	assertFilesNotExist(t, []string{
+		filepath.Join(root, "models", "filter", "f_alerts_scope.go"),
+		filepath.Join(root, "models", "filter", "f_invoices_service.go"),
+	})
+
+	files := []FileComparisonPair{
+		{"expected/go/artifact_out/f_alerts_scope.go", filepath.Join(root, "scopes", "filter", "f_alerts_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestGoBridge(t *testing.T) {
+	root := filepath.Join(outputDir, "bridge")
+	options := compiler.Options{
+		File:    frugalGenFile,
+		Gen:     "go:bridge,package_prefix=github.com/Workiva/frugal/test/out/bridge/",
+		Out:     root,
+		Delim:   delim,
+		Recurse: true,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	// Files without scopes don't have a bridge.
+	assertFilesNotExist(t, []string{filepath.Join(root, "actual_base", "golang", "base_bridge")})
+
+	files := []FileComparisonPair{
+		{"expected/go/bridge/variety_bridge_main.txt", filepath.Join(root, "variety", "variety_bridge", "main.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestGoBridgeWithScopesOut(t *testing.T) {
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "go:bridge,scopes_out=" + filepath.Join(outputDir, "bridge_scopes"),
+		Out:   outputDir,
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err == nil {
+		t.Fatal("Expected error")
+	}
+}
+
+func TestGoPII(t *testing.T) {
+	root := filepath.Join(outputDir, "pii")
+	options := compiler.Options{
+		File:  eventsFile,
+		Gen:   "go:package_prefix=github.com/Workiva/frugal/test/out/pii/",
+		Out:   root,
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/pii/f_types.txt", filepath.Join(root, "events", "f_types.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestGoExperimentalDocs(t *testing.T) {
+	root := filepath.Join(outputDir, "stability")
+	options := compiler.Options{
+		File:  stabilityFile,
+		Gen:   "go:package_prefix=github.com/Workiva/frugal/test/out/stability/",
+		Out:   root,
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/stability/f_beta_scope.txt", filepath.Join(root, "stability", "f_beta_scope.go")},
+		{"expected/go/stability/f_betaservice_service.txt", filepath.Join(root, "stability", "f_betaservice_service.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures deprecated scopes and operations are noted in the docs of the
+// generated publish and subscribe methods.
+func TestGoDeprecatedOperations(t *testing.T) {
+	root := filepath.Join(outputDir, "deprecated_scope")
+	options := compiler.Options{
+		File:  deprecatedScopeFile,
+		Gen:   "go",
+		Out:   root,
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/deprecated_scope/f_sensors_scope.txt", filepath.Join(root, "deprecated_scope", "f_sensors_scope.go")},
+		{"expected/go/deprecated_scope/f_telemetry_scope.txt", filepath.Join(root, "deprecated_scope", "f_telemetry_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures the runtime_check option generates a check of the Frugal library's
+// version when the package is initialized.
+func TestGoRuntimeCheck(t *testing.T) {
+	options := compiler.Options{
+		File:  runtimeCheckFile,
+		Gen:   "go:runtime_check=2.20.0",
+		Out:   filepath.Join(outputDir, "runtime_check"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/runtime_check/f_types.txt", filepath.Join(outputDir, "runtime_check", "runtime_check", "f_types.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures the doc comments of scope operations are included in the scope's
+// contract metadata.
+func TestGoScopeDescriptions(t *testing.T) {
+	options := compiler.Options{
+		File:  descriptionsFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "descriptions"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/descriptions/f_orders_scope.txt", filepath.Join(outputDir, "descriptions", "descriptions", "f_orders_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures the sorted_maps option writes the entries of maps and sets with
+// ordered keys in sorted order.
+func TestGoSortedMaps(t *testing.T) {
+	options := compiler.Options{
+		File:  containersFile,
+		Gen:   "go:sorted_maps",
+		Out:   filepath.Join(outputDir, "sorted_maps"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/sorted_maps/f_types.txt", filepath.Join(outputDir, "sorted_maps", "containers", "f_types.go")},
+		{"expected/go/sorted_maps/f_stock_scope.txt", filepath.Join(outputDir, "sorted_maps", "containers", "f_stock_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures the sorted_maps option dereferences optional and defaulted maps,
+// which are pointers, before indexing them.
+func TestGoSortedMapsPointers(t *testing.T) {
+	options := compiler.Options{
+		File:  sortedMapsFile,
+		Gen:   "go:sorted_maps",
+		Out:   filepath.Join(outputDir, "sorted_maps_pointers"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/sorted_maps/pointers_f_types.txt", filepath.Join(outputDir, "sorted_maps_pointers", "sorted_maps", "f_types.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestGoReplayable(t *testing.T) {
+	options := compiler.Options{
+		File:  replayableFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "replayable"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/replayable/f_ledger_scope.txt", filepath.Join(outputDir, "replayable", "replayable", "f_ledger_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestGoAccessControl(t *testing.T) {
+	options := compiler.Options{
+		File:  accessControlFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "access_control"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/access_control/f_invoices_scope.txt", filepath.Join(outputDir, "access_control", "access_control", "f_invoices_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestGoScopeRequest(t *testing.T) {
+	options := compiler.Options{
+		File:  scopeRequestFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "scope_request"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/scope_request/f_events_scope.txt", filepath.Join(outputDir, "scope_request", "scope_request", "f_events_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestGoRuntimeCheckInvalidVersion(t *testing.T) {
+	options := compiler.Options{
+		File:  runtimeCheckFile,
+		Gen:   "go:runtime_check=2.20",
+		Out:   filepath.Join(outputDir, "runtime_check_invalid"),
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if err.Error() != `runtime_check option "2.20" must be a version of the form major.minor.patch` {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
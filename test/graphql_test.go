@@ -0,0 +1,68 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func TestGraphQL(t *testing.T) {
+	options := compiler.Options{
+		File:  catalogFile,
+		Gen:   "graphql",
+		Out:   filepath.Join(outputDir, "graphql"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/graphql/catalog.graphql", filepath.Join(outputDir, "graphql", "catalog.graphql")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestGraphQLIncludedTypes(t *testing.T) {
+	options := compiler.Options{
+		File:  frugalGenFile,
+		Gen:   "graphql",
+		Out:   filepath.Join(outputDir, "graphql_includes"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/graphql/variety.graphql", filepath.Join(outputDir, "graphql_includes", "variety.graphql")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestGraphQLInvalidAnnotation(t *testing.T) {
+	options := compiler.Options{
+		File:  graphQLInvalidFile,
+		Gen:   "graphql",
+		Out:   filepath.Join(outputDir, "graphql_invalid"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err == nil {
+		t.Fatal("Expected error")
+	}
+}
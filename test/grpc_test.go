@@ -0,0 +1,54 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func TestGRPC(t *testing.T) {
+	root := filepath.Join(outputDir, "grpc")
+	options := compiler.Options{
+		File: catalogFile,
+		Gen: "grpc:package_prefix=github.com/Workiva/frugal/test/out/gen-go/," +
+			"go_package_prefix=github.com/Workiva/frugal/test/out/grpc/",
+		Out:   root,
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/grpc/catalog.proto", filepath.Join(root, "catalogpb", "catalog.proto")},
+		{"expected/grpc/f_catalog_grpc_bridge.txt", filepath.Join(root, "catalogpb", "f_catalog_grpc_bridge.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestGRPCIncludedTypes(t *testing.T) {
+	options := compiler.Options{
+		File:  frugalGenFile,
+		Gen:   "grpc",
+		Out:   filepath.Join(outputDir, "grpc_includes"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err == nil {
+		t.Fatal("Expected error")
+	}
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"testing"
+
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	hashOriginalFile    = "idl/hash/original.frugal"
+	hashReformattedFile = "idl/hash/reformatted.frugal"
+	hashChangedFile     = "idl/hash/changed.frugal"
+)
+
+func contractHashes(t *testing.T, file string) (string, string) {
+	frugal, err := parser.ParseFrugal(file)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	return frugal.ScopeHash(frugal.Scopes[0]), frugal.ServiceHash(frugal.Services[0])
+}
+
+func TestContractHashIgnoresFormatting(t *testing.T) {
+	scopeHash, serviceHash := contractHashes(t, hashOriginalFile)
+	reformattedScopeHash, reformattedServiceHash := contractHashes(t, hashReformattedFile)
+	assert.Len(t, scopeHash, 64)
+	assert.Equal(t, scopeHash, reformattedScopeHash)
+	assert.Equal(t, serviceHash, reformattedServiceHash)
+	assert.NotEqual(t, scopeHash, serviceHash)
+}
+
+func TestContractHashChangesWithTypes(t *testing.T) {
+	scopeHash, serviceHash := contractHashes(t, hashOriginalFile)
+	changedScopeHash, changedServiceHash := contractHashes(t, hashChangedFile)
+	assert.NotEqual(t, scopeHash, changedScopeHash)
+	assert.NotEqual(t, serviceHash, changedServiceHash)
+}
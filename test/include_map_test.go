@@ -0,0 +1,70 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+// Ensures includes are generated in and imported from their remapped
+// namespaces, which also satisfies the namespace error policy.
+func TestIncludeMap(t *testing.T) {
+	options := compiler.Options{
+		File:            namespacesFile,
+		Gen:             "go:package_prefix=github.com/acme/gen",
+		Out:             filepath.Join(outputDir, "include_map"),
+		Delim:           delim,
+		Recurse:         true,
+		NamespacePolicy: compiler.NamespaceError,
+		IncludeMap:      []string{"go:shared:acme_money", "java:shared:com.acme.money"},
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(outputDir, "include_map", "acme_money", "f_types.go")); err != nil {
+		t.Fatal("Expected remapped include to be generated", err)
+	}
+	types, err := ioutil.ReadFile(filepath.Join(outputDir, "include_map", "main", "f_types.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(types), `"github.com/acme/gen/acme_money"`) {
+		t.Fatalf("Expected remapped include import:\n%s", types)
+	}
+}
+
+// Ensures remapping an include which isn't included is an error.
+func TestIncludeMapUnknownInclude(t *testing.T) {
+	options := compiler.Options{
+		File:       namespacesFile,
+		Gen:        "go",
+		Out:        filepath.Join(outputDir, "include_map_unknown"),
+		Delim:      delim,
+		IncludeMap: []string{"billing:acme_billing"},
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.HasPrefix(err.Error(), "Include map: billing is not included by") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
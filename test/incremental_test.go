@@ -0,0 +1,159 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/stretchr/testify/assert"
+)
+
+const incrementalDir = "idl/incremental"
+
+// copyIncrementalIDL copies the incremental test IDL to a directory in the
+// output directory the tests can change it in.
+func copyIncrementalIDL(t *testing.T, dir string) {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"billing.frugal", "shared.frugal"} {
+		contents, err := ioutil.ReadFile(filepath.Join(incrementalDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), contents, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// markGenerated overwrites the generated files so a test can tell whether
+// they were regenerated.
+func markGenerated(t *testing.T, files ...string) {
+	for _, file := range files {
+		if err := ioutil.WriteFile(file, []byte("stale"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func isMarked(t *testing.T, file string) bool {
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(contents) == "stale"
+}
+
+func TestIncremental(t *testing.T) {
+	src := filepath.Join(outputDir, "incremental_src")
+	out := filepath.Join(outputDir, "incremental")
+	copyIncrementalIDL(t, src)
+	options := compiler.Options{
+		File:        filepath.Join(src, "billing.frugal"),
+		Gen:         "go",
+		Out:         out,
+		Delim:       delim,
+		Recurse:     true,
+		Incremental: true,
+	}
+	compile := func() {
+		if err := compiler.Compile(options); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+	}
+	billing := filepath.Join(out, "billing", "f_types.go")
+	shared := filepath.Join(out, "shared", "f_types.go")
+
+	compile()
+	_, err := os.Stat(filepath.Join(out, ".frugal-cache.json"))
+	assert.Nil(t, err)
+
+	// Nothing changed, so nothing is regenerated.
+	markGenerated(t, billing, shared)
+	compile()
+	assert.True(t, isMarked(t, billing))
+	assert.True(t, isMarked(t, shared))
+
+	// Changing an include regenerates it and the files including it.
+	sharedFile := filepath.Join(src, "shared.frugal")
+	contents, err := ioutil.ReadFile(sharedFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents = append(contents, []byte("\nstruct Rate {\n    1: double value,\n}\n")...)
+	if err := ioutil.WriteFile(sharedFile, contents, 0644); err != nil {
+		t.Fatal(err)
+	}
+	compile()
+	assert.False(t, isMarked(t, billing))
+	assert.False(t, isMarked(t, shared))
+
+	// Changing the options regenerates everything.
+	markGenerated(t, billing, shared)
+	options.Delim = "/"
+	compile()
+	assert.False(t, isMarked(t, billing))
+	assert.False(t, isMarked(t, shared))
+}
+
+func TestIncrementalNotRequested(t *testing.T) {
+	options := compiler.Options{
+		File:    filepath.Join(incrementalDir, "billing.frugal"),
+		Gen:     "go",
+		Out:     filepath.Join(outputDir, "incremental_off"),
+		Delim:   delim,
+		Recurse: true,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	assertFilesNotExist(t, []string{filepath.Join(outputDir, "incremental_off", ".frugal-cache.json")})
+}
+
+// Ensures languages compiled to the same output location keep their own
+// manifest entries, so neither is regenerated when nothing changed.
+func TestIncrementalSharedOut(t *testing.T) {
+	src := filepath.Join(outputDir, "incremental_shared_src")
+	out := filepath.Join(outputDir, "incremental_shared")
+	copyIncrementalIDL(t, src)
+	compile := func() {
+		for _, gen := range []string{"go", "dart"} {
+			options := compiler.Options{
+				File:        filepath.Join(src, "billing.frugal"),
+				Gen:         gen,
+				Out:         out,
+				Delim:       delim,
+				Recurse:     true,
+				Incremental: true,
+			}
+			if err := compiler.Compile(options); err != nil {
+				t.Fatal("Unexpected error", err)
+			}
+		}
+	}
+	goFile := filepath.Join(out, "billing", "f_types.go")
+	dartFile := filepath.Join(out, "billing", "lib", "src", "f_invoice.dart")
+
+	compile()
+	markGenerated(t, goFile, dartFile)
+	compile()
+	assert.True(t, isMarked(t, goFile))
+	assert.True(t, isMarked(t, dartFile))
+}
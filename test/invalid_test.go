@@ -14,6 +14,7 @@
 package test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Workiva/frugal/compiler"
@@ -115,3 +116,147 @@ func TestWildcardNamespaceWithVendorAnnotation(t *testing.T) {
 		t.Fatal("Expected error")
 	}
 }
+
+func TestIncludeNameCollision(t *testing.T) {
+	options := compiler.Options{
+		File:  includeCollision,
+		Gen:   "go",
+		Out:   outputDir,
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "both define Address in go namespace shared") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestIncludeReferenceCollision(t *testing.T) {
+	options := compiler.Options{
+		File:  includeAliasCollision,
+		Gen:   "go",
+		Out:   outputDir,
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "both imported as package models in go") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestInvalidStability(t *testing.T) {
+	options := compiler.Options{
+		File:  invalidStabilityFile,
+		Gen:   "go",
+		Out:   outputDir,
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "Scope Beta: \"stability\" annotation must be experimental, stable, or frozen") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestInvalidProtocol(t *testing.T) {
+	options := compiler.Options{
+		File:  invalidProtocolFile,
+		Gen:   "go",
+		Out:   outputDir,
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "Scope Things: \"protocol\" annotation must be json or provider") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestInvalidChunked(t *testing.T) {
+	options := compiler.Options{
+		File:  invalidChunkedFile,
+		Gen:   "go",
+		Out:   outputDir,
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "Operation Uploaded: \"chunked\" annotation requires a binary type") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestInvalidSampling(t *testing.T) {
+	options := compiler.Options{
+		File:  invalidSamplingFile,
+		Gen:   "go",
+		Out:   outputDir,
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "Operation Latency: \"sample_rate\" annotation requires a rate greater than 0 and at most 1") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestInvalidSamplingNaN(t *testing.T) {
+	options := compiler.Options{
+		File:  nanSamplingFile,
+		Gen:   "go",
+		Out:   outputDir,
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "Operation Latency: \"sample_rate\" annotation requires a rate greater than 0 and at most 1") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestInvalidReply(t *testing.T) {
+	options := compiler.Options{
+		File:  invalidReplyFile,
+		Gen:   "go",
+		Out:   outputDir,
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "Operation Placed: reply type declared with both \"->\" and the \"reply\" annotation") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestUnknownReply(t *testing.T) {
+	options := compiler.Options{
+		File:  unknownReplyFile,
+		Gen:   "go",
+		Out:   outputDir,
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "Invalid reply type Nope for Questions.Ask") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
@@ -15,6 +15,7 @@ package test
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -233,3 +234,49 @@ func TestValidJavaVendorNoPathUsesDefinedNamespace(t *testing.T) {
 
 	assertFilesNotExist(t, filesNotToGenerate)
 }
+
+// Ensures request/reply operations fail generation for Java, which doesn't
+// generate requesters and responders.
+func TestJavaReplyUnsupported(t *testing.T) {
+	options := compiler.Options{
+		File:  scopeRequestFile,
+		Gen:   "java",
+		Out:   filepath.Join(outputDir, "java_reply"),
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "Events.EventCreated: reply operations are not supported for java") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+// Ensures deprecated scopes and operations are noted in the docs of the
+// generated publish and subscribe methods.
+func TestJavaDeprecatedOperations(t *testing.T) {
+	defer globals.Reset()
+	nowBefore := globals.Now
+	defer func() {
+		globals.Now = nowBefore
+	}()
+	globals.Now = time.Date(2015, 11, 24, 0, 0, 0, 0, time.UTC)
+
+	options := compiler.Options{
+		File:  deprecatedScopeFile,
+		Gen:   "java",
+		Out:   filepath.Join(outputDir, "java_deprecated_scope"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/java/deprecated_scope/SensorsPublisher.java", filepath.Join(outputDir, "java_deprecated_scope", "deprecated_scope", "SensorsPublisher.java")},
+		{"expected/java/deprecated_scope/SensorsSubscriber.java", filepath.Join(outputDir, "java_deprecated_scope", "deprecated_scope", "SensorsSubscriber.java")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
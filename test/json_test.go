@@ -0,0 +1,51 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func TestJSON(t *testing.T) {
+	root := filepath.Join(outputDir, "json")
+	gens := map[string]string{
+		"go":         "go:json",
+		"java":       "java:json",
+		"py":         "py:json",
+		"dart":       "dart:json",
+		"dart_enums": "dart:json,use_enums,built_collections",
+	}
+	var options []compiler.Options
+	for dir, gen := range gens {
+		options = append(options, compiler.Options{
+			File:  jsonFile,
+			Gen:   gen,
+			Out:   filepath.Join(root, dir),
+			Delim: delim,
+		})
+	}
+
+	files := []FileComparisonPair{
+		{"expected/json/go/f_types.txt", filepath.Join(root, "go", "json", "f_types.go")},
+		{"expected/json/java/Drawing.java", filepath.Join(root, "java", "json", "Drawing.java")},
+		{"expected/json/java/Shape.java", filepath.Join(root, "java", "json", "Shape.java")},
+		{"expected/json/python/ttypes.py", filepath.Join(root, "py", "json_types", "ttypes.py")},
+		{"expected/json/dart/f_drawing.dart", filepath.Join(root, "dart", "json", "lib", "src", "f_drawing.dart")},
+		{"expected/json/dart/f_shape.dart", filepath.Join(root, "dart", "json", "lib", "src", "f_shape.dart")},
+		{"expected/json/dart_enums/f_drawing.dart", filepath.Join(root, "dart_enums", "json", "lib", "src", "f_drawing.dart")},
+	}
+	compileAtFixedDate(t, options, files)
+}
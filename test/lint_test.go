@@ -0,0 +1,85 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"testing"
+
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	lintFile      = "idl/lint/lint.frugal"
+	lintCleanFile = "idl/lint/shared.frugal"
+)
+
+func TestLint(t *testing.T) {
+	logger := &MockValidationLogger{}
+	linter := parser.NewLinterWithLogger(logger, "go", "py")
+	problems, err := linter.Lint(lintFile)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	expected := []string{
+		"idl/lint/lint.frugal: include unused.frugal is never referenced (unused-include)",
+		"idl/lint/lint.frugal: scope Empty has no operations (empty-scope)",
+		"idl/lint/lint.frugal: operation Updates.event_deleted should be UpperCamelCase (operation-name)",
+		"idl/lint/lint.frugal: no py namespace (missing-namespace)",
+		"idl/lint/lint.frugal: enum value Kind.None is a reserved word in py (reserved-word)",
+		"idl/lint/lint.frugal: field Event.from is a reserved word in py (reserved-word)",
+		"idl/lint/lint.frugal: argument Events.new.default is a reserved word in go (reserved-word)",
+		"idl/lint/lint.frugal: prefix variable Updates.var is a reserved word in go (reserved-word)",
+	}
+	actual := make([]string, len(problems))
+	for i, problem := range problems {
+		actual[i] = problem.String()
+	}
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, expected, logger.warnings)
+	assert.Len(t, logger.errors, 0)
+}
+
+func TestLintAllLanguages(t *testing.T) {
+	linter := parser.NewLinterWithLogger(&MockValidationLogger{})
+	problems, err := linter.Lint(lintFile)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	// Without languages, namespaces aren't checked and reserved words are
+	// checked for every language.
+	reserved := []string{}
+	for _, problem := range problems {
+		assert.NotEqual(t, parser.LintMissingNamespace, problem.Rule)
+		if problem.Rule == parser.LintReservedWord {
+			reserved = append(reserved, problem.Message)
+		}
+	}
+	assert.Contains(t, reserved, "method Events.new is a reserved word in java")
+	assert.Contains(t, reserved, "prefix variable Updates.var is a reserved word in dart")
+	assert.NotContains(t, reserved, "field Event.type is a reserved word in go")
+}
+
+func TestLintClean(t *testing.T) {
+	logger := &MockValidationLogger{}
+	linter := parser.NewLinterWithLogger(logger, "go")
+	problems, err := linter.Lint(lintCleanFile)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assert.Len(t, problems, 0)
+	assert.Len(t, logger.warnings, 0)
+}
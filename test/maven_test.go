@@ -0,0 +1,51 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func TestJavaMaven(t *testing.T) {
+	options := compiler.Options{
+		File:    frugalGenFile,
+		Gen:     "java:maven=com.example.events",
+		Out:     filepath.Join(outputDir, "maven"),
+		Delim:   delim,
+		Recurse: true,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/java/maven/pom.xml", filepath.Join(outputDir, "maven", "pom.xml")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestJavaMavenSeparatedScopes(t *testing.T) {
+	options := compiler.Options{
+		File:  frugalGenFile,
+		Gen:   "java:maven,scopes_out=" + filepath.Join(outputDir, "maven_scopes"),
+		Out:   filepath.Join(outputDir, "maven_separated"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err == nil {
+		t.Fatal("Expected error")
+	}
+}
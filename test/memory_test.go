@@ -0,0 +1,139 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures generated files are returned by their paths relative to the output
+// location, matching what's generated to disk, and not left on disk.
+func TestCompileToMemory(t *testing.T) {
+	options := compiler.Options{
+		File:  chunkedFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "memory"),
+		Delim: delim,
+	}
+	files, err := compiler.CompileToMemory(options)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	paths := []string{}
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	assert.Equal(t, []string{"chunked/f_files_scope.go", "chunked/f_types.go"}, paths)
+
+	expected, err := ioutil.ReadFile("expected/go/chunked/f_files_scope.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(expected), string(files["chunked/f_files_scope.go"]))
+
+	if _, err := os.Stat(filepath.Join(outputDir, "memory")); !os.IsNotExist(err) {
+		t.Fatal("Expected nothing to be generated to the output location", err)
+	}
+}
+
+// Ensures the listed outputs are the generated files joined to the output
+// location, which defaults to the language's default output directory, and
+// that nothing is written.
+func TestListOutputs(t *testing.T) {
+	options := compiler.Options{
+		File:  chunkedFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "list_outputs"),
+		Delim: delim,
+	}
+	paths, err := compiler.ListOutputs(options)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	assert.Equal(t, []string{
+		filepath.Join(outputDir, "list_outputs", "chunked", "f_files_scope.go"),
+		filepath.Join(outputDir, "list_outputs", "chunked", "f_types.go"),
+	}, paths)
+	if _, err := os.Stat(filepath.Join(outputDir, "list_outputs")); !os.IsNotExist(err) {
+		t.Fatal("Expected nothing to be generated to the output location", err)
+	}
+
+	options.Out = ""
+	options.Namespaces = []string{"go=acme_chunked"}
+	paths, err = compiler.ListOutputs(options)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	assert.Equal(t, []string{
+		filepath.Join("gen-go", "acme_chunked", "f_files_scope.go"),
+		filepath.Join("gen-go", "acme_chunked", "f_types.go"),
+	}, paths)
+	if _, err := os.Stat("gen-go"); !os.IsNotExist(err) {
+		t.Fatal("Expected nothing to be generated to the default output location", err)
+	}
+}
+
+// Ensures files generated to the directory of an option overriding the
+// output directory of an artifact type are listed in it, and that nothing is
+// written there.
+func TestListOutputsArtifactOut(t *testing.T) {
+	models, err := filepath.Abs(filepath.Join(outputDir, "list_outputs_models"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := compiler.Options{
+		File:  chunkedFile,
+		Gen:   "go:models_out=" + models,
+		Out:   filepath.Join(outputDir, "list_outputs_scopes"),
+		Delim: delim,
+	}
+	paths, err := compiler.ListOutputs(options)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	assert.Equal(t, []string{
+		filepath.Join(models, "chunked", "f_types.go"),
+		filepath.Join(outputDir, "list_outputs_scopes", "chunked", "f_files_scope.go"),
+	}, paths)
+	for _, dir := range []string{models, filepath.Join(outputDir, "list_outputs_scopes")} {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Fatal("Expected nothing to be generated to", dir, err)
+		}
+	}
+}
+
+// Ensures compiling to memory fails with an option overriding the output
+// directory of an artifact type rather than writing to it.
+func TestCompileToMemoryArtifactOut(t *testing.T) {
+	models := filepath.Join(outputDir, "memory_models")
+	options := compiler.Options{
+		File:  chunkedFile,
+		Gen:   "go:models_out=" + models,
+		Delim: delim,
+	}
+	_, err := compiler.CompileToMemory(options)
+	assert.EqualError(t, err, "go option models_out can't be used when compiling to memory")
+	if _, err := os.Stat(models); !os.IsNotExist(err) {
+		t.Fatal("Expected nothing to be generated to the models directory", err)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+// Ensures the generated file is generated in its overridden namespace, which
+// also satisfies the namespace error policy, and that overrides for other
+// languages are ignored.
+func TestNamespaceOverride(t *testing.T) {
+	options := compiler.Options{
+		File:            namespacesFile,
+		Gen:             "go",
+		Out:             filepath.Join(outputDir, "namespace_override"),
+		Delim:           delim,
+		NamespacePolicy: compiler.NamespaceError,
+		IncludeMap:      []string{"shared:acme_money"},
+		Namespaces:      []string{"go=acme_main", "dart=acme.main"},
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	types, err := ioutil.ReadFile(filepath.Join(outputDir, "namespace_override", "acme_main", "f_types.go"))
+	if err != nil {
+		t.Fatal("Expected file to be generated in its overridden namespace", err)
+	}
+	if !strings.Contains(string(types), "package acme_main") {
+		t.Fatalf("Expected overridden package:\n%s", types)
+	}
+}
+
+// Ensures a namespace override which isn't of the form <lang>=<namespace> is
+// an error.
+func TestNamespaceOverrideInvalid(t *testing.T) {
+	options := compiler.Options{
+		File:       namespacesFile,
+		Gen:        "go",
+		Out:        filepath.Join(outputDir, "namespace_override_invalid"),
+		Delim:      delim,
+		Namespaces: []string{"go:acme_main"},
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.HasPrefix(err.Error(), "Invalid namespace override go:acme_main") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
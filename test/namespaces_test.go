@@ -0,0 +1,96 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+// Ensures files without a namespace for the language fall back silently by
+// default.
+func TestNamespacePolicyFallback(t *testing.T) {
+	options := compiler.Options{
+		File:    namespacesFile,
+		Gen:     "go",
+		Out:     filepath.Join(outputDir, "namespace_fallback"),
+		Delim:   delim,
+		Recurse: true,
+		Strict:  true,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+}
+
+// Ensures the warn policy reports includes without a namespace for the
+// language, which strict mode turns into an error.
+func TestNamespacePolicyWarn(t *testing.T) {
+	options := compiler.Options{
+		File:            namespacesFile,
+		Gen:             "go",
+		Out:             filepath.Join(outputDir, "namespace_warn"),
+		Delim:           delim,
+		Recurse:         true,
+		Strict:          true,
+		NamespacePolicy: compiler.NamespaceWarn,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "shared.frugal has no go namespace, falling back to the file name") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+// Ensures the error policy fails on includes without a namespace for the
+// language.
+func TestNamespacePolicyError(t *testing.T) {
+	options := compiler.Options{
+		File:            namespacesFile,
+		Gen:             "java",
+		Out:             filepath.Join(outputDir, "namespace_error"),
+		Delim:           delim,
+		NamespacePolicy: compiler.NamespaceError,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.HasSuffix(err.Error(), "main.frugal has no java namespace") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+// Ensures unknown namespace policies are rejected.
+func TestNamespacePolicyInvalid(t *testing.T) {
+	options := compiler.Options{
+		File:            namespacesFile,
+		Gen:             "go",
+		Out:             filepath.Join(outputDir, "namespace_invalid"),
+		Delim:           delim,
+		NamespacePolicy: "ignore",
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "Invalid namespace policy ignore") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
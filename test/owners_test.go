@@ -0,0 +1,63 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const ownersFile = "idl/owners.frugal"
+
+func TestOwners(t *testing.T) {
+	frugal, err := parser.ParseFrugal(ownersFile)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	ownerships := []string{}
+	for _, ownership := range frugal.Ownerships() {
+		ownerships = append(ownerships, ownership.String())
+	}
+	assert.Equal(t, []string{
+		"scope Invoices: team-billing (#billing)",
+		"operation Invoices.InvoiceDisputed: team-disputes (#billing)",
+		"operation Invoices.InvoicePaid: team-billing (#payments)",
+		"service InvoiceService: team-billing, team-platform (#billing)",
+		"service LegacyService: unowned",
+	}, ownerships)
+}
+
+func TestEventCatalogOwners(t *testing.T) {
+	options := compiler.Options{
+		File:         ownersFile,
+		Gen:          "html",
+		Out:          filepath.Join(outputDir, "event_catalog"),
+		Delim:        delim,
+		EventCatalog: true,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/event_catalog/owners.events.json", filepath.Join(outputDir, "event_catalog", "owners.events.json")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/stretchr/testify/assert"
+)
+
+// concurrencyTracker records the most compilations running at once, per
+// language and in total.
+type concurrencyTracker struct {
+	mu      sync.Mutex
+	running map[string]int
+	max     map[string]int
+}
+
+func (c *concurrencyTracker) compile(options compiler.Options) error {
+	c.mu.Lock()
+	for _, key := range []string{options.Gen, "total"} {
+		c.running[key]++
+		if c.running[key] > c.max[key] {
+			c.max[key] = c.running[key]
+		}
+	}
+	c.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	c.mu.Lock()
+	c.running[options.Gen]--
+	c.running["total"]--
+	c.mu.Unlock()
+	if options.File == "bad.frugal" {
+		return errors.New("bad file")
+	}
+	return nil
+}
+
+func parallelOptions(recurse bool, files ...string) []compiler.Options {
+	options := []compiler.Options{}
+	for _, file := range files {
+		for _, gen := range []string{"go", "dart"} {
+			options = append(options, compiler.Options{File: file, Gen: gen, Out: outputDir, Recurse: recurse})
+		}
+	}
+	return options
+}
+
+// Ensures a language is generated to different output locations in parallel.
+func TestCompileAllParallel(t *testing.T) {
+	tracker := &concurrencyTracker{running: map[string]int{}, max: map[string]int{}}
+	options := parallelOptions(false, "a.frugal", "b.frugal", "c.frugal")
+	for i := range options {
+		if options[i].Gen == "go" {
+			options[i].Out = filepath.Join(outputDir, options[i].File)
+		}
+	}
+	err := compiler.CompileAll(options, 6, tracker.compile)
+	assert.Nil(t, err)
+	assert.True(t, tracker.max["go"] > 1)
+	assert.Equal(t, 1, tracker.max["dart"])
+
+	// Languages have their own default output locations.
+	tracker = &concurrencyTracker{running: map[string]int{}, max: map[string]int{}}
+	options = parallelOptions(false, "a.frugal")
+	for i := range options {
+		options[i].Out = ""
+	}
+	err = compiler.CompileAll(options, 2, tracker.compile)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, tracker.max["total"])
+}
+
+// Ensures files and languages are generated to the same output location one
+// at a time, since they may write the same files.
+func TestCompileAllSerializesOutput(t *testing.T) {
+	for _, recurse := range []bool{false, true} {
+		tracker := &concurrencyTracker{running: map[string]int{}, max: map[string]int{}}
+		err := compiler.CompileAll(parallelOptions(recurse, "a.frugal", "b.frugal", "c.frugal"), 6, tracker.compile)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, tracker.max["total"])
+	}
+}
+
+func TestCompileAllAggregatesErrors(t *testing.T) {
+	tracker := &concurrencyTracker{running: map[string]int{}, max: map[string]int{}}
+	err := compiler.CompileAll(parallelOptions(false, "a.frugal", "bad.frugal"), 4, tracker.compile)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	assert.Equal(t, "Failed to generate bad.frugal (go):\n\tbad file\nFailed to generate bad.frugal (dart):\n\tbad file", err.Error())
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/plugin"
+	"github.com/Workiva/frugal/plugin/plugintest"
+)
+
+// declarationGenerator is an example plugin generator which generates
+// TypeScript-style declarations.
+type declarationGenerator struct{}
+
+func (declarationGenerator) Generate(file *plugin.File, out plugin.Output) error {
+	types := &plugin.TypeMap{
+		Base: map[string]string{
+			"bool": "boolean", "byte": "number", "i8": "number", "i16": "number",
+			"i32": "number", "i64": "number", "double": "number",
+			"string": "string", "binary": "Uint8Array",
+		},
+		List: "Array<%s>",
+		Set:  "Set<%s>",
+		Map:  "Map<%s, %s>",
+		Custom: func(t *plugin.Type, file *plugin.File) string {
+			if include := t.IncludeName(); include != "" {
+				return plugin.SnakeToCamel(include) + "." + t.ParamName()
+			}
+			return t.Name
+		},
+		ResolveTypedefs: true,
+	}
+
+	e := plugin.NewEmitter("  ")
+	e.Line("// %s (plugin SDK %s)", file.Name, plugin.Version)
+	for _, enum := range file.Enums {
+		e.Blank()
+		e.Block(fmt.Sprintf("export enum %s {", enum.Name), "}", func() {
+			for _, value := range enum.Values {
+				e.Line("%s = %d,", plugin.ScreamingSnake(value.Name), value.Value)
+			}
+		})
+	}
+	for _, s := range append(append(file.Structs, file.Exceptions...), file.Unions...) {
+		e.Blank()
+		e.Comment("// ", s.Comment)
+		var err error
+		e.Block(fmt.Sprintf("export interface %s { // %s", s.Name, s.Kind), "}", func() {
+			for _, field := range s.Fields {
+				typ, resolveErr := types.Resolve(field.Type, file)
+				if resolveErr != nil {
+					err = resolveErr
+					return
+				}
+				optional := ""
+				if field.Modifier != plugin.Required {
+					optional = "?"
+				}
+				e.Line("%s%s: %s; // %d", plugin.LowerFirst(plugin.SnakeToCamel(field.Name)), optional, typ, field.ID)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, service := range file.Services {
+		e.Blank()
+		e.Line("// hash %s", service.Hash)
+		e.Block(fmt.Sprintf("export interface F%s {", service.Name), "}", func() {
+			for _, method := range service.Methods {
+				returnType := "void"
+				if method.ReturnType != nil {
+					returnType, _ = types.Resolve(method.ReturnType, file)
+				}
+				e.Line("%s(): Promise<%s>;", method.Name, returnType)
+			}
+		})
+	}
+	for _, scope := range file.Scopes {
+		e.Blank()
+		e.Line("// scope %s prefix %q", scope.Name, scope.Prefix.Template)
+		for _, op := range scope.Operations {
+			e.Line("// %s: %s", op.Name, op.Type)
+		}
+	}
+	return e.WriteTo(out, file.Name+".d.ts")
+}
+
+func TestPluginGenerator(t *testing.T) {
+	plugintest.Update = copyFiles
+	files := plugintest.Generate(t, declarationGenerator{}, filterFile)
+	plugintest.AssertGolden(t, files, filepath.Join("expected", "plugin"))
+}
+
+func TestPluginGeneratorSource(t *testing.T) {
+	files := plugintest.GenerateSource(t, declarationGenerator{}, "example.frugal", `
+typedef list<string> Names
+
+enum Level {
+    lowPriority = 1,
+    highPriority = 2
+}
+
+struct Team {
+    1: required string team_name,
+    2: optional Names members,
+    3: map<string, set<i64>> scores
+}
+`)
+	expected := `// example (plugin SDK ` + plugin.Version + `)
+
+export enum Level {
+  LOW_PRIORITY = 1,
+  HIGH_PRIORITY = 2,
+}
+
+export interface Team { // struct
+  teamName: string; // 1
+  members?: Array<string>; // 2
+  scores?: Map<string, Set<number>>; // 3
+}
+`
+	if files["example.d.ts"] != expected {
+		t.Fatalf("expected:\n%s\ngenerated:\n%s", expected, files["example.d.ts"])
+	}
+}
+
+func TestPluginTypeMapMissingBaseType(t *testing.T) {
+	types := &plugin.TypeMap{List: "[]%s"}
+	_, err := types.Resolve(&plugin.Type{Name: "list", ValueType: &plugin.Type{Name: "i32"}}, &plugin.File{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestPluginNaming(t *testing.T) {
+	cases := []struct {
+		fn       func(string) string
+		in, want string
+	}{
+		{plugin.Title, "getUser", "GetUser"},
+		{plugin.LowerFirst, "GetUser", "getUser"},
+		{plugin.SnakeToCamel, "user_id", "UserId"},
+		{plugin.CamelToSnake, "getHTTPRequest", "get_http_request"},
+		{plugin.CamelToSnake, "UserID", "user_id"},
+		{plugin.ScreamingSnake, "maxRetries", "MAX_RETRIES"},
+	}
+	for _, c := range cases {
+		if got := c.fn(c.in); got != c.want {
+			t.Errorf("%s: expected %s, got %s", c.in, c.want, got)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func TestPrefixValidation(t *testing.T) {
+	root := filepath.Join(outputDir, "prefix_validation")
+	gens := map[string]string{
+		"go":   "go:prefix_validation=error",
+		"java": "java:prefix_validation=sanitize",
+		"py":   "py:asyncio,prefix_validation=error",
+		"dart": "dart:prefix_validation=sanitize",
+	}
+	var options []compiler.Options
+	for lang, gen := range gens {
+		options = append(options, compiler.Options{
+			File:  prefixValidationFile,
+			Gen:   gen,
+			Out:   filepath.Join(root, lang),
+			Delim: delim,
+		})
+	}
+
+	files := []FileComparisonPair{
+		{"expected/prefix_validation/go/f_alerts_scope.txt", filepath.Join(root, "go", "prefix_validation", "f_alerts_scope.go")},
+		{"expected/prefix_validation/java/AlertsPublisher.java", filepath.Join(root, "java", "prefix_validation", "AlertsPublisher.java")},
+		{"expected/prefix_validation/java/AlertsSubscriber.java", filepath.Join(root, "java", "prefix_validation", "AlertsSubscriber.java")},
+		{"expected/prefix_validation/python/f_Alerts_publisher.py", filepath.Join(root, "py", "prefix_validation", "f_Alerts_publisher.py")},
+		{"expected/prefix_validation/python/f_Alerts_subscriber.py", filepath.Join(root, "py", "prefix_validation", "f_Alerts_subscriber.py")},
+		{"expected/prefix_validation/dart/f_alerts_scope.dart", filepath.Join(root, "dart", "prefix_validation", "lib", "src", "f_alerts_scope.dart")},
+	}
+	compileAtFixedDate(t, options, files)
+}
+
+func TestPrefixValidationInvalid(t *testing.T) {
+	options := compiler.Options{
+		File:  prefixValidationFile,
+		Gen:   "go:prefix_validation=strip",
+		Out:   filepath.Join(outputDir, "prefix_validation_invalid"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err == nil {
+		t.Fatal("Expected error for go:prefix_validation=strip")
+	}
+}
@@ -15,6 +15,7 @@ package test
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Workiva/frugal/compiler"
@@ -186,3 +187,107 @@ func TestPythonAbsoluteOutputPath(t *testing.T) {
 	copyAllFiles(t, files)
 	compareAllFiles(t, files)
 }
+
+func TestPythonArtifactOutputDirs(t *testing.T) {
+	root := filepath.Join(outputDir, "artifact_out")
+	options := compiler.Options{
+		File:  filterFile,
+		Gen:   "py:asyncio,services_out=" + filepath.Join(root, "services"),
+		Out:   filepath.Join(root, "models"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	assertFilesExist(t, []string{
+		filepath.Join(root, "models", "__init__.py"),
+		filepath.Join(root, "models", "filter", "ttypes.py"),
+		filepath.Join(root, "models", "filter", "f_Alerts_publisher.py"),
+		filepath.Join(root, "services", "__init__.py"),
+		filepath.Join(root, "services", "filter", "f_Base.py"),
+	})
+
+	files := []FileComparisonPair{
+		{"expected/python/artifact_out/f_Invoices.py", filepath.Join(root, "services", "filter", "f_Invoices.py")},
+		{"expected/python/artifact_out/__init__.py", filepath.Join(root, "services", "filter", "__init__.py")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestPythonStrict(t *testing.T) {
+	options := compiler.Options{
+		File:   frugalGenFile,
+		Gen:    "py",
+		Out:    filepath.Join(outputDir, "python_strict"),
+		Delim:  delim,
+		Strict: true,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "Events: scope subscriber generation is not implemented for vanilla Python 2.7") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+// Ensures the hooks option generates publisher and subscriber hooks which are
+// called with the context and operation name of each message.
+func TestPythonAsyncIOHooks(t *testing.T) {
+	options := compiler.Options{
+		File:  frugalGenFile,
+		Gen:   "py:asyncio,hooks",
+		Out:   filepath.Join(outputDir, "python_hooks"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/python.asyncio/hooks/f_Events_publisher.py", filepath.Join(outputDir, "python_hooks", "variety", "python", "f_Events_publisher.py")},
+		{"expected/python.asyncio/hooks/f_Events_subscriber.py", filepath.Join(outputDir, "python_hooks", "variety", "python", "f_Events_subscriber.py")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures the runtime_check option generates a check of the Frugal library's
+// version, defaulting to the compiler's version, when the types are imported.
+func TestPythonRuntimeCheck(t *testing.T) {
+	options := compiler.Options{
+		File:  runtimeCheckFile,
+		Gen:   "py:runtime_check",
+		Out:   filepath.Join(outputDir, "python_runtime_check"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/python/runtime_check/ttypes.py", filepath.Join(outputDir, "python_runtime_check", "runtime_check", "ttypes.py")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures request/reply operations fail generation for Python, which doesn't
+// generate requesters and responders.
+func TestPythonReplyUnsupported(t *testing.T) {
+	options := compiler.Options{
+		File:  scopeRequestFile,
+		Gen:   "py:asyncio",
+		Out:   filepath.Join(outputDir, "python_reply"),
+		Delim: delim,
+	}
+	err := compiler.Compile(options)
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if !strings.Contains(err.Error(), "Events.EventCreated: reply operations are not supported for py") {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/globals"
+)
+
+func TestGoSampling(t *testing.T) {
+	defer globals.Reset()
+	options := compiler.Options{
+		File:  samplingFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "sampling"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/sampling/f_telemetry_scope.txt", filepath.Join(outputDir, "sampling", "sampling", "f_telemetry_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures sampled operations are reported as unsupported, which strict mode
+// turns into an error, since every message is published for languages other
+// than Go.
+func TestSamplingUnsupported(t *testing.T) {
+	for _, lang := range []string{"java", "py", "dart"} {
+		options := compiler.Options{
+			File:   samplingFile,
+			Gen:    lang,
+			Out:    filepath.Join(outputDir, "sampling_unsupported", lang),
+			Delim:  delim,
+			Strict: true,
+		}
+		err := compiler.Compile(options)
+		if err == nil {
+			t.Fatalf("Expected error for %s", lang)
+		}
+		if !strings.Contains(err.Error(), "Telemetry.Latency: sampling is not supported for "+lang) {
+			t.Fatalf("Unexpected error for %s: %s", lang, err)
+		}
+	}
+}
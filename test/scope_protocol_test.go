@@ -0,0 +1,62 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/globals"
+)
+
+func TestGoScopeProtocol(t *testing.T) {
+	defer globals.Reset()
+	root := filepath.Join(outputDir, "scope_protocol")
+	gens := map[string]string{
+		"annotation": "go",
+		"option":     "go:scope_protocol=json",
+	}
+	for dir, gen := range gens {
+		options := compiler.Options{
+			File:  scopeProtocolFile,
+			Gen:   gen,
+			Out:   filepath.Join(root, dir),
+			Delim: delim,
+		}
+		if err := compiler.Compile(options); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/scope_protocol/f_readings_scope.txt", filepath.Join(root, "annotation", "scope_protocol", "f_readings_scope.go")},
+		{"expected/go/scope_protocol/f_calibrations_scope.txt", filepath.Join(root, "option", "scope_protocol", "f_calibrations_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestScopeProtocolInvalid(t *testing.T) {
+	for _, gen := range []string{"go:scope_protocol=xml", "java", "py", "dart"} {
+		options := compiler.Options{
+			File:  scopeProtocolFile,
+			Gen:   gen,
+			Out:   filepath.Join(outputDir, "scope_protocol_invalid"),
+			Delim: delim,
+		}
+		if err := compiler.Compile(options); err == nil {
+			t.Fatalf("Expected error for %s", gen)
+		}
+	}
+}
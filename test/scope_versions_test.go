@@ -0,0 +1,88 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/globals"
+)
+
+func TestGoScopeVersions(t *testing.T) {
+	defer globals.Reset()
+	root := filepath.Join(outputDir, "scope_versions")
+	gens := map[string]string{
+		"versioned": "go",
+		"subscribe": "go:subscribe_versions=2",
+	}
+	for dir, gen := range gens {
+		options := compiler.Options{
+			File:  scopeVersionsFile,
+			Gen:   gen,
+			Out:   filepath.Join(root, dir),
+			Delim: delim,
+		}
+		if err := compiler.Compile(options); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/scope_versions/f_orders_scope.txt", filepath.Join(root, "versioned", "scope_versions", "f_orders_scope.go")},
+		{"expected/go/scope_versions/f_orders_subscribe_scope.txt", filepath.Join(root, "subscribe", "scope_versions", "f_orders_scope.go")},
+		{"expected/go/scope_versions/f_refunds_subscribe_scope.txt", filepath.Join(root, "subscribe", "scope_versions", "f_refunds_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestJavaScopeVersions(t *testing.T) {
+	defer globals.Reset()
+	options := compiler.Options{
+		File:  scopeVersionsFile,
+		Gen:   "java:generated_annotations=undated",
+		Out:   filepath.Join(outputDir, "scope_versions", "java"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/java/scope_versions/OrdersPublisher.java", filepath.Join(outputDir, "scope_versions", "java", "scope_versions", "OrdersPublisher.java")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestScopeVersionsInvalid(t *testing.T) {
+	defer globals.Reset()
+	options := compiler.Options{
+		File:  scopeVersionsFile,
+		Gen:   "go:subscribe_versions=0",
+		Out:   filepath.Join(outputDir, "scope_versions_invalid"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err == nil {
+		t.Fatal("Expected error for subscribe_versions=0")
+	}
+
+	options.File = scopeVersionsInvalid
+	options.Gen = "go"
+	if err := compiler.Compile(options); err == nil {
+		t.Fatal("Expected error for scope version 0")
+	}
+}
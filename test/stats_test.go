@@ -0,0 +1,69 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"testing"
+
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const payloadStatsFile = "idl/payload_stats.frugal"
+
+func TestPayloadStats(t *testing.T) {
+	logger := &MockValidationLogger{}
+	analyzer := parser.NewPayloadAnalyzerWithLogger(logger, parser.PayloadThresholds{})
+	stats, err := analyzer.Analyze(payloadStatsFile)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	expected := []string{
+		"struct Inner: fields=2 nested_fields=2 depth=1 estimated_size=31",
+		"struct Outer: fields=2 nested_fields=4 depth=2 estimated_size=107",
+		"union Choice: fields=2 nested_fields=2 depth=1 estimated_size=24",
+		"scope Updates: operation OuterUpdated: fields=2 nested_fields=4 depth=2 estimated_size=107",
+	}
+	actual := make([]string, len(stats))
+	for i, s := range stats {
+		actual[i] = s.String()
+	}
+	assert.Equal(t, expected, actual)
+	assert.Len(t, logger.errors, 0)
+}
+
+func TestPayloadStatsThresholds(t *testing.T) {
+	logger := &MockValidationLogger{}
+	analyzer := parser.NewPayloadAnalyzerWithLogger(logger, parser.PayloadThresholds{
+		MaxEstimatedSize: 100,
+		MaxFields:        3,
+		MaxDepth:         1,
+	})
+	_, err := analyzer.Analyze(payloadStatsFile)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	expected := []string{
+		"struct Outer: estimated size 107 exceeds maximum of 100",
+		"struct Outer: nested field count 4 exceeds maximum of 3",
+		"struct Outer: depth 2 exceeds maximum of 1",
+		"scope Updates: operation OuterUpdated: estimated size 107 exceeds maximum of 100",
+		"scope Updates: operation OuterUpdated: nested field count 4 exceeds maximum of 3",
+		"scope Updates: operation OuterUpdated: depth 2 exceeds maximum of 1",
+	}
+	assert.Equal(t, expected, logger.errors)
+	assert.Equal(t, "FAILED: payload analysis of "+payloadStatsFile, err.Error())
+}
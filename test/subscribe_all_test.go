@@ -0,0 +1,43 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+// Ensures generating SubscribeAll with a topic delimiter its wildcard doesn't
+// match is warned about, which strict mode turns into an error.
+func TestSubscribeAllDelimiter(t *testing.T) {
+	for _, lang := range []string{"go", "dart"} {
+		options := compiler.Options{
+			File:   chunkedFile,
+			Gen:    lang,
+			Out:    filepath.Join(outputDir, "subscribe_all_delimiter", lang),
+			Delim:  "/",
+			Strict: true,
+		}
+		err := compiler.Compile(options)
+		if err == nil {
+			t.Fatalf("Expected error for %s", lang)
+		}
+		if !strings.Contains(err.Error(), `Files: SubscribeAll subscribes to a * wildcard topic, which only matches every operation with the . topic delimiter, not "/"`) {
+			t.Fatalf("Unexpected error for %s: %s", lang, err)
+		}
+	}
+}
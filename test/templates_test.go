@@ -0,0 +1,106 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoTemplates(t *testing.T) {
+	defer globals.Reset()
+	options := compiler.Options{
+		File:  templatesFile,
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "templates"),
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/templates/f_entities_scope.txt", filepath.Join(outputDir, "templates", "templates", "f_entities_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures templates defined in conditional blocks are only defined for the
+// languages the blocks are included for.
+func TestTemplatesConditional(t *testing.T) {
+	frugal, err := parser.ParseFrugalForLang(templatesFile, "java")
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	names := []string{}
+	for _, op := range frugal.Scopes[0].Operations {
+		names = append(names, op.Name)
+	}
+	assert.NotContains(t, names, "UserAudited")
+	assert.Len(t, names, 6)
+}
+
+// Ensures expansions are joined on the line of their #expand directive, so
+// positions in parse errors are unchanged, and that parameters are replaced.
+func TestTemplatesKeepLines(t *testing.T) {
+	src := "#template op(Name, Type)\n    {{Name}}: {{ Type }}\n    {{Name}}Batch: list<{{Type}}>\n#endtemplate\nscope A {\n  #expand op(Sent, string)\n}\n"
+	preprocessed, err := parser.Preprocess("test.frugal", []byte(src), "go")
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assert.Equal(t, "\n\n\n\nscope A {\n  Sent: string SentBatch: list<string>\n}\n", string(preprocessed))
+}
+
+// Ensures malformed templates and expansions are errors reporting their line.
+func TestTemplatesInvalid(t *testing.T) {
+	cases := []struct {
+		src string
+		err string
+	}{
+		{"#template op(Name)\n{{Name}}: string\n", "test.frugal:1: #template without #endtemplate"},
+		{"#endtemplate\n", "test.frugal:1: #endtemplate without #template"},
+		{"#template op\n#endtemplate\n", "test.frugal:1: invalid template op, expected <name>(<args>)"},
+		{"#template op(Name, Name)\n#endtemplate\n", "test.frugal:1: duplicate parameter Name of template op"},
+		{"#template op()\n#endtemplate\n#template op()\n#endtemplate\n", "test.frugal:3: duplicate template op"},
+		{"#template op(Name)\n{{Type}}: string\n#endtemplate\n", "test.frugal:2: undefined parameter Type of template op"},
+		{"#template op(Name)\n{{Name}}: string // a comment\n#endtemplate\n", "test.frugal:2: single-line comments can't be used in template op"},
+		{"#template op(Name)\n#if lang == \"go\"\n#endif\n#endtemplate\n", "test.frugal:2: #if can't be used in a template"},
+		{"scope A {\n#expand op(Sent)\n}\n", "test.frugal:2: undefined template op"},
+		{"#template op(Name)\n#endtemplate\n#expand op(A, B)\n", "test.frugal:3: template op takes 1 argument(s), got 2"},
+	}
+	for _, c := range cases {
+		_, err := parser.Preprocess("test.frugal", []byte(c.src), "go")
+		if assert.Error(t, err, c.src) {
+			assert.True(t, strings.HasPrefix(err.Error(), c.err), err.Error())
+		}
+	}
+}
+
+// Ensures string literals containing comment markers can be used in
+// templates.
+func TestTemplatesStrings(t *testing.T) {
+	src := "#template op(Name)\n{{Name}}: string (url=\"http://acme.com/#{{Name}}\")\n#endtemplate\n#expand op(Sent)\n"
+	preprocessed, err := parser.Preprocess("test.frugal", []byte(src), "go")
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assert.Equal(t, "\n\n\nSent: string (url=\"http://acme.com/#Sent\")\n", string(preprocessed))
+}
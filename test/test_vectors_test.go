@@ -0,0 +1,61 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func TestTestVectors(t *testing.T) {
+	root := filepath.Join(outputDir, "test_vectors")
+	options := compiler.Options{
+		File:        testVectorsFile,
+		Gen:         "go",
+		Out:         root,
+		Delim:       delim,
+		TestVectors: true,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	for _, gen := range []string{"java:maven", "py", "dart"} {
+		options.Gen = gen
+		options.Out = filepath.Join(root, gen[:strings.Index(gen+":", ":")])
+		if err := compiler.Compile(options); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+	}
+
+	vectors := filepath.Join(root, "test_vectors", "test_vectors")
+	files := []FileComparisonPair{
+		{"expected/test_vectors/Sample.binary", filepath.Join(vectors, "Sample.binary")},
+		{"expected/test_vectors/Sample.compact", filepath.Join(vectors, "Sample.compact")},
+		{"expected/test_vectors/Sample.json", filepath.Join(vectors, "Sample.json")},
+		{"expected/test_vectors/Node.json", filepath.Join(vectors, "Node.json")},
+		{"expected/test_vectors/go/f_test_vectors_test.txt", filepath.Join(root, "test_vectors", "f_test_vectors_test.go")},
+		{"expected/test_vectors/java/TestVectorsTest.java", filepath.Join(root, "java", "TestVectorsTest.java")},
+		{"expected/test_vectors/java/pom.xml", filepath.Join(root, "java", "pom.xml")},
+		{"expected/test_vectors/python/test_vectors_test.py", filepath.Join(root, "py", "test_vectors", "test_vectors_test.py")},
+		{"expected/test_vectors/dart/test_vectors_test.dart", filepath.Join(root, "dart", "test_vectors", "test", "test_vectors_test.dart")},
+		{"expected/test_vectors/dart/pubspec.yaml", filepath.Join(root, "dart", "test_vectors", "pubspec.yaml")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+
+	// Keyed's map keys are structs, which the JSON protocol can't encode.
+	assertFilesNotExist(t, []string{filepath.Join(vectors, "Keyed.json")})
+}
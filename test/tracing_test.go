@@ -0,0 +1,58 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/globals"
+)
+
+func TestGoTracing(t *testing.T) {
+	defer globals.Reset()
+	options := compiler.Options{
+		File:    batchPublishFile,
+		Gen:     "go:package_prefix=github.com/Workiva/frugal/test/out/,batch_publish",
+		Out:     filepath.Join(outputDir, "tracing", "go"),
+		Delim:   delim,
+		Tracing: true,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/tracing/f_ticks_scope.txt", filepath.Join(outputDir, "tracing", "go", "batch_publish", "f_ticks_scope.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestTracingUnsupportedLanguage(t *testing.T) {
+	defer globals.Reset()
+	for _, gen := range []string{"java", "py", "dart"} {
+		options := compiler.Options{
+			File:    batchPublishFile,
+			Gen:     gen,
+			Out:     filepath.Join(outputDir, "tracing", gen),
+			Delim:   delim,
+			Tracing: true,
+		}
+		if err := compiler.Compile(options); err == nil {
+			t.Fatalf("Expected error for %s", gen)
+		}
+	}
+}
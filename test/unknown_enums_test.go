@@ -0,0 +1,66 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+)
+
+func TestUnknownEnums(t *testing.T) {
+	root := filepath.Join(outputDir, "unknown_enums")
+	gens := map[string]string{
+		"go":   "go:unknown_enums=unknown",
+		"java": "java:unknown_enums=error",
+		"py":   "py:unknown_enums=unknown",
+		"dart": "dart:unknown_enums=error",
+	}
+	var options []compiler.Options
+	for lang, gen := range gens {
+		options = append(options, compiler.Options{
+			File:  unknownEnumsFile,
+			Gen:   gen,
+			Out:   filepath.Join(root, lang),
+			Delim: delim,
+		})
+	}
+
+	files := []FileComparisonPair{
+		{"expected/unknown_enums/go/f_types.txt", filepath.Join(root, "go", "unknown_enums", "f_types.go")},
+		{"expected/unknown_enums/java/Device.java", filepath.Join(root, "java", "unknown_enums", "Device.java")},
+		{"expected/unknown_enums/python/ttypes.py", filepath.Join(root, "py", "unknown_enums", "ttypes.py")},
+		{"expected/unknown_enums/dart/f_device.dart", filepath.Join(root, "dart", "unknown_enums", "lib", "src", "f_device.dart")},
+	}
+	compileAtFixedDate(t, options, files)
+}
+
+func TestUnknownEnumsInvalid(t *testing.T) {
+	for _, c := range []struct{ file, gen string }{
+		{unknownEnumsFile, "go:unknown_enums=ignore"},
+		{unknownEnumsFile, "java:unknown_enums=preserve"},
+		{unknownEnumsFile, "dart:unknown_enums=preserve,use_enums"},
+		{frugalGenFile, "py:unknown_enums=unknown"},
+	} {
+		options := compiler.Options{
+			File:  c.file,
+			Gen:   c.gen,
+			Out:   filepath.Join(outputDir, "unknown_enums_invalid"),
+			Delim: delim,
+		}
+		if err := compiler.Compile(options); err == nil {
+			t.Fatalf("Expected error for %s", c.gen)
+		}
+	}
+}
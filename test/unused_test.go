@@ -0,0 +1,74 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"testing"
+
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	unusedMainFile   = "idl/unused/main.frugal"
+	unusedCommonFile = "idl/unused/common.frugal"
+)
+
+func TestUnusedDefinitions(t *testing.T) {
+	logger := &MockValidationLogger{}
+	analyzer := parser.NewUnusedAnalyzerWithLogger(logger)
+	unused, err := analyzer.Analyze(unusedMainFile)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	expected := []string{
+		"idl/unused/common.frugal: enum Shape is never referenced by a scope, service, or constant",
+		"idl/unused/common.frugal: struct Orphan is never referenced by a scope, service, or constant",
+		"idl/unused/main.frugal: exception UnusedFailure is never referenced by a scope, service, or constant",
+		"idl/unused/main.frugal: struct Unused is never referenced by a scope, service, or constant",
+		"idl/unused/main.frugal: typedef Label is never referenced by a scope, service, or constant",
+	}
+	actual := make([]string, len(unused))
+	for i, definition := range unused {
+		actual[i] = definition.String()
+	}
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, expected, logger.warnings)
+	assert.Len(t, logger.errors, 0)
+}
+
+func TestUnusedDefinitionsIncludedFileAlone(t *testing.T) {
+	logger := &MockValidationLogger{}
+	analyzer := parser.NewUnusedAnalyzerWithLogger(logger)
+	unused, err := analyzer.Analyze(unusedCommonFile)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	// Without the including file, nothing in the workspace is referenced.
+	assert.Len(t, unused, 5)
+}
+
+func TestUnusedDefinitionsWorkspace(t *testing.T) {
+	logger := &MockValidationLogger{}
+	analyzer := parser.NewUnusedAnalyzerWithLogger(logger)
+	unused, err := analyzer.Analyze(unusedCommonFile, unusedMainFile)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	// The included file is only counted once regardless of how it's reached.
+	assert.Len(t, unused, 5)
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	src := filepath.Join(outputDir, "watch_src")
+	out := filepath.Join(outputDir, "watch")
+	copyIncrementalIDL(t, src)
+	options := compiler.Options{
+		Gen:     "go",
+		Out:     out,
+		Delim:   delim,
+		Recurse: true,
+	}
+	billing := filepath.Join(out, "billing", "f_types.go")
+	shared := filepath.Join(out, "shared", "f_types.go")
+
+	compiled := make(chan error)
+	stop := make(chan struct{})
+	defer close(stop)
+	go compiler.Watch([]string{filepath.Join(src, "billing.frugal")}, options, 10*time.Millisecond, stop,
+		func(file string, err error) {
+			compiled <- err
+		})
+	waitForCompile := func() {
+		select {
+		case err := <-compiled:
+			if err != nil {
+				t.Fatal("Unexpected error", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for compilation")
+		}
+	}
+
+	waitForCompile()
+	markGenerated(t, billing, shared)
+
+	// Changing an include regenerates it and the files including it.
+	contents := "namespace go shared\n\nstruct Money {\n    1: string currency,\n    2: i64 cents,\n}\n"
+	if err := ioutil.WriteFile(filepath.Join(src, "shared.frugal"), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitForCompile()
+	assert.False(t, isMarked(t, billing))
+	assert.False(t, isMarked(t, shared))
+}
+
+// Ensures changing an include of a conditional block recompiles the file,
+// even when the block isn't included for the language being generated.
+func TestWatchConditionalInclude(t *testing.T) {
+	src := filepath.Join(outputDir, "watch_conditional_src")
+	if err := os.MkdirAll(src, 0777); err != nil {
+		t.Fatal(err)
+	}
+	main := "#if lang == \"dart\"\ninclude \"shared.frugal\"\n#endif\n\nstruct Invoice {\n    1: string id,\n}\n"
+	if err := ioutil.WriteFile(filepath.Join(src, "main.frugal"), []byte(main), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src, "shared.frugal"), []byte("struct Money {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	options := compiler.Options{
+		Gen:   "go",
+		Out:   filepath.Join(outputDir, "watch_conditional"),
+		Delim: delim,
+	}
+
+	compiled := make(chan error)
+	stop := make(chan struct{})
+	defer close(stop)
+	go compiler.Watch([]string{filepath.Join(src, "main.frugal")}, options, 10*time.Millisecond, stop,
+		func(file string, err error) {
+			compiled <- err
+		})
+	waitForCompile := func() {
+		select {
+		case err := <-compiled:
+			if err != nil {
+				t.Fatal("Unexpected error", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for compilation")
+		}
+	}
+
+	waitForCompile()
+	contents := "struct Money {\n    1: i64 cents,\n}\n"
+	if err := ioutil.WriteFile(filepath.Join(src, "shared.frugal"), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitForCompile()
+}